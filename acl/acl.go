@@ -69,6 +69,10 @@ type ACL struct {
 	operator string
 	quota    string
 	plugin   string
+
+	// operatorCapabilities are the operator capabilities granted in
+	// addition to the operator policy
+	operatorCapabilities capabilitySet
 }
 
 // maxPrivilege returns the policy which grants the most privilege
@@ -227,6 +231,12 @@ func NewACL(management bool, policies []*Policy) (*ACL, error) {
 		}
 		if policy.Operator != nil {
 			acl.operator = maxPrivilege(acl.operator, policy.Operator.Policy)
+			for _, cap := range policy.Operator.Capabilities {
+				if acl.operatorCapabilities == nil {
+					acl.operatorCapabilities = make(capabilitySet)
+				}
+				acl.operatorCapabilities.Set(cap)
+			}
 		}
 		if policy.Quota != nil {
 			acl.quota = maxPrivilege(acl.quota, policy.Quota.Policy)
@@ -611,6 +621,19 @@ func (a *ACL) AllowOperatorWrite() bool {
 	}
 }
 
+// AllowOperatorKeyringTransfer checks if the secure variables keyring keys
+// may be exported and imported
+func (a *ACL) AllowOperatorKeyringTransfer() bool {
+	switch {
+	case a.management:
+		return true
+	case a.operator == PolicyDeny:
+		return false
+	default:
+		return a.operatorCapabilities.Check(OperatorCapabilityKeyringTransfer)
+	}
+}
+
 // AllowQuotaRead checks if read operations are allowed for all quotas
 func (a *ACL) AllowQuotaRead() bool {
 	switch {
@@ -669,6 +692,93 @@ func (a *ACL) AllowPluginList() bool {
 	}
 }
 
+// Resources which can be checked with AllowCapability. They are named after
+// the blocks of an ACL policy.
+const (
+	ResourceNamespace       = "namespace"
+	ResourceSecureVariables = "secure_variables"
+	ResourceHostVolume      = "host_volume"
+	ResourceAgent           = "agent"
+	ResourceNode            = "node"
+	ResourceOperator        = "operator"
+	ResourceQuota           = "quota"
+	ResourcePlugin          = "plugin"
+)
+
+// AllowCapability checks if the capability is allowed on the resource. The
+// namespace is used by namespace and secure variable checks, and name is the
+// secure variable path or the host volume name. Resources without
+// capabilities accept their policy dispositions, such as "read" or "write".
+// An error is returned if the resource or capability is unknown.
+func (a *ACL) AllowCapability(resource, ns, name, capability string) (bool, error) {
+	if capability == PolicyDeny {
+		return false, fmt.Errorf("invalid %s capability %q", resource, capability)
+	}
+
+	var check func() bool
+
+	switch resource {
+	case ResourceNamespace:
+		if !isNamespaceCapabilityValid(capability) {
+			return false, fmt.Errorf("invalid namespace capability %q", capability)
+		}
+		check = func() bool { return a.AllowNamespaceOperation(ns, capability) }
+
+	case ResourceSecureVariables:
+		if !isPathCapabilityValid(capability) {
+			return false, fmt.Errorf("invalid secure variables capability %q", capability)
+		}
+		check = func() bool { return a.AllowSecureVariableOperation(ns, name, capability) }
+
+	case ResourceHostVolume:
+		if !isHostVolumeCapabilityValid(capability) {
+			return false, fmt.Errorf("invalid host volume capability %q", capability)
+		}
+		check = func() bool { return a.AllowHostVolumeOperation(name, capability) }
+
+	case ResourceAgent, ResourceNode, ResourceOperator, ResourceQuota:
+		switch {
+		case capability == PolicyRead:
+			check = map[string]func() bool{
+				ResourceAgent:    a.AllowAgentRead,
+				ResourceNode:     a.AllowNodeRead,
+				ResourceOperator: a.AllowOperatorRead,
+				ResourceQuota:    a.AllowQuotaRead,
+			}[resource]
+		case capability == PolicyWrite:
+			check = map[string]func() bool{
+				ResourceAgent:    a.AllowAgentWrite,
+				ResourceNode:     a.AllowNodeWrite,
+				ResourceOperator: a.AllowOperatorWrite,
+				ResourceQuota:    a.AllowQuotaWrite,
+			}[resource]
+		case resource == ResourceOperator && capability == OperatorCapabilityKeyringTransfer:
+			check = a.AllowOperatorKeyringTransfer
+		default:
+			return false, fmt.Errorf("invalid %s capability %q", resource, capability)
+		}
+
+	case ResourcePlugin:
+		switch capability {
+		case PolicyRead:
+			check = a.AllowPluginRead
+		case PolicyList:
+			check = a.AllowPluginList
+		default:
+			return false, fmt.Errorf("invalid plugin capability %q", capability)
+		}
+
+	default:
+		return false, fmt.Errorf("invalid resource %q", resource)
+	}
+
+	// Everything is allowed if ACLs are disabled
+	if a == nil {
+		return true, nil
+	}
+	return check(), nil
+}
+
 // IsManagement checks if this represents a management token
 func (a *ACL) IsManagement() bool {
 	return a.management
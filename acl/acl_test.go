@@ -95,6 +95,51 @@ func TestACLManagement(t *testing.T) {
 	assert.True(acl.AllowQuotaWrite())
 }
 
+func TestACL_AllowOperatorKeyringTransfer(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		policy string
+		expect bool
+	}{
+		{
+			name:   "operator write",
+			policy: `operator { policy = "write" }`,
+			expect: false,
+		},
+		{
+			name:   "capability only",
+			policy: `operator { capabilities = ["keyring-transfer"] }`,
+			expect: true,
+		},
+		{
+			name:   "capability with read",
+			policy: `operator { policy = "read" capabilities = ["keyring-transfer"] }`,
+			expect: true,
+		},
+		{
+			name:   "capability with deny",
+			policy: `operator { policy = "deny" capabilities = ["keyring-transfer"] }`,
+			expect: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := Parse(tc.policy)
+			require.NoError(t, err)
+			acl, err := NewACL(false, []*Policy{p})
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, acl.AllowOperatorKeyringTransfer())
+		})
+	}
+
+	acl, err := NewACL(true, nil)
+	require.NoError(t, err)
+	require.True(t, acl.AllowOperatorKeyringTransfer())
+}
+
 func TestACLMerge(t *testing.T) {
 	ci.Parallel(t)
 
@@ -740,3 +785,80 @@ func TestACL_matchingCapabilitySet_difference(t *testing.T) {
 	}
 
 }
+
+func TestACL_AllowCapability(t *testing.T) {
+	ci.Parallel(t)
+
+	policy, err := Parse(`
+namespace "default" {
+  capabilities = ["list-jobs", "read-job"]
+  secure_variables {
+    path "app/*" { capabilities = ["read", "list"] }
+  }
+}
+host_volume "shared" {
+  policy = "read"
+}
+node {
+  policy = "write"
+}
+operator {
+  policy = "read"
+}
+plugin {
+  policy = "list"
+}
+`)
+	require.NoError(t, err)
+	acl, err := NewACL(false, []*Policy{policy})
+	require.NoError(t, err)
+
+	cases := []struct {
+		resource, ns, name, capability string
+		allowed                        bool
+		err                            string
+	}{
+		{resource: ResourceNamespace, ns: "default", capability: NamespaceCapabilityReadJob, allowed: true},
+		{resource: ResourceNamespace, ns: "default", capability: NamespaceCapabilitySubmitJob},
+		{resource: ResourceNamespace, ns: "other", capability: NamespaceCapabilityReadJob},
+		{resource: ResourceSecureVariables, ns: "default", name: "app/db", capability: SecureVariablesCapabilityRead, allowed: true},
+		{resource: ResourceSecureVariables, ns: "default", name: "app/db", capability: SecureVariablesCapabilityWrite},
+		{resource: ResourceSecureVariables, ns: "default", name: "other", capability: SecureVariablesCapabilityRead},
+		{resource: ResourceHostVolume, name: "shared", capability: HostVolumeCapabilityMountReadOnly, allowed: true},
+		{resource: ResourceHostVolume, name: "shared", capability: HostVolumeCapabilityMountReadWrite},
+		{resource: ResourceNode, capability: PolicyRead, allowed: true},
+		{resource: ResourceNode, capability: PolicyWrite, allowed: true},
+		{resource: ResourceOperator, capability: PolicyRead, allowed: true},
+		{resource: ResourceOperator, capability: PolicyWrite},
+		{resource: ResourceOperator, capability: OperatorCapabilityKeyringTransfer},
+		{resource: ResourceAgent, capability: PolicyRead},
+		{resource: ResourceQuota, capability: PolicyRead},
+		{resource: ResourcePlugin, capability: PolicyList, allowed: true},
+		{resource: ResourcePlugin, capability: PolicyRead},
+		{resource: "job", capability: PolicyRead, err: `invalid resource "job"`},
+		{resource: ResourceNamespace, ns: "default", capability: "fly", err: `invalid namespace capability "fly"`},
+		{resource: ResourceNode, capability: PolicyList, err: `invalid node capability "list"`},
+		{resource: ResourcePlugin, capability: PolicyWrite, err: `invalid plugin capability "write"`},
+		{resource: ResourceSecureVariables, ns: "default", name: "app/db", capability: PolicyDeny, err: `invalid secure_variables capability "deny"`},
+	}
+
+	for _, tc := range cases {
+		allowed, err := acl.AllowCapability(tc.resource, tc.ns, tc.name, tc.capability)
+		if tc.err != "" {
+			require.EqualError(t, err, tc.err, "%s %s", tc.resource, tc.capability)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tc.allowed, allowed, "%s %s %s %s", tc.resource, tc.ns, tc.name, tc.capability)
+
+		// management tokens and disabled ACLs allow everything that is valid
+		allowed, err = ManagementACL.AllowCapability(tc.resource, tc.ns, tc.name, tc.capability)
+		require.NoError(t, err)
+		require.True(t, allowed)
+
+		var disabled *ACL
+		allowed, err = disabled.AllowCapability(tc.resource, tc.ns, tc.name, tc.capability)
+		require.NoError(t, err)
+		require.True(t, allowed)
+	}
+}
@@ -76,6 +76,18 @@ const (
 	SecureVariablesCapabilityWrite   = "write"
 	SecureVariablesCapabilityDestroy = "destroy"
 	SecureVariablesCapabilityDeny    = "deny"
+
+	// SecureVariablesCapabilityDeleteProtected allows force deleting
+	// secure variables with delete protection, or removing their
+	// protection. It is only granted explicitly.
+	SecureVariablesCapabilityDeleteProtected = "delete-protected"
+)
+
+const (
+	// OperatorCapabilityKeyringTransfer allows exporting and importing
+	// secure variables keyring keys. It isn't granted by any operator
+	// policy disposition and must be listed explicitly.
+	OperatorCapabilityKeyringTransfer = "keyring-transfer"
 )
 
 // Policy represents a parsed HCL or JSON policy.
@@ -135,7 +147,23 @@ type NodePolicy struct {
 }
 
 type OperatorPolicy struct {
-	Policy string
+	Policy       string
+	Capabilities []string
+}
+
+func (p *OperatorPolicy) isValid() bool {
+	if p.Policy == "" && len(p.Capabilities) == 0 {
+		return false
+	}
+	if p.Policy != "" && !isPolicyValid(p.Policy) {
+		return false
+	}
+	for _, cap := range p.Capabilities {
+		if cap != OperatorCapabilityKeyringTransfer {
+			return false
+		}
+	}
+	return true
 }
 
 type QuotaPolicy struct {
@@ -188,7 +216,8 @@ func isNamespaceCapabilityValid(cap string) bool {
 func isPathCapabilityValid(cap string) bool {
 	switch cap {
 	case SecureVariablesCapabilityWrite, SecureVariablesCapabilityRead,
-		SecureVariablesCapabilityList, SecureVariablesCapabilityDestroy, SecureVariablesCapabilityDeny:
+		SecureVariablesCapabilityList, SecureVariablesCapabilityDestroy, SecureVariablesCapabilityDeny,
+		SecureVariablesCapabilityDeleteProtected:
 		return true
 	default:
 		return false
@@ -378,7 +407,7 @@ func Parse(rules string) (*Policy, error) {
 		return nil, fmt.Errorf("Invalid node policy: %#v", p.Node)
 	}
 
-	if p.Operator != nil && !isPolicyValid(p.Operator.Policy) {
+	if p.Operator != nil && !p.Operator.isValid() {
 		return nil, fmt.Errorf("Invalid operator policy: %#v", p.Operator)
 	}
 
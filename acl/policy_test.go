@@ -66,6 +66,9 @@ func TestParse(t *testing.T) {
 					path "project/explicit" {
 						capabilities = ["read", "list", "destroy"]
 					}
+					path "project/protected" {
+						capabilities = ["destroy", "delete-protected"]
+					}
 				}
 			}
 			namespace "autoscaler" {
@@ -158,6 +161,13 @@ func TestParse(t *testing.T) {
 										SecureVariablesCapabilityDestroy,
 									},
 								},
+								{
+									PathSpec: "project/protected",
+									Capabilities: []string{
+										SecureVariablesCapabilityDestroy,
+										SecureVariablesCapabilityDeleteProtected,
+									},
+								},
 							},
 						},
 					},
@@ -245,6 +255,28 @@ func TestParse(t *testing.T) {
 			"Invalid operator policy",
 			nil,
 		},
+		{
+			`
+			operator {
+				capabilities = ["foo"]
+			}
+			`,
+			"Invalid operator policy",
+			nil,
+		},
+		{
+			`
+			operator {
+				capabilities = ["keyring-transfer"]
+			}
+			`,
+			"",
+			&Policy{
+				Operator: &OperatorPolicy{
+					Capabilities: []string{OperatorCapabilityKeyringTransfer},
+				},
+			},
+		},
 		{
 			`
 			quota {
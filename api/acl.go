@@ -202,6 +202,18 @@ func (a *ACLTokens) ExchangeOneTimeToken(secret string, q *WriteOptions) (*ACLTo
 	return resp.Token, wm, nil
 }
 
+// Check is used to test a batch of capabilities against our own token. The
+// results are returned in the same order as the checks.
+func (a *ACLTokens) Check(checks []*ACLCheck, q *QueryOptions) ([]*ACLCheckResult, *QueryMeta, error) {
+	req := &ACLCheckRequest{Checks: checks}
+	var resp []*ACLCheckResult
+	qm, err := a.client.putReadQuery("/v1/acl/check", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
 // ACLPolicyListStub is used to for listing ACL policies
 type ACLPolicyListStub struct {
 	Name        string
@@ -277,3 +289,29 @@ type OneTimeTokenExchangeResponse struct {
 type BootstrapRequest struct {
 	BootstrapSecret string
 }
+
+// ACLCheck is a capability to test against a token. Resource is the name of
+// an ACL policy block, such as "namespace", "secure_variables" or "node".
+// Namespace is used by namespace and secure variable checks and defaults to
+// the namespace of the request. Name is the secure variable path or host
+// volume name. Resources without capabilities, such as "node", are checked
+// with their policy disposition ("read" or "write").
+type ACLCheck struct {
+	Resource   string
+	Namespace  string `json:",omitempty"`
+	Name       string `json:",omitempty"`
+	Capability string
+}
+
+// ACLCheckResult is the result of an ACLCheck. Error is set if the check was
+// invalid.
+type ACLCheckResult struct {
+	ACLCheck
+	Allowed bool
+	Error   string `json:",omitempty"`
+}
+
+// ACLCheckRequest is used to test a batch of capabilities.
+type ACLCheckRequest struct {
+	Checks []*ACLCheck
+}
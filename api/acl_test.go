@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/nomad/api/internal/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestACLPolicies_ListUpsert(t *testing.T) {
@@ -213,6 +214,28 @@ func TestACLTokens_Self(t *testing.T) {
 	}
 }
 
+func TestACLTokens_Check(t *testing.T) {
+	testutil.Parallel(t)
+	c, s, _ := makeACLClient(t, nil, nil)
+	defer s.Stop()
+	at := c.ACLTokens()
+
+	checks := []*ACLCheck{
+		{Resource: "namespace", Capability: "submit-job"},
+		{Resource: "node", Capability: "submit-job"},
+	}
+
+	// The management token is allowed every valid check
+	results, qm, err := at.Check(checks, nil)
+	require.NoError(t, err)
+	assertQueryMeta(t, qm)
+	require.Len(t, results, 2)
+	require.True(t, results[0].Allowed)
+	require.Equal(t, "default", results[0].Namespace)
+	require.False(t, results[1].Allowed)
+	require.NotEmpty(t, results[1].Error)
+}
+
 func TestACLTokens_Delete(t *testing.T) {
 	testutil.Parallel(t)
 	c, s, _ := makeACLClient(t, nil, nil)
@@ -4,8 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"net/url"
 	"strconv"
+	"time"
 )
 
 // Agent encapsulates an API client which talks to Nomad's
@@ -117,15 +117,13 @@ func (a *Agent) Region() (string, error) {
 // number of nodes successfully joined and any error. If one or
 // more nodes have a successful result, no error is returned.
 func (a *Agent) Join(addrs ...string) (int, error) {
-	// Accumulate the addresses
-	v := url.Values{}
-	for _, addr := range addrs {
-		v.Add("address", addr)
-	}
+	endpoint := newQueryParams().
+		addStrings("address", addrs).
+		endpoint("/v1/agent/join")
 
 	// Send the join request
 	var resp joinResponse
-	_, err := a.client.write("/v1/agent/join?"+v.Encode(), nil, &resp, nil)
+	_, err := a.client.write(endpoint, nil, &resp, nil)
 	if err != nil {
 		return 0, fmt.Errorf("failed joining: %s", err)
 	}
@@ -160,7 +158,8 @@ func (a *Agent) MembersOpts(opts *QueryOptions) (*ServerMembers, error) {
 
 // ForceLeave is used to eject an existing node from the cluster.
 func (a *Agent) ForceLeave(node string) error {
-	_, err := a.client.write("/v1/agent/force-leave?node="+node, nil, nil, nil)
+	endpoint := newQueryParams().set("node", node).endpoint("/v1/agent/force-leave")
+	_, err := a.client.write(endpoint, nil, nil, nil)
 	return err
 }
 
@@ -176,13 +175,11 @@ func (a *Agent) Servers() ([]string, error) {
 
 // SetServers is used to update the list of servers on a client node.
 func (a *Agent) SetServers(addrs []string) error {
-	// Accumulate the addresses
-	v := url.Values{}
-	for _, addr := range addrs {
-		v.Add("address", addr)
-	}
+	endpoint := newQueryParams().
+		addStrings("address", addrs).
+		endpoint("/v1/agent/servers")
 
-	_, err := a.client.write("/v1/agent/servers?"+v.Encode(), nil, nil, nil)
+	_, err := a.client.write(endpoint, nil, nil, nil)
 	return err
 }
 
@@ -503,7 +500,11 @@ func (a *Agent) GetSchedulerWorkerConfig(q *QueryOptions) (*SchedulerWorkerPoolA
 		return nil, err
 	}
 
-	return &SchedulerWorkerPoolArgs{NumSchedulers: resp.NumSchedulers, EnabledSchedulers: resp.EnabledSchedulers}, nil
+	return &SchedulerWorkerPoolArgs{
+		NumSchedulers:       resp.NumSchedulers,
+		EnabledSchedulers:   resp.EnabledSchedulers,
+		DedicatedSchedulers: resp.DedicatedSchedulers,
+	}, nil
 }
 
 // SetSchedulerWorkerConfig attempts to update the targeted agent's worker pool configuration
@@ -516,29 +517,39 @@ func (a *Agent) SetSchedulerWorkerConfig(args SchedulerWorkerPoolArgs, q *WriteO
 		return nil, err
 	}
 
-	return &SchedulerWorkerPoolArgs{NumSchedulers: resp.NumSchedulers, EnabledSchedulers: resp.EnabledSchedulers}, nil
+	return &SchedulerWorkerPoolArgs{
+		NumSchedulers:       resp.NumSchedulers,
+		EnabledSchedulers:   resp.EnabledSchedulers,
+		DedicatedSchedulers: resp.DedicatedSchedulers,
+	}, nil
 }
 
 type SchedulerWorkerPoolArgs struct {
 	NumSchedulers     int
 	EnabledSchedulers []string
+
+	// DedicatedSchedulers maps a scheduler type to the number of workers,
+	// out of NumSchedulers, that only process evaluations of that type.
+	DedicatedSchedulers map[string]int
 }
 
 // AgentSchedulerWorkerConfigRequest is used to provide new scheduler worker configuration
 // to a specific Nomad server. EnabledSchedulers must contain at least the `_core` scheduler
 // to be valid.
 type AgentSchedulerWorkerConfigRequest struct {
-	NumSchedulers     int      `json:"num_schedulers"`
-	EnabledSchedulers []string `json:"enabled_schedulers"`
+	NumSchedulers       int            `json:"num_schedulers"`
+	EnabledSchedulers   []string       `json:"enabled_schedulers"`
+	DedicatedSchedulers map[string]int `json:"dedicated_schedulers,omitempty"`
 }
 
 // AgentSchedulerWorkerConfigResponse contains the Nomad server's current running configuration
 // as well as the server's id as a convenience. This can be used to provide starting values for
 // creating an AgentSchedulerWorkerConfigRequest to make changes to the running configuration.
 type AgentSchedulerWorkerConfigResponse struct {
-	ServerID          string   `json:"server_id"`
-	NumSchedulers     int      `json:"num_schedulers"`
-	EnabledSchedulers []string `json:"enabled_schedulers"`
+	ServerID            string         `json:"server_id"`
+	NumSchedulers       int            `json:"num_schedulers"`
+	EnabledSchedulers   []string       `json:"enabled_schedulers"`
+	DedicatedSchedulers map[string]int `json:"dedicated_schedulers,omitempty"`
 }
 
 // GetSchedulerWorkersInfo returns the current status of all of the scheduler workers on
@@ -569,3 +580,40 @@ type AgentSchedulerWorkerInfo struct {
 	Status            string   `json:"status"`
 	WorkloadStatus    string   `json:"workload_status"`
 }
+
+// ClientShutdown drains the node of the targeted client agent and shuts down
+// the agent once the allocations of the node migrated, or the drain deadline
+// passed. The call blocks until the drain is over.
+func (a *Agent) ClientShutdown(req *ClientShutdownRequest, q *WriteOptions) (*ClientShutdownResponse, *WriteMeta, error) {
+	if req == nil {
+		req = new(ClientShutdownRequest)
+	}
+
+	var resp ClientShutdownResponse
+	wm, err := a.client.write("/v1/client/shutdown", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// ClientShutdownRequest is used to configure the drain of a client shutdown.
+type ClientShutdownRequest struct {
+	// Deadline is how long the allocations of the node may take to migrate
+	// before they are stopped. Defaults to one hour.
+	Deadline time.Duration
+
+	// IgnoreSystemJobs leaves the allocations of system jobs running until
+	// the agent shuts down.
+	IgnoreSystemJobs bool
+}
+
+// ClientShutdownResponse is the response of a client shutdown.
+type ClientShutdownResponse struct {
+	NodeID string
+
+	// DrainComplete is false if the drain or the allocations it stopped
+	// didn't complete shortly after the deadline, in which case the agent
+	// shuts down anyway.
+	DrainComplete bool
+}
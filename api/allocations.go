@@ -2,7 +2,9 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"sort"
 	"strings"
@@ -27,6 +29,7 @@ const (
 	AllocClientStatusComplete = "complete"
 	AllocClientStatusFailed   = "failed"
 	AllocClientStatusLost     = "lost"
+	AllocClientStatusUnknown  = "unknown"
 )
 
 const (
@@ -45,8 +48,18 @@ func (c *Client) Allocations() *Allocations {
 
 // List returns a list of all of the allocations.
 func (a *Allocations) List(q *QueryOptions) ([]*AllocationListStub, *QueryMeta, error) {
+	return a.ListWithFilter(nil, q)
+}
+
+// ListWithFilter returns a list of the allocations matching the filter,
+// which is applied by the servers.
+func (a *Allocations) ListWithFilter(filter *AllocationListFilter, q *QueryOptions) ([]*AllocationListStub, *QueryMeta, error) {
+	endpoint, err := endpointWithParams("/v1/allocations", filter)
+	if err != nil {
+		return nil, nil, err
+	}
 	var resp []*AllocationListStub
-	qm, err := a.client.query("/v1/allocations", &resp, q)
+	qm, err := a.client.query(endpoint, &resp, q)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -54,10 +67,56 @@ func (a *Allocations) List(q *QueryOptions) ([]*AllocationListStub, *QueryMeta,
 	return resp, qm, nil
 }
 
+// AllocationListFilter holds the filters applied by the servers to the
+// allocations listed by ListWithFilter. The filters are combined, and can be
+// combined with QueryOptions.Filter as well.
+type AllocationListFilter struct {
+	// ClientStatus matches the allocations with this client status, such as
+	// AllocClientStatusUnknown.
+	ClientStatus string
+
+	// NodeID matches the allocations of the node with this ID or ID prefix.
+	NodeID string
+
+	// MinTimeInStatus matches the allocations which have been in their
+	// client status for at least this long.
+	MinTimeInStatus time.Duration
+}
+
+// Validate implements endpointParams.
+func (f *AllocationListFilter) Validate() error {
+	if f == nil {
+		return nil
+	}
+	if f.MinTimeInStatus < 0 {
+		return fmt.Errorf("minimum time in status cannot be negative")
+	}
+	return nil
+}
+
+func (f *AllocationListFilter) encodeParams(q *queryParams) {
+	if f == nil {
+		return
+	}
+	q.setString("client_status", f.ClientStatus).
+		setString("node_id", f.NodeID)
+	if f.MinTimeInStatus > 0 {
+		q.set("min_time_in_status", f.MinTimeInStatus.String())
+	}
+}
+
 func (a *Allocations) PrefixList(prefix string) ([]*AllocationListStub, *QueryMeta, error) {
 	return a.List(&QueryOptions{Prefix: prefix})
 }
 
+// ListPages lists all of the allocations, one page at a time. It follows the
+// NextToken of each response and calls fn with each page until the last page
+// is reached or fn returns false. The page size is set with
+// QueryOptions.PerPage.
+func (a *Allocations) ListPages(q *QueryOptions, fn func([]*AllocationListStub, *QueryMeta) bool) error {
+	return listPages(q, a.List, fn)
+}
+
 // Info is used to retrieve a single allocation.
 func (a *Allocations) Info(allocID string, q *QueryOptions) (*Allocation, *QueryMeta, error) {
 	var resp Allocation
@@ -82,6 +141,10 @@ func (a *Allocations) Info(allocID string, q *QueryOptions) (*Allocation, *Query
 //
 // The call blocks until command terminates (or an error occurs), and returns the exit code.
 //
+// If the connection is lost, the session is resumed for up to
+// api.StreamResumeTimeout without losing output, provided the Nomad client
+// supports it. Input sent while disconnected may be lost.
+//
 // Note: for cluster topologies where API consumers don't have network access to
 // Nomad clients, set api.ClientConnTimeout to a small value (ex 1ms) to avoid
 // long pauses on this API call.
@@ -193,6 +256,52 @@ type AllocStopResponse struct {
 	WriteMeta
 }
 
+// Reconnect decides on an allocation whose client status is unknown, or
+// which reconnected and is waiting to be reinstated. By default the
+// allocation is accepted back: it is reinstated as soon as its node
+// reconnects and is kept over its replacement. If opts.Reject is set, it is
+// instead marked lost and replaced without waiting.
+func (a *Allocations) Reconnect(alloc *Allocation, opts *AllocReconnectOptions, q *WriteOptions) (*AllocReconnectResponse, error) {
+	endpoint, err := endpointWithParams("/v1/allocation/"+alloc.ID+"/reconnect", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp AllocReconnectResponse
+	wm, err := a.client.write(endpoint, nil, &resp, q)
+	if err != nil {
+		return nil, err
+	}
+	resp.WriteMeta = *wm
+	return &resp, nil
+}
+
+// AllocReconnectOptions are parameters for the Reconnect API
+type AllocReconnectOptions struct {
+	// Reject rejects the allocation instead of accepting it back.
+	Reject bool
+}
+
+// Validate implements endpointParams. Every combination of options is valid.
+func (o *AllocReconnectOptions) Validate() error {
+	return nil
+}
+
+func (o *AllocReconnectOptions) encodeParams(q *queryParams) {
+	if o == nil {
+		return
+	}
+	q.setBool("reject", o.Reject)
+}
+
+// AllocReconnectResponse is the response to an `AllocReconnectRequest`
+type AllocReconnectResponse struct {
+	// EvalID is the id of the evaluation applying the decision.
+	EvalID string
+
+	WriteMeta
+}
+
 // Signal sends a signal to the allocation.
 //
 // Note: for cluster topologies where API consumers don't have network access to
@@ -219,39 +328,72 @@ func (a *Allocations) Services(allocID string, q *QueryOptions) ([]*ServiceRegis
 
 // Allocation is used for serialization of allocations.
 type Allocation struct {
-	ID                    string
-	Namespace             string
-	EvalID                string
-	Name                  string
-	NodeID                string
-	NodeName              string
-	JobID                 string
-	Job                   *Job
-	TaskGroup             string
-	Resources             *Resources
-	TaskResources         map[string]*Resources
-	AllocatedResources    *AllocatedResources
-	Services              map[string]string
-	Metrics               *AllocationMetric
-	DesiredStatus         string
-	DesiredDescription    string
-	DesiredTransition     DesiredTransition
-	ClientStatus          string
-	ClientDescription     string
-	TaskStates            map[string]*TaskState
-	DeploymentID          string
-	DeploymentStatus      *AllocDeploymentStatus
-	FollowupEvalID        string
-	PreviousAllocation    string
-	NextAllocation        string
-	RescheduleTracker     *RescheduleTracker
-	PreemptedAllocations  []string
-	PreemptedByAllocation string
-	CreateIndex           uint64
-	ModifyIndex           uint64
-	AllocModifyIndex      uint64
-	CreateTime            int64
-	ModifyTime            int64
+	ID                        string
+	Namespace                 string
+	EvalID                    string
+	Name                      string
+	NodeID                    string
+	NodeName                  string
+	JobID                     string
+	Job                       *Job
+	TaskGroup                 string
+	Resources                 *Resources
+	TaskResources             map[string]*Resources
+	AllocatedResources        *AllocatedResources
+	Services                  map[string]string
+	Metrics                   *AllocationMetric
+	DesiredStatus             string
+	DesiredDescription        string
+	DesiredTransition         DesiredTransition
+	ClientStatus              string
+	ClientDescription         string
+	TaskStates                map[string]*TaskState
+	DeploymentID              string
+	DeploymentStatus          *AllocDeploymentStatus
+	FollowupEvalID            string
+	PreviousAllocation        string
+	NextAllocation            string
+	RescheduleTracker         *RescheduleTracker
+	PreemptedAllocations      []string
+	PreemptedByAllocation     string
+	MaxClientDisconnect       *time.Duration
+	PlannedDisconnectDeadline time.Time
+	MemoryOversubscribed      bool
+	CreateIndex               uint64
+	ModifyIndex               uint64
+	AllocModifyIndex          uint64
+	CreateTime                time.Time
+	ModifyTime                time.Time
+}
+
+func (a *Allocation) MarshalJSON() ([]byte, error) {
+	type Alias Allocation
+	return json.Marshal(&struct {
+		CreateTime unixNanos
+		ModifyTime unixNanos
+		*Alias
+	}{
+		CreateTime: unixNanos(a.CreateTime),
+		ModifyTime: unixNanos(a.ModifyTime),
+		Alias:      (*Alias)(a),
+	})
+}
+
+func (a *Allocation) UnmarshalJSON(data []byte) error {
+	type Alias Allocation
+	aux := &struct {
+		CreateTime unixNanos
+		ModifyTime unixNanos
+		*Alias
+	}{
+		Alias: (*Alias)(a),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	a.CreateTime = time.Time(aux.CreateTime)
+	a.ModifyTime = time.Time(aux.ModifyTime)
+	return nil
 }
 
 // AllocationMetric is used to deserialize allocation metrics.
@@ -357,10 +499,54 @@ type AllocationListStub struct {
 	RescheduleTracker     *RescheduleTracker
 	PreemptedAllocations  []string
 	PreemptedByAllocation string
+	RescheduleChain       *AllocRescheduleChain `json:",omitempty"`
 	CreateIndex           uint64
 	ModifyIndex           uint64
-	CreateTime            int64
-	ModifyTime            int64
+	CreateTime            time.Time
+	ModifyTime            time.Time
+}
+
+// AllocRescheduleChain is the lineage of an allocation across the
+// allocations that replaced each other. It is only included in allocation
+// list stubs when requested with the reschedule_chain query parameter.
+type AllocRescheduleChain struct {
+	// Ancestors are the IDs of the allocations this allocation replaced,
+	// oldest first.
+	Ancestors []string
+
+	// Descendants are the IDs of the allocations that replaced this
+	// allocation, oldest first.
+	Descendants []string
+}
+
+func (a *AllocationListStub) MarshalJSON() ([]byte, error) {
+	type Alias AllocationListStub
+	return json.Marshal(&struct {
+		CreateTime unixNanos
+		ModifyTime unixNanos
+		*Alias
+	}{
+		CreateTime: unixNanos(a.CreateTime),
+		ModifyTime: unixNanos(a.ModifyTime),
+		Alias:      (*Alias)(a),
+	})
+}
+
+func (a *AllocationListStub) UnmarshalJSON(data []byte) error {
+	type Alias AllocationListStub
+	aux := &struct {
+		CreateTime unixNanos
+		ModifyTime unixNanos
+		*Alias
+	}{
+		Alias: (*Alias)(a),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	a.CreateTime = time.Time(aux.CreateTime)
+	a.ModifyTime = time.Time(aux.ModifyTime)
+	return nil
 }
 
 // AllocDeploymentStatus captures the status of the allocation as part of the
@@ -403,8 +589,9 @@ type AllocatedCpuResources struct {
 }
 
 type AllocatedMemoryResources struct {
-	MemoryMB    int64
-	MemoryMaxMB int64
+	MemoryMB     int64
+	MemoryMaxMB  int64
+	HugePages2MB int64
 }
 
 type AllocatedDeviceResource struct {
@@ -454,8 +641,7 @@ func (a Allocation) RescheduleInfo(t time.Time) (int, int) {
 	if a.RescheduleTracker != nil && availableAttempts > 0 && interval > 0 {
 		for j := len(a.RescheduleTracker.Events) - 1; j >= 0; j-- {
 			lastAttempt := a.RescheduleTracker.Events[j].RescheduleTime
-			timeDiff := t.UTC().UnixNano() - lastAttempt
-			if timeDiff < interval.Nanoseconds() {
+			if t.Sub(lastAttempt) < interval {
 				attempted += 1
 			}
 		}
@@ -487,7 +673,7 @@ type RescheduleTracker struct {
 // RescheduleEvent is used to keep track of previous attempts at rescheduling an allocation
 type RescheduleEvent struct {
 	// RescheduleTime is the timestamp of a reschedule attempt
-	RescheduleTime int64
+	RescheduleTime time.Time
 
 	// PrevAllocID is the ID of the previous allocation being restarted
 	PrevAllocID string
@@ -496,6 +682,32 @@ type RescheduleEvent struct {
 	PrevNodeID string
 }
 
+func (r *RescheduleEvent) MarshalJSON() ([]byte, error) {
+	type Alias RescheduleEvent
+	return json.Marshal(&struct {
+		RescheduleTime unixNanos
+		*Alias
+	}{
+		RescheduleTime: unixNanos(r.RescheduleTime),
+		Alias:          (*Alias)(r),
+	})
+}
+
+func (r *RescheduleEvent) UnmarshalJSON(data []byte) error {
+	type Alias RescheduleEvent
+	aux := &struct {
+		RescheduleTime unixNanos
+		*Alias
+	}{
+		Alias: (*Alias)(r),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.RescheduleTime = time.Time(aux.RescheduleTime)
+	return nil
+}
+
 // DesiredTransition is used to mark an allocation as having a desired state
 // transition. This information can be used by the scheduler to make the
 // correct decision.
@@ -507,6 +719,10 @@ type DesiredTransition struct {
 	// Reschedule is used to indicate that this allocation is eligible to be
 	// rescheduled.
 	Reschedule *bool
+
+	// Reconnect is an operator's decision on an unknown allocation: true if
+	// it was accepted back, false if it was rejected.
+	Reconnect *bool
 }
 
 // ShouldMigrate returns whether the transition object dictates a migration.
@@ -551,6 +767,12 @@ type ExecStreamingOutput struct {
 
 	Exited bool                     `json:"exited,omitempty"`
 	Result *ExecStreamingExitResult `json:"result,omitempty"`
+
+	// SessionToken is set in the first frame of resumable sessions, and
+	// Offset in every following frame. They are used to resume the session
+	// if the connection is lost.
+	SessionToken string `json:"session_token,omitempty"`
+	Offset       int64  `json:"offset,omitempty"`
 }
 
 func AllocSuffix(name string) string {
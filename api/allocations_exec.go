@@ -17,6 +17,10 @@ const (
 	// heartbeatInterval is the amount of time to wait between sending heartbeats
 	// during an exec streaming operation
 	heartbeatInterval = 10 * time.Second
+
+	// streamResumeMaxBackoff is the maximum time to wait between attempts to
+	// resume a stream.
+	streamResumeMaxBackoff = 5 * time.Second
 )
 
 type execSession struct {
@@ -33,6 +37,16 @@ type execSession struct {
 	terminalSizeCh <-chan TerminalSize
 
 	q *QueryOptions
+
+	// sessionToken and offset are used to resume the session if the
+	// connection is lost. They are only accessed by the receiving goroutine,
+	// or after it exited.
+	sessionToken string
+	offset       int64
+
+	// connLock guards conn and serializes writes to it
+	connLock sync.Mutex
+	conn     *websocket.Conn
 }
 
 func (s *execSession) run(ctx context.Context) (exitCode int, err error) {
@@ -43,9 +57,10 @@ func (s *execSession) run(ctx context.Context) (exitCode int, err error) {
 	if err != nil {
 		return -2, err
 	}
-	defer conn.Close()
+	s.setConn(conn)
+	defer s.closeConn()
 
-	sendErrCh := s.startTransmit(ctx, conn)
+	sendErrCh := s.startTransmit(ctx)
 	exitCh, recvErrCh := s.startReceiving(ctx, conn)
 
 	for {
@@ -55,6 +70,15 @@ func (s *execSession) run(ctx context.Context) (exitCode int, err error) {
 		case exitCode := <-exitCh:
 			return exitCode, nil
 		case recvErr := <-recvErrCh:
+			if s.sessionToken != "" && isResumableExecErr(recvErr) {
+				conn, err := s.resume(ctx)
+				if err == nil {
+					exitCh, recvErrCh = s.startReceiving(ctx, conn)
+					continue
+				}
+				recvErr = fmt.Errorf("%v (failed to resume session: %v)", recvErr, err)
+			}
+
 			// drop websocket code, not relevant to user
 			if wsErr, ok := recvErr.(*websocket.CloseError); ok && wsErr.Text != "" {
 				return -2, errors.New(wsErr.Text)
@@ -91,6 +115,13 @@ func (s *execSession) startConnection() (*websocket.Conn, error) {
 	q.Params["tty"] = strconv.FormatBool(s.tty)
 	q.Params["task"] = s.task
 	q.Params["command"] = string(commandBytes)
+	if StreamResumeTimeout > 0 {
+		q.Params["resumable"] = "true"
+	}
+	if s.sessionToken != "" {
+		q.Params["session_token"] = s.sessionToken
+		q.Params["offset"] = strconv.FormatInt(s.offset, 10)
+	}
 
 	reqPath := fmt.Sprintf("/v1/client/allocation/%s/exec", s.alloc.ID)
 
@@ -110,20 +141,70 @@ func (s *execSession) startConnection() (*websocket.Conn, error) {
 	return conn, nil
 }
 
-func (s *execSession) startTransmit(ctx context.Context, conn *websocket.Conn) <-chan error {
+// resume reconnects to the session after its connection was lost, retrying
+// with a backoff for up to StreamResumeTimeout.
+func (s *execSession) resume(ctx context.Context) (*websocket.Conn, error) {
+	s.closeConn()
+
+	deadline := time.Now().Add(StreamResumeTimeout)
+	backoff := 250 * time.Millisecond
+	for {
+		conn, err := s.startConnection()
+		if err == nil {
+			s.setConn(conn)
+			return conn, nil
+		}
+		if err == NodeDownErr || time.Now().Add(backoff).After(deadline) {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > streamResumeMaxBackoff {
+			backoff = streamResumeMaxBackoff
+		}
+	}
+}
+
+func (s *execSession) setConn(conn *websocket.Conn) {
+	s.connLock.Lock()
+	defer s.connLock.Unlock()
+	s.conn = conn
+}
+
+func (s *execSession) closeConn() {
+	s.connLock.Lock()
+	defer s.connLock.Unlock()
+	s.conn.Close()
+}
+
+// isResumableExecErr returns whether the session can be resumed after the
+// given receive error. Errors reported by Nomad close the websocket with a
+// reason, while network failures don't.
+func isResumableExecErr(err error) bool {
+	var closeErr *websocket.CloseError
+	if errors.As(err, &closeErr) {
+		return closeErr.Code == websocket.CloseAbnormalClosure
+	}
+	return true
+}
+
+func (s *execSession) startTransmit(ctx context.Context) <-chan error {
 
 	// FIXME: Handle websocket send errors.
 	// Currently, websocket write failures are dropped. As sending and
 	// receiving are running concurrently, it's expected that some send
 	// requests may fail with connection errors when connection closes.
 	// Connection errors should surface in the receive paths already,
-	// but I'm unsure about one-sided communication errors.
-	var sendLock sync.Mutex
+	// and the session is resumed on the new connection.
 	send := func(v *ExecStreamingInput) {
-		sendLock.Lock()
-		defer sendLock.Unlock()
+		s.connLock.Lock()
+		defer s.connLock.Unlock()
 
-		conn.WriteJSON(v)
+		s.conn.WriteJSON(v)
 	}
 
 	errCh := make(chan error, 4)
@@ -220,7 +301,17 @@ func (s *execSession) startReceiving(ctx context.Context, conn *websocket.Conn)
 				return
 			}
 
+			// Skip frames received before the session was resumed
+			if frame.Offset != 0 {
+				if frame.Offset <= s.offset {
+					continue
+				}
+				s.offset = frame.Offset
+			}
+
 			switch {
+			case frame.SessionToken != "":
+				s.sessionToken = frame.SessionToken
 			case frame.Stdout != nil:
 				if len(frame.Stdout.Data) != 0 {
 					s.stdout.Write(frame.Stdout.Data)
@@ -3,12 +3,14 @@ package api
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"reflect"
 	"sort"
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/hashicorp/nomad/api/internal/testutil"
 	"github.com/stretchr/testify/require"
 )
@@ -57,6 +59,38 @@ func TestAllocations_List(t *testing.T) {
 	require.Nil(t, allocs[0].AllocatedResources)
 }
 
+func TestAllocations_ListWithFilter(t *testing.T) {
+	testutil.Parallel(t)
+	c, s := makeClient(t, nil, func(c *testutil.TestServerConfig) {
+		c.DevMode = true
+	})
+	defer s.Stop()
+	a := c.Allocations()
+
+	job := testJob()
+	_, wm, err := c.Jobs().Register(job, nil)
+	require.NoError(t, err)
+
+	allocs, _, err := a.List(&QueryOptions{WaitIndex: wm.LastIndex})
+	require.NoError(t, err)
+	require.Len(t, allocs, 1)
+	nodeID := allocs[0].NodeID
+
+	allocs, _, err = a.ListWithFilter(&AllocationListFilter{NodeID: nodeID[:8]}, nil)
+	require.NoError(t, err)
+	require.Len(t, allocs, 1)
+
+	allocs, _, err = a.ListWithFilter(&AllocationListFilter{
+		ClientStatus:    AllocClientStatusUnknown,
+		MinTimeInStatus: time.Minute,
+	}, nil)
+	require.NoError(t, err)
+	require.Empty(t, allocs)
+
+	_, _, err = a.ListWithFilter(&AllocationListFilter{MinTimeInStatus: -time.Minute}, nil)
+	require.EqualError(t, err, "minimum time in status cannot be negative")
+}
+
 func TestAllocations_PrefixList(t *testing.T) {
 	testutil.Parallel(t)
 	c, s := makeClient(t, nil, nil)
@@ -221,7 +255,7 @@ func TestAllocations_RescheduleInfo(t *testing.T) {
 			rescheduleTracker: &RescheduleTracker{
 				Events: []*RescheduleEvent{
 					{
-						RescheduleTime: time.Now().Add(-5 * time.Minute).UTC().UnixNano(),
+						RescheduleTime: time.Now().Add(-5 * time.Minute),
 					},
 				},
 			},
@@ -238,16 +272,16 @@ func TestAllocations_RescheduleInfo(t *testing.T) {
 			rescheduleTracker: &RescheduleTracker{
 				Events: []*RescheduleEvent{
 					{
-						RescheduleTime: time.Now().Add(-45 * time.Minute).UTC().UnixNano(),
+						RescheduleTime: time.Now().Add(-45 * time.Minute),
 					},
 					{
-						RescheduleTime: time.Now().Add(-30 * time.Minute).UTC().UnixNano(),
+						RescheduleTime: time.Now().Add(-30 * time.Minute),
 					},
 					{
-						RescheduleTime: time.Now().Add(-10 * time.Minute).UTC().UnixNano(),
+						RescheduleTime: time.Now().Add(-10 * time.Minute),
 					},
 					{
-						RescheduleTime: time.Now().Add(-5 * time.Minute).UTC().UnixNano(),
+						RescheduleTime: time.Now().Add(-5 * time.Minute),
 					},
 				},
 			},
@@ -397,6 +431,22 @@ func TestAllocations_ShouldMigrate(t *testing.T) {
 	require.False(t, DesiredTransition{Migrate: pointerOf(false)}.ShouldMigrate())
 }
 
+func TestAllocations_isResumableExecErr(t *testing.T) {
+	testutil.Parallel(t)
+
+	// Network failures can be resumed
+	require.True(t, isResumableExecErr(io.ErrUnexpectedEOF))
+	require.True(t, isResumableExecErr(&websocket.CloseError{Code: websocket.CloseAbnormalClosure}))
+
+	// Errors reported by Nomad can't
+	require.False(t, isResumableExecErr(&websocket.CloseError{
+		Code: websocket.CloseInternalServerErr,
+		Text: "exec session not found or expired",
+	}))
+	require.False(t, isResumableExecErr(fmt.Errorf("websocket closed before receiving exit code: %w",
+		&websocket.CloseError{Code: websocket.CloseNormalClosure})))
+}
+
 func TestAllocations_Services(t *testing.T) {
 	// TODO(jrasell) add tests once registration process is in place.
 }
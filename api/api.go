@@ -29,6 +29,11 @@ var (
 	// access to Nomad clients, set this to a small value (ex 1ms) to avoid
 	// pausing on client APIs such as AllocFS.
 	ClientConnTimeout = 1 * time.Second
+
+	// StreamResumeTimeout is how long exec sessions and log streams keep
+	// trying to resume after their connection is lost, for example during a
+	// transient network failure. Set this to zero to disable resuming.
+	StreamResumeTimeout = 30 * time.Second
 )
 
 const (
@@ -142,6 +147,18 @@ type QueryMeta struct {
 	// for queries that support paginated lists. To resume paging from
 	// this point, pass this token in the next request's QueryOptions
 	NextToken string
+
+	// QueryTime is the time the server spent running the query against its
+	// state store, excluding time spent blocking for changes.
+	QueryTime time.Duration
+
+	// ObjectsScanned is the number of objects the server read to answer a
+	// paginated list query. It is zero for queries that don't report it.
+	ObjectsScanned int
+
+	// Forwarded is set when the server that received the query forwarded
+	// it to the leader or to another region.
+	Forwarded bool
 }
 
 // WriteMeta is used to return meta data about a write
@@ -197,6 +214,15 @@ type Config struct {
 	TLSConfig *TLSConfig
 
 	Headers http.Header
+
+	// RetryPolicy, if set, retries requests that fail with a transport
+	// error or a retryable status code. Writes are only retried when they
+	// carry an idempotency token or a check-index.
+	RetryPolicy *RetryPolicy
+
+	// RateLimit, if set, limits the rate and the concurrency of the
+	// requests sent by the client. Retries count as separate requests.
+	RateLimit *RateLimit
 }
 
 // ClientConfig copies the configuration with a new client address, region, and
@@ -207,14 +233,16 @@ func (c *Config) ClientConfig(region, address string, tlsEnabled bool) *Config {
 		scheme = "https"
 	}
 	config := &Config{
-		Address:    fmt.Sprintf("%s://%s", scheme, address),
-		Region:     region,
-		Namespace:  c.Namespace,
-		HttpClient: c.HttpClient,
-		SecretID:   c.SecretID,
-		HttpAuth:   c.HttpAuth,
-		WaitTime:   c.WaitTime,
-		TLSConfig:  c.TLSConfig.Copy(),
+		Address:     fmt.Sprintf("%s://%s", scheme, address),
+		Region:      region,
+		Namespace:   c.Namespace,
+		HttpClient:  c.HttpClient,
+		SecretID:    c.SecretID,
+		HttpAuth:    c.HttpAuth,
+		WaitTime:    c.WaitTime,
+		TLSConfig:   c.TLSConfig.Copy(),
+		RetryPolicy: c.RetryPolicy.Copy(),
+		RateLimit:   c.RateLimit.Copy(),
 	}
 
 	// Update the tls server name for connecting to a client
@@ -455,6 +483,7 @@ func ConfigureTLS(httpClient *http.Client, tlsConfig *TLSConfig) error {
 type Client struct {
 	httpClient *http.Client
 	config     Config
+	limiters   *rateLimiters
 }
 
 // NewClient returns a new client
@@ -479,6 +508,7 @@ func NewClient(config *Config) (*Client, error) {
 	client := &Client{
 		config:     *config,
 		httpClient: httpClient,
+		limiters:   newRateLimiters(config.RateLimit),
 	}
 	return client, nil
 }
@@ -586,6 +616,9 @@ type request struct {
 	obj    interface{}
 	ctx    context.Context
 	header http.Header
+
+	// idempotent marks a non-GET request as safe to retry
+	idempotent bool
 }
 
 // setQueryOptions is used to annotate the request with
@@ -790,15 +823,25 @@ func (c *Client) doRequest(r *request) (time.Duration, *http.Response, error) {
 		return 0, nil, err
 	}
 
+	release := func() {}
+	if c.limiters != nil {
+		release, err = c.limiters.limiterFor(r).wait(req.Context())
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+
 	start := time.Now()
 	resp, err := c.httpClient.Do(req)
 	diff := time.Since(start)
 
 	// If the response is compressed, we swap the body's reader.
 	if zipErr := c.autoUnzip(resp); zipErr != nil {
+		release()
 		return 0, nil, zipErr
 	}
 
+	releaseOnClose(resp, release)
 	return diff, resp, err
 }
 
@@ -898,7 +941,7 @@ func (c *Client) websocket(endpoint string, q *QueryOptions) (*websocket.Conn, *
 		if resp.Header.Get("Content-Encoding") == "gzip" {
 			greader, err := gzip.NewReader(resp.Body)
 			if err != nil {
-				return nil, nil, fmt.Errorf("Unexpected response code: %d", resp.StatusCode)
+				return nil, nil, NewAPIError(resp.StatusCode, "")
 			}
 			io.Copy(&buf, greader)
 		} else {
@@ -906,7 +949,7 @@ func (c *Client) websocket(endpoint string, q *QueryOptions) (*websocket.Conn, *
 		}
 		resp.Body.Close()
 
-		return nil, nil, fmt.Errorf("Unexpected response code: %d (%s)", resp.StatusCode, buf.Bytes())
+		return nil, nil, NewAPIError(resp.StatusCode, buf.String())
 	}
 
 	return conn, resp, err
@@ -921,7 +964,7 @@ func (c *Client) query(endpoint string, out interface{}, q *QueryOptions) (*Quer
 		return nil, err
 	}
 	r.setQueryOptions(q)
-	rtt, resp, err := requireOK(c.doRequest(r))
+	rtt, resp, err := c.doRequestRetry(r)
 	if err != nil {
 		return nil, err
 	}
@@ -937,17 +980,29 @@ func (c *Client) query(endpoint string, out interface{}, q *QueryOptions) (*Quer
 	return qm, nil
 }
 
-// putQuery is used to do a PUT request when doing a read against an endpoint
-// and deserialize the response into an interface using standard Nomad
-// conventions.
+// putQuery is used to do a PUT request against an endpoint that takes query
+// options and deserialize the response into an interface using standard Nomad
+// conventions. The request may change state, so it is not retried.
 func (c *Client) putQuery(endpoint string, in, out interface{}, q *QueryOptions) (*QueryMeta, error) {
+	return c.doPutQuery(endpoint, in, out, q, false)
+}
+
+// putReadQuery is used like putQuery to do a PUT request when doing a read
+// against an endpoint. Reads don't change state, so the request may be
+// retried.
+func (c *Client) putReadQuery(endpoint string, in, out interface{}, q *QueryOptions) (*QueryMeta, error) {
+	return c.doPutQuery(endpoint, in, out, q, true)
+}
+
+func (c *Client) doPutQuery(endpoint string, in, out interface{}, q *QueryOptions, idempotent bool) (*QueryMeta, error) {
 	r, err := c.newRequest("PUT", endpoint)
 	if err != nil {
 		return nil, err
 	}
 	r.setQueryOptions(q)
 	r.obj = in
-	rtt, resp, err := requireOK(c.doRequest(r))
+	r.idempotent = idempotent
+	rtt, resp, err := c.doRequestRetry(r)
 	if err != nil {
 		return nil, err
 	}
@@ -972,7 +1027,7 @@ func (c *Client) write(endpoint string, in, out interface{}, q *WriteOptions) (*
 	}
 	r.setWriteOptions(q)
 	r.obj = in
-	rtt, resp, err := requireOK(c.doRequest(r))
+	rtt, resp, err := c.doRequestRetry(r)
 	if err != nil {
 		return nil, err
 	}
@@ -998,7 +1053,7 @@ func (c *Client) delete(endpoint string, in, out interface{}, q *WriteOptions) (
 	}
 	r.setWriteOptions(q)
 	r.obj = in
-	rtt, resp, err := requireOK(c.doRequest(r))
+	rtt, resp, err := c.doRequestRetry(r)
 	if err != nil {
 		return nil, err
 	}
@@ -1041,6 +1096,24 @@ func parseQueryMeta(resp *http.Response, q *QueryMeta) error {
 	default:
 		q.KnownLeader = false
 	}
+
+	// Parse the query cost headers. These are optional as older agents
+	// don't send them.
+	if v := header.Get("X-Nomad-QueryTime"); v != "" {
+		usec, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("Failed to parse X-Nomad-QueryTime: %v", err)
+		}
+		q.QueryTime = time.Duration(usec) * time.Microsecond
+	}
+	if v := header.Get("X-Nomad-ObjectsScanned"); v != "" {
+		scanned, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("Failed to parse X-Nomad-ObjectsScanned: %v", err)
+		}
+		q.ObjectsScanned = scanned
+	}
+	q.Forwarded = header.Get("X-Nomad-Forwarded") == "true"
 	return nil
 }
 
@@ -1097,11 +1170,7 @@ func requireOK(d time.Duration, resp *http.Response, e error) (time.Duration, *h
 		return d, nil, e
 	}
 	if resp.StatusCode != 200 {
-		var buf bytes.Buffer
-		_, _ = io.Copy(&buf, resp.Body)
-		_ = resp.Body.Close()
-		body := strings.TrimSpace(buf.String())
-		return d, nil, fmt.Errorf("Unexpected response code: %d (%s)", resp.StatusCode, body)
+		return d, nil, newAPIErrorFromResponse(resp)
 	}
 	return d, resp, nil
 }
@@ -302,6 +302,9 @@ func TestParseQueryMeta(t *testing.T) {
 	resp.Header.Set("X-Nomad-Index", "12345")
 	resp.Header.Set("X-Nomad-LastContact", "80")
 	resp.Header.Set("X-Nomad-KnownLeader", "true")
+	resp.Header.Set("X-Nomad-QueryTime", "1500")
+	resp.Header.Set("X-Nomad-ObjectsScanned", "42")
+	resp.Header.Set("X-Nomad-Forwarded", "true")
 
 	qm := &QueryMeta{}
 	if err := parseQueryMeta(resp, qm); err != nil {
@@ -317,6 +320,15 @@ func TestParseQueryMeta(t *testing.T) {
 	if !qm.KnownLeader {
 		t.Fatalf("Bad: %v", qm)
 	}
+	if qm.QueryTime != 1500*time.Microsecond {
+		t.Fatalf("Bad: %v", qm)
+	}
+	if qm.ObjectsScanned != 42 {
+		t.Fatalf("Bad: %v", qm)
+	}
+	if !qm.Forwarded {
+		t.Fatalf("Bad: %v", qm)
+	}
 }
 
 func TestParseWriteMeta(t *testing.T) {
@@ -604,3 +616,202 @@ func TestClient_autoUnzip(t *testing.T) {
 		Body:   io.NopCloser(&b),
 	}, nil)
 }
+
+func TestClient_RetryPolicy(t *testing.T) {
+	testutil.Parallel(t)
+
+	var calls int
+	var failures int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("X-Nomad-Index", "7")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	conf := DefaultConfig()
+	conf.Address = ts.URL
+	conf.RetryPolicy = &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  time.Millisecond,
+	}
+	client, err := NewClient(conf)
+	require.NoError(t, err)
+
+	reset := func(n int) {
+		calls, failures = 0, n
+	}
+
+	// Reads are retried until they succeed
+	reset(2)
+	var out map[string]interface{}
+	_, err = client.query("/v1/test", &out, nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, calls)
+
+	// Reads give up after MaxAttempts
+	reset(3)
+	_, err = client.query("/v1/test", &out, nil)
+	require.EqualError(t, err, "Unexpected response code: 503 ()")
+	require.Equal(t, 3, calls)
+
+	// Writes without a check-index or idempotency token are not retried
+	reset(1)
+	_, err = client.write("/v1/test", struct{}{}, nil, nil)
+	require.Error(t, err)
+	require.Equal(t, 1, calls)
+
+	// Writes with a check-index are retried
+	reset(1)
+	wm, err := client.write("/v1/test?cas=5", struct{}{}, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), wm.LastIndex)
+	require.Equal(t, 2, calls)
+
+	// Writes with an idempotency token are retried
+	reset(1)
+	_, err = client.write("/v1/test", struct{}{}, nil, &WriteOptions{IdempotencyToken: "abc"})
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+
+	// Reads sent as PUT requests are retried
+	reset(1)
+	_, err = client.putReadQuery("/v1/test", struct{}{}, &out, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+
+	// Other PUT requests taking query options change state, and are not
+	// retried
+	reset(1)
+	_, err = client.Allocations().Stop(&Allocation{ID: "8ba85cef-26cc-40d4-b6d5-3f6f1bd5ec6f"}, nil)
+	require.Error(t, err)
+	require.Equal(t, 1, calls)
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	testutil.Parallel(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	conf := DefaultConfig()
+	conf.Address = ts.URL
+	conf.RateLimit = &RateLimit{RequestsPerSecond: 20, Burst: 2}
+	client, err := NewClient(conf)
+	require.NoError(t, err)
+
+	// The burst is sent right away, and the next requests wait for the
+	// bucket to refill
+	start := time.Now()
+	var out map[string]interface{}
+	for i := 0; i < 4; i++ {
+		_, err = client.query("/v1/test", &out, nil)
+		require.NoError(t, err)
+	}
+	require.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+
+	// Requests give up waiting when their context is done
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	_, err = client.query("/v1/test", &out, (&QueryOptions{}).WithContext(ctx))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClient_RateLimit_MaxConcurrent(t *testing.T) {
+	testutil.Parallel(t)
+
+	unblock := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/v1/job") && r.URL.Query().Get("index") != "" {
+			<-unblock
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+	defer close(unblock)
+
+	conf := DefaultConfig()
+	conf.Address = ts.URL
+	conf.RateLimit = &RateLimit{
+		MaxConcurrent: 1,
+		BlockingQueries: map[string]*RateLimit{
+			"/v1/job": {MaxConcurrent: 1},
+		},
+	}
+	client, err := NewClient(conf)
+	require.NoError(t, err)
+
+	query := func(endpoint string, q *QueryOptions, timeout time.Duration) error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		if q == nil {
+			q = &QueryOptions{}
+		}
+		var out map[string]interface{}
+		_, err := client.query(endpoint, &out, q.WithContext(ctx))
+		return err
+	}
+
+	// A blocking query in flight uses the slot of its override
+	go query("/v1/jobs", &QueryOptions{WaitIndex: 5}, time.Minute)
+	require.Eventually(t, func() bool {
+		return len(client.limiters.blocking["/v1/job"].sem) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	err = query("/v1/job/example", &QueryOptions{WaitIndex: 5}, 50*time.Millisecond)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Other requests aren't held up by the blocking query, and release
+	// their slot once their response is read
+	require.NoError(t, query("/v1/jobs", nil, time.Second))
+	require.NoError(t, query("/v1/jobs", nil, time.Second))
+
+	// Blocking queries matching no override share the other slots
+	require.NoError(t, query("/v1/nodes", &QueryOptions{WaitIndex: 5}, time.Second))
+	require.Empty(t, client.limiters.defaultLimiter.sem)
+}
+
+func TestRateLimit_limiterFor(t *testing.T) {
+	testutil.Parallel(t)
+
+	rl := newRateLimiters(&RateLimit{
+		BlockingQueries: map[string]*RateLimit{
+			"/v1/job":          {MaxConcurrent: 1},
+			"/v1/job/example/": {MaxConcurrent: 2},
+		},
+	})
+	newReq := func(path string, index uint64) *request {
+		r := &request{url: &url.URL{Path: path}, params: url.Values{}}
+		r.setQueryOptions(&QueryOptions{WaitIndex: index})
+		return r
+	}
+
+	require.Equal(t, rl.defaultLimiter, rl.limiterFor(newReq("/v1/jobs", 0)))
+	require.Equal(t, rl.blocking["/v1/job"], rl.limiterFor(newReq("/v1/jobs", 5)))
+	require.Equal(t, rl.blocking["/v1/job/example/"], rl.limiterFor(newReq("/v1/job/example/allocations", 5)))
+	require.Equal(t, rl.defaultLimiter, rl.limiterFor(newReq("/v1/nodes", 5)))
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	testutil.Parallel(t)
+
+	p := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for retry, max := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 400 * time.Millisecond,
+		5: time.Second,
+		9: time.Second,
+	} {
+		wait := p.backoff(retry)
+		require.LessOrEqual(t, wait, max)
+		require.Greater(t, wait, max/2)
+	}
+}
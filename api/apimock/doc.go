@@ -0,0 +1,14 @@
+// Package apimock provides mocks of the interfaces of the api package, which
+// let programs using the api package be unit tested without a Nomad agent.
+//
+// Each mock has a function field per method, named after the method with a
+// Func suffix, which the method calls:
+//
+//	vars := &apimock.SecureVariables{
+//		ReadFunc: func(path string, qo *api.QueryOptions) (*api.SecureVariable, *api.QueryMeta, error) {
+//			return &api.SecureVariable{Path: path}, &api.QueryMeta{}, nil
+//		},
+//	}
+package apimock
+
+//go:generate go run gen.go
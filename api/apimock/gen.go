@@ -0,0 +1,251 @@
+//go:build ignore
+
+// gen.go generates the mocks of the interfaces of the api package, which are
+// read from api/interfaces.go. Run it with "go generate ./apimock" from the
+// api module.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+const (
+	source = "../interfaces.go"
+	output = "mocks.go"
+)
+
+type mock struct {
+	Name      string
+	Interface string
+	Methods   []method
+}
+
+type method struct {
+	Name    string
+	Params  string
+	Args    string
+	Results string
+}
+
+func main() {
+	mocks, err := parseMocks(source)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing %s: %v\n", source, err)
+		os.Exit(1)
+	}
+
+	var buf bytes.Buffer
+	if err := mockTmpl.Execute(&buf, mocks); err != nil {
+		fmt.Fprintf(os.Stderr, "error generating mocks: %v\n", err)
+		os.Exit(1)
+	}
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error formatting mocks: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(output, out, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing %s: %v\n", output, err)
+		os.Exit(1)
+	}
+}
+
+// parseMocks returns a mock for each exported interface of the file whose
+// name ends with "API".
+func parseMocks(path string) ([]mock, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var mocks []mock
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			iface, ok := ts.Type.(*ast.InterfaceType)
+			if !ok || !ts.Name.IsExported() || !strings.HasSuffix(ts.Name.Name, "API") {
+				continue
+			}
+
+			m := mock{
+				Name:      strings.TrimSuffix(ts.Name.Name, "API"),
+				Interface: ts.Name.Name,
+			}
+			for _, field := range iface.Methods.List {
+				ft, ok := field.Type.(*ast.FuncType)
+				if !ok {
+					return nil, fmt.Errorf("interface %s embeds %s, which is not supported", ts.Name.Name, expr(field.Type))
+				}
+				meth, err := newMethod(field.Names[0].Name, ft)
+				if err != nil {
+					return nil, fmt.Errorf("method %s.%s: %v", ts.Name.Name, field.Names[0].Name, err)
+				}
+				m.Methods = append(m.Methods, meth)
+			}
+			mocks = append(mocks, m)
+		}
+	}
+
+	sort.Slice(mocks, func(i, j int) bool { return mocks[i].Name < mocks[j].Name })
+	return mocks, nil
+}
+
+func newMethod(name string, ft *ast.FuncType) (method, error) {
+	var params, args []string
+	for i, field := range ft.Params.List {
+		typ := expr(field.Type)
+		if typ == "" {
+			return method{}, fmt.Errorf("unsupported parameter type")
+		}
+		names := field.Names
+		if len(names) == 0 {
+			// Parameters of function types can stay unnamed
+			if name == "" {
+				params = append(params, typ)
+				continue
+			}
+			names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("arg%d", i))}
+		}
+		for _, n := range names {
+			params = append(params, n.Name+" "+typ)
+			arg := n.Name
+			if strings.HasPrefix(typ, "...") {
+				arg += "..."
+			}
+			args = append(args, arg)
+		}
+	}
+
+	var results []string
+	if ft.Results != nil {
+		for _, field := range ft.Results.List {
+			typ := expr(field.Type)
+			if typ == "" {
+				return method{}, fmt.Errorf("unsupported result type")
+			}
+			n := len(field.Names)
+			if n == 0 {
+				n = 1
+			}
+			for i := 0; i < n; i++ {
+				results = append(results, typ)
+			}
+		}
+	}
+
+	m := method{
+		Name:   name,
+		Params: strings.Join(params, ", "),
+		Args:   strings.Join(args, ", "),
+	}
+	switch len(results) {
+	case 0:
+	case 1:
+		m.Results = results[0]
+	default:
+		m.Results = "(" + strings.Join(results, ", ") + ")"
+	}
+	return m, nil
+}
+
+// expr returns the source of a type of the api package, as used from the
+// apimock package, or an empty string if the type isn't supported.
+func expr(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.Ident:
+		if t.IsExported() {
+			return "api." + t.Name
+		}
+		return t.Name
+	case *ast.StarExpr:
+		return prefix("*", expr(t.X))
+	case *ast.ArrayType:
+		if t.Len != nil {
+			return ""
+		}
+		return prefix("[]", expr(t.Elt))
+	case *ast.Ellipsis:
+		return prefix("...", expr(t.Elt))
+	case *ast.MapType:
+		k, v := expr(t.Key), expr(t.Value)
+		if k == "" || v == "" {
+			return ""
+		}
+		return "map[" + k + "]" + v
+	case *ast.InterfaceType:
+		if len(t.Methods.List) != 0 {
+			return ""
+		}
+		return "interface{}"
+	case *ast.FuncType:
+		m, err := newMethod("", t)
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace("func(" + m.Params + ") " + m.Results)
+	}
+	return ""
+}
+
+func prefix(p, s string) string {
+	if s == "" {
+		return ""
+	}
+	return p + s
+}
+
+var mockTmpl = template.Must(template.New("mocks").Parse(`// Code generated by gen.go; DO NOT EDIT.
+
+package apimock
+
+import "github.com/hashicorp/nomad/api"
+
+// Client groups a mock of each handle of the api package. The handles can be
+// passed to code that accepts the interfaces of the api package.
+type Client struct {
+{{- range .}}
+	{{.Name}} *{{.Name}}
+{{- end}}
+}
+
+// NewClient returns a Client with a mock for each handle, none of whose
+// functions are set.
+func NewClient() *Client {
+	return &Client{
+{{- range .}}
+		{{.Name}}: &{{.Name}}{},
+{{- end}}
+	}
+}
+{{range $mock := .}}
+// {{.Name}} is a mock of api.{{.Interface}}. Each method calls the function
+// of the field named after it, and panics if the function isn't set.
+type {{.Name}} struct {
+{{- range .Methods}}
+	{{.Name}}Func func({{.Params}}) {{.Results}}
+{{- end}}
+}
+
+var _ api.{{.Interface}} = (*{{.Name}})(nil)
+{{range .Methods}}
+// {{.Name}} calls {{.Name}}Func.
+func (m *{{$mock.Name}}) {{.Name}}({{.Params}}) {{.Results}} {
+	if m.{{.Name}}Func == nil {
+		panic("apimock: unexpected call to {{$mock.Name}}.{{.Name}}")
+	}
+	{{if .Results}}return {{end}}m.{{.Name}}Func({{.Args}})
+}
+{{end}}{{end}}`))
@@ -0,0 +1,58 @@
+package apimock
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/shoenig/test/must"
+)
+
+func TestMocks(t *testing.T) {
+	client := NewClient()
+
+	var vars api.SecureVariablesAPI = client.SecureVariables
+	client.SecureVariables.ReadFunc = func(path string, _ *api.QueryOptions) (*api.SecureVariable, *api.QueryMeta, error) {
+		return &api.SecureVariable{Path: path}, &api.QueryMeta{LastIndex: 7}, nil
+	}
+	sv, meta, err := vars.Read("apps/web", nil)
+	must.NoError(t, err)
+	must.Eq(t, "apps/web", sv.Path)
+	must.Eq(t, 7, meta.LastIndex)
+
+	// Methods without a function panic
+	defer func() {
+		must.Eq(t, "apimock: unexpected call to Keyring.Rotate", recover())
+	}()
+	var keyring api.KeyringAPI = client.Keyring
+	_, _, _ = keyring.Rotate(nil, nil)
+}
+
+func TestMocks_UpToDate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping generator in short mode")
+	}
+
+	// Generate the mocks to a temporary copy of the package
+	dir := filepath.Join(t.TempDir(), "apimock")
+	must.NoError(t, os.Mkdir(dir, 0755))
+	gen, err := os.ReadFile("gen.go")
+	must.NoError(t, err)
+	must.NoError(t, os.WriteFile(filepath.Join(dir, "gen.go"), gen, 0644))
+	iface, err := os.ReadFile("../interfaces.go")
+	must.NoError(t, err)
+	must.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(dir), "interfaces.go"), iface, 0644))
+
+	cmd := exec.Command("go", "run", "gen.go")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	must.NoError(t, err, must.Sprint(string(out)))
+
+	generated, err := os.ReadFile(filepath.Join(dir, "mocks.go"))
+	must.NoError(t, err)
+	current, err := os.ReadFile("mocks.go")
+	must.NoError(t, err)
+	must.Eq(t, string(generated), string(current), must.Sprint(`mocks.go is out of date, run "go generate ./apimock"`))
+}
@@ -1,9 +1,11 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -21,7 +23,7 @@ func (c *Client) CSIVolumes() *CSIVolumes {
 // List returns all CSI volumes.
 func (v *CSIVolumes) List(q *QueryOptions) ([]*CSIVolumeListStub, *QueryMeta, error) {
 	var resp []*CSIVolumeListStub
-	qm, err := v.client.query("/v1/volumes?type=csi", &resp, q)
+	qm, err := v.client.query(newQueryParams().set("type", "csi").endpoint("/v1/volumes"), &resp, q)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -36,16 +38,13 @@ func (v *CSIVolumes) List(q *QueryOptions) ([]*CSIVolumeListStub, *QueryMeta, er
 func (v *CSIVolumes) ListExternal(pluginID string, q *QueryOptions) (*CSIVolumeListExternalResponse, *QueryMeta, error) {
 	var resp *CSIVolumeListExternalResponse
 
-	qp := url.Values{}
-	qp.Set("plugin_id", pluginID)
-	if q.NextToken != "" {
-		qp.Set("next_token", q.NextToken)
-	}
-	if q.PerPage != 0 {
-		qp.Set("per_page", fmt.Sprint(q.PerPage))
-	}
+	endpoint := newQueryParams().
+		set("plugin_id", pluginID).
+		setString("next_token", q.NextToken).
+		setInt("per_page", int(q.PerPage)).
+		endpoint("/v1/volumes/external")
 
-	qm, err := v.client.query("/v1/volumes/external?"+qp.Encode(), &resp, q)
+	qm, err := v.client.query(endpoint, &resp, q)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -82,7 +81,10 @@ func (v *CSIVolumes) Register(vol *CSIVolume, w *WriteOptions) (*WriteMeta, erro
 
 // Deregister deregisters a single CSIVolume from Nomad. The volume will not be deleted from the external storage provider.
 func (v *CSIVolumes) Deregister(id string, force bool, w *WriteOptions) error {
-	_, err := v.client.delete(fmt.Sprintf("/v1/volume/csi/%v?force=%t", url.PathEscape(id), force), nil, nil, w)
+	endpoint := newQueryParams().
+		set("force", strconv.FormatBool(force)).
+		endpoint("/v1/volume/csi/" + url.PathEscape(id))
+	_, err := v.client.delete(endpoint, nil, nil, w)
 	return err
 }
 
@@ -125,7 +127,10 @@ func (v *CSIVolumes) DeleteOpts(req *CSIVolumeDeleteRequest, w *WriteOptions) er
 // node. This is used in the case that the node is temporarily lost and the
 // allocations are unable to drop their claims automatically.
 func (v *CSIVolumes) Detach(volID, nodeID string, w *WriteOptions) error {
-	_, err := v.client.delete(fmt.Sprintf("/v1/volume/csi/%v/detach?node=%v", url.PathEscape(volID), nodeID), nil, nil, w)
+	endpoint := newQueryParams().
+		set("node", nodeID).
+		endpoint("/v1/volume/csi/" + url.PathEscape(volID) + "/detach")
+	_, err := v.client.delete(endpoint, nil, nil, w)
 	return err
 }
 
@@ -145,14 +150,15 @@ func (v *CSIVolumes) CreateSnapshot(snap *CSISnapshot, w *WriteOptions) (*CSISna
 
 // DeleteSnapshot deletes an external storage volume snapshot.
 func (v *CSIVolumes) DeleteSnapshot(snap *CSISnapshot, w *WriteOptions) error {
-	qp := url.Values{}
-	qp.Set("snapshot_id", snap.ID)
-	qp.Set("plugin_id", snap.PluginID)
+	endpoint := newQueryParams().
+		set("snapshot_id", snap.ID).
+		set("plugin_id", snap.PluginID).
+		endpoint("/v1/volumes/snapshot")
 	if w == nil {
 		w = &WriteOptions{}
 	}
 	w.SetHeadersFromCSISecrets(snap.Secrets)
-	_, err := v.client.delete("/v1/volumes/snapshot?"+qp.Encode(), nil, nil, w)
+	_, err := v.client.delete(endpoint, nil, nil, w)
 	return err
 }
 
@@ -160,19 +166,14 @@ func (v *CSIVolumes) DeleteSnapshot(snap *CSISnapshot, w *WriteOptions) error {
 func (v *CSIVolumes) ListSnapshotsOpts(req *CSISnapshotListRequest) (*CSISnapshotListResponse, *QueryMeta, error) {
 	var resp *CSISnapshotListResponse
 
-	qp := url.Values{}
-	if req.PluginID != "" {
-		qp.Set("plugin_id", req.PluginID)
-	}
-	if req.NextToken != "" {
-		qp.Set("next_token", req.NextToken)
-	}
-	if req.PerPage != 0 {
-		qp.Set("per_page", fmt.Sprint(req.PerPage))
-	}
+	endpoint := newQueryParams().
+		setString("plugin_id", req.PluginID).
+		setString("next_token", req.NextToken).
+		setInt("per_page", int(req.PerPage)).
+		endpoint("/v1/volumes/snapshot")
 	req.QueryOptions.SetHeadersFromCSISecrets(req.Secrets)
 
-	qm, err := v.client.query("/v1/volumes/snapshot?"+qp.Encode(), &resp, &req.QueryOptions)
+	qm, err := v.client.query(endpoint, &resp, &req.QueryOptions)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -186,18 +187,13 @@ func (v *CSIVolumes) ListSnapshotsOpts(req *CSISnapshotListRequest) (*CSISnapsho
 func (v *CSIVolumes) ListSnapshots(pluginID string, secrets string, q *QueryOptions) (*CSISnapshotListResponse, *QueryMeta, error) {
 	var resp *CSISnapshotListResponse
 
-	qp := url.Values{}
-	if pluginID != "" {
-		qp.Set("plugin_id", pluginID)
-	}
-	if q.NextToken != "" {
-		qp.Set("next_token", q.NextToken)
-	}
-	if q.PerPage != 0 {
-		qp.Set("per_page", fmt.Sprint(q.PerPage))
-	}
+	endpoint := newQueryParams().
+		setString("plugin_id", pluginID).
+		setString("next_token", q.NextToken).
+		setInt("per_page", int(q.PerPage)).
+		endpoint("/v1/volumes/snapshot")
 
-	qm, err := v.client.query("/v1/volumes/snapshot?"+qp.Encode(), &resp, q)
+	qm, err := v.client.query(endpoint, &resp, q)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -461,13 +457,13 @@ type CSIVolumeDeleteRequest struct {
 
 // CSISnapshot is the storage provider's view of a volume snapshot
 type CSISnapshot struct {
-	ID                     string // storage provider's ID
-	ExternalSourceVolumeID string // storage provider's ID for volume
-	SizeBytes              int64  // value from storage provider
-	CreateTime             int64  // value from storage provider
-	IsReady                bool   // value from storage provider
-	SourceVolumeID         string // Nomad volume ID
-	PluginID               string // CSI plugin ID
+	ID                     string    // storage provider's ID
+	ExternalSourceVolumeID string    // storage provider's ID for volume
+	SizeBytes              int64     // value from storage provider
+	CreateTime             time.Time // value from storage provider
+	IsReady                bool      // value from storage provider
+	SourceVolumeID         string    // Nomad volume ID
+	PluginID               string    // CSI plugin ID
 
 	// These field are only used during snapshot creation and will not be
 	// populated when the snapshot is returned
@@ -476,6 +472,32 @@ type CSISnapshot struct {
 	Parameters map[string]string // secrets needed to create snapshot
 }
 
+func (s *CSISnapshot) MarshalJSON() ([]byte, error) {
+	type Alias CSISnapshot
+	return json.Marshal(&struct {
+		CreateTime unixSeconds
+		*Alias
+	}{
+		CreateTime: unixSeconds(s.CreateTime),
+		Alias:      (*Alias)(s),
+	})
+}
+
+func (s *CSISnapshot) UnmarshalJSON(data []byte) error {
+	type Alias CSISnapshot
+	aux := &struct {
+		CreateTime unixSeconds
+		*Alias
+	}{
+		Alias: (*Alias)(s),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	s.CreateTime = time.Time(aux.CreateTime)
+	return nil
+}
+
 // CSISnapshotSort is a helper used for sorting snapshots by creation time.
 type CSISnapshotSort []*CSISnapshot
 
@@ -484,7 +506,7 @@ func (v CSISnapshotSort) Len() int {
 }
 
 func (v CSISnapshotSort) Less(i, j int) bool {
-	return v[i].CreateTime > v[j].CreateTime
+	return v[i].CreateTime.After(v[j].CreateTime)
 }
 
 func (v CSISnapshotSort) Swap(i, j int) {
@@ -576,7 +598,7 @@ func (c *Client) CSIPlugins() *CSIPlugins {
 // List returns all CSI plugins
 func (v *CSIPlugins) List(q *QueryOptions) ([]*CSIPluginListStub, *QueryMeta, error) {
 	var resp []*CSIPluginListStub
-	qm, err := v.client.query("/v1/plugins?type=csi", &resp, q)
+	qm, err := v.client.query(newQueryParams().set("type", "csi").endpoint("/v1/plugins"), &resp, q)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -204,6 +204,7 @@ type DeploymentState struct {
 	PlacedAllocs      int
 	HealthyAllocs     int
 	UnhealthyAllocs   int
+	UnknownAllocs     int
 }
 
 // DeploymentIndexSort is a wrapper to sort deployments by CreateIndex. We
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// ErrCodeBadRequest, ErrCodeUnauthorized and the other ErrCode constants
+	// are the values of APIError.Code for the status codes Nomad returns.
+	ErrCodeBadRequest         = "bad_request"
+	ErrCodeUnauthorized       = "unauthorized"
+	ErrCodePermissionDenied   = "permission_denied"
+	ErrCodeNotFound           = "not_found"
+	ErrCodeConflict           = "conflict"
+	ErrCodeTooManyRequests    = "too_many_requests"
+	ErrCodeInternal           = "internal"
+	ErrCodeNotImplemented     = "not_implemented"
+	ErrCodeBadGateway         = "bad_gateway"
+	ErrCodeServiceUnavailable = "service_unavailable"
+	ErrCodeGatewayTimeout     = "gateway_timeout"
+	ErrCodeUnknown            = "unknown"
+)
+
+var errCodes = map[int]string{
+	http.StatusBadRequest:          ErrCodeBadRequest,
+	http.StatusUnauthorized:        ErrCodeUnauthorized,
+	http.StatusForbidden:           ErrCodePermissionDenied,
+	http.StatusNotFound:            ErrCodeNotFound,
+	http.StatusConflict:            ErrCodeConflict,
+	http.StatusTooManyRequests:     ErrCodeTooManyRequests,
+	http.StatusInternalServerError: ErrCodeInternal,
+	http.StatusNotImplemented:      ErrCodeNotImplemented,
+	http.StatusBadGateway:          ErrCodeBadGateway,
+	http.StatusServiceUnavailable:  ErrCodeServiceUnavailable,
+	http.StatusGatewayTimeout:      ErrCodeGatewayTimeout,
+}
+
+// APIError is returned by the client when the Nomad HTTP API responds with an
+// unexpected status code. Callers can inspect it with errors.As or the
+// IsNotFound and IsConflict helpers instead of matching on the error string.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Code is a short, stable identifier for the class of error, such as
+	// ErrCodeNotFound.
+	Code string
+
+	// Message is the body of the response, which holds the error returned
+	// by the server.
+	Message string
+
+	// Retryable is true if the request failed with a status code that is
+	// safe to retry later, such as 429 or 503.
+	Retryable bool
+}
+
+// NewAPIError returns an APIError for the status code and response body.
+func NewAPIError(statusCode int, message string) *APIError {
+	code, ok := errCodes[statusCode]
+	if !ok {
+		code = ErrCodeUnknown
+	}
+	retryable := false
+	for _, c := range defaultRetryableStatusCodes {
+		if c == statusCode {
+			retryable = true
+			break
+		}
+	}
+	return &APIError{
+		StatusCode: statusCode,
+		Code:       code,
+		Message:    message,
+		Retryable:  retryable,
+	}
+}
+
+// Error keeps the format the client has always returned, so existing callers
+// matching on the string keep working.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Unexpected response code: %d (%s)", e.StatusCode, e.Message)
+}
+
+// newAPIErrorFromResponse reads and closes the body of resp and returns it as
+// an APIError.
+func newAPIErrorFromResponse(resp *http.Response) *APIError {
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, resp.Body)
+	_ = resp.Body.Close()
+	return NewAPIError(resp.StatusCode, strings.TrimSpace(buf.String()))
+}
+
+// UnwrapAPIError returns the APIError in err's chain, if any.
+func UnwrapAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// IsNotFound returns whether err is an APIError for a 404 response.
+func IsNotFound(err error) bool {
+	apiErr, ok := UnwrapAPIError(err)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsConflict returns whether err is an APIError for a 409 response.
+func IsConflict(err error) bool {
+	apiErr, ok := UnwrapAPIError(err)
+	return ok && apiErr.StatusCode == http.StatusConflict
+}
+
+// IsPermissionDenied returns whether err is an APIError for a 403 response.
+func IsPermissionDenied(err error) bool {
+	apiErr, ok := UnwrapAPIError(err)
+	return ok && apiErr.StatusCode == http.StatusForbidden
+}
+
+// IsRetryable returns whether err is an APIError for a response that is safe
+// to retry later.
+func IsRetryable(err error) bool {
+	apiErr, ok := UnwrapAPIError(err)
+	return ok && apiErr.Retryable
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/nomad/api/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIError(t *testing.T) {
+	testutil.Parallel(t)
+
+	err := NewAPIError(http.StatusNotFound, "job not found")
+	require.Equal(t, "Unexpected response code: 404 (job not found)", err.Error())
+	require.Equal(t, ErrCodeNotFound, err.Code)
+	require.False(t, err.Retryable)
+
+	err = NewAPIError(http.StatusServiceUnavailable, "No cluster leader")
+	require.Equal(t, ErrCodeServiceUnavailable, err.Code)
+	require.True(t, err.Retryable)
+
+	err = NewAPIError(418, "teapot")
+	require.Equal(t, ErrCodeUnknown, err.Code)
+	require.False(t, err.Retryable)
+}
+
+func TestAPIError_Helpers(t *testing.T) {
+	testutil.Parallel(t)
+
+	notFound := NewAPIError(http.StatusNotFound, "")
+	conflict := NewAPIError(http.StatusConflict, "")
+	denied := NewAPIError(http.StatusForbidden, PermissionDeniedErrorContent)
+	unavailable := NewAPIError(http.StatusServiceUnavailable, "")
+	wrapped := fmt.Errorf("reading job: %w", notFound)
+	plain := errors.New("Unexpected response code: 404 (not found)")
+
+	require.True(t, IsNotFound(notFound))
+	require.True(t, IsNotFound(wrapped))
+	require.False(t, IsNotFound(conflict))
+	require.False(t, IsNotFound(plain))
+	require.False(t, IsNotFound(nil))
+
+	require.True(t, IsConflict(conflict))
+	require.False(t, IsConflict(notFound))
+
+	require.True(t, IsPermissionDenied(denied))
+	require.True(t, IsRetryable(unavailable))
+	require.False(t, IsRetryable(plain))
+
+	apiErr, ok := UnwrapAPIError(wrapped)
+	require.True(t, ok)
+	require.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+
+	_, ok = UnwrapAPIError(plain)
+	require.False(t, ok)
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"sort"
 	"time"
 )
@@ -30,6 +31,14 @@ func (e *Evaluations) PrefixList(prefix string) ([]*Evaluation, *QueryMeta, erro
 	return e.List(&QueryOptions{Prefix: prefix})
 }
 
+// ListPages is used to list all of the evaluations, one page at a time. It
+// follows the NextToken of each response and calls fn with each page until
+// the last page is reached or fn returns false. The page size is set with
+// QueryOptions.PerPage.
+func (e *Evaluations) ListPages(q *QueryOptions, fn func([]*Evaluation, *QueryMeta) bool) error {
+	return listPages(q, e.List, fn)
+}
+
 // Info is used to query a single evaluation by its ID.
 func (e *Evaluations) Info(evalID string, q *QueryOptions) (*Evaluation, *QueryMeta, error) {
 	var resp Evaluation
@@ -64,6 +73,18 @@ func (e *Evaluations) Allocations(evalID string, q *QueryOptions) ([]*Allocation
 	return resp, qm, nil
 }
 
+// Explanation is used to retrieve the explanation of the decisions the
+// scheduler made about the allocations of disconnected and reconnected
+// clients while processing an evaluation.
+func (e *Evaluations) Explanation(evalID string, q *QueryOptions) (*EvalExplanation, *QueryMeta, error) {
+	var resp EvalExplanation
+	qm, err := e.client.query("/v1/evaluation/"+evalID+"/explanation", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 const (
 	EvalStatusBlocked   = "blocked"
 	EvalStatusPending   = "pending"
@@ -98,11 +119,42 @@ type Evaluation struct {
 	QuotaLimitReached    string
 	AnnotatePlan         bool
 	QueuedAllocations    map[string]int
+	Explanation          *EvalExplanation
 	SnapshotIndex        uint64
 	CreateIndex          uint64
 	ModifyIndex          uint64
-	CreateTime           int64
-	ModifyTime           int64
+	CreateTime           time.Time
+	ModifyTime           time.Time
+}
+
+func (e *Evaluation) MarshalJSON() ([]byte, error) {
+	type Alias Evaluation
+	return json.Marshal(&struct {
+		CreateTime unixNanos
+		ModifyTime unixNanos
+		*Alias
+	}{
+		CreateTime: unixNanos(e.CreateTime),
+		ModifyTime: unixNanos(e.ModifyTime),
+		Alias:      (*Alias)(e),
+	})
+}
+
+func (e *Evaluation) UnmarshalJSON(data []byte) error {
+	type Alias Evaluation
+	aux := &struct {
+		CreateTime unixNanos
+		ModifyTime unixNanos
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	e.CreateTime = time.Time(aux.CreateTime)
+	e.ModifyTime = time.Time(aux.ModifyTime)
+	return nil
 }
 
 // EvaluationStub is used to serialize parts of an evaluation returned in the
@@ -124,8 +176,38 @@ type EvaluationStub struct {
 	BlockedEval       string
 	CreateIndex       uint64
 	ModifyIndex       uint64
-	CreateTime        int64
-	ModifyTime        int64
+	CreateTime        time.Time
+	ModifyTime        time.Time
+}
+
+func (e *EvaluationStub) MarshalJSON() ([]byte, error) {
+	type Alias EvaluationStub
+	return json.Marshal(&struct {
+		CreateTime unixNanos
+		ModifyTime unixNanos
+		*Alias
+	}{
+		CreateTime: unixNanos(e.CreateTime),
+		ModifyTime: unixNanos(e.ModifyTime),
+		Alias:      (*Alias)(e),
+	})
+}
+
+func (e *EvaluationStub) UnmarshalJSON(data []byte) error {
+	type Alias EvaluationStub
+	aux := &struct {
+		CreateTime unixNanos
+		ModifyTime unixNanos
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	e.CreateTime = time.Time(aux.CreateTime)
+	e.ModifyTime = time.Time(aux.ModifyTime)
+	return nil
 }
 
 type EvalDeleteRequest struct {
@@ -133,6 +215,54 @@ type EvalDeleteRequest struct {
 	WriteRequest
 }
 
+const (
+	AllocDecisionMarkUnknown = "mark-unknown"
+	AllocDecisionWait        = "wait"
+	AllocDecisionReplace     = "replace"
+	AllocDecisionStabilize   = "stabilize"
+	AllocDecisionReconnect   = "reconnect"
+	AllocDecisionStop        = "stop"
+)
+
+// EvalExplanation explains the decisions the scheduler made about the
+// allocations of disconnected and reconnected clients while processing an
+// evaluation.
+type EvalExplanation struct {
+	Allocations            []*AllocExplanation
+	DisconnectStrategy     *DisconnectStrategy
+	ReconnectStabilization time.Duration
+}
+
+// AllocExplanation explains the decision the scheduler made about an
+// allocation of a disconnected or reconnected client.
+type AllocExplanation struct {
+	AllocID      string
+	AllocName    string
+	TaskGroup    string
+	NodeID       string
+	NodeStatus   string
+	ClientStatus string
+
+	// Decision is one of the AllocDecision constants, and Reason describes
+	// why it was made.
+	Decision string
+	Reason   string
+
+	// DisconnectTimeout is when the allocation is considered lost if its
+	// client doesn't reconnect, and StabilizeUntil is when the reconnect
+	// stabilization period of its client ends. They are zero if they don't
+	// apply.
+	DisconnectTimeout time.Time
+	StabilizeUntil    time.Time
+
+	// ReplacementID is the replacement the allocation was compared with when
+	// its client reconnected, and Score and ReplacementScore are their
+	// normalized placement scores.
+	ReplacementID    string
+	Score            float64
+	ReplacementScore float64
+}
+
 // EvalIndexSort is a wrapper to sort evaluations by CreateIndex.
 // We reverse the test so that we get the highest index first.
 type EvalIndexSort []*Evaluation
@@ -187,6 +187,29 @@ func TestEvaluations_Allocations(t *testing.T) {
 	require.Equal(t, 0, len(allocs), "expected 0 evaluations")
 }
 
+func TestEvaluations_Explanation(t *testing.T) {
+	testutil.Parallel(t)
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	e := c.Evaluations()
+
+	// Unknown evals are not found
+	_, _, err := e.Explanation("8E231CF4-CA48-43FF-B694-5801E69E22FA", nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "eval not found")
+
+	// Register a job to create an eval
+	resp, wm, err := c.Jobs().Register(testJob(), nil)
+	require.NoError(t, err)
+	assertWriteMeta(t, wm)
+
+	// The eval has no allocations of disconnected clients to explain
+	explanation, qm, err := e.Explanation(resp.EvalID, nil)
+	require.NoError(t, err)
+	assertQueryMeta(t, qm)
+	require.Empty(t, explanation.Allocations)
+}
+
 func TestEvaluations_Sort(t *testing.T) {
 	testutil.Parallel(t)
 	evals := []*Evaluation{
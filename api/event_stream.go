@@ -5,19 +5,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
-	"time"
-
-	"github.com/mitchellh/mapstructure"
 )
 
 const (
-	TopicDeployment Topic = "Deployment"
-	TopicEvaluation Topic = "Evaluation"
-	TopicAllocation Topic = "Allocation"
-	TopicJob        Topic = "Job"
-	TopicNode       Topic = "Node"
-	TopicService    Topic = "Service"
-	TopicAll        Topic = "*"
+	TopicDeployment     Topic = "Deployment"
+	TopicEvaluation     Topic = "Evaluation"
+	TopicAllocation     Topic = "Allocation"
+	TopicJob            Topic = "Job"
+	TopicNode           Topic = "Node"
+	TopicService        Topic = "Service"
+	TopicSecureVariable Topic = "SecureVariable"
+	TopicKeyring        Topic = "Keyring"
+	TopicAll            Topic = "*"
 )
 
 // Events is a set of events for a corresponding index. Events returned for the
@@ -102,28 +101,49 @@ func (e *Event) Service() (*ServiceRegistration, error) {
 	return out.Service, nil
 }
 
-type eventPayload struct {
-	Allocation *Allocation          `mapstructure:"Allocation"`
-	Deployment *Deployment          `mapstructure:"Deployment"`
-	Evaluation *Evaluation          `mapstructure:"Evaluation"`
-	Job        *Job                 `mapstructure:"Job"`
-	Node       *Node                `mapstructure:"Node"`
-	Service    *ServiceRegistration `mapstructure:"Service"`
+// SecureVariable returns the metadata of a secure variable from a given event
+// payload. If the Event Topic is SecureVariable this will return valid
+// metadata. The variable's contents are never included in events.
+func (e *Event) SecureVariable() (*SecureVariableMetadata, error) {
+	out, err := e.decodePayload()
+	if err != nil {
+		return nil, err
+	}
+	return out.SecureVariable, nil
 }
 
-func (e *Event) decodePayload() (*eventPayload, error) {
-	var out eventPayload
-	cfg := &mapstructure.DecoderConfig{
-		Result:     &out,
-		DecodeHook: mapstructure.StringToTimeHookFunc(time.RFC3339),
+// RootKeyMeta returns the metadata of a root key from a given event payload.
+// If the Event Topic is Keyring this will return valid metadata.
+func (e *Event) RootKeyMeta() (*RootKeyMeta, error) {
+	out, err := e.decodePayload()
+	if err != nil {
+		return nil, err
 	}
+	return out.RootKeyMeta, nil
+}
 
-	dec, err := mapstructure.NewDecoder(cfg)
+type eventPayload struct {
+	Allocation     *Allocation             `json:"Allocation"`
+	Deployment     *Deployment             `json:"Deployment"`
+	Evaluation     *Evaluation             `json:"Evaluation"`
+	Job            *Job                    `json:"Job"`
+	Node           *Node                   `json:"Node"`
+	Service        *ServiceRegistration    `json:"Service"`
+	SecureVariable *SecureVariableMetadata `json:"SecureVariable"`
+	RootKeyMeta    *RootKeyMeta            `json:"RootKeyMeta"`
+}
+
+func (e *Event) decodePayload() (*eventPayload, error) {
+	// The payload has already been decoded into a generic map by the stream
+	// reader. Round-trip it through JSON so the typed decoders, such as the
+	// ones handling timestamps, are applied to the nested objects.
+	buf, err := json.Marshal(e.Payload)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := dec.Decode(e.Payload); err != nil {
+	var out eventPayload
+	if err := json.Unmarshal(buf, &out); err != nil {
 		return nil, err
 	}
 
@@ -146,10 +166,55 @@ func (c *Client) EventStream() *EventStream {
 	return &EventStream{client: c}
 }
 
+// EventStreamFilter holds the filters applied by the servers to the events of
+// the subscribed topics, so that consumers watching a single job or secure
+// variable path don't receive the whole topics. A filter only applies to the
+// events carrying the attribute it matches on: filtering on a job prefix
+// doesn't drop the events of the Node topic. The namespace of the events is
+// filtered with QueryOptions.Namespace.
+type EventStreamFilter struct {
+	// JobPrefix matches the job, evaluation, allocation, deployment, and
+	// service events of the jobs whose ID starts with the prefix.
+	JobPrefix string
+
+	// NodeClass matches the node events of the nodes of the class.
+	NodeClass string
+
+	// VariablePath is a glob matching the path of the secure variable
+	// events, such as "nomad/jobs/web/*". The * wildcard matches any sequence
+	// of characters, including path separators.
+	VariablePath string
+}
+
+// Validate implements endpointParams. Every combination of filters is valid.
+func (f *EventStreamFilter) Validate() error {
+	return nil
+}
+
+func (f *EventStreamFilter) encodeParams(q *queryParams) {
+	if f == nil {
+		return
+	}
+	q.setString("job_prefix", f.JobPrefix).
+		setString("node_class", f.NodeClass).
+		setString("variable_path", f.VariablePath)
+}
+
 // Stream establishes a new subscription to Nomad's event stream and streams
 // results back to the returned channel.
 func (e *EventStream) Stream(ctx context.Context, topics map[Topic][]string, index uint64, q *QueryOptions) (<-chan *Events, error) {
-	r, err := e.client.newRequest("GET", "/v1/event/stream")
+	return e.StreamWithFilter(ctx, topics, index, nil, q)
+}
+
+// StreamWithFilter establishes a new subscription to Nomad's event stream,
+// with the events of the topics filtered by the servers, and streams results
+// back to the returned channel.
+func (e *EventStream) StreamWithFilter(ctx context.Context, topics map[Topic][]string, index uint64, filter *EventStreamFilter, q *QueryOptions) (<-chan *Events, error) {
+	endpoint, err := endpointWithParams("/v1/event/stream", filter)
+	if err != nil {
+		return nil, err
+	}
+	r, err := e.client.newRequest("GET", endpoint)
 	if err != nil {
 		return nil, err
 	}
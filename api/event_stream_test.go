@@ -49,6 +49,47 @@ func TestEvent_Stream(t *testing.T) {
 	}
 }
 
+func TestEvent_StreamWithFilter(t *testing.T) {
+	testutil.Parallel(t)
+
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+
+	// register two jobs to generate events
+	jobs := c.Jobs()
+	job := testJob()
+	job.ID = pointerOf("filtered-out")
+	_, _, err := jobs.Register(job, nil)
+	require.NoError(t, err)
+
+	job2 := testJob()
+	job2.ID = pointerOf("watched")
+	_, _, err = jobs.Register(job2, nil)
+	require.NoError(t, err)
+
+	// build event stream request
+	events := c.EventStream()
+	topics := map[Topic][]string{
+		TopicJob: {"*"},
+	}
+	filter := &EventStreamFilter{JobPrefix: "watch"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	streamCh, err := events.StreamWithFilter(ctx, topics, 0, filter, nil)
+	require.NoError(t, err)
+
+	select {
+	case event := <-streamCh:
+		require.NoError(t, event.Err)
+		require.Len(t, event.Events, 1)
+		require.Equal(t, "watched", event.Events[0].Key)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "failed waiting for event stream event")
+	}
+}
+
 func TestEvent_Stream_Err_InvalidQueryParam(t *testing.T) {
 	testutil.Parallel(t)
 
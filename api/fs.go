@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"path"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -16,6 +18,14 @@ const (
 	// and end of a file.
 	OriginStart = "start"
 	OriginEnd   = "end"
+
+	// LogTypeStdout, LogTypeStderr and LogTypeBoth are the available
+	// parameters for the log type argument when streaming the logs of a task.
+	// LogTypeBoth multiplexes the stdout and stderr logs on a single stream,
+	// and the Stream of each frame tells which of them its data is from.
+	LogTypeStdout = "stdout"
+	LogTypeStderr = "stderr"
+	LogTypeBoth   = "both"
 )
 
 // AllocFileInfo holds information about a file inside the AllocDir
@@ -34,6 +44,12 @@ type StreamFrame struct {
 	Data      []byte `json:",omitempty"`
 	File      string `json:",omitempty"`
 	FileEvent string `json:",omitempty"`
+
+	// Stream is the log stream, stdout or stderr, that the data was read
+	// from, and Timestamp is the time in Unix nanoseconds at which it was
+	// read. They are only set when streaming the logs of a task.
+	Stream    string `json:",omitempty"`
+	Timestamp int64  `json:",omitempty"`
 }
 
 // IsHeartbeat returns if the frame is a heartbeat frame
@@ -41,6 +57,15 @@ func (s *StreamFrame) IsHeartbeat() bool {
 	return len(s.Data) == 0 && s.FileEvent == "" && s.File == "" && s.Offset == 0
 }
 
+// Time returns the time at which the data of the frame was read, or the zero
+// time if it isn't known.
+func (s *StreamFrame) Time() time.Time {
+	if s.Timestamp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, s.Timestamp)
+}
+
 // AllocFS is used to introspect an allocation directory on a Nomad client
 type AllocFS struct {
 	client *Client
@@ -73,7 +98,40 @@ func (a *AllocFS) List(alloc *Allocation, path string, q *QueryOptions) ([]*Allo
 	return resp, qm, nil
 }
 
+// ListRecursive is used to list every file below a given path of an
+// allocation directory. Files are named by their path relative to the given
+// path. If glob is set, only files matching the pattern are returned; patterns
+// without a "/" are matched against the file's base name.
+// Note: for cluster topologies where API consumers don't have network access to
+// Nomad clients, set api.ClientConnTimeout to a small value (ex 1ms) to avoid
+// long pauses on this API call.
+func (a *AllocFS) ListRecursive(alloc *Allocation, path, glob string, q *QueryOptions) ([]*AllocFileInfo, *QueryMeta, error) {
+	if q == nil {
+		q = &QueryOptions{}
+	}
+	if q.Params == nil {
+		q.Params = make(map[string]string)
+	}
+	q.Params["path"] = path
+	q.Params["recursive"] = "true"
+	if glob != "" {
+		q.Params["glob"] = glob
+	}
+
+	var resp []*AllocFileInfo
+	qm, err := a.client.query(fmt.Sprintf("/v1/client/fs/ls/%s", alloc.ID), &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp, qm, nil
+}
+
 // Stat is used to stat a file at a given path of an allocation directory.
+// The returned QueryMeta.LastIndex is the file's modification time in Unix
+// nanoseconds. Passing it back as QueryOptions.WaitIndex blocks the call until
+// the file changes or QueryOptions.WaitTime elapses, which allows following a
+// file such as a rendered template without repeatedly reading it.
 // Note: for cluster topologies where API consumers don't have network access to
 // Nomad clients, set api.ClientConnTimeout to a small value (ex 1ms) to avoid
 // long pauses on this API call.
@@ -230,7 +288,7 @@ func queryClientNode(c *Client, alloc *Allocation, reqPath string, q *QueryOptio
 // * allocation: the allocation to stream from.
 // * follow: Whether the logs should be followed.
 // * task: the tasks name to stream logs for.
-// * logType: Either "stdout" or "stderr"
+// * logType: Either "stdout", "stderr" or "both"
 // * origin: Either "start" or "end" and defines from where the offset is applied.
 // * offset: The offset to start streaming data at.
 // * cancel: A channel that when closed, streaming will end.
@@ -239,7 +297,14 @@ func queryClientNode(c *Client, alloc *Allocation, reqPath string, q *QueryOptio
 // The chan will be closed when follow=false and the end of the file is
 // reached.
 //
-// Unexpected (non-EOF) errors will be sent on the error chan.
+// With the "both" log type, the stdout and stderr logs are multiplexed on a
+// single stream in the order they are read, and the Stream of each frame tells
+// which of them its data is from. The origin and offset apply to each of them.
+//
+// Unexpected (non-EOF) errors will be sent on the error chan. If the
+// connection is lost, the stream is resumed after the last frame for up to
+// api.StreamResumeTimeout before reporting the error. Streams of both log
+// types are resumed after the last frame of each of them.
 //
 // Note: for cluster topologies where API consumers don't have network access to
 // Nomad clients, set api.ClientConnTimeout to a small value (ex 1ms) to avoid
@@ -249,15 +314,7 @@ func (a *AllocFS) Logs(alloc *Allocation, follow bool, task, logType, origin str
 
 	errCh := make(chan error, 1)
 
-	reqPath := fmt.Sprintf("/v1/client/fs/logs/%s", alloc.ID)
-	r, err := queryClientNode(a.client, alloc, reqPath, q,
-		func(q *QueryOptions) {
-			q.Params["follow"] = strconv.FormatBool(follow)
-			q.Params["task"] = task
-			q.Params["type"] = logType
-			q.Params["origin"] = origin
-			q.Params["offset"] = strconv.FormatInt(offset, 10)
-		})
+	r, err := a.logs(alloc, follow, task, logType, origin, offset, q)
 	if err != nil {
 		errCh <- err
 		return nil, errCh
@@ -268,11 +325,18 @@ func (a *AllocFS) Logs(alloc *Allocation, follow bool, task, logType, origin str
 
 	go func() {
 		// Close the body
-		defer r.Close()
+		defer func() { r.Close() }()
 
 		// Create a decoder
 		dec := json.NewDecoder(r)
 
+		// last holds the last frame with data of each log stream, used to
+		// resume the stream after it if the connection is lost.
+		// resumeDeadline is when to give up resuming, set on the first
+		// failure since a frame was received.
+		last := map[string]*StreamFrame{}
+		var resumeDeadline time.Time
+
 		for {
 			// Check if we have been cancelled
 			select {
@@ -287,17 +351,41 @@ func (a *AllocFS) Logs(alloc *Allocation, follow bool, task, logType, origin str
 			if err := dec.Decode(&frame); err != nil {
 				if err == io.EOF || err == io.ErrClosedPipe {
 					close(frames)
-				} else {
+					return
+				}
+				if StreamResumeTimeout <= 0 {
 					errCh <- err
+					return
 				}
-				return
+
+				if resumeDeadline.IsZero() {
+					resumeDeadline = time.Now().Add(StreamResumeTimeout)
+				}
+				nr, rerr := a.resumeLogs(alloc, follow, task, logType, origin,
+					offset, last, resumeDeadline, cancel, q)
+				if rerr != nil {
+					errCh <- err
+					return
+				}
+				r.Close()
+				r = nr
+				dec = json.NewDecoder(r)
+				continue
 			}
+			resumeDeadline = time.Time{}
 
 			// Discard heartbeat frames
 			if frame.IsHeartbeat() {
 				continue
 			}
 
+			if len(frame.Data) != 0 {
+				stream := logType
+				if logType == LogTypeBoth {
+					stream = frame.Stream
+				}
+				last[stream] = &frame
+			}
 			frames <- &frame
 		}
 	}()
@@ -305,6 +393,184 @@ func (a *AllocFS) Logs(alloc *Allocation, follow bool, task, logType, origin str
 	return frames, errCh
 }
 
+// logs opens a stream of the logs of a task.
+func (a *AllocFS) logs(alloc *Allocation, follow bool, task, logType, origin string,
+	offset int64, q *QueryOptions) (io.ReadCloser, error) {
+
+	reqPath := fmt.Sprintf("/v1/client/fs/logs/%s", alloc.ID)
+	return queryClientNode(a.client, alloc, reqPath, q,
+		func(q *QueryOptions) {
+			q.Params["follow"] = strconv.FormatBool(follow)
+			q.Params["task"] = task
+			q.Params["type"] = logType
+			q.Params["origin"] = origin
+			q.Params["offset"] = strconv.FormatInt(offset, 10)
+		})
+}
+
+// resumeLogs reopens a log stream that was interrupted after the last frame of
+// each log stream, retrying with a backoff until the deadline.
+func (a *AllocFS) resumeLogs(alloc *Allocation, follow bool, task, logType, origin string,
+	offset int64, last map[string]*StreamFrame, deadline time.Time, cancel <-chan struct{},
+	q *QueryOptions) (io.ReadCloser, error) {
+
+	backoff := 250 * time.Millisecond
+	for {
+		r, err := a.logsAfter(alloc, follow, task, logType, origin, offset, last, q)
+		if err == nil {
+			return r, nil
+		}
+
+		if time.Now().Add(backoff).After(deadline) {
+			return nil, err
+		}
+		select {
+		case <-cancel:
+			return nil, err
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > streamResumeMaxBackoff {
+			backoff = streamResumeMaxBackoff
+		}
+	}
+}
+
+// logsAfter opens a stream of the logs of a task after the last frame of each
+// log stream, or from the requested origin and offset if no frame was
+// received. The logs endpoint takes a single offset, so once data was received
+// the stdout and stderr logs of a stream of both log types are reopened
+// separately and merged in the order their frames arrive.
+func (a *AllocFS) logsAfter(alloc *Allocation, follow bool, task, logType, origin string,
+	offset int64, last map[string]*StreamFrame, q *QueryOptions) (io.ReadCloser, error) {
+
+	if logType != LogTypeBoth {
+		return a.logAfter(alloc, follow, task, logType, origin, offset, last[logType], q)
+	}
+	if len(last) == 0 {
+		return a.logs(alloc, follow, task, logType, origin, offset, q)
+	}
+
+	var rs []io.ReadCloser
+	for _, stream := range []string{LogTypeStdout, LogTypeStderr} {
+		r, err := a.logAfter(alloc, follow, task, stream, origin, offset, last[stream], q)
+		if err != nil {
+			for _, r := range rs {
+				r.Close()
+			}
+			return nil, err
+		}
+		rs = append(rs, r)
+	}
+	return mergeLogStreams(rs...), nil
+}
+
+// logAfter opens a stream of the logs of a single log type after the last
+// frame, or from the requested origin and offset if it's nil.
+func (a *AllocFS) logAfter(alloc *Allocation, follow bool, task, logType, origin string,
+	offset int64, last *StreamFrame, q *QueryOptions) (io.ReadCloser, error) {
+
+	if last == nil {
+		return a.logs(alloc, follow, task, logType, origin, offset, q)
+	}
+	resumeOffset, err := a.logOffset(alloc, task, logType, last, q)
+	if err != nil {
+		return nil, err
+	}
+	return a.logs(alloc, follow, task, logType, OriginStart, resumeOffset, q)
+}
+
+// mergedLogStreams is the reader returned by mergeLogStreams. Closing it
+// closes the streams it merges.
+type mergedLogStreams struct {
+	*io.PipeReader
+	streams []io.ReadCloser
+}
+
+func (m *mergedLogStreams) Close() error {
+	for _, r := range m.streams {
+		r.Close()
+	}
+	return m.PipeReader.Close()
+}
+
+// mergeLogStreams returns a reader of the frames of the given log streams,
+// encoded in the order they are decoded. It ends once all of them end, or with
+// the first unexpected error of any of them.
+func mergeLogStreams(streams ...io.ReadCloser) io.ReadCloser {
+	pr, pw := io.Pipe()
+
+	var encLock sync.Mutex
+	enc := json.NewEncoder(pw)
+
+	var wg sync.WaitGroup
+	for _, r := range streams {
+		wg.Add(1)
+		go func(r io.Reader) {
+			defer wg.Done()
+			dec := json.NewDecoder(r)
+			for {
+				var frame StreamFrame
+				if err := dec.Decode(&frame); err != nil {
+					if err != io.EOF {
+						pw.CloseWithError(err)
+					}
+					return
+				}
+				encLock.Lock()
+				err := enc.Encode(&frame)
+				encLock.Unlock()
+				if err != nil {
+					return
+				}
+			}
+		}(r)
+	}
+	go func() {
+		wg.Wait()
+		pw.Close()
+	}()
+
+	return &mergedLogStreams{PipeReader: pr, streams: streams}
+}
+
+// logOffset returns the offset right after the given frame, counted from the
+// start of the oldest log file of the task still on the client, as expected
+// by the logs endpoint with the start origin. The offset of a frame is already
+// the offset in its file right after its data.
+func (a *AllocFS) logOffset(alloc *Allocation, task, logType string,
+	frame *StreamFrame, q *QueryOptions) (int64, error) {
+
+	prefix := fmt.Sprintf("%s.%s.", task, logType)
+	frameIdx, err := strconv.Atoi(strings.TrimPrefix(path.Base(frame.File), prefix))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected log file name %q", frame.File)
+	}
+
+	// Don't leak the path parameter of the listing into the logs query
+	lq := &QueryOptions{}
+	if q != nil {
+		*lq = *q
+	}
+	lq.Params = nil
+
+	files, _, err := a.List(alloc, path.Dir(frame.File), lq)
+	if err != nil {
+		return 0, err
+	}
+
+	offset := frame.Offset
+	for _, f := range files {
+		if !strings.HasPrefix(f.Name, prefix) {
+			continue
+		}
+		idx, err := strconv.Atoi(strings.TrimPrefix(f.Name, prefix))
+		if err == nil && idx < frameIdx {
+			offset += f.Size
+		}
+	}
+	return offset, nil
+}
+
 // FrameReader is used to convert a stream of frames into a read closer.
 type FrameReader struct {
 	frames   <-chan *StreamFrame
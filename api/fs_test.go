@@ -2,11 +2,15 @@ package api
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -291,3 +295,160 @@ func TestFS_FrameReader_Error(t *testing.T) {
 		t.Fatalf("bad error: %v", err)
 	}
 }
+
+func TestFS_Logs_Resume(t *testing.T) {
+	testutil.Parallel(t)
+
+	// The first stream is cut after a frame, so the logs are resumed right
+	// after it, counting the rotated log file before the frame's file
+	var offsets []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/client/fs/logs/", func(w http.ResponseWriter, r *http.Request) {
+		offsets = append(offsets, r.URL.Query().Get("origin")+":"+r.URL.Query().Get("offset"))
+		enc := json.NewEncoder(w)
+		if len(offsets) == 1 {
+			require.NoError(t, enc.Encode(&StreamFrame{
+				File:   "alloc/logs/web.stdout.1",
+				Offset: 5,
+				Data:   []byte("hello"),
+			}))
+			w.(http.Flusher).Flush()
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			conn.Close()
+			return
+		}
+		require.NoError(t, enc.Encode(&StreamFrame{
+			File:   "alloc/logs/web.stdout.1",
+			Offset: 11,
+			Data:   []byte(" world"),
+		}))
+	})
+	mux.HandleFunc("/v1/client/fs/ls/", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "alloc/logs", r.URL.Query().Get("path"))
+		require.NoError(t, json.NewEncoder(w).Encode([]*AllocFileInfo{
+			{Name: "web.stdout.0", Size: 100},
+			{Name: "web.stdout.1", Size: 5},
+			{Name: "web.stderr.0", Size: 42},
+		}))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	c, err := NewClient(&Config{Address: ts.URL})
+	require.NoError(t, err)
+
+	cancel := make(chan struct{})
+	defer close(cancel)
+	alloc := &Allocation{ID: "8ba85cef-26cc-40d4-b6d5-3f6f1bd5ec6f"}
+	frames, errCh := c.AllocFS().Logs(alloc, false, "web", "stdout", OriginStart, 0, cancel, nil)
+
+	var result bytes.Buffer
+READ_FRAMES:
+	for {
+		select {
+		case f := <-frames:
+			if f == nil {
+				break READ_FRAMES
+			}
+			result.Write(f.Data)
+		case err := <-errCh:
+			t.Fatalf("unexpected error: %v", err)
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for frames")
+		}
+	}
+
+	require.Equal(t, "hello world", result.String())
+	require.Equal(t, []string{"start:0", "start:105"}, offsets)
+}
+
+func TestFS_Logs_ResumeBoth(t *testing.T) {
+	testutil.Parallel(t)
+
+	// The first stream of both log types is cut after a frame of each of
+	// them, so they are resumed separately right after their last frame
+	var lock sync.Mutex
+	var requests []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/client/fs/logs/", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		lock.Lock()
+		requests = append(requests, query.Get("type")+":"+query.Get("origin")+":"+query.Get("offset"))
+		lock.Unlock()
+
+		enc := json.NewEncoder(w)
+		switch query.Get("type") {
+		case LogTypeBoth:
+			require.NoError(t, enc.Encode(&StreamFrame{
+				File:   "alloc/logs/web.stdout.0",
+				Offset: 5,
+				Data:   []byte("hello"),
+				Stream: LogTypeStdout,
+			}))
+			require.NoError(t, enc.Encode(&StreamFrame{
+				File:   "alloc/logs/web.stderr.0",
+				Offset: 3,
+				Data:   []byte("foo"),
+				Stream: LogTypeStderr,
+			}))
+			w.(http.Flusher).Flush()
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			conn.Close()
+		case LogTypeStdout:
+			require.NoError(t, enc.Encode(&StreamFrame{
+				File:   "alloc/logs/web.stdout.0",
+				Offset: 11,
+				Data:   []byte(" world"),
+				Stream: LogTypeStdout,
+			}))
+		case LogTypeStderr:
+			require.NoError(t, enc.Encode(&StreamFrame{
+				File:   "alloc/logs/web.stderr.0",
+				Offset: 7,
+				Data:   []byte(" bar"),
+				Stream: LogTypeStderr,
+			}))
+		}
+	})
+	mux.HandleFunc("/v1/client/fs/ls/", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewEncoder(w).Encode([]*AllocFileInfo{
+			{Name: "web.stdout.0", Size: 5},
+			{Name: "web.stderr.0", Size: 3},
+		}))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	c, err := NewClient(&Config{Address: ts.URL})
+	require.NoError(t, err)
+
+	cancel := make(chan struct{})
+	defer close(cancel)
+	alloc := &Allocation{ID: "8ba85cef-26cc-40d4-b6d5-3f6f1bd5ec6f"}
+	frames, errCh := c.AllocFS().Logs(alloc, false, "web", LogTypeBoth, OriginEnd, 10, cancel, nil)
+
+	results := map[string]*bytes.Buffer{
+		LogTypeStdout: new(bytes.Buffer),
+		LogTypeStderr: new(bytes.Buffer),
+	}
+READ_FRAMES:
+	for {
+		select {
+		case f := <-frames:
+			if f == nil {
+				break READ_FRAMES
+			}
+			results[f.Stream].Write(f.Data)
+		case err := <-errCh:
+			t.Fatalf("unexpected error: %v", err)
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for frames")
+		}
+	}
+
+	require.Equal(t, "hello world", results[LogTypeStdout].String())
+	require.Equal(t, "foo bar", results[LogTypeStderr].String())
+	require.Equal(t, []string{"both:end:10", "stdout:start:5", "stderr:start:3"}, requests)
+}
@@ -0,0 +1,64 @@
+package api
+
+// The interfaces below are implemented by the handles returned by Client, so
+// that programs using them can be tested with fakes, such as the generated
+// mocks of the apimock package, instead of a Nomad agent. The mocks are
+// generated from this file, so run "go generate ./apimock" after changing it.
+
+// SecureVariablesAPI is the interface of the secure variables handle.
+type SecureVariablesAPI interface {
+	Create(v *SecureVariable, qo *WriteOptions) (*SecureVariable, *WriteMeta, error)
+	CheckedCreate(v *SecureVariable, qo *WriteOptions) (*SecureVariable, *WriteMeta, error)
+	Read(path string, qo *QueryOptions) (*SecureVariable, *QueryMeta, error)
+	Peek(path string, qo *QueryOptions) (*SecureVariable, *QueryMeta, error)
+	Update(v *SecureVariable, qo *WriteOptions) (*SecureVariable, *WriteMeta, error)
+	ForceUpdate(v *SecureVariable, qo *WriteOptions) (*SecureVariable, *WriteMeta, error)
+	CheckedUpdate(v *SecureVariable, qo *WriteOptions) (*SecureVariable, *WriteMeta, error)
+	Delete(path string, qo *WriteOptions) (*WriteMeta, error)
+	ForceDelete(path string, qo *WriteOptions) (*WriteMeta, error)
+	CheckedDelete(path string, checkIndex uint64, qo *WriteOptions) (*WriteMeta, error)
+	List(qo *QueryOptions) ([]*SecureVariableMetadata, *QueryMeta, error)
+	PrefixList(prefix string, qo *QueryOptions) ([]*SecureVariableMetadata, *QueryMeta, error)
+	ListPages(qo *QueryOptions, fn func([]*SecureVariableMetadata, *QueryMeta) bool) error
+	ReadPrefix(prefix string, qo *QueryOptions) ([]*SecureVariable, *QueryMeta, error)
+	ReadPrefixPages(prefix string, qo *QueryOptions, fn func([]*SecureVariable, *QueryMeta) bool) error
+	Search(req *SecureVariablesSearchRequest, qo *QueryOptions) ([]*SecureVariableMetadata, *QueryMeta, error)
+	Purge(req *SecureVariablesPurgeRequest, qo *WriteOptions) ([]string, *WriteMeta, error)
+	Txn(ops []*SecureVariablesTxnOp, qo *WriteOptions) (*SecureVariablesTxnResponse, *WriteMeta, error)
+	ExchangeIdentity(qo *WriteOptions) (*SecureVariablesIdentityToken, *WriteMeta, error)
+	GetItems(path string, qo *QueryOptions) (*SecureVariableItems, *QueryMeta, error)
+	ReplicationStatus(qo *QueryOptions) ([]*SecureVariablesReplicationStatus, *QueryMeta, error)
+}
+
+// KeyringAPI is the interface of the keyring handle.
+type KeyringAPI interface {
+	List(q *QueryOptions) ([]*RootKeyMeta, *QueryMeta, error)
+	Info(keyID string, q *QueryOptions) (*RootKeyMeta, *QueryMeta, error)
+	Delete(opts *KeyringDeleteOptions, w *WriteOptions) (*WriteMeta, error)
+	Update(key *RootKey, w *WriteOptions) (*WriteMeta, error)
+	Rotate(opts *KeyringRotateOptions, w *WriteOptions) (*RootKeyMeta, *WriteMeta, error)
+	Export(opts *KeyringExportOptions, w *WriteOptions) (*RootKeyBundle, *WriteMeta, error)
+	Import(req *KeyringImportRequest, w *WriteOptions) (*RootKeyMeta, *WriteMeta, error)
+	Verify(q *QueryOptions) (*KeyringVerifyResponse, *QueryMeta, error)
+	Health(q *QueryOptions) (*KeyringHealthResponse, *QueryMeta, error)
+	ListSigningKeys(q *QueryOptions) ([]*JobSigningKey, *QueryMeta, error)
+	UpsertSigningKey(key *JobSigningKey, w *WriteOptions) (*JobSigningKey, *WriteMeta, error)
+	DeleteSigningKey(keyID string, w *WriteOptions) (*WriteMeta, error)
+}
+
+// EvaluationsAPI is the interface of the evaluations handle.
+type EvaluationsAPI interface {
+	List(q *QueryOptions) ([]*Evaluation, *QueryMeta, error)
+	PrefixList(prefix string) ([]*Evaluation, *QueryMeta, error)
+	ListPages(q *QueryOptions, fn func([]*Evaluation, *QueryMeta) bool) error
+	Info(evalID string, q *QueryOptions) (*Evaluation, *QueryMeta, error)
+	Delete(evalIDs []string, w *WriteOptions) (*WriteMeta, error)
+	Allocations(evalID string, q *QueryOptions) ([]*AllocationListStub, *QueryMeta, error)
+	Explanation(evalID string, q *QueryOptions) (*EvalExplanation, *QueryMeta, error)
+}
+
+var (
+	_ SecureVariablesAPI = (*SecureVariables)(nil)
+	_ KeyringAPI         = (*Keyring)(nil)
+	_ EvaluationsAPI     = (*Evaluations)(nil)
+)
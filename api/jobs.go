@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
@@ -116,6 +117,19 @@ type RegisterOptions struct {
 	PolicyOverride bool
 	PreserveCounts bool
 	EvalPriority   int
+
+	// Signature is the optional signature of the job by a job signing key
+	// trusted by the servers.
+	Signature *JobSignature
+}
+
+// JobSignature is the ed25519 signature of a job submission. The signed
+// payload is the canonical encoding of the job computed by the servers, so
+// signatures are created by the Nomad CLI, for example with the -sign option
+// of the job run command.
+type JobSignature struct {
+	KeyID     string
+	Signature []byte
 }
 
 // Register is used to register a new job. It returns the ID
@@ -145,6 +159,7 @@ func (j *Jobs) RegisterOpts(job *Job, opts *RegisterOptions, q *WriteOptions) (*
 		req.PolicyOverride = opts.PolicyOverride
 		req.PreserveCounts = opts.PreserveCounts
 		req.EvalPriority = opts.EvalPriority
+		req.Signature = opts.Signature
 	}
 
 	var resp JobRegisterResponse
@@ -171,6 +186,14 @@ func (j *Jobs) PrefixList(prefix string) ([]*JobListStub, *QueryMeta, error) {
 	return j.List(&QueryOptions{Prefix: prefix})
 }
 
+// ListPages is used to list all of the existing jobs, one page at a time. It
+// follows the NextToken of each response and calls fn with each page until
+// the last page is reached or fn returns false. The page size is set with
+// QueryOptions.PerPage.
+func (j *Jobs) ListPages(q *QueryOptions, fn func([]*JobListStub, *QueryMeta) bool) error {
+	return listPages(q, j.List, fn)
+}
+
 // Info is used to retrieve information about a particular
 // job given its unique ID.
 func (j *Jobs) Info(jobID string, q *QueryOptions) (*Job, *QueryMeta, error) {
@@ -209,6 +232,35 @@ func (j *Jobs) Scale(jobID, group string, count *int, message string, error bool
 	return &resp, qm, nil
 }
 
+// ScaleGroups is used to set the count of several task groups of a job in a
+// single job update. A scaling event is recorded for each group. If
+// jobModifyIndex is non-zero the job is only scaled if it hasn't been
+// modified since that index.
+func (j *Jobs) ScaleGroups(jobID string, counts map[string]int, jobModifyIndex uint64, message string,
+	meta map[string]interface{}, q *WriteOptions) (*JobRegisterResponse, *WriteMeta, error) {
+
+	groups := make(map[string]int64, len(counts))
+	for group, count := range counts {
+		groups[group] = int64(count)
+	}
+	req := &ScalingRequest{
+		Target: map[string]string{
+			"Job": jobID,
+		},
+		Groups:         groups,
+		EnforceIndex:   jobModifyIndex != 0,
+		JobModifyIndex: jobModifyIndex,
+		Message:        message,
+		Meta:           meta,
+	}
+	var resp JobRegisterResponse
+	qm, err := j.client.write(fmt.Sprintf("/v1/job/%s/scale", url.PathEscape(jobID)), req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // ScaleStatus is used to retrieve information about a particular
 // job given its unique ID.
 func (j *Jobs) ScaleStatus(jobID string, q *QueryOptions) (*JobScaleStatusResponse, *QueryMeta, error) {
@@ -224,7 +276,10 @@ func (j *Jobs) ScaleStatus(jobID string, q *QueryOptions) (*JobScaleStatusRespon
 // unique ID.
 func (j *Jobs) Versions(jobID string, diffs bool, q *QueryOptions) ([]*Job, []*JobDiff, *QueryMeta, error) {
 	var resp JobVersionsResponse
-	qm, err := j.client.query(fmt.Sprintf("/v1/job/%s/versions?diffs=%v", url.PathEscape(jobID), diffs), &resp, q)
+	endpoint := newQueryParams().
+		set("diffs", strconv.FormatBool(diffs)).
+		endpoint("/v1/job/" + url.PathEscape(jobID) + "/versions")
+	qm, err := j.client.query(endpoint, &resp, q)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -234,16 +289,10 @@ func (j *Jobs) Versions(jobID string, diffs bool, q *QueryOptions) ([]*Job, []*J
 // Allocations is used to return the allocs for a given job ID.
 func (j *Jobs) Allocations(jobID string, allAllocs bool, q *QueryOptions) ([]*AllocationListStub, *QueryMeta, error) {
 	var resp []*AllocationListStub
-	u, err := url.Parse("/v1/job/" + url.PathEscape(jobID) + "/allocations")
-	if err != nil {
-		return nil, nil, err
-	}
-
-	v := u.Query()
-	v.Add("all", strconv.FormatBool(allAllocs))
-	u.RawQuery = v.Encode()
-
-	qm, err := j.client.query(u.String(), &resp, q)
+	endpoint := newQueryParams().
+		set("all", strconv.FormatBool(allAllocs)).
+		endpoint("/v1/job/" + url.PathEscape(jobID) + "/allocations")
+	qm, err := j.client.query(endpoint, &resp, q)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -255,15 +304,10 @@ func (j *Jobs) Allocations(jobID string, allAllocs bool, q *QueryOptions) ([]*Al
 // ID.
 func (j *Jobs) Deployments(jobID string, all bool, q *QueryOptions) ([]*Deployment, *QueryMeta, error) {
 	var resp []*Deployment
-	u, err := url.Parse("/v1/job/" + url.PathEscape(jobID) + "/deployments")
-	if err != nil {
-		return nil, nil, err
-	}
-
-	v := u.Query()
-	v.Add("all", strconv.FormatBool(all))
-	u.RawQuery = v.Encode()
-	qm, err := j.client.query(u.String(), &resp, q)
+	endpoint := newQueryParams().
+		set("all", strconv.FormatBool(all)).
+		endpoint("/v1/job/" + url.PathEscape(jobID) + "/deployments")
+	qm, err := j.client.query(endpoint, &resp, q)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -299,7 +343,10 @@ func (j *Jobs) Evaluations(jobID string, q *QueryOptions) ([]*Evaluation, *Query
 // eventually GC'ed from the system. Most callers should not specify purge.
 func (j *Jobs) Deregister(jobID string, purge bool, q *WriteOptions) (string, *WriteMeta, error) {
 	var resp JobDeregisterResponse
-	wm, err := j.client.delete(fmt.Sprintf("/v1/job/%v?purge=%t", url.PathEscape(jobID), purge), nil, &resp, q)
+	endpoint := newQueryParams().
+		set("purge", strconv.FormatBool(purge)).
+		endpoint("/v1/job/" + url.PathEscape(jobID))
+	wm, err := j.client.delete(endpoint, nil, &resp, q)
 	if err != nil {
 		return "", nil, err
 	}
@@ -330,19 +377,35 @@ type DeregisterOptions struct {
 	NoShutdownDelay bool
 }
 
+// Validate checks that the evaluation priority, if set, is within range.
+func (o *DeregisterOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+	if o.EvalPriority != 0 && (o.EvalPriority < 1 || o.EvalPriority > 100) {
+		return fmt.Errorf("eval priority must be between 1 and 100 inclusively, got %d", o.EvalPriority)
+	}
+	return nil
+}
+
+func (o *DeregisterOptions) encodeParams(q *queryParams) {
+	if o == nil {
+		return
+	}
+	q.set("purge", strconv.FormatBool(o.Purge)).
+		set("global", strconv.FormatBool(o.Global)).
+		set("eval_priority", strconv.Itoa(o.EvalPriority)).
+		set("no_shutdown_delay", strconv.FormatBool(o.NoShutdownDelay))
+}
+
 // DeregisterOpts is used to remove an existing job. See DeregisterOptions
 // for parameters.
 func (j *Jobs) DeregisterOpts(jobID string, opts *DeregisterOptions, q *WriteOptions) (string, *WriteMeta, error) {
 	var resp JobDeregisterResponse
 
-	// The base endpoint to add query params to.
-	endpoint := "/v1/job/" + url.PathEscape(jobID)
-
-	// Protect against nil opts. url.Values expects a string, and so using
-	// fmt.Sprintf is the best way to do this.
-	if opts != nil {
-		endpoint += fmt.Sprintf("?purge=%t&global=%t&eval_priority=%v&no_shutdown_delay=%t",
-			opts.Purge, opts.Global, opts.EvalPriority, opts.NoShutdownDelay)
+	endpoint, err := endpointWithParams("/v1/job/"+url.PathEscape(jobID), opts)
+	if err != nil {
+		return "", nil, err
 	}
 
 	wm, err := j.client.delete(endpoint, nil, &resp, q)
@@ -466,6 +529,71 @@ func (j *Jobs) Revert(jobID string, version uint64, enforcePriorVersion *uint64,
 	return &resp, wm, nil
 }
 
+// Patch is used to update a job by applying a JSON merge patch and a list of
+// operations to its current version. Either may be empty.
+func (j *Jobs) Patch(jobID string, mergePatch map[string]interface{}, ops []*JobPatchOp,
+	enforcePriorVersion *uint64, q *WriteOptions) (*JobRegisterResponse, *WriteMeta, error) {
+
+	var resp JobRegisterResponse
+	req := &JobPatchRequest{
+		JobID:               jobID,
+		MergePatch:          mergePatch,
+		Ops:                 ops,
+		EnforcePriorVersion: enforcePriorVersion,
+	}
+	wm, err := j.client.write("/v1/job/"+url.PathEscape(jobID)+"/patch", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// Restart is used to start a rolling restart of the running allocations of a
+// job. The servers restart the allocations in batches, so the restart
+// carries on if the caller goes away.
+func (j *Jobs) Restart(jobID string, batchSize int, batchWait time.Duration, onError string,
+	q *WriteOptions) (*JobRestart, *WriteMeta, error) {
+
+	var resp JobRestart
+	req := &JobRestartRequest{
+		JobID:     jobID,
+		BatchSize: batchSize,
+		BatchWait: batchWait,
+		OnError:   onError,
+	}
+	wm, err := j.client.write("/v1/job/"+url.PathEscape(jobID)+"/restart", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// RestartInfo is used to read the latest restart of a job.
+func (j *Jobs) RestartInfo(jobID string, q *QueryOptions) (*JobRestart, *QueryMeta, error) {
+	var resp JobRestart
+	qm, err := j.client.query("/v1/job/"+url.PathEscape(jobID)+"/restart", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// UpdateRestart is used to pause, resume or cancel the active restart of a
+// job, by setting its status to JobRestartStatusPaused,
+// JobRestartStatusRunning or JobRestartStatusCancelled.
+func (j *Jobs) UpdateRestart(jobID, status string, q *WriteOptions) (*JobRestart, *WriteMeta, error) {
+	var resp JobRestart
+	req := &JobRestartUpdateRequest{
+		JobID:  jobID,
+		Status: status,
+	}
+	wm, err := j.client.write("/v1/job/"+url.PathEscape(jobID)+"/restart/status", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
 // Stable is used to mark a job version's stability.
 func (j *Jobs) Stable(jobID string, version uint64, stable bool,
 	q *WriteOptions) (*JobStabilityResponse, *WriteMeta, error) {
@@ -507,6 +635,8 @@ type UpdateStrategy struct {
 	Canary           *int           `mapstructure:"canary" hcl:"canary,optional"`
 	AutoRevert       *bool          `mapstructure:"auto_revert" hcl:"auto_revert,optional"`
 	AutoPromote      *bool          `mapstructure:"auto_promote" hcl:"auto_promote,optional"`
+
+	CanaryDistinctHosts *bool `mapstructure:"canary_distinct_hosts" hcl:"canary_distinct_hosts,optional"`
 }
 
 // DefaultUpdateStrategy provides a baseline that can be used to upgrade
@@ -568,6 +698,10 @@ func (u *UpdateStrategy) Copy() *UpdateStrategy {
 		copy.AutoPromote = pointerOf(*u.AutoPromote)
 	}
 
+	if u.CanaryDistinctHosts != nil {
+		copy.CanaryDistinctHosts = pointerOf(*u.CanaryDistinctHosts)
+	}
+
 	return copy
 }
 
@@ -611,6 +745,10 @@ func (u *UpdateStrategy) Merge(o *UpdateStrategy) {
 	if o.AutoPromote != nil {
 		u.AutoPromote = pointerOf(*o.AutoPromote)
 	}
+
+	if o.CanaryDistinctHosts != nil {
+		u.CanaryDistinctHosts = pointerOf(*o.CanaryDistinctHosts)
+	}
 }
 
 func (u *UpdateStrategy) Canonicalize() {
@@ -691,6 +829,10 @@ func (u *UpdateStrategy) Empty() bool {
 		return false
 	}
 
+	if u.CanaryDistinctHosts != nil && *u.CanaryDistinctHosts {
+		return false
+	}
+
 	if u.Canary != nil && *u.Canary != 0 {
 		return false
 	}
@@ -841,6 +983,50 @@ type ParameterizedJobConfig struct {
 	MetaOptional []string `mapstructure:"meta_optional" hcl:"meta_optional,optional"`
 }
 
+// DisconnectStrategy limits how allocations of the job's groups behave
+// while their clients are disconnected.
+type DisconnectStrategy struct {
+	// MaxUnknown is the number of allocations of each group that may be
+	// in the unknown state at the same time. Allocations beyond it are
+	// replaced as if they were lost.
+	MaxUnknown *int `mapstructure:"max_unknown" hcl:"max_unknown,optional"`
+}
+
+func (d *DisconnectStrategy) Canonicalize() {
+	if d.MaxUnknown == nil {
+		d.MaxUnknown = pointerOf(0)
+	}
+}
+
+// DisconnectHook is an action the client takes for each allocation of the
+// job when it loses or regains contact with the servers. Exactly one of
+// Signal, Command, or Webhook must be set.
+type DisconnectHook struct {
+	// Task is the task the action applies to. Signals are sent to every task
+	// of the allocation when it is empty.
+	Task string `hcl:"task,optional"`
+
+	// Signal is sent to the task.
+	Signal string `hcl:"signal,optional"`
+
+	// Command is run with Args inside the task.
+	Command string   `hcl:"command,optional"`
+	Args    []string `hcl:"args,optional"`
+
+	// Webhook is a URL the client sends a POST request describing the event
+	// to.
+	Webhook string `hcl:"webhook,optional"`
+
+	// Timeout bounds how long the command or webhook may take.
+	Timeout *time.Duration `mapstructure:"timeout" hcl:"timeout,optional"`
+}
+
+func (h *DisconnectHook) Canonicalize() {
+	if h.Timeout == nil {
+		h.Timeout = pointerOf(30 * time.Second)
+	}
+}
+
 // Job is used to serialize a job.
 type Job struct {
 	/* Fields parsed from HCL config */
@@ -863,6 +1049,9 @@ type Job struct {
 	ParameterizedJob *ParameterizedJobConfig `hcl:"parameterized,block"`
 	Reschedule       *ReschedulePolicy       `hcl:"reschedule,block"`
 	Migrate          *MigrateStrategy        `hcl:"migrate,block"`
+	Disconnect       *DisconnectStrategy     `hcl:"disconnect,block"`
+	OnDisconnect     []*DisconnectHook       `hcl:"on_disconnect,block"`
+	OnReconnect      []*DisconnectHook       `hcl:"on_reconnect,block"`
 	Meta             map[string]string       `hcl:"meta,block"`
 	ConsulToken      *string                 `mapstructure:"consul_token" hcl:"consul_token,optional"`
 	VaultToken       *string                 `mapstructure:"vault_token" hcl:"vault_token,optional"`
@@ -877,16 +1066,43 @@ type Job struct {
 	ConsulNamespace          *string `mapstructure:"consul_namespace"`
 	VaultNamespace           *string `mapstructure:"vault_namespace"`
 	NomadTokenID             *string `mapstructure:"nomad_token_id"`
+	SigningKeyID             *string `mapstructure:"signing_key_id"`
 	Status                   *string
 	StatusDescription        *string
 	Stable                   *bool
 	Version                  *uint64
-	SubmitTime               *int64
+	SubmitTime               *time.Time
 	CreateIndex              *uint64
 	ModifyIndex              *uint64
 	JobModifyIndex           *uint64
 }
 
+func (j *Job) MarshalJSON() ([]byte, error) {
+	type Alias Job
+	return json.Marshal(&struct {
+		SubmitTime *unixNanos
+		*Alias
+	}{
+		SubmitTime: (*unixNanos)(j.SubmitTime),
+		Alias:      (*Alias)(j),
+	})
+}
+
+func (j *Job) UnmarshalJSON(data []byte) error {
+	type Alias Job
+	aux := &struct {
+		SubmitTime *unixNanos
+		*Alias
+	}{
+		Alias: (*Alias)(j),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	j.SubmitTime = (*time.Time)(aux.SubmitTime)
+	return nil
+}
+
 // IsPeriodic returns whether a job is periodic.
 func (j *Job) IsPeriodic() bool {
 	return j.Periodic != nil
@@ -980,6 +1196,15 @@ func (j *Job) Canonicalize() {
 	if j.Multiregion != nil {
 		j.Multiregion.Canonicalize()
 	}
+	if j.Disconnect != nil {
+		j.Disconnect.Canonicalize()
+	}
+	for _, h := range j.OnDisconnect {
+		h.Canonicalize()
+	}
+	for _, h := range j.OnReconnect {
+		h.Canonicalize()
+	}
 
 	for _, tg := range j.TaskGroups {
 		tg.Canonicalize(j)
@@ -1061,7 +1286,33 @@ type JobListStub struct {
 	CreateIndex       uint64
 	ModifyIndex       uint64
 	JobModifyIndex    uint64
-	SubmitTime        int64
+	SubmitTime        time.Time
+}
+
+func (j *JobListStub) MarshalJSON() ([]byte, error) {
+	type Alias JobListStub
+	return json.Marshal(&struct {
+		SubmitTime unixNanos
+		*Alias
+	}{
+		SubmitTime: unixNanos(j.SubmitTime),
+		Alias:      (*Alias)(j),
+	})
+}
+
+func (j *JobListStub) UnmarshalJSON(data []byte) error {
+	type Alias JobListStub
+	aux := &struct {
+		SubmitTime unixNanos
+		*Alias
+	}{
+		Alias: (*Alias)(j),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	j.SubmitTime = time.Time(aux.SubmitTime)
+	return nil
 }
 
 // JobIDSort is used to sort jobs by their job ID's.
@@ -1223,6 +1474,93 @@ type JobRevertRequest struct {
 	WriteRequest
 }
 
+// JobPatchOp is a single operation of a job patch. Operations follow the
+// semantics of JSON Patch (RFC 6902) and address fields of the job with a
+// JSON pointer, such as "/TaskGroups/0/Count".
+type JobPatchOp struct {
+	// Op is one of "add", "remove", "replace" or "test".
+	Op   string
+	Path string
+
+	// Value is the value of add, replace and test operations.
+	Value interface{} `json:",omitempty"`
+}
+
+// JobPatchRequest is used to update a job by applying a JSON merge patch
+// (RFC 7396) followed by a list of operations to its current version.
+type JobPatchRequest struct {
+	JobID      string
+	MergePatch map[string]interface{} `json:",omitempty"`
+	Ops        []*JobPatchOp          `json:",omitempty"`
+
+	// EnforcePriorVersion if set will enforce that the job is at the given
+	// version before patching.
+	EnforcePriorVersion *uint64
+
+	// ConsulToken and VaultToken are only used to register the patched job
+	// and are not stored.
+	ConsulToken string `json:",omitempty"`
+	VaultToken  string `json:",omitempty"`
+
+	WriteRequest
+}
+
+const (
+	JobRestartStatusRunning   = "running"
+	JobRestartStatusPaused    = "paused"
+	JobRestartStatusComplete  = "complete"
+	JobRestartStatusFailed    = "failed"
+	JobRestartStatusCancelled = "cancelled"
+
+	JobRestartOnErrorPause = "pause"
+	JobRestartOnErrorFail  = "fail"
+)
+
+// JobRestart is a rolling restart of the running allocations of a job.
+type JobRestart struct {
+	ID        string
+	Namespace string
+	JobID     string
+	BatchSize int
+	BatchWait time.Duration
+	OnError   string
+
+	// AllocIDs are the allocations to restart, in order, of which the first
+	// Restarted have been restarted.
+	AllocIDs  []string
+	Restarted int
+
+	// NextBatch is the earliest time the next batch may be restarted
+	NextBatch time.Time
+
+	Status            string
+	StatusDescription string
+
+	CreateIndex uint64
+	ModifyIndex uint64
+	CreateTime  int64
+	ModifyTime  int64
+}
+
+// JobRestartRequest is used to start a rolling restart of a job.
+type JobRestartRequest struct {
+	JobID     string
+	BatchSize int
+	BatchWait time.Duration
+	OnError   string
+
+	WriteRequest
+}
+
+// JobRestartUpdateRequest is used to pause, resume or cancel the restart of
+// a job.
+type JobRestartUpdateRequest struct {
+	JobID  string
+	Status string
+
+	WriteRequest
+}
+
 // JobRegisterRequest is used to update a job
 type JobRegisterRequest struct {
 	Job *Job
@@ -1242,6 +1580,10 @@ type JobRegisterRequest struct {
 	// change the job priority which also impacts preemption.
 	EvalPriority int `json:",omitempty"`
 
+	// Signature is the optional signature of the job by a job signing key
+	// trusted by the servers.
+	Signature *JobSignature `json:",omitempty"`
+
 	WriteRequest
 }
 
@@ -1339,6 +1681,8 @@ type DesiredUpdates struct {
 	DestructiveUpdate uint64
 	Canary            uint64
 	Preemptions       uint64
+	Datacenters       map[string]uint64
+	ReplaceUnknown    uint64
 }
 
 type JobDispatchRequest struct {
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"fmt"
 	"reflect"
 	"sort"
 	"strings"
@@ -769,6 +770,9 @@ func TestJobs_Canonicalize(t *testing.T) {
 										RightDelim:   pointerOf("}}"),
 										Envvars:      pointerOf(false),
 										VaultGrace:   pointerOf(time.Duration(0)),
+										MaxStale:     pointerOf(time.Duration(0)),
+										StaleMode:    pointerOf("fail"),
+										StaleSignal:  pointerOf(""),
 									},
 									{
 										SourcePath:   pointerOf(""),
@@ -782,6 +786,9 @@ func TestJobs_Canonicalize(t *testing.T) {
 										RightDelim:   pointerOf("}}"),
 										Envvars:      pointerOf(true),
 										VaultGrace:   pointerOf(time.Duration(0)),
+										MaxStale:     pointerOf(time.Duration(0)),
+										StaleMode:    pointerOf("fail"),
+										StaleSignal:  pointerOf(""),
 									},
 								},
 							},
@@ -1523,6 +1530,34 @@ func TestJobs_PrefixList(t *testing.T) {
 	}
 }
 
+func TestJobs_ListPages(t *testing.T) {
+	testutil.Parallel(t)
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	jobs := c.Jobs()
+
+	for i := 0; i < 5; i++ {
+		job := testJob()
+		job.ID = pointerOf(fmt.Sprintf("job-%d", i))
+		_, _, err := jobs.Register(job, nil)
+		require.NoError(t, err)
+	}
+
+	var ids []string
+	var pages int
+	err := jobs.ListPages(&QueryOptions{PerPage: 2}, func(page []*JobListStub, _ *QueryMeta) bool {
+		require.LessOrEqual(t, len(page), 2)
+		for _, job := range page {
+			ids = append(ids, job.ID)
+		}
+		pages++
+		return true
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, pages)
+	require.Equal(t, []string{"job-0", "job-1", "job-2", "job-3", "job-4"}, ids)
+}
+
 func TestJobs_List(t *testing.T) {
 	testutil.Parallel(t)
 	c, s := makeClient(t, nil, nil)
@@ -2225,7 +2260,7 @@ func TestJobs_ScaleAction(t *testing.T) {
 	require.Equal(map[string]interface{}{
 		"meta": "data",
 	}, scalingEvent.Meta)
-	require.Greater(scalingEvent.Time, uint64(0))
+	require.False(scalingEvent.Time.IsZero())
 	require.NotNil(scalingEvent.EvalID)
 	require.Equal(scalingResp.EvalID, *scalingEvent.EvalID)
 	require.Equal(int64(origCount), scalingEvent.PreviousCount)
@@ -2279,7 +2314,7 @@ func TestJobs_ScaleAction_Error(t *testing.T) {
 	require.Equal(map[string]interface{}{
 		"meta": "data",
 	}, errEvent.Meta)
-	require.Greater(errEvent.Time, uint64(0))
+	require.False(errEvent.Time.IsZero())
 	require.Nil(errEvent.EvalID)
 }
 
@@ -2331,7 +2366,7 @@ func TestJobs_ScaleAction_Noop(t *testing.T) {
 	require.Equal(map[string]interface{}{
 		"meta": "data",
 	}, noopEvent.Meta)
-	require.Greater(noopEvent.Time, uint64(0))
+	require.False(noopEvent.Time.IsZero())
 	require.Nil(noopEvent.EvalID)
 }
 
@@ -1,8 +1,11 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
+	"strconv"
+	"time"
 )
 
 // Keyring is used to access the Secure Variables keyring
@@ -34,20 +37,87 @@ type RootKey struct {
 type RootKeyMeta struct {
 	KeyID       string // UUID
 	Algorithm   EncryptionAlgorithm
-	CreateTime  int64
+	CreateTime  time.Time
 	CreateIndex uint64
 	ModifyIndex uint64
 	State       RootKeyState
+	PublishTime time.Time
+	Usage       *RootKeyUsage `json:",omitempty"`
+}
+
+func (r *RootKeyMeta) MarshalJSON() ([]byte, error) {
+	type Alias RootKeyMeta
+	return json.Marshal(&struct {
+		CreateTime  unixNanos
+		PublishTime unixNanos
+		*Alias
+	}{
+		CreateTime:  unixNanos(r.CreateTime),
+		PublishTime: unixNanos(r.PublishTime),
+		Alias:       (*Alias)(r),
+	})
+}
+
+func (r *RootKeyMeta) UnmarshalJSON(data []byte) error {
+	type Alias RootKeyMeta
+	aux := &struct {
+		CreateTime  unixNanos
+		PublishTime unixNanos
+		*Alias
+	}{
+		Alias: (*Alias)(r),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.CreateTime = time.Time(aux.CreateTime)
+	r.PublishTime = time.Time(aux.PublishTime)
+	return nil
+}
+
+// RootKeyUsage describes how much a root key is still in use. The signing
+// and last used counters are tracked by the leader since it was elected.
+type RootKeyUsage struct {
+	Variables      int
+	SignOperations uint64
+	LastUsed       time.Time
+}
+
+func (u *RootKeyUsage) MarshalJSON() ([]byte, error) {
+	type Alias RootKeyUsage
+	return json.Marshal(&struct {
+		LastUsed unixNanos
+		*Alias
+	}{
+		LastUsed: unixNanos(u.LastUsed),
+		Alias:    (*Alias)(u),
+	})
+}
+
+func (u *RootKeyUsage) UnmarshalJSON(data []byte) error {
+	type Alias RootKeyUsage
+	aux := &struct {
+		LastUsed unixNanos
+		*Alias
+	}{
+		Alias: (*Alias)(u),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	u.LastUsed = time.Time(aux.LastUsed)
+	return nil
 }
 
 // RootKeyState enum describes the lifecycle of a root key.
 type RootKeyState string
 
 const (
-	RootKeyStateInactive   RootKeyState = "inactive"
-	RootKeyStateActive                  = "active"
-	RootKeyStateRekeying                = "rekeying"
-	RootKeyStateDeprecated              = "deprecated"
+	RootKeyStateInactive     RootKeyState = "inactive"
+	RootKeyStateActive                    = "active"
+	RootKeyStateRekeying                  = "rekeying"
+	RootKeyStateDeprecated                = "deprecated"
+	RootKeyStatePrepublished              = "prepublished"
 )
 
 // List lists all the keyring metadata
@@ -60,6 +130,19 @@ func (k *Keyring) List(q *QueryOptions) ([]*RootKeyMeta, *QueryMeta, error) {
 	return resp, qm, nil
 }
 
+// Info returns the metadata of a specific key of the keyring. Setting the
+// WaitIndex of the query options blocks until the key is modified, which lets
+// callers wait for its state to change.
+func (k *Keyring) Info(keyID string, q *QueryOptions) (*RootKeyMeta, *QueryMeta, error) {
+	var resp RootKeyMeta
+	qm, err := k.client.query(fmt.Sprintf("/v1/operator/keyring/key/%v",
+		url.PathEscape(keyID)), &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // Delete deletes a specific inactive key from the keyring
 func (k *Keyring) Delete(opts *KeyringDeleteOptions, w *WriteOptions) (*WriteMeta, error) {
 	wm, err := k.client.delete(fmt.Sprintf("/v1/operator/keyring/key/%v",
@@ -80,17 +163,12 @@ func (k *Keyring) Update(key *RootKey, w *WriteOptions) (*WriteMeta, error) {
 
 // Rotate requests a key rotation
 func (k *Keyring) Rotate(opts *KeyringRotateOptions, w *WriteOptions) (*RootKeyMeta, *WriteMeta, error) {
-	qp := url.Values{}
-	if opts != nil {
-		if opts.Algorithm != "" {
-			qp.Set("algo", string(opts.Algorithm))
-		}
-		if opts.Full {
-			qp.Set("full", "true")
-		}
+	endpoint, err := endpointWithParams("/v1/operator/keyring/rotate", opts)
+	if err != nil {
+		return nil, nil, err
 	}
 	resp := &struct{ Key *RootKeyMeta }{}
-	wm, err := k.client.write("/v1/operator/keyring/rotate?"+qp.Encode(), nil, resp, w)
+	wm, err := k.client.write(endpoint, nil, resp, w)
 	return resp.Key, wm, err
 }
 
@@ -98,4 +176,256 @@ func (k *Keyring) Rotate(opts *KeyringRotateOptions, w *WriteOptions) (*RootKeyM
 type KeyringRotateOptions struct {
 	Full      bool
 	Algorithm EncryptionAlgorithm
+
+	// PublishTime schedules the rotation. The new key is prepublished to
+	// all servers and becomes the active key at this time.
+	PublishTime time.Time
+}
+
+// Validate checks that the algorithm, if set, is supported, and that a
+// scheduled rotation isn't a full rotation.
+func (o *KeyringRotateOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+	if o.Full && !o.PublishTime.IsZero() {
+		return fmt.Errorf("a full rotation cannot be scheduled")
+	}
+	switch o.Algorithm {
+	case "", EncryptionAlgorithmAES256GCM:
+		return nil
+	default:
+		return fmt.Errorf("unsupported encryption algorithm %q", o.Algorithm)
+	}
+}
+
+func (o *KeyringRotateOptions) encodeParams(q *queryParams) {
+	if o == nil {
+		return
+	}
+	q.setString("algo", string(o.Algorithm)).setBool("full", o.Full)
+	if !o.PublishTime.IsZero() {
+		q.set("publish_time", strconv.FormatInt(o.PublishTime.UnixNano(), 10))
+	}
+}
+
+// RootKeyBundle is a root key sealed with a passphrase, as returned by
+// Export. It should be stored as-is and passed back to Import.
+type RootKeyBundle struct {
+	KeyID      string
+	Algorithm  EncryptionAlgorithm
+	CreateTime time.Time
+	KDF        string
+	Salt       []byte
+	Ciphertext []byte
+}
+
+func (b *RootKeyBundle) MarshalJSON() ([]byte, error) {
+	type Alias RootKeyBundle
+	return json.Marshal(&struct {
+		CreateTime unixNanos
+		*Alias
+	}{
+		CreateTime: unixNanos(b.CreateTime),
+		Alias:      (*Alias)(b),
+	})
+}
+
+func (b *RootKeyBundle) UnmarshalJSON(data []byte) error {
+	type Alias RootKeyBundle
+	aux := &struct {
+		CreateTime unixNanos
+		*Alias
+	}{
+		Alias: (*Alias)(b),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	b.CreateTime = time.Time(aux.CreateTime)
+	return nil
+}
+
+// Export returns a key from the keyring sealed with the passphrase
+func (k *Keyring) Export(opts *KeyringExportOptions, w *WriteOptions) (*RootKeyBundle, *WriteMeta, error) {
+	var resp RootKeyBundle
+	wm, err := k.client.write("/v1/operator/keyring/export", opts, &resp, w)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// KeyringExportOptions are parameters for the Export API
+type KeyringExportOptions struct {
+	KeyID      string // UUID
+	Passphrase string
+}
+
+// Import installs a key from a bundle returned by Export
+func (k *Keyring) Import(req *KeyringImportRequest, w *WriteOptions) (*RootKeyMeta, *WriteMeta, error) {
+	resp := &struct{ Key *RootKeyMeta }{}
+	wm, err := k.client.write("/v1/operator/keyring/import", req, resp, w)
+	return resp.Key, wm, err
+}
+
+// KeyringImportRequest is the body of the Import API
+type KeyringImportRequest struct {
+	Bundle     *RootKeyBundle
+	Passphrase string
+}
+
+// Verify checks that every secure variable can be decrypted with the keys in
+// the keyring, and returns those that cannot. It should be called before
+// deleting inactive keys.
+func (k *Keyring) Verify(q *QueryOptions) (*KeyringVerifyResponse, *QueryMeta, error) {
+	var resp KeyringVerifyResponse
+	qm, err := k.client.query("/v1/operator/keyring/verify", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// KeyringVerifyResponse is the result of the Verify API
+type KeyringVerifyResponse struct {
+	// Variables is the number of secure variables that were checked
+	Variables int
+
+	// Orphaned are the secure variables that cannot be decrypted
+	Orphaned []*KeyringOrphanedVariable
+}
+
+// KeyringOrphanedVariable is a secure variable which cannot be decrypted
+// with the keys in the keyring
+type KeyringOrphanedVariable struct {
+	Namespace string
+	Path      string
+	KeyID     string
+	Error     string
+}
+
+// Health returns the results of the leader's periodic integrity checks of
+// the encrypted secure variables.
+func (k *Keyring) Health(q *QueryOptions) (*KeyringHealthResponse, *QueryMeta, error) {
+	var resp KeyringHealthResponse
+	qm, err := k.client.query("/v1/operator/keyring/health", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// KeyringHealthResponse is the result of the Health API
+type KeyringHealthResponse struct {
+	// Healthy is false if any checked secure variable can't be decrypted
+	Healthy bool
+
+	// LastCheck is the time of the leader's last integrity check, or the
+	// zero time if it hasn't run one since it was elected
+	LastCheck time.Time
+
+	// Checked is the number of secure variables checked by the leader
+	// since it was elected
+	Checked int
+
+	// Failed are the secure variables that failed to decrypt
+	Failed []*KeyringOrphanedVariable
+}
+
+func (r *KeyringHealthResponse) MarshalJSON() ([]byte, error) {
+	type Alias KeyringHealthResponse
+	return json.Marshal(&struct {
+		LastCheck unixNanos
+		*Alias
+	}{
+		LastCheck: unixNanos(r.LastCheck),
+		Alias:     (*Alias)(r),
+	})
+}
+
+func (r *KeyringHealthResponse) UnmarshalJSON(data []byte) error {
+	type Alias KeyringHealthResponse
+	aux := &struct {
+		LastCheck unixNanos
+		*Alias
+	}{
+		Alias: (*Alias)(r),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.LastCheck = time.Time(aux.LastCheck)
+	return nil
+}
+
+// JobSigningKey is an ed25519 public key trusted to sign job submissions.
+type JobSigningKey struct {
+	// KeyID is derived from the public key by the servers.
+	KeyID       string
+	Name        string
+	PublicKey   []byte
+	CreateTime  time.Time
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+func (k *JobSigningKey) MarshalJSON() ([]byte, error) {
+	type Alias JobSigningKey
+	return json.Marshal(&struct {
+		CreateTime unixNanos
+		*Alias
+	}{
+		CreateTime: unixNanos(k.CreateTime),
+		Alias:      (*Alias)(k),
+	})
+}
+
+func (k *JobSigningKey) UnmarshalJSON(data []byte) error {
+	type Alias JobSigningKey
+	aux := &struct {
+		CreateTime unixNanos
+		*Alias
+	}{
+		Alias: (*Alias)(k),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	k.CreateTime = time.Time(aux.CreateTime)
+	return nil
+}
+
+// JobSigningKeyUpsertResponse is the result of the UpsertSigningKey API
+type JobSigningKeyUpsertResponse struct {
+	Key *JobSigningKey
+	WriteMeta
+}
+
+// ListSigningKeys lists the public keys trusted to sign job submissions
+func (k *Keyring) ListSigningKeys(q *QueryOptions) ([]*JobSigningKey, *QueryMeta, error) {
+	var resp []*JobSigningKey
+	qm, err := k.client.query("/v1/operator/keyring/signing-keys", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// UpsertSigningKey trusts a public key to sign job submissions, or renames
+// an already trusted key
+func (k *Keyring) UpsertSigningKey(key *JobSigningKey, w *WriteOptions) (*JobSigningKey, *WriteMeta, error) {
+	var resp JobSigningKeyUpsertResponse
+	wm, err := k.client.write("/v1/operator/keyring/signing-keys", key, &resp, w)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Key, wm, nil
+}
+
+// DeleteSigningKey stops trusting a job signing key
+func (k *Keyring) DeleteSigningKey(keyID string, w *WriteOptions) (*WriteMeta, error) {
+	wm, err := k.client.delete(fmt.Sprintf("/v1/operator/keyring/signing-key/%v",
+		url.PathEscape(keyID)), nil, nil, w)
+	return wm, err
 }
@@ -45,6 +45,17 @@ func TestKeyring_CRUD(t *testing.T) {
 	require.NoError(t, err)
 	assertWriteMeta(t, wm)
 
+	// Read the new key
+	info, qm, err := kr.Info(id, &QueryOptions{WaitIndex: key.CreateIndex})
+	require.NoError(t, err)
+	assertQueryMeta(t, qm)
+	require.Equal(t, id, info.KeyID)
+	require.Equal(t, RootKeyState(RootKeyStateActive), info.State)
+
+	// Reading an unknown key fails
+	_, _, err = kr.Info("00000000-0000-0000-0000-000000000000", nil)
+	require.ErrorContains(t, err, "key not found")
+
 	// Delete the old key
 	wm, err = kr.Delete(&KeyringDeleteOptions{KeyID: keys[0].KeyID}, nil)
 	require.NoError(t, err)
@@ -64,4 +75,19 @@ func TestKeyring_CRUD(t *testing.T) {
 				key.State, "initial key should be inactive")
 		}
 	}
+
+	// Verify that no secure variable is orphaned
+	verify, qm, err := kr.Verify(nil)
+	require.NoError(t, err)
+	assertQueryMeta(t, qm)
+	require.Zero(t, verify.Variables)
+	require.Empty(t, verify.Orphaned)
+
+	// The leader hasn't run an integrity check yet
+	health, qm, err := kr.Health(nil)
+	require.NoError(t, err)
+	assertQueryMeta(t, qm)
+	require.True(t, health.Healthy)
+	require.True(t, health.LastCheck.IsZero())
+	require.Empty(t, health.Failed)
 }
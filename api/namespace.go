@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 	"sort"
+	"time"
 )
 
 // Namespaces is used to query the namespace endpoints.
@@ -67,13 +68,16 @@ func (n *Namespaces) Delete(namespace string, q *WriteOptions) (*WriteMeta, erro
 
 // Namespace is used to serialize a namespace.
 type Namespace struct {
-	Name         string
-	Description  string
-	Quota        string
-	Capabilities *NamespaceCapabilities `hcl:"capabilities,block"`
-	Meta         map[string]string
-	CreateIndex  uint64
-	ModifyIndex  uint64
+	Name            string
+	Description     string
+	Quota           string
+	Capabilities    *NamespaceCapabilities `hcl:"capabilities,block"`
+	Meta            map[string]string
+	Retention       *NamespaceRetention             `hcl:"retention,block"`
+	Defaults        *NamespaceDefaults              `hcl:"defaults,block"`
+	SecureVariables *NamespaceSecureVariablesConfig `hcl:"secure_variables,block"`
+	CreateIndex     uint64
+	ModifyIndex     uint64
 }
 
 type NamespaceCapabilities struct {
@@ -81,6 +85,40 @@ type NamespaceCapabilities struct {
 	DisabledTaskDrivers []string `hcl:"disabled_task_drivers"`
 }
 
+// NamespaceRetention limits how many historic job versions and terminal
+// deployments are kept in a namespace, and for how long.
+type NamespaceRetention struct {
+	JobVersions      int           `hcl:"job_versions"`
+	JobVersionMaxAge time.Duration `hcl:"job_version_max_age"`
+	Deployments      int           `hcl:"deployments"`
+	DeploymentMaxAge time.Duration `hcl:"deployment_max_age"`
+}
+
+// NamespaceSecureVariablesConfig configures the secure variables of a
+// namespace.
+type NamespaceSecureVariablesConfig struct {
+	// ReplicationTargets are the regions the secure variables written in the
+	// namespace are replicated to.
+	ReplicationTargets []string `hcl:"replication_targets"`
+}
+
+// NamespaceDefaults are merged into the vault, consul and identity blocks of
+// the jobs registered in a namespace, for the values the jobs don't set.
+type NamespaceDefaults struct {
+	Vault    *NamespaceVaultDefaults  `hcl:"vault,block"`
+	Consul   *NamespaceConsulDefaults `hcl:"consul,block"`
+	Identity *WorkloadIdentity        `hcl:"identity,block"`
+}
+
+type NamespaceVaultDefaults struct {
+	Namespace string   `hcl:"namespace"`
+	Policies  []string `hcl:"policies"`
+}
+
+type NamespaceConsulDefaults struct {
+	Namespace string `hcl:"namespace"`
+}
+
 // NamespaceIndexSort is a wrapper to sort Namespaces by CreateIndex. We
 // reverse the test so that we get the highest index first.
 type NamespaceIndexSort []*Namespace
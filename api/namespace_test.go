@@ -54,6 +54,7 @@ func TestNamespace_Info(t *testing.T) {
 	_, _, err := namespaces.Info("foo", nil)
 	assert.NotNil(err)
 	assert.Contains(err.Error(), "not found")
+	assert.True(IsNotFound(err))
 
 	// Register the namespace
 	ns := testNamespace()
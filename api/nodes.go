@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strconv"
@@ -58,6 +59,14 @@ func (n *Nodes) PrefixListOpts(prefix string, opts *QueryOptions) ([]*NodeListSt
 	return n.List(opts)
 }
 
+// ListPages is used to list out all of the nodes, one page at a time. It
+// follows the NextToken of each response and calls fn with each page until
+// the last page is reached or fn returns false. The page size is set with
+// QueryOptions.PerPage.
+func (n *Nodes) ListPages(q *QueryOptions, fn func([]*NodeListStub, *QueryMeta) bool) error {
+	return listPages(q, n.List, fn)
+}
+
 // Info is used to query a specific node by its ID.
 func (n *Nodes) Info(nodeID string, q *QueryOptions) (*Node, *QueryMeta, error) {
 	var resp Node
@@ -396,6 +405,9 @@ type NodeUpdateEligibilityRequest struct {
 	// NodeID is the node to update the drain specification for.
 	NodeID      string
 	Eligibility string
+
+	// Reason is an optional operator supplied reason for the change.
+	Reason string
 }
 
 // NodeEligibilityUpdateResponse is used to respond to a node eligibility update
@@ -408,6 +420,12 @@ type NodeEligibilityUpdateResponse struct {
 
 // ToggleEligibility is used to update the scheduling eligibility of the node
 func (n *Nodes) ToggleEligibility(nodeID string, eligible bool, q *WriteOptions) (*NodeEligibilityUpdateResponse, error) {
+	return n.ToggleEligibilityWithReason(nodeID, eligible, "", q)
+}
+
+// ToggleEligibilityWithReason is used to update the scheduling eligibility of
+// the node, recording the operator's reason for the change on the node.
+func (n *Nodes) ToggleEligibilityWithReason(nodeID string, eligible bool, reason string, q *WriteOptions) (*NodeEligibilityUpdateResponse, error) {
 	e := NodeSchedulingEligible
 	if !eligible {
 		e = NodeSchedulingIneligible
@@ -416,6 +434,7 @@ func (n *Nodes) ToggleEligibility(nodeID string, eligible bool, q *WriteOptions)
 	req := &NodeUpdateEligibilityRequest{
 		NodeID:      nodeID,
 		Eligibility: e,
+		Reason:      reason,
 	}
 
 	var resp NodeEligibilityUpdateResponse
@@ -427,6 +446,36 @@ func (n *Nodes) ToggleEligibility(nodeID string, eligible bool, q *WriteOptions)
 	return &resp, nil
 }
 
+// PlannedDisconnect describes a window during which a node is expected to be
+// disconnected.
+type PlannedDisconnect struct {
+	StartedAt time.Time
+	Deadline  time.Time
+}
+
+// NodeUpdatePlannedDisconnectRequest is used to plan or cancel a disconnect
+// of a node.
+type NodeUpdatePlannedDisconnectRequest struct {
+	NodeID string
+
+	// Duration is how long the node is expected to be disconnected. A zero
+	// duration cancels the planned disconnect.
+	Duration time.Duration
+}
+
+// PlanDisconnect is used to mark the node as intentionally disconnected for
+// the given duration, for example for maintenance. Allocations on the node
+// are marked unknown instead of lost while it is disconnected, and resume
+// when it reconnects before the deadline. A zero duration cancels the
+// planned disconnect.
+func (n *Nodes) PlanDisconnect(nodeID string, duration time.Duration, q *WriteOptions) (*WriteMeta, error) {
+	req := &NodeUpdatePlannedDisconnectRequest{
+		NodeID:   nodeID,
+		Duration: duration,
+	}
+	return n.client.write("/v1/node/"+nodeID+"/disconnect", req, nil, q)
+}
+
 // Allocations is used to return the allocations associated with a node.
 func (n *Nodes) Allocations(nodeID string, q *QueryOptions) ([]*Allocation, *QueryMeta, error) {
 	var resp []*Allocation
@@ -440,7 +489,10 @@ func (n *Nodes) Allocations(nodeID string, q *QueryOptions) ([]*Allocation, *Que
 
 func (n *Nodes) CSIVolumes(nodeID string, q *QueryOptions) ([]*CSIVolumeListStub, error) {
 	var resp []*CSIVolumeListStub
-	path := fmt.Sprintf("/v1/volumes?type=csi&node_id=%s", nodeID)
+	path := newQueryParams().
+		set("type", CSIVolumeTypeCSI).
+		set("node_id", nodeID).
+		endpoint("/v1/volumes")
 	if _, err := n.client.query(path, &resp, q); err != nil {
 		return nil, err
 	}
@@ -460,7 +512,7 @@ func (n *Nodes) ForceEvaluate(nodeID string, q *WriteOptions) (string, *WriteMet
 
 func (n *Nodes) Stats(nodeID string, q *QueryOptions) (*HostStats, error) {
 	var resp HostStats
-	path := fmt.Sprintf("/v1/client/stats?node_id=%s", nodeID)
+	path := newQueryParams().set("node_id", nodeID).endpoint("/v1/client/stats")
 	if _, err := n.client.query(path, &resp, q); err != nil {
 		return nil, err
 	}
@@ -469,11 +521,54 @@ func (n *Nodes) Stats(nodeID string, q *QueryOptions) (*HostStats, error) {
 }
 
 func (n *Nodes) GC(nodeID string, q *QueryOptions) error {
-	path := fmt.Sprintf("/v1/client/gc?node_id=%s", nodeID)
+	path := newQueryParams().set("node_id", nodeID).endpoint("/v1/client/gc")
 	_, err := n.client.query(path, nil, q)
 	return err
 }
 
+// Meta returns the metadata of a node, including the keys that were set and
+// unset with UpdateMeta.
+func (n *Nodes) Meta(nodeID string, q *QueryOptions) (*NodeMetaResponse, *QueryMeta, error) {
+	var resp NodeMetaResponse
+	path := newQueryParams().setString("node_id", nodeID).endpoint("/v1/client/metadata")
+	qm, err := n.client.query(path, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// UpdateMeta sets and unsets keys of the metadata of a node at runtime,
+// without changing its configuration. A nil value unsets the key. The changes
+// persist across restarts of the client, and the jobs whose constraints
+// depend on the metadata are re-evaluated.
+func (n *Nodes) UpdateMeta(req *NodeMetaApplyRequest, q *WriteOptions) (*NodeMetaResponse, *WriteMeta, error) {
+	var resp NodeMetaResponse
+	path := newQueryParams().setString("node_id", req.NodeID).endpoint("/v1/client/metadata")
+	wm, err := n.client.write(path, req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// NodeMetaApplyRequest is used to set and unset keys of the metadata of a
+// node.
+type NodeMetaApplyRequest struct {
+	NodeID string
+	Meta   map[string]*string
+}
+
+// NodeMetaResponse is used to deserialize the metadata of a node.
+type NodeMetaResponse struct {
+	// Meta is the metadata of the node, including the dynamic metadata.
+	Meta map[string]string
+
+	// Dynamic is the metadata set and unset with UpdateMeta, where nil values
+	// are keys that were unset.
+	Dynamic map[string]*string
+}
+
 // TODO Add tests
 func (n *Nodes) GcAlloc(allocID string, q *QueryOptions) error {
 	path := fmt.Sprintf("/v1/client/allocation/%s/gc", allocID)
@@ -500,6 +595,26 @@ type NodePurgeResponse struct {
 	NodeModifyIndex uint64
 }
 
+// PurgeDown removes every node that has been down for longer than downFor,
+// or every down node if downFor is zero. Nodes can still re-join the
+// cluster if they come back.
+func (n *Nodes) PurgeDown(downFor time.Duration, q *WriteOptions) (*NodePurgeDownResponse, *WriteMeta, error) {
+	var resp NodePurgeDownResponse
+	path := newQueryParams().set("down_for", downFor.String()).endpoint("/v1/nodes/purge")
+	wm, err := n.client.write(path, nil, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// NodePurgeDownResponse is used to deserialize a PurgeDown response.
+type NodePurgeDownResponse struct {
+	NodeIDs         []string
+	EvalIDs         []string
+	EvalCreateIndex uint64
+}
+
 // DriverInfo is used to deserialize a DriverInfo entry
 type DriverInfo struct {
 	Attributes        map[string]string
@@ -549,13 +664,16 @@ type Node struct {
 	Links                 map[string]string
 	Meta                  map[string]string
 	NodeClass             string
+	DisconnectDomain      string
 	CgroupParent          string
 	Drain                 bool
 	DrainStrategy         *DrainStrategy
 	SchedulingEligibility string
+	EligibilityReason     string
 	Status                string
 	StatusDescription     string
-	StatusUpdatedAt       int64
+	StatusUpdatedAt       time.Time
+	LastReconnect         time.Time
 	Events                []*NodeEvent
 	Drivers               map[string]*DriverInfo
 	HostVolumes           map[string]*HostVolumeInfo
@@ -563,10 +681,41 @@ type Node struct {
 	CSIControllerPlugins  map[string]*CSIInfo
 	CSINodePlugins        map[string]*CSIInfo
 	LastDrain             *DrainMetadata
+	PlannedDisconnect     *PlannedDisconnect
 	CreateIndex           uint64
 	ModifyIndex           uint64
 }
 
+func (n *Node) MarshalJSON() ([]byte, error) {
+	type Alias Node
+	return json.Marshal(&struct {
+		StatusUpdatedAt unixSeconds
+		LastReconnect   unixSeconds
+		*Alias
+	}{
+		StatusUpdatedAt: unixSeconds(n.StatusUpdatedAt),
+		LastReconnect:   unixSeconds(n.LastReconnect),
+		Alias:           (*Alias)(n),
+	})
+}
+
+func (n *Node) UnmarshalJSON(data []byte) error {
+	type Alias Node
+	aux := &struct {
+		StatusUpdatedAt unixSeconds
+		LastReconnect   unixSeconds
+		*Alias
+	}{
+		Alias: (*Alias)(n),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	n.StatusUpdatedAt = time.Time(aux.StatusUpdatedAt)
+	n.LastReconnect = time.Time(aux.LastReconnect)
+	return nil
+}
+
 type NodeResources struct {
 	Cpu      NodeCpuResources
 	Memory   NodeMemoryResources
@@ -576,6 +725,8 @@ type NodeResources struct {
 
 	MinDynamicPort int
 	MaxDynamicPort int
+
+	MemoryOversubscriptionRatio float64
 }
 
 type NodeCpuResources struct {
@@ -585,7 +736,8 @@ type NodeCpuResources struct {
 }
 
 type NodeMemoryResources struct {
-	MemoryMB int64
+	MemoryMB     int64
+	HugePages2MB int64
 }
 
 type NodeDiskResources struct {
@@ -779,6 +931,7 @@ type HostStats struct {
 	CPU              []*HostCPUStats
 	DiskStats        []*HostDiskStats
 	DeviceStats      []*DeviceGroupStats
+	System           *HostSystemStats
 	Uptime           uint64
 	CPUTicksConsumed float64
 }
@@ -807,6 +960,34 @@ type HostDiskStats struct {
 	InodesUsedPercent float64
 }
 
+// HostSystemStats contains the load, conntrack and socket counts of the host.
+// It is only set when the client is configured to collect host system
+// metrics, and each of its stats is nil if not available on the host.
+type HostSystemStats struct {
+	Load      *HostLoadStats
+	Conntrack *HostConntrackStats
+	Sockets   *HostSocketStats
+}
+
+type HostLoadStats struct {
+	Load1  float64
+	Load5  float64
+	Load15 float64
+}
+
+type HostConntrackStats struct {
+	Count int64
+	Max   int64
+}
+
+type HostSocketStats struct {
+	Used        int64
+	TCPInUse    int64
+	TCPOrphan   int64
+	TCPTimeWait int64
+	UDPInUse    int64
+}
+
 // DeviceGroupStats contains statistics for each device of a particular
 // device group, identified by the vendor, type and name of the device.
 type DeviceGroupStats struct {
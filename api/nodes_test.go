@@ -172,7 +172,7 @@ func TestNodes_Info(t *testing.T) {
 	require.Equal(t, 32000, result.NodeResources.MaxDynamicPort)
 
 	// Check that the StatusUpdatedAt field is being populated correctly
-	if result.StatusUpdatedAt < startTime {
+	if result.StatusUpdatedAt.Unix() < startTime {
 		t.Fatalf("start time: %v, status updated: %v", startTime, result.StatusUpdatedAt)
 	}
 
@@ -445,6 +445,71 @@ func TestNodes_ForceEvaluate(t *testing.T) {
 	}
 }
 
+func TestNodes_UpdateMeta(t *testing.T) {
+	testutil.Parallel(t)
+	c, s := makeClient(t, nil, func(c *testutil.TestServerConfig) {
+		c.DevMode = true
+	})
+	defer s.Stop()
+	nodes := c.Nodes()
+
+	// Wait for node registration and get the ID
+	var nodeID string
+	testutil.WaitForResult(func() (bool, error) {
+		out, _, err := nodes.List(nil)
+		if err != nil {
+			return false, err
+		}
+		if n := len(out); n != 1 {
+			return false, fmt.Errorf("expected 1 node, got: %d", n)
+		}
+		nodeID = out[0].ID
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %s", err)
+	})
+
+	// Applying without metadata fails
+	_, _, err := nodes.UpdateMeta(&NodeMetaApplyRequest{NodeID: nodeID}, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "missing metadata to apply")
+
+	// Set and unset keys
+	out, _, err := nodes.UpdateMeta(&NodeMetaApplyRequest{
+		NodeID: nodeID,
+		Meta: map[string]*string{
+			"network":           pointerOf("degraded"),
+			"connect.log_level": nil,
+		},
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "degraded", out.Meta["network"])
+	require.NotContains(t, out.Meta, "connect.log_level")
+
+	// The metadata can be read back
+	out, _, err = nodes.Meta(nodeID, nil)
+	require.NoError(t, err)
+	require.Equal(t, "degraded", out.Meta["network"])
+	require.Equal(t, map[string]*string{
+		"network":           pointerOf("degraded"),
+		"connect.log_level": nil,
+	}, out.Dynamic)
+
+	// The node is re-registered with the new metadata
+	testutil.WaitForResult(func() (bool, error) {
+		node, _, err := nodes.Info(nodeID, nil)
+		if err != nil {
+			return false, err
+		}
+		if node.Meta["network"] != "degraded" {
+			return false, fmt.Errorf("node meta not updated: %v", node.Meta)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %s", err)
+	})
+}
+
 func TestNodes_Sort(t *testing.T) {
 	testutil.Parallel(t)
 	nodes := []*NodeListStub{
@@ -660,6 +725,42 @@ func TestNodes_Purge(t *testing.T) {
 	require.Greater(meta.LastIndex, uint64(0))
 }
 
+func TestNodes_PurgeDown(t *testing.T) {
+	testutil.Parallel(t)
+	c, s := makeClient(t, nil, func(c *testutil.TestServerConfig) {
+		c.DevMode = true
+	})
+	defer s.Stop()
+
+	// Wait for the dev node to register
+	testutil.WaitForResult(func() (bool, error) {
+		out, _, err := c.Nodes().List(nil)
+		if err != nil {
+			return false, err
+		}
+		if n := len(out); n != 1 {
+			return false, fmt.Errorf("expected 1 node, got: %d", n)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %s", err)
+	})
+
+	// The ready node isn't purged
+	out, wm, err := c.Nodes().PurgeDown(time.Hour, nil)
+	require.NoError(t, err)
+	require.Empty(t, out.NodeIDs)
+	require.Greater(t, wm.LastIndex, uint64(0))
+
+	out, _, err = c.Nodes().PurgeDown(0, nil)
+	require.NoError(t, err)
+	require.Empty(t, out.NodeIDs)
+
+	nodes, _, err := c.Nodes().List(nil)
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+}
+
 func TestNodeStatValueFormatting(t *testing.T) {
 	testutil.Parallel(t)
 
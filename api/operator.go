@@ -3,7 +3,6 @@ package api
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 	"io"
 	"io/ioutil"
 	"strconv"
@@ -138,6 +137,10 @@ type SchedulerConfiguration struct {
 	// until the configuration is updated and written to the Nomad servers.
 	PauseEvalBroker bool
 
+	// ReconnectStabilization is how long a node must stay connected after
+	// reconnecting before its unknown allocations are reinstated.
+	ReconnectStabilization time.Duration
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -189,6 +192,34 @@ func (op *Operator) SchedulerGetConfiguration(q *QueryOptions) (*SchedulerConfig
 	return &resp, qm, nil
 }
 
+// PlanQueueStats describes the load of the leader's plan applier.
+type PlanQueueStats struct {
+	Depth                  int
+	ApplyLatency           time.Duration
+	Saturated              bool
+	SaturatedReason        string
+	BackpressureEnabled    bool
+	MaxQueueDepth          int
+	MaxApplyLatency        time.Duration
+	BackpressureRejections uint64
+}
+
+// PlanQueueStatsResponse is the response object that wraps PlanQueueStats.
+type PlanQueueStatsResponse struct {
+	Stats *PlanQueueStats
+	QueryMeta
+}
+
+// PlanQueueStats is used to query the load of the leader's plan applier.
+func (op *Operator) PlanQueueStats(q *QueryOptions) (*PlanQueueStatsResponse, *QueryMeta, error) {
+	var resp PlanQueueStatsResponse
+	qm, err := op.c.query("/v1/operator/scheduler/plan-queue", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // SchedulerSetConfiguration is used to set the current Scheduler configuration.
 func (op *Operator) SchedulerSetConfiguration(conf *SchedulerConfiguration, q *WriteOptions) (*SchedulerSetConfigurationResponse, *WriteMeta, error) {
 	var out SchedulerSetConfigurationResponse
@@ -204,7 +235,10 @@ func (op *Operator) SchedulerSetConfiguration(conf *SchedulerConfiguration, q *W
 // true on success or false on failures.
 func (op *Operator) SchedulerCASConfiguration(conf *SchedulerConfiguration, q *WriteOptions) (*SchedulerSetConfigurationResponse, *WriteMeta, error) {
 	var out SchedulerSetConfigurationResponse
-	wm, err := op.c.write("/v1/operator/scheduler/configuration?cas="+strconv.FormatUint(conf.ModifyIndex, 10), conf, &out, q)
+	endpoint := newQueryParams().
+		set("cas", strconv.FormatUint(conf.ModifyIndex, 10)).
+		endpoint("/v1/operator/scheduler/configuration")
+	wm, err := op.c.write(endpoint, conf, &out, q)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -344,8 +378,7 @@ func (op *Operator) LicenseGet(q *QueryOptions) (*LicenseReply, *QueryMeta, erro
 	}
 
 	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, nil, fmt.Errorf("Unexpected response code: %d (%s)", resp.StatusCode, body)
+		return nil, nil, newAPIErrorFromResponse(resp)
 	}
 
 	err = json.NewDecoder(resp.Body).Decode(&reply)
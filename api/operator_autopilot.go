@@ -202,7 +202,10 @@ func (op *Operator) AutopilotSetConfiguration(conf *AutopilotConfiguration, q *W
 // true on success or false on failures.
 func (op *Operator) AutopilotCASConfiguration(conf *AutopilotConfiguration, q *WriteOptions) (bool, *WriteMeta, error) {
 	var out bool
-	wm, err := op.c.write("/v1/operator/autopilot/configuration?cas="+strconv.FormatUint(conf.ModifyIndex, 10), conf, &out, q)
+	endpoint := newQueryParams().
+		set("cas", strconv.FormatUint(conf.ModifyIndex, 10)).
+		endpoint("/v1/operator/autopilot/configuration")
+	wm, err := op.c.write(endpoint, conf, &out, q)
 	if err != nil {
 		return false, nil, err
 	}
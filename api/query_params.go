@@ -0,0 +1,91 @@
+package api
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// queryParams builds the query string of an endpoint from typed values. It
+// takes care of encoding them, so that IDs and addresses holding reserved
+// characters reach the agent intact. The setters skip zero values unless
+// noted otherwise, so the agent applies its own defaults.
+type queryParams struct {
+	values url.Values
+}
+
+func newQueryParams() *queryParams {
+	return &queryParams{values: url.Values{}}
+}
+
+// setString sets the parameter to the value if it is not empty.
+func (q *queryParams) setString(key, value string) *queryParams {
+	if value != "" {
+		q.values.Set(key, value)
+	}
+	return q
+}
+
+// addStrings adds the values to the parameter, for parameters that may be
+// repeated.
+func (q *queryParams) addStrings(key string, values []string) *queryParams {
+	for _, value := range values {
+		q.values.Add(key, value)
+	}
+	return q
+}
+
+// setBool sets the parameter to "true" if the value is true.
+func (q *queryParams) setBool(key string, value bool) *queryParams {
+	if value {
+		q.values.Set(key, "true")
+	}
+	return q
+}
+
+// setInt sets the parameter to the value if it is not 0.
+func (q *queryParams) setInt(key string, value int) *queryParams {
+	if value != 0 {
+		q.values.Set(key, strconv.Itoa(value))
+	}
+	return q
+}
+
+// set sets the parameter to the value, even if it is empty. It is used for
+// parameters whose zero value differs from the agent's default, or is
+// meaningful in itself, such as a check-and-set index of 0.
+func (q *queryParams) set(key, value string) *queryParams {
+	q.values.Set(key, value)
+	return q
+}
+
+// endpoint returns the path followed by the encoded query string, if any
+// parameter is set.
+func (q *queryParams) endpoint(path string) string {
+	if len(q.values) == 0 {
+		return path
+	}
+	return path + "?" + q.values.Encode()
+}
+
+// endpointParams is implemented by the typed query parameters of the
+// endpoints, such as KeyringRotateOptions or DeregisterOptions. Validate is
+// called before the request is made, so that invalid parameters are reported
+// without a round trip to the agent.
+type endpointParams interface {
+	Validate() error
+	encodeParams(q *queryParams)
+}
+
+// endpointWithParams validates the parameters and returns the path followed
+// by their encoded query string. The implementations accept nil receivers,
+// so callers may pass their options as-is.
+func endpointWithParams(path string, params endpointParams) (string, error) {
+	q := newQueryParams()
+	if params != nil {
+		if err := params.Validate(); err != nil {
+			return "", err
+		}
+		params.encodeParams(q)
+	}
+	return q.endpoint(path), nil
+}
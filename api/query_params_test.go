@@ -0,0 +1,78 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryParams_endpoint(t *testing.T) {
+	testutil.Parallel(t)
+
+	// No parameters leaves the path alone
+	require.Equal(t, "/v1/jobs", newQueryParams().endpoint("/v1/jobs"))
+
+	// Zero values are skipped, except by set
+	q := newQueryParams().
+		setString("empty", "").
+		setBool("false", false).
+		setInt("zero", 0).
+		set("cas", "0").
+		setBool("full", true).
+		setInt("per_page", 10)
+	require.Equal(t, "/v1/x?cas=0&full=true&per_page=10", q.endpoint("/v1/x"))
+
+	// Values are escaped
+	q = newQueryParams().
+		set("node_id", "a&b=c").
+		addStrings("address", []string{"1.2.3.4:4648", "[::1]:4648"})
+	require.Equal(t,
+		"/v1/x?address=1.2.3.4%3A4648&address=%5B%3A%3A1%5D%3A4648&node_id=a%26b%3Dc",
+		q.endpoint("/v1/x"))
+}
+
+func TestQueryParams_endpointWithParams(t *testing.T) {
+	testutil.Parallel(t)
+
+	// Nil options are valid and set no parameters
+	var rotate *KeyringRotateOptions
+	endpoint, err := endpointWithParams("/v1/operator/keyring/rotate", rotate)
+	require.NoError(t, err)
+	require.Equal(t, "/v1/operator/keyring/rotate", endpoint)
+
+	endpoint, err = endpointWithParams("/v1/operator/keyring/rotate", &KeyringRotateOptions{
+		Full:      true,
+		Algorithm: EncryptionAlgorithmAES256GCM,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "/v1/operator/keyring/rotate?algo=aes256-gcm&full=true", endpoint)
+
+	_, err = endpointWithParams("/v1/operator/keyring/rotate", &KeyringRotateOptions{
+		Algorithm: "rot13",
+	})
+	require.EqualError(t, err, `unsupported encryption algorithm "rot13"`)
+
+	endpoint, err = endpointWithParams("/v1/operator/keyring/rotate", &KeyringRotateOptions{
+		PublishTime: time.Unix(0, 1660000000000000000),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "/v1/operator/keyring/rotate?publish_time=1660000000000000000", endpoint)
+
+	_, err = endpointWithParams("/v1/operator/keyring/rotate", &KeyringRotateOptions{
+		Full:        true,
+		PublishTime: time.Unix(0, 1660000000000000000),
+	})
+	require.EqualError(t, err, "a full rotation cannot be scheduled")
+
+	// Deregister options are always sent in full
+	endpoint, err = endpointWithParams("/v1/job/example", &DeregisterOptions{Purge: true})
+	require.NoError(t, err)
+	require.Equal(t,
+		"/v1/job/example?eval_priority=0&global=false&no_shutdown_delay=false&purge=true",
+		endpoint)
+
+	_, err = endpointWithParams("/v1/job/example", &DeregisterOptions{EvalPriority: 101})
+	require.EqualError(t, err, "eval priority must be between 1 and 100 inclusively, got 101")
+}
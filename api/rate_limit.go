@@ -0,0 +1,203 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit configures client-side limits on the requests sent by the
+// client, so that tools using the client can't overwhelm the agent. Requests
+// wait for the limits to allow them, or until their context is done.
+type RateLimit struct {
+	// RequestsPerSecond is the rate at which requests are sent. Zero
+	// disables the rate limit.
+	RequestsPerSecond float64
+
+	// Burst is the number of requests that can be sent at once before
+	// being held to RequestsPerSecond. Values below 1 allow a burst of 1.
+	Burst int
+
+	// MaxConcurrent is the number of requests that can be in flight at
+	// once. A request is in flight until its response body is closed. Zero
+	// disables the limit.
+	MaxConcurrent int
+
+	// BlockingQueries overrides the limits for blocking queries, which are
+	// held open by the agent until the queried index changes. It is keyed by
+	// a path prefix such as "/v1/jobs", and the longest prefix matching the
+	// path of a blocking query applies. Each override has its own limits,
+	// separate from the limits of other requests, so that long-running
+	// blocking queries don't hold up other requests. Blocking queries
+	// matching no prefix share the limits of other requests. The
+	// BlockingQueries of an override are ignored.
+	BlockingQueries map[string]*RateLimit
+}
+
+func (l *RateLimit) Copy() *RateLimit {
+	if l == nil {
+		return nil
+	}
+	nl := new(RateLimit)
+	*nl = *l
+	if l.BlockingQueries != nil {
+		nl.BlockingQueries = make(map[string]*RateLimit, len(l.BlockingQueries))
+		for prefix, override := range l.BlockingQueries {
+			nl.BlockingQueries[prefix] = override.Copy()
+		}
+	}
+	return nl
+}
+
+// rateLimiters holds the limiters of a client, built from its RateLimit.
+type rateLimiters struct {
+	defaultLimiter *limiter
+
+	// blocking are the limiters of blocking queries, keyed by path prefix
+	blocking map[string]*limiter
+}
+
+func newRateLimiters(l *RateLimit) *rateLimiters {
+	if l == nil {
+		return nil
+	}
+	rl := &rateLimiters{
+		defaultLimiter: newLimiter(l),
+		blocking:       make(map[string]*limiter, len(l.BlockingQueries)),
+	}
+	for prefix, override := range l.BlockingQueries {
+		if override != nil {
+			rl.blocking[prefix] = newLimiter(override)
+		}
+	}
+	return rl
+}
+
+// limiterFor returns the limiter that applies to the request.
+func (rl *rateLimiters) limiterFor(r *request) *limiter {
+	if r.params.Get("index") == "" {
+		return rl.defaultLimiter
+	}
+
+	var match string
+	l := rl.defaultLimiter
+	for prefix, override := range rl.blocking {
+		if strings.HasPrefix(r.url.Path, prefix) && len(prefix) >= len(match) {
+			match, l = prefix, override
+		}
+	}
+	return l
+}
+
+// limiter is a token bucket rate limiter combined with a concurrency limit.
+type limiter struct {
+	rate  float64
+	burst float64
+
+	// sem holds a slot per request in flight, if concurrency is limited
+	sem chan struct{}
+
+	lock   sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newLimiter(l *RateLimit) *limiter {
+	burst := float64(l.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	nl := &limiter{
+		rate:   l.RequestsPerSecond,
+		burst:  burst,
+		tokens: burst,
+	}
+	if l.MaxConcurrent > 0 {
+		nl.sem = make(chan struct{}, l.MaxConcurrent)
+	}
+	return nl
+}
+
+// wait waits until the request is allowed by the limiter. The returned
+// function must be called once the request is done to release its slot.
+func (l *limiter) wait(ctx context.Context) (func(), error) {
+	if err := l.waitToken(ctx); err != nil {
+		return nil, err
+	}
+	if l.sem == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	var once sync.Once
+	return func() { once.Do(func() { <-l.sem }) }, nil
+}
+
+// waitToken takes a token from the bucket, waiting for it to be refilled if
+// it is empty.
+func (l *limiter) waitToken(ctx context.Context) error {
+	if l.rate <= 0 {
+		return nil
+	}
+
+	l.lock.Lock()
+	now := time.Now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+
+	// Reserve the token now, so that concurrent requests queue up behind
+	// each other instead of all waking up at once.
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.lock.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Give the token back for the next request
+		l.lock.Lock()
+		l.tokens++
+		l.lock.Unlock()
+		return ctx.Err()
+	}
+}
+
+// limitedBody releases the slot of a request in flight when its response
+// body is closed.
+type limitedBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *limitedBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
+
+// releaseOnClose releases the slot of the request once the response body is
+// closed, or right away if there is no response.
+func releaseOnClose(resp *http.Response, release func()) {
+	if resp == nil || resp.Body == nil {
+		release()
+		return
+	}
+	resp.Body = &limitedBody{ReadCloser: resp.Body, release: release}
+}
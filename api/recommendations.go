@@ -1,5 +1,10 @@
 package api
 
+import (
+	"encoding/json"
+	"time"
+)
+
 // Recommendations is used to query the recommendations endpoints.
 type Recommendations struct {
 	client *Client
@@ -85,12 +90,38 @@ type Recommendation struct {
 	Stats          map[string]float64
 	EnforceVersion bool
 
-	SubmitTime int64
+	SubmitTime time.Time
 
 	CreateIndex uint64
 	ModifyIndex uint64
 }
 
+func (r *Recommendation) MarshalJSON() ([]byte, error) {
+	type Alias Recommendation
+	return json.Marshal(&struct {
+		SubmitTime unixNanos
+		*Alias
+	}{
+		SubmitTime: unixNanos(r.SubmitTime),
+		Alias:      (*Alias)(r),
+	})
+}
+
+func (r *Recommendation) UnmarshalJSON(data []byte) error {
+	type Alias Recommendation
+	aux := &struct {
+		SubmitTime unixNanos
+		*Alias
+	}{
+		Alias: (*Alias)(r),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.SubmitTime = time.Time(aux.SubmitTime)
+	return nil
+}
+
 // RecommendationApplyRequest is used to apply and/or dismiss a set of recommendations
 type RecommendationApplyRequest struct {
 	Apply          []string
@@ -15,6 +15,9 @@ type Resources struct {
 	Networks    []*NetworkResource `hcl:"network,block"`
 	Devices     []*RequestedDevice `hcl:"device,block"`
 
+	// HugePages2MB is the number of 2MiB huge pages to reserve for the task.
+	HugePages2MB *int `mapstructure:"hugepages_2m" hcl:"hugepages_2m,optional"`
+
 	// COMPAT(0.10)
 	// XXX Deprecated. Please do not use. The field will be removed in Nomad
 	// 0.10 and is only being kept to allow any references to be removed before
@@ -85,6 +88,9 @@ func (r *Resources) Merge(other *Resources) {
 	if other.MemoryMB != nil {
 		r.MemoryMB = other.MemoryMB
 	}
+	if other.HugePages2MB != nil {
+		r.HugePages2MB = other.HugePages2MB
+	}
 	if other.DiskMB != nil {
 		r.DiskMB = other.DiskMB
 	}
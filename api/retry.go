@@ -0,0 +1,168 @@
+package api
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+const (
+	// defaultRetryMinBackoff is the wait before the first retry when the
+	// RetryPolicy does not set MinBackoff.
+	defaultRetryMinBackoff = 250 * time.Millisecond
+
+	// defaultRetryMaxBackoff is the upper bound on the wait between retries
+	// when the RetryPolicy does not set MaxBackoff.
+	defaultRetryMaxBackoff = 5 * time.Second
+)
+
+// defaultRetryableStatusCodes are the HTTP status codes retried when the
+// RetryPolicy does not set RetryableStatusCodes.
+var defaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy configures how the client retries requests that fail with a
+// transport error or a retryable status code. Reads are always retried. Writes
+// are only retried when they are safe to apply twice: when they carry an
+// IdempotencyToken or a check-index ("cas" query parameter).
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a request,
+	// including the first. Values below 2 disable retries.
+	MaxAttempts int
+
+	// MinBackoff is the wait before the first retry. It doubles with every
+	// attempt up to MaxBackoff, and a random jitter of up to half the wait
+	// is subtracted.
+	MinBackoff time.Duration
+
+	// MaxBackoff is the upper bound on the wait between attempts.
+	MaxBackoff time.Duration
+
+	// RetryableStatusCodes are the HTTP status codes that are retried. If
+	// empty, 429, 502, 503 and 504 are retried.
+	RetryableStatusCodes []int
+}
+
+// DefaultRetryPolicy returns a retry policy that makes up to 4 attempts with
+// the default backoff and status codes.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  defaultRetryMinBackoff,
+		MaxBackoff:  defaultRetryMaxBackoff,
+	}
+}
+
+func (p *RetryPolicy) Copy() *RetryPolicy {
+	if p == nil {
+		return nil
+	}
+	np := new(RetryPolicy)
+	*np = *p
+	np.RetryableStatusCodes = append([]int(nil), p.RetryableStatusCodes...)
+	return np
+}
+
+// retryableStatus returns whether the status code should be retried.
+func (p *RetryPolicy) retryableStatus(code int) bool {
+	codes := p.RetryableStatusCodes
+	if len(codes) == 0 {
+		codes = defaultRetryableStatusCodes
+	}
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the wait before the given retry, starting at 1.
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	min, max := p.MinBackoff, p.MaxBackoff
+	if min <= 0 {
+		min = defaultRetryMinBackoff
+	}
+	if max <= 0 {
+		max = defaultRetryMaxBackoff
+	}
+
+	wait := min
+	for i := 1; i < retry && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		wait = max
+	}
+
+	// Subtract up to half of the wait as jitter so that clients retrying
+	// the same failure spread out their requests.
+	if half := int64(wait / 2); half > 0 {
+		wait -= time.Duration(rand.Int63n(half))
+	}
+	return wait
+}
+
+// retryable returns whether the request may be sent more than once.
+func (r *request) retryable() bool {
+	// A caller provided reader can't be replayed.
+	if _, ok := r.obj.(io.Reader); ok {
+		return false
+	}
+
+	switch r.method {
+	case "GET", "HEAD":
+		return true
+	}
+	return r.idempotent || r.params.Get("cas") != "" ||
+		r.params.Get("idempotency_token") != ""
+}
+
+// doRequestRetry runs the request with doRequest, retrying it according to
+// the client's RetryPolicy, and checks the final response with requireOK.
+func (c *Client) doRequestRetry(r *request) (time.Duration, *http.Response, error) {
+	policy := c.config.RetryPolicy
+	if policy == nil || policy.MaxAttempts < 2 || !r.retryable() {
+		return requireOK(c.doRequest(r))
+	}
+
+	for attempt := 1; ; attempt++ {
+		rtt, resp, err := c.doRequest(r)
+		if attempt >= policy.MaxAttempts {
+			return requireOK(rtt, resp, err)
+		}
+		if err == nil && !policy.retryableStatus(resp.StatusCode) {
+			return requireOK(rtt, resp, err)
+		}
+
+		// Drain the failed response so the connection can be reused.
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		// Stop early if the caller gave up on the request.
+		ctx := r.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		timer := time.NewTimer(policy.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return rtt, nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		// The body was consumed by the previous attempt, so encode it again.
+		if r.obj != nil {
+			r.body = nil
+		}
+	}
+}
@@ -1,5 +1,10 @@
 package api
 
+import (
+	"encoding/json"
+	"time"
+)
+
 const (
 	// ScalingPolicyTypeHorizontal indicates a policy that does horizontal scaling.
 	ScalingPolicyTypeHorizontal = "horizontal"
@@ -53,6 +58,16 @@ type ScalingRequest struct {
 	Message string
 	Error   bool
 	Meta    map[string]interface{}
+
+	// Groups sets the count of several task groups in a single job update.
+	// It is used instead of the Target group and Count.
+	Groups map[string]int64 `json:",omitempty"`
+
+	// EnforceIndex scales the job only if its modify index still matches
+	// JobModifyIndex, as returned by ScaleStatus.
+	EnforceIndex   bool   `json:",omitempty"`
+	JobModifyIndex uint64 `json:",omitempty"`
+
 	WriteRequest
 	// this is effectively a job update, so we need the ability to override policy.
 	PolicyOverride bool
@@ -114,6 +129,33 @@ type ScalingEvent struct {
 	Message       string
 	Meta          map[string]interface{}
 	EvalID        *string
-	Time          uint64
+	Initiator     string
+	Time          time.Time
 	CreateIndex   uint64
 }
+
+func (e *ScalingEvent) MarshalJSON() ([]byte, error) {
+	type Alias ScalingEvent
+	return json.Marshal(&struct {
+		Time unixNanos
+		*Alias
+	}{
+		Time:  unixNanos(e.Time),
+		Alias: (*Alias)(e),
+	})
+}
+
+func (e *ScalingEvent) UnmarshalJSON(data []byte) error {
+	type Alias ScalingEvent
+	aux := &struct {
+		Time unixNanos
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	e.Time = time.Time(aux.Time)
+	return nil
+}
@@ -18,7 +18,7 @@ func (s *Search) PrefixSearch(prefix string, context contexts.Context, q *QueryO
 	var resp SearchResponse
 	req := &SearchRequest{Prefix: prefix, Context: context}
 
-	qm, err := s.client.putQuery("/v1/search", req, &resp, q)
+	qm, err := s.client.putReadQuery("/v1/search", req, &resp, q)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -47,7 +47,7 @@ func (s *Search) FuzzySearch(text string, context contexts.Context, q *QueryOpti
 		Text:    text,
 	}
 
-	qm, err := s.client.putQuery("/v1/search/fuzzy", req, &resp, q)
+	qm, err := s.client.putReadQuery("/v1/search/fuzzy", req, &resp, q)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1,12 +1,12 @@
 package api
 
 import (
-	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -45,7 +45,8 @@ func (sv *SecureVariables) CheckedCreate(v *SecureVariable, qo *WriteOptions) (*
 
 	v.Path = cleanPathString(v.Path)
 	var out SecureVariable
-	wm, err := sv.writeChecked("/v1/var/"+v.Path+"?cas=0", v, &out, qo)
+	endpoint := newQueryParams().set("cas", "0").endpoint("/v1/var/" + v.Path)
+	wm, err := sv.writeChecked(endpoint, v, &out, qo)
 	if err != nil {
 		return nil, wm, err
 	}
@@ -95,6 +96,21 @@ func (sv *SecureVariables) Update(v *SecureVariable, qo *WriteOptions) (*SecureV
 	return &out, wm, nil
 }
 
+// ForceUpdate is used to update a secure variable, removing its delete
+// protection if the update doesn't keep it. This requires a token with the
+// delete-protected capability.
+func (sv *SecureVariables) ForceUpdate(v *SecureVariable, qo *WriteOptions) (*SecureVariable, *WriteMeta, error) {
+
+	v.Path = cleanPathString(v.Path)
+	var out SecureVariable
+	endpoint := newQueryParams().set("force", "true").endpoint("/v1/var/" + v.Path)
+	wm, err := sv.client.write(endpoint, v, &out, qo)
+	if err != nil {
+		return nil, wm, err
+	}
+	return &out, wm, nil
+}
+
 // CheckedUpdate is used to updated a secure variable if the modify index
 // matches the one on the server.  If it does not, it will return an
 // ErrCASConflict that can be unwrapped for more details.
@@ -102,7 +118,10 @@ func (sv *SecureVariables) CheckedUpdate(v *SecureVariable, qo *WriteOptions) (*
 
 	v.Path = cleanPathString(v.Path)
 	var out SecureVariable
-	wm, err := sv.writeChecked("/v1/var/"+v.Path+"?cas="+fmt.Sprint(v.ModifyIndex), v, &out, qo)
+	endpoint := newQueryParams().
+		set("cas", strconv.FormatUint(v.ModifyIndex, 10)).
+		endpoint("/v1/var/" + v.Path)
+	wm, err := sv.writeChecked(endpoint, v, &out, qo)
 	if err != nil {
 		return nil, wm, err
 	}
@@ -114,7 +133,20 @@ func (sv *SecureVariables) CheckedUpdate(v *SecureVariable, qo *WriteOptions) (*
 func (sv *SecureVariables) Delete(path string, qo *WriteOptions) (*WriteMeta, error) {
 
 	path = cleanPathString(path)
-	wm, err := sv.deleteInternal(path, qo)
+	wm, err := sv.deleteInternal("/v1/var/"+path, qo)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// ForceDelete is used to delete a secure variable even if it has delete
+// protection. This requires a token with the delete-protected capability.
+func (sv *SecureVariables) ForceDelete(path string, qo *WriteOptions) (*WriteMeta, error) {
+
+	path = cleanPathString(path)
+	endpoint := newQueryParams().set("force", "true").endpoint("/v1/var/" + path)
+	wm, err := sv.deleteInternal(endpoint, qo)
 	if err != nil {
 		return nil, err
 	}
@@ -159,6 +191,229 @@ func (sv *SecureVariables) PrefixList(prefix string, qo *QueryOptions) ([]*Secur
 	return sv.List(qo)
 }
 
+// ListPages is used to list the secure variables, one page at a time. It
+// follows the NextToken of each response and calls fn with each page until
+// the last page is reached or fn returns false. The page size is set with
+// QueryOptions.PerPage.
+func (sv *SecureVariables) ListPages(qo *QueryOptions, fn func([]*SecureVariableMetadata, *QueryMeta) bool) error {
+	return listPages(qo, sv.List, fn)
+}
+
+// ReadPrefix is used to query all the secure variables under a path prefix,
+// including their items, in a single request. Secure variables the caller
+// isn't allowed to read are left out. Results are paginated using the
+// PerPage and NextToken query options.
+func (sv *SecureVariables) ReadPrefix(prefix string, qo *QueryOptions) ([]*SecureVariable, *QueryMeta, error) {
+
+	var opts QueryOptions
+	if qo != nil {
+		opts = *qo
+	}
+	opts.Prefix = prefix
+
+	var resp []*SecureVariable
+	qm, err := sv.client.query("/v1/vars/read", &resp, &opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// ReadPrefixPages is used to query the secure variables under a path prefix,
+// one page at a time, like ListPages.
+func (sv *SecureVariables) ReadPrefixPages(prefix string, qo *QueryOptions, fn func([]*SecureVariable, *QueryMeta) bool) error {
+	return listPages(qo, func(q *QueryOptions) ([]*SecureVariable, *QueryMeta, error) {
+		return sv.ReadPrefix(prefix, q)
+	}, fn)
+}
+
+// SecureVariablesSearchRequest describes the secure variables to return from
+// a search. All of the set criteria must match.
+type SecureVariablesSearchRequest struct {
+	// Prefix restricts the search to paths under the prefix. It is cheaper
+	// than Glob and should be used where possible.
+	Prefix string
+
+	// Glob is a pattern, in the syntax of Go's path.Match, that matching
+	// paths must satisfy. For example "app/*/db".
+	Glob string `json:",omitempty"`
+
+	// Meta are metadata key/value pairs that matching secure variables must
+	// all have.
+	Meta map[string]string `json:",omitempty"`
+}
+
+// Search returns the metadata of the secure variables matching the request.
+// Results are paginated using the PerPage and NextToken query options.
+func (sv *SecureVariables) Search(req *SecureVariablesSearchRequest, qo *QueryOptions) ([]*SecureVariableMetadata, *QueryMeta, error) {
+
+	var resp []*SecureVariableMetadata
+	qm, err := sv.client.putReadQuery("/v1/vars/search", req, &resp, qo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// SecureVariablesPurgeRequest describes the secure variables to delete with
+// a purge.
+type SecureVariablesPurgeRequest struct {
+	// Prefix is the path prefix of the secure variables to delete. It is
+	// required.
+	Prefix string
+
+	// Recurse deletes secure variables at any depth under the Prefix.
+	// Otherwise only those with no further "/" in their path after the
+	// Prefix are deleted.
+	Recurse bool
+
+	// DryRun returns the paths that would be deleted without deleting them.
+	DryRun bool
+
+	// Force also deletes the secure variables with delete protection, which
+	// requires the delete-protected capability. Otherwise they are skipped.
+	Force bool
+}
+
+// Purge deletes the secure variables matching the request and returns their
+// paths. The caller must be allowed to destroy all of them, otherwise none
+// are deleted.
+func (sv *SecureVariables) Purge(req *SecureVariablesPurgeRequest, qo *WriteOptions) ([]string, *WriteMeta, error) {
+
+	var resp []string
+	wm, err := sv.client.write("/v1/vars/purge", req, &resp, qo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, wm, nil
+}
+
+const (
+	// SecureVariablesTxnOpSet creates or replaces a secure variable.
+	SecureVariablesTxnOpSet = "set"
+
+	// SecureVariablesTxnOpCAS creates or replaces a secure variable if its
+	// ModifyIndex matches the one of the operation's variable.
+	SecureVariablesTxnOpCAS = "cas"
+
+	// SecureVariablesTxnOpDelete deletes a secure variable.
+	SecureVariablesTxnOpDelete = "delete"
+
+	// SecureVariablesTxnOpDeleteCAS deletes a secure variable if its
+	// ModifyIndex matches the one of the operation's variable.
+	SecureVariablesTxnOpDeleteCAS = "delete-cas"
+
+	// SecureVariablesTxnOpCheckIndex checks that the ModifyIndex of a secure
+	// variable matches the one of the operation's variable, without
+	// modifying it.
+	SecureVariablesTxnOpCheckIndex = "check-index"
+)
+
+// SecureVariablesTxnOp is a single operation of a secure variables
+// transaction.
+type SecureVariablesTxnOp struct {
+	// Op is one of the SecureVariablesTxnOp constants.
+	Op string
+
+	// Var is the secure variable to operate on. Only its Path, and its
+	// Namespace if it differs from the request's, are required for deletes
+	// and checks. Its ModifyIndex is the index checked by the cas,
+	// delete-cas and check-index operations, where 0 checks that the secure
+	// variable does not exist.
+	Var *SecureVariable
+}
+
+// SecureVariablesTxnResponse is the result of a secure variables
+// transaction.
+type SecureVariablesTxnResponse struct {
+	// Result is "ok" if all the operations were applied, or "conflict" or
+	// "conflict-redacted" if the check of one of them failed.
+	Result string
+
+	// Outputs holds the written secure variable of each set and cas
+	// operation of a successful transaction, and nil for other operations.
+	Outputs []*SecureVariable
+
+	// ConflictIndex is the position of the operation whose check failed
+	// when the transaction conflicted.
+	ConflictIndex int
+
+	// Conflict is the current value of the secure variable of the
+	// conflicting operation. Its Items are not set if the token can't read
+	// it.
+	Conflict *SecureVariable
+}
+
+// Txn applies the operations atomically: either all of them are applied, or
+// none are. If the check of an operation fails, it returns the response along
+// with an ErrCASConflict that can be unwrapped for more details.
+func (sv *SecureVariables) Txn(ops []*SecureVariablesTxnOp, qo *WriteOptions) (*SecureVariablesTxnResponse, *WriteMeta, error) {
+
+	for _, op := range ops {
+		if op.Var != nil {
+			op.Var.Path = cleanPathString(op.Var.Path)
+		}
+	}
+
+	r, err := sv.client.newRequest("PUT", "/v1/vars/txn")
+	if err != nil {
+		return nil, nil, err
+	}
+	r.setWriteOptions(qo)
+	r.obj = struct{ Ops []*SecureVariablesTxnOp }{Ops: ops}
+
+	checkFn := requireStatusIn(http.StatusOK, http.StatusConflict)
+	rtt, resp, err := checkFn(sv.client.doRequest(r))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	wm := &WriteMeta{RequestTime: rtt}
+	parseWriteMeta(resp, wm)
+
+	var out SecureVariablesTxnResponse
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode == http.StatusConflict {
+		return &out, wm, ErrCASConflict{
+			CheckIndex: ops[out.ConflictIndex].Var.ModifyIndex,
+			Conflict:   out.Conflict,
+			Redacted:   out.Result == "conflict-redacted",
+		}
+	}
+	return &out, wm, nil
+}
+
+// SecureVariablesIdentityToken is a short-lived token issued in exchange for
+// a workload identity, which can read the workload's secure variables.
+type SecureVariablesIdentityToken struct {
+	// Token is the signed token. Use it as the AuthToken of secure variable
+	// reads and lists.
+	Token string
+
+	// ExpiresAt is when the token stops being accepted. Exchange the token
+	// again before then to renew it.
+	ExpiresAt time.Time
+
+	// Paths are the secure variable path prefixes the token can read.
+	Paths []string
+}
+
+// ExchangeIdentity exchanges the workload identity used as the client's
+// token, such as the NOMAD_TOKEN given to tasks, for a short-lived token
+// scoped to reading the workload's secure variables.
+func (sv *SecureVariables) ExchangeIdentity(qo *WriteOptions) (*SecureVariablesIdentityToken, *WriteMeta, error) {
+
+	var resp SecureVariablesIdentityToken
+	wm, err := sv.client.write("/v1/vars/identity/exchange", nil, &resp, qo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
 // GetItems returns the inner Items collection from a secure variable at a
 // given path
 func (sv *SecureVariables) GetItems(path string, qo *QueryOptions) (*SecureVariableItems, *QueryMeta, error) {
@@ -174,6 +429,66 @@ func (sv *SecureVariables) GetItems(path string, qo *QueryOptions) (*SecureVaria
 	return &svar.Items, qm, nil
 }
 
+// SecureVariablesReplicationStatus is the status of the replication of
+// secure variables from another region.
+type SecureVariablesReplicationStatus struct {
+	// Region is the source region of the replicated secure variables
+	Region string
+
+	// Index is the index of the source region the replicas are up to date
+	// with, and Variables the number of secure variables replicated from it
+	Index     uint64
+	Variables int
+
+	// LastReplicated is the time of the last successful replication, and
+	// Lag the delay between the last write in the source region and its
+	// replication
+	LastReplicated time.Time
+	Lag            time.Duration
+
+	// Error is the error of the last replication attempt, if it failed
+	Error string
+}
+
+func (s *SecureVariablesReplicationStatus) MarshalJSON() ([]byte, error) {
+	type Alias SecureVariablesReplicationStatus
+	return json.Marshal(&struct {
+		LastReplicated unixNanos
+		*Alias
+	}{
+		LastReplicated: unixNanos(s.LastReplicated),
+		Alias:          (*Alias)(s),
+	})
+}
+
+func (s *SecureVariablesReplicationStatus) UnmarshalJSON(data []byte) error {
+	type Alias SecureVariablesReplicationStatus
+	aux := &struct {
+		LastReplicated unixNanos
+		*Alias
+	}{
+		Alias: (*Alias)(s),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	s.LastReplicated = time.Time(aux.LastReplicated)
+	return nil
+}
+
+// ReplicationStatus returns the status of the replication of secure
+// variables from each of the other regions, as tracked by the leader of the
+// region since it was elected.
+func (sv *SecureVariables) ReplicationStatus(qo *QueryOptions) ([]*SecureVariablesReplicationStatus, *QueryMeta, error) {
+
+	var resp []*SecureVariablesReplicationStatus
+	qm, err := sv.client.query("/v1/vars/replication", &resp, qo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
 // readInternal exists because the API's higher-level read method requires
 // the status code to be 200 (OK). For Peek(), we do not consider 404
 // (Not Found) an error.
@@ -212,9 +527,9 @@ func (sv *SecureVariables) readInternal(endpoint string, out **SecureVariable, q
 // readInternal exists because the API's higher-level delete method requires
 // the status code to be 200 (OK). The SV HTTP API returns a 204 (No Content)
 // on success.
-func (sv *SecureVariables) deleteInternal(path string, q *WriteOptions) (*WriteMeta, error) {
+func (sv *SecureVariables) deleteInternal(endpoint string, q *WriteOptions) (*WriteMeta, error) {
 
-	r, err := sv.client.newRequest("DELETE", fmt.Sprintf("/v1/var/%s", path))
+	r, err := sv.client.newRequest("DELETE", endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -237,7 +552,10 @@ func (sv *SecureVariables) deleteInternal(path string, q *WriteOptions) (*WriteM
 // success and a 409 (Conflict) on a CAS error.
 func (sv *SecureVariables) deleteChecked(path string, checkIndex uint64, q *WriteOptions) (*WriteMeta, error) {
 
-	r, err := sv.client.newRequest("DELETE", fmt.Sprintf("/v1/var/%s?cas=%v", path, checkIndex))
+	endpoint := newQueryParams().
+		set("cas", strconv.FormatUint(checkIndex, 10)).
+		endpoint("/v1/var/" + path)
+	r, err := sv.client.newRequest("DELETE", endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -254,15 +572,7 @@ func (sv *SecureVariables) deleteChecked(path string, checkIndex uint64, q *Writ
 	// The only reason we should decode the response body is if
 	// it is a conflict response. Otherwise, there won't be one.
 	if resp.StatusCode == http.StatusConflict {
-
-		conflict := new(SecureVariable)
-		if err := decodeBody(resp, &conflict); err != nil {
-			return nil, err
-		}
-		return nil, ErrCASConflict{
-			Conflict:   conflict,
-			CheckIndex: checkIndex,
-		}
+		return nil, decodeCASConflict(resp, checkIndex)
 	}
 	return wm, nil
 }
@@ -291,15 +601,7 @@ func (sv *SecureVariables) writeChecked(endpoint string, in *SecureVariable, out
 	parseWriteMeta(resp, wm)
 
 	if resp.StatusCode == http.StatusConflict {
-
-		conflict := new(SecureVariable)
-		if err := decodeBody(resp, &conflict); err != nil {
-			return nil, err
-		}
-		return nil, ErrCASConflict{
-			Conflict:   conflict,
-			CheckIndex: in.ModifyIndex,
-		}
+		return nil, decodeCASConflict(resp, in.ModifyIndex)
 	}
 	if out != nil {
 		if err := decodeBody(resp, &out); err != nil {
@@ -321,13 +623,59 @@ type SecureVariable struct {
 	CreateIndex uint64
 	ModifyIndex uint64
 
-	// Times provided as a convenience for operators expressed time.UnixNanos
-	CreateTime int64
-	ModifyTime int64
+	// Times provided as a convenience for operators. They are encoded in JSON
+	// as nanoseconds since the Unix epoch.
+	CreateTime time.Time
+	ModifyTime time.Time
+
+	// Meta is operator provided metadata, such as an owner, rotation date,
+	// or ticket link. It is stored unencrypted and returned when listing
+	// secure variables, so it must not contain sensitive material.
+	Meta map[string]string `json:",omitempty"`
+
+	// SourceRegion is the region the secure variable is replicated from. It
+	// is empty for secure variables written in this region. Replicas are
+	// read-only and can only be modified in their source region.
+	SourceRegion string `json:",omitempty"`
+
+	// DeleteProtection prevents the secure variable from being deleted
+	// unless the deletion is forced, which requires the delete-protected
+	// capability. Updates that don't keep the protection must be forced too.
+	DeleteProtection bool `json:",omitempty"`
 
 	Items SecureVariableItems
 }
 
+func (sv *SecureVariable) MarshalJSON() ([]byte, error) {
+	type Alias SecureVariable
+	return json.Marshal(&struct {
+		CreateTime unixNanos
+		ModifyTime unixNanos
+		*Alias
+	}{
+		CreateTime: unixNanos(sv.CreateTime),
+		ModifyTime: unixNanos(sv.ModifyTime),
+		Alias:      (*Alias)(sv),
+	})
+}
+
+func (sv *SecureVariable) UnmarshalJSON(data []byte) error {
+	type Alias SecureVariable
+	aux := &struct {
+		CreateTime unixNanos
+		ModifyTime unixNanos
+		*Alias
+	}{
+		Alias: (*Alias)(sv),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	sv.CreateTime = time.Time(aux.CreateTime)
+	sv.ModifyTime = time.Time(aux.ModifyTime)
+	return nil
+}
+
 // SecureVariableMetadata specifies the metadata for a secure variable and
 // is used as the list object
 type SecureVariableMetadata struct {
@@ -340,9 +688,56 @@ type SecureVariableMetadata struct {
 	CreateIndex uint64
 	ModifyIndex uint64
 
-	// Times provided as a convenience for operators expressed time.UnixNanos
-	CreateTime int64
-	ModifyTime int64
+	// Times provided as a convenience for operators. They are encoded in JSON
+	// as nanoseconds since the Unix epoch.
+	CreateTime time.Time
+	ModifyTime time.Time
+
+	// ItemCount is the number of items of the secure variable, and Size is
+	// the size in bytes of its encrypted data
+	ItemCount int
+	Size      int
+
+	// Meta is the operator provided metadata of the secure variable
+	Meta map[string]string `json:",omitempty"`
+
+	// SourceRegion is the region the secure variable is replicated from, or
+	// empty if it was written in this region
+	SourceRegion string `json:",omitempty"`
+
+	// DeleteProtection is set if the secure variable can only be deleted
+	// by forcing it
+	DeleteProtection bool `json:",omitempty"`
+}
+
+func (sv *SecureVariableMetadata) MarshalJSON() ([]byte, error) {
+	type Alias SecureVariableMetadata
+	return json.Marshal(&struct {
+		CreateTime unixNanos
+		ModifyTime unixNanos
+		*Alias
+	}{
+		CreateTime: unixNanos(sv.CreateTime),
+		ModifyTime: unixNanos(sv.ModifyTime),
+		Alias:      (*Alias)(sv),
+	})
+}
+
+func (sv *SecureVariableMetadata) UnmarshalJSON(data []byte) error {
+	type Alias SecureVariableMetadata
+	aux := &struct {
+		CreateTime unixNanos
+		ModifyTime unixNanos
+		*Alias
+	}{
+		Alias: (*Alias)(sv),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	sv.CreateTime = time.Time(aux.CreateTime)
+	sv.ModifyTime = time.Time(aux.ModifyTime)
+	return nil
 }
 
 type SecureVariableItems map[string]string
@@ -365,6 +760,12 @@ func (sv1 *SecureVariable) Copy() *SecureVariable {
 	for k, v := range sv1.Items {
 		out.Items[k] = v
 	}
+	if sv1.Meta != nil {
+		out.Meta = make(map[string]string, len(sv1.Meta))
+		for k, v := range sv1.Meta {
+			out.Meta[k] = v
+		}
+	}
 	return &out
 }
 
@@ -380,13 +781,21 @@ func (sv *SecureVariable) Metadata() *SecureVariableMetadata {
 		ModifyIndex: sv.ModifyIndex,
 		CreateTime:  sv.CreateTime,
 		ModifyTime:  sv.ModifyTime,
+		Meta:        sv.Meta,
+
+		SourceRegion:     sv.SourceRegion,
+		DeleteProtection: sv.DeleteProtection,
 	}
 }
 
 // IsZeroValue can be used to test if a SecureVariable has been changed
 // from the default values it gets at creation
 func (sv *SecureVariable) IsZeroValue() bool {
-	return *sv.Metadata() == SecureVariableMetadata{} && sv.Items == nil
+	md := sv.Metadata()
+	return md.Namespace == "" && md.Path == "" &&
+		md.CreateIndex == 0 && md.ModifyIndex == 0 &&
+		md.CreateTime.IsZero() && md.ModifyTime.IsZero() &&
+		md.Meta == nil && sv.Items == nil
 }
 
 // cleanPathString removes leading and trailing slashes since they
@@ -411,9 +820,43 @@ func (sv SecureVariable) AsPrettyJSON() string {
 	return string(b)
 }
 
+// ErrCASConflict is returned when the check of a checked write or delete of
+// a secure variable fails. Conflict is the secure variable's current value,
+// which can be compared with the expected and the intended values to merge
+// them.
 type ErrCASConflict struct {
 	CheckIndex uint64
 	Conflict   *SecureVariable
+
+	// Redacted is true if the token can't read the conflicting secure
+	// variable, in which case only its metadata is set.
+	Redacted bool
+}
+
+// decodeCASConflict decodes the body of a 409 Conflict response to a checked
+// write or delete into an ErrCASConflict. The body is the conflicting secure
+// variable along with whether it was redacted.
+func decodeCASConflict(resp *http.Response, checkIndex uint64) error {
+	buf, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// The secure variable is decoded on its own, as its custom unmarshaling
+	// would ignore the other fields of the body.
+	conflict := new(SecureVariable)
+	if err := json.Unmarshal(buf, conflict); err != nil {
+		return err
+	}
+	var check struct{ Redacted bool }
+	if err := json.Unmarshal(buf, &check); err != nil {
+		return err
+	}
+	return ErrCASConflict{
+		CheckIndex: checkIndex,
+		Conflict:   conflict,
+		Redacted:   check.Redacted,
+	}
 }
 
 func (e ErrCASConflict) Error() string {
@@ -451,8 +894,5 @@ func requireStatusIn(statuses ...int) doRequestWrapper {
 // when the the API client's newRequest method receives an unexpected
 // HTTP response code when accessing the secure variable's HTTP API
 func generateUnexpectedResponseCodeError(resp *http.Response) error {
-	var buf bytes.Buffer
-	io.Copy(&buf, resp.Body)
-	resp.Body.Close()
-	return fmt.Errorf("Unexpected response code: %d (%s)", resp.StatusCode, buf.Bytes())
+	return newAPIErrorFromResponse(resp)
 }
@@ -2,6 +2,9 @@ package api
 
 import (
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -52,6 +55,14 @@ func TestSecureVariables_SimpleCRUD(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	// listed returns the metadata the server lists a secure variable with,
+	// which includes its item count and the size of its encrypted data
+	listed := func(sv *SecureVariable, size int) *SecureVariableMetadata {
+		m := sv.Metadata()
+		m.ItemCount, m.Size = len(sv.Items), size
+		return m
+	}
+
 	// TODO: Need to prevent no-op modifications from happening server-side
 	// t.Run("3 update sv1 no change", func(t *testing.T) {
 
@@ -79,7 +90,7 @@ func TestSecureVariables_SimpleCRUD(t *testing.T) {
 		l, _, err := nsv.List(nil)
 		require.NoError(t, err)
 		require.Len(t, l, 2)
-		require.ElementsMatch(t, []*SecureVariableMetadata{sv1.Metadata(), sv2.Metadata()}, l)
+		require.ElementsMatch(t, []*SecureVariableMetadata{listed(sv1, 71), listed(sv2, 59)}, l)
 	})
 
 	t.Run("5a list vars opts", func(t *testing.T) {
@@ -89,7 +100,7 @@ func TestSecureVariables_SimpleCRUD(t *testing.T) {
 		l, qm, err := nsv.List(&QueryOptions{PerPage: 1})
 		require.NoError(t, err)
 		require.Len(t, l, 1)
-		require.Equal(t, sv1.Metadata(), l[0])
+		require.Equal(t, listed(sv1, 71), l[0])
 		require.NotNil(t, qm.NextToken)
 	})
 
@@ -98,7 +109,7 @@ func TestSecureVariables_SimpleCRUD(t *testing.T) {
 		l, _, err := nsv.PrefixList("my", nil)
 		require.NoError(t, err)
 		require.Len(t, l, 1)
-		require.Equal(t, sv1.Metadata(), l[0])
+		require.Equal(t, listed(sv1, 71), l[0])
 	})
 
 	t.Run("6 delete sv1", func(t *testing.T) {
@@ -159,6 +170,8 @@ func TestSecureVariables_CRUDWithCAS(t *testing.T) {
 	var conflictErr ErrCASConflict
 	require.ErrorAs(t, err, &conflictErr)
 	require.Equal(t, nowVal, conflictErr.Conflict)
+	require.Equal(t, sv1.ModifyIndex, conflictErr.CheckIndex)
+	require.False(t, conflictErr.Redacted)
 
 	// Delete CAS: try to delete sv1 at old ModifyIndex; should
 	// return an ErrCASConflict. Check Conflict.
@@ -252,3 +265,206 @@ func TestSecureVariable_CreateReturnsContent(t *testing.T) {
 	require.NotNil(t, sv1n)
 	require.Equal(t, sv1.Items, sv1n.Items)
 }
+
+func TestSecureVariables_Search(t *testing.T) {
+	testutil.Parallel(t)
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+
+	nsv := c.SecureVariables()
+	for path, owner := range map[string]string{
+		"app/web/db":    "alice",
+		"app/api/db":    "bob",
+		"app/api/cache": "alice",
+	} {
+		sv := NewSecureVariable(path)
+		sv.Items["k1"] = "v1"
+		sv.Meta = map[string]string{"owner": owner}
+		_, _, err := nsv.Create(sv, nil)
+		require.NoError(t, err)
+	}
+
+	paths := func(svs []*SecureVariableMetadata) []string {
+		out := make([]string, 0, len(svs))
+		for _, sv := range svs {
+			out = append(out, sv.Path)
+		}
+		return out
+	}
+
+	svs, _, err := nsv.Search(&SecureVariablesSearchRequest{Glob: "app/*/db"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"app/api/db", "app/web/db"}, paths(svs))
+
+	svs, qm, err := nsv.Search(&SecureVariablesSearchRequest{
+		Prefix: "app",
+		Meta:   map[string]string{"owner": "alice"},
+	}, &QueryOptions{PerPage: 1})
+	require.NoError(t, err)
+	require.Equal(t, []string{"app/api/cache"}, paths(svs))
+	require.Equal(t, "alice", svs[0].Meta["owner"])
+	require.NotEmpty(t, qm.NextToken)
+
+	_, _, err = nsv.Search(&SecureVariablesSearchRequest{Glob: "app/["}, nil)
+	require.ErrorContains(t, err, "invalid glob pattern")
+}
+
+func TestSecureVariables_ReadPrefix(t *testing.T) {
+	testutil.Parallel(t)
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+
+	nsv := c.SecureVariables()
+	for _, path := range []string{"app/web/db", "app/api/db", "app/api/cache", "other/db"} {
+		sv := NewSecureVariable(path)
+		sv.Items["path"] = path
+		_, _, err := nsv.Create(sv, nil)
+		require.NoError(t, err)
+	}
+
+	svs, _, err := nsv.ReadPrefix("app/", nil)
+	require.NoError(t, err)
+	require.Len(t, svs, 3)
+	for _, sv := range svs {
+		require.Equal(t, sv.Path, sv.Items["path"])
+	}
+
+	// The caller's query options are left untouched
+	qo := &QueryOptions{PerPage: 2}
+	var paths []string
+	var pages int
+	err = nsv.ReadPrefixPages("app/api", qo, func(page []*SecureVariable, _ *QueryMeta) bool {
+		pages++
+		for _, sv := range page {
+			paths = append(paths, sv.Path)
+		}
+		return true
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"app/api/cache", "app/api/db"}, paths)
+	require.Equal(t, 1, pages)
+	require.Empty(t, qo.Prefix)
+
+	svs, _, err = nsv.ReadPrefix("nope/", nil)
+	require.NoError(t, err)
+	require.Empty(t, svs)
+}
+
+func TestSecureVariables_DeleteProtection(t *testing.T) {
+	testutil.Parallel(t)
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+
+	nsv := c.SecureVariables()
+	sv := NewSecureVariable("protected")
+	sv.Items["foo"] = "bar"
+	sv.DeleteProtection = true
+	out, _, err := nsv.Create(sv, nil)
+	require.NoError(t, err)
+	require.True(t, out.DeleteProtection)
+
+	// Deletes and updates removing the protection must be forced
+	_, err = nsv.Delete("protected", nil)
+	require.ErrorContains(t, err, "delete protected")
+
+	sv.DeleteProtection = false
+	_, _, err = nsv.Update(sv, nil)
+	require.ErrorContains(t, err, "delete protected")
+
+	out, _, err = nsv.ForceUpdate(sv, nil)
+	require.NoError(t, err)
+	require.False(t, out.DeleteProtection)
+
+	sv.DeleteProtection = true
+	_, _, err = nsv.Update(sv, nil)
+	require.NoError(t, err)
+
+	_, err = nsv.ForceDelete("protected", nil)
+	require.NoError(t, err)
+
+	_, _, err = nsv.Read("protected", nil)
+	require.EqualError(t, err, ErrVariableNotFound)
+}
+
+func TestSecureVariables_Txn(t *testing.T) {
+	testutil.Parallel(t)
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+
+	nsv := c.SecureVariables()
+	key := &SecureVariable{Path: "creds/key", Items: SecureVariableItems{"key": "k1"}}
+	secret := &SecureVariable{Path: "creds/secret", Items: SecureVariableItems{"secret": "s1"}}
+
+	// Create both variables, failing if either exists
+	out, wm, err := nsv.Txn([]*SecureVariablesTxnOp{
+		{Op: SecureVariablesTxnOpCAS, Var: key},
+		{Op: SecureVariablesTxnOpCAS, Var: secret},
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "ok", out.Result)
+	require.Len(t, out.Outputs, 2)
+	require.Equal(t, wm.LastIndex, out.Outputs[0].ModifyIndex)
+	require.Equal(t, secret.Items, out.Outputs[1].Items)
+	created := out.Outputs[1]
+
+	// A stale index conflicts and leaves both variables unchanged
+	staleKey := key.Copy()
+	staleKey.Items["key"] = "k2"
+	staleKey.ModifyIndex = out.Outputs[0].ModifyIndex
+	staleSecret := secret.Copy()
+	staleSecret.Items["secret"] = "s2"
+	staleSecret.ModifyIndex = out.Outputs[1].ModifyIndex - 1
+	out, _, err = nsv.Txn([]*SecureVariablesTxnOp{
+		{Op: SecureVariablesTxnOpCAS, Var: staleKey},
+		{Op: SecureVariablesTxnOpCAS, Var: staleSecret},
+	}, nil)
+	var conflictErr ErrCASConflict
+	require.ErrorAs(t, err, &conflictErr)
+	require.Equal(t, staleSecret.ModifyIndex, conflictErr.CheckIndex)
+	require.Equal(t, created, conflictErr.Conflict)
+	require.False(t, conflictErr.Redacted)
+	require.Equal(t, 1, out.ConflictIndex)
+
+	got, _, err := nsv.Read("creds/key", nil)
+	require.NoError(t, err)
+	require.Equal(t, "k1", got.Items["key"])
+
+	// Delete both variables
+	out, _, err = nsv.Txn([]*SecureVariablesTxnOp{
+		{Op: SecureVariablesTxnOpDelete, Var: &SecureVariable{Path: "creds/key"}},
+		{Op: SecureVariablesTxnOpDeleteCAS, Var: created},
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []*SecureVariable{nil, nil}, out.Outputs)
+}
+
+func TestSecureVariables_decodeCASConflict(t *testing.T) {
+	testutil.Parallel(t)
+
+	body := `{
+  "Namespace": "default",
+  "Path": "a/b/c",
+  "CreateIndex": 10,
+  "ModifyIndex": 12,
+  "CreateTime": 1665914531402211000,
+  "ModifyTime": 1665914562131027000,
+  "Items": null,
+  "CheckIndex": 11,
+  "Redacted": true
+}`
+	resp := &http.Response{
+		StatusCode: http.StatusConflict,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+
+	err := decodeCASConflict(resp, 11)
+	var conflictErr ErrCASConflict
+	require.ErrorAs(t, err, &conflictErr)
+	require.Equal(t, uint64(11), conflictErr.CheckIndex)
+	require.True(t, conflictErr.Redacted)
+	require.Equal(t, "a/b/c", conflictErr.Conflict.Path)
+	require.Equal(t, uint64(12), conflictErr.Conflict.ModifyIndex)
+	require.Equal(t, time.Unix(0, 1665914562131027000), conflictErr.Conflict.ModifyTime)
+	require.Nil(t, conflictErr.Conflict.Items)
+	require.EqualError(t, err, "cas conflict: expected ModifyIndex 11; found 12")
+}
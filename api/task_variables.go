@@ -0,0 +1,178 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// TaskVariablesSocket is the path of the secure variables socket relative
+	// to the allocation directory of a task, which is exposed to the task as
+	// NOMAD_ALLOC_DIR.
+	TaskVariablesSocket = "tmp/nomad_variables.sock"
+)
+
+// TaskVariables is a client for the secure variables socket that the Nomad
+// client exposes to the tasks of an allocation when secure_variables_socket
+// is enabled in its configuration. It is meant to be used from within a task,
+// authenticating with the workload identity of the task.
+type TaskVariables struct {
+	httpClient *http.Client
+	token      string
+}
+
+// NewTaskVariables returns a client for the secure variables socket at
+// socketPath, authenticating with the given workload identity token.
+func NewTaskVariables(socketPath, token string) *TaskVariables {
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socketPath)
+		},
+	}
+	return &TaskVariables{
+		httpClient: &http.Client{Transport: transport},
+		token:      token,
+	}
+}
+
+// NewTaskVariablesFromEnv returns a client for the secure variables socket of
+// the allocation of the current task, using the NOMAD_ALLOC_DIR and
+// NOMAD_TOKEN environment variables of the task.
+func NewTaskVariablesFromEnv() (*TaskVariables, error) {
+	allocDir := os.Getenv("NOMAD_ALLOC_DIR")
+	if allocDir == "" {
+		return nil, errors.New("NOMAD_ALLOC_DIR is not set")
+	}
+	token := os.Getenv("NOMAD_TOKEN")
+	if token == "" {
+		return nil, errors.New("NOMAD_TOKEN is not set")
+	}
+	return NewTaskVariables(filepath.Join(allocDir, TaskVariablesSocket), token), nil
+}
+
+// SecureVariableChange is a change of a secure variable a task subscribed to.
+// Patch is a JSON patch (RFC 6902) which transforms the items of the variable
+// as of the previous change into its current items. The first change of a
+// subscription patches an empty set of items.
+type SecureVariableChange struct {
+	Namespace string
+	Path      string
+	Index     uint64
+
+	// Deleted is true when the variable doesn't exist.
+	Deleted bool
+
+	Patch []*JSONPatchOperation
+
+	// Err is set when the subscription failed, and is the last value sent
+	// on the channel.
+	Err error `json:"-"`
+}
+
+// JSONPatchOperation is an operation of a JSON patch.
+type JSONPatchOperation struct {
+	Op    string  `json:"op"`
+	Path  string  `json:"path"`
+	Value *string `json:"value,omitempty"`
+}
+
+// IsHeartbeat specifies if the change is an empty heartbeat used to keep the
+// subscription alive.
+func (c *SecureVariableChange) IsHeartbeat() bool {
+	return c.Path == "" && c.Err == nil
+}
+
+// Apply applies the patch of the change to items, which must hold the items
+// of the variable as of the previous change. Items are modified in place.
+func (c *SecureVariableChange) Apply(items SecureVariableItems) (SecureVariableItems, error) {
+	if items == nil {
+		items = make(SecureVariableItems)
+	}
+	for _, op := range c.Patch {
+		if !strings.HasPrefix(op.Path, "/") {
+			return nil, fmt.Errorf("invalid JSON patch path %q", op.Path)
+		}
+		key := jsonPointerUnescaper.Replace(op.Path[1:])
+
+		switch op.Op {
+		case "add", "replace":
+			if op.Value == nil {
+				return nil, fmt.Errorf("missing value of JSON patch %s operation on %q", op.Op, op.Path)
+			}
+			if _, ok := items[key]; !ok && op.Op == "replace" {
+				return nil, fmt.Errorf("cannot replace missing item %q", key)
+			}
+			items[key] = *op.Value
+		case "remove":
+			if _, ok := items[key]; !ok {
+				return nil, fmt.Errorf("cannot remove missing item %q", key)
+			}
+			delete(items, key)
+		default:
+			return nil, fmt.Errorf("unsupported JSON patch operation %q", op.Op)
+		}
+	}
+	return items, nil
+}
+
+// jsonPointerUnescaper unescapes a JSON pointer (RFC 6901) token.
+var jsonPointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// Subscribe subscribes to the changes of the secure variable at path, which
+// the workload identity of the task must be allowed to read. Changes are sent
+// to the returned channel until the context is cancelled or the subscription
+// fails, in which case a change with Err set is sent last.
+func (tv *TaskVariables) Subscribe(ctx context.Context, path string) (<-chan *SecureVariableChange, error) {
+	endpoint := newQueryParams().
+		set("path", cleanPathString(path)).
+		endpoint("http://nomad/v1/var/subscribe")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Nomad-Token", tv.token)
+
+	resp, err := tv.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIErrorFromResponse(resp)
+	}
+
+	changesCh := make(chan *SecureVariableChange, 10)
+	go func() {
+		defer resp.Body.Close()
+		defer close(changesCh)
+
+		dec := json.NewDecoder(resp.Body)
+		for ctx.Err() == nil {
+			var change SecureVariableChange
+			if err := dec.Decode(&change); err != nil {
+				change = SecureVariableChange{Err: err}
+			}
+			if change.IsHeartbeat() {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case changesCh <- &change:
+			}
+			if change.Err != nil {
+				return
+			}
+		}
+	}()
+
+	return changesCh, nil
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/api/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecureVariableChange_Apply(t *testing.T) {
+	testutil.Parallel(t)
+
+	value := func(s string) *string { return &s }
+
+	first := &SecureVariableChange{
+		Path: "nomad/jobs/web",
+		Patch: []*JSONPatchOperation{
+			{Op: "add", Path: "/pass~1word", Value: value("one")},
+			{Op: "add", Path: "/user", Value: value("admin")},
+		},
+	}
+	items, err := first.Apply(nil)
+	require.NoError(t, err)
+	require.Equal(t, SecureVariableItems{"pass/word": "one", "user": "admin"}, items)
+
+	second := &SecureVariableChange{
+		Path: "nomad/jobs/web",
+		Patch: []*JSONPatchOperation{
+			{Op: "replace", Path: "/pass~1word", Value: value("two")},
+			{Op: "remove", Path: "/user"},
+		},
+	}
+	items, err = second.Apply(items)
+	require.NoError(t, err)
+	require.Equal(t, SecureVariableItems{"pass/word": "two"}, items)
+
+	// Patches must apply to the items of the previous change
+	_, err = second.Apply(items)
+	require.EqualError(t, err, `cannot remove missing item "user"`)
+
+	invalid := &SecureVariableChange{
+		Patch: []*JSONPatchOperation{{Op: "move", Path: "/user"}},
+	}
+	_, err = invalid.Apply(items)
+	require.EqualError(t, err, `unsupported JSON patch operation "move"`)
+}
+
+func TestTaskVariables_Subscribe_Error(t *testing.T) {
+	testutil.Parallel(t)
+
+	socketPath := filepath.Join(t.TempDir(), "vars.sock")
+	l, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("Permission denied\n"))
+	}))
+	ts.Listener = l
+	ts.Start()
+	defer ts.Close()
+
+	tv := NewTaskVariables(socketPath, "token")
+	_, err = tv.Subscribe(context.Background(), "nomad/jobs/web")
+	require.Error(t, err)
+
+	apiErr, ok := UnwrapAPIError(err)
+	require.True(t, ok, err)
+	require.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	require.Contains(t, err.Error(), "Permission denied")
+}
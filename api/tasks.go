@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"path"
 	"path/filepath"
@@ -52,16 +53,68 @@ type ResourceUsage struct {
 // and the resource usage of the individual pids
 type TaskResourceUsage struct {
 	ResourceUsage *ResourceUsage
-	Timestamp     int64
+	Timestamp     time.Time
 	Pids          map[string]*ResourceUsage
 }
 
+func (u *TaskResourceUsage) MarshalJSON() ([]byte, error) {
+	type Alias TaskResourceUsage
+	return json.Marshal(&struct {
+		Timestamp unixNanos
+		*Alias
+	}{
+		Timestamp: unixNanos(u.Timestamp),
+		Alias:     (*Alias)(u),
+	})
+}
+
+func (u *TaskResourceUsage) UnmarshalJSON(data []byte) error {
+	type Alias TaskResourceUsage
+	aux := &struct {
+		Timestamp unixNanos
+		*Alias
+	}{
+		Alias: (*Alias)(u),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	u.Timestamp = time.Time(aux.Timestamp)
+	return nil
+}
+
 // AllocResourceUsage holds the aggregated task resource usage of the
 // allocation.
 type AllocResourceUsage struct {
 	ResourceUsage *ResourceUsage
 	Tasks         map[string]*TaskResourceUsage
-	Timestamp     int64
+	Timestamp     time.Time
+}
+
+func (u *AllocResourceUsage) MarshalJSON() ([]byte, error) {
+	type Alias AllocResourceUsage
+	return json.Marshal(&struct {
+		Timestamp unixNanos
+		*Alias
+	}{
+		Timestamp: unixNanos(u.Timestamp),
+		Alias:     (*Alias)(u),
+	})
+}
+
+func (u *AllocResourceUsage) UnmarshalJSON(data []byte) error {
+	type Alias AllocResourceUsage
+	aux := &struct {
+		Timestamp unixNanos
+		*Alias
+	}{
+		Alias: (*Alias)(u),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	u.Timestamp = time.Time(aux.Timestamp)
+	return nil
 }
 
 // AllocCheckStatus contains the current status of a nomad service discovery check.
@@ -75,7 +128,35 @@ type AllocCheckStatus struct {
 	Task       string
 	Status     string
 	StatusCode int
-	Timestamp  int64
+	Timestamp  time.Time
+}
+
+// MarshalJSON uses a value receiver because AllocCheckStatus is stored by
+// value in AllocCheckStatuses, whose elements are not addressable.
+func (s AllocCheckStatus) MarshalJSON() ([]byte, error) {
+	type Alias AllocCheckStatus
+	return json.Marshal(&struct {
+		Timestamp unixSeconds
+		*Alias
+	}{
+		Timestamp: unixSeconds(s.Timestamp),
+		Alias:     (*Alias)(&s),
+	})
+}
+
+func (s *AllocCheckStatus) UnmarshalJSON(data []byte) error {
+	type Alias AllocCheckStatus
+	aux := &struct {
+		Timestamp unixSeconds
+		*Alias
+	}{
+		Alias: (*Alias)(s),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	s.Timestamp = time.Time(aux.Timestamp)
+	return nil
 }
 
 // AllocCheckStatuses holds the set of nomad service discovery checks within
@@ -106,6 +187,13 @@ func (r *RestartPolicy) Merge(rp *RestartPolicy) {
 	}
 }
 
+// RestartBudget limits the number of restarts shared by all the tasks of an
+// allocation within a rolling interval.
+type RestartBudget struct {
+	Attempts *int           `hcl:"attempts,optional"`
+	Interval *time.Duration `hcl:"interval,optional"`
+}
+
 // Reschedule configures how Tasks are rescheduled  when they crash or fail.
 type ReschedulePolicy struct {
 	// Attempts limits the number of rescheduling attempts that can occur in an interval.
@@ -305,6 +393,14 @@ func (s *Spread) Canonicalize() {
 	}
 }
 
+// MaxClientDisconnectTier overrides the task group's max_client_disconnect for
+// allocations placed on nodes where Attribute resolves to Value.
+type MaxClientDisconnectTier struct {
+	Attribute string         `hcl:"attribute,optional"`
+	Value     string         `hcl:"value,optional"`
+	Duration  *time.Duration `mapstructure:"duration" hcl:"duration,optional"`
+}
+
 // EphemeralDisk is an ephemeral disk object
 type EphemeralDisk struct {
 	Sticky  *bool `hcl:"sticky,optional"`
@@ -432,26 +528,29 @@ func (vm *VolumeMount) Canonicalize() {
 
 // TaskGroup is the unit of scheduling.
 type TaskGroup struct {
-	Name                      *string                   `hcl:"name,label"`
-	Count                     *int                      `hcl:"count,optional"`
-	Constraints               []*Constraint             `hcl:"constraint,block"`
-	Affinities                []*Affinity               `hcl:"affinity,block"`
-	Tasks                     []*Task                   `hcl:"task,block"`
-	Spreads                   []*Spread                 `hcl:"spread,block"`
-	Volumes                   map[string]*VolumeRequest `hcl:"volume,block"`
-	RestartPolicy             *RestartPolicy            `hcl:"restart,block"`
-	ReschedulePolicy          *ReschedulePolicy         `hcl:"reschedule,block"`
-	EphemeralDisk             *EphemeralDisk            `hcl:"ephemeral_disk,block"`
-	Update                    *UpdateStrategy           `hcl:"update,block"`
-	Migrate                   *MigrateStrategy          `hcl:"migrate,block"`
-	Networks                  []*NetworkResource        `hcl:"network,block"`
-	Meta                      map[string]string         `hcl:"meta,block"`
-	Services                  []*Service                `hcl:"service,block"`
-	ShutdownDelay             *time.Duration            `mapstructure:"shutdown_delay" hcl:"shutdown_delay,optional"`
-	StopAfterClientDisconnect *time.Duration            `mapstructure:"stop_after_client_disconnect" hcl:"stop_after_client_disconnect,optional"`
-	MaxClientDisconnect       *time.Duration            `mapstructure:"max_client_disconnect" hcl:"max_client_disconnect,optional"`
-	Scaling                   *ScalingPolicy            `hcl:"scaling,block"`
-	Consul                    *Consul                   `hcl:"consul,block"`
+	Name                      *string                    `hcl:"name,label"`
+	Count                     *int                       `hcl:"count,optional"`
+	Constraints               []*Constraint              `hcl:"constraint,block"`
+	Affinities                []*Affinity                `hcl:"affinity,block"`
+	Tasks                     []*Task                    `hcl:"task,block"`
+	Spreads                   []*Spread                  `hcl:"spread,block"`
+	Volumes                   map[string]*VolumeRequest  `hcl:"volume,block"`
+	RestartPolicy             *RestartPolicy             `hcl:"restart,block"`
+	RestartBudget             *RestartBudget             `hcl:"restart_budget,block"`
+	ReschedulePolicy          *ReschedulePolicy          `hcl:"reschedule,block"`
+	EphemeralDisk             *EphemeralDisk             `hcl:"ephemeral_disk,block"`
+	Update                    *UpdateStrategy            `hcl:"update,block"`
+	Migrate                   *MigrateStrategy           `hcl:"migrate,block"`
+	Networks                  []*NetworkResource         `hcl:"network,block"`
+	Meta                      map[string]string          `hcl:"meta,block"`
+	Services                  []*Service                 `hcl:"service,block"`
+	ShutdownDelay             *time.Duration             `mapstructure:"shutdown_delay" hcl:"shutdown_delay,optional"`
+	StopAfterClientDisconnect *time.Duration             `mapstructure:"stop_after_client_disconnect" hcl:"stop_after_client_disconnect,optional"`
+	MaxClientDisconnect       *time.Duration             `mapstructure:"max_client_disconnect" hcl:"max_client_disconnect,optional"`
+	MaxClientDisconnectJitter *time.Duration             `mapstructure:"max_client_disconnect_jitter" hcl:"max_client_disconnect_jitter,optional"`
+	MaxClientDisconnectTiers  []*MaxClientDisconnectTier `hcl:"max_client_disconnect_tier,block"`
+	Scaling                   *ScalingPolicy             `hcl:"scaling,block"`
+	Consul                    *Consul                    `hcl:"consul,block"`
 }
 
 // NewTaskGroup creates a new TaskGroup.
@@ -676,12 +775,31 @@ func (l *TaskLifecycle) Empty() bool {
 	return l == nil || (l.Hook == "")
 }
 
+// TaskCheckpoint configures the checkpointing of a task during a planned
+// disconnect of its node, for drivers that support it.
+type TaskCheckpoint struct {
+	Timeout *time.Duration `mapstructure:"timeout" hcl:"timeout,optional"`
+}
+
+func (c *TaskCheckpoint) Canonicalize() {
+	if c.Timeout == nil {
+		c.Timeout = pointerOf(1 * time.Minute)
+	}
+}
+
+// WorkloadIdentity configures how a task's workload identity is exposed to
+// the task.
+type WorkloadIdentity struct {
+	Env bool `mapstructure:"env" hcl:"env,optional"`
+}
+
 // Task is a single process in a task group.
 type Task struct {
 	Name            string                 `hcl:"name,label"`
 	Driver          string                 `hcl:"driver,optional"`
 	User            string                 `hcl:"user,optional"`
 	Lifecycle       *TaskLifecycle         `hcl:"lifecycle,block"`
+	Checkpoint      *TaskCheckpoint        `hcl:"checkpoint,block"`
 	Config          map[string]interface{} `hcl:"config,block"`
 	Constraints     []*Constraint          `hcl:"constraint,block"`
 	Affinities      []*Affinity            `hcl:"affinity,block"`
@@ -694,6 +812,7 @@ type Task struct {
 	LogConfig       *LogConfig             `mapstructure:"logs" hcl:"logs,block"`
 	Artifacts       []*TaskArtifact        `hcl:"artifact,block"`
 	Vault           *Vault                 `hcl:"vault,block"`
+	Identity        *WorkloadIdentity      `hcl:"identity,block"`
 	Templates       []*Template            `hcl:"template,block"`
 	DispatchPayload *DispatchPayloadConfig `hcl:"dispatch_payload,block"`
 	VolumeMounts    []*VolumeMount         `hcl:"volume_mount,block"`
@@ -743,6 +862,9 @@ func (t *Task) Canonicalize(tg *TaskGroup, job *Job) {
 	if t.CSIPluginConfig != nil {
 		t.CSIPluginConfig.Canonicalize()
 	}
+	if t.Checkpoint != nil {
+		t.Checkpoint.Canonicalize()
+	}
 	if t.RestartPolicy == nil {
 		t.RestartPolicy = tg.RestartPolicy
 	} else {
@@ -847,6 +969,9 @@ type Template struct {
 	Envvars      *bool          `mapstructure:"env" hcl:"env,optional"`
 	VaultGrace   *time.Duration `mapstructure:"vault_grace" hcl:"vault_grace,optional"`
 	Wait         *WaitConfig    `mapstructure:"wait" hcl:"wait,block"`
+	MaxStale     *time.Duration `mapstructure:"max_stale" hcl:"max_stale,optional"`
+	StaleMode    *string        `mapstructure:"stale_mode" hcl:"stale_mode,optional"`
+	StaleSignal  *string        `mapstructure:"stale_signal" hcl:"stale_signal,optional"`
 }
 
 func (tmpl *Template) Canonicalize() {
@@ -890,6 +1015,22 @@ func (tmpl *Template) Canonicalize() {
 	if tmpl.Envvars == nil {
 		tmpl.Envvars = pointerOf(false)
 	}
+	if tmpl.MaxStale == nil {
+		tmpl.MaxStale = pointerOf(time.Duration(0))
+	}
+	if tmpl.StaleMode == nil {
+		tmpl.StaleMode = pointerOf("fail")
+	}
+	if tmpl.StaleSignal == nil {
+		if *tmpl.StaleMode == "signal" {
+			tmpl.StaleSignal = pointerOf("SIGHUP")
+		} else {
+			tmpl.StaleSignal = pointerOf("")
+		}
+	} else {
+		sig := *tmpl.StaleSignal
+		tmpl.StaleSignal = pointerOf(strings.ToUpper(sig))
+	}
 
 	//COMPAT(0.12) VaultGrace is deprecated and unused as of Vault 0.5
 	if tmpl.VaultGrace == nil {
@@ -1021,7 +1162,7 @@ const (
 // appropriate to the events type.
 type TaskEvent struct {
 	Type           string
-	Time           int64
+	Time           time.Time
 	DisplayMessage string
 	Details        map[string]string
 	Message        string
@@ -1048,6 +1189,32 @@ type TaskEvent struct {
 	GenericSource    string
 }
 
+func (e *TaskEvent) MarshalJSON() ([]byte, error) {
+	type Alias TaskEvent
+	return json.Marshal(&struct {
+		Time unixNanos
+		*Alias
+	}{
+		Time:  unixNanos(e.Time),
+		Alias: (*Alias)(e),
+	})
+}
+
+func (e *TaskEvent) UnmarshalJSON(data []byte) error {
+	type Alias TaskEvent
+	aux := &struct {
+		Time unixNanos
+		*Alias
+	}{
+		Alias: (*Alias)(e),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	e.Time = time.Time(aux.Time)
+	return nil
+}
+
 // CSIPluginType is an enum string that encapsulates the valid options for a
 // CSIPlugin stanza's Type. These modes will allow the plugin to be used in
 // different ways by the client.
@@ -408,6 +408,48 @@ func TestTask_Canonicalize_TaskLifecycle(t *testing.T) {
 	}
 }
 
+func TestTask_Canonicalize_Checkpoint(t *testing.T) {
+	testutil.Parallel(t)
+	testCases := []struct {
+		name     string
+		expected *TaskCheckpoint
+		task     *Task
+	}{
+		{
+			name:     "none",
+			task:     &Task{},
+			expected: nil,
+		},
+		{
+			name: "default timeout",
+			task: &Task{
+				Checkpoint: &TaskCheckpoint{},
+			},
+			expected: &TaskCheckpoint{Timeout: pointerOf(time.Minute)},
+		},
+		{
+			name: "timeout",
+			task: &Task{
+				Checkpoint: &TaskCheckpoint{Timeout: pointerOf(5 * time.Minute)},
+			},
+			expected: &TaskCheckpoint{Timeout: pointerOf(5 * time.Minute)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tg := &TaskGroup{
+				Name: pointerOf("foo"),
+			}
+			j := &Job{
+				ID: pointerOf("test"),
+			}
+			tc.task.Canonicalize(tg, j)
+			require.Equal(t, tc.expected, tc.task.Checkpoint)
+		})
+	}
+}
+
 func TestTask_Template_WaitConfig_Canonicalize_and_Copy(t *testing.T) {
 	testutil.Parallel(t)
 	taskWithWait := func(wc *WaitConfig) *Task {
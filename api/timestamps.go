@@ -0,0 +1,101 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// unixNanos is a time.Time which is encoded in JSON as an integer count of
+// nanoseconds since the Unix epoch, the representation used by the Nomad HTTP
+// API for most timestamps. Decoding also accepts RFC3339 strings so that
+// objects which have been re-encoded by other tools can still be read.
+type unixNanos time.Time
+
+func (t unixNanos) MarshalJSON() ([]byte, error) {
+	return encodeUnixTime(time.Time(t), time.Nanosecond), nil
+}
+
+func (t *unixNanos) UnmarshalJSON(data []byte) error {
+	v, err := decodeUnixTime(data, time.Nanosecond)
+	if err != nil {
+		return err
+	}
+	*t = unixNanos(v)
+	return nil
+}
+
+// unixSeconds is a time.Time which is encoded in JSON as an integer count of
+// seconds since the Unix epoch. A handful of fields, such as node status
+// updates and check results, are reported by the server at this resolution.
+type unixSeconds time.Time
+
+func (t unixSeconds) MarshalJSON() ([]byte, error) {
+	return encodeUnixTime(time.Time(t), time.Second), nil
+}
+
+func (t *unixSeconds) UnmarshalJSON(data []byte) error {
+	v, err := decodeUnixTime(data, time.Second)
+	if err != nil {
+		return err
+	}
+	*t = unixSeconds(v)
+	return nil
+}
+
+// encodeUnixTime encodes t as an integer count of units since the Unix epoch.
+// The zero time is encoded as 0, matching what the server sends for unset
+// timestamps.
+func encodeUnixTime(t time.Time, unit time.Duration) []byte {
+	if t.IsZero() {
+		return []byte("0")
+	}
+	if unit == time.Second {
+		return strconv.AppendInt(nil, t.Unix(), 10)
+	}
+	return strconv.AppendInt(nil, t.UnixNano(), 10)
+}
+
+// decodeUnixTime decodes a JSON number holding a count of units since the
+// Unix epoch, or a string holding an RFC3339 timestamp. Null and 0 are
+// decoded as the zero time.
+func decodeUnixTime(data []byte, unit time.Duration) (time.Time, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return time.Time{}, nil
+	}
+
+	if data[0] == '"' {
+		s, err := strconv.Unquote(string(data))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid timestamp %s: %v", data, err)
+		}
+		if s == "" {
+			return time.Time{}, nil
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid timestamp %q: %v", s, err)
+		}
+		return t, nil
+	}
+
+	n, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		// Payloads which have passed through a map[string]interface{}, such
+		// as event stream payloads, may have been re-encoded as floats.
+		f, ferr := strconv.ParseFloat(string(data), 64)
+		if ferr != nil {
+			return time.Time{}, fmt.Errorf("invalid timestamp %s: %v", data, err)
+		}
+		n = int64(f)
+	}
+	if n == 0 {
+		return time.Time{}, nil
+	}
+	if unit == time.Second {
+		return time.Unix(n, 0), nil
+	}
+	return time.Unix(0, n), nil
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimestamps_decodeUnixTime(t *testing.T) {
+	testutil.Parallel(t)
+
+	ts := time.Date(2022, 7, 1, 12, 30, 45, 123456789, time.UTC)
+
+	cases := []struct {
+		name  string
+		input string
+		unit  time.Duration
+		exp   time.Time
+		err   bool
+	}{
+		{name: "nanos", input: "1656678645123456789", unit: time.Nanosecond, exp: ts},
+		{name: "seconds", input: "1656678645", unit: time.Second, exp: ts.Truncate(time.Second)},
+		{name: "float nanos", input: "1.656678645e+18", unit: time.Nanosecond, exp: ts.Truncate(time.Second)},
+		{name: "rfc3339", input: `"2022-07-01T12:30:45.123456789Z"`, unit: time.Nanosecond, exp: ts},
+		{name: "zero", input: "0", unit: time.Nanosecond, exp: time.Time{}},
+		{name: "null", input: "null", unit: time.Nanosecond, exp: time.Time{}},
+		{name: "empty string", input: `""`, unit: time.Second, exp: time.Time{}},
+		{name: "bad string", input: `"yesterday"`, unit: time.Nanosecond, err: true},
+		{name: "bad value", input: "true", unit: time.Nanosecond, err: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := decodeUnixTime([]byte(tc.input), tc.unit)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, tc.exp.Equal(got), "expected %v, got %v", tc.exp, got)
+		})
+	}
+}
+
+func TestTimestamps_encodeUnixTime(t *testing.T) {
+	testutil.Parallel(t)
+
+	ts := time.Unix(1656678645, 123456789)
+	require.Equal(t, "1656678645123456789", string(encodeUnixTime(ts, time.Nanosecond)))
+	require.Equal(t, "1656678645", string(encodeUnixTime(ts, time.Second)))
+	require.Equal(t, "0", string(encodeUnixTime(time.Time{}, time.Nanosecond)))
+}
+
+func TestTimestamps_Allocation(t *testing.T) {
+	testutil.Parallel(t)
+
+	input := `{
+  "ID": "abc",
+  "CreateTime": 1656678645123456789,
+  "ModifyTime": "2022-07-01T12:31:00Z",
+  "RescheduleTracker": {"Events": [{"RescheduleTime": 1656678600000000000}]}
+}`
+
+	var alloc Allocation
+	require.NoError(t, json.Unmarshal([]byte(input), &alloc))
+	require.Equal(t, "abc", alloc.ID)
+	require.Equal(t, int64(1656678645123456789), alloc.CreateTime.UnixNano())
+	require.Equal(t, int64(1656678660), alloc.ModifyTime.Unix())
+	require.Equal(t, int64(1656678600), alloc.RescheduleTracker.Events[0].RescheduleTime.Unix())
+
+	// Timestamps are always written back as nanoseconds so that the objects
+	// can be submitted to servers which expect integers.
+	out, err := json.Marshal(&alloc)
+	require.NoError(t, err)
+
+	var raw map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(out))
+	dec.UseNumber()
+	require.NoError(t, dec.Decode(&raw))
+	require.Equal(t, json.Number("1656678645123456789"), raw["CreateTime"])
+	require.Equal(t, json.Number("1656678660000000000"), raw["ModifyTime"])
+	require.Equal(t, "abc", raw["ID"])
+}
+
+func TestTimestamps_Job(t *testing.T) {
+	testutil.Parallel(t)
+
+	var job Job
+	require.NoError(t, json.Unmarshal([]byte(`{"ID": "example"}`), &job))
+	require.Nil(t, job.SubmitTime)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"ID": "example", "SubmitTime": 1656678645000000000}`), &job))
+	require.NotNil(t, job.SubmitTime)
+	require.Equal(t, int64(1656678645), job.SubmitTime.Unix())
+
+	out, err := json.Marshal(&job)
+	require.NoError(t, err)
+	require.Contains(t, string(out), `"SubmitTime":1656678645000000000`)
+}
+
+func TestTimestamps_AllocCheckStatuses(t *testing.T) {
+	testutil.Parallel(t)
+
+	statuses := AllocCheckStatuses{
+		"abc": {ID: "abc", Timestamp: time.Unix(1656678645, 0)},
+	}
+
+	// Map values are not addressable, so this exercises the value receiver.
+	out, err := json.Marshal(statuses)
+	require.NoError(t, err)
+	require.Contains(t, string(out), `"Timestamp":1656678645`)
+
+	var decoded AllocCheckStatuses
+	require.NoError(t, json.Unmarshal(out, &decoded))
+	require.True(t, statuses["abc"].Timestamp.Equal(decoded["abc"].Timestamp))
+}
@@ -30,3 +30,24 @@ func formatFloat(f float64, maxPrec int) string {
 func pointerOf[A any](a A) *A {
 	return &a
 }
+
+// listPages calls list for each page of results, following the NextToken of
+// each response until the last page is reached or fn returns false. The query
+// options are copied, so q is left unmodified.
+func listPages[T any](q *QueryOptions, list func(*QueryOptions) ([]T, *QueryMeta, error), fn func([]T, *QueryMeta) bool) error {
+	var opts QueryOptions
+	if q != nil {
+		opts = *q
+	}
+
+	for {
+		page, qm, err := list(&opts)
+		if err != nil {
+			return err
+		}
+		if !fn(page, qm) || qm.NextToken == "" {
+			return nil
+		}
+		opts.NextToken = qm.NextToken
+	}
+}
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"errors"
+	"strconv"
 	"testing"
 
 	"github.com/hashicorp/nomad/api/internal/testutil"
@@ -51,3 +53,54 @@ func Test_PointerOf(t *testing.T) {
 	sPtr = &b
 	must.NotEq(t, s, *sPtr)
 }
+
+func Test_listPages(t *testing.T) {
+	testutil.Parallel(t)
+
+	// list returns pages of two items from 0 to 4, using the next item as
+	// the token.
+	var tokens []string
+	list := func(q *QueryOptions) ([]int, *QueryMeta, error) {
+		tokens = append(tokens, q.NextToken)
+		start := 0
+		if q.NextToken != "" {
+			start, _ = strconv.Atoi(q.NextToken)
+		}
+		var page []int
+		for i := start; i < start+2 && i < 5; i++ {
+			page = append(page, i)
+		}
+		qm := &QueryMeta{}
+		if start+2 < 5 {
+			qm.NextToken = strconv.Itoa(start + 2)
+		}
+		return page, qm, nil
+	}
+
+	var all []int
+	q := &QueryOptions{PerPage: 2}
+	must.NoError(t, listPages(q, list, func(page []int, _ *QueryMeta) bool {
+		all = append(all, page...)
+		return true
+	}))
+	must.Eq(t, []int{0, 1, 2, 3, 4}, all)
+	must.Eq(t, []string{"", "2", "4"}, tokens)
+	must.Eq(t, "", q.NextToken)
+
+	// Returning false stops the iteration
+	var pages int
+	must.NoError(t, listPages(nil, list, func([]int, *QueryMeta) bool {
+		pages++
+		return false
+	}))
+	must.Eq(t, 1, pages)
+
+	// Errors are returned
+	err := listPages(nil, func(*QueryOptions) ([]int, *QueryMeta, error) {
+		return nil, nil, errors.New("boom")
+	}, func([]int, *QueryMeta) bool {
+		t.Fatal("unexpected page")
+		return false
+	})
+	must.EqError(t, err, "boom")
+}
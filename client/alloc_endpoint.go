@@ -21,10 +21,13 @@ import (
 // Allocations endpoint is used for interacting with client allocations
 type Allocations struct {
 	c *Client
+
+	// execSessions are the resumable exec sessions
+	execSessions *execSessions
 }
 
 func NewAllocationsEndpoint(c *Client) *Allocations {
-	a := &Allocations{c: c}
+	a := &Allocations{c: c, execSessions: newExecSessions()}
 	a.c.streamingRpcs.Register("Allocations.Exec", a.exec)
 	return a
 }
@@ -169,7 +172,10 @@ func (a *Allocations) exec(conn io.ReadWriteCloser) {
 	encoder := codec.NewEncoder(conn, nstructs.MsgpackHandle)
 
 	code, err := a.execImpl(encoder, decoder, execID)
-	if err != nil {
+	if err == errExecSessionDetached {
+		a.c.logger.Info("task exec session detached", "exec_id", execID)
+		return
+	} else if err != nil {
 		a.c.logger.Info("task exec session ended with an error", "error", err, "code", code)
 		handleStreamResultError(err, code, encoder)
 		return
@@ -276,6 +282,15 @@ func (a *Allocations) execImpl(encoder *codec.Encoder, decoder *codec.Decoder, e
 		return pointer.Of(int64(404)), fmt.Errorf("task %q not started yet.", req.Task)
 	}
 
+	// Resume an existing session, which may outlive the task
+	if req.SessionToken != "" {
+		s, err := a.execSessions.get(&req)
+		if err != nil {
+			return pointer.Of(int64(404)), err
+		}
+		return nil, s.attach(a.execSessions, req.Offset, encoder, decoder)
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -284,6 +299,11 @@ func (a *Allocations) execImpl(encoder *codec.Encoder, decoder *codec.Decoder, e
 		return pointer.Of(int64(404)), fmt.Errorf("task %q is not running.", req.Task)
 	}
 
+	if req.Resumable {
+		s := a.execSessions.start(h, &req)
+		return nil, s.attachFirst(a.execSessions, encoder, decoder)
+	}
+
 	err = h(ctx, req.Cmd, req.Tty, newExecStream(decoder, encoder))
 	if err != nil {
 		code := pointer.Of(int64(500))
@@ -0,0 +1,358 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-msgpack/codec"
+	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/uuid"
+	nstructs "github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+const (
+	// execSessionResumeTimeout is how long a resumable exec session is kept
+	// running after its connection is lost, waiting to be resumed.
+	execSessionResumeTimeout = 30 * time.Second
+
+	// execSessionBufferFrames is the number of output frames a resumable
+	// exec session keeps to replay them when it is resumed.
+	execSessionBufferFrames = 1024
+)
+
+var (
+	// errExecSessionNotFound is returned when resuming an exec session that
+	// doesn't exist or has expired.
+	errExecSessionNotFound = errors.New("exec session not found or expired")
+
+	// errExecSessionDetached is returned when the connection of an exec
+	// session is lost or replaced by a newer one.
+	errExecSessionDetached = errors.New("exec session detached")
+)
+
+// execOutputFrame is an exec output message annotated with what clients need
+// to resume the session: the session token in the first frame, and the
+// offset of every following frame.
+type execOutputFrame struct {
+	*drivers.ExecTaskStreamingResponseMsg
+	SessionToken string `json:"session_token,omitempty"`
+	Offset       int64  `json:"offset,omitempty"`
+}
+
+// execSessions tracks the resumable exec sessions running on the client.
+type execSessions struct {
+	l        sync.Mutex
+	sessions map[string]*execSession
+}
+
+func newExecSessions() *execSessions {
+	return &execSessions{
+		sessions: make(map[string]*execSession),
+	}
+}
+
+// start runs the command of the request with the given exec handler in a new
+// resumable session and returns the session.
+func (e *execSessions) start(h drivermanager.TaskExecHandler, req *cstructs.AllocExecRequest) *execSession {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &execSession{
+		token:    uuid.Generate(),
+		allocID:  req.AllocID,
+		task:     req.Task,
+		ctx:      ctx,
+		cancel:   cancel,
+		inputCh:  make(chan *drivers.ExecTaskStreamingRequestMsg),
+		first:    1,
+		next:     1,
+		notifyCh: make(chan struct{}),
+	}
+
+	e.l.Lock()
+	e.sessions[s.token] = s
+	e.l.Unlock()
+
+	go func() {
+		err := h(ctx, req.Cmd, req.Tty, s)
+		s.finish(err)
+	}()
+
+	return s
+}
+
+// get returns the session with the given token for the allocation task of the
+// request.
+func (e *execSessions) get(req *cstructs.AllocExecRequest) (*execSession, error) {
+	e.l.Lock()
+	defer e.l.Unlock()
+
+	s, ok := e.sessions[req.SessionToken]
+	if !ok || s.allocID != req.AllocID || s.task != req.Task {
+		return nil, errExecSessionNotFound
+	}
+	return s, nil
+}
+
+// remove forgets about a session.
+func (e *execSessions) remove(s *execSession) {
+	e.l.Lock()
+	defer e.l.Unlock()
+	delete(e.sessions, s.token)
+}
+
+// execSession is an exec session that outlives the connection that started
+// it, so that it can be resumed after a transient network failure. It
+// implements drivers.ExecTaskStream, buffering the output of the command
+// until it is sent to the currently attached connection.
+type execSession struct {
+	token   string
+	allocID string
+	task    string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	inputCh chan *drivers.ExecTaskStreamingRequestMsg
+
+	l        sync.Mutex
+	frames   [][]byte      // encoded output frames, starting at offset first
+	first    int64         // offset of frames[0]
+	next     int64         // offset of the next frame
+	sent     int64         // offset of the last frame sent to a connection
+	notifyCh chan struct{} // closed when a frame is added or the exec ends
+	done     bool
+	err      error
+
+	// attachment is closed when the current connection is detached
+	attachment chan struct{}
+	attachGen  int
+	expiry     *time.Timer
+}
+
+// Send buffers an output message of the command. It blocks while the buffer
+// is full of frames that weren't sent yet, so that a slow or lost connection
+// applies backpressure to the command instead of losing its output.
+func (s *execSession) Send(m *drivers.ExecTaskStreamingResponseMsg) error {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	for len(s.frames) >= execSessionBufferFrames && s.first > s.sent {
+		notifyCh := s.notifyCh
+		s.l.Unlock()
+		select {
+		case <-notifyCh:
+		case <-s.ctx.Done():
+			s.l.Lock()
+			return io.ErrClosedPipe
+		}
+		s.l.Lock()
+	}
+
+	var buf bytes.Buffer
+	if err := codec.NewEncoder(&buf, nstructs.JsonHandle).Encode(&execOutputFrame{
+		ExecTaskStreamingResponseMsg: m,
+		Offset:                       s.next,
+	}); err != nil {
+		return err
+	}
+
+	s.frames = append(s.frames, buf.Bytes())
+	s.next++
+	if len(s.frames) > execSessionBufferFrames {
+		s.frames = s.frames[1:]
+		s.first++
+	}
+	s.notifyLocked()
+	return nil
+}
+
+// Recv returns the next input of the attached connections, or io.EOF once
+// the session ended.
+func (s *execSession) Recv() (*drivers.ExecTaskStreamingRequestMsg, error) {
+	select {
+	case req := <-s.inputCh:
+		return req, nil
+	case <-s.ctx.Done():
+		return nil, io.EOF
+	}
+}
+
+// finish records the result of the command.
+func (s *execSession) finish(err error) {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	s.done = true
+	s.err = err
+	s.notifyLocked()
+}
+
+func (s *execSession) notifyLocked() {
+	close(s.notifyCh)
+	s.notifyCh = make(chan struct{})
+}
+
+// attachFirst sends the session token to the connection that started the
+// session and attaches it.
+func (s *execSession) attachFirst(sessions *execSessions,
+	encoder *codec.Encoder, decoder *codec.Decoder) error {
+
+	var buf bytes.Buffer
+	err := codec.NewEncoder(&buf, nstructs.JsonHandle).Encode(&execOutputFrame{
+		SessionToken: s.token,
+	})
+	if err == nil {
+		err = encoder.Encode(cstructs.StreamErrWrapper{Payload: buf.Bytes()})
+	}
+	if err != nil {
+		// Nobody knows the token, so the session can't be resumed.
+		s.cancel()
+		sessions.remove(s)
+		return err
+	}
+
+	return s.attach(sessions, 0, encoder, decoder)
+}
+
+// attach streams the output of the session after the given offset to the
+// encoder and forwards the input read from the decoder, until the command
+// ends, the connection fails or a newer connection is attached. If the
+// connection is lost, the command is stopped unless the session is resumed
+// within execSessionResumeTimeout.
+func (s *execSession) attach(sessions *execSessions, offset int64,
+	encoder *codec.Encoder, decoder *codec.Decoder) error {
+
+	s.l.Lock()
+	if offset+1 < s.first || offset >= s.next {
+		s.l.Unlock()
+		return fmt.Errorf("exec session output after offset %d is not available", offset)
+	}
+	if s.attachment != nil {
+		close(s.attachment)
+	}
+	if s.expiry != nil {
+		s.expiry.Stop()
+		s.expiry = nil
+	}
+	attachment := make(chan struct{})
+	s.attachment = attachment
+	s.attachGen++
+	s.sent = offset
+	s.l.Unlock()
+
+	connErrCh := make(chan error, 2)
+	go s.forwardInput(decoder, attachment, connErrCh)
+
+	err := s.sendOutput(offset, encoder, attachment, connErrCh)
+	if err == errExecSessionDetached {
+		s.detach(sessions, attachment)
+		return err
+	}
+
+	// The command ended and all of its output was sent.
+	s.cancel()
+	sessions.remove(s)
+	return err
+}
+
+// sendOutput sends the frames after offset until the command ends or the
+// connection is detached.
+func (s *execSession) sendOutput(offset int64, encoder *codec.Encoder,
+	attachment <-chan struct{}, connErrCh <-chan error) error {
+
+	for {
+		s.l.Lock()
+		if s.attachment != attachment {
+			s.l.Unlock()
+			return errExecSessionDetached
+		}
+		frames := s.frames[offset+1-s.first:]
+		done, err := s.done, s.err
+		notifyCh := s.notifyCh
+		s.l.Unlock()
+
+		for _, frame := range frames {
+			if err := encoder.Encode(cstructs.StreamErrWrapper{Payload: frame}); err != nil {
+				return errExecSessionDetached
+			}
+			offset++
+		}
+
+		// Let a blocked Send know that the frames can be evicted.
+		if len(frames) != 0 {
+			s.l.Lock()
+			if s.attachment == attachment {
+				s.sent = offset
+				s.notifyLocked()
+			}
+			s.l.Unlock()
+		}
+
+		if done && len(frames) == 0 {
+			return err
+		}
+		if len(frames) != 0 {
+			continue
+		}
+
+		select {
+		case <-notifyCh:
+		case <-attachment:
+			return errExecSessionDetached
+		case <-connErrCh:
+			return errExecSessionDetached
+		}
+	}
+}
+
+// forwardInput forwards the input read from the decoder to the command.
+func (s *execSession) forwardInput(decoder *codec.Decoder, attachment <-chan struct{}, connErrCh chan<- error) {
+	for {
+		req := &drivers.ExecTaskStreamingRequestMsg{}
+		if err := decoder.Decode(req); err != nil {
+			connErrCh <- err
+			return
+		}
+
+		select {
+		case s.inputCh <- req:
+		case <-attachment:
+			return
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// detach marks the attachment as lost, stopping the command unless the
+// session is resumed in time.
+func (s *execSession) detach(sessions *execSessions, attachment chan struct{}) {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	// A newer connection already took over the session.
+	if s.attachment != attachment {
+		return
+	}
+	close(attachment)
+	s.attachment = nil
+
+	gen := s.attachGen
+	s.expiry = time.AfterFunc(execSessionResumeTimeout, func() {
+		s.l.Lock()
+		resumed := s.attachGen != gen
+		s.l.Unlock()
+		if resumed {
+			return
+		}
+
+		s.cancel()
+		sessions.remove(s)
+	})
+}
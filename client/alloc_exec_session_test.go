@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/hashicorp/go-msgpack/codec"
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	nstructs "github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/hashicorp/nomad/plugins/drivers/proto"
+	"github.com/stretchr/testify/require"
+)
+
+// testExecFrame is the subset of an exec output frame checked by the tests.
+type testExecFrame struct {
+	Stdout *struct {
+		Data []byte `json:"data"`
+	} `json:"stdout"`
+	Exited       bool   `json:"exited"`
+	SessionToken string `json:"session_token"`
+	Offset       int64  `json:"offset"`
+}
+
+func readTestExecFrame(t *testing.T, decoder *codec.Decoder) *testExecFrame {
+	var wrapper cstructs.StreamErrWrapper
+	require.NoError(t, decoder.Decode(&wrapper))
+	require.Nil(t, wrapper.Error)
+
+	var frame testExecFrame
+	require.NoError(t, json.Unmarshal(wrapper.Payload, &frame))
+	return &frame
+}
+
+func TestExecSession_Resume(t *testing.T) {
+	ci.Parallel(t)
+
+	sessions := newExecSessions()
+	outputCh := make(chan string)
+	handler := func(ctx context.Context, cmd []string, tty bool, stream drivers.ExecTaskStream) error {
+		for out := range outputCh {
+			err := stream.Send(&drivers.ExecTaskStreamingResponseMsg{
+				Stdout: &proto.ExecTaskStreamingIOOperation{Data: []byte(out)},
+			})
+			require.NoError(t, err)
+		}
+		return stream.Send(&drivers.ExecTaskStreamingResponseMsg{
+			Exited: true,
+			Result: &proto.ExitResult{},
+		})
+	}
+
+	req := &cstructs.AllocExecRequest{
+		AllocID: "alloc",
+		Task:    "web",
+		Cmd:     []string{"/bin/sh"},
+	}
+	s := sessions.start(handler, req)
+
+	// Attach the connection that started the session
+	server, client := net.Pipe()
+	attachErrCh := make(chan error, 1)
+	go func() {
+		attachErrCh <- s.attachFirst(sessions,
+			codec.NewEncoder(server, nstructs.MsgpackHandle),
+			codec.NewDecoder(server, nstructs.MsgpackHandle))
+	}()
+	decoder := codec.NewDecoder(client, nstructs.MsgpackHandle)
+
+	frame := readTestExecFrame(t, decoder)
+	require.Equal(t, s.token, frame.SessionToken)
+
+	outputCh <- "one"
+	frame = readTestExecFrame(t, decoder)
+	require.Equal(t, "one", string(frame.Stdout.Data))
+	require.Equal(t, int64(1), frame.Offset)
+
+	// Losing the connection detaches the session without stopping it
+	client.Close()
+	require.Equal(t, errExecSessionDetached, <-attachErrCh)
+	require.NoError(t, s.ctx.Err())
+
+	outputCh <- "two"
+
+	// Sessions can only be resumed for the same allocation task
+	_, err := sessions.get(&cstructs.AllocExecRequest{
+		AllocID:      "other",
+		Task:         "web",
+		SessionToken: s.token,
+	})
+	require.Equal(t, errExecSessionNotFound, err)
+
+	req.SessionToken = s.token
+	resumed, err := sessions.get(req)
+	require.NoError(t, err)
+
+	// Resuming replays the output missed while disconnected
+	server, client = net.Pipe()
+	defer client.Close()
+	go func() {
+		attachErrCh <- resumed.attach(sessions, 1,
+			codec.NewEncoder(server, nstructs.MsgpackHandle),
+			codec.NewDecoder(server, nstructs.MsgpackHandle))
+	}()
+	decoder = codec.NewDecoder(client, nstructs.MsgpackHandle)
+
+	frame = readTestExecFrame(t, decoder)
+	require.Equal(t, "two", string(frame.Stdout.Data))
+	require.Equal(t, int64(2), frame.Offset)
+
+	close(outputCh)
+	frame = readTestExecFrame(t, decoder)
+	require.True(t, frame.Exited)
+	require.Equal(t, int64(3), frame.Offset)
+
+	// The session is gone once the command exited
+	require.NoError(t, <-attachErrCh)
+	_, err = sessions.get(req)
+	require.Equal(t, errExecSessionNotFound, err)
+}
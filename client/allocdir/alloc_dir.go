@@ -68,6 +68,10 @@ var (
 	// AllocHTTPSocket is the path relative to the task dir root for the unix
 	// socket connected to Consul's HTTP endpoint.
 	AllocHTTPSocket = filepath.Join(SharedAllocName, TmpDirName, "consul_http.sock")
+
+	// AllocSecureVariablesSocket is the path relative to the task dir root
+	// for the unix socket tasks subscribe to secure variable changes through.
+	AllocSecureVariablesSocket = filepath.Join(SharedAllocName, TmpDirName, "nomad_variables.sock")
 )
 
 // AllocDir allows creating, destroying, and accessing an allocation's
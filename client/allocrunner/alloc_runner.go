@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/nomad/client/allocrunner/state"
 	"github.com/hashicorp/nomad/client/allocrunner/tasklifecycle"
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner"
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/restarts"
 	"github.com/hashicorp/nomad/client/allocwatcher"
 	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/consul"
@@ -192,6 +193,10 @@ type allocRunner struct {
 
 	// getter is an interface for retrieving artifacts.
 	getter cinterfaces.ArtifactGetter
+
+	// lastHeartbeat returns the time of the last successful heartbeat of the
+	// client.
+	lastHeartbeat func() time.Time
 }
 
 // RPCer is the interface needed by hooks to make RPC calls.
@@ -238,6 +243,7 @@ func NewAllocRunner(config *Config) (*allocRunner, error) {
 		serviceRegWrapper:        config.ServiceRegWrapper,
 		checkStore:               config.CheckStore,
 		getter:                   config.Getter,
+		lastHeartbeat:            config.LastHeartbeat,
 	}
 
 	// Create the logger based on the allocation ID
@@ -270,6 +276,12 @@ func NewAllocRunner(config *Config) (*allocRunner, error) {
 
 // initTaskRunners creates task runners but does *not* run them.
 func (ar *allocRunner) initTaskRunners(tasks []*structs.Task) error {
+	// Create the restart budget shared by all the tasks, if any
+	var budget *restarts.GroupBudget
+	if tg := ar.alloc.Job.LookupTaskGroup(ar.alloc.TaskGroup); tg != nil && tg.RestartBudget != nil {
+		budget = restarts.NewGroupBudget(tg.RestartBudget.Copy())
+	}
+
 	for _, task := range tasks {
 		trConfig := &taskrunner.Config{
 			Alloc:               ar.alloc,
@@ -293,6 +305,8 @@ func (ar *allocRunner) initTaskRunners(tasks []*structs.Task) error {
 			ShutdownDelayCtx:    ar.shutdownDelayCtx,
 			ServiceRegWrapper:   ar.serviceRegWrapper,
 			Getter:              ar.getter,
+			RestartBudget:       budget,
+			LastHeartbeat:       ar.lastHeartbeat,
 		}
 
 		if ar.cpusetManager != nil {
@@ -1372,6 +1386,31 @@ func (ar *allocRunner) GetTaskExecHandler(taskName string) drivermanager.TaskExe
 	return tr.TaskExecHandler()
 }
 
+// Checkpoint checkpoints the tasks of the allocation that are configured to
+// be, as the client disconnects during a planned disconnect with the given
+// deadline. It blocks until all the tasks are checkpointed.
+func (ar *allocRunner) Checkpoint(deadline time.Time) {
+	var wg sync.WaitGroup
+	for _, tr := range ar.tasks {
+		wg.Add(1)
+		go func(tr *taskrunner.TaskRunner) {
+			defer wg.Done()
+			tr.Checkpoint(deadline)
+		}(tr)
+	}
+	wg.Wait()
+}
+
+// GetTaskHandle returns the task handle and driver network used to reattach
+// to the task, or nil if the task isn't running.
+func (ar *allocRunner) GetTaskHandle(taskName string) (*drivers.TaskHandle, *drivers.DriverNetwork) {
+	tr, ok := ar.tasks[taskName]
+	if !ok {
+		return nil, nil
+	}
+	return tr.TaskHandle()
+}
+
 func (ar *allocRunner) GetTaskDriverCapabilities(taskName string) (*drivers.Capabilities, error) {
 	tr, ok := ar.tasks[taskName]
 	if !ok {
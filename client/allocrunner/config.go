@@ -1,6 +1,8 @@
 package allocrunner
 
 import (
+	"time"
+
 	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/allocwatcher"
 	clientconfig "github.com/hashicorp/nomad/client/config"
@@ -93,4 +95,8 @@ type Config struct {
 
 	// Getter is an interface for retrieving artifacts.
 	Getter interfaces.ArtifactGetter
+
+	// LastHeartbeat returns the time of the last successful heartbeat of the
+	// client to the servers.
+	LastHeartbeat func() time.Time
 }
@@ -0,0 +1,366 @@
+package allocrunner
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// secureVariablesWatchMaxQueryTime is the time the blocking queries of
+	// the secure variables broker wait for a change.
+	secureVariablesWatchMaxQueryTime = 5 * time.Minute
+
+	// secureVariablesWatchMaxBackoff caps the backoff between failed reads
+	// of a watched secure variable.
+	secureVariablesWatchMaxBackoff = 30 * time.Second
+
+	// secureVariablesSubscriptionBuffer is the number of changes buffered
+	// for a subscriber. Subscribers which fall further behind are closed,
+	// since they would miss a patch.
+	secureVariablesSubscriptionBuffer = 8
+)
+
+// errSecureVariablesSubscriberTooSlow closes the subscriptions which don't
+// keep up with the changes of their variable.
+var errSecureVariablesSubscriberTooSlow = errors.New("subscriber is too slow to receive secure variable changes")
+
+// secureVariablesBroker watches the secure variables subscribed to by the
+// tasks of an allocation and fans their changes out to the subscribers. A
+// variable is read with the workload identity of the subscribed task, so the
+// servers only let a task subscribe to the variables it can read. Subscribers
+// to the same variable with the same identity share a single watch.
+type secureVariablesBroker struct {
+	logger    hclog.Logger
+	rpc       RPCer
+	region    string
+	namespace string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	l        sync.Mutex
+	watchers map[secureVariablesWatchKey]*secureVariableWatcher
+}
+
+type secureVariablesWatchKey struct {
+	path  string
+	token [sha256.Size]byte
+}
+
+func newSecureVariablesBroker(logger hclog.Logger, rpc RPCer, region, namespace string) *secureVariablesBroker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &secureVariablesBroker{
+		logger:    logger,
+		rpc:       rpc,
+		region:    region,
+		namespace: namespace,
+		ctx:       ctx,
+		cancel:    cancel,
+		watchers:  make(map[secureVariablesWatchKey]*secureVariableWatcher),
+	}
+}
+
+// subscribe returns a subscription to the changes of the secure variable at
+// path, read with the given workload identity token.
+func (b *secureVariablesBroker) subscribe(path, token string) *secureVariableSubscription {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	key := secureVariablesWatchKey{path: path, token: sha256.Sum256([]byte(token))}
+	w, ok := b.watchers[key]
+	if !ok {
+		ctx, cancel := context.WithCancel(b.ctx)
+		w = &secureVariableWatcher{
+			broker: b,
+			key:    key,
+			path:   path,
+			token:  token,
+			ctx:    ctx,
+			cancel: cancel,
+			subs:   make(map[*secureVariableSubscription]struct{}),
+		}
+		b.watchers[key] = w
+		go w.run()
+	}
+
+	return w.add()
+}
+
+// stop ends all the watches and closes their subscriptions.
+func (b *secureVariablesBroker) stop() {
+	b.cancel()
+}
+
+// removeWatcher forgets about a watcher which has no subscribers left.
+func (b *secureVariablesBroker) removeWatcher(w *secureVariableWatcher) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	w.l.Lock()
+	defer w.l.Unlock()
+
+	if len(w.subs) != 0 || b.watchers[w.key] != w {
+		return
+	}
+	delete(b.watchers, w.key)
+	w.cancel()
+}
+
+// evict stops a watcher regardless of its subscribers, so that it doesn't
+// take new ones.
+func (b *secureVariablesBroker) evict(w *secureVariableWatcher) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	if b.watchers[w.key] == w {
+		delete(b.watchers, w.key)
+	}
+	w.cancel()
+}
+
+// secureVariableSubscription receives the changes of a secure variable.
+type secureVariableSubscription struct {
+	watcher *secureVariableWatcher
+	ch      chan *cstructs.SecureVariableChange
+
+	// closeCh is closed with err set once no more changes will be sent.
+	closeCh chan struct{}
+	err     error
+}
+
+// Changes returns the channel the changes of the variable are sent to.
+func (s *secureVariableSubscription) Changes() <-chan *cstructs.SecureVariableChange {
+	return s.ch
+}
+
+// Done returns a channel which is closed when the subscription ends, after
+// which Err returns why it ended.
+func (s *secureVariableSubscription) Done() <-chan struct{} {
+	return s.closeCh
+}
+
+// Err returns why the subscription ended.
+func (s *secureVariableSubscription) Err() error {
+	s.watcher.l.Lock()
+	defer s.watcher.l.Unlock()
+	return s.err
+}
+
+// Unsubscribe stops the subscription.
+func (s *secureVariableSubscription) Unsubscribe() {
+	s.watcher.remove(s, nil)
+	s.watcher.broker.removeWatcher(s.watcher)
+}
+
+// secureVariableWatcher runs a blocking query on a secure variable and
+// publishes its changes to its subscribers.
+type secureVariableWatcher struct {
+	broker *secureVariablesBroker
+	key    secureVariablesWatchKey
+	path   string
+	token  string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	l     sync.Mutex
+	subs  map[*secureVariableSubscription]struct{}
+	read  bool // whether the variable was read yet
+	index uint64
+	items structs.SecureVariableItems
+}
+
+// add subscribes to the watched variable. If the variable was already read,
+// the subscriber starts with its current items.
+func (w *secureVariableWatcher) add() *secureVariableSubscription {
+	w.l.Lock()
+	defer w.l.Unlock()
+
+	s := &secureVariableSubscription{
+		watcher: w,
+		ch:      make(chan *cstructs.SecureVariableChange, secureVariablesSubscriptionBuffer),
+		closeCh: make(chan struct{}),
+	}
+	if w.ctx.Err() != nil {
+		s.err = w.ctx.Err()
+		close(s.closeCh)
+		return s
+	}
+
+	w.subs[s] = struct{}{}
+	if w.read {
+		s.ch <- w.changeLocked(nil, w.items)
+	}
+	return s
+}
+
+// remove closes a subscription with the given error.
+func (w *secureVariableWatcher) remove(s *secureVariableSubscription, err error) {
+	w.l.Lock()
+	defer w.l.Unlock()
+	w.removeLocked(s, err)
+}
+
+func (w *secureVariableWatcher) removeLocked(s *secureVariableSubscription, err error) {
+	if _, ok := w.subs[s]; !ok {
+		return
+	}
+	delete(w.subs, s)
+	s.err = err
+	close(s.closeCh)
+}
+
+// run watches the variable until the watcher is stopped.
+func (w *secureVariableWatcher) run() {
+	backoff := time.Second
+	for {
+		args := &structs.SecureVariablesReadRequest{
+			Path: w.path,
+			QueryOptions: structs.QueryOptions{
+				Region:        w.broker.region,
+				Namespace:     w.broker.namespace,
+				AuthToken:     w.token,
+				AllowStale:    true,
+				MinQueryIndex: w.index,
+				MaxQueryTime:  secureVariablesWatchMaxQueryTime,
+			},
+		}
+		var reply structs.SecureVariablesReadResponse
+		err := w.broker.rpc.RPC(structs.SecureVariablesReadRPCMethod, args, &reply)
+
+		if w.ctx.Err() != nil {
+			w.closeAll(w.ctx.Err())
+			return
+		}
+
+		if err != nil {
+			// The identity of the task isn't allowed to read the variable,
+			// which won't change by retrying.
+			if structs.IsErrPermissionDenied(err) {
+				w.broker.evict(w)
+				w.closeAll(structs.ErrPermissionDenied)
+				return
+			}
+
+			w.broker.logger.Debug("failed to read subscribed secure variable",
+				"path", w.path, "error", err, "retry_in", backoff)
+
+			select {
+			case <-time.After(backoff):
+				if backoff < secureVariablesWatchMaxBackoff {
+					backoff = backoff * 2
+					if backoff > secureVariablesWatchMaxBackoff {
+						backoff = secureVariablesWatchMaxBackoff
+					}
+				}
+				continue
+			case <-w.ctx.Done():
+				w.closeAll(w.ctx.Err())
+				return
+			}
+		}
+		backoff = time.Second
+
+		w.update(&reply)
+	}
+}
+
+// update publishes the result of a read to the subscribers if it changed
+// the variable.
+func (w *secureVariableWatcher) update(reply *structs.SecureVariablesReadResponse) {
+	w.l.Lock()
+	defer w.l.Unlock()
+
+	var items structs.SecureVariableItems
+	if reply.Data != nil {
+		items = reply.Data.Items
+	}
+
+	first := !w.read
+	prevItems, prevDeleted := w.items, w.items == nil
+	w.read = true
+	if reply.Index > w.index {
+		w.index = reply.Index
+	}
+	w.items = items
+
+	change := w.changeLocked(prevItems, items)
+	if !first && len(change.Patch) == 0 && prevDeleted == change.Deleted {
+		return
+	}
+
+	for s := range w.subs {
+		select {
+		case s.ch <- change:
+		default:
+			w.removeLocked(s, errSecureVariablesSubscriberTooSlow)
+		}
+	}
+}
+
+// changeLocked returns the change of the variable from the given items to
+// its current items.
+func (w *secureVariableWatcher) changeLocked(from, to structs.SecureVariableItems) *cstructs.SecureVariableChange {
+	return &cstructs.SecureVariableChange{
+		Namespace: w.broker.namespace,
+		Path:      w.path,
+		Index:     w.index,
+		Deleted:   to == nil,
+		Patch:     secureVariableItemsPatch(from, to),
+	}
+}
+
+// closeAll closes all the subscriptions with the given error.
+func (w *secureVariableWatcher) closeAll(err error) {
+	w.l.Lock()
+	defer w.l.Unlock()
+
+	for s := range w.subs {
+		w.removeLocked(s, err)
+	}
+}
+
+// secureVariableItemsPatch returns the JSON patch transforming the items from
+// into the items to, sorted by key so that patches are deterministic.
+func secureVariableItemsPatch(from, to structs.SecureVariableItems) []*cstructs.JSONPatchOperation {
+	keys := make([]string, 0, len(from)+len(to))
+	for k := range from {
+		keys = append(keys, k)
+	}
+	for k := range to {
+		if _, ok := from[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	patch := []*cstructs.JSONPatchOperation{}
+	for _, k := range keys {
+		oldValue, hadKey := from[k]
+		newValue, hasKey := to[k]
+		path := "/" + jsonPointerEscaper.Replace(k)
+
+		switch {
+		case !hasKey:
+			patch = append(patch, &cstructs.JSONPatchOperation{Op: "remove", Path: path})
+		case !hadKey:
+			patch = append(patch, &cstructs.JSONPatchOperation{Op: "add", Path: path, Value: pointer.Of(newValue)})
+		case oldValue != newValue:
+			patch = append(patch, &cstructs.JSONPatchOperation{Op: "replace", Path: path, Value: pointer.Of(newValue)})
+		}
+	}
+	return patch
+}
+
+// jsonPointerEscaper escapes a key into a JSON pointer (RFC 6901) token.
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
@@ -0,0 +1,214 @@
+package allocrunner
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	secureVariablesSocketHookName = "secure_variables_socket"
+
+	// secureVariablesSocketHeartbeat is the interval of the empty frames
+	// sent to keep idle subscriptions alive.
+	secureVariablesSocketHeartbeat = 10 * time.Second
+)
+
+// secureVariablesSockHook serves a unix socket in the shared alloc dir on
+// which the tasks of the allocation can subscribe to changes of the secure
+// variables they can read. Tasks authenticate with their workload identity,
+// which the broker then uses to read the variables from the servers.
+type secureVariablesSockHook struct {
+	logger  hclog.Logger
+	enabled bool
+
+	allocDir *allocdir.AllocDir
+	broker   *secureVariablesBroker
+
+	// lock synchronizes alloc and server which may be mutated and read
+	// concurrently via Prerun, Update, Postrun and the socket handlers.
+	lock   sync.Mutex
+	alloc  *structs.Allocation
+	server *http.Server
+}
+
+func newSecureVariablesSocketHook(logger hclog.Logger, alloc *structs.Allocation, allocDir *allocdir.AllocDir,
+	rpc RPCer, region string, enabled bool) *secureVariablesSockHook {
+	logger = logger.Named(secureVariablesSocketHookName)
+	return &secureVariablesSockHook{
+		logger:   logger,
+		enabled:  enabled,
+		allocDir: allocDir,
+		broker:   newSecureVariablesBroker(logger, rpc, region, alloc.Namespace),
+		alloc:    alloc,
+	}
+}
+
+func (*secureVariablesSockHook) Name() string {
+	return secureVariablesSocketHookName
+}
+
+func (h *secureVariablesSockHook) Prerun() error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if !h.enabled || h.server != nil {
+		return nil
+	}
+
+	sockPath := filepath.Join(h.allocDir.AllocDir, allocdir.AllocSecureVariablesSocket)
+	if err := maybeRemoveOldSocket(sockPath); err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("unix", sockPath)
+	if err != nil {
+		return fmt.Errorf("unable to create unix socket for secure variables: %w", err)
+	}
+
+	// The socket should be usable by all users in case a task is running as
+	// a non-privileged user. Access is controlled by the workload identity
+	// tasks authenticate with.
+	if err := os.Chmod(sockPath, os.ModePerm); err != nil {
+		listener.Close()
+		return fmt.Errorf("unable to set permissions on unix socket: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/var/subscribe", h.handleSubscribe)
+	h.server = &http.Server{Handler: mux}
+
+	go func(server *http.Server) {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			h.logger.Error("error serving secure variables socket", "error", err)
+		}
+	}(h.server)
+
+	return nil
+}
+
+func (h *secureVariablesSockHook) Update(req *interfaces.RunnerUpdateRequest) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.alloc = req.Alloc
+	return nil
+}
+
+func (h *secureVariablesSockHook) Postrun() error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.broker.stop()
+	if h.server != nil {
+		if err := h.server.Close(); err != nil {
+			// Only log a failure to stop, worst case is the server leaks a
+			// goroutine.
+			h.logger.Warn("error stopping secure variables socket", "error", err)
+		}
+	}
+	return nil
+}
+
+// authenticate returns whether the token is the workload identity of one of
+// the tasks of the allocation.
+func (h *secureVariablesSockHook) authenticate(token string) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if token == "" {
+		return false
+	}
+	for _, identity := range h.alloc.SignedIdentities {
+		if subtle.ConstantTimeCompare([]byte(identity), []byte(token)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// handleSubscribe streams the changes of the secure variable at the path of
+// the request as newline delimited JSON.
+func (h *secureVariablesSockHook) handleSubscribe(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(resp, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	path := req.URL.Query().Get("path")
+	if path == "" {
+		http.Error(resp, "Missing secure variable path", http.StatusBadRequest)
+		return
+	}
+
+	token := req.Header.Get("X-Nomad-Token")
+	if !h.authenticate(token) {
+		http.Error(resp, structs.ErrPermissionDenied.Error(), http.StatusForbidden)
+		return
+	}
+
+	sub := h.broker.subscribe(path, token)
+	defer sub.Unsubscribe()
+
+	// Wait for the first read of the variable, so that failing to read it
+	// is reported as the status of the response.
+	var change interface{}
+	select {
+	case change = <-sub.Changes():
+	case <-sub.Done():
+		if err := sub.Err(); structs.IsErrPermissionDenied(err) {
+			http.Error(resp, err.Error(), http.StatusForbidden)
+		} else {
+			http.Error(resp, fmt.Sprintf("subscription closed: %v", err), http.StatusInternalServerError)
+		}
+		return
+	case <-req.Context().Done():
+		return
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Cache-Control", "no-cache")
+	flusher, _ := resp.(http.Flusher)
+	enc := json.NewEncoder(resp)
+
+	heartbeat := time.NewTicker(secureVariablesSocketHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		if err := enc.Encode(change); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		select {
+		case change = <-sub.Changes():
+		case <-heartbeat.C:
+			change = struct{}{}
+		case <-sub.Done():
+			// Changes sent before the subscription ended are still
+			// delivered, a subscriber only misses them if it's too slow.
+			select {
+			case change = <-sub.Changes():
+				continue
+			default:
+			}
+			h.logger.Debug("secure variable subscription closed", "path", path, "error", sub.Err())
+			return
+		case <-req.Context().Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package allocrunner
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocdir"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSecureVariablesRPC answers the secure variable reads of the broker
+// with the replies sent on replyCh.
+type fakeSecureVariablesRPC struct {
+	argsCh  chan *structs.SecureVariablesReadRequest
+	replyCh chan *structs.SecureVariablesReadResponse
+	errCh   chan error
+}
+
+func newFakeSecureVariablesRPC() *fakeSecureVariablesRPC {
+	return &fakeSecureVariablesRPC{
+		argsCh:  make(chan *structs.SecureVariablesReadRequest, 10),
+		replyCh: make(chan *structs.SecureVariablesReadResponse),
+		errCh:   make(chan error),
+	}
+}
+
+func (f *fakeSecureVariablesRPC) RPC(method string, args interface{}, reply interface{}) error {
+	f.argsCh <- args.(*structs.SecureVariablesReadRequest)
+	select {
+	case out := <-f.replyCh:
+		*reply.(*structs.SecureVariablesReadResponse) = *out
+		return nil
+	case err := <-f.errCh:
+		return err
+	}
+}
+
+func testSecureVariablesSocketClient(allocDir *allocdir.AllocDir) *http.Client {
+	sockPath := filepath.Join(allocDir.AllocDir, allocdir.AllocSecureVariablesSocket)
+	return &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", sockPath)
+		},
+	}}
+}
+
+func testSecureVariablesSubscribe(t *testing.T, client *http.Client, path, token string) *http.Response {
+	req, err := http.NewRequest(http.MethodGet, "http://nomad/v1/var/subscribe?path="+path, nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Nomad-Token", token)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestSecureVariablesSocketHook_Subscribe(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	alloc.SignedIdentities = map[string]string{"web": "web-identity"}
+	logger := testlog.HCLogger(t)
+
+	allocDir, cleanupDir := allocdir.TestAllocDir(t, logger, "SecureVariablesSocket", alloc.ID)
+	defer cleanupDir()
+
+	rpc := newFakeSecureVariablesRPC()
+	h := newSecureVariablesSocketHook(logger, alloc, allocDir, rpc, "global", true)
+	require.NoError(t, h.Prerun())
+	defer h.Postrun()
+
+	client := testSecureVariablesSocketClient(allocDir)
+
+	// Tokens that aren't an identity of the allocation are rejected
+	resp := testSecureVariablesSubscribe(t, client, "nomad/jobs/web", "other")
+	resp.Body.Close()
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	respCh := make(chan *http.Response, 1)
+	go func() {
+		respCh <- testSecureVariablesSubscribe(t, client, "nomad/jobs/web", "web-identity")
+	}()
+
+	// The variable is read with the workload identity of the task
+	args := <-rpc.argsCh
+	require.Equal(t, "nomad/jobs/web", args.Path)
+	require.Equal(t, "web-identity", args.AuthToken)
+	require.Equal(t, alloc.Namespace, args.Namespace)
+	require.Zero(t, args.MinQueryIndex)
+
+	rpc.replyCh <- &structs.SecureVariablesReadResponse{
+		Data: &structs.SecureVariableDecrypted{
+			Items: structs.SecureVariableItems{"user": "admin", "pass/word": "one"},
+		},
+		QueryMeta: structs.QueryMeta{Index: 10},
+	}
+
+	resp = <-respCh
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	dec := json.NewDecoder(resp.Body)
+
+	var change cstructs.SecureVariableChange
+	require.NoError(t, dec.Decode(&change))
+	require.Equal(t, uint64(10), change.Index)
+	require.False(t, change.Deleted)
+	require.Equal(t, []*cstructs.JSONPatchOperation{
+		{Op: "add", Path: "/pass~1word", Value: pointer.Of("one")},
+		{Op: "add", Path: "/user", Value: pointer.Of("admin")},
+	}, change.Patch)
+
+	// Changes are sent as patches from the previous items
+	args = <-rpc.argsCh
+	require.Equal(t, uint64(10), args.MinQueryIndex)
+	rpc.replyCh <- &structs.SecureVariablesReadResponse{
+		Data: &structs.SecureVariableDecrypted{
+			Items: structs.SecureVariableItems{"pass/word": "two", "port": "8080"},
+		},
+		QueryMeta: structs.QueryMeta{Index: 11},
+	}
+
+	change = cstructs.SecureVariableChange{}
+	require.NoError(t, dec.Decode(&change))
+	require.Equal(t, uint64(11), change.Index)
+	require.Equal(t, []*cstructs.JSONPatchOperation{
+		{Op: "replace", Path: "/pass~1word", Value: pointer.Of("two")},
+		{Op: "add", Path: "/port", Value: pointer.Of("8080")},
+		{Op: "remove", Path: "/user"},
+	}, change.Patch)
+
+	// Deleting the variable removes all its items
+	<-rpc.argsCh
+	rpc.replyCh <- &structs.SecureVariablesReadResponse{
+		QueryMeta: structs.QueryMeta{Index: 12},
+	}
+
+	change = cstructs.SecureVariableChange{}
+	require.NoError(t, dec.Decode(&change))
+	require.True(t, change.Deleted)
+	require.Len(t, change.Patch, 2)
+}
+
+func TestSecureVariablesSocketHook_PermissionDenied(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	alloc.SignedIdentities = map[string]string{"web": "web-identity"}
+	logger := testlog.HCLogger(t)
+
+	allocDir, cleanupDir := allocdir.TestAllocDir(t, logger, "SecureVariablesSocket", alloc.ID)
+	defer cleanupDir()
+
+	rpc := newFakeSecureVariablesRPC()
+	h := newSecureVariablesSocketHook(logger, alloc, allocDir, rpc, "global", true)
+	require.NoError(t, h.Prerun())
+	defer h.Postrun()
+
+	respCh := make(chan *http.Response, 1)
+	go func() {
+		client := testSecureVariablesSocketClient(allocDir)
+		respCh <- testSecureVariablesSubscribe(t, client, "nomad/jobs/other", "web-identity")
+	}()
+
+	<-rpc.argsCh
+	rpc.errCh <- structs.ErrPermissionDenied
+
+	resp := <-respCh
+	resp.Body.Close()
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
@@ -0,0 +1,204 @@
+package taskrunner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	ti "github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+const (
+	// checkpointDirName is the name of the directory of the task holding its
+	// checkpoint.
+	checkpointDirName = "checkpoint"
+
+	// checkpointDeadlineFileName is the name of the file of the checkpoint
+	// directory holding the deadline of the planned disconnect the task was
+	// checkpointed for. It is written once the checkpoint is complete.
+	checkpointDeadlineFileName = ".deadline"
+)
+
+var _ interfaces.TaskExitedHook = (*checkpointHook)(nil)
+
+type checkpointHookConfig struct {
+	driver     drivers.CheckpointDriver
+	checkpoint *structs.TaskCheckpoint
+	dir        string
+	handle     func() *DriverHandle
+	events     ti.EventEmitter
+	logger     hclog.Logger
+}
+
+// checkpointHook checkpoints the task when the client disconnects during a
+// planned disconnect of the node. If the task doesn't survive the disconnect,
+// the task runner restores it from its checkpoint when the client restarts.
+// The checkpoint is removed when the task exits, since it would no longer
+// reflect the task's state.
+type checkpointHook struct {
+	driver     drivers.CheckpointDriver
+	checkpoint *structs.TaskCheckpoint
+	dir        string
+	handle     func() *DriverHandle
+	events     ti.EventEmitter
+	logger     hclog.Logger
+
+	// deadline is the deadline of the planned disconnect the task was last
+	// checkpointed for.
+	deadline time.Time
+	lock     sync.Mutex
+}
+
+func newCheckpointHook(c *checkpointHookConfig) *checkpointHook {
+	h := &checkpointHook{
+		driver:     c.driver,
+		checkpoint: c.checkpoint,
+		dir:        c.dir,
+		handle:     c.handle,
+		events:     c.events,
+	}
+	h.logger = c.logger.Named(h.Name())
+	return h
+}
+
+func (*checkpointHook) Name() string {
+	return "checkpoint"
+}
+
+// checkpointTask checkpoints the running task for the planned disconnect with
+// the given deadline, unless it was already checkpointed for it.
+func (h *checkpointHook) checkpointTask(deadline time.Time) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if deadline.Equal(h.deadline) {
+		return
+	}
+
+	handle := h.handle()
+	if handle == nil {
+		// The task isn't running, so there is nothing to checkpoint
+		return
+	}
+	h.deadline = deadline
+
+	if err := h.replaceCheckpoint(handle.ID(), deadline); err != nil {
+		h.logger.Warn("failed to checkpoint task", "error", err)
+		h.events.EmitEvent(structs.NewTaskEvent(structs.TaskCheckpointFailed).
+			SetDisplayMessage(fmt.Sprintf("Failed to checkpoint task on planned disconnect: %v", err)))
+		return
+	}
+
+	h.logger.Debug("checkpointed task on planned disconnect", "deadline", deadline)
+	h.events.EmitEvent(structs.NewTaskEvent(structs.TaskCheckpointed).
+		SetDisplayMessage("Task checkpointed on planned disconnect"))
+}
+
+// replaceCheckpoint replaces the checkpoint of the task by a new one.
+func (h *checkpointHook) replaceCheckpoint(taskID string, deadline time.Time) error {
+	if err := os.RemoveAll(h.dir); err != nil {
+		return fmt.Errorf("failed to remove previous checkpoint: %v", err)
+	}
+	if err := os.MkdirAll(h.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create checkpoint directory: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), h.checkpoint.Timeout)
+	defer cancel()
+	if err := h.driver.CheckpointTask(ctx, taskID, h.dir); err != nil {
+		os.RemoveAll(h.dir)
+		return err
+	}
+
+	path := filepath.Join(h.dir, checkpointDeadlineFileName)
+	if err := os.WriteFile(path, []byte(deadline.Format(time.RFC3339Nano)), 0600); err != nil {
+		os.RemoveAll(h.dir)
+		return fmt.Errorf("failed to save checkpoint deadline: %v", err)
+	}
+	return nil
+}
+
+// checkpointDeadline returns the deadline of the planned disconnect the
+// checkpoint in the directory was taken for. It fails if there is no complete
+// checkpoint in the directory.
+func checkpointDeadline(dir string) (time.Time, error) {
+	buf, err := os.ReadFile(filepath.Join(dir, checkpointDeadlineFileName))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339Nano, strings.TrimSpace(string(buf)))
+}
+
+func (h *checkpointHook) Exited(context.Context, *interfaces.TaskExitedRequest, *interfaces.TaskExitedResponse) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	// A restarted task is checkpointed again on the next disconnect
+	h.deadline = time.Time{}
+	if err := os.RemoveAll(h.dir); err != nil {
+		h.logger.Warn("failed to remove checkpoint", "error", err)
+	}
+	return nil
+}
+
+// checkpointDriver returns the driver of the task if the task is configured
+// to be checkpointed and the driver supports it.
+func (tr *TaskRunner) checkpointDriver() (drivers.CheckpointDriver, bool) {
+	if tr.Task().Checkpoint == nil || tr.driverCapabilities == nil || !tr.driverCapabilities.Checkpoint {
+		return nil, false
+	}
+	cd, ok := tr.driver.(drivers.CheckpointDriver)
+	return cd, ok
+}
+
+// checkpointDir returns the directory holding the checkpoint of the task.
+func (tr *TaskRunner) checkpointDir() string {
+	return filepath.Join(tr.taskDir.Dir, checkpointDirName)
+}
+
+// Checkpoint checkpoints the task if it is configured to be, as the client
+// disconnects during a planned disconnect with the given deadline. It blocks
+// until the task is checkpointed.
+func (tr *TaskRunner) Checkpoint(deadline time.Time) {
+	if tr.checkpointHook != nil {
+		tr.checkpointHook.checkpointTask(deadline)
+	}
+}
+
+// startTask starts the task with the driver. A task that couldn't be
+// reattached to when the client restarted is restored from its checkpoint
+// instead, if it was checkpointed during a planned disconnect that isn't
+// over yet.
+func (tr *TaskRunner) startTask(taskConfig *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	restore := tr.restoreFromCheckpoint
+	tr.restoreFromCheckpoint = false
+
+	cd, ok := tr.checkpointDriver()
+	if !restore || !ok {
+		return tr.driver.StartTask(taskConfig)
+	}
+
+	dir := tr.checkpointDir()
+	deadline, err := checkpointDeadline(dir)
+	if err != nil || time.Now().After(deadline) {
+		return tr.driver.StartTask(taskConfig)
+	}
+
+	handle, net, err := cd.RestoreTask(taskConfig, dir)
+	if err != nil {
+		tr.logger.Warn("failed to restore task from checkpoint; starting it instead", "error", err)
+		return tr.driver.StartTask(taskConfig)
+	}
+
+	tr.EmitEvent(structs.NewTaskEvent(structs.TaskRestoredFromCheckpoint).
+		SetDisplayMessage("Task restored from the checkpoint taken on planned disconnect"))
+	return handle, net, nil
+}
@@ -0,0 +1,106 @@
+package taskrunner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/stretchr/testify/require"
+)
+
+// mockCheckpointDriver records the tasks it checkpoints, writing a file to
+// the checkpoint directory unless it is set to fail.
+type mockCheckpointDriver struct {
+	drivers.CheckpointDriver
+	checkpointed []string
+	err          error
+}
+
+func (d *mockCheckpointDriver) CheckpointTask(ctx context.Context, taskID, dir string) error {
+	d.checkpointed = append(d.checkpointed, taskID)
+	if d.err != nil {
+		return d.err
+	}
+	return os.WriteFile(filepath.Join(dir, "state"), []byte(taskID), 0600)
+}
+
+func testCheckpointHook(t *testing.T, driver *mockCheckpointDriver, handle *DriverHandle) (*checkpointHook, *mockEmitter) {
+	events := &mockEmitter{}
+	h := newCheckpointHook(&checkpointHookConfig{
+		driver:     driver,
+		checkpoint: &structs.TaskCheckpoint{Timeout: time.Second},
+		dir:        filepath.Join(t.TempDir(), checkpointDirName),
+		handle:     func() *DriverHandle { return handle },
+		events:     events,
+		logger:     testlog.HCLogger(t),
+	})
+	return h, events
+}
+
+func TestTaskRunner_CheckpointHook_Checkpoint(t *testing.T) {
+	ci.Parallel(t)
+
+	driver := &mockCheckpointDriver{}
+	handle := NewDriverHandle(nil, "task-1", mock.Job().TaskGroups[0].Tasks[0], time.Second, nil)
+	h, events := testCheckpointHook(t, driver, handle)
+
+	// The task is checkpointed once per planned disconnect, along with the
+	// deadline of the disconnect
+	deadline := time.Now().Add(time.Hour).Round(0)
+	h.checkpointTask(deadline)
+	h.checkpointTask(deadline)
+	require.Equal(t, []string{"task-1"}, driver.checkpointed)
+	require.FileExists(t, filepath.Join(h.dir, "state"))
+	require.Len(t, events.events, 1)
+	require.Equal(t, structs.TaskCheckpointed, events.events[0].Type)
+
+	saved, err := checkpointDeadline(h.dir)
+	require.NoError(t, err)
+	require.True(t, deadline.Equal(saved))
+
+	// Exiting removes the checkpoint, and the task is checkpointed again on
+	// the next disconnect
+	require.NoError(t, h.Exited(context.Background(), nil, nil))
+	require.NoDirExists(t, h.dir)
+
+	h.checkpointTask(deadline)
+	require.Equal(t, []string{"task-1", "task-1"}, driver.checkpointed)
+	require.FileExists(t, filepath.Join(h.dir, "state"))
+}
+
+func TestTaskRunner_CheckpointHook_Failed(t *testing.T) {
+	ci.Parallel(t)
+
+	driver := &mockCheckpointDriver{err: errors.New("no space left")}
+	handle := NewDriverHandle(nil, "task-1", mock.Job().TaskGroups[0].Tasks[0], time.Second, nil)
+	h, events := testCheckpointHook(t, driver, handle)
+
+	// A failed checkpoint is reported, and doesn't leave a partial
+	// checkpoint behind
+	h.checkpointTask(time.Now().Add(time.Hour))
+	require.NoDirExists(t, h.dir)
+	require.Len(t, events.events, 1)
+	require.Equal(t, structs.TaskCheckpointFailed, events.events[0].Type)
+
+	_, err := checkpointDeadline(h.dir)
+	require.Error(t, err)
+}
+
+func TestTaskRunner_CheckpointHook_NotRunning(t *testing.T) {
+	ci.Parallel(t)
+
+	driver := &mockCheckpointDriver{}
+	h, events := testCheckpointHook(t, driver, nil)
+
+	h.checkpointTask(time.Now().Add(time.Hour))
+	require.Empty(t, driver.checkpointed)
+	require.Empty(t, events.events)
+}
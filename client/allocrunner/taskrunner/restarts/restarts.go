@@ -20,6 +20,51 @@ const (
 	ReasonDelay              = "Exceeded allowed attempts, applying a delay"
 )
 
+// GroupBudget is a restart budget shared by the restart trackers of all the
+// tasks of an allocation. It limits the number of restarts across the tasks
+// within a rolling interval.
+type GroupBudget struct {
+	policy   *structs.RestartBudget
+	restarts []time.Time // When the restarts in the interval happened
+	lock     sync.Mutex
+}
+
+func NewGroupBudget(policy *structs.RestartBudget) *GroupBudget {
+	return &GroupBudget{
+		policy: policy,
+	}
+}
+
+// take records a restart at the given time and returns false if the budget
+// doesn't allow it.
+func (b *GroupBudget) take(now time.Time) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	// Drop the restarts that left the rolling interval.
+	cutoff := now.Add(-b.policy.Interval)
+	i := 0
+	for ; i < len(b.restarts); i++ {
+		if b.restarts[i].After(cutoff) {
+			break
+		}
+	}
+	b.restarts = b.restarts[i:]
+
+	if len(b.restarts) >= b.policy.Attempts {
+		return false
+	}
+	b.restarts = append(b.restarts, now)
+	return true
+}
+
+// Count returns the number of restarts in the current interval.
+func (b *GroupBudget) Count() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return len(b.restarts)
+}
+
 func NewRestartTracker(policy *structs.RestartPolicy, jobType string, tlc *structs.TaskLifecycleConfig) *RestartTracker {
 	onSuccess := true
 
@@ -62,6 +107,7 @@ type RestartTracker struct {
 	startTime        time.Time // When the interval began
 	reason           string    // The reason for the last state
 	policy           *structs.RestartPolicy
+	budget           *GroupBudget // Restart budget shared with the other tasks
 	rand             *rand.Rand
 	lock             sync.Mutex
 }
@@ -73,6 +119,16 @@ func (r *RestartTracker) SetPolicy(policy *structs.RestartPolicy) {
 	r.policy = policy
 }
 
+// SetBudget sets the restart budget shared with the other tasks of the
+// allocation. Restarts due to failures are only allowed while the budget isn't
+// exhausted.
+func (r *RestartTracker) SetBudget(budget *GroupBudget) *RestartTracker {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.budget = budget
+	return r
+}
+
 // GetPolicy returns a copy of the policy used to determine restarts.
 func (r *RestartTracker) GetPolicy() *structs.RestartPolicy {
 	r.lock.Lock()
@@ -226,16 +282,34 @@ func (r *RestartTracker) GetState() (string, time.Duration) {
 				`Exceeded allowed attempts %d in interval %v and mode is "fail"`,
 				r.policy.Attempts, r.policy.Interval)
 			return structs.TaskNotRestarting, 0
-		} else {
-			r.reason = ReasonDelay
-			return structs.TaskRestarting, r.getDelay()
 		}
+		if !r.takeBudget(now) {
+			return structs.TaskNotRestarting, 0
+		}
+		r.reason = ReasonDelay
+		return structs.TaskRestarting, r.getDelay()
+	}
+
+	if !r.takeBudget(now) {
+		return structs.TaskNotRestarting, 0
 	}
 
 	r.reason = ReasonWithinPolicy
 	return structs.TaskRestarting, r.jitter()
 }
 
+// takeBudget records a restart against the group restart budget, if any, and
+// sets the reason and returns false if the budget is exhausted.
+func (r *RestartTracker) takeBudget(now time.Time) bool {
+	if r.budget == nil || r.budget.take(now) {
+		return true
+	}
+	r.reason = fmt.Sprintf(
+		"Exceeded group restart budget of %d attempts in interval %v",
+		r.budget.policy.Attempts, r.budget.policy.Interval)
+	return false
+}
+
 // getDelay returns the delay time to enter the next interval.
 func (r *RestartTracker) getDelay() time.Duration {
 	end := r.startTime.Add(r.policy.Interval)
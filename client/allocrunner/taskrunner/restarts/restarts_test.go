@@ -79,6 +79,48 @@ func TestClient_RestartTracker_ModeFail(t *testing.T) {
 	}
 }
 
+func TestClient_RestartTracker_GroupBudget(t *testing.T) {
+	ci.Parallel(t)
+	p := testPolicy(true, structs.RestartPolicyModeDelay)
+	budget := NewGroupBudget(&structs.RestartBudget{
+		Attempts: 4,
+		Interval: time.Hour,
+	})
+
+	rt1 := NewRestartTracker(p, structs.JobTypeService, nil).SetBudget(budget)
+	rt2 := NewRestartTracker(p, structs.JobTypeService, nil).SetBudget(budget)
+
+	// Restarts of both tasks are counted against the shared budget.
+	for i := 0; i < 2; i++ {
+		state, _ := rt1.SetExitResult(testExitResult(127)).GetState()
+		require.Equal(t, structs.TaskRestarting, state)
+		state, _ = rt2.SetExitResult(testExitResult(127)).GetState()
+		require.Equal(t, structs.TaskRestarting, state)
+	}
+	require.Equal(t, 4, budget.Count())
+
+	// Restarts not caused by failures don't use the budget.
+	state, _ := rt1.SetRestartTriggered(false).GetState()
+	require.Equal(t, structs.TaskRestarting, state)
+
+	// The next failure exhausts the budget even though the task's own
+	// restart policy would still allow a restart.
+	state, _ = rt1.SetExitResult(testExitResult(127)).GetState()
+	require.Equal(t, structs.TaskNotRestarting, state)
+	require.Contains(t, rt1.GetReason(), "group restart budget")
+
+	// Restarts that left the interval are no longer counted.
+	budget.lock.Lock()
+	for i := range budget.restarts {
+		budget.restarts[i] = budget.restarts[i].Add(-2 * time.Hour)
+	}
+	budget.lock.Unlock()
+
+	state, _ = rt2.SetExitResult(testExitResult(127)).GetState()
+	require.Equal(t, structs.TaskRestarting, state)
+	require.Equal(t, 1, budget.Count())
+}
+
 func TestClient_RestartTracker_NoRestartOnSuccess(t *testing.T) {
 	ci.Parallel(t)
 	p := testPolicy(false, structs.RestartPolicyModeDelay)
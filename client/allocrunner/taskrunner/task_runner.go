@@ -249,6 +249,14 @@ type TaskRunner struct {
 	// closed.
 	waitOnServers bool
 
+	// restoreFromCheckpoint is set if a restore fails, so that the task is
+	// started from its checkpoint if it has one.
+	restoreFromCheckpoint bool
+
+	// checkpointHook is set if the task is checkpointed on planned
+	// disconnects.
+	checkpointHook *checkpointHook
+
 	networkIsolationLock sync.Mutex
 	networkIsolationSpec *drivers.NetworkIsolationSpec
 
@@ -260,6 +268,10 @@ type TaskRunner struct {
 
 	// getter is an interface for retrieving artifacts.
 	getter cinterfaces.ArtifactGetter
+
+	// lastHeartbeat returns the time of the last successful heartbeat of the
+	// client.
+	lastHeartbeat func() time.Time
 }
 
 type Config struct {
@@ -328,6 +340,14 @@ type Config struct {
 
 	// Getter is an interface for retrieving artifacts.
 	Getter cinterfaces.ArtifactGetter
+
+	// RestartBudget is the restart budget shared by the tasks of the
+	// allocation, or nil if the task group doesn't set one.
+	RestartBudget *restarts.GroupBudget
+
+	// LastHeartbeat returns the time of the last successful heartbeat of the
+	// client to the servers.
+	LastHeartbeat func() time.Time
 }
 
 func NewTaskRunner(config *Config) (*TaskRunner, error) {
@@ -388,6 +408,7 @@ func NewTaskRunner(config *Config) (*TaskRunner, error) {
 		shutdownDelayCancelFn:  config.ShutdownDelayCancelFn,
 		serviceRegWrapper:      config.ServiceRegWrapper,
 		getter:                 config.Getter,
+		lastHeartbeat:          config.LastHeartbeat,
 	}
 
 	// Create the logger based on the allocation ID
@@ -416,6 +437,9 @@ func NewTaskRunner(config *Config) (*TaskRunner, error) {
 		rp = tg.RestartPolicy
 	}
 	tr.restartTracker = restarts.NewRestartTracker(rp, tr.alloc.Job.Type, config.Task.Lifecycle)
+	if config.RestartBudget != nil {
+		tr.restartTracker.SetBudget(config.RestartBudget)
+	}
 
 	// Get the driver
 	if err := tr.initDriver(); err != nil {
@@ -423,13 +447,14 @@ func NewTaskRunner(config *Config) (*TaskRunner, error) {
 		return nil, err
 	}
 
-	// Initialize the runners hooks. Must come after initDriver so hooks
-	// can use tr.driverCapabilities
-	tr.initHooks()
-
 	// Initialize base labels
 	tr.initLabels()
 
+	// Initialize the runners hooks. Must come after initDriver so hooks
+	// can use tr.driverCapabilities, and after initLabels so hooks can
+	// publish metrics
+	tr.initHooks()
+
 	// Initialize initial task received event
 	tr.appendEvent(structs.NewTaskEvent(structs.TaskReceived))
 
@@ -897,7 +922,7 @@ func (tr *TaskRunner) runDriver() error {
 	}
 
 	// Start the job if there's no existing handle (or if RecoverTask failed)
-	handle, net, err := tr.driver.StartTask(taskConfig)
+	handle, net, err := tr.startTask(taskConfig)
 	if err != nil {
 		// The plugin has died, try relaunching it
 		if err == bstructs.ErrPluginShutdown {
@@ -1182,6 +1207,7 @@ func (tr *TaskRunner) Restore() error {
 		if restored {
 			return nil
 		}
+		tr.restoreFromCheckpoint = true
 
 		alloc := tr.Alloc()
 		if tr.state.State == structs.TaskStateDead || alloc.TerminalStatus() || alloc.Job.Type == structs.JobTypeSystem {
@@ -3,6 +3,7 @@ package taskrunner
 import (
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/state"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
 )
 
 func (tr *TaskRunner) Alloc() *structs.Allocation {
@@ -82,10 +83,15 @@ func (tr *TaskRunner) getNomadToken() string {
 	return tr.nomadToken
 }
 
+// setNomadToken updates the workload identity on the task runner, and in the
+// task's environment if the task's identity block asks for it.
 func (tr *TaskRunner) setNomadToken(token string) {
 	tr.nomadTokenLock.Lock()
 	defer tr.nomadTokenLock.Unlock()
 	tr.nomadToken = token
+
+	identity := tr.Task().Identity
+	tr.envBuilder.SetWorkloadToken(token, identity != nil && identity.Env)
 }
 
 // getDriverHandle returns a driver handle.
@@ -106,13 +112,34 @@ func (tr *TaskRunner) setDriverHandle(handle *DriverHandle) {
 	tr.envBuilder.SetDriverNetwork(handle.net)
 }
 
+// clearDriverHandle destroys the task and clears its driver handle, along
+// with the task handle persisted to reattach to it.
 func (tr *TaskRunner) clearDriverHandle() {
 	tr.handleLock.Lock()
-	defer tr.handleLock.Unlock()
 	if tr.handle != nil {
 		tr.driver.DestroyTask(tr.handle.ID(), true)
 	}
 	tr.handle = nil
+	tr.handleLock.Unlock()
+
+	tr.stateLock.Lock()
+	defer tr.stateLock.Unlock()
+	if tr.localState.TaskHandle == nil {
+		return
+	}
+	tr.localState.TaskHandle = nil
+	tr.localState.DriverNetwork = nil
+	if err := tr.stateDB.PutTaskRunnerLocalState(tr.allocID, tr.taskName, tr.localState); err != nil {
+		tr.logger.Warn("error persisting cleared task handle", "error", err)
+	}
+}
+
+// TaskHandle returns a copy of the task handle and driver network used to
+// reattach to the running task, or nil if the task isn't running.
+func (tr *TaskRunner) TaskHandle() (*drivers.TaskHandle, *drivers.DriverNetwork) {
+	tr.stateLock.RLock()
+	defer tr.stateLock.RUnlock()
+	return tr.localState.TaskHandle.Copy(), tr.localState.DriverNetwork.Copy()
 }
 
 // setKillErr stores any error that arouse while killing the task
@@ -115,6 +115,8 @@ func (tr *TaskRunner) initHooks() {
 			envBuilder:      tr.envBuilder,
 			consulNamespace: consulNamespace,
 			nomadNamespace:  tr.alloc.Job.Namespace,
+			lastHeartbeat:   tr.lastHeartbeat,
+			metricLabels:    tr.baseLabels,
 		}))
 	}
 
@@ -171,6 +173,20 @@ func (tr *TaskRunner) initHooks() {
 	if tr.driverCapabilities.RemoteTasks {
 		tr.runnerHooks = append(tr.runnerHooks, newRemoteTaskHook(tr, hookLogger))
 	}
+
+	// If the task is to be checkpointed and its driver supports it, add the
+	// checkpoint hook.
+	if cd, ok := tr.checkpointDriver(); ok {
+		tr.checkpointHook = newCheckpointHook(&checkpointHookConfig{
+			driver:     cd,
+			checkpoint: task.Checkpoint,
+			dir:        tr.checkpointDir(),
+			handle:     tr.getDriverHandle,
+			events:     tr,
+			logger:     hookLogger,
+		})
+		tr.runnerHooks = append(tr.runnerHooks, tr.checkpointHook)
+	}
 }
 
 func (tr *TaskRunner) emitHookError(err error, hookName string) {
@@ -295,6 +295,49 @@ func TestTaskRunner_Stop_ExitCode(t *testing.T) {
 
 }
 
+// TestTaskRunner_TaskHandle_Cleared asserts that the persisted task handle is
+// cleared once the task exits, so it isn't used to reattach to a dead task.
+func TestTaskRunner_TaskHandle_Cleared(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	alloc.Job.TaskGroups[0].Count = 1
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"run_for": "500ms",
+	}
+	conf, cleanup := testTaskRunnerConfig(t, alloc, task.Name)
+	defer cleanup()
+
+	stateDB, err := cstate.NewBoltStateDB(conf.Logger, t.TempDir())
+	require.NoError(t, err)
+	defer stateDB.Close()
+	conf.StateDB = stateDB
+
+	tr, err := NewTaskRunner(conf)
+	require.NoError(t, err)
+	go tr.Run()
+	defer tr.Kill(context.Background(), structs.NewTaskEvent("cleanup"))
+
+	testWaitForTaskToStart(t, tr)
+
+	handle, _ := tr.TaskHandle()
+	require.NotNil(t, handle)
+	ls, _, err := stateDB.GetTaskRunnerState(alloc.ID, task.Name)
+	require.NoError(t, err)
+	require.NotNil(t, ls.TaskHandle)
+
+	testWaitForTaskToDie(t, tr)
+
+	handle, _ = tr.TaskHandle()
+	require.Nil(t, handle)
+	ls, _, err = stateDB.GetTaskRunnerState(alloc.ID, task.Name)
+	require.NoError(t, err)
+	require.Nil(t, ls.TaskHandle)
+	require.Nil(t, ls.DriverNetwork)
+}
+
 // TestTaskRunner_Restore_Running asserts restoring a running task does not
 // rerun the task.
 func TestTaskRunner_Restore_Running(t *testing.T) {
@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	metrics "github.com/armon/go-metrics"
 	ctconf "github.com/hashicorp/consul-template/config"
 	"github.com/hashicorp/consul-template/manager"
 	"github.com/hashicorp/consul-template/signals"
@@ -36,6 +37,10 @@ const (
 	// DefaultMaxTemplateEventRate is the default maximum rate at which a
 	// template event should be fired.
 	DefaultMaxTemplateEventRate = 3 * time.Second
+
+	// staleCheckInterval is how often the staleness of the rendered templates
+	// is checked against their budget and published.
+	staleCheckInterval = time.Second
 )
 
 var (
@@ -112,6 +117,13 @@ type TaskTemplateManagerConfig struct {
 
 	// NomadToken is the Nomad token or identity claim for the task
 	NomadToken string
+
+	// LastHeartbeat returns the time of the last successful heartbeat of the
+	// client to the servers. If nil, the client is considered connected.
+	LastHeartbeat func() time.Time
+
+	// MetricLabels are the labels of the metrics published for the task.
+	MetricLabels []metrics.Label
 }
 
 // Validate validates the configuration.
@@ -150,20 +162,22 @@ func NewTaskTemplateManager(config *TaskTemplateManagerConfig) (*TaskTemplateMan
 
 	// Parse the signals that we need
 	for _, tmpl := range config.Templates {
-		if tmpl.ChangeSignal == "" {
-			continue
-		}
+		for _, signal := range []string{tmpl.ChangeSignal, tmpl.StaleSignal} {
+			if signal == "" {
+				continue
+			}
 
-		sig, err := signals.Parse(tmpl.ChangeSignal)
-		if err != nil {
-			return nil, fmt.Errorf("Failed to parse signal %q", tmpl.ChangeSignal)
-		}
+			sig, err := signals.Parse(signal)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to parse signal %q", signal)
+			}
 
-		if tm.signals == nil {
-			tm.signals = make(map[string]os.Signal)
-		}
+			if tm.signals == nil {
+				tm.signals = make(map[string]os.Signal)
+			}
 
-		tm.signals[tmpl.ChangeSignal] = sig
+			tm.signals[signal] = sig
+		}
 	}
 
 	// Build the consul-template runner
@@ -240,8 +254,9 @@ func (tm *TaskTemplateManager) run() {
 	// Unblock the task
 	close(tm.config.UnblockCh)
 
-	// If all our templates are change mode no-op, then we can exit here
-	if tm.allTemplatesNoop() {
+	// If all our templates are change mode no-op and their staleness isn't
+	// monitored, then we can exit here
+	if tm.allTemplatesNoop() && !tm.monitorStaleness() {
 		return
 	}
 
@@ -381,10 +396,25 @@ func (tm *TaskTemplateManager) handleTemplateRerenders(allRenderedTime time.Time
 	// A lookup for the last time the template was handled
 	handledRenders := make(map[string]time.Time, len(tm.config.Templates))
 
+	// The templates whose stale signal has been sent since they were last
+	// fresh
+	staleSignaled := make(map[*structs.Template]struct{})
+
+	var staleCh <-chan time.Time
+	if tm.monitorStaleness() {
+		staleTicker := time.NewTicker(staleCheckInterval)
+		defer staleTicker.Stop()
+		staleCh = staleTicker.C
+	}
+
 	for {
 		select {
 		case <-tm.shutdownCh:
 			return
+		case now := <-staleCh:
+			if tm.checkStaleness(now, staleSignaled) {
+				return
+			}
 		case err, ok := <-tm.runner.ErrCh:
 			if !ok {
 				continue
@@ -577,6 +607,97 @@ func (tm *TaskTemplateManager) processScript(script *structs.ChangeScript, wg *s
 			)))
 }
 
+// monitorStaleness returns whether the staleness of the templates must be
+// checked, because a template has a staleness budget or the allocation
+// metrics are published.
+func (tm *TaskTemplateManager) monitorStaleness() bool {
+	if tm.config.ClientConfig.PublishAllocationMetrics {
+		return true
+	}
+	for _, tmpl := range tm.config.Templates {
+		if tmpl.MaxStale > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// checkStaleness publishes the staleness of the rendered templates and takes
+// the stale mode action of the templates that exceed their staleness budget.
+// The data of a template is fresh when it is rendered and for as long as the
+// client heartbeats to the servers. Templates in staleSignaled have already
+// been signaled for their current stale period. It returns true if the task
+// was killed.
+func (tm *TaskTemplateManager) checkStaleness(now time.Time, staleSignaled map[*structs.Template]struct{}) bool {
+	var lastHeartbeat time.Time
+	if tm.config.LastHeartbeat != nil {
+		lastHeartbeat = tm.config.LastHeartbeat()
+	} else {
+		lastHeartbeat = now
+	}
+
+	var maxStaleness time.Duration
+	signals := make(map[string]struct{})
+	for id, event := range tm.runner.RenderEvents() {
+		fresh := event.LastWouldRender
+		if lastHeartbeat.After(fresh) {
+			fresh = lastHeartbeat
+		}
+		staleness := now.Sub(fresh)
+		if staleness < 0 {
+			staleness = 0
+		}
+		if staleness > maxStaleness {
+			maxStaleness = staleness
+		}
+
+		for _, tmpl := range tm.lookup[id] {
+			if tmpl.MaxStale <= 0 {
+				continue
+			}
+
+			if staleness <= tmpl.MaxStale {
+				delete(staleSignaled, tmpl)
+				continue
+			}
+
+			switch tmpl.StaleMode {
+			case structs.TemplateStaleModeSignal:
+				if _, ok := staleSignaled[tmpl]; !ok {
+					staleSignaled[tmpl] = struct{}{}
+					signals[tmpl.StaleSignal] = struct{}{}
+				}
+			default:
+				tm.config.Lifecycle.Kill(context.Background(),
+					structs.NewTaskEvent(structs.TaskKilling).
+						SetFailsTask().
+						SetDisplayMessage(fmt.Sprintf("Template data is stale for %v, exceeding max_stale of %v",
+							staleness.Truncate(time.Second), tmpl.MaxStale)))
+				return true
+			}
+		}
+	}
+
+	if tm.config.ClientConfig.PublishAllocationMetrics {
+		metrics.SetGaugeWithLabels([]string{"client", "allocs", "template", "staleness"},
+			float32(maxStaleness.Seconds()), tm.config.MetricLabels)
+	}
+
+	for signal := range signals {
+		s := tm.signals[signal]
+		event := structs.NewTaskEvent(structs.TaskSignaling).SetTaskSignal(s).SetDisplayMessage("Template data exceeded max_stale")
+		if err := tm.config.Lifecycle.Signal(event, signal); err != nil {
+			tm.config.Lifecycle.Kill(context.Background(),
+				structs.NewTaskEvent(structs.TaskKilling).
+					SetFailsTask().
+					SetDisplayMessage(fmt.Sprintf("Template failed to send stale signal %v: %v", s, err)))
+			return true
+		}
+	}
+
+	return false
+}
+
 // allTemplatesNoop returns whether all the managed templates have change mode noop.
 func (tm *TaskTemplateManager) allTemplatesNoop() bool {
 	for _, tmpl := range tm.config.Templates {
@@ -15,6 +15,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
@@ -147,6 +148,7 @@ type testHarness struct {
 	consul         *ctestutil.TestServer
 	emitRate       time.Duration
 	nomadNamespace string
+	lastHeartbeat  func() time.Time
 }
 
 // newTestHarness returns a harness starting a dev consul and vault server,
@@ -221,6 +223,7 @@ func (h *testHarness) startWithErr() error {
 		TaskDir:              h.taskDir,
 		EnvBuilder:           h.envBuilder,
 		MaxTemplateEventRate: h.emitRate,
+		LastHeartbeat:        h.lastHeartbeat,
 	})
 	return err
 }
@@ -1209,6 +1212,95 @@ func TestTaskTemplateManager_Signal_Error(t *testing.T) {
 	require.Contains(harness.mockHooks.KillEvent.DisplayMessage, "failed to send signals")
 }
 
+func TestTaskTemplateManager_MaxStale_Fail(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	template := &structs.Template{
+		EmbeddedTmpl: "hello, world!",
+		DestPath:     "my.tmpl",
+		ChangeMode:   structs.TemplateChangeModeNoop,
+		MaxStale:     time.Second,
+		StaleMode:    structs.TemplateStaleModeFail,
+	}
+
+	// The client heartbeats until disconnected is set
+	var disconnected atomic.Bool
+	lastHeartbeat := time.Now()
+	harness := newTestHarness(t, []*structs.Template{template}, false, false)
+	harness.lastHeartbeat = func() time.Time {
+		if !disconnected.Load() {
+			lastHeartbeat = time.Now()
+		}
+		return lastHeartbeat
+	}
+	harness.start(t)
+	defer harness.stop()
+
+	select {
+	case <-harness.mockHooks.UnblockCh:
+	case <-time.After(time.Duration(5*testutil.TestMultiplier()) * time.Second):
+		t.Fatalf("Task unblock should have been called")
+	}
+
+	// The data is kept fresh while the client is connected
+	select {
+	case <-harness.mockHooks.KillCh:
+		t.Fatalf("Task should not have been killed: %v", harness.mockHooks.KillEvent)
+	case <-time.After(3 * staleCheckInterval):
+	}
+
+	// The task fails once the budget is exceeded after the client disconnects
+	disconnected.Store(true)
+	select {
+	case <-harness.mockHooks.KillCh:
+	case <-time.After(time.Duration(5*testutil.TestMultiplier()) * time.Second):
+		t.Fatalf("Task should have been killed")
+	}
+
+	require.True(harness.mockHooks.KillEvent.FailsTask)
+	require.Contains(harness.mockHooks.KillEvent.DisplayMessage, "Template data is stale")
+}
+
+func TestTaskTemplateManager_MaxStale_Signal(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	template := &structs.Template{
+		EmbeddedTmpl: "hello, world!",
+		DestPath:     "my.tmpl",
+		ChangeMode:   structs.TemplateChangeModeNoop,
+		MaxStale:     time.Second,
+		StaleMode:    structs.TemplateStaleModeSignal,
+		StaleSignal:  "SIGALRM",
+	}
+
+	// The client never heartbeats
+	harness := newTestHarness(t, []*structs.Template{template}, false, false)
+	harness.lastHeartbeat = func() time.Time { return time.Time{} }
+	harness.start(t)
+	defer harness.stop()
+
+	select {
+	case <-harness.mockHooks.UnblockCh:
+	case <-time.After(time.Duration(5*testutil.TestMultiplier()) * time.Second):
+		t.Fatalf("Task unblock should have been called")
+	}
+
+	select {
+	case <-harness.mockHooks.SignalCh:
+	case <-time.After(time.Duration(5*testutil.TestMultiplier()) * time.Second):
+		t.Fatalf("Task should have been signaled")
+	}
+
+	// The task is only signaled once while the data stays stale
+	time.Sleep(3 * staleCheckInterval)
+	harness.mockHooks.signalLock.Lock()
+	require.Equal([]string{"SIGALRM"}, harness.mockHooks.Signals)
+	harness.mockHooks.signalLock.Unlock()
+	require.Nil(harness.mockHooks.KillEvent)
+}
+
 func TestTaskTemplateManager_ScriptExecution(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
+	metrics "github.com/armon/go-metrics"
 	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
 	ti "github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
@@ -42,6 +44,13 @@ type templateHookConfig struct {
 
 	// nomadNamespace is the job's Nomad namespace
 	nomadNamespace string
+
+	// lastHeartbeat returns the time of the last successful heartbeat of the
+	// client
+	lastHeartbeat func() time.Time
+
+	// metricLabels are the labels of the task's metrics
+	metricLabels []metrics.Label
 }
 
 type templateHook struct {
@@ -144,6 +153,8 @@ func (h *templateHook) newManager() (unblock chan struct{}, err error) {
 		MaxTemplateEventRate: template.DefaultMaxTemplateEventRate,
 		NomadNamespace:       h.config.nomadNamespace,
 		NomadToken:           h.nomadToken,
+		LastHeartbeat:        h.config.lastHeartbeat,
+		MetricLabels:         h.config.metricLabels,
 	})
 	if err != nil {
 		h.logger.Error("failed to create template manager", "error", err)
@@ -88,14 +88,16 @@ const (
 	// connected to server yet
 	noServerRetryIntv = time.Second
 
-	// stateSnapshotIntv is how often the client snapshots state
-	stateSnapshotIntv = 60 * time.Second
-
 	// initialHeartbeatStagger is used to stagger the interval between
 	// starting and the initial heartbeat. After the initial heartbeat,
 	// we switch to using the TTL specified by the servers.
 	initialHeartbeatStagger = 10 * time.Second
 
+	// reconnectJitterFraction is the fraction of the reconnect window given
+	// by the servers which is added at random to the window before retrying
+	// to heartbeat.
+	reconnectJitterFraction = 10
+
 	// nodeUpdateRetryIntv is how often the client checks for updates to the
 	// node attributes or meta map.
 	nodeUpdateRetryIntv = 5 * time.Second
@@ -165,6 +167,8 @@ type AllocRunner interface {
 
 	GetTaskExecHandler(taskName string) drivermanager.TaskExecHandler
 	GetTaskDriverCapabilities(taskName string) (*drivers.Capabilities, error)
+	GetTaskHandle(taskName string) (*drivers.TaskHandle, *drivers.DriverNetwork)
+	Checkpoint(deadline time.Time)
 }
 
 // Client is used to implement the client interaction with Nomad. Clients
@@ -188,6 +192,11 @@ type Client struct {
 	config     *config.Config
 	configLock sync.Mutex
 
+	// dynamicMeta is the node metadata applied with the NodeMeta.Apply RPC
+	// over the configured metadata. It must only be accessed with configLock
+	// held.
+	dynamicMeta map[string]*string
+
 	logger    hclog.InterceptLogger
 	rpcLogger hclog.Logger
 
@@ -207,6 +216,24 @@ type Client struct {
 	heartbeatLock   sync.Mutex
 	heartbeatStop   *heartbeatStop
 
+	// plannedDisconnect is the planned disconnect of the node last reported
+	// by the servers, and is accessed using the heartbeatLock.
+	plannedDisconnect *structs.PlannedDisconnect
+
+	// checkpointedDeadline is the deadline of the planned disconnect the
+	// tasks were last checkpointed for. checkpointLock is held while the
+	// tasks are checkpointed.
+	checkpointedDeadline time.Time
+	checkpointLock       sync.Mutex
+
+	// disconnectHooks runs the hooks of jobs when the client loses and
+	// regains contact with the servers
+	disconnectHooks *disconnectHooks
+
+	// secureVariables caches the secure variables read through the client if
+	// stale secure variables are enabled
+	secureVariables *secureVariablesCache
+
 	// triggerDiscoveryCh triggers Consul discovery; see triggerDiscovery
 	triggerDiscoveryCh chan struct{}
 
@@ -411,6 +438,15 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 	// Start server manager rebalancing go routine
 	go c.servers.Start()
 
+	// Initialize the cache of secure variables served while disconnected
+	if cfg.StaleSecureVariables {
+		cache, err := newSecureVariablesCache()
+		if err != nil {
+			return nil, err
+		}
+		c.secureVariables = cache
+	}
+
 	// initialize the client
 	if err := c.init(); err != nil {
 		return nil, fmt.Errorf("failed to initialize client: %v", err)
@@ -513,8 +549,14 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 	// lifetime when out of touch with the server
 	go c.heartbeatStop.watch()
 
+	// Watch for disconnection and reconnection to run the hooks of jobs
+	c.disconnectHooks = newDisconnectHooks(c.NodeID(), c.heartbeatState,
+		c.getAllocRunners, c.checkpointAllocs, logger, c.shutdownCh)
+	go c.disconnectHooks.watch()
+
 	// Add the stats collector
-	statsCollector := stats.NewHostStatsCollector(c.logger, c.GetConfig().AllocDir, c.devicemanager.AllStats)
+	conf := c.GetConfig()
+	statsCollector := stats.NewHostStatsCollector(c.logger, conf.AllocDir, c.devicemanager.AllStats, conf.CollectHostSystemMetrics)
 	c.hostStatsCollector = statsCollector
 
 	// Add the garbage collector
@@ -824,6 +866,10 @@ func (c *Client) Shutdown() error {
 			arGroup.AddCh(ar.DestroyCh())
 		}
 	} else {
+		// The tasks are left running, but may not survive the client being
+		// stopped for a planned disconnect
+		c.checkpointAllocs()
+
 		// In normal mode call shutdown
 		for _, ar := range c.getAllocRunners() {
 			ar.Shutdown()
@@ -1168,6 +1214,9 @@ func (c *Client) restoreState() error {
 		// Send to server with clientstatus=failed
 	}
 
+	// Recover task handles missing from the alloc state
+	c.restoreTaskHandles(allocs)
+
 	// Load each alloc back
 	for _, alloc := range allocs {
 
@@ -1209,6 +1258,7 @@ func (c *Client) restoreState() error {
 			CheckStore:          c.checkStore,
 			RPCClient:           c,
 			Getter:              c.getter,
+			LastHeartbeat:       c.lastHeartbeat,
 		}
 
 		ar, err := allocrunner.NewAllocRunner(arConf)
@@ -1353,6 +1403,23 @@ func (c *Client) NumAllocs() int {
 	return n
 }
 
+// NumStoppingAllocs returns the number of allocations the servers stopped,
+// such as when draining the node, whose tasks are still running.
+func (c *Client) NumStoppingAllocs() int {
+	n := 0
+	for _, ar := range c.getAllocRunners() {
+		if !ar.Alloc().ServerTerminalStatus() {
+			continue
+		}
+		select {
+		case <-ar.WaitCh():
+		default:
+			n++
+		}
+	}
+	return n
+}
+
 // ensureNodeID restores, or generates if necessary, a unique node ID and
 // SecretID.  The node ID is, if available, a persistent unique ID.  The secret
 // ID is a high-entropy random UUID.
@@ -1459,6 +1526,7 @@ func (c *Client) setupNode() error {
 		node.NodeResources = &structs.NodeResources{}
 		node.NodeResources.MinDynamicPort = newConfig.MinDynamicPort
 		node.NodeResources.MaxDynamicPort = newConfig.MaxDynamicPort
+		node.NodeResources.MemoryOversubscriptionRatio = newConfig.MemoryOversubscriptionRatio
 	}
 	if node.ReservedResources == nil {
 		node.ReservedResources = &structs.NodeReservedResources{}
@@ -1515,6 +1583,15 @@ func (c *Client) setupNode() error {
 		node.Meta["connect.proxy_concurrency"] = defaultConnectProxyConcurrency
 	}
 
+	// Apply the dynamic metadata, which takes precedence over the configured
+	// metadata across restarts
+	dynamicMeta, err := c.stateDB.GetNodeMeta()
+	if err != nil {
+		return fmt.Errorf("failed to restore dynamic node metadata: %v", err)
+	}
+	applyNodeMeta(node.Meta, dynamicMeta)
+	c.dynamicMeta = dynamicMeta
+
 	c.config = newConfig
 	return nil
 }
@@ -1584,8 +1661,10 @@ func (c *Client) updateNodeFromFingerprint(response *fingerprint.FingerprintResp
 
 		response.NodeResources.MinDynamicPort = newConfig.MinDynamicPort
 		response.NodeResources.MaxDynamicPort = newConfig.MaxDynamicPort
+		response.NodeResources.MemoryOversubscriptionRatio = newConfig.MemoryOversubscriptionRatio
 		if newConfig.Node.NodeResources.MinDynamicPort != response.NodeResources.MinDynamicPort ||
-			newConfig.Node.NodeResources.MaxDynamicPort != response.NodeResources.MaxDynamicPort {
+			newConfig.Node.NodeResources.MaxDynamicPort != response.NodeResources.MaxDynamicPort ||
+			newConfig.Node.NodeResources.MemoryOversubscriptionRatio != response.NodeResources.MemoryOversubscriptionRatio {
 			nodeHasChanged = true
 		}
 
@@ -1673,6 +1752,16 @@ func (c *Client) registerAndHeartbeat() {
 			return
 		}
 		if err := c.updateNodeStatus(); err != nil {
+			var deferred *reconnectDeferredError
+			if errors.As(err, &deferred) {
+				// Jitter the retry so that the nodes given the same window
+				// don't come back all at once
+				intv := deferred.window + helper.RandomStagger(deferred.window/reconnectJitterFraction)
+				c.logger.Info("servers deferred reconnect, retrying", "period", intv)
+				heartbeat = time.After(intv)
+				continue
+			}
+
 			// The servers have changed such that this node has not been
 			// registered before
 			if strings.Contains(err.Error(), "node not found") {
@@ -1700,6 +1789,45 @@ func (c *Client) lastHeartbeat() time.Time {
 	return c.heartbeatStop.getLastOk()
 }
 
+// heartbeatState returns the time of the last successful heartbeat and the
+// heartbeat TTL, which is zero until the client registers.
+func (c *Client) heartbeatState() (time.Time, time.Duration) {
+	c.heartbeatLock.Lock()
+	defer c.heartbeatLock.Unlock()
+	return c.lastHeartbeat(), c.heartbeatTTL
+}
+
+// checkpointAllocs checkpoints the tasks of the allocations of the client if a
+// disconnect of the node is planned, so that the tasks that don't survive the
+// disconnect can be restored from their checkpoint. It is called once the
+// client actually disconnects, so that the checkpoints are as recent as
+// possible, and blocks until all the tasks are checkpointed. The tasks are
+// only checkpointed once per planned disconnect.
+func (c *Client) checkpointAllocs() {
+	c.checkpointLock.Lock()
+	defer c.checkpointLock.Unlock()
+
+	c.heartbeatLock.Lock()
+	planned := c.plannedDisconnect
+	c.heartbeatLock.Unlock()
+
+	if planned == nil || time.Now().After(planned.Deadline) || planned.Deadline.Equal(c.checkpointedDeadline) {
+		return
+	}
+	c.checkpointedDeadline = planned.Deadline
+
+	c.logger.Info("checkpointing tasks for planned disconnect", "deadline", planned.Deadline)
+	var wg sync.WaitGroup
+	for _, ar := range c.getAllocRunners() {
+		wg.Add(1)
+		go func(ar AllocRunner) {
+			defer wg.Done()
+			ar.Checkpoint(planned.Deadline)
+		}(ar)
+	}
+	wg.Wait()
+}
+
 // getHeartbeatRetryIntv is used to retrieve the time to wait before attempting
 // another heartbeat.
 func (c *Client) getHeartbeatRetryIntv(err error) time.Duration {
@@ -1754,15 +1882,19 @@ func (c *Client) getHeartbeatRetryIntv(err error) time.Duration {
 // state of the client
 func (c *Client) periodicSnapshot() {
 	// Create a snapshot timer
-	snapshot := time.After(stateSnapshotIntv)
+	interval := c.GetConfig().StateSnapshotInterval
+	snapshot := time.After(interval)
 
 	for {
 		select {
 		case <-snapshot:
-			snapshot = time.After(stateSnapshotIntv)
+			snapshot = time.After(interval)
 			if err := c.saveState(); err != nil {
 				c.logger.Error("error saving state", "error", err)
 			}
+			if err := c.snapshotTaskHandles(); err != nil {
+				c.logger.Error("error saving client snapshot", "error", err)
+			}
 
 		case <-c.shutdownCh:
 			return
@@ -1915,6 +2047,16 @@ func (c *Client) registerNode() error {
 	return nil
 }
 
+// reconnectDeferredError is returned by updateNodeStatus when the servers
+// deferred the reconnection of the node, which must retry after the window.
+type reconnectDeferredError struct {
+	window time.Duration
+}
+
+func (e *reconnectDeferredError) Error() string {
+	return fmt.Sprintf("servers deferred reconnect for %v", e.window)
+}
+
 // updateNodeStatus is used to heartbeat and update the status of the node
 func (c *Client) updateNodeStatus() error {
 	start := time.Now()
@@ -1930,6 +2072,12 @@ func (c *Client) updateNodeStatus() error {
 	}
 	end := time.Now()
 
+	// The servers didn't accept the heartbeat, as too many nodes are
+	// reconnecting
+	if resp.ReconnectWindow > 0 {
+		return &reconnectDeferredError{window: resp.ReconnectWindow}
+	}
+
 	if len(resp.EvalIDs) != 0 {
 		c.logger.Debug("evaluations triggered by node update", "num_evals", len(resp.EvalIDs))
 	}
@@ -1942,6 +2090,7 @@ func (c *Client) updateNodeStatus() error {
 	c.heartbeatStop.setLastOk(time.Now())
 	c.heartbeatTTL = resp.HeartbeatTTL
 	c.haveHeartbeated = true
+	c.plannedDisconnect = resp.PlannedDisconnect
 	c.heartbeatLock.Unlock()
 	c.logger.Trace("next heartbeat", "period", resp.HeartbeatTTL)
 
@@ -2544,6 +2693,7 @@ func (c *Client) addAlloc(alloc *structs.Allocation, migrateToken string) error
 		CheckStore:          c.checkStore,
 		RPCClient:           c,
 		Getter:              c.getter,
+		LastHeartbeat:       c.lastHeartbeat,
 	}
 
 	ar, err := allocrunner.NewAllocRunner(arConf)
@@ -2985,6 +3135,20 @@ func (c *Client) setGaugeForDiskStats(nodeID string, hStats *stats.HostStats, ba
 		metrics.SetGaugeWithLabels([]string{"client", "host", "disk", "available"}, float32(disk.Available), labels)
 		metrics.SetGaugeWithLabels([]string{"client", "host", "disk", "used_percent"}, float32(disk.UsedPercent), labels)
 		metrics.SetGaugeWithLabels([]string{"client", "host", "disk", "inodes_percent"}, float32(disk.InodesUsedPercent), labels)
+
+		// A device may be mounted more than once, so the stats are also
+		// emitted per mountpoint under their own name, leaving the labels of
+		// the disk metrics unchanged
+		labels = append(labels, metrics.Label{
+			Name:  "mountpoint",
+			Value: disk.Mountpoint,
+		})
+
+		metrics.SetGaugeWithLabels([]string{"client", "host", "mount", "size"}, float32(disk.Size), labels)
+		metrics.SetGaugeWithLabels([]string{"client", "host", "mount", "used"}, float32(disk.Used), labels)
+		metrics.SetGaugeWithLabels([]string{"client", "host", "mount", "available"}, float32(disk.Available), labels)
+		metrics.SetGaugeWithLabels([]string{"client", "host", "mount", "used_percent"}, float32(disk.UsedPercent), labels)
+		metrics.SetGaugeWithLabels([]string{"client", "host", "mount", "inodes_percent"}, float32(disk.InodesUsedPercent), labels)
 	}
 }
 
@@ -3036,6 +3200,33 @@ func (c *Client) setGaugeForAllocationStats(nodeID string, baseLabels []metrics.
 	}
 }
 
+// setGaugeForSystemStats proxies metrics for host system statistics, which
+// are only collected when enabled in the telemetry configuration
+func (c *Client) setGaugeForSystemStats(hStats *stats.HostStats, baseLabels []metrics.Label) {
+	if hStats.System == nil {
+		return
+	}
+
+	if load := hStats.System.Load; load != nil {
+		metrics.SetGaugeWithLabels([]string{"client", "host", "load", "1"}, float32(load.Load1), baseLabels)
+		metrics.SetGaugeWithLabels([]string{"client", "host", "load", "5"}, float32(load.Load5), baseLabels)
+		metrics.SetGaugeWithLabels([]string{"client", "host", "load", "15"}, float32(load.Load15), baseLabels)
+	}
+
+	if conntrack := hStats.System.Conntrack; conntrack != nil {
+		metrics.SetGaugeWithLabels([]string{"client", "host", "conntrack", "count"}, float32(conntrack.Count), baseLabels)
+		metrics.SetGaugeWithLabels([]string{"client", "host", "conntrack", "max"}, float32(conntrack.Max), baseLabels)
+	}
+
+	if sockets := hStats.System.Sockets; sockets != nil {
+		metrics.SetGaugeWithLabels([]string{"client", "host", "sockets", "used"}, float32(sockets.Used), baseLabels)
+		metrics.SetGaugeWithLabels([]string{"client", "host", "sockets", "tcp_inuse"}, float32(sockets.TCPInUse), baseLabels)
+		metrics.SetGaugeWithLabels([]string{"client", "host", "sockets", "tcp_orphan"}, float32(sockets.TCPOrphan), baseLabels)
+		metrics.SetGaugeWithLabels([]string{"client", "host", "sockets", "tcp_timewait"}, float32(sockets.TCPTimeWait), baseLabels)
+		metrics.SetGaugeWithLabels([]string{"client", "host", "sockets", "udp_inuse"}, float32(sockets.UDPInUse), baseLabels)
+	}
+}
+
 // No labels are required so we emit with only a key/value syntax
 func (c *Client) setGaugeForUptime(hStats *stats.HostStats, baseLabels []metrics.Label) {
 	metrics.SetGaugeWithLabels([]string{"client", "uptime"}, float32(hStats.Uptime), baseLabels)
@@ -3051,6 +3242,7 @@ func (c *Client) emitHostStats() {
 	c.setGaugeForUptime(hStats, labels)
 	c.setGaugeForCPUStats(nodeID, hStats, labels)
 	c.setGaugeForDiskStats(nodeID, hStats, labels)
+	c.setGaugeForSystemStats(hStats, labels)
 }
 
 // emitClientMetrics emits lower volume client metrics
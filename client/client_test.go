@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -1792,3 +1793,46 @@ func TestClient_ReconnectAllocs(t *testing.T) {
 	require.False(t, invalid, "expected alloc to not be marked invalid")
 	require.Equal(t, unknownAlloc.AllocModifyIndex, finalAlloc.AllocModifyIndex)
 }
+
+// checkpointAllocRunner records the deadlines its allocation is checkpointed
+// for.
+type checkpointAllocRunner struct {
+	AllocRunner
+	lock      sync.Mutex
+	deadlines []time.Time
+}
+
+func (ar *checkpointAllocRunner) Checkpoint(deadline time.Time) {
+	ar.lock.Lock()
+	defer ar.lock.Unlock()
+	ar.deadlines = append(ar.deadlines, deadline)
+}
+
+func TestClient_checkpointAllocs(t *testing.T) {
+	ci.Parallel(t)
+
+	ar := &checkpointAllocRunner{}
+	c := &Client{
+		allocs: map[string]AllocRunner{"alloc-1": ar},
+		logger: testlog.HCLogger(t),
+	}
+
+	// Nothing is checkpointed without a planned disconnect, or after its
+	// deadline
+	c.checkpointAllocs()
+	c.plannedDisconnect = &structs.PlannedDisconnect{Deadline: time.Now().Add(-time.Minute)}
+	c.checkpointAllocs()
+	require.Empty(t, ar.deadlines)
+
+	// The allocations are checkpointed once per planned disconnect
+	deadline := time.Now().Add(time.Hour)
+	c.plannedDisconnect = &structs.PlannedDisconnect{Deadline: deadline}
+	c.checkpointAllocs()
+	c.checkpointAllocs()
+	require.Equal(t, []time.Time{deadline}, ar.deadlines)
+
+	next := deadline.Add(time.Hour)
+	c.plannedDisconnect = &structs.PlannedDisconnect{Deadline: next}
+	c.checkpointAllocs()
+	require.Equal(t, []time.Time{deadline, next}, ar.deadlines)
+}
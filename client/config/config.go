@@ -147,6 +147,11 @@ type Config struct {
 	// MinDynamicPort is the smallest dynamic port generated
 	MinDynamicPort int
 
+	// MemoryOversubscriptionRatio is the factor by which replacements for
+	// allocations on disconnected clients may exceed the node's memory
+	// capacity. A value of 0 or 1 disables oversubscription.
+	MemoryOversubscriptionRatio float64
+
 	// A mapping of directories on the host OS to attempt to embed inside each
 	// task's chroot.
 	ChrootEnv map[string]string
@@ -174,6 +179,11 @@ type Config struct {
 	// level metrics to remote Telemetry sinks
 	PublishNodeMetrics bool
 
+	// CollectHostSystemMetrics determines whether the client collects the
+	// load, conntrack and socket counts of the host along with the other
+	// host stats
+	CollectHostSystemMetrics bool
+
 	// PublishAllocationMetrics determines whether nomad is going to publish
 	// allocation metrics to remote Telemetry sinks
 	PublishAllocationMetrics bool
@@ -189,6 +199,11 @@ type Config struct {
 	// collector will allow.
 	GCParallelDestroys int
 
+	// StateSnapshotInterval is the time interval at which the client
+	// persists the state of its allocations and a snapshot of the task
+	// handles used to reattach to running tasks on restart
+	StateSnapshotInterval time.Duration
+
 	// GCDiskUsageThreshold is the disk usage threshold given as a percent
 	// beyond which the Nomad client triggers GC of terminal allocations
 	GCDiskUsageThreshold float64
@@ -220,6 +235,15 @@ type Config struct {
 	// DisableRemoteExec disables remote exec targeting tasks on this client
 	DisableRemoteExec bool
 
+	// StaleSecureVariables allows the client to serve the secure variables
+	// read through it from a local cache while it is disconnected from the
+	// servers
+	StaleSecureVariables bool
+
+	// SecureVariablesSocket enables the unix socket in the alloc dir on which
+	// tasks can subscribe to changes of the secure variables they can read
+	SecureVariablesSocket bool
+
 	// TemplateConfig includes configuration for template rendering
 	TemplateConfig *ClientTemplateConfig
 
@@ -729,6 +753,7 @@ func DefaultConfig() *Config {
 		LogLevel:                "DEBUG",
 		GCInterval:              1 * time.Minute,
 		GCParallelDestroys:      2,
+		StateSnapshotInterval:   1 * time.Minute,
 		GCDiskUsageThreshold:    80,
 		GCInodeUsageThreshold:   70,
 		GCMaxAllocs:             50,
@@ -0,0 +1,284 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	cleanhttp "github.com/hashicorp/go-cleanhttp"
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/hashicorp/nomad/plugins/drivers/proto"
+)
+
+const (
+	// disconnectHooksInterval is how often the client checks whether it lost
+	// or regained contact with the servers.
+	disconnectHooksInterval = time.Second
+
+	// disconnectHooksGrace is how long past its heartbeat TTL the client
+	// waits for a heartbeat before running the on_disconnect hooks. It is
+	// shorter than the servers' default heartbeat grace, so that the hooks
+	// run before the servers consider the client disconnected.
+	disconnectHooksGrace = 5 * time.Second
+
+	// disconnectHookDefaultTimeout bounds commands and webhooks of hooks
+	// without a timeout.
+	disconnectHookDefaultTimeout = 30 * time.Second
+
+	disconnectHookEventDisconnect = "disconnect"
+	disconnectHookEventReconnect  = "reconnect"
+)
+
+// disconnectHookPayload is the body of the requests sent to the webhooks of
+// disconnect hooks.
+type disconnectHookPayload struct {
+	Event     string
+	Time      time.Time
+	NodeID    string
+	Namespace string
+	JobID     string
+	AllocID   string
+	TaskGroup string
+}
+
+// disconnectHooks runs the on_disconnect hooks of the jobs of the client's
+// allocations when the client misses its heartbeat deadline, and their
+// on_reconnect hooks once it heartbeats again. This lets applications switch
+// to a degraded mode before the servers consider their allocations lost. The
+// tasks are checkpointed as well when the client disconnects, if a disconnect
+// of the node is planned.
+type disconnectHooks struct {
+	nodeID     string
+	heartbeat  func() (lastOk time.Time, ttl time.Duration)
+	getRunners func() map[string]AllocRunner
+	checkpoint func()
+	httpClient *http.Client
+	logger     hclog.Logger
+	shutdownCh chan struct{}
+
+	disconnected bool
+}
+
+func newDisconnectHooks(
+	nodeID string,
+	heartbeat func() (time.Time, time.Duration),
+	getRunners func() map[string]AllocRunner,
+	checkpoint func(),
+	logger hclog.Logger,
+	shutdownCh chan struct{}) *disconnectHooks {
+
+	return &disconnectHooks{
+		nodeID:     nodeID,
+		heartbeat:  heartbeat,
+		getRunners: getRunners,
+		checkpoint: checkpoint,
+		httpClient: cleanhttp.DefaultClient(),
+		logger:     logger.Named("disconnect_hooks"),
+		shutdownCh: shutdownCh,
+	}
+}
+
+// watch checks the heartbeat of the client until it shuts down.
+func (h *disconnectHooks) watch() {
+	ticker := time.NewTicker(disconnectHooksInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			h.check(now)
+		case <-h.shutdownCh:
+			return
+		}
+	}
+}
+
+// check runs the hooks of the allocations if the client lost or regained
+// contact with the servers since the last check.
+func (h *disconnectHooks) check(now time.Time) {
+	lastOk, ttl := h.heartbeat()
+	if ttl == 0 {
+		// The client hasn't registered yet
+		return
+	}
+
+	disconnected := now.After(lastOk.Add(ttl + disconnectHooksGrace))
+	if disconnected == h.disconnected {
+		return
+	}
+	h.disconnected = disconnected
+
+	event := disconnectHookEventReconnect
+	if disconnected {
+		event = disconnectHookEventDisconnect
+		h.logger.Warn("lost contact with servers; running on_disconnect hooks", "last_heartbeat", lastOk)
+		go h.checkpoint()
+	} else {
+		h.logger.Info("regained contact with servers; running on_reconnect hooks")
+	}
+
+	for _, ar := range h.getRunners() {
+		go h.runAllocHooks(ar, event, now)
+	}
+}
+
+// runAllocHooks runs the hooks of the job of the allocation for the event, in
+// order.
+func (h *disconnectHooks) runAllocHooks(ar AllocRunner, event string, now time.Time) {
+	alloc := ar.Alloc()
+	if alloc == nil || alloc.Job == nil || alloc.ClientTerminalStatus() || ar.IsDestroyed() {
+		return
+	}
+
+	hooks := alloc.Job.OnDisconnect
+	if event == disconnectHookEventReconnect {
+		hooks = alloc.Job.OnReconnect
+	}
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+
+	for _, hook := range hooks {
+		// Hooks for tasks of other groups don't apply
+		if hook.Task != "" && (tg == nil || tg.LookupTask(hook.Task) == nil) {
+			continue
+		}
+
+		logger := h.logger.With("event", event, "alloc_id", alloc.ID, "task", hook.Task)
+		if err := h.runHook(ar, alloc, hook, event, now); err != nil {
+			logger.Warn("failed to run hook", "error", err)
+			continue
+		}
+		logger.Debug("ran hook")
+	}
+}
+
+func (h *disconnectHooks) runHook(ar AllocRunner, alloc *structs.Allocation,
+	hook *structs.DisconnectHook, event string, now time.Time) error {
+
+	timeout := hook.Timeout
+	if timeout == 0 {
+		timeout = disconnectHookDefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	switch {
+	case hook.Signal != "":
+		return ar.Signal(hook.Task, hook.Signal)
+	case hook.Command != "":
+		return h.runCommand(ctx, ar, hook)
+	case hook.Webhook != "":
+		return h.sendWebhook(ctx, alloc, hook, event, now)
+	}
+	return nil
+}
+
+// runCommand runs the command of the hook in its task.
+func (h *disconnectHooks) runCommand(ctx context.Context, ar AllocRunner, hook *structs.DisconnectHook) error {
+	handler := ar.GetTaskExecHandler(hook.Task)
+	if handler == nil {
+		return fmt.Errorf("task %q is not running", hook.Task)
+	}
+
+	stream := newHookExecStream(ctx)
+	command := append([]string{hook.Command}, hook.Args...)
+	if err := handler(ctx, command, false, stream); err != nil {
+		return err
+	}
+	if code := stream.exitCode(); code != 0 {
+		return fmt.Errorf("command exited with code %d", code)
+	}
+	return nil
+}
+
+// sendWebhook sends a POST request describing the event to the webhook of
+// the hook.
+func (h *disconnectHooks) sendWebhook(ctx context.Context, alloc *structs.Allocation,
+	hook *structs.DisconnectHook, event string, now time.Time) error {
+
+	body, err := json.Marshal(&disconnectHookPayload{
+		Event:     event,
+		Time:      now,
+		NodeID:    h.nodeID,
+		Namespace: alloc.Namespace,
+		JobID:     alloc.JobID,
+		AllocID:   alloc.ID,
+		TaskGroup: alloc.TaskGroup,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.Webhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// hookExecStream implements drivers.ExecTaskStream for the commands of
+// disconnect hooks. The commands get no input, and their output is
+// discarded.
+type hookExecStream struct {
+	ctx     context.Context
+	stdinCh chan struct{}
+
+	l    sync.Mutex
+	code int
+}
+
+func newHookExecStream(ctx context.Context) *hookExecStream {
+	s := &hookExecStream{
+		ctx:     ctx,
+		stdinCh: make(chan struct{}, 1),
+	}
+	s.stdinCh <- struct{}{}
+	return s
+}
+
+// Send records the exit code of the command.
+func (s *hookExecStream) Send(m *drivers.ExecTaskStreamingResponseMsg) error {
+	if m.Exited && m.Result != nil {
+		s.l.Lock()
+		s.code = int(m.Result.ExitCode)
+		s.l.Unlock()
+	}
+	return nil
+}
+
+// Recv closes the input of the command, then blocks until the command is
+// done.
+func (s *hookExecStream) Recv() (*drivers.ExecTaskStreamingRequestMsg, error) {
+	select {
+	case <-s.stdinCh:
+		return &drivers.ExecTaskStreamingRequestMsg{
+			Stdin: &proto.ExecTaskStreamingIOOperation{Close: true},
+		}, nil
+	case <-s.ctx.Done():
+		return nil, io.EOF
+	}
+}
+
+func (s *hookExecStream) exitCode() int {
+	s.l.Lock()
+	defer s.l.Unlock()
+	return s.code
+}
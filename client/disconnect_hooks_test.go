@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/hashicorp/nomad/plugins/drivers/proto"
+	"github.com/stretchr/testify/require"
+)
+
+// hooksAllocRunner records the signals and commands sent to its tasks by
+// disconnect hooks.
+type hooksAllocRunner struct {
+	AllocRunner
+	alloc *structs.Allocation
+
+	l        sync.Mutex
+	signals  []string
+	commands [][]string
+}
+
+func (ar *hooksAllocRunner) Alloc() *structs.Allocation { return ar.alloc }
+
+func (ar *hooksAllocRunner) IsDestroyed() bool { return false }
+
+func (ar *hooksAllocRunner) Signal(taskName, signal string) error {
+	ar.l.Lock()
+	defer ar.l.Unlock()
+	ar.signals = append(ar.signals, taskName+":"+signal)
+	return nil
+}
+
+func (ar *hooksAllocRunner) GetTaskExecHandler(taskName string) drivermanager.TaskExecHandler {
+	return func(ctx context.Context, command []string, tty bool, stream drivers.ExecTaskStream) error {
+		// The command gets no input
+		msg, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if msg.Stdin == nil || !msg.Stdin.Close {
+			return nil
+		}
+
+		ar.l.Lock()
+		ar.commands = append(ar.commands, command)
+		ar.l.Unlock()
+
+		code := int32(0)
+		if command[0] == "/bin/false" {
+			code = 1
+		}
+		return stream.Send(&drivers.ExecTaskStreamingResponseMsg{
+			Exited: true,
+			Result: &proto.ExitResult{ExitCode: code},
+		})
+	}
+}
+
+func (ar *hooksAllocRunner) recorded() ([]string, [][]string) {
+	ar.l.Lock()
+	defer ar.l.Unlock()
+	return ar.signals, ar.commands
+}
+
+func TestDisconnectHooks_check(t *testing.T) {
+	ci.Parallel(t)
+
+	webhookCh := make(chan *disconnectHookPayload, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload disconnectHookPayload
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		webhookCh <- &payload
+	}))
+	defer srv.Close()
+
+	alloc := mock.Alloc()
+	taskName := alloc.Job.TaskGroups[0].Tasks[0].Name
+	alloc.Job.OnDisconnect = []*structs.DisconnectHook{
+		{Task: taskName, Signal: "SIGUSR1"},
+		{Webhook: srv.URL, Timeout: time.Second},
+		{Task: "other-group-task", Signal: "SIGHUP"},
+	}
+	alloc.Job.OnReconnect = []*structs.DisconnectHook{
+		{Task: taskName, Command: "/bin/resume", Args: []string{"-now"}},
+	}
+	ar := &hooksAllocRunner{alloc: alloc}
+
+	var lastOk time.Time
+	ttl := 10 * time.Second
+	checkpointCh := make(chan struct{}, 2)
+	h := newDisconnectHooks("node-1",
+		func() (time.Time, time.Duration) { return lastOk, ttl },
+		func() map[string]AllocRunner { return map[string]AllocRunner{alloc.ID: ar} },
+		func() { checkpointCh <- struct{}{} },
+		testlog.HCLogger(t), nil)
+
+	// Nothing runs while the client heartbeats in time
+	now := time.Now()
+	lastOk = now
+	h.check(now.Add(ttl))
+	require.False(t, h.disconnected)
+
+	// Missing the heartbeat runs the on_disconnect hooks, and checkpoints the
+	// tasks
+	h.check(now.Add(ttl + disconnectHooksGrace + time.Second))
+	require.True(t, h.disconnected)
+
+	select {
+	case <-checkpointCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("tasks not checkpointed")
+	}
+
+	select {
+	case payload := <-webhookCh:
+		require.Equal(t, disconnectHookEventDisconnect, payload.Event)
+		require.Equal(t, "node-1", payload.NodeID)
+		require.Equal(t, alloc.ID, payload.AllocID)
+		require.Equal(t, alloc.JobID, payload.JobID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook not called")
+	}
+	require.Eventually(t, func() bool {
+		signals, _ := ar.recorded()
+		return len(signals) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	signals, commands := ar.recorded()
+	require.Equal(t, []string{taskName + ":SIGUSR1"}, signals)
+	require.Empty(t, commands)
+
+	// Hooks only run once per disconnect
+	h.check(now.Add(ttl + disconnectHooksGrace + 2*time.Second))
+
+	// Heartbeating again runs the on_reconnect hooks
+	lastOk = now.Add(ttl + disconnectHooksGrace + 3*time.Second)
+	h.check(lastOk)
+	require.False(t, h.disconnected)
+	require.Eventually(t, func() bool {
+		_, commands := ar.recorded()
+		return len(commands) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	signals, commands = ar.recorded()
+	require.Len(t, signals, 1)
+	require.Equal(t, [][]string{{"/bin/resume", "-now"}}, commands)
+	require.Empty(t, checkpointCh)
+}
+
+func TestDisconnectHooks_runHook(t *testing.T) {
+	ci.Parallel(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	alloc := mock.Alloc()
+	ar := &hooksAllocRunner{alloc: alloc}
+	h := newDisconnectHooks("node-1", nil, nil, nil, testlog.HCLogger(t), nil)
+
+	// Failed commands and webhooks are reported
+	err := h.runHook(ar, alloc, &structs.DisconnectHook{Task: "web", Command: "/bin/false"},
+		disconnectHookEventDisconnect, time.Now())
+	require.EqualError(t, err, "command exited with code 1")
+
+	err = h.runHook(ar, alloc, &structs.DisconnectHook{Webhook: srv.URL},
+		disconnectHookEventDisconnect, time.Now())
+	require.ErrorContains(t, err, "503")
+}
@@ -3,4 +3,5 @@ package fingerprint
 func initPlatformFingerprints(fps map[string]Factory) {
 	fps["cgroup"] = NewCGroupFingerprint
 	fps["bridge"] = NewBridgeFingerprint
+	fps["hugepages"] = NewHugePagesFingerprint
 }
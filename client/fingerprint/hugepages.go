@@ -0,0 +1,27 @@
+package fingerprint
+
+import log "github.com/hashicorp/go-hclog"
+
+const (
+	// hugePageSize2MB is the name of the 2MiB huge page size in sysfs
+	hugePageSize2MB = "2048kB"
+)
+
+// HugePagesFingerprint is used to fingerprint the huge pages and NUMA
+// topology of the node
+type HugePagesFingerprint struct {
+	StaticFingerprinter
+
+	logger log.Logger
+
+	// sysfsRoot is the mount point of sysfs, overridden in tests
+	sysfsRoot string
+}
+
+// NewHugePagesFingerprint is used to create a huge pages fingerprint
+func NewHugePagesFingerprint(logger log.Logger) Fingerprint {
+	return &HugePagesFingerprint{
+		logger:    logger.Named("hugepages"),
+		sysfsRoot: "/sys",
+	}
+}
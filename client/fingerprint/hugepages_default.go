@@ -0,0 +1,8 @@
+//go:build !linux
+// +build !linux
+
+package fingerprint
+
+func (f *HugePagesFingerprint) Fingerprint(*FingerprintRequest, *FingerprintResponse) error {
+	return nil
+}
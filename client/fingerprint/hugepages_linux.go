@@ -0,0 +1,112 @@
+package fingerprint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+func (f *HugePagesFingerprint) Fingerprint(req *FingerprintRequest, resp *FingerprintResponse) error {
+	pools, err := f.readHugePages(filepath.Join(f.sysfsRoot, "kernel", "mm", "hugepages"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			// The kernel was built without huge page support
+			return nil
+		}
+		f.logger.Warn("error reading huge pages", "error", err)
+		return nil
+	}
+
+	for size, total := range pools {
+		resp.AddAttribute(fmt.Sprintf("memory.hugepages.%s.total", size), strconv.FormatInt(total, 10))
+	}
+
+	nodes, err := f.numaNodes()
+	if err != nil {
+		f.logger.Warn("error reading NUMA nodes", "error", err)
+	}
+	if len(nodes) > 0 {
+		resp.AddAttribute("numa.node_count", strconv.Itoa(len(nodes)))
+	}
+	for _, node := range nodes {
+		dir := filepath.Join(f.sysfsRoot, "devices", "system", "node", "node"+node, "hugepages")
+		nodePools, err := f.readHugePages(dir)
+		if err != nil {
+			continue
+		}
+		for size, total := range nodePools {
+			resp.AddAttribute(fmt.Sprintf("numa.node%s.hugepages.%s.total", node, size), strconv.FormatInt(total, 10))
+		}
+	}
+
+	if total := pools[hugePageSize2MB]; total > 0 {
+		resp.NodeResources = &structs.NodeResources{
+			Memory: structs.NodeMemoryResources{
+				HugePages2MB: total,
+			},
+		}
+	}
+
+	resp.Detected = true
+	return nil
+}
+
+// readHugePages returns the number of huge pages allocated to each pool in
+// dir, keyed by the page size as named by the kernel (eg. "2048kB").
+func (f *HugePagesFingerprint) readHugePages(dir string) (map[string]int64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	pools := make(map[string]int64, len(entries))
+	for _, entry := range entries {
+		size := strings.TrimPrefix(entry.Name(), "hugepages-")
+		if size == entry.Name() {
+			continue
+		}
+		total, err := readSysfsInt(filepath.Join(dir, entry.Name(), "nr_hugepages"))
+		if err != nil {
+			return nil, err
+		}
+		pools[size] = total
+	}
+	return pools, nil
+}
+
+// numaNodes returns the sorted IDs of the NUMA nodes of the host.
+func (f *HugePagesFingerprint) numaNodes() ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(f.sysfsRoot, "devices", "system", "node", "node[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]int, 0, len(matches))
+	for _, match := range matches {
+		id, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(match), "node"))
+		if err != nil {
+			continue
+		}
+		nodes = append(nodes, id)
+	}
+	sort.Ints(nodes)
+
+	ids := make([]string, len(nodes))
+	for i, id := range nodes {
+		ids[i] = strconv.Itoa(id)
+	}
+	return ids, nil
+}
+
+func readSysfsInt(path string) (int64, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+}
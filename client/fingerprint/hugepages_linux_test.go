@@ -0,0 +1,62 @@
+package fingerprint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func writeHugePages(t *testing.T, dir, size, total string) {
+	path := filepath.Join(dir, "hugepages-"+size)
+	require.NoError(t, os.MkdirAll(path, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(path, "nr_hugepages"), []byte(total+"\n"), 0644))
+}
+
+func TestHugePagesFingerprint(t *testing.T) {
+	ci.Parallel(t)
+
+	root := t.TempDir()
+	writeHugePages(t, filepath.Join(root, "kernel", "mm", "hugepages"), "2048kB", "1024")
+	writeHugePages(t, filepath.Join(root, "kernel", "mm", "hugepages"), "1048576kB", "2")
+	writeHugePages(t, filepath.Join(root, "devices", "system", "node", "node0", "hugepages"), "2048kB", "512")
+	writeHugePages(t, filepath.Join(root, "devices", "system", "node", "node1", "hugepages"), "2048kB", "512")
+
+	f := NewHugePagesFingerprint(testlog.HCLogger(t)).(*HugePagesFingerprint)
+	f.sysfsRoot = root
+
+	request := &FingerprintRequest{Config: &config.Config{}, Node: &structs.Node{}}
+	var response FingerprintResponse
+	require.NoError(t, f.Fingerprint(request, &response))
+
+	require.True(t, response.Detected)
+	require.Equal(t, map[string]string{
+		"memory.hugepages.2048kB.total":     "1024",
+		"memory.hugepages.1048576kB.total":  "2",
+		"numa.node_count":                   "2",
+		"numa.node0.hugepages.2048kB.total": "512",
+		"numa.node1.hugepages.2048kB.total": "512",
+	}, response.Attributes)
+	require.NotNil(t, response.NodeResources)
+	require.Equal(t, int64(1024), response.NodeResources.Memory.HugePages2MB)
+}
+
+func TestHugePagesFingerprint_Unsupported(t *testing.T) {
+	ci.Parallel(t)
+
+	f := NewHugePagesFingerprint(testlog.HCLogger(t)).(*HugePagesFingerprint)
+	f.sysfsRoot = t.TempDir()
+
+	request := &FingerprintRequest{Config: &config.Config{}, Node: &structs.Node{}}
+	var response FingerprintResponse
+	require.NoError(t, f.Fingerprint(request, &response))
+
+	require.False(t, response.Detected)
+	require.Empty(t, response.Attributes)
+	require.Nil(t, response.NodeResources)
+}
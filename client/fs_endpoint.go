@@ -12,6 +12,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -31,7 +32,7 @@ var (
 	allocIDNotPresentErr = fmt.Errorf("must provide a valid alloc id")
 	pathNotPresentErr    = fmt.Errorf("must provide a file path")
 	taskNotPresentErr    = fmt.Errorf("must provide task name")
-	logTypeNotPresentErr = fmt.Errorf("must provide log type (stdout/stderr)")
+	logTypeNotPresentErr = fmt.Errorf("must provide log type (stdout/stderr/both)")
 	invalidOrigin        = fmt.Errorf("origin must be start or end")
 )
 
@@ -67,6 +68,10 @@ const (
 	// and end of a file.
 	OriginStart = "start"
 	OriginEnd   = "end"
+
+	// logTypeBoth is the log type that streams both the stdout and stderr
+	// logs of a task, multiplexed on a single stream.
+	logTypeBoth = "both"
 )
 
 // FileSystem endpoint is used for accessing the logs and filesystem of
@@ -116,15 +121,77 @@ func (f *FileSystem) List(args *cstructs.FsListRequest, reply *cstructs.FsListRe
 	if err != nil {
 		return err
 	}
-	files, err := fs.List(args.Path)
+
+	var files []*cstructs.AllocFileInfo
+	if args.Recursive {
+		files, err = listRecursive(fs, args.Path, "")
+	} else {
+		files, err = fs.List(args.Path)
+	}
 	if err != nil {
 		return err
 	}
 
+	if args.Glob != "" {
+		files, err = filterGlob(files, args.Glob)
+		if err != nil {
+			return err
+		}
+	}
+
 	reply.Files = files
 	return nil
 }
 
+// listRecursive walks the directory at path and returns every entry below it,
+// named by its path relative to the directory being walked. Each directory is
+// listed through the AllocDirFS so that paths escaping the allocation
+// directory are rejected.
+func listRecursive(fs allocdir.AllocDirFS, path, rel string) ([]*cstructs.AllocFileInfo, error) {
+	entries, err := fs.List(filepath.Join(path, rel))
+	if err != nil {
+		return nil, err
+	}
+
+	var out []*cstructs.AllocFileInfo
+	for _, entry := range entries {
+		name := filepath.Join(rel, entry.Name)
+		entry.Name = name
+		out = append(out, entry)
+
+		if entry.IsDir {
+			children, err := listRecursive(fs, path, name)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, children...)
+		}
+	}
+	return out, nil
+}
+
+// filterGlob returns the entries whose name matches the glob pattern. Patterns
+// without a path separator match against the entry's base name.
+func filterGlob(entries []*cstructs.AllocFileInfo, pattern string) ([]*cstructs.AllocFileInfo, error) {
+	matchBase := !strings.Contains(pattern, "/")
+
+	out := make([]*cstructs.AllocFileInfo, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name
+		if matchBase {
+			name = filepath.Base(name)
+		}
+		ok, err := filepath.Match(pattern, name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob %q: %v", pattern, err)
+		}
+		if ok {
+			out = append(out, entry)
+		}
+	}
+	return out, nil
+}
+
 // Stat is used to stat a file in the allocation's directory.
 func (f *FileSystem) Stat(args *cstructs.FsStatRequest, reply *cstructs.FsStatResponse) error {
 	defer metrics.MeasureSince([]string{"client", "file_system", "stat"}, time.Now())
@@ -150,10 +217,59 @@ func (f *FileSystem) Stat(args *cstructs.FsStatRequest, reply *cstructs.FsStatRe
 		return err
 	}
 
+	if args.MinQueryIndex > 0 && !info.IsDir && statIndex(info) <= args.MinQueryIndex {
+		info, err = f.blockingStat(fs, args, info)
+		if err != nil {
+			return err
+		}
+	}
+
 	reply.Info = info
+	reply.Index = statIndex(info)
 	return nil
 }
 
+// blockingStat waits for the file at the request path to change, returning
+// its updated info. If MaxQueryTime elapses first, the original info is
+// returned unchanged so that the caller can issue another blocking request.
+func (f *FileSystem) blockingStat(fs allocdir.AllocDirFS, args *cstructs.FsStatRequest,
+	info *cstructs.AllocFileInfo) (*cstructs.AllocFileInfo, error) {
+
+	timeout := args.MaxQueryTime
+	if timeout <= 0 || timeout > structs.MaxBlockingRPCQueryTime {
+		timeout = structs.MaxBlockingRPCQueryTime
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	changes, err := fs.ChangeEvents(ctx, args.Path, info.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-changes.Modified:
+	case <-changes.Truncated:
+	case <-changes.Deleted:
+	case <-f.c.shutdownCh:
+		return info, nil
+	case <-ctx.Done():
+		return info, nil
+	}
+
+	return fs.Stat(args.Path)
+}
+
+// statIndex is the blocking query index of a stat result, which is the file's
+// modification time in Unix nanoseconds.
+func statIndex(info *cstructs.AllocFileInfo) uint64 {
+	if info == nil || info.ModTime.IsZero() {
+		return 0
+	}
+	return uint64(info.ModTime.UnixNano())
+}
+
 // stream is is used to stream the contents of file in an allocation's
 // directory.
 func (f *FileSystem) stream(conn io.ReadWriteCloser) {
@@ -373,8 +489,11 @@ func (f *FileSystem) logs(conn io.ReadWriteCloser) {
 		handleStreamResultError(taskNotPresentErr, pointer.Of(int64(400)), encoder)
 		return
 	}
+	logTypes := []string{req.LogType}
 	switch req.LogType {
 	case "stdout", "stderr":
+	case logTypeBoth:
+		logTypes = []string{"stdout", "stderr"}
 	default:
 		handleStreamResultError(logTypeNotPresentErr, pointer.Of(int64(400)), encoder)
 		return
@@ -435,15 +554,7 @@ func (f *FileSystem) logs(conn io.ReadWriteCloser) {
 	errCh := make(chan error)
 
 	// Start streaming
-	go func() {
-		if err := f.logsImpl(ctx, req.Follow, req.PlainText,
-			req.Offset, req.Origin, req.Task, req.LogType, fs, frames); err != nil {
-			select {
-			case errCh <- err:
-			case <-ctx.Done():
-			}
-		}
-	}()
+	go f.streamLogs(ctx, &req, logTypes, fs, frames, errCh)
 
 	// Create a goroutine to detect the remote side closing
 	go func() {
@@ -518,6 +629,54 @@ OUTER:
 	}
 }
 
+// streamLogs streams the logs of each of the log types of the task onto the
+// frames channel and their errors onto the error channel, and closes the frames
+// channel once all of them are done. Frames with data are tagged with the log
+// stream they were read from and the time they were read at, so that
+// multiplexed streams can be told apart and ordered.
+func (f *FileSystem) streamLogs(ctx context.Context, req *cstructs.FsLogsRequest,
+	logTypes []string, fs allocdir.AllocDirFS,
+	frames chan<- *sframer.StreamFrame, errCh chan<- error) {
+
+	var wg sync.WaitGroup
+	for _, logType := range logTypes {
+		logFrames := make(chan *sframer.StreamFrame, streamFramesBuffer)
+
+		wg.Add(1)
+		go func(logType string) {
+			defer wg.Done()
+
+			// Keep draining the frames after the context is done, so that the
+			// framer can flush and close the channel.
+			for frame := range logFrames {
+				if !frame.IsHeartbeat() {
+					frame.Stream = logType
+					frame.Timestamp = time.Now().UnixNano()
+				}
+				select {
+				case frames <- frame:
+				case <-ctx.Done():
+				}
+			}
+		}(logType)
+
+		wg.Add(1)
+		go func(logType string) {
+			defer wg.Done()
+			if err := f.logsImpl(ctx, req.Follow, req.PlainText,
+				req.Offset, req.Origin, req.Task, logType, fs, logFrames); err != nil {
+				select {
+				case errCh <- err:
+				case <-ctx.Done():
+				}
+			}
+		}(logType)
+	}
+
+	wg.Wait()
+	close(frames)
+}
+
 // logsImpl is used to stream the logs of a the given task. Output is sent on
 // the passed frames channel and the method will return on EOF if follow is not
 // true otherwise when the context is cancelled or on an error.
@@ -108,6 +108,48 @@ func TestFS_Stat(t *testing.T) {
 	require.True(resp.Info.IsDir)
 }
 
+func TestFS_Stat_Blocking(t *testing.T) {
+	ci.Parallel(t)
+
+	ad := tempAllocDir(t)
+	defer ad.Destroy()
+	require.NoError(t, os.MkdirAll(ad.AllocDir, 0o755))
+
+	path := filepath.Join(ad.AllocDir, "rendered.conf")
+	require.NoError(t, ioutil.WriteFile(path, []byte("a"), 0o644))
+
+	info, err := ad.Stat("rendered.conf")
+	require.NoError(t, err)
+
+	f := &FileSystem{c: &Client{shutdownCh: make(chan struct{})}}
+	req := &cstructs.FsStatRequest{
+		Path: "rendered.conf",
+		QueryOptions: structs.QueryOptions{
+			MinQueryIndex: statIndex(info),
+			MaxQueryTime:  5 * time.Second,
+		},
+	}
+
+	// Unchanged files return the original info once the wait elapses
+	req.MaxQueryTime = 100 * time.Millisecond
+	out, err := f.blockingStat(ad, req, info)
+	require.NoError(t, err)
+	require.Equal(t, statIndex(info), statIndex(out))
+
+	// Changes unblock the request with the updated info
+	req.MaxQueryTime = 5 * time.Second
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		later := info.ModTime.Add(time.Second)
+		ioutil.WriteFile(path, []byte("abc"), 0o644)
+		os.Chtimes(path, later, later)
+	}()
+	out, err = f.blockingStat(ad, req, info)
+	require.NoError(t, err)
+	require.Greater(t, statIndex(out), statIndex(info))
+	require.Equal(t, int64(3), out.Size)
+}
+
 func TestFS_Stat_ACL(t *testing.T) {
 	ci.Parallel(t)
 
@@ -241,6 +283,50 @@ func TestFS_List(t *testing.T) {
 	require.True(resp.Files[0].IsDir)
 }
 
+func TestFS_List_RecursiveGlob(t *testing.T) {
+	ci.Parallel(t)
+
+	ad := tempAllocDir(t)
+	defer ad.Destroy()
+
+	for _, p := range []string{"web/local/app.conf", "web/local/app.log", "web/secrets/db.conf"} {
+		full := filepath.Join(ad.AllocDir, p)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, ioutil.WriteFile(full, []byte("x"), 0o644))
+	}
+
+	names := func(files []*cstructs.AllocFileInfo) []string {
+		out := make([]string, len(files))
+		for i, f := range files {
+			out[i] = f.Name
+		}
+		return out
+	}
+
+	files, err := listRecursive(ad, "web", "")
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"local", "local/app.conf", "local/app.log", "secrets", "secrets/db.conf",
+	}, names(files))
+
+	// Patterns without a separator match the base name
+	matched, err := filterGlob(files, "*.conf")
+	require.NoError(t, err)
+	require.Equal(t, []string{"local/app.conf", "secrets/db.conf"}, names(matched))
+
+	// Patterns with a separator match the relative path
+	matched, err = filterGlob(files, "local/*")
+	require.NoError(t, err)
+	require.Equal(t, []string{"local/app.conf", "local/app.log"}, names(matched))
+
+	_, err = filterGlob(files, "[")
+	require.Error(t, err)
+
+	// Walking outside the alloc dir is rejected
+	_, err = listRecursive(ad, "../..", "")
+	require.Error(t, err)
+}
+
 func TestFS_List_ACL(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1351,6 +1437,108 @@ OUTER:
 	}
 }
 
+func TestFS_Logs_Both(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	// Start a server and client
+	s, cleanupS := nomad.TestServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+
+	c, cleanupC := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s.GetConfig().RPCAddr.String()}
+	})
+	defer cleanupC()
+
+	expectedStdout := "Hello from the other side\n"
+	expectedStderr := "Hello from the dark side\n"
+	job := mock.BatchJob()
+	job.TaskGroups[0].Count = 1
+	job.TaskGroups[0].Tasks[0].Config = map[string]interface{}{
+		"run_for":       "2s",
+		"stdout_string": expectedStdout,
+		"stderr_string": expectedStderr,
+	}
+
+	// Wait for client to be running job
+	alloc := testutil.WaitForRunning(t, s.RPC, job)[0]
+
+	// Make the request
+	req := &cstructs.FsLogsRequest{
+		AllocID:      alloc.ID,
+		Task:         job.TaskGroups[0].Tasks[0].Name,
+		LogType:      logTypeBoth,
+		Origin:       "start",
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+
+	// Get the handler
+	handler, err := c.StreamingRpcHandler("FileSystem.Logs")
+	require.NoError(err)
+
+	// Create a pipe
+	p1, p2 := net.Pipe()
+	defer p1.Close()
+	defer p2.Close()
+
+	errCh := make(chan error)
+	streamMsg := make(chan *cstructs.StreamErrWrapper)
+
+	// Start the handler
+	go handler(p2)
+
+	// Start the decoder
+	go func() {
+		decoder := codec.NewDecoder(p1, structs.MsgpackHandle)
+		for {
+			var msg cstructs.StreamErrWrapper
+			if err := decoder.Decode(&msg); err != nil {
+				if err == io.EOF || strings.Contains(err.Error(), "closed") {
+					return
+				}
+				errCh <- fmt.Errorf("error decoding: %v", err)
+			}
+
+			streamMsg <- &msg
+		}
+	}()
+
+	// Send the request
+	encoder := codec.NewEncoder(p1, structs.MsgpackHandle)
+	require.Nil(encoder.Encode(req))
+
+	// The frames of each stream are tagged with it
+	timeout := time.After(3 * time.Second)
+	received := map[string]string{}
+OUTER:
+	for {
+		select {
+		case <-timeout:
+			t.Fatal("timeout")
+		case err := <-errCh:
+			t.Fatal(err)
+		case msg := <-streamMsg:
+			if msg.Error != nil {
+				t.Fatalf("Got error: %v", msg.Error.Error())
+			}
+
+			var frame sframer.StreamFrame
+			require.NoError(codec.NewDecoderBytes(msg.Payload, structs.JsonHandle).Decode(&frame))
+			if frame.IsHeartbeat() {
+				continue
+			}
+			require.NotZero(frame.Timestamp)
+			require.Contains(frame.File, frame.Stream)
+
+			received[frame.Stream] += string(frame.Data)
+			if received["stdout"] == expectedStdout && received["stderr"] == expectedStderr {
+				break OUTER
+			}
+		}
+	}
+}
+
 func TestFS_findClosest(t *testing.T) {
 	task := "foo"
 	entries := []*cstructs.AllocFileInfo{
@@ -27,6 +27,14 @@ type StreamFrame struct {
 	// FileEvent is the last file event that occurred that could cause the
 	// streams position to change or end
 	FileEvent string `json:",omitempty"`
+
+	// Stream is the log stream, stdout or stderr, that the data was read
+	// from. It is only set when streaming the logs of a task.
+	Stream string `json:",omitempty"`
+
+	// Timestamp is the time in Unix nanoseconds at which the data was read.
+	// It is only set when streaming the logs of a task.
+	Timestamp int64 `json:",omitempty"`
 }
 
 // IsHeartbeat returns if the frame is a heartbeat frame
@@ -39,6 +47,8 @@ func (s *StreamFrame) Clear() {
 	s.Data = nil
 	s.File = ""
 	s.FileEvent = ""
+	s.Stream = ""
+	s.Timestamp = 0
 }
 
 func (s *StreamFrame) IsCleared() bool {
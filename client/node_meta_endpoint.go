@@ -0,0 +1,109 @@
+package client
+
+import (
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper"
+	nstructs "github.com/hashicorp/nomad/nomad/structs"
+)
+
+// NodeMeta endpoint is used for reading and updating the dynamic metadata of
+// the client node
+type NodeMeta struct {
+	c *Client
+}
+
+// Apply sets and unsets keys of the node metadata. The changes are persisted
+// to the state DB so that they survive restarts, and the node is
+// re-registered so that the servers re-evaluate the jobs whose constraints
+// depend on the metadata.
+func (n *NodeMeta) Apply(args *structs.NodeMetaApplyRequest, reply *structs.NodeMetaResponse) error {
+	defer metrics.MeasureSince([]string{"client", "node_meta", "apply"}, time.Now())
+
+	// Check node write permissions
+	if aclObj, err := n.c.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
+		return nstructs.ErrPermissionDenied
+	}
+
+	if err := args.Validate(); err != nil {
+		return nstructs.NewErrRPCCoded(400, err.Error())
+	}
+
+	n.c.configLock.Lock()
+	defer n.c.configLock.Unlock()
+
+	dynamicMeta := make(map[string]*string, len(n.c.dynamicMeta)+len(args.Meta))
+	for k, v := range n.c.dynamicMeta {
+		dynamicMeta[k] = v
+	}
+	for k, v := range args.Meta {
+		dynamicMeta[k] = v
+	}
+	if err := n.c.stateDB.PutNodeMeta(dynamicMeta); err != nil {
+		return err
+	}
+
+	newConfig := n.c.config.Copy()
+	applyNodeMeta(newConfig.Node.Meta, args.Meta)
+	n.c.config = newConfig
+	n.c.dynamicMeta = dynamicMeta
+
+	n.c.logger.Info("applied dynamic node metadata", "keys", len(args.Meta))
+	n.c.updateNode()
+
+	reply.Meta = helper.CopyMapStringString(newConfig.Node.Meta)
+	reply.Dynamic = copyNodeMeta(dynamicMeta)
+	return nil
+}
+
+// Read returns the metadata of the node, including which keys were applied
+// dynamically.
+func (n *NodeMeta) Read(args *nstructs.NodeSpecificRequest, reply *structs.NodeMetaResponse) error {
+	defer metrics.MeasureSince([]string{"client", "node_meta", "read"}, time.Now())
+
+	// Check node read permissions
+	if aclObj, err := n.c.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeRead() {
+		return nstructs.ErrPermissionDenied
+	}
+
+	n.c.configLock.Lock()
+	defer n.c.configLock.Unlock()
+
+	reply.Meta = helper.CopyMapStringString(n.c.config.Node.Meta)
+	reply.Dynamic = copyNodeMeta(n.c.dynamicMeta)
+	return nil
+}
+
+// applyNodeMeta sets the keys of dynamic with a value on meta, and deletes
+// the keys with a nil value.
+func applyNodeMeta(meta map[string]string, dynamic map[string]*string) {
+	for k, v := range dynamic {
+		if v == nil {
+			delete(meta, k)
+		} else {
+			meta[k] = *v
+		}
+	}
+}
+
+// copyNodeMeta returns a copy of the dynamic node metadata.
+func copyNodeMeta(dynamic map[string]*string) map[string]*string {
+	if dynamic == nil {
+		return nil
+	}
+	c := make(map[string]*string, len(dynamic))
+	for k, v := range dynamic {
+		if v != nil {
+			s := *v
+			v = &s
+		}
+		c[k] = v
+	}
+	return c
+}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/config"
+	cstate "github.com/hashicorp/nomad/client/state"
+	"github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/mock"
+	nstructs "github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeMeta_Apply(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	s1, _, cleanupS1 := testServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	client, cleanup := TestClient(t, func(c *config.Config) {
+		c.RPCHandler = s1
+		c.Node.Meta = map[string]string{"rack": "r1", "zone": "a"}
+	})
+	defer cleanup()
+	waitTilNodeReady(client, t)
+
+	// The state DB of dev mode clients doesn't persist anything
+	client.stateDB = cstate.NewMemDB(client.logger)
+
+	// Applying without any key fails
+	req := &structs.NodeMetaApplyRequest{}
+	var resp structs.NodeMetaResponse
+	err := client.ClientRPC("NodeMeta.Apply", req, &resp)
+	require.EqualError(err, "RPC Error:: 400,missing metadata to apply")
+
+	// Keys are set and unset over the configured metadata
+	req.Meta = map[string]*string{
+		"network": pointer.Of("degraded"),
+		"rack":    nil,
+	}
+	require.NoError(client.ClientRPC("NodeMeta.Apply", req, &resp))
+	require.Equal("degraded", resp.Meta["network"])
+	require.Equal("a", resp.Meta["zone"])
+	require.NotContains(resp.Meta, "rack")
+	require.Equal(req.Meta, resp.Dynamic)
+
+	node := client.Node()
+	require.Equal("degraded", node.Meta["network"])
+	require.NotContains(node.Meta, "rack")
+
+	// The node is re-registered with the new metadata
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := s1.State().NodeByID(nil, node.ID)
+		if err != nil {
+			return false, err
+		}
+		if out.Meta["network"] != "degraded" {
+			return false, fmt.Errorf("node meta not updated: %v", out.Meta)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+
+	// Later changes are merged with the previous ones
+	req.Meta = map[string]*string{"network": pointer.Of("ok")}
+	require.NoError(client.ClientRPC("NodeMeta.Apply", req, &resp))
+	require.Equal(map[string]*string{
+		"network": pointer.Of("ok"),
+		"rack":    nil,
+	}, resp.Dynamic)
+
+	// The dynamic metadata is persisted
+	stored, err := client.stateDB.GetNodeMeta()
+	require.NoError(err)
+	require.Equal(resp.Dynamic, stored)
+
+	// And can be read back
+	var readResp structs.NodeMetaResponse
+	require.NoError(client.ClientRPC("NodeMeta.Read", &nstructs.NodeSpecificRequest{}, &readResp))
+	require.Equal(resp.Meta, readResp.Meta)
+	require.Equal(resp.Dynamic, readResp.Dynamic)
+}
+
+func TestNodeMeta_Apply_ACL(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	server, addr, root, cleanupS := testACLServer(t, nil)
+	defer cleanupS()
+
+	client, cleanupC := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{addr}
+		c.ACLEnabled = true
+	})
+	defer cleanupC()
+
+	tokenRead := mock.CreatePolicyAndToken(t, server.State(), 1005, "read", mock.NodePolicy(acl.PolicyRead))
+	tokenWrite := mock.CreatePolicyAndToken(t, server.State(), 1007, "write", mock.NodePolicy(acl.PolicyWrite))
+
+	cases := []struct {
+		Name          string
+		Token         string
+		ExpectedError string
+	}{
+		{
+			Name:          "no token",
+			ExpectedError: nstructs.ErrPermissionDenied.Error(),
+		},
+		{
+			Name:          "read token",
+			Token:         tokenRead.SecretID,
+			ExpectedError: nstructs.ErrPermissionDenied.Error(),
+		},
+		{
+			Name:  "write token",
+			Token: tokenWrite.SecretID,
+		},
+		{
+			Name:  "root token",
+			Token: root.SecretID,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			req := &structs.NodeMetaApplyRequest{
+				Meta: map[string]*string{"network": pointer.Of("degraded")},
+			}
+			req.AuthToken = c.Token
+
+			var resp structs.NodeMetaResponse
+			err := client.ClientRPC("NodeMeta.Apply", req, &resp)
+			if c.ExpectedError != "" {
+				require.EqualError(err, c.ExpectedError)
+			} else {
+				require.NoError(err)
+				require.Equal("degraded", resp.Meta["network"])
+			}
+		})
+	}
+}
+
+func TestNodeMeta_Restore(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	client, cleanup := TestClient(t, func(c *config.Config) {
+		c.Node.Meta = map[string]string{"rack": "r1", "zone": "a"}
+		c.StateDBFactory = func(logger hclog.Logger, _ string) (cstate.StateDB, error) {
+			db := cstate.NewMemDB(logger)
+			err := db.PutNodeMeta(map[string]*string{
+				"network": pointer.Of("degraded"),
+				"rack":    nil,
+			})
+			return db, err
+		}
+	})
+	defer cleanup()
+
+	// The dynamic metadata is applied over the configured metadata
+	node := client.Node()
+	require.Equal("degraded", node.Meta["network"])
+	require.Equal("a", node.Meta["zone"])
+	require.NotContains(node.Meta, "rack")
+}
@@ -24,6 +24,7 @@ type rpcEndpoints struct {
 	FileSystem  *FileSystem
 	Allocations *Allocations
 	Agent       *Agent
+	NodeMeta    *NodeMeta
 }
 
 // ClientRPC is used to make a local, client only RPC call
@@ -54,6 +55,24 @@ func (c *Client) RPC(method string, args interface{}, reply interface{}) error {
 		return conf.RPCHandler.RPC(method, args, reply)
 	}
 
+	// Secure variables may be served from the cache if the servers can't be
+	// reached
+	if c.secureVariables != nil && method == structs.SecureVariablesReadRPCMethod {
+		args, argsOk := args.(*structs.SecureVariablesReadRequest)
+		reply, replyOk := reply.(*structs.SecureVariablesReadResponse)
+		if argsOk && replyOk {
+			return c.readSecureVariable(args, reply)
+		}
+	}
+
+	return c.rpc(method, args, reply)
+}
+
+// rpc is used to forward an RPC call to a nomad server, retrying on errors
+// which are safe to retry until the RPC hold timeout.
+func (c *Client) rpc(method string, args interface{}, reply interface{}) error {
+	conf := c.GetConfig()
+
 	// We will try to automatically retry requests that fail due to things like server unavailability
 	// but instead of retrying forever, lets have a solid upper-bound
 	deadline := time.Now()
@@ -104,7 +123,7 @@ TRY:
 		// so before we give up on blocking queries make one last attempt for an immediate answer
 		if info, ok := args.(structs.RPCInfo); ok && info.TimeToBlock() > 0 {
 			info.SetTimeToBlock(0)
-			return c.RPC(method, args, reply)
+			return c.rpc(method, args, reply)
 		}
 		c.rpcLogger.Error("error performing RPC to server, deadline exceeded, cannot retry", "error", rpcErr, "rpc", method, "server", server.Addr)
 		return rpcErr
@@ -124,7 +143,7 @@ TRY:
 				newBlockTime = 0
 			}
 			info.SetTimeToBlock(newBlockTime)
-			return c.RPC(method, args, reply)
+			return c.rpc(method, args, reply)
 		}
 
 		goto TRY
@@ -265,6 +284,7 @@ func (c *Client) setupClientRpc(rpcs map[string]interface{}) {
 		c.endpoints.FileSystem = NewFileSystemEndpoint(c)
 		c.endpoints.Allocations = NewAllocationsEndpoint(c)
 		c.endpoints.Agent = NewAgentEndpoint(c)
+		c.endpoints.NodeMeta = &NodeMeta{c}
 		c.setupClientRpcServer(c.rpcServer)
 	}
 
@@ -279,6 +299,7 @@ func (c *Client) setupClientRpcServer(server *rpc.Server) {
 	server.Register(c.endpoints.FileSystem)
 	server.Register(c.endpoints.Allocations)
 	server.Register(c.endpoints.Agent)
+	server.Register(c.endpoints.NodeMeta)
 }
 
 // rpcConnListener is a long lived function that listens for new connections
@@ -0,0 +1,256 @@
+package client
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net"
+	"net/rpc"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-msgpack/codec"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// secureVariablesCacheMaxEntries bounds the number of secure variables
+	// held by the cache. The least recently refreshed entry is evicted when
+	// it is full.
+	secureVariablesCacheMaxEntries = 1024
+
+	// secureVariablesCacheMinWait and secureVariablesCacheMaxWait bound the
+	// backoff of blocking reads which the cache can only answer with an
+	// index the caller has already seen, so that they don't spin while the
+	// client is disconnected.
+	secureVariablesCacheMinWait = 1 * time.Second
+	secureVariablesCacheMaxWait = 1 * time.Minute
+)
+
+// secureVariablesCache holds the secure variables read through the client, so
+// that they can be served stale while the client is disconnected from the
+// servers. Entries are keyed by the token used to read them, so a cached
+// variable is only returned to callers which the servers have already
+// authorized to read it. Variables are encrypted with a key which is
+// generated when the client starts and is never persisted, so the cache does
+// not survive a restart of the client.
+type secureVariablesCache struct {
+	aead cipher.AEAD
+
+	entries map[secureVariablesCacheKey]*secureVariablesCacheEntry
+	lock    sync.Mutex
+}
+
+type secureVariablesCacheKey struct {
+	namespace string
+	path      string
+	token     [sha256.Size]byte
+}
+
+type secureVariablesCacheEntry struct {
+	nonce      []byte
+	ciphertext []byte
+	index      uint64
+	cachedAt   time.Time
+
+	// staleReads counts the blocking reads answered with this entry after
+	// the caller had already seen it, to back them off
+	staleReads int
+}
+
+func newSecureVariablesCache() (*secureVariablesCache, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate secure variables cache key: %v", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &secureVariablesCache{
+		aead:    aead,
+		entries: make(map[secureVariablesCacheKey]*secureVariablesCacheEntry),
+	}, nil
+}
+
+func (c *secureVariablesCache) key(args *structs.SecureVariablesReadRequest) secureVariablesCacheKey {
+	return secureVariablesCacheKey{
+		namespace: args.RequestNamespace(),
+		path:      args.Path,
+		token:     sha256.Sum256([]byte(args.AuthToken)),
+	}
+}
+
+// put stores the result of a successful read.
+func (c *secureVariablesCache) put(args *structs.SecureVariablesReadRequest, reply *structs.SecureVariablesReadResponse) error {
+	key := c.key(args)
+
+	if reply.Data == nil {
+		c.lock.Lock()
+		delete(c.entries, key)
+		c.lock.Unlock()
+		return nil
+	}
+
+	var plaintext []byte
+	if err := codec.NewEncoderBytes(&plaintext, structs.MsgpackHandle).Encode(reply.Data); err != nil {
+		return err
+	}
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	entry := &secureVariablesCacheEntry{
+		nonce:      nonce,
+		ciphertext: c.aead.Seal(nil, nonce, plaintext, nil),
+		index:      reply.Index,
+		cachedAt:   time.Now(),
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= secureVariablesCacheMaxEntries {
+		c.evictOldestLocked()
+	}
+	c.entries[key] = entry
+	return nil
+}
+
+// get returns the cached secure variable for the request, or false if there
+// isn't one.
+func (c *secureVariablesCache) get(args *structs.SecureVariablesReadRequest, reply *structs.SecureVariablesReadResponse) (bool, error) {
+	c.lock.Lock()
+	entry, ok := c.entries[c.key(args)]
+	c.lock.Unlock()
+	if !ok {
+		return false, nil
+	}
+
+	plaintext, err := c.aead.Open(nil, entry.nonce, entry.ciphertext, nil)
+	if err != nil {
+		return false, err
+	}
+	var sv structs.SecureVariableDecrypted
+	if err := codec.NewDecoderBytes(plaintext, structs.MsgpackHandle).Decode(&sv); err != nil {
+		return false, err
+	}
+
+	reply.Data = &sv
+	reply.Index = entry.index
+	reply.KnownLeader = false
+	reply.LastContact = time.Since(entry.cachedAt)
+	return true, nil
+}
+
+// backoff returns how long a blocking read should wait before it is answered
+// with the cached entry for the request, which the caller has already seen.
+// The wait doubles with every such read of the entry, up to
+// secureVariablesCacheMaxWait.
+func (c *secureVariablesCache) backoff(args *structs.SecureVariablesReadRequest) time.Duration {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	wait := secureVariablesCacheMinWait
+	entry, ok := c.entries[c.key(args)]
+	if !ok {
+		return wait
+	}
+	for i := 0; i < entry.staleReads && wait < secureVariablesCacheMaxWait; i++ {
+		wait *= 2
+	}
+	if wait > secureVariablesCacheMaxWait {
+		wait = secureVariablesCacheMaxWait
+	}
+	entry.staleReads++
+	return wait
+}
+
+func (c *secureVariablesCache) evictOldestLocked() {
+	var oldestKey secureVariablesCacheKey
+	var oldest time.Time
+	for key, entry := range c.entries {
+		if oldest.IsZero() || entry.cachedAt.Before(oldest) {
+			oldestKey, oldest = key, entry.cachedAt
+		}
+	}
+	delete(c.entries, oldestKey)
+}
+
+// readSecureVariable reads a secure variable from the servers, and falls back
+// to the cache if the client cannot reach them.
+func (c *Client) readSecureVariable(args *structs.SecureVariablesReadRequest, reply *structs.SecureVariablesReadResponse) error {
+	start := time.Now()
+	block := args.TimeToBlock()
+
+	err := c.rpc(structs.SecureVariablesReadRPCMethod, args, reply)
+	if err == nil {
+		if cerr := c.secureVariables.put(args, reply); cerr != nil {
+			c.rpcLogger.Warn("failed to cache secure variable", "path", args.Path, "error", cerr)
+		}
+		return nil
+	}
+
+	if !isDisconnectedErr(err) {
+		return err
+	}
+	ok, cerr := c.secureVariables.get(args, reply)
+	if cerr != nil {
+		c.rpcLogger.Warn("failed to read cached secure variable", "path", args.Path, "error", cerr)
+		return err
+	}
+	if !ok {
+		return err
+	}
+	c.rpcLogger.Debug("serving stale secure variable", "path", args.Path, "error", err)
+
+	// A blocking read returns as soon as the servers can't be reached, so
+	// if the cache has nothing newer than the caller has seen, wait as the
+	// blocking query would have rather than letting the caller spin.
+	if block > 0 && reply.Index <= args.MinQueryIndex {
+		wait := c.secureVariables.backoff(args)
+		if wait > block {
+			wait = block
+		}
+		if wait -= time.Since(start); wait > 0 {
+			timer, stop := helper.NewSafeTimer(wait)
+			defer stop()
+			select {
+			case <-timer.C:
+			case <-c.shutdownCh:
+			}
+		}
+	}
+	return nil
+}
+
+// isDisconnectedErr returns true if the error means the client could not get
+// an answer from the servers, because it has no path to them or they have no
+// leader, rather than the servers rejecting the request.
+func isDisconnectedErr(err error) bool {
+	switch {
+	case err == noServersErr,
+		structs.IsErrNoLeader(err),
+		structs.IsErrNoRegionPath(err),
+		helper.IsErrEOF(err),
+		errors.Is(err, rpc.ErrShutdown):
+		return true
+	}
+
+	// errors returned by the servers are never connection errors, even if
+	// they came from a connection of the servers
+	var serverErr rpc.ServerError
+	if errors.As(err, &serverErr) || structs.IsErrRPCCoded(err) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,217 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/rpc"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/nomad"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestSecureVariablesCache(t *testing.T) {
+	ci.Parallel(t)
+
+	cache, err := newSecureVariablesCache()
+	must.NoError(t, err)
+
+	sv := mock.SecureVariable()
+	args := &structs.SecureVariablesReadRequest{
+		Path: sv.Path,
+		QueryOptions: structs.QueryOptions{
+			Namespace: sv.Namespace,
+			AuthToken: "token-a",
+		},
+	}
+
+	var reply structs.SecureVariablesReadResponse
+	ok, err := cache.get(args, &reply)
+	must.NoError(t, err)
+	must.False(t, ok)
+
+	must.NoError(t, cache.put(args, &structs.SecureVariablesReadResponse{
+		Data:      sv,
+		QueryMeta: structs.QueryMeta{Index: 10, KnownLeader: true},
+	}))
+
+	// The variable is never held in plaintext
+	for _, entry := range cache.entries {
+		must.StrNotContains(t, string(entry.ciphertext), "value1")
+	}
+
+	ok, err = cache.get(args, &reply)
+	must.NoError(t, err)
+	must.True(t, ok)
+	must.Eq(t, sv.Items, reply.Data.Items)
+	must.Eq(t, sv.Path, reply.Data.Path)
+	must.Eq(t, uint64(10), reply.Index)
+	must.False(t, reply.KnownLeader)
+
+	// Other tokens don't share the entry
+	other := *args
+	other.AuthToken = "token-b"
+	ok, err = cache.get(&other, &structs.SecureVariablesReadResponse{})
+	must.NoError(t, err)
+	must.False(t, ok)
+
+	// A read of a deleted variable removes it
+	must.NoError(t, cache.put(args, &structs.SecureVariablesReadResponse{}))
+	ok, err = cache.get(args, &reply)
+	must.NoError(t, err)
+	must.False(t, ok)
+}
+
+func TestSecureVariablesCache_Evict(t *testing.T) {
+	ci.Parallel(t)
+
+	cache, err := newSecureVariablesCache()
+	must.NoError(t, err)
+
+	request := func(i int) *structs.SecureVariablesReadRequest {
+		return &structs.SecureVariablesReadRequest{
+			Path:         fmt.Sprintf("path/%d", i),
+			QueryOptions: structs.QueryOptions{Namespace: "default"},
+		}
+	}
+	for i := 0; i <= secureVariablesCacheMaxEntries; i++ {
+		must.NoError(t, cache.put(request(i), &structs.SecureVariablesReadResponse{
+			Data: mock.SecureVariable(),
+		}))
+	}
+	must.MapLen(t, secureVariablesCacheMaxEntries, cache.entries)
+
+	ok, err := cache.get(request(0), &structs.SecureVariablesReadResponse{})
+	must.NoError(t, err)
+	must.False(t, ok)
+	ok, err = cache.get(request(secureVariablesCacheMaxEntries), &structs.SecureVariablesReadResponse{})
+	must.NoError(t, err)
+	must.True(t, ok)
+}
+
+func TestSecureVariablesCache_isDisconnectedErr(t *testing.T) {
+	ci.Parallel(t)
+
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+
+	must.True(t, isDisconnectedErr(noServersErr))
+	must.True(t, isDisconnectedErr(structs.ErrNoLeader))
+	must.True(t, isDisconnectedErr(rpc.ServerError(structs.ErrNoRegionPath.Error())))
+	must.True(t, isDisconnectedErr(fmt.Errorf("rpc error: %w", fmt.Errorf("failed to get conn: %w", dialErr))))
+	must.True(t, isDisconnectedErr(fmt.Errorf("rpc error: %w", io.EOF)))
+	must.True(t, isDisconnectedErr(fmt.Errorf("rpc error: %w", rpc.ErrShutdown)))
+
+	must.False(t, isDisconnectedErr(fmt.Errorf("rpc error: %w", rpc.ServerError("Permission denied"))))
+	must.False(t, isDisconnectedErr(structs.NewErrRPCCoded(404, "not found")))
+	must.False(t, isDisconnectedErr(fmt.Errorf("rpc error: %w", errors.New("failed to decode response"))))
+}
+
+func TestSecureVariablesCache_backoff(t *testing.T) {
+	ci.Parallel(t)
+
+	cache, err := newSecureVariablesCache()
+	must.NoError(t, err)
+
+	sv := mock.SecureVariable()
+	args := &structs.SecureVariablesReadRequest{
+		Path:         sv.Path,
+		QueryOptions: structs.QueryOptions{Namespace: sv.Namespace},
+	}
+	reply := &structs.SecureVariablesReadResponse{Data: sv, QueryMeta: structs.QueryMeta{Index: 10}}
+	must.NoError(t, cache.put(args, reply))
+
+	// repeated stale reads back off exponentially up to the limit
+	for _, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+		must.Eq(t, expected, cache.backoff(args))
+	}
+	for i := 0; i < 10; i++ {
+		cache.backoff(args)
+	}
+	must.Eq(t, secureVariablesCacheMaxWait, cache.backoff(args))
+
+	// a fresh read from the servers resets the backoff
+	must.NoError(t, cache.put(args, reply))
+	must.Eq(t, secureVariablesCacheMinWait, cache.backoff(args))
+}
+
+func TestClient_StaleSecureVariables(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := nomad.TestServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	c, cleanupC := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s1.GetConfig().RPCAddr.String()}
+		c.StaleSecureVariables = true
+		c.RPCHoldTimeout = 100 * time.Millisecond
+	})
+	defer cleanupC()
+
+	sv := mock.SecureVariable()
+	sv.Namespace = structs.DefaultNamespace
+	applyReq := &structs.SecureVariablesApplyRequest{
+		Op:           structs.SVOpSet,
+		Var:          sv,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	must.NoError(t, s1.RPC(structs.SecureVariablesApplyRPCMethod, applyReq,
+		&structs.SecureVariablesApplyResponse{}))
+
+	readReq := &structs.SecureVariablesReadRequest{
+		Path: sv.Path,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+
+	// Wait for the client to read the variable from the servers
+	testutil.WaitForResult(func() (bool, error) {
+		var reply structs.SecureVariablesReadResponse
+		if err := c.RPC(structs.SecureVariablesReadRPCMethod, readReq, &reply); err != nil {
+			return false, err
+		}
+		if reply.Data == nil {
+			return false, errors.New("variable not found")
+		}
+		return reply.KnownLeader, errors.New("no known leader")
+	}, func(err error) {
+		t.Fatalf("failed to read variable: %v", err)
+	})
+
+	// Once the servers are gone the cached variable is served
+	cleanupS1()
+	testutil.WaitForResult(func() (bool, error) {
+		var reply structs.SecureVariablesReadResponse
+		if err := c.RPC(structs.SecureVariablesReadRPCMethod, readReq, &reply); err != nil {
+			return false, err
+		}
+		if reply.KnownLeader {
+			return false, errors.New("expected stale read")
+		}
+		return reply.Data != nil && reply.Data.Items["key1"] == "value1", errors.New("wrong variable")
+	}, func(err error) {
+		t.Fatalf("failed to read stale variable: %v", err)
+	})
+
+	// A blocking read for a newer index than the cache has waits rather
+	// than returning the cached variable immediately
+	var reply structs.SecureVariablesReadResponse
+	must.NoError(t, c.RPC(structs.SecureVariablesReadRPCMethod, readReq, &reply))
+	readReq.MinQueryIndex = reply.Index
+	readReq.MaxQueryTime = 500 * time.Millisecond
+	start := time.Now()
+	must.NoError(t, c.RPC(structs.SecureVariablesReadRPCMethod, readReq, &reply))
+	must.Greater(t, time.Since(start), 400*time.Millisecond)
+	must.NotNil(t, reply.Data)
+}
@@ -102,6 +102,18 @@ var (
 
 	// registryStateKey is the key at which dynamic plugin registry state is stored
 	registryStateKey = []byte("registry_state")
+
+	// snapshotBucketName is the bucket name containing the client snapshot
+	snapshotBucketName = []byte("snapshot")
+
+	// clientSnapshotKey is the key at which the client snapshot is stored
+	clientSnapshotKey = []byte("client")
+
+	// nodeBucketName is the bucket name containing data about the node
+	nodeBucketName = []byte("node")
+
+	// nodeMetaKey is the key at which the dynamic node metadata is stored
+	nodeMetaKey = []byte("meta")
 )
 
 // taskBucketName returns the bucket name for the given task name.
@@ -725,6 +737,87 @@ func (s *BoltStateDB) GetDynamicPluginRegistryState() (*dynamicplugins.RegistryS
 	return ps, nil
 }
 
+// PutClientSnapshot stores the client snapshot or returns an error.
+func (s *BoltStateDB) PutClientSnapshot(snap *ClientSnapshot) error {
+	return s.db.Update(func(tx *boltdd.Tx) error {
+		snapBkt, err := tx.CreateBucketIfNotExists(snapshotBucketName)
+		if err != nil {
+			return err
+		}
+		return snapBkt.Put(clientSnapshotKey, snap)
+	})
+}
+
+// GetClientSnapshot retrieves the client snapshot or returns an error.
+func (s *BoltStateDB) GetClientSnapshot() (*ClientSnapshot, error) {
+	var snap *ClientSnapshot
+
+	err := s.db.View(func(tx *boltdd.Tx) error {
+		snapBkt := tx.Bucket(snapshotBucketName)
+		if snapBkt == nil {
+			// No state, return
+			return nil
+		}
+
+		// Restore the snapshot if it exists
+		snap = &ClientSnapshot{}
+		if err := snapBkt.Get(clientSnapshotKey, snap); err != nil {
+			if !boltdd.IsErrNotFound(err) {
+				return fmt.Errorf("failed to read client snapshot: %v", err)
+			}
+
+			// Key not found, reset snap to nil
+			snap = nil
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return snap, nil
+}
+
+// PutNodeMeta stores the dynamic node metadata or returns an error.
+func (s *BoltStateDB) PutNodeMeta(meta map[string]*string) error {
+	return s.db.Update(func(tx *boltdd.Tx) error {
+		nodeBkt, err := tx.CreateBucketIfNotExists(nodeBucketName)
+		if err != nil {
+			return err
+		}
+		return nodeBkt.Put(nodeMetaKey, meta)
+	})
+}
+
+// GetNodeMeta retrieves the dynamic node metadata or returns an error.
+func (s *BoltStateDB) GetNodeMeta() (map[string]*string, error) {
+	var meta map[string]*string
+
+	err := s.db.View(func(tx *boltdd.Tx) error {
+		nodeBkt := tx.Bucket(nodeBucketName)
+		if nodeBkt == nil {
+			// No state, return
+			return nil
+		}
+
+		if err := nodeBkt.Get(nodeMetaKey, &meta); err != nil {
+			if !boltdd.IsErrNotFound(err) {
+				return fmt.Errorf("failed to read dynamic node metadata: %v", err)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return meta, nil
+}
+
 func keyForCheck(allocID string, checkID structs.CheckID) []byte {
 	return []byte(fmt.Sprintf("%s_%s", allocID, checkID))
 }
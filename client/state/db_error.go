@@ -81,6 +81,22 @@ func (m *ErrDB) PutDynamicPluginRegistryState(state *dynamicplugins.RegistryStat
 	return fmt.Errorf("Error!")
 }
 
+func (m *ErrDB) GetClientSnapshot() (*ClientSnapshot, error) {
+	return nil, fmt.Errorf("Error!")
+}
+
+func (m *ErrDB) PutClientSnapshot(snap *ClientSnapshot) error {
+	return fmt.Errorf("Error!")
+}
+
+func (m *ErrDB) GetNodeMeta() (map[string]*string, error) {
+	return nil, fmt.Errorf("Error!")
+}
+
+func (m *ErrDB) PutNodeMeta(meta map[string]*string) error {
+	return fmt.Errorf("Error!")
+}
+
 func (m *ErrDB) GetDevicePluginState() (*dmstate.PluginState, error) {
 	return nil, fmt.Errorf("Error!")
 }
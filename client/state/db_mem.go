@@ -41,6 +41,12 @@ type MemDB struct {
 	// dynamicmanager -> registry-state
 	dynamicManagerPs *dynamicplugins.RegistryState
 
+	// client snapshot
+	clientSnapshot *ClientSnapshot
+
+	// dynamic node metadata
+	nodeMeta map[string]*string
+
 	logger hclog.Logger
 
 	mu sync.RWMutex
@@ -233,6 +239,32 @@ func (m *MemDB) PutDynamicPluginRegistryState(ps *dynamicplugins.RegistryState)
 	return nil
 }
 
+func (m *MemDB) GetClientSnapshot() (*ClientSnapshot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.clientSnapshot, nil
+}
+
+func (m *MemDB) PutClientSnapshot(snap *ClientSnapshot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clientSnapshot = snap
+	return nil
+}
+
+func (m *MemDB) GetNodeMeta() (map[string]*string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.nodeMeta, nil
+}
+
+func (m *MemDB) PutNodeMeta(meta map[string]*string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nodeMeta = meta
+	return nil
+}
+
 func (m *MemDB) PutCheckResult(allocID string, qr *structs.CheckQueryResult) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -88,6 +88,22 @@ func (n NoopDB) GetDynamicPluginRegistryState() (*dynamicplugins.RegistryState,
 	return nil, nil
 }
 
+func (n NoopDB) GetClientSnapshot() (*ClientSnapshot, error) {
+	return nil, nil
+}
+
+func (n NoopDB) PutClientSnapshot(snap *ClientSnapshot) error {
+	return nil
+}
+
+func (n NoopDB) GetNodeMeta() (map[string]*string, error) {
+	return nil, nil
+}
+
+func (n NoopDB) PutNodeMeta(meta map[string]*string) error {
+	return nil
+}
+
 func (n NoopDB) PutCheckResult(allocID string, qr *structs.CheckQueryResult) error {
 	return nil
 }
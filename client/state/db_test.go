@@ -12,9 +12,11 @@ import (
 	dmstate "github.com/hashicorp/nomad/client/devicemanager/state"
 	"github.com/hashicorp/nomad/client/dynamicplugins"
 	driverstate "github.com/hashicorp/nomad/client/pluginmanager/drivermanager/state"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/kr/pretty"
 	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/require"
@@ -356,6 +358,77 @@ func TestStateDB_DriverManager(t *testing.T) {
 	})
 }
 
+// TestStateDB_ClientSnapshot asserts the behavior of client snapshot related
+// StateDB methods.
+func TestStateDB_ClientSnapshot(t *testing.T) {
+	ci.Parallel(t)
+
+	testDB(t, func(t *testing.T, db StateDB) {
+		require := require.New(t)
+
+		// Getting nonexistent state should return nils
+		snap, err := db.GetClientSnapshot()
+		require.NoError(err)
+		require.Nil(snap)
+
+		// Putting a snapshot should work
+		alloc := mock.Alloc()
+		expected := &ClientSnapshot{
+			Time: time.Now().Round(0),
+			Allocs: map[string]*AllocSnapshot{
+				alloc.ID: {
+					Ports: structs.AllocatedPorts{{Label: "http", Value: 25000}},
+					Tasks: map[string]*trstate.LocalState{
+						"web": {
+							TaskHandle: &drivers.TaskHandle{
+								Version: 1,
+								Config:  &drivers.TaskConfig{ID: "web-1"},
+								State:   drivers.TaskStateRunning,
+							},
+						},
+					},
+				},
+			},
+		}
+		require.NoError(db.PutClientSnapshot(expected))
+
+		// Getting should return the snapshot
+		snap, err = db.GetClientSnapshot()
+		require.NoError(err)
+		require.NotNil(snap)
+		require.True(expected.Time.Equal(snap.Time))
+		require.Equal(expected.Allocs[alloc.ID].Ports, snap.Allocs[alloc.ID].Ports)
+		require.Equal("web-1", snap.Allocs[alloc.ID].Tasks["web"].TaskHandle.Config.ID)
+	})
+}
+
+// TestStateDB_NodeMeta asserts the behavior of dynamic node metadata related
+// StateDB methods.
+func TestStateDB_NodeMeta(t *testing.T) {
+	ci.Parallel(t)
+
+	testDB(t, func(t *testing.T, db StateDB) {
+		require := require.New(t)
+
+		// Getting nonexistent state should return nils
+		meta, err := db.GetNodeMeta()
+		require.NoError(err)
+		require.Nil(meta)
+
+		// Putting metadata should work, including unset keys
+		expected := map[string]*string{
+			"network": pointer.Of("degraded"),
+			"rack":    nil,
+		}
+		require.NoError(db.PutNodeMeta(expected))
+
+		// Getting should return the metadata
+		meta, err = db.GetNodeMeta()
+		require.NoError(err)
+		require.Equal(expected, meta)
+	})
+}
+
 // TestStateDB_DynamicRegistry asserts the behavior of dynamic registry state related StateDB
 // methods.
 func TestStateDB_DynamicRegistry(t *testing.T) {
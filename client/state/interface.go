@@ -96,6 +96,22 @@ type StateDB interface {
 	// GetCheckResults is used to restore the set of check results on this Client.
 	GetCheckResults() (checks.ClientResults, error)
 
+	// GetClientSnapshot is used to retrieve the most recent snapshot of the
+	// task handles of the client. It may be nil.
+	GetClientSnapshot() (*ClientSnapshot, error)
+
+	// PutClientSnapshot is used to store a snapshot of the task handles of
+	// the client, replacing any previous snapshot.
+	PutClientSnapshot(snap *ClientSnapshot) error
+
+	// GetNodeMeta is used to retrieve the dynamic metadata of the node. It
+	// may be nil.
+	GetNodeMeta() (map[string]*string, error)
+
+	// PutNodeMeta is used to store the dynamic metadata of the node,
+	// replacing any previous metadata.
+	PutNodeMeta(meta map[string]*string) error
+
 	// Close the database. Unsafe for further use after calling regardless
 	// of return value.
 	Close() error
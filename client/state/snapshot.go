@@ -0,0 +1,30 @@
+package state
+
+import (
+	"time"
+
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// ClientSnapshot is a point in time copy of the state needed to reattach to
+// the tasks running on a client. It is stored as a single entry so that it
+// can be read back quickly when the agent restarts, and is used to recover
+// task handles missing from the per-task state of an allocation.
+type ClientSnapshot struct {
+	// Time is when the snapshot was taken.
+	Time time.Time
+
+	// Allocs maps allocation IDs to their snapshot.
+	Allocs map[string]*AllocSnapshot
+}
+
+// AllocSnapshot is the snapshot of a single allocation.
+type AllocSnapshot struct {
+	// Ports are the dynamic and static ports allocated to the allocation.
+	Ports structs.AllocatedPorts
+
+	// Tasks maps task names to the local state of their task runner, which
+	// includes the task handle and driver network.
+	Tasks map[string]*state.LocalState
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"time"
+
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/state"
+	cstate "github.com/hashicorp/nomad/client/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// snapshotTaskHandles writes a snapshot of the task handles and ports of the
+// non-terminal allocations of the client to the state DB. The handles are
+// taken from the running task runners rather than the state DB, so the
+// snapshot still has them if persisting a task's own state failed. It is read
+// back on restart to reattach to tasks whose own state is missing a task
+// handle.
+func (c *Client) snapshotTaskHandles() error {
+	snap := &cstate.ClientSnapshot{
+		Time:   time.Now(),
+		Allocs: make(map[string]*cstate.AllocSnapshot),
+	}
+
+	for id, ar := range c.getAllocRunners() {
+		alloc := ar.Alloc()
+		if alloc.TerminalStatus() {
+			continue
+		}
+		tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+		if tg == nil {
+			continue
+		}
+
+		allocSnap := &cstate.AllocSnapshot{
+			Tasks: make(map[string]*state.LocalState),
+		}
+		if alloc.AllocatedResources != nil {
+			allocSnap.Ports = alloc.AllocatedResources.Shared.Ports
+		}
+
+		for _, task := range tg.Tasks {
+			handle, net := ar.GetTaskHandle(task.Name)
+			if handle == nil {
+				continue
+			}
+			allocSnap.Tasks[task.Name] = &state.LocalState{
+				TaskHandle:    handle,
+				DriverNetwork: net,
+			}
+		}
+		snap.Allocs[id] = allocSnap
+	}
+
+	return c.stateDB.PutClientSnapshot(snap)
+}
+
+// restoreTaskHandles fills in the task handles and ports missing from the
+// restored allocations using the most recent client snapshot, so that the
+// alloc runners can reattach to the running tasks instead of waiting for the
+// servers to be contacted.
+func (c *Client) restoreTaskHandles(allocs []*structs.Allocation) {
+	snap, err := c.stateDB.GetClientSnapshot()
+	if err != nil {
+		c.logger.Warn("failed to read client snapshot", "error", err)
+		return
+	}
+	if snap == nil {
+		return
+	}
+
+	for _, alloc := range allocs {
+		allocSnap, ok := snap.Allocs[alloc.ID]
+		if !ok {
+			continue
+		}
+
+		if alloc.AllocatedResources != nil && len(alloc.AllocatedResources.Shared.Ports) == 0 {
+			alloc.AllocatedResources.Shared.Ports = allocSnap.Ports
+		}
+
+		for taskName, snapState := range allocSnap.Tasks {
+			ls, ts, err := c.stateDB.GetTaskRunnerState(alloc.ID, taskName)
+			if err != nil {
+				c.logger.Warn("failed to read task state", "error", err, "alloc_id", alloc.ID, "task", taskName)
+				continue
+			}
+			if ls != nil && ls.TaskHandle != nil {
+				continue
+			}
+			if ts != nil && ts.State == structs.TaskStateDead {
+				continue
+			}
+
+			if ls == nil {
+				ls = state.NewLocalState()
+			}
+			ls.TaskHandle = snapState.TaskHandle
+			ls.DriverNetwork = snapState.DriverNetwork
+			if err := c.stateDB.PutTaskRunnerLocalState(alloc.ID, taskName, ls); err != nil {
+				c.logger.Warn("failed to restore task handle from client snapshot",
+					"error", err, "alloc_id", alloc.ID, "task", taskName)
+				continue
+			}
+			c.logger.Debug("restored task handle from client snapshot",
+				"alloc_id", alloc.ID, "task", taskName, "snapshot_time", snap.Time)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package client
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	trstate "github.com/hashicorp/nomad/client/allocrunner/taskrunner/state"
+	"github.com/hashicorp/nomad/client/config"
+	cstate "github.com/hashicorp/nomad/client/state"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestClient_snapshotTaskHandles(t *testing.T) {
+	ci.Parallel(t)
+
+	c, cleanup := TestClient(t, func(c *config.Config) {
+		c.StateDBFactory = cstate.NewBoltStateDB
+	})
+	defer cleanup()
+
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"run_for": "10s",
+	}
+	must.NoError(t, c.addAlloc(alloc, ""))
+
+	testutil.WaitForResult(func() (bool, error) {
+		ar, err := c.getAllocRunner(alloc.ID)
+		if err != nil {
+			return false, err
+		}
+		handle, _ := ar.GetTaskHandle(task.Name)
+		return handle != nil, fmt.Errorf("task not started")
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+
+	must.NoError(t, c.snapshotTaskHandles())
+
+	snap, err := c.stateDB.GetClientSnapshot()
+	must.NoError(t, err)
+	must.NotNil(t, snap.Allocs[alloc.ID])
+	ls := snap.Allocs[alloc.ID].Tasks[task.Name]
+	must.NotNil(t, ls)
+	must.NotNil(t, ls.TaskHandle)
+	must.Eq(t, alloc.AllocatedResources.Shared.Ports, snap.Allocs[alloc.ID].Ports)
+}
+
+func TestClient_restoreTaskHandles(t *testing.T) {
+	ci.Parallel(t)
+
+	c, cleanup := TestClient(t, nil)
+	defer cleanup()
+
+	// Use a bolt DB so that the objects read back are decoded copies of the
+	// ones stored, as they are on a real client
+	stateDB, err := cstate.NewBoltStateDB(c.logger, t.TempDir())
+	must.NoError(t, err)
+	defer stateDB.Close()
+	c.stateDB = stateDB
+
+	alloc := mock.Alloc()
+	deadAlloc := mock.Alloc()
+	runningAlloc := mock.Alloc()
+	taskName := alloc.Job.LookupTaskGroup(alloc.TaskGroup).Tasks[0].Name
+	ports := structs.AllocatedPorts{{Label: "http", Value: 25000}}
+	alloc.AllocatedResources.Shared.Ports = nil
+
+	handle := func(id string) *drivers.TaskHandle {
+		return &drivers.TaskHandle{
+			Version: 1,
+			Config:  &drivers.TaskConfig{ID: id},
+			State:   drivers.TaskStateRunning,
+		}
+	}
+	must.NoError(t, c.stateDB.PutClientSnapshot(&cstate.ClientSnapshot{
+		Time: time.Now(),
+		Allocs: map[string]*cstate.AllocSnapshot{
+			alloc.ID: {
+				Ports: ports,
+				Tasks: map[string]*trstate.LocalState{
+					taskName: {TaskHandle: handle("task-1")},
+				},
+			},
+			deadAlloc.ID: {
+				Tasks: map[string]*trstate.LocalState{
+					taskName: {TaskHandle: handle("dead")},
+				},
+			},
+			runningAlloc.ID: {
+				Tasks: map[string]*trstate.LocalState{
+					taskName: {TaskHandle: handle("stale")},
+				},
+			},
+		},
+	}))
+
+	// The task state was persisted without a handle
+	hookState := &trstate.HookState{Data: map[string]string{"k": "v"}}
+	must.NoError(t, c.stateDB.PutTaskRunnerLocalState(alloc.ID, taskName, &trstate.LocalState{
+		Hooks: map[string]*trstate.HookState{"hook": hookState},
+	}))
+
+	// A dead task is not reattached
+	must.NoError(t, c.stateDB.PutTaskState(deadAlloc.ID, taskName, &structs.TaskState{State: structs.TaskStateDead}))
+
+	// The handle of the task's own state is newer than the snapshot
+	must.NoError(t, c.stateDB.PutTaskRunnerLocalState(runningAlloc.ID, taskName, &trstate.LocalState{
+		TaskHandle: handle("current"),
+	}))
+
+	c.restoreTaskHandles([]*structs.Allocation{alloc, deadAlloc, runningAlloc})
+
+	ls, _, err := c.stateDB.GetTaskRunnerState(alloc.ID, taskName)
+	must.NoError(t, err)
+	must.NotNil(t, ls.TaskHandle)
+	must.Eq(t, "task-1", ls.TaskHandle.Config.ID)
+	must.Eq(t, hookState, ls.Hooks["hook"])
+	must.Eq(t, ports, alloc.AllocatedResources.Shared.Ports)
+
+	ls, _, err = c.stateDB.GetTaskRunnerState(deadAlloc.ID, taskName)
+	must.NoError(t, err)
+	must.Nil(t, ls)
+
+	ls, _, err = c.stateDB.GetTaskRunnerState(runningAlloc.ID, taskName)
+	must.NoError(t, err)
+	must.Eq(t, "current", ls.TaskHandle.Config.ID)
+}
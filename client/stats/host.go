@@ -21,6 +21,7 @@ type HostStats struct {
 	DiskStats        []*DiskStats
 	AllocDirStats    *DiskStats
 	DeviceStats      []*DeviceGroupStats
+	System           *SystemStats
 	Uptime           uint64
 	Timestamp        int64
 	CPUTicksConsumed float64
@@ -76,6 +77,14 @@ type HostStatsCollector struct {
 	allocDir             string
 	deviceStatsCollector DeviceStatsCollector
 
+	// systemStatsEnabled enables the collection of the host system stats,
+	// such as the load, conntrack and socket counts.
+	systemStatsEnabled bool
+
+	// conntrackFailed is set when the conntrack stats cannot be read; used
+	// to squelch logspam.
+	conntrackFailed bool
+
 	// badParts is a set of partitions whose usage cannot be read; used to
 	// squelch logspam.
 	badParts map[string]struct{}
@@ -85,8 +94,9 @@ type HostStatsCollector struct {
 
 // NewHostStatsCollector returns a HostStatsCollector. The allocDir is passed in
 // so that we can present the disk related statistics for the mountpoint where
-// the allocation directory lives
-func NewHostStatsCollector(logger hclog.Logger, allocDir string, deviceStatsCollector DeviceStatsCollector) *HostStatsCollector {
+// the allocation directory lives. The host system stats are only collected if
+// collectSystemStats is set.
+func NewHostStatsCollector(logger hclog.Logger, allocDir string, deviceStatsCollector DeviceStatsCollector, collectSystemStats bool) *HostStatsCollector {
 	logger = logger.Named("host_stats")
 	numCores := runtime.NumCPU()
 	statsCalculator := make(map[string]*HostCpuStatsCalculator)
@@ -97,6 +107,7 @@ func NewHostStatsCollector(logger hclog.Logger, allocDir string, deviceStatsColl
 		allocDir:             allocDir,
 		badParts:             make(map[string]struct{}),
 		deviceStatsCollector: deviceStatsCollector,
+		systemStatsEnabled:   collectSystemStats,
 	}
 	return collector
 }
@@ -159,6 +170,11 @@ func (h *HostStatsCollector) collectLocked() error {
 	deviceStats := h.collectDeviceGroupStats()
 	hs.DeviceStats = deviceStats
 
+	// Collect host system stats if enabled
+	if h.systemStatsEnabled {
+		hs.System = h.collectSystemStats()
+	}
+
 	// Update the collected status object.
 	h.hostStats = hs
 
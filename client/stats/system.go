@@ -0,0 +1,127 @@
+package stats
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/shirou/gopsutil/v3/load"
+	"github.com/shirou/gopsutil/v3/net"
+)
+
+// SystemStats represents host level capacity signals which are only collected
+// when the client is configured to collect host system metrics.
+type SystemStats struct {
+	Load      *LoadStats
+	Conntrack *ConntrackStats
+	Sockets   *SocketStats
+}
+
+// LoadStats represents the load averages of the host
+type LoadStats struct {
+	Load1  float64
+	Load5  float64
+	Load15 float64
+}
+
+// ConntrackStats represents the usage of the connection tracking table of the
+// host. It is only available on Linux hosts with the nf_conntrack module
+// loaded.
+type ConntrackStats struct {
+	Count int64
+	Max   int64
+}
+
+// SocketStats represents the number of sockets in use on the host. It is only
+// available on Linux hosts.
+type SocketStats struct {
+	Used        int64
+	TCPInUse    int64
+	TCPOrphan   int64
+	TCPTimeWait int64
+	UDPInUse    int64
+}
+
+// collectSystemStats collects the host system stats. Stats which can't be
+// collected on the host are left nil.
+func (h *HostStatsCollector) collectSystemStats() *SystemStats {
+	ss := &SystemStats{}
+
+	avg, err := load.Avg()
+	if err != nil {
+		h.logger.Error("failed to collect load stats", "error", err)
+	} else {
+		ss.Load = &LoadStats{
+			Load1:  avg.Load1,
+			Load5:  avg.Load5,
+			Load15: avg.Load15,
+		}
+	}
+
+	// The conntrack table only exists when the nf_conntrack module is
+	// loaded, so failures are expected and only logged once.
+	filters, err := net.FilterCounters()
+	switch {
+	case err != nil:
+		if !h.conntrackFailed {
+			h.conntrackFailed = true
+			h.logger.Debug("failed to collect conntrack stats", "error", err)
+		}
+	case len(filters) > 0:
+		h.conntrackFailed = false
+		ss.Conntrack = &ConntrackStats{
+			Count: filters[0].ConnTrackCount,
+			Max:   filters[0].ConnTrackMax,
+		}
+	}
+
+	sockets, err := collectSocketStats()
+	if err != nil {
+		h.logger.Error("failed to collect socket stats", "error", err)
+	}
+	ss.Sockets = sockets
+
+	return ss
+}
+
+// parseSockstat parses the socket counts of the content of /proc/net/sockstat,
+// which looks like:
+//
+//	sockets: used 290
+//	TCP: inuse 5 orphan 0 tw 2 alloc 7 mem 1
+//	UDP: inuse 1 mem 0
+func parseSockstat(r io.Reader) (*SocketStats, error) {
+	ss := &SocketStats{}
+	fields := map[string]*int64{
+		"sockets:used": &ss.Used,
+		"TCP:inuse":    &ss.TCPInUse,
+		"TCP:orphan":   &ss.TCPOrphan,
+		"TCP:tw":       &ss.TCPTimeWait,
+		"UDP:inuse":    &ss.UDPInUse,
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		parts := strings.Fields(scanner.Text())
+		if len(parts) < 3 || len(parts)%2 != 1 {
+			continue
+		}
+		for i := 1; i < len(parts); i += 2 {
+			field, ok := fields[parts[0]+parts[i]]
+			if !ok {
+				continue
+			}
+			v, err := strconv.ParseInt(parts[i+1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %s %s: %v", parts[0], parts[i], err)
+			}
+			*field = v
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return ss, nil
+}
@@ -0,0 +1,24 @@
+//go:build linux
+
+package stats
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// collectSocketStats returns the socket counts of the host from procfs.
+func collectSocketStats() (*SocketStats, error) {
+	procRoot := os.Getenv("HOST_PROC")
+	if procRoot == "" {
+		procRoot = "/proc"
+	}
+
+	f, err := os.Open(filepath.Join(procRoot, "net", "sockstat"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseSockstat(f)
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package stats
+
+// collectSocketStats is not supported outside of Linux.
+func collectSocketStats() (*SocketStats, error) {
+	return nil, nil
+}
@@ -0,0 +1,60 @@
+package stats
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSockstat(t *testing.T) {
+	ci.Parallel(t)
+
+	sockstat := `sockets: used 290
+TCP: inuse 5 orphan 1 tw 12 alloc 7 mem 1
+UDP: inuse 3 mem 0
+UDPLITE: inuse 0
+RAW: inuse 0
+FRAG: inuse 0 memory 0
+`
+	ss, err := parseSockstat(strings.NewReader(sockstat))
+	require.NoError(t, err)
+	require.Equal(t, &SocketStats{
+		Used:        290,
+		TCPInUse:    5,
+		TCPOrphan:   1,
+		TCPTimeWait: 12,
+		UDPInUse:    3,
+	}, ss)
+
+	_, err = parseSockstat(strings.NewReader("TCP: inuse five\n"))
+	require.EqualError(t, err, `invalid value for TCP: inuse: strconv.ParseInt: parsing "five": invalid syntax`)
+}
+
+func TestHostStatsCollector_SystemStats(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+
+	// System stats are only collected when enabled
+	hs := NewHostStatsCollector(logger, cwd, nil, false)
+	require.NoError(t, hs.Collect())
+	require.Nil(t, hs.Stats().System)
+
+	hs = NewHostStatsCollector(logger, cwd, nil, true)
+	require.NoError(t, hs.Collect())
+	system := hs.Stats().System
+	require.NotNil(t, system)
+	require.NotNil(t, system.Load)
+
+	if runtime.GOOS == "linux" {
+		require.NotNil(t, system.Sockets)
+		require.NotZero(t, system.Sockets.Used)
+	}
+}
@@ -72,6 +72,15 @@ type FsListRequest struct {
 	// Path is the path to list
 	Path string
 
+	// Recursive lists the contents of every directory below Path. Entries
+	// are named by their path relative to Path.
+	Recursive bool
+
+	// Glob, if set, restricts the listing to entries matching the pattern.
+	// Patterns containing a path separator are matched against the path
+	// relative to Path, otherwise against the entry's base name.
+	Glob string
+
 	structs.QueryOptions
 }
 
@@ -83,7 +92,9 @@ type FsListResponse struct {
 	structs.QueryMeta
 }
 
-// FsStatRequest is used to stat a file
+// FsStatRequest is used to stat a file. If MinQueryIndex is set, the request
+// blocks until the file's modification time, in Unix nanoseconds, is greater
+// than the index or MaxQueryTime elapses.
 type FsStatRequest struct {
 	// AllocID is the allocation to stat the file in
 	AllocID string
@@ -179,6 +190,18 @@ type AllocExecRequest struct {
 	// Cmd is the command to be executed
 	Cmd []string
 
+	// Resumable requests a session that can be resumed after the connection
+	// is lost. The first output frame of the session carries its token.
+	Resumable bool
+
+	// SessionToken, if set, resumes the session with this token instead of
+	// executing Cmd.
+	SessionToken string
+
+	// Offset is the offset of the last output frame received before the
+	// connection was lost, when resuming a session.
+	Offset int64
+
 	structs.QueryOptions
 }
 
@@ -215,6 +238,46 @@ type AllocStatsResponse struct {
 	structs.QueryMeta
 }
 
+// NodeMetaApplyRequest is used to set and unset the dynamic metadata of a
+// node without changing its configuration.
+type NodeMetaApplyRequest struct {
+	// NodeID is the node to update the metadata of.
+	NodeID string
+
+	// Meta maps the keys to set to their value. A nil value unsets the key,
+	// even if it is set in the configuration of the node.
+	Meta map[string]*string
+
+	structs.QueryOptions
+}
+
+// Validate returns an error if the request doesn't change any key or
+// contains an empty key.
+func (r *NodeMetaApplyRequest) Validate() error {
+	if len(r.Meta) == 0 {
+		return errors.New("missing metadata to apply")
+	}
+	for k := range r.Meta {
+		if k == "" {
+			return errors.New("metadata keys cannot be empty")
+		}
+	}
+	return nil
+}
+
+// NodeMetaResponse is used to return the metadata of a node.
+type NodeMetaResponse struct {
+	// Meta is the metadata of the node, with the dynamic metadata applied
+	// over its configured metadata.
+	Meta map[string]string
+
+	// Dynamic is the metadata applied with the NodeMeta.Apply RPC, where nil
+	// values are keys that were unset.
+	Dynamic map[string]*string
+
+	structs.QueryMeta
+}
+
 // MemoryStats holds memory usage related stats
 type MemoryStats struct {
 	RSS            uint64
@@ -353,6 +416,30 @@ func (h *HealthCheckResponse) AddDriverInfo(name string, driverInfo *structs.Dri
 	h.Drivers[name] = driverInfo
 }
 
+// SecureVariableChange is streamed to the tasks subscribed to a secure
+// variable through the secure variables socket of their allocation. Patch is
+// a JSON patch (RFC 6902) to apply to the items of the variable as last sent
+// to transform them into the current items. The first change of a
+// subscription patches an empty set of items.
+type SecureVariableChange struct {
+	Namespace string
+	Path      string
+	Index     uint64
+
+	// Deleted is true when the variable doesn't exist, in which case the
+	// patch removes all its items.
+	Deleted bool
+
+	Patch []*JSONPatchOperation
+}
+
+// JSONPatchOperation is an operation of a JSON patch.
+type JSONPatchOperation struct {
+	Op    string  `json:"op"`
+	Path  string  `json:"path"`
+	Value *string `json:"value,omitempty"`
+}
+
 // CheckBufSize is the size of the buffer that is used for job output
 const CheckBufSize = 4 * 1024
 
@@ -121,6 +121,10 @@ const (
 
 	// VaultNamespace is the environment variable for passing the Vault namespace, if applicable
 	VaultNamespace = "VAULT_NAMESPACE"
+
+	// WorkloadToken is the environment variable for passing the task's
+	// signed workload identity, which can be used as a Nomad token
+	WorkloadToken = "NOMAD_TOKEN"
 )
 
 // The node values that can be interpreted.
@@ -421,6 +425,8 @@ type Builder struct {
 	vaultToken       string
 	vaultNamespace   string
 	injectVaultToken bool
+	workloadToken    string
+	injectWorkload   bool
 	jobID            string
 	jobName          string
 	jobParentID      string
@@ -566,6 +572,11 @@ func (b *Builder) buildEnv(allocDir, localDir, secretsDir string,
 		envMap[VaultNamespace] = b.vaultNamespace
 	}
 
+	// Build the workload identity token
+	if b.injectWorkload && b.workloadToken != "" {
+		envMap[WorkloadToken] = b.workloadToken
+	}
+
 	// Copy and interpolate task meta
 	for k, v := range b.taskMeta {
 		envMap[hargs.ReplaceEnv(k, nodeAttrs, envMap)] = hargs.ReplaceEnv(v, nodeAttrs, envMap)
@@ -1017,6 +1028,16 @@ func (b *Builder) SetVaultToken(token, namespace string, inject bool) *Builder {
 	return b
 }
 
+// SetWorkloadToken sets the signed workload identity of the task, which is
+// exposed to the task as NOMAD_TOKEN if inject is set.
+func (b *Builder) SetWorkloadToken(token string, inject bool) *Builder {
+	b.mu.Lock()
+	b.workloadToken = token
+	b.injectWorkload = inject
+	b.mu.Unlock()
+	return b
+}
+
 // addPort keys and values for other tasks to an env var map
 func addPort(m map[string]string, taskName, ip, portLabel string, port int) {
 	key := fmt.Sprintf("%s%s_%s", AddrPrefix, taskName, portLabel)
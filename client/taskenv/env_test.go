@@ -517,6 +517,26 @@ func TestEnvironment_VaultToken(t *testing.T) {
 	}
 }
 
+func TestEnvironment_WorkloadToken(t *testing.T) {
+	ci.Parallel(t)
+
+	n := mock.Node()
+	a := mock.Alloc()
+	env := NewBuilder(n, a, a.Job.TaskGroups[0].Tasks[0], "global")
+
+	require.NotContains(t, env.Build().All(), WorkloadToken)
+
+	// the token is only injected if the task opts in
+	env.SetWorkloadToken("header.claims.signature", false)
+	require.NotContains(t, env.Build().All(), WorkloadToken)
+
+	env.SetWorkloadToken("header.claims.signature", true)
+	require.Equal(t, "header.claims.signature", env.Build().All()[WorkloadToken])
+
+	env.SetWorkloadToken("", true)
+	require.NotContains(t, env.Build().All(), WorkloadToken)
+}
+
 func TestEnvironment_Envvars(t *testing.T) {
 	ci.Parallel(t)
 
@@ -558,3 +558,37 @@ func TestHTTP_OneTimeToken(t *testing.T) {
 		require.EqualError(t, err, structs.ErrPermissionDenied.Error())
 	})
 }
+
+func TestHTTP_ACLCheck(t *testing.T) {
+	ci.Parallel(t)
+	httpACLTest(t, nil, func(s *TestAgent) {
+		body := structs.ACLCheckRequest{
+			Checks: []*structs.ACLCheck{
+				{Resource: "namespace", Capability: "submit-job"},
+				{Resource: "node", Capability: "submit-job"},
+			},
+		}
+		buf := encodeReq(body)
+		req, err := http.NewRequest("PUT", "/v1/acl/check", buf)
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		setToken(req, s.RootToken)
+
+		obj, err := s.Server.ACLCheckRequest(respW, req)
+		require.NoError(t, err)
+		require.NotEmpty(t, respW.Result().Header.Get("X-Nomad-Index"))
+
+		results := obj.([]*structs.ACLCheckResult)
+		require.Len(t, results, 2)
+		require.True(t, results[0].Allowed)
+		require.Equal(t, "default", results[0].Namespace)
+		require.False(t, results[1].Allowed)
+		require.NotEmpty(t, results[1].Error)
+
+		// Only PUT and POST are accepted
+		req, err = http.NewRequest("GET", "/v1/acl/check", nil)
+		require.NoError(t, err)
+		_, err = s.Server.ACLCheckRequest(httptest.NewRecorder(), req)
+		require.EqualError(t, err, ErrInvalidMethod)
+	})
+}
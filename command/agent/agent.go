@@ -12,6 +12,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	metrics "github.com/armon/go-metrics"
@@ -109,6 +110,16 @@ type Agent struct {
 	shutdownCh   chan struct{}
 	shutdownLock sync.Mutex
 
+	// shutdownRequestCh is closed when a shutdown of the agent is requested
+	// through the API, which the agent command handles like an interrupt
+	// with a graceful leave.
+	shutdownRequestCh   chan struct{}
+	shutdownRequestOnce sync.Once
+
+	// clientShutdownStarted is set once a client shutdown request started
+	// draining the node, so concurrent requests are rejected.
+	clientShutdownStarted atomic.Bool
+
 	// builtinDialer dials the builtinListener. It is used for connecting
 	// consul-template to the HTTP API in process. In the event this agent is
 	// not running in client mode, these two fields will be nil.
@@ -121,10 +132,11 @@ type Agent struct {
 // NewAgent is used to create a new agent with the given configuration
 func NewAgent(config *Config, logger log.InterceptLogger, logOutput io.Writer, inmem *metrics.InmemSink) (*Agent, error) {
 	a := &Agent{
-		config:     config,
-		logOutput:  logOutput,
-		shutdownCh: make(chan struct{}),
-		InmemSink:  inmem,
+		config:            config,
+		logOutput:         logOutput,
+		shutdownCh:        make(chan struct{}),
+		shutdownRequestCh: make(chan struct{}),
+		InmemSink:         inmem,
 	}
 
 	// Create the loggers
@@ -235,6 +247,23 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		conf.EnabledSchedulers = schedulers
 
 	}
+	if len(agentConfig.Server.DedicatedSchedulers) != 0 {
+		dedicated := 0
+		for sched, n := range agentConfig.Server.DedicatedSchedulers {
+			if n < 0 {
+				return nil, fmt.Errorf("dedicated_schedulers for %q cannot be negative", sched)
+			}
+			if !helper.SliceStringContains(conf.EnabledSchedulers, sched) {
+				return nil, fmt.Errorf("dedicated_schedulers refers to %q which is not an enabled scheduler", sched)
+			}
+			dedicated += n
+		}
+		if dedicated > 0 && dedicated >= conf.NumSchedulers {
+			return nil, fmt.Errorf("dedicated_schedulers total %d must be less than num_schedulers %d, to leave a shared scheduler for core jobs",
+				dedicated, conf.NumSchedulers)
+		}
+		conf.DedicatedSchedulers = helper.CopyMap(agentConfig.Server.DedicatedSchedulers)
+	}
 	if agentConfig.ACL.Enabled {
 		conf.ACLEnabled = true
 	}
@@ -256,6 +285,8 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 	if agentConfig.Server.EnableEventBroker != nil {
 		conf.EnableEventBroker = *agentConfig.Server.EnableEventBroker
 	}
+	conf.RequireMlock = agentConfig.Server.RequireMlock
+	conf.RequireJobSignatures = agentConfig.Server.RequireJobSignatures
 	if agentConfig.Server.EventBufferSize != nil {
 		if *agentConfig.Server.EventBufferSize < 0 {
 			return nil, fmt.Errorf("Invalid Config, event_buffer_size must be non-negative")
@@ -333,6 +364,16 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		}
 		conf.NodeGCThreshold = dur
 	}
+	if purgeThreshold := agentConfig.Server.NodePurgeThreshold; purgeThreshold != "" {
+		dur, err := time.ParseDuration(purgeThreshold)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse node_purge_threshold: %v", err)
+		}
+		if dur < 0 {
+			return nil, fmt.Errorf("node_purge_threshold must be non-negative")
+		}
+		conf.NodePurgeThreshold = dur
+	}
 	if gcInterval := agentConfig.Server.JobGCInterval; gcInterval != "" {
 		dur, err := time.ParseDuration(gcInterval)
 		if err != nil {
@@ -387,6 +428,9 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 	if maxHPS := agentConfig.Server.MaxHeartbeatsPerSecond; maxHPS != 0 {
 		conf.MaxHeartbeatsPerSecond = maxHPS
 	}
+	if maxRPS := agentConfig.Server.MaxReconnectsPerSecond; maxRPS != 0 {
+		conf.MaxReconnectsPerSecond = maxRPS
+	}
 	if failoverTTL := agentConfig.Server.FailoverHeartbeatTTL; failoverTTL != 0 {
 		conf.FailoverHeartbeatTTL = failoverTTL
 	}
@@ -453,6 +497,44 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		}
 	}
 
+	// Set plan backpressure configuration.
+	if planBackpressureConf := agentConfig.Server.PlanBackpressure; planBackpressureConf != nil {
+		if planBackpressureConf.Enabled != nil {
+			conf.PlanBackpressureEnabled = *planBackpressureConf.Enabled
+		}
+
+		if planBackpressureConf.MaxQueueDepth < 0 {
+			return nil, fmt.Errorf("plan_backpressure.max_queue_depth cannot be negative")
+		} else if planBackpressureConf.MaxQueueDepth > 0 {
+			conf.PlanBackpressureMaxQueueDepth = planBackpressureConf.MaxQueueDepth
+		}
+
+		if planBackpressureConf.MaxApplyLatency < 0 {
+			return nil, fmt.Errorf("plan_backpressure.max_apply_latency cannot be negative")
+		} else if planBackpressureConf.MaxApplyLatency > 0 {
+			conf.PlanBackpressureMaxApplyLatency = planBackpressureConf.MaxApplyLatency
+		}
+	}
+
+	// Set keyring rotation configuration.
+	if keyringConf := agentConfig.Server.Keyring; keyringConf != nil {
+		if keyringConf.RotationInterval < 0 {
+			return nil, fmt.Errorf("keyring.rotation_interval cannot be negative")
+		} else if keyringConf.RotationIntervalHCL != "" {
+			conf.RootKeyRotationThreshold = keyringConf.RotationInterval
+		}
+
+		if keyringConf.PrepublishPeriod < 0 {
+			return nil, fmt.Errorf("keyring.prepublish_period cannot be negative")
+		} else if keyringConf.PrepublishPeriod > 0 {
+			if conf.RootKeyRotationThreshold > 0 &&
+				keyringConf.PrepublishPeriod >= conf.RootKeyRotationThreshold {
+				return nil, fmt.Errorf("keyring.prepublish_period must be less than keyring.rotation_interval")
+			}
+			conf.RootKeyPrepublishPeriod = keyringConf.PrepublishPeriod
+		}
+	}
+
 	// Add Enterprise license configs
 	conf.LicenseEnv = agentConfig.Server.LicenseEnv
 	conf.LicensePath = agentConfig.Server.LicensePath
@@ -619,7 +701,10 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	conf.ClientMinPort = uint(agentConfig.Client.ClientMinPort)
 	conf.MaxDynamicPort = agentConfig.Client.MaxDynamicPort
 	conf.MinDynamicPort = agentConfig.Client.MinDynamicPort
+	conf.MemoryOversubscriptionRatio = agentConfig.Client.MemoryOversubscriptionRatio
 	conf.DisableRemoteExec = agentConfig.Client.DisableRemoteExec
+	conf.StaleSecureVariables = agentConfig.Client.StaleSecureVariables
+	conf.SecureVariablesSocket = agentConfig.Client.SecureVariablesSocket
 
 	if agentConfig.Client.TemplateConfig != nil {
 		conf.TemplateConfig = agentConfig.Client.TemplateConfig.Copy()
@@ -637,6 +722,7 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	conf.Node.Name = agentConfig.NodeName
 	conf.Node.Meta = agentConfig.Client.Meta
 	conf.Node.NodeClass = agentConfig.Client.NodeClass
+	conf.Node.DisconnectDomain = agentConfig.Client.DisconnectDomain
 
 	// Set up the HTTP advertise address
 	conf.Node.HTTPAddr = agentConfig.AdvertiseAddrs.HTTP
@@ -685,6 +771,7 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	conf.StatsCollectionInterval = agentConfig.Telemetry.collectionInterval
 	conf.PublishNodeMetrics = agentConfig.Telemetry.PublishNodeMetrics
 	conf.PublishAllocationMetrics = agentConfig.Telemetry.PublishAllocationMetrics
+	conf.CollectHostSystemMetrics = agentConfig.Telemetry.CollectHostSystemMetrics
 
 	// Set the TLS related configs
 	conf.TLSConfig = agentConfig.TLSConfig
@@ -696,6 +783,10 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	conf.GCDiskUsageThreshold = agentConfig.Client.GCDiskUsageThreshold
 	conf.GCInodeUsageThreshold = agentConfig.Client.GCInodeUsageThreshold
 	conf.GCMaxAllocs = agentConfig.Client.GCMaxAllocs
+
+	if agentConfig.Client.StateSnapshotInterval != 0 {
+		conf.StateSnapshotInterval = agentConfig.Client.StateSnapshotInterval
+	}
 	if agentConfig.Client.NoHostUUID != nil {
 		conf.NoHostUUID = *agentConfig.Client.NoHostUUID
 	} else {
@@ -759,6 +850,12 @@ func (a *Agent) setupServer() error {
 		return fmt.Errorf("setting up server node ID failed: %s", err)
 	}
 
+	// Fetch the keys stored in cloud secret managers before setting up the
+	// keyrings
+	if err := a.setupCloudSecrets(conf); err != nil {
+		return fmt.Errorf("failed to fetch secrets: %v", err)
+	}
+
 	// Sets up the keyring for gossip encryption
 	if err := a.setupKeyrings(conf); err != nil {
 		return fmt.Errorf("failed to configure keyring: %v", err)
@@ -1040,6 +1137,14 @@ func (a *Agent) Leave() error {
 	return nil
 }
 
+// requestShutdown asks the agent command to gracefully shut down the agent.
+func (a *Agent) requestShutdown() {
+	a.shutdownRequestOnce.Do(func() {
+		a.logger.Info("shutdown requested through the API")
+		close(a.shutdownRequestCh)
+	})
+}
+
 // Shutdown is used to terminate the agent.
 func (a *Agent) Shutdown() error {
 	a.shutdownLock.Lock()
@@ -801,9 +801,10 @@ func (s *HTTPServer) getScheduleWorkersConfig(resp http.ResponseWriter, req *htt
 
 	config := srv.GetSchedulerWorkerConfig()
 	response := &api.AgentSchedulerWorkerConfigResponse{
-		ServerID:          srv.LocalMember().Name,
-		NumSchedulers:     config.NumSchedulers,
-		EnabledSchedulers: config.EnabledSchedulers,
+		ServerID:            srv.LocalMember().Name,
+		NumSchedulers:       config.NumSchedulers,
+		EnabledSchedulers:   config.EnabledSchedulers,
+		DedicatedSchedulers: config.DedicatedSchedulers,
 	}
 
 	return response, nil
@@ -833,8 +834,9 @@ func (s *HTTPServer) updateScheduleWorkersConfig(resp http.ResponseWriter, req *
 	// the server_id provided in the payload is ignored to allow the
 	// response to be roundtripped right into a PUT.
 	newArgs := nomad.SchedulerWorkerPoolArgs{
-		NumSchedulers:     args.NumSchedulers,
-		EnabledSchedulers: args.EnabledSchedulers,
+		NumSchedulers:       args.NumSchedulers,
+		EnabledSchedulers:   args.EnabledSchedulers,
+		DedicatedSchedulers: args.DedicatedSchedulers,
 	}
 	if newArgs.IsInvalid() {
 		return nil, CodedError(http.StatusBadRequest, "Invalid request")
@@ -842,9 +844,10 @@ func (s *HTTPServer) updateScheduleWorkersConfig(resp http.ResponseWriter, req *
 	reply := srv.SetSchedulerWorkerConfig(newArgs)
 
 	response := &api.AgentSchedulerWorkerConfigResponse{
-		ServerID:          srv.LocalMember().Name,
-		NumSchedulers:     reply.NumSchedulers,
-		EnabledSchedulers: reply.EnabledSchedulers,
+		ServerID:            srv.LocalMember().Name,
+		NumSchedulers:       reply.NumSchedulers,
+		EnabledSchedulers:   reply.EnabledSchedulers,
+		DedicatedSchedulers: reply.DedicatedSchedulers,
 	}
 
 	return response, nil
@@ -137,6 +137,11 @@ func TestAgent_ServerConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, float64(11.0), out.MaxHeartbeatsPerSecond)
 
+	conf.Server.MaxReconnectsPerSecond = 22.0
+	out, err = a.serverConfig()
+	require.NoError(t, err)
+	require.Equal(t, float64(22.0), out.MaxReconnectsPerSecond)
+
 	conf.Server.FailoverHeartbeatTTL = 337 * time.Second
 	out, err = a.serverConfig()
 	require.NoError(t, err)
@@ -243,6 +248,28 @@ func TestAgent_ServerConfig_SchedulerFlags(t *testing.T) {
 	}
 }
 
+func TestAgent_ServerConfig_DedicatedSchedulers(t *testing.T) {
+	ci.Parallel(t)
+
+	conf := DefaultConfig()
+	conf.AdvertiseAddrs.Serf = "127.0.0.1:4000"
+	conf.AdvertiseAddrs.RPC = "127.0.0.1:4001"
+	conf.AdvertiseAddrs.HTTP = "10.10.11.1:4005"
+	require.NoError(t, conf.normalizeAddrs())
+	conf.Server.NumSchedulers = pointer.Of(2)
+	a := &Agent{config: conf}
+
+	conf.Server.DedicatedSchedulers = map[string]int{"service": 1}
+	out, err := a.serverConfig()
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"service": 1}, out.DedicatedSchedulers)
+
+	// At least one shared scheduler must be left for core jobs
+	conf.Server.DedicatedSchedulers = map[string]int{"service": 1, "batch": 1}
+	_, err = a.serverConfig()
+	require.ErrorContains(t, err, "must be less than num_schedulers 2")
+}
+
 // TestAgent_ServerConfig_Limits_Errors asserts invalid Limits configurations
 // cause errors. This is the server-only (RPC) counterpart to
 // TestHTTPServer_Limits_Error.
@@ -444,6 +471,132 @@ func TestAgent_ServerConfig_PlanRejectionTracker(t *testing.T) {
 	}
 }
 
+func TestAgent_ServerConfig_PlanBackpressure(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name            string
+		config          *PlanBackpressure
+		expectedEnabled bool
+		expectedDepth   int
+		expectedLatency time.Duration
+		expectedErr     string
+	}{
+		{
+			name:            "default",
+			expectedDepth:   1024,
+			expectedLatency: 5 * time.Second,
+		},
+		{
+			name: "valid config",
+			config: &PlanBackpressure{
+				Enabled:         pointer.Of(true),
+				MaxQueueDepth:   64,
+				MaxApplyLatency: time.Second,
+			},
+			expectedEnabled: true,
+			expectedDepth:   64,
+			expectedLatency: time.Second,
+		},
+		{
+			name: "invalid queue depth",
+			config: &PlanBackpressure{
+				MaxQueueDepth: -1,
+			},
+			expectedErr: "max_queue_depth cannot be negative",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := DevConfig(nil)
+			require.NoError(t, config.normalizeAddrs())
+			config.Server.PlanBackpressure = tc.config
+
+			serverConfig, err := convertServerConfig(config)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedEnabled, serverConfig.PlanBackpressureEnabled)
+			require.Equal(t, tc.expectedDepth, serverConfig.PlanBackpressureMaxQueueDepth)
+			require.Equal(t, tc.expectedLatency, serverConfig.PlanBackpressureMaxApplyLatency)
+		})
+	}
+}
+
+func TestAgent_ServerConfig_Keyring(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name               string
+		config             *KeyringConfig
+		expectedRotation   time.Duration
+		expectedPrepublish time.Duration
+		expectedErr        string
+	}{
+		{
+			name:             "default",
+			expectedRotation: 720 * time.Hour,
+		},
+		{
+			name: "valid config",
+			config: &KeyringConfig{
+				RotationInterval:    24 * time.Hour,
+				RotationIntervalHCL: "24h",
+				PrepublishPeriod:    time.Hour,
+				PrepublishPeriodHCL: "1h",
+			},
+			expectedRotation:   24 * time.Hour,
+			expectedPrepublish: time.Hour,
+		},
+		{
+			name: "rotation disabled",
+			config: &KeyringConfig{
+				RotationInterval:    0,
+				RotationIntervalHCL: "0s",
+			},
+			expectedRotation: 0,
+		},
+		{
+			name: "prepublish period too long",
+			config: &KeyringConfig{
+				RotationInterval:    time.Hour,
+				RotationIntervalHCL: "1h",
+				PrepublishPeriod:    time.Hour,
+				PrepublishPeriodHCL: "1h",
+			},
+			expectedErr: "prepublish_period must be less than keyring.rotation_interval",
+		},
+		{
+			name: "invalid rotation interval",
+			config: &KeyringConfig{
+				RotationInterval:    -time.Hour,
+				RotationIntervalHCL: "-1h",
+			},
+			expectedErr: "rotation_interval cannot be negative",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := DevConfig(nil)
+			require.NoError(t, config.normalizeAddrs())
+			config.Server.Keyring = tc.config
+
+			serverConfig, err := convertServerConfig(config)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedRotation, serverConfig.RootKeyRotationThreshold)
+			require.Equal(t, tc.expectedPrepublish, serverConfig.RootKeyPrepublishPeriod)
+		})
+	}
+}
+
 func TestAgent_ServerConfig_RaftMultiplier_Ok(t *testing.T) {
 	ci.Parallel(t)
 
@@ -660,6 +813,7 @@ func TestAgent_Client_TelemetryConfiguration(t *testing.T) {
 	assert.Equal(c.StatsCollectionInterval, telemetry.collectionInterval)
 	assert.Equal(c.PublishNodeMetrics, telemetry.PublishNodeMetrics)
 	assert.Equal(c.PublishAllocationMetrics, telemetry.PublishAllocationMetrics)
+	assert.Equal(c.CollectHostSystemMetrics, telemetry.CollectHostSystemMetrics)
 }
 
 // TestAgent_HTTPCheck asserts Agent.agentHTTPCheck properly alters the HTTP
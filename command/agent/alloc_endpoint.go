@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/golang/snappy"
 	"github.com/gorilla/websocket"
@@ -33,7 +34,7 @@ func (s *HTTPServer) AllocsRequest(resp http.ResponseWriter, req *http.Request)
 		return nil, nil
 	}
 
-	// Parse resources and task_states field selection
+	// Parse resources, task_states and reschedule_chain field selection
 	resources, err := parseBool(req, "resources")
 	if err != nil {
 		return nil, err
@@ -42,8 +43,12 @@ func (s *HTTPServer) AllocsRequest(resp http.ResponseWriter, req *http.Request)
 	if err != nil {
 		return nil, err
 	}
+	rescheduleChain, err := parseBool(req, "reschedule_chain")
+	if err != nil {
+		return nil, err
+	}
 
-	if resources != nil || taskStates != nil {
+	if resources != nil || taskStates != nil || rescheduleChain != nil {
 		args.Fields = structs.NewAllocStubFields()
 		if resources != nil {
 			args.Fields.Resources = *resources
@@ -51,6 +56,20 @@ func (s *HTTPServer) AllocsRequest(resp http.ResponseWriter, req *http.Request)
 		if taskStates != nil {
 			args.Fields.TaskStates = *taskStates
 		}
+		if rescheduleChain != nil {
+			args.Fields.RescheduleChain = *rescheduleChain
+		}
+	}
+
+	query := req.URL.Query()
+	args.FilterClientStatus = query.Get("client_status")
+	args.FilterNodeID = query.Get("node_id")
+	if v := query.Get("min_time_in_status"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, CodedError(http.StatusBadRequest, fmt.Sprintf("Invalid min_time_in_status: %v", err))
+		}
+		args.FilterMinTimeInStatus = d
 	}
 
 	var out structs.AllocListResponse
@@ -88,6 +107,8 @@ func (s *HTTPServer) AllocSpecificRequest(resp http.ResponseWriter, req *http.Re
 		return s.allocChecks(allocID, resp, req)
 	case "stop":
 		return s.allocStop(allocID, resp, req)
+	case "reconnect":
+		return s.allocReconnect(allocID, resp, req)
 	case "services":
 		return s.allocServiceRegistrations(resp, req, allocID)
 	}
@@ -171,6 +192,40 @@ func (s *HTTPServer) allocStop(allocID string, resp http.ResponseWriter, req *ht
 	return &out, nil
 }
 
+func (s *HTTPServer) allocReconnect(allocID string, resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if !(req.Method == "POST" || req.Method == "PUT") {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	reject := false
+	if rejectQS := req.URL.Query().Get("reject"); rejectQS != "" {
+		var err error
+		reject, err = strconv.ParseBool(rejectQS)
+		if err != nil {
+			return nil, CodedError(400, fmt.Sprintf("reject value is not a boolean: %v", err))
+		}
+	}
+
+	rr := &structs.AllocReconnectRequest{
+		AllocID: allocID,
+		Reject:  reject,
+	}
+	s.parseWriteRequest(req, &rr.WriteRequest)
+
+	var out structs.AllocReconnectResponse
+	rpcErr := s.agent.RPC("Alloc.Reconnect", &rr, &out)
+
+	if rpcErr != nil {
+		if structs.IsErrUnknownAllocation(rpcErr) {
+			rpcErr = CodedError(404, allocNotFoundErr)
+		}
+		return nil, rpcErr
+	}
+
+	setIndex(resp, out.Index)
+	return &out, nil
+}
+
 // allocServiceRegistrations returns a list of all service registrations
 // assigned to the job identifier. It is callable via the
 // /v1/allocation/:alloc_id/services HTTP API and uses the
@@ -496,11 +551,30 @@ func (s *HTTPServer) allocExec(allocID string, resp http.ResponseWriter, req *ht
 		}
 	}
 
+	resumable := false
+	if r := req.URL.Query().Get("resumable"); r != "" {
+		resumable, err = strconv.ParseBool(r)
+		if err != nil {
+			return nil, fmt.Errorf("resumable value is not a boolean: %v", err)
+		}
+	}
+
+	var offset int64
+	if o := req.URL.Query().Get("offset"); o != "" {
+		offset, err = strconv.ParseInt(o, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse offset: %v", err)
+		}
+	}
+
 	args := cstructs.AllocExecRequest{
-		AllocID: allocID,
-		Task:    task,
-		Cmd:     command,
-		Tty:     ttyB,
+		AllocID:      allocID,
+		Task:         task,
+		Cmd:          command,
+		Tty:          ttyB,
+		Resumable:    resumable,
+		SessionToken: req.URL.Query().Get("session_token"),
+		Offset:       offset,
 	}
 	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
 
@@ -598,7 +672,12 @@ func (s *HTTPServer) execStreamImpl(ws *websocket.Conn, args *cstructs.AllocExec
 			return
 		}
 
-		go forwardExecInput(encoder, ws, errCh)
+		// Closing the pipe once the websocket is lost lets the client
+		// detach the session without waiting for more output.
+		go func() {
+			forwardExecInput(encoder, ws, errCh)
+			cancel()
+		}()
 
 		for {
 			var res cstructs.StreamErrWrapper
@@ -86,6 +86,36 @@ func TestHTTP_AllocsList(t *testing.T) {
 	})
 }
 
+func TestHTTP_AllocsList_StatusFilters(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		state := s.Agent.server.State()
+		alloc1 := mock.Alloc()
+		alloc1.ClientStatus = structs.AllocClientStatusUnknown
+		alloc2 := mock.Alloc()
+		require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc1, alloc2}))
+
+		req, err := http.NewRequest("GET", "/v1/allocations?client_status=unknown&node_id="+alloc1.NodeID[:8]+"&min_time_in_status=0s", nil)
+		require.NoError(t, err)
+		obj, err := s.Server.AllocsRequest(httptest.NewRecorder(), req)
+		require.NoError(t, err)
+		allocs := obj.([]*structs.AllocListStub)
+		require.Len(t, allocs, 1)
+		require.Equal(t, alloc1.ID, allocs[0].ID)
+
+		// Invalid filters are rejected
+		req, err = http.NewRequest("GET", "/v1/allocations?min_time_in_status=soon", nil)
+		require.NoError(t, err)
+		_, err = s.Server.AllocsRequest(httptest.NewRecorder(), req)
+		require.ErrorContains(t, err, "Invalid min_time_in_status")
+
+		req, err = http.NewRequest("GET", "/v1/allocations?client_status=stuck", nil)
+		require.NoError(t, err)
+		_, err = s.Server.AllocsRequest(httptest.NewRecorder(), req)
+		require.ErrorContains(t, err, `invalid client status filter "stuck"`)
+	})
+}
+
 func TestHTTP_AllocsPrefixList(t *testing.T) {
 	ci.Parallel(t)
 	httpTest(t, nil, func(s *TestAgent) {
@@ -0,0 +1,164 @@
+package agent
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// clientShutdownDefaultDeadline is the drain deadline of the client
+	// shutdown requests which don't set one, matching the node drain command.
+	clientShutdownDefaultDeadline = time.Hour
+
+	// clientShutdownGracePeriod is how long after the drain deadline the
+	// client waits for the drain to complete and its allocations to stop
+	// before shutting down anyway.
+	clientShutdownGracePeriod = time.Minute
+
+	// clientShutdownPollInterval is how often the client checks whether its
+	// stopped allocations exited.
+	clientShutdownPollInterval = time.Second
+)
+
+// ClientShutdownRequest drains the local client node and shuts down the agent
+// once its allocations migrated or the drain deadline passed. The request
+// blocks until the drain is over, and the agent shuts down after responding.
+func (s *HTTPServer) ClientShutdownRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "PUT" && req.Method != "POST" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	client := s.agent.Client()
+	if client == nil {
+		return nil, CodedError(400, "Agent is not running a client")
+	}
+
+	// Shutting down the agent requires agent write and draining the node
+	// requires node write, which is also checked by the servers.
+	aclObj, err := s.ResolveToken(req)
+	if err != nil {
+		return nil, err
+	}
+	if aclObj != nil && !(aclObj.AllowAgentWrite() && aclObj.AllowNodeWrite()) {
+		return nil, structs.ErrPermissionDenied
+	}
+
+	var shutdownReq api.ClientShutdownRequest
+	if req.Body != nil && req.Body != http.NoBody {
+		if err := decodeBody(req, &shutdownReq); err != nil {
+			return nil, CodedError(400, err.Error())
+		}
+	}
+	if shutdownReq.Deadline < 0 {
+		return nil, CodedError(400, "deadline must be non-negative")
+	}
+	if shutdownReq.Deadline == 0 {
+		shutdownReq.Deadline = clientShutdownDefaultDeadline
+	}
+
+	if !s.agent.clientShutdownStarted.CompareAndSwap(false, true) {
+		return nil, CodedError(409, "Client shutdown already in progress")
+	}
+
+	nodeID := client.NodeID()
+	args := structs.NodeUpdateDrainRequest{
+		NodeID: nodeID,
+		DrainStrategy: &structs.DrainStrategy{
+			DrainSpec: structs.DrainSpec{
+				Deadline:         shutdownReq.Deadline,
+				IgnoreSystemJobs: shutdownReq.IgnoreSystemJobs,
+			},
+		},
+		Meta: map[string]string{"message": "client shutdown requested"},
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.NodeDrainUpdateResponse
+	if err := client.RPC("Node.UpdateDrain", &args, &out); err != nil {
+		// Allow retrying requests which failed before draining
+		s.agent.clientShutdownStarted.Store(false)
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+
+	s.logger.Info("draining node before shutting down", "node_id", nodeID, "deadline", shutdownReq.Deadline)
+	deadline := time.Now().Add(shutdownReq.Deadline + clientShutdownGracePeriod)
+	complete := s.waitForClientDrain(&args.WriteRequest, nodeID, out.Index, deadline)
+	if !complete {
+		s.logger.Warn("node drain did not complete before shutting down", "node_id", nodeID)
+	}
+
+	// Shut down once the response is sent
+	go func() {
+		<-req.Context().Done()
+		s.agent.requestShutdown()
+	}()
+
+	return &api.ClientShutdownResponse{
+		NodeID:        nodeID,
+		DrainComplete: complete,
+	}, nil
+}
+
+// waitForClientDrain blocks until the drain of the local node completed and
+// the allocations it stopped exited, and returns whether it happened before
+// the deadline.
+func (s *HTTPServer) waitForClientDrain(wr *structs.WriteRequest, nodeID string, index uint64, deadline time.Time) bool {
+	client := s.agent.Client()
+
+	// The drain strategy is removed from the node once all its allocations
+	// were migrated or stopped at the drain deadline.
+	for {
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			return false
+		}
+		if wait > structs.MaxBlockingRPCQueryTime {
+			wait = structs.MaxBlockingRPCQueryTime
+		}
+
+		args := structs.NodeSpecificRequest{
+			NodeID: nodeID,
+			QueryOptions: structs.QueryOptions{
+				Region:        wr.Region,
+				AuthToken:     wr.AuthToken,
+				MinQueryIndex: index,
+				MaxQueryTime:  wait,
+				AllowStale:    true,
+			},
+		}
+		var out structs.SingleNodeResponse
+		if err := client.RPC("Node.GetNode", &args, &out); err != nil {
+			s.logger.Warn("failed to check the node drain status", "node_id", nodeID, "error", err)
+			select {
+			case <-time.After(clientShutdownPollInterval):
+			case <-s.agent.shutdownCh:
+				return false
+			}
+			continue
+		}
+		if out.Node != nil && out.Node.DrainStrategy == nil {
+			break
+		}
+		if out.Index > index {
+			index = out.Index
+		}
+	}
+
+	ticker := time.NewTicker(clientShutdownPollInterval)
+	defer ticker.Stop()
+	for client.NumStoppingAllocs() > 0 {
+		if time.Now().After(deadline) {
+			return false
+		}
+		select {
+		case <-ticker.C:
+		case <-s.agent.shutdownCh:
+			return false
+		}
+	}
+	return true
+}
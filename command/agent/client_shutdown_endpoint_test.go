@@ -0,0 +1,88 @@
+package agent
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_ClientShutdown(t *testing.T) {
+	ci.Parallel(t)
+
+	httpACLTest(t, nil, func(s *TestAgent) {
+		state := s.Agent.server.State()
+		nodeID := s.client.NodeID()
+		testutil.WaitForResult(func() (bool, error) {
+			node, err := state.NodeByID(nil, nodeID)
+			return node != nil && node.Status == structs.NodeStatusReady, err
+		}, func(err error) {
+			t.Fatalf("node not ready: %v", err)
+		})
+
+		newRequest := func(token *structs.ACLToken) (*http.Request, context.CancelFunc) {
+			body := strings.NewReader(`{"Deadline": 60000000000}`)
+			req, err := http.NewRequest("PUT", "/v1/client/shutdown", body)
+			require.NoError(t, err)
+			if token != nil {
+				setToken(req, token)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			return req.WithContext(ctx), cancel
+		}
+
+		// Both agent and node write are required
+		agentToken := mock.CreatePolicyAndToken(t, state, 1005, "agent",
+			mock.AgentPolicy(acl.PolicyWrite))
+		req, cancel := newRequest(agentToken)
+		defer cancel()
+		_, err := s.Server.ClientShutdownRequest(httptest.NewRecorder(), req)
+		require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+		token := mock.CreatePolicyAndToken(t, state, 1007, "valid",
+			mock.AgentPolicy(acl.PolicyWrite)+mock.NodePolicy(acl.PolicyWrite))
+		req, cancel = newRequest(token)
+		defer cancel()
+		obj, err := s.Server.ClientShutdownRequest(httptest.NewRecorder(), req)
+		require.NoError(t, err)
+		require.Equal(t, &api.ClientShutdownResponse{
+			NodeID:        nodeID,
+			DrainComplete: true,
+		}, obj)
+
+		node, err := state.NodeByID(nil, nodeID)
+		require.NoError(t, err)
+		require.Nil(t, node.DrainStrategy)
+		require.Equal(t, structs.NodeSchedulingIneligible, node.SchedulingEligibility)
+
+		// Concurrent requests are rejected
+		req2, cancel2 := newRequest(token)
+		defer cancel2()
+		_, err = s.Server.ClientShutdownRequest(httptest.NewRecorder(), req2)
+		require.Error(t, err)
+		require.Equal(t, 409, err.(HTTPCodedError).Code())
+
+		// The agent shuts down once the response is sent
+		select {
+		case <-s.Agent.shutdownRequestCh:
+			t.Fatal("shutdown requested before the response was sent")
+		case <-time.After(100 * time.Millisecond):
+		}
+		cancel()
+		select {
+		case <-s.Agent.shutdownRequestCh:
+		case <-time.After(5 * time.Second):
+			t.Fatal("shutdown not requested")
+		}
+	})
+}
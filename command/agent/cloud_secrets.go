@@ -0,0 +1,154 @@
+package agent
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/hashicorp/nomad/nomad"
+	"google.golang.org/api/secretmanager/v1"
+)
+
+const (
+	// cloudSecretProviderAWS fetches secrets from AWS Secrets Manager.
+	cloudSecretProviderAWS = "aws-secretsmanager"
+
+	// cloudSecretProviderGCP fetches secrets from GCP Secret Manager.
+	cloudSecretProviderGCP = "gcp-secretmanager"
+
+	// cloudSecretTimeout is the time allowed to fetch a secret at startup.
+	cloudSecretTimeout = 30 * time.Second
+
+	// rootKeySize is the size of the key material of root keys, which use
+	// the AES-256-GCM algorithm.
+	rootKeySize = 32
+)
+
+// cloudSecretFetcher returns the value of a secret stored in a cloud secret
+// manager.
+type cloudSecretFetcher func(ctx context.Context, secret *CloudSecret) (string, error)
+
+// cloudSecretProviders are the cloud secret managers supported by the
+// encrypt_secret and root_key_secret server options. Tests replace them with
+// fakes.
+var cloudSecretProviders = map[string]cloudSecretFetcher{
+	cloudSecretProviderAWS: fetchAWSSecret,
+	cloudSecretProviderGCP: fetchGCPSecret,
+}
+
+// setupCloudSecrets fetches the gossip encryption key and the initial root
+// key stored in cloud secret managers. The secrets are expected to hold base64
+// encoded keys, like the encrypt option.
+func (a *Agent) setupCloudSecrets(conf *nomad.Config) error {
+	if secret := a.config.Server.EncryptSecret; secret != nil {
+		key, err := fetchCloudSecret(secret)
+		if err != nil {
+			return fmt.Errorf("failed to fetch encrypt_secret: %v", err)
+		}
+		if _, err := base64.StdEncoding.DecodeString(key); err != nil {
+			return fmt.Errorf("invalid encryption key in encrypt_secret: %v", err)
+		}
+		a.config.Server.EncryptKey = key
+	}
+
+	if secret := a.config.Server.RootKeySecret; secret != nil {
+		encoded, err := fetchCloudSecret(secret)
+		if err != nil {
+			return fmt.Errorf("failed to fetch root_key_secret: %v", err)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return fmt.Errorf("invalid root key in root_key_secret: %v", err)
+		}
+		if len(key) != rootKeySize {
+			return fmt.Errorf("invalid root key in root_key_secret: must be %d bytes", rootKeySize)
+		}
+		conf.InitialRootKey = key
+	}
+	return nil
+}
+
+// fetchCloudSecret returns the value of the secret, without surrounding
+// whitespace.
+func fetchCloudSecret(secret *CloudSecret) (string, error) {
+	if err := secret.Validate(); err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cloudSecretTimeout)
+	defer cancel()
+
+	value, err := cloudSecretProviders[secret.Provider](ctx, secret)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(value), nil
+}
+
+// fetchAWSSecret reads a secret from AWS Secrets Manager, using the
+// credentials of the AWS environment. Binary secrets are returned base64
+// encoded.
+func fetchAWSSecret(ctx context.Context, secret *CloudSecret) (string, error) {
+	config := aws.NewConfig()
+	if secret.Region != "" {
+		config = config.WithRegion(secret.Region)
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *config,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create AWS session: %v", err)
+	}
+
+	input := &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secret.SecretID),
+	}
+	if secret.Version != "" {
+		input.VersionId = aws.String(secret.Version)
+	}
+
+	out, err := secretsmanager.New(sess).GetSecretValueWithContext(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	if out.SecretString != nil {
+		return *out.SecretString, nil
+	}
+	return base64.StdEncoding.EncodeToString(out.SecretBinary), nil
+}
+
+// fetchGCPSecret reads a secret version from GCP Secret Manager, using the
+// application default credentials.
+func fetchGCPSecret(ctx context.Context, secret *CloudSecret) (string, error) {
+	svc, err := secretmanager.NewService(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to create GCP Secret Manager client: %v", err)
+	}
+
+	version := secret.Version
+	if version == "" {
+		version = "latest"
+	}
+	name := fmt.Sprintf("%s/versions/%s", strings.TrimSuffix(secret.SecretID, "/"), version)
+
+	out, err := svc.Projects.Secrets.Versions.Access(name).Context(ctx).Do()
+	if err != nil {
+		return "", err
+	}
+	if out.Payload == nil {
+		return "", fmt.Errorf("secret %q has no payload", name)
+	}
+
+	// The API returns the payload base64 encoded
+	data, err := base64.StdEncoding.DecodeString(out.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode secret %q: %v", name, err)
+	}
+	return string(data), nil
+}
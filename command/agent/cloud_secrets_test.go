@@ -0,0 +1,88 @@
+package agent
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad"
+	"github.com/stretchr/testify/require"
+)
+
+// testCloudSecrets are the secrets returned by the "test" secret provider.
+var testCloudSecrets = map[string]string{
+	"gossip":     "  HS5lJ+XuTlYKWaeGYyG+/A==\n",
+	"root":       base64.StdEncoding.EncodeToString(make([]byte, rootKeySize)),
+	"short-root": base64.StdEncoding.EncodeToString(make([]byte, 16)),
+	"invalid":    "not base64!",
+}
+
+func init() {
+	cloudSecretProviders["test"] = func(_ context.Context, secret *CloudSecret) (string, error) {
+		value, ok := testCloudSecrets[secret.SecretID]
+		if !ok {
+			return "", fmt.Errorf("secret %q not found", secret.SecretID)
+		}
+		return value, nil
+	}
+}
+
+func TestAgent_SetupCloudSecrets(t *testing.T) {
+	ci.Parallel(t)
+
+	conf := DefaultConfig()
+	conf.Server.EncryptSecret = &CloudSecret{Provider: "test", SecretID: "gossip"}
+	conf.Server.RootKeySecret = &CloudSecret{Provider: "test", SecretID: "root"}
+	a := &Agent{config: conf}
+
+	serverConf := nomad.DefaultConfig()
+	require.NoError(t, a.setupCloudSecrets(serverConf))
+	require.Equal(t, "HS5lJ+XuTlYKWaeGYyG+/A==", conf.Server.EncryptKey)
+	require.Equal(t, make([]byte, rootKeySize), serverConf.InitialRootKey)
+}
+
+func TestAgent_SetupCloudSecrets_Invalid(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name          string
+		encryptSecret *CloudSecret
+		rootKeySecret *CloudSecret
+		expectedErr   string
+	}{
+		{
+			name:          "unknown provider",
+			encryptSecret: &CloudSecret{Provider: "vault", SecretID: "gossip"},
+			expectedErr:   `failed to fetch encrypt_secret: unknown secret provider "vault"`,
+		},
+		{
+			name:          "missing secret",
+			encryptSecret: &CloudSecret{Provider: "test", SecretID: "missing"},
+			expectedErr:   `failed to fetch encrypt_secret: secret "missing" not found`,
+		},
+		{
+			name:          "invalid gossip key",
+			encryptSecret: &CloudSecret{Provider: "test", SecretID: "invalid"},
+			expectedErr:   "invalid encryption key in encrypt_secret",
+		},
+		{
+			name:          "short root key",
+			rootKeySecret: &CloudSecret{Provider: "test", SecretID: "short-root"},
+			expectedErr:   "invalid root key in root_key_secret: must be 32 bytes",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			conf := DefaultConfig()
+			conf.Server.EncryptSecret = tc.encryptSecret
+			conf.Server.RootKeySecret = tc.rootKeySecret
+			a := &Agent{config: conf}
+
+			err := a.setupCloudSecrets(nomad.DefaultConfig())
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
@@ -329,6 +329,23 @@ func (c *Command) IsValidConfig(config, cmdConfig *Config) bool {
 		}
 	}
 
+	if config.Server.EncryptKey != "" && config.Server.EncryptSecret != nil {
+		c.Ui.Error("Only one of encrypt and encrypt_secret may be set")
+		return false
+	}
+	if secret := config.Server.EncryptSecret; secret != nil {
+		if err := secret.Validate(); err != nil {
+			c.Ui.Error(fmt.Sprintf("Invalid encrypt_secret: %s", err))
+			return false
+		}
+	}
+	if secret := config.Server.RootKeySecret; secret != nil {
+		if err := secret.Validate(); err != nil {
+			c.Ui.Error(fmt.Sprintf("Invalid root_key_secret: %s", err))
+			return false
+		}
+	}
+
 	if config.Server.EncryptKey != "" {
 		if _, err := config.Server.EncryptBytes(); err != nil {
 			c.Ui.Error(fmt.Sprintf("Invalid encryption key: %s", err))
@@ -385,6 +402,11 @@ func (c *Command) IsValidConfig(config, cmdConfig *Config) bool {
 		return false
 	}
 
+	if ratio := config.Client.MemoryOversubscriptionRatio; ratio != 0 && ratio < 1 {
+		c.Ui.Error(fmt.Sprintf("Invalid memory_oversubscription_ratio: %v must be at least 1", ratio))
+		return false
+	}
+
 	if config.Client.Reserved == nil {
 		// Coding error; should always be set by DefaultConfig()
 		c.Ui.Error("client.reserved must be initialized. Please report a bug.")
@@ -881,6 +903,7 @@ func (c *Command) handleSignals() int {
 	// Wait for a signal
 WAIT:
 	var sig os.Signal
+	var requested bool
 	select {
 	case s := <-signalCh:
 		sig = s
@@ -888,6 +911,8 @@ WAIT:
 		sig = os.Interrupt
 	case <-c.ShutdownCh:
 		sig = os.Interrupt
+	case <-c.agent.shutdownRequestCh:
+		requested = true
 	case <-c.retryJoinErrCh:
 		return 1
 	}
@@ -897,7 +922,11 @@ WAIT:
 		goto WAIT
 	}
 
-	c.Ui.Output(fmt.Sprintf("Caught signal: %v", sig))
+	if requested {
+		c.Ui.Output("Shutdown requested through the API")
+	} else {
+		c.Ui.Output(fmt.Sprintf("Caught signal: %v", sig))
+	}
 
 	// Check if this is a SIGHUP
 	if sig == syscall.SIGHUP {
@@ -905,8 +934,9 @@ WAIT:
 		goto WAIT
 	}
 
-	// Check if we should do a graceful leave
-	graceful := false
+	// Check if we should do a graceful leave. Shutdowns requested through
+	// the API always are.
+	graceful := requested
 	if sig == os.Interrupt && c.agent.GetConfig().LeaveOnInt {
 		graceful = true
 	} else if sig == syscall.SIGTERM && c.agent.GetConfig().LeaveOnTerm {
@@ -350,6 +350,16 @@ func TestIsValidConfig(t *testing.T) {
 			},
 			err: "and max",
 		},
+		{
+			name: "SmallMemoryOversubscriptionRatio",
+			conf: Config{
+				Client: &ClientConfig{
+					Enabled:                     true,
+					MemoryOversubscriptionRatio: 0.5,
+				},
+			},
+			err: "memory_oversubscription_ratio",
+		},
 		{
 			name: "DynamicPortOk",
 			conf: Config{
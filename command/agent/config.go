@@ -200,6 +200,10 @@ type ClientConfig struct {
 	// NodeClass is used to group the node by class
 	NodeClass string `hcl:"node_class"`
 
+	// DisconnectDomain identifies the failure domain of the node's network,
+	// such as its rack or switch
+	DisconnectDomain string `hcl:"disconnect_domain"`
+
 	// Options is used for configuration of nomad internals,
 	// like fingerprinters and drivers. The format is:
 	//
@@ -248,6 +252,11 @@ type ClientConfig struct {
 	// uses for allocations
 	MinDynamicPort int `hcl:"min_dynamic_port"`
 
+	// MemoryOversubscriptionRatio is the factor by which replacements for
+	// allocations on disconnected clients may exceed the node's memory
+	// capacity, when memory oversubscription is enabled in the scheduler
+	MemoryOversubscriptionRatio float64 `hcl:"memory_oversubscription_ratio"`
+
 	// Reserved is used to reserve resources from being used by Nomad. This can
 	// be used to target a certain utilization or to prevent Nomad from using a
 	// particular set of ports.
@@ -258,6 +267,11 @@ type ClientConfig struct {
 	GCInterval    time.Duration
 	GCIntervalHCL string `hcl:"gc_interval" json:"-"`
 
+	// StateSnapshotInterval is the time interval at which the client
+	// snapshots the state used to reattach to running tasks on restart
+	StateSnapshotInterval    time.Duration
+	StateSnapshotIntervalHCL string `hcl:"state_snapshot_interval" json:"-"`
+
 	// GCParallelDestroys is the number of parallel destroys the garbage
 	// collector will allow.
 	GCParallelDestroys int `hcl:"gc_parallel_destroys"`
@@ -281,6 +295,15 @@ type ClientConfig struct {
 	// DisableRemoteExec disables remote exec targeting tasks on this client
 	DisableRemoteExec bool `hcl:"disable_remote_exec"`
 
+	// StaleSecureVariables allows the client to serve the secure variables
+	// read through it from a local cache while it is disconnected from the
+	// servers
+	StaleSecureVariables bool `hcl:"stale_secure_variables"`
+
+	// SecureVariablesSocket enables the unix socket in the alloc dir on which
+	// tasks can subscribe to changes of the secure variables they can read
+	SecureVariablesSocket bool `hcl:"secure_variables_socket"`
+
 	// TemplateConfig includes configuration for template rendering
 	TemplateConfig *client.ClientTemplateConfig `hcl:"template"`
 
@@ -432,11 +455,21 @@ type ServerConfig struct {
 	// that the workers dequeue for processing.
 	EnabledSchedulers []string `hcl:"enabled_schedulers"`
 
+	// DedicatedSchedulers reserves a number of the scheduler workers for
+	// each listed scheduler type. Dedicated workers only dequeue evaluations
+	// of their type, so a flood of one type can't starve the others.
+	DedicatedSchedulers map[string]int `hcl:"dedicated_schedulers"`
+
 	// NodeGCThreshold controls how "old" a node must be to be collected by GC.
 	// Age is not the only requirement for a node to be GCed but the threshold
 	// can be used to filter by age.
 	NodeGCThreshold string `hcl:"node_gc_threshold"`
 
+	// NodePurgeThreshold controls how long a node must have been down before
+	// it is purged, even if its allocations haven't been marked terminal.
+	// Purging is disabled if unset.
+	NodePurgeThreshold string `hcl:"node_purge_threshold"`
+
 	// JobGCInterval controls how often we dispatch a job to GC jobs that are
 	// available for garbage collection.
 	JobGCInterval string `hcl:"job_gc_interval"`
@@ -494,6 +527,12 @@ type ServerConfig struct {
 	// to meet the target rate.
 	MaxHeartbeatsPerSecond float64 `hcl:"max_heartbeats_per_second"`
 
+	// MaxReconnectsPerSecond is the maximum rate at which nodes that were
+	// disconnected or down are allowed to reconnect. Nodes reconnecting
+	// beyond this rate are told to retry after a reconnect window. Zero
+	// disables the limit.
+	MaxReconnectsPerSecond float64 `hcl:"max_reconnects_per_second"`
+
 	// FailoverHeartbeatTTL is the TTL applied to heartbeats after
 	// a new leader is elected, since we no longer know the status
 	// of all the heartbeats.
@@ -543,6 +582,16 @@ type ServerConfig struct {
 	// Encryption key to use for the Serf communication
 	EncryptKey string `hcl:"encrypt" json:"-"`
 
+	// EncryptSecret references a cloud secret holding the encryption key to
+	// use for the Serf communication, fetched at startup instead of storing
+	// the key in the configuration.
+	EncryptSecret *CloudSecret `hcl:"encrypt_secret"`
+
+	// RootKeySecret references a cloud secret holding the key material of
+	// the initial root key of the secure variables keyring, used by the
+	// leader when it bootstraps the keyring.
+	RootKeySecret *CloudSecret `hcl:"root_key_secret"`
+
 	// ServerJoin contains information that is used to attempt to join servers
 	ServerJoin *ServerJoin `hcl:"server_join"`
 
@@ -555,6 +604,14 @@ type ServerConfig struct {
 	// detects potentially bad nodes.
 	PlanRejectionTracker *PlanRejectionTracker `hcl:"plan_rejection_tracker"`
 
+	// PlanBackpressure configures when the leader rejects plans because the
+	// plan applier is saturated.
+	PlanBackpressure *PlanBackpressure `hcl:"plan_backpressure"`
+
+	// Keyring configures the automatic rotation of the root keys of the
+	// secure variables keyring.
+	Keyring *KeyringConfig `hcl:"keyring"`
+
 	// EnableEventBroker configures whether this server's state store
 	// will generate events for its event stream.
 	EnableEventBroker *bool `hcl:"enable_event_broker"`
@@ -564,6 +621,14 @@ type ServerConfig struct {
 	// for the EventBufferSize is 1.
 	EventBufferSize *int `hcl:"event_buffer_size"`
 
+	// RequireMlock makes the server fail to load keyring keys whose
+	// material can't be locked into memory, instead of logging a warning.
+	RequireMlock bool `hcl:"require_mlock"`
+
+	// RequireJobSignatures makes the servers reject job submissions which
+	// aren't signed by a trusted job signing key.
+	RequireJobSignatures bool `hcl:"require_job_signatures"`
+
 	// LicensePath is the path to search for an enterprise license.
 	LicensePath string `hcl:"license_path"`
 
@@ -598,11 +663,16 @@ func (s *ServerConfig) Copy() *ServerConfig {
 	ns.RaftMultiplier = pointer.Copy(s.RaftMultiplier)
 	ns.NumSchedulers = pointer.Copy(s.NumSchedulers)
 	ns.EnabledSchedulers = slices.Clone(s.EnabledSchedulers)
+	ns.DedicatedSchedulers = helper.CopyMap(s.DedicatedSchedulers)
 	ns.StartJoin = slices.Clone(s.StartJoin)
 	ns.RetryJoin = slices.Clone(s.RetryJoin)
 	ns.ServerJoin = s.ServerJoin.Copy()
+	ns.EncryptSecret = s.EncryptSecret.Copy()
+	ns.RootKeySecret = s.RootKeySecret.Copy()
 	ns.DefaultSchedulerConfig = s.DefaultSchedulerConfig.Copy()
 	ns.PlanRejectionTracker = s.PlanRejectionTracker.Copy()
+	ns.PlanBackpressure = s.PlanBackpressure.Copy()
+	ns.Keyring = s.Keyring.Copy()
 	ns.EnableEventBroker = pointer.Copy(s.EnableEventBroker)
 	ns.EventBufferSize = pointer.Copy(s.EventBufferSize)
 	ns.licenseAdditionalPublicKeys = slices.Clone(s.licenseAdditionalPublicKeys)
@@ -690,6 +760,161 @@ func (p *PlanRejectionTracker) Merge(b *PlanRejectionTracker) *PlanRejectionTrac
 	return &result
 }
 
+// CloudSecret references a secret stored in a cloud secret manager, such as
+// AWS Secrets Manager or GCP Secret Manager.
+type CloudSecret struct {
+	// Provider is the secret manager storing the secret, either
+	// "aws-secretsmanager" or "gcp-secretmanager".
+	Provider string `hcl:"provider"`
+
+	// SecretID is the name or ARN of the AWS secret, or the resource name of
+	// the GCP secret in the "projects/<project>/secrets/<secret>" format.
+	SecretID string `hcl:"secret_id"`
+
+	// Version is the version of the secret to fetch. Defaults to the
+	// current version of AWS secrets and to the latest version of GCP
+	// secrets.
+	Version string `hcl:"version"`
+
+	// Region is the AWS region of the secret. Defaults to the region of the
+	// AWS environment.
+	Region string `hcl:"region"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+func (c *CloudSecret) Copy() *CloudSecret {
+	if c == nil {
+		return nil
+	}
+
+	nc := *c
+	nc.ExtraKeysHCL = slices.Clone(c.ExtraKeysHCL)
+	return &nc
+}
+
+// Validate returns an error if the secret reference is incomplete.
+func (c *CloudSecret) Validate() error {
+	if _, ok := cloudSecretProviders[c.Provider]; !ok {
+		return fmt.Errorf("unknown secret provider %q", c.Provider)
+	}
+	if c.SecretID == "" {
+		return fmt.Errorf("secret_id is required")
+	}
+	return nil
+}
+
+// PlanBackpressure is used in servers to configure when plans are rejected
+// because the plan applier can't keep up.
+type PlanBackpressure struct {
+	// Enabled controls if plans are rejected while the plan applier is
+	// saturated.
+	Enabled *bool `hcl:"enabled"`
+
+	// MaxQueueDepth is the plan queue depth at which the plan applier is
+	// considered saturated.
+	MaxQueueDepth int `hcl:"max_queue_depth"`
+
+	// MaxApplyLatency is the average raft apply latency of plans at which
+	// the plan applier is considered saturated.
+	MaxApplyLatency    time.Duration
+	MaxApplyLatencyHCL string `hcl:"max_apply_latency" json:"-"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+func (p *PlanBackpressure) Copy() *PlanBackpressure {
+	if p == nil {
+		return nil
+	}
+
+	np := *p
+	np.Enabled = pointer.Copy(p.Enabled)
+	np.ExtraKeysHCL = slices.Clone(p.ExtraKeysHCL)
+	return &np
+}
+
+func (p *PlanBackpressure) Merge(b *PlanBackpressure) *PlanBackpressure {
+	if p == nil {
+		return b
+	}
+
+	result := *p
+
+	if b == nil {
+		return &result
+	}
+
+	if b.Enabled != nil {
+		result.Enabled = b.Enabled
+	}
+
+	if b.MaxQueueDepth != 0 {
+		result.MaxQueueDepth = b.MaxQueueDepth
+	}
+
+	if b.MaxApplyLatency != 0 {
+		result.MaxApplyLatency = b.MaxApplyLatency
+	}
+	if b.MaxApplyLatencyHCL != "" {
+		result.MaxApplyLatencyHCL = b.MaxApplyLatencyHCL
+	}
+	return &result
+}
+
+// KeyringConfig is used in servers to configure the automatic rotation of
+// the root keys of the secure variables keyring.
+type KeyringConfig struct {
+	// RotationInterval is how old the active root key can be before it is
+	// rotated. Zero disables automatic rotation.
+	RotationInterval    time.Duration
+	RotationIntervalHCL string `hcl:"rotation_interval" json:"-"`
+
+	// PrepublishPeriod is how long before a rotation the next root key is
+	// prepublished to all servers. Zero disables prepublication.
+	PrepublishPeriod    time.Duration
+	PrepublishPeriodHCL string `hcl:"prepublish_period" json:"-"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+func (k *KeyringConfig) Copy() *KeyringConfig {
+	if k == nil {
+		return nil
+	}
+
+	nk := *k
+	nk.ExtraKeysHCL = slices.Clone(k.ExtraKeysHCL)
+	return &nk
+}
+
+func (k *KeyringConfig) Merge(b *KeyringConfig) *KeyringConfig {
+	if k == nil {
+		return b
+	}
+
+	result := *k
+
+	if b == nil {
+		return &result
+	}
+
+	// an explicit zero disables rotation or prepublication, so the HCL
+	// values tell whether the durations were set
+	if b.RotationIntervalHCL != "" {
+		result.RotationInterval = b.RotationInterval
+		result.RotationIntervalHCL = b.RotationIntervalHCL
+	}
+	if b.PrepublishPeriodHCL != "" {
+		result.PrepublishPeriod = b.PrepublishPeriod
+		result.PrepublishPeriodHCL = b.PrepublishPeriodHCL
+	}
+	return &result
+}
+
 // Search is used in servers to configure search API options.
 type Search struct {
 	// FuzzyEnabled toggles whether the FuzzySearch API is enabled. If not
@@ -820,6 +1045,10 @@ type Telemetry struct {
 	PublishAllocationMetrics bool          `hcl:"publish_allocation_metrics"`
 	PublishNodeMetrics       bool          `hcl:"publish_node_metrics"`
 
+	// CollectHostSystemMetrics enables the collection of host level capacity
+	// signals, such as the load, conntrack and socket counts, by the client.
+	CollectHostSystemMetrics bool `hcl:"collect_host_system_metrics"`
+
 	// PrefixFilter allows for filtering out metrics from being collected
 	PrefixFilter []string `hcl:"prefix_filter"`
 
@@ -1776,6 +2005,9 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	if b.NodeGCThreshold != "" {
 		result.NodeGCThreshold = b.NodeGCThreshold
 	}
+	if b.NodePurgeThreshold != "" {
+		result.NodePurgeThreshold = b.NodePurgeThreshold
+	}
 	if b.JobGCInterval != "" {
 		result.JobGCInterval = b.JobGCInterval
 	}
@@ -1818,6 +2050,9 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	if b.MaxHeartbeatsPerSecond != 0.0 {
 		result.MaxHeartbeatsPerSecond = b.MaxHeartbeatsPerSecond
 	}
+	if b.MaxReconnectsPerSecond != 0.0 {
+		result.MaxReconnectsPerSecond = b.MaxReconnectsPerSecond
+	}
 	if b.FailoverHeartbeatTTL != 0 {
 		result.FailoverHeartbeatTTL = b.FailoverHeartbeatTTL
 	}
@@ -1848,6 +2083,12 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	if b.EncryptKey != "" {
 		result.EncryptKey = b.EncryptKey
 	}
+	if b.EncryptSecret != nil {
+		result.EncryptSecret = b.EncryptSecret.Copy()
+	}
+	if b.RootKeySecret != nil {
+		result.RootKeySecret = b.RootKeySecret.Copy()
+	}
 	if b.ServerJoin != nil {
 		result.ServerJoin = result.ServerJoin.Merge(b.ServerJoin)
 	}
@@ -1859,6 +2100,14 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 		result.EnableEventBroker = b.EnableEventBroker
 	}
 
+	if b.RequireMlock {
+		result.RequireMlock = true
+	}
+
+	if b.RequireJobSignatures {
+		result.RequireJobSignatures = true
+	}
+
 	if b.EventBufferSize != nil {
 		result.EventBufferSize = b.EventBufferSize
 	}
@@ -1867,6 +2116,14 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 		result.PlanRejectionTracker = result.PlanRejectionTracker.Merge(b.PlanRejectionTracker)
 	}
 
+	if b.PlanBackpressure != nil {
+		result.PlanBackpressure = result.PlanBackpressure.Merge(b.PlanBackpressure)
+	}
+
+	if b.Keyring != nil {
+		result.Keyring = result.Keyring.Merge(b.Keyring)
+	}
+
 	if b.DefaultSchedulerConfig != nil {
 		c := *b.DefaultSchedulerConfig
 		result.DefaultSchedulerConfig = &c
@@ -1897,6 +2154,9 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 
 	// Add the schedulers
 	result.EnabledSchedulers = append(result.EnabledSchedulers, b.EnabledSchedulers...)
+	if len(b.DedicatedSchedulers) != 0 {
+		result.DedicatedSchedulers = helper.CopyMap(b.DedicatedSchedulers)
+	}
 
 	// Copy the start join addresses
 	result.StartJoin = make([]string, 0, len(s.StartJoin)+len(b.StartJoin))
@@ -1927,6 +2187,9 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	if b.NodeClass != "" {
 		result.NodeClass = b.NodeClass
 	}
+	if b.DisconnectDomain != "" {
+		result.DisconnectDomain = b.DisconnectDomain
+	}
 	if b.NetworkInterface != "" {
 		result.NetworkInterface = b.NetworkInterface
 	}
@@ -1954,6 +2217,9 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	if b.MinDynamicPort != 0 {
 		result.MinDynamicPort = b.MinDynamicPort
 	}
+	if b.MemoryOversubscriptionRatio != 0 {
+		result.MemoryOversubscriptionRatio = b.MemoryOversubscriptionRatio
+	}
 	if result.Reserved == nil && b.Reserved != nil {
 		reserved := *b.Reserved
 		result.Reserved = &reserved
@@ -1969,6 +2235,12 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	if b.GCIntervalHCL != "" {
 		result.GCIntervalHCL = b.GCIntervalHCL
 	}
+	if b.StateSnapshotInterval != 0 {
+		result.StateSnapshotInterval = b.StateSnapshotInterval
+	}
+	if b.StateSnapshotIntervalHCL != "" {
+		result.StateSnapshotIntervalHCL = b.StateSnapshotIntervalHCL
+	}
 	if b.GCParallelDestroys != 0 {
 		result.GCParallelDestroys = b.GCParallelDestroys
 	}
@@ -1990,6 +2262,14 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 		result.DisableRemoteExec = b.DisableRemoteExec
 	}
 
+	if b.StaleSecureVariables {
+		result.StaleSecureVariables = b.StaleSecureVariables
+	}
+
+	if b.SecureVariablesSocket {
+		result.SecureVariablesSocket = b.SecureVariablesSocket
+	}
+
 	if b.TemplateConfig != nil {
 		result.TemplateConfig = b.TemplateConfig
 	}
@@ -2104,6 +2384,9 @@ func (a *Telemetry) Merge(b *Telemetry) *Telemetry {
 	if b.PublishNodeMetrics {
 		result.PublishNodeMetrics = true
 	}
+	if b.CollectHostSystemMetrics {
+		result.CollectHostSystemMetrics = true
+	}
 	if b.PublishAllocationMetrics {
 		result.PublishAllocationMetrics = true
 	}
@@ -64,6 +64,7 @@ func ParseConfigFile(path string) (*Config, error) {
 	// convert strings to time.Durations
 	tds := []durationConversionMap{
 		{"gc_interval", &c.Client.GCInterval, &c.Client.GCIntervalHCL, nil},
+		{"client.state_snapshot_interval", &c.Client.StateSnapshotInterval, &c.Client.StateSnapshotIntervalHCL, nil},
 		{"acl.token_ttl", &c.ACL.TokenTTL, &c.ACL.TokenTTLHCL, nil},
 		{"acl.policy_ttl", &c.ACL.PolicyTTL, &c.ACL.PolicyTTLHCL, nil},
 		{"client.server_join.retry_interval", &c.Client.ServerJoin.RetryInterval, &c.Client.ServerJoin.RetryIntervalHCL, nil},
@@ -138,6 +139,21 @@ func ParseConfigFile(path string) (*Config, error) {
 		},
 	}
 
+	if pb := c.Server.PlanBackpressure; pb != nil {
+		tds = append(tds, durationConversionMap{
+			"server.plan_backpressure.max_apply_latency", &pb.MaxApplyLatency, &pb.MaxApplyLatencyHCL, nil})
+	}
+	if kc := c.Server.Keyring; kc != nil {
+		tds = append(tds,
+			durationConversionMap{"server.keyring.rotation_interval", &kc.RotationInterval, &kc.RotationIntervalHCL, nil},
+			durationConversionMap{"server.keyring.prepublish_period", &kc.PrepublishPeriod, &kc.PrepublishPeriodHCL, nil},
+		)
+	}
+	if sc := c.Server.DefaultSchedulerConfig; sc != nil {
+		tds = append(tds, durationConversionMap{
+			"server.default_scheduler_config.reconnect_stabilization", &sc.ReconnectStabilization, &sc.ReconnectStabilizationHCL, nil})
+	}
+
 	// Add enterprise audit sinks for time.Duration parsing
 	for i, sink := range c.Audit.Sinks {
 		tds = append(tds, durationConversionMap{
@@ -40,11 +40,12 @@ var basicConfig = &Config{
 		Serf: "127.0.0.4",
 	},
 	Client: &ClientConfig{
-		Enabled:   true,
-		StateDir:  "/tmp/client-state",
-		AllocDir:  "/tmp/alloc",
-		Servers:   []string{"a.b.c:80", "127.0.0.1:1234"},
-		NodeClass: "linux-medium-64bit",
+		Enabled:          true,
+		StateDir:         "/tmp/client-state",
+		AllocDir:         "/tmp/alloc",
+		Servers:          []string{"a.b.c:80", "127.0.0.1:1234"},
+		NodeClass:        "linux-medium-64bit",
+		DisconnectDomain: "rack-a",
 		ServerJoin: &ServerJoin{
 			RetryJoin:        []string{"1.1.1.1", "2.2.2.2"},
 			RetryInterval:    time.Duration(15) * time.Second,
@@ -76,14 +77,18 @@ var basicConfig = &Config{
 			DiskMB:        10,
 			ReservedPorts: "1,100,10-12",
 		},
-		GCInterval:            6 * time.Second,
-		GCIntervalHCL:         "6s",
-		GCParallelDestroys:    6,
-		GCDiskUsageThreshold:  82,
-		GCInodeUsageThreshold: 91,
-		GCMaxAllocs:           50,
-		NoHostUUID:            pointer.Of(false),
-		DisableRemoteExec:     true,
+		GCInterval:               6 * time.Second,
+		GCIntervalHCL:            "6s",
+		StateSnapshotInterval:    30 * time.Second,
+		StateSnapshotIntervalHCL: "30s",
+		GCParallelDestroys:       6,
+		GCDiskUsageThreshold:     82,
+		GCInodeUsageThreshold:    91,
+		GCMaxAllocs:              50,
+		NoHostUUID:               pointer.Of(false),
+		DisableRemoteExec:        true,
+		StaleSecureVariables:     true,
+		SecureVariablesSocket:    true,
 		HostVolumes: []*structs.ClientHostVolumeConfig{
 			{Name: "tmp", Path: "/tmp"},
 		},
@@ -100,7 +105,11 @@ var basicConfig = &Config{
 		RaftMultiplier:            pointer.Of(4),
 		NumSchedulers:             pointer.Of(2),
 		EnabledSchedulers:         []string{"test"},
+		DedicatedSchedulers:       map[string]int{"test": 1},
+		RequireMlock:              true,
+		RequireJobSignatures:      true,
 		NodeGCThreshold:           "12h",
+		NodePurgeThreshold:        "720h",
 		EvalGCThreshold:           "12h",
 		JobGCInterval:             "3m",
 		JobGCThreshold:            "12h",
@@ -112,6 +121,7 @@ var basicConfig = &Config{
 		MinHeartbeatTTL:           33 * time.Second,
 		MinHeartbeatTTLHCL:        "33s",
 		MaxHeartbeatsPerSecond:    11.0,
+		MaxReconnectsPerSecond:    22.0,
 		FailoverHeartbeatTTL:      330 * time.Second,
 		FailoverHeartbeatTTLHCL:   "330s",
 		RetryJoin:                 []string{"1.1.1.1", "2.2.2.2"},
@@ -132,6 +142,17 @@ var basicConfig = &Config{
 			NodeWindow:    41 * time.Minute,
 			NodeWindowHCL: "41m",
 		},
+		Keyring: &KeyringConfig{
+			RotationInterval:    240 * time.Hour,
+			RotationIntervalHCL: "240h",
+			PrepublishPeriod:    24 * time.Hour,
+			PrepublishPeriodHCL: "24h",
+		},
+		RootKeySecret: &CloudSecret{
+			Provider: "aws-secretsmanager",
+			SecretID: "nomad/root-key",
+			Region:   "us-east-1",
+		},
 		ServerJoin: &ServerJoin{
 			RetryJoin:        []string{"1.1.1.1", "2.2.2.2"},
 			RetryInterval:    time.Duration(15) * time.Second,
@@ -191,6 +212,7 @@ var basicConfig = &Config{
 		collectionInterval:       3 * time.Second,
 		PublishAllocationMetrics: true,
 		PublishNodeMetrics:       true,
+		CollectHostSystemMetrics: true,
 	},
 	LeaveOnInt:                true,
 	LeaveOnTerm:               true,
@@ -269,6 +269,7 @@ func TestConfig_Merge(t *testing.T) {
 			DisableHostname:                    true,
 			PublishNodeMetrics:                 true,
 			PublishAllocationMetrics:           true,
+			CollectHostSystemMetrics:           true,
 			CirconusAPIToken:                   "1",
 			CirconusAPIApp:                     "nomad",
 			CirconusAPIURL:                     "https://api.circonus.com/v2",
@@ -299,16 +300,17 @@ func TestConfig_Merge(t *testing.T) {
 				"foo": "bar",
 				"baz": "zip",
 			},
-			ChrootEnv:         map[string]string{},
-			ClientMaxPort:     20000,
-			ClientMinPort:     22000,
-			NetworkSpeed:      105,
-			CpuCompute:        105,
-			MinDynamicPort:    10002,
-			MaxDynamicPort:    10003,
-			MemoryMB:          105,
-			MaxKillTimeout:    "50s",
-			DisableRemoteExec: false,
+			ChrootEnv:                   map[string]string{},
+			ClientMaxPort:               20000,
+			ClientMinPort:               22000,
+			NetworkSpeed:                105,
+			CpuCompute:                  105,
+			MinDynamicPort:              10002,
+			MaxDynamicPort:              10003,
+			MemoryMB:                    105,
+			MemoryOversubscriptionRatio: 1.5,
+			MaxKillTimeout:              "50s",
+			DisableRemoteExec:           false,
 			TemplateConfig: &client.ClientTemplateConfig{
 				FunctionDenylist: client.DefaultTemplateFunctionDenylist,
 				DisableSandbox:   false,
@@ -339,6 +341,7 @@ func TestConfig_Merge(t *testing.T) {
 			HeartbeatGrace:         2 * time.Minute,
 			MinHeartbeatTTL:        2 * time.Minute,
 			MaxHeartbeatsPerSecond: 200.0,
+			MaxReconnectsPerSecond: 20.0,
 			RejoinAfterLeave:       true,
 			StartJoin:              []string{"1.1.1.1"},
 			RetryJoin:              []string{"1.1.1.1"},
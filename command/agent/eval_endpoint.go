@@ -83,6 +83,9 @@ func (s *HTTPServer) EvalSpecificRequest(resp http.ResponseWriter, req *http.Req
 	case strings.HasSuffix(path, "/allocations"):
 		evalID := strings.TrimSuffix(path, "/allocations")
 		return s.evalAllocations(resp, req, evalID)
+	case strings.HasSuffix(path, "/explanation"):
+		evalID := strings.TrimSuffix(path, "/explanation")
+		return s.evalExplanation(resp, req, evalID)
 	default:
 		return s.evalQuery(resp, req, path)
 	}
@@ -138,3 +141,39 @@ func (s *HTTPServer) evalQuery(resp http.ResponseWriter, req *http.Request, eval
 	}
 	return out.Eval, nil
 }
+
+// evalExplanation returns the explanation of the decisions the scheduler made
+// about the allocations of disconnected and reconnected clients while
+// processing the evaluation. The explanation is empty if there were no such
+// allocations.
+func (s *HTTPServer) evalExplanation(resp http.ResponseWriter, req *http.Request, evalID string) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.EvalSpecificRequest{
+		EvalID: evalID,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.SingleEvalResponse
+	if err := s.agent.RPC("Eval.GetEval", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Eval == nil {
+		return nil, CodedError(404, "eval not found")
+	}
+
+	explanation := out.Eval.Explanation
+	if explanation == nil {
+		explanation = &structs.EvalExplanation{}
+	}
+	if explanation.Allocations == nil {
+		explanation.Allocations = make([]*structs.AllocExplanation, 0)
+	}
+	return explanation, nil
+}
@@ -289,6 +289,47 @@ func TestHTTP_EvalAllocations(t *testing.T) {
 	})
 }
 
+func TestHTTP_EvalExplanation(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Directly manipulate the state
+		state := s.Agent.server.State()
+		eval := mock.Eval()
+		eval.Explanation = &structs.EvalExplanation{
+			Allocations: []*structs.AllocExplanation{{
+				AllocID:  uuid.Generate(),
+				Decision: structs.AllocDecisionWait,
+				Reason:   "client is still disconnected",
+			}},
+		}
+		other := mock.Eval()
+		err := state.UpsertEvals(structs.MsgTypeTestSetup, 1000, []*structs.Evaluation{eval, other})
+		require.NoError(t, err)
+
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/evaluation/"+eval.ID+"/explanation", nil)
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		obj, err := s.Server.EvalSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.NotEmpty(t, respW.Result().Header.Get("X-Nomad-Index"))
+		require.Equal(t, eval.Explanation, obj.(*structs.EvalExplanation))
+
+		// Evals without an explanation have an empty one
+		req, err = http.NewRequest("GET", "/v1/evaluation/"+other.ID+"/explanation", nil)
+		require.NoError(t, err)
+		obj, err = s.Server.EvalSpecificRequest(httptest.NewRecorder(), req)
+		require.NoError(t, err)
+		require.Empty(t, obj.(*structs.EvalExplanation).Allocations)
+
+		// Unknown evals are not found
+		req, err = http.NewRequest("GET", "/v1/evaluation/"+uuid.Generate()+"/explanation", nil)
+		require.NoError(t, err)
+		_, err = s.Server.EvalSpecificRequest(httptest.NewRecorder(), req)
+		require.EqualError(t, err, "eval not found")
+	})
+}
+
 func TestHTTP_EvalQuery(t *testing.T) {
 	ci.Parallel(t)
 	httpTest(t, nil, func(s *TestAgent) {
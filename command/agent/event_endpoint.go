@@ -41,6 +41,7 @@ func (s *HTTPServer) EventStream(resp http.ResponseWriter, req *http.Request) (i
 	args := &structs.EventStreamRequest{
 		Topics: topics,
 		Index:  index,
+		Filter: parseEventFilter(query),
 	}
 	resp.Header().Set("Content-Type", "application/json")
 	resp.Header().Set("Cache-Control", "no-cache")
@@ -148,6 +149,20 @@ func parseEventTopics(query url.Values) (map[structs.Topic][]string, error) {
 	return topics, nil
 }
 
+// parseEventFilter returns the server-side filter of the events, or nil if
+// none of its parameters are set.
+func parseEventFilter(query url.Values) *structs.EventFilter {
+	filter := &structs.EventFilter{
+		JobPrefix:    query.Get("job_prefix"),
+		NodeClass:    query.Get("node_class"),
+		VariablePath: query.Get("variable_path"),
+	}
+	if filter.IsEmpty() {
+		return nil
+	}
+	return filter
+}
+
 func parseTopic(topic string) (string, string, error) {
 	parts := strings.Split(topic, ":")
 	// infer wildcard if only given a topic
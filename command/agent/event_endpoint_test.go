@@ -202,3 +202,18 @@ func TestEventStream_QueryParse(t *testing.T) {
 		})
 	}
 }
+
+func TestEventStream_FilterParse(t *testing.T) {
+	ci.Parallel(t)
+
+	query, err := url.ParseQuery("topic=Job&job_prefix=web&variable_path=nomad%2Fjobs%2Fweb%2F*")
+	require.NoError(t, err)
+	require.Equal(t, &structs.EventFilter{
+		JobPrefix:    "web",
+		VariablePath: "nomad/jobs/web/*",
+	}, parseEventFilter(query))
+
+	query, err = url.ParseQuery("topic=Job")
+	require.NoError(t, err)
+	require.Nil(t, parseEventFilter(query))
+}
@@ -20,7 +20,7 @@ var (
 	allocIDNotPresentErr  = CodedError(400, "must provide a valid alloc id")
 	fileNameNotPresentErr = CodedError(400, "must provide a file name")
 	taskNotPresentErr     = CodedError(400, "must provide task name")
-	logTypeNotPresentErr  = CodedError(400, "must provide log type (stdout/stderr)")
+	logTypeNotPresentErr  = CodedError(400, "must provide log type (stdout/stderr/both)")
 	clientNotRunning      = CodedError(400, "node is not running a Nomad Client")
 	invalidOrigin         = CodedError(400, "origin must be start or end")
 )
@@ -63,6 +63,13 @@ func (s *HTTPServer) DirectoryListRequest(resp http.ResponseWriter, req *http.Re
 	args := &cstructs.FsListRequest{
 		AllocID: allocID,
 		Path:    path,
+		Glob:    req.URL.Query().Get("glob"),
+	}
+	if recursive := req.URL.Query().Get("recursive"); recursive != "" {
+		var err error
+		if args.Recursive, err = strconv.ParseBool(recursive); err != nil {
+			return nil, fmt.Errorf("failed to parse recursive field to boolean: %v", err)
+		}
 	}
 	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
 
@@ -127,6 +134,9 @@ func (s *HTTPServer) FileStatRequest(resp http.ResponseWriter, req *http.Request
 		return nil, rpcErr
 	}
 
+	// The index of a stat is the file's modification time so that callers
+	// can watch the file for changes with a blocking query.
+	setIndex(resp, reply.Index)
 	return reply.Info, nil
 }
 
@@ -256,7 +266,7 @@ func (s *HTTPServer) Stream(resp http.ResponseWriter, req *http.Request) (interf
 
 // Logs streams the content of a log blocking on EOF. The parameters are:
 //   - task: task name to stream logs for.
-//   - type: stdout/stderr to stream.
+//   - type: stdout/stderr to stream, or both to multiplex them on one stream.
 //   - follow: A boolean of whether to follow the logs.
 //   - offset: The offset to start streaming data at, defaults to zero.
 //   - origin: Either "start" or "end" and defines from where the offset is
@@ -289,7 +299,7 @@ func (s *HTTPServer) Logs(resp http.ResponseWriter, req *http.Request) (interfac
 
 	logType = q.Get("type")
 	switch logType {
-	case "stdout", "stderr":
+	case "stdout", "stderr", "both":
 	default:
 		return nil, logTypeNotPresentErr
 	}
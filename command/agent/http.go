@@ -353,6 +353,7 @@ func (s HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/job/", s.wrap(s.JobSpecificRequest))
 
 	s.mux.HandleFunc("/v1/nodes", s.wrap(s.NodesRequest))
+	s.mux.HandleFunc("/v1/nodes/purge", s.wrap(s.NodesPurgeRequest))
 	s.mux.HandleFunc("/v1/node/", s.wrap(s.NodeSpecificRequest))
 
 	s.mux.HandleFunc("/v1/allocations", s.wrap(s.AllocsRequest))
@@ -377,13 +378,16 @@ func (s HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/acl/token/onetime", s.wrap(s.UpsertOneTimeToken))
 	s.mux.HandleFunc("/v1/acl/token/onetime/exchange", s.wrap(s.ExchangeOneTimeToken))
 	s.mux.HandleFunc("/v1/acl/bootstrap", s.wrap(s.ACLTokenBootstrap))
+	s.mux.HandleFunc("/v1/acl/check", s.wrap(s.ACLCheckRequest))
 	s.mux.HandleFunc("/v1/acl/tokens", s.wrap(s.ACLTokensRequest))
 	s.mux.HandleFunc("/v1/acl/token", s.wrap(s.ACLTokenSpecificRequest))
 	s.mux.HandleFunc("/v1/acl/token/", s.wrap(s.ACLTokenSpecificRequest))
 
 	s.mux.Handle("/v1/client/fs/", wrapCORS(s.wrap(s.FsRequest)))
 	s.mux.HandleFunc("/v1/client/gc", s.wrap(s.ClientGCRequest))
+	s.mux.HandleFunc("/v1/client/shutdown", s.wrap(s.ClientShutdownRequest))
 	s.mux.Handle("/v1/client/stats", wrapCORS(s.wrap(s.ClientStatsRequest)))
+	s.mux.HandleFunc("/v1/client/metadata", s.wrap(s.ClientMetadataRequest))
 	s.mux.Handle("/v1/client/allocation/", wrapCORS(s.wrap(s.ClientAllocRequest)))
 
 	s.mux.HandleFunc("/v1/agent/self", s.wrap(s.AgentSelfRequest))
@@ -435,6 +439,7 @@ func (s HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/system/reconcile/summaries", s.wrap(s.ReconcileJobSummaries))
 
 	s.mux.HandleFunc("/v1/operator/scheduler/configuration", s.wrap(s.OperatorSchedulerConfiguration))
+	s.mux.HandleFunc("/v1/operator/scheduler/plan-queue", s.wrap(s.OperatorPlanQueueStats))
 
 	s.mux.HandleFunc("/v1/event/stream", s.wrap(s.EventStream))
 
@@ -443,6 +448,12 @@ func (s HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/namespace/", s.wrap(s.NamespaceSpecificRequest))
 
 	s.mux.Handle("/v1/vars", wrapCORS(s.wrap(s.SecureVariablesListRequest)))
+	s.mux.Handle("/v1/vars/search", wrapCORS(s.wrap(s.SecureVariablesSearchRequest)))
+	s.mux.Handle("/v1/vars/read", wrapCORS(s.wrap(s.SecureVariablesReadPrefixRequest)))
+	s.mux.Handle("/v1/vars/purge", wrapCORS(s.wrap(s.SecureVariablesPurgeRequest)))
+	s.mux.Handle("/v1/vars/txn", wrapCORS(s.wrap(s.SecureVariablesTxnRequest)))
+	s.mux.Handle("/v1/vars/replication", wrapCORS(s.wrap(s.SecureVariablesReplicationStatusRequest)))
+	s.mux.Handle("/v1/vars/identity/exchange", wrapCORS(s.wrap(s.SecureVariablesExchangeIdentityRequest)))
 	s.mux.Handle("/v1/var/", wrapCORSWithAllowedMethods(s.wrap(s.SecureVariableSpecificRequest), "HEAD", "GET", "PUT", "DELETE"))
 
 	uiConfigEnabled := s.agent.config.UI != nil && s.agent.config.UI.Enabled
@@ -726,12 +737,24 @@ func setNextToken(resp http.ResponseWriter, nextToken string) {
 	}
 }
 
+// setQueryCost is used to set the headers describing the server side cost
+// of a query
+func setQueryCost(resp http.ResponseWriter, m *structs.QueryMeta) {
+	queryUsec := uint64(m.QueryTime / time.Microsecond)
+	resp.Header().Set("X-Nomad-QueryTime", strconv.FormatUint(queryUsec, 10))
+	resp.Header().Set("X-Nomad-Forwarded", strconv.FormatBool(m.Forwarded))
+	if m.ObjectsScanned > 0 {
+		resp.Header().Set("X-Nomad-ObjectsScanned", strconv.Itoa(m.ObjectsScanned))
+	}
+}
+
 // setMeta is used to set the query response meta data
 func setMeta(resp http.ResponseWriter, m *structs.QueryMeta) {
 	setIndex(resp, m.Index)
 	setLastContact(resp, m.LastContact)
 	setKnownLeader(resp, m.KnownLeader)
 	setNextToken(resp, m.NextToken)
+	setQueryCost(resp, m)
 }
 
 // setHeaders is used to set canonical response header fields
@@ -194,9 +194,12 @@ func TestSetLastContact(t *testing.T) {
 func TestSetMeta(t *testing.T) {
 	ci.Parallel(t)
 	meta := structs.QueryMeta{
-		Index:       1000,
-		KnownLeader: true,
-		LastContact: 123456 * time.Microsecond,
+		Index:          1000,
+		KnownLeader:    true,
+		LastContact:    123456 * time.Microsecond,
+		QueryTime:      1500 * time.Microsecond,
+		ObjectsScanned: 42,
+		Forwarded:      true,
 	}
 	resp := httptest.NewRecorder()
 	setMeta(resp, &meta)
@@ -212,6 +215,18 @@ func TestSetMeta(t *testing.T) {
 	if header != "123" {
 		t.Fatalf("Bad: %v", header)
 	}
+	header = resp.Header().Get("X-Nomad-QueryTime")
+	if header != "1500" {
+		t.Fatalf("Bad: %v", header)
+	}
+	header = resp.Header().Get("X-Nomad-ObjectsScanned")
+	if header != "42" {
+		t.Fatalf("Bad: %v", header)
+	}
+	header = resp.Header().Get("X-Nomad-Forwarded")
+	if header != "true" {
+		t.Fatalf("Bad: %v", header)
+	}
 }
 
 func TestSetHeaders(t *testing.T) {
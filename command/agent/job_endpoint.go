@@ -1,6 +1,7 @@
 package agent
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -78,6 +79,9 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/revert"):
 		jobName := strings.TrimSuffix(path, "/revert")
 		return s.jobRevert(resp, req, jobName)
+	case strings.HasSuffix(path, "/patch"):
+		jobName := strings.TrimSuffix(path, "/patch")
+		return s.jobPatch(resp, req, jobName)
 	case strings.HasSuffix(path, "/deployments"):
 		jobName := strings.TrimSuffix(path, "/deployments")
 		return s.jobDeployments(resp, req, jobName)
@@ -93,6 +97,12 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/services"):
 		jobName := strings.TrimSuffix(path, "/services")
 		return s.jobServiceRegistrations(resp, req, jobName)
+	case strings.HasSuffix(path, "/restart/status"):
+		jobName := strings.TrimSuffix(path, "/restart/status")
+		return s.jobRestartUpdate(resp, req, jobName)
+	case strings.HasSuffix(path, "/restart"):
+		jobName := strings.TrimSuffix(path, "/restart")
+		return s.jobRestart(resp, req, jobName)
 	default:
 		return s.jobCRUD(resp, req, path)
 	}
@@ -417,6 +427,12 @@ func (s *HTTPServer) jobUpdate(resp http.ResponseWriter, req *http.Request,
 		EvalPriority:   args.EvalPriority,
 		WriteRequest:   *writeReq,
 	}
+	if args.Signature != nil {
+		regReq.Signature = &structs.JobSignature{
+			KeyID:     args.Signature.KeyID,
+			Signature: args.Signature.Signature,
+		}
+	}
 
 	var out structs.JobRegisterResponse
 	if err := s.agent.RPC("Job.Register", &regReq, &out); err != nil {
@@ -554,6 +570,9 @@ func (s *HTTPServer) jobScaleAction(resp http.ResponseWriter, req *http.Request,
 		Message:        args.Message,
 		Error:          args.Error,
 		Meta:           args.Meta,
+		Groups:         args.Groups,
+		EnforceIndex:   args.EnforceIndex,
+		JobModifyIndex: args.JobModifyIndex,
 	}
 	// parseWriteRequest overrides Namespace, Region and AuthToken
 	// based on values from the original http request
@@ -630,6 +649,156 @@ func (s *HTTPServer) jobRevert(resp http.ResponseWriter, req *http.Request,
 	return out, nil
 }
 
+func (s *HTTPServer) jobRestart(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+
+	switch req.Method {
+	case "GET":
+		return s.jobRestartStatus(resp, req, jobName)
+	case "PUT", "POST":
+		return s.jobRestartStart(resp, req, jobName)
+	default:
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) jobRestartStatus(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+
+	args := structs.JobSpecificRequest{
+		JobID: jobName,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.SingleJobRestartResponse
+	if err := s.agent.RPC(structs.JobGetRestartRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Restart == nil {
+		return nil, CodedError(404, "job restart not found")
+	}
+	return out.Restart, nil
+}
+
+func (s *HTTPServer) jobRestartStart(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+
+	var args structs.JobRestartRequest
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	if args.JobID == "" {
+		args.JobID = jobName
+	}
+	if args.JobID != jobName {
+		return nil, CodedError(400, "Job ID does not match")
+	}
+
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.JobRestartResponse
+	if err := s.agent.RPC(structs.JobRestartRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return out.Restart, nil
+}
+
+func (s *HTTPServer) jobRestartUpdate(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+
+	if req.Method != "PUT" && req.Method != "POST" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args structs.JobRestartUpdateRequest
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	if args.JobID == "" {
+		args.JobID = jobName
+	}
+	if args.JobID != jobName {
+		return nil, CodedError(400, "Job ID does not match")
+	}
+
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.JobRestartResponse
+	if err := s.agent.RPC(structs.JobUpdateRestartRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return out.Restart, nil
+}
+
+// jobPatchRequest mirrors api.JobPatchRequest but keeps the merge patch and
+// operation values as raw JSON so they are passed to the servers untouched.
+type jobPatchRequest struct {
+	JobID      string
+	MergePatch json.RawMessage
+	Ops        []struct {
+		Op    string
+		Path  string
+		Value json.RawMessage
+	}
+	EnforcePriorVersion *uint64
+	ConsulToken         string
+	VaultToken          string
+}
+
+func (s *HTTPServer) jobPatch(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+
+	if req.Method != "PUT" && req.Method != "POST" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var patchRequest jobPatchRequest
+	if err := decodeBody(req, &patchRequest); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	if patchRequest.JobID == "" {
+		return nil, CodedError(400, "JobID must be specified")
+	}
+	if patchRequest.JobID != jobName {
+		return nil, CodedError(400, "Job ID does not match")
+	}
+
+	args := structs.JobPatchRequest{
+		JobID:               patchRequest.JobID,
+		EnforcePriorVersion: patchRequest.EnforcePriorVersion,
+		ConsulToken:         patchRequest.ConsulToken,
+		VaultToken:          patchRequest.VaultToken,
+	}
+	if len(patchRequest.MergePatch) > 0 && string(patchRequest.MergePatch) != "null" {
+		args.MergePatch = patchRequest.MergePatch
+	}
+	for _, op := range patchRequest.Ops {
+		args.Ops = append(args.Ops, &structs.JobPatchOp{
+			Op:    op.Op,
+			Path:  op.Path,
+			Value: op.Value,
+		})
+	}
+
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.JobRegisterResponse
+	if err := s.agent.RPC(structs.JobPatchRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	return out, nil
+}
+
 func (s *HTTPServer) jobStable(resp http.ResponseWriter, req *http.Request,
 	jobName string) (interface{}, error) {
 
@@ -975,6 +1144,15 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 		}
 	}
 
+	if job.Disconnect != nil {
+		j.Disconnect = &structs.DisconnectStrategy{
+			MaxUnknown: *job.Disconnect.MaxUnknown,
+		}
+	}
+
+	j.OnDisconnect = ApiDisconnectHooksToStructs(job.OnDisconnect)
+	j.OnReconnect = ApiDisconnectHooksToStructs(job.OnReconnect)
+
 	if len(job.TaskGroups) > 0 {
 		j.TaskGroups = []*structs.TaskGroup{}
 		for _, taskGroup := range job.TaskGroups {
@@ -1004,6 +1182,16 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		Mode:     *taskGroup.RestartPolicy.Mode,
 	}
 
+	if taskGroup.RestartBudget != nil {
+		tg.RestartBudget = &structs.RestartBudget{}
+		if taskGroup.RestartBudget.Attempts != nil {
+			tg.RestartBudget.Attempts = *taskGroup.RestartBudget.Attempts
+		}
+		if taskGroup.RestartBudget.Interval != nil {
+			tg.RestartBudget.Interval = *taskGroup.RestartBudget.Interval
+		}
+	}
+
 	if taskGroup.ShutdownDelay != nil {
 		tg.ShutdownDelay = taskGroup.ShutdownDelay
 	}
@@ -1016,6 +1204,23 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		tg.MaxClientDisconnect = taskGroup.MaxClientDisconnect
 	}
 
+	if taskGroup.MaxClientDisconnectJitter != nil {
+		tg.MaxClientDisconnectJitter = taskGroup.MaxClientDisconnectJitter
+	}
+
+	if l := len(taskGroup.MaxClientDisconnectTiers); l != 0 {
+		tg.MaxClientDisconnectTiers = make([]*structs.MaxClientDisconnectTier, l)
+		for i, tier := range taskGroup.MaxClientDisconnectTiers {
+			tg.MaxClientDisconnectTiers[i] = &structs.MaxClientDisconnectTier{
+				Attribute: tier.Attribute,
+				Value:     tier.Value,
+			}
+			if tier.Duration != nil {
+				tg.MaxClientDisconnectTiers[i].Duration = *tier.Duration
+			}
+		}
+	}
+
 	if taskGroup.ReschedulePolicy != nil {
 		tg.ReschedulePolicy = &structs.ReschedulePolicy{
 			Attempts:      *taskGroup.ReschedulePolicy.Attempts,
@@ -1102,6 +1307,10 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		if taskGroup.Update.AutoPromote != nil {
 			tg.Update.AutoPromote = *taskGroup.Update.AutoPromote
 		}
+
+		if taskGroup.Update.CanaryDistinctHosts != nil {
+			tg.Update.CanaryDistinctHosts = *taskGroup.Update.CanaryDistinctHosts
+		}
 	}
 
 	if len(taskGroup.Tasks) > 0 {
@@ -1226,6 +1435,9 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 					Envvars:      *template.Envvars,
 					VaultGrace:   *template.VaultGrace,
 					Wait:         apiWaitConfigToStructsWaitConfig(template.Wait),
+					MaxStale:     *template.MaxStale,
+					StaleMode:    *template.StaleMode,
+					StaleSignal:  *template.StaleSignal,
 				})
 		}
 	}
@@ -1242,6 +1454,18 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 			Sidecar: apiTask.Lifecycle.Sidecar,
 		}
 	}
+
+	if apiTask.Checkpoint != nil {
+		structsTask.Checkpoint = &structs.TaskCheckpoint{
+			Timeout: *apiTask.Checkpoint.Timeout,
+		}
+	}
+
+	if apiTask.Identity != nil {
+		structsTask.Identity = &structs.WorkloadIdentity{
+			Env: apiTask.Identity.Env,
+		}
+	}
 }
 
 // apiWaitConfigToStructsWaitConfig is a copy and type conversion between the API
@@ -1302,6 +1526,10 @@ func ApiResourcesToStructs(in *api.Resources) *structs.Resources {
 		out.MemoryMaxMB = *in.MemoryMaxMB
 	}
 
+	if in.HugePages2MB != nil {
+		out.HugePages2MB = *in.HugePages2MB
+	}
+
 	// COMPAT(0.10): Only being used to issue warnings
 	if in.IOPS != nil {
 		out.IOPS = *in.IOPS
@@ -1786,6 +2014,26 @@ func ApiAffinityToStructs(a1 *api.Affinity) *structs.Affinity {
 	}
 }
 
+func ApiDisconnectHooksToStructs(in []*api.DisconnectHook) []*structs.DisconnectHook {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]*structs.DisconnectHook, len(in))
+	for i, h := range in {
+		out[i] = &structs.DisconnectHook{
+			Task:    h.Task,
+			Signal:  h.Signal,
+			Command: h.Command,
+			Args:    helper.CopySliceString(h.Args),
+			Webhook: h.Webhook,
+			Timeout: *h.Timeout,
+		}
+	}
+
+	return out
+}
+
 func ApiSpreadToStructs(a1 *api.Spread) *structs.Spread {
 	ret := &structs.Spread{}
 	ret.Attribute = a1.Attribute
@@ -1817,6 +1817,63 @@ func TestHTTP_JobDispatch(t *testing.T) {
 	})
 }
 
+func TestHTTP_JobRestart(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		job := mock.Job()
+		state := s.Agent.server.State()
+		require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{alloc}))
+
+		// The job has not been restarted yet
+		req, err := http.NewRequest("GET", "/v1/job/"+job.ID+"/restart", nil)
+		require.NoError(t, err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.EqualError(t, err, "job restart not found")
+
+		// Start a restart of the running allocation
+		buf := encodeReq(structs.JobRestartRequest{
+			BatchSize: 1,
+			OnError:   structs.JobRestartOnErrorPause,
+		})
+		req, err = http.NewRequest("PUT", "/v1/job/"+job.ID+"/restart", buf)
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		obj, err := s.Server.JobSpecificRequest(respW, req)
+		require.NoError(t, err)
+		restart := obj.(*structs.JobRestart)
+		require.Equal(t, job.ID, restart.JobID)
+		require.Equal(t, []string{alloc.ID}, restart.AllocIDs)
+		require.NotZero(t, respW.Header().Get("X-Nomad-Index"))
+
+		// The request must match the job in the path
+		buf = encodeReq(structs.JobRestartRequest{JobID: "other", BatchSize: 1})
+		req, err = http.NewRequest("PUT", "/v1/job/"+job.ID+"/restart", buf)
+		require.NoError(t, err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.EqualError(t, err, "Job ID does not match")
+
+		// Cancel the restart
+		buf = encodeReq(structs.JobRestartUpdateRequest{Status: structs.JobRestartStatusCancelled})
+		req, err = http.NewRequest("PUT", "/v1/job/"+job.ID+"/restart/status", buf)
+		require.NoError(t, err)
+		obj, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.NoError(t, err)
+		require.Equal(t, structs.JobRestartStatusCancelled, obj.(*structs.JobRestart).Status)
+
+		req, err = http.NewRequest("GET", "/v1/job/"+job.ID+"/restart", nil)
+		require.NoError(t, err)
+		obj, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.NoError(t, err)
+		require.Equal(t, restart.ID, obj.(*structs.JobRestart).ID)
+	})
+}
+
 func TestHTTP_JobRevert(t *testing.T) {
 	ci.Parallel(t)
 	httpTest(t, nil, func(s *TestAgent) {
@@ -1876,6 +1933,59 @@ func TestHTTP_JobRevert(t *testing.T) {
 	})
 }
 
+func TestHTTP_JobPatch(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Create the job and register it
+		job := mock.Job()
+		regReq := structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var regResp structs.JobRegisterResponse
+		require.NoError(t, s.Agent.RPC("Job.Register", &regReq, &regResp))
+
+		args := api.JobPatchRequest{
+			JobID:      job.ID,
+			MergePatch: map[string]interface{}{"Meta": map[string]interface{}{"owner": "ops"}},
+			Ops: []*api.JobPatchOp{
+				{Op: "replace", Path: "/TaskGroups/0/Count", Value: 5},
+			},
+		}
+		buf := encodeReq(args)
+
+		// Make the HTTP request
+		req, err := http.NewRequest("PUT", "/v1/job/"+job.ID+"/patch", buf)
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		// Make the request
+		obj, err := s.Server.JobSpecificRequest(respW, req)
+		require.NoError(t, err)
+
+		// Check the response
+		patchResp := obj.(structs.JobRegisterResponse)
+		require.NotEmpty(t, patchResp.EvalID)
+		require.NotEmpty(t, respW.Result().Header.Get("X-Nomad-Index"))
+
+		// Check the job was patched
+		getReq := structs.JobSpecificRequest{
+			JobID: job.ID,
+			QueryOptions: structs.QueryOptions{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var getResp structs.SingleJobResponse
+		require.NoError(t, s.Agent.RPC("Job.GetJob", &getReq, &getResp))
+		require.Equal(t, 5, getResp.Job.TaskGroups[0].Count)
+		require.Equal(t, "ops", getResp.Job.Meta["owner"])
+	})
+}
+
 func TestHTTP_JobStable(t *testing.T) {
 	ci.Parallel(t)
 	httpTest(t, nil, func(s *TestAgent) {
@@ -2437,6 +2547,27 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 				},
 			},
 		},
+		Disconnect: &api.DisconnectStrategy{
+			MaxUnknown: pointer.Of(2),
+		},
+		OnDisconnect: []*api.DisconnectHook{
+			{
+				Task:   "task1",
+				Signal: "SIGUSR1",
+			},
+			{
+				Webhook: "http://127.0.0.1:8080/degraded",
+				Timeout: pointer.Of(5 * time.Second),
+			},
+		},
+		OnReconnect: []*api.DisconnectHook{
+			{
+				Task:    "task1",
+				Command: "/bin/resume",
+				Args:    []string{"-now"},
+				Timeout: pointer.Of(10 * time.Second),
+			},
+		},
 		TaskGroups: []*api.TaskGroup{
 			{
 				Name:  pointer.Of("group1"),
@@ -2747,6 +2878,9 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 									Min: pointer.Of(5 * time.Second),
 									Max: pointer.Of(10 * time.Second),
 								},
+								MaxStale:    pointer.Of(time.Hour),
+								StaleMode:   pointer.Of("signal"),
+								StaleSignal: pointer.Of("sigusr1"),
 							},
 						},
 						DispatchPayload: &api.DispatchPayloadConfig{
@@ -2839,6 +2973,28 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 				},
 			},
 		},
+		Disconnect: &structs.DisconnectStrategy{
+			MaxUnknown: 2,
+		},
+		OnDisconnect: []*structs.DisconnectHook{
+			{
+				Task:    "task1",
+				Signal:  "SIGUSR1",
+				Timeout: 30 * time.Second,
+			},
+			{
+				Webhook: "http://127.0.0.1:8080/degraded",
+				Timeout: 5 * time.Second,
+			},
+		},
+		OnReconnect: []*structs.DisconnectHook{
+			{
+				Task:    "task1",
+				Command: "/bin/resume",
+				Args:    []string{"-now"},
+				Timeout: 10 * time.Second,
+			},
+		},
 		TaskGroups: []*structs.TaskGroup{
 			{
 				Name:  "group1",
@@ -3160,6 +3316,9 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 									Min: pointer.Of(5 * time.Second),
 									Max: pointer.Of(10 * time.Second),
 								},
+								MaxStale:    time.Hour,
+								StaleMode:   "signal",
+								StaleSignal: "SIGUSR1",
 							},
 						},
 						DispatchPayload: &structs.DispatchPayloadConfig{
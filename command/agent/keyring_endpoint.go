@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/nomad/api"
@@ -16,6 +17,23 @@ func (s *HTTPServer) KeyringRequest(resp http.ResponseWriter, req *http.Request)
 
 	path := strings.TrimPrefix(req.URL.Path, "/v1/operator/keyring/")
 	switch {
+	case strings.HasPrefix(path, "signing-keys"):
+		switch req.Method {
+		case http.MethodGet:
+			return s.keyringListJobSigningKeysRequest(resp, req)
+		case http.MethodPost, http.MethodPut:
+			return s.keyringUpsertJobSigningKeyRequest(resp, req)
+		default:
+			return nil, CodedError(405, ErrInvalidMethod)
+		}
+	case strings.HasPrefix(path, "signing-key/"):
+		keyID := strings.TrimPrefix(path, "signing-key/")
+		switch req.Method {
+		case http.MethodDelete:
+			return s.keyringDeleteJobSigningKeyRequest(resp, req, keyID)
+		default:
+			return nil, CodedError(405, ErrInvalidMethod)
+		}
 	case strings.HasPrefix(path, "keys"):
 		switch req.Method {
 		case http.MethodGet:
@@ -28,6 +46,8 @@ func (s *HTTPServer) KeyringRequest(resp http.ResponseWriter, req *http.Request)
 	case strings.HasPrefix(path, "key"):
 		keyID := strings.TrimPrefix(req.URL.Path, "/v1/operator/keyring/key/")
 		switch req.Method {
+		case http.MethodGet:
+			return s.keyringGetRequest(resp, req, keyID)
 		case http.MethodDelete:
 			return s.keyringDeleteRequest(resp, req, keyID)
 		default:
@@ -35,6 +55,26 @@ func (s *HTTPServer) KeyringRequest(resp http.ResponseWriter, req *http.Request)
 		}
 	case strings.HasPrefix(path, "rotate"):
 		return s.keyringRotateRequest(resp, req)
+	case strings.HasPrefix(path, "export"):
+		if req.Method != http.MethodPut && req.Method != http.MethodPost {
+			return nil, CodedError(405, ErrInvalidMethod)
+		}
+		return s.keyringExportRequest(resp, req)
+	case strings.HasPrefix(path, "import"):
+		if req.Method != http.MethodPut && req.Method != http.MethodPost {
+			return nil, CodedError(405, ErrInvalidMethod)
+		}
+		return s.keyringImportRequest(resp, req)
+	case strings.HasPrefix(path, "verify"):
+		if req.Method != http.MethodGet {
+			return nil, CodedError(405, ErrInvalidMethod)
+		}
+		return s.keyringVerifyRequest(resp, req)
+	case strings.HasPrefix(path, "health"):
+		if req.Method != http.MethodGet {
+			return nil, CodedError(405, ErrInvalidMethod)
+		}
+		return s.keyringHealthRequest(resp, req)
 	default:
 		return nil, CodedError(405, ErrInvalidMethod)
 	}
@@ -59,6 +99,29 @@ func (s *HTTPServer) keyringListRequest(resp http.ResponseWriter, req *http.Requ
 	return out.Keys, nil
 }
 
+func (s *HTTPServer) keyringGetRequest(resp http.ResponseWriter, req *http.Request, keyID string) (interface{}, error) {
+
+	if keyID == "" {
+		return nil, CodedError(400, "missing key ID")
+	}
+
+	args := structs.KeyringGetRootKeyMetaRequest{KeyID: keyID}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.KeyringGetRootKeyMetaResponse
+	if err := s.agent.RPC("Keyring.GetMeta", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Key == nil {
+		return nil, CodedError(404, "key not found")
+	}
+	return out.Key, nil
+}
+
 func (s *HTTPServer) keyringRotateRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 
 	args := structs.KeyringRotateRootKeyRequest{}
@@ -74,6 +137,17 @@ func (s *HTTPServer) keyringRotateRequest(resp http.ResponseWriter, req *http.Re
 		args.Full = true
 	}
 
+	if raw := query.Get("publish_time"); raw != "" {
+		publishTime, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, CodedError(400, fmt.Sprintf("invalid publish_time: %v", err))
+		}
+		if args.Full {
+			return nil, CodedError(400, "a full rotation cannot be scheduled")
+		}
+		args.PublishTime = publishTime
+	}
+
 	var out structs.KeyringRotateRootKeyResponse
 	if err := s.agent.RPC("Keyring.Rotate", &args, &out); err != nil {
 		return nil, err
@@ -132,3 +206,151 @@ func (s *HTTPServer) keyringDeleteRequest(resp http.ResponseWriter, req *http.Re
 	setIndex(resp, out.Index)
 	return out, nil
 }
+
+func (s *HTTPServer) keyringListJobSigningKeysRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+
+	args := structs.KeyringListJobSigningKeysRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.KeyringListJobSigningKeysResponse
+	if err := s.agent.RPC(structs.JobSigningKeyListRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Keys == nil {
+		out.Keys = make([]*structs.JobSigningKey, 0)
+	}
+	return out.Keys, nil
+}
+
+func (s *HTTPServer) keyringUpsertJobSigningKeyRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+
+	var key api.JobSigningKey
+	if err := decodeBody(req, &key); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+
+	args := structs.KeyringUpsertJobSigningKeyRequest{
+		Key: &structs.JobSigningKey{
+			KeyID:     key.KeyID,
+			Name:      key.Name,
+			PublicKey: key.PublicKey,
+		},
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.KeyringUpsertJobSigningKeyResponse
+	if err := s.agent.RPC(structs.JobSigningKeyUpsertRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
+func (s *HTTPServer) keyringDeleteJobSigningKeyRequest(resp http.ResponseWriter, req *http.Request, keyID string) (interface{}, error) {
+
+	args := structs.KeyringDeleteJobSigningKeyRequest{KeyID: keyID}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.KeyringDeleteJobSigningKeyResponse
+	if err := s.agent.RPC(structs.JobSigningKeyDeleteRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
+// keyringExportRequest takes the passphrase in the request body rather
+// than the query string, so that it doesn't end up in access logs
+func (s *HTTPServer) keyringExportRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+
+	var body api.KeyringExportOptions
+	if err := decodeBody(req, &body); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+
+	args := structs.KeyringExportRootKeyRequest{
+		KeyID:      body.KeyID,
+		Passphrase: body.Passphrase,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.KeyringExportRootKeyResponse
+	if err := s.agent.RPC("Keyring.Export", &args, &out); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &out.QueryMeta)
+	return out.Bundle, nil
+}
+
+func (s *HTTPServer) keyringImportRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+
+	var body api.KeyringImportRequest
+	if err := decodeBody(req, &body); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	if body.Bundle == nil {
+		return nil, CodedError(400, "root key bundle is required")
+	}
+
+	args := structs.KeyringImportRootKeyRequest{
+		Bundle: &structs.RootKeyBundle{
+			KeyID:      body.Bundle.KeyID,
+			Algorithm:  structs.EncryptionAlgorithm(body.Bundle.Algorithm),
+			CreateTime: body.Bundle.CreateTime.UnixNano(),
+			KDF:        body.Bundle.KDF,
+			Salt:       body.Bundle.Salt,
+			Ciphertext: body.Bundle.Ciphertext,
+		},
+		Passphrase: body.Passphrase,
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.KeyringImportRootKeyResponse
+	if err := s.agent.RPC("Keyring.Import", &args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
+func (s *HTTPServer) keyringVerifyRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+
+	args := structs.KeyringVerifyRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.KeyringVerifyResponse
+	if err := s.agent.RPC("Keyring.Verify", &args, &out); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &out.QueryMeta)
+	if out.Orphaned == nil {
+		out.Orphaned = make([]*structs.KeyringOrphanedVariable, 0)
+	}
+	return out, nil
+}
+
+func (s *HTTPServer) keyringHealthRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+
+	args := structs.KeyringHealthRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.KeyringHealthResponse
+	if err := s.agent.RPC("Keyring.Health", &args, &out); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &out.QueryMeta)
+	if out.Failed == nil {
+		out.Failed = make([]*structs.KeyringOrphanedVariable, 0)
+	}
+	return out, nil
+}
@@ -50,6 +50,21 @@ func TestHTTP_Keyring_CRUD(t *testing.T) {
 			}
 		}
 
+		// Get
+
+		req, err = http.NewRequest(http.MethodGet, "/v1/operator/keyring/key/"+newID1, nil)
+		require.NoError(t, err)
+		obj, err = s.Server.KeyringRequest(respW, req)
+		require.NoError(t, err)
+		getResp := obj.(*structs.RootKeyMeta)
+		require.Equal(t, newID1, getResp.KeyID)
+		require.True(t, getResp.Active())
+
+		req, err = http.NewRequest(http.MethodGet, "/v1/operator/keyring/key/"+uuid.Generate(), nil)
+		require.NoError(t, err)
+		_, err = s.Server.KeyringRequest(respW, req)
+		require.EqualError(t, err, "key not found")
+
 		// Update
 
 		keyMeta := rotateResp.Key
@@ -99,5 +114,27 @@ func TestHTTP_Keyring_CRUD(t *testing.T) {
 				require.False(t, key.Active(), "initial key should be inactive")
 			}
 		}
+
+		// Verify
+
+		req, err = http.NewRequest(http.MethodGet, "/v1/operator/keyring/verify", nil)
+		require.NoError(t, err)
+		obj, err = s.Server.KeyringRequest(respW, req)
+		require.NoError(t, err)
+		verifyResp := obj.(structs.KeyringVerifyResponse)
+		require.Zero(t, verifyResp.Variables)
+		require.NotNil(t, verifyResp.Orphaned)
+		require.Empty(t, verifyResp.Orphaned)
+
+		// Health
+
+		req, err = http.NewRequest(http.MethodGet, "/v1/operator/keyring/health", nil)
+		require.NoError(t, err)
+		obj, err = s.Server.KeyringRequest(respW, req)
+		require.NoError(t, err)
+		healthResp := obj.(structs.KeyringHealthResponse)
+		require.True(t, healthResp.Healthy)
+		require.NotNil(t, healthResp.Failed)
+		require.Empty(t, healthResp.Failed)
 	})
 }
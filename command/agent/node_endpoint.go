@@ -1,8 +1,10 @@
 package agent
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -66,6 +68,9 @@ func (s *HTTPServer) NodeSpecificRequest(resp http.ResponseWriter, req *http.Req
 	case strings.HasSuffix(path, "/eligibility"):
 		nodeName := strings.TrimSuffix(path, "/eligibility")
 		return s.nodeToggleEligibility(resp, req, nodeName)
+	case strings.HasSuffix(path, "/disconnect"):
+		nodeName := strings.TrimSuffix(path, "/disconnect")
+		return s.nodePlannedDisconnect(resp, req, nodeName)
 	case strings.HasSuffix(path, "/purge"):
 		nodeName := strings.TrimSuffix(path, "/purge")
 		return s.nodePurge(resp, req, nodeName)
@@ -179,6 +184,30 @@ func (s *HTTPServer) nodeToggleEligibility(resp http.ResponseWriter, req *http.R
 	return out, nil
 }
 
+func (s *HTTPServer) nodePlannedDisconnect(resp http.ResponseWriter, req *http.Request,
+	nodeID string) (interface{}, error) {
+	if req.Method != "PUT" && req.Method != "POST" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var disconnectRequest structs.NodeUpdatePlannedDisconnectRequest
+	if err := decodeBody(req, &disconnectRequest); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	if disconnectRequest.NodeID == "" {
+		disconnectRequest.NodeID = nodeID
+	}
+
+	s.parseWriteRequest(req, &disconnectRequest.WriteRequest)
+
+	var out structs.GenericResponse
+	if err := s.agent.RPC("Node.UpdatePlannedDisconnect", &disconnectRequest, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
 func (s *HTTPServer) nodeQuery(resp http.ResponseWriter, req *http.Request,
 	nodeID string) (interface{}, error) {
 	if req.Method != "GET" {
@@ -203,6 +232,32 @@ func (s *HTTPServer) nodeQuery(resp http.ResponseWriter, req *http.Request,
 	return out.Node, nil
 }
 
+func (s *HTTPServer) NodesPurgeRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "PUT" && req.Method != "POST" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.NodePurgeDownRequest{}
+	if downFor := req.URL.Query().Get("down_for"); downFor != "" {
+		dur, err := time.ParseDuration(downFor)
+		if err != nil {
+			return nil, CodedError(400, fmt.Sprintf("failed to parse down_for: %v", err))
+		}
+		args.DownFor = dur
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.NodePurgeDownResponse
+	if err := s.agent.RPC("Node.PurgeDown", &args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	if out.NodeIDs == nil {
+		out.NodeIDs = []string{}
+	}
+	return out, nil
+}
+
 func (s *HTTPServer) nodePurge(resp http.ResponseWriter, req *http.Request, nodeID string) (interface{}, error) {
 	if req.Method != "PUT" && req.Method != "POST" {
 		return nil, CodedError(405, ErrInvalidMethod)
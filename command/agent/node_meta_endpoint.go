@@ -0,0 +1,87 @@
+package agent
+
+import (
+	"net/http"
+	"strings"
+
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+func (s *HTTPServer) ClientMetadataRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	switch req.Method {
+	case "GET":
+		return s.clientMetadataRead(resp, req)
+	case "PUT", "POST":
+		return s.clientMetadataApply(resp, req)
+	default:
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) clientMetadataRead(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	// Get the requested Node ID
+	requestedNode := req.URL.Query().Get("node_id")
+
+	// Build the request and parse the ACL token
+	args := structs.NodeSpecificRequest{
+		NodeID: requestedNode,
+	}
+	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
+
+	var reply cstructs.NodeMetaResponse
+	if err := s.nodeMetaRPC("NodeMeta.Read", requestedNode, &args, &reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *HTTPServer) clientMetadataApply(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var args cstructs.NodeMetaApplyRequest
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+
+	// The node can be given in the body or the query string
+	if requestedNode := req.URL.Query().Get("node_id"); requestedNode != "" {
+		args.NodeID = requestedNode
+	}
+	if err := args.Validate(); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
+
+	var reply cstructs.NodeMetaResponse
+	if err := s.nodeMetaRPC("NodeMeta.Apply", args.NodeID, &args, &reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// nodeMetaRPC makes a NodeMeta RPC on the local client if it is the requested
+// node, or through the servers otherwise.
+func (s *HTTPServer) nodeMetaRPC(method, nodeID string, args, reply interface{}) error {
+	// Determine the handler to use
+	useLocalClient, useClientRPC, useServerRPC := s.rpcHandlerForNode(nodeID)
+
+	// Make the RPC
+	var rpcErr error
+	if useLocalClient {
+		rpcErr = s.agent.Client().ClientRPC(method, args, reply)
+	} else if useClientRPC {
+		rpcErr = s.agent.Client().RPC(method, args, reply)
+	} else if useServerRPC {
+		rpcErr = s.agent.Server().RPC(method, args, reply)
+	} else {
+		rpcErr = CodedError(400, "No local Node and node_id not provided")
+	}
+
+	if rpcErr != nil {
+		if structs.IsErrNoNodeConn(rpcErr) {
+			rpcErr = CodedError(404, rpcErr.Error())
+		} else if strings.Contains(rpcErr.Error(), "Unknown node") {
+			rpcErr = CodedError(404, rpcErr.Error())
+		}
+	}
+	return rpcErr
+}
@@ -0,0 +1,56 @@
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_ClientMetadataRequest(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Applying without metadata fails
+		req, err := http.NewRequest("POST", "/v1/client/metadata", strings.NewReader(`{}`))
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		_, err = s.Server.ClientMetadataRequest(respW, req)
+		require.Error(t, err)
+		require.Equal(t, 400, err.(HTTPCodedError).Code())
+
+		// Apply to the local node
+		body := `{"Meta": {"network": "degraded", "rack": null}}`
+		req, err = http.NewRequest("POST", "/v1/client/metadata", strings.NewReader(body))
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		obj, err := s.Server.ClientMetadataRequest(respW, req)
+		require.NoError(t, err)
+		out := obj.(cstructs.NodeMetaResponse)
+		require.Equal(t, "degraded", out.Meta["network"])
+		require.Contains(t, out.Dynamic, "rack")
+		require.Nil(t, out.Dynamic["rack"])
+
+		// Read it back
+		req, err = http.NewRequest("GET", "/v1/client/metadata", nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.ClientMetadataRequest(respW, req)
+		require.NoError(t, err)
+		out = obj.(cstructs.NodeMetaResponse)
+		require.Equal(t, "degraded", out.Meta["network"])
+		require.Equal(t, "degraded", *out.Dynamic["network"])
+
+		// Unknown nodes are not found
+		req, err = http.NewRequest("POST", "/v1/client/metadata?node_id="+uuid.Generate(), strings.NewReader(body))
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		_, err = s.Server.ClientMetadataRequest(respW, req)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Unknown node")
+	})
+}
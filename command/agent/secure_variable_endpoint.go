@@ -32,6 +32,144 @@ func (s *HTTPServer) SecureVariablesListRequest(resp http.ResponseWriter, req *h
 	return out.Data, nil
 }
 
+func (s *HTTPServer) SecureVariablesReadPrefixRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+
+	args := structs.SecureVariablesReadPrefixRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.SecureVariablesReadPrefixResponse
+	if err := s.agent.RPC(structs.SecureVariablesReadPrefixRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+
+	if out.Data == nil {
+		out.Data = make([]*structs.SecureVariableDecrypted, 0)
+	}
+	return out.Data, nil
+}
+
+func (s *HTTPServer) SecureVariablesSearchRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+
+	args := structs.SecureVariablesSearchRequest{}
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.SecureVariablesSearchResponse
+	if err := s.agent.RPC(structs.SecureVariablesSearchRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+
+	if out.Data == nil {
+		out.Data = make([]*structs.SecureVariableMetadata, 0)
+	}
+	return out.Data, nil
+}
+
+func (s *HTTPServer) SecureVariablesPurgeRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+
+	args := structs.SecureVariablesPurgeRequest{}
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.SecureVariablesPurgeResponse
+	if err := s.agent.RPC(structs.SecureVariablesPurgeRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+
+	if out.Paths == nil {
+		out.Paths = make([]string, 0)
+	}
+	return out.Paths, nil
+}
+
+func (s *HTTPServer) SecureVariablesReplicationStatusRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+
+	args := structs.SecureVariablesReplicationStatusRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.SecureVariablesReplicationStatusResponse
+	if err := s.agent.RPC(structs.SecureVariablesReplicationStatusRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+
+	if out.Sources == nil {
+		out.Sources = make([]*structs.SecureVariablesReplicationStatus, 0)
+	}
+	return out.Sources, nil
+}
+
+func (s *HTTPServer) SecureVariablesTxnRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+
+	args := structs.SecureVariablesTxnRequest{}
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.SecureVariablesTxnResponse
+	if err := s.agent.RPC(structs.SecureVariablesTxnRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+
+	// None of the operations were applied, write out a 409 Conflict response
+	if out.Conflict != nil {
+		resp.WriteHeader(http.StatusConflict)
+	}
+	return out, nil
+}
+
+func (s *HTTPServer) SecureVariablesExchangeIdentityRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+
+	args := structs.SecureVariablesExchangeIdentityRequest{}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.SecureVariablesExchangeIdentityResponse
+	if err := s.agent.RPC(structs.SecureVariablesExchangeIdentityRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
 func (s *HTTPServer) SecureVariableSpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	path := strings.TrimPrefix(req.URL.Path, "/v1/var/")
 	if len(path) == 0 {
@@ -97,6 +235,12 @@ func (s *HTTPServer) secureVariableUpsert(resp http.ResponseWriter, req *http.Re
 		args.Var.ModifyIndex = checkIndex
 	}
 
+	force, err := parseForce(req)
+	if err != nil {
+		return nil, err
+	}
+	args.Force = force
+
 	var out structs.SecureVariablesApplyResponse
 	if err := s.agent.RPC(structs.SecureVariablesApplyRPCMethod, &args, &out); err != nil {
 
@@ -116,7 +260,7 @@ func (s *HTTPServer) secureVariableUpsert(resp http.ResponseWriter, req *http.Re
 	if out.Conflict != nil {
 		setIndex(resp, out.Conflict.ModifyIndex)
 		resp.WriteHeader(http.StatusConflict)
-		return out.Conflict, nil
+		return structs.NewSecureVariableConflict(&out, args.Var.ModifyIndex), nil
 	}
 
 	// Finally, we know that this is a success response, send it to the caller
@@ -145,6 +289,12 @@ func (s *HTTPServer) secureVariableDelete(resp http.ResponseWriter, req *http.Re
 		args.Var.ModifyIndex = checkIndex
 	}
 
+	force, err := parseForce(req)
+	if err != nil {
+		return nil, err
+	}
+	args.Force = force
+
 	var out structs.SecureVariablesApplyResponse
 	if err := s.agent.RPC(structs.SecureVariablesApplyRPCMethod, &args, &out); err != nil {
 
@@ -164,7 +314,7 @@ func (s *HTTPServer) secureVariableDelete(resp http.ResponseWriter, req *http.Re
 	if out.Conflict != nil {
 		setIndex(resp, out.Conflict.ModifyIndex)
 		resp.WriteHeader(http.StatusConflict)
-		return out.Conflict, nil
+		return structs.NewSecureVariableConflict(&out, args.Var.ModifyIndex), nil
 	}
 
 	// Finally, we know that this is a success response, send it to the caller
@@ -183,3 +333,13 @@ func parseCAS(req *http.Request) (bool, uint64, error) {
 	}
 	return false, 0, nil
 }
+
+// parseForce parses the force query parameter, which deletes or unprotects
+// a secure variable with delete protection.
+func parseForce(req *http.Request) (bool, error) {
+	force, err := parseBool(req, "force")
+	if err != nil {
+		return false, CodedError(http.StatusBadRequest, err.Error())
+	}
+	return force != nil && *force, nil
+}
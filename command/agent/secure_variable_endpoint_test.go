@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/hashicorp/go-msgpack/codec"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -102,6 +103,43 @@ func TestHTTP_SecureVariables(t *testing.T) {
 		})
 		rpcResetSV(s)
 
+		t.Run("error_badverb_read_prefix", func(t *testing.T) {
+			req, err := http.NewRequest("PUT", "/v1/vars/read", nil)
+			require.NoError(t, err)
+			respW := httptest.NewRecorder()
+			_, err = s.Server.SecureVariablesReadPrefixRequest(respW, req)
+			require.EqualError(t, err, ErrInvalidMethod)
+		})
+		t.Run("read_prefix", func(t *testing.T) {
+			// Test the empty case
+			req, err := http.NewRequest("GET", "/v1/vars/read?prefix=nope", nil)
+			require.NoError(t, err)
+			respW := httptest.NewRecorder()
+			obj, err := s.Server.SecureVariablesReadPrefixRequest(respW, req)
+			require.NoError(t, err)
+			require.Empty(t, obj.([]*structs.SecureVariableDecrypted))
+
+			sv1 := mock.SecureVariable()
+			sv2 := mock.SecureVariable()
+			sv2.Path = sv1.Path + "/child"
+			require.NoError(t, rpcWriteSV(s, sv1, nil))
+			require.NoError(t, rpcWriteSV(s, sv2, nil))
+
+			req, err = http.NewRequest("GET", "/v1/vars/read?prefix="+sv1.Path, nil)
+			require.NoError(t, err)
+			respW = httptest.NewRecorder()
+			obj, err = s.Server.SecureVariablesReadPrefixRequest(respW, req)
+			require.NoError(t, err)
+			require.NotZero(t, respW.HeaderMap.Get("X-Nomad-Index"))
+
+			// The variables are returned with their items
+			out := obj.([]*structs.SecureVariableDecrypted)
+			require.Len(t, out, 2)
+			require.Equal(t, sv1.Items, out[0].Items)
+			require.Equal(t, sv2.Items, out[1].Items)
+		})
+		rpcResetSV(s)
+
 		t.Run("error_badverb_query", func(t *testing.T) {
 			req, err := http.NewRequest("LOLWUT", "/v1/var/does/not/exist", nil)
 			require.NoError(t, err)
@@ -290,6 +328,7 @@ func TestHTTP_SecureVariables(t *testing.T) {
 				// can use a simple equality check
 				svU.ModifyIndex = out.ModifyIndex
 				svU.ModifyTime = out.ModifyTime
+				svU.ItemCount, svU.Size = 3, 73
 				require.Equal(t, &svU, out)
 			}
 		})
@@ -314,9 +353,23 @@ func TestHTTP_SecureVariables(t *testing.T) {
 
 				// Evaluate the conflict variable
 				require.NotNil(t, obj)
-				conflict, ok := obj.(*structs.SecureVariableDecrypted)
-				require.True(t, ok, "Expected *structs.SecureVariableDecrypted, got %T", obj)
-				require.Equal(t, conflict, sv)
+				conflict, ok := obj.(*structs.SecureVariableConflict)
+				require.True(t, ok, "Expected *structs.SecureVariableConflict, got %T", obj)
+				require.Equal(t, sv, &conflict.SecureVariableDecrypted)
+				require.Equal(t, uint64(1), conflict.CheckIndex)
+				require.False(t, conflict.Redacted)
+
+				// The conflict is encoded as the secure variable along
+				// with the check
+				var encoded bytes.Buffer
+				require.NoError(t, codec.NewEncoder(&encoded, structs.JsonHandleWithExtensions).Encode(obj))
+				var body map[string]interface{}
+				require.NoError(t, json.Unmarshal(encoded.Bytes(), &body))
+				require.Equal(t, sv.Path, body["Path"])
+				require.Equal(t, float64(sv.ModifyIndex), body["ModifyIndex"])
+				require.Equal(t, sv.Items["key1"], body["Items"].(map[string]interface{})["key1"])
+				require.Equal(t, float64(1), body["CheckIndex"])
+				require.Equal(t, false, body["Redacted"])
 
 				// Check for the index
 				require.NotZero(t, respW.HeaderMap.Get("X-Nomad-Index"))
@@ -368,6 +421,7 @@ func TestHTTP_SecureVariables(t *testing.T) {
 				// can use a simple equality check
 				svU.CreateIndex, svU.ModifyIndex = out.CreateIndex, out.ModifyIndex
 				svU.CreateTime, svU.ModifyTime = out.CreateTime, out.ModifyTime
+				svU.ItemCount, svU.Size = 3, 73
 				require.Equal(t, svU.SecureVariableMetadata, out.SecureVariableMetadata)
 
 				// fmt writes sorted output of maps for testability.
@@ -409,9 +463,10 @@ func TestHTTP_SecureVariables(t *testing.T) {
 
 				// Evaluate the conflict variable
 				require.NotNil(t, obj)
-				conflict, ok := obj.(*structs.SecureVariableDecrypted)
-				require.True(t, ok, "Expected *structs.SecureVariableDecrypted, got %T", obj)
-				require.True(t, sv.Equals(*conflict))
+				conflict, ok := obj.(*structs.SecureVariableConflict)
+				require.True(t, ok, "Expected *structs.SecureVariableConflict, got %T", obj)
+				require.True(t, sv.Equals(conflict.SecureVariableDecrypted))
+				require.Equal(t, uint64(1), conflict.CheckIndex)
 
 				// Check for the index
 				require.NotZero(t, respW.HeaderMap.Get("X-Nomad-Index"))
@@ -465,6 +520,96 @@ func TestHTTP_SecureVariables(t *testing.T) {
 			require.NoError(t, err)
 			require.Nil(t, sv)
 		})
+		t.Run("error_badverb_txn", func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/v1/vars/txn", nil)
+			require.NoError(t, err)
+			respW := httptest.NewRecorder()
+			_, err = s.Server.SecureVariablesTxnRequest(respW, req)
+			require.EqualError(t, err, ErrInvalidMethod)
+		})
+		t.Run("error_parse_txn", func(t *testing.T) {
+			buf := encodeBrokenReq(&structs.SecureVariablesTxnRequest{})
+			req, err := http.NewRequest("PUT", "/v1/vars/txn", buf)
+			require.NoError(t, err)
+			respW := httptest.NewRecorder()
+			obj, err := s.Server.SecureVariablesTxnRequest(respW, req)
+			require.EqualError(t, err, "unexpected EOF")
+			require.Nil(t, obj)
+		})
+		t.Run("txn", func(t *testing.T) {
+			sv1 := mock.SecureVariable()
+			sv2 := mock.SecureVariable()
+			sv2.Namespace = sv1.Namespace
+			require.NoError(t, rpcWriteSV(s, sv1, sv1))
+
+			txnReq := func(ops ...*structs.SecureVariablesTxnOp) (*httptest.ResponseRecorder, *structs.SecureVariablesTxnResponse) {
+				buf := encodeReq(&structs.SecureVariablesTxnRequest{Ops: ops})
+				req, err := http.NewRequest("PUT", "/v1/vars/txn?namespace="+sv1.Namespace, buf)
+				require.NoError(t, err)
+				respW := httptest.NewRecorder()
+				obj, err := s.Server.SecureVariablesTxnRequest(respW, req)
+				require.NoError(t, err)
+				out, ok := obj.(structs.SecureVariablesTxnResponse)
+				require.True(t, ok, "Expected structs.SecureVariablesTxnResponse, got %T", obj)
+				require.NotZero(t, respW.HeaderMap.Get("X-Nomad-Index"))
+				return respW, &out
+			}
+
+			// A stale check index conflicts and nothing is written
+			svU := sv1.Copy()
+			svU.Items["new"] = "new"
+			respW, out := txnReq(
+				&structs.SecureVariablesTxnOp{Op: structs.SVOpSet, Var: sv2},
+				&structs.SecureVariablesTxnOp{Op: structs.SVOpCheckIndex, Var: &structs.SecureVariableDecrypted{
+					SecureVariableMetadata: structs.SecureVariableMetadata{
+						Path:        sv1.Path,
+						ModifyIndex: sv1.ModifyIndex - 1,
+					},
+				}},
+			)
+			require.Equal(t, http.StatusConflict, respW.Result().StatusCode)
+			require.Equal(t, 1, out.ConflictIndex)
+			require.Equal(t, sv1, out.Conflict)
+
+			got, err := rpcReadSV(s, sv2.Namespace, sv2.Path)
+			require.NoError(t, err)
+			require.Nil(t, got)
+
+			// A matching check index applies every operation
+			svU.ModifyIndex = sv1.ModifyIndex
+			respW, out = txnReq(
+				&structs.SecureVariablesTxnOp{Op: structs.SVOpSet, Var: sv2},
+				&structs.SecureVariablesTxnOp{Op: structs.SVOpCAS, Var: &svU},
+			)
+			require.Equal(t, http.StatusOK, respW.Result().StatusCode)
+			require.Equal(t, structs.SVOpResultOk, out.Result)
+			require.Len(t, out.Outputs, 2)
+
+			got, err = rpcReadSV(s, sv2.Namespace, sv2.Path)
+			require.NoError(t, err)
+			require.Equal(t, out.Outputs[0], got)
+			got, err = rpcReadSV(s, sv1.Namespace, sv1.Path)
+			require.NoError(t, err)
+			require.Equal(t, out.Outputs[1], got)
+		})
+		rpcResetSV(s)
+
+		t.Run("error_badverb_exchange", func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/v1/vars/identity/exchange", nil)
+			require.NoError(t, err)
+			respW := httptest.NewRecorder()
+			_, err = s.Server.SecureVariablesExchangeIdentityRequest(respW, req)
+			require.EqualError(t, err, ErrInvalidMethod)
+		})
+		t.Run("error_rpc_exchange", func(t *testing.T) {
+			req, err := http.NewRequest("PUT", "/v1/vars/identity/exchange", nil)
+			require.NoError(t, err)
+			req.Header.Set("X-Nomad-Token", "not.a.identity")
+			respW := httptest.NewRecorder()
+			obj, err := s.Server.SecureVariablesExchangeIdentityRequest(respW, req)
+			require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+			require.Nil(t, obj)
+		})
 	})
 }
 
@@ -37,11 +37,12 @@ advertise {
 }
 
 client {
-  enabled    = true
-  state_dir  = "/tmp/client-state"
-  alloc_dir  = "/tmp/alloc"
-  servers    = ["a.b.c:80", "127.0.0.1:1234"]
-  node_class = "linux-medium-64bit"
+  enabled           = true
+  state_dir         = "/tmp/client-state"
+  alloc_dir         = "/tmp/alloc"
+  servers           = ["a.b.c:80", "127.0.0.1:1234"]
+  node_class        = "linux-medium-64bit"
+  disconnect_domain = "rack-a"
 
   meta {
     foo = "bar"
@@ -89,8 +90,11 @@ client {
   gc_disk_usage_threshold  = 82
   gc_inode_usage_threshold = 91
   gc_max_allocs            = 50
+  state_snapshot_interval  = "30s"
   no_host_uuid             = false
   disable_remote_exec      = true
+  stale_secure_variables   = true
+  secure_variables_socket  = true
 
   host_volume "tmp" {
     path = "/tmp"
@@ -109,7 +113,11 @@ server {
   raft_protocol                 = 3
   num_schedulers                = 2
   enabled_schedulers            = ["test"]
+  dedicated_schedulers          = { test = 1 }
+  require_mlock                 = true
+  require_job_signatures        = true
   node_gc_threshold             = "12h"
+  node_purge_threshold          = "720h"
   job_gc_interval               = "3m"
   job_gc_threshold              = "12h"
   eval_gc_threshold             = "12h"
@@ -119,6 +127,7 @@ server {
   heartbeat_grace               = "30s"
   min_heartbeat_ttl             = "33s"
   max_heartbeats_per_second     = 11.0
+  max_reconnects_per_second     = 22.0
   failover_heartbeat_ttl        = "330s"
   retry_join                    = ["1.1.1.1", "2.2.2.2"]
   start_join                    = ["1.1.1.1", "2.2.2.2"]
@@ -139,6 +148,17 @@ server {
     node_window    = "41m"
   }
 
+  keyring {
+    rotation_interval = "240h"
+    prepublish_period = "24h"
+  }
+
+  root_key_secret {
+    provider  = "aws-secretsmanager"
+    secret_id = "nomad/root-key"
+    region    = "us-east-1"
+  }
+
   server_join {
     retry_join     = ["1.1.1.1", "2.2.2.2"]
     retry_max      = 3
@@ -187,13 +207,14 @@ audit {
 }
 
 telemetry {
-  statsite_address           = "127.0.0.1:1234"
-  statsd_address             = "127.0.0.1:2345"
-  prometheus_metrics         = true
-  disable_hostname           = true
-  collection_interval        = "3s"
-  publish_allocation_metrics = true
-  publish_node_metrics       = true
+  statsite_address            = "127.0.0.1:1234"
+  statsd_address              = "127.0.0.1:2345"
+  prometheus_metrics          = true
+  disable_hostname            = true
+  collection_interval         = "3s"
+  publish_allocation_metrics  = true
+  publish_node_metrics        = true
+  collect_host_system_metrics = true
 }
 
 leave_on_interrupt = true
@@ -3,7 +3,6 @@ package command
 import (
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/api/contexts"
@@ -152,7 +151,7 @@ func (c *AllocChecksCommand) Run(args []string) int {
 		}
 		list = append(list,
 			pair("Mode", check.Mode),
-			pair("Timestamp", formatTaskTimes(time.Unix(check.Timestamp, 0))),
+			pair("Timestamp", formatTaskTimes(check.Timestamp)),
 			pair("Output", check.Output),
 		)
 		c.Ui.Output(formatList(list))
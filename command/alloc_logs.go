@@ -1,6 +1,7 @@
 package command
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -38,6 +39,14 @@ Logs Specific Options:
   -stderr
     Display stderr logs.
 
+  -both
+    Display both the stdout and stderr logs on stdout, interleaved in the order
+    they are read. Cannot be used with -stderr.
+
+  -prefix
+    Prefix each line of the logs with the name of the log stream it was read
+    from, "stdout" or "stderr". Can only be used with -both.
+
   -verbose
     Show full information.
 
@@ -79,6 +88,8 @@ func (l *AllocLogsCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(l.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
 			"-stderr":  complete.PredictNothing,
+			"-both":    complete.PredictNothing,
+			"-prefix":  complete.PredictNothing,
 			"-verbose": complete.PredictNothing,
 			"-task":    complete.PredictAnything,
 			"-job":     complete.PredictAnything,
@@ -107,7 +118,7 @@ func (l *AllocLogsCommand) AutocompleteArgs() complete.Predictor {
 func (l *AllocLogsCommand) Name() string { return "alloc logs" }
 
 func (l *AllocLogsCommand) Run(args []string) int {
-	var verbose, job, tail, stderr, follow bool
+	var verbose, job, tail, stderr, both, prefix, follow bool
 	var numLines, numBytes int64
 	var task string
 
@@ -118,6 +129,8 @@ func (l *AllocLogsCommand) Run(args []string) int {
 	flags.BoolVar(&tail, "tail", false, "")
 	flags.BoolVar(&follow, "f", false, "")
 	flags.BoolVar(&stderr, "stderr", false, "")
+	flags.BoolVar(&both, "both", false, "")
+	flags.BoolVar(&prefix, "prefix", false, "")
 	flags.Int64Var(&numLines, "n", -1, "")
 	flags.Int64Var(&numBytes, "c", -1, "")
 	flags.StringVar(&task, "task", "", "")
@@ -142,6 +155,17 @@ func (l *AllocLogsCommand) Run(args []string) int {
 		return 1
 	}
 
+	if stderr && both {
+		l.Ui.Error("-stderr and -both are mutually exclusive")
+		l.Ui.Error(commandErrorText(l))
+		return 1
+	}
+	if prefix && !both {
+		l.Ui.Error("-prefix can only be used with -both")
+		l.Ui.Error(commandErrorText(l))
+		return 1
+	}
+
 	client, err := l.Meta.Client()
 	if err != nil {
 		l.Ui.Error(fmt.Sprintf("Error initializing client: %v", err))
@@ -213,16 +237,18 @@ func (l *AllocLogsCommand) Run(args []string) int {
 		return 1
 	}
 
-	logType := "stdout"
+	logType := api.LogTypeStdout
 	if stderr {
-		logType = "stderr"
+		logType = api.LogTypeStderr
+	} else if both {
+		logType = api.LogTypeBoth
 	}
 
 	// We have a file, output it.
 	var r io.ReadCloser
 	var readErr error
 	if !tail {
-		r, readErr = l.followFile(client, alloc, follow, task, logType, api.OriginStart, 0)
+		r, readErr = l.followFile(client, alloc, follow, task, logType, api.OriginStart, 0, prefix)
 		if readErr != nil {
 			readErr = fmt.Errorf("Error reading file: %v", readErr)
 		}
@@ -241,7 +267,7 @@ func (l *AllocLogsCommand) Run(args []string) int {
 			numLines = defaultTailLines
 		}
 
-		r, readErr = l.followFile(client, alloc, follow, task, logType, api.OriginEnd, offset)
+		r, readErr = l.followFile(client, alloc, follow, task, logType, api.OriginEnd, offset, prefix)
 
 		// If numLines is set, wrap the reader
 		if numLines != -1 {
@@ -269,9 +295,10 @@ func (l *AllocLogsCommand) Run(args []string) int {
 }
 
 // followFile outputs the contents of the file to stdout relative to the end of
-// the file.
+// the file. If prefix is set, each line is prefixed with the log stream it was
+// read from.
 func (l *AllocLogsCommand) followFile(client *api.Client, alloc *api.Allocation,
-	follow bool, task, logType, origin string, offset int64) (io.ReadCloser, error) {
+	follow bool, task, logType, origin string, offset int64, prefix bool) (io.ReadCloser, error) {
 
 	cancel := make(chan struct{})
 	frames, errCh := client.AllocFS().Logs(alloc, follow, task, logType, origin, offset, cancel, nil)
@@ -280,6 +307,9 @@ func (l *AllocLogsCommand) followFile(client *api.Client, alloc *api.Allocation,
 		return nil, err
 	default:
 	}
+	if prefix {
+		frames = prefixLogLines(frames, cancel)
+	}
 	signalCh := make(chan os.Signal, 1)
 	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
 
@@ -299,6 +329,47 @@ func (l *AllocLogsCommand) followFile(client *api.Client, alloc *api.Allocation,
 	return r, nil
 }
 
+// prefixLogLines prefixes each line of the data of the frames with the log
+// stream it was read from. A line left unterminated when the other stream
+// writes is ended, and its remainder gets a new prefix.
+func prefixLogLines(frames <-chan *api.StreamFrame, cancel <-chan struct{}) <-chan *api.StreamFrame {
+	out := make(chan *api.StreamFrame, 10)
+	go func() {
+		defer close(out)
+
+		var lastStream string
+		var midLine bool
+		for frame := range frames {
+			if len(frame.Data) != 0 {
+				var buf bytes.Buffer
+				if midLine && frame.Stream != lastStream {
+					buf.WriteByte('\n')
+					midLine = false
+				}
+				for _, line := range bytes.SplitAfter(frame.Data, []byte("\n")) {
+					if len(line) == 0 {
+						continue
+					}
+					if !midLine {
+						buf.WriteString(frame.Stream + ": ")
+					}
+					buf.Write(line)
+					midLine = line[len(line)-1] != '\n'
+				}
+				lastStream = frame.Stream
+				frame.Data = buf.Bytes()
+			}
+
+			select {
+			case out <- frame:
+			case <-cancel:
+				return
+			}
+		}
+	}()
+	return out
+}
+
 func lookupAllocTask(alloc *api.Allocation) (string, error) {
 	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
 	if tg == nil {
@@ -3,6 +3,7 @@ package command
 import (
 	"testing"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -33,6 +34,24 @@ func TestLogsCommand_Fails(t *testing.T) {
 
 	ui.ErrorWriter.Reset()
 
+	// Fails on conflicting log types
+	code = cmd.Run([]string{"-stderr", "-both", "foobar"})
+	must.One(t, code)
+
+	out = ui.ErrorWriter.String()
+	must.StrContains(t, out, "-stderr and -both are mutually exclusive")
+
+	ui.ErrorWriter.Reset()
+
+	// Fails on prefixing a single log type
+	code = cmd.Run([]string{"-prefix", "foobar"})
+	must.One(t, code)
+
+	out = ui.ErrorWriter.String()
+	must.StrContains(t, out, "-prefix can only be used with -both")
+
+	ui.ErrorWriter.Reset()
+
 	// Fails on connection failure
 	code = cmd.Run([]string{"-address=nope", "foobar"})
 	must.One(t, code)
@@ -90,3 +109,25 @@ func TestLogsCommand_AutocompleteArgs(t *testing.T) {
 	must.Len(t, 1, res)
 	must.Eq(t, a.ID, res[0])
 }
+
+func TestLogsCommand_prefixLogLines(t *testing.T) {
+	ci.Parallel(t)
+
+	frames := make(chan *api.StreamFrame, 10)
+	for _, f := range []*api.StreamFrame{
+		{Stream: api.LogTypeStdout, Data: []byte("foo\nba")},
+		{Stream: api.LogTypeStdout, Data: []byte("r\n")},
+		{Stream: api.LogTypeStderr, Data: []byte("err")},
+		{Stream: api.LogTypeStdout, Data: []byte("baz\n")},
+		{FileEvent: "file deleted"},
+	} {
+		frames <- f
+	}
+	close(frames)
+
+	var out []byte
+	for f := range prefixLogLines(frames, make(chan struct{})) {
+		out = append(out, f.Data...)
+	}
+	must.Eq(t, "stdout: foo\nstdout: bar\nstderr: err\nstdout: baz\n", string(out))
+}
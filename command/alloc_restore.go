@@ -0,0 +1,171 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type AllocRestoreCommand struct {
+	Meta
+}
+
+func (c *AllocRestoreCommand) Help() string {
+	helpText := `
+Usage: nomad alloc restore [options] <allocation>
+
+  Restore an allocation whose client disconnected. This command is used to
+  accept an allocation that is unknown, or that reconnected and is waiting for
+  its node to stabilize, back as running without waiting for the scheduler to
+  reconcile it. The replacement allocation, if any, is stopped instead.
+
+  With the -reject flag, the allocation is rejected instead: it is stopped
+  once its client reconnects, and it is replaced if it was not already. An
+  interactive monitoring session will display log lines as the evaluation
+  reconciling the allocation completes. It is safe to exit the monitor early
+  with ctrl-c.
+
+  When ACLs are enabled, this command requires a token with the
+  'alloc-lifecycle', 'read-job', and 'list-jobs' capabilities for the
+  allocation's namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Restore Specific Options:
+
+  -reject
+    Reject the allocation instead of accepting it back as running.
+
+  -detach
+    Return immediately instead of entering monitor mode. After the
+    restore command is submitted, a new evaluation ID is printed to the
+    screen, which can be used to examine the evaluation using the eval-status
+    command.
+
+  -verbose
+    Show full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AllocRestoreCommand) Synopsis() string {
+	return "Accept or reject an allocation whose client disconnected"
+}
+
+func (c *AllocRestoreCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-reject":  complete.PredictNothing,
+			"-detach":  complete.PredictNothing,
+			"-verbose": complete.PredictNothing,
+		})
+}
+
+func (c *AllocRestoreCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Allocs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Allocs]
+	})
+}
+
+func (c *AllocRestoreCommand) Name() string { return "alloc restore" }
+
+func (c *AllocRestoreCommand) Run(args []string) int {
+	var reject, detach, verbose bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&reject, "reject", false, "")
+	flags.BoolVar(&detach, "detach", false, "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one alloc
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <alloc-id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	allocID := args[0]
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Query the allocation info
+	if len(allocID) == 1 {
+		c.Ui.Error("Alloc ID must contain at least two characters.")
+		return 1
+	}
+
+	allocID = sanitizeUUIDPrefix(allocID)
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	allocs, _, err := client.Allocations().PrefixList(allocID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying allocation: %v", err))
+		return 1
+	}
+
+	if len(allocs) == 0 {
+		c.Ui.Error(fmt.Sprintf("No allocation(s) with prefix or id %q found", allocID))
+		return 1
+	}
+
+	if len(allocs) > 1 {
+		// Format the allocs
+		out := formatAllocListStubs(allocs, verbose, length)
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple allocations\n\n%s", out))
+		return 1
+	}
+
+	// Prefix lookup matched a single allocation
+	q := &api.QueryOptions{Namespace: allocs[0].Namespace}
+	alloc, _, err := client.Allocations().Info(allocs[0].ID, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying allocation: %s", err))
+		return 1
+	}
+
+	opts := &api.AllocReconnectOptions{Reject: reject}
+	wq := &api.WriteOptions{Namespace: alloc.Namespace}
+	resp, err := client.Allocations().Reconnect(alloc, opts, wq)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error restoring allocation: %s", err))
+		return 1
+	}
+
+	if detach {
+		c.Ui.Output(resp.EvalID)
+		return 0
+	}
+
+	mon := newMonitor(c.Ui, client, length)
+	return mon.monitor(resp.EvalID)
+}
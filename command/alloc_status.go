@@ -29,6 +29,12 @@ Usage: nomad alloc status [options] <allocation>
   status, metadata, and verbose failure messages reported by internal
   subsystems.
 
+  Without an allocation argument, the allocations matching the filter options
+  are listed instead. For example, the allocations whose client has been
+  disconnected for more than 10 minutes can be found with:
+
+      $ nomad alloc status -client-status=unknown -min-time-in-status=10m
+
   When ACLs are enabled, this command requires a token with the 'read-job' and
   'list-jobs' capabilities for the allocation's namespace.
 
@@ -52,6 +58,25 @@ Alloc Status Options:
 
   -t
     Format and display allocation using a Go template.
+
+  ` + sensitiveOptionsUsage + `
+
+Alloc Status Filter Options:
+
+  -client-status
+    List the allocations with this client status, such as "unknown".
+
+  -node
+    List the allocations of the node with this ID or ID prefix.
+
+  -min-time-in-status
+    List the allocations which have been in their client status for at least
+    this duration, such as "10m". The time an allocation entered its status is
+    only recorded for some statuses, including "unknown" and "lost". For the
+    others, the last time the allocation was modified is used instead.
+
+  -filter
+    Specifies an expression used to filter the listed allocations.
 `
 
 	return strings.TrimSpace(helpText)
@@ -64,10 +89,22 @@ func (c *AllocStatusCommand) Synopsis() string {
 func (c *AllocStatusCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-short":   complete.PredictNothing,
-			"-verbose": complete.PredictNothing,
-			"-json":    complete.PredictNothing,
-			"-t":       complete.PredictAnything,
+			"-short":          complete.PredictNothing,
+			"-verbose":        complete.PredictNothing,
+			"-json":           complete.PredictNothing,
+			"-t":              complete.PredictAnything,
+			"-show-sensitive": complete.PredictNothing,
+			"-client-status": complete.PredictSet(
+				api.AllocClientStatusPending,
+				api.AllocClientStatusRunning,
+				api.AllocClientStatusComplete,
+				api.AllocClientStatusFailed,
+				api.AllocClientStatusLost,
+				api.AllocClientStatusUnknown,
+			),
+			"-node":               complete.PredictAnything,
+			"-min-time-in-status": complete.PredictAnything,
+			"-filter":             complete.PredictAnything,
 		})
 }
 
@@ -89,8 +126,9 @@ func (c *AllocStatusCommand) AutocompleteArgs() complete.Predictor {
 func (c *AllocStatusCommand) Name() string { return "alloc status" }
 
 func (c *AllocStatusCommand) Run(args []string) int {
-	var short, displayStats, verbose, json bool
-	var tmpl string
+	var short, displayStats, verbose, json, showSensitive bool
+	var tmpl, filter, clientStatus, nodeID string
+	var minTimeInStatus time.Duration
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
@@ -99,6 +137,11 @@ func (c *AllocStatusCommand) Run(args []string) int {
 	flags.BoolVar(&displayStats, "stats", false, "")
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&tmpl, "t", "", "")
+	flags.BoolVar(&showSensitive, "show-sensitive", false, "")
+	flags.StringVar(&clientStatus, "client-status", "", "")
+	flags.StringVar(&nodeID, "node", "", "")
+	flags.DurationVar(&minTimeInStatus, "min-time-in-status", 0, "")
+	flags.StringVar(&filter, "filter", "", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -114,38 +157,58 @@ func (c *AllocStatusCommand) Run(args []string) int {
 		return 1
 	}
 
-	// If args not specified but output format is specified, format and output the allocations data list
-	if len(args) == 0 && (json || len(tmpl) > 0) {
-		allocs, _, err := client.Allocations().List(nil)
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// If args not specified but output format or filters are specified,
+	// output the allocations data list
+	filtered := filter != "" || clientStatus != "" || nodeID != "" || minTimeInStatus != 0
+	if len(args) == 0 && (json || len(tmpl) > 0 || filtered) {
+		allocs, _, err := client.Allocations().ListWithFilter(&api.AllocationListFilter{
+			ClientStatus:    clientStatus,
+			NodeID:          sanitizeUUIDPrefix(nodeID),
+			MinTimeInStatus: minTimeInStatus,
+		}, &api.QueryOptions{Filter: filter})
 		if err != nil {
 			c.Ui.Error(fmt.Sprintf("Error querying allocations: %v", err))
 			return 1
 		}
 
-		out, err := Format(json, tmpl, allocs)
-		if err != nil {
-			c.Ui.Error(err.Error())
-			return 1
+		if json || len(tmpl) > 0 {
+			out, err := Format(json, tmpl, allocs)
+			if err != nil {
+				c.Ui.Error(err.Error())
+				return 1
+			}
+
+			c.Ui.Output(out)
+			return 0
 		}
 
-		c.Ui.Output(out)
+		if len(allocs) == 0 {
+			c.Ui.Output("No allocations found")
+			return 0
+		}
+		c.Ui.Output(formatAllocStatusList(allocs, length))
 		return 0
 	}
 
 	if len(args) != 1 {
 		c.Ui.Error("This command takes one of the following argument conditions:")
 		c.Ui.Error(" * A single <allocation>")
-		c.Ui.Error(" * No arguments, with output format specified")
+		c.Ui.Error(" * No arguments, with output format or filters specified")
 		c.Ui.Error(commandErrorText(c))
 		return 1
 	}
-	allocID := args[0]
-
-	// Truncate the id unless full length is requested
-	length := shortId
-	if verbose {
-		length = fullId
+	if filtered {
+		c.Ui.Error("The filter options can't be used with an <allocation> argument")
+		c.Ui.Error(commandErrorText(c))
+		return 1
 	}
+	allocID := args[0]
 
 	// Query the allocation info
 	if len(allocID) == 1 {
@@ -176,6 +239,10 @@ func (c *AllocStatusCommand) Run(args []string) int {
 		return 1
 	}
 
+	if !showSensitive {
+		redactAllocation(alloc, sensitivePatterns())
+	}
+
 	// If output format is specified, format and output the data
 	if json || len(tmpl) > 0 {
 		out, err := Format(json, tmpl, alloc)
@@ -210,6 +277,12 @@ func (c *AllocStatusCommand) Run(args []string) int {
 			c.Ui.Output("")
 			c.Ui.Output(checkOutput)
 		}
+
+		// add the allocations this allocation replaced or was replaced by
+		if chainOutput := formatAllocRescheduleChain(alloc, client, length); chainOutput != "" {
+			c.Ui.Output("")
+			c.Ui.Output(chainOutput)
+		}
 	}
 
 	if short {
@@ -238,9 +311,29 @@ func (c *AllocStatusCommand) Run(args []string) int {
 	return 0
 }
 
+// formatAllocStatusList formats the allocations listed with the filter
+// options, which may belong to any job.
+func formatAllocStatusList(stubs []*api.AllocationListStub, uuidLength int) string {
+	now := time.Now()
+	allocs := make([]string, len(stubs)+1)
+	allocs[0] = "ID|Node ID|Namespace|Job ID|Task Group|Desired|Status|Modified"
+	for i, alloc := range stubs {
+		allocs[i+1] = fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s|%s",
+			limit(alloc.ID, uuidLength),
+			limit(alloc.NodeID, uuidLength),
+			alloc.Namespace,
+			alloc.JobID,
+			alloc.TaskGroup,
+			alloc.DesiredStatus,
+			alloc.ClientStatus,
+			prettyTimeDiff(alloc.ModifyTime, now))
+	}
+	return formatList(allocs)
+}
+
 func formatAllocShortInfo(alloc *api.Allocation, client *api.Client) string {
-	formattedCreateTime := prettyTimeDiff(time.Unix(0, alloc.CreateTime), time.Now())
-	formattedModifyTime := prettyTimeDiff(time.Unix(0, alloc.ModifyTime), time.Now())
+	formattedCreateTime := prettyTimeDiff(alloc.CreateTime, time.Now())
+	formattedModifyTime := prettyTimeDiff(alloc.ModifyTime, time.Now())
 
 	basic := []string{
 		fmt.Sprintf("ID|%s", alloc.ID),
@@ -256,11 +349,11 @@ func formatAllocBasicInfo(alloc *api.Allocation, client *api.Client, uuidLength
 	var formattedCreateTime, formattedModifyTime string
 
 	if verbose {
-		formattedCreateTime = formatUnixNanoTime(alloc.CreateTime)
-		formattedModifyTime = formatUnixNanoTime(alloc.ModifyTime)
+		formattedCreateTime = formatTime(alloc.CreateTime)
+		formattedModifyTime = formatTime(alloc.ModifyTime)
 	} else {
-		formattedCreateTime = prettyTimeDiff(time.Unix(0, alloc.CreateTime), time.Now())
-		formattedModifyTime = prettyTimeDiff(time.Unix(0, alloc.ModifyTime), time.Now())
+		formattedCreateTime = prettyTimeDiff(alloc.CreateTime, time.Now())
+		formattedModifyTime = prettyTimeDiff(alloc.ModifyTime, time.Now())
 	}
 
 	basic := []string{
@@ -303,7 +396,7 @@ func formatAllocBasicInfo(alloc *api.Allocation, client *api.Client, uuidLength
 	}
 
 	if alloc.RescheduleTracker != nil && len(alloc.RescheduleTracker.Events) > 0 {
-		attempts, total := alloc.RescheduleInfo(time.Unix(0, alloc.ModifyTime))
+		attempts, total := alloc.RescheduleInfo(alloc.ModifyTime)
 		// Show this section only if the reschedule policy limits the number of attempts
 		if total > 0 {
 			reschedInfo := fmt.Sprintf("Reschedule Attempts|%d/%d", attempts, total)
@@ -386,6 +479,42 @@ func formatAllocNomadServiceChecks(allocID string, client *api.Client) string {
 	return fmt.Sprintf("Nomad Service Checks:\n%s", formatList(results))
 }
 
+// formatAllocRescheduleChain returns the allocations the allocation replaced
+// and was replaced by, oldest first, or an empty string if there are none.
+func formatAllocRescheduleChain(alloc *api.Allocation, client *api.Client, uuidLength int) string {
+	stubs, _, err := client.Allocations().List(&api.QueryOptions{
+		Namespace: alloc.Namespace,
+		Prefix:    alloc.ID,
+		Params: map[string]string{
+			"reschedule_chain": "true",
+			"task_states":      "false",
+		},
+	})
+	if err != nil {
+		return ""
+	}
+
+	var chain *api.AllocRescheduleChain
+	for _, stub := range stubs {
+		if stub.ID == alloc.ID {
+			chain = stub.RescheduleChain
+		}
+	}
+	if chain == nil || len(chain.Ancestors)+len(chain.Descendants) == 0 {
+		return ""
+	}
+
+	results := []string{"ID|Relation"}
+	for _, id := range chain.Ancestors {
+		results = append(results, fmt.Sprintf("%s|ancestor", limit(id, uuidLength)))
+	}
+	results = append(results, fmt.Sprintf("%s|current", limit(alloc.ID, uuidLength)))
+	for _, id := range chain.Descendants {
+		results = append(results, fmt.Sprintf("%s|descendant", limit(id, uuidLength)))
+	}
+	return fmt.Sprintf("Reschedule Chain:\n%s", formatList(results))
+}
+
 // futureEvalTimePretty returns when the eval is eligible to reschedule
 // relative to current time, based on the WaitUntil field
 func futureEvalTimePretty(evalID string, client *api.Client) string {
@@ -453,7 +582,7 @@ func (c *AllocStatusCommand) outputTaskStatus(state *api.TaskState) {
 		if msg == "" {
 			msg = buildDisplayMessage(event)
 		}
-		formattedTime := formatUnixNanoTime(event.Time)
+		formattedTime := formatTime(event.Time)
 		events[size-i] = fmt.Sprintf("%s|%s|%s", formattedTime, event.Type, msg)
 		// Reverse order so we are sorted by time
 	}
@@ -756,7 +885,7 @@ func (c *AllocStatusCommand) shortTaskStatus(alloc *api.Allocation) {
 		if l != 0 {
 			last := state.Events[l-1]
 			lastEvent = last.Type
-			lastTime = formatUnixNanoTime(last.Time)
+			lastTime = formatTime(last.Time)
 		}
 
 		tasks = append(tasks, fmt.Sprintf("%s|%s|%s|%s|%s",
@@ -233,6 +233,41 @@ func TestAllocStatusCommand_RescheduleInfo(t *testing.T) {
 	must.RegexMatch(t, regexp.MustCompile(".*Reschedule Attempts\\s*=\\s*1/2"), out)
 }
 
+func TestAllocStatusCommand_Filters(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, url := testServer(t, false, nil)
+	defer stopTestAgent(srv)
+
+	state := srv.Agent.Server().State()
+	unknown := mock.Alloc()
+	unknown.ClientStatus = structs.AllocClientStatusUnknown
+	unknown.AllocStates = []*structs.AllocState{{
+		Field: structs.AllocStateFieldClientStatus,
+		Value: structs.AllocClientStatusUnknown,
+		Time:  time.Now().Add(-time.Hour).UTC(),
+	}}
+	running := mock.Alloc()
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{unknown, running}))
+
+	ui := cli.NewMockUi()
+	cmd := &AllocStatusCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, "-client-status=unknown", "-min-time-in-status=30m"})
+	must.Zero(t, code)
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, unknown.ID[:8])
+	must.StrNotContains(t, out, running.ID[:8])
+
+	ui.OutputWriter.Reset()
+	code = cmd.Run([]string{"-address=" + url, "-client-status=unknown", "-min-time-in-status=2h"})
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), "No allocations found")
+
+	// Filters can't be combined with an allocation
+	code = cmd.Run([]string{"-address=" + url, "-client-status=unknown", unknown.ID})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "The filter options can't be used with an <allocation> argument")
+}
+
 func TestAllocStatusCommand_ScoreMetrics(t *testing.T) {
 	ci.Parallel(t)
 	srv, client, url := testServer(t, true, nil)
@@ -16,6 +16,14 @@ const (
 
 	// EnvNomadCLIForceColor is an env var that forces colored UI output.
 	EnvNomadCLIForceColor = `NOMAD_CLI_FORCE_COLOR`
+
+	// EnvNomadCLISensitivePatterns is an env var that overrides the comma
+	// separated list of key patterns whose values are redacted from output.
+	EnvNomadCLISensitivePatterns = `NOMAD_CLI_SENSITIVE_PATTERNS`
+
+	// EnvNomadVarShowValues is an env var that disables the redaction of
+	// secure variable values from the output of the var commands by default.
+	EnvNomadVarShowValues = `NOMAD_VAR_SHOW_VALUES`
 )
 
 // DeprecatedCommand is a command that wraps an existing command and prints a
@@ -157,6 +165,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"alloc restore": func() (cli.Command, error) {
+			return &AllocRestoreCommand{
+				Meta: meta,
+			}, nil
+		},
 		"alloc stop": func() (cli.Command, error) {
 			return &AllocStopCommand{
 				Meta: meta,
@@ -295,6 +308,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"fmt": func() (cli.Command, error) {
+			return &FormatCommand{
+				Meta: meta,
+			}, nil
+		},
 		"fs": func() (cli.Command, error) {
 			return &AllocFSCommand{
 				Meta: meta,
@@ -370,6 +388,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"job restart": func() (cli.Command, error) {
+			return &JobRestartCommand{
+				Meta: meta,
+			}, nil
+		},
 		"job revert": func() (cli.Command, error) {
 			return &JobRevertCommand{
 				Meta: meta,
@@ -465,6 +488,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"node disconnect": func() (cli.Command, error) {
+			return &NodeDisconnectCommand{
+				Meta: meta,
+			}, nil
+		},
 		"node-drain": func() (cli.Command, error) {
 			return &NodeDrainCommand{
 				Meta: meta,
@@ -480,6 +508,21 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"node meta": func() (cli.Command, error) {
+			return &NodeMetaCommand{
+				Meta: meta,
+			}, nil
+		},
+		"node meta apply": func() (cli.Command, error) {
+			return &NodeMetaApplyCommand{
+				Meta: meta,
+			}, nil
+		},
+		"node meta read": func() (cli.Command, error) {
+			return &NodeMetaReadCommand{
+				Meta: meta,
+			}, nil
+		},
 		"node-status": func() (cli.Command, error) {
 			return &NodeStatusCommand{
 				Meta: meta,
@@ -530,6 +573,31 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 		// because the flags have changed too. So we've provided the
 		// deprecation warning in the original command and when it's
 		// time to remove it we can remove the entire command
+		"operator job-signing-key": func() (cli.Command, error) {
+			return &OperatorJobSigningKeyCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator job-signing-key generate": func() (cli.Command, error) {
+			return &OperatorJobSigningKeyGenerateCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator job-signing-key install": func() (cli.Command, error) {
+			return &OperatorJobSigningKeyInstallCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator job-signing-key list": func() (cli.Command, error) {
+			return &OperatorJobSigningKeyListCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator job-signing-key remove": func() (cli.Command, error) {
+			return &OperatorJobSigningKeyRemoveCommand{
+				Meta: meta,
+			}, nil
+		},
 		"operator keyring": func() (cli.Command, error) {
 			return &OperatorKeyringCommand{
 				Meta: meta,
@@ -624,6 +692,26 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"operator secure-variables keyring escrow": func() (cli.Command, error) {
+			return &OperatorSecureVariablesKeyringEscrowCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator secure-variables keyring export": func() (cli.Command, error) {
+			return &OperatorSecureVariablesKeyringExportCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator secure-variables keyring health": func() (cli.Command, error) {
+			return &OperatorSecureVariablesKeyringHealthCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator secure-variables keyring import": func() (cli.Command, error) {
+			return &OperatorSecureVariablesKeyringImportCommand{
+				Meta: meta,
+			}, nil
+		},
 		"operator secure-variables keyring install": func() (cli.Command, error) {
 			return &OperatorSecureVariablesKeyringInstallCommand{
 				Meta: meta,
@@ -639,11 +727,21 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"operator secure-variables keyring restore": func() (cli.Command, error) {
+			return &OperatorSecureVariablesKeyringRestoreCommand{
+				Meta: meta,
+			}, nil
+		},
 		"operator secure-variables keyring rotate": func() (cli.Command, error) {
 			return &OperatorSecureVariablesKeyringRotateCommand{
 				Meta: meta,
 			}, nil
 		},
+		"operator secure-variables keyring verify": func() (cli.Command, error) {
+			return &OperatorSecureVariablesKeyringVerifyCommand{
+				Meta: meta,
+			}, nil
+		},
 		"operator snapshot": func() (cli.Command, error) {
 			return &OperatorSnapshotCommand{
 				Meta: meta,
@@ -911,6 +1009,16 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"var edit": func() (cli.Command, error) {
+			return &VarEditCommand{
+				Meta: meta,
+			}, nil
+		},
+		"var get": func() (cli.Command, error) {
+			return &VarGetCommand{
+				Meta: meta,
+			}, nil
+		},
 		"var list": func() (cli.Command, error) {
 			return &VarListCommand{
 				Meta: meta,
@@ -921,6 +1029,56 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"var policy": func() (cli.Command, error) {
+			return &VarPolicyCommand{
+				Meta: meta,
+			}, nil
+		},
+		"var policy check": func() (cli.Command, error) {
+			return &VarPolicyCheckCommand{
+				Meta: meta,
+			}, nil
+		},
+		"var policy generate": func() (cli.Command, error) {
+			return &VarPolicyGenerateCommand{
+				Meta: meta,
+			}, nil
+		},
+		"var purge": func() (cli.Command, error) {
+			return &VarPurgeCommand{
+				Meta: meta,
+			}, nil
+		},
+		"var put": func() (cli.Command, error) {
+			return &VarPutCommand{
+				Meta: meta,
+			}, nil
+		},
+		"var rotate": func() (cli.Command, error) {
+			return &VarRotateCommand{
+				Meta: meta,
+			}, nil
+		},
+		"var run": func() (cli.Command, error) {
+			return &VarRunCommand{
+				Meta: meta,
+			}, nil
+		},
+		"var status": func() (cli.Command, error) {
+			return &VarStatusCommand{
+				Meta: meta,
+			}, nil
+		},
+		"var txn": func() (cli.Command, error) {
+			return &VarTxnCommand{
+				Meta: meta,
+			}, nil
+		},
+		"var migrate-vault": func() (cli.Command, error) {
+			return &VarMigrateVaultCommand{
+				Meta: meta,
+			}, nil
+		},
 		"version": func() (cli.Command, error) {
 			return &VersionCommand{
 				Version: version.GetVersion(),
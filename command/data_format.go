@@ -2,8 +2,10 @@ package command
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 	"text/template"
 
 	"github.com/hashicorp/go-msgpack/codec"
@@ -48,7 +50,26 @@ func (p *JSONFormat) TransformData(data interface{}) (string, error) {
 		return "", err
 	}
 
-	return buf.String(), nil
+	// The codec writes the output of types with their own JSON marshalers,
+	// such as api objects carrying timestamps, verbatim and unindented.
+	// Re-encode the document so the whole output is laid out consistently.
+	// Numbers are kept as-is so nanosecond timestamps don't lose precision.
+	dec := json.NewDecoder(&buf)
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return "", err
+	}
+
+	var out bytes.Buffer
+	jsonEnc := json.NewEncoder(&out)
+	jsonEnc.SetEscapeHTML(false)
+	jsonEnc.SetIndent("", "    ")
+	if err := jsonEnc.Encode(v); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(out.String(), "\n"), nil
 }
 
 type TemplateFormat struct {
@@ -2,11 +2,14 @@ package command
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/api/contexts"
 	flaghelper "github.com/hashicorp/nomad/helper/flags"
+	"github.com/mitchellh/cli"
 	"github.com/posener/complete"
 )
 
@@ -37,13 +40,25 @@ Promote Options:
 
   -group
     Group may be specified many times and is used to promote that particular
-    group. If no specific groups are specified, all groups are promoted.
+    group. If no specific groups are specified and the command is run from a
+    terminal, the groups with canaries awaiting promotion are listed and can
+    be selected interactively. Otherwise all groups are promoted.
+
+  -yes
+    Promote all groups without prompting for a selection.
 
   -detach
     Return immediately instead of entering monitor mode. After deployment
     resume, the evaluation ID will be printed to the screen, which can be used
     to examine the evaluation using the eval-status command.
 
+  -json
+    Output the result of the promotion in its JSON format. Implies -detach.
+
+  -t
+    Format and display the result of the promotion using a Go template.
+    Implies -detach.
+
   -verbose
     Display full information.
 `
@@ -58,7 +73,10 @@ func (c *DeploymentPromoteCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
 			"-group":   complete.PredictAnything,
+			"-yes":     complete.PredictNothing,
 			"-detach":  complete.PredictNothing,
+			"-json":    complete.PredictNothing,
+			"-t":       complete.PredictAnything,
 			"-verbose": complete.PredictNothing,
 		})
 }
@@ -81,13 +99,17 @@ func (c *DeploymentPromoteCommand) AutocompleteArgs() complete.Predictor {
 func (c *DeploymentPromoteCommand) Name() string { return "deployment promote" }
 
 func (c *DeploymentPromoteCommand) Run(args []string) int {
-	var detach, verbose bool
+	var detach, verbose, autoYes, json bool
+	var tmpl string
 	var groups []string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&detach, "detach", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.BoolVar(&autoYes, "yes", false, "")
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
 	flags.Var((*flaghelper.StringFlag)(&groups), "group", "")
 
 	if err := flags.Parse(args); err != nil {
@@ -128,6 +150,18 @@ func (c *DeploymentPromoteCommand) Run(args []string) int {
 		return 1
 	}
 
+	structured := json || len(tmpl) > 0
+
+	// Let the user pick from the groups awaiting promotion when running
+	// interactively without any groups given
+	if len(groups) == 0 && !autoYes && !structured && isTty() {
+		groups, err = selectPromoteGroups(c.Ui, deploy)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+	}
+
 	var u *api.DeploymentUpdateResponse
 	if len(groups) == 0 {
 		u, _, err = client.Deployments().PromoteAll(deploy.ID, nil)
@@ -140,6 +174,26 @@ func (c *DeploymentPromoteCommand) Run(args []string) int {
 		return 1
 	}
 
+	// Read the deployment back to report what was promoted
+	promoted, _, err := client.Deployments().Info(deploy.ID, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving deployment: %s", err))
+		return 1
+	}
+	result := newDeploymentPromoteResult(promoted, groups, u.EvalID)
+
+	if structured {
+		out, err := Format(json, tmpl, result)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+		return 0
+	}
+
+	c.Ui.Output(formatDeploymentPromoteResult(result, length))
+
 	evalCreated := u.EvalID != ""
 
 	// Nothing to do
@@ -155,3 +209,124 @@ func (c *DeploymentPromoteCommand) Run(args []string) int {
 	mon := newMonitor(c.Ui, client, length)
 	return mon.monitor(u.EvalID)
 }
+
+// DeploymentPromoteResult is the structured result of a promotion, as output
+// with the -json and -t flags.
+type DeploymentPromoteResult struct {
+	DeploymentID string
+	EvalID       string
+	Groups       []*DeploymentPromoteGroup
+}
+
+// DeploymentPromoteGroup describes the canaries of a task group after the
+// promotion.
+type DeploymentPromoteGroup struct {
+	Name            string
+	Promoted        bool
+	DesiredCanaries int
+	PlacedCanaries  int
+	HealthyAllocs   int
+}
+
+// newDeploymentPromoteResult builds the result for the requested groups, or
+// every group with canaries if all groups were promoted.
+func newDeploymentPromoteResult(deploy *api.Deployment, groups []string, evalID string) *DeploymentPromoteResult {
+	result := &DeploymentPromoteResult{
+		DeploymentID: deploy.ID,
+		EvalID:       evalID,
+	}
+
+	if len(groups) == 0 {
+		for name, state := range deploy.TaskGroups {
+			if state.DesiredCanaries > 0 {
+				groups = append(groups, name)
+			}
+		}
+	}
+	sort.Strings(groups)
+
+	for _, name := range groups {
+		group := &DeploymentPromoteGroup{Name: name}
+		if state, ok := deploy.TaskGroups[name]; ok {
+			group.Promoted = state.Promoted
+			group.DesiredCanaries = state.DesiredCanaries
+			group.PlacedCanaries = len(state.PlacedCanaries)
+			group.HealthyAllocs = state.HealthyAllocs
+		}
+		result.Groups = append(result.Groups, group)
+	}
+	return result
+}
+
+func formatDeploymentPromoteResult(result *DeploymentPromoteResult, uuidLength int) string {
+	if len(result.Groups) == 0 {
+		return fmt.Sprintf("No canaries to promote in deployment %q", limit(result.DeploymentID, uuidLength))
+	}
+
+	rows := make([]string, len(result.Groups)+1)
+	rows[0] = "Task Group|Promoted|Desired Canaries|Placed Canaries|Healthy"
+	for i, group := range result.Groups {
+		rows[i+1] = fmt.Sprintf("%s|%v|%d|%d|%d",
+			group.Name, group.Promoted, group.DesiredCanaries,
+			group.PlacedCanaries, group.HealthyAllocs)
+	}
+	return fmt.Sprintf("Promoted deployment %q\n\n%s",
+		limit(result.DeploymentID, uuidLength), formatList(rows))
+}
+
+// selectPromoteGroups lists the groups of the deployment with canaries
+// awaiting promotion and asks the user which of them to promote. An empty
+// selection promotes all groups and returns no group names.
+func selectPromoteGroups(ui cli.Ui, deploy *api.Deployment) ([]string, error) {
+	var pending []string
+	for name, state := range deploy.TaskGroups {
+		if state.DesiredCanaries > 0 && !state.Promoted {
+			pending = append(pending, name)
+		}
+	}
+
+	// There is nothing to choose from
+	if len(pending) < 2 {
+		return nil, nil
+	}
+	sort.Strings(pending)
+
+	rows := make([]string, len(pending)+1)
+	rows[0] = "#|Task Group|Desired Canaries|Placed Canaries|Healthy"
+	for i, name := range pending {
+		state := deploy.TaskGroups[name]
+		rows[i+1] = fmt.Sprintf("%d|%s|%d|%d|%d", i+1, name,
+			state.DesiredCanaries, len(state.PlacedCanaries), state.HealthyAllocs)
+	}
+	ui.Output(fmt.Sprintf("Groups with canaries awaiting promotion:\n\n%s\n", formatList(rows)))
+
+	answer, err := ui.Ask("Groups to promote (comma separated names or numbers, empty for all):")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse answer: %v", err)
+	}
+
+	var selected []string
+	seen := map[string]bool{}
+	for _, field := range strings.Split(answer, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		name := field
+		if n, err := strconv.Atoi(field); err == nil {
+			if n < 1 || n > len(pending) {
+				return nil, fmt.Errorf("Invalid selection %d: must be between 1 and %d", n, len(pending))
+			}
+			name = pending[n-1]
+		} else if _, ok := deploy.TaskGroups[name]; !ok {
+			return nil, fmt.Errorf("Deployment has no task group %q", name)
+		}
+
+		if !seen[name] {
+			seen[name] = true
+			selected = append(selected, name)
+		}
+	}
+	return selected, nil
+}
@@ -4,11 +4,14 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/mitchellh/cli"
 	"github.com/posener/complete"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDeploymentPromoteCommand_Implements(t *testing.T) {
@@ -62,3 +65,93 @@ func TestDeploymentPromoteCommand_AutocompleteArgs(t *testing.T) {
 	assert.Equal(1, len(res))
 	assert.Equal(d.ID, res[0])
 }
+
+func TestDeploymentPromoteCommand_selectPromoteGroups(t *testing.T) {
+	ci.Parallel(t)
+
+	deploy := &api.Deployment{
+		ID: uuid.Generate(),
+		TaskGroups: map[string]*api.DeploymentState{
+			"web":    {DesiredCanaries: 2, PlacedCanaries: []string{"a", "b"}, HealthyAllocs: 2},
+			"api":    {DesiredCanaries: 1, PlacedCanaries: []string{"c"}},
+			"cache":  {DesiredCanaries: 1, Promoted: true},
+			"worker": {DesiredTotal: 3},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		answer   string
+		expected []string
+		err      string
+	}{
+		{name: "all", answer: "\n", expected: nil},
+		{name: "by number", answer: "2\n", expected: []string{"web"}},
+		{name: "by name and number", answer: "web, 1, web\n", expected: []string{"web", "api"}},
+		{name: "out of range", answer: "3\n", err: "Invalid selection 3"},
+		{name: "unknown group", answer: "db\n", err: `Deployment has no task group "db"`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			ui.InputReader = strings.NewReader(tc.answer)
+
+			groups, err := selectPromoteGroups(ui, deploy)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, groups)
+
+			// Only groups awaiting promotion are offered, in order
+			out := ui.OutputWriter.String()
+			require.Contains(t, out, "1  api")
+			require.Contains(t, out, "2  web")
+			require.NotContains(t, out, "cache")
+			require.NotContains(t, out, "worker")
+		})
+	}
+
+	t.Run("single group", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		single := &api.Deployment{TaskGroups: map[string]*api.DeploymentState{
+			"web": {DesiredCanaries: 1},
+		}}
+		groups, err := selectPromoteGroups(ui, single)
+		require.NoError(t, err)
+		require.Nil(t, groups)
+		require.Empty(t, ui.OutputWriter.String())
+	})
+}
+
+func TestDeploymentPromoteCommand_Result(t *testing.T) {
+	ci.Parallel(t)
+
+	deploy := &api.Deployment{
+		ID: uuid.Generate(),
+		TaskGroups: map[string]*api.DeploymentState{
+			"web":    {DesiredCanaries: 2, PlacedCanaries: []string{"a", "b"}, HealthyAllocs: 2, Promoted: true},
+			"api":    {DesiredCanaries: 1, PlacedCanaries: []string{"c"}, Promoted: true},
+			"worker": {DesiredTotal: 3},
+		},
+	}
+
+	// All groups with canaries are reported when promoting all
+	result := newDeploymentPromoteResult(deploy, nil, "eval-id")
+	require.Equal(t, deploy.ID, result.DeploymentID)
+	require.Equal(t, "eval-id", result.EvalID)
+	require.Equal(t, []*DeploymentPromoteGroup{
+		{Name: "api", Promoted: true, DesiredCanaries: 1, PlacedCanaries: 1},
+		{Name: "web", Promoted: true, DesiredCanaries: 2, PlacedCanaries: 2, HealthyAllocs: 2},
+	}, result.Groups)
+
+	// Only the requested groups are reported otherwise
+	result = newDeploymentPromoteResult(deploy, []string{"web"}, "")
+	require.Len(t, result.Groups, 1)
+	require.Equal(t, "web", result.Groups[0].Name)
+
+	out := formatDeploymentPromoteResult(result, fullId)
+	require.Contains(t, out, deploy.ID)
+	require.Contains(t, out, "web         true")
+}
@@ -50,6 +50,12 @@ Status Options:
 
   -t
     Format and display deployment using a Go template.
+
+  -watch
+    Refresh the output whenever the status changes, until interrupted. Changes
+    are detected with blocking queries and the output is refreshed at most
+    once every 2 seconds, or at the interval given as a duration, for example
+    -watch=10s. With -json, each change is written as a single line of JSON.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -65,6 +71,7 @@ func (c *DeploymentStatusCommand) AutocompleteFlags() complete.Flags {
 			"-json":    complete.PredictNothing,
 			"-monitor": complete.PredictNothing,
 			"-t":       complete.PredictAnything,
+			"-watch":   complete.PredictNothing,
 		})
 }
 
@@ -88,6 +95,7 @@ func (c *DeploymentStatusCommand) Name() string { return "deployment status" }
 func (c *DeploymentStatusCommand) Run(args []string) int {
 	var json, verbose, monitor bool
 	var tmpl string
+	var watch watchFlag
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
@@ -95,6 +103,7 @@ func (c *DeploymentStatusCommand) Run(args []string) int {
 	flags.BoolVar(&json, "json", false, "")
 	flags.BoolVar(&monitor, "monitor", false, "")
 	flags.StringVar(&tmpl, "t", "", "")
+	flags.Var(&watch, "watch", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -105,6 +114,10 @@ func (c *DeploymentStatusCommand) Run(args []string) int {
 		c.Ui.Error("The monitor flag cannot be used with the '-json' or '-t' flags")
 		return 1
 	}
+	if monitor && watch.enabled {
+		c.Ui.Error("The monitor flag cannot be used with the '-watch' flag")
+		return 1
+	}
 
 	// Check that we got exactly one argument
 	args = flags.Args()
@@ -114,12 +127,6 @@ func (c *DeploymentStatusCommand) Run(args []string) int {
 		return 1
 	}
 
-	// Truncate the id unless full length is requested
-	length := shortId
-	if verbose {
-		length = fullId
-	}
-
 	// Get the HTTP client
 	client, err := c.Meta.Client()
 	if err != nil {
@@ -127,6 +134,45 @@ func (c *DeploymentStatusCommand) Run(args []string) int {
 		return 1
 	}
 
+	if !watch.enabled {
+		return c.output(client, args, json, tmpl, monitor, verbose)
+	}
+
+	var query watchQuery
+	if len(args) == 0 {
+		query = func(q *api.QueryOptions) (uint64, error) {
+			_, meta, err := client.Deployments().List(q)
+			return metaIndex(meta), err
+		}
+	} else {
+		// Resolve the deployment once, so that the blocking query doesn't
+		// have to match it by prefix. If it can't be resolved, the output
+		// explains why.
+		deploy, possible, err := getDeployment(client.Deployments(), args[0])
+		if err != nil || len(possible) != 0 {
+			return c.output(client, args, json, tmpl, monitor, verbose)
+		}
+		args = []string{deploy.ID}
+
+		query = func(q *api.QueryOptions) (uint64, error) {
+			_, meta, err := client.Deployments().Info(deploy.ID, q)
+			return metaIndex(meta), err
+		}
+	}
+	return c.watch(&watch, json, []watchQuery{query}, func() int {
+		return c.output(client, args, json, tmpl, monitor, verbose)
+	})
+}
+
+// output displays the list of deployments, or the status of the deployment
+// given in args.
+func (c *DeploymentStatusCommand) output(client *api.Client, args []string, json bool, tmpl string, monitor, verbose bool) int {
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
 	// List if no arguments are provided
 	if len(args) == 0 {
 		deploys, _, err := client.Deployments().List(nil)
@@ -592,7 +638,7 @@ func formatMultiregionDeployment(regions map[string]*api.Deployment, uuidLength
 
 func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 	// Detect if we need to add these columns
-	var canaries, autorevert, progressDeadline bool
+	var canaries, autorevert, progressDeadline, unknown bool
 	tgNames := make([]string, 0, len(d.TaskGroups))
 	for name, state := range d.TaskGroups {
 		tgNames = append(tgNames, name)
@@ -605,6 +651,9 @@ func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 		if state.ProgressDeadline != 0 {
 			progressDeadline = true
 		}
+		if state.UnknownAllocs > 0 {
+			unknown = true
+		}
 	}
 
 	// Sort the task group names to get a reliable ordering
@@ -623,6 +672,9 @@ func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 		rowString += "Canaries|"
 	}
 	rowString += "Placed|Healthy|Unhealthy"
+	if unknown {
+		rowString += "|Unknown"
+	}
 	if progressDeadline {
 		rowString += "|Progress Deadline"
 	}
@@ -648,6 +700,9 @@ func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 			row += fmt.Sprintf("%d|", state.DesiredCanaries)
 		}
 		row += fmt.Sprintf("%d|%d|%d", state.PlacedAllocs, state.HealthyAllocs, state.UnhealthyAllocs)
+		if unknown {
+			row += fmt.Sprintf("|%d", state.UnknownAllocs)
+		}
 		if progressDeadline {
 			if state.RequireProgressBy.IsZero() {
 				row += fmt.Sprintf("|%v", "N/A")
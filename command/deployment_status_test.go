@@ -49,6 +49,20 @@ func TestDeploymentStatusCommand_Fails(t *testing.T) {
 		require.Contains(t, out, "The monitor flag cannot be used with the '-json' or '-t' flags")
 		ui.ErrorWriter.Reset()
 	}
+
+	// Fails if monitor passed with watch
+	code = cmd.Run([]string{"-monitor", "-watch", "12"})
+	require.Equal(t, 1, code)
+	out = ui.ErrorWriter.String()
+	require.Contains(t, out, "The monitor flag cannot be used with the '-watch' flag")
+	ui.ErrorWriter.Reset()
+
+	// Fails to resolve the deployment to watch
+	code = cmd.Run([]string{"-address=nope", "-watch", "12"})
+	require.Equal(t, 1, code)
+	out = ui.ErrorWriter.String()
+	require.Contains(t, out, "Error retrieving deployment")
+	ui.ErrorWriter.Reset()
 }
 
 func TestDeploymentStatusCommand_AutocompleteArgs(t *testing.T) {
@@ -32,6 +32,10 @@ Eval Status Options:
   -monitor
     Monitor an outstanding evaluation
 
+  -explain
+    Explain the decisions the scheduler made about the allocations of
+    disconnected and reconnected clients while processing the evaluation.
+
   -verbose
     Show full information.
 
@@ -52,6 +56,7 @@ func (c *EvalStatusCommand) Synopsis() string {
 func (c *EvalStatusCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
+			"-explain": complete.PredictNothing,
 			"-json":    complete.PredictNothing,
 			"-monitor": complete.PredictNothing,
 			"-t":       complete.PredictAnything,
@@ -81,13 +86,14 @@ func (c *EvalStatusCommand) AutocompleteArgs() complete.Predictor {
 func (c *EvalStatusCommand) Name() string { return "eval status" }
 
 func (c *EvalStatusCommand) Run(args []string) int {
-	var monitor, verbose, json bool
+	var monitor, verbose, explain, json bool
 	var tmpl string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&monitor, "monitor", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.BoolVar(&explain, "explain", false, "")
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&tmpl, "t", "", "")
 
@@ -194,11 +200,11 @@ func (c *EvalStatusCommand) Run(args []string) int {
 	// Format eval timestamps
 	var formattedCreateTime, formattedModifyTime string
 	if verbose {
-		formattedCreateTime = formatUnixNanoTime(eval.CreateTime)
-		formattedModifyTime = formatUnixNanoTime(eval.ModifyTime)
+		formattedCreateTime = formatTime(eval.CreateTime)
+		formattedModifyTime = formatTime(eval.ModifyTime)
 	} else {
-		formattedCreateTime = prettyTimeDiff(time.Unix(0, eval.CreateTime), time.Now())
-		formattedModifyTime = prettyTimeDiff(time.Unix(0, eval.ModifyTime), time.Now())
+		formattedCreateTime = prettyTimeDiff(eval.CreateTime, time.Now())
+		formattedModifyTime = prettyTimeDiff(eval.ModifyTime, time.Now())
 	}
 
 	// Format the evaluation data
@@ -257,9 +263,63 @@ func (c *EvalStatusCommand) Run(args []string) int {
 		}
 	}
 
+	if explain {
+		c.Ui.Output(c.Colorize().Color("\n[bold]Disconnect Decisions[reset]"))
+		c.Ui.Output(formatEvalExplanation(eval.Explanation, length))
+	}
+
 	return 0
 }
 
+// formatEvalExplanation formats the decisions the scheduler made about the
+// allocations of disconnected and reconnected clients.
+func formatEvalExplanation(e *api.EvalExplanation, length int) string {
+	if e == nil || len(e.Allocations) == 0 {
+		return "No allocations of disconnected or reconnected clients"
+	}
+
+	var out strings.Builder
+	var settings []string
+	if e.DisconnectStrategy != nil && e.DisconnectStrategy.MaxUnknown != nil {
+		settings = append(settings, fmt.Sprintf("Max Unknown|%d", *e.DisconnectStrategy.MaxUnknown))
+	}
+	if e.ReconnectStabilization > 0 {
+		settings = append(settings, fmt.Sprintf("Reconnect Stabilization|%s", e.ReconnectStabilization))
+	}
+	if len(settings) > 0 {
+		out.WriteString(formatKV(settings))
+		out.WriteString("\n\n")
+	}
+
+	rows := make([]string, len(e.Allocations)+1)
+	rows[0] = "Alloc ID|Task Group|Name|Node ID|Node Status|Client Status|Decision|Reason|Details"
+	for i, alloc := range e.Allocations {
+		var details []string
+		if !alloc.DisconnectTimeout.IsZero() {
+			details = append(details, "timeout "+formatTime(alloc.DisconnectTimeout))
+		}
+		if !alloc.StabilizeUntil.IsZero() {
+			details = append(details, "stabilizes "+formatTime(alloc.StabilizeUntil))
+		}
+		if alloc.ReplacementID != "" {
+			details = append(details, fmt.Sprintf("score %.3f vs %.3f for replacement %s",
+				alloc.Score, alloc.ReplacementScore, limit(alloc.ReplacementID, length)))
+		}
+		rows[i+1] = fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s|%s|%s",
+			limit(alloc.AllocID, length),
+			alloc.TaskGroup,
+			alloc.AllocName,
+			limit(alloc.NodeID, length),
+			alloc.NodeStatus,
+			alloc.ClientStatus,
+			alloc.Decision,
+			alloc.Reason,
+			strings.Join(details, ", "))
+	}
+	out.WriteString(formatList(rows))
+	return out.String()
+}
+
 func sortedTaskGroupFromMetrics(groups map[string]*api.AllocationMetric) []string {
 	tgs := make([]string, 0, len(groups))
 	for tg := range groups {
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -85,3 +86,32 @@ func TestEvalStatusCommand_AutocompleteArgs(t *testing.T) {
 	assert.Equal(1, len(res))
 	assert.Equal(e.ID, res[0])
 }
+
+func TestEvalStatusCommand_formatEvalExplanation(t *testing.T) {
+	ci.Parallel(t)
+
+	out := formatEvalExplanation(nil, shortId)
+	assert.Equal(t, "No allocations of disconnected or reconnected clients", out)
+
+	maxUnknown := 1
+	out = formatEvalExplanation(&api.EvalExplanation{
+		DisconnectStrategy: &api.DisconnectStrategy{MaxUnknown: &maxUnknown},
+		Allocations: []*api.AllocExplanation{{
+			AllocID:          "11111111-1111-1111-1111-111111111111",
+			AllocName:        "example.web[0]",
+			TaskGroup:        "web",
+			NodeID:           "22222222-2222-2222-2222-222222222222",
+			NodeStatus:       "ready",
+			ClientStatus:     "running",
+			Decision:         api.AllocDecisionStop,
+			Reason:           "replacement has a higher score",
+			ReplacementID:    "33333333-3333-3333-3333-333333333333",
+			Score:            0.5,
+			ReplacementScore: 0.75,
+		}},
+	}, shortId)
+	assert.Contains(t, out, "Max Unknown")
+	assert.Contains(t, out, "11111111")
+	assert.Contains(t, out, "replacement has a higher score")
+	assert.Contains(t, out, "score 0.500 vs 0.750 for replacement 33333333")
+}
@@ -0,0 +1,257 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/posener/complete"
+)
+
+const (
+	// fmtExitUnformatted is the exit code of the fmt command when it is run
+	// with -check and some files are not formatted.
+	fmtExitUnformatted = 3
+)
+
+// fmtExtensions are the extensions of the files the fmt command formats when
+// given a directory. They cover jobspecs and secure variable specifications.
+var fmtExtensions = []string{".nomad", ".hcl"}
+
+// FormatCommand rewrites jobspecs and secure variable specifications in the
+// canonical HCL format.
+type FormatCommand struct {
+	Meta
+}
+
+func (c *FormatCommand) Help() string {
+	helpText := `
+Usage: nomad fmt [options] [<path>...]
+
+  Rewrites HCL jobspecs and secure variable specifications to the canonical
+  format and style. Each path may be a file or a directory, in which case the
+  files ending in ".nomad" or ".hcl" in the directory are formatted. Without
+  any path, the files in the current directory are formatted. If the path is
+  "-", the content read from stdin is formatted to stdout.
+
+  Files are parsed before they are formatted, and files with syntax errors are
+  reported and left untouched.
+
+Format Options:
+
+  -check
+    Check if the files are formatted without modifying them. The command exits
+    with code 3 if any file is not formatted, which is useful in CI.
+
+  -list=<bool>
+    List the files whose formatting differs from the canonical format.
+    Defaults to true.
+
+  -recursive
+    Also format the files in the subdirectories of the given directories.
+
+  -write=<bool>
+    Write the canonical format to the files instead of only reporting them.
+    Disabled by -check. Defaults to true.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *FormatCommand) Synopsis() string {
+	return "Rewrite jobspecs and secure variable specifications to the canonical format"
+}
+
+func (c *FormatCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-check":     complete.PredictNothing,
+		"-list":      complete.PredictSet("true", "false"),
+		"-recursive": complete.PredictNothing,
+		"-write":     complete.PredictSet("true", "false"),
+	}
+}
+
+func (c *FormatCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictOr(
+		complete.PredictFiles("*.nomad"),
+		complete.PredictFiles("*.hcl"),
+		complete.PredictDirs("*"),
+	)
+}
+
+func (c *FormatCommand) Name() string { return "fmt" }
+
+// fmtResult summarizes the files formatted by a run of the fmt command.
+type fmtResult struct {
+	unformatted bool
+	failed      bool
+}
+
+func (c *FormatCommand) Run(args []string) int {
+	var check, list, recursive, write bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetNone)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&check, "check", false, "")
+	flags.BoolVar(&list, "list", true, "")
+	flags.BoolVar(&recursive, "recursive", false, "")
+	flags.BoolVar(&write, "write", true, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+	if check {
+		write = false
+	}
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	var result fmtResult
+	for _, path := range paths {
+		if path == "-" {
+			c.formatStdin(check, &result)
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading %q: %s", path, err))
+			result.failed = true
+			continue
+		}
+		if !info.IsDir() {
+			c.formatFile(path, list, write, &result)
+			continue
+		}
+
+		files, err := fmtDirFiles(path, recursive)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading directory %q: %s", path, err))
+			result.failed = true
+			continue
+		}
+		for _, file := range files {
+			c.formatFile(file, list, write, &result)
+		}
+	}
+
+	switch {
+	case result.failed:
+		return 1
+	case check && result.unformatted:
+		return fmtExitUnformatted
+	}
+	return 0
+}
+
+// formatFile formats a single file, listing it if its format changed and
+// writing the canonical format back if write is set.
+func (c *FormatCommand) formatFile(path string, list, write bool, result *fmtResult) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading %q: %s", path, err))
+		result.failed = true
+		return
+	}
+
+	out, err := formatHCL(path, src)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		result.failed = true
+		return
+	}
+	if bytes.Equal(src, out) {
+		return
+	}
+	result.unformatted = true
+
+	if list {
+		c.Ui.Output(path)
+	}
+	if write {
+		info, err := os.Stat(path)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading %q: %s", path, err))
+			result.failed = true
+			return
+		}
+		if err := os.WriteFile(path, out, info.Mode().Perm()); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error writing %q: %s", path, err))
+			result.failed = true
+		}
+	}
+}
+
+// formatStdin formats the content read from stdin to stdout, or only checks
+// if it is formatted.
+func (c *FormatCommand) formatStdin(check bool, result *fmtResult) {
+	src, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading stdin: %s", err))
+		result.failed = true
+		return
+	}
+
+	out, err := formatHCL("<stdin>", src)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		result.failed = true
+		return
+	}
+	if !bytes.Equal(src, out) {
+		result.unformatted = true
+	}
+	if !check {
+		c.Ui.Output(strings.TrimSuffix(string(out), "\n"))
+	}
+}
+
+// formatHCL returns the canonical format of the HCL source. The source is
+// parsed first, since formatting a source with syntax errors could change its
+// meaning.
+func formatHCL(filename string, src []byte) ([]byte, error) {
+	_, diags := hclsyntax.ParseConfig(src, filename, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("Error parsing %q: %s", filename, diags.Error())
+	}
+	return hclwrite.Format(src), nil
+}
+
+// fmtDirFiles returns the files of the directory to format, including those
+// of its subdirectories if recursive is set. Hidden subdirectories are
+// skipped.
+func fmtDirFiles(dir string, recursive bool) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == dir {
+				return nil
+			}
+			if !recursive || strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		for _, ext := range fmtExtensions {
+			if strings.HasSuffix(d.Name(), ext) {
+				files = append(files, path)
+				break
+			}
+		}
+		return nil
+	})
+	return files, err
+}
@@ -0,0 +1,98 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	fmtTestUnformatted = `job "example" {
+datacenters = ["dc1"]
+  group "cache" {
+    count   =   1
+  }
+}
+`
+
+	fmtTestFormatted = `job "example" {
+  datacenters = ["dc1"]
+  group "cache" {
+    count = 1
+  }
+}
+`
+)
+
+func TestFormatCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &FormatCommand{}
+}
+
+func TestFormatCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+	dir := t.TempDir()
+
+	jobPath := filepath.Join(dir, "example.nomad")
+	varPath := filepath.Join(dir, "nested", "spec.nsv.hcl")
+	require.NoError(t, os.WriteFile(jobPath, []byte(fmtTestUnformatted), 0600))
+	require.NoError(t, os.MkdirAll(filepath.Dir(varPath), 0700))
+	require.NoError(t, os.WriteFile(varPath, []byte("items {\n  user =   \"admin\"\n}\n"), 0600))
+
+	// Check mode reports the unformatted files without modifying them
+	ui := cli.NewMockUi()
+	cmd := &FormatCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-check", dir})
+	require.Equal(t, fmtExitUnformatted, code)
+	require.Equal(t, jobPath+"\n", ui.OutputWriter.String())
+
+	content, err := os.ReadFile(jobPath)
+	require.NoError(t, err)
+	require.Equal(t, fmtTestUnformatted, string(content))
+
+	// Subdirectories are only formatted with -recursive
+	ui = cli.NewMockUi()
+	cmd = &FormatCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-recursive", dir})
+	require.Zero(t, code, ui.ErrorWriter.String())
+	require.Equal(t, jobPath+"\n"+varPath+"\n", ui.OutputWriter.String())
+
+	content, err = os.ReadFile(jobPath)
+	require.NoError(t, err)
+	require.Equal(t, fmtTestFormatted, string(content))
+
+	content, err = os.ReadFile(varPath)
+	require.NoError(t, err)
+	require.Equal(t, "items {\n  user = \"admin\"\n}\n", string(content))
+
+	// Formatted files pass the check
+	ui = cli.NewMockUi()
+	cmd = &FormatCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-check", "-recursive", dir})
+	require.Zero(t, code)
+	require.Empty(t, ui.OutputWriter.String())
+}
+
+func TestFormatCommand_SyntaxError(t *testing.T) {
+	ci.Parallel(t)
+	dir := t.TempDir()
+
+	invalid := "job \"example\" {\n  datacenters = [\n"
+	path := filepath.Join(dir, "invalid.nomad")
+	require.NoError(t, os.WriteFile(path, []byte(invalid), 0600))
+
+	ui := cli.NewMockUi()
+	cmd := &FormatCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{path})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "Error parsing")
+
+	// Files with syntax errors are left untouched
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, invalid, string(content))
+}
@@ -88,12 +88,6 @@ func formatTime(t time.Time) string {
 	return t.Format("2006-01-02T15:04:05Z07:00")
 }
 
-// formatUnixNanoTime is a helper for formatting time for output.
-func formatUnixNanoTime(nano int64) string {
-	t := time.Unix(0, nano)
-	return formatTime(t)
-}
-
 // formatTimeDifference takes two times and determines their duration difference
 // truncating to a passed unit.
 // E.g. formatTimeDifference(first=1m22s33ms, second=1m28s55ms, time.Second) -> 6s
@@ -488,20 +482,27 @@ func (j *JobGetter) Get(jpath string) (*api.Job, error) {
 		jobStruct, err = jobspec.Parse(jobfile)
 	case j.JSON:
 		// Support JSON files with both a top-level Job key as well as
-		// ones without.
-		eitherJob := struct {
-			NestedJob *api.Job `json:"Job"`
-			api.Job
-		}{}
+		// ones without. api.Job has its own JSON decoder, so it can't be
+		// embedded alongside the nested key and is decoded separately.
+		var buf bytes.Buffer
+		if _, err := io.Copy(&buf, jobfile); err != nil {
+			return nil, fmt.Errorf("Error reading job file from %s: %v", jpath, err)
+		}
 
-		if err := json.NewDecoder(jobfile).Decode(&eitherJob); err != nil {
+		var nested struct {
+			Job *api.Job
+		}
+		if err := json.Unmarshal(buf.Bytes(), &nested); err != nil {
 			return nil, fmt.Errorf("Failed to parse JSON job: %w", err)
 		}
 
-		if eitherJob.NestedJob != nil {
-			jobStruct = eitherJob.NestedJob
+		if nested.Job != nil {
+			jobStruct = nested.Job
 		} else {
-			jobStruct = &eitherJob.Job
+			jobStruct = new(api.Job)
+			if err := json.Unmarshal(buf.Bytes(), jobStruct); err != nil {
+				return nil, fmt.Errorf("Failed to parse JSON job: %w", err)
+			}
 		}
 	default:
 		var buf bytes.Buffer
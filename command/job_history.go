@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/api/contexts"
@@ -262,7 +261,7 @@ func (c *JobHistoryCommand) formatJobVersion(job *api.Job, diff *api.JobDiff, ne
 	basic := []string{
 		fmt.Sprintf("Version|%d", *job.Version),
 		fmt.Sprintf("Stable|%v", *job.Stable),
-		fmt.Sprintf("Submit Date|%v", formatTime(time.Unix(0, *job.SubmitTime))),
+		fmt.Sprintf("Submit Date|%v", formatTime(*job.SubmitTime)),
 	}
 
 	if diff != nil {
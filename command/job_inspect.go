@@ -37,6 +37,8 @@ Inspect Options:
 
   -t
     Format and display job using a Go template.
+
+  ` + redactSensitiveUsage + `
 `
 	return strings.TrimSpace(helpText)
 }
@@ -48,9 +50,10 @@ func (c *JobInspectCommand) Synopsis() string {
 func (c *JobInspectCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-version": complete.PredictAnything,
-			"-json":    complete.PredictNothing,
-			"-t":       complete.PredictAnything,
+			"-version":          complete.PredictAnything,
+			"-json":             complete.PredictNothing,
+			"-t":                complete.PredictAnything,
+			"-redact-sensitive": complete.PredictNothing,
 		})
 }
 
@@ -72,7 +75,7 @@ func (c *JobInspectCommand) AutocompleteArgs() complete.Predictor {
 func (c *JobInspectCommand) Name() string { return "job inspect" }
 
 func (c *JobInspectCommand) Run(args []string) int {
-	var json bool
+	var json, redactSensitive bool
 	var tmpl, versionStr string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
@@ -80,6 +83,7 @@ func (c *JobInspectCommand) Run(args []string) int {
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&tmpl, "t", "", "")
 	flags.StringVar(&versionStr, "version", "", "")
+	flags.BoolVar(&redactSensitive, "redact-sensitive", false, "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -154,6 +158,10 @@ func (c *JobInspectCommand) Run(args []string) int {
 		return 1
 	}
 
+	if redactSensitive {
+		redactJob(job, sensitivePatterns())
+	}
+
 	// If output format is specified, format and output the data
 	if json || len(tmpl) > 0 {
 		out, err := Format(json, tmpl, job)
@@ -1,14 +1,17 @@
 package command
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/mitchellh/cli"
 	"github.com/posener/complete"
+	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -83,3 +86,34 @@ func TestInspectCommand_AutocompleteArgs(t *testing.T) {
 	assert.Equal(1, len(res))
 	assert.Equal(j.ID, res[0])
 }
+
+func TestInspectCommand_RedactSensitive(t *testing.T) {
+	ci.Parallel(t)
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	job := testJob("redact")
+	job.TaskGroups[0].Tasks[0].Env = map[string]string{"DB_PASSWORD": "hunter2", "MONKEY": "banana"}
+	_, _, err := client.Jobs().Register(job, nil)
+	must.NoError(t, err)
+
+	inspect := func(args ...string) *api.Job {
+		ui := cli.NewMockUi()
+		cmd := &JobInspectCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run(append([]string{"-address=" + url, "-json"}, args...))
+		must.Zero(t, code, must.Sprint(ui.ErrorWriter.String()))
+
+		var out api.Job
+		must.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &out))
+		return &out
+	}
+
+	// The output is unredacted by default so it can be submitted back
+	out := inspect("redact")
+	must.Eq(t, map[string]string{"DB_PASSWORD": "hunter2", "MONKEY": "banana"},
+		out.TaskGroups[0].Tasks[0].Env)
+
+	out = inspect("-redact-sensitive", "redact")
+	must.Eq(t, map[string]string{"DB_PASSWORD": redactedValue, "MONKEY": "banana"},
+		out.TaskGroups[0].Tasks[0].Env)
+}
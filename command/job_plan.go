@@ -315,6 +315,11 @@ func (c *JobPlanCommand) outputPlannedJob(job *api.Job, resp *api.JobPlanRespons
 			c.Colorize().Color(fmt.Sprintf("[bold][yellow]Job Warnings:\n%s[reset]\n", resp.Warnings)))
 	}
 
+	// Print the chosen datacenters if the job has weighted datacenters
+	if resp.Annotations != nil && hasWeightedDatacenters(job) {
+		c.addDatacenterPlacements(resp)
+	}
+
 	// Print preemptions if there are any
 	if resp.Annotations != nil && len(resp.Annotations.PreemptedAllocs) > 0 {
 		c.addPreemptions(resp)
@@ -323,6 +328,46 @@ func (c *JobPlanCommand) outputPlannedJob(job *api.Job, resp *api.JobPlanRespons
 	return getExitCode(resp)
 }
 
+// hasWeightedDatacenters returns whether any of the job datacenters has a
+// placement weight.
+func hasWeightedDatacenters(job *api.Job) bool {
+	for _, dc := range job.Datacenters {
+		if strings.Contains(dc, ":") {
+			return true
+		}
+	}
+	return false
+}
+
+// addDatacenterPlacements shows the datacenters chosen for new placements
+func (c *JobPlanCommand) addDatacenterPlacements(resp *api.JobPlanResponse) {
+	tgs := make([]string, 0, len(resp.Annotations.DesiredTGUpdates))
+	for tg := range resp.Annotations.DesiredTGUpdates {
+		tgs = append(tgs, tg)
+	}
+	sort.Strings(tgs)
+
+	placements := []string{"Task Group|Datacenter|Placements"}
+	for _, tg := range tgs {
+		dcs := resp.Annotations.DesiredTGUpdates[tg].Datacenters
+		names := make([]string, 0, len(dcs))
+		for dc := range dcs {
+			names = append(names, dc)
+		}
+		sort.Strings(names)
+		for _, dc := range names {
+			placements = append(placements, fmt.Sprintf("%s|%s|%d", tg, dc, dcs[dc]))
+		}
+	}
+	if len(placements) == 1 {
+		return
+	}
+
+	c.Ui.Output(c.Colorize().Color("[bold]Datacenter Placements:[reset]"))
+	c.Ui.Output(formatList(placements))
+	c.Ui.Output("")
+}
+
 // addPreemptions shows details about preempted allocations
 func (c *JobPlanCommand) addPreemptions(resp *api.JobPlanResponse) {
 	c.Ui.Output(c.Colorize().Color("[bold][yellow]Preemptions:\n[reset]"))
@@ -528,6 +573,8 @@ func formatTaskGroupDiff(tg *api.TaskGroupDiff, tgPrefix int, verbose bool) stri
 				color = "[yellow]"
 			case scheduler.UpdateTypeCanary:
 				color = "[light_yellow]"
+			case scheduler.UpdateTypeReplaceUnknown:
+				color = "[red]"
 			}
 			updates = append(updates, fmt.Sprintf("[reset]%s%d %s", color, count, updateType))
 		}
@@ -0,0 +1,288 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type JobRestartCommand struct {
+	Meta
+}
+
+func (c *JobRestartCommand) Help() string {
+	helpText := `
+Usage: nomad job restart [options] <job id>
+
+  Restart the running allocations of a job in batches. The servers carry out
+  the restart, restarting the tasks of a batch of allocations in place and
+  waiting before the next batch, so the restart continues if this command is
+  interrupted. Use -monitor to watch a restart in progress again.
+
+  A job only has one active restart at a time. A restart which is paused,
+  either by an operator or because a batch failed to restart, can be resumed
+  with -resume, which retries the batch that failed.
+
+  When ACLs are enabled, this command requires a token with the
+  'alloc-lifecycle', 'list-jobs', and 'read-job' capabilities for the job's
+  namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Restart Options:
+
+  -batch-size=<n>
+    Number of allocations restarted at once. Defaults to 1.
+
+  -wait=<duration>
+    Time to wait after a batch is restarted before restarting the next one.
+    Defaults to 0s.
+
+  -on-error=<pause|fail>
+    What to do when a batch fails to restart. "pause" pauses the restart
+    until it is resumed with -resume, and "fail" stops it. Defaults to "fail".
+
+  -detach
+    Return immediately instead of monitoring the restart.
+
+  -monitor
+    Monitor the active restart of the job instead of starting a new one.
+
+  -pause
+    Pause the active restart of the job.
+
+  -resume
+    Resume the paused restart of the job and monitor it.
+
+  -cancel
+    Cancel the active restart of the job. Allocations already restarted are
+    not affected.
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobRestartCommand) Synopsis() string {
+	return "Restart the allocations of a job in batches"
+}
+
+func (c *JobRestartCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-batch-size": complete.PredictAnything,
+			"-wait":       complete.PredictAnything,
+			"-on-error":   complete.PredictSet(api.JobRestartOnErrorPause, api.JobRestartOnErrorFail),
+			"-detach":     complete.PredictNothing,
+			"-monitor":    complete.PredictNothing,
+			"-pause":      complete.PredictNothing,
+			"-resume":     complete.PredictNothing,
+			"-cancel":     complete.PredictNothing,
+			"-verbose":    complete.PredictNothing,
+		})
+}
+
+func (c *JobRestartCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Jobs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Jobs]
+	})
+}
+
+func (c *JobRestartCommand) Name() string { return "job restart" }
+
+func (c *JobRestartCommand) Run(args []string) int {
+	var detach, monitor, pause, resume, cancel, verbose bool
+	var batchSize int
+	var wait time.Duration
+	var onError string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.IntVar(&batchSize, "batch-size", 1, "")
+	flags.DurationVar(&wait, "wait", 0, "")
+	flags.StringVar(&onError, "on-error", api.JobRestartOnErrorFail, "")
+	flags.BoolVar(&detach, "detach", false, "")
+	flags.BoolVar(&monitor, "monitor", false, "")
+	flags.BoolVar(&pause, "pause", false, "")
+	flags.BoolVar(&resume, "resume", false, "")
+	flags.BoolVar(&cancel, "cancel", false, "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if l := len(args); l != 1 {
+		c.Ui.Error("This command takes one argument: <job id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	actions := 0
+	for _, set := range []bool{monitor, pause, resume, cancel} {
+		if set {
+			actions++
+		}
+	}
+	if actions > 1 {
+		c.Ui.Error("Only one of -monitor, -pause, -resume and -cancel may be specified")
+		return 1
+	}
+	if batchSize < 1 {
+		c.Ui.Error(fmt.Sprintf("Invalid -batch-size %d, must be at least 1", batchSize))
+		return 1
+	}
+	if wait < 0 {
+		c.Ui.Error(fmt.Sprintf("Invalid -wait %s, must not be negative", wait))
+		return 1
+	}
+	if onError != api.JobRestartOnErrorPause && onError != api.JobRestartOnErrorFail {
+		c.Ui.Error(fmt.Sprintf("Invalid -on-error %q, must be one of %q or %q",
+			onError, api.JobRestartOnErrorPause, api.JobRestartOnErrorFail))
+		return 1
+	}
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Check if the job exists
+	jobID := strings.TrimSpace(args[0])
+	jobs, _, err := client.Jobs().PrefixList(jobID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error restarting job: %s", err))
+		return 1
+	}
+	if len(jobs) == 0 {
+		c.Ui.Error(fmt.Sprintf("No job(s) with prefix or id %q found", jobID))
+		return 1
+	}
+	if len(jobs) > 1 {
+		if (jobID != jobs[0].ID) || (c.allNamespaces() && jobs[0].ID == jobs[1].ID) {
+			c.Ui.Error(fmt.Sprintf("Prefix matched multiple jobs\n\n%s", createStatusListOutput(jobs, c.allNamespaces())))
+			return 1
+		}
+	}
+	jobID = jobs[0].ID
+	q := &api.QueryOptions{Namespace: jobs[0].JobSummary.Namespace}
+	wq := &api.WriteOptions{Namespace: jobs[0].JobSummary.Namespace}
+
+	var restart *api.JobRestart
+	switch {
+	case monitor:
+		restart, _, err = client.Jobs().RestartInfo(jobID, q)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error retrieving restart of job %q: %s", jobID, err))
+			return 1
+		}
+	case pause, cancel:
+		status := api.JobRestartStatusPaused
+		if cancel {
+			status = api.JobRestartStatusCancelled
+		}
+		restart, _, err = client.Jobs().UpdateRestart(jobID, status, wq)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error updating restart of job %q: %s", jobID, err))
+			return 1
+		}
+		c.Ui.Output(fmt.Sprintf("Restart %q of job %q is %s after restarting %d of %d allocations",
+			limit(restart.ID, length), jobID, restart.Status, restart.Restarted, len(restart.AllocIDs)))
+		return 0
+	case resume:
+		restart, _, err = client.Jobs().UpdateRestart(jobID, api.JobRestartStatusRunning, wq)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error updating restart of job %q: %s", jobID, err))
+			return 1
+		}
+	default:
+		restart, _, err = client.Jobs().Restart(jobID, batchSize, wait, onError, wq)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error restarting job %q: %s", jobID, err))
+			return 1
+		}
+	}
+
+	c.Ui.Output(formatKV([]string{
+		fmt.Sprintf("Restart ID|%s", limit(restart.ID, length)),
+		fmt.Sprintf("Job ID|%s", restart.JobID),
+		fmt.Sprintf("Allocations|%d", len(restart.AllocIDs)),
+		fmt.Sprintf("Batch Size|%d", restart.BatchSize),
+		fmt.Sprintf("Batch Wait|%s", restart.BatchWait),
+		fmt.Sprintf("On Error|%s", restart.OnError),
+	}))
+
+	if detach {
+		return 0
+	}
+	c.Ui.Output("")
+	return c.monitorRestart(client, restart, q, length)
+}
+
+// monitorRestart outputs the progress of the restart until it finishes or
+// is paused. Interrupting it doesn't affect the restart.
+func (c *JobRestartCommand) monitorRestart(client *api.Client, restart *api.JobRestart,
+	q *api.QueryOptions, length int) int {
+
+	lastRestarted := -1
+	for {
+		if restart.Restarted != lastRestarted {
+			lastRestarted = restart.Restarted
+			c.Ui.Output(fmt.Sprintf("%s: Restarted %d of %d allocations",
+				formatTime(time.Now()), restart.Restarted, len(restart.AllocIDs)))
+		}
+
+		switch restart.Status {
+		case api.JobRestartStatusComplete:
+			c.Ui.Output(fmt.Sprintf("%s: Restart %q complete", formatTime(time.Now()), limit(restart.ID, length)))
+			return 0
+		case api.JobRestartStatusPaused:
+			c.Ui.Error(fmt.Sprintf("%s: Restart %q paused: %s\nResume it with \"nomad job restart -resume %s\"",
+				formatTime(time.Now()), limit(restart.ID, length), restart.StatusDescription, restart.JobID))
+			return 1
+		case api.JobRestartStatusFailed, api.JobRestartStatusCancelled:
+			c.Ui.Error(fmt.Sprintf("%s: Restart %q %s: %s",
+				formatTime(time.Now()), limit(restart.ID, length), restart.Status, restart.StatusDescription))
+			return 1
+		}
+
+		q.WaitIndex = restart.ModifyIndex
+		next, _, err := client.Jobs().RestartInfo(restart.JobID, q)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error retrieving restart of job %q: %s", restart.JobID, err))
+			return 1
+		}
+		if next.ID != restart.ID {
+			c.Ui.Error(fmt.Sprintf("Restart %q was replaced by restart %q",
+				limit(restart.ID, length), limit(next.ID, length)))
+			return 1
+		}
+		restart = next
+	}
+}
@@ -0,0 +1,82 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobRestartCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &JobRestartCommand{}
+}
+
+func TestJobRestartCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &JobRestartCommand{Meta: Meta{Ui: ui}}
+
+	cases := []struct {
+		args []string
+		err  string
+	}{
+		{[]string{"some", "bad", "args"}, commandErrorText(cmd)},
+		{[]string{"-pause", "-cancel", "example"}, "Only one of"},
+		{[]string{"-batch-size=0", "example"}, "Invalid -batch-size"},
+		{[]string{"-wait=-1s", "example"}, "Invalid -wait"},
+		{[]string{"-on-error=ignore", "example"}, "Invalid -on-error"},
+		{[]string{"-address=nope", "example"}, "Error restarting job"},
+	}
+	for _, tc := range cases {
+		code := cmd.Run(tc.args)
+		require.Equal(t, 1, code, "args: %v", tc.args)
+		require.Contains(t, ui.ErrorWriter.String(), tc.err, "args: %v", tc.args)
+		ui.ErrorWriter.Reset()
+	}
+}
+
+func TestJobRestartCommand_NoAllocs(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &JobRestartCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	state := srv.Agent.Server().State()
+	j := mock.Job()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, j))
+
+	code := cmd.Run([]string{"-address=" + url, j.ID})
+	require.Equal(t, 1, code)
+	out := ui.ErrorWriter.String()
+	require.True(t, strings.Contains(out, "no running allocations"), out)
+}
+
+func TestJobRestartCommand_AutocompleteArgs(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &JobRestartCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	// Create a fake job
+	state := srv.Agent.Server().State()
+	j := mock.Job()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, j))
+
+	prefix := j.ID[:len(j.ID)-5]
+	args := complete.Args{Last: prefix}
+	predictor := cmd.AutocompleteArgs()
+
+	res := predictor.Predict(args)
+	require.Equal(t, []string{j.ID}, res)
+}
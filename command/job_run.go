@@ -112,6 +112,14 @@ Run Options:
   -preserve-counts
     If set, the existing task group counts will be preserved when updating a job.
 
+  -sign=<path>
+    Sign the job with the ed25519 private key of the PEM file at the given
+    path, as written by "nomad operator job-signing-key generate". The public
+    key must be trusted by the servers with "nomad operator job-signing-key
+    install". Signatures are required when the servers are configured with
+    require_job_signatures. The Consul and Vault tokens are not covered by the
+    signature.
+
   -consul-token
     If set, the passed Consul token is stored in the job before sending to the
     Nomad servers. This allows passing the Consul token without storing it in
@@ -168,6 +176,7 @@ func (c *JobRunCommand) AutocompleteFlags() complete.Flags {
 			"-var":             complete.PredictAnything,
 			"-var-file":        complete.PredictFiles("*.var"),
 			"-eval-priority":   complete.PredictNothing,
+			"-sign":            complete.PredictFiles("*.pem"),
 		})
 }
 
@@ -183,7 +192,7 @@ func (c *JobRunCommand) Name() string { return "job run" }
 
 func (c *JobRunCommand) Run(args []string) int {
 	var detach, verbose, output, override, preserveCounts bool
-	var checkIndexStr, consulToken, consulNamespace, vaultToken, vaultNamespace string
+	var checkIndexStr, consulToken, consulNamespace, vaultToken, vaultNamespace, signKey string
 	var evalPriority int
 
 	flagSet := c.Meta.FlagSet(c.Name(), FlagSetClient)
@@ -204,6 +213,7 @@ func (c *JobRunCommand) Run(args []string) int {
 	flagSet.Var(&c.JobGetter.Vars, "var", "")
 	flagSet.Var(&c.JobGetter.VarFiles, "var-file", "")
 	flagSet.IntVar(&evalPriority, "eval-priority", 0, "")
+	flagSet.StringVar(&signKey, "sign", "", "")
 
 	if err := flagSet.Parse(args); err != nil {
 		return 1
@@ -318,6 +328,13 @@ func (c *JobRunCommand) Run(args []string) int {
 		opts.EnforceIndex = true
 		opts.ModifyIndex = checkIndex
 	}
+	if signKey != "" {
+		opts.Signature, err = signJob(job, signKey)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error signing job: %s", err))
+			return 1
+		}
+	}
 
 	// Submit the job
 	resp, _, err := client.Jobs().RegisterOpts(job, opts, nil)
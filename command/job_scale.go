@@ -3,6 +3,8 @@ package command
 import (
 	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -23,9 +25,16 @@ type JobScaleCommand struct {
 func (j *JobScaleCommand) Help() string {
 	helpText := `
 Usage: nomad job scale [options] <job> [<group>] <count>
+       nomad job scale [options] <job> <group>=<count> [<group>=<count>...]
 
   Perform a scaling action by altering the count within a job group.
 
+  Several groups can be scaled in a single job update by passing
+  <group>=<count> pairs. A count can be absolute, such as "5", or relative to
+  the group's current count, such as "+2", "-1" or "-50%". Percentages are
+  rounded to the nearest whole count. Relative counts are only applied if the
+  job hasn't been modified since its current counts were read.
+
   Upon successful job submission, this command will immediately
   enter an interactive monitor. This is useful to watch Nomad's
   internals make scheduling decisions and place the submitted work
@@ -46,6 +55,10 @@ Scale Options:
     the evaluation ID will be printed to the screen, which can be used to
     examine the evaluation using the eval-status command.
 
+  -message
+    The reason for the scaling action, recorded on the scaling event of each
+    group. Defaults to "submitted using the Nomad CLI".
+
   -verbose
     Display full information.
 `
@@ -61,6 +74,7 @@ func (j *JobScaleCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(j.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
 			"-detach":  complete.PredictNothing,
+			"-message": complete.PredictAnything,
 			"-verbose": complete.PredictNothing,
 		})
 }
@@ -71,21 +85,27 @@ func (j *JobScaleCommand) Name() string { return "job scale" }
 // Run satisfies the cli.Command Run function.
 func (j *JobScaleCommand) Run(args []string) int {
 	var detach, verbose bool
+	var msg string
 
 	flags := j.Meta.FlagSet(j.Name(), FlagSetClient)
 	flags.Usage = func() { j.Ui.Output(j.Help()) }
 	flags.BoolVar(&detach, "detach", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.StringVar(&msg, "message", "submitted using the Nomad CLI", "")
 	if err := flags.Parse(args); err != nil {
 		return 1
 	}
 
 	var jobString, countString, groupString string
+	var pairs []string
 	args = flags.Args()
 
-	// It is possible to specify either 2 or 3 arguments. Check and assign the
-	// args so they can be validate later on.
-	if numArgs := len(args); numArgs < 2 || numArgs > 3 {
+	// It is possible to specify either 2 or 3 arguments, or the job followed
+	// by any number of group=count pairs. Check and assign the args so they
+	// can be validate later on.
+	if numArgs := len(args); numArgs >= 2 && strings.Contains(args[1], "=") {
+		pairs = args[1:]
+	} else if numArgs < 2 || numArgs > 3 {
 		j.Ui.Error("Command requires at least two arguments and no more than three")
 		return 1
 	} else if numArgs == 3 {
@@ -96,13 +116,6 @@ func (j *JobScaleCommand) Run(args []string) int {
 	}
 	jobString = args[0]
 
-	// Convert the count string arg to an int as required by the API.
-	count, err := strconv.Atoi(countString)
-	if err != nil {
-		j.Ui.Error(fmt.Sprintf("Failed to convert count string to int: %s", err))
-		return 1
-	}
-
 	// Get the HTTP client.
 	client, err := j.Meta.Client()
 	if err != nil {
@@ -118,21 +131,75 @@ func (j *JobScaleCommand) Run(args []string) int {
 		return 1
 	}
 
-	if err := j.performGroupCheck(job.TaskGroups, &groupString); err != nil {
-		j.Ui.Error(err.Error())
-		return 1
+	counts := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		group, count, ok := strings.Cut(pair, "=")
+		if !ok || group == "" || count == "" {
+			j.Ui.Error(fmt.Sprintf("Invalid group count %q, expected <group>=<count>", pair))
+			return 1
+		}
+		if _, ok := counts[group]; ok {
+			j.Ui.Error(fmt.Sprintf("Group %v specified more than once", group))
+			return 1
+		}
+		counts[group] = count
+	}
+	if len(pairs) == 0 {
+		if err := j.performGroupCheck(job.TaskGroups, &groupString); err != nil {
+			j.Ui.Error(err.Error())
+			return 1
+		}
+		counts[groupString] = countString
 	}
 
-	// This is our default message added to scaling submissions.
-	msg := "submitted using the Nomad CLI"
+	// Resolve relative counts against the current desired counts.
+	relative := false
+	groups := make(map[string]int, len(counts))
+	for group, countString := range counts {
+		status, ok := job.TaskGroups[group]
+		if !ok {
+			j.Ui.Error(fmt.Sprintf("Group %v not found within job", group))
+			return 1
+		}
+		count, rel, err := parseScaleCount(countString, status.Desired)
+		if err != nil {
+			j.Ui.Error(fmt.Sprintf("Failed to parse count for group %v: %s", group, err))
+			return 1
+		}
+		relative = relative || rel
+		groups[group] = count
+	}
 
-	// Perform the scaling action.
-	resp, _, err := client.Jobs().Scale(jobString, groupString, &count, msg, false, nil, nil)
+	// Perform the scaling action. A single absolute count uses the original
+	// request so that it works against servers without multi-group support.
+	var resp *api.JobRegisterResponse
+	if len(groups) == 1 && !relative {
+		count := groups[groupString]
+		resp, _, err = client.Jobs().Scale(jobString, groupString, &count, msg, false, nil, nil)
+	} else {
+		var index uint64
+		if relative {
+			index = job.JobModifyIndex
+		}
+		resp, _, err = client.Jobs().ScaleGroups(jobString, groups, index, msg, nil, nil)
+	}
 	if err != nil {
 		j.Ui.Error(fmt.Sprintf("Error submitting scaling request: %s", err))
 		return 1
 	}
 
+	if len(groups) > 1 || relative {
+		names := make([]string, 0, len(groups))
+		for group := range groups {
+			names = append(names, group)
+		}
+		sort.Strings(names)
+		for _, group := range names {
+			j.Ui.Output(fmt.Sprintf("Scaling group %q from %d to %d",
+				group, job.TaskGroups[group].Desired, groups[group]))
+		}
+	}
+
 	// Print any warnings if we have some.
 	if resp.Warnings != "" {
 		j.Ui.Output(
@@ -186,3 +253,40 @@ func (j *JobScaleCommand) performGroupCheck(groups map[string]api.TaskGroupScale
 	// If we got here, we didn't find a match and therefore return an error.
 	return fmt.Errorf("Group %v not found within job", *group)
 }
+
+// parseScaleCount parses a count argument, which is either an absolute count
+// or a change relative to current such as "+2", "-1" or "-50%". It returns the
+// resulting count and whether it was relative.
+func parseScaleCount(s string, current int) (int, bool, error) {
+	relative := strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-")
+
+	var count int
+	switch {
+	case strings.HasSuffix(s, "%"):
+		if !relative {
+			return 0, false, fmt.Errorf("percentage %q must start with + or -", s)
+		}
+		pct, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to parse percentage %q: %v", s, err)
+		}
+		count = int(math.Round(float64(current) * (1 + pct/100)))
+	case relative:
+		delta, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to convert count string to int: %v", err)
+		}
+		count = current + delta
+	default:
+		c, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to convert count string to int: %v", err)
+		}
+		count = c
+	}
+
+	if count < 0 {
+		return 0, false, fmt.Errorf("count %q would scale the group below zero", s)
+	}
+	return count, relative, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
 )
 
 func TestJobScaleCommand_SingleGroup(t *testing.T) {
@@ -122,3 +123,75 @@ func TestJobScaleCommand_MultiGroup(t *testing.T) {
 		t.Fatalf("Expected Evaluation ID within output: %v", out)
 	}
 }
+
+func TestJobScaleCommand_GroupPairs(t *testing.T) {
+	ci.Parallel(t)
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &JobScaleCommand{Meta: Meta{Ui: ui}}
+
+	// Create a job with two task groups.
+	job := testJob("scale_cmd_group_pairs")
+	group2 := api.NewTaskGroup("group2", 4).
+		AddTask(api.NewTask("task2", "mock_driver").SetConfig("run_for", "5s"))
+	job.AddTaskGroup(group2)
+	_, _, err := client.Jobs().Register(job, nil)
+	must.NoError(t, err)
+
+	// Scale both groups in a single update with relative counts.
+	code := cmd.Run([]string{"-address=" + url, "-detach", "-message", "load test",
+		"scale_cmd_group_pairs", "group1=+2", "group2=-50%"})
+	must.Zero(t, code, must.Sprint(ui.ErrorWriter.String()))
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, `Scaling group "group1" from 1 to 3`)
+	must.StrContains(t, out, `Scaling group "group2" from 4 to 2`)
+
+	status, _, err := client.Jobs().ScaleStatus("scale_cmd_group_pairs", nil)
+	must.NoError(t, err)
+	for group, count := range map[string]int{"group1": 3, "group2": 2} {
+		tg := status.TaskGroups[group]
+		must.Eq(t, count, tg.Desired)
+		must.Len(t, 1, tg.Events)
+		must.Eq(t, "load test", tg.Events[0].Message)
+	}
+
+	// An unknown group fails without scaling anything.
+	code = cmd.Run([]string{"-address=" + url, "scale_cmd_group_pairs", "group1=1", "nope=1"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Group nope not found within job")
+}
+
+func TestJobScaleCommand_parseScaleCount(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		input    string
+		current  int
+		expected int
+		relative bool
+		err      bool
+	}{
+		{input: "3", current: 1, expected: 3},
+		{input: "+2", current: 1, expected: 3, relative: true},
+		{input: "-1", current: 1, expected: 0, relative: true},
+		{input: "-2", current: 1, err: true},
+		{input: "+50%", current: 4, expected: 6, relative: true},
+		{input: "-50%", current: 5, expected: 3, relative: true},
+		{input: "50%", current: 4, err: true},
+		{input: "two", current: 4, err: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			count, relative, err := parseScaleCount(tc.input, tc.current)
+			if tc.err {
+				must.Error(t, err)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.expected, count)
+			must.Eq(t, tc.relative, relative)
+		})
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/mitchellh/cli"
@@ -141,7 +140,7 @@ func formatScalingEventListOutput(e scalingEventList, verbose bool, limit int) [
 			output[i+1] += fmt.Sprintf("|%v|%s|%s",
 				e[i].event.Error, e[i].event.Message, valueOrNil(e[i].event.EvalID))
 		}
-		output[i+1] += fmt.Sprintf("|%v", formatTime(time.Unix(0, int64(e[i].event.Time))))
+		output[i+1] += fmt.Sprintf("|%v", formatTime(e[i].event.Time))
 		i++
 	}
 	return output
@@ -200,7 +199,7 @@ func (s scalingEventList) Len() int {
 // Less satisfies the Less function on the sort.Interface and sorts by the
 // event time.
 func (s scalingEventList) Less(i, j int) bool {
-	return s[i].event.Time > s[j].event.Time
+	return s[i].event.Time.After(s[j].event.Time)
 }
 
 // Swap satisfies the Swap function on the sort.Interface.
@@ -0,0 +1,83 @@
+package command
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// signJob signs the job with the ed25519 private key of the PEM file at
+// keyPath. The signature covers the job as the servers will see it, so the
+// job is sent through the same JSON encoding and conversion as the HTTP API
+// before being signed.
+func signJob(job *api.Job, keyPath string) (*api.JobSignature, error) {
+	privateKey, err := readJobSigningPrivateKey(keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := json.Marshal(job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode job: %v", err)
+	}
+	var wireJob api.Job
+	if err := json.Unmarshal(buf, &wireJob); err != nil {
+		return nil, fmt.Errorf("failed to decode job: %v", err)
+	}
+
+	sig, err := structs.SignJob(agent.ApiJobToStructJob(&wireJob), privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign job: %v", err)
+	}
+	return &api.JobSignature{
+		KeyID:     sig.KeyID,
+		Signature: sig.Signature,
+	}, nil
+}
+
+// readJobSigningPrivateKey reads an ed25519 private key from a PKCS #8 PEM
+// file, as written by "nomad operator job-signing-key generate".
+func readJobSigningPrivateKey(path string) (ed25519.PrivateKey, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %v", err)
+	}
+	block, _ := pem.Decode(buf)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode signing key %q: no PEM data found", path)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key %q: %v", path, err)
+	}
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("signing key %q is not an ed25519 private key", path)
+	}
+	return privateKey, nil
+}
+
+// readJobSigningPublicKey reads an ed25519 public key from a PKIX PEM file,
+// as written by "nomad operator job-signing-key generate".
+func readJobSigningPublicKey(buf []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(buf)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("not an ed25519 public key")
+	}
+	return publicKey, nil
+}
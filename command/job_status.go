@@ -54,6 +54,12 @@ Status Options:
 
   -verbose
     Display full information.
+
+  -watch
+    Refresh the output whenever the status changes, until interrupted. Changes
+    are detected with blocking queries and the output is refreshed at most
+    once every 2 seconds, or at the interval given as a duration, for example
+    -watch=10s.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -69,6 +75,7 @@ func (c *JobStatusCommand) AutocompleteFlags() complete.Flags {
 			"-evals":      complete.PredictNothing,
 			"-short":      complete.PredictNothing,
 			"-verbose":    complete.PredictNothing,
+			"-watch":      complete.PredictNothing,
 		})
 }
 
@@ -91,6 +98,7 @@ func (c *JobStatusCommand) Name() string { return "status" }
 
 func (c *JobStatusCommand) Run(args []string) int {
 	var short bool
+	var watch watchFlag
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
@@ -98,6 +106,7 @@ func (c *JobStatusCommand) Run(args []string) int {
 	flags.BoolVar(&c.evals, "evals", false, "")
 	flags.BoolVar(&c.allAllocs, "all-allocs", false, "")
 	flags.BoolVar(&c.verbose, "verbose", false, "")
+	flags.Var(&watch, "watch", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -124,6 +133,51 @@ func (c *JobStatusCommand) Run(args []string) int {
 		return 1
 	}
 
+	if !watch.enabled {
+		return c.output(client, args, short)
+	}
+
+	var queries []watchQuery
+	if len(args) == 0 {
+		queries = append(queries, func(q *api.QueryOptions) (uint64, error) {
+			_, meta, err := client.Jobs().List(q)
+			return metaIndex(meta), err
+		})
+	} else {
+		// Resolve the job once, so that the blocking queries don't have to
+		// match it by prefix. If it can't be resolved, the output explains
+		// why.
+		jobID := strings.TrimSpace(args[0])
+		jobs, _, err := client.Jobs().PrefixList(jobID)
+		if err != nil || len(jobs) == 0 {
+			return c.output(client, args, short)
+		}
+		if len(jobs) > 1 && (jobID != jobs[0].ID || c.allNamespaces() && jobs[0].ID == jobs[1].ID) {
+			return c.output(client, args, short)
+		}
+		jobID, namespace := jobs[0].ID, jobs[0].JobSummary.Namespace
+		args = []string{jobID}
+
+		queries = append(queries,
+			func(q *api.QueryOptions) (uint64, error) {
+				q.Namespace, q.Prefix = namespace, jobID
+				_, meta, err := client.Jobs().List(q)
+				return metaIndex(meta), err
+			},
+			func(q *api.QueryOptions) (uint64, error) {
+				q.Namespace = namespace
+				_, meta, err := client.Jobs().Allocations(jobID, c.allAllocs, q)
+				return metaIndex(meta), err
+			},
+		)
+	}
+	return c.watch(&watch, false, queries, func() int {
+		return c.output(client, args, short)
+	})
+}
+
+// output displays the list of jobs, or the status of the job given in args.
+func (c *JobStatusCommand) output(client *api.Client, args []string, short bool) int {
 	allNamespaces := c.allNamespaces()
 
 	// Invoke list mode if no job ID.
@@ -178,7 +232,7 @@ func (c *JobStatusCommand) Run(args []string) int {
 	basic := []string{
 		fmt.Sprintf("ID|%s", *job.ID),
 		fmt.Sprintf("Name|%s", *job.Name),
-		fmt.Sprintf("Submit Date|%s", formatTime(time.Unix(0, *job.SubmitTime))),
+		fmt.Sprintf("Submit Date|%s", formatTime(*job.SubmitTime)),
 		fmt.Sprintf("Type|%s", *job.Type),
 		fmt.Sprintf("Priority|%d", *job.Priority),
 		fmt.Sprintf("Datacenters|%s", strings.Join(job.Datacenters, ",")),
@@ -453,15 +507,15 @@ func formatAllocListStubs(stubs []*api.AllocationListStub, verbose bool, uuidLen
 				alloc.JobVersion,
 				alloc.DesiredStatus,
 				alloc.ClientStatus,
-				formatUnixNanoTime(alloc.CreateTime),
-				formatUnixNanoTime(alloc.ModifyTime))
+				formatTime(alloc.CreateTime),
+				formatTime(alloc.ModifyTime))
 		}
 	} else {
 		allocs[0] = "ID|Node ID|Task Group|Version|Desired|Status|Created|Modified"
 		for i, alloc := range stubs {
 			now := time.Now()
-			createTimePretty := prettyTimeDiff(time.Unix(0, alloc.CreateTime), now)
-			modTimePretty := prettyTimeDiff(time.Unix(0, alloc.ModifyTime), now)
+			createTimePretty := prettyTimeDiff(alloc.CreateTime, now)
+			modTimePretty := prettyTimeDiff(alloc.ModifyTime, now)
 			allocs[i+1] = fmt.Sprintf("%s|%s|%s|%d|%s|%s|%s|%s",
 				limit(alloc.ID, uuidLength),
 				limit(alloc.NodeID, uuidLength),
@@ -494,15 +548,15 @@ func formatAllocList(allocations []*api.Allocation, verbose bool, uuidLength int
 				*alloc.Job.Version,
 				alloc.DesiredStatus,
 				alloc.ClientStatus,
-				formatUnixNanoTime(alloc.CreateTime),
-				formatUnixNanoTime(alloc.ModifyTime))
+				formatTime(alloc.CreateTime),
+				formatTime(alloc.ModifyTime))
 		}
 	} else {
 		allocs[0] = "ID|Node ID|Task Group|Version|Desired|Status|Created|Modified"
 		for i, alloc := range allocations {
 			now := time.Now()
-			createTimePretty := prettyTimeDiff(time.Unix(0, alloc.CreateTime), now)
-			modTimePretty := prettyTimeDiff(time.Unix(0, alloc.ModifyTime), now)
+			createTimePretty := prettyTimeDiff(alloc.CreateTime, now)
+			modTimePretty := prettyTimeDiff(alloc.ModifyTime, now)
 			allocs[i+1] = fmt.Sprintf("%s|%s|%s|%d|%s|%s|%s|%s",
 				limit(alloc.ID, uuidLength),
 				limit(alloc.NodeID, uuidLength),
@@ -582,7 +636,7 @@ func (c *JobStatusCommand) outputReschedulingEvals(client *api.Client, job *api.
 	mostRecentAllocs := make(map[string]*api.AllocationListStub)
 	for _, alloc := range allocListStubs {
 		a, ok := mostRecentAllocs[alloc.TaskGroup]
-		if !ok || alloc.ModifyTime > a.ModifyTime {
+		if !ok || alloc.ModifyTime.After(a.ModifyTime) {
 			mostRecentAllocs[alloc.TaskGroup] = alloc
 		}
 	}
@@ -679,7 +733,7 @@ func createStatusListOutput(jobs []*api.JobListStub, displayNS bool) string {
 				getTypeString(job),
 				job.Priority,
 				getStatusString(job.Status, &job.Stop),
-				formatTime(time.Unix(0, job.SubmitTime)))
+				formatTime(job.SubmitTime))
 		}
 	} else {
 		out[0] = "ID|Type|Priority|Status|Submit Date"
@@ -689,7 +743,7 @@ func createStatusListOutput(jobs []*api.JobListStub, displayNS bool) string {
 				getTypeString(job),
 				job.Priority,
 				getStatusString(job.Status, &job.Stop),
-				formatTime(time.Unix(0, job.SubmitTime)))
+				formatTime(job.SubmitTime))
 		}
 	}
 	return formatList(out)
@@ -160,7 +160,7 @@ func (c *NamespaceApplyCommand) Run(args []string) int {
 
 		// Lookup the given namespace
 		namespace, _, err = client.Namespaces().Info(name, nil)
-		if err != nil && !strings.Contains(err.Error(), "404") {
+		if err != nil && !api.IsNotFound(err) {
 			c.Ui.Error(fmt.Sprintf("Error looking up namespace: %s", err))
 			return 1
 		}
@@ -221,6 +221,9 @@ func parseNamespaceSpecImpl(result *api.Namespace, list *ast.ObjectList) error {
 
 	delete(m, "capabilities")
 	delete(m, "meta")
+	delete(m, "retention")
+	delete(m, "defaults")
+	delete(m, "secure_variables")
 
 	// Decode the rest
 	if err := mapstructure.WeakDecode(m, result); err != nil {
@@ -243,6 +246,59 @@ func parseNamespaceSpecImpl(result *api.Namespace, list *ast.ObjectList) error {
 		}
 	}
 
+	rObj := list.Filter("retention")
+	if len(rObj.Items) > 0 {
+		for _, o := range rObj.Elem().Items {
+			var m map[string]interface{}
+			if err := hcl.DecodeObject(&m, o.Val); err != nil {
+				return err
+			}
+
+			var opts api.NamespaceRetention
+			dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+				DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+				WeaklyTypedInput: true,
+				TagName:          "hcl",
+				Result:           &opts,
+			})
+			if err != nil {
+				return err
+			}
+			if err := dec.Decode(m); err != nil {
+				return err
+			}
+			result.Retention = &opts
+			break
+		}
+	}
+
+	dObj := list.Filter("defaults")
+	if len(dObj.Items) > 0 {
+		for _, o := range dObj.Elem().Items {
+			ot, ok := o.Val.(*ast.ObjectType)
+			if !ok {
+				break
+			}
+			defaults, err := parseNamespaceDefaults(ot.List)
+			if err != nil {
+				return fmt.Errorf("defaults: %v", err)
+			}
+			result.Defaults = defaults
+			break
+		}
+	}
+
+	if svObj := list.Filter("secure_variables"); len(svObj.Items) > 0 {
+		for _, o := range svObj.Elem().Items {
+			var opts api.NamespaceSecureVariablesConfig
+			if err := hcl.DecodeObject(&opts, o.Val); err != nil {
+				return err
+			}
+			result.SecureVariables = &opts
+			break
+		}
+	}
+
 	if metaO := list.Filter("meta"); len(metaO.Items) > 0 {
 		for _, o := range metaO.Elem().Items {
 			var m map[string]interface{}
@@ -257,3 +313,44 @@ func parseNamespaceSpecImpl(result *api.Namespace, list *ast.ObjectList) error {
 
 	return nil
 }
+
+// parseNamespaceDefaults parses the vault, consul and identity blocks of the
+// defaults block of a namespace specification
+func parseNamespaceDefaults(list *ast.ObjectList) (*api.NamespaceDefaults, error) {
+	var defaults api.NamespaceDefaults
+
+	if vObj := list.Filter("vault"); len(vObj.Items) > 0 {
+		for _, o := range vObj.Elem().Items {
+			var opts api.NamespaceVaultDefaults
+			if err := hcl.DecodeObject(&opts, o.Val); err != nil {
+				return nil, err
+			}
+			defaults.Vault = &opts
+			break
+		}
+	}
+
+	if cObj := list.Filter("consul"); len(cObj.Items) > 0 {
+		for _, o := range cObj.Elem().Items {
+			var opts api.NamespaceConsulDefaults
+			if err := hcl.DecodeObject(&opts, o.Val); err != nil {
+				return nil, err
+			}
+			defaults.Consul = &opts
+			break
+		}
+	}
+
+	if iObj := list.Filter("identity"); len(iObj.Items) > 0 {
+		for _, o := range iObj.Elem().Items {
+			var opts api.WorkloadIdentity
+			if err := hcl.DecodeObject(&opts, o.Val); err != nil {
+				return nil, err
+			}
+			defaults.Identity = &opts
+			break
+		}
+	}
+
+	return &defaults, nil
+}
@@ -3,10 +3,13 @@ package command
 import (
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/mitchellh/cli"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNamespaceApplyCommand_Implements(t *testing.T) {
@@ -57,3 +60,78 @@ func TestNamespaceApplyCommand_Good(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Len(t, namespaces, 2)
 }
+
+func TestNamespaceApplyCommand_parseRetention(t *testing.T) {
+	ci.Parallel(t)
+
+	spec, err := parseNamespaceSpec([]byte(`
+name = "foo"
+
+retention {
+  job_versions        = 3
+  job_version_max_age = "72h"
+  deployments         = 5
+  deployment_max_age  = "24h"
+}
+`))
+	require.NoError(t, err)
+	require.Equal(t, "foo", spec.Name)
+	require.Equal(t, &api.NamespaceRetention{
+		JobVersions:      3,
+		JobVersionMaxAge: 72 * time.Hour,
+		Deployments:      5,
+		DeploymentMaxAge: 24 * time.Hour,
+	}, spec.Retention)
+}
+
+func TestNamespaceApplyCommand_parseDefaults(t *testing.T) {
+	ci.Parallel(t)
+
+	spec, err := parseNamespaceSpec([]byte(`
+name = "foo"
+
+defaults {
+  vault {
+    namespace = "team"
+    policies  = ["team-read", "team-write"]
+  }
+
+  consul {
+    namespace = "team"
+  }
+
+  identity {
+    env = true
+  }
+}
+`))
+	require.NoError(t, err)
+	require.Equal(t, &api.NamespaceDefaults{
+		Vault: &api.NamespaceVaultDefaults{
+			Namespace: "team",
+			Policies:  []string{"team-read", "team-write"},
+		},
+		Consul: &api.NamespaceConsulDefaults{
+			Namespace: "team",
+		},
+		Identity: &api.WorkloadIdentity{
+			Env: true,
+		},
+	}, spec.Defaults)
+}
+
+func TestNamespaceApplyCommand_parseSecureVariables(t *testing.T) {
+	ci.Parallel(t)
+
+	spec, err := parseNamespaceSpec([]byte(`
+name = "foo"
+
+secure_variables {
+  replication_targets = ["us-west", "eu-central"]
+}
+`))
+	require.NoError(t, err)
+	require.Equal(t, &api.NamespaceSecureVariablesConfig{
+		ReplicationTargets: []string{"us-west", "eu-central"},
+	}, spec.SecureVariables)
+}
@@ -139,6 +139,32 @@ func formatNamespaceBasics(ns *api.Namespace) string {
 		fmt.Sprintf("EnabledDrivers|%s", enabled_drivers),
 		fmt.Sprintf("DisabledDrivers|%s", disabled_drivers),
 	}
+	if r := ns.Retention; r != nil {
+		basic = append(basic,
+			fmt.Sprintf("RetainedJobVersions|%d", r.JobVersions),
+			fmt.Sprintf("JobVersionMaxAge|%s", r.JobVersionMaxAge),
+			fmt.Sprintf("RetainedDeployments|%d", r.Deployments),
+			fmt.Sprintf("DeploymentMaxAge|%s", r.DeploymentMaxAge),
+		)
+	}
+	if d := ns.Defaults; d != nil {
+		if d.Vault != nil {
+			basic = append(basic,
+				fmt.Sprintf("DefaultVaultNamespace|%s", d.Vault.Namespace),
+				fmt.Sprintf("DefaultVaultPolicies|%s", strings.Join(d.Vault.Policies, ",")),
+			)
+		}
+		if d.Consul != nil {
+			basic = append(basic, fmt.Sprintf("DefaultConsulNamespace|%s", d.Consul.Namespace))
+		}
+		if d.Identity != nil {
+			basic = append(basic, fmt.Sprintf("DefaultIdentityEnv|%v", d.Identity.Env))
+		}
+	}
+	if sv := ns.SecureVariables; sv != nil {
+		basic = append(basic, fmt.Sprintf("SecureVariablesReplicationTargets|%s",
+			strings.Join(sv.ReplicationTargets, ",")))
+	}
 
 	return formatKV(basic)
 }
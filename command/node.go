@@ -32,6 +32,16 @@ Usage: nomad node <subcommand> [options] [args]
 
       $ nomad node drain -enable -deadline 4h <node-id>
 
+  Plan a disconnect of a node for maintenance, so its allocations are marked
+  unknown rather than lost while it is disconnected:
+
+      $ nomad node disconnect -duration 30m <node-id>
+
+  Set the dynamic metadata of a node, for example to keep jobs that require a
+  healthy network off of it after an incident:
+
+      $ nomad node meta apply -node-id <node-id> network=degraded
+
   Please see the individual subcommand help for detailed usage information.
 `
 
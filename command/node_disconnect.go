@@ -0,0 +1,167 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type NodeDisconnectCommand struct {
+	Meta
+}
+
+func (c *NodeDisconnectCommand) Help() string {
+	helpText := `
+Usage: nomad node disconnect [options] <node>
+
+  Plans a disconnect of a node, for example for maintenance of its network or
+  of the agent. When the node misses its heartbeats before the deadline of the
+  planned disconnect, the allocations running on it are marked unknown instead
+  of lost, regardless of the max_client_disconnect of their task group.
+  Replacements are scheduled as usual, and when the node reconnects the
+  allocations are reconciled as if it had never been lost. If the node hasn't
+  reconnected when the deadline passes, its allocations are lost.
+
+  The -self flag is useful to plan a disconnect of the local node. A planned
+  disconnect is cleared when the node reconnects, or with the -cancel flag.
+
+  If ACLs are enabled, this option requires a token with the 'node:write'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Node Disconnect Options:
+
+  -duration
+    How long the node is expected to be disconnected, for example "30m".
+    Required unless -cancel is set.
+
+  -cancel
+    Cancel the planned disconnect of the node.
+
+  -self
+    Plan a disconnect of the local node.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodeDisconnectCommand) Synopsis() string {
+	return "Plan a disconnect of a node for maintenance"
+}
+
+func (c *NodeDisconnectCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-duration": complete.PredictAnything,
+			"-cancel":   complete.PredictNothing,
+			"-self":     complete.PredictNothing,
+		})
+}
+
+func (c *NodeDisconnectCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Nodes, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Nodes]
+	})
+}
+
+func (c *NodeDisconnectCommand) Name() string { return "node disconnect" }
+
+func (c *NodeDisconnectCommand) Run(args []string) int {
+	var cancel, self bool
+	var duration time.Duration
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.DurationVar(&duration, "duration", 0, "")
+	flags.BoolVar(&cancel, "cancel", false, "")
+	flags.BoolVar(&self, "self", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got either a duration or cancel, but not both.
+	if (cancel && duration != 0) || (!cancel && duration <= 0) {
+		c.Ui.Error("Either a positive '-duration' or the '-cancel' flag must be set")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Check that we got a node ID
+	args = flags.Args()
+	if l := len(args); self && l != 0 || !self && l != 1 {
+		c.Ui.Error("Node ID must be specified if -self isn't being used")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// If -self flag is set then determine the current node.
+	var nodeID string
+	if !self {
+		nodeID = args[0]
+	} else {
+		var err error
+		if nodeID, err = getLocalNodeID(client); err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+	}
+
+	// Check if node exists
+	if len(nodeID) == 1 {
+		c.Ui.Error("Identifier must contain at least two characters.")
+		return 1
+	}
+
+	nodeID = sanitizeUUIDPrefix(nodeID)
+	nodes, _, err := client.Nodes().PrefixList(nodeID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error planning node disconnect: %s", err))
+		return 1
+	}
+	// Return error if no nodes are found
+	if len(nodes) == 0 {
+		c.Ui.Error(fmt.Sprintf("No node(s) with prefix or id %q found", nodeID))
+		return 1
+	}
+	if len(nodes) > 1 {
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple nodes\n\n%s",
+			formatNodeStubList(nodes, true)))
+		return 1
+	}
+	node := nodes[0]
+
+	if _, err := client.Nodes().PlanDisconnect(node.ID, duration, nil); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error planning node disconnect: %s", err))
+		return 1
+	}
+
+	if cancel {
+		c.Ui.Output(fmt.Sprintf("Node %q planned disconnect canceled", node.ID))
+	} else {
+		c.Ui.Output(fmt.Sprintf("Node %q planned to disconnect until %s",
+			node.ID, formatTime(time.Now().Add(duration))))
+	}
+	return 0
+}
@@ -0,0 +1,61 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeDisconnectCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &NodeDisconnectCommand{}
+}
+
+func TestNodeDisconnectCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	testCases := []struct {
+		name      string
+		args      []string
+		expectErr string
+	}{
+		{
+			name:      "no duration",
+			args:      []string{"12345678-abcd-efab-cdef-123456789abc"},
+			expectErr: "Either a positive '-duration' or the '-cancel' flag must be set",
+		},
+		{
+			name:      "duration and cancel",
+			args:      []string{"-duration=1h", "-cancel", "12345678-abcd-efab-cdef-123456789abc"},
+			expectErr: "Either a positive '-duration' or the '-cancel' flag must be set",
+		},
+		{
+			name:      "no node",
+			args:      []string{"-duration=1h"},
+			expectErr: "Node ID must be specified",
+		},
+		{
+			name:      "bad address",
+			args:      []string{"-address=nope", "-duration=1h", "12345678-abcd-efab-cdef-123456789abc"},
+			expectErr: "Error planning node disconnect",
+		},
+		{
+			name:      "unknown node",
+			args:      []string{"-address=" + url, "-duration=1h", "12345678-abcd-efab-cdef-123456789abc"},
+			expectErr: "No node(s) with prefix or id",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := &NodeDisconnectCommand{Meta: Meta{Ui: ui}}
+			code := cmd.Run(tc.args)
+			require.Equal(t, 1, code)
+			require.Contains(t, ui.ErrorWriter.String(), tc.expectErr)
+		})
+	}
+}
@@ -40,6 +40,10 @@ Node Eligibility Options:
 
   -self
     Set the eligibility of the local node.
+
+  -reason
+    Reason for the eligibility change. The reason is stored on the node and
+    shown by the node status command.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -54,6 +58,7 @@ func (c *NodeEligibilityCommand) AutocompleteFlags() complete.Flags {
 			"-disable": complete.PredictNothing,
 			"-enable":  complete.PredictNothing,
 			"-self":    complete.PredictNothing,
+			"-reason":  complete.PredictAnything,
 		})
 }
 
@@ -76,12 +81,14 @@ func (c *NodeEligibilityCommand) Name() string { return "node eligibility" }
 
 func (c *NodeEligibilityCommand) Run(args []string) int {
 	var enable, disable, self bool
+	var reason string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&enable, "enable", false, "Mark node as eligibile for scheduling")
 	flags.BoolVar(&disable, "disable", false, "Mark node as ineligibile for scheduling")
 	flags.BoolVar(&self, "self", false, "")
+	flags.StringVar(&reason, "reason", "", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -152,7 +159,7 @@ func (c *NodeEligibilityCommand) Run(args []string) int {
 	}
 
 	// Toggle node eligibility
-	if _, err := client.Nodes().ToggleEligibility(node.ID, enable, nil); err != nil {
+	if _, err := client.Nodes().ToggleEligibilityWithReason(node.ID, enable, reason, nil); err != nil {
 		c.Ui.Error(fmt.Sprintf("Error updating scheduling eligibility: %s", err))
 		return 1
 	}
@@ -0,0 +1,95 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+type NodeMetaCommand struct {
+	Meta
+}
+
+func (c *NodeMetaCommand) Help() string {
+	helpText := `
+Usage: nomad node meta <subcommand> [options] [args]
+
+  This command groups subcommands for interacting with the dynamic metadata of
+  nodes. Dynamic metadata is set at runtime, without editing the configuration
+  of the agent or restarting it, and persists across restarts. Jobs whose
+  constraints depend on the metadata are re-evaluated when it changes.
+
+  Mark the local node as having a degraded network:
+
+      $ nomad node meta apply network=degraded
+
+  Unset a key of the metadata of another node:
+
+      $ nomad node meta apply -node-id <node-id> -unset network
+
+  Read the metadata of the local node:
+
+      $ nomad node meta read
+
+  Please see the individual subcommand help for detailed usage information.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodeMetaCommand) Synopsis() string {
+	return "Interact with the dynamic metadata of nodes"
+}
+
+func (c *NodeMetaCommand) Name() string { return "node meta" }
+
+func (c *NodeMetaCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+// predictNodeIDs predicts the IDs of the nodes matching the prefix being
+// completed.
+func predictNodeIDs(m Meta) complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := m.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Nodes, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Nodes]
+	})
+}
+
+// lookupNodeMetaID returns the ID of the node matching the prefix given with
+// the -node-id flag of the node meta commands. An empty prefix is returned
+// as is, which targets the local node of the agent.
+func lookupNodeMetaID(client *api.Client, prefix string) (string, error) {
+	if prefix == "" {
+		return "", nil
+	}
+	if len(prefix) == 1 {
+		return "", fmt.Errorf("Identifier must contain at least two characters.")
+	}
+
+	prefix = sanitizeUUIDPrefix(prefix)
+	nodes, _, err := client.Nodes().PrefixList(prefix)
+	if err != nil {
+		return "", fmt.Errorf("Error querying node: %s", err)
+	}
+	if len(nodes) == 0 {
+		return "", fmt.Errorf("No node(s) with prefix or id %q found", prefix)
+	}
+	if len(nodes) > 1 {
+		return "", fmt.Errorf("Prefix matched multiple nodes\n\n%s",
+			formatNodeStubList(nodes, true))
+	}
+	return nodes[0].ID, nil
+}
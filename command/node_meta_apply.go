@@ -0,0 +1,142 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type NodeMetaApplyCommand struct {
+	Meta
+}
+
+func (c *NodeMetaApplyCommand) Help() string {
+	helpText := `
+Usage: nomad node meta apply [options] <key>=<value>...
+
+  Sets and unsets keys of the metadata of a node at runtime, without editing
+  the configuration of the agent or restarting it. The changes are merged with
+  the metadata previously applied, persist across restarts of the agent, and
+  take precedence over the metadata of the configuration. Jobs whose
+  constraints depend on the metadata are re-evaluated once the node has been
+  updated.
+
+  Defaults to the node of the agent the command is run against.
+
+  If ACLs are enabled, this option requires a token with the 'node:write'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Node Meta Apply Options:
+
+  -node-id
+    The ID of the node to update the metadata of. Defaults to the node of the
+    agent.
+
+  -unset
+    A comma separated list of keys to unset. Keys are unset even if they are
+    set in the configuration of the node.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodeMetaApplyCommand) Synopsis() string {
+	return "Set and unset the dynamic metadata of a node"
+}
+
+func (c *NodeMetaApplyCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-node-id": predictNodeIDs(c.Meta),
+			"-unset":   complete.PredictAnything,
+		})
+}
+
+func (c *NodeMetaApplyCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *NodeMetaApplyCommand) Name() string { return "node meta apply" }
+
+func (c *NodeMetaApplyCommand) Run(args []string) int {
+	var nodeID, unset string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&nodeID, "node-id", "", "")
+	flags.StringVar(&unset, "unset", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	meta, err := parseNodeMetaApply(flags.Args(), unset)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	nodeID, err = lookupNodeMetaID(client, nodeID)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	_, _, err = client.Nodes().UpdateMeta(&api.NodeMetaApplyRequest{
+		NodeID: nodeID,
+		Meta:   meta,
+	}, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error applying node metadata: %s", err))
+		return 1
+	}
+
+	if nodeID == "" {
+		c.Ui.Output("Applied metadata to the local node")
+	} else {
+		c.Ui.Output(fmt.Sprintf("Applied metadata to node %q", nodeID))
+	}
+	return 0
+}
+
+// parseNodeMetaApply returns the metadata to apply from the key=value
+// arguments and the keys of the -unset flag.
+func parseNodeMetaApply(args []string, unset string) (map[string]*string, error) {
+	meta := make(map[string]*string, len(args))
+	for _, arg := range args {
+		k, v, ok := strings.Cut(arg, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("Invalid metadata %q, expected key=value", arg)
+		}
+		meta[k] = &v
+	}
+	if unset != "" {
+		for _, k := range strings.Split(unset, ",") {
+			k = strings.TrimSpace(k)
+			if k == "" {
+				continue
+			}
+			if _, ok := meta[k]; ok {
+				return nil, fmt.Errorf("Key %q cannot be both set and unset", k)
+			}
+			meta[k] = nil
+		}
+	}
+	if len(meta) == 0 {
+		return nil, fmt.Errorf("At least one key=value argument or -unset key must be given")
+	}
+	return meta, nil
+}
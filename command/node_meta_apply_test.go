@@ -0,0 +1,107 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeMetaApplyCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &NodeMetaApplyCommand{}
+	var _ cli.Command = &NodeMetaReadCommand{}
+}
+
+func TestNodeMetaApplyCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	testCases := []struct {
+		name      string
+		args      []string
+		expectErr string
+	}{
+		{
+			name:      "no metadata",
+			args:      []string{},
+			expectErr: "At least one key=value argument or -unset key must be given",
+		},
+		{
+			name:      "invalid metadata",
+			args:      []string{"network"},
+			expectErr: `Invalid metadata "network", expected key=value`,
+		},
+		{
+			name:      "set and unset",
+			args:      []string{"-unset=network", "network=degraded"},
+			expectErr: `Key "network" cannot be both set and unset`,
+		},
+		{
+			name:      "unknown node",
+			args:      []string{"-address=" + url, "-node-id=12345678-abcd-efab-cdef-123456789abc", "network=degraded"},
+			expectErr: "No node(s) with prefix or id",
+		},
+		{
+			name:      "no local node",
+			args:      []string{"-address=" + url, "network=degraded"},
+			expectErr: "No local Node and node_id not provided",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := &NodeMetaApplyCommand{Meta: Meta{Ui: ui}}
+			code := cmd.Run(tc.args)
+			require.Equal(t, 1, code)
+			require.Contains(t, ui.ErrorWriter.String(), tc.expectErr)
+		})
+	}
+}
+
+func TestNodeMetaApplyCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	waitForNodes(t, client)
+	nodeID := srv.Agent.Client().NodeID()
+
+	// Apply to the local node
+	ui := cli.NewMockUi()
+	cmd := &NodeMetaApplyCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, "-unset=connect.log_level", "network=degraded", "rack=r1"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "Applied metadata to the local node")
+
+	// Apply to a node by prefix
+	ui = cli.NewMockUi()
+	cmd = &NodeMetaApplyCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-node-id=" + nodeID[:8], "rack=r2"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "Applied metadata to node "+`"`+nodeID+`"`)
+
+	meta, _, err := client.Nodes().Meta(nodeID, nil)
+	require.NoError(t, err)
+	require.Equal(t, "degraded", meta.Meta["network"])
+	require.Equal(t, "r2", meta.Meta["rack"])
+	require.NotContains(t, meta.Meta, "connect.log_level")
+	require.Equal(t, map[string]*string{
+		"network":           pointer.Of("degraded"),
+		"rack":              pointer.Of("r2"),
+		"connect.log_level": nil,
+	}, meta.Dynamic)
+
+	// Read it back
+	ui = cli.NewMockUi()
+	readCmd := &NodeMetaReadCommand{Meta: Meta{Ui: ui}}
+	code = readCmd.Run([]string{"-address=" + url})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.Contains(t, out, "All Meta")
+	require.Regexp(t, `network\s+= degraded`, out)
+	require.Regexp(t, `connect.log_level\s+= <unset>`, out)
+}
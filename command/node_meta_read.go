@@ -0,0 +1,146 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+type NodeMetaReadCommand struct {
+	Meta
+}
+
+func (c *NodeMetaReadCommand) Help() string {
+	helpText := `
+Usage: nomad node meta read [options]
+
+  Reads the metadata of a node, including the keys that were set and unset
+  with "nomad node meta apply". Defaults to the node of the agent the command
+  is run against.
+
+  If ACLs are enabled, this option requires a token with the 'node:read'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Node Meta Read Options:
+
+  -node-id
+    The ID of the node to read the metadata of. Defaults to the node of the
+    agent.
+
+  -json
+    Output the metadata in JSON format.
+
+  -t
+    Format and display the metadata using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodeMetaReadCommand) Synopsis() string {
+	return "Read the metadata of a node"
+}
+
+func (c *NodeMetaReadCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-node-id": predictNodeIDs(c.Meta),
+			"-json":    complete.PredictNothing,
+			"-t":       complete.PredictAnything,
+		})
+}
+
+func (c *NodeMetaReadCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *NodeMetaReadCommand) Name() string { return "node meta read" }
+
+func (c *NodeMetaReadCommand) Run(args []string) int {
+	var nodeID, tmpl string
+	var json bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&nodeID, "node-id", "", "")
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if len(flags.Args()) != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	nodeID, err = lookupNodeMetaID(client, nodeID)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	meta, _, err := client.Nodes().Meta(nodeID, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading node metadata: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, meta)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+		return 0
+	}
+
+	keys := make([]string, 0, len(meta.Meta))
+	for k := range meta.Meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	all := make([]string, len(keys))
+	for i, k := range keys {
+		all[i] = fmt.Sprintf("%s|%s", k, meta.Meta[k])
+	}
+
+	keys = keys[:0]
+	for k := range meta.Dynamic {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	dynamic := make([]string, len(keys))
+	for i, k := range keys {
+		if v := meta.Dynamic[k]; v != nil {
+			dynamic[i] = fmt.Sprintf("%s|%s", k, *v)
+		} else {
+			dynamic[i] = fmt.Sprintf("%s|<unset>", k)
+		}
+	}
+
+	c.Ui.Output(c.Colorize().Color("[bold]All Meta[reset]"))
+	c.Ui.Output(formatKV(all))
+	c.Ui.Output(c.Colorize().Color("\n[bold]Dynamic Meta[reset]"))
+	if len(dynamic) == 0 {
+		c.Ui.Output("No dynamic metadata")
+	} else {
+		c.Ui.Output(formatKV(dynamic))
+	}
+	return 0
+}
@@ -99,6 +99,12 @@ Node Status Options:
 
   -t
     Format and display node using a Go template.
+
+  -watch
+    Refresh the output whenever the status changes, until interrupted. Changes
+    are detected with blocking queries and the output is refreshed at most
+    once every 2 seconds, or at the interval given as a duration, for example
+    -watch=10s. With -json, each change is written as a single line of JSON.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -122,6 +128,7 @@ func (c *NodeStatusCommand) AutocompleteFlags() complete.Flags {
 			"-os":         complete.PredictAnything,
 			"-quiet":      complete.PredictAnything,
 			"-verbose":    complete.PredictNothing,
+			"-watch":      complete.PredictNothing,
 		})
 }
 
@@ -143,6 +150,7 @@ func (c *NodeStatusCommand) AutocompleteArgs() complete.Predictor {
 func (c *NodeStatusCommand) Name() string { return "node status" }
 
 func (c *NodeStatusCommand) Run(args []string) int {
+	var watch watchFlag
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
@@ -158,6 +166,7 @@ func (c *NodeStatusCommand) Run(args []string) int {
 	flags.StringVar(&c.filter, "filter", "", "")
 	flags.IntVar(&c.perPage, "per-page", 0, "")
 	flags.StringVar(&c.pageToken, "page-token", "", "")
+	flags.Var(&watch, "watch", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -184,6 +193,61 @@ func (c *NodeStatusCommand) Run(args []string) int {
 		return 1
 	}
 
+	if !watch.enabled {
+		return c.output(client, args)
+	}
+
+	queries := []watchQuery{}
+	if len(args) == 0 && !c.self {
+		queries = append(queries, func(q *api.QueryOptions) (uint64, error) {
+			_, meta, err := client.Nodes().List(q)
+			return metaIndex(meta), err
+		})
+		if c.list_allocs {
+			queries = append(queries, func(q *api.QueryOptions) (uint64, error) {
+				q.Namespace = api.AllNamespacesNamespace
+				_, meta, err := client.Allocations().List(q)
+				return metaIndex(meta), err
+			})
+		}
+	} else {
+		// Resolve the node once, so that the blocking queries don't have to
+		// match it by prefix. If it can't be resolved, the output explains
+		// why.
+		var nodeID string
+		if c.self {
+			nodeID, err = getLocalNodeID(client)
+		} else {
+			nodes, _, perr := client.Nodes().PrefixList(sanitizeUUIDPrefix(args[0]))
+			if perr == nil && len(nodes) == 1 && len(args[0]) > 1 {
+				nodeID = nodes[0].ID
+			}
+			err = perr
+		}
+		if err != nil || nodeID == "" {
+			return c.output(client, args)
+		}
+		c.self = false
+		args = []string{nodeID}
+
+		queries = append(queries,
+			func(q *api.QueryOptions) (uint64, error) {
+				_, meta, err := client.Nodes().Info(nodeID, q)
+				return metaIndex(meta), err
+			},
+			func(q *api.QueryOptions) (uint64, error) {
+				_, meta, err := client.Nodes().Allocations(nodeID, q)
+				return metaIndex(meta), err
+			},
+		)
+	}
+	return c.watch(&watch, c.json, queries, func() int {
+		return c.output(client, args)
+	})
+}
+
+// output displays the list of nodes, or the status of the node given in args.
+func (c *NodeStatusCommand) output(client *api.Client, args []string) int {
 	// Use list mode if no node name was provided
 	if len(args) == 0 && !c.self {
 		if c.quiet && (c.verbose || c.json) {
@@ -437,6 +501,18 @@ func nodeNetworkNames(n *api.Node) []string {
 	return networks
 }
 
+// reconnectStabilizationRemaining returns how long the node has to stay
+// connected before the scheduler reinstates its reconnected allocations. The
+// scheduler configuration requires operator:read, so errors reading it are
+// ignored and the timer isn't shown.
+func reconnectStabilizationRemaining(client *api.Client, node *api.Node) time.Duration {
+	resp, _, err := client.Operator().SchedulerGetConfiguration(nil)
+	if err != nil || resp.SchedulerConfig == nil {
+		return 0
+	}
+	return time.Until(node.LastReconnect.Add(resp.SchedulerConfig.ReconnectStabilization))
+}
+
 func formatDrain(n *api.Node) string {
 	if n.DrainStrategy != nil {
 		b := new(strings.Builder)
@@ -479,12 +555,33 @@ func (c *NodeStatusCommand) formatNode(client *api.Client, node *api.Node) int {
 		fmt.Sprintf("Name|%s", node.Name),
 		fmt.Sprintf("Class|%s", node.NodeClass),
 		fmt.Sprintf("DC|%s", node.Datacenter),
+	}
+	if node.DisconnectDomain != "" {
+		basic = append(basic, fmt.Sprintf("Disconnect Domain|%s", node.DisconnectDomain))
+	}
+	basic = append(basic,
 		fmt.Sprintf("Drain|%v", formatDrain(node)),
 		fmt.Sprintf("Eligibility|%s", node.SchedulingEligibility),
-		fmt.Sprintf("Status|%s", node.Status),
+	)
+	if node.EligibilityReason != "" {
+		basic = append(basic, fmt.Sprintf("Eligibility Reason|%s", node.EligibilityReason))
+	}
+	basic = append(basic, fmt.Sprintf("Status|%s", node.Status))
+	if node.PlannedDisconnect != nil {
+		basic = append(basic, fmt.Sprintf("Planned Disconnect Deadline|%s",
+			formatTime(node.PlannedDisconnect.Deadline)))
+	}
+	if !node.LastReconnect.IsZero() {
+		basic = append(basic, fmt.Sprintf("Last Reconnect|%s", formatTime(node.LastReconnect)))
+		if remaining := reconnectStabilizationRemaining(client, node); remaining > 0 {
+			basic = append(basic, fmt.Sprintf("Reconnect Stabilization|%s remaining",
+				remaining.Truncate(time.Second)))
+		}
+	}
+	basic = append(basic,
 		fmt.Sprintf("CSI Controllers|%s", strings.Join(nodeCSIControllerNames(node), ",")),
 		fmt.Sprintf("CSI Drivers|%s", strings.Join(nodeCSINodeNames(node), ",")),
-	}
+	)
 
 	if c.short {
 		basic = append(basic, fmt.Sprintf("Host Volumes|%s", strings.Join(nodeVolumeNames(node), ",")))
@@ -569,6 +666,10 @@ func (c *NodeStatusCommand) formatNode(client *api.Client, node *api.Node) int {
 		c.printMemoryStats(hostStats)
 		c.Ui.Output(c.Colorize().Color("\n[bold]Disk Stats[reset]"))
 		c.printDiskStats(hostStats)
+		if hostStats.System != nil {
+			c.Ui.Output(c.Colorize().Color("\n[bold]System Stats[reset]"))
+			c.Ui.Output(formatKV(getHostSystemStats(hostStats.System)))
+		}
 		if len(hostStats.DeviceStats) > 0 {
 			c.Ui.Output(c.Colorize().Color("\n[bold]Device Stats[reset]"))
 			printDeviceStats(c.Ui, hostStats.DeviceStats)
@@ -891,6 +992,31 @@ func (c *NodeStatusCommand) printDiskStats(hostStats *api.HostStats) {
 	}
 }
 
+// getHostSystemStats returns the load, conntrack and socket counts of the
+// host, omitting the stats which are not available on the host.
+func getHostSystemStats(systemStats *api.HostSystemStats) []string {
+	var attrs []string
+	if load := systemStats.Load; load != nil {
+		attrs = append(attrs, fmt.Sprintf("Load Average|%v, %v, %v",
+			humanize.FormatFloat(floatFormat, load.Load1),
+			humanize.FormatFloat(floatFormat, load.Load5),
+			humanize.FormatFloat(floatFormat, load.Load15)))
+	}
+	if conntrack := systemStats.Conntrack; conntrack != nil {
+		attrs = append(attrs, fmt.Sprintf("Conntrack|%d/%d", conntrack.Count, conntrack.Max))
+	}
+	if sockets := systemStats.Sockets; sockets != nil {
+		attrs = append(attrs,
+			fmt.Sprintf("Sockets Used|%d", sockets.Used),
+			fmt.Sprintf("TCP In Use|%d", sockets.TCPInUse),
+			fmt.Sprintf("TCP Orphaned|%d", sockets.TCPOrphan),
+			fmt.Sprintf("TCP Time Wait|%d", sockets.TCPTimeWait),
+			fmt.Sprintf("UDP In Use|%d", sockets.UDPInUse),
+		)
+	}
+	return attrs
+}
+
 // getRunningAllocs returns a slice of allocation id's running on the node
 func getRunningAllocs(client *api.Client, nodeID string) ([]*api.Allocation, error) {
 	var allocs []*api.Allocation
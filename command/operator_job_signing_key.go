@@ -0,0 +1,87 @@
+package command
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+
+	"github.com/hashicorp/nomad/api"
+)
+
+// OperatorJobSigningKeyCommand is a Command implementation that handles
+// managing the public keys trusted to sign job submissions.
+type OperatorJobSigningKeyCommand struct {
+	Meta
+}
+
+func (c *OperatorJobSigningKeyCommand) Help() string {
+	helpText := `
+Usage: nomad operator job-signing-key [options]
+
+  Manages the ed25519 keys used to sign job submissions. The servers only store
+  the public keys they trust; the private keys are kept by the operators or
+  pipelines submitting jobs, which sign them with "nomad job run -sign".
+
+  If ACLs are enabled, the install, list and remove subcommands require a
+  management token.
+
+  Generate a new key pair:
+
+      $ nomad operator job-signing-key generate ci
+
+  Trust a public key:
+
+      $ nomad operator job-signing-key install -name=ci ci.pub.pem
+
+  List the trusted keys:
+
+      $ nomad operator job-signing-key list
+
+  Stop trusting a key:
+
+      $ nomad operator job-signing-key remove <key ID>
+
+  Please see individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorJobSigningKeyCommand) Synopsis() string {
+	return "Manages the keys trusted to sign job submissions"
+}
+
+func (c *OperatorJobSigningKeyCommand) AutocompleteFlags() complete.Flags {
+	return c.Meta.AutocompleteFlags(FlagSetClient)
+}
+
+func (c *OperatorJobSigningKeyCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OperatorJobSigningKeyCommand) Name() string {
+	return "job-signing-key"
+}
+
+func (c *OperatorJobSigningKeyCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+// renderJobSigningKeys is a helper for formatting the job signing keys API
+// responses
+func renderJobSigningKeys(keys []*api.JobSigningKey, verbose bool) string {
+	out := make([]string, len(keys)+1)
+	out[0] = "Key|Name|Create Time"
+	if verbose {
+		out[0] += "|Public Key"
+	}
+	for i, k := range keys {
+		out[i+1] = fmt.Sprintf("%s|%s|%s", k.KeyID, k.Name, formatTime(k.CreateTime))
+		if verbose {
+			out[i+1] += "|" + hex.EncodeToString(k.PublicKey)
+		}
+	}
+	return formatList(out)
+}
@@ -0,0 +1,126 @@
+package command
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/posener/complete"
+)
+
+// OperatorJobSigningKeyGenerateCommand is a Command implementation that
+// generates a new job signing key pair.
+type OperatorJobSigningKeyGenerateCommand struct {
+	Meta
+}
+
+func (c *OperatorJobSigningKeyGenerateCommand) Help() string {
+	helpText := `
+Usage: nomad operator job-signing-key generate [options] <name>
+
+  Generate a new ed25519 key pair to sign job submissions. The private key is
+  written to "<name>.pem" as PKCS #8 PEM and the public key to "<name>.pub.pem"
+  as PKIX PEM. This command doesn't contact the Nomad servers; the public key
+  must be installed with "nomad operator job-signing-key install" for the
+  servers to trust it.
+
+  Keep the private key secret: anyone holding it can submit signed jobs.
+
+Generate Options:
+
+  -force
+    Overwrite the key files if they already exist.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorJobSigningKeyGenerateCommand) Synopsis() string {
+	return "Generates a new job signing key pair"
+}
+
+func (c *OperatorJobSigningKeyGenerateCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-force": complete.PredictNothing,
+	}
+}
+
+func (c *OperatorJobSigningKeyGenerateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OperatorJobSigningKeyGenerateCommand) Name() string {
+	return "job-signing-key generate"
+}
+
+func (c *OperatorJobSigningKeyGenerateCommand) Run(args []string) int {
+	var force bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetNone)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&force, "force", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command requires one argument: <name>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	name := args[0]
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error generating key: %s", err))
+		return 1
+	}
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error encoding private key: %s", err))
+		return 1
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error encoding public key: %s", err))
+		return 1
+	}
+
+	flag := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !force {
+		flag |= os.O_EXCL
+	}
+	files := []struct {
+		path  string
+		mode  os.FileMode
+		block *pem.Block
+	}{
+		{name + ".pem", 0600, &pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}},
+		{name + ".pub.pem", 0644, &pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}},
+	}
+	for _, file := range files {
+		f, err := os.OpenFile(file.path, flag, file.mode)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error writing key: %s", err))
+			return 1
+		}
+		err = pem.Encode(f, file.block)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error writing key %q: %s", file.path, err))
+			return 1
+		}
+	}
+
+	c.Ui.Output(fmt.Sprintf("Generated job signing key %s in %s and %s",
+		structs.JobSigningKeyID(publicKey), files[0].path, files[1].path))
+	return 0
+}
@@ -0,0 +1,113 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+// OperatorJobSigningKeyInstallCommand is a Command implementation that
+// trusts a public key to sign job submissions.
+type OperatorJobSigningKeyInstallCommand struct {
+	Meta
+}
+
+func (c *OperatorJobSigningKeyInstallCommand) Help() string {
+	helpText := `
+Usage: nomad operator job-signing-key install [options] <filepath>
+
+  Trust the ed25519 public key of the PKIX PEM file to sign job submissions,
+  such as the "<name>.pub.pem" file written by "nomad operator job-signing-key
+  generate". The key file will be read from stdin by specifying "-". Installing
+  a key which is already trusted updates its name.
+
+  If ACLs are enabled, this command requires a management token.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Install Options:
+
+  -name
+    A description of the key, such as the pipeline or team using it.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorJobSigningKeyInstallCommand) Synopsis() string {
+	return "Trusts a public key to sign job submissions"
+}
+
+func (c *OperatorJobSigningKeyInstallCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-name": complete.PredictAnything,
+		})
+}
+
+func (c *OperatorJobSigningKeyInstallCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFiles("*.pem")
+}
+
+func (c *OperatorJobSigningKeyInstallCommand) Name() string {
+	return "job-signing-key install"
+}
+
+func (c *OperatorJobSigningKeyInstallCommand) Run(args []string) int {
+	var name string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&name, "name", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command requires one argument: <filepath>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	path := args[0]
+
+	var buf []byte
+	var err error
+	if path == "-" {
+		buf, err = io.ReadAll(os.Stdin)
+	} else {
+		buf, err = os.ReadFile(path)
+	}
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to read key file: %v", err))
+		return 1
+	}
+	publicKey, err := readJobSigningPublicKey(buf)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to parse key file: %v", err))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating nomad cli client: %s", err))
+		return 1
+	}
+
+	key, _, err := client.Keyring().UpsertSigningKey(&api.JobSigningKey{
+		Name:      name,
+		PublicKey: publicKey,
+	}, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("error: %s", err))
+		return 1
+	}
+	c.Ui.Output(fmt.Sprintf("Installed job signing key %s", key.KeyID))
+	return 0
+}
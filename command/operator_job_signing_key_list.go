@@ -0,0 +1,89 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+// OperatorJobSigningKeyListCommand is a Command implementation that lists
+// the public keys trusted to sign job submissions.
+type OperatorJobSigningKeyListCommand struct {
+	Meta
+}
+
+func (c *OperatorJobSigningKeyListCommand) Help() string {
+	helpText := `
+Usage: nomad operator job-signing-key list [options]
+
+  List the public keys trusted to sign job submissions.
+
+  If ACLs are enabled, this command requires a management token.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+List Options:
+
+  -verbose
+    Show full information, including the hex encoded public keys.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorJobSigningKeyListCommand) Synopsis() string {
+	return "Lists the keys trusted to sign job submissions"
+}
+
+func (c *OperatorJobSigningKeyListCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-verbose": complete.PredictNothing,
+		})
+}
+
+func (c *OperatorJobSigningKeyListCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OperatorJobSigningKeyListCommand) Name() string {
+	return "job-signing-key list"
+}
+
+func (c *OperatorJobSigningKeyListCommand) Run(args []string) int {
+	var verbose bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if len(flags.Args()) != 0 {
+		c.Ui.Error("This command requires no arguments.")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating nomad cli client: %s", err))
+		return 1
+	}
+
+	keys, _, err := client.Keyring().ListSigningKeys(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("error: %s", err))
+		return 1
+	}
+	if len(keys) == 0 {
+		c.Ui.Output("No job signing keys found")
+		return 0
+	}
+	c.Ui.Output(renderJobSigningKeys(keys, verbose))
+	return 0
+}
@@ -0,0 +1,78 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+// OperatorJobSigningKeyRemoveCommand is a Command implementation that stops
+// trusting a job signing key.
+type OperatorJobSigningKeyRemoveCommand struct {
+	Meta
+}
+
+func (c *OperatorJobSigningKeyRemoveCommand) Help() string {
+	helpText := `
+Usage: nomad operator job-signing-key remove [options] <key ID>
+
+  Stop trusting a job signing key. Jobs already running are not affected, but
+  when the servers require signed job submissions, new submissions signed with
+  the key are rejected and job versions signed with it can no longer be
+  reverted to.
+
+  If ACLs are enabled, this command requires a management token.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace)
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorJobSigningKeyRemoveCommand) Synopsis() string {
+	return "Stops trusting a job signing key"
+}
+
+func (c *OperatorJobSigningKeyRemoveCommand) AutocompleteFlags() complete.Flags {
+	return c.Meta.AutocompleteFlags(FlagSetClient)
+}
+
+func (c *OperatorJobSigningKeyRemoveCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *OperatorJobSigningKeyRemoveCommand) Name() string {
+	return "job-signing-key remove"
+}
+
+func (c *OperatorJobSigningKeyRemoveCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command requires one argument: <key ID>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	keyID := args[0]
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating nomad cli client: %s", err))
+		return 1
+	}
+
+	if _, err := client.Keyring().DeleteSigningKey(keyID, nil); err != nil {
+		c.Ui.Error(fmt.Sprintf("error: %s", err))
+		return 1
+	}
+	c.Ui.Output(fmt.Sprintf("Removed job signing key %s", keyID))
+	return 0
+}
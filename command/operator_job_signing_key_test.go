@@ -0,0 +1,80 @@
+package command
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperatorJobSigningKeyCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorJobSigningKeyCommand{}
+	var _ cli.Command = &OperatorJobSigningKeyGenerateCommand{}
+	var _ cli.Command = &OperatorJobSigningKeyInstallCommand{}
+	var _ cli.Command = &OperatorJobSigningKeyListCommand{}
+	var _ cli.Command = &OperatorJobSigningKeyRemoveCommand{}
+}
+
+func TestOperatorJobSigningKeyCommand_SignedRun(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, url := testServer(t, false, func(c *agent.Config) {
+		c.Server.RequireJobSignatures = true
+	})
+	defer srv.Shutdown()
+
+	name := filepath.Join(t.TempDir(), "ci")
+
+	// Generate a key pair, refusing to overwrite it
+	ui := cli.NewMockUi()
+	gen := &OperatorJobSigningKeyGenerateCommand{Meta: Meta{Ui: ui}}
+	require.Zero(t, gen.Run([]string{name}), ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "Generated job signing key")
+	require.Equal(t, 1, gen.Run([]string{name}))
+
+	// Unsigned jobs are rejected
+	ui = cli.NewMockUi()
+	run := &JobRunCommand{Meta: Meta{Ui: ui}}
+	require.Equal(t, 1, run.Run([]string{"-address=" + url, "-detach", "assets/example-short.nomad"}))
+	require.Contains(t, ui.ErrorWriter.String(), "job submissions must be signed")
+
+	// Jobs signed with an untrusted key are rejected
+	ui = cli.NewMockUi()
+	run = &JobRunCommand{Meta: Meta{Ui: ui}}
+	require.Equal(t, 1, run.Run([]string{"-address=" + url, "-detach", "-sign=" + name + ".pem", "assets/example-short.nomad"}))
+	require.Contains(t, ui.ErrorWriter.String(), "is not trusted")
+
+	// Trust the public key
+	ui = cli.NewMockUi()
+	install := &OperatorJobSigningKeyInstallCommand{Meta: Meta{Ui: ui}}
+	require.Zero(t, install.Run([]string{"-address=" + url, "-name=ci", name + ".pub.pem"}), ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "Installed job signing key")
+
+	ui = cli.NewMockUi()
+	list := &OperatorJobSigningKeyListCommand{Meta: Meta{Ui: ui}}
+	require.Zero(t, list.Run([]string{"-address=" + url}), ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "ci")
+
+	// Signed jobs are accepted
+	ui = cli.NewMockUi()
+	run = &JobRunCommand{Meta: Meta{Ui: ui}}
+	require.Zero(t, run.Run([]string{"-address=" + url, "-detach", "-sign=" + name + ".pem", "assets/example-short.nomad"}), ui.ErrorWriter.String())
+
+	job, _, err := srv.Client().Jobs().Info("example", nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, *job.SigningKeyID)
+
+	// Stop trusting the key
+	ui = cli.NewMockUi()
+	remove := &OperatorJobSigningKeyRemoveCommand{Meta: Meta{Ui: ui}}
+	require.Zero(t, remove.Run([]string{"-address=" + url, *job.SigningKeyID}), ui.ErrorWriter.String())
+
+	ui = cli.NewMockUi()
+	list = &OperatorJobSigningKeyListCommand{Meta: Meta{Ui: ui}}
+	require.Zero(t, list.Run([]string{"-address=" + url}), ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "No job signing keys found")
+}
@@ -3,6 +3,7 @@ package command
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/nomad/api"
 	flagHelper "github.com/hashicorp/nomad/helper/flags"
@@ -24,6 +25,7 @@ type OperatorSchedulerSetConfig struct {
 	memoryOversubscription   flagHelper.BoolValue
 	rejectJobRegistration    flagHelper.BoolValue
 	pauseEvalBroker          flagHelper.BoolValue
+	reconnectStabilization   string
 	preemptBatchScheduler    flagHelper.BoolValue
 	preemptServiceScheduler  flagHelper.BoolValue
 	preemptSysBatchScheduler flagHelper.BoolValue
@@ -41,6 +43,7 @@ func (o *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
 			"-memory-oversubscription":    complete.PredictSet("true", "false"),
 			"-reject-job-registration":    complete.PredictSet("true", "false"),
 			"-pause-eval-broker":          complete.PredictSet("true", "false"),
+			"-reconnect-stabilization":    complete.PredictAnything,
 			"-preempt-batch-scheduler":    complete.PredictSet("true", "false"),
 			"-preempt-service-scheduler":  complete.PredictSet("true", "false"),
 			"-preempt-sysbatch-scheduler": complete.PredictSet("true", "false"),
@@ -65,6 +68,7 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 	flags.Var(&o.memoryOversubscription, "memory-oversubscription", "")
 	flags.Var(&o.rejectJobRegistration, "reject-job-registration", "")
 	flags.Var(&o.pauseEvalBroker, "pause-eval-broker", "")
+	flags.StringVar(&o.reconnectStabilization, "reconnect-stabilization", "", "")
 	flags.Var(&o.preemptBatchScheduler, "preempt-batch-scheduler", "")
 	flags.Var(&o.preemptServiceScheduler, "preempt-service-scheduler", "")
 	flags.Var(&o.preemptSysBatchScheduler, "preempt-sysbatch-scheduler", "")
@@ -97,6 +101,15 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 		return 1
 	}
 
+	var reconnectStabilization time.Duration
+	if o.reconnectStabilization != "" {
+		reconnectStabilization, err = time.ParseDuration(o.reconnectStabilization)
+		if err != nil {
+			o.Ui.Error(fmt.Sprintf("Error parsing reconnect-stabilization value %q: %v", o.reconnectStabilization, err))
+			return 1
+		}
+	}
+
 	// Fetch the current configuration. This will be used as a base to merge
 	// user configuration onto.
 	resp, _, err := client.Operator().SchedulerGetConfiguration(nil)
@@ -127,6 +140,9 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 	o.memoryOversubscription.Merge(&schedulerConfig.MemoryOversubscriptionEnabled)
 	o.rejectJobRegistration.Merge(&schedulerConfig.RejectJobRegistration)
 	o.pauseEvalBroker.Merge(&schedulerConfig.PauseEvalBroker)
+	if o.reconnectStabilization != "" {
+		schedulerConfig.ReconnectStabilization = reconnectStabilization
+	}
 	o.preemptBatchScheduler.Merge(&schedulerConfig.PreemptionConfig.BatchSchedulerEnabled)
 	o.preemptServiceScheduler.Merge(&schedulerConfig.PreemptionConfig.ServiceSchedulerEnabled)
 	o.preemptSysBatchScheduler.Merge(&schedulerConfig.PreemptionConfig.SysBatchSchedulerEnabled)
@@ -190,6 +206,12 @@ Scheduler Set Config Options:
     When set to true, the eval broker which usually runs on the leader will be
     disabled. This will prevent the scheduler workers from receiving new work.
 
+  -reconnect-stabilization=<duration>
+    Specifies how long a node must stay connected after reconnecting before
+    the scheduler reinstates its unknown allocations and stops their
+    replacements. This prevents allocations from churning when a node's
+    connection flaps. Set to "0s" to reinstate allocations immediately.
+
   -preempt-batch-scheduler=[true|false]
     Specifies whether preemption for batch jobs is enabled. Note that if this
     is set to true, then batch jobs can preempt any other jobs.
@@ -3,6 +3,7 @@ package command
 import (
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
@@ -42,6 +43,7 @@ func TestOperatorSchedulerSetConfig_Run(t *testing.T) {
 		"-address=" + addr,
 		"-scheduler-algorithm=spread",
 		"-pause-eval-broker=true",
+		"-reconnect-stabilization=5m",
 		"-memory-oversubscription=true",
 		"-reject-job-registration=true",
 		"-preempt-batch-scheduler=true",
@@ -66,6 +68,7 @@ func TestOperatorSchedulerSetConfig_Run(t *testing.T) {
 		MemoryOversubscriptionEnabled: true,
 		RejectJobRegistration:         true,
 		PauseEvalBroker:               true,
+		ReconnectStabilization:        5 * time.Minute,
 	}, modifiedConfig.SchedulerConfig)
 
 	ui.ErrorWriter.Reset()
@@ -104,5 +107,6 @@ func schedulerConfigEquals(t *testing.T, expected, actual *api.SchedulerConfigur
 	require.Equal(t, expected.RejectJobRegistration, actual.RejectJobRegistration)
 	require.Equal(t, expected.MemoryOversubscriptionEnabled, actual.MemoryOversubscriptionEnabled)
 	require.Equal(t, expected.PauseEvalBroker, actual.PauseEvalBroker)
+	require.Equal(t, expected.ReconnectStabilization, actual.ReconnectStabilization)
 	require.Equal(t, expected.PreemptionConfig, actual.PreemptionConfig)
 }
@@ -2,6 +2,7 @@ package command
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/mitchellh/cli"
@@ -35,6 +36,14 @@ Usage: nomad operator secure-variables keyring [options]
 
       $ nomad operator secure-variables keyring list
 
+  Verify that all secure variables can be decrypted:
+
+      $ nomad operator secure-variables keyring verify
+
+  Report the results of the leader's periodic integrity checks:
+
+      $ nomad operator secure-variables keyring health
+
   Remove an encryption key from the keyring:
 
       $ nomad operator secure-variables keyring remove <key ID>
@@ -43,6 +52,26 @@ Usage: nomad operator secure-variables keyring [options]
 
       $ nomad operator secure-variables keyring install <path to .json file>
 
+  Export an encryption key sealed with a passphrase:
+
+      $ nomad operator secure-variables keyring export -key-id=<key ID> \
+          -i-understand-the-risks > key.bundle.json
+
+  Import an encryption key exported from another cluster:
+
+      $ nomad operator secure-variables keyring import \
+          -i-understand-the-risks key.bundle.json
+
+  Escrow the active encryption key into 5 shares, any 3 of which restore it:
+
+      $ nomad operator secure-variables keyring escrow -shares=5 -threshold=3 \
+          -bundle-file=key.escrow.json -i-understand-the-risks
+
+  Restore an escrowed encryption key:
+
+      $ nomad operator secure-variables keyring restore \
+          -i-understand-the-risks key.escrow.json
+
   Please see individual subcommand help for detailed usage information.
 `
 	return strings.TrimSpace(helpText)
@@ -77,11 +106,43 @@ func renderSecureVariablesKeysResponse(keys []*api.RootKeyMeta, verbose bool) st
 	}
 	out := make([]string, len(keys)+1)
 	out[0] = "Key|State|Create Time"
+	if verbose {
+		out[0] += "|Publish Time|Variables|Sign Operations|Last Used"
+	}
 	i := 1
 	for _, k := range keys {
 		out[i] = fmt.Sprintf("%s|%v|%s",
-			k.KeyID[:length], k.State, formatUnixNanoTime(k.CreateTime))
+			k.KeyID[:length], k.State, formatTime(k.CreateTime))
+		if verbose {
+			usage := k.Usage
+			if usage == nil {
+				usage = &api.RootKeyUsage{}
+			}
+			publishTime := "<none>"
+			if !k.PublishTime.IsZero() {
+				publishTime = formatTime(k.PublishTime)
+			}
+			lastUsed := "<none>"
+			if !usage.LastUsed.IsZero() {
+				lastUsed = formatTime(usage.LastUsed)
+			}
+			out[i] += fmt.Sprintf("|%s|%d|%d|%s",
+				publishTime, usage.Variables, usage.SignOperations, lastUsed)
+		}
 		i = i + 1
 	}
 	return formatList(out)
 }
+
+// readKeyringPassphrase reads the passphrase used to seal exported keys
+// from the file, or prompts for it if no file is given
+func readKeyringPassphrase(ui cli.Ui, path string) (string, error) {
+	if path != "" {
+		buf, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read passphrase file: %v", err)
+		}
+		return strings.TrimRight(string(buf), "\r\n"), nil
+	}
+	return ui.AskSecret("Passphrase:")
+}
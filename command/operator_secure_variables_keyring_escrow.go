@@ -0,0 +1,261 @@
+package command
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/helper/shamir"
+	"github.com/posener/complete"
+)
+
+const (
+	// keyringEscrowPassphraseSize is the number of random bytes of the
+	// passphrase sealing an escrowed key, which is split into the shares.
+	keyringEscrowPassphraseSize = 32
+)
+
+// keyringEscrowBundle is the file written by the escrow command. It holds the
+// sealed key, but not the shares of its passphrase.
+type keyringEscrowBundle struct {
+	Shares    int
+	Threshold int
+	Bundle    *api.RootKeyBundle
+}
+
+// OperatorSecureVariablesKeyringEscrowCommand is a Command implementation
+// that seals a secure variables encryption key with a random passphrase and
+// splits the passphrase into shares for escrow.
+type OperatorSecureVariablesKeyringEscrowCommand struct {
+	Meta
+}
+
+func (c *OperatorSecureVariablesKeyringEscrowCommand) Help() string {
+	helpText := `
+Usage: nomad operator secure-variables keyring escrow [options]
+
+  Escrow a secure variables encryption key, so that it can be restored if the
+  keystore is lost. The key is sealed with a random passphrase, in the same way
+  as with "nomad operator secure-variables keyring export", and the sealed key
+  bundle is written to a file. The passphrase is split with Shamir's Secret
+  Sharing into shares, which are printed once. Any threshold number of shares
+  restore the key with "nomad operator secure-variables keyring restore", while
+  fewer shares reveal nothing about it.
+
+  Distribute the shares to separate custodians and store them offline, apart
+  from the bundle.
+
+  If ACLs are enabled, this command requires a management token or a token
+  with the operator "keyring-transfer" capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Keyring Escrow Options:
+
+  -bundle-file=<path>
+    Write the sealed key bundle to this file, which must not exist. Required.
+
+  -key-id=<key ID>
+    The ID of the key to escrow. Defaults to the active key.
+
+  -shares=<n>
+    The number of shares to split the passphrase into. Defaults to 5.
+
+  -threshold=<n>
+    The number of shares required to restore the key. Defaults to 3.
+
+  -json
+    Output the shares in JSON format.
+
+  -i-understand-the-risks
+    Required to confirm that exporting the key material is intended.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorSecureVariablesKeyringEscrowCommand) Synopsis() string {
+	return "Escrows a secure variables encryption key with Shamir's Secret Sharing"
+}
+
+func (c *OperatorSecureVariablesKeyringEscrowCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-bundle-file":            complete.PredictFiles("*"),
+			"-key-id":                 complete.PredictAnything,
+			"-shares":                 complete.PredictAnything,
+			"-threshold":              complete.PredictAnything,
+			"-json":                   complete.PredictNothing,
+			"-i-understand-the-risks": complete.PredictNothing,
+		})
+}
+
+func (c *OperatorSecureVariablesKeyringEscrowCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OperatorSecureVariablesKeyringEscrowCommand) Name() string {
+	return "secure-variables keyring escrow"
+}
+
+func (c *OperatorSecureVariablesKeyringEscrowCommand) Run(args []string) int {
+	var bundleFile, keyID string
+	var shares, threshold int
+	var jsonOutput, confirmed bool
+
+	flags := c.Meta.FlagSet("secure-variables keyring escrow", FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&bundleFile, "bundle-file", "", "")
+	flags.StringVar(&keyID, "key-id", "", "")
+	flags.IntVar(&shares, "shares", 5, "")
+	flags.IntVar(&threshold, "threshold", 3, "")
+	flags.BoolVar(&jsonOutput, "json", false, "")
+	flags.BoolVar(&confirmed, "i-understand-the-risks", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if len(flags.Args()) != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if bundleFile == "" {
+		c.Ui.Error("The -bundle-file flag is required")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if threshold < 2 || shares < threshold || shares > shamir.MaxShares {
+		c.Ui.Error(fmt.Sprintf("The -threshold must be at least 2, and the -shares between the threshold and %d", shamir.MaxShares))
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if !confirmed {
+		c.Ui.Error("Escrowing a key requires the -i-understand-the-risks flag")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Create the file first, so that nothing is exported if it can't be
+	// written
+	f, err := os.OpenFile(bundleFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating bundle file: %s", err))
+		return 1
+	}
+	written := false
+	defer func() {
+		f.Close()
+		if !written {
+			os.Remove(bundleFile)
+		}
+	}()
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating nomad cli client: %s", err))
+		return 1
+	}
+
+	if keyID == "" {
+		keyID, err = activeRootKeyID(client)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("error: %s", err))
+			return 1
+		}
+	}
+
+	secret := make([]byte, keyringEscrowPassphraseSize)
+	if _, err := rand.Read(secret); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error generating passphrase: %s", err))
+		return 1
+	}
+	parts, err := shamir.Split(secret, shares, threshold)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error splitting passphrase: %s", err))
+		return 1
+	}
+
+	bundle, _, err := client.Keyring().Export(&api.KeyringExportOptions{
+		KeyID:      keyID,
+		Passphrase: keyringEscrowPassphrase(secret),
+	}, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("error: %s", err))
+		return 1
+	}
+
+	buf, err := json.MarshalIndent(&keyringEscrowBundle{
+		Shares:    shares,
+		Threshold: threshold,
+		Bundle:    bundle,
+	}, "", "  ")
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error formatting key bundle: %s", err))
+		return 1
+	}
+	if _, err := f.Write(append(buf, '\n')); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error writing bundle file: %s", err))
+		return 1
+	}
+	if err := f.Sync(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error writing bundle file: %s", err))
+		return 1
+	}
+	written = true
+
+	encoded := make([]string, len(parts))
+	for i, part := range parts {
+		encoded[i] = base64.StdEncoding.EncodeToString(part)
+	}
+
+	if jsonOutput {
+		out, err := Format(true, "", encoded)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+		return 0
+	}
+
+	c.Ui.Output(fmt.Sprintf("Escrowed encryption key %s to %q", bundle.KeyID, bundleFile))
+	c.Ui.Output("")
+	for i, share := range encoded {
+		c.Ui.Output(fmt.Sprintf("Share %d: %s", i+1, share))
+	}
+	c.Ui.Output("")
+	c.Ui.Output(wrapAtLength(fmt.Sprintf(
+		"The passphrase of the bundle was split into %d shares, %d of which are "+
+			"required to restore the key. Distribute the shares to separate "+
+			"custodians and store them offline. They will not be shown again.",
+		shares, threshold)))
+	return 0
+}
+
+// activeRootKeyID returns the ID of the active key of the keyring.
+func activeRootKeyID(client *api.Client) (string, error) {
+	keys, _, err := client.Keyring().List(nil)
+	if err != nil {
+		return "", err
+	}
+	for _, key := range keys {
+		if key.State == api.RootKeyStateActive {
+			return key.KeyID, nil
+		}
+	}
+	return "", fmt.Errorf("no active key found")
+}
+
+// keyringEscrowPassphrase returns the passphrase sealing an escrowed key from
+// the secret split into the shares.
+func keyringEscrowPassphrase(secret []byte) string {
+	return base64.RawURLEncoding.EncodeToString(secret)
+}
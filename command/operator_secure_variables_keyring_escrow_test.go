@@ -0,0 +1,138 @@
+package command
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperatorSecureVariablesKeyringEscrowCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorSecureVariablesKeyringEscrowCommand{}
+	var _ cli.Command = &OperatorSecureVariablesKeyringRestoreCommand{}
+}
+
+func TestOperatorSecureVariablesKeyringEscrowCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	bundleFile := filepath.Join(t.TempDir(), "escrow.json")
+	testCases := []struct {
+		name      string
+		args      []string
+		expectErr string
+	}{
+		{
+			name:      "no bundle file",
+			args:      []string{"-i-understand-the-risks"},
+			expectErr: "The -bundle-file flag is required",
+		},
+		{
+			name:      "bad threshold",
+			args:      []string{"-bundle-file", bundleFile, "-shares", "2", "-threshold", "3", "-i-understand-the-risks"},
+			expectErr: "The -threshold must be at least 2",
+		},
+		{
+			name:      "not confirmed",
+			args:      []string{"-bundle-file", bundleFile},
+			expectErr: "requires the -i-understand-the-risks flag",
+		},
+		{
+			name:      "bad address",
+			args:      []string{"-address", "nope", "-bundle-file", bundleFile, "-i-understand-the-risks"},
+			expectErr: "error:",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := &OperatorSecureVariablesKeyringEscrowCommand{Meta: Meta{Ui: ui}}
+			code := cmd.Run(tc.args)
+			require.Equal(t, 1, code)
+			require.Contains(t, ui.ErrorWriter.String(), tc.expectErr)
+
+			// The bundle file isn't left behind on failures
+			require.NoFileExists(t, bundleFile)
+		})
+	}
+}
+
+func TestOperatorSecureVariablesKeyringEscrowCommand_Restore(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+	testutil.WaitForLeader(t, srv.Agent.RPC)
+
+	keys, _, err := client.Keyring().List(nil)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+
+	dir := t.TempDir()
+	escrow := func(name string) []string {
+		bundleFile := filepath.Join(dir, name)
+		ui := cli.NewMockUi()
+		cmd := &OperatorSecureVariablesKeyringEscrowCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"-address=" + url, "-bundle-file=" + bundleFile,
+			"-shares=4", "-threshold=3", "-i-understand-the-risks"})
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+		require.Contains(t, ui.OutputWriter.String(), "Escrowed encryption key "+keys[0].KeyID)
+
+		// The bundle file records the threshold, and only the owner can
+		// read it
+		info, err := os.Stat(bundleFile)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		buf, err := os.ReadFile(bundleFile)
+		require.NoError(t, err)
+		var bundle keyringEscrowBundle
+		require.NoError(t, json.Unmarshal(buf, &bundle))
+		require.Equal(t, 4, bundle.Shares)
+		require.Equal(t, 3, bundle.Threshold)
+		require.Equal(t, keys[0].KeyID, bundle.Bundle.KeyID)
+
+		matches := regexp.MustCompile(`(?m)^Share \d: (\S+)$`).FindAllStringSubmatch(ui.OutputWriter.String(), -1)
+		require.Len(t, matches, 4)
+		shares := make([]string, len(matches))
+		for i, m := range matches {
+			shares[i] = m[1]
+		}
+		return shares
+	}
+	shares := escrow("escrow.json")
+	otherShares := escrow("other.json")
+
+	// Escrowing never overwrites an existing bundle
+	ui := cli.NewMockUi()
+	escrowCmd := &OperatorSecureVariablesKeyringEscrowCommand{Meta: Meta{Ui: ui}}
+	code := escrowCmd.Run([]string{"-address=" + url, "-bundle-file=" + filepath.Join(dir, "escrow.json"), "-i-understand-the-risks"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "Error creating bundle file")
+
+	// Shares of another escrow don't restore the key
+	ui = cli.NewMockUi()
+	cmd := &OperatorSecureVariablesKeyringRestoreCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-share=" + shares[0], "-share=" + shares[1],
+		"-share=" + otherShares[2], "-i-understand-the-risks", filepath.Join(dir, "escrow.json")})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "Check that the shares were created with this bundle")
+
+	// The missing shares are prompted for. The mock UI buffers its input on
+	// each prompt, so it must be read one byte at a time.
+	ui = cli.NewMockUi()
+	ui.InputReader = iotest.OneByteReader(strings.NewReader(shares[3] + "\n" + shares[1] + "\n"))
+	cmd = &OperatorSecureVariablesKeyringRestoreCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-share=" + shares[2],
+		"-i-understand-the-risks", filepath.Join(dir, "escrow.json")})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "Share 2 of 3:")
+	require.Contains(t, ui.OutputWriter.String(), "Restored encryption key "+keys[0].KeyID)
+}
@@ -0,0 +1,132 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+// OperatorSecureVariablesKeyringExportCommand is a Command implementation
+// that exports a secure variables encryption key sealed with a passphrase.
+type OperatorSecureVariablesKeyringExportCommand struct {
+	Meta
+}
+
+func (c *OperatorSecureVariablesKeyringExportCommand) Help() string {
+	helpText := `
+Usage: nomad operator secure-variables keyring export [options]
+
+  Export a secure variables encryption key, sealed with a passphrase, so that
+  it can be imported into another cluster if the keystore is lost. The sealed
+  key bundle is written to stdout as JSON.
+
+  Anyone holding the bundle and its passphrase can decrypt every secure
+  variable encrypted with the key, and sign workload identities that the
+  cluster will accept. Store both separately and securely.
+
+  If ACLs are enabled, this command requires a management token or a token
+  with the operator "keyring-transfer" capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Keyring Export Options:
+
+  -key-id=<key ID>
+    The ID of the key to export. Required.
+
+  -passphrase-file=<path>
+    Read the passphrase from this file instead of prompting for it. The
+    passphrase must be at least 12 characters.
+
+  -i-understand-the-risks
+    Required to confirm that exporting the key material is intended.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorSecureVariablesKeyringExportCommand) Synopsis() string {
+	return "Exports a secure variables encryption key sealed with a passphrase"
+}
+
+func (c *OperatorSecureVariablesKeyringExportCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-key-id":                 complete.PredictAnything,
+			"-passphrase-file":        complete.PredictFiles("*"),
+			"-i-understand-the-risks": complete.PredictNothing,
+		})
+}
+
+func (c *OperatorSecureVariablesKeyringExportCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OperatorSecureVariablesKeyringExportCommand) Name() string {
+	return "secure-variables keyring export"
+}
+
+func (c *OperatorSecureVariablesKeyringExportCommand) Run(args []string) int {
+	var keyID, passphraseFile string
+	var confirmed bool
+
+	flags := c.Meta.FlagSet("secure-variables keyring export", FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&keyID, "key-id", "", "")
+	flags.StringVar(&passphraseFile, "passphrase-file", "", "")
+	flags.BoolVar(&confirmed, "i-understand-the-risks", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if len(flags.Args()) != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if keyID == "" {
+		c.Ui.Error("The -key-id flag is required")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if !confirmed {
+		c.Ui.Error("Exporting a key requires the -i-understand-the-risks flag")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	passphrase, err := readKeyringPassphrase(c.Ui, passphraseFile)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading passphrase: %s", err))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating nomad cli client: %s", err))
+		return 1
+	}
+
+	bundle, _, err := client.Keyring().Export(&api.KeyringExportOptions{
+		KeyID:      keyID,
+		Passphrase: passphrase,
+	}, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("error: %s", err))
+		return 1
+	}
+
+	out, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error formatting key bundle: %s", err))
+		return 1
+	}
+	c.Ui.Output(string(out))
+	return 0
+}
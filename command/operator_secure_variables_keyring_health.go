@@ -0,0 +1,115 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+// OperatorSecureVariablesKeyringHealthCommand is a Command implementation
+// that reports the results of the leader's secure variables integrity checks.
+type OperatorSecureVariablesKeyringHealthCommand struct {
+	Meta
+}
+
+func (c *OperatorSecureVariablesKeyringHealthCommand) Help() string {
+	helpText := `
+Usage: nomad operator secure-variables keyring health [options]
+
+  Report the results of the integrity checks of the encrypted secure variables.
+  The leader periodically decrypts a random sample of secure variables, which
+  authenticates their ciphertext, and keeps checking those that fail until they
+  are rewritten or deleted. Results are reset when a new leader is elected.
+
+  The command exits with code 2 if any checked secure variable cannot be
+  decrypted, so it can be used as a health check.
+
+  If ACLs are enabled, this command requires a management token.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Keyring Options:
+
+  -verbose
+    Show full key IDs.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorSecureVariablesKeyringHealthCommand) Synopsis() string {
+	return "Reports the integrity of encrypted secure variables"
+}
+
+func (c *OperatorSecureVariablesKeyringHealthCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-verbose": complete.PredictNothing,
+		})
+}
+
+func (c *OperatorSecureVariablesKeyringHealthCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OperatorSecureVariablesKeyringHealthCommand) Name() string {
+	return "secure-variables keyring health"
+}
+
+func (c *OperatorSecureVariablesKeyringHealthCommand) Run(args []string) int {
+	var verbose bool
+
+	flags := c.Meta.FlagSet("secure-variables keyring health", FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 0 {
+		c.Ui.Error("This command requires no arguments.")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating nomad cli client: %s", err))
+		return 1
+	}
+
+	resp, _, err := client.Keyring().Health(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("error: %s", err))
+		return 1
+	}
+
+	if resp.LastCheck.IsZero() {
+		c.Ui.Output("The leader has not checked any secure variables since it was elected")
+		return 0
+	}
+	if resp.Healthy {
+		c.Ui.Output(fmt.Sprintf("All %d secure variables checked since the leader was elected can be decrypted (last check %s)",
+			resp.Checked, formatTime(resp.LastCheck)))
+		return 0
+	}
+
+	length := fullId
+	if !verbose {
+		length = shortId
+	}
+	out := make([]string, len(resp.Failed)+1)
+	out[0] = "Namespace|Path|Key|Error"
+	for i, failed := range resp.Failed {
+		out[i+1] = fmt.Sprintf("%s|%s|%s|%s",
+			failed.Namespace, failed.Path, limit(failed.KeyID, length), failed.Error)
+	}
+	c.Ui.Error(fmt.Sprintf("%d secure variables failed their integrity check (last check %s):\n\n%s",
+		len(resp.Failed), formatTime(resp.LastCheck), formatList(out)))
+	return 2
+}
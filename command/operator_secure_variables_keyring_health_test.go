@@ -0,0 +1,32 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperatorSecureVariablesKeyringHealthCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorSecureVariablesKeyringHealthCommand{}
+}
+
+func TestOperatorSecureVariablesKeyringHealthCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &OperatorSecureVariablesKeyringHealthCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	require.Equal(t, 1, cmd.Run([]string{"-address=" + url, "extra"}))
+	require.Contains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	require.Equal(t, 0, cmd.Run([]string{"-address=" + url}))
+	require.Contains(t, ui.OutputWriter.String(), "The leader has not checked any secure variables")
+}
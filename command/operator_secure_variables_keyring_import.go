@@ -0,0 +1,142 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+// OperatorSecureVariablesKeyringImportCommand is a Command implementation
+// that imports a secure variables encryption key from a sealed bundle.
+type OperatorSecureVariablesKeyringImportCommand struct {
+	Meta
+}
+
+func (c *OperatorSecureVariablesKeyringImportCommand) Help() string {
+	helpText := `
+Usage: nomad operator secure-variables keyring import [options] <filepath>
+
+  Import a secure variables encryption key from a bundle written by the
+  "nomad operator secure-variables keyring export" command. The bundle will be
+  read from stdin by specifying "-", otherwise a path to the file is expected.
+
+  A key that isn't already in the keyring is installed as inactive, so that it
+  can decrypt existing secure variables without being used for new ones. A key
+  that is already known keeps its current state, and its material can't be
+  replaced by a bundle with the same key ID.
+
+  If ACLs are enabled, this command requires a management token or a token
+  with the operator "keyring-transfer" capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Keyring Import Options:
+
+  -passphrase-file=<path>
+    Read the passphrase from this file instead of prompting for it.
+
+  -i-understand-the-risks
+    Required to confirm that importing the key material is intended.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorSecureVariablesKeyringImportCommand) Synopsis() string {
+	return "Imports a secure variables encryption key from a sealed bundle"
+}
+
+func (c *OperatorSecureVariablesKeyringImportCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-passphrase-file":        complete.PredictFiles("*"),
+			"-i-understand-the-risks": complete.PredictNothing,
+		})
+}
+
+func (c *OperatorSecureVariablesKeyringImportCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFiles("*.json")
+}
+
+func (c *OperatorSecureVariablesKeyringImportCommand) Name() string {
+	return "secure-variables keyring import"
+}
+
+func (c *OperatorSecureVariablesKeyringImportCommand) Run(args []string) int {
+	var passphraseFile string
+	var confirmed bool
+
+	flags := c.Meta.FlagSet("secure-variables keyring import", FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&passphraseFile, "passphrase-file", "", "")
+	flags.BoolVar(&confirmed, "i-understand-the-risks", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command requires one argument: <filepath>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if !confirmed {
+		c.Ui.Error("Importing a key requires the -i-understand-the-risks flag")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	var buf []byte
+	var err error
+	if args[0] == "-" {
+		buf, err = ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to read stdin: %v", err))
+			return 1
+		}
+	} else {
+		buf, err = ioutil.ReadFile(args[0])
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to read file: %v", err))
+			return 1
+		}
+	}
+
+	bundle := &api.RootKeyBundle{}
+	if err := json.NewDecoder(bytes.NewBuffer(buf)).Decode(bundle); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to parse key bundle: %v", err))
+		return 1
+	}
+
+	passphrase, err := readKeyringPassphrase(c.Ui, passphraseFile)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading passphrase: %s", err))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating nomad cli client: %s", err))
+		return 1
+	}
+
+	meta, _, err := client.Keyring().Import(&api.KeyringImportRequest{
+		Bundle:     bundle,
+		Passphrase: passphrase,
+	}, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("error: %s", err))
+		return 1
+	}
+	c.Ui.Output(fmt.Sprintf("Imported encryption key %s (%s)", meta.KeyID, meta.State))
+	return 0
+}
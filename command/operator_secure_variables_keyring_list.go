@@ -29,7 +29,10 @@ General Options:
 Keyring Options:
 
   -verbose
-    Show full information.
+    Show full information, including how many secure variables are encrypted
+    with each key and how often the leader has used it since it was elected.
+    A key that encrypts no variables and hasn't been used recently can safely
+    be removed.
 `
 
 	return strings.TrimSpace(helpText)
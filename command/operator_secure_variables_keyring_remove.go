@@ -22,6 +22,12 @@ Usage: nomad operator secure-variables keyring remove [options] <key ID>
   Remove an encryption key from the cluster. This operation may only be
   performed on keys that are not the active key.
 
+  Secure variables encrypted with a removed key can no longer be read. Before
+  removing a key, run "nomad operator secure-variables keyring verify" to
+  check that every secure variable can be decrypted, and "nomad operator
+  secure-variables keyring list -verbose" to check that no secure variable is
+  still encrypted with the key.
+
   If ACLs are enabled, this command requires a management token.
 
 General Options:
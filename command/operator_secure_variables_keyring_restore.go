@@ -0,0 +1,166 @@
+package command
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	flaghelper "github.com/hashicorp/nomad/helper/flags"
+	"github.com/hashicorp/nomad/helper/shamir"
+	"github.com/posener/complete"
+)
+
+// OperatorSecureVariablesKeyringRestoreCommand is a Command implementation
+// that restores a secure variables encryption key escrowed with the escrow
+// command.
+type OperatorSecureVariablesKeyringRestoreCommand struct {
+	Meta
+}
+
+func (c *OperatorSecureVariablesKeyringRestoreCommand) Help() string {
+	helpText := `
+Usage: nomad operator secure-variables keyring restore [options] <filepath>
+
+  Restore a secure variables encryption key from a bundle written by the
+  "nomad operator secure-variables keyring escrow" command. The shares of the
+  passphrase are combined to unseal the key, which is then imported in the
+  same way as with "nomad operator secure-variables keyring import".
+
+  The shares can be given with the -share flag. The command prompts for the
+  remaining shares until the threshold recorded in the bundle is reached, so
+  that each custodian can enter theirs without it being echoed or kept in the
+  shell history.
+
+  If ACLs are enabled, this command requires a management token or a token
+  with the operator "keyring-transfer" capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Keyring Restore Options:
+
+  -share=<share>
+    A share of the passphrase. Can be specified multiple times.
+
+  -i-understand-the-risks
+    Required to confirm that importing the key material is intended.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorSecureVariablesKeyringRestoreCommand) Synopsis() string {
+	return "Restores a secure variables encryption key from escrow"
+}
+
+func (c *OperatorSecureVariablesKeyringRestoreCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-share":                  complete.PredictAnything,
+			"-i-understand-the-risks": complete.PredictNothing,
+		})
+}
+
+func (c *OperatorSecureVariablesKeyringRestoreCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFiles("*.json")
+}
+
+func (c *OperatorSecureVariablesKeyringRestoreCommand) Name() string {
+	return "secure-variables keyring restore"
+}
+
+func (c *OperatorSecureVariablesKeyringRestoreCommand) Run(args []string) int {
+	var shareArgs []string
+	var confirmed bool
+
+	flags := c.Meta.FlagSet("secure-variables keyring restore", FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.Var((*flaghelper.StringFlag)(&shareArgs), "share", "")
+	flags.BoolVar(&confirmed, "i-understand-the-risks", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command requires one argument: <filepath>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if !confirmed {
+		c.Ui.Error("Restoring a key requires the -i-understand-the-risks flag")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	buf, err := os.ReadFile(args[0])
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to read file: %v", err))
+		return 1
+	}
+	var escrow keyringEscrowBundle
+	if err := json.Unmarshal(buf, &escrow); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to parse escrow bundle: %v", err))
+		return 1
+	}
+	if escrow.Bundle == nil || escrow.Threshold < 2 {
+		c.Ui.Error("Failed to parse escrow bundle: missing key bundle or threshold")
+		return 1
+	}
+
+	parts := make([][]byte, 0, escrow.Threshold)
+	addShare := func(share string) error {
+		part, err := base64.StdEncoding.DecodeString(strings.TrimSpace(share))
+		if err != nil {
+			return fmt.Errorf("invalid share: %v", err)
+		}
+		parts = append(parts, part)
+		return nil
+	}
+	for _, share := range shareArgs {
+		if err := addShare(share); err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+	}
+	for len(parts) < escrow.Threshold {
+		share, err := c.Ui.AskSecret(fmt.Sprintf("Share %d of %d:", len(parts)+1, escrow.Threshold))
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading share: %s", err))
+			return 1
+		}
+		if err := addShare(share); err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+	}
+
+	secret, err := shamir.Combine(parts)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error combining shares: %s", err))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating nomad cli client: %s", err))
+		return 1
+	}
+
+	meta, _, err := client.Keyring().Import(&api.KeyringImportRequest{
+		Bundle:     escrow.Bundle,
+		Passphrase: keyringEscrowPassphrase(secret),
+	}, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("error: %s", err))
+		c.Ui.Error("Check that the shares were created with this bundle")
+		return 1
+	}
+	c.Ui.Output(fmt.Sprintf("Restored encryption key %s (%s)", meta.KeyID, meta.State))
+	return 0
+}
@@ -3,6 +3,7 @@ package command
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/posener/complete"
@@ -20,6 +21,9 @@ Usage: nomad operator secure-variables keyring rotate [options]
 
   Generate a new encryption key for all future variables.
 
+  If a key has been prepublished ahead of a scheduled rotation, that key is
+  made active immediately instead of generating a new key.
+
   If ACLs are enabled, this command requires a management token.
 
 General Options:
@@ -33,6 +37,11 @@ Keyring Options:
     will immediately return and the re-encryption process will run
     asynchronously on the leader.
 
+  -schedule=<duration>
+    Schedule the rotation instead of rotating immediately. The new key is
+    prepublished to all servers, and becomes the active key once the duration
+    has elapsed, for example "24h". Cannot be used with -full.
+
   -verbose
     Show full information.
 `
@@ -47,8 +56,9 @@ func (c *OperatorSecureVariablesKeyringRotateCommand) Synopsis() string {
 func (c *OperatorSecureVariablesKeyringRotateCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-full":    complete.PredictNothing,
-			"-verbose": complete.PredictNothing,
+			"-full":     complete.PredictNothing,
+			"-schedule": complete.PredictAnything,
+			"-verbose":  complete.PredictNothing,
 		})
 }
 
@@ -62,10 +72,12 @@ func (c *OperatorSecureVariablesKeyringRotateCommand) Name() string {
 
 func (c *OperatorSecureVariablesKeyringRotateCommand) Run(args []string) int {
 	var rotateFull, verbose bool
+	var schedule time.Duration
 
 	flags := c.Meta.FlagSet("secure-variables keyring rotate", FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&rotateFull, "full", false, "full key rotation")
+	flags.DurationVar(&schedule, "schedule", 0, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
 
 	if err := flags.Parse(args); err != nil {
@@ -79,14 +91,26 @@ func (c *OperatorSecureVariablesKeyringRotateCommand) Run(args []string) int {
 		return 1
 	}
 
+	opts := &api.KeyringRotateOptions{Full: rotateFull}
+	if schedule < 0 {
+		c.Ui.Error("The -schedule duration cannot be negative")
+		return 1
+	}
+	if schedule > 0 {
+		opts.PublishTime = time.Now().Add(schedule)
+	}
+	if err := opts.Validate(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Invalid options: %s", err))
+		return 1
+	}
+
 	client, err := c.Meta.Client()
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error creating nomad cli client: %s", err))
 		return 1
 	}
 
-	resp, _, err := client.Keyring().Rotate(
-		&api.KeyringRotateOptions{Full: rotateFull}, nil)
+	resp, _, err := client.Keyring().Rotate(opts, nil)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("error: %s", err))
 		return 1
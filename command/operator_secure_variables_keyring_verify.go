@@ -0,0 +1,109 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+// OperatorSecureVariablesKeyringVerifyCommand is a Command implementation
+// that checks every secure variable can be decrypted with the keyring.
+type OperatorSecureVariablesKeyringVerifyCommand struct {
+	Meta
+}
+
+func (c *OperatorSecureVariablesKeyringVerifyCommand) Help() string {
+	helpText := `
+Usage: nomad operator secure-variables keyring verify [options]
+
+  Verify that every secure variable can be decrypted with the keys installed in
+  the keyring, and list the secure variables which cannot. Variables become
+  orphaned when the key that encrypted them is removed, so run this command
+  before removing inactive keys.
+
+  The command exits with code 2 if any secure variable cannot be decrypted.
+
+  If ACLs are enabled, this command requires a management token.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Keyring Options:
+
+  -verbose
+    Show full key IDs.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorSecureVariablesKeyringVerifyCommand) Synopsis() string {
+	return "Verifies that all secure variables can be decrypted"
+}
+
+func (c *OperatorSecureVariablesKeyringVerifyCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-verbose": complete.PredictNothing,
+		})
+}
+
+func (c *OperatorSecureVariablesKeyringVerifyCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OperatorSecureVariablesKeyringVerifyCommand) Name() string {
+	return "secure-variables keyring verify"
+}
+
+func (c *OperatorSecureVariablesKeyringVerifyCommand) Run(args []string) int {
+	var verbose bool
+
+	flags := c.Meta.FlagSet("secure-variables keyring verify", FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 0 {
+		c.Ui.Error("This command requires no arguments.")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating nomad cli client: %s", err))
+		return 1
+	}
+
+	resp, _, err := client.Keyring().Verify(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("error: %s", err))
+		return 1
+	}
+
+	if len(resp.Orphaned) == 0 {
+		c.Ui.Output(fmt.Sprintf("All %d secure variables can be decrypted", resp.Variables))
+		return 0
+	}
+
+	length := fullId
+	if !verbose {
+		length = shortId
+	}
+	out := make([]string, len(resp.Orphaned)+1)
+	out[0] = "Namespace|Path|Key|Error"
+	for i, orphan := range resp.Orphaned {
+		out[i+1] = fmt.Sprintf("%s|%s|%s|%s",
+			orphan.Namespace, orphan.Path, limit(orphan.KeyID, length), orphan.Error)
+	}
+	c.Ui.Error(fmt.Sprintf("%d of %d secure variables cannot be decrypted:\n\n%s",
+		len(resp.Orphaned), resp.Variables, formatList(out)))
+	return 2
+}
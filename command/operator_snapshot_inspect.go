@@ -2,10 +2,16 @@ package command
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
 
+	humanize "github.com/dustin/go-humanize"
+	"github.com/hashicorp/nomad/helper/raftutil"
 	"github.com/hashicorp/nomad/helper/snapshot"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/posener/complete"
 )
 
@@ -21,12 +27,21 @@ Usage: nomad operator snapshot inspect [options] <file>
 
   To inspect the file "backup.snap":
     $ nomad operator snapshot inspect backup.snap
+
+Snapshot Inspect Options:
+
+  -vars
+    Report the number and encrypted size of the secure variables in each
+    namespace, and the root keys which encrypt them. Secure variables are not
+    decrypted.
 `
 	return strings.TrimSpace(helpText)
 }
 
 func (c *OperatorSnapshotInspectCommand) AutocompleteFlags() complete.Flags {
-	return complete.Flags{}
+	return complete.Flags{
+		"-vars": complete.PredictNothing,
+	}
 }
 
 func (c *OperatorSnapshotInspectCommand) AutocompleteArgs() complete.Predictor {
@@ -40,7 +55,19 @@ func (c *OperatorSnapshotInspectCommand) Synopsis() string {
 func (c *OperatorSnapshotInspectCommand) Name() string { return "operator snapshot inspect" }
 
 func (c *OperatorSnapshotInspectCommand) Run(args []string) int {
+	var vars bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetNone)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&vars, "vars", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to parse args: %v", err))
+		return 1
+	}
+
 	// Check that we either got no filename or exactly one.
+	args = flags.Args()
 	if len(args) != 1 {
 		c.Ui.Error("This command takes one argument: <filename>")
 		c.Ui.Error(commandErrorText(c))
@@ -70,5 +97,131 @@ func (c *OperatorSnapshotInspectCommand) Run(args []string) int {
 	}
 
 	c.Ui.Output(formatList(output))
+
+	if !vars {
+		return 0
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading snapshot file: %s", err))
+		return 1
+	}
+	store, _, err := raftutil.RestoreFromArchive(f, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to read archive file: %s", err))
+		return 1
+	}
+
+	stats, err := snapshotSecureVariableStats(store)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading secure variables: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(c.Colorize().Color("\n[bold]Secure Variables[reset]"))
+	c.Ui.Output(formatSnapshotVarNamespaces(stats))
+	c.Ui.Output(c.Colorize().Color("\n[bold]Root Keys[reset]"))
+	c.Ui.Output(formatSnapshotVarKeys(stats))
 	return 0
 }
+
+// snapshotVarStats summarizes the secure variables in a snapshot.
+type snapshotVarStats struct {
+	namespaces map[string]*snapshotVarCount
+	keys       map[string]*snapshotVarCount
+
+	// keyStates is the state of each root key in the snapshot. Keys which
+	// encrypt variables but have no metadata in the snapshot are missing.
+	keyStates map[string]structs.RootKeyState
+}
+
+type snapshotVarCount struct {
+	vars int
+	size int
+}
+
+// snapshotSecureVariableStats counts the secure variables in the state store
+// by namespace and by root key, using only their encrypted payloads.
+func snapshotSecureVariableStats(store *state.StateStore) (*snapshotVarStats, error) {
+	stats := &snapshotVarStats{
+		namespaces: make(map[string]*snapshotVarCount),
+		keys:       make(map[string]*snapshotVarCount),
+		keyStates:  make(map[string]structs.RootKeyState),
+	}
+
+	iter, err := store.SecureVariables(nil)
+	if err != nil {
+		return nil, err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		sv := raw.(*structs.SecureVariableEncrypted)
+
+		ns, ok := stats.namespaces[sv.Namespace]
+		if !ok {
+			ns = &snapshotVarCount{}
+			stats.namespaces[sv.Namespace] = ns
+		}
+		ns.vars++
+		ns.size += len(sv.Data)
+
+		key, ok := stats.keys[sv.KeyID]
+		if !ok {
+			key = &snapshotVarCount{}
+			stats.keys[sv.KeyID] = key
+		}
+		key.vars++
+		key.size += len(sv.Data)
+	}
+
+	iter, err = store.RootKeyMetas(nil)
+	if err != nil {
+		return nil, err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		meta := raw.(*structs.RootKeyMeta)
+		stats.keyStates[meta.KeyID] = meta.State
+		if _, ok := stats.keys[meta.KeyID]; !ok {
+			stats.keys[meta.KeyID] = &snapshotVarCount{}
+		}
+	}
+
+	return stats, nil
+}
+
+func formatSnapshotVarNamespaces(stats *snapshotVarStats) string {
+	names := make([]string, 0, len(stats.namespaces))
+	for name := range stats.namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var total snapshotVarCount
+	out := []string{"Namespace|Variables|Encrypted Size"}
+	for _, name := range names {
+		count := stats.namespaces[name]
+		total.vars += count.vars
+		total.size += count.size
+		out = append(out, fmt.Sprintf("%s|%d|%s", name, count.vars, humanize.IBytes(uint64(count.size))))
+	}
+	out = append(out, fmt.Sprintf("Total|%d|%s", total.vars, humanize.IBytes(uint64(total.size))))
+	return formatList(out)
+}
+
+func formatSnapshotVarKeys(stats *snapshotVarStats) string {
+	ids := make([]string, 0, len(stats.keys))
+	for id := range stats.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	out := []string{"Key ID|State|Variables|Encrypted Size"}
+	for _, id := range ids {
+		count := stats.keys[id]
+		keyState := string(stats.keyStates[id])
+		if keyState == "" {
+			keyState = "missing"
+		}
+		out = append(out, fmt.Sprintf("%s|%s|%d|%s", id, keyState, count.vars, humanize.IBytes(uint64(count.size))))
+	}
+	return formatList(out)
+}
@@ -35,6 +35,32 @@ func TestOperatorSnapshotInspect_Works(t *testing.T) {
 	}
 }
 
+func TestOperatorSnapshotInspect_Vars(t *testing.T) {
+	ci.Parallel(t)
+
+	snapPath := generateSnapshotFile(t, func(srv *agent.TestAgent, client *api.Client, url string) {
+		for _, path := range []string{"test/one", "test/two"} {
+			sv := api.NewSecureVariable(path)
+			sv.Items["password"] = "hunter2"
+			_, _, err := client.SecureVariables().Create(sv, nil)
+			require.NoError(t, err)
+		}
+	})
+
+	ui := cli.NewMockUi()
+	cmd := &OperatorSnapshotInspectCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-vars", snapPath})
+	require.Zero(t, code, ui.ErrorWriter.String())
+
+	output := ui.OutputWriter.String()
+	require.Contains(t, output, "Secure Variables")
+	require.Contains(t, output, "Root Keys")
+	require.Regexp(t, `default\s+2\s+`, output)
+	require.Regexp(t, `active\s+2\s+`, output)
+	require.NotContains(t, output, "hunter2")
+}
+
 func TestOperatorSnapshotInspect_HandlesFailure(t *testing.T) {
 	ci.Parallel(t)
 
@@ -0,0 +1,195 @@
+package command
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/nomad/api"
+)
+
+const (
+	// redactedValue replaces the values of sensitive env and meta keys.
+	redactedValue = "<redacted>"
+
+	// sensitiveOptionsUsage is the help text shared by commands that redact
+	// sensitive env and meta values.
+	sensitiveOptionsUsage = `-show-sensitive
+    Display the values of env and meta keys that look sensitive. By default
+    the values of keys with a PASSWORD, TOKEN, or KEY word in their name, such
+    as DB_PASSWORD or apiKey, are redacted. The patterns can be overridden
+    with a comma separated list in the NOMAD_CLI_SENSITIVE_PATTERNS
+    environment variable.`
+
+	// redactSensitiveUsage is the help text of the commands whose output
+	// can be submitted back to Nomad, and so is only redacted on request.
+	redactSensitiveUsage = `-redact-sensitive
+    Redact the values of env and meta keys that look sensitive, which are
+    keys with a PASSWORD, TOKEN, or KEY word in their name, such as
+    DB_PASSWORD or apiKey. The patterns can be overridden with a comma
+    separated list in the NOMAD_CLI_SENSITIVE_PATTERNS environment variable.
+    Redacted jobs can't be submitted back with "nomad job run -json".`
+
+	// redactVarUsage is the help text shared by the var commands that redact
+	// the values of secure variable items.
+	redactVarUsage = `-redact
+    Redact the values of the secure variable items from the output. Defaults
+    to true, unless the NOMAD_VAR_SHOW_VALUES environment variable is set to
+    true. Use -redact=false to display the values.`
+)
+
+// defaultSensitivePatterns are the case-insensitive words that mark an env or
+// meta key as sensitive.
+var defaultSensitivePatterns = []string{"PASSWORD", "TOKEN", "KEY"}
+
+// sensitivePatterns returns the patterns used to detect sensitive keys,
+// honoring the NOMAD_CLI_SENSITIVE_PATTERNS environment variable.
+func sensitivePatterns() []string {
+	raw := os.Getenv(EnvNomadCLISensitivePatterns)
+	if raw == "" {
+		return defaultSensitivePatterns
+	}
+
+	var patterns []string
+	for _, p := range strings.Split(raw, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			patterns = append(patterns, strings.ToUpper(p))
+		}
+	}
+	return patterns
+}
+
+// isSensitiveKey returns whether the key matches any of the patterns. Keys
+// and patterns are compared word by word, so KEY matches API_KEY and apiKey
+// but not MONKEY, and ACCESS_KEY matches AWS_ACCESS_KEY_ID.
+func isSensitiveKey(key string, patterns []string) bool {
+	words := keyWords(key)
+	for _, p := range patterns {
+		pw := keyWords(p)
+		if len(pw) == 0 {
+			continue
+		}
+		for i := 0; i+len(pw) <= len(words); i++ {
+			if equalWords(words[i:i+len(pw)], pw) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// keyWords splits a key into its upper cased words, which are separated by
+// any character other than a letter or digit, or by a lower to upper case
+// transition.
+func keyWords(key string) []string {
+	var words []string
+	var word []rune
+	var prev rune
+	for _, r := range key {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = nil
+			}
+		case unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)) && len(word) > 0:
+			words = append(words, string(word))
+			word = []rune{unicode.ToUpper(r)}
+		default:
+			word = append(word, unicode.ToUpper(r))
+		}
+		prev = r
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
+
+func equalWords(a, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// redactMap returns a copy of m with the values of sensitive keys replaced.
+func redactMap(m map[string]string, patterns []string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		if isSensitiveKey(k, patterns) {
+			v = redactedValue
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// redactJob masks the values of sensitive keys in the env and meta blocks of
+// the job, its groups and its tasks. The job is modified in place.
+func redactJob(job *api.Job, patterns []string) {
+	if job == nil {
+		return
+	}
+	job.Meta = redactMap(job.Meta, patterns)
+	for _, tg := range job.TaskGroups {
+		tg.Meta = redactMap(tg.Meta, patterns)
+		for _, task := range tg.Tasks {
+			task.Meta = redactMap(task.Meta, patterns)
+			task.Env = redactMap(task.Env, patterns)
+		}
+	}
+}
+
+// redactAllocation masks the values of sensitive keys in the env and meta
+// blocks of the job embedded in the allocation.
+func redactAllocation(alloc *api.Allocation, patterns []string) {
+	if alloc == nil {
+		return
+	}
+	redactJob(alloc.Job, patterns)
+}
+
+// redactVarDefault returns the default of the -redact flag of the var
+// commands. Values are redacted unless NOMAD_VAR_SHOW_VALUES is set to a true
+// value; an invalid value keeps them redacted.
+func redactVarDefault() bool {
+	show, err := strconv.ParseBool(os.Getenv(EnvNomadVarShowValues))
+	return err != nil || !show
+}
+
+// redactVarItems returns a copy of the secure variable with the values of all
+// its items replaced. Unlike env and meta keys, every item of a secure
+// variable is considered sensitive.
+func redactVarItems(sv *api.SecureVariable) *api.SecureVariable {
+	if sv == nil {
+		return nil
+	}
+	out := *sv
+	if sv.Items != nil {
+		out.Items = make(api.SecureVariableItems, len(sv.Items))
+		for k := range sv.Items {
+			out.Items[k] = redactedValue
+		}
+	}
+	return &out
+}
+
+// dropVarItems deletes the items of the secure variable once the command is
+// done with them, so that the values are no longer referenced by the command.
+// Go strings are immutable, so this doesn't wipe the values from memory; they
+// stay there until the garbage collector reuses it.
+func dropVarItems(sv *api.SecureVariable) {
+	if sv == nil {
+		return
+	}
+	for k := range sv.Items {
+		delete(sv.Items, k)
+	}
+}
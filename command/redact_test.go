@@ -0,0 +1,95 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/shoenig/test/must"
+)
+
+func TestRedact_SensitivePatterns(t *testing.T) {
+	t.Setenv(EnvNomadCLISensitivePatterns, "")
+	must.Eq(t, defaultSensitivePatterns, sensitivePatterns())
+
+	t.Setenv(EnvNomadCLISensitivePatterns, "secret, cert ,")
+	must.Eq(t, []string{"SECRET", "CERT"}, sensitivePatterns())
+}
+
+func TestRedact_IsSensitiveKey(t *testing.T) {
+	ci.Parallel(t)
+
+	patterns := append(defaultSensitivePatterns, "ACCESS_KEY")
+	testCases := []struct {
+		key       string
+		sensitive bool
+	}{
+		{key: "DB_PASSWORD", sensitive: true},
+		{key: "api_token", sensitive: true},
+		{key: "apiKey", sensitive: true},
+		{key: "tls.key", sensitive: true},
+		{key: "KEY", sensitive: true},
+		{key: "AWS_ACCESS_KEY_ID", sensitive: true},
+		{key: "MONKEY", sensitive: false},
+		{key: "keyboard_layout", sensitive: false},
+		{key: "TOKENIZER", sensitive: false},
+		{key: "ACCESS_LOG_KEYS", sensitive: false},
+		{key: "PORT", sensitive: false},
+	}
+	for _, tc := range testCases {
+		must.Eq(t, tc.sensitive, isSensitiveKey(tc.key, patterns), must.Sprint(tc.key))
+	}
+}
+
+func TestRedact_Job(t *testing.T) {
+	ci.Parallel(t)
+
+	job := &api.Job{
+		ID:   pointer.Of("example"),
+		Meta: map[string]string{"owner": "ops", "api_token": "abc"},
+		TaskGroups: []*api.TaskGroup{{
+			Meta: map[string]string{"db_password": "hunter2"},
+			Tasks: []*api.Task{{
+				Env:  map[string]string{"AWS_SECRET_ACCESS_KEY": "s3cr3t", "PORT": "8080"},
+				Meta: map[string]string{"version": "1"},
+			}},
+		}},
+	}
+
+	redactJob(job, defaultSensitivePatterns)
+
+	must.Eq(t, map[string]string{"owner": "ops", "api_token": redactedValue}, job.Meta)
+	must.Eq(t, map[string]string{"db_password": redactedValue}, job.TaskGroups[0].Meta)
+	task := job.TaskGroups[0].Tasks[0]
+	must.Eq(t, map[string]string{"AWS_SECRET_ACCESS_KEY": redactedValue, "PORT": "8080"}, task.Env)
+	must.Eq(t, map[string]string{"version": "1"}, task.Meta)
+}
+
+func TestRedact_VarDefault(t *testing.T) {
+	t.Setenv(EnvNomadVarShowValues, "")
+	must.True(t, redactVarDefault())
+
+	t.Setenv(EnvNomadVarShowValues, "nope")
+	must.True(t, redactVarDefault())
+
+	t.Setenv(EnvNomadVarShowValues, "true")
+	must.False(t, redactVarDefault())
+}
+
+func TestRedact_VarItems(t *testing.T) {
+	ci.Parallel(t)
+
+	sv := api.NewSecureVariable("apps/web")
+	sv.Items["user"] = "admin"
+	sv.Items["pass"] = "s3cr3t"
+
+	redacted := redactVarItems(sv)
+	must.Eq(t, "apps/web", redacted.Path)
+	must.Eq(t, api.SecureVariableItems{"user": redactedValue, "pass": redactedValue}, redacted.Items)
+	must.Eq(t, "s3cr3t", sv.Items["pass"])
+
+	dropVarItems(sv)
+	must.MapEmpty(t, sv.Items)
+	must.MapLen(t, 2, redacted.Items)
+}
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/hashicorp/nomad/api/contexts"
@@ -33,6 +34,10 @@ Usage: nomad var <subcommand> [options] [args]
 
       $ nomad var put <path>
 
+  Edit a secure variable with a text editor:
+
+      $ nomad var edit <path>
+
   Examine a secure variable:
 
       $ nomad var get <path>
@@ -41,6 +46,34 @@ Usage: nomad var <subcommand> [options] [args]
 
       $ nomad var list <prefix>
 
+  Apply secure variable operations atomically:
+
+      $ nomad var txn @<ops-file>
+
+  Delete all secure variables under a prefix:
+
+      $ nomad var purge -prefix=<prefix> -recurse
+
+  Generate an ACL policy for secure variables:
+
+      $ nomad var policy generate -path=<path> -capabilities=read,write
+
+  Check the replication of secure variables from other regions:
+
+      $ nomad var status -replication
+
+  Run a command with secure variables exported as environment variables:
+
+      $ nomad var run -prefix=<prefix> -- <command>
+
+  Copy secrets from a Vault KV secrets engine:
+
+      $ nomad var migrate-vault -prefix=<vault-prefix>
+
+  Rotate the credentials stored in a secure variable:
+
+      $ nomad var rotate -rotator=random <path>
+
   Please see the individual subcommand help for detailed usage information.
 `
 
@@ -72,3 +105,75 @@ func SecureVariablePathPredictor(factory ApiClientFactory) complete.Predictor {
 		return resp.Matches[contexts.SecureVariables]
 	})
 }
+
+const (
+	// varOutputTable, varOutputJSON, and varOutputGoTemplate are the values
+	// accepted by the -output flag of the var subcommands.
+	varOutputTable      = "table"
+	varOutputJSON       = "json"
+	varOutputGoTemplate = "go-template"
+)
+
+// varOutputUsage is the help text shared by the var subcommands for the
+// -output flag and its template companion.
+func varOutputUsage(tmplFlag string) string {
+	return strings.TrimSpace(`
+  -output=<format>
+    Format of the command output. Must be one of "table", "json", or
+    "go-template". When "go-template" is used, the template is provided with
+    the ` + "`" + tmplFlag + "`" + ` option. Defaults to "table".`)
+}
+
+// resolveVarOutput folds the -json and template flags of a var subcommand
+// into the -output format it selects, and validates the result. The -json and
+// template flags predate -output and are kept as shorthands for it.
+func resolveVarOutput(json bool, output, tmpl string) (string, error) {
+	switch {
+	case json && output != "" && output != varOutputJSON:
+		return "", fmt.Errorf("The -json flag can not be combined with -output=%s", output)
+	case json:
+		output = varOutputJSON
+	case output == "" && tmpl != "":
+		output = varOutputGoTemplate
+	}
+	if err := validateVarOutput(output, tmpl); err != nil {
+		return "", err
+	}
+	return output, nil
+}
+
+// validateVarOutput checks that the -output format is one we know how to
+// render and that a template is provided exactly when it is needed.
+func validateVarOutput(format, tmpl string) error {
+	switch format {
+	case "", varOutputTable, varOutputJSON:
+		if tmpl != "" {
+			return fmt.Errorf("A template can only be used with -output=%s", varOutputGoTemplate)
+		}
+	case varOutputGoTemplate:
+		if tmpl == "" {
+			return fmt.Errorf("-output=%s requires a template", varOutputGoTemplate)
+		}
+	default:
+		return fmt.Errorf("Unsupported output format %q; must be one of %q, %q, or %q",
+			format, varOutputTable, varOutputJSON, varOutputGoTemplate)
+	}
+	return nil
+}
+
+// formatVarOutput renders data according to the -output format selected for
+// a var subcommand. The table function is only invoked for the table format,
+// so it may assume that the data is ready for human consumption.
+func formatVarOutput(format, tmpl string, data interface{}, table func() string) (string, error) {
+	if err := validateVarOutput(format, tmpl); err != nil {
+		return "", err
+	}
+	switch format {
+	case varOutputJSON:
+		return Format(true, "", data)
+	case varOutputGoTemplate:
+		return Format(false, tmpl, data)
+	default:
+		return table(), nil
+	}
+}
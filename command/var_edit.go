@@ -0,0 +1,511 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+const (
+	// varEditDefaultEditor is the editor used when neither NOMAD_EDITOR nor
+	// EDITOR are set.
+	varEditDefaultEditor = "vi"
+
+	// varEditCommentPrefix starts the lines of the edited file which are
+	// ignored. The lines are rewritten each time the file is opened.
+	varEditCommentPrefix = "#"
+)
+
+// varEditIdentRe matches the keys which can be written without quotes in HCL.
+var varEditIdentRe = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_\-]*$`)
+
+type VarEditCommand struct {
+	Meta
+}
+
+func (c *VarEditCommand) Help() string {
+	helpText := `
+Usage: nomad var edit [options] <path>
+
+  Edit is used to edit the secure variable stored at the given path with a
+  text editor, in the same way as "kubectl edit". The metadata and items of the
+  secure variable are written to a temporary file in HCL or JSON format, which
+  is opened with the editor set by the NOMAD_EDITOR or EDITOR environment
+  variables, or vi if neither is set. Once the editor exits, the file is
+  validated and the secure variable is updated. Exiting the editor without
+  changing the file cancels the edit. If the secure variable doesn't exist, it
+  is created.
+
+  The secure variable is only written if it wasn't modified since it was read.
+  If it was, the edit can be restarted from the latest version of the secure
+  variable. The rejected changes are kept in a temporary file, whose path is
+  printed. If the edited file is invalid, it can be re-opened to fix it.
+
+  If ACLs are enabled, this command requires a token with the ` + "`read`" + ` and
+  ` + "`write`" + ` capabilities for the target secure variable's namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Edit Options:
+
+  -json
+    Edit the secure variable in JSON format instead of HCL.
+
+  -y
+    Automatically answer "yes" to the prompts to re-open the editor.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *VarEditCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json": complete.PredictNothing,
+			"-y":    complete.PredictNothing,
+		},
+	)
+}
+
+func (c *VarEditCommand) AutocompleteArgs() complete.Predictor {
+	return SecureVariablePathPredictor(c.Meta.Client)
+}
+
+func (c *VarEditCommand) Synopsis() string {
+	return "Edit a secure variable with a text editor"
+}
+
+func (c *VarEditCommand) Name() string { return "var edit" }
+
+func (c *VarEditCommand) Run(args []string) int {
+	var jsonFormat, autoYes bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&jsonFormat, "json", false, "")
+	flags.BoolVar(&autoYes, "y", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <path>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	path := args[0]
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	sv, err := c.read(client, path)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving secure variable: %s", err))
+		return 1
+	}
+	defer dropVarItems(sv)
+
+	content, err := renderVarEdit(sv, jsonFormat)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error rendering secure variable: %s", err))
+		return 1
+	}
+
+	for {
+		edited, err := c.edit(content, jsonFormat)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error editing secure variable: %s", err))
+			return 1
+		}
+		if bytes.Equal(stripVarEditComments(edited), stripVarEditComments(content)) {
+			c.Ui.Output("Edit cancelled, no changes made")
+			return 0
+		}
+
+		meta, items, err := parseVarEdit(edited, jsonFormat)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error parsing secure variable: %s", err))
+			if !c.confirm(autoYes, "Do you want to re-open the editor to fix it? [Y/n]") {
+				return 1
+			}
+			content = addVarEditError(edited, err)
+			continue
+		}
+
+		next := *sv
+		next.Meta = meta
+		next.Items = items
+		out, _, err := client.SecureVariables().CheckedUpdate(&next, nil)
+		dropVarItems(&next)
+		if err == nil {
+			dropVarItems(out)
+			c.Ui.Output(fmt.Sprintf("Successfully wrote secure variable %q", out.Path))
+			return 0
+		}
+
+		var casErr api.ErrCASConflict
+		if !errors.As(err, &casErr) {
+			c.Ui.Error(fmt.Sprintf("Error writing secure variable: %s", err))
+			return 1
+		}
+
+		// Keep the rejected changes, so they can be merged by hand
+		rejected, saveErr := saveVarEdit(edited, jsonFormat)
+		if saveErr != nil {
+			c.Ui.Error(fmt.Sprintf("Error saving the rejected changes: %s", saveErr))
+		} else {
+			c.Ui.Warn(fmt.Sprintf("Your changes were saved to %q", rejected))
+		}
+		c.Ui.Error(fmt.Sprintf("Error writing secure variable: secure variable %q was modified at index %d after it was read at index %d",
+			sv.Path, casErr.Conflict.ModifyIndex, sv.ModifyIndex))
+		if !c.confirm(autoYes, "Do you want to edit the latest version of the secure variable? [Y/n]") {
+			return 1
+		}
+
+		dropVarItems(sv)
+		sv, err = c.read(client, path)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error retrieving secure variable: %s", err))
+			return 1
+		}
+		content, err = renderVarEdit(sv, jsonFormat)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error rendering secure variable: %s", err))
+			return 1
+		}
+	}
+}
+
+// read returns the secure variable stored at the path, or a new secure
+// variable that can only be written if the path is still free if there is
+// none.
+func (c *VarEditCommand) read(client *api.Client, path string) (*api.SecureVariable, error) {
+	sv, _, err := client.SecureVariables().Read(path, nil)
+	if err != nil {
+		if err.Error() != api.ErrVariableNotFound {
+			return nil, err
+		}
+		sv = api.NewSecureVariable(path)
+	}
+	if sv.Meta == nil {
+		sv.Meta = make(map[string]string)
+	}
+	return sv, nil
+}
+
+// edit writes the content to a temporary file, opens it with the editor and
+// returns the edited content.
+func (c *VarEditCommand) edit(content []byte, jsonFormat bool) ([]byte, error) {
+	f, err := os.CreateTemp("", "nomad-var-edit-*"+varEditExt(jsonFormat))
+	if err != nil {
+		return nil, err
+	}
+	name := f.Name()
+	defer os.Remove(name)
+
+	// Overwrite the secrets before removing the file
+	defer func() {
+		if info, err := os.Stat(name); err == nil {
+			_ = os.WriteFile(name, make([]byte, info.Size()), 0600)
+		}
+	}()
+
+	_, err = f.Write(content)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	editor := varEditor()
+	parts := strings.Fields(editor)
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("invalid editor %q", editor)
+	}
+	cmd := exec.Command(parts[0], append(parts[1:], name)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("editor %q failed: %v", editor, err)
+	}
+
+	return os.ReadFile(name)
+}
+
+// confirm asks the question, and returns whether the answer is yes. An empty
+// answer is a yes.
+func (c *VarEditCommand) confirm(autoYes bool, question string) bool {
+	if autoYes {
+		return true
+	}
+	answer, err := c.Ui.Ask(question)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to parse answer: %v", err))
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "", "y", "yes":
+		return true
+	}
+	return false
+}
+
+// varEditor returns the editor command, which may include arguments.
+func varEditor() string {
+	for _, env := range []string{"NOMAD_EDITOR", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(env)); editor != "" {
+			return editor
+		}
+	}
+	return varEditDefaultEditor
+}
+
+func varEditExt(jsonFormat bool) string {
+	if jsonFormat {
+		return ".json"
+	}
+	return ".hcl"
+}
+
+// saveVarEdit writes the edited content to a temporary file which is kept, and
+// returns its path.
+func saveVarEdit(content []byte, jsonFormat bool) (string, error) {
+	f, err := os.CreateTemp("", "nomad-var-edit-rejected-*"+varEditExt(jsonFormat))
+	if err != nil {
+		return "", err
+	}
+	_, err = f.Write(content)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return f.Name(), err
+}
+
+// renderVarEdit returns the content of the file edited for the secure
+// variable. The HCL format has a header of comments describing the secure
+// variable, which are ignored when parsing. JSON has no comments, so the
+// header is only written for HCL.
+func renderVarEdit(sv *api.SecureVariable, jsonFormat bool) ([]byte, error) {
+	if jsonFormat {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		err := enc.Encode(struct {
+			Meta  map[string]string
+			Items map[string]string
+		}{sv.Meta, sv.Items})
+		if err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(varEditHeader(sv))
+	buf.WriteString("\nmeta {\n")
+	if err := writeVarEditHCLMap(&buf, sv.Meta); err != nil {
+		return nil, err
+	}
+	buf.WriteString("}\n\nitems {\n")
+	if err := writeVarEditHCLMap(&buf, sv.Items); err != nil {
+		return nil, err
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes(), nil
+}
+
+func varEditHeader(sv *api.SecureVariable) string {
+	lines := []string{
+		"Edit the metadata and items of the secure variable below. Lines",
+		"starting with '#' are ignored, and an unchanged file cancels the edit.",
+		"",
+		fmt.Sprintf("Path: %s", sv.Path),
+	}
+	if sv.Namespace != "" {
+		lines = append(lines, fmt.Sprintf("Namespace: %s", sv.Namespace))
+	}
+	if sv.ModifyIndex == 0 {
+		lines = append(lines, "This secure variable doesn't exist and will be created.")
+	} else {
+		lines = append(lines, fmt.Sprintf("Modify Index: %d", sv.ModifyIndex))
+	}
+
+	var buf strings.Builder
+	for _, line := range lines {
+		buf.WriteString(strings.TrimSpace(varEditCommentPrefix + " " + line))
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
+
+// writeVarEditHCLMap writes the key/value pairs of the map sorted by key,
+// quoting the keys which aren't identifiers. Values are quoted as JSON
+// strings, whose escapes HCL supports.
+func writeVarEditHCLMap(buf *bytes.Buffer, m map[string]string) error {
+	keys := make([]string, 0, len(m))
+	width := 0
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	quoted := make([]string, len(keys))
+	for i, k := range keys {
+		quoted[i] = k
+		if !varEditIdentRe.MatchString(k) {
+			q, err := marshalVarEditString(k)
+			if err != nil {
+				return err
+			}
+			quoted[i] = q
+		}
+		if len(quoted[i]) > width {
+			width = len(quoted[i])
+		}
+	}
+	for i, k := range keys {
+		v, err := marshalVarEditString(m[k])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(buf, "  %-*s = %s\n", width, quoted[i], v)
+	}
+	return nil
+}
+
+func marshalVarEditString(s string) (string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// stripVarEditComments returns the content without its comment lines, to
+// compare edits regardless of the header.
+func stripVarEditComments(content []byte) []byte {
+	var out [][]byte
+	for _, line := range bytes.Split(content, []byte("\n")) {
+		if bytes.HasPrefix(bytes.TrimSpace(line), []byte(varEditCommentPrefix)) {
+			continue
+		}
+		out = append(out, line)
+	}
+	return bytes.TrimSpace(bytes.Join(out, []byte("\n")))
+}
+
+// addVarEditError returns the edited content with the error added in
+// comments, replacing the previous header. JSON content is returned as is.
+func addVarEditError(content []byte, err error) []byte {
+	trimmed := bytes.TrimSpace(content)
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		return content
+	}
+
+	// Drop the leading comments
+	lines := bytes.Split(content, []byte("\n"))
+	i := 0
+	for ; i < len(lines); i++ {
+		if !bytes.HasPrefix(bytes.TrimSpace(lines[i]), []byte(varEditCommentPrefix)) {
+			break
+		}
+	}
+
+	var buf bytes.Buffer
+	for _, line := range strings.Split(strings.TrimSpace(err.Error()), "\n") {
+		buf.WriteString(strings.TrimSpace(varEditCommentPrefix + " " + line))
+		buf.WriteString("\n")
+	}
+	buf.Write(bytes.Join(lines[i:], []byte("\n")))
+	return buf.Bytes()
+}
+
+// parseVarEdit parses and validates the edited content, and returns the meta
+// and items of the secure variable.
+func parseVarEdit(content []byte, jsonFormat bool) (map[string]string, map[string]string, error) {
+	var spec struct {
+		Meta  map[string]string
+		Items map[string]string
+	}
+
+	if jsonFormat {
+		dec := json.NewDecoder(bytes.NewReader(content))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&spec); err != nil {
+			return nil, nil, err
+		}
+		if dec.More() {
+			return nil, nil, fmt.Errorf("unexpected content after the secure variable")
+		}
+	} else {
+		root, err := hcl.ParseBytes(content)
+		if err != nil {
+			return nil, nil, err
+		}
+		list, ok := root.Node.(*ast.ObjectList)
+		if !ok {
+			return nil, nil, fmt.Errorf("error parsing: root should be an object")
+		}
+		for _, item := range list.Items {
+			key := item.Keys[0].Token.Value()
+			if key != "meta" && key != "items" {
+				return nil, nil, fmt.Errorf("invalid key %q: must be meta or items", key)
+			}
+		}
+		for _, block := range []struct {
+			name string
+			dst  *map[string]string
+		}{{"meta", &spec.Meta}, {"items", &spec.Items}} {
+			items := list.Filter(block.name).Items
+			if len(items) > 1 {
+				return nil, nil, fmt.Errorf("only one %s block is allowed", block.name)
+			}
+			if len(items) == 0 {
+				continue
+			}
+			if _, ok := items[0].Val.(*ast.ObjectType); !ok {
+				return nil, nil, fmt.Errorf("%s must be a block", block.name)
+			}
+			if err := hcl.DecodeObject(block.dst, items[0].Val); err != nil {
+				return nil, nil, fmt.Errorf("error decoding %s: %v", block.name, err)
+			}
+		}
+	}
+
+	if len(spec.Items) == 0 {
+		return nil, nil, fmt.Errorf("a secure variable requires at least one item")
+	}
+	for k := range spec.Items {
+		if k == "" {
+			return nil, nil, fmt.Errorf("item keys can't be empty")
+		}
+	}
+	if spec.Meta == nil {
+		spec.Meta = make(map[string]string)
+	}
+	return spec.Meta, spec.Items, nil
+}
@@ -0,0 +1,269 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarEditCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &VarEditCommand{}
+}
+
+func TestVarEditCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name      string
+		args      []string
+		expectErr string
+	}{
+		{
+			name:      "no path",
+			args:      []string{},
+			expectErr: "This command takes one argument",
+		},
+		{
+			name:      "bad address",
+			args:      []string{"-address", "nope", "foo"},
+			expectErr: "Error retrieving secure variable",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := &VarEditCommand{Meta: Meta{Ui: ui}}
+			code := cmd.Run(tc.args)
+			require.Equal(t, 1, code)
+			require.Contains(t, ui.ErrorWriter.String(), tc.expectErr)
+		})
+	}
+}
+
+func TestVarEdit_RenderParse(t *testing.T) {
+	ci.Parallel(t)
+
+	sv := &api.SecureVariable{
+		Namespace:   "default",
+		Path:        "apps/web",
+		ModifyIndex: 42,
+		Meta:        map[string]string{"owner": "team"},
+		Items: api.SecureVariableItems{
+			"password": "p\"a$$\n\tword",
+			"tls.key":  "-----BEGIN-----\n<key>\n",
+		},
+	}
+
+	for _, jsonFormat := range []bool{false, true} {
+		t.Run(fmt.Sprintf("json=%v", jsonFormat), func(t *testing.T) {
+			content, err := renderVarEdit(sv, jsonFormat)
+			require.NoError(t, err)
+
+			meta, items, err := parseVarEdit(content, jsonFormat)
+			require.NoError(t, err)
+			require.Equal(t, map[string]string(sv.Meta), meta)
+			require.Equal(t, map[string]string(sv.Items), items)
+		})
+	}
+
+	content, err := renderVarEdit(sv, false)
+	require.NoError(t, err)
+	require.Contains(t, string(content), "# Path: apps/web\n")
+	require.Contains(t, string(content), "# Modify Index: 42\n")
+	require.Contains(t, string(content), `  "tls.key" = "-----BEGIN-----\n<key>\n"`)
+}
+
+func TestVarEdit_ParseErrors(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name       string
+		content    string
+		jsonFormat bool
+		expectErr  string
+	}{
+		{
+			name:      "no items",
+			content:   "meta {\n  owner = \"team\"\n}\n",
+			expectErr: "a secure variable requires at least one item",
+		},
+		{
+			name:      "unknown key",
+			content:   "path = \"foo\"\nitems {\n  k = \"v\"\n}\n",
+			expectErr: `invalid key "path": must be meta or items`,
+		},
+		{
+			name:      "duplicate block",
+			content:   "items {\n  k = \"v\"\n}\nitems {\n  l = \"v\"\n}\n",
+			expectErr: "only one items block is allowed",
+		},
+		{
+			name:      "not a block",
+			content:   "items = \"v\"\n",
+			expectErr: "items must be a block",
+		},
+		{
+			name:      "invalid hcl",
+			content:   "items {\n",
+			expectErr: "object expected closing RBRACE",
+		},
+		{
+			name:       "unknown json field",
+			content:    `{"Path": "foo", "Items": {"k": "v"}}`,
+			jsonFormat: true,
+			expectErr:  `unknown field "Path"`,
+		},
+		{
+			name:       "json no items",
+			content:    `{"Items": {}}`,
+			jsonFormat: true,
+			expectErr:  "a secure variable requires at least one item",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := parseVarEdit([]byte(tc.content), tc.jsonFormat)
+			require.ErrorContains(t, err, tc.expectErr)
+		})
+	}
+}
+
+// testVarEditor sets a fake editor, which replaces the edited file with the
+// content of the file "edit<n>" of the returned directory on its nth run, and
+// keeps the file it was given as "seen<n>". If the file "wait<n>" exists, the
+// nth run waits for the file "continue" first.
+func testVarEditor(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts are not supported on Windows")
+	}
+
+	dir := t.TempDir()
+	script := filepath.Join(dir, "editor.sh")
+	require.NoError(t, os.WriteFile(script, []byte(`#!/bin/sh
+dir=$(dirname "$0")
+n=$(cat "$dir/count" 2>/dev/null || echo 0)
+n=$((n+1))
+echo $n > "$dir/count"
+cp "$1" "$dir/seen$n"
+if [ -f "$dir/wait$n" ]; then
+  while [ ! -f "$dir/continue" ]; do sleep 0.1; done
+fi
+if [ -f "$dir/edit$n" ]; then
+  cp "$dir/edit$n" "$1"
+fi
+`), 0755))
+	t.Setenv("NOMAD_EDITOR", script)
+	return dir
+}
+
+func TestVarEditCommand(t *testing.T) {
+	// Not parallel, as it sets the editor in the environment
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+	testutil.WaitForLeader(t, srv.Agent.RPC)
+
+	t.Run("create", func(t *testing.T) {
+		dir := testVarEditor(t)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "edit1"), []byte(`
+meta {
+  owner = "team"
+}
+
+items {
+  password = "s3cr3t"
+}
+`), 0644))
+
+		ui := cli.NewMockUi()
+		cmd := &VarEditCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"-address=" + url, "apps/new"})
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+		require.Contains(t, ui.OutputWriter.String(), `Successfully wrote secure variable "apps/new"`)
+
+		seen, err := os.ReadFile(filepath.Join(dir, "seen1"))
+		require.NoError(t, err)
+		require.Contains(t, string(seen), "doesn't exist and will be created")
+
+		sv, _, err := client.SecureVariables().Read("apps/new", nil)
+		require.NoError(t, err)
+		require.Equal(t, "team", sv.Meta["owner"])
+		require.Equal(t, api.SecureVariableItems{"password": "s3cr3t"}, sv.Items)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		testVarEditor(t)
+
+		ui := cli.NewMockUi()
+		cmd := &VarEditCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"-address=" + url, "apps/new"})
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+		require.Contains(t, ui.OutputWriter.String(), "Edit cancelled, no changes made")
+	})
+
+	t.Run("invalid then fixed", func(t *testing.T) {
+		dir := testVarEditor(t)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "edit1"), []byte(`{"Meta": {}}`), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "edit2"), []byte(`{"Items": {"password": "fixed"}}`), 0644))
+
+		ui := cli.NewMockUi()
+		cmd := &VarEditCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"-address=" + url, "-json", "-y", "apps/new"})
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+		require.Contains(t, ui.ErrorWriter.String(), "a secure variable requires at least one item")
+
+		sv, _, err := client.SecureVariables().Read("apps/new", nil)
+		require.NoError(t, err)
+		require.Empty(t, sv.Meta)
+		require.Equal(t, api.SecureVariableItems{"password": "fixed"}, sv.Items)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		dir := testVarEditor(t)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "wait1"), nil, 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "edit1"), []byte("items {\n  password = \"mine\"\n}\n"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "edit2"), []byte("items {\n  password = \"merged\"\n}\n"), 0644))
+
+		ui := cli.NewMockUi()
+		cmd := &VarEditCommand{Meta: Meta{Ui: ui}}
+		doneCh := make(chan int)
+		go func() {
+			doneCh <- cmd.Run([]string{"-address=" + url, "-y", "apps/new"})
+		}()
+
+		// Update the secure variable while it's edited
+		require.Eventually(t, func() bool {
+			_, err := os.Stat(filepath.Join(dir, "seen1"))
+			return err == nil
+		}, 10*time.Second, 50*time.Millisecond)
+		sv := api.NewSecureVariable("apps/new")
+		sv.Items["password"] = "theirs"
+		_, _, err := client.SecureVariables().Create(sv, nil)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "continue"), nil, 0644))
+
+		require.Equal(t, 0, <-doneCh, ui.ErrorWriter.String())
+		require.Contains(t, ui.ErrorWriter.String(), `secure variable "apps/new" was modified at index`)
+		require.Contains(t, ui.ErrorWriter.String(), "Your changes were saved to")
+
+		// The editor was re-opened with the latest version
+		seen, err := os.ReadFile(filepath.Join(dir, "seen2"))
+		require.NoError(t, err)
+		require.Contains(t, string(seen), `password = "theirs"`)
+
+		out, _, err := client.SecureVariables().Read("apps/new", nil)
+		require.NoError(t, err)
+		require.Equal(t, api.SecureVariableItems{"password": "merged"}, out.Items)
+	})
+}
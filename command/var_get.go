@@ -0,0 +1,255 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type VarGetCommand struct {
+	Meta
+}
+
+func (c *VarGetCommand) Help() string {
+	helpText := `
+Usage: nomad var get [options] <path>
+
+  Get is used to output a secure variable stored at the given path. The values
+  of its items are redacted from the table and JSON output by default. They
+  are output by the -item and -template options, which are used to explicitly
+  read them, or with -redact=false.
+
+  With the -recurse option, the path is a prefix and all the secure variables
+  under it are output, fetching them in a single request per page of results.
+
+  If ACLs are enabled, this command requires a token with the ` + "`read`" + `
+  capability for the target secure variable's namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Get Options:
+
+  -item=<key>
+    Output only the value of the given item, with no additional formatting.
+    This is useful for substituting a single secret in a shell script. The
+    command fails if the secure variable has no such item.
+
+  -recurse
+    Output all the secure variables under the path prefix, instead of the
+    secure variable at the path. Secure variables the token can't read are
+    left out. The JSON output is a list of secure variables, and templates
+    are executed against that list. Can not be combined with -item.
+
+  ` + varOutputUsage("-template") + `
+
+  -json
+    Output the secure variable in JSON format. Shorthand for -output=json.
+
+  -template
+    Format and display the secure variable using a Go template, for example
+    '{{ .Items.password }}'. Implies -output=go-template when -output is not
+    set.
+
+  ` + redactVarUsage + `
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *VarGetCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-item":     complete.PredictAnything,
+			"-recurse":  complete.PredictNothing,
+			"-json":     complete.PredictNothing,
+			"-template": complete.PredictAnything,
+			"-output":   complete.PredictSet(varOutputTable, varOutputJSON, varOutputGoTemplate),
+			"-redact":   complete.PredictNothing,
+		},
+	)
+}
+
+func (c *VarGetCommand) AutocompleteArgs() complete.Predictor {
+	return SecureVariablePathPredictor(c.Meta.Client)
+}
+
+func (c *VarGetCommand) Synopsis() string {
+	return "Read a secure variable"
+}
+
+func (c *VarGetCommand) Name() string { return "var get" }
+
+func (c *VarGetCommand) Run(args []string) int {
+	var json, redact, recurse bool
+	var item, tmpl, output string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&item, "item", "", "")
+	flags.BoolVar(&recurse, "recurse", false, "")
+	flags.StringVar(&tmpl, "template", "", "")
+	flags.StringVar(&output, "output", "", "")
+	flags.BoolVar(&redact, "redact", redactVarDefault(), "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if l := len(args); l != 1 {
+		c.Ui.Error("This command takes one argument: <path>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	path := args[0]
+
+	// The -item flag prints a raw value, so it can't be combined with the
+	// other output formats.
+	if item != "" && (json || tmpl != "" || output != "") {
+		c.Ui.Error("The -item flag can not be combined with -json, -template, or -output")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if item != "" && recurse {
+		c.Ui.Error("The -item flag can not be combined with -recurse")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	output, err := resolveVarOutput(json, output, tmpl)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	if recurse {
+		return c.getPrefix(client, path, output, tmpl, redact)
+	}
+
+	sv, _, err := client.SecureVariables().Read(path, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving secure variable: %s", err))
+		return 1
+	}
+	defer dropVarItems(sv)
+
+	if item != "" {
+		value, ok := sv.Items[item]
+		if !ok {
+			c.Ui.Error(fmt.Sprintf("Secure variable %q has no item %q", sv.Path, item))
+			return 1
+		}
+		c.Ui.Output(value)
+		return 0
+	}
+
+	data := sv
+	if redact && output != varOutputGoTemplate {
+		data = redactVarItems(sv)
+	}
+	out, err := formatVarOutput(output, tmpl, data, func() string {
+		return formatVar(data)
+	})
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+	c.Ui.Output(out)
+	return 0
+}
+
+// getPrefix outputs all the secure variables under the prefix, following the
+// pages of results until the last one.
+func (c *VarGetCommand) getPrefix(client *api.Client, prefix, output, tmpl string, redact bool) int {
+	vars := []*api.SecureVariable{}
+	err := client.SecureVariables().ReadPrefixPages(prefix, nil,
+		func(page []*api.SecureVariable, _ *api.QueryMeta) bool {
+			vars = append(vars, page...)
+			return true
+		})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving secure variables: %s", err))
+		return 1
+	}
+	defer func() {
+		for _, sv := range vars {
+			dropVarItems(sv)
+		}
+	}()
+
+	data := vars
+	if redact && output != varOutputGoTemplate {
+		data = make([]*api.SecureVariable, len(vars))
+		for i, sv := range vars {
+			data[i] = redactVarItems(sv)
+		}
+	}
+	out, err := formatVarOutput(output, tmpl, data, func() string {
+		if len(data) == 0 {
+			return msgSecureVariableNotFound
+		}
+		tables := make([]string, len(data))
+		for i, sv := range data {
+			tables[i] = formatVar(sv)
+		}
+		return strings.Join(tables, "\n\n")
+	})
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+	c.Ui.Output(out)
+	return 0
+}
+
+// formatVar renders a secure variable and its items for human consumption.
+func formatVar(sv *api.SecureVariable) string {
+	basic := []string{
+		fmt.Sprintf("Namespace|%s", sv.Namespace),
+		fmt.Sprintf("Path|%s", sv.Path),
+		fmt.Sprintf("Create Time|%s", formatTime(sv.CreateTime)),
+		fmt.Sprintf("Modify Time|%s", formatTime(sv.ModifyTime)),
+		fmt.Sprintf("Check Index|%d", sv.ModifyIndex),
+	}
+	if sv.DeleteProtection {
+		basic = append(basic, "Delete Protection|true")
+	}
+	out := formatKV(basic)
+
+	if len(sv.Meta) > 0 {
+		out += "\n\nMeta\n" + formatKV(sortedKV(sv.Meta))
+	}
+	if len(sv.Items) > 0 {
+		out += "\n\nItems\n" + formatKV(sortedKV(sv.Items))
+	}
+	return out
+}
+
+// sortedKV returns the "key|value" rows of a map sorted by key.
+func sortedKV(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	rows := make([]string, len(keys))
+	for i, k := range keys {
+		rows[i] = fmt.Sprintf("%s|%s", k, m[k])
+	}
+	return rows
+}
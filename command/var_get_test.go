@@ -0,0 +1,188 @@
+package command
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarGetCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &VarGetCommand{}
+}
+
+func TestVarGetCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name      string
+		args      []string
+		expectErr string
+	}{
+		{
+			name:      "no args",
+			args:      []string{},
+			expectErr: "This command takes one argument: <path>",
+		},
+		{
+			name:      "item and template",
+			args:      []string{"-item", "password", "-template", "{{.Path}}", "foo"},
+			expectErr: "The -item flag can not be combined",
+		},
+		{
+			name:      "bad output format",
+			args:      []string{"-output", "yaml", "foo"},
+			expectErr: `Unsupported output format "yaml"`,
+		},
+		{
+			name:      "item and recurse",
+			args:      []string{"-item", "password", "-recurse", "foo"},
+			expectErr: "The -item flag can not be combined with -recurse",
+		},
+		{
+			name:      "bad address",
+			args:      []string{"-address", "nope", "foo"},
+			expectErr: "Error retrieving secure variable",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := &VarGetCommand{Meta: Meta{Ui: ui}}
+			code := cmd.Run(tc.args)
+			require.Equal(t, 1, code)
+			require.Contains(t, ui.ErrorWriter.String(), tc.expectErr)
+		})
+	}
+}
+
+func TestVarGetCommand(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create a server
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	sv := api.NewSecureVariable("test/get")
+	sv.Items["username"] = "admin"
+	sv.Items["password"] = "hunter2"
+	_, _, err := client.SecureVariables().Create(sv, nil)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		args   []string
+		expect string
+	}{
+		{
+			name:   "item",
+			args:   []string{"-item", "password"},
+			expect: "hunter2",
+		},
+		{
+			name:   "template",
+			args:   []string{"-template", "{{ .Items.username }}:{{ .Items.password }}"},
+			expect: "admin:hunter2",
+		},
+		{
+			name:   "table",
+			args:   []string{},
+			expect: "Items\npassword = <redacted>\nusername = <redacted>",
+		},
+		{
+			name:   "table unredacted",
+			args:   []string{"-redact=false"},
+			expect: "Items\npassword = hunter2\nusername = admin",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := &VarGetCommand{Meta: Meta{Ui: ui}}
+			args := append([]string{"-address=" + url}, tc.args...)
+			code := cmd.Run(append(args, "test/get"))
+			require.Equal(t, 0, code, ui.ErrorWriter.String())
+			require.True(t, strings.HasSuffix(strings.TrimSpace(ui.OutputWriter.String()), tc.expect),
+				"unexpected output: %s", ui.OutputWriter.String())
+		})
+	}
+
+	// The JSON output is redacted unless asked otherwise, like var put's
+	for _, tc := range []struct {
+		args   []string
+		expect api.SecureVariableItems
+	}{
+		{[]string{"-json"}, api.SecureVariableItems{"username": redactedValue, "password": redactedValue}},
+		{[]string{"-json", "-redact=false"}, api.SecureVariableItems{"username": "admin", "password": "hunter2"}},
+	} {
+		ui := cli.NewMockUi()
+		cmd := &VarGetCommand{Meta: Meta{Ui: ui}}
+		args := append([]string{"-address=" + url}, tc.args...)
+		code := cmd.Run(append(args, "test/get"))
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+		var out api.SecureVariable
+		require.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &out))
+		require.Equal(t, tc.expect, out.Items)
+	}
+
+	// A missing item fails
+	ui := cli.NewMockUi()
+	cmd := &VarGetCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, "-item", "nope", "test/get"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), `has no item "nope"`)
+}
+
+func TestVarGetCommand_Recurse(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create a server
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	for _, path := range []string{"apps/web", "apps/api", "other"} {
+		sv := api.NewSecureVariable(path)
+		sv.Items["password"] = path + "-secret"
+		_, _, err := client.SecureVariables().Create(sv, nil)
+		require.NoError(t, err)
+	}
+
+	run := func(args ...string) string {
+		ui := cli.NewMockUi()
+		cmd := &VarGetCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run(append([]string{"-address=" + url, "-recurse"}, args...))
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+		return ui.OutputWriter.String()
+	}
+
+	// The table output is redacted
+	out := run("apps/")
+	require.Contains(t, out, "Path        = apps/api")
+	require.Contains(t, out, "Path        = apps/web")
+	require.NotContains(t, out, "other")
+	require.NotContains(t, out, "-secret")
+
+	out = run("-template", "{{ range . }}{{ .Path }}={{ .Items.password }} {{ end }}", "apps/")
+	require.Equal(t, "apps/api=apps/api-secret apps/web=apps/web-secret", strings.TrimSpace(out))
+
+	// The JSON output is redacted unless asked otherwise
+	out = run("-json", "apps/")
+	var svs []*api.SecureVariable
+	require.NoError(t, json.Unmarshal([]byte(out), &svs))
+	require.Len(t, svs, 2)
+	require.Equal(t, redactedValue, svs[1].Items["password"])
+
+	out = run("-json", "-redact=false", "apps/")
+	svs = nil
+	require.NoError(t, json.Unmarshal([]byte(out), &svs))
+	require.Len(t, svs, 2)
+	require.Equal(t, "apps/web-secret", svs[1].Items["password"])
+
+	out = run("nope/")
+	require.Contains(t, out, msgSecureVariableNotFound)
+}
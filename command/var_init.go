@@ -1,10 +1,15 @@
 package command
 
 import (
+	"embed"
+	"errors"
 	"fmt"
+	"io/fs"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/muesli/reflow/wordwrap"
@@ -19,8 +24,18 @@ const (
 	// DefaultHclVarInitName is the default name we use when initializing the
 	// example var file in JSON format
 	DefaultJsonVarInitName = "spec.nsv.json"
+
+	// varTemplateHclExt and varTemplateJsonExt are the extensions of the
+	// secure variable specification templates in each format
+	varTemplateHclExt  = ".nsv.hcl"
+	varTemplateJsonExt = ".nsv.json"
 )
 
+// varTemplates holds the built-in secure variable specification templates
+//
+//go:embed var_templates
+var varTemplates embed.FS
+
 // VarInitCommand generates a new secure variable specification
 type VarInitCommand struct {
 	Meta
@@ -39,6 +54,18 @@ Init Options:
   -json
     Create an example JSON secure variable specification.
 
+  -template=<name>
+    Create the specification from a template for a common kind of secure
+    variable, such as "database-credentials", "tls-cert", or "api-keys".
+
+  -template-dir=<path>
+    Directory of additional templates, named "<name>.nsv.hcl" and
+    "<name>.nsv.json". Templates in this directory override the built-in
+    templates of the same name.
+
+  -list-templates
+    List the available templates.
+
   -q
     Suppress non-error output
 `
@@ -51,7 +78,10 @@ func (c *VarInitCommand) Synopsis() string {
 
 func (c *VarInitCommand) AutocompleteFlags() complete.Flags {
 	return complete.Flags{
-		"-json": complete.PredictNothing,
+		"-json":           complete.PredictNothing,
+		"-template":       complete.PredictAnything,
+		"-template-dir":   complete.PredictDirs("*"),
+		"-list-templates": complete.PredictNothing,
 	}
 }
 
@@ -62,13 +92,16 @@ func (c *VarInitCommand) AutocompleteArgs() complete.Predictor {
 func (c *VarInitCommand) Name() string { return "var init" }
 
 func (c *VarInitCommand) Run(args []string) int {
-	var jsonOutput bool
-	var quiet bool
+	var jsonOutput, quiet, listTemplates bool
+	var template, templateDir string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&jsonOutput, "json", false, "")
 	flags.BoolVar(&quiet, "q", false, "")
+	flags.BoolVar(&listTemplates, "list-templates", false, "")
+	flags.StringVar(&template, "template", "", "")
+	flags.StringVar(&templateDir, "template-dir", "", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -82,12 +115,24 @@ func (c *VarInitCommand) Run(args []string) int {
 		return 1
 	}
 
+	if listTemplates {
+		return c.listTemplates(templateDir)
+	}
+
 	fileName := DefaultHclVarInitName
 	fileContent := defaultHclVarSpec
 	if jsonOutput {
 		fileName = DefaultJsonVarInitName
 		fileContent = defaultJsonVarSpec
 	}
+	if template != "" {
+		content, err := varTemplate(templateDir, template, jsonOutput)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		fileContent = string(content)
+	}
 	if len(args) == 1 {
 		fileName = args[0]
 	}
@@ -118,6 +163,96 @@ func (c *VarInitCommand) Run(args []string) int {
 	return 0
 }
 
+// listTemplates outputs the names of the available templates, and where each
+// one comes from.
+func (c *VarInitCommand) listTemplates(dir string) int {
+	builtin, err := varTemplateNames(varTemplates, "var_templates")
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to list templates: %v", err))
+		return 1
+	}
+	sources := make(map[string]string, len(builtin))
+	for _, name := range builtin {
+		sources[name] = "builtin"
+	}
+	if dir != "" {
+		custom, err := varTemplateNames(os.DirFS(dir), ".")
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to list templates in %q: %v", dir, err))
+			return 1
+		}
+		for _, name := range custom {
+			sources[name] = dir
+		}
+	}
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := []string{"Name|Source"}
+	for _, name := range names {
+		out = append(out, fmt.Sprintf("%s|%s", name, sources[name]))
+	}
+	c.Ui.Output(formatList(out))
+	return 0
+}
+
+// varTemplate returns the contents of the named template in the requested
+// format. Templates in dir take precedence over the built-in templates.
+func varTemplate(dir, name string, jsonOutput bool) ([]byte, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return nil, fmt.Errorf("Invalid template name %q", name)
+	}
+	fileName := name + varTemplateHclExt
+	if jsonOutput {
+		fileName = name + varTemplateJsonExt
+	}
+
+	if dir != "" {
+		content, err := ioutil.ReadFile(filepath.Join(dir, fileName))
+		if err == nil {
+			return content, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("Failed to read template %q: %v", name, err)
+		}
+	}
+
+	content, err := varTemplates.ReadFile("var_templates/" + fileName)
+	if err != nil {
+		return nil, fmt.Errorf("Template %q not found, use -list-templates to list the available templates", name)
+	}
+	return content, nil
+}
+
+// varTemplateNames returns the names of the templates in the dir of fsys,
+// which are available in either format.
+func varTemplateNames(fsys fs.FS, dir string) ([]string, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), varTemplateHclExt)
+		name = strings.TrimSuffix(name, varTemplateJsonExt)
+		if name == entry.Name() || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names, nil
+}
+
 const (
 	msgWarnKeys = `
 	REMINDER: While keys in the items map can contain dots, using them in
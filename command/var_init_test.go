@@ -0,0 +1,90 @@
+package command
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarInitCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &VarInitCommand{}
+}
+
+func TestVarInitCommand_Templates(t *testing.T) {
+	ci.Parallel(t)
+
+	names, err := varTemplateNames(varTemplates, "var_templates")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"api-keys", "database-credentials", "tls-cert"}, names)
+
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			// Both formats of the built-in templates hold the same
+			// metadata and items.
+			content, err := varTemplate("", name, false)
+			require.NoError(t, err)
+			var fromHCL struct {
+				Meta  map[string]string `hcl:"meta"`
+				Items map[string]string `hcl:"items"`
+			}
+			require.NoError(t, hcl.Decode(&fromHCL, string(content)))
+
+			content, err = varTemplate("", name, true)
+			require.NoError(t, err)
+			var fromJSON struct {
+				Meta  map[string]string
+				Items map[string]string
+			}
+			require.NoError(t, json.Unmarshal(content, &fromJSON))
+
+			require.NotEmpty(t, fromHCL.Items)
+			require.Equal(t, fromJSON.Meta, fromHCL.Meta)
+			require.Equal(t, fromJSON.Items, fromHCL.Items)
+		})
+	}
+}
+
+func TestVarInitCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+	dir := t.TempDir()
+
+	// Templates in the directory override the built-in ones
+	custom := "items {\n  token = \"\"\n}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "api-keys.nsv.hcl"), []byte(custom), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "queue.nsv.hcl"), []byte(custom), 0600))
+
+	ui := cli.NewMockUi()
+	cmd := &VarInitCommand{Meta: Meta{Ui: ui}}
+	require.Equal(t, 0, cmd.Run([]string{"-list-templates", "-template-dir", dir}))
+	out := ui.OutputWriter.String()
+	require.Regexp(t, `api-keys\s+`+dir, out)
+	require.Regexp(t, `database-credentials\s+builtin`, out)
+	require.Regexp(t, `queue\s+`+dir, out)
+
+	file := filepath.Join(dir, "db.nsv.hcl")
+	require.Equal(t, 0, cmd.Run([]string{"-q", "-template=database-credentials", file}))
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.Contains(t, string(content), "password")
+
+	file = filepath.Join(dir, "keys.nsv.hcl")
+	require.Equal(t, 0, cmd.Run([]string{"-q", "-template-dir", dir, "-template=api-keys", file}))
+	content, err = os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, custom, string(content))
+
+	ui.ErrorWriter.Reset()
+	require.Equal(t, 1, cmd.Run([]string{"-template=unknown", filepath.Join(dir, "unknown.nsv.hcl")}))
+	require.Contains(t, ui.ErrorWriter.String(), `Template "unknown" not found`)
+
+	ui.ErrorWriter.Reset()
+	require.Equal(t, 1, cmd.Run([]string{"-template=../api-keys", filepath.Join(dir, "bad.nsv.hcl")}))
+	require.Contains(t, ui.ErrorWriter.String(), `Invalid template name`)
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/hashicorp/nomad/api"
 	"github.com/posener/complete"
 )
@@ -27,6 +28,10 @@ Usage: nomad var list [options] <prefix>
   List is used to list available secure variables. Supplying an optional prefix,
   filters the list to variables having a path starting with the prefix.
 
+  With the -tree option, the paths are rendered as a hierarchy, where each
+  node shows the number of secure variables, items and bytes of encrypted data
+  below it, and when any of them was last updated.
+
   If ACLs are enabled, this command will return only secure variables stored at
   namespaced paths where the token has the ` + "`read`" + ` capability.
 
@@ -45,17 +50,27 @@ List Options:
   -filter
     Specifies an expression used to filter query results. Queries using this
     option are less efficient than using the prefix parameter; therefore,
-    the prefix parameter should be used whenever possible.
+    the prefix parameter should be used whenever possible. Secure variable
+    metadata can be matched with expressions such as
+    'SecureVariableMetadata.Meta.owner == "ops"'.
+
+  ` + varOutputUsage("-t") + `
 
   -json
-    Output the secure variables in JSON format.
+    Output the secure variables in JSON format. Shorthand for -output=json.
 
   -t
-    Format and display the secure variables using a Go template.
+    Format and display the secure variables using a Go template. Implies
+    -output=go-template when -output is not set.
 
   -q
     Output matching secure variable paths with no additional information.
     This option overrides the ` + "`-t`" + ` option.
+
+  -tree
+    Render the path hierarchy of the secure variables as a tree, with the
+    variable count, item count, size and last update time of each node. Only
+    supported with the table output format.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -63,8 +78,10 @@ List Options:
 func (c *VarListCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-json": complete.PredictNothing,
-			"-t":    complete.PredictAnything,
+			"-json":   complete.PredictNothing,
+			"-t":      complete.PredictAnything,
+			"-tree":   complete.PredictNothing,
+			"-output": complete.PredictSet(varOutputTable, varOutputJSON, varOutputGoTemplate),
 		},
 	)
 }
@@ -79,18 +96,20 @@ func (c *VarListCommand) Synopsis() string {
 
 func (c *VarListCommand) Name() string { return "var list" }
 func (c *VarListCommand) Run(args []string) int {
-	var json, quiet bool
+	var json, quiet, tree bool
 	var perPage int
-	var tmpl, pageToken, filter, prefix string
+	var tmpl, pageToken, filter, prefix, output string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&quiet, "q", false, "")
 	flags.BoolVar(&json, "json", false, "")
+	flags.BoolVar(&tree, "tree", false, "")
 	flags.StringVar(&tmpl, "t", "", "")
 	flags.IntVar(&perPage, "per-page", 0, "")
 	flags.StringVar(&pageToken, "page-token", "", "")
 	flags.StringVar(&filter, "filter", "", "")
+	flags.StringVar(&output, "output", "", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -108,6 +127,18 @@ func (c *VarListCommand) Run(args []string) int {
 		prefix = args[0]
 	}
 
+	output, err := resolveVarOutput(json, output, tmpl)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if tree && (quiet || (output != "" && output != varOutputTable)) {
+		c.Ui.Error("The -tree flag can only be used with the table output format")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
 	// Get the HTTP client
 	client, err := c.Meta.Client()
 	if err != nil {
@@ -133,7 +164,7 @@ func (c *VarListCommand) Run(args []string) int {
 	}
 
 	switch {
-	case json:
+	case output == varOutputJSON:
 
 		// obj and items enable us to rework the output before sending it
 		// to the Format method for transformation into JSON.
@@ -162,8 +193,8 @@ func (c *VarListCommand) Run(args []string) int {
 		}
 
 		// By this point, the output is ready to be transformed to JSON via
-		// the Format func.
-		out, err := Format(json, tmpl, obj)
+		// the shared var output formatter.
+		out, err := formatVarOutput(output, "", obj, nil)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
@@ -180,8 +211,8 @@ func (c *VarListCommand) Run(args []string) int {
 			formatList(
 				dataToQuietStringSlice(vars, c.Meta.namespace)))
 
-	case len(tmpl) > 0:
-		out, err := Format(json, tmpl, vars)
+	case output == varOutputGoTemplate:
+		out, err := formatVarOutput(output, tmpl, vars, nil)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
@@ -189,6 +220,9 @@ func (c *VarListCommand) Run(args []string) int {
 
 		c.Ui.Output(out)
 
+	case tree:
+		c.Ui.Output(formatVarTree(vars, c.Meta.namespace))
+
 	default:
 		c.Ui.Output(formatVarStubs(vars))
 	}
@@ -222,12 +256,99 @@ func formatVarStubs(vars []*api.SecureVariableMetadata) string {
 		rows[i+1] = fmt.Sprintf("%s|%s|%s",
 			sv.Namespace,
 			sv.Path,
-			time.Unix(0, sv.ModifyTime),
+			sv.ModifyTime,
 		)
 	}
 	return formatList(rows)
 }
 
+// varTreeNode is a node of the path hierarchy rendered by var list -tree. Each
+// node aggregates the metadata of the secure variables at or below its path.
+type varTreeNode struct {
+	variables int
+	items     int
+	size      int
+	modified  time.Time
+	children  map[string]*varTreeNode
+}
+
+func (n *varTreeNode) add(segments []string, sv *api.SecureVariableMetadata) {
+	n.variables++
+	n.items += sv.ItemCount
+	n.size += sv.Size
+	if sv.ModifyTime.After(n.modified) {
+		n.modified = sv.ModifyTime
+	}
+	if len(segments) == 0 {
+		return
+	}
+
+	if n.children == nil {
+		n.children = make(map[string]*varTreeNode)
+	}
+	child, ok := n.children[segments[0]]
+	if !ok {
+		child = &varTreeNode{}
+		n.children[segments[0]] = child
+	}
+	child.add(segments[1:], sv)
+}
+
+// render appends the rows of the children of the node, drawing the branches
+// of the tree below the top level nodes.
+func (n *varTreeNode) render(rows []string, indent string, top bool) []string {
+	names := make([]string, 0, len(n.children))
+	for name := range n.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for i, name := range names {
+		child := n.children[name]
+
+		branch, childIndent := "├── ", indent+"│   "
+		if i == len(names)-1 {
+			branch, childIndent = "└── ", indent+"    "
+		}
+		if top {
+			branch, childIndent = "", ""
+		}
+		if len(child.children) > 0 && !strings.HasSuffix(name, ":") {
+			name += "/"
+		}
+
+		rows = append(rows, fmt.Sprintf("%s%s%s|%d|%d|%s|%s",
+			indent, branch, name,
+			child.variables,
+			child.items,
+			humanize.IBytes(uint64(child.size)),
+			formatTime(child.modified),
+		))
+		rows = child.render(rows, childIndent, false)
+	}
+	return rows
+}
+
+// formatVarTree renders the path hierarchy of the secure variables. When
+// listing all namespaces, the top level nodes are the namespaces.
+func formatVarTree(vars []*api.SecureVariableMetadata, ns string) string {
+	if len(vars) == 0 {
+		return msgSecureVariableNotFound
+	}
+
+	root := &varTreeNode{}
+	for _, sv := range vars {
+		segments := strings.Split(strings.Trim(sv.Path, "/"), "/")
+		if ns == "*" {
+			segments = append([]string{sv.Namespace + ":"}, segments...)
+		}
+		root.add(segments, sv)
+	}
+
+	rows := []string{"Path|Variables|Items|Size|Last Updated"}
+	return formatList(root.render(rows, "", true))
+}
+
 func dataToQuietStringSlice(vars []*api.SecureVariableMetadata, ns string) []string {
 	// If ns is the wildcard namespace, we have to provide namespace
 	// as part of the quiet output, otherwise it can be a simple list
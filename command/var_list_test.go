@@ -39,6 +39,27 @@ func TestVarListCommand_Offline(t *testing.T) {
 			expectUsageError:   true,
 			expectStdErrPrefix: "This command takes flags and either no arguments or one: <prefix>",
 		},
+		{
+			name:               "bad output format",
+			args:               []string{"-output", "yaml"},
+			exitCode:           1,
+			expectUsageError:   true,
+			expectStdErrPrefix: "Unsupported output format \"yaml\"",
+		},
+		{
+			name:               "json and output conflict",
+			args:               []string{"-json", "-output", "table"},
+			exitCode:           1,
+			expectUsageError:   true,
+			expectStdErrPrefix: "The -json flag can not be combined with -output=table",
+		},
+		{
+			name:               "tree and json conflict",
+			args:               []string{"-tree", "-json"},
+			exitCode:           1,
+			expectUsageError:   true,
+			expectStdErrPrefix: "The -tree flag can only be used with the table output format",
+		},
 		{
 			name:               "bad address",
 			args:               []string{"-address", "nope"},
@@ -161,7 +182,7 @@ func TestVarListCommand_Online(t *testing.T) {
 				"Namespace|Path|Last Updated",
 				fmt.Sprintf(
 					"default|a/b/c/d|%s",
-					time.Unix(0, variables.HavingPrefix("a/b/c/d")[0].ModifyTime),
+					variables.HavingPrefix("a/b/c/d")[0].ModifyTime,
 				),
 			},
 			),
@@ -344,6 +365,44 @@ func TestVarListCommand_Online(t *testing.T) {
 	}
 }
 
+func TestVarListCommand_FormatTree(t *testing.T) {
+	ci.Parallel(t)
+
+	t1 := time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	vars := []*api.SecureVariableMetadata{
+		{Namespace: "default", Path: "nomad/jobs/web", ItemCount: 2, Size: 100, ModifyTime: t1},
+		{Namespace: "default", Path: "nomad/jobs/web/db", ItemCount: 1, Size: 60, ModifyTime: t2},
+		{Namespace: "default", Path: "nomad/jobs/api", ItemCount: 3, Size: 1000, ModifyTime: t1},
+		{Namespace: "default", Path: "ops", ItemCount: 1, Size: 40, ModifyTime: t1},
+	}
+
+	require.Equal(t, formatList([]string{
+		"Path|Variables|Items|Size|Last Updated",
+		"nomad/|3|6|1.1 KiB|" + formatTime(t2),
+		"└── jobs/|3|6|1.1 KiB|" + formatTime(t2),
+		"    ├── api|1|3|1000 B|" + formatTime(t1),
+		"    └── web/|2|3|160 B|" + formatTime(t2),
+		"        └── db|1|1|60 B|" + formatTime(t2),
+		"ops|1|1|40 B|" + formatTime(t1),
+	}), formatVarTree(vars, "default"))
+
+	// Namespaces are the top level nodes when listing all namespaces
+	vars = []*api.SecureVariableMetadata{
+		{Namespace: "default", Path: "ops", ItemCount: 1, Size: 40, ModifyTime: t1},
+		{Namespace: "prod", Path: "ops", ItemCount: 2, Size: 50, ModifyTime: t2},
+	}
+	require.Equal(t, formatList([]string{
+		"Path|Variables|Items|Size|Last Updated",
+		"default:|1|1|40 B|" + formatTime(t1),
+		"└── ops|1|1|40 B|" + formatTime(t1),
+		"prod:|1|2|50 B|" + formatTime(t2),
+		"└── ops|1|2|50 B|" + formatTime(t2),
+	}), formatVarTree(vars, "*"))
+
+	require.Equal(t, msgSecureVariableNotFound, formatVarTree(nil, "default"))
+}
+
 func resetUiWriters(ui *cli.MockUi) {
 	ui.ErrorWriter.Reset()
 	ui.OutputWriter.Reset()
@@ -0,0 +1,192 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type VarMigrateVaultCommand struct {
+	Meta
+}
+
+func (c *VarMigrateVaultCommand) Help() string {
+	helpText := `
+Usage: nomad var migrate-vault [options] -prefix=<vault-prefix>
+
+  Migrate-vault is used to copy all the secrets under a path prefix of a Vault
+  KV secrets engine to Nomad secure variables. Each secret is written to the
+  secure variable with the same path relative to the prefix, under the
+  destination prefix. For example, with -prefix=secret/apps/ the secret
+  "secret/apps/web/db" is copied to the secure variable "apps/web/db".
+
+  Secure variables which already exist are skipped, unless the -overwrite
+  flag is set. Secret values which are not strings are stored as JSON.
+
+  The Vault client is configured from the environment, as with the Vault CLI,
+  so VAULT_ADDR and VAULT_TOKEN must be set. The Vault token requires the
+  list and read capabilities for the secrets.
+
+  If ACLs are enabled, this command requires a token with the ` + "`write`" + `
+  capability for the target secure variables' namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Migrate Vault Options:
+
+  -prefix=<vault-prefix>
+    The Vault path under which secrets are copied, including the mount of the
+    KV secrets engine. Required.
+
+  -dest=<prefix>
+    The path prefix of the secure variables to write. Defaults to the Vault
+    prefix without the mount of the secrets engine.
+
+  -overwrite
+    Replace the items of secure variables which already exist.
+
+  -dry-run
+    List the secrets that would be copied without writing any secure variable.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *VarMigrateVaultCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-prefix":    complete.PredictAnything,
+			"-dest":      complete.PredictAnything,
+			"-overwrite": complete.PredictNothing,
+			"-dry-run":   complete.PredictNothing,
+		},
+	)
+}
+
+func (c *VarMigrateVaultCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *VarMigrateVaultCommand) Synopsis() string {
+	return "Copy secrets from Vault KV to secure variables"
+}
+
+func (c *VarMigrateVaultCommand) Name() string { return "var migrate-vault" }
+
+func (c *VarMigrateVaultCommand) Run(args []string) int {
+	var overwrite, dryRun bool
+	var prefix, dest string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&prefix, "prefix", "", "")
+	flags.StringVar(&dest, "dest", "", "")
+	flags.BoolVar(&overwrite, "overwrite", false, "")
+	flags.BoolVar(&dryRun, "dry-run", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments
+	if len(flags.Args()) != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if prefix == "" {
+		c.Ui.Error("The -prefix flag is required")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	kv, vaultPrefix, err := newVaultKV(prefix)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	// Vault only lists the secrets under a "directory"
+	if vaultPrefix != "" && !strings.HasSuffix(vaultPrefix, "/") {
+		vaultPrefix += "/"
+	}
+	if dest == "" {
+		dest = vaultPrefix
+	} else if !strings.HasSuffix(dest, "/") {
+		dest += "/"
+	}
+
+	paths, err := kv.walk(vaultPrefix)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing Vault secrets under %q: %s", prefix, err))
+		return 1
+	}
+	if len(paths) == 0 {
+		c.Ui.Output(fmt.Sprintf("No Vault secrets found under %q", prefix))
+		return 0
+	}
+
+	if dryRun {
+		c.Ui.Output(fmt.Sprintf("Would copy %d Vault secret(s):", len(paths)))
+		for _, path := range paths {
+			c.Ui.Output(fmt.Sprintf("  %s%s -> %s", kv.mount, path, dest+strings.TrimPrefix(path, vaultPrefix)))
+		}
+		return 0
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	var copied, skipped, failed int
+	for _, path := range paths {
+		vaultPath := kv.mount + path
+		sv := api.NewSecureVariable(dest + strings.TrimPrefix(path, vaultPrefix))
+
+		data, err := kv.read(path)
+		if err == nil && data == nil {
+			// The secret was deleted since it was listed
+			continue
+		}
+		if err == nil {
+			sv.Items, err = vaultSecretItems(data)
+		}
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading Vault secret %q: %s", vaultPath, err))
+			failed++
+			continue
+		}
+
+		if overwrite {
+			_, _, err = client.SecureVariables().Create(sv, nil)
+		} else {
+			_, _, err = client.SecureVariables().CheckedCreate(sv, nil)
+		}
+		var conflict api.ErrCASConflict
+		switch {
+		case errors.As(err, &conflict):
+			c.Ui.Warn(fmt.Sprintf("Skipped %s: secure variable %q already exists", vaultPath, sv.Path))
+			skipped++
+		case err != nil:
+			c.Ui.Error(fmt.Sprintf("Error writing secure variable %q: %s", sv.Path, err))
+			failed++
+		default:
+			c.Ui.Output(fmt.Sprintf("Copied %s -> %s", vaultPath, sv.Path))
+			copied++
+		}
+	}
+
+	c.Ui.Output(fmt.Sprintf("Copied %d, skipped %d, and failed to copy %d Vault secret(s)",
+		copied, skipped, failed))
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,91 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarMigrateVaultCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &VarMigrateVaultCommand{}
+}
+
+func TestVarMigrateVaultCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	cmd := &VarMigrateVaultCommand{Meta: Meta{Ui: ui}}
+	require.Equal(t, 1, cmd.Run([]string{"foo"}))
+	require.Contains(t, ui.ErrorWriter.String(), "This command takes no arguments")
+
+	ui = cli.NewMockUi()
+	cmd = &VarMigrateVaultCommand{Meta: Meta{Ui: ui}}
+	require.Equal(t, 1, cmd.Run([]string{}))
+	require.Contains(t, ui.ErrorWriter.String(), "The -prefix flag is required")
+}
+
+func TestVarMigrateVaultCommand(t *testing.T) {
+	// Not parallel, the Vault client is configured from the environment
+	v := testutil.NewTestVault(t)
+	defer v.Stop()
+	t.Setenv("VAULT_ADDR", v.HTTPAddr)
+	t.Setenv("VAULT_TOKEN", v.RootToken)
+
+	for _, path := range []string{"apps/web", "apps/db/creds"} {
+		_, err := v.Client.Logical().Write("secret/data/"+path, map[string]interface{}{
+			"data": map[string]interface{}{"user": path},
+		})
+		require.NoError(t, err)
+	}
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	// A dry run doesn't write any secure variable
+	ui := cli.NewMockUi()
+	cmd := &VarMigrateVaultCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, "-prefix=secret/apps", "-dry-run"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "secret/apps/db/creds -> apps/db/creds")
+	require.Contains(t, ui.OutputWriter.String(), "secret/apps/web -> apps/web")
+
+	list, _, err := client.SecureVariables().List(nil)
+	require.NoError(t, err)
+	require.Empty(t, list)
+
+	// Existing secure variables are skipped
+	_, _, err = client.SecureVariables().Create(&api.SecureVariable{
+		Path:  "migrated/web",
+		Items: api.SecureVariableItems{"user": "existing"},
+	}, nil)
+	require.NoError(t, err)
+
+	ui = cli.NewMockUi()
+	cmd = &VarMigrateVaultCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-prefix=secret/apps", "-dest=migrated"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "Copied 1, skipped 1, and failed to copy 0 Vault secret(s)")
+
+	sv, _, err := client.SecureVariables().Read("migrated/db/creds", nil)
+	require.NoError(t, err)
+	require.Equal(t, api.SecureVariableItems{"user": "apps/db/creds"}, sv.Items)
+	sv, _, err = client.SecureVariables().Read("migrated/web", nil)
+	require.NoError(t, err)
+	require.Equal(t, api.SecureVariableItems{"user": "existing"}, sv.Items)
+
+	// Existing secure variables are replaced with -overwrite
+	ui = cli.NewMockUi()
+	cmd = &VarMigrateVaultCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-prefix=secret/apps", "-dest=migrated", "-overwrite"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "Copied 2, skipped 0, and failed to copy 0 Vault secret(s)")
+
+	sv, _, err = client.SecureVariables().Read("migrated/web", nil)
+	require.NoError(t, err)
+	require.Equal(t, api.SecureVariableItems{"user": "apps/web"}, sv.Items)
+}
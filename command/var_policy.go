@@ -0,0 +1,42 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+type VarPolicyCommand struct {
+	Meta
+}
+
+func (c *VarPolicyCommand) Help() string {
+	helpText := `
+Usage: nomad var policy <subcommand> [options] [args]
+
+  This command groups subcommands for writing and testing the ACL policies
+  which grant access to secure variables.
+
+  Generate a policy granting read and write access to a path:
+
+      $ nomad var policy generate -path='apps/*' -capabilities=read,write
+
+  Check what a token can do with a secure variable:
+
+      $ nomad var policy check <token> <path>
+
+  Please see the individual subcommand help for detailed usage information.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *VarPolicyCommand) Synopsis() string {
+	return "Generate and check secure variable ACL policies"
+}
+
+func (c *VarPolicyCommand) Name() string { return "var policy" }
+
+func (c *VarPolicyCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
@@ -0,0 +1,162 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type VarPolicyCheckCommand struct {
+	Meta
+}
+
+func (c *VarPolicyCheckCommand) Help() string {
+	helpText := `
+Usage: nomad var policy check [options] <token> <path>
+
+  Check which capabilities the ACL token with the given secret ID has on the
+  secure variable at path. The checks are made by the servers with the same
+  policies they use to authorize requests, so wildcard paths and capabilities
+  granted by several policies are resolved as they would be for the token.
+
+  The check applies to the namespace set with the -namespace flag. If ACLs are
+  disabled, every capability is allowed.
+
+  The command exits with code 2 if any of the capabilities is denied.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Check Options:
+
+  -capabilities=<capabilities>
+    Comma-separated list of the capabilities to check. Must be among "read",
+    "list", "write", "destroy" and "delete-protected". Defaults to
+    "read,write".
+
+  -json
+    Output the results in JSON format.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *VarPolicyCheckCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-capabilities": complete.PredictSet(
+				acl.SecureVariablesCapabilityRead,
+				acl.SecureVariablesCapabilityList,
+				acl.SecureVariablesCapabilityWrite,
+				acl.SecureVariablesCapabilityDestroy,
+				acl.SecureVariablesCapabilityDeleteProtected,
+			),
+			"-json": complete.PredictNothing,
+		},
+	)
+}
+
+func (c *VarPolicyCheckCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *VarPolicyCheckCommand) Synopsis() string {
+	return "Check the capabilities of a token on a secure variable"
+}
+
+func (c *VarPolicyCheckCommand) Name() string { return "var policy check" }
+
+func (c *VarPolicyCheckCommand) Run(args []string) int {
+	var capabilities string
+	var json bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&capabilities, "capabilities",
+		acl.SecureVariablesCapabilityRead+","+acl.SecureVariablesCapabilityWrite, "")
+	flags.BoolVar(&json, "json", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got the token and the path
+	args = flags.Args()
+	if len(args) != 2 {
+		c.Ui.Error("This command takes two arguments: <token> <path>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	token, path := args[0], args[1]
+
+	caps := splitVarPolicyCapabilities(capabilities)
+	if len(caps) == 0 {
+		c.Ui.Error("At least one capability is required")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	checks := make([]*api.ACLCheck, len(caps))
+	for i, cap := range caps {
+		switch cap {
+		case acl.SecureVariablesCapabilityRead, acl.SecureVariablesCapabilityList,
+			acl.SecureVariablesCapabilityWrite, acl.SecureVariablesCapabilityDestroy,
+			acl.SecureVariablesCapabilityDeleteProtected:
+		default:
+			c.Ui.Error(fmt.Sprintf("Invalid capability %q", cap))
+			c.Ui.Error(commandErrorText(c))
+			return 1
+		}
+		checks[i] = &api.ACLCheck{
+			Resource:   acl.ResourceSecureVariables,
+			Name:       path,
+			Capability: cap,
+		}
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// The checks are made against the token of the request, so make the
+	// request with the token being checked rather than our own
+	client.SetSecretID(token)
+
+	results, _, err := client.ACLTokens().Check(checks, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error checking capabilities: %s", err))
+		return 1
+	}
+
+	code := 0
+	out := make([]string, len(results)+1)
+	out[0] = "Capability|Allowed"
+	for i, result := range results {
+		if result.Error != "" {
+			c.Ui.Error(fmt.Sprintf("Error checking capability %q: %s", result.Capability, result.Error))
+			return 1
+		}
+		if !result.Allowed {
+			code = 2
+		}
+		out[i+1] = fmt.Sprintf("%s|%t", result.Capability, result.Allowed)
+	}
+
+	if json {
+		s, err := Format(true, "", results)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(s)
+		return code
+	}
+
+	c.Ui.Output(formatList(out))
+	return code
+}
@@ -0,0 +1,100 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarPolicyCheckCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &VarPolicyCheckCommand{}
+}
+
+func TestVarPolicyCheckCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name      string
+		args      []string
+		expectErr string
+	}{
+		{
+			name:      "args",
+			args:      []string{"token"},
+			expectErr: "This command takes two arguments",
+		},
+		{
+			name:      "bad capability",
+			args:      []string{"-capabilities", "deny", "token", "apps/a"},
+			expectErr: `Invalid capability "deny"`,
+		},
+		{
+			name:      "bad address",
+			args:      []string{"-address", "nope", "token", "apps/a"},
+			expectErr: "Error checking capabilities",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := &VarPolicyCheckCommand{Meta: Meta{Ui: ui}}
+			code := cmd.Run(tc.args)
+			require.Equal(t, 1, code)
+			require.Contains(t, ui.ErrorWriter.String(), tc.expectErr)
+		})
+	}
+}
+
+func TestVarPolicyCheckCommand(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, url := testServer(t, false, func(c *agent.Config) {
+		c.ACL.Enabled = true
+	})
+	defer stopTestAgent(srv)
+
+	// Create a token with a policy generated by var policy generate
+	ui := cli.NewMockUi()
+	gen := &VarPolicyGenerateCommand{Meta: Meta{Ui: ui}}
+	require.Equal(t, 0, gen.Run([]string{"-path", "apps/*", "-capabilities", "read"}))
+
+	state := srv.Agent.Server().State()
+	policy := mock.ACLPolicy()
+	policy.Rules = ui.OutputWriter.String()
+	policy.SetHash()
+	require.NoError(t, state.UpsertACLPolicies(structs.MsgTypeTestSetup, 1000, []*structs.ACLPolicy{policy}))
+
+	token := mock.ACLToken()
+	token.Policies = []string{policy.Name}
+	token.SetHash()
+	require.NoError(t, state.UpsertACLTokens(structs.MsgTypeTestSetup, 1001, []*structs.ACLToken{token}))
+
+	// The token can read but not write under the path
+	ui = cli.NewMockUi()
+	cmd := &VarPolicyCheckCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, token.SecretID, "apps/web"})
+	require.Equal(t, 2, code, ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.Regexp(t, `read\s+true`, out)
+	require.Regexp(t, `write\s+false`, out)
+
+	// All the checked capabilities are allowed
+	ui = cli.NewMockUi()
+	cmd = &VarPolicyCheckCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-capabilities=read,list", token.SecretID, "apps/web"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+	// Nothing is allowed outside of the path
+	ui = cli.NewMockUi()
+	cmd = &VarPolicyCheckCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-json", token.SecretID, "other/web"})
+	require.Equal(t, 2, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), `"Allowed": false`)
+	require.NotContains(t, ui.OutputWriter.String(), `"Allowed": true`)
+}
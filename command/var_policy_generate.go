@@ -0,0 +1,158 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/api"
+	flaghelper "github.com/hashicorp/nomad/helper/flags"
+	"github.com/posener/complete"
+)
+
+type VarPolicyGenerateCommand struct {
+	Meta
+}
+
+func (c *VarPolicyGenerateCommand) Help() string {
+	helpText := `
+Usage: nomad var policy generate [options]
+
+  Generate an ACL policy granting capabilities on secure variables. The policy
+  is written to stdout in HCL, ready to be applied with:
+
+      $ nomad var policy generate -path='apps/*' | nomad acl policy apply apps -
+
+  The policy applies to the namespace set with the -namespace flag, or to the
+  "default" namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Generate Options:
+
+  -path=<path>
+    The secure variable path the capabilities apply to. Paths may end with a
+    "*" wildcard to match every path with that prefix. Can be specified
+    multiple times. Required.
+
+  -capabilities=<capabilities>
+    Comma-separated list of the capabilities to grant on the paths. Must be
+    among "read", "list", "write", "destroy", "delete-protected" and "deny".
+    Defaults to "read".
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *VarPolicyGenerateCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-path": complete.PredictAnything,
+			"-capabilities": complete.PredictSet(
+				acl.SecureVariablesCapabilityRead,
+				acl.SecureVariablesCapabilityList,
+				acl.SecureVariablesCapabilityWrite,
+				acl.SecureVariablesCapabilityDestroy,
+				acl.SecureVariablesCapabilityDeleteProtected,
+				acl.SecureVariablesCapabilityDeny,
+			),
+		},
+	)
+}
+
+func (c *VarPolicyGenerateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *VarPolicyGenerateCommand) Synopsis() string {
+	return "Generate an ACL policy for secure variables"
+}
+
+func (c *VarPolicyGenerateCommand) Name() string { return "var policy generate" }
+
+func (c *VarPolicyGenerateCommand) Run(args []string) int {
+	var paths flaghelper.StringFlag
+	var capabilities string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.Var(&paths, "path", "")
+	flags.StringVar(&capabilities, "capabilities", acl.SecureVariablesCapabilityRead, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments
+	if len(flags.Args()) != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if len(paths) == 0 {
+		c.Ui.Error("The -path flag is required")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	namespace := c.Meta.namespace
+	if namespace == "" {
+		namespace = api.DefaultNamespace
+	}
+
+	caps := splitVarPolicyCapabilities(capabilities)
+	if len(caps) == 0 {
+		c.Ui.Error("At least one capability is required")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	rules := formatVarPolicy(namespace, paths, caps)
+
+	// Parse the policy the same way the servers will, so that invalid
+	// capabilities are reported now rather than when applying it
+	if _, err := acl.Parse(rules); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error generating policy: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(strings.TrimSpace(rules))
+	return 0
+}
+
+// splitVarPolicyCapabilities splits a comma-separated list of capabilities,
+// ignoring whitespace and empty items.
+func splitVarPolicyCapabilities(s string) []string {
+	caps := []string{}
+	for _, cap := range strings.Split(s, ",") {
+		if cap = strings.TrimSpace(cap); cap != "" {
+			caps = append(caps, cap)
+		}
+	}
+	return caps
+}
+
+// formatVarPolicy renders the HCL of a policy granting the capabilities on
+// the secure variable paths in the namespace.
+func formatVarPolicy(namespace string, paths, caps []string) string {
+	quoted := make([]string, len(caps))
+	for i, cap := range caps {
+		quoted[i] = fmt.Sprintf("%q", cap)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "namespace %q {\n", namespace)
+	b.WriteString("  secure_variables {\n")
+	for i, path := range paths {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "    path %q {\n", path)
+		fmt.Fprintf(&b, "      capabilities = [%s]\n", strings.Join(quoted, ", "))
+		b.WriteString("    }\n")
+	}
+	b.WriteString("  }\n")
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,99 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarPolicyGenerateCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &VarPolicyGenerateCommand{}
+}
+
+func TestVarPolicyGenerateCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name      string
+		args      []string
+		expectErr string
+	}{
+		{
+			name:      "args",
+			args:      []string{"-path", "apps/*", "foo"},
+			expectErr: "This command takes no arguments",
+		},
+		{
+			name:      "no path",
+			args:      []string{},
+			expectErr: "The -path flag is required",
+		},
+		{
+			name:      "no capabilities",
+			args:      []string{"-path", "apps/*", "-capabilities", ","},
+			expectErr: "At least one capability is required",
+		},
+		{
+			name:      "bad capability",
+			args:      []string{"-path", "apps/*", "-capabilities", "read,sudo"},
+			expectErr: "Invalid secure variable capability 'sudo'",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := &VarPolicyGenerateCommand{Meta: Meta{Ui: ui}}
+			code := cmd.Run(tc.args)
+			require.Equal(t, 1, code)
+			require.Contains(t, ui.ErrorWriter.String(), tc.expectErr)
+		})
+	}
+}
+
+func TestVarPolicyGenerateCommand(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	cmd := &VarPolicyGenerateCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{
+		"-namespace", "prod",
+		"-path", "apps/*",
+		"-path", "shared/config",
+		"-capabilities", "read, write",
+	})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+	out := ui.OutputWriter.String()
+	require.Equal(t, `namespace "prod" {
+  secure_variables {
+    path "apps/*" {
+      capabilities = ["read", "write"]
+    }
+
+    path "shared/config" {
+      capabilities = ["read", "write"]
+    }
+  }
+}
+`, out)
+
+	// The output is a valid policy granting the capabilities
+	policy, err := acl.Parse(out)
+	require.NoError(t, err)
+	require.Len(t, policy.Namespaces, 1)
+	paths := policy.Namespaces[0].SecureVariables.Paths
+	require.Len(t, paths, 2)
+	require.Equal(t, "apps/*", paths[0].PathSpec)
+	require.Contains(t, paths[0].Capabilities, acl.SecureVariablesCapabilityWrite)
+
+	// The namespace defaults to the default namespace
+	ui = cli.NewMockUi()
+	cmd = &VarPolicyGenerateCommand{Meta: Meta{Ui: ui}}
+	require.Equal(t, 0, cmd.Run([]string{"-path", "apps/*"}))
+	require.Contains(t, ui.OutputWriter.String(), `namespace "default" {`)
+	require.Contains(t, ui.OutputWriter.String(), `capabilities = ["read"]`)
+}
@@ -0,0 +1,203 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type VarPurgeCommand struct {
+	Meta
+}
+
+func (c *VarPurgeCommand) Help() string {
+	helpText := `
+Usage: nomad var purge [options]
+
+  Purge is used to delete all the secure variables under a path prefix in a
+  single request. Without -recurse, only variables directly under the prefix
+  are deleted; variables in nested paths are kept. The deletes are applied in
+  batches by the server.
+
+  The secure variables to delete are listed and confirmation is requested
+  before deleting them, unless the -yes flag is set.
+
+  If ACLs are enabled, this command requires a token with the ` + "`destroy`" + `
+  capability for every matching secure variable. If the token is missing the
+  capability for any of them, no variables are deleted.
+
+  Secure variables with delete protection are skipped, unless the -force flag
+  is set. Forcing their deletion requires the ` + "`delete-protected`" + ` capability
+  as well.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Purge Options:
+
+  -prefix
+    The path prefix of the secure variables to delete. Required.
+
+  -recurse
+    Delete secure variables at any depth under the prefix.
+
+  -dry-run
+    List the secure variables that would be deleted without deleting them.
+
+  -force
+    Also delete the secure variables with delete protection.
+
+  -yes
+    Automatically answer "yes" to the confirmation prompt.
+
+  ` + varOutputUsage("-template") + `
+
+  -json
+    Output the paths of the deleted secure variables in JSON format.
+    Shorthand for -output=json.
+
+  -template
+    Format and display the paths of the deleted secure variables using a Go
+    template, for example '{{ len . }}'. The template is executed against the
+    list of paths. Implies -output=go-template when -output is not set.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *VarPurgeCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-prefix":   complete.PredictAnything,
+			"-recurse":  complete.PredictNothing,
+			"-dry-run":  complete.PredictNothing,
+			"-force":    complete.PredictNothing,
+			"-yes":      complete.PredictNothing,
+			"-json":     complete.PredictNothing,
+			"-template": complete.PredictAnything,
+			"-output":   complete.PredictSet(varOutputTable, varOutputJSON, varOutputGoTemplate),
+		},
+	)
+}
+
+func (c *VarPurgeCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *VarPurgeCommand) Synopsis() string {
+	return "Delete all secure variables under a prefix"
+}
+
+func (c *VarPurgeCommand) Name() string { return "var purge" }
+
+func (c *VarPurgeCommand) Run(args []string) int {
+	var recurse, dryRun, force, autoYes, json bool
+	var prefix, tmpl, output string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&prefix, "prefix", "", "")
+	flags.BoolVar(&recurse, "recurse", false, "")
+	flags.BoolVar(&dryRun, "dry-run", false, "")
+	flags.BoolVar(&force, "force", false, "")
+	flags.BoolVar(&autoYes, "yes", false, "")
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "template", "", "")
+	flags.StringVar(&output, "output", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments
+	if len(flags.Args()) != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if prefix == "" {
+		c.Ui.Error("The -prefix flag is required")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	output, err := resolveVarOutput(json, output, tmpl)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	req := &api.SecureVariablesPurgeRequest{
+		Prefix:  prefix,
+		Recurse: recurse,
+		DryRun:  true,
+		Force:   force,
+	}
+
+	// Always start with a dry run, so the operator can confirm the list of
+	// secure variables before they are deleted
+	paths, _, err := client.SecureVariables().Purge(req, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error purging secure variables: %s", err))
+		return 1
+	}
+
+	if dryRun {
+		return c.output(paths, output, tmpl, "Would delete")
+	}
+	if len(paths) == 0 {
+		return c.output(paths, output, tmpl, "Deleted")
+	}
+
+	if !autoYes {
+		c.Ui.Output(formatVarPurgePaths(paths, "Would delete"))
+		question := fmt.Sprintf("Are you sure you want to delete %d secure variable(s)? [y/N]", len(paths))
+		answer, err := c.Ui.Ask(question)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to parse answer: %v", err))
+			return 1
+		}
+		if answer != "y" {
+			c.Ui.Output("Cancelling secure variable purge")
+			return 0
+		}
+	}
+
+	req.DryRun = false
+	paths, _, err = client.SecureVariables().Purge(req, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error purging secure variables: %s", err))
+		return 1
+	}
+	return c.output(paths, output, tmpl, "Deleted")
+}
+
+func (c *VarPurgeCommand) output(paths []string, output, tmpl, verb string) int {
+	out, err := formatVarOutput(output, tmpl, paths, func() string {
+		return formatVarPurgePaths(paths, verb)
+	})
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+	c.Ui.Output(out)
+	return 0
+}
+
+func formatVarPurgePaths(paths []string, verb string) string {
+	if len(paths) == 0 {
+		return msgSecureVariableNotFound
+	}
+	return fmt.Sprintf("%s %d secure variable(s):\n  %s",
+		verb, len(paths), strings.Join(paths, "\n  "))
+}
@@ -0,0 +1,194 @@
+package command
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarPurgeCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &VarPurgeCommand{}
+}
+
+func TestVarPurgeCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name      string
+		args      []string
+		expectErr string
+	}{
+		{
+			name:      "args",
+			args:      []string{"foo"},
+			expectErr: "This command takes no arguments",
+		},
+		{
+			name:      "no prefix",
+			args:      []string{},
+			expectErr: "The -prefix flag is required",
+		},
+		{
+			name:      "json with other output",
+			args:      []string{"-prefix", "foo/", "-json", "-output=table"},
+			expectErr: "The -json flag can not be combined with -output=table",
+		},
+		{
+			name:      "bad output",
+			args:      []string{"-prefix", "foo/", "-output=yaml"},
+			expectErr: `Unsupported output format "yaml"`,
+		},
+		{
+			name:      "go-template without template",
+			args:      []string{"-prefix", "foo/", "-output=go-template"},
+			expectErr: "-output=go-template requires a template",
+		},
+		{
+			name:      "bad address",
+			args:      []string{"-address", "nope", "-prefix", "foo/"},
+			expectErr: "Error purging secure variables",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := &VarPurgeCommand{Meta: Meta{Ui: ui}}
+			code := cmd.Run(tc.args)
+			require.Equal(t, 1, code)
+			require.Contains(t, ui.ErrorWriter.String(), tc.expectErr)
+		})
+	}
+}
+
+func TestVarPurgeCommand(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	for _, path := range []string{"apps/legacy/a", "apps/legacy/b", "apps/legacy/nested/c", "apps/current/d"} {
+		sv := api.NewSecureVariable(path)
+		sv.Items["k"] = "v"
+		_, _, err := client.SecureVariables().Create(sv, nil)
+		require.NoError(t, err)
+	}
+
+	remaining := func() []string {
+		vars, _, err := client.SecureVariables().List(nil)
+		require.NoError(t, err)
+		var paths []string
+		for _, v := range vars {
+			paths = append(paths, v.Path)
+		}
+		return paths
+	}
+
+	// A dry run lists the variables without deleting them
+	ui := cli.NewMockUi()
+	cmd := &VarPurgeCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, "-prefix=apps/legacy/", "-recurse", "-dry-run"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.Contains(t, out, "Would delete 3 secure variable(s)")
+	require.Contains(t, out, "apps/legacy/nested/c")
+	require.Len(t, remaining(), 4)
+
+	// Declining the confirmation keeps the variables
+	ui = cli.NewMockUi()
+	ui.InputReader = strings.NewReader("n\n")
+	cmd = &VarPurgeCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-prefix=apps/legacy/"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "Cancelling secure variable purge")
+	require.Len(t, remaining(), 4)
+
+	// Without -recurse only the direct children are deleted
+	ui = cli.NewMockUi()
+	cmd = &VarPurgeCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-prefix=apps/legacy/", "-yes", "-json"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	var deleted []string
+	require.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &deleted))
+	require.Equal(t, []string{"apps/legacy/a", "apps/legacy/b"}, deleted)
+	require.ElementsMatch(t, []string{"apps/legacy/nested/c", "apps/current/d"}, remaining())
+
+	// With -recurse nested variables are deleted too
+	ui = cli.NewMockUi()
+	ui.InputReader = strings.NewReader("y\n")
+	cmd = &VarPurgeCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-prefix=apps/legacy/", "-recurse"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "Deleted 1 secure variable(s)")
+	require.Equal(t, []string{"apps/current/d"}, remaining())
+
+	// Protected variables are only deleted with -force
+	sv := api.NewSecureVariable("apps/current/protected")
+	sv.Items["k"] = "v"
+	sv.DeleteProtection = true
+	_, _, err := client.SecureVariables().Create(sv, nil)
+	require.NoError(t, err)
+
+	ui = cli.NewMockUi()
+	cmd = &VarPurgeCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-prefix=apps/current/", "-yes"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Equal(t, []string{"apps/current/protected"}, remaining())
+
+	ui = cli.NewMockUi()
+	cmd = &VarPurgeCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-prefix=apps/current/", "-yes", "-force"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Empty(t, remaining())
+}
+
+func TestVarPurgeCommand_Output(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	for _, path := range []string{"apps/a", "apps/b"} {
+		sv := api.NewSecureVariable(path)
+		sv.Items["k"] = "v"
+		_, _, err := client.SecureVariables().Create(sv, nil)
+		require.NoError(t, err)
+	}
+
+	t.Run("table", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &VarPurgeCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"-address=" + url, "-prefix=apps/", "-dry-run", "-output=table"})
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+		require.Equal(t, "Would delete 2 secure variable(s):\n  apps/a\n  apps/b",
+			strings.TrimSpace(ui.OutputWriter.String()))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &VarPurgeCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"-address=" + url, "-prefix=apps/", "-dry-run", "-output=json"})
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+		var paths []string
+		require.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &paths))
+		require.Equal(t, []string{"apps/a", "apps/b"}, paths)
+	})
+
+	t.Run("go-template", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &VarPurgeCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"-address=" + url, "-prefix=apps/", "-yes",
+			"-template={{ len . }} {{ index . 0 }}"})
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+		require.Equal(t, "2 apps/a", strings.TrimSpace(ui.OutputWriter.String()))
+
+		vars, _, err := client.SecureVariables().List(nil)
+		require.NoError(t, err)
+		require.Empty(t, vars)
+	})
+}
@@ -0,0 +1,334 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	envparse "github.com/hashicorp/go-envparse"
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type VarPutCommand struct {
+	Meta
+}
+
+func (c *VarPutCommand) Help() string {
+	helpText := `
+Usage: nomad var put [options] <path> [<key>=<value>|@<file>]...
+
+  Put is used to create or update the secure variable stored at the given
+  path. The items of the secure variable are given as key=value arguments, and
+  replace all the items of an existing secure variable.
+
+  Items may also be read from a file given as @<file>, or from stdin with @-.
+  The format of the files is set with the -format option. Arguments are
+  applied in order, so later items override earlier ones with the same key.
+
+  Options may be given before or after the path and items. Items starting
+  with a dash must be given after a "--" argument.
+
+  The items may instead be copied from a secret of a Vault KV secrets engine
+  with the -from-vault option, to migrate secrets from Vault to Nomad. Items
+  given as arguments are added to those read from Vault, and override them.
+
+  If ACLs are enabled, this command requires a token with the ` + "`write`" + `
+  capability for the target secure variable's namespace.
+
+  Secure variables with delete protection can only be deleted by forcing it.
+  Updating a protected secure variable without -delete-protection removes its
+  protection, which must be forced as well and requires the
+  ` + "`delete-protected`" + ` capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Put Options:
+
+  -check-index
+    If set, the secure variable is only written if the passed modify index
+    matches the modify index of the stored secure variable. If a check-index
+    value of zero is passed, the secure variable is only written if it does not
+    exist yet. This is useful to avoid overwriting concurrent updates.
+
+  -delete-protection
+    Protect the secure variable from being deleted, unless the deletion is
+    forced.
+
+  -force
+    Remove the delete protection of the secure variable, if it is updated
+    without -delete-protection. Can't be combined with -check-index.
+
+  -format=<env|json>
+    The format of the files items are read from. Defaults to "env", where
+    each line is a KEY=value item as in a dotenv file. Values may be single
+    or double quoted, and empty lines and lines starting with # are ignored.
+    With "json", the file must contain an object of string values.
+
+  -from-vault=<vault-path>
+    Copy the items of the secret stored at the given path in Vault, for
+    example "secret/myapp/db". Both versions of the KV secrets engine are
+    supported. The Vault client is configured from the environment, so
+    VAULT_ADDR and VAULT_TOKEN must be set. Values which are not strings are
+    stored as JSON.
+
+  ` + varOutputUsage("-template") + `
+
+  -json
+    Output the secure variable in JSON format. Shorthand for -output=json.
+
+  -template
+    Format and display the written secure variable using a Go template.
+    Implies -output=go-template when -output is not set.
+
+  ` + redactVarUsage + `
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *VarPutCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-check-index":       complete.PredictNothing,
+			"-delete-protection": complete.PredictNothing,
+			"-force":             complete.PredictNothing,
+			"-format":            complete.PredictSet(varPutFormatEnv, varPutFormatJSON),
+			"-from-vault":        complete.PredictAnything,
+			"-json":              complete.PredictNothing,
+			"-template":          complete.PredictAnything,
+			"-output":            complete.PredictSet(varOutputTable, varOutputJSON, varOutputGoTemplate),
+			"-redact":            complete.PredictNothing,
+		},
+	)
+}
+
+func (c *VarPutCommand) AutocompleteArgs() complete.Predictor {
+	return SecureVariablePathPredictor(c.Meta.Client)
+}
+
+func (c *VarPutCommand) Synopsis() string {
+	return "Create or update a secure variable"
+}
+
+func (c *VarPutCommand) Name() string { return "var put" }
+
+func (c *VarPutCommand) Run(args []string) int {
+	var json, redact, deleteProtection, force bool
+	var checkIndexStr, fromVault, format, tmpl, output string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&checkIndexStr, "check-index", "", "")
+	flags.BoolVar(&deleteProtection, "delete-protection", false, "")
+	flags.BoolVar(&force, "force", false, "")
+	flags.StringVar(&format, "format", varPutFormatEnv, "")
+	flags.StringVar(&fromVault, "from-vault", "", "")
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "template", "", "")
+	flags.StringVar(&output, "output", "", "")
+	flags.BoolVar(&redact, "redact", redactVarDefault(), "")
+
+	args, err := parseFlagsInterspersed(flags, args)
+	if err != nil {
+		return 1
+	}
+
+	checkIndex, enforce, err := parseCheckIndex(checkIndexStr)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing check-index value %q: %v", checkIndexStr, err))
+		return 1
+	}
+	if enforce && force {
+		c.Ui.Error("The -force flag can't be combined with -check-index")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if format != varPutFormatEnv && format != varPutFormatJSON {
+		c.Ui.Error(fmt.Sprintf("Invalid format %q, must be one of %q or %q", format, varPutFormatEnv, varPutFormatJSON))
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	output, err = resolveVarOutput(json, output, tmpl)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Check that we got a path and the items
+	if len(args) < 1 {
+		c.Ui.Error("This command takes at least one argument: <path>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	sv := api.NewSecureVariable(args[0])
+	sv.DeleteProtection = deleteProtection
+	defer dropVarItems(sv)
+
+	if fromVault != "" {
+		kv, path, err := newVaultKV(fromVault)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		data, err := kv.read(path)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading Vault secret %q: %s", fromVault, err))
+			return 1
+		}
+		if data == nil {
+			c.Ui.Error(fmt.Sprintf("No Vault secret found at %q", fromVault))
+			return 1
+		}
+		items, err := vaultSecretItems(data)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading Vault secret %q: %s", fromVault, err))
+			return 1
+		}
+		for k, v := range items {
+			sv.Items[k] = v
+		}
+	}
+
+	for _, arg := range args[1:] {
+		if strings.HasPrefix(arg, "@") {
+			items, err := readVarItemsFile(strings.TrimPrefix(arg, "@"), format)
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Error reading items from %q: %s", arg, err))
+				return 1
+			}
+			for k, v := range items {
+				sv.Items[k] = v
+			}
+			continue
+		}
+
+		k, v, ok := strings.Cut(arg, "=")
+		if !ok || k == "" {
+			c.Ui.Error(fmt.Sprintf("Invalid item %q: items must be given as <key>=<value>", arg))
+			c.Ui.Error(commandErrorText(c))
+			return 1
+		}
+		sv.Items[k] = v
+	}
+	if len(sv.Items) == 0 {
+		c.Ui.Error("A secure variable requires at least one item")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	var out *api.SecureVariable
+	switch {
+	case enforce:
+		sv.ModifyIndex = checkIndex
+		out, _, err = client.SecureVariables().CheckedUpdate(sv, nil)
+	case force:
+		out, _, err = client.SecureVariables().ForceUpdate(sv, nil)
+	default:
+		out, _, err = client.SecureVariables().Create(sv, nil)
+	}
+	if err != nil {
+		var casErr api.ErrCASConflict
+		if errors.As(err, &casErr) {
+			if checkIndex == 0 {
+				c.Ui.Error(fmt.Sprintf("Error writing secure variable: secure variable %q already exists", sv.Path))
+			} else {
+				c.Ui.Error(fmt.Sprintf("Error writing secure variable: check-index %d does not match the modify index %d of secure variable %q",
+					checkIndex, casErr.Conflict.ModifyIndex, sv.Path))
+			}
+			return 1
+		}
+		c.Ui.Error(fmt.Sprintf("Error writing secure variable: %s", err))
+		return 1
+	}
+	defer dropVarItems(out)
+
+	data := out
+	if redact && output != varOutputGoTemplate {
+		data = redactVarItems(out)
+	}
+	s, err := formatVarOutput(output, tmpl, data, func() string {
+		return fmt.Sprintf("Successfully wrote secure variable %q", out.Path)
+	})
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+	c.Ui.Output(s)
+	return 0
+}
+
+// parseFlagsInterspersed parses the flags given anywhere among the arguments,
+// and returns the other arguments in order. The arguments after a "--"
+// argument are never parsed as flags.
+func parseFlagsInterspersed(flags *flag.FlagSet, args []string) ([]string, error) {
+	var rest []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		parsed := args[:len(args)-len(flags.Args())]
+		args = flags.Args()
+		if len(parsed) > 0 && parsed[len(parsed)-1] == "--" {
+			return append(rest, args...), nil
+		}
+		if len(args) == 0 {
+			return rest, nil
+		}
+		rest = append(rest, args[0])
+		args = args[1:]
+	}
+}
+
+const (
+	varPutFormatEnv  = "env"
+	varPutFormatJSON = "json"
+)
+
+// readVarItemsFile reads secure variable items from the file at path, or from
+// stdin if the path is "-", in the given format.
+func readVarItemsFile(path, format string) (map[string]string, error) {
+	var buf []byte
+	var err error
+	if path == "-" {
+		buf, err = io.ReadAll(os.Stdin)
+	} else {
+		buf, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Clear the plaintext once it is parsed
+	defer func() {
+		for i := range buf {
+			buf[i] = 0
+		}
+	}()
+
+	switch format {
+	case varPutFormatJSON:
+		var items map[string]string
+		if err := json.Unmarshal(buf, &items); err != nil {
+			return nil, fmt.Errorf("expected a JSON object of string values: %w", err)
+		}
+		return items, nil
+	default:
+		return envparse.Parse(bytes.NewReader(buf))
+	}
+}
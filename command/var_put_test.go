@@ -0,0 +1,311 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarPutCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &VarPutCommand{}
+}
+
+func TestVarPutCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name      string
+		args      []string
+		expectErr string
+	}{
+		{
+			name:      "no path",
+			args:      []string{},
+			expectErr: "This command takes at least one argument",
+		},
+		{
+			name:      "bad item",
+			args:      []string{"foo", "bar"},
+			expectErr: `Invalid item "bar"`,
+		},
+		{
+			name:      "no items",
+			args:      []string{"foo"},
+			expectErr: "A secure variable requires at least one item",
+		},
+		{
+			name:      "bad check index",
+			args:      []string{"-check-index", "nope", "foo", "k=v"},
+			expectErr: `Error parsing check-index value "nope"`,
+		},
+		{
+			name:      "force with check index",
+			args:      []string{"-force", "-check-index", "1", "foo", "k=v"},
+			expectErr: "The -force flag can't be combined with -check-index",
+		},
+		{
+			name:      "bad format",
+			args:      []string{"-format", "yaml", "foo", "k=v"},
+			expectErr: `Invalid format "yaml"`,
+		},
+		{
+			name:      "bad format after path",
+			args:      []string{"foo", "k=v", "-format=yaml"},
+			expectErr: `Invalid format "yaml"`,
+		},
+		{
+			name:      "json with other output",
+			args:      []string{"-json", "-output=table", "foo", "k=v"},
+			expectErr: "The -json flag can not be combined with -output=table",
+		},
+		{
+			name:      "bad output",
+			args:      []string{"foo", "k=v", "-output=yaml"},
+			expectErr: `Unsupported output format "yaml"`,
+		},
+		{
+			name:      "template without go-template output",
+			args:      []string{"-output=json", "-template={{ .Path }}", "foo", "k=v"},
+			expectErr: "A template can only be used with -output=go-template",
+		},
+		{
+			name:      "missing file",
+			args:      []string{"foo", "@does-not-exist.env"},
+			expectErr: `Error reading items from "@does-not-exist.env"`,
+		},
+		{
+			name:      "bad address",
+			args:      []string{"-address", "nope", "foo", "k=v"},
+			expectErr: "Error writing secure variable",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := &VarPutCommand{Meta: Meta{Ui: ui}}
+			code := cmd.Run(tc.args)
+			require.Equal(t, 1, code)
+			require.Contains(t, ui.ErrorWriter.String(), tc.expectErr)
+		})
+	}
+}
+
+func TestVarPutCommand(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &VarPutCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, "-json", "apps/web", "user=admin", "pass=s3cr3t"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+	var out api.SecureVariable
+	require.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &out))
+	require.Equal(t, "apps/web", out.Path)
+	require.Equal(t, api.SecureVariableItems{"user": redactedValue, "pass": redactedValue}, out.Items)
+
+	sv, _, err := client.SecureVariables().Read("apps/web", nil)
+	require.NoError(t, err)
+	require.Equal(t, api.SecureVariableItems{"user": "admin", "pass": "s3cr3t"}, sv.Items)
+}
+
+func TestVarPutCommand_Output(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	t.Run("table", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &VarPutCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"-address=" + url, "-output=table", "apps/table", "user=admin"})
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+		require.Equal(t, `Successfully wrote secure variable "apps/table"`, strings.TrimSpace(ui.OutputWriter.String()))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &VarPutCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"-address=" + url, "-output=json", "apps/json", "user=admin"})
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+		var out api.SecureVariable
+		require.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &out))
+		require.Equal(t, "apps/json", out.Path)
+		require.Equal(t, api.SecureVariableItems{"user": redactedValue}, out.Items)
+	})
+
+	t.Run("go-template", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &VarPutCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"-address=" + url, "apps/tmpl", "user=admin",
+			"-template={{ .Path }} {{ .Items.user }}"})
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+		require.Equal(t, "apps/tmpl admin", strings.TrimSpace(ui.OutputWriter.String()))
+	})
+}
+
+func TestVarPutCommand_CheckIndex(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &VarPutCommand{Meta: Meta{Ui: ui}}
+
+	// A zero check index only creates the secure variable
+	code := cmd.Run([]string{"-address=" + url, "-check-index=0", "apps/web", "user=admin"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+	code = cmd.Run([]string{"-address=" + url, "-check-index=0", "apps/web", "user=root"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), `secure variable "apps/web" already exists`)
+
+	sv, _, err := client.SecureVariables().Read("apps/web", nil)
+	require.NoError(t, err)
+	require.Equal(t, "admin", sv.Items["user"])
+
+	// A stale check index is rejected
+	ui.ErrorWriter.Reset()
+	stale := fmt.Sprint(sv.ModifyIndex - 1)
+	code = cmd.Run([]string{"-address=" + url, "-check-index=" + stale, "apps/web", "user=root"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(),
+		fmt.Sprintf("check-index %s does not match the modify index %d", stale, sv.ModifyIndex))
+
+	// The current check index updates the secure variable
+	code = cmd.Run([]string{"-address=" + url, "-check-index=" + fmt.Sprint(sv.ModifyIndex), "apps/web", "user=root"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+	sv, _, err = client.SecureVariables().Read("apps/web", nil)
+	require.NoError(t, err)
+	require.Equal(t, "root", sv.Items["user"])
+}
+
+func TestVarPutCommand_DeleteProtection(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &VarPutCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, "-delete-protection", "apps/web", "user=admin"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+	sv, _, err := client.SecureVariables().Read("apps/web", nil)
+	require.NoError(t, err)
+	require.True(t, sv.DeleteProtection)
+
+	// Updating without the protection must be forced
+	code = cmd.Run([]string{"-address=" + url, "apps/web", "user=root"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "delete protected")
+
+	code = cmd.Run([]string{"-address=" + url, "-force", "apps/web", "user=root"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+	sv, _, err = client.SecureVariables().Read("apps/web", nil)
+	require.NoError(t, err)
+	require.False(t, sv.DeleteProtection)
+	require.Equal(t, "root", sv.Items["user"])
+}
+
+func TestVarPutCommand_File(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	dir := t.TempDir()
+	envFile := filepath.Join(dir, "config.env")
+	require.NoError(t, os.WriteFile(envFile, []byte(`# database
+DB_USER=admin
+export DB_PASS="s3cr=t # not a comment"
+
+DB_HOST='db.example.com'
+`), 0600))
+
+	ui := cli.NewMockUi()
+	cmd := &VarPutCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, "-format=env", "apps/web", "@" + envFile, "DB_HOST=localhost"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+	sv, _, err := client.SecureVariables().Read("apps/web", nil)
+	require.NoError(t, err)
+	require.Equal(t, api.SecureVariableItems{
+		"DB_USER": "admin",
+		"DB_PASS": "s3cr=t # not a comment",
+		"DB_HOST": "localhost",
+	}, sv.Items)
+
+	// Items can be read from JSON files too, and options can follow the path
+	jsonFile := filepath.Join(dir, "config.json")
+	require.NoError(t, os.WriteFile(jsonFile, []byte(`{"user": "root"}`), 0600))
+
+	code = cmd.Run([]string{"-address=" + url, "apps/web", "@" + jsonFile, "-format=json"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+	sv, _, err = client.SecureVariables().Read("apps/web", nil)
+	require.NoError(t, err)
+	require.Equal(t, api.SecureVariableItems{"user": "root"}, sv.Items)
+
+	// Items starting with a dash follow "--"
+	code = cmd.Run([]string{"-address=" + url, "apps/web", "--", "-flag=value"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+	sv, _, err = client.SecureVariables().Read("apps/web", nil)
+	require.NoError(t, err)
+	require.Equal(t, api.SecureVariableItems{"-flag": "value"}, sv.Items)
+
+	// Invalid lines are reported
+	require.NoError(t, os.WriteFile(envFile, []byte("DB_USER=admin\nDB_PASS\n"), 0600))
+	code = cmd.Run([]string{"-address=" + url, "apps/web", "@" + envFile})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "error on line 2: missing =")
+}
+
+func TestVarPutCommand_FromVault(t *testing.T) {
+	// Not parallel, the Vault client is configured from the environment
+	v := testutil.NewTestVault(t)
+	defer v.Stop()
+	t.Setenv("VAULT_ADDR", v.HTTPAddr)
+	t.Setenv("VAULT_TOKEN", v.RootToken)
+
+	_, err := v.Client.Logical().Write("secret/data/apps/web", map[string]interface{}{
+		"data": map[string]interface{}{"user": "admin", "pass": "s3cr3t"},
+	})
+	require.NoError(t, err)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	// Items given as arguments override those read from Vault
+	ui := cli.NewMockUi()
+	cmd := &VarPutCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, "-from-vault=secret/apps/web", "apps/web", "pass=override"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+	sv, _, err := client.SecureVariables().Read("apps/web", nil)
+	require.NoError(t, err)
+	require.Equal(t, api.SecureVariableItems{"user": "admin", "pass": "override"}, sv.Items)
+
+	ui = cli.NewMockUi()
+	cmd = &VarPutCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-from-vault=secret/apps/missing", "apps/missing"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), `No Vault secret found at "secret/apps/missing"`)
+}
@@ -0,0 +1,277 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/command/varrotate"
+	flaghelper "github.com/hashicorp/nomad/helper/flags"
+	"github.com/posener/complete"
+)
+
+const (
+	// varRotateMetaKey is the meta key of the jobs given to -trigger-job that
+	// is updated after a rotation, to create a new version of the jobs.
+	varRotateMetaKey = "nomad_var_rotated"
+
+	// varRotateDefaultItem is the item rotated without -item.
+	varRotateDefaultItem = "password"
+)
+
+type VarRotateCommand struct {
+	Meta
+}
+
+func (c *VarRotateCommand) Help() string {
+	helpText := `
+Usage: nomad var rotate [options] <path>
+
+  Rotate is used to generate new credentials for the items of the secure
+  variable stored at the given path. The new values are generated by a
+  rotator, then written with a check-and-set on the modify index of the
+  secure variable read before the rotation, so that concurrent updates are
+  not overwritten. The other items of the secure variable are kept.
+
+  The jobs given with -trigger-job are updated after the rotation, so that
+  their allocations are replaced and read the new credentials. The value of
+  the "` + varRotateMetaKey + `" meta key of each job is set to the path and
+  new modify index of the secure variable, which creates a new version of the
+  job and a deployment for jobs with an update stanza.
+
+  If the secure variable can't be written after a rotator other than "random"
+  ran, the rotated items are saved to a file only readable by the user, since
+  the rotator may have changed the credential already.
+
+  If ACLs are enabled, this command requires a token with the ` + "`read`" + ` and
+  ` + "`write`" + ` capabilities for the secure variable, and the
+  ` + "`submit-job`" + ` capability for the namespace of the triggered jobs.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Rotate Options:
+
+  -rotator=<rotator>
+    The rotator generating the new values. Defaults to "random". Supported
+    rotators are:
+
+      random: Generates random values. Supports the "length" (32 by default)
+      and "charset" ("alphanumeric", "hex" or "symbols") options.
+
+      exec:<command>: Runs the command, which receives the secure variable,
+      the items to rotate and the options as a JSON object on its standard
+      input, and must write the new items as a JSON object of strings to its
+      standard output.
+
+      postgres: Changes the password of the PostgreSQL role of the "username"
+      item with psql, connecting with the current password. Supports the
+      "host", "port", "database" and "sslmode" options, which default to the
+      items of the same name, and the "username", "length" and "psql"
+      options.
+
+  -item=<key>
+    The key of an item to rotate. Can be specified multiple times. Defaults to
+    "` + varRotateDefaultItem + `".
+
+  -option=<key>=<value>
+    An option of the rotator. Can be specified multiple times.
+
+  -trigger-job=<job-id>
+    A job to update after the rotation. Can be specified multiple times.
+
+  -timeout=<duration>
+    How long the rotator is given to generate the new values. Defaults to 1m.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *VarRotateCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-rotator":     complete.PredictSet(append(varrotate.Names(), "exec:")...),
+			"-item":        complete.PredictAnything,
+			"-option":      complete.PredictAnything,
+			"-trigger-job": complete.PredictAnything,
+			"-timeout":     complete.PredictAnything,
+		},
+	)
+}
+
+func (c *VarRotateCommand) AutocompleteArgs() complete.Predictor {
+	return SecureVariablePathPredictor(c.Meta.Client)
+}
+
+func (c *VarRotateCommand) Synopsis() string {
+	return "Rotate the credentials stored in a secure variable"
+}
+
+func (c *VarRotateCommand) Name() string { return "var rotate" }
+
+func (c *VarRotateCommand) Run(args []string) int {
+	var rotatorSpec string
+	var keys, optionArgs, jobIDs []string
+	var timeout time.Duration
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&rotatorSpec, "rotator", "random", "")
+	flags.Var((*flaghelper.StringFlag)(&keys), "item", "")
+	flags.Var((*flaghelper.StringFlag)(&optionArgs), "option", "")
+	flags.Var((*flaghelper.StringFlag)(&jobIDs), "trigger-job", "")
+	flags.DurationVar(&timeout, "timeout", time.Minute, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <path>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	path := args[0]
+
+	if len(keys) == 0 {
+		keys = []string{varRotateDefaultItem}
+	}
+	options, err := parseVarRotateOptions(optionArgs)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	rotator, err := varrotate.New(rotatorSpec)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating rotator: %s", err))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	sv, _, err := client.SecureVariables().Read(path, nil)
+	if err != nil {
+		if err.Error() == api.ErrVariableNotFound {
+			c.Ui.Error(fmt.Sprintf("Secure variable %q not found; create it with 'nomad var put' first", path))
+			return 1
+		}
+		c.Ui.Error(fmt.Sprintf("Error retrieving secure variable: %s", err))
+		return 1
+	}
+	defer dropVarItems(sv)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	items, err := varrotate.Rotate(ctx, rotator, &varrotate.Request{
+		Namespace: sv.Namespace,
+		Path:      sv.Path,
+		Keys:      keys,
+		Items:     sv.Items,
+		Options:   options,
+	})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error rotating secure variable %q: %s", sv.Path, err))
+		return 1
+	}
+
+	for k, v := range items {
+		sv.Items[k] = v
+	}
+	out, _, err := client.SecureVariables().CheckedUpdate(sv, nil)
+	if err != nil {
+		var casErr api.ErrCASConflict
+		if errors.As(err, &casErr) {
+			c.Ui.Error(fmt.Sprintf("Error writing secure variable: secure variable %q was modified during the rotation (modify index %d, expected %d)",
+				sv.Path, casErr.Conflict.ModifyIndex, sv.ModifyIndex))
+		} else {
+			c.Ui.Error(fmt.Sprintf("Error writing secure variable: %s", err))
+		}
+		if rotatorSpec != "random" {
+			c.Ui.Error("The rotator may have changed the credential already")
+			if file, err := saveVarRotateItems(sv.Items); err != nil {
+				c.Ui.Error(fmt.Sprintf("Error saving the rotated items: %s", err))
+			} else {
+				c.Ui.Error(fmt.Sprintf("The rotated items were saved to %s; write them with 'nomad var put -format=json %s @%s', then delete the file",
+					file, sv.Path, file))
+			}
+		}
+		return 1
+	}
+	defer dropVarItems(out)
+
+	rotated := make([]string, 0, len(items))
+	for k := range items {
+		rotated = append(rotated, fmt.Sprintf("%q", k))
+	}
+	sort.Strings(rotated)
+	c.Ui.Output(fmt.Sprintf("Successfully rotated item(s) %s of secure variable %q",
+		strings.Join(rotated, ", "), out.Path))
+
+	code := 0
+	for _, jobID := range jobIDs {
+		patch := map[string]interface{}{
+			"Meta": map[string]interface{}{
+				varRotateMetaKey: fmt.Sprintf("%s@%d", out.Path, out.ModifyIndex),
+			},
+		}
+		resp, _, err := client.Jobs().Patch(jobID, patch, nil, nil, nil)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error updating job %q: %s", jobID, err))
+			code = 1
+			continue
+		}
+		c.Ui.Output(fmt.Sprintf("Updated job %q, evaluation ID %q", jobID, resp.EvalID))
+	}
+	return code
+}
+
+// saveVarRotateItems saves the items of a secure variable that couldn't be
+// written after a rotation to a new JSON file only readable by the user, so
+// that credentials changed by the rotator aren't lost. It returns the path of
+// the file.
+func saveVarRotateItems(items map[string]string) (string, error) {
+	f, err := os.CreateTemp("", "nomad-var-rotate-*.json")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if err := json.NewEncoder(f).Encode(items); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// parseVarRotateOptions parses the <key>=<value> arguments of the -option
+// flag.
+func parseVarRotateOptions(args []string) (map[string]string, error) {
+	options := make(map[string]string, len(args))
+	for _, arg := range args {
+		k, v, ok := strings.Cut(arg, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("Invalid option %q: options must be given as <key>=<value>", arg)
+		}
+		options[k] = v
+	}
+	return options, nil
+}
@@ -0,0 +1,167 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/varrotate"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarRotateCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &VarRotateCommand{}
+}
+
+func TestVarRotateCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name      string
+		args      []string
+		expectErr string
+	}{
+		{
+			name:      "no path",
+			args:      []string{},
+			expectErr: "This command takes one argument",
+		},
+		{
+			name:      "bad option",
+			args:      []string{"-option", "length", "foo"},
+			expectErr: `Invalid option "length"`,
+		},
+		{
+			name:      "unknown rotator",
+			args:      []string{"-rotator", "mysql", "foo"},
+			expectErr: `unknown rotator "mysql"`,
+		},
+		{
+			name:      "exec without command",
+			args:      []string{"-rotator", "exec:", "foo"},
+			expectErr: "the exec rotator requires a command",
+		},
+		{
+			name:      "bad address",
+			args:      []string{"-address", "nope", "foo"},
+			expectErr: "Error retrieving secure variable",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := &VarRotateCommand{Meta: Meta{Ui: ui}}
+			code := cmd.Run(tc.args)
+			require.Equal(t, 1, code)
+			require.Contains(t, ui.ErrorWriter.String(), tc.expectErr)
+		})
+	}
+}
+
+func TestVarRotateCommand(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+	testutil.WaitForLeader(t, srv.Agent.RPC)
+
+	sv := api.NewSecureVariable("apps/db")
+	sv.Items["username"] = "app"
+	sv.Items["password"] = "old"
+	sv, _, err := client.SecureVariables().Create(sv, nil)
+	require.NoError(t, err)
+
+	job := testJob("job1")
+	_, _, err = client.Jobs().Register(job, nil)
+	require.NoError(t, err)
+
+	// Rotating a missing secure variable fails
+	ui := cli.NewMockUi()
+	cmd := &VarRotateCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, "apps/missing"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), `Secure variable "apps/missing" not found`)
+
+	ui = cli.NewMockUi()
+	cmd = &VarRotateCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-option", "length=16", "-option", "charset=hex",
+		"-trigger-job", "job1", "apps/db"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), `Successfully rotated item(s) "password" of secure variable "apps/db"`)
+	require.Contains(t, ui.OutputWriter.String(), `Updated job "job1"`)
+
+	out, _, err := client.SecureVariables().Read("apps/db", nil)
+	require.NoError(t, err)
+	require.Equal(t, "app", out.Items["username"])
+	require.Len(t, out.Items["password"], 16)
+	require.Regexp(t, "^[0-9a-f]+$", out.Items["password"])
+	require.Greater(t, out.ModifyIndex, sv.ModifyIndex)
+
+	jobOut, _, err := client.Jobs().Info("job1", nil)
+	require.NoError(t, err)
+	require.Equal(t, pointer.Of(uint64(1)), jobOut.Version)
+	require.Contains(t, jobOut.Meta[varRotateMetaKey], "apps/db@")
+}
+
+// conflictRotator updates the secure variable during the rotation, so that
+// writing the rotated items fails.
+type conflictRotator struct {
+	client *api.Client
+}
+
+func (r conflictRotator) Rotate(_ context.Context, req *varrotate.Request) (map[string]string, error) {
+	sv := api.NewSecureVariable(req.Path)
+	sv.Items["password"] = "concurrent"
+	if _, _, err := r.client.SecureVariables().Update(sv, nil); err != nil {
+		return nil, err
+	}
+	return map[string]string{"password": "rotated"}, nil
+}
+
+func TestVarRotateCommand_SavesItemsOnConflict(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+	testutil.WaitForLeader(t, srv.Agent.RPC)
+
+	sv := api.NewSecureVariable("apps/conflict")
+	sv.Items["username"] = "app"
+	sv.Items["password"] = "old"
+	_, _, err := client.SecureVariables().Create(sv, nil)
+	require.NoError(t, err)
+
+	varrotate.Register("test-conflict", func(string) (varrotate.Rotator, error) {
+		return conflictRotator{client: client}, nil
+	})
+
+	ui := cli.NewMockUi()
+	cmd := &VarRotateCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, "-rotator", "test-conflict", "apps/conflict"})
+	require.Equal(t, 1, code)
+	errOut := ui.ErrorWriter.String()
+	require.Contains(t, errOut, "was modified during the rotation")
+
+	// The rotated items are saved, only readable by the user
+	match := regexp.MustCompile(`The rotated items were saved to (\S+);`).FindStringSubmatch(errOut)
+	require.Len(t, match, 2, errOut)
+	defer os.Remove(match[1])
+
+	info, err := os.Stat(match[1])
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	raw, err := os.ReadFile(match[1])
+	require.NoError(t, err)
+	var items map[string]string
+	require.NoError(t, json.Unmarshal(raw, &items))
+	require.Equal(t, map[string]string{"username": "app", "password": "rotated"}, items)
+}
@@ -0,0 +1,312 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/posener/complete"
+)
+
+const (
+	// varRunKillTimeout is how long a command run with -renew is given to
+	// exit after being sent SIGTERM, before it is killed and restarted.
+	varRunKillTimeout = 10 * time.Second
+
+	// varRunRetryInterval is the time between two attempts to watch the
+	// secure variables of a command run with -renew after a failure.
+	varRunRetryInterval = 5 * time.Second
+)
+
+type VarRunCommand struct {
+	Meta
+}
+
+func (c *VarRunCommand) Help() string {
+	helpText := `
+Usage: nomad var run [options] -prefix=<prefix> -- <command> [<args>...]
+
+  Run is used to run a command with the items of the secure variables stored
+  under a path prefix exported as environment variables, in the same way as
+  envconsul. The name of each environment variable is derived from the path
+  of the secure variable relative to the prefix, followed by the key of the
+  item. For example, with -prefix=apps/billing the item "password" of the
+  secure variable "apps/billing/db" is exported as DB_PASSWORD. Names are
+  upper-cased, and characters other than letters, digits and underscores are
+  replaced with underscores. The items of the secure variable stored at the
+  prefix itself are exported with the name of their key.
+
+  The command inherits the environment of this process, which is overridden
+  by the secure variables. Signals received by this process are forwarded to
+  the command, and the command's exit code is returned once it exits.
+
+  If ACLs are enabled, this command requires a token with the ` + "`read`" + `
+  capability for the secure variables' namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Run Options:
+
+  -prefix=<prefix>
+    The path prefix of the secure variables to export. Required.
+
+  -renew
+    Watch the secure variables under the prefix, and restart the command when
+    the environment derived from them changes. The command is sent SIGTERM,
+    and is killed if it doesn't exit within 10 seconds.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *VarRunCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-prefix": SecureVariablePathPredictor(c.Meta.Client),
+			"-renew":  complete.PredictNothing,
+		},
+	)
+}
+
+func (c *VarRunCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFiles("*")
+}
+
+func (c *VarRunCommand) Synopsis() string {
+	return "Run a command with secure variables in its environment"
+}
+
+func (c *VarRunCommand) Name() string { return "var run" }
+
+func (c *VarRunCommand) Run(args []string) int {
+	var renew bool
+	var prefix string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&prefix, "prefix", "", "")
+	flags.BoolVar(&renew, "renew", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got a command to run
+	args = flags.Args()
+	if len(args) < 1 {
+		c.Ui.Error("This command takes at least one argument: <command>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if prefix == "" {
+		c.Ui.Error("The -prefix flag is required")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	prefix = strings.TrimSuffix(prefix, "/")
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	env, err := readVarRunEnv(client.SecureVariables(), prefix)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading secure variables: %s", err))
+		return 1
+	}
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalCh)
+
+	// changes receives the new environment each time it changes. It is never
+	// ready without -renew.
+	var changes <-chan map[string]string
+	if renew {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		changes = c.watchVarRunEnv(ctx, client, prefix, env)
+	}
+
+	for {
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Env = os.Environ()
+		for k, v := range env {
+			cmd.Env = append(cmd.Env, k+"="+v)
+		}
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+
+		if err := cmd.Start(); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error starting command: %s", err))
+			return 1
+		}
+		exited := make(chan error, 1)
+		go func() { exited <- cmd.Wait() }()
+
+		restart := false
+		for !restart {
+			select {
+			case err := <-exited:
+				return varRunExitCode(err)
+			case sig := <-signalCh:
+				_ = cmd.Process.Signal(sig)
+			case env = <-changes:
+				c.Ui.Warn("Secure variables changed, restarting the command")
+				stopVarRunCommand(cmd, exited)
+				restart = true
+			}
+		}
+	}
+}
+
+// watchVarRunEnv watches the secure variables under the prefix until the
+// context is done, and sends the environment derived from them each time it
+// differs from the previous one, starting with env.
+func (c *VarRunCommand) watchVarRunEnv(ctx context.Context, client *api.Client, prefix string, env map[string]string) <-chan map[string]string {
+	changes := make(chan map[string]string)
+	query := func(q *api.QueryOptions) (uint64, error) {
+		_, meta, err := client.SecureVariables().PrefixList(prefix, q)
+		return metaIndex(meta), err
+	}
+
+	go func() {
+		// The first query returns immediately with the current index, and the
+		// environment is read again in case it changed since it was read
+		// before starting the command.
+		indexes := []uint64{0}
+		for {
+			err := waitForChange(ctx, []watchQuery{query}, indexes)
+			var next map[string]string
+			if err == nil {
+				next, err = readVarRunEnv(client.SecureVariables(), prefix)
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				c.Ui.Warn(fmt.Sprintf("Error watching secure variables: %s", err))
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(varRunRetryInterval):
+				}
+				continue
+			}
+			if helper.CompareMapStringString(env, next) {
+				continue
+			}
+
+			env = next
+			select {
+			case <-ctx.Done():
+				return
+			case changes <- next:
+			}
+		}
+	}()
+	return changes
+}
+
+// stopVarRunCommand sends SIGTERM to the command and waits for it to exit,
+// killing it if it doesn't exit within varRunKillTimeout.
+func stopVarRunCommand(cmd *exec.Cmd, exited <-chan error) {
+	if err := cmd.Process.Signal(syscall.SIGTERM); err != nil {
+		_ = cmd.Process.Kill()
+	}
+	select {
+	case <-exited:
+	case <-time.After(varRunKillTimeout):
+		_ = cmd.Process.Kill()
+		<-exited
+	}
+}
+
+// varRunExitCode returns the exit code of a command from the error returned
+// by Wait.
+func varRunExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+		return exitErr.ExitCode()
+	}
+	return 1
+}
+
+// readVarRunEnv reads the secure variables under the prefix and returns the
+// environment variables derived from their items. Paths sharing the prefix
+// but not under it, such as "apps/billing2" for the prefix "apps/billing",
+// are ignored.
+func readVarRunEnv(vars api.SecureVariablesAPI, prefix string) (map[string]string, error) {
+	metas, _, err := vars.PrefixList(prefix, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	env := make(map[string]string)
+	sources := make(map[string]string)
+	for _, meta := range metas {
+		rel := strings.TrimPrefix(meta.Path, prefix)
+		if rel != "" && !strings.HasPrefix(rel, "/") {
+			continue
+		}
+		rel = strings.TrimPrefix(rel, "/")
+
+		sv, _, err := vars.Read(meta.Path, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error reading secure variable %q: %w", meta.Path, err)
+		}
+
+		keys := make([]string, 0, len(sv.Items))
+		for k := range sv.Items {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			name := varEnvName(rel, k)
+			source := fmt.Sprintf("%q of %q", k, sv.Path)
+			if other, ok := sources[name]; ok {
+				return nil, fmt.Errorf("items %s and %s are both exported as %s", other, source, name)
+			}
+			sources[name] = source
+			env[name] = sv.Items[k]
+		}
+	}
+	return env, nil
+}
+
+// varEnvName returns the name of the environment variable of an item, from
+// the path of its secure variable relative to the prefix and its key.
+func varEnvName(rel, key string) string {
+	name := key
+	if rel != "" {
+		name = rel + "_" + key
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
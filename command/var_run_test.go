@@ -0,0 +1,198 @@
+package command
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/apimock"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarRunCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &VarRunCommand{}
+}
+
+func TestVarRunCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name      string
+		args      []string
+		expectErr string
+	}{
+		{
+			name:      "no command",
+			args:      []string{"-prefix", "apps"},
+			expectErr: "This command takes at least one argument: <command>",
+		},
+		{
+			name:      "no prefix",
+			args:      []string{"--", "env"},
+			expectErr: "The -prefix flag is required",
+		},
+		{
+			name:      "bad address",
+			args:      []string{"-address", "nope", "-prefix", "apps", "--", "env"},
+			expectErr: "Error reading secure variables",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := &VarRunCommand{Meta: Meta{Ui: ui}}
+			code := cmd.Run(tc.args)
+			require.Equal(t, 1, code)
+			require.Contains(t, ui.ErrorWriter.String(), tc.expectErr)
+		})
+	}
+}
+
+func TestVarRunCommand_varEnvName(t *testing.T) {
+	ci.Parallel(t)
+
+	require.Equal(t, "PASSWORD", varEnvName("", "password"))
+	require.Equal(t, "DB_PASSWORD", varEnvName("db", "password"))
+	require.Equal(t, "EU_WEST_DB_API_KEY", varEnvName("eu-west/db", "api.key"))
+}
+
+func TestVarRunCommand_readVarRunEnv(t *testing.T) {
+	ci.Parallel(t)
+
+	vars := map[string]map[string]string{
+		"apps/billing":    {"mode": "live"},
+		"apps/billing/db": {"password": "s3cr3t"},
+		"apps/billing2":   {"ignored": "true"},
+		"apps/billing/mq": {"password": "other"},
+	}
+	mock := &apimock.SecureVariables{
+		PrefixListFunc: func(prefix string, _ *api.QueryOptions) ([]*api.SecureVariableMetadata, *api.QueryMeta, error) {
+			require.Equal(t, "apps/billing", prefix)
+			metas := []*api.SecureVariableMetadata{}
+			for path := range vars {
+				metas = append(metas, &api.SecureVariableMetadata{Path: path})
+			}
+			return metas, &api.QueryMeta{}, nil
+		},
+		ReadFunc: func(path string, _ *api.QueryOptions) (*api.SecureVariable, *api.QueryMeta, error) {
+			sv := api.NewSecureVariable(path)
+			sv.Items = vars[path]
+			return sv, &api.QueryMeta{}, nil
+		},
+	}
+
+	env, err := readVarRunEnv(mock, "apps/billing")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"MODE":        "live",
+		"DB_PASSWORD": "s3cr3t",
+		"MQ_PASSWORD": "other",
+	}, env)
+
+	// Items exported with the same name are rejected
+	vars["apps/billing"]["db_password"] = "clash"
+	_, err = readVarRunEnv(mock, "apps/billing")
+	require.ErrorContains(t, err, "are both exported as DB_PASSWORD")
+}
+
+func TestVarRunCommand(t *testing.T) {
+	ci.Parallel(t)
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not found")
+	}
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	for path, items := range map[string]api.SecureVariableItems{
+		"apps/billing":    {"mode": "live"},
+		"apps/billing/db": {"password": "hunter2"},
+		"apps/billing2":   {"ignored": "true"},
+	} {
+		sv := api.NewSecureVariable(path)
+		sv.Items = items
+		_, _, err := client.SecureVariables().Create(sv, nil)
+		require.NoError(t, err)
+	}
+
+	out := filepath.Join(t.TempDir(), "out")
+	ui := cli.NewMockUi()
+	cmd := &VarRunCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, "-prefix=apps/billing/", "--",
+		"sh", "-c", `echo "$MODE $DB_PASSWORD ${IGNORED:-unset}" > ` + out + `; exit 3`})
+	require.Equal(t, 3, code, ui.ErrorWriter.String())
+
+	b, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Equal(t, "live hunter2 unset\n", string(b))
+
+	// Items exported with the same name are rejected
+	sv := api.NewSecureVariable("apps/billing/db_password")
+	sv.Items["x"] = "y"
+	_, _, err = client.SecureVariables().Create(sv, nil)
+	require.NoError(t, err)
+	sv = api.NewSecureVariable("apps/billing/db/password")
+	sv.Items["x"] = "z"
+	_, _, err = client.SecureVariables().Create(sv, nil)
+	require.NoError(t, err)
+
+	ui = cli.NewMockUi()
+	cmd = &VarRunCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-prefix=apps/billing", "--", "true"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "are both exported as DB_PASSWORD_X")
+}
+
+func TestVarRunCommand_Renew(t *testing.T) {
+	ci.Parallel(t)
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not found")
+	}
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	sv := api.NewSecureVariable("apps/web")
+	sv.Items["version"] = "v1"
+	_, _, err := client.SecureVariables().Create(sv, nil)
+	require.NoError(t, err)
+
+	// The command keeps running until it is restarted with the new version
+	out := filepath.Join(t.TempDir(), "out")
+	ui := cli.NewMockUi()
+	cmd := &VarRunCommand{Meta: Meta{Ui: ui}}
+	codeCh := make(chan int, 1)
+	go func() {
+		codeCh <- cmd.Run([]string{"-address=" + url, "-prefix=apps/web", "-renew", "--",
+			"sh", "-c", `echo "$VERSION" >> ` + out + `; [ "$VERSION" = v2 ] || exec sleep 30`})
+	}()
+
+	testutil.WaitForResult(func() (bool, error) {
+		b, err := os.ReadFile(out)
+		return string(b) == "v1\n", err
+	}, func(err error) {
+		require.NoError(t, err, "command did not start")
+	})
+
+	sv.Items["version"] = "v2"
+	_, _, err = client.SecureVariables().Create(sv, nil)
+	require.NoError(t, err)
+
+	select {
+	case code := <-codeCh:
+		require.Zero(t, code, ui.ErrorWriter.String())
+	case <-time.After(20 * time.Second):
+		require.Fail(t, "command was not restarted")
+	}
+
+	b, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Equal(t, "v1\nv2\n", string(b))
+}
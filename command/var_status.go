@@ -0,0 +1,173 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type VarStatusCommand struct {
+	Meta
+}
+
+func (c *VarStatusCommand) Help() string {
+	helpText := `
+Usage: nomad var status [options]
+
+  Display the secure variables replication settings of the namespace set with
+  the -namespace flag, or of the "default" namespace.
+
+  With the -replication flag, display instead the status of the replication of
+  secure variables from each of the other regions to this region. Secure
+  variables are replicated from the regions whose namespaces list this region
+  in their replication targets. The status is tracked by the leader of the
+  region since it was elected.
+
+  If ACLs are enabled, the -replication flag requires a token with the
+  'operator:read' capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Status Options:
+
+  -replication
+    Display the status of the replication of secure variables from the other
+    regions.
+
+  -json
+    Output the status in JSON format.
+
+  -t
+    Format and display the status using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *VarStatusCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-replication": complete.PredictNothing,
+			"-json":        complete.PredictNothing,
+			"-t":           complete.PredictAnything,
+		},
+	)
+}
+
+func (c *VarStatusCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *VarStatusCommand) Synopsis() string {
+	return "Display the status of secure variables replication"
+}
+
+func (c *VarStatusCommand) Name() string { return "var status" }
+
+func (c *VarStatusCommand) Run(args []string) int {
+	var replication, json bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&replication, "replication", false, "")
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments
+	if len(flags.Args()) != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	var out interface{}
+	var formatted string
+	if replication {
+		sources, _, err := client.SecureVariables().ReplicationStatus(nil)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error querying replication status: %s", err))
+			return 1
+		}
+		out = sources
+		formatted = formatVarReplicationStatus(sources)
+	} else {
+		namespace := c.Meta.namespace
+		if namespace == "" {
+			namespace = api.DefaultNamespace
+		}
+		ns, _, err := client.Namespaces().Info(namespace, nil)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error querying namespace: %s", err))
+			return 1
+		}
+		out = ns.SecureVariables
+		formatted = formatVarNamespaceStatus(ns)
+	}
+
+	if json || len(tmpl) > 0 {
+		s, err := Format(json, tmpl, out)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(s)
+		return 0
+	}
+
+	c.Ui.Output(formatted)
+	return 0
+}
+
+// formatVarNamespaceStatus renders the secure variables settings of the
+// namespace.
+func formatVarNamespaceStatus(ns *api.Namespace) string {
+	targets := "<none>"
+	if ns.SecureVariables != nil && len(ns.SecureVariables.ReplicationTargets) > 0 {
+		targets = strings.Join(ns.SecureVariables.ReplicationTargets, ",")
+	}
+	return formatKV([]string{
+		fmt.Sprintf("Namespace|%s", ns.Name),
+		fmt.Sprintf("Replication Targets|%s", targets),
+	})
+}
+
+// formatVarReplicationStatus renders a table of the replication status of
+// each source region.
+func formatVarReplicationStatus(sources []*api.SecureVariablesReplicationStatus) string {
+	if len(sources) == 0 {
+		return "No secure variables replication"
+	}
+
+	rows := make([]string, len(sources)+1)
+	rows[0] = "Region|Index|Variables|Last Replicated|Lag|Error"
+	for i, source := range sources {
+		lastReplicated := "<never>"
+		if !source.LastReplicated.IsZero() {
+			lastReplicated = formatTime(source.LastReplicated)
+		}
+		rows[i+1] = fmt.Sprintf("%s|%d|%d|%s|%s|%s",
+			source.Region,
+			source.Index,
+			source.Variables,
+			lastReplicated,
+			source.Lag,
+			source.Error,
+		)
+	}
+	return formatList(rows)
+}
@@ -0,0 +1,90 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarStatusCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &VarStatusCommand{}
+}
+
+func TestVarStatusCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name      string
+		args      []string
+		expectErr string
+	}{
+		{
+			name:      "args",
+			args:      []string{"foo"},
+			expectErr: "This command takes no arguments",
+		},
+		{
+			name:      "bad address",
+			args:      []string{"-address", "nope", "-replication"},
+			expectErr: "Error querying replication status",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := &VarStatusCommand{Meta: Meta{Ui: ui}}
+			code := cmd.Run(tc.args)
+			require.Equal(t, 1, code)
+			require.Contains(t, ui.ErrorWriter.String(), tc.expectErr)
+		})
+	}
+}
+
+func TestVarStatusCommand(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	_, err := client.Namespaces().Register(&api.Namespace{
+		Name: "apps",
+		SecureVariables: &api.NamespaceSecureVariablesConfig{
+			ReplicationTargets: []string{"east", "west"},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	ui := cli.NewMockUi()
+	cmd := &VarStatusCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, "-namespace=apps"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.Contains(t, out, "apps")
+	require.Contains(t, out, "east,west")
+
+	// A single region has nothing to replicate from
+	ui = cli.NewMockUi()
+	cmd = &VarStatusCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-replication"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Equal(t, "No secure variables replication", strings.TrimSpace(ui.OutputWriter.String()))
+}
+
+func TestVarStatusCommand_formatReplicationStatus(t *testing.T) {
+	ci.Parallel(t)
+
+	out := formatVarReplicationStatus([]*api.SecureVariablesReplicationStatus{
+		{Region: "east", Index: 42, Variables: 3},
+		{Region: "west", Error: "failed"},
+	})
+	lines := strings.Split(out, "\n")
+	require.Len(t, lines, 3)
+	require.Regexp(t, `^Region\s+Index\s+Variables\s+Last Replicated\s+Lag\s+Error$`, lines[0])
+	require.Regexp(t, `^east\s+42\s+3\s+<never>\s+0s\s+<none>$`, lines[1])
+	require.Regexp(t, `^west\s+0\s+0\s+<never>\s+0s\s+failed$`, lines[2])
+}
@@ -0,0 +1,24 @@
+# API keys for the third-party services a workload calls.
+#
+# Tasks can read the secure variables at "nomad/jobs/<job>",
+# "nomad/jobs/<job>/<group>", and "nomad/jobs/<job>/<group>/<task>" from
+# their templates without any additional ACL policy.
+# path = "nomad/jobs/example"
+
+# namespace = "default"
+
+# Metadata is stored unencrypted and is returned when listing secure
+# variables, so it must not contain sensitive material.
+meta {
+  owner     = "team-name"
+  rotate_by = "2006-01-02"
+}
+
+# The items are encrypted and decrypted as a single unit. Name each key after
+# the service it authenticates to, without dots, so that templates can refer
+# to it as {{ .service_name }}.
+items {
+  payments_api_key = "CHANGE_ME"
+  email_api_key    = "CHANGE_ME"
+  maps_api_key     = "CHANGE_ME"
+}
@@ -0,0 +1,26 @@
+# Credentials used by a workload to connect to a database.
+#
+# Tasks can read the secure variables at "nomad/jobs/<job>",
+# "nomad/jobs/<job>/<group>", and "nomad/jobs/<job>/<group>/<task>" from
+# their templates without any additional ACL policy.
+# path = "nomad/jobs/example/cache"
+
+# namespace = "default"
+
+# Metadata is stored unencrypted and is returned when listing secure
+# variables, so it must not contain sensitive material. Use it to record
+# who owns the credentials and when they should be rotated.
+meta {
+  owner       = "team-name"
+  rotate_by   = "2006-01-02"
+  description = "Database credentials for the example job"
+}
+
+# The items are encrypted and decrypted as a single unit.
+items {
+  host     = "db.service.consul"
+  port     = "5432"
+  database = "example"
+  username = "example"
+  password = "CHANGE_ME"
+}
@@ -0,0 +1,149 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarCommand_formatVarOutput(t *testing.T) {
+	ci.Parallel(t)
+
+	sv := &api.SecureVariableMetadata{
+		Namespace:   "default",
+		Path:        "a/b/c",
+		CreateIndex: 10,
+		ModifyIndex: 20,
+	}
+	table := func() string { return "table output" }
+
+	testCases := []struct {
+		name      string
+		format    string
+		tmpl      string
+		expect    string
+		expectErr string
+	}{
+		{
+			name:   "default",
+			expect: "table output",
+		},
+		{
+			name:   "table",
+			format: varOutputTable,
+			expect: "table output",
+		},
+		{
+			name:   "json",
+			format: varOutputJSON,
+			expect: `{
+    "CreateIndex": 10,
+    "CreateTime": 0,
+    "ItemCount": 0,
+    "ModifyIndex": 20,
+    "ModifyTime": 0,
+    "Namespace": "default",
+    "Path": "a/b/c",
+    "Size": 0
+}`,
+		},
+		{
+			name:   "go-template",
+			format: varOutputGoTemplate,
+			tmpl:   "{{.Namespace}}:{{.Path}}@{{.ModifyIndex}}",
+			expect: "default:a/b/c@20",
+		},
+		{
+			name:      "go-template without template",
+			format:    varOutputGoTemplate,
+			expectErr: "-output=go-template requires a template",
+		},
+		{
+			name:      "template without go-template",
+			format:    varOutputJSON,
+			tmpl:      "{{.Path}}",
+			expectErr: "A template can only be used with -output=go-template",
+		},
+		{
+			name:      "unknown format",
+			format:    "yaml",
+			expectErr: `Unsupported output format "yaml"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := formatVarOutput(tc.format, tc.tmpl, sv, table)
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, out)
+		})
+	}
+}
+
+func TestVarCommand_resolveVarOutput(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name      string
+		json      bool
+		output    string
+		tmpl      string
+		expect    string
+		expectErr string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:   "json shorthand",
+			json:   true,
+			expect: varOutputJSON,
+		},
+		{
+			name:   "json shorthand with json output",
+			json:   true,
+			output: varOutputJSON,
+			expect: varOutputJSON,
+		},
+		{
+			name:      "json shorthand with table output",
+			json:      true,
+			output:    varOutputTable,
+			expectErr: "The -json flag can not be combined with -output=table",
+		},
+		{
+			name:   "template shorthand",
+			tmpl:   "{{.Path}}",
+			expect: varOutputGoTemplate,
+		},
+		{
+			name:      "template with table output",
+			output:    varOutputTable,
+			tmpl:      "{{.Path}}",
+			expectErr: "A template can only be used with -output=go-template",
+		},
+		{
+			name:      "unsupported output",
+			output:    "yaml",
+			expectErr: `Unsupported output format "yaml"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := resolveVarOutput(tc.json, tc.output, tc.tmpl)
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, out)
+		})
+	}
+}
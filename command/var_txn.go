@@ -0,0 +1,155 @@
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type VarTxnCommand struct {
+	Meta
+}
+
+func (c *VarTxnCommand) Help() string {
+	helpText := `
+Usage: nomad var txn [options] <@ops-file>
+
+  Txn is used to apply many secure variable operations atomically. Either all
+  the operations are applied, or none are if the check of one of them fails.
+  This allows updating related secure variables, such as a credential key and
+  its secret, without a window where they mismatch.
+
+  The operations are read from the given JSON file, or from stdin if the path
+  is "-". The file contains a list of operations, each made of an "Op" and a
+  secure variable "Var":
+
+      [
+        {"Op": "cas", "Var": {"Path": "creds/key", "ModifyIndex": 42, "Items": {"key": "..."}}},
+        {"Op": "cas", "Var": {"Path": "creds/secret", "ModifyIndex": 42, "Items": {"secret": "..."}}}
+      ]
+
+  The supported operations are "set", "cas", "delete", "delete-cas" and
+  "check-index". The cas, delete-cas and check-index operations check that the
+  ModifyIndex of the secure variable matches the one of the operation, where 0
+  checks that the secure variable does not exist.
+
+  If ACLs are enabled, this command requires a token with the capabilities
+  required by every operation: ` + "`write`" + ` for sets, ` + "`destroy`" + ` for
+  deletes and ` + "`read`" + ` for checks.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Txn Options:
+
+  -json
+    Output the transaction result in JSON format.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *VarTxnCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json": complete.PredictNothing,
+		},
+	)
+}
+
+func (c *VarTxnCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFiles("*.json")
+}
+
+func (c *VarTxnCommand) Synopsis() string {
+	return "Apply secure variable operations atomically"
+}
+
+func (c *VarTxnCommand) Name() string { return "var txn" }
+
+func (c *VarTxnCommand) Run(args []string) int {
+	var jsonOutput bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&jsonOutput, "json", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <@ops-file>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	path := strings.TrimPrefix(args[0], "@")
+
+	var buf []byte
+	var err error
+	if path == "-" {
+		buf, err = io.ReadAll(os.Stdin)
+	} else {
+		buf, err = os.ReadFile(path)
+	}
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading operations file: %s", err))
+		return 1
+	}
+
+	var ops []*api.SecureVariablesTxnOp
+	if err := json.Unmarshal(buf, &ops); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing operations file: %s", err))
+		return 1
+	}
+	if len(ops) == 0 {
+		c.Ui.Error("The operations file must contain at least one operation")
+		return 1
+	}
+	for i, op := range ops {
+		if op == nil || op.Var == nil || op.Var.Path == "" {
+			c.Ui.Error(fmt.Sprintf("Operation %d requires a secure variable path", i))
+			return 1
+		}
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	out, _, err := client.SecureVariables().Txn(ops, nil)
+	if err != nil {
+		var casErr api.ErrCASConflict
+		if errors.As(err, &casErr) {
+			op := ops[out.ConflictIndex]
+			c.Ui.Error(fmt.Sprintf("Transaction not applied: operation %d (%s %q) expected ModifyIndex %d; found %d",
+				out.ConflictIndex, op.Op, op.Var.Path, casErr.CheckIndex, casErr.Conflict.ModifyIndex))
+			return 1
+		}
+		c.Ui.Error(fmt.Sprintf("Error applying secure variable transaction: %s", err))
+		return 1
+	}
+
+	if jsonOutput {
+		s, err := Format(true, "", out)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(s)
+		return 0
+	}
+	c.Ui.Output(fmt.Sprintf("Successfully applied %d secure variable operation(s)", len(ops)))
+	return 0
+}
@@ -0,0 +1,124 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarTxnCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &VarTxnCommand{}
+}
+
+func TestVarTxnCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	writeOps := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	testCases := []struct {
+		name      string
+		args      []string
+		expectErr string
+	}{
+		{
+			name:      "no args",
+			args:      []string{},
+			expectErr: "This command takes one argument",
+		},
+		{
+			name:      "missing file",
+			args:      []string{"@" + filepath.Join(dir, "missing.json")},
+			expectErr: "Error reading operations file",
+		},
+		{
+			name:      "bad json",
+			args:      []string{"@" + writeOps("bad.json", `[{"Op":`)},
+			expectErr: "Error parsing operations file",
+		},
+		{
+			name:      "no ops",
+			args:      []string{"@" + writeOps("empty.json", `[]`)},
+			expectErr: "must contain at least one operation",
+		},
+		{
+			name:      "no path",
+			args:      []string{"@" + writeOps("nopath.json", `[{"Op": "delete", "Var": {}}]`)},
+			expectErr: "Operation 0 requires a secure variable path",
+		},
+		{
+			name:      "bad address",
+			args:      []string{"-address", "nope", "@" + writeOps("ok.json", `[{"Op": "delete", "Var": {"Path": "foo"}}]`)},
+			expectErr: "Error applying secure variable transaction",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := &VarTxnCommand{Meta: Meta{Ui: ui}}
+			code := cmd.Run(tc.args)
+			require.Equal(t, 1, code)
+			require.Contains(t, ui.ErrorWriter.String(), tc.expectErr)
+		})
+	}
+}
+
+func TestVarTxnCommand(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	key, _, err := client.SecureVariables().Create(&api.SecureVariable{
+		Path:  "creds/key",
+		Items: api.SecureVariableItems{"key": "k1"},
+	}, nil)
+	require.NoError(t, err)
+
+	ops := filepath.Join(t.TempDir(), "ops.json")
+	writeOps := func(keyIndex uint64) {
+		content := fmt.Sprintf(`[
+  {"Op": "cas", "Var": {"Path": "creds/key", "ModifyIndex": %d, "Items": {"key": "k2"}}},
+  {"Op": "set", "Var": {"Path": "creds/secret", "Items": {"secret": "s2"}}}
+]`, keyIndex)
+		require.NoError(t, os.WriteFile(ops, []byte(content), 0o600))
+	}
+
+	// A stale index applies none of the operations
+	writeOps(key.ModifyIndex - 1)
+	ui := cli.NewMockUi()
+	cmd := &VarTxnCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, "@" + ops})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(),
+		fmt.Sprintf(`operation 0 (cas "creds/key") expected ModifyIndex %d; found %d`, key.ModifyIndex-1, key.ModifyIndex))
+
+	_, _, err = client.SecureVariables().Read("creds/secret", nil)
+	require.EqualError(t, err, api.ErrVariableNotFound)
+
+	// The current index applies all of them
+	writeOps(key.ModifyIndex)
+	ui = cli.NewMockUi()
+	cmd = &VarTxnCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "@" + ops})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "Successfully applied 2 secure variable operation(s)")
+
+	sv, _, err := client.SecureVariables().Read("creds/key", nil)
+	require.NoError(t, err)
+	require.Equal(t, "k2", sv.Items["key"])
+	sv, _, err = client.SecureVariables().Read("creds/secret", nil)
+	require.NoError(t, err)
+	require.Equal(t, "s2", sv.Items["secret"])
+}
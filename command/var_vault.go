@@ -0,0 +1,133 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	vapi "github.com/hashicorp/vault/api"
+)
+
+// vaultKV reads secrets from a Vault KV secrets engine, hiding the differences
+// between versions 1 and 2 of the engine. Paths given to its methods are
+// relative to the mount of the engine.
+type vaultKV struct {
+	logical *vapi.Logical
+	mount   string
+	version int
+}
+
+// newVaultKV returns a reader for the KV secrets engine mounted at the given
+// Vault path, and the path relative to the mount. The Vault client is
+// configured from the environment, as with the Vault CLI, so VAULT_ADDR and
+// VAULT_TOKEN are used.
+func newVaultKV(path string) (*vaultKV, string, error) {
+	client, err := vapi.NewClient(vapi.DefaultConfig())
+	if err != nil {
+		return nil, "", fmt.Errorf("Error initializing Vault client: %v", err)
+	}
+	return newVaultKVFromClient(client, path)
+}
+
+func newVaultKVFromClient(client *vapi.Client, path string) (*vaultKV, string, error) {
+	path = strings.TrimPrefix(path, "/")
+	kv := &vaultKV{logical: client.Logical(), version: 1}
+
+	// Ask Vault which engine is mounted at the path, as the Vault CLI does.
+	// Older versions of Vault don't support this endpoint and only have
+	// version 1 of the engine.
+	secret, err := kv.logical.Read("sys/internal/ui/mounts/" + path)
+	if err != nil {
+		return nil, "", fmt.Errorf("Error looking up Vault mount of %q: %v", path, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return kv, path, nil
+	}
+
+	if mount, ok := secret.Data["path"].(string); ok {
+		kv.mount = mount
+	}
+	if options, ok := secret.Data["options"].(map[string]interface{}); ok {
+		if version, ok := options["version"].(string); ok && version == "2" {
+			kv.version = 2
+		}
+	}
+	return kv, strings.TrimPrefix(path, kv.mount), nil
+}
+
+// read returns the data of the secret at path, or nil if it doesn't exist.
+func (kv *vaultKV) read(path string) (map[string]interface{}, error) {
+	full := kv.mount + path
+	if kv.version == 2 {
+		full = kv.mount + "data/" + path
+	}
+	secret, err := kv.logical.Read(full)
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, nil
+	}
+	if kv.version == 1 {
+		return secret.Data, nil
+	}
+
+	// Deleted versions of a secret are returned without data
+	data, _ := secret.Data["data"].(map[string]interface{})
+	return data, nil
+}
+
+// walk returns the paths of all the secrets under prefix, sorted.
+func (kv *vaultKV) walk(prefix string) ([]string, error) {
+	full := kv.mount + prefix
+	if kv.version == 2 {
+		full = kv.mount + "metadata/" + prefix
+	}
+	secret, err := kv.logical.List(full)
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, nil
+	}
+	keys, _ := secret.Data["keys"].([]interface{})
+
+	var paths []string
+	for _, raw := range keys {
+		key, ok := raw.(string)
+		if !ok {
+			continue
+		}
+		if !strings.HasSuffix(key, "/") {
+			paths = append(paths, prefix+key)
+			continue
+		}
+		nested, err := kv.walk(prefix + key)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, nested...)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// vaultSecretItems converts the data of a Vault secret to secure variable
+// items. Secure variable items are strings, so values of any other type are
+// encoded as JSON.
+func vaultSecretItems(data map[string]interface{}) (map[string]string, error) {
+	items := make(map[string]string, len(data))
+	for k, v := range data {
+		if s, ok := v.(string); ok {
+			items[k] = s
+			continue
+		}
+		buf, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode value of %q: %v", k, err)
+		}
+		items[k] = string(buf)
+	}
+	return items, nil
+}
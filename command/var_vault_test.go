@@ -0,0 +1,84 @@
+package command
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/testutil"
+	vapi "github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultKV(t *testing.T) {
+	ci.Parallel(t)
+
+	v := testutil.NewTestVault(t)
+	defer v.Stop()
+
+	// The dev server mounts version 2 of the engine at secret/, so mount
+	// version 1 next to it
+	require.NoError(t, v.Client.Sys().Mount("kv1", &vapi.MountInput{
+		Type:    "kv",
+		Options: map[string]string{"version": "1"},
+	}))
+
+	_, err := v.Client.Logical().Write("kv1/apps/web", map[string]interface{}{"user": "v1"})
+	require.NoError(t, err)
+	_, err = v.Client.Logical().Write("kv1/apps/db/creds", map[string]interface{}{"user": "v1"})
+	require.NoError(t, err)
+	_, err = v.Client.Logical().Write("secret/data/apps/web", map[string]interface{}{
+		"data": map[string]interface{}{"user": "v2"},
+	})
+	require.NoError(t, err)
+	_, err = v.Client.Logical().Write("secret/data/apps/db/creds", map[string]interface{}{
+		"data": map[string]interface{}{"user": "v2"},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		mount   string
+		version int
+	}{
+		{name: "v1", mount: "kv1/", version: 1},
+		{name: "v2", mount: "secret/", version: 2},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			kv, path, err := newVaultKVFromClient(v.Client, tc.mount+"apps/web")
+			require.NoError(t, err)
+			require.Equal(t, tc.mount, kv.mount)
+			require.Equal(t, tc.version, kv.version)
+			require.Equal(t, "apps/web", path)
+
+			data, err := kv.read(path)
+			require.NoError(t, err)
+			require.Equal(t, map[string]interface{}{"user": tc.name}, data)
+
+			data, err = kv.read("apps/missing")
+			require.NoError(t, err)
+			require.Nil(t, data)
+
+			paths, err := kv.walk("apps/")
+			require.NoError(t, err)
+			require.Equal(t, []string{"apps/db/creds", "apps/web"}, paths)
+		})
+	}
+}
+
+func TestVaultSecretItems(t *testing.T) {
+	ci.Parallel(t)
+
+	items, err := vaultSecretItems(map[string]interface{}{
+		"user":  "admin",
+		"port":  json.Number("5432"),
+		"hosts": []interface{}{"a", "b"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"user":  "admin",
+		"port":  "5432",
+		"hosts": `["a","b"]`,
+	}, items)
+}
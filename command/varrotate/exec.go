@@ -0,0 +1,55 @@
+package varrotate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// execRotator delegates the rotation to an external command. The command and
+// its arguments are split on whitespace.
+//
+// The command receives the request as a JSON object on its standard input,
+// with the fields of Request, and must write the new items as a JSON object
+// of strings to its standard output. Its standard error is included in the
+// error returned if it fails.
+type execRotator struct {
+	command []string
+}
+
+func newExecRotator(arg string) (Rotator, error) {
+	command := strings.Fields(arg)
+	if len(command) == 0 {
+		return nil, fmt.Errorf("the exec rotator requires a command, as in exec:<command>")
+	}
+	return &execRotator{command: command}, nil
+}
+
+func (r *execRotator) Rotate(ctx context.Context, req *Request) (map[string]string, error) {
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.command[0], r.command[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("rotator command failed: %v: %s", err, msg)
+		}
+		return nil, fmt.Errorf("rotator command failed: %v", err)
+	}
+
+	var items map[string]string
+	if err := json.Unmarshal(stdout.Bytes(), &items); err != nil {
+		return nil, fmt.Errorf("rotator command output is not a JSON object of strings: %v", err)
+	}
+	return items, nil
+}
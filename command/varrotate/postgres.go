@@ -0,0 +1,101 @@
+package varrotate
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// postgresConnParams maps the connection parameters of the postgres rotator
+// to the environment variables of psql.
+var postgresConnParams = map[string]string{
+	"host":     "PGHOST",
+	"port":     "PGPORT",
+	"database": "PGDATABASE",
+	"sslmode":  "PGSSLMODE",
+}
+
+// postgresRotator changes the password of a PostgreSQL role to a random
+// value with psql, which must be installed. It rotates a single item, the
+// password, and connects as the role with its current password, read from
+// the item. The name of the role is read from the "username" item.
+//
+// The connection parameters host, port, database and sslmode are read from
+// the options, then from the items of the same name, and default to the
+// environment of psql. The options also support:
+//
+//   - username: the name of the role, overriding the "username" item.
+//   - length: the number of characters of the password, 32 by default.
+//   - psql: the path of psql, found on the PATH by default.
+type postgresRotator struct{}
+
+func newPostgresRotator(arg string) (Rotator, error) {
+	if arg != "" {
+		return nil, fmt.Errorf("the postgres rotator takes no argument")
+	}
+	return postgresRotator{}, nil
+}
+
+func (postgresRotator) Rotate(ctx context.Context, req *Request) (map[string]string, error) {
+	if len(req.Keys) != 1 {
+		return nil, fmt.Errorf("the postgres rotator rotates a single item, the password")
+	}
+	key := req.Keys[0]
+
+	username := req.Options["username"]
+	if username == "" {
+		username = req.Items["username"]
+	}
+	if username == "" {
+		return nil, fmt.Errorf(`the postgres rotator requires a "username" item or option`)
+	}
+
+	length, _, err := randomOptions(req.Options)
+	if err != nil {
+		return nil, err
+	}
+	password, err := randomString(length, charsetAlphanumeric)
+	if err != nil {
+		return nil, err
+	}
+
+	psql := req.Options["psql"]
+	if psql == "" {
+		psql = "psql"
+	}
+	cmd := exec.CommandContext(ctx, psql, "-X", "-q", "-v", "ON_ERROR_STOP=1",
+		"-v", "username="+username, "-f", "-")
+	cmd.Env = append(os.Environ(), "PGUSER="+username, "PGPASSWORD="+req.Items[key])
+	for param, env := range postgresConnParams {
+		if v := req.Options[param]; v != "" {
+			cmd.Env = append(cmd.Env, env+"="+v)
+		} else if v := req.Items[param]; v != "" {
+			cmd.Env = append(cmd.Env, env+"="+v)
+		}
+	}
+
+	// The password is set on the standard input so that it doesn't show in
+	// the arguments of the process, and the statement is built by the server
+	// with quote_ident and quote_literal. The password is alphanumeric, so
+	// setting it needs no escaping.
+	var stderr bytes.Buffer
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("\\set password %s\n%s\n", password, postgresAlterRole))
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("failed to change the password of role %q: %v: %s", username, err, msg)
+		}
+		return nil, fmt.Errorf("failed to change the password of role %q: %v", username, err)
+	}
+
+	return map[string]string{key: password}, nil
+}
+
+// postgresAlterRole is the psql input changing the password of the role of
+// the username variable to the password variable.
+const postgresAlterRole = `SELECT format('ALTER ROLE %s WITH PASSWORD %s', ` +
+	`quote_ident(:'username'), quote_literal(:'password')) \gexec`
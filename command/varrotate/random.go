@@ -0,0 +1,92 @@
+package varrotate
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+const (
+	// defaultRandomLength is the length of the values generated by the
+	// random rotator without a length option.
+	defaultRandomLength = 32
+
+	charsetAlphanumeric = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+	charsetHex          = "0123456789abcdef"
+	charsetSymbols      = charsetAlphanumeric + "!#$%&()*+,-./:;<=>?@[]^_{|}~"
+)
+
+var randomCharsets = map[string]string{
+	"alphanumeric": charsetAlphanumeric,
+	"hex":          charsetHex,
+	"symbols":      charsetSymbols,
+}
+
+// randomRotator generates random values for the items to rotate. It supports
+// the options:
+//
+//   - length: the number of characters of the values, 32 by default.
+//   - charset: the characters of the values, one of "alphanumeric" (the
+//     default), "hex" or "symbols".
+type randomRotator struct{}
+
+func newRandomRotator(arg string) (Rotator, error) {
+	if arg != "" {
+		return nil, fmt.Errorf("the random rotator takes no argument")
+	}
+	return randomRotator{}, nil
+}
+
+func (randomRotator) Rotate(_ context.Context, req *Request) (map[string]string, error) {
+	length, charset, err := randomOptions(req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make(map[string]string, len(req.Keys))
+	for _, k := range req.Keys {
+		v, err := randomString(length, charset)
+		if err != nil {
+			return nil, err
+		}
+		items[k] = v
+	}
+	return items, nil
+}
+
+// randomOptions parses the options of the random rotator.
+func randomOptions(options map[string]string) (int, string, error) {
+	length := defaultRandomLength
+	if s, ok := options["length"]; ok {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			return 0, "", fmt.Errorf("invalid length option %q: must be a positive integer", s)
+		}
+		length = n
+	}
+
+	charset := charsetAlphanumeric
+	if name, ok := options["charset"]; ok {
+		if charset, ok = randomCharsets[name]; !ok {
+			return 0, "", fmt.Errorf("invalid charset option %q: must be alphanumeric, hex or symbols", name)
+		}
+	}
+	return length, charset, nil
+}
+
+// randomString returns a string of the given length made of characters of
+// the charset picked uniformly at random.
+func randomString(length int, charset string) (string, error) {
+	max := big.NewInt(int64(len(charset)))
+	b := make([]byte, length)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = charset[n.Int64()]
+	}
+	return string(b), nil
+}
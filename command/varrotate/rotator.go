@@ -0,0 +1,115 @@
+// Package varrotate implements the rotators used by the "nomad var rotate"
+// command to generate new credentials for the items of secure variables.
+//
+// The built-in rotators are "random", which generates random values,
+// "exec:<command>", which delegates to an external command, and "postgres",
+// which changes the password of a PostgreSQL role. Other rotators can be made
+// available with Register.
+package varrotate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Request describes the rotation of the items of a secure variable.
+type Request struct {
+	// Namespace and Path identify the secure variable.
+	Namespace string
+	Path      string
+
+	// Keys are the keys of the items to rotate.
+	Keys []string
+
+	// Items are the current items of the secure variable. They are empty if
+	// the secure variable doesn't exist yet.
+	Items map[string]string
+
+	// Options configure the rotator. Each rotator documents the options it
+	// supports.
+	Options map[string]string
+}
+
+// Rotator generates new credentials for the items of a secure variable.
+type Rotator interface {
+	// Rotate returns the new values of the items to rotate. It may return
+	// other items as well, which are written with them. Rotators that change
+	// the credential in an external system must only return once the new
+	// credential is in effect.
+	Rotate(ctx context.Context, req *Request) (map[string]string, error)
+}
+
+// Factory creates a rotator. arg is the part of the rotator's name after the
+// colon, as in "exec:<command>", and is empty if there is none.
+type Factory func(arg string) (Rotator, error)
+
+var (
+	factoriesLock sync.RWMutex
+	factories     = map[string]Factory{
+		"random":   newRandomRotator,
+		"exec":     newExecRotator,
+		"postgres": newPostgresRotator,
+	}
+)
+
+// Register makes a rotator available under the given name. It replaces the
+// rotator previously registered under that name, if any.
+func Register(name string, factory Factory) {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+	factories[name] = factory
+}
+
+// Names returns the sorted names of the available rotators.
+func Names() []string {
+	factoriesLock.RLock()
+	defer factoriesLock.RUnlock()
+
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New returns the rotator of the given spec, which is the name of a rotator
+// optionally followed by a colon and an argument, as in "exec:<command>".
+func New(spec string) (Rotator, error) {
+	name, arg, _ := strings.Cut(spec, ":")
+
+	factoriesLock.RLock()
+	factory, ok := factories[name]
+	factoriesLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown rotator %q, must be one of %s", name, strings.Join(Names(), ", "))
+	}
+	return factory(arg)
+}
+
+// validate checks that the rotator returned a non-empty value for each of
+// the items to rotate.
+func (r *Request) validate(items map[string]string) error {
+	for _, k := range r.Keys {
+		if items[k] == "" {
+			return fmt.Errorf("rotator returned no value for item %q", k)
+		}
+	}
+	return nil
+}
+
+// Rotate runs the rotator for the request, and checks that it returned all
+// the items to rotate.
+func Rotate(ctx context.Context, rotator Rotator, req *Request) (map[string]string, error) {
+	items, err := rotator.Rotate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.validate(items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,185 @@
+package varrotate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+// writeScript writes an executable shell script to a temporary directory and
+// returns its path.
+func writeScript(t *testing.T, name, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts are not supported on Windows")
+	}
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755))
+	return path
+}
+
+func TestNew(t *testing.T) {
+	ci.Parallel(t)
+
+	_, err := New("random")
+	require.NoError(t, err)
+	_, err = New("random:nope")
+	require.EqualError(t, err, "the random rotator takes no argument")
+	_, err = New("exec:/bin/true -v")
+	require.NoError(t, err)
+	_, err = New("exec")
+	require.EqualError(t, err, "the exec rotator requires a command, as in exec:<command>")
+	_, err = New("mysql")
+	require.EqualError(t, err, `unknown rotator "mysql", must be one of exec, postgres, random`)
+}
+
+func TestRandomRotator(t *testing.T) {
+	ci.Parallel(t)
+
+	rotator, err := New("random")
+	require.NoError(t, err)
+
+	items, err := Rotate(context.Background(), rotator, &Request{
+		Keys:    []string{"password", "token"},
+		Options: map[string]string{"length": "20", "charset": "hex"},
+	})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	require.Regexp(t, "^[0-9a-f]{20}$", items["password"])
+	require.Regexp(t, "^[0-9a-f]{20}$", items["token"])
+	require.NotEqual(t, items["password"], items["token"])
+
+	items, err = Rotate(context.Background(), rotator, &Request{Keys: []string{"password"}})
+	require.NoError(t, err)
+	require.Regexp(t, "^[a-zA-Z0-9]{32}$", items["password"])
+
+	_, err = Rotate(context.Background(), rotator, &Request{
+		Keys:    []string{"password"},
+		Options: map[string]string{"length": "0"},
+	})
+	require.EqualError(t, err, `invalid length option "0": must be a positive integer`)
+
+	_, err = Rotate(context.Background(), rotator, &Request{
+		Keys:    []string{"password"},
+		Options: map[string]string{"charset": "emoji"},
+	})
+	require.EqualError(t, err, `invalid charset option "emoji": must be alphanumeric, hex or symbols`)
+}
+
+func TestExecRotator(t *testing.T) {
+	ci.Parallel(t)
+
+	// The script echoes the path of the request it received as the new
+	// password
+	script := writeScript(t, "rotate.sh", `
+read input
+path=$(echo "$input" | sed 's/.*"Path":"\([^"]*\)".*/\1/')
+echo "{\"password\": \"new-$path-$1\"}"
+`)
+	rotator, err := New("exec:" + script + " arg")
+	require.NoError(t, err)
+
+	items, err := Rotate(context.Background(), rotator, &Request{
+		Path:  "apps/db",
+		Keys:  []string{"password"},
+		Items: map[string]string{"password": "old"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"password": "new-apps/db-arg"}, items)
+
+	// Items to rotate must all be returned
+	_, err = Rotate(context.Background(), rotator, &Request{Keys: []string{"token"}})
+	require.EqualError(t, err, `rotator returned no value for item "token"`)
+
+	// Failures include the standard error of the command
+	failing := writeScript(t, "fail.sh", "echo 'no database' >&2\nexit 2\n")
+	rotator, err = New("exec:" + failing)
+	require.NoError(t, err)
+	_, err = Rotate(context.Background(), rotator, &Request{Keys: []string{"password"}})
+	require.EqualError(t, err, "rotator command failed: exit status 2: no database")
+
+	invalid := writeScript(t, "invalid.sh", "echo nope\n")
+	rotator, err = New("exec:" + invalid)
+	require.NoError(t, err)
+	_, err = Rotate(context.Background(), rotator, &Request{Keys: []string{"password"}})
+	require.ErrorContains(t, err, "rotator command output is not a JSON object of strings")
+}
+
+func TestPostgresRotator(t *testing.T) {
+	ci.Parallel(t)
+
+	// The fake psql records its environment and input
+	dir := t.TempDir()
+	psql := writeScript(t, "psql", `
+env | grep '^PG' | sort > `+filepath.Join(dir, "env")+`
+echo "$@" > `+filepath.Join(dir, "args")+`
+cat > `+filepath.Join(dir, "input")+`
+`)
+	rotator, err := New("postgres")
+	require.NoError(t, err)
+
+	items, err := Rotate(context.Background(), rotator, &Request{
+		Keys: []string{"password"},
+		Items: map[string]string{
+			"username": `app"user`,
+			"password": "old",
+			"host":     "db.example.com",
+		},
+		Options: map[string]string{"psql": psql, "port": "6543", "length": "24"},
+	})
+	require.NoError(t, err)
+	require.Regexp(t, "^[a-zA-Z0-9]{24}$", items["password"])
+
+	env, err := os.ReadFile(filepath.Join(dir, "env"))
+	require.NoError(t, err)
+	for _, kv := range []string{"PGHOST=db.example.com", "PGPORT=6543", "PGPASSWORD=old", `PGUSER=app"user`} {
+		require.Contains(t, strings.Split(string(env), "\n"), kv)
+	}
+
+	args, err := os.ReadFile(filepath.Join(dir, "args"))
+	require.NoError(t, err)
+	require.Contains(t, string(args), `-v username=app"user`)
+
+	input, err := os.ReadFile(filepath.Join(dir, "input"))
+	require.NoError(t, err)
+	require.Equal(t, `\set password `+items["password"]+"\n"+
+		`SELECT format('ALTER ROLE %s WITH PASSWORD %s', quote_ident(:'username'), quote_literal(:'password')) \gexec`+"\n",
+		string(input))
+
+	_, err = Rotate(context.Background(), rotator, &Request{
+		Keys:    []string{"password"},
+		Options: map[string]string{"psql": psql},
+	})
+	require.EqualError(t, err, `the postgres rotator requires a "username" item or option`)
+}
+
+type staticRotator map[string]string
+
+func (r staticRotator) Rotate(context.Context, *Request) (map[string]string, error) {
+	return r, nil
+}
+
+func TestRegister(t *testing.T) {
+	// Not parallel, as it changes the registered rotators
+	Register("static", func(arg string) (Rotator, error) {
+		return staticRotator{"password": arg}, nil
+	})
+	defer func() {
+		factoriesLock.Lock()
+		delete(factories, "static")
+		factoriesLock.Unlock()
+	}()
+
+	require.Contains(t, Names(), "static")
+	rotator, err := New("static:s3cr3t")
+	require.NoError(t, err)
+	items, err := Rotate(context.Background(), rotator, &Request{Keys: []string{"password"}})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"password": "s3cr3t"}, items)
+}
@@ -193,7 +193,7 @@ func csiFormatSnapshots(snapshots []*api.CSISnapshot, verbose bool) string {
 			v.ID,
 			limit(v.ExternalSourceVolumeID, length),
 			humanize.IBytes(uint64(v.SizeBytes)),
-			formatUnixNanoTime(v.CreateTime*1e9), // seconds to nanoseconds
+			formatTime(v.CreateTime),
 			v.IsReady,
 		))
 	}
@@ -0,0 +1,210 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-glint"
+)
+
+const (
+	// defaultWatchInterval is the minimum time between two refreshes of the
+	// output of a command run with -watch, unless an interval is given.
+	defaultWatchInterval = 2 * time.Second
+
+	// watchWaitTime is the maximum duration of the blocking queries used to
+	// detect changes.
+	watchWaitTime = 5 * time.Minute
+)
+
+// watchFlag is the value of the -watch flag of the status commands. It may be
+// set as a boolean, or to the minimum interval between two refreshes.
+type watchFlag struct {
+	enabled  bool
+	interval time.Duration
+}
+
+func (w *watchFlag) IsBoolFlag() bool { return true }
+
+func (w *watchFlag) String() string {
+	if w == nil || !w.enabled {
+		return "false"
+	}
+	return w.refreshInterval().String()
+}
+
+func (w *watchFlag) Set(v string) error {
+	if enabled, err := strconv.ParseBool(v); err == nil {
+		w.enabled = enabled
+		return nil
+	}
+
+	interval, err := time.ParseDuration(v)
+	if err != nil {
+		return fmt.Errorf("must be a boolean or a duration: %q", v)
+	}
+	if interval <= 0 {
+		return fmt.Errorf("must be a positive duration: %q", v)
+	}
+	w.enabled = true
+	w.interval = interval
+	return nil
+}
+
+func (w *watchFlag) refreshInterval() time.Duration {
+	if w.interval == 0 {
+		return defaultWatchInterval
+	}
+	return w.interval
+}
+
+// watchQuery runs a blocking query with the given options and returns the
+// index of its result.
+type watchQuery func(q *api.QueryOptions) (uint64, error)
+
+// metaIndex returns the index of a query result, or zero if the query failed.
+func metaIndex(meta *api.QueryMeta) uint64 {
+	if meta == nil {
+		return 0
+	}
+	return meta.LastIndex
+}
+
+// watch renders the output of a command each time the result of one of the
+// blocking queries changes, until it is interrupted. The output is only
+// displayed when it differs from the previous one. On a terminal it is
+// redrawn in place, unless jsonLines is set in which case each change is
+// written as a single line of JSON.
+func (m *Meta) watch(w *watchFlag, jsonLines bool, queries []watchQuery, render func() int) int {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	var d *glint.Document
+	if !jsonLines && isStdoutTerminal() {
+		d = glint.New()
+		defer d.Close()
+	}
+
+	indexes := make([]uint64, len(queries))
+	var last string
+	for {
+		if err := waitForChange(ctx, queries, indexes); err != nil {
+			if ctx.Err() != nil {
+				return 0
+			}
+			m.Ui.Error(fmt.Sprintf("Error watching for changes: %s", err))
+			return 1
+		}
+		rendered := time.Now()
+
+		out, code := m.captureOutput(render)
+		if code != 0 {
+			return code
+		}
+		if jsonLines {
+			out = compactJSON(out)
+		}
+
+		if out != last {
+			last = out
+			if d != nil {
+				d.Set(glint.Text(out))
+				d.RenderFrame()
+			} else {
+				m.Ui.Output(out)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0
+		case <-time.After(w.refreshInterval() - time.Since(rendered)):
+		}
+	}
+}
+
+// waitForChange runs the queries concurrently and returns as soon as the
+// index of one of them changes, updating indexes in place. Queries with an
+// index of zero have not run yet, so waitForChange waits for all of them to
+// return instead.
+func waitForChange(ctx context.Context, queries []watchQuery, indexes []uint64) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		i     int
+		index uint64
+		err   error
+	}
+	results := make(chan result, len(queries))
+	for i, query := range queries {
+		go func(i int, query watchQuery) {
+			q := &api.QueryOptions{
+				AllowStale: true,
+				WaitIndex:  indexes[i],
+				WaitTime:   watchWaitTime,
+			}
+			index, err := query(q.WithContext(ctx))
+			results <- result{i: i, index: index, err: err}
+		}(i, query)
+	}
+
+	for range queries {
+		r := <-results
+		if r.err != nil {
+			return r.err
+		}
+		previous := indexes[r.i]
+		indexes[r.i] = r.index
+		if previous != 0 && previous != r.index {
+			return nil
+		}
+	}
+	return nil
+}
+
+// captureOutput runs render with the output of the Ui buffered, and returns
+// what was written. Errors and warnings are still written as they occur.
+func (m *Meta) captureOutput(render func() int) (string, int) {
+	ui := &bufferedUi{Ui: m.Ui}
+	m.Ui = ui
+	defer func() { m.Ui = ui.Ui }()
+
+	code := render()
+	return strings.TrimSuffix(ui.buf.String(), "\n"), code
+}
+
+// bufferedUi is a cli.Ui which buffers its output and info messages.
+type bufferedUi struct {
+	cli.Ui
+	buf bytes.Buffer
+}
+
+func (u *bufferedUi) Output(s string) {
+	u.buf.WriteString(s)
+	u.buf.WriteString("\n")
+}
+
+func (u *bufferedUi) Info(s string) {
+	u.Output(s)
+}
+
+// compactJSON returns s on a single line if it is JSON, and unchanged
+// otherwise.
+func compactJSON(s string) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, []byte(s)); err != nil {
+		return s
+	}
+	return buf.String()
+}
@@ -0,0 +1,127 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchFlag(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		args     []string
+		enabled  bool
+		interval time.Duration
+		err      bool
+	}{
+		{args: []string{}, enabled: false, interval: defaultWatchInterval},
+		{args: []string{"-watch"}, enabled: true, interval: defaultWatchInterval},
+		{args: []string{"-watch=false"}, enabled: false, interval: defaultWatchInterval},
+		{args: []string{"-watch=10s"}, enabled: true, interval: 10 * time.Second},
+		{args: []string{"-watch=0s"}, err: true},
+		{args: []string{"-watch=often"}, err: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprint(tc.args), func(t *testing.T) {
+			var w watchFlag
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			flags.Usage = func() {}
+			flags.Var(&w, "watch", "")
+
+			err := flags.Parse(tc.args)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.enabled, w.enabled)
+			require.Equal(t, tc.interval, w.refreshInterval())
+		})
+	}
+}
+
+func TestWaitForChange(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	changed := make(chan uint64, 1)
+	queries := []watchQuery{
+		// Never changes until the query is cancelled
+		func(q *api.QueryOptions) (uint64, error) {
+			if q.WaitIndex == 0 {
+				return 5, nil
+			}
+			<-q.Context().Done()
+			return q.WaitIndex, q.Context().Err()
+		},
+		// Changes when told to
+		func(q *api.QueryOptions) (uint64, error) {
+			if q.WaitIndex == 0 {
+				return 10, nil
+			}
+			return <-changed, nil
+		},
+	}
+
+	// The first call returns once all queries have run
+	indexes := make([]uint64, len(queries))
+	require.NoError(t, waitForChange(ctx, queries, indexes))
+	require.Equal(t, []uint64{5, 10}, indexes)
+
+	// Later calls return as soon as one query reports a change
+	changed <- 11
+	require.NoError(t, waitForChange(ctx, queries, indexes))
+	require.Equal(t, []uint64{5, 11}, indexes)
+
+	// Errors are returned
+	queries = append(queries, func(*api.QueryOptions) (uint64, error) {
+		return 0, errors.New("connection refused")
+	})
+	indexes = append(indexes, 1)
+	require.EqualError(t, waitForChange(ctx, queries, indexes), "connection refused")
+}
+
+func TestMeta_watch(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	m := &Meta{Ui: ui}
+
+	var index uint64
+	query := func(q *api.QueryOptions) (uint64, error) {
+		index++
+		return index, nil
+	}
+
+	// The same output is only written once, and JSON is written on a single
+	// line.
+	outputs := []string{"{\n  \"a\": 1\n}", "{\n  \"a\": 1\n}", "{\n  \"a\": 2\n}"}
+	var renders int
+	render := func() int {
+		if renders == len(outputs) {
+			m.Ui.Error("done")
+			return 1
+		}
+		m.Ui.Output(outputs[renders])
+		renders++
+		return 0
+	}
+
+	w := &watchFlag{enabled: true, interval: time.Millisecond}
+	require.Equal(t, 1, m.watch(w, true, []watchQuery{query}, render))
+	require.Equal(t, "{\"a\":1}\n{\"a\":2}\n", ui.OutputWriter.String())
+	require.Equal(t, "done\n", ui.ErrorWriter.String())
+	require.Same(t, ui, m.Ui)
+}
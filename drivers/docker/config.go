@@ -254,6 +254,8 @@ var (
 			"selinuxlabel": hclspec.NewAttr("selinuxlabel", "string", false),
 		})), hclspec.NewLiteral("{ enabled = false }")),
 		"allow_privileged": hclspec.NewAttr("allow_privileged", "bool", false),
+		// require tasks to run in a user namespace remapped by the daemon
+		"require_user_namespace": hclspec.NewAttr("require_user_namespace", "bool", false),
 		"allow_caps": hclspec.NewDefault(
 			hclspec.NewAttr("allow_caps", "list(string)", false),
 			hclspec.NewLiteral(capabilities.HCLSpecLiteral),
@@ -630,6 +632,7 @@ type DriverConfig struct {
 	GC                            GCConfig      `codec:"gc"`
 	Volumes                       VolumeConfig  `codec:"volumes"`
 	AllowPrivileged               bool          `codec:"allow_privileged"`
+	RequireUserNamespace          bool          `codec:"require_user_namespace"`
 	AllowCaps                     []string      `codec:"allow_caps"`
 	GPURuntimeName                string        `codec:"nvidia_runtime"`
 	InfraImage                    string        `codec:"infra_image"`
@@ -113,6 +113,10 @@ type Driver struct {
 	// gpuRuntime indicates nvidia-docker runtime availability
 	gpuRuntime bool
 
+	// userNamespaces indicates the daemon runs containers in remapped user
+	// namespaces
+	userNamespaces bool
+
 	// A tri-state boolean to know if the fingerprinting has happened and
 	// whether it has been successful
 	fingerprintSuccess *bool
@@ -944,6 +948,17 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 	}
 	hostConfig.Privileged = driverConfig.Privileged
 
+	// enforce the user namespace policy; Docker only supports remapping users
+	// for the whole daemon, so tasks may only opt out of it
+	if d.config.RequireUserNamespace {
+		if !d.userNamespaces {
+			return c, fmt.Errorf(`Docker user namespaces are required on this Nomad agent but the Docker daemon does not remap users`)
+		}
+		if driverConfig.UsernsMode == "host" {
+			return c, fmt.Errorf(`Docker userns_mode "host" is disabled on this Nomad agent`)
+		}
+	}
+
 	// set add/drop capabilities
 	if hostConfig.CapAdd, hostConfig.CapDrop, err = capabilities.Delta(
 		capabilities.DockerDefaults(), d.config.AllowCaps, driverConfig.CapAdd, driverConfig.CapDrop,
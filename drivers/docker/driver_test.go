@@ -1004,6 +1004,31 @@ func TestDockerDriver_CreateContainerConfig(t *testing.T) {
 	require.Equal(t, containerName, c.Name)
 }
 
+func TestDockerDriver_CreateContainerConfig_RequireUserNamespace(t *testing.T) {
+	ci.Parallel(t)
+
+	task, cfg, ports := dockerTask(t)
+	defer freeport.Return(ports)
+	require.NoError(t, task.EncodeConcreteDriverConfig(cfg))
+
+	dh := dockerDriverHarness(t, nil)
+	driver := dh.Impl().(*Driver)
+	driver.config.RequireUserNamespace = true
+
+	// Should error if the daemon does not remap users
+	_, err := driver.createContainerConfig(task, cfg, "org/repo:0.1")
+	require.EqualError(t, err, "Docker user namespaces are required on this Nomad agent but the Docker daemon does not remap users")
+
+	driver.userNamespaces = true
+	_, err = driver.createContainerConfig(task, cfg, "org/repo:0.1")
+	require.NoError(t, err)
+
+	// Should error if the task opts out of the daemon's user namespace
+	cfg.UsernsMode = "host"
+	_, err = driver.createContainerConfig(task, cfg, "org/repo:0.1")
+	require.EqualError(t, err, `Docker userns_mode "host" is disabled on this Nomad agent`)
+}
+
 func TestDockerDriver_CreateContainerConfig_RuntimeConflict(t *testing.T) {
 	ci.Parallel(t)
 
@@ -171,6 +171,9 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 			strings.Join(runtimeNames, ","))
 		fp.Attributes["driver.docker.os_type"] = pstructs.NewStringAttribute(dockerInfo.OSType)
 
+		d.userNamespaces = daemonRemapsUsers(dockerInfo.SecurityOptions)
+		fp.Attributes["driver.docker.user_namespaces"] = pstructs.NewBoolAttribute(d.userNamespaces)
+
 		// If this situations arises, we are running in Windows 10 with Linux Containers enabled via VM
 		if runtime.GOOS == "windows" && dockerInfo.OSType == "linux" {
 			if d.fingerprintSuccessful() {
@@ -189,3 +192,17 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 
 	return fp
 }
+
+// daemonRemapsUsers returns whether the Docker daemon runs containers in
+// user namespaces, either because userns-remap is enabled or because the
+// daemon itself is running rootless.
+func daemonRemapsUsers(securityOptions []string) bool {
+	for _, option := range securityOptions {
+		for _, kv := range strings.Split(option, ",") {
+			if kv == "name=userns" || kv == "name=rootless" {
+				return true
+			}
+		}
+	}
+	return false
+}
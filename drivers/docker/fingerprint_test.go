@@ -27,3 +27,12 @@ func TestDockerDriver_FingerprintHealth(t *testing.T) {
 	fp := d.buildFingerprint()
 	require.Equal(t, drivers.HealthStateHealthy, fp.Health)
 }
+
+func TestDockerDriver_daemonRemapsUsers(t *testing.T) {
+	ci.Parallel(t)
+
+	require.False(t, daemonRemapsUsers(nil))
+	require.False(t, daemonRemapsUsers([]string{"name=seccomp,profile=default", "name=cgroupns"}))
+	require.True(t, daemonRemapsUsers([]string{"name=seccomp,profile=default", "name=userns"}))
+	require.True(t, daemonRemapsUsers([]string{"name=seccomp,profile=default", "name=rootless"}))
+}
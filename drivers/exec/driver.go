@@ -6,16 +6,19 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/hashicorp/consul-template/signals"
 	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocdir"
 	"github.com/hashicorp/nomad/client/lib/cgutil"
 	"github.com/hashicorp/nomad/drivers/shared/capabilities"
 	"github.com/hashicorp/nomad/drivers/shared/eventer"
 	"github.com/hashicorp/nomad/drivers/shared/executor"
 	"github.com/hashicorp/nomad/drivers/shared/resolvconf"
+	"github.com/hashicorp/nomad/drivers/shared/userns"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/plugins/base"
@@ -78,17 +81,34 @@ var (
 			hclspec.NewAttr("allow_caps", "list(string)", false),
 			hclspec.NewLiteral(capabilities.HCLSpecLiteral),
 		),
+		"require_user_namespace": hclspec.NewDefault(
+			hclspec.NewAttr("require_user_namespace", "bool", false),
+			hclspec.NewLiteral("false"),
+		),
+		"user_namespace_min_host_id": hclspec.NewDefault(
+			hclspec.NewAttr("user_namespace_min_host_id", "number", false),
+			hclspec.NewLiteral(strconv.Itoa(userns.DefaultMinHostID)),
+		),
+		"user_namespace_max_host_id": hclspec.NewDefault(
+			hclspec.NewAttr("user_namespace_max_host_id", "number", false),
+			hclspec.NewLiteral(strconv.Itoa(userns.DefaultMaxHostID)),
+		),
+		"user_namespace_size": hclspec.NewDefault(
+			hclspec.NewAttr("user_namespace_size", "number", false),
+			hclspec.NewLiteral(strconv.Itoa(userns.DefaultSize)),
+		),
 	})
 
 	// taskConfigSpec is the hcl specification for the driver config section of
 	// a task within a job. It is returned in the TaskConfigSchema RPC
 	taskConfigSpec = hclspec.NewObject(map[string]*hclspec.Spec{
-		"command":  hclspec.NewAttr("command", "string", true),
-		"args":     hclspec.NewAttr("args", "list(string)", false),
-		"pid_mode": hclspec.NewAttr("pid_mode", "string", false),
-		"ipc_mode": hclspec.NewAttr("ipc_mode", "string", false),
-		"cap_add":  hclspec.NewAttr("cap_add", "list(string)", false),
-		"cap_drop": hclspec.NewAttr("cap_drop", "list(string)", false),
+		"command":        hclspec.NewAttr("command", "string", true),
+		"args":           hclspec.NewAttr("args", "list(string)", false),
+		"pid_mode":       hclspec.NewAttr("pid_mode", "string", false),
+		"ipc_mode":       hclspec.NewAttr("ipc_mode", "string", false),
+		"cap_add":        hclspec.NewAttr("cap_add", "list(string)", false),
+		"cap_drop":       hclspec.NewAttr("cap_drop", "list(string)", false),
+		"user_namespace": hclspec.NewAttr("user_namespace", "bool", false),
 	})
 
 	// driverCapabilities represents the RPC response for what features are
@@ -132,6 +152,10 @@ type Driver struct {
 	// whether it has been successful
 	fingerprintSuccess *bool
 	fingerprintLock    sync.Mutex
+
+	// userNamespaces assigns the host ID ranges of tasks that run in user
+	// namespaces
+	userNamespaces *userns.Pool
 }
 
 // Config is the driver configuration set by the SetConfig RPC call
@@ -151,6 +175,17 @@ type Config struct {
 	// AllowCaps configures which Linux Capabilities are enabled for tasks
 	// running on this node.
 	AllowCaps []string `codec:"allow_caps"`
+
+	// RequireUserNamespace rejects tasks that do not configure a
+	// user_namespace block, so that no task runs as root on the host.
+	RequireUserNamespace bool `codec:"require_user_namespace"`
+
+	// UserNamespaceMinHostID and UserNamespaceMaxHostID bound the host
+	// UIDs and GIDs that tasks' user namespaces are mapped onto. Each
+	// allocation is assigned a range of UserNamespaceSize IDs of its own.
+	UserNamespaceMinHostID uint32 `codec:"user_namespace_min_host_id"`
+	UserNamespaceMaxHostID uint32 `codec:"user_namespace_max_host_id"`
+	UserNamespaceSize      uint32 `codec:"user_namespace_size"`
 }
 
 func (c *Config) validate() error {
@@ -171,9 +206,30 @@ func (c *Config) validate() error {
 		return fmt.Errorf("allow_caps configured with capabilities not supported by system: %s", badCaps)
 	}
 
+	if c.RequireUserNamespace && !userns.Supported() {
+		return fmt.Errorf("require_user_namespace is set but user namespaces are not supported by system")
+	}
+
+	pool, err := c.userNamespacePool()
+	if err != nil {
+		return fmt.Errorf("invalid user namespace host ID range: %v", err)
+	}
+	if c.RequireUserNamespace && pool == nil {
+		return fmt.Errorf("require_user_namespace is set but user_namespace_size is 0")
+	}
+
 	return nil
 }
 
+// userNamespacePool returns a pool of the host ID ranges configured for
+// tasks' user namespaces, or nil if no ranges are configured.
+func (c *Config) userNamespacePool() (*userns.Pool, error) {
+	if c.UserNamespaceSize == 0 {
+		return nil, nil
+	}
+	return userns.NewPool(c.UserNamespaceMinHostID, c.UserNamespaceMaxHostID, c.UserNamespaceSize)
+}
+
 // TaskConfig is the driver configuration of a task within a job
 type TaskConfig struct {
 	// Command is the thing to exec.
@@ -195,6 +251,10 @@ type TaskConfig struct {
 
 	// CapDrop is a set of linux capabilities to disable.
 	CapDrop []string `codec:"cap_drop"`
+
+	// UserNamespace maps the task's users onto a range of unprivileged host
+	// IDs assigned by the driver, if set.
+	UserNamespace bool `codec:"user_namespace"`
 }
 
 func (tc *TaskConfig) validate() error {
@@ -223,6 +283,72 @@ func (tc *TaskConfig) validate() error {
 	return nil
 }
 
+// userNamespace checks the task's user_namespace option against the driver's
+// policy and returns the mapping to hand to the executor, if any. The host ID
+// range is assigned from the driver's pool and must be released with
+// releaseUserNamespace.
+func (d *Driver) userNamespace(cfg *drivers.TaskConfig, tc *TaskConfig) (*executor.UserNamespace, error) {
+	if !tc.UserNamespace {
+		if d.config.RequireUserNamespace {
+			return nil, fmt.Errorf("user_namespace is required by the exec driver configuration")
+		}
+		return nil, nil
+	}
+	if !userns.Supported() {
+		return nil, fmt.Errorf("user_namespace configured but user namespaces are not supported by system")
+	}
+	if d.userNamespaces == nil {
+		return nil, fmt.Errorf("user_namespace configured but the exec driver has no user namespace host IDs to assign")
+	}
+
+	hostID, err := d.userNamespaces.Acquire(cfg.AllocID, cfg.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign user namespace: %v", err)
+	}
+	return &executor.UserNamespace{
+		HostUID: hostID,
+		HostGID: hostID,
+		Size:    d.userNamespaces.Size(),
+	}, nil
+}
+
+// releaseUserNamespace returns the task's host ID range to the pool, once no
+// other task of the allocation uses it.
+func (d *Driver) releaseUserNamespace(cfg *drivers.TaskConfig) {
+	if d.userNamespaces != nil {
+		d.userNamespaces.Release(cfg.AllocID, cfg.ID)
+	}
+}
+
+// chownTaskDirs hands the task's directories to root in its user namespace,
+// so that the task can use them as it would without one. Files already in
+// local/ and secrets/, such as rendered templates, keep their owners relative
+// to the namespace. The shared alloc directories are only chowned themselves,
+// because other tasks of the allocation may have written to them.
+func chownTaskDirs(taskDir *allocdir.TaskDir, ns *executor.UserNamespace) error {
+	recursive := []string{
+		taskDir.LocalDir,
+		taskDir.SecretsDir,
+		filepath.Join(taskDir.Dir, allocdir.TmpDirName),
+	}
+	for _, dir := range recursive {
+		if err := userns.ShiftOwner(dir, ns.HostUID, ns.HostGID, ns.Size, true); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	shallow := []string{
+		taskDir.Dir,
+		filepath.Join(taskDir.SharedAllocDir, allocdir.SharedDataDir),
+		filepath.Join(taskDir.SharedAllocDir, allocdir.TmpDirName),
+	}
+	for _, dir := range shallow {
+		if err := userns.ShiftOwner(dir, ns.HostUID, ns.HostGID, ns.Size, false); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
 // TaskState is the state which is encoded in the handle returned in
 // StartTask. This information is needed to rebuild the task state and handler
 // during recovery.
@@ -231,6 +357,10 @@ type TaskState struct {
 	TaskConfig     *drivers.TaskConfig
 	Pid            int
 	StartedAt      time.Time
+
+	// UserNamespace is the host ID range assigned to the task, if any, so
+	// that it can be claimed again when the task is recovered
+	UserNamespace *executor.UserNamespace
 }
 
 // NewExecDriver returns a new DrivePlugin implementation
@@ -285,6 +415,16 @@ func (d *Driver) SetConfig(cfg *base.Config) error {
 	if err := config.validate(); err != nil {
 		return err
 	}
+
+	// keep the ranges assigned to running tasks unless the pool changed
+	if config.UserNamespaceMinHostID != d.config.UserNamespaceMinHostID ||
+		config.UserNamespaceMaxHostID != d.config.UserNamespaceMaxHostID || config.UserNamespaceSize != d.config.UserNamespaceSize {
+		pool, err := config.userNamespacePool()
+		if err != nil {
+			return err
+		}
+		d.userNamespaces = pool
+	}
 	d.config = config
 
 	if cfg != nil && cfg.AgentConfig != nil {
@@ -366,6 +506,7 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 	}
 
 	fp.Attributes["driver.exec"] = pstructs.NewBoolAttribute(true)
+	fp.Attributes["driver.exec.user_namespaces"] = pstructs.NewBoolAttribute(userns.Supported())
 	d.setFingerprintSuccess()
 	return fp
 }
@@ -405,6 +546,12 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 		return fmt.Errorf("failed to reattach to executor: %v", err)
 	}
 
+	if ns := taskState.UserNamespace; ns != nil && d.userNamespaces != nil {
+		if err := d.userNamespaces.Claim(handle.Config.AllocID, handle.Config.ID, ns.HostUID); err != nil {
+			d.logger.Warn("failed to claim user namespace of recovered task", "error", err, "task_id", handle.Config.ID)
+		}
+	}
+
 	h := &taskHandle{
 		exec:         exec,
 		pid:          taskState.Pid,
@@ -475,6 +622,17 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 	}
 	d.logger.Debug("task capabilities", "capabilities", caps)
 
+	userNamespace, err := d.userNamespace(cfg, &driverConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	if userNamespace != nil {
+		if err := chownTaskDirs(cfg.TaskDir(), userNamespace); err != nil {
+			d.releaseUserNamespace(cfg)
+			return nil, nil, fmt.Errorf("failed to chown task directories for user namespace: %v", err)
+		}
+	}
+
 	execCmd := &executor.ExecCommand{
 		Cmd:              driverConfig.Command,
 		Args:             driverConfig.Args,
@@ -492,11 +650,13 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		ModePID:          executor.IsolationMode(d.config.DefaultModePID, driverConfig.ModePID),
 		ModeIPC:          executor.IsolationMode(d.config.DefaultModeIPC, driverConfig.ModeIPC),
 		Capabilities:     caps,
+		UserNamespace:    userNamespace,
 	}
 
 	ps, err := exec.Launch(execCmd)
 	if err != nil {
 		pluginClient.Kill()
+		d.releaseUserNamespace(cfg)
 		return nil, nil, fmt.Errorf("failed to launch command with executor: %v", err)
 	}
 
@@ -515,12 +675,14 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		Pid:            ps.Pid,
 		TaskConfig:     cfg,
 		StartedAt:      h.startedAt,
+		UserNamespace:  userNamespace,
 	}
 
 	if err := handle.SetDriverState(&driverState); err != nil {
 		d.logger.Error("failed to start task, error setting driver state", "error", err)
 		_ = exec.Shutdown("", 0)
 		pluginClient.Kill()
+		d.releaseUserNamespace(cfg)
 		return nil, nil, fmt.Errorf("failed to set driver state: %v", err)
 	}
 
@@ -621,6 +783,7 @@ func (d *Driver) DestroyTask(taskID string, force bool) error {
 	// workaround for the case where DestroyTask was issued on task restart
 	d.resetCgroup(handle)
 
+	d.releaseUserNamespace(handle.taskConfig)
 	d.tasks.Delete(taskID)
 	return nil
 }
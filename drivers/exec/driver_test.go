@@ -17,9 +17,11 @@ import (
 	"time"
 
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocdir"
 	"github.com/hashicorp/nomad/client/lib/cgutil"
 	ctestutils "github.com/hashicorp/nomad/client/testutil"
 	"github.com/hashicorp/nomad/drivers/shared/executor"
+	"github.com/hashicorp/nomad/drivers/shared/userns"
 	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/helper/testtask"
@@ -719,11 +721,14 @@ func TestConfig_ParseAllHCL(t *testing.T) {
 config {
   command = "/bin/bash"
   args = ["-c", "echo hello"]
+
+  user_namespace = true
 }`
 
 	expected := &TaskConfig{
-		Command: "/bin/bash",
-		Args:    []string{"-c", "echo hello"},
+		Command:       "/bin/bash",
+		Args:          []string{"-c", "echo hello"},
+		UserNamespace: true,
 	}
 
 	var tc *TaskConfig
@@ -873,3 +878,117 @@ func TestDriver_TaskConfig_validate(t *testing.T) {
 		}
 	})
 }
+
+func TestDriver_userNamespace(t *testing.T) {
+	ci.Parallel(t)
+
+	newDriver := func(config Config) *Driver {
+		d := NewExecDriver(context.Background(), testlog.HCLogger(t)).(*Driver)
+		var data []byte
+		require.NoError(t, basePlug.MsgPackEncode(&data, config))
+		require.NoError(t, d.SetConfig(&basePlug.Config{PluginConfig: data}))
+		return d
+	}
+	config := Config{
+		DefaultModePID:         executor.IsolationModePrivate,
+		DefaultModeIPC:         executor.IsolationModePrivate,
+		UserNamespaceMinHostID: 100000,
+		UserNamespaceMaxHostID: 100000 + 2*65536,
+		UserNamespaceSize:      65536,
+	}
+	task := func(allocID, name string) *drivers.TaskConfig {
+		return &drivers.TaskConfig{ID: allocID + "/" + name, AllocID: allocID, Name: name}
+	}
+
+	t.Run("unset", func(t *testing.T) {
+		ns, err := newDriver(config).userNamespace(task("a", "web"), &TaskConfig{})
+		require.NoError(t, err)
+		require.Nil(t, ns)
+	})
+
+	t.Run("required", func(t *testing.T) {
+		required := config
+		required.RequireUserNamespace = true
+		if !userns.Supported() {
+			required.UserNamespaceSize = 0
+			require.Error(t, required.validate())
+			return
+		}
+		_, err := newDriver(required).userNamespace(task("a", "web"), &TaskConfig{})
+		require.EqualError(t, err, "user_namespace is required by the exec driver configuration")
+	})
+
+	if !userns.Supported() {
+		t.Skip("user namespaces not supported by system")
+	}
+
+	t.Run("not configured", func(t *testing.T) {
+		unset := config
+		unset.UserNamespaceSize = 0
+		_, err := newDriver(unset).userNamespace(task("a", "web"), &TaskConfig{UserNamespace: true})
+		require.ErrorContains(t, err, "no user namespace host IDs to assign")
+	})
+
+	t.Run("assigned by driver", func(t *testing.T) {
+		d := newDriver(config)
+		web, err := d.userNamespace(task("a", "web"), &TaskConfig{UserNamespace: true})
+		require.NoError(t, err)
+		require.Equal(t, &executor.UserNamespace{HostUID: 100000, HostGID: 100000, Size: 65536}, web)
+
+		// tasks of the same alloc share a range, other allocs don't
+		sidecar, err := d.userNamespace(task("a", "sidecar"), &TaskConfig{UserNamespace: true})
+		require.NoError(t, err)
+		require.Equal(t, web, sidecar)
+		other, err := d.userNamespace(task("b", "web"), &TaskConfig{UserNamespace: true})
+		require.NoError(t, err)
+		require.Equal(t, uint32(100000+65536), other.HostUID)
+
+		_, err = d.userNamespace(task("c", "web"), &TaskConfig{UserNamespace: true})
+		require.ErrorContains(t, err, "all 2 user namespace ranges are in use")
+
+		d.releaseUserNamespace(task("a", "web"))
+		d.releaseUserNamespace(task("a", "sidecar"))
+		again, err := d.userNamespace(task("c", "web"), &TaskConfig{UserNamespace: true})
+		require.NoError(t, err)
+		require.Equal(t, web, again)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		bad := config
+		bad.UserNamespaceMinHostID = 0
+		require.ErrorContains(t, bad.validate(), "must not include root")
+	})
+}
+
+func TestDriver_chownTaskDirs(t *testing.T) {
+	ci.Parallel(t)
+	ctestutils.RequireRoot(t)
+
+	if !userns.Supported() {
+		t.Skip("user namespaces not supported by system")
+	}
+
+	task := &drivers.TaskConfig{
+		ID:       uuid.Generate(),
+		AllocID:  uuid.Generate(),
+		Name:     "test",
+		AllocDir: t.TempDir(),
+	}
+	taskDir := task.TaskDir()
+	for _, dir := range []string{taskDir.LocalDir, taskDir.SecretsDir, filepath.Join(taskDir.SharedAllocDir, allocdir.SharedDataDir)} {
+		require.NoError(t, os.MkdirAll(dir, 0777))
+	}
+	template := filepath.Join(taskDir.SecretsDir, "token")
+	require.NoError(t, os.WriteFile(template, nil, 0600))
+
+	ns := &executor.UserNamespace{HostUID: 100000, HostGID: 200000, Size: 65536}
+	require.NoError(t, chownTaskDirs(taskDir, ns))
+
+	for _, path := range []string{taskDir.Dir, taskDir.LocalDir, template, filepath.Join(taskDir.SharedAllocDir, allocdir.SharedDataDir)} {
+		info, err := os.Lstat(path)
+		require.NoError(t, err)
+		st := info.Sys().(*syscall.Stat_t)
+		require.Equal(t, uint32(100000), st.Uid, path)
+		require.Equal(t, uint32(200000), st.Gid, path)
+	}
+}
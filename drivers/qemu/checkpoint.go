@@ -0,0 +1,150 @@
+package qemu
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+const (
+	// qemuSnapshotTag is the tag of the snapshot CheckpointTask saves to the
+	// disk images of a VM. Saving it again replaces the previous snapshot.
+	qemuSnapshotTag = "nomad-checkpoint"
+
+	// qemuSnapshotFileName is the name of the file of the checkpoint
+	// directory holding the tag of the snapshot. It is only written once the
+	// snapshot is complete.
+	qemuSnapshotFileName = "qemu.snapshot"
+
+	// qemuMonitorPrompt is printed by the monitor when it is ready to accept
+	// a command.
+	qemuMonitorPrompt = "(qemu) "
+
+	// qemuMonitorTimeout is the time given to the monitor to reply to a
+	// command when the context has no deadline.
+	qemuMonitorTimeout = 5 * time.Minute
+)
+
+// CheckpointTask saves a snapshot of the VM of the task with the savevm
+// monitor command. The snapshot holds both the state of the VM and of its
+// disks, so the disk images must be in a format supporting snapshots, such as
+// qcow2. The monitor is only available when graceful_shutdown is enabled. The
+// VM is paused while the snapshot is saved, and resumes once it is done.
+func (d *Driver) CheckpointTask(ctx context.Context, taskID string, dir string) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+	if handle.monitorPath == "" {
+		return errors.New("checkpointing a VM requires graceful_shutdown to be enabled")
+	}
+
+	m, err := dialQemuMonitor(ctx, handle.monitorPath)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	d.logger.Debug("checkpointing VM", "task_id", taskID, "snapshot", qemuSnapshotTag)
+
+	// The savevm command prints nothing unless it fails, for example because
+	// a disk image doesn't support snapshots
+	out, err := m.run("savevm " + qemuSnapshotTag)
+	if err != nil {
+		return err
+	}
+	if out = strings.TrimSpace(out); out != "" {
+		return fmt.Errorf("failed to save VM snapshot: %s", out)
+	}
+	out, err = m.run("info snapshots")
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(out, qemuSnapshotTag) {
+		return fmt.Errorf("failed to find VM snapshot: %s", strings.TrimSpace(out))
+	}
+
+	return os.WriteFile(filepath.Join(dir, qemuSnapshotFileName), []byte(qemuSnapshotTag), 0600)
+}
+
+// RestoreTask starts the VM of the task from the snapshot saved by
+// CheckpointTask. The disks are reverted to the snapshot as well.
+func (d *Driver) RestoreTask(cfg *drivers.TaskConfig, dir string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	tag, err := os.ReadFile(filepath.Join(dir, qemuSnapshotFileName))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find VM snapshot: %v", err)
+	}
+	return d.startTask(cfg, string(tag))
+}
+
+// qemuMonitor is a connection to the human monitor of a VM.
+type qemuMonitor struct {
+	conn net.Conn
+	ctx  context.Context
+}
+
+// dialQemuMonitor connects to the monitor and waits for its first prompt.
+func dialQemuMonitor(ctx context.Context, monitorPath string) (*qemuMonitor, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", monitorPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to qemu monitor: %v", err)
+	}
+
+	m := &qemuMonitor{conn: conn, ctx: ctx}
+	if _, err := m.readPrompt(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return m, nil
+}
+
+// run sends the command to the monitor and returns its output, without the
+// echo of the command.
+func (m *qemuMonitor) run(cmd string) (string, error) {
+	if _, err := m.conn.Write([]byte(cmd + "\n")); err != nil {
+		return "", fmt.Errorf("failed to send %q to qemu monitor: %v", cmd, err)
+	}
+	out, err := m.readPrompt()
+	if err != nil {
+		return "", fmt.Errorf("failed to run %q on qemu monitor: %v", cmd, err)
+	}
+	if echo := cmd + "\r\n"; strings.HasPrefix(out, echo) {
+		out = out[len(echo):]
+	}
+	return out, nil
+}
+
+// readPrompt reads the output of the monitor until its next prompt.
+func (m *qemuMonitor) readPrompt() (string, error) {
+	deadline, ok := m.ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(qemuMonitorTimeout)
+	}
+	if err := m.conn.SetReadDeadline(deadline); err != nil {
+		return "", err
+	}
+
+	var out bytes.Buffer
+	buf := make([]byte, 1024)
+	for !bytes.HasSuffix(out.Bytes(), []byte(qemuMonitorPrompt)) {
+		n, err := m.conn.Read(buf)
+		out.Write(buf[:n])
+		if err != nil {
+			return out.String(), err
+		}
+	}
+	return strings.TrimSuffix(out.String(), qemuMonitorPrompt), nil
+}
+
+func (m *qemuMonitor) Close() error {
+	return m.conn.Close()
+}
@@ -0,0 +1,116 @@
+package qemu
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/stretchr/testify/require"
+)
+
+// testQemuMonitor serves a fake monitor on the socket, which echoes the
+// commands it runs like the human monitor does. The savevm command fails with
+// the given error if it is set. It returns a channel receiving the commands it
+// ran.
+func testQemuMonitor(t *testing.T, socketPath, savevmErr string) <-chan string {
+	l, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	cmds := make(chan string, 10)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		conn.Write([]byte("QEMU monitor - type 'help' for more information\r\n" + qemuMonitorPrompt))
+		var snapshots []string
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			cmd := scanner.Text()
+			cmds <- cmd
+
+			out := cmd + "\r\n"
+			switch {
+			case strings.HasPrefix(cmd, "savevm "):
+				if savevmErr != "" {
+					out += savevmErr + "\r\n"
+				} else {
+					snapshots = append(snapshots, strings.TrimPrefix(cmd, "savevm "))
+				}
+			case cmd == "info snapshots":
+				if len(snapshots) == 0 {
+					out += "There is no snapshot available.\r\n"
+				} else {
+					out += "List of snapshots present on all disks:\r\n"
+					for i, tag := range snapshots {
+						out += fmt.Sprintf("%d  %s  1.21 GiB 2022-09-01 10:00:00 00:01:02.345\r\n", i+1, tag)
+					}
+				}
+			}
+			conn.Write([]byte(out + qemuMonitorPrompt))
+		}
+	}()
+	return cmds
+}
+
+func TestQemuDriver_CheckpointTask(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	monitorPath := filepath.Join(dir, qemuMonitorSocketName)
+	cmds := testQemuMonitor(t, monitorPath, "")
+
+	d := NewQemuDriver(context.Background(), testlog.HCLogger(t)).(*Driver)
+	d.tasks.Set("task-1", &taskHandle{monitorPath: monitorPath})
+
+	require.NoError(t, d.CheckpointTask(context.Background(), "task-1", dir))
+	tag, err := os.ReadFile(filepath.Join(dir, qemuSnapshotFileName))
+	require.NoError(t, err)
+	require.Equal(t, qemuSnapshotTag, string(tag))
+
+	// The snapshot holds the disks as well, and savevm resumes the VM on its
+	// own
+	require.Equal(t, "savevm "+qemuSnapshotTag, <-cmds)
+	require.Equal(t, "info snapshots", <-cmds)
+	require.Empty(t, cmds)
+}
+
+func TestQemuDriver_CheckpointTask_Failed(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	monitorPath := filepath.Join(dir, qemuMonitorSocketName)
+	testQemuMonitor(t, monitorPath, "Error: Device 'drive0' is writable but does not support snapshots")
+
+	d := NewQemuDriver(context.Background(), testlog.HCLogger(t)).(*Driver)
+	d.tasks.Set("task-1", &taskHandle{monitorPath: monitorPath})
+
+	err := d.CheckpointTask(context.Background(), "task-1", dir)
+	require.ErrorContains(t, err, "does not support snapshots")
+	require.NoFileExists(t, filepath.Join(dir, qemuSnapshotFileName))
+
+	// A task without a snapshot can't be restored
+	_, _, err = d.RestoreTask(&drivers.TaskConfig{ID: "task-1"}, dir)
+	require.ErrorContains(t, err, "failed to find VM snapshot")
+}
+
+func TestQemuDriver_CheckpointTask_NoMonitor(t *testing.T) {
+	ci.Parallel(t)
+
+	d := NewQemuDriver(context.Background(), testlog.HCLogger(t)).(*Driver)
+	d.tasks.Set("task-1", &taskHandle{})
+
+	err := d.CheckpointTask(context.Background(), "task-1", t.TempDir())
+	require.ErrorContains(t, err, "graceful_shutdown")
+}
@@ -105,9 +105,11 @@ var (
 			drivers.NetIsolationModeGroup,
 		},
 		MountConfigs: drivers.MountConfigSupportNone,
+		Checkpoint:   runtime.GOOS != "windows",
 	}
 
-	_ drivers.DriverPlugin = (*Driver)(nil)
+	_ drivers.DriverPlugin     = (*Driver)(nil)
+	_ drivers.CheckpointDriver = (*Driver)(nil)
 )
 
 // TaskConfig is the driver configuration of a taskConfig within a job
@@ -389,6 +391,12 @@ func validateArgs(pluginConfigAllowList, args []string) error {
 }
 
 func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	return d.startTask(cfg, "")
+}
+
+// startTask starts the VM of the task. If snapshot is set, the VM is
+// restored from the snapshot saved by CheckpointTask instead of booting.
+func (d *Driver) startTask(cfg *drivers.TaskConfig, snapshot string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
 	if _, ok := d.tasks.Get(cfg.ID); ok {
 		return nil, nil, fmt.Errorf("taskConfig with ID '%s' already started", cfg.ID)
 	}
@@ -498,6 +506,10 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		args = append(args, "-device", "virtserialport,chardev=qga0,name=org.qemu.guest_agent.0")
 	}
 
+	if snapshot != "" {
+		args = append(args, "-loadvm", snapshot)
+	}
+
 	// Add pass through arguments to qemu executable. A user can specify
 	// these arguments in driver task configuration. These arguments are
 	// passed directly to the qemu driver as command line options.
@@ -154,6 +154,18 @@ type ExecCommand struct {
 
 	// Capabilities are the linux capabilities to be enabled by the task driver.
 	Capabilities []string
+
+	// UserNamespace, if set, runs the task in a new user namespace with its
+	// users and groups mapped onto an unprivileged range of host IDs.
+	UserNamespace *UserNamespace
+}
+
+// UserNamespace maps the IDs [0, Size) inside a task's user namespace onto
+// the host IDs starting at HostUID and HostGID.
+type UserNamespace struct {
+	HostUID uint32
+	HostGID uint32
+	Size    uint32
 }
 
 // SetWriters sets the writer for the process stdout and stderr. This should
@@ -560,6 +560,26 @@ func configureNamespaces(pidMode, ipcMode string) lconfigs.Namespaces {
 	return namespaces
 }
 
+// configureUserNamespace adds a user namespace to the container, mapping IDs
+// [0, Size) inside of it onto the configured range of host IDs. Root in the
+// task is then an unprivileged user on the host.
+func configureUserNamespace(cfg *lconfigs.Config, userns *UserNamespace) {
+	if userns == nil || userns.Size == 0 {
+		return
+	}
+	cfg.Namespaces = append(cfg.Namespaces, lconfigs.Namespace{Type: lconfigs.NEWUSER})
+	cfg.UidMappings = []lconfigs.IDMap{{
+		ContainerID: 0,
+		HostID:      int(userns.HostUID),
+		Size:        int(userns.Size),
+	}}
+	cfg.GidMappings = []lconfigs.IDMap{{
+		ContainerID: 0,
+		HostID:      int(userns.HostGID),
+		Size:        int(userns.Size),
+	}}
+}
+
 // configureIsolation prepares the isolation primitives of the container.
 // The process runs in a container configured with the following:
 //
@@ -586,6 +606,9 @@ func configureIsolation(cfg *lconfigs.Config, command *ExecCommand) error {
 		})
 	}
 
+	// map the task's users onto an unprivileged range of host IDs
+	configureUserNamespace(cfg, command.UserNamespace)
+
 	// paths to mask using a bind mount to /dev/null to prevent reading
 	cfg.MaskPaths = []string{
 		"/proc/kcore",
@@ -699,6 +722,14 @@ func configureCgroups(cfg *lconfigs.Config, command *ExecCommand) error {
 		cfg.Cgroups.Resources.MemorySwappiness = &memSwappiness
 	}
 
+	// Limit the task to the huge pages reserved for it
+	if hugePages := res.Memory.HugePages2MB; hugePages > 0 {
+		cfg.Cgroups.Resources.HugetlbLimit = []*lconfigs.HugepageLimit{{
+			Pagesize: "2MB",
+			Limit:    uint64(hugePages) * 2 * 1024 * 1024,
+		}}
+	}
+
 	cpuShares := res.Cpu.CpuShares
 	if cpuShares < 2 {
 		return fmt.Errorf("resources.Cpu.CpuShares must be equal to or greater than 2: %v", cpuShares)
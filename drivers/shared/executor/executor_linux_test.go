@@ -20,6 +20,7 @@ import (
 	"github.com/hashicorp/nomad/drivers/shared/capabilities"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	tu "github.com/hashicorp/nomad/testutil"
 	"github.com/opencontainers/runc/libcontainer/cgroups"
@@ -128,6 +129,64 @@ func TestExecutor_configureNamespaces(t *testing.T) {
 	})
 }
 
+func TestExecutor_configureUserNamespace(t *testing.T) {
+	ci.Parallel(t)
+	t.Run("unset", func(t *testing.T) {
+		cfg := &lconfigs.Config{}
+		configureUserNamespace(cfg, nil)
+		require.Empty(t, cfg.Namespaces)
+		require.Empty(t, cfg.UidMappings)
+		require.Empty(t, cfg.GidMappings)
+	})
+
+	t.Run("mapped", func(t *testing.T) {
+		cfg := &lconfigs.Config{}
+		configureUserNamespace(cfg, &UserNamespace{
+			HostUID: 100000,
+			HostGID: 200000,
+			Size:    65536,
+		})
+		require.True(t, cfg.Namespaces.Contains(lconfigs.NEWUSER))
+		require.Equal(t, []lconfigs.IDMap{
+			{ContainerID: 0, HostID: 100000, Size: 65536},
+		}, cfg.UidMappings)
+		require.Equal(t, []lconfigs.IDMap{
+			{ContainerID: 0, HostID: 200000, Size: 65536},
+		}, cfg.GidMappings)
+	})
+}
+
+func TestExecutor_configureCgroups_HugePages(t *testing.T) {
+	ci.Parallel(t)
+
+	command := &ExecCommand{
+		ResourceLimits: true,
+		Resources: &drivers.Resources{
+			NomadResources: &structs.AllocatedTaskResources{
+				Cpu: structs.AllocatedCpuResources{CpuShares: 100},
+			},
+			LinuxResources: &drivers.LinuxResources{
+				CpusetCgroupPath: filepath.Join(cgutil.CgroupRoot, "testing.scope", "hugepages.scope"),
+			},
+		},
+	}
+
+	t.Run("unset", func(t *testing.T) {
+		cfg := &lconfigs.Config{Cgroups: &lconfigs.Cgroup{Resources: &lconfigs.Resources{}}}
+		require.NoError(t, configureCgroups(cfg, command))
+		require.Empty(t, cfg.Cgroups.Resources.HugetlbLimit)
+	})
+
+	t.Run("reserved", func(t *testing.T) {
+		command.Resources.NomadResources.Memory.HugePages2MB = 512
+		cfg := &lconfigs.Config{Cgroups: &lconfigs.Cgroup{Resources: &lconfigs.Resources{}}}
+		require.NoError(t, configureCgroups(cfg, command))
+		require.Equal(t, []*lconfigs.HugepageLimit{
+			{Pagesize: "2MB", Limit: 512 * 2 * 1024 * 1024},
+		}, cfg.Cgroups.Resources.HugetlbLimit)
+	})
+}
+
 func TestExecutor_Isolation_PID_and_IPC_hostMode(t *testing.T) {
 	ci.Parallel(t)
 	r := require.New(t)
@@ -51,6 +51,11 @@ func (c *grpcExecutorClient) Launch(cmd *ExecCommand) (*ProcessState, error) {
 		DefaultIpcMode:     cmd.ModeIPC,
 		Capabilities:       cmd.Capabilities,
 	}
+	if userns := cmd.UserNamespace; userns != nil {
+		req.UsernsHostUid = userns.HostUID
+		req.UsernsHostGid = userns.HostGID
+		req.UsernsSize = userns.Size
+	}
 	resp, err := c.client.Launch(ctx, req)
 	if err != nil {
 		return nil, err
@@ -21,7 +21,7 @@ type grpcExecutorServer struct {
 }
 
 func (s *grpcExecutorServer) Launch(ctx context.Context, req *proto.LaunchRequest) (*proto.LaunchResponse, error) {
-	ps, err := s.impl.Launch(&ExecCommand{
+	cmd := &ExecCommand{
 		Cmd:                req.Cmd,
 		Args:               req.Args,
 		Resources:          drivers.ResourcesFromProto(req.Resources),
@@ -39,8 +39,16 @@ func (s *grpcExecutorServer) Launch(ctx context.Context, req *proto.LaunchReques
 		ModePID:            req.DefaultPidMode,
 		ModeIPC:            req.DefaultIpcMode,
 		Capabilities:       req.Capabilities,
-	})
+	}
+	if req.UsernsSize > 0 {
+		cmd.UserNamespace = &UserNamespace{
+			HostUID: req.UsernsHostUid,
+			HostGID: req.UsernsHostGid,
+			Size:    req.UsernsSize,
+		}
+	}
 
+	ps, err := s.impl.Launch(cmd)
 	if err != nil {
 		return nil, err
 	}
@@ -46,6 +46,9 @@ type LaunchRequest struct {
 	CpusetCgroup         string                       `protobuf:"bytes,17,opt,name=cpuset_cgroup,json=cpusetCgroup,proto3" json:"cpuset_cgroup,omitempty"`
 	AllowCaps            []string                     `protobuf:"bytes,18,rep,name=allow_caps,json=allowCaps,proto3" json:"allow_caps,omitempty"`
 	Capabilities         []string                     `protobuf:"bytes,19,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	UsernsHostUid        uint32                       `protobuf:"varint,20,opt,name=userns_host_uid,json=usernsHostUid,proto3" json:"userns_host_uid,omitempty"`
+	UsernsHostGid        uint32                       `protobuf:"varint,21,opt,name=userns_host_gid,json=usernsHostGid,proto3" json:"userns_host_gid,omitempty"`
+	UsernsSize           uint32                       `protobuf:"varint,22,opt,name=userns_size,json=usernsSize,proto3" json:"userns_size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
 	XXX_unrecognized     []byte                       `json:"-"`
 	XXX_sizecache        int32                        `json:"-"`
@@ -209,6 +212,27 @@ func (m *LaunchRequest) GetCapabilities() []string {
 	return nil
 }
 
+func (m *LaunchRequest) GetUsernsHostUid() uint32 {
+	if m != nil {
+		return m.UsernsHostUid
+	}
+	return 0
+}
+
+func (m *LaunchRequest) GetUsernsHostGid() uint32 {
+	if m != nil {
+		return m.UsernsHostGid
+	}
+	return 0
+}
+
+func (m *LaunchRequest) GetUsernsSize() uint32 {
+	if m != nil {
+		return m.UsernsSize
+	}
+	return 0
+}
+
 type LaunchResponse struct {
 	Process              *ProcessState `protobuf:"bytes,1,opt,name=process,proto3" json:"process,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
@@ -874,74 +898,77 @@ func init() {
 }
 
 var fileDescriptor_66b85426380683f3 = []byte{
-	// 1058 bytes of a gzipped FileDescriptorProto
+	// 1107 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0x6d, 0x6f, 0x1b, 0x45,
-	0x10, 0xee, 0xc5, 0x89, 0x5f, 0xc6, 0x76, 0xe2, 0x2e, 0xa8, 0x5c, 0x8d, 0x50, 0xcd, 0x21, 0x51,
-	0x0b, 0xca, 0x25, 0x4a, 0xdf, 0x90, 0x90, 0x28, 0x22, 0x2d, 0xa8, 0x52, 0x1a, 0x45, 0x97, 0x42,
-	0x25, 0x3e, 0x70, 0x6c, 0xee, 0xb6, 0xf6, 0x2a, 0xf6, 0xed, 0xb2, 0xbb, 0xe7, 0x04, 0x09, 0x89,
-	0x4f, 0xfc, 0x03, 0x90, 0xf8, 0x31, 0xfc, 0x38, 0xb4, 0x6f, 0x17, 0x3b, 0x2d, 0xd5, 0xb9, 0x88,
-	0x4f, 0xbe, 0x1d, 0x3f, 0xcf, 0xcc, 0xec, 0xce, 0xcc, 0x33, 0x70, 0x27, 0x17, 0x74, 0x41, 0x84,
-	0xdc, 0x95, 0x53, 0x2c, 0x48, 0xbe, 0x4b, 0x2e, 0x48, 0x56, 0x2a, 0x26, 0x76, 0xb9, 0x60, 0x8a,
-	0x55, 0xc7, 0xd8, 0x1c, 0xd1, 0xc7, 0x53, 0x2c, 0xa7, 0x34, 0x63, 0x82, 0xc7, 0x05, 0x9b, 0xe3,
-	0x3c, 0xe6, 0xb3, 0x72, 0x42, 0x0b, 0x19, 0xaf, 0xe2, 0x86, 0xb7, 0x26, 0x8c, 0x4d, 0x66, 0xc4,
-	0x3a, 0x39, 0x2d, 0x5f, 0xee, 0x2a, 0x3a, 0x27, 0x52, 0xe1, 0x39, 0x77, 0x80, 0xc8, 0x11, 0x77,
-	0x7d, 0x78, 0x1b, 0xce, 0x9e, 0x2c, 0x26, 0xfa, 0xbb, 0x09, 0xfd, 0x43, 0x5c, 0x16, 0xd9, 0x34,
-	0x21, 0x3f, 0x97, 0x44, 0x2a, 0x34, 0x80, 0x46, 0x36, 0xcf, 0xc3, 0x60, 0x14, 0x8c, 0x3b, 0x89,
-	0xfe, 0x44, 0x08, 0x36, 0xb1, 0x98, 0xc8, 0x70, 0x63, 0xd4, 0x18, 0x77, 0x12, 0xf3, 0x8d, 0x8e,
-	0xa0, 0x23, 0x88, 0x64, 0xa5, 0xc8, 0x88, 0x0c, 0x1b, 0xa3, 0x60, 0xdc, 0xdd, 0xdf, 0x8b, 0xff,
-	0x2d, 0x71, 0x17, 0xdf, 0x86, 0x8c, 0x13, 0xcf, 0x4b, 0x2e, 0x5d, 0xa0, 0x5b, 0xd0, 0x95, 0x2a,
-	0x67, 0xa5, 0x4a, 0x39, 0x56, 0xd3, 0x70, 0xd3, 0x44, 0x07, 0x6b, 0x3a, 0xc6, 0x6a, 0xea, 0x00,
-	0x44, 0x08, 0x0b, 0xd8, 0xaa, 0x00, 0x44, 0x08, 0x03, 0x18, 0x40, 0x83, 0x14, 0x8b, 0xb0, 0x69,
-	0x92, 0xd4, 0x9f, 0x3a, 0xef, 0x52, 0x12, 0x11, 0xb6, 0x0c, 0xd6, 0x7c, 0xa3, 0x9b, 0xd0, 0x56,
-	0x58, 0x9e, 0xa5, 0x39, 0x15, 0x61, 0xdb, 0xd8, 0x5b, 0xfa, 0xfc, 0x98, 0x0a, 0x74, 0x1b, 0x76,
-	0x7c, 0x3e, 0xe9, 0x8c, 0xce, 0xa9, 0x92, 0x61, 0x67, 0x14, 0x8c, 0xdb, 0xc9, 0xb6, 0x37, 0x1f,
-	0x1a, 0x2b, 0xda, 0x83, 0x77, 0x4f, 0xb1, 0xa4, 0x59, 0xca, 0x05, 0xcb, 0x88, 0x94, 0x69, 0x36,
-	0x11, 0xac, 0xe4, 0x21, 0x18, 0x34, 0x32, 0xff, 0x1d, 0xdb, 0xbf, 0x0e, 0xcc, 0x3f, 0xe8, 0x31,
-	0x34, 0xe7, 0xac, 0x2c, 0x94, 0x0c, 0xbb, 0xa3, 0xc6, 0xb8, 0xbb, 0x7f, 0xa7, 0xe6, 0x53, 0x3d,
-	0xd3, 0xa4, 0xc4, 0x71, 0xd1, 0xb7, 0xd0, 0xca, 0xc9, 0x82, 0xea, 0x17, 0xef, 0x19, 0x37, 0x9f,
-	0xd5, 0x74, 0xf3, 0xd8, 0xb0, 0x12, 0xcf, 0x46, 0x53, 0xb8, 0x5e, 0x10, 0x75, 0xce, 0xc4, 0x59,
-	0x4a, 0x25, 0x9b, 0x61, 0x45, 0x59, 0x11, 0xf6, 0x4d, 0x11, 0xbf, 0xa8, 0xe9, 0xf2, 0xc8, 0xf2,
-	0x9f, 0x7a, 0xfa, 0x09, 0x27, 0x59, 0x32, 0x28, 0xae, 0x58, 0x51, 0x04, 0xfd, 0x82, 0xa5, 0x9c,
-	0x2e, 0x98, 0x4a, 0x05, 0x63, 0x2a, 0xdc, 0x36, 0x6f, 0xd4, 0x2d, 0xd8, 0xb1, 0xb6, 0x25, 0x8c,
-	0x29, 0x34, 0x86, 0x41, 0x4e, 0x5e, 0xe2, 0x72, 0xa6, 0x52, 0x4e, 0xf3, 0x74, 0xce, 0x72, 0x12,
-	0xee, 0x98, 0xd2, 0x6c, 0x3b, 0xfb, 0x31, 0xcd, 0x9f, 0xb1, 0x9c, 0x2c, 0x23, 0x29, 0xcf, 0x2c,
-	0x72, 0xb0, 0x82, 0x7c, 0xca, 0x33, 0x83, 0xfc, 0x08, 0xfa, 0x19, 0x2f, 0x25, 0x51, 0xbe, 0x36,
-	0xd7, 0x0d, 0xac, 0x67, 0x8d, 0xae, 0x2a, 0x1f, 0x00, 0xe0, 0xd9, 0x8c, 0x9d, 0xa7, 0x19, 0xe6,
-	0x32, 0x44, 0xa6, 0x71, 0x3a, 0xc6, 0x72, 0x80, 0xb9, 0x44, 0x11, 0xf4, 0x32, 0xcc, 0xf1, 0x29,
-	0x9d, 0x51, 0x45, 0x89, 0x0c, 0xdf, 0x31, 0x80, 0x15, 0x5b, 0xf4, 0x13, 0x6c, 0xfb, 0xe9, 0x91,
-	0x9c, 0x15, 0x92, 0xa0, 0x23, 0x68, 0xb9, 0xb6, 0x30, 0x23, 0xd4, 0xdd, 0xbf, 0x17, 0xd7, 0x9b,
-	0xe7, 0xd8, 0xb5, 0xcc, 0x89, 0xc2, 0x8a, 0x24, 0xde, 0x49, 0xd4, 0x87, 0xee, 0x0b, 0x4c, 0x95,
-	0x9b, 0xce, 0xe8, 0x47, 0xe8, 0xd9, 0xe3, 0xff, 0x14, 0xee, 0x10, 0x76, 0x4e, 0xa6, 0xa5, 0xca,
-	0xd9, 0x79, 0xe1, 0x05, 0xe1, 0x06, 0x34, 0x25, 0x9d, 0x14, 0x78, 0xe6, 0x34, 0xc1, 0x9d, 0xd0,
-	0x87, 0xd0, 0x9b, 0x08, 0x9c, 0x91, 0x94, 0x13, 0x41, 0x59, 0x1e, 0x6e, 0x8c, 0x82, 0x71, 0x23,
-	0xe9, 0x1a, 0xdb, 0xb1, 0x31, 0x45, 0x08, 0x06, 0x97, 0xde, 0x6c, 0xc6, 0xd1, 0x14, 0x6e, 0x7c,
-	0xc7, 0x73, 0x1d, 0xb4, 0xd2, 0x01, 0x17, 0x68, 0x45, 0x53, 0x82, 0xff, 0xac, 0x29, 0xd1, 0x4d,
-	0x78, 0xef, 0x95, 0x48, 0x2e, 0x89, 0x01, 0x6c, 0x7f, 0x4f, 0x84, 0xa4, 0xcc, 0xdf, 0x32, 0xfa,
-	0x14, 0x76, 0x2a, 0x8b, 0x7b, 0xdb, 0x10, 0x5a, 0x0b, 0x6b, 0x72, 0x37, 0xf7, 0xc7, 0xe8, 0x13,
-	0xe8, 0xe9, 0x77, 0xab, 0x32, 0x1f, 0x42, 0x9b, 0x16, 0x8a, 0x88, 0x85, 0x7b, 0xa4, 0x46, 0x52,
-	0x9d, 0xa3, 0x17, 0xd0, 0x77, 0x58, 0xe7, 0xf6, 0x1b, 0xd8, 0x92, 0xda, 0xb0, 0xe6, 0x15, 0x9f,
-	0x63, 0x79, 0x66, 0x1d, 0x59, 0x7a, 0x74, 0x1b, 0xfa, 0x27, 0xa6, 0x12, 0xaf, 0x2f, 0xd4, 0x96,
-	0x2f, 0x94, 0xbe, 0xac, 0x07, 0xba, 0xeb, 0x9f, 0x41, 0xf7, 0xc9, 0x05, 0xc9, 0x3c, 0xf1, 0x01,
-	0xb4, 0x73, 0x82, 0xf3, 0x19, 0x2d, 0x88, 0x4b, 0x6a, 0x18, 0xdb, 0xe5, 0x12, 0xfb, 0xe5, 0x12,
-	0x3f, 0xf7, 0xcb, 0x25, 0xa9, 0xb0, 0x7e, 0x55, 0x6c, 0xbc, 0xba, 0x2a, 0x1a, 0x97, 0xab, 0x22,
-	0x3a, 0x80, 0x9e, 0x0d, 0xe6, 0xee, 0x7f, 0x03, 0x9a, 0xac, 0x54, 0xbc, 0x54, 0x26, 0x56, 0x2f,
-	0x71, 0x27, 0xf4, 0x3e, 0x74, 0xc8, 0x05, 0x55, 0x69, 0xa6, 0xc7, 0x7a, 0xc3, 0xdc, 0xa0, 0xad,
-	0x0d, 0x07, 0x2c, 0x27, 0xd1, 0xef, 0x01, 0xf4, 0x96, 0x3b, 0x56, 0xc7, 0xe6, 0x34, 0x77, 0x37,
-	0xd5, 0x9f, 0x6f, 0xe4, 0x2f, 0xbd, 0x4d, 0x63, 0xf9, 0x6d, 0x50, 0x0c, 0x9b, 0x7a, 0x6d, 0x9a,
-	0x85, 0xf3, 0xe6, 0x6b, 0x1b, 0xdc, 0xfe, 0x9f, 0x1d, 0x68, 0x3f, 0x71, 0x83, 0x84, 0x7e, 0x81,
-	0xa6, 0x9d, 0x7e, 0x74, 0xbf, 0xee, 0xd4, 0xad, 0xec, 0xda, 0xe1, 0x83, 0x75, 0x69, 0xae, 0x7e,
-	0xd7, 0x90, 0x84, 0x4d, 0xad, 0x03, 0xe8, 0x6e, 0x5d, 0x0f, 0x4b, 0x22, 0x32, 0xbc, 0xb7, 0x1e,
-	0xa9, 0x0a, 0xfa, 0x1b, 0xb4, 0xfd, 0x38, 0xa3, 0x87, 0x75, 0x7d, 0x5c, 0x91, 0x93, 0xe1, 0xe7,
-	0xeb, 0x13, 0xab, 0x04, 0xfe, 0x08, 0x60, 0xe7, 0xca, 0x48, 0xa3, 0x2f, 0xeb, 0xfa, 0x7b, 0xbd,
-	0xea, 0x0c, 0x1f, 0xbd, 0x35, 0xbf, 0x4a, 0xeb, 0x57, 0x68, 0x39, 0xed, 0x40, 0xb5, 0x2b, 0xba,
-	0x2a, 0x3f, 0xc3, 0x87, 0x6b, 0xf3, 0xaa, 0xe8, 0x17, 0xb0, 0x65, 0x74, 0x01, 0xd5, 0x2e, 0xeb,
-	0xb2, 0x76, 0x0d, 0xef, 0xaf, 0xc9, 0xf2, 0x71, 0xf7, 0x02, 0xdd, 0xff, 0x56, 0x58, 0xea, 0xf7,
-	0xff, 0x8a, 0x62, 0xd5, 0xef, 0xff, 0x2b, 0xfa, 0x65, 0xfa, 0x5f, 0x8f, 0x61, 0xfd, 0xfe, 0x5f,
-	0xd2, 0xbb, 0xfa, 0xfd, 0xbf, 0xac, 0x5b, 0xd1, 0x35, 0xf4, 0x57, 0x00, 0x7d, 0x6d, 0x3a, 0x51,
-	0x82, 0xe0, 0x39, 0x2d, 0x26, 0xe8, 0x51, 0x4d, 0xf1, 0xd6, 0x2c, 0x2b, 0xe0, 0x8e, 0xe9, 0x53,
-	0xf9, 0xea, 0xed, 0x1d, 0xf8, 0xb4, 0xc6, 0xc1, 0x5e, 0xf0, 0x75, 0xeb, 0x87, 0x2d, 0xab, 0x59,
-	0x4d, 0xf3, 0x73, 0xf7, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x2e, 0x63, 0xc4, 0xd3, 0x74, 0x0c,
-	0x00, 0x00,
+	0x17, 0x7d, 0x36, 0x4e, 0xfc, 0x72, 0x6d, 0xc7, 0xee, 0x3c, 0x25, 0x6c, 0x8d, 0x50, 0xcd, 0x22,
+	0xb5, 0x16, 0x94, 0x4d, 0x94, 0xbe, 0x21, 0x21, 0x51, 0x44, 0x5a, 0x4a, 0xa5, 0x34, 0x8a, 0xd6,
+	0x2d, 0x95, 0xf8, 0xc0, 0x32, 0xd9, 0x99, 0xda, 0xa3, 0xd8, 0x3b, 0xcb, 0xcc, 0xac, 0x13, 0x2a,
+	0x24, 0x3e, 0xf1, 0x0f, 0x40, 0xe2, 0x4f, 0xf0, 0x1f, 0xd1, 0xbc, 0xec, 0xc6, 0x4e, 0x0b, 0xac,
+	0x8b, 0xf8, 0xe4, 0x9d, 0xe3, 0x73, 0xee, 0xbd, 0x33, 0xf7, 0xce, 0x19, 0xb8, 0x45, 0x04, 0x5b,
+	0x50, 0x21, 0x77, 0xe5, 0x14, 0x0b, 0x4a, 0x76, 0xe9, 0x39, 0x4d, 0x72, 0xc5, 0xc5, 0x6e, 0x26,
+	0xb8, 0xe2, 0xe5, 0x32, 0x34, 0x4b, 0x74, 0x63, 0x8a, 0xe5, 0x94, 0x25, 0x5c, 0x64, 0x61, 0xca,
+	0xe7, 0x98, 0x84, 0xd9, 0x2c, 0x9f, 0xb0, 0x54, 0x86, 0xab, 0xbc, 0xc1, 0xf5, 0x09, 0xe7, 0x93,
+	0x19, 0xb5, 0x41, 0x4e, 0xf2, 0x97, 0xbb, 0x8a, 0xcd, 0xa9, 0x54, 0x78, 0x9e, 0x39, 0x42, 0xe0,
+	0x84, 0xbb, 0x45, 0x7a, 0x9b, 0xce, 0xae, 0x2c, 0x27, 0xf8, 0xa3, 0x01, 0xdd, 0x43, 0x9c, 0xa7,
+	0xc9, 0x34, 0xa2, 0x3f, 0xe4, 0x54, 0x2a, 0xd4, 0x87, 0x5a, 0x32, 0x27, 0xbe, 0x37, 0xf4, 0x46,
+	0xad, 0x48, 0x7f, 0x22, 0x04, 0x9b, 0x58, 0x4c, 0xa4, 0xbf, 0x31, 0xac, 0x8d, 0x5a, 0x91, 0xf9,
+	0x46, 0x47, 0xd0, 0x12, 0x54, 0xf2, 0x5c, 0x24, 0x54, 0xfa, 0xb5, 0xa1, 0x37, 0x6a, 0xef, 0xef,
+	0x85, 0x7f, 0x55, 0xb8, 0xcb, 0x6f, 0x53, 0x86, 0x51, 0xa1, 0x8b, 0x2e, 0x42, 0xa0, 0xeb, 0xd0,
+	0x96, 0x8a, 0xf0, 0x5c, 0xc5, 0x19, 0x56, 0x53, 0x7f, 0xd3, 0x64, 0x07, 0x0b, 0x1d, 0x63, 0x35,
+	0x75, 0x04, 0x2a, 0x84, 0x25, 0x6c, 0x95, 0x04, 0x2a, 0x84, 0x21, 0xf4, 0xa1, 0x46, 0xd3, 0x85,
+	0x5f, 0x37, 0x45, 0xea, 0x4f, 0x5d, 0x77, 0x2e, 0xa9, 0xf0, 0x1b, 0x86, 0x6b, 0xbe, 0xd1, 0x35,
+	0x68, 0x2a, 0x2c, 0x4f, 0x63, 0xc2, 0x84, 0xdf, 0x34, 0x78, 0x43, 0xaf, 0x1f, 0x32, 0x81, 0x6e,
+	0x42, 0xaf, 0xa8, 0x27, 0x9e, 0xb1, 0x39, 0x53, 0xd2, 0x6f, 0x0d, 0xbd, 0x51, 0x33, 0xda, 0x2e,
+	0xe0, 0x43, 0x83, 0xa2, 0x3d, 0xb8, 0x7a, 0x82, 0x25, 0x4b, 0xe2, 0x4c, 0xf0, 0x84, 0x4a, 0x19,
+	0x27, 0x13, 0xc1, 0xf3, 0xcc, 0x07, 0xc3, 0x46, 0xe6, 0xbf, 0x63, 0xfb, 0xd7, 0x81, 0xf9, 0x07,
+	0x3d, 0x84, 0xfa, 0x9c, 0xe7, 0xa9, 0x92, 0x7e, 0x7b, 0x58, 0x1b, 0xb5, 0xf7, 0x6f, 0x55, 0x3c,
+	0xaa, 0xa7, 0x5a, 0x14, 0x39, 0x2d, 0x7a, 0x0c, 0x0d, 0x42, 0x17, 0x4c, 0x9f, 0x78, 0xc7, 0x84,
+	0xf9, 0xa4, 0x62, 0x98, 0x87, 0x46, 0x15, 0x15, 0x6a, 0x34, 0x85, 0x2b, 0x29, 0x55, 0x67, 0x5c,
+	0x9c, 0xc6, 0x4c, 0xf2, 0x19, 0x56, 0x8c, 0xa7, 0x7e, 0xd7, 0x34, 0xf1, 0xb3, 0x8a, 0x21, 0x8f,
+	0xac, 0xfe, 0x49, 0x21, 0x1f, 0x67, 0x34, 0x89, 0xfa, 0xe9, 0x25, 0x14, 0x05, 0xd0, 0x4d, 0x79,
+	0x9c, 0xb1, 0x05, 0x57, 0xb1, 0xe0, 0x5c, 0xf9, 0xdb, 0xe6, 0x8c, 0xda, 0x29, 0x3f, 0xd6, 0x58,
+	0xc4, 0xb9, 0x42, 0x23, 0xe8, 0x13, 0xfa, 0x12, 0xe7, 0x33, 0x15, 0x67, 0x8c, 0xc4, 0x73, 0x4e,
+	0xa8, 0xdf, 0x33, 0xad, 0xd9, 0x76, 0xf8, 0x31, 0x23, 0x4f, 0x39, 0xa1, 0xcb, 0x4c, 0x96, 0x25,
+	0x96, 0xd9, 0x5f, 0x61, 0x3e, 0xc9, 0x12, 0xc3, 0xfc, 0x10, 0xba, 0x49, 0x96, 0x4b, 0xaa, 0x8a,
+	0xde, 0x5c, 0x31, 0xb4, 0x8e, 0x05, 0x5d, 0x57, 0xde, 0x07, 0xc0, 0xb3, 0x19, 0x3f, 0x8b, 0x13,
+	0x9c, 0x49, 0x1f, 0x99, 0xc1, 0x69, 0x19, 0xe4, 0x00, 0x67, 0x12, 0x05, 0xd0, 0x49, 0x70, 0x86,
+	0x4f, 0xd8, 0x8c, 0x29, 0x46, 0xa5, 0xff, 0x7f, 0x43, 0x58, 0xc1, 0xd0, 0x0d, 0xe8, 0xe9, 0xb1,
+	0x4a, 0x65, 0x3c, 0xe5, 0x52, 0xc5, 0x39, 0x23, 0xfe, 0xd5, 0xa1, 0x37, 0xea, 0x46, 0x5d, 0x0b,
+	0x7f, 0xcd, 0xa5, 0x7a, 0xce, 0xc8, 0x65, 0xde, 0x84, 0x11, 0xff, 0x9d, 0xcb, 0xbc, 0xc7, 0x8c,
+	0xe8, 0x29, 0x77, 0x3c, 0xc9, 0x5e, 0x51, 0x7f, 0xc7, 0x70, 0xc0, 0x42, 0x63, 0xf6, 0x8a, 0x06,
+	0xdf, 0xc3, 0x76, 0x71, 0x5d, 0x65, 0xc6, 0x53, 0x49, 0xd1, 0x11, 0x34, 0xdc, 0x1c, 0x9a, 0x3b,
+	0xdb, 0xde, 0xbf, 0x13, 0x56, 0x33, 0x90, 0xd0, 0xcd, 0xe8, 0x58, 0x61, 0x45, 0xa3, 0x22, 0x48,
+	0xd0, 0x85, 0xf6, 0x0b, 0xcc, 0x94, 0xb3, 0x83, 0xe0, 0x3b, 0xe8, 0xd8, 0xe5, 0x7f, 0x94, 0xee,
+	0x10, 0x7a, 0xe3, 0x69, 0xae, 0x08, 0x3f, 0x4b, 0x0b, 0x07, 0xda, 0x81, 0xba, 0x64, 0x93, 0x14,
+	0xcf, 0x9c, 0x09, 0xb9, 0x15, 0xfa, 0x00, 0x3a, 0x13, 0x81, 0x13, 0x1a, 0x67, 0x54, 0x30, 0x4e,
+	0xfc, 0x8d, 0xa1, 0x37, 0xaa, 0x45, 0x6d, 0x83, 0x1d, 0x1b, 0x28, 0x40, 0xd0, 0xbf, 0x88, 0x66,
+	0x2b, 0x0e, 0xa6, 0xb0, 0xf3, 0x3c, 0x23, 0x3a, 0x69, 0x69, 0x3c, 0x2e, 0xd1, 0x8a, 0x89, 0x79,
+	0xff, 0xda, 0xc4, 0x82, 0x6b, 0xf0, 0xee, 0x6b, 0x99, 0x5c, 0x11, 0x7d, 0xd8, 0xfe, 0x86, 0x0a,
+	0xc9, 0x78, 0xb1, 0xcb, 0xe0, 0x63, 0xe8, 0x95, 0x88, 0x3b, 0x5b, 0x1f, 0x1a, 0x0b, 0x0b, 0xb9,
+	0x9d, 0x17, 0xcb, 0xe0, 0x23, 0xe8, 0xe8, 0x73, 0x2b, 0x2b, 0x1f, 0x40, 0x93, 0xa5, 0x8a, 0x8a,
+	0x85, 0x3b, 0xa4, 0x5a, 0x54, 0xae, 0x83, 0x17, 0xd0, 0x75, 0x5c, 0x17, 0xf6, 0x2b, 0xd8, 0x92,
+	0x1a, 0x58, 0x73, 0x8b, 0xcf, 0xb0, 0x3c, 0xb5, 0x81, 0xac, 0x3c, 0xb8, 0x09, 0xdd, 0xb1, 0xe9,
+	0xc4, 0x9b, 0x1b, 0xb5, 0x55, 0x34, 0x4a, 0x6f, 0xb6, 0x20, 0xba, 0xed, 0x9f, 0x42, 0xfb, 0xd1,
+	0x39, 0x4d, 0x0a, 0xe1, 0x3d, 0x68, 0x12, 0x8a, 0xc9, 0x8c, 0xa5, 0xd4, 0x15, 0x35, 0x08, 0xed,
+	0x6b, 0x16, 0x16, 0xaf, 0x59, 0xf8, 0xac, 0x78, 0xcd, 0xa2, 0x92, 0x5b, 0xbc, 0x4d, 0x1b, 0xaf,
+	0xbf, 0x4d, 0xb5, 0x8b, 0xb7, 0x29, 0x38, 0x80, 0x8e, 0x4d, 0xe6, 0xf6, 0xbf, 0x03, 0x75, 0x9e,
+	0xab, 0x2c, 0x57, 0x26, 0x57, 0x27, 0x72, 0x2b, 0xf4, 0x1e, 0xb4, 0xe8, 0x39, 0x53, 0x71, 0xa2,
+	0x7d, 0x64, 0xc3, 0xec, 0xa0, 0xa9, 0x81, 0x03, 0x4e, 0x68, 0xf0, 0x8b, 0x07, 0x9d, 0xe5, 0x89,
+	0xd5, 0xb9, 0x33, 0x46, 0xdc, 0x4e, 0xf5, 0xe7, 0xdf, 0xea, 0x97, 0xce, 0xa6, 0xb6, 0x7c, 0x36,
+	0x28, 0x84, 0x4d, 0xfd, 0x4e, 0xfb, 0x9b, 0xff, 0xb8, 0x6d, 0xc3, 0xdb, 0xff, 0xad, 0x05, 0xcd,
+	0x47, 0xee, 0x22, 0xa1, 0x1f, 0xa1, 0x6e, 0x6f, 0x3f, 0xba, 0x5b, 0xf5, 0xd6, 0xad, 0x3c, 0xee,
+	0x83, 0x7b, 0xeb, 0xca, 0x5c, 0xff, 0xfe, 0x87, 0x24, 0x6c, 0x6a, 0x1f, 0x40, 0xb7, 0xab, 0x46,
+	0x58, 0x32, 0x91, 0xc1, 0x9d, 0xf5, 0x44, 0x65, 0xd2, 0x9f, 0xa1, 0x59, 0x5c, 0x67, 0x74, 0xbf,
+	0x6a, 0x8c, 0x4b, 0x76, 0x32, 0xf8, 0x74, 0x7d, 0x61, 0x59, 0xc0, 0xaf, 0x1e, 0xf4, 0x2e, 0x5d,
+	0x69, 0xf4, 0x79, 0xd5, 0x78, 0x6f, 0x76, 0x9d, 0xc1, 0x83, 0xb7, 0xd6, 0x97, 0x65, 0xfd, 0x04,
+	0x0d, 0xe7, 0x1d, 0xa8, 0x72, 0x47, 0x57, 0xed, 0x67, 0x70, 0x7f, 0x6d, 0x5d, 0x99, 0xfd, 0x1c,
+	0xb6, 0x8c, 0x2f, 0xa0, 0xca, 0x6d, 0x5d, 0xf6, 0xae, 0xc1, 0xdd, 0x35, 0x55, 0x45, 0xde, 0x3d,
+	0x4f, 0xcf, 0xbf, 0x35, 0x96, 0xea, 0xf3, 0xbf, 0xe2, 0x58, 0x83, 0x7b, 0xeb, 0xca, 0x96, 0xe7,
+	0x5f, 0x5f, 0xc3, 0xea, 0xf3, 0xbf, 0xe4, 0x77, 0x83, 0x3b, 0xeb, 0x89, 0xca, 0xa4, 0xbf, 0x7b,
+	0xd0, 0xd5, 0xd0, 0x58, 0x09, 0x8a, 0xe7, 0x2c, 0x9d, 0xa0, 0x07, 0x15, 0xcd, 0x5b, 0xab, 0xac,
+	0x81, 0x3b, 0x65, 0x51, 0xca, 0x17, 0x6f, 0x1f, 0xa0, 0x28, 0x6b, 0xe4, 0xed, 0x79, 0x5f, 0x36,
+	0xbe, 0xdd, 0xb2, 0x9e, 0x55, 0x37, 0x3f, 0xb7, 0xff, 0x1c, 0x00, 0xcb, 0x51, 0xaf, 0x0b, 0xe5,
+	0x0c, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -0,0 +1,120 @@
+package userns
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Pool hands out non-overlapping ranges of host IDs for user namespaces from
+// the range configured by the operator, so that job authors never choose
+// which host IDs their tasks run as. Tasks of the same allocation share a
+// range, so that they can share the files in the alloc directory.
+type Pool struct {
+	minHostID uint32
+	size      uint32
+	slots     uint32
+
+	lock   sync.Mutex
+	allocs map[string]*poolEntry
+	used   map[uint32]string
+}
+
+type poolEntry struct {
+	slot  uint32
+	tasks map[string]struct{}
+}
+
+// NewPool returns a pool of ranges of size IDs each, taken from the host IDs
+// [minHostID, maxHostID).
+func NewPool(minHostID, maxHostID, size uint32) (*Pool, error) {
+	if minHostID == 0 {
+		return nil, fmt.Errorf("host ID range must not include root")
+	}
+	if err := ValidateRange("id", minHostID, size, minHostID); err != nil {
+		return nil, err
+	}
+	if maxHostID < minHostID || maxHostID-minHostID < size {
+		return nil, fmt.Errorf("host ID range [%d, %d) is too small for ranges of %d IDs", minHostID, maxHostID, size)
+	}
+	return &Pool{
+		minHostID: minHostID,
+		size:      size,
+		slots:     (maxHostID - minHostID) / size,
+		allocs:    make(map[string]*poolEntry),
+		used:      make(map[uint32]string),
+	}, nil
+}
+
+// Size returns the number of IDs in each range.
+func (p *Pool) Size() uint32 {
+	return p.size
+}
+
+// Acquire returns the first host ID of the range assigned to the allocation,
+// assigning a free one if the allocation doesn't have one yet.
+func (p *Pool) Acquire(allocID, taskID string) (uint32, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if e, ok := p.allocs[allocID]; ok {
+		e.tasks[taskID] = struct{}{}
+		return p.hostID(e.slot), nil
+	}
+	for slot := uint32(0); slot < p.slots; slot++ {
+		if _, ok := p.used[slot]; !ok {
+			p.add(allocID, taskID, slot)
+			return p.hostID(slot), nil
+		}
+	}
+	return 0, fmt.Errorf("all %d user namespace ranges are in use", p.slots)
+}
+
+// Claim marks the range starting at hostID as assigned to the allocation,
+// when a task that was started with it is recovered.
+func (p *Pool) Claim(allocID, taskID string, hostID uint32) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if hostID < p.minHostID || (hostID-p.minHostID)%p.size != 0 || (hostID-p.minHostID)/p.size >= p.slots {
+		return fmt.Errorf("host ID %d is not the start of a range in the configured pool", hostID)
+	}
+	slot := (hostID - p.minHostID) / p.size
+	if e, ok := p.allocs[allocID]; ok {
+		if e.slot != slot {
+			return fmt.Errorf("allocation %s already uses the range starting at host ID %d", allocID, p.hostID(e.slot))
+		}
+		e.tasks[taskID] = struct{}{}
+		return nil
+	}
+	if other, ok := p.used[slot]; ok {
+		return fmt.Errorf("range starting at host ID %d is already used by allocation %s", hostID, other)
+	}
+	p.add(allocID, taskID, slot)
+	return nil
+}
+
+// Release removes the task from the allocation's range, and frees the range
+// once no task of the allocation uses it anymore.
+func (p *Pool) Release(allocID, taskID string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	e, ok := p.allocs[allocID]
+	if !ok {
+		return
+	}
+	delete(e.tasks, taskID)
+	if len(e.tasks) == 0 {
+		delete(p.allocs, allocID)
+		delete(p.used, e.slot)
+	}
+}
+
+func (p *Pool) add(allocID, taskID string, slot uint32) {
+	p.allocs[allocID] = &poolEntry{slot: slot, tasks: map[string]struct{}{taskID: {}}}
+	p.used[slot] = allocID
+}
+
+func (p *Pool) hostID(slot uint32) uint32 {
+	return p.minHostID + slot*p.size
+}
@@ -0,0 +1,53 @@
+package userns
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPool(t *testing.T) {
+	ci.Parallel(t)
+
+	_, err := NewPool(DefaultMinHostID, DefaultMinHostID+10, DefaultSize)
+	require.ErrorContains(t, err, "too small")
+	_, err = NewPool(0, DefaultMaxHostID, DefaultSize)
+	require.ErrorContains(t, err, "must not include root")
+
+	p, err := NewPool(100000, 100000+2*1000, 1000)
+	require.NoError(t, err)
+
+	// tasks of the same alloc share a range
+	a1, err := p.Acquire("a", "web")
+	require.NoError(t, err)
+	require.Equal(t, uint32(100000), a1)
+	a2, err := p.Acquire("a", "sidecar")
+	require.NoError(t, err)
+	require.Equal(t, a1, a2)
+
+	b, err := p.Acquire("b", "web")
+	require.NoError(t, err)
+	require.Equal(t, uint32(101000), b)
+
+	_, err = p.Acquire("c", "web")
+	require.ErrorContains(t, err, "all 2 user namespace ranges are in use")
+
+	// the range is freed once the last task of the alloc releases it
+	p.Release("a", "web")
+	_, err = p.Acquire("c", "web")
+	require.Error(t, err)
+	p.Release("a", "sidecar")
+	c, err := p.Acquire("c", "web")
+	require.NoError(t, err)
+	require.Equal(t, a1, c)
+
+	// recovered tasks claim the range they were started with
+	p.Release("b", "web")
+	require.ErrorContains(t, p.Claim("d", "web", 100000), "already used by allocation c")
+	require.ErrorContains(t, p.Claim("d", "web", 100500), "not the start of a range")
+	require.ErrorContains(t, p.Claim("d", "web", 102000), "not the start of a range")
+	require.NoError(t, p.Claim("d", "web", 101000))
+	require.ErrorContains(t, p.Claim("d", "other", 100000), "already uses the range")
+	require.NoError(t, p.Claim("d", "other", 101000))
+}
@@ -0,0 +1,40 @@
+// Package userns contains helpers shared by task drivers that run tasks in
+// Linux user namespaces.
+package userns
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// DefaultSize is the number of IDs mapped into a task's user namespace
+	// when the jobspec does not set one. It covers the full 16-bit ID range
+	// most distributions expect to exist.
+	DefaultSize = 65536
+
+	// DefaultMinHostID is the lowest host ID a task's namespace may be
+	// mapped onto unless the operator configures otherwise. It keeps tasks
+	// from being mapped onto root or system users on the host.
+	DefaultMinHostID = 65536
+
+	// DefaultMaxHostID is the end of the host IDs that ranges are assigned
+	// from unless the operator configures otherwise, leaving room for 1024
+	// allocations with ranges of DefaultSize.
+	DefaultMaxHostID = DefaultMinHostID + 1024*DefaultSize
+)
+
+// ValidateRange checks that the host IDs [hostID, hostID+size) lie entirely
+// at or above minHostID and fit within the 32-bit ID space.
+func ValidateRange(kind string, hostID, size, minHostID uint32) error {
+	if size == 0 {
+		return fmt.Errorf("%s range size must be greater than 0", kind)
+	}
+	if hostID < minHostID {
+		return fmt.Errorf("host %s %d is below the minimum host ID %d", kind, hostID, minHostID)
+	}
+	if uint64(hostID)+uint64(size) > math.MaxUint32 {
+		return fmt.Errorf("host %s range %d+%d overflows the ID space", kind, hostID, size)
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+//go:build !linux
+// +build !linux
+
+package userns
+
+import "fmt"
+
+// Supported returns whether the kernel allows creating user namespaces,
+// which is never the case outside of Linux.
+func Supported() bool {
+	return false
+}
+
+// ShiftOwner always fails outside of Linux, where tasks can't run in user
+// namespaces.
+func ShiftOwner(path string, hostUID, hostGID, size uint32, recursive bool) error {
+	return fmt.Errorf("user namespaces are not supported on this platform")
+}
@@ -0,0 +1,85 @@
+package userns
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+const (
+	// maxUserNamespacesPath limits the number of user namespaces that may be
+	// created; a value of 0 disables them entirely.
+	maxUserNamespacesPath = "/proc/sys/user/max_user_namespaces"
+
+	// selfUserNamespacePath only exists on kernels built with
+	// CONFIG_USER_NS.
+	selfUserNamespacePath = "/proc/self/ns/user"
+)
+
+// Supported returns whether the kernel allows creating user namespaces.
+func Supported() bool {
+	return supported(selfUserNamespacePath, maxUserNamespacesPath)
+}
+
+func supported(nsPath, maxPath string) bool {
+	if _, err := os.Stat(nsPath); err != nil {
+		return false
+	}
+
+	// Kernels older than 4.9 do not expose the limit, but support user
+	// namespaces whenever the namespace file exists.
+	b, err := os.ReadFile(maxPath)
+	if os.IsNotExist(err) {
+		return true
+	} else if err != nil {
+		return false
+	}
+	max, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil {
+		return false
+	}
+	return max > 0
+}
+
+// ShiftOwner changes the owner of path, and of everything below it if
+// recursive is set, into the host ID ranges starting at hostUID and hostGID,
+// so that IDs inside the task's user namespace own the files. An ID below
+// size is shifted by the start of the range, so that a file owned by UID 1000
+// is owned by UID 1000 in the task, and any other ID outside the range
+// becomes root in the task. IDs already inside the range are left alone.
+func ShiftOwner(path string, hostUID, hostGID, size uint32, recursive bool) error {
+	shift := func(path string, info os.FileInfo) error {
+		st, ok := info.Sys().(*syscall.Stat_t)
+		if !ok {
+			return fmt.Errorf("could not read owner of %s", path)
+		}
+		return os.Lchown(path, int(shiftID(st.Uid, hostUID, size)), int(shiftID(st.Gid, hostGID, size)))
+	}
+	if !recursive {
+		info, err := os.Lstat(path)
+		if err != nil {
+			return err
+		}
+		return shift(path, info)
+	}
+	return filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		return shift(path, info)
+	})
+}
+
+func shiftID(id, hostID, size uint32) uint32 {
+	switch {
+	case id >= hostID && id-hostID < size:
+		return id
+	case id < size:
+		return hostID + id
+	default:
+		return hostID
+	}
+}
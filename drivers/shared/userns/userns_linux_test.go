@@ -0,0 +1,71 @@
+package userns
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupported(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	nsPath := filepath.Join(dir, "user")
+	maxPath := filepath.Join(dir, "max_user_namespaces")
+
+	// no namespace file means the kernel lacks CONFIG_USER_NS
+	require.False(t, supported(nsPath, maxPath))
+
+	require.NoError(t, os.WriteFile(nsPath, nil, 0644))
+	require.True(t, supported(nsPath, maxPath))
+
+	require.NoError(t, os.WriteFile(maxPath, []byte("0\n"), 0644))
+	require.False(t, supported(nsPath, maxPath))
+
+	require.NoError(t, os.WriteFile(maxPath, []byte("63412\n"), 0644))
+	require.True(t, supported(nsPath, maxPath))
+}
+
+func TestShiftOwner(t *testing.T) {
+	ci.Parallel(t)
+
+	require.Equal(t, uint32(100000), shiftID(0, 100000, 65536))
+	require.Equal(t, uint32(101000), shiftID(1000, 100000, 65536))
+	require.Equal(t, uint32(101000), shiftID(101000, 100000, 65536))
+	require.Equal(t, uint32(100000), shiftID(65536, 100000, 65536))
+
+	if os.Geteuid() != 0 {
+		t.Skip("must be root to change file owners")
+	}
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, nil, 0600))
+	require.NoError(t, os.Lchown(file, 1000, 1001))
+
+	owner := func(path string) (uint32, uint32) {
+		info, err := os.Lstat(path)
+		require.NoError(t, err)
+		st := info.Sys().(*syscall.Stat_t)
+		return st.Uid, st.Gid
+	}
+
+	require.NoError(t, ShiftOwner(dir, 100000, 200000, 65536, false))
+	uid, gid := owner(dir)
+	require.Equal(t, []uint32{100000, 200000}, []uint32{uid, gid})
+	uid, gid = owner(file)
+	require.Equal(t, []uint32{1000, 1001}, []uint32{uid, gid})
+
+	require.NoError(t, ShiftOwner(dir, 100000, 200000, 65536, true))
+	uid, gid = owner(file)
+	require.Equal(t, []uint32{101000, 201001}, []uint32{uid, gid})
+
+	// shifting again is a no-op
+	require.NoError(t, ShiftOwner(dir, 100000, 200000, 65536, true))
+	uid, gid = owner(file)
+	require.Equal(t, []uint32{101000, 201001}, []uint32{uid, gid})
+}
@@ -0,0 +1,36 @@
+package userns
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRange(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		hostID uint32
+		size   uint32
+		err    string
+	}{
+		{name: "ok", hostID: 100000, size: DefaultSize},
+		{name: "at minimum", hostID: DefaultMinHostID, size: 1},
+		{name: "zero size", hostID: 100000, size: 0, err: "size must be greater than 0"},
+		{name: "host root", hostID: 0, size: DefaultSize, err: "below the minimum host ID"},
+		{name: "overflow", hostID: math.MaxUint32 - 10, size: DefaultSize, err: "overflows"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateRange("uid", tc.hostID, tc.size, DefaultMinHostID)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package consul
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -51,7 +52,7 @@ func (tc *OnUpdateChecksTest) TestOnUpdateCheck_IgnoreWarning_IgnoreErrors(f *fr
 		Retries:  60,
 	}
 	f.NoError(
-		e2eutil.WaitForLastDeploymentStatus(jobID, "", "successful", wc),
+		e2eutil.WaitForLastDeploymentStatus(context.Background(), jobID, "", "successful", wc),
 		"deployment should have completed successfully",
 	)
 
@@ -63,7 +64,7 @@ func (tc *OnUpdateChecksTest) TestOnUpdateCheck_IgnoreWarning_IgnoreErrors(f *fr
 	)
 
 	f.NoError(
-		e2eutil.WaitForLastDeploymentStatus(jobID, "", "successful", wc),
+		e2eutil.WaitForLastDeploymentStatus(context.Background(), jobID, "", "successful", wc),
 		"deployment should have completed successfully",
 	)
 }
@@ -89,7 +90,7 @@ func (tc *OnUpdateChecksTest) TestOnUpdate_CheckRestart(f *framework.F) {
 		Retries:  60,
 	}
 	f.NoError(
-		e2eutil.WaitForLastDeploymentStatus(jobID, "", "successful", wc),
+		e2eutil.WaitForLastDeploymentStatus(context.Background(), jobID, "", "successful", wc),
 		"deployment should have completed successfully",
 	)
 
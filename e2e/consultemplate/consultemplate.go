@@ -1,6 +1,7 @@
 package consultemplate
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -381,7 +382,7 @@ func (tc *ConsulTemplateTest) TestConsulTemplate_NomadServiceLookups(f *framewor
 	_, _, err = tc.Nomad().Jobs().Register(serviceJob, nil)
 	f.NoError(err)
 	tc.jobIDs = append(tc.jobIDs, serviceJobID)
-	f.NoError(e2eutil.WaitForAllocStatusExpected(serviceJobID, "default", []string{"running"}), "job should be running")
+	f.NoError(e2eutil.WaitForAllocStatusExpected(context.Background(), serviceJobID, "default", []string{"running"}), "job should be running")
 
 	// Pull the allocation ID for the job, we use this to ensure this is found
 	// in the rendered template later on.
@@ -401,14 +402,14 @@ func (tc *ConsulTemplateTest) TestConsulTemplate_NomadServiceLookups(f *framewor
 	diffNamespaceServiceJobID := "test-consul-template-nomad-lookups" + uuid.Generate()[0:8]
 	f.NoError(e2eutil.Register(diffNamespaceServiceJobID, "consultemplate/input/nomad_provider_service_ns.nomad"))
 	tc.namespacedJobIDs["platform"] = append(tc.namespacedJobIDs["platform"], diffNamespaceServiceJobID)
-	f.NoError(e2eutil.WaitForAllocStatusExpected(diffNamespaceServiceJobID, "platform", []string{"running"}), "job should be running")
+	f.NoError(e2eutil.WaitForAllocStatusExpected(context.Background(), diffNamespaceServiceJobID, "platform", []string{"running"}), "job should be running")
 
 	// Register a job which includes consul-template function performing Nomad
 	// service listing and reads.
 	serviceLookupJobID := "test-consul-template-nomad-lookups" + uuid.Generate()[0:8]
 	f.NoError(e2eutil.Register(serviceLookupJobID, "consultemplate/input/nomad_provider_service_lookup.nomad"))
 	tc.jobIDs = append(tc.jobIDs, serviceLookupJobID)
-	f.NoError(e2eutil.WaitForAllocStatusExpected(serviceLookupJobID, "default", []string{"running"}), "job should be running")
+	f.NoError(e2eutil.WaitForAllocStatusExpected(context.Background(), serviceLookupJobID, "default", []string{"running"}), "job should be running")
 
 	// Find the allocation ID for the job which contains templates, so we can
 	// perform filesystem actions.
@@ -1,6 +1,7 @@
 package csi
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -145,7 +146,7 @@ func (tc *CSIControllerPluginEBSTest) TestVolumeClaim(f *framework.F) {
 	writeJobID := "write-ebs-" + tc.uuid
 	f.NoError(e2eutil.Register(writeJobID, "csi/input/use-ebs-volume.nomad"))
 	f.NoError(
-		e2eutil.WaitForAllocStatusExpected(writeJobID, ns, []string{"running"}),
+		e2eutil.WaitForAllocStatusExpected(context.Background(), writeJobID, ns, []string{"running"}),
 		"job should be running")
 
 	allocs, err := e2eutil.AllocsForJob(writeJobID, ns)
@@ -175,7 +176,7 @@ func (tc *CSIControllerPluginEBSTest) TestVolumeClaim(f *framework.F) {
 	tc.testJobIDs = append(tc.testJobIDs, readJobID) // ensure failed tests clean up
 	f.NoError(e2eutil.Register(readJobID, "csi/input/use-ebs-volume.nomad"))
 	f.NoError(
-		e2eutil.WaitForAllocStatusExpected(readJobID, ns, []string{"running"}),
+		e2eutil.WaitForAllocStatusExpected(context.Background(), readJobID, ns, []string{"running"}),
 		"job should be running")
 
 	allocs, err = e2eutil.AllocsForJob(readJobID, ns)
@@ -231,7 +232,7 @@ func (tc *CSIControllerPluginEBSTest) TestNodeDrain(f *framework.F) {
 	writeJobID := "write-ebs-for-drain" + tc.uuid
 	f.NoError(e2eutil.Register(writeJobID, "csi/input/use-ebs-volume.nomad"))
 	f.NoError(
-		e2eutil.WaitForAllocStatusExpected(writeJobID, ns, []string{"running"}),
+		e2eutil.WaitForAllocStatusExpected(context.Background(), writeJobID, ns, []string{"running"}),
 		"job should be running")
 	tc.testJobIDs = append(tc.testJobIDs, writeJobID) // ensure failed tests clean up
 
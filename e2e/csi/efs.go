@@ -1,6 +1,7 @@
 package csi
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -77,7 +78,7 @@ func (tc *CSINodeOnlyPluginEFSTest) TestEFSVolumeClaim(f *framework.F) {
 	tc.testJobIDs = append(tc.testJobIDs, writeJobID) // ensure failed tests clean up
 	f.NoError(e2e.Register(writeJobID, "csi/input/use-efs-volume-write.nomad"))
 	f.NoError(
-		e2e.WaitForAllocStatusExpected(writeJobID, ns, []string{"running"}),
+		e2e.WaitForAllocStatusExpected(context.Background(), writeJobID, ns, []string{"running"}),
 		"job should be running")
 
 	allocs, err := e2e.AllocsForJob(writeJobID, ns)
@@ -105,7 +106,7 @@ func (tc *CSINodeOnlyPluginEFSTest) TestEFSVolumeClaim(f *framework.F) {
 	tc.testJobIDs = append(tc.testJobIDs, readJobID) // ensure failed tests clean up
 	f.NoError(e2e.Register(readJobID, "csi/input/use-efs-volume-read.nomad"))
 	f.NoError(
-		e2e.WaitForAllocStatusExpected(readJobID, ns, []string{"running"}),
+		e2e.WaitForAllocStatusExpected(context.Background(), readJobID, ns, []string{"running"}),
 		"job should be running")
 
 	allocs, err = e2e.AllocsForJob(readJobID, ns)
@@ -1,14 +1,12 @@
 package disconnectedclients
 
 import (
-	"fmt"
+	"context"
 	"testing"
 	"time"
 
-	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/e2e/e2eutil"
 	"github.com/hashicorp/nomad/helper/uuid"
-	"github.com/hashicorp/nomad/testutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -18,6 +16,12 @@ const ns = ""
 var wait30s = &e2eutil.WaitConfig{Interval: time.Second, Retries: 30}
 var wait60s = &e2eutil.WaitConfig{Interval: time.Second, Retries: 60}
 
+// netsplit partitions the node from all the servers, leaving the agent
+// running
+func netsplit(nodeID string, after time.Duration) (string, error) {
+	return e2eutil.NetworkPartition(nodeID, nil, after)
+}
+
 type expectedAllocStatus struct {
 	disconnected string
 	unchanged    string
@@ -42,7 +46,7 @@ func TestDisconnectedClients(t *testing.T) {
 			// marked disconnected are replaced
 			name:         "netsplit client no max disconnect",
 			jobFile:      "./input/lost_simple.nomad",
-			disconnectFn: e2eutil.AgentDisconnect,
+			disconnectFn: netsplit,
 			expectedAfterDisconnect: expectedAllocStatus{
 				disconnected: "lost",
 				unchanged:    "running",
@@ -61,7 +65,7 @@ func TestDisconnectedClients(t *testing.T) {
 			// replacements are rolled back after reconnection
 			name:         "netsplit client with max disconnect",
 			jobFile:      "./input/lost_max_disconnect.nomad",
-			disconnectFn: e2eutil.AgentDisconnect,
+			disconnectFn: netsplit,
 			expectedAfterDisconnect: expectedAllocStatus{
 				disconnected: "unknown",
 				unchanged:    "running",
@@ -79,7 +83,7 @@ func TestDisconnectedClients(t *testing.T) {
 			// marked disconnected are replaced
 			name:         "shutdown client no max disconnect",
 			jobFile:      "./input/lost_simple.nomad",
-			disconnectFn: e2eutil.AgentDisconnect,
+			disconnectFn: e2eutil.AgentRestartAfter,
 			expectedAfterDisconnect: expectedAllocStatus{
 				disconnected: "lost",
 				unchanged:    "running",
@@ -97,7 +101,7 @@ func TestDisconnectedClients(t *testing.T) {
 			// marked disconnected are replaced
 			name:         "shutdown client with max disconnect",
 			jobFile:      "./input/lost_max_disconnect.nomad",
-			disconnectFn: e2eutil.AgentDisconnect,
+			disconnectFn: e2eutil.AgentRestartAfter,
 			expectedAfterDisconnect: expectedAllocStatus{
 				disconnected: "unknown",
 				unchanged:    "running",
@@ -115,6 +119,9 @@ func TestDisconnectedClients(t *testing.T) {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+			defer cancel()
+
 			jobIDs := []string{}
 			t.Cleanup(disconnectedClientsCleanup(t))
 			t.Cleanup(e2eutil.CleanupJobsAndGC(t, &jobIDs))
@@ -125,21 +132,21 @@ func TestDisconnectedClients(t *testing.T) {
 			require.NoError(t, err)
 			jobIDs = append(jobIDs, jobID)
 
-			err = e2eutil.WaitForAllocStatusExpected(jobID, ns,
+			err = e2eutil.WaitForAllocStatusExpected(ctx, jobID, ns,
 				[]string{"running", "running"})
 			require.NoError(t, err, "job should be running")
 
-			err = e2eutil.WaitForLastDeploymentStatus(jobID, ns, "successful", nil)
+			err = e2eutil.WaitForLastDeploymentStatus(ctx, jobID, ns, "successful", nil)
 			require.NoError(t, err, "success", "deployment did not complete")
 
 			// pick one alloc to make our disconnected alloc (and its node)
-			allocs, err := e2eutil.AllocsForJob(jobID, ns)
+			allocs, err := e2eutil.AllocInfosForJob(jobID, ns)
 			require.NoError(t, err, "could not query allocs for job")
 			require.Len(t, allocs, 2, "could not find 2 allocs for job")
 
-			disconnectedAllocID := allocs[0]["ID"]
-			disconnectedNodeID := allocs[0]["Node ID"]
-			unchangedAllocID := allocs[1]["ID"]
+			disconnectedAllocID := allocs[0].ID
+			disconnectedNodeID := allocs[0].NodeID
+			unchangedAllocID := allocs[1].ID
 
 			// disconnect the node and wait for the results
 
@@ -147,22 +154,22 @@ func TestDisconnectedClients(t *testing.T) {
 			require.NoError(t, err, "expected agent disconnect job to register")
 			jobIDs = append(jobIDs, restartJobID)
 
-			err = e2eutil.WaitForNodeStatus(disconnectedNodeID, "disconnected", wait60s)
+			err = e2eutil.WaitForNodeStatus(ctx, disconnectedNodeID, "disconnected", wait60s)
 			require.NoError(t, err, "expected node to go down")
 
 			require.NoError(t, waitForAllocStatusMap(
-				jobID, disconnectedAllocID, unchangedAllocID, tc.expectedAfterDisconnect, wait60s))
+				ctx, jobID, disconnectedAllocID, unchangedAllocID, tc.expectedAfterDisconnect, wait60s))
 
-			allocs, err = e2eutil.AllocsForJob(jobID, ns)
+			allocs, err = e2eutil.AllocInfosForJob(jobID, ns)
 			require.NoError(t, err, "could not query allocs for job")
 			require.Len(t, allocs, 3, "could not find 3 allocs for job")
 
 			// wait for the reconnect and wait for the results
 
-			err = e2eutil.WaitForNodeStatus(disconnectedNodeID, "ready", wait30s)
+			err = e2eutil.WaitForNodeStatus(ctx, disconnectedNodeID, "ready", wait30s)
 			require.NoError(t, err, "expected node to come back up")
 			require.NoError(t, waitForAllocStatusMap(
-				jobID, disconnectedAllocID, unchangedAllocID, tc.expectedAfterReconnect, wait60s))
+				ctx, jobID, disconnectedAllocID, unchangedAllocID, tc.expectedAfterReconnect, wait60s))
 		})
 	}
 
@@ -184,59 +191,44 @@ func disconnectedClientsCleanup(t *testing.T) func() {
 	}
 }
 
-func waitForAllocStatusMap(jobID, disconnectedAllocID, unchangedAllocID string, expected expectedAllocStatus, wc *e2eutil.WaitConfig) error {
-	var err error
-	interval, retries := wc.OrDefault()
-	testutil.WaitForResultRetries(retries, func() (bool, error) {
-		time.Sleep(interval)
-		allocs, err := e2eutil.AllocsForJob(jobID, ns)
+// waitForAllocStatusMap waits for the disconnected, unchanged, and
+// replacement allocs of the job to reach their expected statuses. If they
+// never do, it returns a *e2eutil.WaitError describing the mismatched allocs.
+func waitForAllocStatusMap(ctx context.Context, jobID, disconnectedAllocID, unchangedAllocID string, expected expectedAllocStatus, wc *e2eutil.WaitConfig) error {
+	var mismatches []*e2eutil.StatusMismatch
+	err := e2eutil.Poll(ctx, wc, func() (bool, error) {
+		allocs, err := e2eutil.AllocInfosForJob(jobID, ns)
 		if err != nil {
 			return false, err
 		}
 
-		var merr *multierror.Error
-
+		mismatches = nil
 		for _, alloc := range allocs {
-			switch allocID, allocStatus := alloc["ID"], alloc["Status"]; allocID {
+			want := expected.replacement
+			switch alloc.ID {
 			case disconnectedAllocID:
-				if allocStatus != expected.disconnected {
-					merr = multierror.Append(merr, fmt.Errorf(
-						"disconnected alloc %q on node %q should be %q, got %q",
-						allocID, alloc["Node ID"], expected.disconnected, allocStatus))
-				}
+				want = expected.disconnected
 			case unchangedAllocID:
-				if allocStatus != expected.unchanged {
-					merr = multierror.Append(merr, fmt.Errorf(
-						"unchanged alloc %q on node %q should be %q, got %q",
-						allocID, alloc["Node ID"], expected.unchanged, allocStatus))
-				}
-			default:
-				if allocStatus != expected.replacement {
-					merr = multierror.Append(merr, fmt.Errorf(
-						"replacement alloc %q on node %q should be %q, got %q",
-						allocID, alloc["Node ID"], expected.replacement, allocStatus))
-				}
+				want = expected.unchanged
+			}
+			if alloc.ClientStatus != want {
+				mismatches = append(mismatches, &e2eutil.StatusMismatch{
+					ID:       alloc.ID,
+					NodeID:   alloc.NodeID,
+					Expected: want,
+					Actual:   alloc.ClientStatus,
+				})
 			}
 		}
-		if merr != nil {
-			return false, merr.ErrorOrNil()
-		}
-		return true, nil
-	}, func(e error) {
-		err = e
+		return len(mismatches) == 0, nil
 	})
-
-	// TODO(tgross): remove this block once this test has stabilized
 	if err != nil {
-		fmt.Printf("test failed, printing allocation status of all %q allocs for analysis\n", jobID)
-		fmt.Println("----------------")
-		allocs, _ := e2eutil.AllocsForJob(jobID, ns)
-		for _, alloc := range allocs {
-			out, _ := e2eutil.Command("nomad", "alloc", "status", alloc["ID"])
-			fmt.Println(out)
-			fmt.Println("----------------")
+		return &e2eutil.WaitError{
+			Kind:        "alloc",
+			Mismatches:  mismatches,
+			Err:         err,
+			Diagnostics: e2eutil.AllocDiagnostics(jobID, ns),
 		}
 	}
-
-	return err
+	return nil
 }
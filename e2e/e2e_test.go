@@ -42,6 +42,7 @@ import (
 	// we get a quick check that they compile on every commit
 	_ "github.com/hashicorp/nomad/e2e/disconnectedclients"
 	_ "github.com/hashicorp/nomad/e2e/namespaces"
+	_ "github.com/hashicorp/nomad/e2e/upgradeinplace"
 	_ "github.com/hashicorp/nomad/e2e/volumes"
 )
 
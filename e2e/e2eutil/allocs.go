@@ -1,15 +1,14 @@
 package e2eutil
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"reflect"
 	"strings"
 	"time"
 
 	api "github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/testutil"
-	"github.com/kr/pretty"
 )
 
 // AllocsByName sorts allocs by Name
@@ -27,20 +26,80 @@ func (a AllocsByName) Swap(i, j int) {
 	a[i], a[j] = a[j], a[i]
 }
 
-// WaitForAllocStatusExpected polls 'nomad job status' and exactly compares
-// the status of all allocations (including any previous versions) against the
-// expected list.
-func WaitForAllocStatusExpected(jobID, ns string, expected []string) error {
-	err := WaitForAllocStatusComparison(
-		func() ([]string, error) { return AllocStatuses(jobID, ns) },
-		func(got []string) bool { return reflect.DeepEqual(got, expected) },
-		nil,
-	)
+// WaitForAllocStatusExpected polls the allocations of the job and exactly
+// compares the status of all allocations (including any previous versions)
+// against the expected list, until they match, the default WaitConfig runs
+// out, or the context is done. If they never match, it returns a *WaitError
+// describing the mismatched allocations.
+func WaitForAllocStatusExpected(ctx context.Context, jobID, ns string, expected []string) error {
+	var mismatches []*StatusMismatch
+	err := Poll(ctx, nil, func() (bool, error) {
+		allocs, err := AllocInfosForJob(jobID, ns)
+		if err != nil {
+			return false, err
+		}
+		mismatches = allocStatusMismatches(allocs, expected)
+		return len(mismatches) == 0, nil
+	})
 	if err != nil {
-		allocs, _ := AllocsForJob(jobID, ns)
-		err = fmt.Errorf("%v\nallocs: %v", err, pretty.Sprint(allocs))
+		return &WaitError{
+			Kind:        "alloc",
+			Mismatches:  mismatches,
+			Err:         err,
+			Diagnostics: AllocDiagnostics(jobID, ns),
+		}
 	}
-	return err
+	return nil
+}
+
+// allocStatusMismatches compares the client status of each allocation with
+// the expected status at the same position.
+func allocStatusMismatches(allocs []*AllocInfo, expected []string) []*StatusMismatch {
+	var mismatches []*StatusMismatch
+	for i := 0; i < len(allocs) || i < len(expected); i++ {
+		m := &StatusMismatch{}
+		if i < len(allocs) {
+			m.ID, m.NodeID, m.Actual = allocs[i].ID, allocs[i].NodeID, allocs[i].ClientStatus
+		}
+		if i < len(expected) {
+			m.Expected = expected[i]
+		}
+		if m.Expected != m.Actual {
+			mismatches = append(mismatches, m)
+		}
+	}
+	return mismatches
+}
+
+// AllocDiagnostics returns the output of 'nomad alloc status' for each
+// allocation of the job, for tests to report when they fail. Errors are
+// included in the output rather than returned.
+func AllocDiagnostics(jobID, ns string) string {
+	allocs, err := AllocInfosForJob(jobID, ns)
+	if err != nil {
+		return fmt.Sprintf("could not query allocs of job %q: %v", jobID, err)
+	}
+
+	var nsArg = []string{}
+	if ns != "" {
+		nsArg = []string{"-namespace", ns}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "allocation status of all %d %q allocs:\n", len(allocs), jobID)
+	for _, alloc := range allocs {
+		cmd := []string{"nomad", "alloc", "status"}
+		cmd = append(cmd, nsArg...)
+		cmd = append(cmd, alloc.ID)
+
+		out, err := Command(cmd[0], cmd[1:]...)
+		if err != nil {
+			fmt.Fprintf(&b, "could not get status of alloc %q: %v\n", alloc.ID, err)
+		}
+		b.WriteString("----------------\n")
+		b.WriteString(out)
+	}
+	return b.String()
 }
 
 // WaitForAllocStatusComparison is a convenience wrapper that polls the query
@@ -62,51 +121,122 @@ func WaitForAllocStatusComparison(query func() ([]string, error), comparison fun
 	return err
 }
 
-// AllocsForJob returns a slice of key->value maps, each describing the values
-// of the 'nomad job status' Allocations section (not actual
-// structs.Allocation objects, query the API if you want those)
-func AllocsForJob(jobID, ns string) ([]map[string]string, error) {
+// AllocInfo describes an allocation as returned by the typed alloc helpers
+// such as AllocInfosForJob.
+type AllocInfo struct {
+	ID            string
+	EvalID        string
+	NodeID        string
+	NodeName      string
+	JobID         string
+	JobVersion    uint64
+	TaskGroup     string
+	ClientStatus  string
+	DesiredStatus string
+	CreateTime    time.Time
+	ModifyTime    time.Time
+}
 
-	var nsArg = []string{}
-	if ns != "" {
-		nsArg = []string{"-namespace", ns}
+func newAllocInfo(stub *api.AllocationListStub) *AllocInfo {
+	return &AllocInfo{
+		ID:            stub.ID,
+		EvalID:        stub.EvalID,
+		NodeID:        stub.NodeID,
+		NodeName:      stub.NodeName,
+		JobID:         stub.JobID,
+		JobVersion:    stub.JobVersion,
+		TaskGroup:     stub.TaskGroup,
+		ClientStatus:  stub.ClientStatus,
+		DesiredStatus: stub.DesiredStatus,
+		CreateTime:    stub.CreateTime,
+		ModifyTime:    stub.ModifyTime,
 	}
+}
 
-	cmd := []string{"nomad", "job", "status"}
-	params := []string{"-verbose", "-all-allocs", jobID}
-	cmd = append(cmd, nsArg...)
-	cmd = append(cmd, params...)
+// columns returns the allocation keyed by the column names of the verbose
+// 'nomad job status' Allocations section, for the map-based helpers.
+func (a *AllocInfo) columns() map[string]string {
+	const timeFormat = "01/02/06 15:04:05 MST"
+	return map[string]string{
+		"ID":         a.ID,
+		"Eval ID":    a.EvalID,
+		"Node ID":    a.NodeID,
+		"Node Name":  a.NodeName,
+		"Task Group": a.TaskGroup,
+		"Version":    fmt.Sprintf("%d", a.JobVersion),
+		"Desired":    a.DesiredStatus,
+		"Status":     a.ClientStatus,
+		"Created":    a.CreateTime.Format(timeFormat),
+		"Modified":   a.ModifyTime.Format(timeFormat),
+	}
+}
 
-	out, err := Command(cmd[0], cmd[1:]...)
+// AllocInfosForJob returns all the allocations of the job, including those
+// of previous versions of the job, in the same order as 'nomad job status'.
+func AllocInfosForJob(jobID, ns string) ([]*AllocInfo, error) {
+	nomadClient, err := api.NewClient(api.DefaultConfig())
 	if err != nil {
-		return nil, fmt.Errorf("'nomad job status' failed: %w", err)
+		return nil, fmt.Errorf("could not create Nomad client: %w", err)
 	}
 
-	section, err := GetSection(out, "Allocations")
+	stubs, _, err := nomadClient.Jobs().Allocations(jobID, true, &api.QueryOptions{Namespace: ns})
 	if err != nil {
-		return nil, fmt.Errorf("could not find Allocations section: %w", err)
+		return nil, fmt.Errorf("could not query allocations for job: %w", err)
 	}
 
-	allocs, err := ParseColumns(section)
+	allocs := make([]*AllocInfo, 0, len(stubs))
+	for _, stub := range stubs {
+		allocs = append(allocs, newAllocInfo(stub))
+	}
+	return allocs, nil
+}
+
+// AllocInfosForNode returns all the allocations on the node, in the same
+// order as 'nomad node status'.
+func AllocInfosForNode(nodeID string) ([]*AllocInfo, error) {
+	nomadClient, err := api.NewClient(api.DefaultConfig())
 	if err != nil {
-		return nil, fmt.Errorf("could not parse Allocations section: %w", err)
+		return nil, fmt.Errorf("could not create Nomad client: %w", err)
+	}
+
+	nodeAllocs, _, err := nomadClient.Nodes().Allocations(nodeID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not query allocations for node: %w", err)
+	}
+
+	allocs := make([]*AllocInfo, 0, len(nodeAllocs))
+	for _, alloc := range nodeAllocs {
+		allocs = append(allocs, newAllocInfo(alloc.Stub()))
 	}
 	return allocs, nil
 }
 
+// AllocsForJob returns a slice of key->value maps, each describing the values
+// of the 'nomad job status' Allocations section (not actual
+// structs.Allocation objects, query the API if you want those)
+//
+// Deprecated: use AllocInfosForJob instead.
+func AllocsForJob(jobID, ns string) ([]map[string]string, error) {
+	allocs, err := AllocInfosForJob(jobID, ns)
+	if err != nil {
+		return nil, err
+	}
+	return allocColumns(allocs), nil
+}
+
 // AllocTaskEventsForJob returns a map of allocation IDs containing a map of
 // Task Event key value pairs
 func AllocTaskEventsForJob(jobID, ns string) (map[string][]map[string]string, error) {
-	allocs, err := AllocsForJob(jobID, ns)
+	allocs, err := AllocInfosForJob(jobID, ns)
 	if err != nil {
 		return nil, err
 	}
 
 	results := make(map[string][]map[string]string)
 	for _, alloc := range allocs {
-		results[alloc["ID"]] = make([]map[string]string, 0)
+		results[alloc.ID] = make([]map[string]string, 0)
 
-		cmd := []string{"nomad", "alloc", "status", alloc["ID"]}
+		cmd := []string{"nomad", "alloc", "status", alloc.ID}
 		out, err := Command(cmd[0], cmd[1:]...)
 		if err != nil {
 			return nil, fmt.Errorf("querying alloc status: %w", err)
@@ -121,7 +251,7 @@ func AllocTaskEventsForJob(jobID, ns string) (map[string][]map[string]string, er
 		if err != nil {
 			return nil, fmt.Errorf("could not parse recent events section: %w", err)
 		}
-		results[alloc["ID"]] = events
+		results[alloc.ID] = events
 	}
 
 	return results, nil
@@ -130,36 +260,35 @@ func AllocTaskEventsForJob(jobID, ns string) (map[string][]map[string]string, er
 // AllocsForNode returns a slice of key->value maps, each describing the values
 // of the 'nomad node status' Allocations section (not actual
 // structs.Allocation objects, query the API if you want those)
+//
+// Deprecated: use AllocInfosForNode instead.
 func AllocsForNode(nodeID string) ([]map[string]string, error) {
-
-	out, err := Command("nomad", "node", "status", "-verbose", nodeID)
-	if err != nil {
-		return nil, fmt.Errorf("'nomad node status' failed: %w", err)
-	}
-
-	section, err := GetSection(out, "Allocations")
+	allocs, err := AllocInfosForNode(nodeID)
 	if err != nil {
-		return nil, fmt.Errorf("could not find Allocations section: %w", err)
+		return nil, err
 	}
+	return allocColumns(allocs), nil
+}
 
-	allocs, err := ParseColumns(section)
-	if err != nil {
-		return nil, fmt.Errorf("could not parse Allocations section: %w", err)
+func allocColumns(allocs []*AllocInfo) []map[string]string {
+	out := make([]map[string]string, 0, len(allocs))
+	for _, alloc := range allocs {
+		out = append(out, alloc.columns())
 	}
-	return allocs, nil
+	return out
 }
 
 // AllocStatuses returns a slice of client statuses
 func AllocStatuses(jobID, ns string) ([]string, error) {
 
-	allocs, err := AllocsForJob(jobID, ns)
+	allocs, err := AllocInfosForJob(jobID, ns)
 	if err != nil {
 		return nil, err
 	}
 
 	statuses := []string{}
 	for _, alloc := range allocs {
-		statuses = append(statuses, alloc["Status"])
+		statuses = append(statuses, alloc.ClientStatus)
 	}
 	return statuses, nil
 }
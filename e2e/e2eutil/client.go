@@ -13,7 +13,9 @@ import (
 // NomadClient creates a default Nomad client based on the env vars
 // from the test environment. Fails the test if it can't be created
 func NomadClient(t *testing.T) *napi.Client {
-	client, err := napi.NewClient(napi.DefaultConfig())
+	conf := napi.DefaultConfig()
+	conf.RetryPolicy = napi.DefaultRetryPolicy()
+	client, err := napi.NewClient(conf)
 	require.NoError(t, err, "could not create Nomad client")
 	return client
 }
@@ -1,24 +1,21 @@
 package e2eutil
 
 import (
+	"context"
 	"fmt"
-	"time"
-
-	"github.com/hashicorp/nomad/testutil"
 )
 
-func WaitForLastDeploymentStatus(jobID, ns, status string, wc *WaitConfig) error {
+// WaitForLastDeploymentStatus polls the status of the latest deployment of
+// the job until it matches, the WaitConfig runs out, or the context is done.
+// If it never matches, it returns a *WaitError describing the deployment.
+func WaitForLastDeploymentStatus(ctx context.Context, jobID, ns, status string, wc *WaitConfig) error {
 	var nsArg = []string{}
 	if ns != "" {
 		nsArg = []string{"-namespace", ns}
 	}
 
-	var got string
-	var err error
-	interval, retries := wc.OrDefault()
-	testutil.WaitForResultRetries(retries, func() (bool, error) {
-		time.Sleep(interval)
-
+	mismatch := &StatusMismatch{Expected: status}
+	err := Poll(ctx, wc, func() (bool, error) {
 		cmd := []string{"nomad", "job", "status"}
 		cmd = append(cmd, nsArg...)
 		cmd = append(cmd, jobID)
@@ -38,12 +35,17 @@ func WaitForLastDeploymentStatus(jobID, ns, status string, wc *WaitConfig) error
 			return false, fmt.Errorf("could not parse Latest Deployment section: %w", err)
 		}
 
-		got = fields["Status"]
-		return got == status, nil
-	}, func(e error) {
-		err = fmt.Errorf("deployment status check failed: got %#v", got)
+		mismatch.ID, mismatch.Actual = fields["ID"], fields["Status"]
+		return mismatch.Actual == status, nil
 	})
-	return err
+	if err != nil {
+		return &WaitError{
+			Kind:       "deployment",
+			Mismatches: []*StatusMismatch{mismatch},
+			Err:        err,
+		}
+	}
+	return nil
 }
 
 func LastDeploymentID(jobID, ns string) (string, error) {
@@ -0,0 +1,116 @@
+package e2eutil
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// NewRootKey returns a random AES-256 root key which can be installed with
+// InstallRootKey. The key is inactive, so installing it doesn't change the key
+// used to encrypt new secure variables.
+func NewRootKey(t *testing.T) *api.RootKey {
+	material := make([]byte, 32)
+	_, err := rand.Read(material)
+	require.NoError(t, err, "could not generate root key")
+
+	return &api.RootKey{
+		Meta: &api.RootKeyMeta{
+			KeyID:      uuid.Generate(),
+			Algorithm:  api.EncryptionAlgorithmAES256GCM,
+			CreateTime: time.Now(),
+			State:      api.RootKeyStateInactive,
+		},
+		Key: base64.StdEncoding.EncodeToString(material),
+	}
+}
+
+// InstallRootKey installs a known root key in the keyring, and waits for it
+// to be listed in the state it was installed with.
+func InstallRootKey(t *testing.T, nomadClient *api.Client, key *api.RootKey) {
+	_, err := nomadClient.Keyring().Update(key, nil)
+	require.NoError(t, err, "could not install root key")
+
+	WaitForRootKeyState(t, nomadClient, key.Meta.KeyID, key.Meta.State)
+}
+
+// RotateRootKey rotates the keyring and returns the metadata of the new
+// active key once it is listed. If full is set, all the secure variables are
+// re-encrypted with the new key, see WaitForRekey.
+func RotateRootKey(t *testing.T, nomadClient *api.Client, full bool) *api.RootKeyMeta {
+	meta, _, err := nomadClient.Keyring().Rotate(&api.KeyringRotateOptions{Full: full}, nil)
+	require.NoError(t, err, "could not rotate root key")
+	require.NotNil(t, meta, "rotation did not return a root key")
+
+	WaitForRootKeyState(t, nomadClient, meta.KeyID, api.RootKeyStateActive)
+	return meta
+}
+
+// ActiveRootKey returns the metadata of the active root key.
+func ActiveRootKey(t *testing.T, nomadClient *api.Client) *api.RootKeyMeta {
+	keys, _, err := nomadClient.Keyring().List(nil)
+	require.NoError(t, err, "could not list root keys")
+	for _, key := range keys {
+		if key.State == api.RootKeyStateActive {
+			return key
+		}
+	}
+	require.FailNow(t, "no active root key")
+	return nil
+}
+
+// WaitForRootKeyState waits for the root key to be listed in the given state.
+func WaitForRootKeyState(t *testing.T, nomadClient *api.Client, keyID string, state api.RootKeyState) {
+	testutil.WaitForResultRetries(retries, func() (bool, error) {
+		time.Sleep(time.Millisecond * 100)
+		keys, _, err := nomadClient.Keyring().List(nil)
+		if err != nil {
+			return false, fmt.Errorf("error listing root keys: %v", err)
+		}
+		for _, key := range keys {
+			if key.KeyID == keyID {
+				return key.State == state,
+					fmt.Errorf("root key %s is %s, expected %s", keyID, key.State, state)
+			}
+		}
+		return false, fmt.Errorf("root key %s not found", keyID)
+	}, func(err error) {
+		require.NoError(t, err, "root key did not reach the expected state")
+	})
+}
+
+// WaitForRekey waits for the secure variables to be re-encrypted after a full
+// rotation, when no root key is left in the rekeying state.
+func WaitForRekey(t *testing.T, nomadClient *api.Client) {
+	testutil.WaitForResultRetries(retries, func() (bool, error) {
+		time.Sleep(time.Millisecond * 100)
+		keys, _, err := nomadClient.Keyring().List(nil)
+		if err != nil {
+			return false, fmt.Errorf("error listing root keys: %v", err)
+		}
+		for _, key := range keys {
+			if key.State == api.RootKeyStateRekeying {
+				return false, fmt.Errorf("root key %s is still rekeying", key.KeyID)
+			}
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err, "secure variables were not re-encrypted")
+	})
+}
+
+// AssertSecureVariableDecryptable asserts that the servers can decrypt the
+// secure variable at path, and that it holds the expected items.
+func AssertSecureVariableDecryptable(t *testing.T, nomadClient *api.Client, namespace, path string, expected api.SecureVariableItems) {
+	sv, _, err := nomadClient.SecureVariables().Read(path, &api.QueryOptions{Namespace: namespace})
+	require.NoError(t, err, "could not read secure variable %q", path)
+	require.NotNil(t, sv, "secure variable %q not found", path)
+	require.Equal(t, expected, sv.Items, "unexpected items for secure variable %q", path)
+}
@@ -1,41 +1,53 @@
 package e2eutil
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/nomad/api"
-	"github.com/hashicorp/nomad/testutil"
 )
 
 // AgentDisconnect is a test helper function that runs a raw_exec job
 // that will disconnect a client at the network level and reconnect it
-// after the specified period of time.
+// after the specified period of time. It partitions the client from all
+// the servers, see NetworkPartition.
 //
 // Returns once the job is registered with the job ID of the restart
 // job and any registration errors, not after the duration, so that
 // callers can take actions while the client is down.
 func AgentDisconnect(nodeID string, after time.Duration) (string, error) {
-	jobID := "disconnect-" + nodeID
+	return NetworkPartition(nodeID, nil, after)
+}
+
+// NetworkPartition is a test helper function that runs a raw_exec job
+// that blocks the client's RPC connections to the target server
+// addresses, or to all servers if there are no targets, and removes the
+// block after the specified period of time. Unlike AgentRestartAfter, the
+// agent and its tasks keep running while the client is partitioned, so
+// tests can distinguish a netsplit from an agent restart. The rules are
+// injected with iptables, or nftables if iptables isn't installed.
+//
+// Returns once the job is registered with the job ID of the partition
+// job and any registration errors, not after the duration, so that
+// callers can take actions while the client is partitioned.
+func NetworkPartition(nodeID string, targets []string, after time.Duration) (string, error) {
+	jobID := "partition-" + nodeID
 	vars := []string{"-var", "nodeID=" + nodeID}
+	if len(targets) > 0 {
+		vars = append(vars, "-var", "targets="+strings.Join(targets, " "))
+	}
 	if after > 0 {
 		vars = append(vars, "-var", fmt.Sprintf("time=%d", int(after.Seconds())))
 	}
 
-	jobFilePath := "../e2eutil/input/disconnect-node.nomad"
-
-	// TODO: temporary hack around having older tests running on the
-	// framework vs new tests not, as the framework has a different
-	// working directory
-	dir, err := os.Getwd()
+	jobFilePath, err := inputJobPath("partition-node.nomad")
 	if err != nil {
 		return "", err
 	}
-	if filepath.Base(dir) == "e2e" {
-		jobFilePath = "e2eutil/input/disconnect-node.nomad"
-	}
 
 	err = RegisterWithArgs(jobID, jobFilePath, vars...)
 	return jobID, err
@@ -55,8 +67,17 @@ func AgentRestartAfter(nodeID string, after time.Duration) (string, error) {
 		vars = append(vars, "-var", fmt.Sprintf("time=%d", int(after.Seconds())))
 	}
 
-	jobFilePath := "../e2eutil/input/restart-node.nomad"
+	jobFilePath, err := inputJobPath("restart-node.nomad")
+	if err != nil {
+		return "", err
+	}
 
+	err = RegisterWithArgs(jobID, jobFilePath, vars...)
+	return jobID, err
+}
+
+// inputJobPath returns the path to one of the e2eutil input jobs.
+func inputJobPath(name string) (string, error) {
 	// TODO: temporary hack around having older tests running on the
 	// framework vs new tests not, as the framework has a different
 	// working directory
@@ -65,11 +86,9 @@ func AgentRestartAfter(nodeID string, after time.Duration) (string, error) {
 		return "", err
 	}
 	if filepath.Base(dir) == "e2e" {
-		jobFilePath = "e2eutil/input/restart-node.nomad"
+		return filepath.Join("e2eutil/input", name), nil
 	}
-
-	err = RegisterWithArgs(jobID, jobFilePath, vars...)
-	return jobID, err
+	return filepath.Join("../e2eutil/input", name), nil
 }
 
 // AgentRestart is a test helper function that restarts a client node
@@ -186,28 +205,31 @@ func NodeStatusListFiltered(filterFn func(string) bool) ([]map[string]string, er
 	return nodes, nil
 }
 
-func WaitForNodeStatus(nodeID, status string, wc *WaitConfig) error {
-	var got string
-	var err error
-	interval, retries := wc.OrDefault()
-	testutil.WaitForResultRetries(retries, func() (bool, error) {
-		time.Sleep(interval)
-
+// WaitForNodeStatus polls the status of the node until it matches, the
+// WaitConfig runs out, or the context is done. If it never matches, it
+// returns a *WaitError describing the node.
+func WaitForNodeStatus(ctx context.Context, nodeID, status string, wc *WaitConfig) error {
+	mismatch := &StatusMismatch{ID: nodeID, Expected: status}
+	err := Poll(ctx, wc, func() (bool, error) {
 		nodeStatuses, err := NodeStatusList()
 		if err != nil {
 			return false, err
 		}
+		mismatch.Actual = ""
 		for _, nodeStatus := range nodeStatuses {
 			if nodeStatus["ID"] == nodeID {
-				got = nodeStatus["Status"]
-				if got == status {
-					return true, nil
-				}
+				mismatch.Actual = nodeStatus["Status"]
+				return mismatch.Actual == status, nil
 			}
 		}
 		return false, nil
-	}, func(e error) {
-		err = fmt.Errorf("node status check failed: got %#v", got)
 	})
-	return err
+	if err != nil {
+		return &WaitError{
+			Kind:       "node",
+			Mismatches: []*StatusMismatch{mismatch},
+			Err:        err,
+		}
+	}
+	return nil
 }
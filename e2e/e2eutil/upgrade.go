@@ -0,0 +1,158 @@
+package e2eutil
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// UpgradeConfig describes how to upgrade the agents of a cluster provisioned
+// by the e2e Terraform stack in place. See UpgradeConfigFromEnv.
+type UpgradeConfig struct {
+	// Binary is the path to the local Nomad binary to install.
+	Binary string
+
+	// Version is the version reported by Binary, used to check that each
+	// agent is running the new binary after its restart.
+	Version string
+
+	// SSHKey is the path to the private key used to ssh into the agents.
+	SSHKey string
+
+	// SSHUser is the user to ssh into Linux agents as.
+	SSHUser string
+
+	// Servers are the public IP addresses of the servers.
+	Servers []string
+}
+
+// UpgradeConfigFromEnv returns the upgrade config from the environment, or
+// skips the test if the environment doesn't describe an upgrade. The
+// following environment variables are used:
+//
+//   - NOMAD_E2E_UPGRADE_BINARY: path to the Nomad binary to upgrade to
+//   - NOMAD_E2E_UPGRADE_VERSION: the version of that binary (ex. "1.4.0")
+//   - NOMAD_E2E_SSH_KEY: path to the ssh private key for the cluster
+//   - NOMAD_E2E_SERVERS: comma-separated public IP addresses of the servers
+//
+// The last two are set by the "environment" output of the Terraform stack.
+func UpgradeConfigFromEnv(t *testing.T) *UpgradeConfig {
+	cfg := &UpgradeConfig{
+		Binary:  os.Getenv("NOMAD_E2E_UPGRADE_BINARY"),
+		Version: os.Getenv("NOMAD_E2E_UPGRADE_VERSION"),
+		SSHKey:  os.Getenv("NOMAD_E2E_SSH_KEY"),
+		SSHUser: "ubuntu",
+	}
+	if cfg.Binary == "" || cfg.Version == "" {
+		t.Skip("skipping upgrade test, NOMAD_E2E_UPGRADE_BINARY and NOMAD_E2E_UPGRADE_VERSION must be set")
+	}
+	require.NotEmpty(t, cfg.SSHKey, "NOMAD_E2E_SSH_KEY must be set to upgrade agents")
+
+	servers := os.Getenv("NOMAD_E2E_SERVERS")
+	require.NotEmpty(t, servers, "NOMAD_E2E_SERVERS must be set to upgrade servers")
+	cfg.Servers = strings.Split(servers, ",")
+
+	_, err := os.Stat(cfg.Binary)
+	require.NoError(t, err, "could not find upgrade binary")
+	return cfg
+}
+
+// UpgradeServers replaces the Nomad binary of each server and restarts it,
+// one at a time. Before moving on to the next server, it waits for the
+// cluster to have a leader and for the upgraded server to rejoin with the new
+// version, so that quorum is never lost.
+func UpgradeServers(t *testing.T, nomadClient *api.Client, cfg *UpgradeConfig) {
+	for i, addr := range cfg.Servers {
+		t.Logf("upgrading server %s to %s", addr, cfg.Version)
+		upgradeAgent(t, cfg, addr)
+
+		WaitForLeader(t, nomadClient)
+		waitForServersUpgraded(t, nomadClient, cfg.Version, i+1)
+	}
+}
+
+// UpgradeClients replaces the Nomad binary of each Linux client and restarts
+// it, one at a time, waiting for the client to be ready with the new version
+// before moving on to the next one. Clients are restarted rather than
+// drained, so that their allocations are expected to survive the upgrade.
+func UpgradeClients(t *testing.T, nomadClient *api.Client, cfg *UpgradeConfig) {
+	nodeIDs, err := ListLinuxClientNodes(nomadClient)
+	require.NoError(t, err, "could not list Linux clients")
+
+	for _, nodeID := range nodeIDs {
+		node, _, err := nomadClient.Nodes().Info(nodeID, nil)
+		require.NoError(t, err, "could not read node %s", nodeID)
+
+		addr := node.Attributes["unique.platform.aws.public-ipv4"]
+		require.NotEmpty(t, addr, "node %s has no public IP address", nodeID)
+
+		t.Logf("upgrading client %s (%s) to %s", nodeID, addr, cfg.Version)
+		upgradeAgent(t, cfg, addr)
+		waitForClientUpgraded(t, nomadClient, nodeID, cfg.Version)
+	}
+}
+
+// upgradeAgent copies the binary to the host and restarts the agent with it.
+func upgradeAgent(t *testing.T, cfg *UpgradeConfig, addr string) {
+	target := fmt.Sprintf("%s@%s", cfg.SSHUser, addr)
+	opts := []string{"-i", cfg.SSHKey, "-o", "StrictHostKeyChecking=no"}
+
+	args := append(append([]string{}, opts...), cfg.Binary, target+":/tmp/nomad")
+	out, err := exec.Command("scp", args...).CombinedOutput()
+	require.NoError(t, err, "could not copy binary to %s: %s", addr, out)
+
+	args = append(append([]string{}, opts...), target,
+		"sudo mv /tmp/nomad /usr/local/bin/nomad && "+
+			"sudo chmod +x /usr/local/bin/nomad && "+
+			"sudo systemctl restart nomad")
+	out, err = exec.Command("ssh", args...).CombinedOutput()
+	require.NoError(t, err, "could not restart agent on %s: %s", addr, out)
+}
+
+// waitForServersUpgraded waits for at least count alive servers to report
+// the version.
+func waitForServersUpgraded(t *testing.T, nomadClient *api.Client, version string, count int) {
+	testutil.WaitForResultRetries(retries, func() (bool, error) {
+		defer time.Sleep(time.Millisecond * 500)
+		members, err := nomadClient.Agent().Members()
+		if err != nil {
+			return false, fmt.Errorf("error listing servers: %v", err)
+		}
+
+		upgraded := 0
+		for _, member := range members.Members {
+			if member.Status == "alive" && strings.HasPrefix(member.Tags["build"], version) {
+				upgraded++
+			}
+		}
+		return upgraded >= count,
+			fmt.Errorf("only %d servers alive at version %s (wanted %d)", upgraded, version, count)
+	}, func(err error) {
+		require.NoError(t, err, "server was not upgraded")
+	})
+}
+
+// waitForClientUpgraded waits for the node to be ready and report the version.
+func waitForClientUpgraded(t *testing.T, nomadClient *api.Client, nodeID, version string) {
+	testutil.WaitForResultRetries(retries, func() (bool, error) {
+		defer time.Sleep(time.Millisecond * 500)
+		node, _, err := nomadClient.Nodes().Info(nodeID, nil)
+		if err != nil {
+			return false, fmt.Errorf("error reading node: %v", err)
+		}
+		if !strings.HasPrefix(node.Attributes["nomad.version"], version) {
+			return false, fmt.Errorf("node %s is at version %s (wanted %s)",
+				nodeID, node.Attributes["nomad.version"], version)
+		}
+		return node.Status == "ready", fmt.Errorf("node %s is %s", nodeID, node.Status)
+	}, func(err error) {
+		require.NoError(t, err, "client was not upgraded")
+	})
+}
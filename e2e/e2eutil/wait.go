@@ -1,6 +1,11 @@
 package e2eutil
 
-import "time"
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
 
 // WaitConfig is an interval and wait time that can be passed to a waiter
 // function, but with a default value that comes from the OrDefault method
@@ -23,3 +28,103 @@ func (wc *WaitConfig) OrDefault() (time.Duration, int64) {
 	}
 	return wc.Interval, wc.Retries
 }
+
+// Poll calls check every interval of the WaitConfig until it returns true,
+// the retries of the WaitConfig run out, or the context is done. It returns
+// the error of the last call to check, or the error of the context if check
+// didn't return one.
+func Poll(ctx context.Context, wc *WaitConfig, check func() (bool, error)) error {
+	interval, retries := wc.OrDefault()
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	var err error
+	for i := int64(0); i < retries; i++ {
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			if err == nil {
+				err = ctx.Err()
+			}
+			return err
+		}
+
+		var ok bool
+		ok, err = check()
+		if ok {
+			return nil
+		}
+		timer.Reset(interval)
+	}
+	if err == nil {
+		err = fmt.Errorf("retries exhausted")
+	}
+	return err
+}
+
+// StatusMismatch is an object whose status didn't match the expected status
+// when a WaitFor helper gave up. Expected is empty for objects that weren't
+// expected at all, and Actual is empty for objects that weren't found.
+type StatusMismatch struct {
+	ID       string
+	NodeID   string
+	Expected string
+	Actual   string
+}
+
+func (m *StatusMismatch) String() string {
+	var b strings.Builder
+	if m.ID != "" {
+		fmt.Fprintf(&b, "%q ", m.ID)
+	}
+	if m.NodeID != "" {
+		fmt.Fprintf(&b, "on node %q ", m.NodeID)
+	}
+	switch {
+	case m.Expected == "":
+		fmt.Fprintf(&b, "not expected, got %q", m.Actual)
+	case m.Actual == "":
+		fmt.Fprintf(&b, "should be %q, not found", m.Expected)
+	default:
+		fmt.Fprintf(&b, "should be %q, got %q", m.Expected, m.Actual)
+	}
+	return b.String()
+}
+
+// WaitError is returned by the WaitFor helpers when the objects they wait on
+// don't reach the expected status in time. Tests can retrieve it with
+// errors.As to assert on the mismatches.
+type WaitError struct {
+	// Kind is the kind of object waited on, such as "alloc" or "node".
+	Kind string
+
+	// Mismatches are the objects whose status was wrong the last time they
+	// were checked.
+	Mismatches []*StatusMismatch
+
+	// Err is the error of the last check or of the context, if any.
+	Err error
+
+	// Diagnostics is the output of commands describing the objects, such as
+	// 'nomad alloc status', captured when the helper gave up.
+	Diagnostics string
+}
+
+func (e *WaitError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s status check failed", e.Kind)
+	if e.Err != nil {
+		fmt.Fprintf(&b, ": %v", e.Err)
+	}
+	for _, m := range e.Mismatches {
+		fmt.Fprintf(&b, "\n  %s %s", e.Kind, m)
+	}
+	if e.Diagnostics != "" {
+		fmt.Fprintf(&b, "\n%s", e.Diagnostics)
+	}
+	return b.String()
+}
+
+func (e *WaitError) Unwrap() error {
+	return e.Err
+}
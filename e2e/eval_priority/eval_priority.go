@@ -1,6 +1,7 @@
 package eval_priority
 
 import (
+	"context"
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/e2e/e2eutil"
 	"github.com/hashicorp/nomad/e2e/framework"
@@ -51,7 +52,7 @@ func (tc *EvalPriorityTest) TestEvalPrioritySet(f *framework.F) {
 	tc.jobIDs = append(tc.jobIDs, jobID)
 
 	// Wait for the deployment to finish.
-	f.NoError(e2eutil.WaitForLastDeploymentStatus(jobID, "default", "successful", nil))
+	f.NoError(e2eutil.WaitForLastDeploymentStatus(context.Background(), jobID, "default", "successful", nil))
 
 	// Pull the job evaluation list from the API and ensure that this didn't
 	// error and contains two evals.
@@ -77,7 +78,7 @@ func (tc *EvalPriorityTest) TestEvalPrioritySet(f *framework.F) {
 	// priority.
 	f.NoError(e2eutil.RegisterWithArgs(jobID, "eval_priority/inputs/thirteen_job_priority.nomad",
 		"-eval-priority=7", "-var", "image=busybox:1.34"))
-	f.NoError(e2eutil.WaitForLastDeploymentStatus(jobID, "default", "successful",
+	f.NoError(e2eutil.WaitForLastDeploymentStatus(context.Background(), jobID, "default", "successful",
 		&e2eutil.WaitConfig{Retries: 200}))
 
 	// Pull the latest list of evaluations for the job which will include those
@@ -126,7 +127,7 @@ func (tc *EvalPriorityTest) TestEvalPriorityNotSet(f *framework.F) {
 	tc.jobIDs = append(tc.jobIDs, jobID)
 
 	// Wait for the deployment to finish.
-	f.NoError(e2eutil.WaitForLastDeploymentStatus(jobID, "default", "successful", nil))
+	f.NoError(e2eutil.WaitForLastDeploymentStatus(context.Background(), jobID, "default", "successful", nil))
 
 	// Pull the job evaluation list from the API and ensure that this didn't
 	// error and contains two evals.
@@ -151,7 +152,7 @@ func (tc *EvalPriorityTest) TestEvalPriorityNotSet(f *framework.F) {
 	// Update the job image without setting an eval priority.
 	f.NoError(e2eutil.RegisterWithArgs(jobID, "eval_priority/inputs/thirteen_job_priority.nomad",
 		"-var", "image=busybox:1.34"))
-	f.NoError(e2eutil.WaitForLastDeploymentStatus(jobID, "default", "successful",
+	f.NoError(e2eutil.WaitForLastDeploymentStatus(context.Background(), jobID, "default", "successful",
 		&e2eutil.WaitConfig{Retries: 200}))
 
 	// Pull the latest list of evaluations for the job which will include those
@@ -96,7 +96,9 @@ func (p *singleClusterProvisioner) SetupTestCase(t *testing.T, opts SetupOptions
 	}
 
 	// Build Nomad api client
-	nomadClient, err := napi.NewClient(napi.DefaultConfig())
+	nomadConf := napi.DefaultConfig()
+	nomadConf.RetryPolicy = napi.DefaultRetryPolicy()
+	nomadClient, err := napi.NewClient(nomadConf)
 	if err != nil {
 		return nil, err
 	}
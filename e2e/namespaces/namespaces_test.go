@@ -1,6 +1,7 @@
 package namespaces
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
@@ -50,7 +51,7 @@ func TestNamespacesFiltering(t *testing.T) {
 		must.NoError(t, e2eutil.Register(jobID, jobspec))
 		namespacedJobIDs = append(namespacedJobIDs, [2]string{ns, jobID})
 		expected := []string{"running"}
-		require.NoError(t, e2eutil.WaitForAllocStatusExpected(jobID, ns, expected), "job should be running")
+		require.NoError(t, e2eutil.WaitForAllocStatusExpected(context.Background(), jobID, ns, expected), "job should be running")
 		return jobID
 	}
 
@@ -1,6 +1,7 @@
 package networking
 
 import (
+	"context"
 	"os"
 	"strings"
 
@@ -50,7 +51,7 @@ func (tc *NetworkingE2ETest) TestNetworking_DockerBridgedHostname(f *framework.F
 	jobID := "test-networking-" + uuid.Generate()[0:8]
 	f.NoError(e2eutil.Register(jobID, "networking/inputs/docker_bridged_hostname.nomad"))
 	tc.jobIDs = append(tc.jobIDs, jobID)
-	f.NoError(e2eutil.WaitForAllocStatusExpected(jobID, "default", []string{"running"}),
+	f.NoError(e2eutil.WaitForAllocStatusExpected(context.Background(), jobID, "default", []string{"running"}),
 		"job should be running with 1 alloc")
 
 	// Grab the allocations for the job.
@@ -74,7 +75,7 @@ func (tc *NetworkingE2ETest) TestNetworking_DockerBridgedHostnameInterpolation(f
 	jobID := "test-networking-" + uuid.Generate()[0:8]
 	f.NoError(e2eutil.Register(jobID, "networking/inputs/docker_bridged_hostname_interpolation.nomad"))
 	tc.jobIDs = append(tc.jobIDs, jobID)
-	f.NoError(e2eutil.WaitForAllocStatusExpected(jobID, "default", []string{"running"}),
+	f.NoError(e2eutil.WaitForAllocStatusExpected(context.Background(), jobID, "default", []string{"running"}),
 		"job should be running with 1 alloc")
 
 	// Grab the allocations for the job.
@@ -1,6 +1,7 @@
 package nodedrain
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -103,7 +104,7 @@ func (tc *NodeDrainE2ETest) TestNodeDrainEphemeralMigrate(f *framework.F) {
 	tc.jobIDs = append(tc.jobIDs, jobID)
 
 	expected := []string{"running"}
-	f.NoError(e2e.WaitForAllocStatusExpected(jobID, ns, expected), "job should be running")
+	f.NoError(e2e.WaitForAllocStatusExpected(context.Background(), jobID, ns, expected), "job should be running")
 
 	allocs, err := e2e.AllocsForJob(jobID, ns)
 	f.NoError(err, "could not get allocs for job")
@@ -132,7 +133,7 @@ func (tc *NodeDrainE2ETest) TestNodeDrainEphemeralMigrate(f *framework.F) {
 
 	// wait for the allocation to be migrated
 	expected = []string{"running", "complete"}
-	f.NoError(e2e.WaitForAllocStatusExpected(jobID, ns, expected), "job should be running")
+	f.NoError(e2e.WaitForAllocStatusExpected(context.Background(), jobID, ns, expected), "job should be running")
 
 	allocs, err = e2e.AllocsForJob(jobID, ns)
 	f.NoError(err, "could not get allocations for job")
@@ -179,7 +180,7 @@ func (tc *NodeDrainE2ETest) TestNodeDrainIgnoreSystem(f *framework.F) {
 	f.NoError(e2e.Register(serviceJobID, "nodedrain/input/drain_simple.nomad"))
 	tc.jobIDs = append(tc.jobIDs, serviceJobID)
 
-	f.NoError(e2e.WaitForAllocStatusExpected(serviceJobID, ns, []string{"running"}))
+	f.NoError(e2e.WaitForAllocStatusExpected(context.Background(), serviceJobID, ns, []string{"running"}))
 
 	allocs, err := e2e.AllocsForJob(serviceJobID, ns)
 	f.NoError(err, "could not get allocs for service job")
@@ -190,7 +191,7 @@ func (tc *NodeDrainE2ETest) TestNodeDrainIgnoreSystem(f *framework.F) {
 	tc.jobIDs = append(tc.jobIDs, systemJobID)
 
 	expected := []string{"running"}
-	f.NoError(e2e.WaitForAllocStatusExpected(serviceJobID, ns, expected),
+	f.NoError(e2e.WaitForAllocStatusExpected(context.Background(), serviceJobID, ns, expected),
 		"service job should be running")
 
 	// can't just give it a static list because the number of nodes can vary
@@ -253,7 +254,7 @@ func (tc *NodeDrainE2ETest) TestNodeDrainDeadline(f *framework.F) {
 	tc.jobIDs = append(tc.jobIDs, jobID)
 
 	expected := []string{"running"}
-	f.NoError(e2e.WaitForAllocStatusExpected(jobID, ns, expected), "job should be running")
+	f.NoError(e2e.WaitForAllocStatusExpected(context.Background(), jobID, ns, expected), "job should be running")
 
 	nodes, err := nodesForJob(jobID)
 	f.NoError(err, "could not get nodes for job")
@@ -296,7 +297,7 @@ func (tc *NodeDrainE2ETest) TestNodeDrainForce(f *framework.F) {
 	tc.jobIDs = append(tc.jobIDs, jobID)
 
 	expected := []string{"running"}
-	f.NoError(e2e.WaitForAllocStatusExpected(jobID, ns, expected), "job should be running")
+	f.NoError(e2e.WaitForAllocStatusExpected(context.Background(), jobID, ns, expected), "job should be running")
 
 	nodes, err := nodesForJob(jobID)
 	f.NoError(err, "could not get nodes for job")
@@ -1,6 +1,7 @@
 package rescheduling
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"reflect"
@@ -60,7 +61,7 @@ func (tc *RescheduleE2ETest) TestNoReschedule(f *framework.F) {
 
 	expected := []string{"failed", "failed", "failed"}
 	f.NoError(
-		e2e.WaitForAllocStatusExpected(jobID, ns, expected),
+		e2e.WaitForAllocStatusExpected(context.Background(), jobID, ns, expected),
 		"should have exactly 3 failed allocs",
 	)
 }
@@ -96,7 +97,7 @@ func (tc *RescheduleE2ETest) TestDefaultReschedule(f *framework.F) {
 
 	expected := []string{"failed", "failed", "failed"}
 	f.NoError(
-		e2e.WaitForAllocStatusExpected(jobID, ns, expected),
+		e2e.WaitForAllocStatusExpected(context.Background(), jobID, ns, expected),
 		"should have exactly 3 failed allocs",
 	)
 
@@ -105,7 +106,7 @@ func (tc *RescheduleE2ETest) TestDefaultReschedule(f *framework.F) {
 	time.Sleep(time.Second * 35)
 	expected = []string{"failed", "failed", "failed", "failed", "failed", "failed"}
 	f.NoError(
-		e2e.WaitForAllocStatusExpected(jobID, ns, expected),
+		e2e.WaitForAllocStatusExpected(context.Background(), jobID, ns, expected),
 		"should have exactly 6 failed allocs after 35s",
 	)
 }
@@ -119,7 +120,7 @@ func (tc *RescheduleE2ETest) TestRescheduleMaxAttempts(f *framework.F) {
 
 	expected := []string{"failed", "failed", "failed"}
 	f.NoError(
-		e2e.WaitForAllocStatusExpected(jobID, ns, expected),
+		e2e.WaitForAllocStatusExpected(context.Background(), jobID, ns, expected),
 		"should have exactly 3 failed allocs",
 	)
 
@@ -179,7 +180,7 @@ func (tc *RescheduleE2ETest) TestRescheduleWithUpdate(f *framework.F) {
 
 	expected := []string{"running", "running", "running"}
 	f.NoError(
-		e2e.WaitForAllocStatusExpected(jobID, ns, expected),
+		e2e.WaitForAllocStatusExpected(context.Background(), jobID, ns, expected),
 		"should have exactly 3 running allocs",
 	)
 
@@ -210,12 +211,12 @@ func (tc *RescheduleE2ETest) TestRescheduleWithCanary(f *framework.F) {
 
 	expected := []string{"running", "running", "running"}
 	f.NoError(
-		e2e.WaitForAllocStatusExpected(jobID, ns, expected),
+		e2e.WaitForAllocStatusExpected(context.Background(), jobID, ns, expected),
 		"should have exactly 3 running allocs",
 	)
 
 	f.NoError(
-		e2e.WaitForLastDeploymentStatus(jobID, ns, "successful", nil),
+		e2e.WaitForLastDeploymentStatus(context.Background(), jobID, ns, "successful", nil),
 		"deployment should be successful")
 
 	// reschedule to make fail
@@ -235,7 +236,7 @@ func (tc *RescheduleE2ETest) TestRescheduleWithCanary(f *framework.F) {
 	)
 
 	f.NoError(
-		e2e.WaitForLastDeploymentStatus(jobID, ns, "running", nil),
+		e2e.WaitForLastDeploymentStatus(context.Background(), jobID, ns, "running", nil),
 		"deployment should be running")
 }
 
@@ -249,12 +250,12 @@ func (tc *RescheduleE2ETest) TestRescheduleWithCanaryAutoRevert(f *framework.F)
 
 	expected := []string{"running", "running", "running"}
 	f.NoError(
-		e2e.WaitForAllocStatusExpected(jobID, ns, expected),
+		e2e.WaitForAllocStatusExpected(context.Background(), jobID, ns, expected),
 		"should have exactly 3 running allocs",
 	)
 
 	f.NoError(
-		e2e.WaitForLastDeploymentStatus(jobID, ns, "successful", nil),
+		e2e.WaitForLastDeploymentStatus(context.Background(), jobID, ns, "successful", nil),
 		"deployment should be successful")
 
 	// reschedule to make fail
@@ -276,12 +277,12 @@ func (tc *RescheduleE2ETest) TestRescheduleWithCanaryAutoRevert(f *framework.F)
 	// then we'll fail and revert
 	expected = []string{"failed", "failed", "failed", "running", "running", "running"}
 	f.NoError(
-		e2e.WaitForAllocStatusExpected(jobID, ns, expected),
+		e2e.WaitForAllocStatusExpected(context.Background(), jobID, ns, expected),
 		"should have exactly 3 running reverted allocs",
 	)
 
 	f.NoError(
-		e2e.WaitForLastDeploymentStatus(jobID, ns, "successful", nil),
+		e2e.WaitForLastDeploymentStatus(context.Background(), jobID, ns, "successful", nil),
 		"deployment should be successful")
 }
 
@@ -294,12 +295,12 @@ func (tc *RescheduleE2ETest) TestRescheduleMaxParallel(f *framework.F) {
 
 	expected := []string{"running", "running", "running"}
 	f.NoError(
-		e2e.WaitForAllocStatusExpected(jobID, ns, expected),
+		e2e.WaitForAllocStatusExpected(context.Background(), jobID, ns, expected),
 		"should have exactly 3 running allocs",
 	)
 
 	f.NoError(
-		e2e.WaitForLastDeploymentStatus(jobID, ns, "successful", nil),
+		e2e.WaitForLastDeploymentStatus(context.Background(), jobID, ns, "successful", nil),
 		"deployment should be successful")
 
 	// reschedule to make fail
@@ -324,7 +325,7 @@ func (tc *RescheduleE2ETest) TestRescheduleMaxParallel(f *framework.F) {
 	)
 
 	f.NoError(
-		e2e.WaitForLastDeploymentStatus(jobID, ns, "running", nil),
+		e2e.WaitForLastDeploymentStatus(context.Background(), jobID, ns, "running", nil),
 		"deployment should be running")
 }
 
@@ -338,12 +339,12 @@ func (tc *RescheduleE2ETest) TestRescheduleMaxParallelAutoRevert(f *framework.F)
 
 	expected := []string{"running", "running", "running"}
 	f.NoError(
-		e2e.WaitForAllocStatusExpected(jobID, ns, expected),
+		e2e.WaitForAllocStatusExpected(context.Background(), jobID, ns, expected),
 		"should have exactly 3 running allocs",
 	)
 
 	f.NoError(
-		e2e.WaitForLastDeploymentStatus(jobID, ns, "successful", nil),
+		e2e.WaitForLastDeploymentStatus(context.Background(), jobID, ns, "successful", nil),
 		"deployment should be successful")
 
 	// reschedule to make fail
@@ -379,7 +380,7 @@ func (tc *RescheduleE2ETest) TestRescheduleMaxParallelAutoRevert(f *framework.F)
 	// deployment to be marked complete before we can assert that it's successful
 	// and verify the count of deployments
 	f.NoError(
-		e2e.WaitForLastDeploymentStatus(jobID, ns, "successful", nil),
+		e2e.WaitForLastDeploymentStatus(context.Background(), jobID, ns, "successful", nil),
 		"most recent deployment should be successful")
 
 	out, err := e2e.Command("nomad", "deployment", "status")
@@ -410,7 +411,7 @@ func (tc *RescheduleE2ETest) TestRescheduleProgressDeadline(f *framework.F) {
 
 	expected := []string{"running"}
 	f.NoError(
-		e2e.WaitForAllocStatusExpected(jobID, ns, expected),
+		e2e.WaitForAllocStatusExpected(context.Background(), jobID, ns, expected),
 		"should have a running allocation",
 	)
 
@@ -425,7 +426,7 @@ func (tc *RescheduleE2ETest) TestRescheduleProgressDeadline(f *framework.F) {
 	f.NoError(err, "could not get new progress deadline")
 	f.NotEqual(oldDeadline, newDeadline, "progress deadline should have been updated")
 
-	f.NoError(e2e.WaitForLastDeploymentStatus(jobID, ns, "successful", nil),
+	f.NoError(e2e.WaitForLastDeploymentStatus(context.Background(), jobID, ns, "successful", nil),
 		"deployment should be successful")
 }
 
@@ -451,7 +452,7 @@ func (tc *RescheduleE2ETest) TestRescheduleProgressDeadlineFail(f *framework.F)
 	f.NoError(err, "could not get progress deadline")
 	time.Sleep(time.Second * 20)
 
-	f.NoError(e2e.WaitForLastDeploymentStatus(jobID, ns, "failed", nil),
+	f.NoError(e2e.WaitForLastDeploymentStatus(context.Background(), jobID, ns, "failed", nil),
 		"deployment should be failed")
 
 	f.NoError(
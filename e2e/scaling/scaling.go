@@ -1,6 +1,7 @@
 package scaling
 
 import (
+	"context"
 	"os"
 
 	"github.com/hashicorp/nomad/api"
@@ -64,12 +65,12 @@ func (tc *ScalingE2ETest) TestScalingBasic(f *framework.F) {
 	jobID := "test-scaling-" + uuid.Generate()[0:8]
 	f.NoError(e2eutil.Register(jobID, "scaling/input/namespace_default_1.nomad"))
 	tc.namespacedJobIDs = append(tc.namespacedJobIDs, [2]string{defaultNS, jobID})
-	f.NoError(e2eutil.WaitForAllocStatusExpected(jobID, defaultNS, []string{"running", "running"}),
+	f.NoError(e2eutil.WaitForAllocStatusExpected(context.Background(), jobID, defaultNS, []string{"running", "running"}),
 		"job should be running with 2 allocs")
 
 	// Ensure we wait for the deployment to finish, otherwise scaling will
 	// fail.
-	f.NoError(e2eutil.WaitForLastDeploymentStatus(jobID, defaultNS, "successful", nil))
+	f.NoError(e2eutil.WaitForLastDeploymentStatus(context.Background(), jobID, defaultNS, "successful", nil))
 
 	// Simple scaling action.
 	testMeta := map[string]interface{}{"scaling-e2e-test": "value"}
@@ -78,12 +79,12 @@ func (tc *ScalingE2ETest) TestScalingBasic(f *framework.F) {
 		"Nomad e2e testing", false, testMeta, nil)
 	f.NoError(err)
 	f.NotEmpty(scaleResp.EvalID)
-	f.NoError(e2eutil.WaitForAllocStatusExpected(jobID, defaultNS, []string{"running", "running", "running"}),
+	f.NoError(e2eutil.WaitForAllocStatusExpected(context.Background(), jobID, defaultNS, []string{"running", "running", "running"}),
 		"job should be running with 3 allocs")
 
 	// Ensure we wait for the deployment to finish, otherwise scaling will
 	// fail for this reason.
-	f.NoError(e2eutil.WaitForLastDeploymentStatus(jobID, defaultNS, "successful", nil))
+	f.NoError(e2eutil.WaitForLastDeploymentStatus(context.Background(), jobID, defaultNS, "successful", nil))
 
 	// Attempt break break the policy min/max parameters.
 	_, _, err = tc.Nomad().Jobs().Scale(
@@ -131,8 +132,8 @@ func (tc *ScalingE2ETest) TestScalingNamespaces(f *framework.F) {
 	// Register and wait for the job deployments to succeed.
 	f.NoError(e2eutil.Register(defaultJobID, "scaling/input/namespace_default_1.nomad"))
 	f.NoError(e2eutil.Register(aJobID, "scaling/input/namespace_a_1.nomad"))
-	f.NoError(e2eutil.WaitForLastDeploymentStatus(defaultJobID, defaultNS, "successful", nil))
-	f.NoError(e2eutil.WaitForLastDeploymentStatus(aJobID, ANS, "successful", nil))
+	f.NoError(e2eutil.WaitForLastDeploymentStatus(context.Background(), defaultJobID, defaultNS, "successful", nil))
+	f.NoError(e2eutil.WaitForLastDeploymentStatus(context.Background(), aJobID, ANS, "successful", nil))
 
 	tc.namespacedJobIDs = append(tc.namespacedJobIDs, [2]string{defaultNS, defaultJobID})
 	tc.namespacedJobIDs = append(tc.namespacedJobIDs, [2]string{ANS, aJobID})
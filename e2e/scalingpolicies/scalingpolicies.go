@@ -1,6 +1,7 @@
 package scalingpolicies
 
 import (
+	"context"
 	"os"
 
 	"github.com/hashicorp/nomad/api"
@@ -183,6 +184,6 @@ func (tc *ScalingPolicyE2ETest) run(f *framework.F, jobSpec, ns string, expected
 	jobID := "test-scaling-policy-" + uuid.Generate()[0:8]
 	f.NoError(e2eutil.Register(jobID, jobSpec))
 	tc.namespacedJobIDs = append(tc.namespacedJobIDs, [2]string{ns, jobID})
-	f.NoError(e2eutil.WaitForAllocStatusExpected(jobID, ns, expected), "job should be running")
+	f.NoError(e2eutil.WaitForAllocStatusExpected(context.Background(), jobID, ns, expected), "job should be running")
 	return jobID
 }
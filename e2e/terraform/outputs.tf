@@ -51,6 +51,8 @@ export NOMAD_CLIENT_CERT=${abspath(path.root)}/keys/tls_api_client.crt
 export NOMAD_CLIENT_KEY=${abspath(path.root)}/keys/tls_api_client.key
 export NOMAD_TOKEN=${data.local_file.nomad_token.content}
 export NOMAD_E2E=1
+export NOMAD_E2E_SSH_KEY=${abspath(path.root)}/keys/${local.random_name}.pem
+export NOMAD_E2E_SERVERS=${join(",", aws_instance.server.*.public_ip)}
 
 EOM
 }
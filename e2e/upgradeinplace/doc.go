@@ -0,0 +1,4 @@
+package upgradeinplace
+
+// This package contains only tests, so this is a placeholder file to
+// make sure builds don't fail with "no non-test Go files in" errors
@@ -0,0 +1,121 @@
+package upgradeinplace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/e2e/e2eutil"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ns = "default"
+
+// TestUpgradeInPlace upgrades the servers and then the clients of a running
+// cluster to a new version of Nomad, and asserts that workloads keep running
+// on their original allocations and that secure variables written before the
+// upgrade can still be decrypted by every server.
+func TestUpgradeInPlace(t *testing.T) {
+
+	cfg := e2eutil.UpgradeConfigFromEnv(t)
+
+	nomad := e2eutil.NomadClient(t)
+	e2eutil.WaitForLeader(t, nomad)
+	e2eutil.WaitForNodesReady(t, nomad, 2) // jobs are spread over 2 hosts
+
+	jobIDs := []string{}
+	t.Cleanup(e2eutil.CleanupJobsAndGC(t, &jobIDs))
+
+	vars := map[string]api.SecureVariableItems{}
+	t.Cleanup(func() {
+		for path := range vars {
+			_, err := nomad.SecureVariables().Delete(path, nil)
+			assert.NoError(t, err)
+		}
+	})
+
+	for _, jobFile := range []string{
+		"./input/service.nomad",
+		"./input/max_disconnect.nomad",
+	} {
+		jobID := "test-upgrade-" + uuid.Short()
+		require.NoError(t, e2eutil.Register(jobID, jobFile))
+		jobIDs = append(jobIDs, jobID)
+
+		err := e2eutil.WaitForAllocStatusExpected(context.Background(), jobID, ns, []string{"running", "running"})
+		require.NoError(t, err, "job should be running")
+		err = e2eutil.WaitForLastDeploymentStatus(context.Background(), jobID, ns, "successful", nil)
+		require.NoError(t, err, "deployment did not complete")
+
+		// write a secure variable the job's workload identity can read
+		path := "nomad/jobs/" + jobID
+		sv := api.NewSecureVariable(path)
+		sv.Items["secret"] = uuid.Generate()
+		_, _, err = nomad.SecureVariables().Create(sv, nil)
+		require.NoError(t, err, "could not write secure variable")
+		vars[path] = sv.Items
+	}
+
+	allocs := runningAllocs(t, nomad, jobIDs)
+
+	e2eutil.UpgradeServers(t, nomad, cfg)
+	assertNoAllocChurn(t, nomad, jobIDs, allocs)
+	assertVarsDecryptable(t, nomad, vars)
+
+	e2eutil.UpgradeClients(t, nomad, cfg)
+	assertNoAllocChurn(t, nomad, jobIDs, allocs)
+	assertVarsDecryptable(t, nomad, vars)
+}
+
+// runningAllocs returns the IDs of the running allocations of the jobs.
+func runningAllocs(t *testing.T, nomad *api.Client, jobIDs []string) []string {
+	allocIDs := []string{}
+	for _, jobID := range jobIDs {
+		stubs, _, err := nomad.Jobs().Allocations(jobID, true, nil)
+		require.NoError(t, err, "could not list allocations for job %s", jobID)
+		for _, stub := range stubs {
+			if stub.ClientStatus == api.AllocClientStatusRunning {
+				allocIDs = append(allocIDs, stub.ID)
+			}
+		}
+	}
+	return allocIDs
+}
+
+// assertNoAllocChurn asserts that the jobs' allocations are exactly the ones
+// that were running before the upgrade, and that they are still running.
+func assertNoAllocChurn(t *testing.T, nomad *api.Client, jobIDs, expected []string) {
+	allocIDs := []string{}
+	for _, jobID := range jobIDs {
+		stubs, _, err := nomad.Jobs().Allocations(jobID, true, nil)
+		require.NoError(t, err, "could not list allocations for job %s", jobID)
+		for _, stub := range stubs {
+			allocIDs = append(allocIDs, stub.ID)
+			require.Equal(t, api.AllocClientStatusRunning, stub.ClientStatus,
+				"allocation %s of job %s is not running", stub.ID, jobID)
+		}
+	}
+	require.ElementsMatch(t, expected, allocIDs, "allocations were replaced")
+}
+
+// assertVarsDecryptable asserts that the secure variables can be decrypted,
+// both by the leader and by followers serving stale reads, and that the
+// servers didn't fail to decrypt anything.
+func assertVarsDecryptable(t *testing.T, nomad *api.Client, vars map[string]api.SecureVariableItems) {
+	for path, items := range vars {
+		e2eutil.AssertSecureVariableDecryptable(t, nomad, ns, path, items)
+
+		sv, _, err := nomad.SecureVariables().Read(path, &api.QueryOptions{AllowStale: true})
+		require.NoError(t, err, "could not read secure variable %q", path)
+		require.Equal(t, items, sv.Items, "unexpected items for secure variable %q", path)
+	}
+
+	metrics, _, err := nomad.Operator().MetricsSummary(nil)
+	require.NoError(t, err, "could not read metrics")
+	for _, counter := range metrics.Counters {
+		require.NotEqual(t, "nomad.keyring.decrypt_failures", counter.Name,
+			"server failed to decrypt with key %s", counter.DisplayLabels["key_id"])
+	}
+}
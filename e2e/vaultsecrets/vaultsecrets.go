@@ -127,7 +127,7 @@ func (tc *VaultSecretsTest) TestVaultSecrets(f *framework.F) {
 	tc.jobIDs = append(tc.jobIDs, jobID)
 
 	// job doesn't have access to secrets, so they can't start
-	err = e2e.WaitForAllocStatusExpected(jobID, ns, []string{"pending"})
+	err = e2e.WaitForAllocStatusExpected(context.Background(), jobID, ns, []string{"pending"})
 	f.NoError(err, "expected pending allocation")
 
 	// we should get a task event about why they can't start
@@ -158,7 +158,7 @@ func (tc *VaultSecretsTest) TestVaultSecrets(f *framework.F) {
 	ttlStart := time.Now()
 
 	// job should be now unblocked
-	err = e2e.WaitForAllocStatusExpected(jobID, ns, []string{"running", "complete"})
+	err = e2e.WaitForAllocStatusExpected(context.Background(), jobID, ns, []string{"running", "complete"})
 	f.NoError(err, "expected running allocation")
 
 	allocID, err = latestAllocID(jobID)
@@ -1,6 +1,7 @@
 package volumes
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -33,7 +34,7 @@ func TestVolumeMounts(t *testing.T) {
 	jobIDs = append(jobIDs, jobID)
 
 	expected := []string{"running"}
-	require.NoError(t, e2eutil.WaitForAllocStatusExpected(jobID, ns, expected),
+	require.NoError(t, e2eutil.WaitForAllocStatusExpected(context.Background(), jobID, ns, expected),
 		"job should be running")
 
 	allocs, err := e2eutil.AllocsForJob(jobID, ns)
@@ -0,0 +1,17 @@
+// Package mlock provides helpers to keep secret material, such as keyring
+// keys, out of swap and to clear it from memory once it's no longer needed.
+package mlock
+
+import "errors"
+
+// ErrUnsupported is returned by Lock and Alloc on platforms that can't lock memory.
+var ErrUnsupported = errors.New("memory locking is not supported on this platform")
+
+// Zero overwrites b with zeros. Callers should zero buffers holding secret
+// material as soon as they are done with them, rather than waiting for the
+// garbage collector to reuse the memory.
+func Zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
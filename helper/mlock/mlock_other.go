@@ -0,0 +1,25 @@
+//go:build !linux && !darwin && !freebsd
+
+package mlock
+
+// Supported returns whether memory locking is supported on this platform.
+func Supported() bool {
+	return false
+}
+
+// Lock always returns ErrUnsupported on this platform.
+func Lock(b []byte) error {
+	return ErrUnsupported
+}
+
+// Alloc always returns ErrUnsupported on this platform.
+func Alloc(n int) ([]byte, error) {
+	return nil, ErrUnsupported
+}
+
+// Free zeros b. Alloc never succeeds on this platform, so there is nothing
+// else to release.
+func Free(b []byte) error {
+	Zero(b)
+	return nil
+}
@@ -0,0 +1,52 @@
+package mlock
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestZero(t *testing.T) {
+	ci.Parallel(t)
+
+	b := []byte("secret")
+	Zero(b)
+	must.Eq(t, make([]byte, 6), b)
+}
+
+func TestLock(t *testing.T) {
+	ci.Parallel(t)
+
+	if !Supported() {
+		must.ErrorIs(t, Lock([]byte("secret")), ErrUnsupported)
+		return
+	}
+	must.NoError(t, Lock(nil))
+	must.NoError(t, Lock(make([]byte, 32)))
+}
+
+func TestAllocFree(t *testing.T) {
+	ci.Parallel(t)
+
+	if !Supported() {
+		_, err := Alloc(32)
+		must.ErrorIs(t, err, ErrUnsupported)
+		return
+	}
+
+	b, err := Alloc(0)
+	must.NoError(t, err)
+	must.Nil(t, b)
+	must.NoError(t, Free(b))
+
+	b, err = Alloc(96)
+	if err != nil {
+		// RLIMIT_MEMLOCK may be too low to lock anything
+		t.Skipf("could not lock memory: %v", err)
+	}
+	must.Len(t, 96, b)
+	must.Eq(t, make([]byte, 96), b)
+	copy(b, "secret")
+	must.NoError(t, Free(b))
+}
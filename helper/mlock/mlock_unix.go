@@ -0,0 +1,51 @@
+//go:build linux || darwin || freebsd
+
+package mlock
+
+import "golang.org/x/sys/unix"
+
+// Supported returns whether memory locking is supported on this platform.
+func Supported() bool {
+	return true
+}
+
+// Lock locks the pages backing b into memory so that they are never written
+// to swap. Locking is per page, so unlocking b would also unlock whatever
+// else shares its pages; buffers that need to be unlocked later should come
+// from Alloc instead.
+func Lock(b []byte) error {
+	if len(b) == 0 {
+		return nil
+	}
+	return unix.Mlock(b)
+}
+
+// Alloc returns an n byte buffer on pages of its own, outside the Go heap,
+// locked into memory. The buffer must be released with Free.
+func Alloc(n int) ([]byte, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	b, err := unix.Mmap(-1, 0, n, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_ANON|unix.MAP_PRIVATE)
+	if err != nil {
+		return nil, err
+	}
+	if err := unix.Mlock(b); err != nil {
+		unix.Munmap(b)
+		return nil, err
+	}
+	return b, nil
+}
+
+// Free zeros a buffer returned by Alloc, unlocks it and returns its pages to
+// the operating system. b must not be used afterwards.
+func Free(b []byte) error {
+	if len(b) == 0 {
+		return nil
+	}
+	Zero(b)
+	if err := unix.Munlock(b); err != nil {
+		return err
+	}
+	return unix.Munmap(b)
+}
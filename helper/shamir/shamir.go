@@ -0,0 +1,169 @@
+// Package shamir implements Shamir's Secret Sharing over GF(2^8), to split a
+// secret into shares of which any threshold number can reconstruct it, while
+// fewer shares reveal nothing about it.
+//
+// Each byte of the secret is the constant term of a random polynomial of
+// degree threshold-1. A share holds the value of every polynomial at the same
+// non-zero x coordinate, which is appended as the last byte of the share.
+package shamir
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const (
+	// MaxShares is the maximum number of shares, limited by the number of
+	// non-zero x coordinates in GF(2^8).
+	MaxShares = 255
+
+	// ShareOverhead is the number of bytes a share adds to the secret.
+	ShareOverhead = 1
+)
+
+// mul multiplies a and b in GF(2^8) with the AES polynomial x^8+x^4+x^3+x+1.
+// It uses the shift-and-add method with masks instead of branches or table
+// lookups, so that its timing does not depend on the secret operands.
+func mul(a, b byte) byte {
+	var r byte
+	for i := 0; i < 8; i++ {
+		r ^= a & -(b & 1)
+		b >>= 1
+		a = a<<1 ^ 0x1b&-(a>>7)
+	}
+	return r
+}
+
+// inverse returns the multiplicative inverse of a, computed in constant time
+// as a^254, since a^255 = 1 for every non-zero a. The inverse of 0 is 0.
+func inverse(a byte) byte {
+	b := mul(a, a)   // a^2
+	c := mul(a, b)   // a^3
+	b = mul(c, c)    // a^6
+	b = mul(b, b)    // a^12
+	c = mul(b, c)    // a^15
+	b = mul(b, b)    // a^24
+	b = mul(b, b)    // a^48
+	b = mul(b, c)    // a^63
+	b = mul(b, b)    // a^126
+	b = mul(a, b)    // a^127
+	return mul(b, b) // a^254
+}
+
+func div(a, b byte) byte {
+	if b == 0 {
+		panic("shamir: division by zero")
+	}
+	return mul(a, inverse(b))
+}
+
+// Split splits the secret into the given number of shares, any threshold of
+// which can be combined to reconstruct it.
+func Split(secret []byte, shares, threshold int) ([][]byte, error) {
+	switch {
+	case len(secret) == 0:
+		return nil, errors.New("cannot split an empty secret")
+	case threshold < 2:
+		return nil, errors.New("threshold must be at least 2")
+	case shares < threshold:
+		return nil, errors.New("shares cannot be less than the threshold")
+	case shares > MaxShares:
+		return nil, fmt.Errorf("shares cannot exceed %d", MaxShares)
+	}
+
+	// Pick distinct random x coordinates by shuffling 1..255
+	xs := make([]byte, MaxShares)
+	for i := range xs {
+		xs[i] = byte(i + 1)
+	}
+	for i := len(xs) - 1; i > 0; i-- {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return nil, err
+		}
+		j := n.Int64()
+		xs[i], xs[j] = xs[j], xs[i]
+	}
+
+	out := make([][]byte, shares)
+	for i := range out {
+		out[i] = make([]byte, len(secret)+ShareOverhead)
+		out[i][len(secret)] = xs[i]
+	}
+
+	coeffs := make([]byte, threshold)
+	for idx, b := range secret {
+		coeffs[0] = b
+		if _, err := rand.Read(coeffs[1:]); err != nil {
+			return nil, err
+		}
+		for i := range out {
+			out[i][idx] = evaluate(coeffs, xs[i])
+		}
+	}
+	for i := range coeffs {
+		coeffs[i] = 0
+	}
+	return out, nil
+}
+
+// evaluate returns the value of the polynomial at x, with Horner's method.
+func evaluate(coeffs []byte, x byte) byte {
+	var y byte
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		y = mul(y, x) ^ coeffs[i]
+	}
+	return y
+}
+
+// Combine reconstructs the secret from shares returned by Split. It cannot
+// detect that fewer shares than the threshold were given, in which case the
+// result is not the secret, so the secret should be authenticated by the
+// caller.
+func Combine(shares [][]byte) ([]byte, error) {
+	if len(shares) < 2 {
+		return nil, errors.New("at least 2 shares are required")
+	}
+	size := len(shares[0])
+	if size <= ShareOverhead {
+		return nil, errors.New("shares are too short")
+	}
+
+	xs := make([]byte, len(shares))
+	seen := make(map[byte]bool, len(shares))
+	for i, share := range shares {
+		if len(share) != size {
+			return nil, errors.New("all shares must have the same length")
+		}
+		x := share[size-1]
+		if x == 0 {
+			return nil, errors.New("invalid share")
+		}
+		if seen[x] {
+			return nil, errors.New("duplicate share")
+		}
+		seen[x] = true
+		xs[i] = x
+	}
+
+	// Interpolate each polynomial at 0 with Lagrange's formula. Addition and
+	// subtraction are both XOR in GF(2^8).
+	secret := make([]byte, size-ShareOverhead)
+	for idx := range secret {
+		var y byte
+		for i, share := range shares {
+			basis := byte(1)
+			for j := range shares {
+				if i == j {
+					continue
+				}
+				basis = mul(basis, div(xs[j], xs[i]^xs[j]))
+			}
+			y ^= mul(share[idx], basis)
+		}
+		secret[idx] = y
+	}
+	return secret, nil
+}
@@ -0,0 +1,69 @@
+package shamir
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestField(t *testing.T) {
+	for a := 1; a < 256; a++ {
+		for b := 1; b < 256; b++ {
+			must.Eq(t, byte(a), div(mul(byte(a), byte(b)), byte(b)))
+		}
+	}
+	must.Eq(t, 0, mul(0, 7))
+	must.Eq(t, 1, mul(0x53, 0xca)) // inverses in the AES field
+}
+
+func TestSplitCombine(t *testing.T) {
+	secret := []byte("correct horse battery staple")
+
+	shares, err := Split(secret, 5, 3)
+	must.NoError(t, err)
+	must.Len(t, 5, shares)
+	for _, share := range shares {
+		must.Len(t, len(secret)+ShareOverhead, share)
+	}
+
+	// Any threshold of shares reconstructs the secret
+	for _, subset := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}, {0, 1, 2, 3, 4}} {
+		parts := make([][]byte, len(subset))
+		for i, idx := range subset {
+			parts[i] = shares[idx]
+		}
+		out, err := Combine(parts)
+		must.NoError(t, err)
+		must.Eq(t, secret, out)
+	}
+
+	// Fewer shares do not
+	out, err := Combine(shares[:2])
+	must.NoError(t, err)
+	must.NotEq(t, secret, out)
+}
+
+func TestSplit_Invalid(t *testing.T) {
+	_, err := Split(nil, 5, 3)
+	must.EqError(t, err, "cannot split an empty secret")
+	_, err = Split([]byte("x"), 5, 1)
+	must.EqError(t, err, "threshold must be at least 2")
+	_, err = Split([]byte("x"), 2, 3)
+	must.EqError(t, err, "shares cannot be less than the threshold")
+	_, err = Split([]byte("x"), 256, 3)
+	must.EqError(t, err, "shares cannot exceed 255")
+}
+
+func TestCombine_Invalid(t *testing.T) {
+	shares, err := Split([]byte("secret"), 3, 2)
+	must.NoError(t, err)
+
+	_, err = Combine(shares[:1])
+	must.EqError(t, err, "at least 2 shares are required")
+	_, err = Combine([][]byte{shares[0], shares[0]})
+	must.EqError(t, err, "duplicate share")
+	_, err = Combine([][]byte{shares[0], shares[1][1:]})
+	must.EqError(t, err, "all shares must have the same length")
+	_, err = Combine([][]byte{{1}, {2}})
+	must.EqError(t, err, "shares are too short")
+}
@@ -431,6 +431,7 @@ func parseUpdate(result **api.UpdateStrategy, list *ast.ObjectList) error {
 		"auto_revert",
 		"auto_promote",
 		"canary",
+		"canary_distinct_hosts",
 	}
 	if err := checkHCLKeys(o.Val, valid); err != nil {
 		return err
@@ -483,6 +484,77 @@ func parseMigrate(result **api.MigrateStrategy, list *ast.ObjectList) error {
 	return dec.Decode(m)
 }
 
+func parseDisconnect(result **api.DisconnectStrategy, list *ast.ObjectList) error {
+	list = list.Elem()
+	if len(list.Items) > 1 {
+		return fmt.Errorf("only one 'disconnect' block allowed")
+	}
+
+	// Get our resource object
+	o := list.Items[0]
+
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, o.Val); err != nil {
+		return err
+	}
+
+	// Check for invalid keys
+	valid := []string{
+		"max_unknown",
+	}
+	if err := checkHCLKeys(o.Val, valid); err != nil {
+		return err
+	}
+
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           result,
+	})
+	if err != nil {
+		return err
+	}
+	return dec.Decode(m)
+}
+
+func parseDisconnectHooks(result *[]*api.DisconnectHook, list *ast.ObjectList) error {
+	for _, o := range list.Elem().Items {
+		// Check for invalid keys
+		valid := []string{
+			"task",
+			"signal",
+			"command",
+			"args",
+			"webhook",
+			"timeout",
+		}
+		if err := checkHCLKeys(o.Val, valid); err != nil {
+			return err
+		}
+
+		var m map[string]interface{}
+		if err := hcl.DecodeObject(&m, o.Val); err != nil {
+			return err
+		}
+
+		var hook api.DisconnectHook
+		dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+			WeaklyTypedInput: true,
+			Result:           &hook,
+		})
+		if err != nil {
+			return err
+		}
+		if err := dec.Decode(m); err != nil {
+			return err
+		}
+
+		*result = append(*result, &hook)
+	}
+
+	return nil
+}
+
 func parseVault(result *api.Vault, list *ast.ObjectList) error {
 	list = list.Elem()
 	if len(list.Items) == 0 {
@@ -43,6 +43,7 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 			"consul",
 			"affinity",
 			"restart",
+			"restart_budget",
 			"meta",
 			"task",
 			"ephemeral_disk",
@@ -58,6 +59,8 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 			"scaling",
 			"stop_after_client_disconnect",
 			"max_client_disconnect",
+			"max_client_disconnect_jitter",
+			"max_client_disconnect_tier",
 		}
 		if err := checkHCLKeys(listVal, valid); err != nil {
 			return multierror.Prefix(err, fmt.Sprintf("'%s' ->", n))
@@ -74,6 +77,7 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 		delete(m, "meta")
 		delete(m, "task")
 		delete(m, "restart")
+		delete(m, "restart_budget")
 		delete(m, "ephemeral_disk")
 		delete(m, "update")
 		delete(m, "vault")
@@ -83,6 +87,7 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 		delete(m, "service")
 		delete(m, "volume")
 		delete(m, "scaling")
+		delete(m, "max_client_disconnect_tier")
 
 		// Build the group with the basic decode
 		var g api.TaskGroup
@@ -128,6 +133,13 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 			}
 		}
 
+		// Parse restart budget
+		if o := listVal.Filter("restart_budget"); len(o.Items) > 0 {
+			if err := parseRestartBudget(&g.RestartBudget, o); err != nil {
+				return multierror.Prefix(err, fmt.Sprintf("'%s', restart_budget ->", n))
+			}
+		}
+
 		// Parse spread
 		if o := listVal.Filter("spread"); len(o.Items) > 0 {
 			if err := parseSpread(&g.Spreads, o); err != nil {
@@ -135,6 +147,13 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 			}
 		}
 
+		// Parse max_client_disconnect tiers
+		if o := listVal.Filter("max_client_disconnect_tier"); len(o.Items) > 0 {
+			if err := parseMaxClientDisconnectTiers(&g.MaxClientDisconnectTiers, o); err != nil {
+				return multierror.Prefix(err, fmt.Sprintf("'%s', max_client_disconnect_tier ->", n))
+			}
+		}
+
 		// Parse network
 		if o := listVal.Filter("network"); len(o.Items) > 0 {
 			networks, err := ParseNetwork(o)
@@ -344,6 +363,45 @@ func parseRestartPolicy(final **api.RestartPolicy, list *ast.ObjectList) error {
 	return nil
 }
 
+func parseRestartBudget(final **api.RestartBudget, list *ast.ObjectList) error {
+	list = list.Elem()
+	if len(list.Items) > 1 {
+		return fmt.Errorf("only one 'restart_budget' block allowed")
+	}
+
+	obj := list.Items[0]
+
+	// Check for invalid keys
+	valid := []string{
+		"attempts",
+		"interval",
+	}
+	if err := checkHCLKeys(obj.Val, valid); err != nil {
+		return err
+	}
+
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, obj.Val); err != nil {
+		return err
+	}
+
+	var result api.RestartBudget
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+		WeaklyTypedInput: true,
+		Result:           &result,
+	})
+	if err != nil {
+		return err
+	}
+	if err := dec.Decode(m); err != nil {
+		return err
+	}
+
+	*final = &result
+	return nil
+}
+
 func parseVolumes(out *map[string]*api.VolumeRequest, list *ast.ObjectList) error {
 	hcl.DecodeObject(out, list)
 
@@ -441,3 +499,39 @@ func parseScalingPolicy(item *ast.ObjectItem) (*api.ScalingPolicy, error) {
 
 	return &result, nil
 }
+
+func parseMaxClientDisconnectTiers(result *[]*api.MaxClientDisconnectTier, list *ast.ObjectList) error {
+	for _, o := range list.Elem().Items {
+		// Check for invalid keys
+		valid := []string{
+			"attribute",
+			"value",
+			"duration",
+		}
+		if err := checkHCLKeys(o.Val, valid); err != nil {
+			return err
+		}
+
+		var m map[string]interface{}
+		if err := hcl.DecodeObject(&m, o.Val); err != nil {
+			return err
+		}
+
+		var tier api.MaxClientDisconnectTier
+		dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+			WeaklyTypedInput: true,
+			Result:           &tier,
+		})
+		if err != nil {
+			return err
+		}
+		if err := dec.Decode(m); err != nil {
+			return err
+		}
+
+		*result = append(*result, &tier)
+	}
+
+	return nil
+}
@@ -38,6 +38,9 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 	delete(m, "vault")
 	delete(m, "spread")
 	delete(m, "multiregion")
+	delete(m, "disconnect")
+	delete(m, "on_disconnect")
+	delete(m, "on_reconnect")
 
 	// Set the ID and name to the object key
 	result.ID = stringToPtr(obj.Keys[0].Token.Value().(string))
@@ -63,12 +66,15 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 		"affinity",
 		"spread",
 		"datacenters",
+		"disconnect",
 		"group",
 		"id",
 		"meta",
 		"migrate",
 		"name",
 		"namespace",
+		"on_disconnect",
+		"on_reconnect",
 		"parameterized",
 		"periodic",
 		"priority",
@@ -142,6 +148,25 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 		}
 	}
 
+	// If we have a disconnect strategy, then parse that
+	if o := listVal.Filter("disconnect"); len(o.Items) > 0 {
+		if err := parseDisconnect(&result.Disconnect, o); err != nil {
+			return multierror.Prefix(err, "disconnect ->")
+		}
+	}
+
+	// Parse disconnect and reconnect hooks
+	if o := listVal.Filter("on_disconnect"); len(o.Items) > 0 {
+		if err := parseDisconnectHooks(&result.OnDisconnect, o); err != nil {
+			return multierror.Prefix(err, "on_disconnect ->")
+		}
+	}
+	if o := listVal.Filter("on_reconnect"); len(o.Items) > 0 {
+		if err := parseDisconnectHooks(&result.OnReconnect, o); err != nil {
+			return multierror.Prefix(err, "on_reconnect ->")
+		}
+	}
+
 	// If we have a multiregion block, then parse that
 	if o := listVal.Filter("multiregion"); len(o.Items) > 0 {
 		var mr api.Multiregion
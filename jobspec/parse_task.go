@@ -33,6 +33,8 @@ var (
 		"affinity",
 		"dispatch_payload",
 		"lifecycle",
+		"checkpoint",
+		"identity",
 		"leader",
 		"restart",
 		"service",
@@ -102,6 +104,8 @@ func parseTask(item *ast.ObjectItem, keys []string) (*api.Task, error) {
 	delete(m, "affinity")
 	delete(m, "dispatch_payload")
 	delete(m, "lifecycle")
+	delete(m, "checkpoint")
+	delete(m, "identity")
 	delete(m, "env")
 	delete(m, "logs")
 	delete(m, "meta")
@@ -361,6 +365,68 @@ func parseTask(item *ast.ObjectItem, keys []string) (*api.Task, error) {
 			return nil, err
 		}
 	}
+
+	// If we have an identity block parse that
+	if o := listVal.Filter("identity"); len(o.Items) > 0 {
+		if len(o.Items) > 1 {
+			return nil, fmt.Errorf("only one identity block is allowed in a task. Number of identity blocks found: %d", len(o.Items))
+		}
+
+		var m map[string]interface{}
+		identityBlock := o.Items[0]
+
+		// Check for invalid keys
+		valid := []string{
+			"env",
+		}
+		if err := checkHCLKeys(identityBlock.Val, valid); err != nil {
+			return nil, multierror.Prefix(err, "identity ->")
+		}
+
+		if err := hcl.DecodeObject(&m, identityBlock.Val); err != nil {
+			return nil, err
+		}
+
+		t.Identity = &api.WorkloadIdentity{}
+		if err := mapstructure.WeakDecode(m, t.Identity); err != nil {
+			return nil, err
+		}
+	}
+
+	// If we have a checkpoint block parse that
+	if o := listVal.Filter("checkpoint"); len(o.Items) > 0 {
+		if len(o.Items) > 1 {
+			return nil, fmt.Errorf("only one checkpoint block is allowed in a task. Number of checkpoint blocks found: %d", len(o.Items))
+		}
+
+		var m map[string]interface{}
+		checkpointBlock := o.Items[0]
+
+		// Check for invalid keys
+		valid := []string{
+			"timeout",
+		}
+		if err := checkHCLKeys(checkpointBlock.Val, valid); err != nil {
+			return nil, multierror.Prefix(err, "checkpoint ->")
+		}
+
+		if err := hcl.DecodeObject(&m, checkpointBlock.Val); err != nil {
+			return nil, err
+		}
+
+		t.Checkpoint = &api.TaskCheckpoint{}
+		dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+			WeaklyTypedInput: true,
+			Result:           t.Checkpoint,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := dec.Decode(m); err != nil {
+			return nil, err
+		}
+	}
 	return &t, nil
 }
 
@@ -456,6 +522,9 @@ func parseTemplates(result *[]*api.Template, list *ast.ObjectList) error {
 			"source",
 			"splay",
 			"env",
+			"max_stale",
+			"stale_mode",
+			"stale_signal",
 			"vault_grace", //COMPAT(0.12) not used; emits warning in 0.11.
 		}
 		if err := checkHCLKeys(o.Val, valid); err != nil {
@@ -603,6 +672,7 @@ func parseResources(result *api.Resources, list *ast.ObjectList) error {
 		"disk",
 		"memory",
 		"memory_max",
+		"hugepages_2m",
 		"network",
 		"device",
 		"cores",
@@ -344,6 +344,12 @@ func TestParse(t *testing.T) {
 								},
 								KillTimeout:   timeToPtr(22 * time.Second),
 								ShutdownDelay: 11 * time.Second,
+								Checkpoint: &api.TaskCheckpoint{
+									Timeout: timeToPtr(2 * time.Minute),
+								},
+								Identity: &api.WorkloadIdentity{
+									Env: true,
+								},
 								LogConfig: &api.LogConfig{
 									MaxFiles:      intToPtr(14),
 									MaxFileSizeMB: intToPtr(101),
@@ -380,6 +386,9 @@ func TestParse(t *testing.T) {
 										Perms:        stringToPtr("0644"),
 										Envvars:      boolToPtr(true),
 										VaultGrace:   timeToPtr(33 * time.Second),
+										MaxStale:     timeToPtr(time.Hour),
+										StaleMode:    stringToPtr("signal"),
+										StaleSignal:  stringToPtr("SIGUSR1"),
 									},
 									{
 										SourcePath: stringToPtr("bar"),
@@ -1124,6 +1133,79 @@ func TestParse(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"disconnect-job.hcl",
+			&api.Job{
+				ID:          stringToPtr("foo"),
+				Name:        stringToPtr("foo"),
+				Datacenters: []string{"dc1"},
+				Disconnect: &api.DisconnectStrategy{
+					MaxUnknown: intToPtr(2),
+				},
+				OnDisconnect: []*api.DisconnectHook{
+					{
+						Task:   "bar",
+						Signal: "SIGUSR1",
+					},
+					{
+						Webhook: "http://127.0.0.1:8080/degraded",
+						Timeout: timeToPtr(5 * time.Second),
+					},
+				},
+				OnReconnect: []*api.DisconnectHook{
+					{
+						Task:    "bar",
+						Command: "/usr/local/bin/resume",
+						Args:    []string{"-now"},
+					},
+				},
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name:                stringToPtr("bar"),
+						Count:               intToPtr(5),
+						MaxClientDisconnect: timeToPtr(time.Hour),
+						Tasks: []*api.Task{
+							{
+								Name:   "bar",
+								Driver: "raw_exec",
+								Config: map[string]interface{}{
+									"command": "bash",
+									"args":    []interface{}{"-c", "echo hi"},
+								},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"restart-budget.hcl",
+			&api.Job{
+				ID:   stringToPtr("foo"),
+				Name: stringToPtr("foo"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("bar"),
+						RestartBudget: &api.RestartBudget{
+							Attempts: intToPtr(10),
+							Interval: timeToPtr(30 * time.Minute),
+						},
+						Tasks: []*api.Task{
+							{
+								Name:   "app",
+								Driver: "docker",
+							},
+							{
+								Name:   "sidecar",
+								Driver: "docker",
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
 		{
 			"tg-network.hcl",
 			&api.Job{
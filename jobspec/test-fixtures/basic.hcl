@@ -281,6 +281,14 @@ job "binstore-storagelocker" {
 
       shutdown_delay = "11s"
 
+      checkpoint {
+        timeout = "2m"
+      }
+
+      identity {
+        env = true
+      }
+
       artifact {
         source = "http://foo.com/artifact"
 
@@ -312,6 +320,9 @@ job "binstore-storagelocker" {
         splay         = "10s"
         env           = true
         vault_grace   = "33s"
+        max_stale     = "1h"
+        stale_mode    = "signal"
+        stale_signal  = "SIGUSR1"
       }
 
       template {
@@ -0,0 +1,37 @@
+job "foo" {
+  datacenters = ["dc1"]
+
+  disconnect {
+    max_unknown = 2
+  }
+
+  on_disconnect {
+    task   = "bar"
+    signal = "SIGUSR1"
+  }
+
+  on_disconnect {
+    webhook = "http://127.0.0.1:8080/degraded"
+    timeout = "5s"
+  }
+
+  on_reconnect {
+    task    = "bar"
+    command = "/usr/local/bin/resume"
+    args    = ["-now"]
+  }
+
+  group "bar" {
+    count                 = 5
+    max_client_disconnect = "1h"
+
+    task "bar" {
+      driver = "raw_exec"
+
+      config {
+        command = "bash"
+        args    = ["-c", "echo hi"]
+      }
+    }
+  }
+}
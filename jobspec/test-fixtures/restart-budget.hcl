@@ -0,0 +1,16 @@
+job "foo" {
+  group "bar" {
+    restart_budget {
+      attempts = 10
+      interval = "30m"
+    }
+
+    task "app" {
+      driver = "docker"
+    }
+
+    task "sidecar" {
+      driver = "docker"
+    }
+  }
+}
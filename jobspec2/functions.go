@@ -2,6 +2,7 @@ package jobspec2
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/hashicorp/go-cty-funcs/cidr"
 	"github.com/hashicorp/go-cty-funcs/crypto"
@@ -95,6 +96,7 @@ func Functions(basedir string, allowFS bool) map[string]function.Function {
 		"uuidv4":          uuid.V4Func,
 		"uuidv5":          uuid.V5Func,
 		"values":          stdlib.ValuesFunc,
+		"var":             varFunc,
 		"yamldecode":      ctyyaml.YAMLDecodeFunc,
 		"yamlencode":      ctyyaml.YAMLEncodeFunc,
 		"zipmap":          stdlib.ZipmapFunc,
@@ -113,6 +115,27 @@ func Functions(basedir string, allowFS bool) map[string]function.Function {
 	return funcs
 }
 
+// varFunc returns a reference to an item of a secure variable in the
+// namespace of the job. The servers replace the reference with the value of
+// the item when the job is registered, if the token that submits the job can
+// read the variable.
+var varFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "path", Type: cty.String},
+		{Name: "item", Type: cty.String},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		path, item := args[0].AsString(), args[1].AsString()
+		if path == "" || item == "" {
+			return cty.UnknownVal(cty.String), fmt.Errorf("secure variable path and item must not be empty")
+		}
+		// Keep in sync with secureVariableRefRe in the nomad package
+		ref := fmt.Sprintf("${nomad_var:%s:%s}", strconv.Quote(path), strconv.Quote(item))
+		return cty.StringVal(ref), nil
+	},
+})
+
 func guardFS(allowFS bool, fn function.Function) function.Function {
 	if allowFS {
 		return fn
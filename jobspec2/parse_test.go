@@ -89,6 +89,39 @@ job "example" {
 	require.Equal(t, "aug", *out.Region)
 }
 
+func TestParse_SecureVariableFunction(t *testing.T) {
+	ci.Parallel(t)
+
+	hcl := `
+job "example" {
+  meta {
+    image = var("config/web", "image")
+    quoted = var("config/\"web\"", "port")
+  }
+}
+`
+
+	out, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	require.NoError(t, err)
+	require.Equal(t, `${nomad_var:"config/web":"image"}`, out.Meta["image"])
+	require.Equal(t, `${nomad_var:"config/\"web\"":"port"}`, out.Meta["quoted"])
+
+	_, err = ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(`
+job "example" {
+  meta {
+    image = var("config/web", "")
+  }
+}
+`),
+	})
+	require.ErrorContains(t, err, "must not be empty")
+}
+
 func TestParse_VariablesDefaultsAndSet(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1052,3 +1085,29 @@ func TestWaitConfig(t *testing.T) {
 	require.Equal(t, 5*time.Second, *tmpl.Wait.Min)
 	require.Equal(t, 60*time.Second, *tmpl.Wait.Max)
 }
+
+func TestParse_HugePages(t *testing.T) {
+	ci.Parallel(t)
+
+	hcl := `job "example" {
+  group "db" {
+    task "db" {
+      driver = "exec"
+      resources {
+        memory       = 1024
+        hugepages_2m = 512
+      }
+    }
+  }
+}`
+
+	job, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	require.NoError(t, err)
+
+	res := job.TaskGroups[0].Tasks[0].Resources
+	require.Equal(t, 1024, *res.MemoryMB)
+	require.Equal(t, 512, *res.HugePages2MB)
+}
@@ -718,6 +718,7 @@ func (a *ACL) ListTokens(args *structs.ACLTokenListRequest, reply *structs.ACLTo
 			}
 
 			reply.QueryMeta.NextToken = nextToken
+			reply.QueryMeta.ObjectsScanned = paginator.Scanned()
 			reply.Tokens = tokens
 
 			// Use the last index that affected the token table
@@ -880,6 +881,76 @@ func (a *ACL) ResolveToken(args *structs.ResolveACLTokenRequest, reply *structs.
 	return nil
 }
 
+// Check is used to test a batch of capabilities against the token of the
+// request, so that UIs and CLIs can hide actions the caller cannot perform.
+// Unlike the other ACL endpoints, it does not fail when ACLs are disabled;
+// every valid check is allowed instead.
+func (a *ACL) Check(args *structs.ACLCheckRequest, reply *structs.ACLCheckResponse) error {
+	if done, err := a.srv.forward("ACL.Check", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "check"}, time.Now())
+
+	if len(args.Checks) > structs.ACLCheckMaxChecks {
+		return structs.NewErrRPCCodedf(400,
+			"too many checks: %d exceeds the limit of %d",
+			len(args.Checks), structs.ACLCheckMaxChecks)
+	}
+
+	aclObj, err := a.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	}
+
+	// Setup the query meta
+	a.srv.setQueryMeta(&reply.QueryMeta)
+
+	// results are returned in the same order as the checks, so that callers
+	// can match them up by position
+	reply.Results = make([]*structs.ACLCheckResult, 0, len(args.Checks))
+	for _, check := range args.Checks {
+		if check == nil {
+			reply.Results = append(reply.Results, &structs.ACLCheckResult{
+				Error: "invalid check: check is empty",
+			})
+			continue
+		}
+		result := &structs.ACLCheckResult{ACLCheck: *check}
+		switch result.Resource {
+		case policy.ResourceNamespace, policy.ResourceSecureVariables:
+			if result.Namespace == "" {
+				result.Namespace = args.RequestNamespace()
+			}
+		}
+		allowed, err := aclObj.AllowCapability(
+			result.Resource, result.Namespace, result.Name, result.Capability)
+		if err != nil {
+			result.Error = err.Error()
+		}
+		result.Allowed = allowed
+		reply.Results = append(reply.Results, result)
+	}
+
+	// Use the last index that affected the policy or token tables
+	snap, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	index, err := snap.Index("acl_policy")
+	if err != nil {
+		return err
+	}
+	tokenIndex, err := snap.Index("acl_token")
+	if err != nil {
+		return err
+	}
+	if tokenIndex > index {
+		index = tokenIndex
+	}
+	reply.Index = index
+	return nil
+}
+
 func (a *ACL) UpsertOneTimeToken(args *structs.OneTimeTokenUpsertRequest, reply *structs.OneTimeTokenUpsertResponse) error {
 	if !a.srv.config.ACLEnabled {
 		return aclDisabled
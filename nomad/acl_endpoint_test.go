@@ -1567,6 +1567,108 @@ func TestACLEndpoint_ResolveToken(t *testing.T) {
 	assert.Nil(t, resp.Token)
 }
 
+func TestACLEndpoint_Check(t *testing.T) {
+	ci.Parallel(t)
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	rules := `
+namespace "default" {
+  capabilities = ["list-jobs"]
+  secure_variables {
+    path "nomad/jobs/*" { capabilities = ["read"] }
+  }
+}
+node { policy = "read" }
+`
+	token := mock.CreatePolicyAndToken(t, s1.fsm.State(), 1000, "check", rules)
+
+	checks := []*structs.ACLCheck{
+		{Resource: "namespace", Capability: "list-jobs"},
+		{Resource: "namespace", Capability: "submit-job"},
+		{Resource: "namespace", Namespace: "other", Capability: "list-jobs"},
+		{Resource: "secure_variables", Name: "nomad/jobs/example", Capability: "read"},
+		{Resource: "secure_variables", Name: "nomad/jobs/example", Capability: "write"},
+		{Resource: "node", Capability: "read"},
+		{Resource: "node", Capability: "write"},
+		{Resource: "node", Capability: "submit-job"},
+		{Resource: "bogus", Capability: "read"},
+		nil,
+		{Resource: "node", Capability: "read"},
+	}
+
+	req := &structs.ACLCheckRequest{
+		Checks: checks,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: "default",
+			AuthToken: token.SecretID,
+		},
+	}
+	var resp structs.ACLCheckResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.Check", req, &resp))
+	require.Equal(t, uint64(1001), resp.Index)
+	require.Len(t, resp.Results, len(checks))
+
+	// results keep the position of their check, even for empty checks
+	allowed := []bool{true, false, false, true, false, true, false, false, false, false, true}
+	for i, result := range resp.Results {
+		require.Equal(t, allowed[i], result.Allowed, "check %d", i)
+		if checks[i] != nil {
+			require.Equal(t, checks[i].Resource, result.Resource)
+		}
+	}
+	require.Equal(t, "default", resp.Results[0].Namespace)
+	require.Equal(t, "other", resp.Results[2].Namespace)
+	require.Empty(t, resp.Results[6].Error)
+	require.Contains(t, resp.Results[7].Error, "invalid node capability")
+	require.Contains(t, resp.Results[8].Error, "invalid resource")
+	require.Contains(t, resp.Results[9].Error, "check is empty")
+
+	// The management token is allowed everything
+	req.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.Check", req, &resp))
+	for i, result := range resp.Results {
+		require.Equal(t, result.Error == "", result.Allowed, "check %d", i)
+	}
+
+	// Unknown tokens are rejected
+	req.AuthToken = uuid.Generate()
+	err := msgpackrpc.CallWithCodec(codec, "ACL.Check", req, &resp)
+	require.EqualError(t, err, structs.ErrTokenNotFound.Error())
+
+	// Too many checks are rejected
+	req.AuthToken = root.SecretID
+	req.Checks = make([]*structs.ACLCheck, structs.ACLCheckMaxChecks+1)
+	err = msgpackrpc.CallWithCodec(codec, "ACL.Check", req, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "too many checks")
+}
+
+func TestACLEndpoint_Check_ACLDisabled(t *testing.T) {
+	ci.Parallel(t)
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	req := &structs.ACLCheckRequest{
+		Checks: []*structs.ACLCheck{
+			{Resource: "namespace", Capability: "submit-job"},
+			{Resource: "namespace", Capability: "bogus"},
+		},
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var resp structs.ACLCheckResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.Check", req, &resp))
+	require.Len(t, resp.Results, 2)
+	require.True(t, resp.Results[0].Allowed)
+	require.False(t, resp.Results[1].Allowed)
+	require.NotEmpty(t, resp.Results[1].Error)
+}
+
 func TestACLEndpoint_OneTimeToken(t *testing.T) {
 	ci.Parallel(t)
 
@@ -46,6 +46,10 @@ func (a *Alloc) List(args *structs.AllocListRequest, reply *structs.AllocListRes
 	}
 	allow := aclObj.AllowNsOpFunc(acl.NamespaceCapabilityReadJob)
 
+	if err := args.Validate(); err != nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "%v", err)
+	}
+
 	// Setup the blocking query
 	sort := state.SortOption(args.Reverse)
 	opts := blockingOptions{
@@ -88,6 +92,14 @@ func (a *Alloc) List(args *structs.AllocListRequest, reply *structs.AllocListRes
 					return err
 				}
 
+				now := time.Now().UTC()
+				iter = memdb.NewFilterIterator(iter, func(raw interface{}) bool {
+					if alloc := raw.(*structs.Allocation); alloc != nil {
+						return args.ShouldBeFiltered(alloc, now)
+					}
+					return false
+				})
+
 				tokenizer := paginator.NewStructsTokenizer(iter, opts)
 				filters := []paginator.Filter{
 					paginator.NamespaceFilter{
@@ -99,7 +111,15 @@ func (a *Alloc) List(args *structs.AllocListRequest, reply *structs.AllocListRes
 				paginator, err := paginator.NewPaginator(iter, tokenizer, filters, args.QueryOptions,
 					func(raw interface{}) error {
 						allocation := raw.(*structs.Allocation)
-						stubs = append(stubs, allocation.Stub(args.Fields))
+						stub := allocation.Stub(args.Fields)
+						if args.Fields != nil && args.Fields.RescheduleChain {
+							chain, err := state.AllocRescheduleChain(ws, allocation)
+							if err != nil {
+								return err
+							}
+							stub.RescheduleChain = chain
+						}
+						stubs = append(stubs, stub)
 						return nil
 					})
 				if err != nil {
@@ -114,6 +134,7 @@ func (a *Alloc) List(args *structs.AllocListRequest, reply *structs.AllocListRes
 				}
 
 				reply.QueryMeta.NextToken = nextToken
+				reply.QueryMeta.ObjectsScanned = paginator.Scanned()
 				reply.Allocations = stubs
 			}
 
@@ -330,6 +351,116 @@ func (a *Alloc) Stop(args *structs.AllocStopRequest, reply *structs.AllocStopRes
 	return nil
 }
 
+// Reconnect is used to accept an unknown allocation back, or to reject it so
+// that it is replaced without waiting for its node to reconnect or for the
+// max_client_disconnect window to expire.
+func (a *Alloc) Reconnect(args *structs.AllocReconnectRequest, reply *structs.AllocReconnectResponse) error {
+	if done, err := a.srv.forward("Alloc.Reconnect", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "alloc", "reconnect"}, time.Now())
+
+	alloc, err := getAlloc(a.srv.State(), args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	// Check for namespace alloc-lifecycle permissions.
+	allowNsOp := acl.NamespaceValidator(acl.NamespaceCapabilityAllocLifecycle)
+	aclObj, err := a.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if !allowNsOp(aclObj, alloc.Namespace) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Only unknown allocations, or reconnected ones that are waiting to be
+	// reinstated, have a reconnect to decide on
+	awaiting, err := allocAwaitingReconnect(a.srv.State(), alloc, time.Now())
+	if err != nil {
+		return err
+	}
+	if !awaiting {
+		return structs.NewErrRPCCoded(http.StatusBadRequest,
+			fmt.Sprintf("allocation %s is %s and not awaiting reconnection", alloc.ID, alloc.ClientStatus))
+	}
+
+	now := time.Now().UTC().UnixNano()
+	eval := &structs.Evaluation{
+		ID:             uuid.Generate(),
+		Namespace:      alloc.Namespace,
+		Priority:       alloc.Job.Priority,
+		Type:           alloc.Job.Type,
+		TriggeredBy:    structs.EvalTriggerReconnect,
+		JobID:          alloc.Job.ID,
+		JobModifyIndex: alloc.Job.ModifyIndex,
+		Status:         structs.EvalStatusPending,
+		CreateTime:     now,
+		ModifyTime:     now,
+	}
+
+	transitionReq := &structs.AllocUpdateDesiredTransitionRequest{
+		Evals: []*structs.Evaluation{eval},
+		Allocs: map[string]*structs.DesiredTransition{
+			args.AllocID: {
+				Reconnect: pointer.Of(!args.Reject),
+			},
+		},
+	}
+
+	// Commit this update via Raft
+	_, index, err := a.srv.raftApply(structs.AllocUpdateDesiredTransitionRequestType, transitionReq)
+	if err != nil {
+		a.logger.Error("AllocUpdateDesiredTransitionRequest failed", "error", err)
+		return err
+	}
+
+	// Setup the response
+	reply.Index = index
+	reply.EvalID = eval.ID
+	return nil
+}
+
+// allocAwaitingReconnect returns whether the allocation is unknown, or has
+// reconnected but is not reinstated yet: its replacement is still running, or
+// its node is within the reconnect stabilization period.
+func allocAwaitingReconnect(store *state.StateStore, alloc *structs.Allocation, now time.Time) (bool, error) {
+	if alloc.DesiredStatus != structs.AllocDesiredStatusRun {
+		return false, nil
+	}
+	if alloc.ClientStatus == structs.AllocClientStatusUnknown {
+		return true, nil
+	}
+	if reconnected, _ := alloc.Reconnected(); !reconnected ||
+		alloc.ClientStatus != structs.AllocClientStatusRunning {
+		return false, nil
+	}
+
+	allocs, err := store.AllocsByJob(nil, alloc.Namespace, alloc.JobID, false)
+	if err != nil {
+		return false, err
+	}
+	for _, other := range allocs {
+		if other.PreviousAllocation == alloc.ID && !other.TerminalStatus() {
+			return true, nil
+		}
+	}
+
+	_, schedConfig, err := store.SchedulerConfig()
+	if err != nil {
+		return false, err
+	}
+	node, err := store.NodeByID(nil, alloc.NodeID)
+	if err != nil {
+		return false, err
+	}
+	if schedConfig == nil || node == nil || node.LastReconnect == 0 {
+		return false, nil
+	}
+	deadline := time.Unix(node.LastReconnect, 0).Add(schedConfig.ReconnectStabilization)
+	return now.Before(deadline), nil
+}
+
 // UpdateDesiredTransition is used to update the desired transitions of an
 // allocation.
 func (a *Alloc) UpdateDesiredTransition(args *structs.AllocUpdateDesiredTransitionRequest, reply *structs.GenericResponse) error {
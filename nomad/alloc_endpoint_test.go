@@ -1,6 +1,7 @@
 package nomad
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
@@ -478,6 +479,18 @@ func TestAllocEndpoint_List_Fields(t *testing.T) {
 				require.Len(t, allocs[0].TaskStates, 1)
 			},
 		},
+		{
+			Name: "RescheduleChain",
+			Fields: &structs.AllocStubFields{
+				TaskStates:      true,
+				RescheduleChain: true,
+			},
+			Assert: func(t *testing.T, allocs []*structs.AllocListStub) {
+				require.NotNil(t, allocs[0].RescheduleChain)
+				require.Empty(t, allocs[0].RescheduleChain.Ancestors)
+				require.Empty(t, allocs[0].RescheduleChain.Descendants)
+			},
+		},
 	}
 
 	for i := range cases {
@@ -501,6 +514,113 @@ func TestAllocEndpoint_List_Fields(t *testing.T) {
 
 }
 
+func TestAllocEndpoint_List_StatusFilters(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	now := time.Now().UTC()
+	node1, node2 := uuid.Generate(), uuid.Generate()
+
+	// An allocation unknown for an hour, one unknown for a minute, and a
+	// running one
+	unknownOld := mock.Alloc()
+	unknownOld.NodeID = node1
+	unknownOld.ClientStatus = structs.AllocClientStatusUnknown
+	unknownOld.AllocStates = []*structs.AllocState{{
+		Field: structs.AllocStateFieldClientStatus,
+		Value: structs.AllocClientStatusUnknown,
+		Time:  now.Add(-time.Hour),
+	}}
+	unknownNew := mock.Alloc()
+	unknownNew.NodeID = node2
+	unknownNew.ClientStatus = structs.AllocClientStatusUnknown
+	unknownNew.AllocStates = []*structs.AllocState{{
+		Field: structs.AllocStateFieldClientStatus,
+		Value: structs.AllocClientStatusUnknown,
+		Time:  now.Add(-time.Minute),
+	}}
+	running := mock.Alloc()
+	running.NodeID = node1
+	running.ClientStatus = structs.AllocClientStatusRunning
+
+	state := s1.fsm.State()
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000,
+		[]*structs.Allocation{unknownOld, unknownNew, running}))
+
+	testCases := []struct {
+		name      string
+		req       structs.AllocListRequest
+		expected  []string
+		expectErr string
+	}{
+		{
+			name: "client status",
+			req: structs.AllocListRequest{
+				FilterClientStatus: structs.AllocClientStatusUnknown,
+			},
+			expected: []string{unknownOld.ID, unknownNew.ID},
+		},
+		{
+			name: "node ID prefix",
+			req: structs.AllocListRequest{
+				FilterNodeID: node1[:8],
+			},
+			expected: []string{unknownOld.ID, running.ID},
+		},
+		{
+			name: "time in status",
+			req: structs.AllocListRequest{
+				FilterClientStatus:    structs.AllocClientStatusUnknown,
+				FilterMinTimeInStatus: 10 * time.Minute,
+			},
+			expected: []string{unknownOld.ID},
+		},
+		{
+			name: "combined with filter expression",
+			req: structs.AllocListRequest{
+				QueryOptions: structs.QueryOptions{
+					Filter: fmt.Sprintf("NodeID == %q", node2),
+				},
+				FilterClientStatus: structs.AllocClientStatusUnknown,
+			},
+			expected: []string{unknownNew.ID},
+		},
+		{
+			name: "invalid client status",
+			req: structs.AllocListRequest{
+				FilterClientStatus: "stuck",
+			},
+			expectErr: `invalid client status filter "stuck"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := tc.req
+			req.Region = "global"
+			req.Namespace = structs.DefaultNamespace
+
+			var resp structs.AllocListResponse
+			err := msgpackrpc.CallWithCodec(codec, "Alloc.List", &req, &resp)
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var ids []string
+			for _, stub := range resp.Allocations {
+				ids = append(ids, stub.ID)
+			}
+			require.ElementsMatch(t, tc.expected, ids)
+		})
+	}
+}
+
 func TestAllocEndpoint_List_ACL(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1175,6 +1295,63 @@ func TestAllocEndpoint_Stop_ACL(t *testing.T) {
 	require.True(*out2.DesiredTransition.Migrate)
 }
 
+func TestAllocEndpoint_Reconnect(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create an unknown and a running allocation
+	unknown := mock.Alloc()
+	unknown.ClientStatus = structs.AllocClientStatusUnknown
+	running := mock.Alloc()
+	running.ClientStatus = structs.AllocClientStatusRunning
+	state := s1.fsm.State()
+	require.Nil(state.UpsertJobSummary(998, mock.JobSummary(unknown.JobID)))
+	require.Nil(state.UpsertJobSummary(999, mock.JobSummary(running.JobID)))
+	require.Nil(state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{unknown, running}))
+
+	req := &structs.AllocReconnectRequest{
+		AllocID: running.ID,
+	}
+	req.Namespace = structs.DefaultNamespace
+	req.Region = running.Job.Region
+
+	// The running allocation is not awaiting reconnection
+	var resp structs.AllocReconnectResponse
+	err := msgpackrpc.CallWithCodec(codec, "Alloc.Reconnect", req, &resp)
+	require.ErrorContains(err, "not awaiting reconnection")
+
+	// Accept the unknown allocation
+	req.AllocID = unknown.ID
+	var resp2 structs.AllocReconnectResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Alloc.Reconnect", req, &resp2))
+	require.NotZero(resp2.Index)
+
+	out, err := state.AllocByID(nil, unknown.ID)
+	require.Nil(err)
+	require.True(out.DesiredTransition.ShouldAcceptReconnect())
+
+	eval, err := state.EvalByID(nil, resp2.EvalID)
+	require.Nil(err)
+	require.NotNil(eval)
+	require.Equal(structs.EvalTriggerReconnect, eval.TriggeredBy)
+
+	// Reject it instead
+	req.Reject = true
+	var resp3 structs.AllocReconnectResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Alloc.Reconnect", req, &resp3))
+
+	out, err = state.AllocByID(nil, unknown.ID)
+	require.Nil(err)
+	require.True(out.DesiredTransition.ShouldRejectReconnect())
+}
+
 func TestAllocEndpoint_List_AllNamespaces_ACL_OSS(t *testing.T) {
 	ci.Parallel(t)
 
@@ -0,0 +1,110 @@
+package nomad
+
+import (
+	"errors"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	nstructs "github.com/hashicorp/nomad/nomad/structs"
+
+	"github.com/hashicorp/nomad/client/structs"
+)
+
+// NodeMeta is used to forward RPC requests to the targed Nomad client's
+// NodeMeta endpoint.
+type NodeMeta struct {
+	srv    *Server
+	logger log.Logger
+}
+
+// Apply sets and unsets keys of the dynamic metadata of a node.
+func (n *NodeMeta) Apply(args *structs.NodeMetaApplyRequest, reply *structs.NodeMetaResponse) error {
+	// We only allow stale reads since the only potentially stale information is
+	// the Node registration and the cost is fairly high for adding another hop
+	// in the forwarding chain.
+	args.QueryOptions.AllowStale = true
+
+	// Potentially forward to a different region.
+	if done, err := n.srv.forward("NodeMeta.Apply", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "node_meta", "apply"}, time.Now())
+
+	// Check node write permissions
+	if aclObj, err := n.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
+		return nstructs.ErrPermissionDenied
+	}
+
+	// Verify the arguments.
+	if err := args.Validate(); err != nil {
+		return nstructs.NewErrRPCCoded(400, err.Error())
+	}
+
+	return n.forwardToNode("NodeMeta.Apply", args.NodeID, args, reply)
+}
+
+// Read returns the metadata of a node.
+func (n *NodeMeta) Read(args *nstructs.NodeSpecificRequest, reply *structs.NodeMetaResponse) error {
+	// We only allow stale reads since the only potentially stale information is
+	// the Node registration and the cost is fairly high for adding another hop
+	// in the forwarding chain.
+	args.QueryOptions.AllowStale = true
+
+	// Potentially forward to a different region.
+	if done, err := n.srv.forward("NodeMeta.Read", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "node_meta", "read"}, time.Now())
+
+	// Check node read permissions
+	if aclObj, err := n.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeRead() {
+		return nstructs.ErrPermissionDenied
+	}
+
+	return n.forwardToNode("NodeMeta.Read", args.NodeID, args, reply)
+}
+
+// forwardToNode makes the RPC on the given node, through the server that is
+// connected to it if needed.
+func (n *NodeMeta) forwardToNode(method, nodeID string, args, reply interface{}) error {
+	if nodeID == "" {
+		return errors.New("missing NodeID")
+	}
+
+	// Check if the node even exists and is compatible with NodeRpc
+	snap, err := n.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	// Make sure Node is new enough to support RPC
+	_, err = getNodeForRpc(snap, nodeID)
+	if err != nil {
+		return err
+	}
+
+	// Get the connection to the client
+	state, ok := n.srv.getNodeConn(nodeID)
+	if !ok {
+
+		// Determine the Server that has a connection to the node.
+		srv, err := n.srv.serverWithNodeConn(nodeID, n.srv.Region())
+		if err != nil {
+			return err
+		}
+
+		if srv == nil {
+			return nstructs.ErrNoNodeConn
+		}
+
+		return n.srv.forwardServer(srv, method, args, reply)
+	}
+
+	// Make the RPC
+	return NodeRpc(state.Session, method, args, reply)
+}
@@ -0,0 +1,153 @@
+package nomad
+
+import (
+	"fmt"
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client"
+	"github.com/hashicorp/nomad/client/config"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeMeta_Apply_Local(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	// Start a server and client
+	s, cleanupS := TestServer(t, nil)
+	defer cleanupS()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	c, cleanupC := client.TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s.config.RPCAddr.String()}
+	})
+	defer cleanupC()
+
+	testutil.WaitForResult(func() (bool, error) {
+		nodes := s.connectedNodes()
+		return len(nodes) == 1, nil
+	}, func(err error) {
+		t.Fatalf("should have a clients")
+	})
+
+	// Register a system job, which is evaluated when the node changes
+	job := mock.SystemJob()
+	require.NoError(s.State().UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+	evalsBefore := func() int {
+		evals, err := s.State().EvalsByJob(nil, job.Namespace, job.ID)
+		require.NoError(err)
+		return len(evals)
+	}()
+
+	// Make the request without having a node-id
+	req := &cstructs.NodeMetaApplyRequest{
+		Meta:         map[string]*string{"network": pointer.Of("degraded")},
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var resp cstructs.NodeMetaResponse
+	err := msgpackrpc.CallWithCodec(codec, "NodeMeta.Apply", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "missing NodeID")
+
+	// Fetch the response setting the node id
+	req.NodeID = c.NodeID()
+	require.NoError(msgpackrpc.CallWithCodec(codec, "NodeMeta.Apply", req, &resp))
+	require.Equal("degraded", resp.Meta["network"])
+
+	// The node is re-registered with the new metadata, which creates
+	// evaluations for the jobs that can be placed on it
+	testutil.WaitForResult(func() (bool, error) {
+		node, err := s.State().NodeByID(nil, c.NodeID())
+		if err != nil {
+			return false, err
+		}
+		if node.Meta["network"] != "degraded" {
+			return false, fmt.Errorf("node meta not updated: %v", node.Meta)
+		}
+		evals, err := s.State().EvalsByJob(nil, job.Namespace, job.ID)
+		if err != nil {
+			return false, err
+		}
+		if len(evals) <= evalsBefore {
+			return false, fmt.Errorf("expected a new evaluation, got %d", len(evals))
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+
+	// The metadata can be read back
+	readReq := &structs.NodeSpecificRequest{
+		NodeID:       c.NodeID(),
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var readResp cstructs.NodeMetaResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "NodeMeta.Read", readReq, &readResp))
+	require.Equal("degraded", readResp.Meta["network"])
+	require.Equal(req.Meta, readResp.Dynamic)
+}
+
+func TestNodeMeta_Apply_Local_ACL(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	// Start a server
+	s, root, cleanupS := TestACLServer(t, nil)
+	defer cleanupS()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	// Create a bad token
+	tokenBad := mock.CreatePolicyAndToken(t, s.State(), 1005, "invalid", mock.NodePolicy(acl.PolicyRead))
+	tokenGood := mock.CreatePolicyAndToken(t, s.State(), 1009, "valid2", mock.NodePolicy(acl.PolicyWrite))
+
+	cases := []struct {
+		Name          string
+		Token         string
+		ExpectedError string
+	}{
+		{
+			Name:          "bad token",
+			Token:         tokenBad.SecretID,
+			ExpectedError: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			Name:          "good token",
+			Token:         tokenGood.SecretID,
+			ExpectedError: "Unknown node",
+		},
+		{
+			Name:          "root token",
+			Token:         root.SecretID,
+			ExpectedError: "Unknown node",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			req := &cstructs.NodeMetaApplyRequest{
+				NodeID: uuid.Generate(),
+				Meta:   map[string]*string{"network": pointer.Of("degraded")},
+				QueryOptions: structs.QueryOptions{
+					AuthToken: c.Token,
+					Region:    "global",
+				},
+			}
+
+			var resp cstructs.NodeMetaResponse
+			err := msgpackrpc.CallWithCodec(codec, "NodeMeta.Apply", req, &resp)
+			require.NotNil(err)
+			require.Contains(err.Error(), c.ExpectedError)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"golang.org/x/exp/slices"
 
 	"github.com/hashicorp/memberlist"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/uuid"
@@ -62,6 +63,18 @@ type Config struct {
 	// event publishing
 	EnableEventBroker bool
 
+	// RequireMlock makes adding a keyring key fail if its material can't
+	// be locked into memory
+	RequireMlock bool
+
+	// RequireJobSignatures makes job registrations fail unless they are
+	// signed by a trusted job signing key
+	RequireJobSignatures bool
+
+	// InitialRootKey is the key material used for the first root key of the
+	// keyring when the leader initializes it, instead of generating one
+	InitialRootKey []byte
+
 	// EventBufferSize is the amount of events to hold in memory.
 	EventBufferSize int64
 
@@ -138,6 +151,10 @@ type Config struct {
 	// that the workers dequeue for processing.
 	EnabledSchedulers []string
 
+	// DedicatedSchedulers maps a scheduler type to the number of workers,
+	// out of NumSchedulers, reserved for evaluations of that type.
+	DedicatedSchedulers map[string]int
+
 	// ReconcileInterval controls how often we reconcile the strongly
 	// consistent store with the Serf info. This is used to handle nodes
 	// that are force removed, as well as intermittent unavailability during
@@ -166,6 +183,10 @@ type Config struct {
 	// for GC. This gives users some time to view and debug a failed nodes.
 	NodeGCThreshold time.Duration
 
+	// NodePurgeThreshold is how long a node must have been down before the
+	// node GC purges it regardless of its allocations. Zero disables it.
+	NodePurgeThreshold time.Duration
+
 	// DeploymentGCInterval is how often we dispatch a job to GC terminal
 	// deployments.
 	DeploymentGCInterval time.Duration
@@ -202,13 +223,27 @@ type Config struct {
 	RootKeyGCThreshold time.Duration
 
 	// RootKeyRotationThreshold is how "old" an active key can be
-	// before it's rotated
+	// before it's rotated. Zero disables automatic rotation.
 	RootKeyRotationThreshold time.Duration
 
+	// RootKeyPrepublishPeriod is how long before an automatic rotation
+	// the next root key is prepublished, so that every server has it
+	// before it becomes active. Zero disables prepublication.
+	RootKeyPrepublishPeriod time.Duration
+
 	// SecureVariablesRekeyInterval is how often we dispatch a job to
 	// rekey any variables associated with a key in the Rekeying state
 	SecureVariablesRekeyInterval time.Duration
 
+	// SecureVariablesIntegrityInterval is how often the leader samples
+	// secure variables to check that they can still be decrypted and
+	// authenticated with the keyring
+	SecureVariablesIntegrityInterval time.Duration
+
+	// SecureVariablesIntegritySampleSize is how many secure variables the
+	// leader checks every SecureVariablesIntegrityInterval
+	SecureVariablesIntegritySampleSize int
+
 	// EvalNackTimeout controls how long we allow a sub-scheduler to
 	// work on an evaluation before we consider it failed and Nack it.
 	// This allows that evaluation to be handed to another sub-scheduler
@@ -256,6 +291,19 @@ type Config struct {
 	// rejections for nodes.
 	NodePlanRejectionWindow time.Duration
 
+	// PlanBackpressureEnabled controls whether the leader rejects plans when
+	// the plan applier is saturated.
+	PlanBackpressureEnabled bool
+
+	// PlanBackpressureMaxQueueDepth is the plan queue depth at which the
+	// plan applier is considered saturated. Zero disables the check.
+	PlanBackpressureMaxQueueDepth int
+
+	// PlanBackpressureMaxApplyLatency is the average raft apply latency of
+	// plans at which the plan applier is considered saturated. Zero disables
+	// the check.
+	PlanBackpressureMaxApplyLatency time.Duration
+
 	// MinHeartbeatTTL is the minimum time between heartbeats.
 	// This is used as a floor to prevent excessive updates.
 	MinHeartbeatTTL time.Duration
@@ -265,6 +313,13 @@ type Config struct {
 	// to meet the target rate.
 	MaxHeartbeatsPerSecond float64
 
+	// MaxReconnectsPerSecond is the maximum rate at which nodes that were
+	// disconnected or down are allowed to reconnect. Nodes reconnecting
+	// beyond this rate are given a reconnect window after which they must
+	// retry, which rate-limits the reconciliation of their allocations
+	// after a partition heals. Zero disables the limit.
+	MaxReconnectsPerSecond float64
+
 	// HeartbeatGrace is the additional time given as a grace period
 	// beyond the TTL to account for network and processing delays
 	// as well as clock skew.
@@ -394,6 +449,7 @@ func (c *Config) Copy() *Config {
 	nc.RaftConfig = pointer.Copy(c.RaftConfig)
 	nc.SerfConfig = pointer.Copy(c.SerfConfig)
 	nc.EnabledSchedulers = slices.Clone(c.EnabledSchedulers)
+	nc.DedicatedSchedulers = helper.CopyMap(c.DedicatedSchedulers)
 	nc.ConsulConfig = c.ConsulConfig.Copy()
 	nc.VaultConfig = c.VaultConfig.Copy()
 	nc.TLSConfig = c.TLSConfig.Copy()
@@ -456,6 +512,9 @@ func DefaultConfig() *Config {
 		NodePlanRejectionEnabled:         false,
 		NodePlanRejectionThreshold:       15,
 		NodePlanRejectionWindow:          10 * time.Minute,
+		PlanBackpressureEnabled:          false,
+		PlanBackpressureMaxQueueDepth:    1024,
+		PlanBackpressureMaxApplyLatency:  5 * time.Second,
 		ConsulConfig:                     config.DefaultConsulConfig(),
 		VaultConfig:                      config.DefaultVaultConfig(),
 		RPCHoldTimeout:                   5 * time.Second,
@@ -483,7 +542,9 @@ func DefaultConfig() *Config {
 				ServiceSchedulerEnabled:  false,
 			},
 		},
-		DeploymentQueryRateLimit: deploymentwatcher.LimitStateQueriesPerSecond,
+		DeploymentQueryRateLimit:           deploymentwatcher.LimitStateQueriesPerSecond,
+		SecureVariablesIntegrityInterval:   1 * time.Hour,
+		SecureVariablesIntegritySampleSize: 100,
 	}
 
 	// Enable all known schedulers by default
@@ -5,12 +5,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/armon/go-metrics"
 	log "github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
 	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/nomad/helper/mlock"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -96,6 +99,12 @@ func (c *CoreScheduler) forceGC(eval *structs.Evaluation) error {
 
 // jobGC is used to garbage collect eligible jobs.
 func (c *CoreScheduler) jobGC(eval *structs.Evaluation) error {
+	// Prune historic versions of jobs that are not themselves eligible for
+	// garbage collection.
+	if err := c.jobVersionGC(eval); err != nil {
+		return err
+	}
+
 	// Get all the jobs eligible for garbage collection.
 	ws := memdb.NewWatchSet()
 	iter, err := c.snap.JobsByGC(ws, true)
@@ -219,6 +228,139 @@ func (c *CoreScheduler) partitionJobReap(jobs []*structs.Job, leaderACL string)
 	return requests
 }
 
+// jobVersionGC is used to garbage collect historic job versions that fall
+// outside of their namespace's retention policy.
+func (c *CoreScheduler) jobVersionGC(eval *structs.Evaluation) error {
+	ws := memdb.NewWatchSet()
+	policies, err := c.namespaceRetention(ws)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	var gcVersions []*structs.JobVersionID
+	for namespace, retention := range policies {
+		if retention.JobVersions == 0 && retention.JobVersionMaxAge == 0 {
+			continue
+		}
+
+		iter, err := c.snap.JobsByNamespace(ws, namespace)
+		if err != nil {
+			return err
+		}
+
+		pruned := 0
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			job := raw.(*structs.Job)
+			versions, err := c.snap.JobVersionsByID(ws, job.Namespace, job.ID)
+			if err != nil {
+				c.logger.Error("job version GC failed to get versions for job",
+					"job", job.ID, "namespace", job.Namespace, "error", err)
+				continue
+			}
+
+			for _, v := range jobVersionsOutsideRetention(versions, retention, now) {
+				gcVersions = append(gcVersions, &structs.JobVersionID{
+					Namespace: v.Namespace,
+					ID:        v.ID,
+					Version:   v.Version,
+				})
+				pruned++
+			}
+		}
+
+		if pruned > 0 {
+			metrics.IncrCounterWithLabels([]string{"nomad", "core", "retention", "job_versions_pruned"},
+				float32(pruned), []metrics.Label{{Name: "namespace", Value: namespace}})
+		}
+	}
+
+	// Fast-path the nothing case
+	if len(gcVersions) == 0 {
+		return nil
+	}
+	c.logger.Debug("job version GC found eligible versions", "versions", len(gcVersions))
+	return c.jobVersionReap(gcVersions, eval.LeaderACL)
+}
+
+// jobVersionsOutsideRetention returns the historic versions of a job that fall
+// outside of the retention policy. The versions must be sorted newest first,
+// as returned by the state store. The current version is not counted towards
+// the retained versions, and it and the most recent stable version are always
+// retained.
+func jobVersionsOutsideRetention(versions []*structs.Job,
+	retention *structs.NamespaceRetention, now time.Time) []*structs.Job {
+
+	var pruned []*structs.Job
+	kept := 0
+	keptStable := false
+	for i, v := range versions {
+		if i == 0 {
+			keptStable = v.Stable
+			continue
+		}
+		if v.Stable && !keptStable {
+			keptStable = true
+			kept++
+			continue
+		}
+
+		tooMany := retention.JobVersions > 0 && kept >= retention.JobVersions
+		tooOld := retention.JobVersionMaxAge > 0 &&
+			now.Sub(time.Unix(0, v.SubmitTime)) > retention.JobVersionMaxAge
+		if tooMany || tooOld {
+			pruned = append(pruned, v)
+			continue
+		}
+		kept++
+	}
+	return pruned
+}
+
+// jobVersionReap contacts the leader and issues a reap on the passed job
+// versions.
+func (c *CoreScheduler) jobVersionReap(versions []*structs.JobVersionID, leaderACL string) error {
+	for len(versions) > 0 {
+		n := len(versions)
+		if n > structs.MaxUUIDsPerWriteRequest {
+			n = structs.MaxUUIDsPerWriteRequest
+		}
+		req := &structs.JobVersionsDeleteRequest{
+			Versions: versions[:n],
+			WriteRequest: structs.WriteRequest{
+				Region:    c.srv.config.Region,
+				AuthToken: leaderACL,
+			},
+		}
+		versions = versions[n:]
+
+		var resp structs.GenericResponse
+		if err := c.srv.RPC("Job.ReapVersions", req, &resp); err != nil {
+			c.logger.Error("job version reap failed", "error", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// namespaceRetention returns the retention policies of all namespaces that
+// have one set, keyed by namespace name.
+func (c *CoreScheduler) namespaceRetention(ws memdb.WatchSet) (map[string]*structs.NamespaceRetention, error) {
+	iter, err := c.snap.Namespaces(ws)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := map[string]*structs.NamespaceRetention{}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		ns := raw.(*structs.Namespace)
+		if ns.Retention != nil {
+			policies[ns.Name] = ns.Retention
+		}
+	}
+	return policies, nil
+}
+
 // evalGC is used to garbage collect old evaluations
 func (c *CoreScheduler) evalGC(eval *structs.Evaluation) error {
 	// Iterate over the evaluations
@@ -420,8 +562,16 @@ func (c *CoreScheduler) nodeGC(eval *structs.Evaluation) error {
 	oldThreshold := c.getThreshold(eval, "node",
 		"node_gc_threshold", c.srv.config.NodeGCThreshold)
 
+	// Nodes that have been down for longer than the purge threshold are
+	// reaped whether or not their allocations are terminal yet
+	var purgeCutoff time.Time
+	if c.srv.config.NodePurgeThreshold > 0 {
+		purgeCutoff = time.Now().Add(-c.srv.config.NodePurgeThreshold)
+	}
+
 	// Collect the nodes to GC
 	var gcNode []string
+	var purged int
 OUTER:
 	for {
 		raw := iter.Next()
@@ -430,6 +580,12 @@ OUTER:
 		}
 		node := raw.(*structs.Node)
 
+		if !purgeCutoff.IsZero() && node.DownBefore(purgeCutoff) {
+			gcNode = append(gcNode, node.ID)
+			purged++
+			continue
+		}
+
 		// Ignore non-terminal and new nodes
 		if !node.TerminalStatus() || node.ModifyIndex > oldThreshold {
 			continue
@@ -463,6 +619,10 @@ OUTER:
 		return nil
 	}
 	c.logger.Debug("node GC found eligible nodes", "nodes", len(gcNode))
+	if purged > 0 {
+		c.logger.Info("purging nodes down past the purge threshold",
+			"nodes", purged, "threshold", c.srv.config.NodePurgeThreshold)
+	}
 	return c.nodeReap(eval, gcNode)
 }
 
@@ -517,8 +677,24 @@ func (c *CoreScheduler) deploymentGC(eval *structs.Evaluation) error {
 	oldThreshold := c.getThreshold(eval, "deployment",
 		"deployment_gc_threshold", c.srv.config.DeploymentGCThreshold)
 
+	// Namespaces with a retention policy may override the threshold and
+	// limit the number of deployments kept per job.
+	policies, err := c.namespaceRetention(ws)
+	if err != nil {
+		return err
+	}
+	nsThresholds := make(map[string]uint64, len(policies))
+	for namespace, retention := range policies {
+		if retention.DeploymentMaxAge > 0 {
+			nsThresholds[namespace] = c.getThreshold(eval, "deployment",
+				"deployment_max_age", retention.DeploymentMaxAge)
+		}
+	}
+
 	// Collect the deployments to GC
 	var gcDeployment []string
+	pruned := map[string]int{}
+	retained := map[structs.NamespacedID][]*structs.Deployment{}
 
 OUTER:
 	for {
@@ -528,8 +704,20 @@ OUTER:
 		}
 		deploy := raw.(*structs.Deployment)
 
-		// Ignore non-terminal and new deployments
-		if deploy.Active() || deploy.ModifyIndex > oldThreshold {
+		// Ignore non-terminal deployments
+		if deploy.Active() {
+			continue
+		}
+
+		threshold := oldThreshold
+		if t, ok := nsThresholds[deploy.Namespace]; ok {
+			threshold = t
+		}
+		retention := policies[deploy.Namespace]
+
+		// Ignore new deployments unless they may be limited by count
+		isNew := deploy.ModifyIndex > threshold
+		if isNew && (retention == nil || retention.Deployments == 0) {
 			continue
 		}
 
@@ -548,8 +736,38 @@ OUTER:
 			}
 		}
 
+		if isNew {
+			jobID := structs.NamespacedID{ID: deploy.JobID, Namespace: deploy.Namespace}
+			retained[jobID] = append(retained[jobID], deploy)
+			continue
+		}
+
 		// Deployment is eligible for garbage collection
 		gcDeployment = append(gcDeployment, deploy.ID)
+		if retention != nil {
+			pruned[deploy.Namespace]++
+		}
+	}
+
+	// Prune the oldest deployments of jobs that exceed their namespace's
+	// retention count
+	for jobID, deploys := range retained {
+		limit := policies[jobID.Namespace].Deployments
+		if len(deploys) <= limit {
+			continue
+		}
+		sort.Slice(deploys, func(i, j int) bool {
+			return deploys[i].CreateIndex > deploys[j].CreateIndex
+		})
+		for _, deploy := range deploys[limit:] {
+			gcDeployment = append(gcDeployment, deploy.ID)
+			pruned[jobID.Namespace]++
+		}
+	}
+
+	for namespace, n := range pruned {
+		metrics.IncrCounterWithLabels([]string{"nomad", "core", "retention", "deployments_pruned"},
+			float32(n), []metrics.Label{{Name: "namespace", Value: namespace}})
 	}
 
 	// Fast-path the nothing case
@@ -823,8 +1041,8 @@ func (c *CoreScheduler) rootKeyRotateOrGC(eval *structs.Evaluation) error {
 			break
 		}
 		keyMeta := raw.(*structs.RootKeyMeta)
-		if keyMeta.Active() {
-			continue // never GC the active key
+		if keyMeta.Active() || keyMeta.Prepublished() {
+			continue // never GC the active or next key
 		}
 		if keyMeta.CreateIndex > oldThreshold {
 			continue // don't GC recent keys
@@ -858,11 +1076,14 @@ func (c *CoreScheduler) rootKeyRotateOrGC(eval *structs.Evaluation) error {
 }
 
 // rootKeyRotation checks if the active key is old enough that we need
-// to kick off a rotation. Returns true if the key was rotated.
+// to kick off a rotation, or if the next key needs to be prepublished
+// ahead of the rotation. Returns true if the keyring was modified.
 func (c *CoreScheduler) rootKeyRotation(eval *structs.Evaluation) (bool, error) {
 
-	rotationThreshold := c.getThreshold(eval, "root key",
-		"root_key_rotation_threshold", c.srv.config.RootKeyRotationThreshold)
+	rotationInterval := c.srv.config.RootKeyRotationThreshold
+	if rotationInterval == 0 {
+		return false, nil // automatic rotation is disabled
+	}
 
 	ws := memdb.NewWatchSet()
 	activeKey, err := c.snap.GetActiveRootKeyMeta(ws)
@@ -872,11 +1093,52 @@ func (c *CoreScheduler) rootKeyRotation(eval *structs.Evaluation) (bool, error)
 	if activeKey == nil {
 		return false, nil // no active key
 	}
-	if activeKey.CreateIndex >= rotationThreshold {
+
+	now := time.Now()
+	metrics.SetGauge([]string{"keyring", "active_key_age"},
+		float32(now.Sub(time.Unix(0, activeKey.CreateTime)).Seconds()))
+
+	prepublishedKey, err := c.snap.GetPrepublishedRootKeyMeta(ws)
+	if err != nil {
+		return false, err
+	}
+	if prepublishedKey != nil {
+		if now.UnixNano() < prepublishedKey.PublishTime {
+			return false, nil // wait for the publish time
+		}
+		c.logger.Info("activating prepublished root key", "key_id", prepublishedKey.KeyID)
+		return c.rotateRootKey(eval, 0)
+	}
+
+	rotationThreshold := c.getThreshold(eval, "root key",
+		"root_key_rotation_threshold", rotationInterval)
+	rotationTime := time.Unix(0, activeKey.CreateTime).Add(rotationInterval)
+
+	if activeKey.PublishTime == 0 {
+		if activeKey.CreateIndex < rotationThreshold {
+			return c.rotateRootKey(eval, 0)
+		}
+	} else {
+		// a key that was prepublished is only as old as its publish time
+		rotationTime = time.Unix(0, activeKey.PublishTime).Add(rotationInterval)
+		if eval.JobID == structs.CoreJobForceGC || !now.Before(rotationTime) {
+			return c.rotateRootKey(eval, 0)
+		}
+	}
+
+	prepublishPeriod := c.srv.config.RootKeyPrepublishPeriod
+	if prepublishPeriod == 0 || now.Before(rotationTime.Add(-prepublishPeriod)) {
 		return false, nil // key is too new
 	}
+	c.logger.Info("prepublishing root key", "publish_time", rotationTime.UTC())
+	return c.rotateRootKey(eval, rotationTime.UnixNano())
+}
 
+// rotateRootKey rotates the active key, or prepublishes the next key if
+// the publish time is set. Returns true if the key was rotated.
+func (c *CoreScheduler) rotateRootKey(eval *structs.Evaluation, publishTime int64) (bool, error) {
 	req := &structs.KeyringRotateRootKeyRequest{
+		PublishTime: publishTime,
 		WriteRequest: structs.WriteRequest{
 			Region:    c.srv.config.Region,
 			AuthToken: eval.LeaderACL,
@@ -917,10 +1179,26 @@ func (c *CoreScheduler) secureVariablesRekey(eval *structs.Evaluation) error {
 		if err != nil {
 			return err
 		}
+
+		// report how many variables are left to rekey before each pass, as
+		// a rekey can take several evaluations to complete
+		remaining := 0
+		for raw := varIter.Next(); raw != nil; raw = varIter.Next() {
+			remaining++
+		}
+		labels := []metrics.Label{{Name: "key_id", Value: keyMeta.KeyID}}
+		metrics.SetGaugeWithLabels([]string{"keyring", "rekey", "remaining"},
+			float32(remaining), labels)
+
+		varIter, err = c.snap.GetSecureVariablesByKeyID(ws, keyMeta.KeyID)
+		if err != nil {
+			return err
+		}
 		err = c.rotateVariables(varIter, eval)
 		if err != nil {
 			return err
 		}
+		metrics.SetGaugeWithLabels([]string{"keyring", "rekey", "remaining"}, 0, labels)
 
 		// we've now rotated all this key's variables, so set its state
 		keyMeta = keyMeta.Copy()
@@ -1017,6 +1295,7 @@ func (c *CoreScheduler) rotateVariables(iter memdb.ResultIterator, eval *structs
 		}
 		dv.Items = make(map[string]string)
 		err = json.Unmarshal(cleartext, &dv.Items)
+		mlock.Zero(cleartext)
 		if err != nil {
 			return err
 		}
@@ -1037,6 +1316,7 @@ func (c *CoreScheduler) rotateVariables(iter memdb.ResultIterator, eval *structs
 			// made with the new key, so there's nothing for us to do here
 			continue
 		}
+		metrics.IncrCounter([]string{"keyring", "rekey", "variables"}, 1)
 	}
 
 	return nil
@@ -983,6 +983,61 @@ func TestCoreScheduler_NodeGC_RunningAllocs(t *testing.T) {
 	}
 }
 
+func TestCoreScheduler_NodeGC_PurgeThreshold(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NodePurgeThreshold = 24 * time.Hour
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// COMPAT Remove in 0.6: Reset the FSM time table since we reconcile which sets index 0
+	s1.fsm.timetable.table = make([]TimeTableEntry, 1, 10)
+
+	// Insert nodes that have been down for longer and shorter than the
+	// purge threshold, both with running allocs that would block the GC
+	store := s1.fsm.State()
+	stale := mock.Node()
+	stale.Status = structs.NodeStatusDown
+	stale.StatusUpdatedAt = time.Now().Add(-48 * time.Hour).Unix()
+	recent := mock.Node()
+	recent.Status = structs.NodeStatusDown
+	recent.StatusUpdatedAt = time.Now().Add(-time.Hour).Unix()
+	require.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1000, stale))
+	require.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1001, recent))
+
+	var allocs []*structs.Allocation
+	for _, node := range []*structs.Node{stale, recent} {
+		alloc := mock.Alloc()
+		alloc.NodeID = node.ID
+		alloc.DesiredStatus = structs.AllocDesiredStatusRun
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		require.NoError(t, store.UpsertJobSummary(1002, mock.JobSummary(alloc.JobID)))
+		allocs = append(allocs, alloc)
+	}
+	require.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1003, allocs))
+
+	// Update the time tables to make this work
+	tt := s1.fsm.TimeTable()
+	tt.Witness(2000, time.Now().UTC().Add(-1*s1.config.NodeGCThreshold))
+
+	snap, err := store.Snapshot()
+	require.NoError(t, err)
+	core := NewCoreScheduler(s1, snap)
+
+	gc := s1.coreJobEval(structs.CoreJobNodeGC, 2000)
+	require.NoError(t, core.Process(gc))
+
+	out, err := store.NodeByID(nil, stale.ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	out, err = store.NodeByID(nil, recent.ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+}
+
 func TestCoreScheduler_NodeGC_Force(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1788,6 +1843,53 @@ func TestCoreScheduler_DeploymentGC(t *testing.T) {
 	assert.NotNil(out3, "Terminal Deployment With Allocs")
 }
 
+func TestCoreScheduler_DeploymentGC_RetentionCount(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// COMPAT Remove in 0.6: Reset the FSM time table since we reconcile which sets index 0
+	s1.fsm.timetable.table = make([]TimeTableEntry, 1, 10)
+
+	// Keep a single terminal deployment per job in the namespace
+	store := s1.fsm.State()
+	ns := mock.Namespace()
+	ns.Retention = &structs.NamespaceRetention{Deployments: 1}
+	require.NoError(t, store.UpsertNamespaces(999, []*structs.Namespace{ns}))
+
+	// Insert three terminal deployments of the same job, none of which are
+	// old enough to be collected by the deployment GC threshold
+	d1, d2, d3 := mock.Deployment(), mock.Deployment(), mock.Deployment()
+	for _, d := range []*structs.Deployment{d1, d2, d3} {
+		d.Namespace = ns.Name
+		d.JobID = d1.JobID
+		d.Status = structs.DeploymentStatusSuccessful
+	}
+	require.NoError(t, store.UpsertDeployment(1000, d1))
+	require.NoError(t, store.UpsertDeployment(1001, d2))
+	require.NoError(t, store.UpsertDeployment(1002, d3))
+
+	snap, err := store.Snapshot()
+	require.NoError(t, err)
+	core := NewCoreScheduler(s1, snap)
+
+	gc := s1.coreJobEval(structs.CoreJobDeploymentGC, 2000)
+	require.NoError(t, core.Process(gc))
+
+	// Only the most recent deployment should be retained
+	ws := memdb.NewWatchSet()
+	for _, d := range []*structs.Deployment{d1, d2} {
+		out, err := store.DeploymentByID(ws, d.ID)
+		require.NoError(t, err)
+		require.Nil(t, out)
+	}
+	out, err := store.DeploymentByID(ws, d3.ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+}
+
 func TestCoreScheduler_DeploymentGC_Force(t *testing.T) {
 	ci.Parallel(t)
 	for _, withAcl := range []bool{false, true} {
@@ -2539,6 +2641,101 @@ func TestCoreScheduler_RootKeyGC(t *testing.T) {
 	require.NotNil(t, key, "new key should not have been GCd")
 }
 
+// TestCoreScheduler_RootKeyRotation_Prepublish exercises prepublishing the
+// next root key ahead of a scheduled rotation
+func TestCoreScheduler_RootKeyRotation_Prepublish(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, cleanup := TestServer(t, func(c *Config) {
+		c.RootKeyRotationThreshold = time.Hour
+		c.RootKeyPrepublishPeriod = 10 * time.Minute
+	})
+	defer cleanup()
+	testutil.WaitForLeader(t, srv.RPC)
+
+	store := srv.fsm.State()
+	runCoreJob := func(index uint64) {
+		t.Helper()
+		snap, err := store.Snapshot()
+		require.NoError(t, err)
+		core := NewCoreScheduler(srv, snap)
+		eval := srv.coreJobEval(structs.CoreJobRootKeyRotateOrGC, index)
+		require.NoError(t, core.(*CoreScheduler).rootKeyRotateOrGC(eval))
+	}
+
+	// the bootstrapped key is too new to prepublish the next one
+	runCoreJob(1000)
+	prepublished, err := store.GetPrepublishedRootKeyMeta(nil)
+	require.NoError(t, err)
+	require.Nil(t, prepublished)
+
+	// insert an active key due for rotation in 5 minutes
+	key1 := structs.NewRootKeyMeta()
+	key1.CreateTime = time.Now().Add(-55 * time.Minute).UnixNano()
+	key1.SetActive()
+	require.NoError(t, store.UpsertRootKeyMeta(1100, key1, false))
+
+	runCoreJob(1200)
+	prepublished, err = store.GetPrepublishedRootKeyMeta(nil)
+	require.NoError(t, err)
+	require.NotNil(t, prepublished, "expected next key to be prepublished")
+	require.Equal(t, key1.CreateTime+int64(time.Hour), prepublished.PublishTime)
+
+	activeKey, err := store.GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+	require.Equal(t, key1.KeyID, activeKey.KeyID, "active key should not change before publish time")
+
+	// the prepublished key isn't replaced or activated early
+	runCoreJob(1300)
+	got, err := store.GetPrepublishedRootKeyMeta(nil)
+	require.NoError(t, err)
+	require.Equal(t, prepublished.KeyID, got.KeyID)
+
+	// the prepublished key is activated once its publish time has passed
+	key2 := prepublished.Copy()
+	key2.PublishTime = time.Now().Add(-time.Second).UnixNano()
+	require.NoError(t, store.UpsertRootKeyMeta(1400, key2, false))
+
+	runCoreJob(1500)
+	activeKey, err = store.GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+	require.Equal(t, key2.KeyID, activeKey.KeyID, "expected prepublished key to be activated")
+
+	// the activated key is only as old as its publish time, so the next key
+	// isn't prepublished yet
+	runCoreJob(1600)
+	prepublished, err = store.GetPrepublishedRootKeyMeta(nil)
+	require.NoError(t, err)
+	require.Nil(t, prepublished)
+}
+
+// TestCoreScheduler_RootKeyRotation_Disabled verifies that root keys aren't
+// rotated when the rotation interval is zero
+func TestCoreScheduler_RootKeyRotation_Disabled(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, cleanup := TestServer(t, func(c *Config) {
+		c.RootKeyRotationThreshold = 0
+	})
+	defer cleanup()
+	testutil.WaitForLeader(t, srv.RPC)
+
+	store := srv.fsm.State()
+	key0, err := store.GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+	require.NotNil(t, key0)
+
+	snap, err := store.Snapshot()
+	require.NoError(t, err)
+	core := NewCoreScheduler(srv, snap)
+	eval := srv.coreJobEval(structs.CoreJobForceGC, 1000)
+	require.NoError(t, core.(*CoreScheduler).rootKeyRotateOrGC(eval))
+
+	activeKey, err := store.GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+	require.Equal(t, key0.KeyID, activeKey.KeyID)
+}
+
 // TestCoreScheduler_SecureVariablesRekey exercises secure variables rekeying
 func TestCoreScheduler_SecureVariablesRekey(t *testing.T) {
 	ci.Parallel(t)
@@ -2675,3 +2872,78 @@ func TestCoreScheduler_FailLoop(t *testing.T) {
 			out.TriggeredBy)
 	}
 }
+
+func TestCoreScheduler_JobVersionGC(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	store := s1.fsm.State()
+	ns := mock.Namespace()
+	ns.Retention = &structs.NamespaceRetention{JobVersions: 2}
+	require.NoError(t, store.UpsertNamespaces(999, []*structs.Namespace{ns}))
+
+	// Register four versions of a running job
+	job := mock.Job()
+	job.Namespace = ns.Name
+	for i := uint64(0); i < 4; i++ {
+		job = job.Copy()
+		job.Meta["version"] = fmt.Sprintf("%d", i)
+		require.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000+i, job))
+	}
+
+	snap, err := store.Snapshot()
+	require.NoError(t, err)
+	core := NewCoreScheduler(s1, snap)
+
+	gc := s1.coreJobEval(structs.CoreJobJobGC, 2000)
+	require.NoError(t, core.Process(gc))
+
+	ws := memdb.NewWatchSet()
+	versions, err := store.JobVersionsByID(ws, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+	require.Equal(t, uint64(3), versions[0].Version)
+	require.Equal(t, uint64(2), versions[1].Version)
+	require.Equal(t, uint64(1), versions[2].Version)
+}
+
+func TestCoreScheduler_jobVersionsOutsideRetention(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	versions := make([]*structs.Job, 5)
+	for i := range versions {
+		versions[i] = &structs.Job{
+			Version:    uint64(len(versions) - 1 - i),
+			SubmitTime: now.Add(-time.Duration(i) * time.Hour).UnixNano(),
+		}
+	}
+	versions[3].Stable = true
+
+	prunedVersions := func(r *structs.NamespaceRetention) []uint64 {
+		var out []uint64
+		for _, v := range jobVersionsOutsideRetention(versions, r, now) {
+			out = append(out, v.Version)
+		}
+		return out
+	}
+
+	// The current version does not count towards the historic versions, and
+	// the stable version is kept even past the count
+	require.Equal(t, []uint64{2, 0},
+		prunedVersions(&structs.NamespaceRetention{JobVersions: 1}))
+	require.Equal(t, []uint64{0},
+		prunedVersions(&structs.NamespaceRetention{JobVersions: 2}))
+	require.Empty(t, prunedVersions(&structs.NamespaceRetention{JobVersions: 4}))
+
+	// The current version is kept regardless of its age
+	require.Equal(t, []uint64{2, 0},
+		prunedVersions(&structs.NamespaceRetention{JobVersionMaxAge: 90 * time.Minute}))
+	require.Equal(t, []uint64{3, 2, 0},
+		prunedVersions(&structs.NamespaceRetention{JobVersionMaxAge: time.Nanosecond}))
+
+	require.Empty(t, prunedVersions(&structs.NamespaceRetention{}))
+}
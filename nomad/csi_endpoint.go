@@ -197,6 +197,7 @@ func (v *CSIVolume) List(args *structs.CSIVolumeListRequest, reply *structs.CSIV
 			}
 
 			reply.QueryMeta.NextToken = nextToken
+			reply.QueryMeta.ObjectsScanned = paginator.Scanned()
 			reply.Volumes = vs
 			return v.srv.replySetIndex(csiVolumeTable, &reply.QueryMeta)
 		}}
@@ -456,6 +456,7 @@ func (d *Deployment) List(args *structs.DeploymentListRequest, reply *structs.De
 			}
 
 			reply.QueryMeta.NextToken = nextToken
+			reply.QueryMeta.ObjectsScanned = paginator.Scanned()
 			reply.Deployments = deploys
 
 			// Use the last index that affected the deployment table
@@ -432,6 +432,11 @@ func (w *deploymentWatcher) watch() {
 
 	rollback, deadlineHit := false, false
 
+	// disconnected is set when the progress deadline was hit while
+	// allocations of the deployment were on disconnected clients. Failing the
+	// deployment is deferred until those clients reconnect or are replaced.
+	disconnected := false
+
 FAIL:
 	for {
 		select {
@@ -446,6 +451,13 @@ FAIL:
 			// deadlineHit flag is never reset, so even in the case of a
 			// manual promotion, we'll describe any failure as a progress
 			// deadline failure at this point.
+			if hasUnknownAllocs(w.getDeployment()) {
+				w.logger.Debug("deadline hit with allocations on disconnected clients, pausing")
+				disconnected = true
+				w.setDisconnectedDescription(true)
+				continue
+			}
+
 			deadlineHit = true
 			fail, rback, err := w.shouldFail()
 			if err != nil {
@@ -466,8 +478,22 @@ FAIL:
 		case <-w.deploymentUpdateCh:
 			// Get the updated deployment and check if we should change the
 			// deadline timer
-			next := w.getDeploymentProgressCutoff(w.getDeployment())
-			if !next.Equal(currentDeadline) {
+			d := w.getDeployment()
+			next := w.getDeploymentProgressCutoff(d)
+
+			// Resume the progress deadline once the disconnected clients have
+			// reconnected or their allocations were replaced, and create an
+			// eval so the scheduler reconciles the deployment.
+			resumed := false
+			if disconnected && !hasUnknownAllocs(d) {
+				w.logger.Debug("disconnected allocations resolved, resuming")
+				disconnected = false
+				resumed = true
+				w.setDisconnectedDescription(false)
+				w.createBatchedUpdate(nil, allocIndex)
+			}
+
+			if !next.Equal(currentDeadline) || resumed {
 				prevDeadlineZero := currentDeadline.IsZero()
 				currentDeadline = next
 				// The most recent deadline can be zero if no allocs were created for this deployment.
@@ -612,6 +638,12 @@ func (w *deploymentWatcher) handleAllocUpdate(allocs []*structs.AllocListStub) (
 			continue
 		}
 
+		// Allocations on disconnected clients can't report their health, so
+		// don't account for them until their client reconnects.
+		if alloc.ClientStatus == structs.AllocClientStatusUnknown {
+			continue
+		}
+
 		// Determine if the update stanza for this group is progress based
 		progressBased := dstate.ProgressDeadline != 0
 
@@ -699,6 +731,43 @@ func (w *deploymentWatcher) shouldFail() (fail, rollback bool, err error) {
 	return fail, false, nil
 }
 
+// hasUnknownAllocs returns whether any allocation of the deployment is on a
+// disconnected client.
+func hasUnknownAllocs(d *structs.Deployment) bool {
+	if d == nil {
+		return false
+	}
+	for _, dstate := range d.TaskGroups {
+		if dstate.UnknownAllocs > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// setDisconnectedDescription updates the description of a running deployment
+// to reflect whether it is waiting for disconnected clients. Descriptions set
+// by other means, such as pending promotion, are left untouched.
+func (w *deploymentWatcher) setDisconnectedDescription(disconnected bool) {
+	d := w.getDeployment()
+	if d == nil || d.Status != structs.DeploymentStatusRunning {
+		return
+	}
+
+	from, to := structs.DeploymentStatusDescriptionRunning, structs.DeploymentStatusDescriptionRunningDisconnected
+	if !disconnected {
+		from, to = to, from
+	}
+	if d.StatusDescription != from {
+		return
+	}
+
+	u := w.getDeploymentStatusUpdate(structs.DeploymentStatusRunning, to)
+	if _, err := w.upsertDeploymentStatusUpdate(u, nil, nil); err != nil {
+		w.logger.Error("failed to update deployment status", "error", err)
+	}
+}
+
 // getDeploymentProgressCutoff returns the progress cutoff for the given
 // deployment
 func (w *deploymentWatcher) getDeploymentProgressCutoff(d *structs.Deployment) time.Time {
@@ -1234,6 +1234,108 @@ func TestDeploymentWatcher_Watch_ProgressDeadline(t *testing.T) {
 	})
 }
 
+// Test that the progress deadline is paused while allocations are on
+// disconnected clients and resumes once they reconnect.
+func TestDeploymentWatcher_Watch_ProgressDeadline_Disconnected(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	w, m := testDeploymentWatcher(t, 1000.0, 1*time.Millisecond)
+
+	// Create a job, alloc, and a deployment
+	j := mock.Job()
+	j.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	j.TaskGroups[0].Update.MaxParallel = 2
+	j.TaskGroups[0].Update.ProgressDeadline = 500 * time.Millisecond
+	j.Stable = true
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.TaskGroups["web"].ProgressDeadline = 500 * time.Millisecond
+	a := mock.Alloc()
+	now := time.Now()
+	a.CreateTime = now.UnixNano()
+	a.ModifyTime = now.UnixNano()
+	a.DeploymentID = d.ID
+	require.Nil(m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), j), "UpsertJob")
+	require.Nil(m.state.UpsertDeployment(m.nextIndex(), d), "UpsertDeployment")
+	require.Nil(m.state.UpsertAllocs(structs.MsgTypeTestSetup, m.nextIndex(), []*structs.Allocation{a}), "UpsertAllocs")
+
+	// Mark the alloc as being on a disconnected client
+	a2 := a.Copy()
+	a2.ClientStatus = structs.AllocClientStatusUnknown
+	require.Nil(m.state.UpsertAllocs(structs.MsgTypeTestSetup, m.nextIndex(), []*structs.Allocation{a2}), "UpsertAllocs")
+
+	out, err := m.state.DeploymentByID(nil, d.ID)
+	require.NoError(err)
+	require.Equal(1, out.TaskGroups["web"].UnknownAllocs)
+
+	// require that we get calls to UpsertDeploymentStatusUpdate
+	c := &matchDeploymentStatusUpdateConfig{
+		DeploymentID:      d.ID,
+		Status:            structs.DeploymentStatusRunning,
+		StatusDescription: structs.DeploymentStatusDescriptionRunningDisconnected,
+	}
+	m.On("UpdateDeploymentStatus", mocker.MatchedBy(matchDeploymentStatusUpdateRequest(c))).Return(nil)
+	c2 := &matchDeploymentStatusUpdateConfig{
+		DeploymentID:      d.ID,
+		Status:            structs.DeploymentStatusFailed,
+		StatusDescription: structs.DeploymentStatusDescriptionProgressDeadline,
+		Eval:              true,
+	}
+	m.On("UpdateDeploymentStatus", mocker.MatchedBy(matchDeploymentStatusUpdateRequest(c2))).Return(nil)
+	m.On("UpdateAllocDesiredTransition", mocker.Anything).Return(nil).Maybe()
+
+	w.SetEnabled(true, m.state)
+	testutil.WaitForResult(func() (bool, error) { return 1 == watchersCount(w), nil },
+		func(err error) { require.Equal(1, watchersCount(w), "Should have 1 deployment") })
+
+	// Wait for the deadline to pass and require the deployment is still
+	// running while waiting for the disconnected client
+	testutil.WaitForResult(func() (bool, error) {
+		d, err := m.state.DeploymentByID(nil, d.ID)
+		if err != nil {
+			return false, err
+		}
+		if d.Status != structs.DeploymentStatusRunning {
+			return false, fmt.Errorf("bad status %q", d.Status)
+		}
+		return d.StatusDescription == structs.DeploymentStatusDescriptionRunningDisconnected,
+			fmt.Errorf("bad status description %q", d.StatusDescription)
+	}, func(err error) {
+		t.Fatal(err)
+	})
+
+	// Reconnect the client and require the deployment resumes
+	a3 := a.Copy()
+	a3.ClientStatus = structs.AllocClientStatusRunning
+	a3.ModifyTime = time.Now().UnixNano()
+	require.Nil(m.state.UpdateAllocsFromClient(structs.MsgTypeTestSetup, m.nextIndex(), []*structs.Allocation{a3}))
+
+	testutil.WaitForResult(func() (bool, error) {
+		d, err := m.state.DeploymentByID(nil, d.ID)
+		if err != nil {
+			return false, err
+		}
+		if n := d.TaskGroups["web"].UnknownAllocs; n != 0 {
+			return false, fmt.Errorf("got %d unknown allocs", n)
+		}
+		return d.StatusDescription != structs.DeploymentStatusDescriptionRunningDisconnected,
+			fmt.Errorf("bad status description %q", d.StatusDescription)
+	}, func(err error) {
+		t.Fatal(err)
+	})
+
+	// Without healthy allocations the resumed deadline fails the deployment
+	testutil.WaitForResult(func() (bool, error) {
+		d, err := m.state.DeploymentByID(nil, d.ID)
+		if err != nil {
+			return false, err
+		}
+		return d.Status == structs.DeploymentStatusFailed, fmt.Errorf("bad status %q", d.Status)
+	}, func(err error) {
+		t.Fatal(err)
+	})
+}
+
 // Test that progress deadline handling works when there are multiple groups
 func TestDeploymentWatcher_ProgressCutoff(t *testing.T) {
 	ci.Parallel(t)
@@ -6,6 +6,7 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/ed25519"
+	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -14,18 +15,24 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	// note: this is aliased so that it's more noticeable if someone
 	// accidentally swaps it out for math/rand via running goimports
 	cryptorand "crypto/rand"
 
+	metrics "github.com/armon/go-metrics"
 	jwt "github.com/golang-jwt/jwt/v4"
 	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-msgpack/codec"
+	"golang.org/x/crypto/nacl/box"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/exp/slices"
 	"golang.org/x/time/rate"
 
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/mlock"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -34,16 +41,48 @@ const nomadKeystoreExtension = ".nks.json"
 // Encrypter is the keyring for secure variables.
 type Encrypter struct {
 	srv          *Server
+	log          log.Logger
 	keystorePath string
 
+	// requireMlock fails adding keys whose material can't be locked
+	// into memory, rather than only logging a warning
+	requireMlock bool
+
 	keyring map[string]*keyset
 	lock    sync.RWMutex
 }
 
 type keyset struct {
-	rootKey    *structs.RootKey
-	cipher     cipher.AEAD
-	privateKey ed25519.PrivateKey
+	rootKey   *structs.RootKey
+	cipher    cipher.AEAD
+	publicKey ed25519.PublicKey
+
+	// material is the locked buffer that backs rootKey.Key, or nil if the
+	// key material couldn't be locked. The signing key is derived from
+	// rootKey.Key as needed, because the ed25519 package caches keys by
+	// address and so they must live on the Go heap.
+	material []byte
+
+	// signOps and lastUsed track the local use of the key since this
+	// server started, and are reported by KeyUsage
+	signOps  atomic.Uint64
+	lastUsed atomic.Int64
+}
+
+// used records that the key was just used
+func (ks *keyset) used() {
+	ks.lastUsed.Store(time.Now().UnixNano())
+}
+
+// zero clears the key material of a keyset that has been removed from the
+// keyring, and unlocks it if it was locked. The keyset must not be used
+// afterwards.
+func (ks *keyset) zero() error {
+	if ks.material != nil {
+		return mlock.Free(ks.material)
+	}
+	mlock.Zero(ks.rootKey.Key)
+	return nil
 }
 
 // NewEncrypter loads or creates a new local keystore and returns an
@@ -53,21 +92,26 @@ func NewEncrypter(srv *Server, keystorePath string) (*Encrypter, error) {
 	if err != nil {
 		return nil, err
 	}
-	encrypter, err := encrypterFromKeystore(keystorePath)
+	encrypter := &Encrypter{
+		srv:          srv,
+		log:          log.NewNullLogger(),
+		keyring:      make(map[string]*keyset),
+		keystorePath: keystorePath,
+	}
+	if srv != nil {
+		encrypter.log = srv.logger.Named("keyring")
+		encrypter.requireMlock = srv.config.RequireMlock
+	}
+
+	err = encrypter.loadKeystore(keystorePath)
 	if err != nil {
 		return nil, err
 	}
-	encrypter.srv = srv
 	return encrypter, nil
 }
 
-func encrypterFromKeystore(keystoreDirectory string) (*Encrypter, error) {
-
-	encrypter := &Encrypter{
-		keyring:      make(map[string]*keyset),
-		keystorePath: keystoreDirectory,
-	}
-
+// loadKeystore adds the keys found in the keystore directory to the keyring
+func (e *Encrypter) loadKeystore(keystoreDirectory string) error {
 	err := filepath.Walk(keystoreDirectory, func(path string, info fs.FileInfo, err error) error {
 		if err != nil {
 			return fmt.Errorf("could not read path %s from keystore: %v", path, err)
@@ -87,7 +131,7 @@ func encrypterFromKeystore(keystoreDirectory string) (*Encrypter, error) {
 			return nil
 		}
 
-		key, err := encrypter.loadKeyFromStore(path)
+		key, err := e.loadKeyFromStore(path)
 		if err != nil {
 			return fmt.Errorf("could not load key file %s from keystore: %v", path, err)
 		}
@@ -95,17 +139,13 @@ func encrypterFromKeystore(keystoreDirectory string) (*Encrypter, error) {
 			return fmt.Errorf("root key ID %s must match key file %s", key.Meta.KeyID, path)
 		}
 
-		err = encrypter.AddKey(key)
+		err = e.AddKey(key)
 		if err != nil {
 			return fmt.Errorf("could not add key file %s to keystore: %v", path, err)
 		}
 		return nil
 	})
-	if err != nil {
-		return nil, err
-	}
-
-	return encrypter, nil
+	return err
 }
 
 // Encrypt encrypts the clear data with the cipher for the current
@@ -138,25 +178,58 @@ func (e *Encrypter) Encrypt(cleartext []byte) ([]byte, string, error) {
 	// ciphertext together, and so that we're not tempted to reuse
 	// the cleartext buffer which the caller still owns
 	ciphertext := keyset.cipher.Seal(nonce, nonce, cleartext, additional)
+	keyset.used()
 	return ciphertext, keyID, nil
 }
 
 // Decrypt takes an encrypted buffer and then root key ID. It extracts
 // the nonce, decrypts the content, and returns the cleartext data.
 func (e *Encrypter) Decrypt(ciphertext []byte, keyID string) ([]byte, error) {
+	return e.decrypt(ciphertext, keyID, true)
+}
+
+// Authenticate checks that the encrypted buffer can be decrypted with the
+// root key and that its authentication tag is valid, without recording the
+// key as used.
+func (e *Encrypter) Authenticate(ciphertext []byte, keyID string) error {
+	_, err := e.decrypt(ciphertext, keyID, false)
+	return err
+}
+
+func (e *Encrypter) decrypt(ciphertext []byte, keyID string, markUsed bool) ([]byte, error) {
 	e.lock.RLock()
 	defer e.lock.RUnlock()
 
 	keyset, err := e.keysetByIDLocked(keyID)
 	if err != nil {
+		emitDecryptFailure(keyID)
 		return nil, err
 	}
 
 	nonceSize := keyset.cipher.NonceSize()
+	if len(ciphertext) < nonceSize {
+		emitDecryptFailure(keyID)
+		return nil, fmt.Errorf("ciphertext is shorter than its nonce")
+	}
 	nonce := ciphertext[:nonceSize] // nonce was stored alongside ciphertext
 	additional := []byte(keyID)     // keyID was included in the signature inputs
 
-	return keyset.cipher.Open(nil, nonce, ciphertext[nonceSize:], additional)
+	if markUsed {
+		keyset.used()
+	}
+	cleartext, err := keyset.cipher.Open(nil, nonce, ciphertext[nonceSize:], additional)
+	if err != nil {
+		emitDecryptFailure(keyID)
+		return nil, err
+	}
+	return cleartext, nil
+}
+
+// emitDecryptFailure counts a failure to decrypt with the given root key, so
+// that operators can alert on a missing or corrupt key.
+func emitDecryptFailure(keyID string) {
+	metrics.IncrCounterWithLabels([]string{"keyring", "decrypt_failures"}, 1,
+		[]metrics.Label{{Name: "key_id", Value: keyID}})
 }
 
 // keyIDHeader is the JWT header for the Nomad Key ID used to sign the
@@ -178,14 +251,33 @@ func (e *Encrypter) SignClaims(claim *structs.IdentityClaims) (string, error) {
 	token := jwt.NewWithClaims(&jwt.SigningMethodEd25519{}, claim)
 	token.Header[keyIDHeader] = keyset.rootKey.Meta.KeyID
 
-	tokenString, err := token.SignedString(keyset.privateKey)
+	privateKey := ed25519.NewKeyFromSeed(keyset.rootKey.Key)
+	defer mlock.Zero(privateKey)
+
+	tokenString, err := token.SignedString(privateKey)
 	if err != nil {
 		return "", err
 	}
 
+	keyset.signOps.Add(1)
+	keyset.used()
 	return tokenString, nil
 }
 
+// KeyUsage returns the number of signing operations and the last time the
+// key was used by this server, or zero values if the key isn't in the
+// keyring.
+func (e *Encrypter) KeyUsage(keyID string) (uint64, int64) {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	keyset, ok := e.keyring[keyID]
+	if !ok {
+		return 0, 0
+	}
+	return keyset.signOps.Load(), keyset.lastUsed.Load()
+}
+
 // VerifyClaim accepts a previously-signed encoded claim and validates
 // it before returning the claim
 func (e *Encrypter) VerifyClaim(tokenString string) (*structs.IdentityClaims, error) {
@@ -205,7 +297,7 @@ func (e *Encrypter) VerifyClaim(tokenString string) (*structs.IdentityClaims, er
 		if err != nil {
 			return nil, err
 		}
-		return keyset.privateKey.Public(), nil
+		return keyset.publicKey, nil
 	})
 
 	if err != nil {
@@ -256,19 +348,51 @@ func (e *Encrypter) addCipher(rootKey *structs.RootKey) error {
 		return fmt.Errorf("invalid algorithm %s", rootKey.Meta.Algorithm)
 	}
 
-	privateKey := ed25519.NewKeyFromSeed(rootKey.Key)
+	// the keyring keeps its own copy of the key material, locked out of
+	// swap on pages of its own so that it can be unlocked again when the
+	// key is removed
+	material, err := mlock.Alloc(len(rootKey.Key))
+	if err != nil {
+		if e.requireMlock {
+			return fmt.Errorf("could not lock key material into memory: %v", err)
+		}
+		e.log.Warn("could not lock key material into memory", "key_id", rootKey.Meta.KeyID, "error", err)
+	}
+	key := material
+	if key == nil {
+		key = make([]byte, len(rootKey.Key))
+	}
+	copy(key, rootKey.Key)
+
+	privateKey := ed25519.NewKeyFromSeed(key)
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+	mlock.Zero(privateKey)
 
 	e.lock.Lock()
 	defer e.lock.Unlock()
-	e.keyring[rootKey.Meta.KeyID] = &keyset{
-		rootKey:    rootKey,
-		cipher:     aead,
-		privateKey: privateKey,
+	ks := &keyset{
+		rootKey:   &structs.RootKey{Meta: rootKey.Meta, Key: key},
+		cipher:    aead,
+		publicKey: publicKey,
+		material:  material,
+	}
+
+	// keep the usage of a key whose metadata is being updated, and clear
+	// the material of the keyset it replaces
+	if old, ok := e.keyring[rootKey.Meta.KeyID]; ok {
+		ks.signOps.Store(old.signOps.Load())
+		ks.lastUsed.Store(old.lastUsed.Load())
+		if err := old.zero(); err != nil {
+			e.log.Warn("could not unlock key material", "key_id", rootKey.Meta.KeyID, "error", err)
+		}
 	}
+	e.keyring[rootKey.Meta.KeyID] = ks
 	return nil
 }
 
-// GetKey retrieves the key material by ID from the keyring
+// GetKey retrieves a copy of the key material by ID from the keyring. The
+// keyring clears its own copy when the key is removed, so callers own the
+// returned buffer and should zero it once they are done with it.
 func (e *Encrypter) GetKey(keyID string) ([]byte, error) {
 	e.lock.RLock()
 	defer e.lock.RUnlock()
@@ -277,7 +401,7 @@ func (e *Encrypter) GetKey(keyID string) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	return keyset.rootKey.Key, nil
+	return slices.Clone(keyset.rootKey.Key), nil
 }
 
 // activeKeySetLocked returns the keyset that belongs to the key marked as
@@ -310,6 +434,11 @@ func (e *Encrypter) RemoveKey(keyID string) error {
 	// remove the serialized file?
 	e.lock.Lock()
 	defer e.lock.Unlock()
+	if ks, ok := e.keyring[keyID]; ok {
+		if err := ks.zero(); err != nil {
+			e.log.Warn("could not unlock key material", "key_id", keyID, "error", err)
+		}
+	}
 	delete(e.keyring, keyID)
 	return nil
 }
@@ -348,10 +477,11 @@ func (e *Encrypter) loadKeyFromStore(path string) (*structs.RootKey, error) {
 	}
 
 	meta := &structs.RootKeyMeta{
-		State:      storedKey.Meta.State,
-		KeyID:      storedKey.Meta.KeyID,
-		Algorithm:  storedKey.Meta.Algorithm,
-		CreateTime: storedKey.Meta.CreateTime,
+		State:       storedKey.Meta.State,
+		KeyID:       storedKey.Meta.KeyID,
+		Algorithm:   storedKey.Meta.Algorithm,
+		CreateTime:  storedKey.Meta.CreateTime,
+		PublishTime: storedKey.Meta.PublishTime,
 	}
 	if err = meta.Validate(); err != nil {
 		return nil, err
@@ -368,6 +498,114 @@ func (e *Encrypter) loadKeyFromStore(path string) (*structs.RootKey, error) {
 	}, nil
 }
 
+const (
+	// scrypt parameters used to derive the wrapping key of a root key
+	// bundle from its passphrase
+	bundleScryptN    = 32768
+	bundleScryptR    = 8
+	bundleScryptP    = 1
+	bundleSaltLen    = 32
+	bundleWrapKeyLen = 32
+	minBundlePassLen = 12
+)
+
+// sealRootKey seals the root key into a bundle using a wrapping key
+// derived from the passphrase. The key ID is used as additional data so
+// that a bundle can't be installed under another ID.
+func sealRootKey(rootKey *structs.RootKey, passphrase string) (*structs.RootKeyBundle, error) {
+	salt := make([]byte, bundleSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("could not generate salt: %v", err)
+	}
+	aead, err := bundleCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("could not generate nonce: %v", err)
+	}
+
+	return &structs.RootKeyBundle{
+		KeyID:      rootKey.Meta.KeyID,
+		Algorithm:  rootKey.Meta.Algorithm,
+		CreateTime: rootKey.Meta.CreateTime,
+		KDF:        structs.RootKeyBundleKDFScrypt,
+		Salt:       salt,
+		Ciphertext: aead.Seal(nonce, nonce, rootKey.Key, []byte(rootKey.Meta.KeyID)),
+	}, nil
+}
+
+// openRootKey opens a bundle created by sealRootKey. The returned key is
+// inactive and the caller owns its key material.
+func openRootKey(bundle *structs.RootKeyBundle, passphrase string) (*structs.RootKey, error) {
+	if err := bundle.Validate(); err != nil {
+		return nil, err
+	}
+	aead, err := bundleCipher(passphrase, bundle.Salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(bundle.Ciphertext) < aead.NonceSize() {
+		return nil, fmt.Errorf("root key bundle is malformed")
+	}
+	nonce := bundle.Ciphertext[:aead.NonceSize()]
+	key, err := aead.Open(nil, nonce, bundle.Ciphertext[aead.NonceSize():], []byte(bundle.KeyID))
+	if err != nil {
+		return nil, fmt.Errorf("could not open root key bundle: invalid passphrase or corrupt bundle")
+	}
+
+	meta := structs.NewRootKeyMeta()
+	meta.KeyID = bundle.KeyID
+	meta.Algorithm = bundle.Algorithm
+	meta.CreateTime = bundle.CreateTime
+	if err := meta.Validate(); err != nil {
+		mlock.Zero(key)
+		return nil, err
+	}
+	return &structs.RootKey{Meta: meta, Key: key}, nil
+}
+
+// bundleCipher derives the wrapping key for a root key bundle.
+func bundleCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	if len(passphrase) < minBundlePassLen {
+		return nil, fmt.Errorf("passphrase must be at least %d characters", minBundlePassLen)
+	}
+	wrapKey, err := scrypt.Key([]byte(passphrase), salt,
+		bundleScryptN, bundleScryptR, bundleScryptP, bundleWrapKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("could not derive key from passphrase: %v", err)
+	}
+	defer mlock.Zero(wrapKey)
+
+	block, err := aes.NewCipher(wrapKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not create cipher: %v", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// wrapRootKey seals key material to the X25519 public key of the server
+// replicating it, which is the only one that can open it.
+func wrapRootKey(key, publicKey []byte) ([]byte, error) {
+	if len(publicKey) != 32 {
+		return nil, fmt.Errorf("invalid public key length %d", len(publicKey))
+	}
+	var recipient [32]byte
+	copy(recipient[:], publicKey)
+	return box.SealAnonymous(nil, key, &recipient, cryptorand.Reader)
+}
+
+// unwrapRootKey opens key material sealed by wrapRootKey. The caller owns
+// the returned key material.
+func unwrapRootKey(wrapped []byte, publicKey, privateKey *[32]byte) ([]byte, error) {
+	key, ok := box.OpenAnonymous(nil, wrapped, publicKey, privateKey)
+	if !ok {
+		return nil, fmt.Errorf("could not open wrapped root key")
+	}
+	return key, nil
+}
+
 type KeyringReplicator struct {
 	srv       *Server
 	encrypter *Encrypter
@@ -42,6 +42,43 @@ func TestEncrypter_LoadSave(t *testing.T) {
 	}
 }
 
+// TestEncrypter_RemoveKey_Zero verifies that the keyring keeps and hands out
+// copies of key material, and releases its own copy when a key is removed
+func TestEncrypter_RemoveKey_Zero(t *testing.T) {
+	ci.Parallel(t)
+
+	encrypter, err := NewEncrypter(nil, t.TempDir())
+	require.NoError(t, err)
+
+	key, err := structs.NewRootKey(structs.EncryptionAlgorithmAES256GCM)
+	require.NoError(t, err)
+	material := key.Key
+	require.NoError(t, encrypter.AddKey(key))
+
+	ks := encrypter.keyring[key.Meta.KeyID]
+	require.Equal(t, material, []byte(ks.rootKey.Key))
+	require.NotSame(t, &material[0], &ks.rootKey.Key[0])
+	if ks.material != nil {
+		require.Same(t, &ks.material[0], &ks.rootKey.Key[0])
+	}
+
+	got, err := encrypter.GetKey(key.Meta.KeyID)
+	require.NoError(t, err)
+	require.Equal(t, material, got)
+	require.NotSame(t, &ks.rootKey.Key[0], &got[0])
+
+	require.NoError(t, encrypter.RemoveKey(key.Meta.KeyID))
+	if ks.material == nil {
+		// the unlocked copy is zeroed in place; a locked copy is unmapped
+		require.Equal(t, make([]byte, len(material)), ks.rootKey.Key)
+	}
+	require.NotEqual(t, make([]byte, len(material)), material)
+	require.NotEqual(t, make([]byte, len(got)), got)
+
+	_, err = encrypter.GetKey(key.Meta.KeyID)
+	require.Error(t, err)
+}
+
 // TestEncrypter_Restore exercises the entire reload of a keystore,
 // including pairing metadata with key material
 func TestEncrypter_Restore(t *testing.T) {
@@ -288,6 +325,29 @@ func TestEncrypter_EncryptDecrypt(t *testing.T) {
 	require.Equal(t, cleartext, got)
 }
 
+func TestEncrypter_InitialRootKey(t *testing.T) {
+	ci.Parallel(t)
+
+	initialKey := []byte("0123456789abcdef0123456789abcdef")
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.InitialRootKey = initialKey
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+
+	var keyMeta *structs.RootKeyMeta
+	require.Eventually(t, func() bool {
+		keyMeta, _ = srv.fsm.State().GetActiveRootKeyMeta(nil)
+		return keyMeta != nil
+	}, time.Second*5, time.Millisecond*100, "expected keyring to be initialized")
+
+	// The keyring is bootstrapped with the provided key material
+	key, err := srv.encrypter.GetKey(keyMeta.KeyID)
+	require.NoError(t, err)
+	require.Equal(t, initialKey, key)
+}
+
 func TestEncrypter_SignVerify(t *testing.T) {
 
 	ci.Parallel(t)
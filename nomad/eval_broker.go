@@ -69,6 +69,10 @@ type EvalBroker struct {
 	// ready tracks the ready jobs by scheduler in a priority queue
 	ready map[string]PendingEvaluations
 
+	// readyTime tracks when each ready evaluation was made ready, to measure
+	// how long evaluations of each scheduler type wait for a worker
+	readyTime map[string]time.Time
+
 	// unack is a map of evalID to an un-acknowledged evaluation
 	unack map[string]*unackEval
 
@@ -141,6 +145,7 @@ func NewEvalBroker(timeout, initialNackDelay, subsequentNackDelay time.Duration,
 		jobEvals:             make(map[structs.NamespacedID]string),
 		blocked:              make(map[structs.NamespacedID]PendingEvaluations),
 		ready:                make(map[string]PendingEvaluations),
+		readyTime:            make(map[string]time.Time),
 		unack:                make(map[string]*unackEval),
 		waiting:              make(map[string]chan struct{}),
 		requeue:              make(map[string]*structs.Evaluation),
@@ -318,6 +323,7 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, queue string) {
 	// Push onto the heap
 	heap.Push(&pending, eval)
 	b.ready[queue] = pending
+	b.readyTime[eval.ID] = time.Now()
 
 	// Update the stats
 	b.stats.TotalReady += 1
@@ -442,6 +448,12 @@ func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string,
 	b.ready[sched] = pending
 	eval := raw.(*structs.Evaluation)
 
+	// Measure how long the evaluation waited for a worker of its type
+	if readyAt, ok := b.readyTime[eval.ID]; ok {
+		metrics.MeasureSince([]string{"nomad", "broker", sched, "wait_time"}, readyAt)
+		delete(b.readyTime, eval.ID)
+	}
+
 	// Generate a UUID for the token
 	token := uuid.Generate()
 
@@ -739,6 +751,7 @@ func (b *EvalBroker) flush() {
 	b.jobEvals = make(map[structs.NamespacedID]string)
 	b.blocked = make(map[structs.NamespacedID]PendingEvaluations)
 	b.ready = make(map[string]PendingEvaluations)
+	b.readyTime = make(map[string]time.Time)
 	b.unack = make(map[string]*unackEval)
 	b.timeWait = make(map[string]*time.Timer)
 	b.delayHeap = delayheap.NewDelayHeap()
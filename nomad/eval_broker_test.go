@@ -1506,3 +1506,35 @@ func TestEvalBroker_NamespacedJobs(t *testing.T) {
 	require.Equal(1, len(b.blocked))
 
 }
+
+func TestEvalBroker_ReadyTime(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+
+	service := mock.Eval()
+	batch := mock.Eval()
+	batch.Type = structs.JobTypeBatch
+	b.Enqueue(service)
+	b.Enqueue(batch)
+
+	b.l.RLock()
+	require.Len(t, b.readyTime, 2)
+	b.l.RUnlock()
+
+	// Dequeuing an evaluation stops tracking how long it was ready
+	out, _, err := b.Dequeue([]string{structs.JobTypeBatch}, time.Second)
+	require.NoError(t, err)
+	require.Equal(t, batch.ID, out.ID)
+
+	b.l.RLock()
+	require.Len(t, b.readyTime, 1)
+	require.Contains(t, b.readyTime, service.ID)
+	b.l.RUnlock()
+
+	// Disabling the broker flushes it
+	b.SetEnabled(false)
+	b.l.RLock()
+	require.Empty(t, b.readyTime)
+	b.l.RUnlock()
+}
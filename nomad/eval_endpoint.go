@@ -663,6 +663,7 @@ func (e *Eval) List(args *structs.EvalListRequest, reply *structs.EvalListRespon
 				}
 
 				reply.QueryMeta.NextToken = nextToken
+				reply.QueryMeta.ObjectsScanned = paginator.Scanned()
 				reply.Evaluations = evals
 			}
 
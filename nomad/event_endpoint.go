@@ -47,6 +47,7 @@ func (e *Event) stream(conn io.ReadWriteCloser) {
 		Topics:    args.Topics,
 		Index:     uint64(args.Index),
 		Namespace: args.Namespace,
+		Filter:    args.Filter,
 	}
 
 	// Get the servers broker and subscribe
@@ -326,6 +326,10 @@ func TestEventStream_ACL(t *testing.T) {
 	policyNsNode += "\n" + mock.NodePolicy("read")
 	tokenNsNode := mock.CreatePolicyAndToken(t, s.State(), 1007, "validnNsNode", policyNsNode)
 
+	policyNsSV := mock.NamespacePolicyWithSecureVariables("foo", "", nil,
+		map[string][]string{"*": {acl.SecureVariablesCapabilityList}})
+	tokenNsSV := mock.CreatePolicyAndToken(t, s.State(), 1008, "validNsSV", policyNsSV)
+
 	cases := []struct {
 		Name        string
 		Token       string
@@ -415,6 +419,42 @@ func TestEventStream_ACL(t *testing.T) {
 				p.Publish(&structs.Events{Index: uint64(1000), Events: []structs.Event{{Topic: "Node", Payload: mock.Node()}}})
 			},
 		},
+		{
+			Name:  "job namespace token - request invalid secure variable topic",
+			Token: tokenNsFoo.SecretID,
+			Topics: map[structs.Topic][]string{
+				structs.TopicSecureVariable: {"*"}, // bad
+			},
+			Namespace:   "foo",
+			ExpectedErr: structs.ErrPermissionDenied.Error(),
+			PublishFn: func(p *stream.EventBroker) {
+				p.Publish(&structs.Events{Index: uint64(1000), Events: []structs.Event{{Topic: "Job", Namespace: "foo", Payload: mock.Job()}}})
+			},
+		},
+		{
+			Name:  "secure variable namespace token, valid",
+			Token: tokenNsSV.SecretID,
+			Topics: map[structs.Topic][]string{
+				structs.TopicSecureVariable: {"*"}, // good
+			},
+			Namespace:   "foo",
+			ExpectedErr: "subscription closed by server",
+			PublishFn: func(p *stream.EventBroker) {
+				p.Publish(&structs.Events{Index: uint64(1000), Events: []structs.Event{{Topic: structs.TopicSecureVariable, Namespace: "foo", Payload: &structs.SecureVariableEvent{}}}})
+			},
+		},
+		{
+			Name:  "secure variable namespace token - request keyring topic",
+			Token: tokenNsSV.SecretID,
+			Topics: map[structs.Topic][]string{
+				structs.TopicKeyring: {"*"}, // bad
+			},
+			Namespace:   "foo",
+			ExpectedErr: structs.ErrPermissionDenied.Error(),
+			PublishFn: func(p *stream.EventBroker) {
+				p.Publish(&structs.Events{Index: uint64(1000), Events: []structs.Event{{Topic: "Job", Namespace: "foo", Payload: mock.Job()}}})
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -58,6 +58,8 @@ const (
 	SecureVariablesSnapshot              SnapshotType = 22
 	SecureVariablesQuotaSnapshot         SnapshotType = 23
 	RootKeyMetaSnapshot                  SnapshotType = 24
+	JobSigningKeySnapshot                SnapshotType = 25
+	JobRestartSnapshot                   SnapshotType = 26
 
 	// Namespace appliers were moved from enterprise and therefore start at 64
 	NamespaceSnapshot SnapshotType = 64
@@ -271,6 +273,8 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyAllocUpdateDesiredTransition(msgType, buf[1:], log.Index)
 	case structs.NodeUpdateEligibilityRequestType:
 		return n.applyNodeEligibilityUpdate(msgType, buf[1:], log.Index)
+	case structs.NodeUpdatePlannedDisconnectRequestType:
+		return n.applyNodePlannedDisconnectUpdate(msgType, buf[1:], log.Index)
 	case structs.BatchNodeUpdateDrainRequestType:
 		return n.applyBatchDrainUpdate(msgType, buf[1:], log.Index)
 	case structs.SchedulerConfigRequestType:
@@ -323,6 +327,18 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyRootKeyMetaUpsert(msgType, buf[1:], log.Index)
 	case structs.RootKeyMetaDeleteRequestType:
 		return n.applyRootKeyMetaDelete(msgType, buf[1:], log.Index)
+	case structs.JobSigningKeyUpsertRequestType:
+		return n.applyJobSigningKeyUpsert(msgType, buf[1:], log.Index)
+	case structs.JobSigningKeyDeleteRequestType:
+		return n.applyJobSigningKeyDelete(msgType, buf[1:], log.Index)
+	case structs.JobVersionsDeleteRequestType:
+		return n.applyJobVersionsDelete(buf[1:], log.Index)
+	case structs.SVBatchDeleteRequestType:
+		return n.applySecureVariableBatchDelete(buf[1:], log.Index)
+	case structs.SVTxnRequestType:
+		return n.applySecureVariableTxn(buf[1:], log.Index)
+	case structs.JobRestartUpsertRequestType:
+		return n.applyJobRestartUpsert(msgType, buf[1:], log.Index)
 	}
 
 	// Check enterprise only message types.
@@ -497,7 +513,7 @@ func (n *nomadFSM) applyNodeEligibilityUpdate(msgType structs.MessageType, buf [
 		return err
 	}
 
-	if err := n.state.UpdateNodeEligibility(msgType, index, req.NodeID, req.Eligibility, req.UpdatedAt, req.NodeEvent); err != nil {
+	if err := n.state.UpdateNodeEligibility(msgType, index, req.NodeID, req.Eligibility, req.Reason, req.UpdatedAt, req.NodeEvent); err != nil {
 		n.logger.Error("UpdateNodeEligibility failed", "error", err)
 		return err
 	}
@@ -513,6 +529,20 @@ func (n *nomadFSM) applyNodeEligibilityUpdate(msgType structs.MessageType, buf [
 	return nil
 }
 
+func (n *nomadFSM) applyNodePlannedDisconnectUpdate(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "node_planned_disconnect_update"}, time.Now())
+	var req structs.NodeUpdatePlannedDisconnectRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpdateNodePlannedDisconnect(msgType, index, req.NodeID, req.PlannedDisconnect, req.UpdatedAt, req.NodeEvent); err != nil {
+		n.logger.Error("UpdateNodePlannedDisconnect failed", "error", err)
+		return err
+	}
+	return nil
+}
+
 func (n *nomadFSM) applyUpsertJob(msgType structs.MessageType, buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "register_job"}, time.Now())
 	var req structs.JobRegisterRequest
@@ -1098,6 +1128,22 @@ func (n *nomadFSM) applyDeploymentDelete(buf []byte, index uint64) interface{} {
 	return nil
 }
 
+// applyJobVersionsDelete is used to delete historic job versions
+func (n *nomadFSM) applyJobVersionsDelete(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_job_versions_delete"}, time.Now())
+	var req structs.JobVersionsDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteJobVersions(index, req.Versions); err != nil {
+		n.logger.Error("DeleteJobVersions failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
 // applyJobStability is used to set the stability of a job
 func (n *nomadFSM) applyJobStability(buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_job_stability"}, time.Now())
@@ -1749,6 +1795,26 @@ func (n *nomadFSM) restoreImpl(old io.ReadCloser, filter *FSMFilter) error {
 				return err
 			}
 
+		case JobSigningKeySnapshot:
+			key := new(structs.JobSigningKey)
+			if err := dec.Decode(key); err != nil {
+				return err
+			}
+
+			if err := restore.JobSigningKeyRestore(key); err != nil {
+				return err
+			}
+
+		case JobRestartSnapshot:
+			restart := new(structs.JobRestart)
+			if err := dec.Decode(restart); err != nil {
+				return err
+			}
+
+			if err := restore.JobRestartRestore(restart); err != nil {
+				return err
+			}
+
 		default:
 			// Check if this is an enterprise only object being restored
 			restorer, ok := n.enterpriseRestorers[snapType]
@@ -2057,6 +2123,33 @@ func (n *nomadFSM) applySecureVariableOperation(msgType structs.MessageType, buf
 	}
 }
 
+func (n *nomadFSM) applySecureVariableBatchDelete(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_sv_batch_delete"}, time.Now())
+
+	var req structs.SVBatchDeleteStateRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.SVEDeleteBatch(index, &req); err != nil {
+		n.logger.Error("SVEDeleteBatch failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (n *nomadFSM) applySecureVariableTxn(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_sv_txn"}, time.Now())
+
+	var req structs.SVTxnStateRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	return n.state.SVETxn(index, &req)
+}
+
 func (n *nomadFSM) applyRootKeyMetaUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_root_key_meta_upsert"}, time.Now())
 
@@ -2089,6 +2182,54 @@ func (n *nomadFSM) applyRootKeyMetaDelete(msgType structs.MessageType, buf []byt
 	return nil
 }
 
+func (n *nomadFSM) applyJobSigningKeyUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_job_signing_key_upsert"}, time.Now())
+
+	var req structs.KeyringUpsertJobSigningKeyRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertJobSigningKey(index, req.Key); err != nil {
+		n.logger.Error("UpsertJobSigningKey failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (n *nomadFSM) applyJobSigningKeyDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_job_signing_key_delete"}, time.Now())
+
+	var req structs.KeyringDeleteJobSigningKeyRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteJobSigningKey(index, req.KeyID); err != nil {
+		n.logger.Error("DeleteJobSigningKey failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (n *nomadFSM) applyJobRestartUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_job_restart_upsert"}, time.Now())
+
+	var req structs.JobRestartUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertJobRestart(msgType, index, req.Restart); err != nil {
+		n.logger.Error("UpsertJobRestart failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
 func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "persist"}, time.Now())
 	// Register the nodes
@@ -2209,6 +2350,14 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistJobSigningKeys(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistJobRestarts(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	return nil
 }
 
@@ -2836,6 +2985,52 @@ func (s *nomadSnapshot) persistRootKeyMeta(sink raft.SnapshotSink,
 	return nil
 }
 
+func (s *nomadSnapshot) persistJobSigningKeys(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+
+	ws := memdb.NewWatchSet()
+	keys, err := s.snap.JobSigningKeys(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		raw := keys.Next()
+		if raw == nil {
+			break
+		}
+		key := raw.(*structs.JobSigningKey)
+		sink.Write([]byte{byte(JobSigningKeySnapshot)})
+		if err := encoder.Encode(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistJobRestarts(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+
+	ws := memdb.NewWatchSet()
+	restarts, err := s.snap.JobRestarts(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		raw := restarts.Next()
+		if raw == nil {
+			break
+		}
+		restart := raw.(*structs.JobRestart)
+		sink.Write([]byte{byte(JobRestartSnapshot)})
+		if err := encoder.Encode(restart); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Release is a no-op, as we just need to GC the pointer
 // to the state store snapshot. There is nothing to explicitly
 // cleanup.
@@ -3,6 +3,8 @@ package nomad
 import (
 	"bytes"
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"fmt"
 	"reflect"
 	"strings"
@@ -3420,6 +3422,60 @@ func TestFSM_SnapshotRestore_SecureVariables(t *testing.T) {
 	require.ElementsMatch(t, restoredSVs, svs)
 }
 
+func TestFSM_SnapshotRestore_JobSigningKeys(t *testing.T) {
+	ci.Parallel(t)
+
+	fsm := testFSM(t)
+	testState := fsm.State()
+
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key := &structs.JobSigningKey{
+		KeyID:     structs.JobSigningKeyID(publicKey),
+		Name:      "ci",
+		PublicKey: publicKey,
+	}
+	require.NoError(t, testState.UpsertJobSigningKey(10, key))
+
+	restoredFSM := testSnapshotRestore(t, fsm)
+	out, err := restoredFSM.State().JobSigningKeyByID(nil, key.KeyID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	require.Equal(t, "ci", out.Name)
+	require.Equal(t, []byte(publicKey), out.PublicKey)
+	require.Equal(t, uint64(10), out.CreateIndex)
+}
+
+func TestFSM_SnapshotRestore_JobRestarts(t *testing.T) {
+	ci.Parallel(t)
+
+	fsm := testFSM(t)
+	testState := fsm.State()
+
+	restart := &structs.JobRestart{
+		ID:        uuid.Generate(),
+		Namespace: structs.DefaultNamespace,
+		JobID:     "example",
+		BatchSize: 2,
+		BatchWait: 30 * time.Second,
+		OnError:   structs.JobRestartOnErrorPause,
+		AllocIDs:  []string{uuid.Generate(), uuid.Generate(), uuid.Generate()},
+		Restarted: 2,
+		Status:    structs.JobRestartStatusPaused,
+	}
+	require.NoError(t, testState.UpsertJobRestart(structs.MsgTypeTestSetup, 10, restart))
+
+	restoredFSM := testSnapshotRestore(t, fsm)
+	out, err := restoredFSM.State().JobRestartByJob(nil, structs.DefaultNamespace, "example")
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	require.Equal(t, restart.ID, out.ID)
+	require.Equal(t, restart.AllocIDs, out.AllocIDs)
+	require.Equal(t, 2, out.Restarted)
+	require.Equal(t, structs.JobRestartStatusPaused, out.Status)
+	require.Equal(t, uint64(10), out.CreateIndex)
+}
+
 func TestFSM_ACLEvents(t *testing.T) {
 	ci.Parallel(t)
 
@@ -11,6 +11,7 @@ import (
 
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -21,6 +22,10 @@ const (
 	// NodeHeartbeatEventMissed is the event used when the Nodes heartbeat is
 	// missed.
 	NodeHeartbeatEventMissed = "Node heartbeat missed"
+
+	// reconnectSlotExpiry is how long after its reconnect window a node's
+	// reserved slot is kept, in case the node retries late.
+	reconnectSlotExpiry = 10 * time.Minute
 )
 
 var (
@@ -39,15 +44,33 @@ type nodeHeartbeater struct {
 	// a TTL. On expiration, the node status is updated to be 'down'.
 	heartbeatTimers     map[string]*time.Timer
 	heartbeatTimersLock sync.Mutex
+
+	// reconnectLimiter limits the rate at which disconnected and down nodes
+	// reconnect, if MaxReconnectsPerSecond is set. reconnectSlots tracks the
+	// time at which each node that was told to wait is allowed to reconnect.
+	reconnectLimiter     *rate.Limiter
+	reconnectSlots       map[string]time.Time
+	reconnectSlotsPruned time.Time
+	reconnectLock        sync.Mutex
 }
 
 // newNodeHeartbeater returns a new node heartbeater used to detect and act on
 // failed node heartbeats.
 func newNodeHeartbeater(s *Server) *nodeHeartbeater {
-	return &nodeHeartbeater{
+	h := &nodeHeartbeater{
 		Server: s,
 		logger: s.logger.Named("heartbeat"),
 	}
+	if rps := s.config.MaxReconnectsPerSecond; rps > 0 {
+		// Allow a second's worth of reconnects at once
+		burst := int(rps)
+		if burst < 1 {
+			burst = 1
+		}
+		h.reconnectLimiter = rate.NewLimiter(rate.Limit(rps), burst)
+		h.reconnectSlots = make(map[string]time.Time)
+	}
+	return h
 }
 
 // initializeHeartbeatTimers is used when a leader is newly elected to create
@@ -239,9 +262,52 @@ func (h *nodeHeartbeater) clearAllHeartbeatTimers() error {
 		t.Stop()
 	}
 	h.heartbeatTimers = nil
+
+	// The next leader assigns new reconnect slots
+	h.reconnectLock.Lock()
+	if h.reconnectSlots != nil {
+		h.reconnectSlots = make(map[string]time.Time)
+	}
+	h.reconnectLock.Unlock()
 	return nil
 }
 
+// reconnectWindow returns how long the node must wait before it is allowed
+// to reconnect, or zero if it can reconnect now. The first time a node is
+// rate limited it's given a slot, so that it's allowed to reconnect once it
+// retries after the window, and the nodes reconnect at the rate of
+// MaxReconnectsPerSecond cluster-wide.
+func (h *nodeHeartbeater) reconnectWindow(id string, now time.Time) time.Duration {
+	if h.reconnectLimiter == nil {
+		return 0
+	}
+
+	h.reconnectLock.Lock()
+	defer h.reconnectLock.Unlock()
+
+	// Forget the slots of nodes that never came back
+	if now.Sub(h.reconnectSlotsPruned) > reconnectSlotExpiry {
+		for nodeID, slot := range h.reconnectSlots {
+			if now.Sub(slot) > reconnectSlotExpiry {
+				delete(h.reconnectSlots, nodeID)
+			}
+		}
+		h.reconnectSlotsPruned = now
+	}
+
+	slot, ok := h.reconnectSlots[id]
+	if !ok {
+		slot = now.Add(h.reconnectLimiter.ReserveN(now, 1).DelayFrom(now))
+	}
+	if !now.Before(slot) {
+		delete(h.reconnectSlots, id)
+		return 0
+	}
+
+	h.reconnectSlots[id] = slot
+	return slot.Sub(now)
+}
+
 // heartbeatStats is a long running routine used to capture
 // the number of active heartbeats being tracked
 func (h *nodeHeartbeater) heartbeatStats() {
@@ -144,6 +144,44 @@ func TestHeartbeat_ResetHeartbeatTimerLocked_Renew(t *testing.T) {
 	t.Fatalf("should have expired")
 }
 
+func TestHeartbeat_ReconnectWindow(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.MaxReconnectsPerSecond = 1
+	})
+	defer cleanupS1()
+
+	now := time.Now()
+
+	// The first node reconnects right away
+	require.Zero(t, s1.reconnectWindow("node1", now))
+
+	// The next nodes are given increasing windows
+	window2 := s1.reconnectWindow("node2", now)
+	require.InDelta(t, time.Second, window2, float64(10*time.Millisecond))
+	window3 := s1.reconnectWindow("node3", now)
+	require.InDelta(t, 2*time.Second, window3, float64(10*time.Millisecond))
+
+	// Retrying early keeps the slot of the node
+	require.Equal(t, window2-500*time.Millisecond, s1.reconnectWindow("node2", now.Add(500*time.Millisecond)))
+
+	// Retrying after the window reconnects the node, once
+	require.Zero(t, s1.reconnectWindow("node2", now.Add(window2)))
+	require.NotContains(t, s1.reconnectSlots, "node2")
+
+	// Slots of nodes that never came back expire
+	later := now.Add(window3 + reconnectSlotExpiry + time.Second)
+	require.Zero(t, s1.reconnectWindow("node4", later))
+	require.Empty(t, s1.reconnectSlots)
+
+	// Without a limit nodes always reconnect right away
+	s2, cleanupS2 := TestServer(t, nil)
+	defer cleanupS2()
+	require.Zero(t, s2.reconnectWindow("node1", now))
+	require.Zero(t, s2.reconnectWindow("node2", now))
+}
+
 func TestHeartbeat_InvalidateHeartbeat(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
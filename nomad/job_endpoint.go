@@ -63,6 +63,7 @@ func NewJobEndpoints(s *Server) *Job {
 		logger: s.logger.Named("job"),
 		mutators: []jobMutator{
 			jobCanonicalizer{},
+			jobNamespaceDefaults{srv: s},
 			jobConnectHook{},
 			jobExposeCheckHook{},
 			jobImpliedConstraints{},
@@ -90,11 +91,54 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 		return fmt.Errorf("missing job for registration")
 	}
 
+	// Verify the signature of the submission, before admission controllers
+	// mutate the job.
+	if err := j.verifyJobSignature(args); err != nil {
+		return err
+	}
+
+	return j.register(args, reply)
+}
+
+// verifyJobSignature checks the signature of a job registration against the
+// trusted job signing keys, and records which key signed the job.
+func (j *Job) verifyJobSignature(args *structs.JobRegisterRequest) error {
+	args.Job.SigningKeyID = ""
+
+	if args.Signature == nil {
+		if j.srv.config.RequireJobSignatures {
+			return structs.ErrJobSignatureRequired
+		}
+		return nil
+	}
+
+	key, err := j.srv.fsm.State().JobSigningKeyByID(nil, args.Signature.KeyID)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		return fmt.Errorf("%w: job signing key %q is not trusted", structs.ErrJobSignatureInvalid, args.Signature.KeyID)
+	}
+	if err := structs.VerifyJobSignature(args.Job, args.Signature, key); err != nil {
+		return err
+	}
+
+	args.Job.SigningKeyID = key.KeyID
+	return nil
+}
+
+// register registers a job whose signature, if any, was verified.
+func (j *Job) register(args *structs.JobRegisterRequest, reply *structs.JobRegisterResponse) error {
 	// defensive check; http layer and RPC requester should ensure namespaces are set consistently
 	if args.RequestNamespace() != args.Job.Namespace {
 		return fmt.Errorf("mismatched request namespace in request: %q, %q", args.RequestNamespace(), args.Job.Namespace)
 	}
 
+	// Replace the references to secure variables with their values
+	if err := j.resolveSecureVariables(args.Job, args.RequestNamespace(), args.AuthToken); err != nil {
+		return err
+	}
+
 	// Run admission controllers
 	job, warnings, err := j.admissionControllers(args.Job)
 	if err != nil {
@@ -628,8 +672,87 @@ func (j *Job) Revert(args *structs.JobRevertRequest, reply *structs.JobRegisterR
 		reg.JobModifyIndex = cur.JobModifyIndex
 	}
 
+	// The version was verified when it was registered, but make sure it was
+	// signed with a key which is still trusted if signatures are required.
+	if j.srv.config.RequireJobSignatures {
+		if revJob.SigningKeyID == "" {
+			return fmt.Errorf("%w: job %q version %d was not signed", structs.ErrJobSignatureRequired, args.JobID, args.JobVersion)
+		}
+		key, err := snap.JobSigningKeyByID(ws, revJob.SigningKeyID)
+		if err != nil {
+			return err
+		}
+		if key == nil {
+			return fmt.Errorf("%w: job signing key %q is not trusted", structs.ErrJobSignatureInvalid, revJob.SigningKeyID)
+		}
+	}
+
 	// Register the version.
-	return j.Register(reg, reply)
+	return j.register(reg, reply)
+}
+
+// Patch is used to update a job by applying a JSON merge patch and a list of
+// operations to its current version. The patched job is validated and
+// registered like a job submitted in full.
+func (j *Job) Patch(args *structs.JobPatchRequest, reply *structs.JobRegisterResponse) error {
+	if done, err := j.srv.forward(structs.JobPatchRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "patch"}, time.Now())
+
+	// Check for submit-job permissions
+	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate the arguments
+	if err := args.Validate(); err != nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, err.Error())
+	}
+
+	// A patched job can't carry the signature of the version it was derived
+	// from, so it can't be registered if signatures are required.
+	if j.srv.config.RequireJobSignatures {
+		return fmt.Errorf("%w: patched jobs can't be signed", structs.ErrJobSignatureRequired)
+	}
+
+	// Lookup the current version of the job
+	snap, err := j.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	ws := memdb.NewWatchSet()
+	cur, err := snap.JobByID(ws, args.RequestNamespace(), args.JobID)
+	if err != nil {
+		return err
+	}
+	if cur == nil {
+		return structs.NewErrRPCCoded(http.StatusNotFound, fmt.Sprintf("job %q not found", args.JobID))
+	}
+	if args.EnforcePriorVersion != nil && cur.Version != *args.EnforcePriorVersion {
+		return fmt.Errorf("Current job has version %d; enforcing version %d", cur.Version, *args.EnforcePriorVersion)
+	}
+
+	patched, err := cur.Patch(args.MergePatch, args.Ops)
+	if err != nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, err.Error())
+	}
+	patched.SigningKeyID = ""
+	patched.ConsulToken = args.ConsulToken
+	patched.VaultToken = args.VaultToken
+
+	// Register the patched job, failing if the job was modified since it was
+	// read so that concurrent patches are not lost.
+	reg := &structs.JobRegisterRequest{
+		Job:            patched,
+		EnforceIndex:   true,
+		JobModifyIndex: cur.JobModifyIndex,
+		WriteRequest:   args.WriteRequest,
+	}
+	return j.register(reg, reply)
 }
 
 // Stable is used to mark the job version as stable
@@ -887,6 +1010,36 @@ func (j *Job) Deregister(args *structs.JobDeregisterRequest, reply *structs.JobD
 	return nil
 }
 
+// ReapVersions is used to remove historic job versions that fall outside of
+// their namespace's retention policy. It is called by the core scheduler.
+func (j *Job) ReapVersions(args *structs.JobVersionsDeleteRequest, reply *structs.GenericResponse) error {
+	if done, err := j.srv.forward("Job.ReapVersions", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "reap_versions"}, time.Now())
+
+	// Only the leader ACL may reap job versions
+	aclObj, err := j.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	if len(args.Versions) == 0 {
+		return fmt.Errorf("given no job versions to reap")
+	}
+
+	// Update via Raft
+	_, index, err := j.srv.raftApply(structs.JobVersionsDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
 // BatchDeregister is used to remove a set of jobs from the cluster.
 func (j *Job) BatchDeregister(args *structs.JobBatchDeregisterRequest, reply *structs.JobBatchDeregisterResponse) error {
 	if done, err := j.srv.forward("Job.BatchDeregister", args, args, reply); done {
@@ -1027,56 +1180,87 @@ func (j *Job) Scale(args *structs.JobScaleRequest, reply *structs.JobRegisterRes
 	// return a shared pointer.
 	job = job.Copy()
 
-	// Find target group in job TaskGroups
-	groupName := args.Target[structs.ScalingTargetGroup]
-	var group *structs.TaskGroup
-	for _, tg := range job.TaskGroups {
-		if tg.Name == groupName {
-			group = tg
-			break
-		}
+	// The scale status reports the job's ModifyIndex as its JobModifyIndex
+	if args.EnforceIndex && args.JobModifyIndex != job.ModifyIndex {
+		return structs.NewErrRPCCoded(400, fmt.Sprintf("%s %d: job exists with conflicting job modify index: %d",
+			RegisterEnforceIndexErrPrefix, args.JobModifyIndex, job.ModifyIndex))
 	}
 
-	if group == nil {
-		return structs.NewErrRPCCoded(400,
-			fmt.Sprintf("task group %q specified for scaling does not exist in job", groupName))
+	// Record who requested the scaling action on its events
+	var initiator string
+	if token, err := j.srv.ResolveSecretToken(args.AuthToken); err != nil {
+		return err
+	} else if token != nil {
+		initiator = token.AccessorID
 	}
 
-	now := time.Now().UnixNano()
-	prevCount := int64(group.Count)
-
-	event := &structs.ScalingEventRequest{
-		Namespace: job.Namespace,
-		JobID:     job.ID,
-		TaskGroup: groupName,
-		ScalingEvent: &structs.ScalingEvent{
-			Time:          now,
-			PreviousCount: prevCount,
-			Count:         args.Count,
-			Message:       args.Message,
-			Error:         args.Error,
-			Meta:          args.Meta,
-		},
+	// Find the target groups in job TaskGroups. A request either sets the
+	// count of several groups or targets a single group, possibly without a
+	// count to record an error or message.
+	counts := args.Groups
+	groupNames := make([]string, 0, len(counts))
+	if len(counts) > 0 {
+		for name := range counts {
+			groupNames = append(groupNames, name)
+		}
+		sort.Strings(groupNames)
+	} else {
+		groupName := args.Target[structs.ScalingTargetGroup]
+		groupNames = append(groupNames, groupName)
+		if args.Count != nil {
+			counts = map[string]int64{groupName: *args.Count}
+		}
 	}
 
-	if args.Count != nil {
+	now := time.Now().UnixNano()
+	events := make([]*structs.ScalingEventRequest, 0, len(groupNames))
+	for _, groupName := range groupNames {
+		group := job.LookupTaskGroup(groupName)
+		if group == nil {
+			return structs.NewErrRPCCoded(400,
+				fmt.Sprintf("task group %q specified for scaling does not exist in job", groupName))
+		}
+
+		event := &structs.ScalingEventRequest{
+			Namespace: job.Namespace,
+			JobID:     job.ID,
+			TaskGroup: groupName,
+			ScalingEvent: &structs.ScalingEvent{
+				Time:          now,
+				PreviousCount: int64(group.Count),
+				Message:       args.Message,
+				Error:         args.Error,
+				Meta:          args.Meta,
+				Initiator:     initiator,
+			},
+		}
+		events = append(events, event)
+
+		count, ok := counts[groupName]
+		if !ok {
+			continue
+		}
+		event.ScalingEvent.Count = pointer.Of(count)
+
 		// Further validation for count-based scaling event
 		if group.Scaling != nil {
-			if *args.Count < group.Scaling.Min {
+			if count < group.Scaling.Min {
 				return structs.NewErrRPCCoded(400,
-					fmt.Sprintf("group count was less than scaling policy minimum: %d < %d",
-						*args.Count, group.Scaling.Min))
+					fmt.Sprintf("group count was less than scaling policy minimum: %d < %d (group %q)",
+						count, group.Scaling.Min, groupName))
 			}
-			if group.Scaling.Max < *args.Count {
+			if group.Scaling.Max < count {
 				return structs.NewErrRPCCoded(400,
-					fmt.Sprintf("group count was greater than scaling policy maximum: %d > %d",
-						*args.Count, group.Scaling.Max))
+					fmt.Sprintf("group count was greater than scaling policy maximum: %d > %d (group %q)",
+						count, group.Scaling.Max, groupName))
 			}
 		}
 
 		// Update group count
-		group.Count = int(*args.Count)
+		group.Count = int(count)
+	}
 
+	if len(counts) > 0 {
 		// Block scaling event if there's an active deployment
 		deployment, err := snap.LatestDeploymentByJobID(ws, namespace, args.JobID)
 		if err != nil {
@@ -1088,7 +1272,7 @@ func (j *Job) Scale(args *structs.JobScaleRequest, reply *structs.JobRegisterRes
 			return structs.NewErrRPCCoded(400, "job scaling blocked due to active deployment")
 		}
 
-		// Commit the job update
+		// Commit the job update, which changes every group at once
 		_, jobModifyIndex, err := j.srv.raftApply(
 			structs.JobRegisterRequestType,
 			structs.JobRegisterRequest{
@@ -1134,16 +1318,23 @@ func (j *Job) Scale(args *structs.JobScaleRequest, reply *structs.JobRegisterRes
 
 			reply.EvalID = eval.ID
 			reply.EvalCreateIndex = evalIndex
-			event.ScalingEvent.EvalID = &reply.EvalID
+			for _, event := range events {
+				if event.ScalingEvent.Count != nil {
+					event.ScalingEvent.EvalID = &reply.EvalID
+				}
+			}
 		}
 	} else {
 		reply.JobModifyIndex = job.ModifyIndex
 	}
 
-	_, eventIndex, err := j.srv.raftApply(structs.ScalingEventRegisterRequestType, event)
-	if err != nil {
-		j.logger.Error("scaling event create failed", "error", err)
-		return err
+	var eventIndex uint64
+	for _, event := range events {
+		_, eventIndex, err = j.srv.raftApply(structs.ScalingEventRegisterRequestType, event)
+		if err != nil {
+			j.logger.Error("scaling event create failed", "error", err)
+			return err
+		}
 	}
 
 	reply.Index = eventIndex
@@ -1386,6 +1577,7 @@ func (j *Job) List(args *structs.JobListRequest, reply *structs.JobListResponse)
 				}
 
 				reply.QueryMeta.NextToken = nextToken
+				reply.QueryMeta.ObjectsScanned = paginator.Scanned()
 				reply.Jobs = jobs
 			}
 
@@ -1606,6 +1798,11 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 		return fmt.Errorf("Job required for plan")
 	}
 
+	// Replace the references to secure variables with their values
+	if err := j.resolveSecureVariables(args.Job, args.RequestNamespace(), args.AuthToken); err != nil {
+		return err
+	}
+
 	// Run admission controllers
 	job, warnings, err := j.admissionControllers(args.Job)
 	if err != nil {
@@ -2216,3 +2413,202 @@ func (j *Job) GetServiceRegistrations(
 		},
 	})
 }
+
+// Restart starts a rolling restart of the running allocations of a job. The
+// leader restarts the allocations in batches, so the restart carries on
+// regardless of the client that started it.
+func (j *Job) Restart(args *structs.JobRestartRequest, reply *structs.JobRestartResponse) error {
+	if done, err := j.srv.forward(structs.JobRestartRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "restart"}, time.Now())
+
+	// Check for alloc-lifecycle permissions
+	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityAllocLifecycle) {
+		return structs.ErrPermissionDenied
+	}
+
+	if err := args.Validate(); err != nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, err.Error())
+	}
+
+	snap, err := j.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	job, err := snap.JobByID(nil, args.RequestNamespace(), args.JobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return structs.NewErrRPCCoded(http.StatusNotFound, fmt.Sprintf("job %q not found", args.JobID))
+	}
+
+	existing, err := snap.JobRestartByJob(nil, job.Namespace, job.ID)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.Active() {
+		return structs.NewErrRPCCoded(http.StatusConflict,
+			fmt.Sprintf("job %q already has a %s restart", job.ID, existing.Status))
+	}
+
+	// Restart the running allocations of each group in order
+	allocs, err := snap.AllocsByJob(nil, job.Namespace, job.ID, false)
+	if err != nil {
+		return err
+	}
+	running := make([]*structs.Allocation, 0, len(allocs))
+	for _, alloc := range allocs {
+		if !alloc.TerminalStatus() && alloc.ClientStatus == structs.AllocClientStatusRunning {
+			running = append(running, alloc)
+		}
+	}
+	if len(running) == 0 {
+		return structs.NewErrRPCCoded(http.StatusBadRequest,
+			fmt.Sprintf("job %q has no running allocations to restart", job.ID))
+	}
+	sort.Slice(running, func(i, j int) bool {
+		if running[i].TaskGroup != running[j].TaskGroup {
+			return running[i].TaskGroup < running[j].TaskGroup
+		}
+		return running[i].Index() < running[j].Index()
+	})
+
+	now := time.Now().UTC().UnixNano()
+	restart := &structs.JobRestart{
+		ID:         uuid.Generate(),
+		Namespace:  job.Namespace,
+		JobID:      job.ID,
+		BatchSize:  args.BatchSize,
+		BatchWait:  args.BatchWait,
+		OnError:    args.OnError,
+		AllocIDs:   make([]string, 0, len(running)),
+		Status:     structs.JobRestartStatusRunning,
+		CreateTime: now,
+		ModifyTime: now,
+	}
+	for _, alloc := range running {
+		restart.AllocIDs = append(restart.AllocIDs, alloc.ID)
+	}
+
+	req := &structs.JobRestartUpsertRequest{
+		Restart:      restart,
+		WriteRequest: args.WriteRequest,
+	}
+	out, index, err := j.srv.raftApply(structs.JobRestartUpsertRequestType, req)
+	if err != nil {
+		return err
+	}
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+
+	reply.Restart, err = j.srv.fsm.State().JobRestartByJob(nil, job.Namespace, job.ID)
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	return nil
+}
+
+// GetRestart returns the latest restart of a job.
+func (j *Job) GetRestart(args *structs.JobSpecificRequest, reply *structs.SingleJobRestartResponse) error {
+	if done, err := j.srv.forward(structs.JobGetRestartRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "get_restart"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			restart, err := s.JobRestartByJob(ws, args.RequestNamespace(), args.JobID)
+			if err != nil {
+				return err
+			}
+			reply.Restart = restart
+			if restart == nil {
+				return j.srv.replySetIndex(state.TableJobRestarts, &reply.QueryMeta)
+			}
+
+			// Set the query response
+			reply.Index = restart.ModifyIndex
+			j.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return j.srv.blockingRPC(&opts)
+}
+
+// UpdateRestart pauses, resumes or cancels the active restart of a job.
+func (j *Job) UpdateRestart(args *structs.JobRestartUpdateRequest, reply *structs.JobRestartResponse) error {
+	if done, err := j.srv.forward(structs.JobUpdateRestartRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "update_restart"}, time.Now())
+
+	// Check for alloc-lifecycle permissions
+	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityAllocLifecycle) {
+		return structs.ErrPermissionDenied
+	}
+
+	if err := args.Validate(); err != nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, err.Error())
+	}
+
+	existing, err := j.srv.fsm.State().JobRestartByJob(nil, args.RequestNamespace(), args.JobID)
+	if err != nil {
+		return err
+	}
+	if existing == nil || !existing.Active() {
+		return structs.NewErrRPCCoded(http.StatusNotFound,
+			fmt.Sprintf("job %q has no active restart", args.JobID))
+	}
+	if existing.Status == args.Status {
+		return structs.NewErrRPCCoded(http.StatusBadRequest,
+			fmt.Sprintf("restart of job %q is already %s", args.JobID, existing.Status))
+	}
+
+	restart := existing.Copy()
+	restart.Status = args.Status
+	switch args.Status {
+	case structs.JobRestartStatusRunning:
+		restart.StatusDescription = ""
+	case structs.JobRestartStatusPaused:
+		restart.StatusDescription = "paused by operator"
+	case structs.JobRestartStatusCancelled:
+		restart.StatusDescription = "cancelled by operator"
+	}
+	restart.ModifyTime = time.Now().UTC().UnixNano()
+
+	req := &structs.JobRestartUpsertRequest{
+		Restart:      restart,
+		WriteRequest: args.WriteRequest,
+	}
+	out, index, err := j.srv.raftApply(structs.JobRestartUpsertRequestType, req)
+	if err != nil {
+		return err
+	}
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+
+	reply.Restart, err = j.srv.fsm.State().JobRestartByJob(nil, restart.Namespace, restart.JobID)
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	return nil
+}
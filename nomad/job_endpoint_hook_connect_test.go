@@ -340,7 +340,7 @@ func TestJobEndpointConnect_groupConnectHook_MeshGateway(t *testing.T) {
 func TestJobEndpointConnect_ConnectInterpolation(t *testing.T) {
 	ci.Parallel(t)
 
-	server := &Server{logger: testlog.HCLogger(t)}
+	server := &Server{logger: testlog.HCLogger(t), fsm: testFSM(t)}
 	jobEndpoint := NewJobEndpoints(server)
 
 	j := mock.ConnectJob()
@@ -126,6 +126,27 @@ func (jobCanonicalizer) Mutate(job *structs.Job) (*structs.Job, []error, error)
 	return job, nil, nil
 }
 
+// jobNamespaceDefaults merges the defaults of the job's namespace into the
+// job. Nonexistent namespaces are left to the namespace validator.
+type jobNamespaceDefaults struct {
+	srv *Server
+}
+
+func (jobNamespaceDefaults) Name() string {
+	return "namespace-defaults"
+}
+
+func (h jobNamespaceDefaults) Mutate(job *structs.Job) (*structs.Job, []error, error) {
+	ns, err := h.srv.State().NamespaceByName(nil, job.Namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ns != nil {
+		ns.Defaults.MergeInto(job)
+	}
+	return job, nil, nil
+}
+
 // jobImpliedConstraints adds constraints to a job implied by other job fields
 // and stanzas.
 type jobImpliedConstraints struct{}
@@ -0,0 +1,187 @@
+package nomad
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/helper/mlock"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// secureVariableRefPrefix starts the references to secure variables that
+	// the var() HCL2 function leaves in jobspecs.
+	secureVariableRefPrefix = "${nomad_var:"
+)
+
+// secureVariableRefRe matches a reference to an item of a secure variable, as
+// written by the var() function of jobspec2: ${nomad_var:"<path>":"<item>"},
+// where the path and item are Go quoted strings.
+var secureVariableRefRe = regexp.MustCompile(`\$\{nomad_var:("(?:[^"\\]|\\.)*"):("(?:[^"\\]|\\.)*")\}`)
+
+// secureVariableResolver replaces the references to secure variables in a job
+// with the values of their items. Each referenced variable must be readable
+// by the token that submitted the job.
+type secureVariableResolver struct {
+	srv       *Server
+	namespace string
+	aclObj    *acl.ACL
+
+	// items caches the decrypted items of the variables, by path
+	items map[string]map[string]string
+}
+
+// resolveSecureVariables replaces the references to secure variables in the
+// job with their values. It runs before the admission controllers, so that
+// they see the values the job will be registered with.
+func (j *Job) resolveSecureVariables(job *structs.Job, namespace, authToken string) error {
+	aclObj, err := j.srv.ResolveToken(authToken)
+	if err != nil {
+		return err
+	}
+
+	r := &secureVariableResolver{
+		srv:       j.srv,
+		namespace: namespace,
+		aclObj:    aclObj,
+		items:     map[string]map[string]string{},
+	}
+	return r.resolveValue(reflect.ValueOf(job))
+}
+
+// resolveValue walks the exported fields, slices and maps of v and resolves
+// the references in every string it finds.
+func (r *secureVariableResolver) resolveValue(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		return r.resolveValue(v.Elem())
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		// The value of an interface isn't settable, so resolve a copy of it
+		elem := reflect.New(v.Elem().Type()).Elem()
+		elem.Set(v.Elem())
+		if err := r.resolveValue(elem); err != nil {
+			return err
+		}
+		if v.CanSet() {
+			v.Set(elem)
+		}
+
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Field(i); f.CanSet() {
+				if err := r.resolveValue(f); err != nil {
+					return err
+				}
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := r.resolveValue(v.Index(i)); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(iter.Value())
+			if err := r.resolveValue(elem); err != nil {
+				return err
+			}
+			v.SetMapIndex(iter.Key(), elem)
+		}
+
+	case reflect.String:
+		if !v.CanSet() || !strings.Contains(v.String(), secureVariableRefPrefix) {
+			return nil
+		}
+		s, err := r.resolveString(v.String())
+		if err != nil {
+			return err
+		}
+		v.SetString(s)
+	}
+
+	return nil
+}
+
+// resolveString replaces the references in s with their values.
+func (r *secureVariableResolver) resolveString(s string) (string, error) {
+	var resolveErr error
+	out := secureVariableRefRe.ReplaceAllStringFunc(s, func(ref string) string {
+		if resolveErr != nil {
+			return ref
+		}
+		m := secureVariableRefRe.FindStringSubmatch(ref)
+		path, err := strconv.Unquote(m[1])
+		if err != nil {
+			resolveErr = fmt.Errorf("invalid secure variable reference %s: %v", ref, err)
+			return ref
+		}
+		item, err := strconv.Unquote(m[2])
+		if err != nil {
+			resolveErr = fmt.Errorf("invalid secure variable reference %s: %v", ref, err)
+			return ref
+		}
+
+		value, err := r.lookup(path, item)
+		if err != nil {
+			resolveErr = err
+			return ref
+		}
+		return value
+	})
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+	return out, nil
+}
+
+// lookup returns the value of the item of the variable at path.
+func (r *secureVariableResolver) lookup(path, item string) (string, error) {
+	items, ok := r.items[path]
+	if !ok {
+		if r.aclObj != nil && !r.aclObj.AllowSecureVariableOperation(r.namespace, path, acl.PolicyRead) {
+			return "", fmt.Errorf("%w: secure variable %q", structs.ErrPermissionDenied, path)
+		}
+
+		ev, err := r.srv.fsm.State().GetSecureVariable(nil, r.namespace, path)
+		if err != nil {
+			return "", err
+		}
+		if ev == nil {
+			return "", fmt.Errorf("secure variable %q not found", path)
+		}
+
+		b, err := r.srv.encrypter.Decrypt(ev.Data, ev.KeyID)
+		if err != nil {
+			return "", err
+		}
+		err = json.Unmarshal(b, &items)
+		mlock.Zero(b)
+		if err != nil {
+			return "", err
+		}
+		r.items[path] = items
+	}
+
+	value, ok := items[item]
+	if !ok {
+		return "", fmt.Errorf("secure variable %q has no item %q", path, item)
+	}
+	return value, nil
+}
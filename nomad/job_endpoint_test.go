@@ -1,6 +1,8 @@
 package nomad
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"reflect"
@@ -734,6 +736,82 @@ func TestJobEndpoint_Register_Connect_ValidatesWithoutSidecarTask(t *testing.T)
 	require.Contains(t, err.Error(), "exposed_no_sidecar requires use of sidecar_proxy")
 }
 
+func TestJobEndpoint_Register_Signed(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.RequireJobSignatures = true
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key := &structs.JobSigningKey{
+		KeyID:     structs.JobSigningKeyID(publicKey),
+		PublicKey: publicKey,
+	}
+	require.NoError(t, s1.fsm.State().UpsertJobSigningKey(100, key))
+
+	job := mock.Job()
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+
+	// Unsigned submissions are rejected
+	err = msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	require.EqualError(t, err, structs.ErrJobSignatureRequired.Error())
+
+	// Signatures of another job are rejected
+	other := job.Copy()
+	other.TaskGroups[0].Count = 100
+	req.Signature, err = structs.SignJob(other, privateKey)
+	require.NoError(t, err)
+	err = msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	require.EqualError(t, err, structs.ErrJobSignatureInvalid.Error())
+
+	// Signed submissions record the signing key
+	req.Signature, err = structs.SignJob(job, privateKey)
+	require.NoError(t, err)
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+
+	out, err := s1.fsm.State().JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, key.KeyID, out.SigningKeyID)
+
+	// Register a second version, then stop trusting the key
+	job2 := job.Copy()
+	job2.TaskGroups[0].Count = 2
+	req.Job = job2
+	req.Signature, err = structs.SignJob(job2, privateKey)
+	require.NoError(t, err)
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+	require.NoError(t, s1.fsm.State().DeleteJobSigningKey(resp.Index+1, key.KeyID))
+
+	// Versions signed with a key which is no longer trusted can't be
+	// reverted to
+	revertReq := &structs.JobRevertRequest{
+		JobID:      job.ID,
+		JobVersion: 0,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	err = msgpackrpc.CallWithCodec(codec, "Job.Revert", revertReq, &resp)
+	require.ErrorContains(t, err, "is not trusted")
+
+	require.NoError(t, s1.fsm.State().UpsertJobSigningKey(resp.Index+2, key))
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Revert", revertReq, &resp))
+}
+
 func TestJobEndpoint_Register_ACL(t *testing.T) {
 	ci.Parallel(t)
 
@@ -898,6 +976,108 @@ func TestJobEndpoint_Register_ACL(t *testing.T) {
 	}
 }
 
+func TestJobEndpoint_Register_SecureVariables(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create the secure variable referenced by the jobs
+	applyReq := &structs.SecureVariablesApplyRequest{
+		Op: structs.SVOpSet,
+		Var: &structs.SecureVariableDecrypted{
+			SecureVariableMetadata: structs.SecureVariableMetadata{
+				Namespace: structs.DefaultNamespace,
+				Path:      "config/web",
+			},
+			Items: structs.SecureVariableItems{"image": "redis:7", "port": "6379"},
+		},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var applyResp structs.SecureVariablesApplyResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.SecureVariablesApplyRPCMethod, applyReq, &applyResp))
+
+	submitJobPolicy := mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitJob})
+	submitJobToken := mock.CreatePolicyAndToken(t, s1.State(), 1001, "test-submit-job", submitJobPolicy)
+	readVarPolicy := mock.NamespacePolicyWithSecureVariables(structs.DefaultNamespace, "",
+		[]string{acl.NamespaceCapabilitySubmitJob}, map[string][]string{"config/*": {"read"}})
+	readVarToken := mock.CreatePolicyAndToken(t, s1.State(), 1002, "test-read-var", readVarPolicy)
+
+	newJob := func(item string) *structs.Job {
+		job := mock.Job()
+		task := job.TaskGroups[0].Tasks[0]
+		task.Config["image"] = `${nomad_var:"config/web":"image"}`
+		task.Meta = map[string]string{
+			"addr": `localhost:${nomad_var:"config/web":"` + item + `"}`,
+		}
+		return job
+	}
+
+	cases := []struct {
+		name   string
+		job    *structs.Job
+		token  string
+		expErr string
+	}{
+		{
+			name:   "token can't read variable",
+			job:    newJob("port"),
+			token:  submitJobToken.SecretID,
+			expErr: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			name:   "missing item",
+			job:    newJob("missing"),
+			token:  readVarToken.SecretID,
+			expErr: `secure variable "config/web" has no item "missing"`,
+		},
+		{
+			name:  "token can read variable",
+			job:   newJob("port"),
+			token: readVarToken.SecretID,
+		},
+		{
+			name:  "management token",
+			job:   newJob("port"),
+			token: root.SecretID,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := &structs.JobRegisterRequest{
+				Job: tc.job,
+				WriteRequest: structs.WriteRequest{
+					Region:    "global",
+					Namespace: tc.job.Namespace,
+					AuthToken: tc.token,
+				},
+			}
+			var resp structs.JobRegisterResponse
+			err := msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+			if tc.expErr != "" {
+				require.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+
+			out, err := s1.fsm.State().JobByID(nil, tc.job.Namespace, tc.job.ID)
+			require.NoError(t, err)
+			require.NotNil(t, out)
+			task := out.TaskGroups[0].Tasks[0]
+			require.Equal(t, "redis:7", task.Config["image"])
+			require.Equal(t, "localhost:6379", task.Meta["addr"])
+		})
+	}
+}
+
 func TestJobEndpoint_Register_InvalidNamespace(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1449,6 +1629,87 @@ func TestJobEndpoint_Register_Vault_AllowUnauthenticated(t *testing.T) {
 	}
 }
 
+func TestJobEndpoint_Register_NamespaceDefaults(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	s1.config.VaultConfig.Enabled = pointer.Of(true)
+	s1.config.VaultConfig.AllowUnauthenticated = pointer.Of(true)
+	s1.vault = &TestVaultClient{}
+
+	ns := mock.Namespace()
+	ns.Defaults = &structs.NamespaceDefaults{
+		Vault: &structs.NamespaceVaultDefaults{
+			Namespace: "team",
+			Policies:  []string{"team-read"},
+		},
+		Consul: &structs.NamespaceConsulDefaults{
+			Namespace: "team",
+		},
+		Identity: &structs.WorkloadIdentity{
+			Env: true,
+		},
+	}
+	require.NoError(t, s1.fsm.State().UpsertNamespaces(1000, []*structs.Namespace{ns}))
+
+	// The first task gets the defaults while the second one sets its own
+	// policies and identity, and the third one doesn't use Vault
+	job := mock.Job()
+	job.Namespace = ns.Name
+	tg := job.TaskGroups[0]
+	tg.Tasks[0].Vault = &structs.Vault{ChangeMode: structs.VaultChangeModeRestart}
+	override := tg.Tasks[0].Copy()
+	override.Name = "override"
+	override.Services = nil
+	override.Vault.Policies = []string{"override"}
+	override.Identity = &structs.WorkloadIdentity{Env: false}
+	noVault := override.Copy()
+	noVault.Name = "no-vault"
+	noVault.Vault = nil
+	noVault.Identity = nil
+	tg.Tasks = append(tg.Tasks, override, noVault)
+
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+
+	out, err := s1.fsm.State().JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+
+	outTG := out.TaskGroups[0]
+	require.Equal(t, "team", outTG.Consul.Namespace)
+	require.Equal(t, "team", outTG.Tasks[0].Vault.Namespace)
+	require.Equal(t, []string{"team-read"}, outTG.Tasks[0].Vault.Policies)
+	require.Equal(t, "team", outTG.Tasks[1].Vault.Namespace)
+	require.Equal(t, []string{"override"}, outTG.Tasks[1].Vault.Policies)
+	require.Nil(t, outTG.Tasks[2].Vault)
+	require.True(t, outTG.Tasks[0].Identity.Env)
+	require.False(t, outTG.Tasks[1].Identity.Env)
+	require.True(t, outTG.Tasks[2].Identity.Env)
+
+	// The job's Consul namespace takes precedence over the default
+	job.ConsulNamespace = "job"
+	resp = structs.JobRegisterResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+
+	out, err = s1.fsm.State().JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Empty(t, out.TaskGroups[0].Consul.Namespace)
+}
+
 // TestJobEndpoint_Register_Vault_OverrideConstraint asserts that job
 // submitters can specify their own Vault constraint to override the
 // automatically injected one.
@@ -2340,6 +2601,72 @@ func TestJobRegister_ACL_RejectedBySchedulerConfig(t *testing.T) {
 	}
 }
 
+func TestJobEndpoint_Patch(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Register the initial version of the job
+	job := mock.Job()
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+
+	// Patching an unknown job fails
+	patchReq := &structs.JobPatchRequest{
+		JobID:      "unknown",
+		MergePatch: []byte(`{"Priority": 70}`),
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	err := msgpackrpc.CallWithCodec(codec, structs.JobPatchRPCMethod, patchReq, &resp)
+	require.ErrorContains(t, err, "not found")
+
+	// Enforcing the wrong prior version fails
+	patchReq.JobID = job.ID
+	patchReq.EnforcePriorVersion = pointer.Of(uint64(10))
+	err = msgpackrpc.CallWithCodec(codec, structs.JobPatchRPCMethod, patchReq, &resp)
+	require.ErrorContains(t, err, "enforcing version 10")
+
+	// A patch producing an invalid job fails validation
+	patchReq.EnforcePriorVersion = nil
+	patchReq.MergePatch = nil
+	patchReq.Ops = []*structs.JobPatchOp{
+		{Op: structs.JobPatchOpReplace, Path: "/TaskGroups/0/Count", Value: []byte(`-1`)},
+	}
+	err = msgpackrpc.CallWithCodec(codec, structs.JobPatchRPCMethod, patchReq, &resp)
+	require.ErrorContains(t, err, "count can't be negative")
+
+	// Patch the count and the priority of the job
+	patchReq.EnforcePriorVersion = pointer.Of(uint64(0))
+	patchReq.MergePatch = []byte(`{"Priority": 70}`)
+	patchReq.Ops = []*structs.JobPatchOp{
+		{Op: structs.JobPatchOpReplace, Path: "/TaskGroups/0/Count", Value: []byte(`3`)},
+	}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.JobPatchRPCMethod, patchReq, &resp))
+	require.NotEmpty(t, resp.EvalID)
+
+	// Check the new version of the job
+	out, err := s1.fsm.State().JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), out.Version)
+	require.Equal(t, 70, out.Priority)
+	require.Equal(t, 3, out.TaskGroups[0].Count)
+}
+
 func TestJobEndpoint_Revert(t *testing.T) {
 	ci.Parallel(t)
 
@@ -7129,6 +7456,78 @@ func TestJobEndpoint_Scale(t *testing.T) {
 	require.Equal(int64(originalCount), events[groupName][0].PreviousCount)
 }
 
+func TestJobEndpoint_Scale_Groups(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	job.TaskGroups = append(job.TaskGroups, job.TaskGroups[0].Copy())
+	job.TaskGroups[1].Name = "web2"
+	job.TaskGroups[1].Count = 2
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	scale := &structs.JobScaleRequest{
+		JobID: job.ID,
+		Groups: map[string]int64{
+			"web":  3,
+			"web2": 5,
+		},
+		Message:        "because of the load",
+		EnforceIndex:   true,
+		JobModifyIndex: 999,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+			AuthToken: root.SecretID,
+		},
+	}
+
+	// A stale index is rejected
+	var resp structs.JobRegisterResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), RegisterEnforceIndexErrPrefix)
+
+	scale.JobModifyIndex = 1000
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp))
+	require.NotEmpty(resp.EvalID)
+
+	// Both groups are updated in a single job version
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Equal(job.Version+1, out.Version)
+	require.Equal(3, out.LookupTaskGroup("web").Count)
+	require.Equal(5, out.LookupTaskGroup("web2").Count)
+
+	// Each group records an event with the initiator and reason
+	events, _, err := state.ScalingEventsByJob(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	for group, prev := range map[string]int64{"web": 10, "web2": 2} {
+		require.Len(events[group], 1)
+		event := events[group][0]
+		require.Equal(prev, event.PreviousCount)
+		require.Equal(root.AccessorID, event.Initiator)
+		require.Equal("because of the load", event.Message)
+		require.Equal(resp.EvalID, *event.EvalID)
+	}
+
+	// Unknown groups fail without updating the job
+	scale.EnforceIndex = false
+	scale.Groups = map[string]int64{"web": 1, "nope": 1}
+	err = msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), `task group "nope" specified for scaling does not exist`)
+	out, err = state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Equal(3, out.LookupTaskGroup("web").Count)
+}
+
 func TestJobEndpoint_Scale_DeploymentBlocking(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -8179,3 +8578,163 @@ func TestJob_GetServiceRegistrations(t *testing.T) {
 		})
 	}
 }
+
+func TestJobEndpoint_Restart(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job, allocs := testJobRestartAllocs(t, s1, 3)
+
+	// Invalid requests are rejected
+	req := &structs.JobRestartRequest{
+		JobID:     job.ID,
+		BatchSize: 0,
+		OnError:   structs.JobRestartOnErrorPause,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRestartResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.JobRestartRPCMethod, req, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "batch size must be at least 1")
+
+	// The allocations are restarted in order of their index
+	req.BatchSize = 2
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.JobRestartRPCMethod, req, &resp))
+	require.NotNil(t, resp.Restart)
+	require.Equal(t, []string{allocs[0].ID, allocs[1].ID, allocs[2].ID}, resp.Restart.AllocIDs)
+	require.Equal(t, 2, resp.Restart.BatchSize)
+	require.NotZero(t, resp.Index)
+
+	// Only one restart of a job may be active
+	var resp2 structs.JobRestartResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.JobRestartRPCMethod, req, &resp2)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "already has a")
+
+	// The allocations' node doesn't exist, so the leader pauses the restart
+	getReq := &structs.JobSpecificRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	testutil.WaitForResult(func() (bool, error) {
+		var getResp structs.SingleJobRestartResponse
+		if err := msgpackrpc.CallWithCodec(codec, structs.JobGetRestartRPCMethod, getReq, &getResp); err != nil {
+			return false, err
+		}
+		if getResp.Restart == nil || getResp.Restart.ID != resp.Restart.ID {
+			return false, fmt.Errorf("unexpected restart: %#v", getResp.Restart)
+		}
+		if getResp.Restart.Status != structs.JobRestartStatusPaused {
+			return false, fmt.Errorf("restart is %s", getResp.Restart.Status)
+		}
+		if getResp.Restart.StatusDescription == "" {
+			return false, errors.New("missing status description")
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+
+	// Cancel the restart, after which it can't be updated
+	updateReq := &structs.JobRestartUpdateRequest{
+		JobID:  job.ID,
+		Status: structs.JobRestartStatusCancelled,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var updateResp structs.JobRestartResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.JobUpdateRestartRPCMethod, updateReq, &updateResp))
+	require.Equal(t, structs.JobRestartStatusCancelled, updateResp.Restart.Status)
+	require.Equal(t, "cancelled by operator", updateResp.Restart.StatusDescription)
+
+	updateReq.Status = structs.JobRestartStatusRunning
+	err = msgpackrpc.CallWithCodec(codec, structs.JobUpdateRestartRPCMethod, updateReq, &updateResp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "has no active restart")
+}
+
+func TestJobEndpoint_Restart_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job, _ := testJobRestartAllocs(t, s1, 1)
+
+	req := &structs.JobRestartRequest{
+		JobID:     job.ID,
+		BatchSize: 1,
+		BatchWait: time.Hour,
+		OnError:   structs.JobRestartOnErrorPause,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Restarting requires the alloc-lifecycle capability
+	readToken := mock.CreatePolicyAndToken(t, s1.State(), 1003, "test-read-job",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	req.AuthToken = readToken.SecretID
+	var resp structs.JobRestartResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.JobRestartRPCMethod, req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	lifecycleToken := mock.CreatePolicyAndToken(t, s1.State(), 1005, "test-alloc-lifecycle",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityAllocLifecycle}))
+	req.AuthToken = lifecycleToken.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.JobRestartRPCMethod, req, &resp))
+
+	// Reading the restart requires the read-job capability
+	getReq := &structs.JobSpecificRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+			AuthToken: lifecycleToken.SecretID,
+		},
+	}
+	var getResp structs.SingleJobRestartResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.JobGetRestartRPCMethod, getReq, &getResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	getReq.AuthToken = readToken.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.JobGetRestartRPCMethod, getReq, &getResp))
+	require.Equal(t, resp.Restart.ID, getResp.Restart.ID)
+
+	// Updating the restart requires the alloc-lifecycle capability
+	updateReq := &structs.JobRestartUpdateRequest{
+		JobID:  job.ID,
+		Status: structs.JobRestartStatusCancelled,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var updateResp structs.JobRestartResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.JobUpdateRestartRPCMethod, updateReq, &updateResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	updateReq.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.JobUpdateRestartRPCMethod, updateReq, &updateResp))
+	require.Equal(t, structs.JobRestartStatusCancelled, updateResp.Restart.Status)
+}
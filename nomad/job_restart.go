@@ -0,0 +1,178 @@
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// jobRestartIdleInterval is the longest the leader waits before checking
+	// the job restarts again when none of them has a batch due.
+	jobRestartIdleInterval = 5 * time.Minute
+
+	// jobRestartRetryInterval is how long the leader waits before checking
+	// the job restarts again after failing to carry them out.
+	jobRestartRetryInterval = 10 * time.Second
+)
+
+// runJobRestarts is a long lived function that restarts the batches of the
+// running job restarts as they fall due, until the server loses leadership.
+// The progress of each restart is recorded in the state store, so a new
+// leader picks up where the previous one stopped. A batch interrupted by a
+// leader election is restarted again in full.
+func (s *Server) runJobRestarts(stopCh chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		ws := memdb.NewWatchSet()
+		wait, err := s.restartDueJobBatches(ws, s.restartJobAlloc)
+		if err != nil {
+			s.logger.Error("failed to restart jobs", "error", err)
+			wait = jobRestartRetryInterval
+		}
+
+		waitCtx, waitCancel := context.WithTimeout(ctx, wait)
+		ws.WatchCtx(waitCtx)
+		waitCancel()
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// restartDueJobBatches restarts the next batch of every running job restart
+// which is due, and returns how long to wait for the next batch to fall due.
+// The watch set fires when a restart is started or updated.
+func (s *Server) restartDueJobBatches(ws memdb.WatchSet, restartFn func(*structs.Allocation) error) (time.Duration, error) {
+	iter, err := s.fsm.State().JobRestarts(ws)
+	if err != nil {
+		return 0, err
+	}
+
+	wait := jobRestartIdleInterval
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		restart := raw.(*structs.JobRestart)
+		if restart.Status != structs.JobRestartStatusRunning {
+			continue
+		}
+		if until := time.Until(restart.NextBatch); until > 0 {
+			if until < wait {
+				wait = until
+			}
+			continue
+		}
+		if err := s.restartJobBatch(restart, restartFn); err != nil {
+			return 0, err
+		}
+	}
+	return wait, nil
+}
+
+// restartJobBatch restarts the next batch of allocations of the restart with
+// restartFn, and records the outcome. Allocations which were stopped since
+// the restart started are skipped.
+func (s *Server) restartJobBatch(restart *structs.JobRestart, restartFn func(*structs.Allocation) error) error {
+	defer metrics.MeasureSince([]string{"nomad", "job", "restart_batch"}, time.Now())
+
+	snap, err := s.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	batch := restart.NextBatchAllocIDs()
+	var mErr multierror.Error
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for _, allocID := range batch {
+		alloc, err := snap.AllocByID(nil, allocID)
+		if err != nil {
+			return err
+		}
+		if alloc == nil || alloc.TerminalStatus() {
+			continue
+		}
+
+		wg.Add(1)
+		go func(alloc *structs.Allocation) {
+			defer wg.Done()
+			if err := restartFn(alloc); err != nil {
+				lock.Lock()
+				defer lock.Unlock()
+				mErr.Errors = append(mErr.Errors,
+					fmt.Errorf("failed to restart allocation %s: %v", alloc.ID, err))
+			}
+		}(alloc)
+	}
+	wg.Wait()
+
+	// Record the outcome on the current version of the restart, so that an
+	// operator pausing or cancelling it while the batch was restarted isn't
+	// overwritten.
+	current, err := s.fsm.State().JobRestartByJob(nil, restart.Namespace, restart.JobID)
+	if err != nil {
+		return err
+	}
+	if current == nil || current.ID != restart.ID {
+		return nil
+	}
+
+	update := current.Copy()
+	now := time.Now().UTC()
+	update.ModifyTime = now.UnixNano()
+	if err := mErr.ErrorOrNil(); err != nil {
+		s.logger.Warn("failed to restart batch of job restart", "namespace", restart.Namespace,
+			"job_id", restart.JobID, "restart_id", restart.ID, "error", err)
+		if update.Status == structs.JobRestartStatusRunning {
+			update.Status = structs.JobRestartStatusFailed
+			if update.OnError == structs.JobRestartOnErrorPause {
+				update.Status = structs.JobRestartStatusPaused
+			}
+			update.StatusDescription = err.Error()
+		}
+	} else {
+		update.Restarted += len(batch)
+		update.NextBatch = now.Add(update.BatchWait)
+		if update.Restarted >= len(update.AllocIDs) && update.Active() {
+			update.Status = structs.JobRestartStatusComplete
+			update.StatusDescription = ""
+		}
+	}
+
+	req := &structs.JobRestartUpsertRequest{
+		Restart: update,
+		WriteRequest: structs.WriteRequest{
+			Region: s.config.Region,
+		},
+	}
+	_, _, err = s.raftApply(structs.JobRestartUpsertRequestType, req)
+	return err
+}
+
+// restartJobAlloc restarts the tasks of an allocation on its client.
+func (s *Server) restartJobAlloc(alloc *structs.Allocation) error {
+	req := &structs.AllocRestartRequest{
+		AllocID: alloc.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    s.config.Region,
+			Namespace: alloc.Namespace,
+			AuthToken: s.getLeaderAcl(),
+		},
+	}
+	var resp structs.GenericResponse
+	return s.RPC("ClientAllocations.Restart", req, &resp)
+}
@@ -0,0 +1,150 @@
+package nomad
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// testJobRestartAllocs upserts a job with count running allocations.
+func testJobRestartAllocs(t *testing.T, srv *Server, count int) (*structs.Job, []*structs.Allocation) {
+	store := srv.fsm.State()
+	job := mock.Job()
+	require.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	allocs := make([]*structs.Allocation, count)
+	for i := range allocs {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.Name = fmt.Sprintf("%s.web[%d]", job.ID, i)
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs[i] = alloc
+	}
+	require.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1001, allocs))
+	return job, allocs
+}
+
+func TestJobRestart_RestartJobBatch(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+
+	job, allocs := testJobRestartAllocs(t, srv, 3)
+	store := srv.fsm.State()
+
+	// The batches are far apart so that the leader doesn't restart them
+	// itself.
+	restart := &structs.JobRestart{
+		ID:        uuid.Generate(),
+		Namespace: job.Namespace,
+		JobID:     job.ID,
+		BatchSize: 2,
+		BatchWait: time.Hour,
+		OnError:   structs.JobRestartOnErrorPause,
+		AllocIDs:  []string{allocs[0].ID, allocs[1].ID, allocs[2].ID},
+		NextBatch: time.Now().Add(time.Hour),
+		Status:    structs.JobRestartStatusRunning,
+	}
+	require.NoError(t, store.UpsertJobRestart(structs.MsgTypeTestSetup, 1002, restart))
+
+	var lock sync.Mutex
+	var restarted []string
+	var failing bool
+	restartFn := func(alloc *structs.Allocation) error {
+		lock.Lock()
+		defer lock.Unlock()
+		if failing {
+			return errors.New("node down")
+		}
+		restarted = append(restarted, alloc.ID)
+		return nil
+	}
+	current := func() *structs.JobRestart {
+		out, err := store.JobRestartByJob(nil, job.Namespace, job.ID)
+		require.NoError(t, err)
+		return out
+	}
+
+	// The first batch restarts two allocations
+	require.NoError(t, srv.restartJobBatch(current(), restartFn))
+	require.ElementsMatch(t, []string{allocs[0].ID, allocs[1].ID}, restarted)
+	out := current()
+	require.Equal(t, 2, out.Restarted)
+	require.Equal(t, structs.JobRestartStatusRunning, out.Status)
+	require.True(t, out.NextBatch.After(time.Now().Add(59*time.Minute)))
+
+	// A failed batch pauses the restart
+	failing = true
+	require.NoError(t, srv.restartJobBatch(current(), restartFn))
+	out = current()
+	require.Equal(t, 2, out.Restarted)
+	require.Equal(t, structs.JobRestartStatusPaused, out.Status)
+	require.Contains(t, out.StatusDescription, "node down")
+
+	// The batch is retried on resume, skipping stopped allocations
+	stopped := allocs[2].Copy()
+	stopped.DesiredStatus = structs.AllocDesiredStatusStop
+	stopped.ClientStatus = structs.AllocClientStatusComplete
+	require.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1003, []*structs.Allocation{stopped}))
+
+	resumed := out.Copy()
+	resumed.Status = structs.JobRestartStatusRunning
+	resumed.StatusDescription = ""
+	require.NoError(t, store.UpsertJobRestart(structs.MsgTypeTestSetup, 1004, resumed))
+
+	require.NoError(t, srv.restartJobBatch(current(), restartFn))
+	require.Len(t, restarted, 2)
+	out = current()
+	require.Equal(t, 3, out.Restarted)
+	require.Equal(t, structs.JobRestartStatusComplete, out.Status)
+}
+
+func TestJobRestart_RestartJobBatch_Fail(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+
+	job, allocs := testJobRestartAllocs(t, srv, 1)
+	store := srv.fsm.State()
+
+	restart := &structs.JobRestart{
+		ID:        uuid.Generate(),
+		Namespace: job.Namespace,
+		JobID:     job.ID,
+		BatchSize: 1,
+		OnError:   structs.JobRestartOnErrorFail,
+		AllocIDs:  []string{allocs[0].ID},
+		NextBatch: time.Now().Add(time.Hour),
+		Status:    structs.JobRestartStatusRunning,
+	}
+	require.NoError(t, store.UpsertJobRestart(structs.MsgTypeTestSetup, 1002, restart))
+
+	err := srv.restartJobBatch(restart, func(*structs.Allocation) error {
+		return errors.New("node down")
+	})
+	require.NoError(t, err)
+
+	out, err := store.JobRestartByJob(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, 0, out.Restarted)
+	require.Equal(t, structs.JobRestartStatusFailed, out.Status)
+	require.Contains(t, out.StatusDescription, "node down")
+}
@@ -1,13 +1,18 @@
 package nomad
 
 import (
+	"crypto/ed25519"
+	"crypto/subtle"
 	"fmt"
+	"strconv"
 	"time"
 
 	metrics "github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
 
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/mlock"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -34,28 +39,57 @@ func (k *Keyring) Rotate(args *structs.KeyringRotateRootKeyRequest, reply *struc
 		return structs.ErrPermissionDenied
 	}
 
+	if args.Full && args.PublishTime != 0 {
+		return fmt.Errorf("a full rotation cannot be scheduled")
+	}
 	if args.Algorithm == "" {
 		args.Algorithm = structs.EncryptionAlgorithmAES256GCM
 	}
 
-	rootKey, err := structs.NewRootKey(args.Algorithm)
+	snap, err := k.srv.fsm.State().Snapshot()
 	if err != nil {
 		return err
 	}
-
-	rootKey.Meta.SetActive()
-
-	// make sure it's been added to the local keystore before we write
-	// it to raft, so that followers don't try to Get a key that
-	// hasn't yet been written to disk
-	err = k.encrypter.AddKey(rootKey)
+	prepublished, err := snap.GetPrepublishedRootKeyMeta(nil)
 	if err != nil {
 		return err
 	}
 
+	var keyMeta *structs.RootKeyMeta
+	if prepublished != nil {
+		if args.PublishTime != 0 {
+			return fmt.Errorf("root key %s is already prepublished", prepublished.KeyID)
+		}
+
+		// every server already has the prepublished key, so rotating
+		// activates it early instead of creating another key
+		keyMeta = prepublished.Copy()
+		keyMeta.SetActive()
+	} else {
+		rootKey, err := structs.NewRootKey(args.Algorithm)
+		if err != nil {
+			return err
+		}
+
+		if args.PublishTime > time.Now().UnixNano() {
+			rootKey.Meta.SetPrepublished(args.PublishTime)
+		} else {
+			rootKey.Meta.SetActive()
+		}
+
+		// make sure it's been added to the local keystore before we write
+		// it to raft, so that followers don't try to Get a key that
+		// hasn't yet been written to disk
+		err = k.encrypter.AddKey(rootKey)
+		if err != nil {
+			return err
+		}
+		keyMeta = rootKey.Meta
+	}
+
 	// Update metadata via Raft so followers can retrieve this key
 	req := structs.KeyringUpdateRootKeyMetaRequest{
-		RootKeyMeta:  rootKey.Meta,
+		RootKeyMeta:  keyMeta,
 		Rekey:        args.Full,
 		WriteRequest: args.WriteRequest,
 	}
@@ -66,9 +100,22 @@ func (k *Keyring) Rotate(args *structs.KeyringRotateRootKeyRequest, reply *struc
 	if err, ok := out.(error); ok && err != nil {
 		return err
 	}
-	reply.Key = rootKey.Meta
+	reply.Key = keyMeta
 	reply.Index = index
 
+	if keyMeta.Prepublished() {
+		metrics.IncrCounter([]string{"keyring", "prepublications"}, 1)
+		k.logger.Info("prepublished root key", "key_id", keyMeta.KeyID,
+			"publish_time", time.Unix(0, keyMeta.PublishTime).UTC())
+		return nil
+	}
+
+	metrics.IncrCounterWithLabels([]string{"keyring", "rotations"}, 1,
+		[]metrics.Label{
+			{Name: "full", Value: strconv.FormatBool(args.Full)},
+			{Name: "prepublished", Value: strconv.FormatBool(prepublished != nil)},
+		})
+
 	if args.Full {
 		// like most core jobs, we don't commit this to raft b/c it's not
 		// going to be periodically recreated and the ACL is from this leader
@@ -131,6 +178,12 @@ func (k *Keyring) List(args *structs.KeyringListRootKeyMetaRequest, reply *struc
 					break
 				}
 				keyMeta := raw.(*structs.RootKeyMeta)
+				usage, err := k.keyUsage(snap, keyMeta.KeyID)
+				if err != nil {
+					return err
+				}
+				keyMeta = keyMeta.Copy()
+				keyMeta.Usage = usage
 				keys = append(keys, keyMeta)
 			}
 			reply.Keys = keys
@@ -140,6 +193,166 @@ func (k *Keyring) List(args *structs.KeyringListRootKeyMetaRequest, reply *struc
 	return k.srv.blockingRPC(&opts)
 }
 
+// GetMeta retrieves the metadata of an existing key of the keyring, without
+// its key material.
+func (k *Keyring) GetMeta(args *structs.KeyringGetRootKeyMetaRequest, reply *structs.KeyringGetRootKeyMetaResponse) error {
+	if done, err := k.srv.forward("Keyring.GetMeta", args, args, reply); done {
+		return err
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "get_meta"}, time.Now())
+
+	if aclObj, err := k.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.KeyID == "" {
+		return fmt.Errorf("root key ID is required")
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+
+			// retrieve the key metadata
+			snap, err := k.srv.fsm.State().Snapshot()
+			if err != nil {
+				return err
+			}
+			keyMeta, err := snap.RootKeyMetaByID(ws, args.KeyID)
+			if err != nil {
+				return err
+			}
+			if keyMeta == nil {
+				reply.Key = nil
+				return k.srv.replySetIndex(state.TableRootKeyMeta, &reply.QueryMeta)
+			}
+
+			usage, err := k.keyUsage(snap, keyMeta.KeyID)
+			if err != nil {
+				return err
+			}
+			keyMeta = keyMeta.Copy()
+			keyMeta.Usage = usage
+			reply.Key = keyMeta
+			reply.Index = keyMeta.ModifyIndex
+			return nil
+		},
+	}
+	return k.srv.blockingRPC(&opts)
+}
+
+// keyUsage counts the secure variables encrypted with the key and adds the
+// local signing and usage counters from the encrypter.
+func (k *Keyring) keyUsage(snap *state.StateSnapshot, keyID string) (*structs.RootKeyUsage, error) {
+	iter, err := snap.GetSecureVariablesByKeyID(nil, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &structs.RootKeyUsage{}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		usage.Variables++
+	}
+	usage.SignOperations, usage.LastUsed = k.encrypter.KeyUsage(keyID)
+	return usage, nil
+}
+
+// Verify checks that every secure variable can be decrypted with the keys in
+// the keyring of the server handling the request, and reports those which
+// cannot. It should be run before deleting inactive keys.
+func (k *Keyring) Verify(args *structs.KeyringVerifyRequest, reply *structs.KeyringVerifyResponse) error {
+	if done, err := k.srv.forward("Keyring.Verify", args, args, reply); done {
+		return err
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "verify"}, time.Now())
+
+	if aclObj, err := k.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	snap, err := k.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	iter, err := snap.SecureVariables(nil)
+	if err != nil {
+		return err
+	}
+
+	reply.Orphaned = []*structs.KeyringOrphanedVariable{}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		sv := raw.(*structs.SecureVariableEncrypted)
+		reply.Variables++
+
+		var verr error
+		keyMeta, err := snap.RootKeyMetaByID(nil, sv.KeyID)
+		switch {
+		case err != nil:
+			return err
+		case keyMeta == nil:
+			verr = fmt.Errorf("root key %s has been deleted", sv.KeyID)
+		default:
+			_, verr = k.encrypter.Decrypt(sv.Data, sv.KeyID)
+		}
+		if verr != nil {
+			reply.Orphaned = append(reply.Orphaned, &structs.KeyringOrphanedVariable{
+				Namespace: sv.Namespace,
+				Path:      sv.Path,
+				KeyID:     sv.KeyID,
+				Error:     verr.Error(),
+			})
+		}
+	}
+
+	// The result depends on both the variables and the keys, so report the
+	// highest index of the two tables.
+	svIndex, err := snap.Index(state.TableSecureVariables)
+	if err != nil {
+		return err
+	}
+	keyIndex, err := snap.Index(state.TableRootKeyMeta)
+	if err != nil {
+		return err
+	}
+	reply.Index = helper.Max(1, helper.Max(svIndex, keyIndex))
+	k.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
+
+// Health reports the results of the leader's periodic integrity checks of
+// the encrypted secure variables since it was elected.
+func (k *Keyring) Health(args *structs.KeyringHealthRequest, reply *structs.KeyringHealthResponse) error {
+	if done, err := k.srv.forward("Keyring.Health", args, args, reply); done {
+		return err
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "health"}, time.Now())
+
+	if aclObj, err := k.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	k.srv.keyringIntegrity.health(reply)
+
+	index, err := k.srv.fsm.State().Index(state.TableSecureVariables)
+	if err != nil {
+		return err
+	}
+	reply.Index = helper.Max(1, index)
+	k.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
+
 // Update updates an existing key in the keyring, including both the
 // key material and metadata.
 func (k *Keyring) Update(args *structs.KeyringUpdateRootKeyRequest, reply *structs.KeyringUpdateRootKeyResponse) error {
@@ -160,29 +373,154 @@ func (k *Keyring) Update(args *structs.KeyringUpdateRootKeyRequest, reply *struc
 		return err
 	}
 
+	index, err := k.installKey(args.RootKey, args.WriteRequest)
+	if err != nil {
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// installKey adds the key to the local keystore and writes its metadata
+// to raft.
+func (k *Keyring) installKey(rootKey *structs.RootKey, wr structs.WriteRequest) (uint64, error) {
+
 	// make sure it's been added to the local keystore before we write
 	// it to raft, so that followers don't try to Get a key that
 	// hasn't yet been written to disk
-	err = k.encrypter.AddKey(args.RootKey)
+	err := k.encrypter.AddKey(rootKey)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	// unwrap the request to turn it into a meta update only
 	metaReq := &structs.KeyringUpdateRootKeyMetaRequest{
-		RootKeyMeta:  args.RootKey.Meta,
-		WriteRequest: args.WriteRequest,
+		RootKeyMeta:  rootKey.Meta,
+		WriteRequest: wr,
 	}
 
 	// update the metadata via Raft
 	out, index, err := k.srv.raftApply(structs.RootKeyMetaUpsertRequestType, metaReq)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	if err, ok := out.(error); ok && err != nil {
+		return 0, err
+	}
+	return index, nil
+}
+
+// Export returns a root key sealed with a passphrase, so that it can be
+// imported into another cluster if the keystore is lost.
+func (k *Keyring) Export(args *structs.KeyringExportRootKeyRequest, reply *structs.KeyringExportRootKeyResponse) error {
+	if done, err := k.srv.forward("Keyring.Export", args, args, reply); done {
 		return err
 	}
 
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "export"}, time.Now())
+
+	if aclObj, err := k.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowOperatorKeyringTransfer() {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.KeyID == "" {
+		return fmt.Errorf("root key ID is required")
+	}
+
+	snap, err := k.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	keyMeta, err := snap.RootKeyMetaByID(nil, args.KeyID)
+	if err != nil {
+		return err
+	}
+	if keyMeta == nil {
+		return fmt.Errorf("no such key %q in keyring", args.KeyID)
+	}
+
+	key, err := k.encrypter.GetKey(keyMeta.KeyID)
+	if err != nil {
+		return err
+	}
+	defer mlock.Zero(key)
+
+	bundle, err := sealRootKey(&structs.RootKey{Meta: keyMeta, Key: key}, args.Passphrase)
+	if err != nil {
+		return err
+	}
+
+	k.logger.Warn("root key exported", "key_id", keyMeta.KeyID)
+	reply.Bundle = bundle
+	reply.Index = keyMeta.ModifyIndex
+	return nil
+}
+
+// Import installs a root key from a bundle created by Export. Keys that
+// aren't already in the keyring are installed as inactive, so that they
+// can decrypt existing secure variables without being used for new ones.
+func (k *Keyring) Import(args *structs.KeyringImportRootKeyRequest, reply *structs.KeyringImportRootKeyResponse) error {
+	if done, err := k.srv.forward("Keyring.Import", args, args, reply); done {
+		return err
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "import"}, time.Now())
+
+	if aclObj, err := k.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowOperatorKeyringTransfer() {
+		return structs.ErrPermissionDenied
+	}
+
+	rootKey, err := openRootKey(args.Bundle, args.Passphrase)
+	if err != nil {
+		return err
+	}
+
+	// keep the state of a key this cluster already knows about, so that
+	// restoring lost key material doesn't change which key is active
+	snap, err := k.srv.fsm.State().Snapshot()
+	if err != nil {
+		mlock.Zero(rootKey.Key)
+		return err
+	}
+	keyMeta, err := snap.RootKeyMetaByID(nil, rootKey.Meta.KeyID)
+	if err != nil {
+		mlock.Zero(rootKey.Key)
+		return err
+	}
+	if keyMeta != nil {
+		if keyMeta.Algorithm != rootKey.Meta.Algorithm {
+			mlock.Zero(rootKey.Key)
+			return fmt.Errorf("root key algorithm cannot be changed after a key is created")
+		}
+		rootKey.Meta = keyMeta.Copy()
+	}
+
+	// a key that's already in the keyring can only be imported again with
+	// the same material, so that an import can't replace the key that
+	// existing secure variables were encrypted with
+	if existing, err := k.encrypter.GetKey(rootKey.Meta.KeyID); err == nil {
+		same := subtle.ConstantTimeCompare(existing, rootKey.Key) == 1
+		mlock.Zero(existing)
+		if !same {
+			mlock.Zero(rootKey.Key)
+			return fmt.Errorf("root key %q already exists with different key material", rootKey.Meta.KeyID)
+		}
+	}
+
+	// the keyring keeps its own copy of the key material
+	index, err := k.installKey(rootKey, args.WriteRequest)
+	mlock.Zero(rootKey.Key)
+	if err != nil {
+		return err
+	}
+
+	k.logger.Warn("root key imported", "key_id", rootKey.Meta.KeyID)
+	reply.Key = rootKey.Meta.Copy()
 	reply.Index = index
 	return nil
 }
@@ -271,6 +609,55 @@ func (k *Keyring) Get(args *structs.KeyringGetRootKeyRequest, reply *structs.Key
 	return k.srv.blockingRPC(&opts)
 }
 
+// GetWrapped retrieves an existing key from the keyring with its key material
+// sealed to the public key of the requesting server. It is used only to
+// replicate root keys to other regions.
+func (k *Keyring) GetWrapped(args *structs.KeyringGetWrappedRootKeyRequest, reply *structs.KeyringGetWrappedRootKeyResponse) error {
+	// ensure that only another server can make this request
+	err := validateTLSCertificateLevel(k.srv, k.ctx, tlsCertificateLevelServer)
+	if err != nil {
+		return err
+	}
+
+	if done, err := k.srv.forward("Keyring.GetWrapped", args, args, reply); done {
+		return err
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "get_wrapped"}, time.Now())
+
+	if args.KeyID == "" {
+		return fmt.Errorf("root key ID is required")
+	}
+
+	snap, err := k.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	keyMeta, err := snap.RootKeyMetaByID(nil, args.KeyID)
+	if err != nil {
+		return err
+	}
+	if keyMeta == nil {
+		return k.srv.replySetIndex(state.TableRootKeyMeta, &reply.QueryMeta)
+	}
+
+	key, err := k.encrypter.GetKey(keyMeta.KeyID)
+	if err != nil {
+		return err
+	}
+	defer mlock.Zero(key)
+
+	wrapped, err := wrapRootKey(key, args.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	reply.Meta = keyMeta
+	reply.WrappedKey = wrapped
+	reply.Index = keyMeta.ModifyIndex
+	return nil
+}
+
 func (k *Keyring) Delete(args *structs.KeyringDeleteRootKeyRequest, reply *structs.KeyringDeleteRootKeyResponse) error {
 	if done, err := k.srv.forward("Keyring.Delete", args, args, reply); done {
 		return err
@@ -320,3 +707,119 @@ func (k *Keyring) Delete(args *structs.KeyringDeleteRootKeyRequest, reply *struc
 	reply.Index = index
 	return nil
 }
+
+// UpsertJobSigningKey trusts a public key to sign job submissions, or
+// updates the name of an already trusted key.
+func (k *Keyring) UpsertJobSigningKey(args *structs.KeyringUpsertJobSigningKeyRequest, reply *structs.KeyringUpsertJobSigningKeyResponse) error {
+	if done, err := k.srv.forward(structs.JobSigningKeyUpsertRPCMethod, args, args, reply); done {
+		return err
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "upsert_job_signing_key"}, time.Now())
+
+	if aclObj, err := k.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.Key == nil {
+		return fmt.Errorf("job signing key is required")
+	}
+	args.Key = args.Key.Copy()
+	if args.Key.KeyID == "" && len(args.Key.PublicKey) == ed25519.PublicKeySize {
+		args.Key.KeyID = structs.JobSigningKeyID(args.Key.PublicKey)
+	}
+	if err := args.Key.Validate(); err != nil {
+		return err
+	}
+	args.Key.CreateTime = time.Now().UTC().UnixNano()
+
+	out, index, err := k.srv.raftApply(structs.JobSigningKeyUpsertRequestType, args)
+	if err != nil {
+		return err
+	}
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+
+	key, err := k.srv.fsm.State().JobSigningKeyByID(nil, args.Key.KeyID)
+	if err != nil {
+		return err
+	}
+	reply.Key = key
+	reply.Index = index
+	return nil
+}
+
+// ListJobSigningKeys lists the public keys trusted to sign job submissions.
+func (k *Keyring) ListJobSigningKeys(args *structs.KeyringListJobSigningKeysRequest, reply *structs.KeyringListJobSigningKeysResponse) error {
+	if done, err := k.srv.forward(structs.JobSigningKeyListRPCMethod, args, args, reply); done {
+		return err
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "list_job_signing_keys"}, time.Now())
+
+	if aclObj, err := k.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			iter, err := s.JobSigningKeys(ws)
+			if err != nil {
+				return err
+			}
+
+			keys := []*structs.JobSigningKey{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				keys = append(keys, raw.(*structs.JobSigningKey))
+			}
+			reply.Keys = keys
+			return k.srv.replySetIndex(state.TableJobSigningKeys, &reply.QueryMeta)
+		},
+	}
+	return k.srv.blockingRPC(&opts)
+}
+
+// DeleteJobSigningKey stops trusting a job signing key. Jobs already
+// registered with its signatures keep running.
+func (k *Keyring) DeleteJobSigningKey(args *structs.KeyringDeleteJobSigningKeyRequest, reply *structs.KeyringDeleteJobSigningKeyResponse) error {
+	if done, err := k.srv.forward(structs.JobSigningKeyDeleteRPCMethod, args, args, reply); done {
+		return err
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "delete_job_signing_key"}, time.Now())
+
+	if aclObj, err := k.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.KeyID == "" {
+		return fmt.Errorf("job signing key ID is required")
+	}
+
+	key, err := k.srv.fsm.State().JobSigningKeyByID(nil, args.KeyID)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		return nil // safe to bail out early
+	}
+
+	out, index, err := k.srv.raftApply(structs.JobSigningKeyDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+	reply.Index = index
+	return nil
+}
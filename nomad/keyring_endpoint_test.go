@@ -1,14 +1,20 @@
 package nomad
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
 
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/box"
 
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
 )
@@ -292,3 +298,581 @@ func TestKeyringEndpoint_Rotate(t *testing.T) {
 	gotKey := getResp.Key
 	require.Len(t, gotKey.Key, 32)
 }
+
+// TestKeyringEndpoint_Rotate_Prepublish verifies that a scheduled rotation
+// prepublishes the new key, and that rotating again activates it early
+func TestKeyringEndpoint_Rotate_Prepublish(t *testing.T) {
+
+	ci.Parallel(t)
+	srv, rootToken, shutdown := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+
+	store := srv.fsm.State()
+	activeKey, err := store.GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+	require.NotNil(t, activeKey)
+
+	// Schedule a rotation, which can't be a full one
+	publishTime := time.Now().Add(time.Hour).UnixNano()
+	rotateReq := &structs.KeyringRotateRootKeyRequest{
+		Full:        true,
+		PublishTime: publishTime,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: rootToken.SecretID,
+		},
+	}
+	var rotateResp structs.KeyringRotateRootKeyResponse
+	err = msgpackrpc.CallWithCodec(codec, "Keyring.Rotate", rotateReq, &rotateResp)
+	require.EqualError(t, err, "a full rotation cannot be scheduled")
+
+	rotateReq.Full = false
+	err = msgpackrpc.CallWithCodec(codec, "Keyring.Rotate", rotateReq, &rotateResp)
+	require.NoError(t, err)
+	require.True(t, rotateResp.Key.Prepublished())
+	require.Equal(t, publishTime, rotateResp.Key.PublishTime)
+	newID := rotateResp.Key.KeyID
+
+	// The new key is available but the active key is unchanged
+	got, err := store.GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+	require.Equal(t, activeKey.KeyID, got.KeyID)
+	_, err = srv.encrypter.GetKey(newID)
+	require.NoError(t, err)
+
+	// Only one key can be prepublished at a time
+	err = msgpackrpc.CallWithCodec(codec, "Keyring.Rotate", rotateReq, &rotateResp)
+	require.ErrorContains(t, err, "already prepublished")
+
+	// Rotating activates the prepublished key instead of creating a new one
+	rotateReq.PublishTime = 0
+	err = msgpackrpc.CallWithCodec(codec, "Keyring.Rotate", rotateReq, &rotateResp)
+	require.NoError(t, err)
+	require.Equal(t, newID, rotateResp.Key.KeyID)
+	require.True(t, rotateResp.Key.Active())
+
+	got, err = store.GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+	require.Equal(t, newID, got.KeyID)
+	require.Equal(t, publishTime, got.PublishTime)
+
+	prepublished, err := store.GetPrepublishedRootKeyMeta(nil)
+	require.NoError(t, err)
+	require.Nil(t, prepublished)
+}
+
+// TestKeyringEndpoint_List_Usage verifies that listing the keyring reports
+// how much each key is in use
+func TestKeyringEndpoint_List_Usage(t *testing.T) {
+
+	ci.Parallel(t)
+	srv, rootToken, shutdown := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+
+	// Write secure variables with the bootstrap key and sign a claim
+	for _, path := range []string{"usage/a", "usage/b"} {
+		sv := mock.SecureVariable()
+		sv.Path = path
+		applyReq := structs.SecureVariablesApplyRequest{
+			Op:  structs.SVOpSet,
+			Var: sv,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				AuthToken: rootToken.SecretID,
+			},
+		}
+		var applyResp structs.SecureVariablesApplyResponse
+		err := msgpackrpc.CallWithCodec(codec, structs.SecureVariablesApplyRPCMethod, &applyReq, &applyResp)
+		require.NoError(t, err)
+	}
+	alloc := mock.Alloc()
+	_, err := srv.encrypter.SignClaims(alloc.ToTaskIdentityClaims(nil, "web"))
+	require.NoError(t, err)
+
+	listReq := &structs.KeyringListRootKeyMetaRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var listResp structs.KeyringListRootKeyMetaResponse
+	err = msgpackrpc.CallWithCodec(codec, "Keyring.List", listReq, &listResp)
+	require.NoError(t, err)
+	require.Len(t, listResp.Keys, 1)
+
+	usage := listResp.Keys[0].Usage
+	require.NotNil(t, usage)
+	require.Equal(t, 2, usage.Variables)
+	require.Equal(t, uint64(1), usage.SignOperations)
+	require.NotZero(t, usage.LastUsed)
+
+	// Usage is never persisted
+	stored, err := srv.State().RootKeyMetaByID(nil, listResp.Keys[0].KeyID)
+	require.NoError(t, err)
+	require.Nil(t, stored.Usage)
+}
+
+// TestKeyringEndpoint_GetMeta exercises reading the metadata of a single key
+// with a blocking query
+func TestKeyringEndpoint_GetMeta(t *testing.T) {
+
+	ci.Parallel(t)
+	srv, rootToken, shutdown := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+
+	key, err := structs.NewRootKey(structs.EncryptionAlgorithmAES256GCM)
+	require.NoError(t, err)
+	id := key.Meta.KeyID
+
+	updateReq := &structs.KeyringUpdateRootKeyRequest{
+		RootKey: key,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: rootToken.SecretID,
+		},
+	}
+	var updateResp structs.KeyringUpdateRootKeyResponse
+	err = msgpackrpc.CallWithCodec(codec, "Keyring.Update", updateReq, &updateResp)
+	require.NoError(t, err)
+
+	// Unlike Get, GetMeta requires a management token
+	getReq := &structs.KeyringGetRootKeyMetaRequest{
+		KeyID:        id,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var getResp structs.KeyringGetRootKeyMetaResponse
+	err = msgpackrpc.CallWithCodec(codec, "Keyring.GetMeta", getReq, &getResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	getReq.AuthToken = rootToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Keyring.GetMeta", getReq, &getResp)
+	require.NoError(t, err)
+	require.Equal(t, updateResp.Index, getResp.Index)
+	require.Equal(t, id, getResp.Key.KeyID)
+	require.False(t, getResp.Key.Active())
+	require.NotNil(t, getResp.Key.Usage)
+
+	// Make a blocking query and wait for the key to become active
+	var wg sync.WaitGroup
+	wg.Add(1)
+	var blockingResp structs.KeyringGetRootKeyMetaResponse
+
+	go func() {
+		defer wg.Done()
+		codec := rpcClient(t, srv) // not safe to share across goroutines
+		req := &structs.KeyringGetRootKeyMetaRequest{
+			KeyID: id,
+			QueryOptions: structs.QueryOptions{
+				Region:        "global",
+				AuthToken:     rootToken.SecretID,
+				MinQueryIndex: getResp.Index,
+			},
+		}
+		err := msgpackrpc.CallWithCodec(codec, "Keyring.GetMeta", req, &blockingResp)
+		require.NoError(t, err)
+	}()
+
+	updateReq.RootKey.Meta.SetActive()
+	err = msgpackrpc.CallWithCodec(codec, "Keyring.Update", updateReq, &updateResp)
+	require.NoError(t, err)
+
+	wg.Wait()
+	require.Equal(t, updateResp.Index, blockingResp.Index)
+	require.True(t, blockingResp.Key.Active())
+
+	// Unknown keys return no metadata
+	getReq.KeyID = uuid.Generate()
+	getResp = structs.KeyringGetRootKeyMetaResponse{}
+	err = msgpackrpc.CallWithCodec(codec, "Keyring.GetMeta", getReq, &getResp)
+	require.NoError(t, err)
+	require.Nil(t, getResp.Key)
+}
+
+func TestKeyringEndpoint_GetWrapped(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+
+	keyMeta, err := srv.fsm.State().GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+	key, err := srv.encrypter.GetKey(keyMeta.KeyID)
+	require.NoError(t, err)
+
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	req := &structs.KeyringGetWrappedRootKeyRequest{
+		KeyID:        keyMeta.KeyID,
+		PublicKey:    []byte("short"),
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var resp structs.KeyringGetWrappedRootKeyResponse
+	err = msgpackrpc.CallWithCodec(codec, "Keyring.GetWrapped", req, &resp)
+	require.ErrorContains(t, err, "invalid public key")
+
+	// The key material is only sent sealed to the requester's public key
+	req.PublicKey = publicKey[:]
+	err = msgpackrpc.CallWithCodec(codec, "Keyring.GetWrapped", req, &resp)
+	require.NoError(t, err)
+	require.Equal(t, keyMeta.KeyID, resp.Meta.KeyID)
+	require.NotContains(t, string(resp.WrappedKey), string(key))
+
+	unwrapped, err := unwrapRootKey(resp.WrappedKey, publicKey, privateKey)
+	require.NoError(t, err)
+	require.Equal(t, key, unwrapped)
+
+	otherPublic, otherPrivate, err := box.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, err = unwrapRootKey(resp.WrappedKey, otherPublic, otherPrivate)
+	require.Error(t, err)
+
+	// Unknown keys return no metadata
+	req.KeyID = uuid.Generate()
+	resp = structs.KeyringGetWrappedRootKeyResponse{}
+	err = msgpackrpc.CallWithCodec(codec, "Keyring.GetWrapped", req, &resp)
+	require.NoError(t, err)
+	require.Nil(t, resp.Meta)
+}
+
+// TestKeyringEndpoint_ExportImport exercises moving a root key between
+// clusters with a passphrase-sealed bundle
+func TestKeyringEndpoint_ExportImport(t *testing.T) {
+
+	ci.Parallel(t)
+	srv1, rootToken1, shutdown1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown1()
+	testutil.WaitForLeader(t, srv1.RPC)
+	codec1 := rpcClient(t, srv1)
+
+	srv2, rootToken2, shutdown2 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown2()
+	testutil.WaitForLeader(t, srv2.RPC)
+	codec2 := rpcClient(t, srv2)
+
+	activeKey, err := srv1.State().GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+	keyID := activeKey.KeyID
+	const passphrase = "correct horse battery staple"
+
+	exportReq := &structs.KeyringExportRootKeyRequest{
+		KeyID:        keyID,
+		Passphrase:   passphrase,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var exportResp structs.KeyringExportRootKeyResponse
+
+	// operator write alone isn't enough to export the key
+	writeToken := mock.CreatePolicyAndToken(t, srv1.State(), 1000, "operator-write",
+		`operator { policy = "write" }`)
+	exportReq.AuthToken = writeToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec1, "Keyring.Export", exportReq, &exportResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	transferToken := mock.CreatePolicyAndToken(t, srv1.State(), 1010, "keyring-transfer",
+		`operator { capabilities = ["keyring-transfer"] }`)
+	exportReq.AuthToken = transferToken.SecretID
+
+	exportReq.Passphrase = "too short"
+	err = msgpackrpc.CallWithCodec(codec1, "Keyring.Export", exportReq, &exportResp)
+	require.ErrorContains(t, err, "passphrase must be at least")
+
+	exportReq.Passphrase = passphrase
+	err = msgpackrpc.CallWithCodec(codec1, "Keyring.Export", exportReq, &exportResp)
+	require.NoError(t, err)
+	bundle := exportResp.Bundle
+	require.NotNil(t, bundle)
+	require.Equal(t, keyID, bundle.KeyID)
+
+	key, err := srv1.encrypter.GetKey(keyID)
+	require.NoError(t, err)
+	require.NotContains(t, string(bundle.Ciphertext), string(key))
+
+	// import into the other cluster
+	importReq := &structs.KeyringImportRootKeyRequest{
+		Bundle:     bundle,
+		Passphrase: "not the right passphrase",
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: rootToken2.SecretID,
+		},
+	}
+	var importResp structs.KeyringImportRootKeyResponse
+	err = msgpackrpc.CallWithCodec(codec2, "Keyring.Import", importReq, &importResp)
+	require.ErrorContains(t, err, "invalid passphrase")
+
+	// the bundle can't be installed under another key ID
+	importReq.Passphrase = passphrase
+	otherBundle := *bundle
+	otherBundle.KeyID = uuid.Generate()
+	importReq.Bundle = &otherBundle
+	err = msgpackrpc.CallWithCodec(codec2, "Keyring.Import", importReq, &importResp)
+	require.ErrorContains(t, err, "invalid passphrase")
+
+	importReq.Bundle = bundle
+	err = msgpackrpc.CallWithCodec(codec2, "Keyring.Import", importReq, &importResp)
+	require.NoError(t, err)
+	require.NotNil(t, importResp.Key)
+	require.Equal(t, keyID, importResp.Key.KeyID)
+	require.Equal(t, structs.RootKeyStateInactive, importResp.Key.State)
+
+	imported, err := srv2.encrypter.GetKey(keyID)
+	require.NoError(t, err)
+	require.Equal(t, key, imported)
+
+	// importing a key that's already known keeps its state
+	importReq.AuthToken = rootToken1.SecretID
+	err = msgpackrpc.CallWithCodec(codec1, "Keyring.Import", importReq, &importResp)
+	require.NoError(t, err)
+	require.True(t, importResp.Key.Active())
+
+	// but it can't replace the key material of a known key ID
+	forged, err := structs.NewRootKey(structs.EncryptionAlgorithmAES256GCM)
+	require.NoError(t, err)
+	forged.Meta.KeyID = keyID
+	importReq.Bundle, err = sealRootKey(forged, passphrase)
+	require.NoError(t, err)
+	err = msgpackrpc.CallWithCodec(codec1, "Keyring.Import", importReq, &importResp)
+	require.ErrorContains(t, err, "already exists with different key material")
+
+	stillKey, err := srv1.encrypter.GetKey(keyID)
+	require.NoError(t, err)
+	require.Equal(t, key, stillKey)
+}
+
+func TestKeyringEndpoint_Verify(t *testing.T) {
+
+	ci.Parallel(t)
+	srv, rootToken, shutdown := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+
+	writeVar := func(path string) {
+		sv := mock.SecureVariable()
+		sv.Path = path
+		applyReq := structs.SecureVariablesApplyRequest{
+			Op:  structs.SVOpSet,
+			Var: sv,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				AuthToken: rootToken.SecretID,
+			},
+		}
+		var applyResp structs.SecureVariablesApplyResponse
+		err := msgpackrpc.CallWithCodec(codec, structs.SecureVariablesApplyRPCMethod, &applyReq, &applyResp)
+		require.NoError(t, err)
+	}
+	verify := func() *structs.KeyringVerifyResponse {
+		verifyReq := &structs.KeyringVerifyRequest{
+			QueryOptions: structs.QueryOptions{
+				Region:    "global",
+				AuthToken: rootToken.SecretID,
+			},
+		}
+		var verifyResp structs.KeyringVerifyResponse
+		err := msgpackrpc.CallWithCodec(codec, "Keyring.Verify", verifyReq, &verifyResp)
+		require.NoError(t, err)
+		return &verifyResp
+	}
+
+	// Write secure variables with the bootstrap key
+	writeVar("verify/a")
+	writeVar("verify/b")
+
+	resp := verify()
+	require.Equal(t, 2, resp.Variables)
+	require.Empty(t, resp.Orphaned)
+	require.NotZero(t, resp.Index)
+
+	// Verifying requires a management token
+	verifyReq := &structs.KeyringVerifyRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	err := msgpackrpc.CallWithCodec(codec, "Keyring.Verify", verifyReq, &structs.KeyringVerifyResponse{})
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Rotate without re-encrypting the existing variables, then delete the
+	// key that encrypted them
+	listReq := &structs.KeyringListRootKeyMetaRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: rootToken.SecretID,
+		},
+	}
+	var listResp structs.KeyringListRootKeyMetaResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Keyring.List", listReq, &listResp))
+	require.Len(t, listResp.Keys, 1)
+	oldKeyID := listResp.Keys[0].KeyID
+
+	rotateReq := &structs.KeyringRotateRootKeyRequest{
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: rootToken.SecretID,
+		},
+	}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Keyring.Rotate", rotateReq, &structs.KeyringRotateRootKeyResponse{}))
+	writeVar("verify/c")
+
+	deleteReq := &structs.KeyringDeleteRootKeyRequest{
+		KeyID: oldKeyID,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: rootToken.SecretID,
+		},
+	}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Keyring.Delete", deleteReq, &structs.KeyringDeleteRootKeyResponse{}))
+
+	resp = verify()
+	require.Equal(t, 3, resp.Variables)
+	require.Len(t, resp.Orphaned, 2)
+	for _, orphan := range resp.Orphaned {
+		require.Contains(t, []string{"verify/a", "verify/b"}, orphan.Path)
+		require.Equal(t, oldKeyID, orphan.KeyID)
+		require.Contains(t, orphan.Error, "has been deleted")
+	}
+}
+
+func TestKeyringEndpoint_Health(t *testing.T) {
+
+	ci.Parallel(t)
+	srv, rootToken, shutdown := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.SecureVariablesIntegrityInterval = 50 * time.Millisecond
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+
+	// Reporting health requires a management token
+	healthReq := &structs.KeyringHealthRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	err := msgpackrpc.CallWithCodec(codec, "Keyring.Health", healthReq, &structs.KeyringHealthResponse{})
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Write a secure variable whose ciphertext has been corrupted
+	data, keyID, err := srv.encrypter.Encrypt([]byte("health"))
+	require.NoError(t, err)
+	data[len(data)-1] ^= 0xff
+	sv := mock.SecureVariableEncrypted()
+	sv.Path = "health/a"
+	sv.KeyID = keyID
+	sv.Data = data
+	setResp := srv.fsm.State().SVESet(1000, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpSet,
+		Var: sv,
+	})
+	require.NoError(t, setResp.Error)
+
+	healthReq.AuthToken = rootToken.SecretID
+	var healthResp structs.KeyringHealthResponse
+	testutil.WaitForResult(func() (bool, error) {
+		healthResp = structs.KeyringHealthResponse{}
+		err := msgpackrpc.CallWithCodec(codec, "Keyring.Health", healthReq, &healthResp)
+		if err != nil {
+			return false, err
+		}
+		return !healthResp.Healthy, fmt.Errorf("expected leader to report the corrupted variable")
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+	require.NotZero(t, healthResp.LastCheck)
+	require.NotZero(t, healthResp.Checked)
+	require.NotZero(t, healthResp.Index)
+	require.Len(t, healthResp.Failed, 1)
+	require.Equal(t, "health/a", healthResp.Failed[0].Path)
+	require.Equal(t, keyID, healthResp.Failed[0].KeyID)
+}
+
+// TestKeyringEndpoint_JobSigningKeys exercises the job signing key operations
+func TestKeyringEndpoint_JobSigningKeys(t *testing.T) {
+	ci.Parallel(t)
+	srv, rootToken, shutdown := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keyID := structs.JobSigningKeyID(publicKey)
+
+	// Upsert a key, the key ID is derived from the public key
+	upsertReq := &structs.KeyringUpsertJobSigningKeyRequest{
+		Key:          &structs.JobSigningKey{Name: "ci", PublicKey: publicKey},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var upsertResp structs.KeyringUpsertJobSigningKeyResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.JobSigningKeyUpsertRPCMethod, upsertReq, &upsertResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	upsertReq.AuthToken = rootToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, structs.JobSigningKeyUpsertRPCMethod, upsertReq, &upsertResp)
+	require.NoError(t, err)
+	require.NotZero(t, upsertResp.Index)
+	require.Equal(t, keyID, upsertResp.Key.KeyID)
+	require.NotZero(t, upsertResp.Key.CreateTime)
+
+	// Invalid keys are rejected
+	invalidReq := &structs.KeyringUpsertJobSigningKeyRequest{
+		Key: &structs.JobSigningKey{PublicKey: []byte("invalid")},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: rootToken.SecretID,
+		},
+	}
+	err = msgpackrpc.CallWithCodec(codec, structs.JobSigningKeyUpsertRPCMethod, invalidReq, &upsertResp)
+	require.ErrorContains(t, err, "must be an ed25519 public key")
+
+	// List the keys
+	listReq := &structs.KeyringListJobSigningKeysRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: rootToken.SecretID,
+		},
+	}
+	var listResp structs.KeyringListJobSigningKeysResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.JobSigningKeyListRPCMethod, listReq, &listResp)
+	require.NoError(t, err)
+	require.Len(t, listResp.Keys, 1)
+	require.Equal(t, "ci", listResp.Keys[0].Name)
+	require.Equal(t, upsertResp.Index, listResp.Index)
+
+	// Delete the key
+	delReq := &structs.KeyringDeleteJobSigningKeyRequest{
+		KeyID: keyID,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: rootToken.SecretID,
+		},
+	}
+	var delResp structs.KeyringDeleteJobSigningKeyResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.JobSigningKeyDeleteRPCMethod, delReq, &delResp)
+	require.NoError(t, err)
+	require.Greater(t, delResp.Index, upsertResp.Index)
+
+	err = msgpackrpc.CallWithCodec(codec, structs.JobSigningKeyListRPCMethod, listReq, &listResp)
+	require.NoError(t, err)
+	require.Empty(t, listResp.Keys)
+}
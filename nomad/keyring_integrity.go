@@ -0,0 +1,177 @@
+package nomad
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// keyringIntegrity tracks the results of the leader's periodic integrity
+// checks of the encrypted secure variables. It is reset whenever the server
+// is elected, so the results only cover the current leadership term.
+type keyringIntegrity struct {
+	lastCheck int64
+	checked   int
+
+	// failed are the secure variables which failed to decrypt. They are
+	// checked again on every pass so that a variable which has since been
+	// rewritten or deleted no longer counts as a failure.
+	failed map[structs.NamespacedID]*structs.KeyringOrphanedVariable
+
+	lock sync.Mutex
+}
+
+func newKeyringIntegrity() *keyringIntegrity {
+	return &keyringIntegrity{
+		failed: map[structs.NamespacedID]*structs.KeyringOrphanedVariable{},
+	}
+}
+
+func (ki *keyringIntegrity) reset() {
+	ki.lock.Lock()
+	defer ki.lock.Unlock()
+	ki.lastCheck = 0
+	ki.checked = 0
+	ki.failed = map[structs.NamespacedID]*structs.KeyringOrphanedVariable{}
+}
+
+// health copies the current results into the reply.
+func (ki *keyringIntegrity) health(reply *structs.KeyringHealthResponse) {
+	ki.lock.Lock()
+	defer ki.lock.Unlock()
+
+	reply.Healthy = len(ki.failed) == 0
+	reply.LastCheck = ki.lastCheck
+	reply.Checked = ki.checked
+	reply.Failed = make([]*structs.KeyringOrphanedVariable, 0, len(ki.failed))
+	for _, failed := range ki.failed {
+		f := *failed
+		reply.Failed = append(reply.Failed, &f)
+	}
+	sort.Slice(reply.Failed, func(i, j int) bool {
+		if reply.Failed[i].Namespace != reply.Failed[j].Namespace {
+			return reply.Failed[i].Namespace < reply.Failed[j].Namespace
+		}
+		return reply.Failed[i].Path < reply.Failed[j].Path
+	})
+}
+
+// check decrypts a random sample of size secure variables, plus every
+// variable which failed a previous check, and records those that fail to
+// decrypt. Decryption authenticates the ciphertext, so this detects
+// variables that have been corrupted at rest as well as those whose key is
+// missing from the keyring.
+func (ki *keyringIntegrity) check(snap *state.StateSnapshot, encrypter *Encrypter, size int) error {
+	ki.lock.Lock()
+	defer ki.lock.Unlock()
+
+	sample := []*structs.SecureVariableEncrypted{}
+	rechecked := map[structs.NamespacedID]struct{}{}
+	for id := range ki.failed {
+		sv, err := snap.GetSecureVariable(nil, id.Namespace, id.ID)
+		if err != nil {
+			return err
+		}
+		if sv != nil {
+			sample = append(sample, sv)
+			rechecked[id] = struct{}{}
+		}
+	}
+	previous := len(sample)
+
+	iter, err := snap.SecureVariables(nil)
+	if err != nil {
+		return err
+	}
+	seen := 0
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		sv := raw.(*structs.SecureVariableEncrypted)
+		if _, ok := rechecked[structs.NamespacedID{Namespace: sv.Namespace, ID: sv.Path}]; ok {
+			continue
+		}
+
+		// reservoir sampling, so that every variable is equally likely to
+		// be checked without loading them all
+		seen++
+		if seen <= size {
+			sample = append(sample, sv)
+		} else if i := rand.Intn(seen); i < size {
+			sample[previous+i] = sv
+		}
+	}
+
+	failed := map[structs.NamespacedID]*structs.KeyringOrphanedVariable{}
+	for _, sv := range sample {
+		var verr error
+		keyMeta, err := snap.RootKeyMetaByID(nil, sv.KeyID)
+		switch {
+		case err != nil:
+			return err
+		case keyMeta == nil:
+			verr = fmt.Errorf("root key %s has been deleted", sv.KeyID)
+		default:
+			verr = encrypter.Authenticate(sv.Data, sv.KeyID)
+		}
+		if verr != nil {
+			failed[structs.NamespacedID{Namespace: sv.Namespace, ID: sv.Path}] =
+				&structs.KeyringOrphanedVariable{
+					Namespace: sv.Namespace,
+					Path:      sv.Path,
+					KeyID:     sv.KeyID,
+					Error:     verr.Error(),
+				}
+		}
+	}
+
+	ki.failed = failed
+	ki.lastCheck = time.Now().UnixNano()
+	ki.checked += len(sample)
+
+	metrics.IncrCounter([]string{"keyring", "integrity", "checked"}, float32(len(sample)))
+	metrics.SetGauge([]string{"keyring", "integrity", "failures"}, float32(len(ki.failed)))
+	return nil
+}
+
+// checkKeyringIntegrity is a long lived function that periodically samples
+// secure variables to check their integrity until the server loses
+// leadership.
+func (s *Server) checkKeyringIntegrity(stopCh chan struct{}) {
+	s.keyringIntegrity.reset()
+
+	timer := time.NewTimer(s.config.SecureVariablesIntegrityInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-timer.C:
+			timer.Reset(s.config.SecureVariablesIntegrityInterval)
+			snap, err := s.fsm.State().Snapshot()
+			if err != nil {
+				s.logger.Error("failed to get state", "error", err)
+				continue
+			}
+			err = s.keyringIntegrity.check(snap, s.encrypter,
+				s.config.SecureVariablesIntegritySampleSize)
+			if err != nil {
+				s.logger.Error("failed to check secure variables integrity", "error", err)
+				continue
+			}
+
+			var reply structs.KeyringHealthResponse
+			s.keyringIntegrity.health(&reply)
+			for _, failed := range reply.Failed {
+				s.logger.Error("secure variable failed integrity check",
+					"namespace", failed.Namespace, "path", failed.Path,
+					"key_id", failed.KeyID, "error", failed.Error)
+			}
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package nomad
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyringIntegrity_Check(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+
+	store := srv.fsm.State()
+	index := uint64(1000)
+	writeVar := func(path string, corrupt bool) {
+		data, keyID, err := srv.encrypter.Encrypt([]byte(path))
+		require.NoError(t, err)
+		if corrupt {
+			data[len(data)-1] ^= 0xff
+		}
+		sv := mock.SecureVariableEncrypted()
+		sv.Path = path
+		sv.KeyID = keyID
+		sv.Data = data
+		index++
+		resp := store.SVESet(index, &structs.SVApplyStateRequest{
+			Op:  structs.SVOpSet,
+			Var: sv,
+		})
+		require.NoError(t, resp.Error)
+	}
+	health := func(ki *keyringIntegrity, size int) *structs.KeyringHealthResponse {
+		snap, err := store.Snapshot()
+		require.NoError(t, err)
+		require.NoError(t, ki.check(snap, srv.encrypter, size))
+		var resp structs.KeyringHealthResponse
+		ki.health(&resp)
+		return &resp
+	}
+
+	for i := 0; i < 3; i++ {
+		writeVar(fmt.Sprintf("integrity/%d", i), false)
+	}
+	writeVar("integrity/corrupt", true)
+
+	ki := newKeyringIntegrity()
+	resp := health(ki, 10)
+	require.False(t, resp.Healthy)
+	require.NotZero(t, resp.LastCheck)
+	require.Equal(t, 4, resp.Checked)
+	require.Len(t, resp.Failed, 1)
+	require.Equal(t, "integrity/corrupt", resp.Failed[0].Path)
+	require.Contains(t, resp.Failed[0].Error, "message authentication failed")
+
+	// Failed variables are checked on every pass in addition to the sample
+	resp = health(ki, 1)
+	require.False(t, resp.Healthy)
+	require.Equal(t, 6, resp.Checked)
+	require.Len(t, resp.Failed, 1)
+
+	// Rewriting the variable clears the failure
+	writeVar("integrity/corrupt", false)
+	resp = health(ki, 0)
+	require.True(t, resp.Healthy)
+	require.Equal(t, 7, resp.Checked)
+	require.Empty(t, resp.Failed)
+
+	// Results are reset when the server is elected
+	ki.reset()
+	var reset structs.KeyringHealthResponse
+	ki.health(&reset)
+	require.True(t, reset.Healthy)
+	require.Zero(t, reset.LastCheck)
+	require.Zero(t, reset.Checked)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/raft"
 	"github.com/hashicorp/serf/serf"
+	"golang.org/x/exp/slices"
 	"golang.org/x/time/rate"
 )
 
@@ -365,6 +366,16 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	// Periodically publish job status metrics
 	go s.publishJobStatusMetrics(stopCh)
 
+	// Periodically check the integrity of encrypted secure variables
+	go s.checkKeyringIntegrity(stopCh)
+
+	// Restart the batches of the running job restarts
+	go s.runJobRestarts(stopCh)
+
+	// Replicate the secure variables of the namespaces that target this
+	// region from the other regions
+	go s.replicateSecureVariables(stopCh)
+
 	// Setup the heartbeat timers. This is done both when starting up or when
 	// a leader fail over happens. Since the timers are maintained by the leader
 	// node, effectively this means all the timers are renewed at the time of failover.
@@ -1717,10 +1728,15 @@ func (s *Server) initializeKeyring() error {
 	s.logger.Named("core").Trace("initializing keyring")
 
 	rootKey, err := structs.NewRootKey(structs.EncryptionAlgorithmAES256GCM)
-	rootKey.Meta.SetActive()
 	if err != nil {
 		return fmt.Errorf("could not initialize keyring: %v", err)
 	}
+	rootKey.Meta.SetActive()
+
+	// Use the key material provided by the operator, if any
+	if len(s.config.InitialRootKey) > 0 {
+		rootKey.Key = slices.Clone(s.config.InitialRootKey)
+	}
 
 	err = s.encrypter.AddKey(rootKey)
 	if err != nil {
@@ -45,6 +45,11 @@ const (
 	// ineligible
 	NodeEligibilityEventIneligible = "Node marked as ineligible for scheduling"
 
+	// NodePlannedDisconnectEventSet and NodePlannedDisconnectEventCanceled
+	// are used when a disconnect of the node is planned or canceled
+	NodePlannedDisconnectEventSet      = "Node disconnect planned"
+	NodePlannedDisconnectEventCanceled = "Node planned disconnect canceled"
+
 	// NodeHeartbeatEventReregistered is the message used when the node becomes
 	// reregistered by the heartbeat.
 	NodeHeartbeatEventReregistered = "Node reregistered by heartbeat"
@@ -332,6 +337,72 @@ func (n *Node) BatchDeregister(args *structs.NodeBatchDeregisterRequest, reply *
 	})
 }
 
+// PurgeDown is used to purge every node that has been down for longer than
+// the requested duration, so that clusters don't accumulate nodes that will
+// never return.
+func (n *Node) PurgeDown(args *structs.NodePurgeDownRequest, reply *structs.NodePurgeDownResponse) error {
+	if done, err := n.srv.forward("Node.PurgeDown", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client", "purge_down"}, time.Now())
+
+	if aclObj, err := n.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.DownFor < 0 {
+		return fmt.Errorf("down duration must be non-negative")
+	}
+
+	snap, err := n.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	iter, err := snap.Nodes(nil)
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-args.DownFor)
+	var nodeIDs []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if node.DownBefore(cutoff) {
+			nodeIDs = append(nodeIDs, node.ID)
+		}
+	}
+
+	reply.NodeIDs = nodeIDs
+	if len(nodeIDs) == 0 {
+		reply.Index, err = snap.Index("nodes")
+		return err
+	}
+
+	for _, ids := range partitionAll(structs.MaxUUIDsPerWriteRequest, nodeIDs) {
+		req := &structs.NodeBatchDeregisterRequest{
+			NodeIDs:      ids,
+			WriteRequest: args.WriteRequest,
+		}
+		var resp structs.NodeUpdateResponse
+		err := n.deregister(req, &resp, func() (interface{}, uint64, error) {
+			return n.srv.raftApply(structs.NodeBatchDeregisterRequestType, req)
+		})
+		if err != nil {
+			return err
+		}
+		reply.EvalIDs = append(reply.EvalIDs, resp.EvalIDs...)
+		if reply.EvalCreateIndex == 0 {
+			reply.EvalCreateIndex = resp.EvalCreateIndex
+		}
+		reply.Index = resp.Index
+	}
+
+	n.logger.Info("purged down nodes", "nodes", len(nodeIDs), "down_for", args.DownFor)
+	return nil
+}
+
 // deregister takes a raftMessage closure, to support both Deregister and BatchDeregister
 func (n *Node) deregister(args *structs.NodeBatchDeregisterRequest,
 	reply *structs.NodeUpdateResponse,
@@ -468,6 +539,21 @@ func (n *Node) UpdateStatus(args *structs.NodeUpdateStatusRequest, reply *struct
 	// XXX: Could use the SecretID here but have to update the heartbeat system
 	// to track SecretIDs.
 
+	// Defer the reconnection of the node if too many nodes are reconnecting
+	// at once, such as after a partition heals, so that the reconciliation
+	// of their allocations is spread over time.
+	if nodeStatusTransitionIsReconnect(args.Status, node.Status) {
+		if window := n.srv.reconnectWindow(args.NodeID, time.Now()); window > 0 {
+			metrics.IncrCounter([]string{"nomad", "client", "reconnect_deferred"}, 1)
+			n.logger.Debug("deferring node reconnect", "node_id", args.NodeID, "window", window)
+			reply.ReconnectWindow = window
+
+			n.srv.peerLock.RLock()
+			defer n.srv.peerLock.RUnlock()
+			return n.constructNodeServerInfoResponse(snap, reply)
+		}
+	}
+
 	// Update the timestamp of when the node status was updated
 	args.UpdatedAt = time.Now().Unix()
 
@@ -562,6 +648,10 @@ func (n *Node) UpdateStatus(args *structs.NodeUpdateStatusRequest, reply *struct
 
 	// Set the reply index and leader
 	reply.Index = index
+	if node.Status == args.Status {
+		// The planned disconnect is cleared when the status changes
+		reply.PlannedDisconnect = node.PlannedDisconnect
+	}
 	n.srv.peerLock.RLock()
 	defer n.srv.peerLock.RUnlock()
 	if err := n.constructNodeServerInfoResponse(snap, reply); err != nil {
@@ -582,6 +672,13 @@ func nodeStatusTransitionRequiresEval(newStatus, oldStatus string) bool {
 	return initToReady || terminalToReady || disconnectedToOther || otherToDisconnected
 }
 
+// nodeStatusTransitionIsReconnect returns true if a node that was
+// disconnected or down becomes ready again.
+func nodeStatusTransitionIsReconnect(newStatus, oldStatus string) bool {
+	return newStatus == structs.NodeStatusReady &&
+		(oldStatus == structs.NodeStatusDisconnected || oldStatus == structs.NodeStatusDown)
+}
+
 // UpdateDrain is used to update the drain mode of a client node
 func (n *Node) UpdateDrain(args *structs.NodeUpdateDrainRequest,
 	reply *structs.NodeDrainUpdateResponse) error {
@@ -675,6 +772,89 @@ func (n *Node) UpdateDrain(args *structs.NodeUpdateDrainRequest,
 	return nil
 }
 
+// UpdatePlannedDisconnect is used to plan a disconnect of a node, for example
+// for maintenance. The allocations running on the node are marked unknown
+// instead of lost if the node disconnects before the deadline, so that they
+// can resume when it reconnects.
+func (n *Node) UpdatePlannedDisconnect(args *structs.NodeUpdatePlannedDisconnectRequest,
+	reply *structs.GenericResponse) error {
+	if done, err := n.srv.forward("Node.UpdatePlannedDisconnect", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client", "update_planned_disconnect"}, time.Now())
+
+	// Check node write permissions
+	if aclObj, err := n.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Verify the arguments
+	if args.NodeID == "" {
+		return fmt.Errorf("missing node ID for planning a disconnect")
+	}
+	if args.Duration < 0 {
+		return fmt.Errorf("planned disconnect duration cannot be negative")
+	}
+	if args.NodeEvent != nil || args.PlannedDisconnect != nil {
+		return fmt.Errorf("node event and planned disconnect must not be set")
+	}
+
+	// Look for the node
+	snap, err := n.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	node, err := snap.NodeByID(nil, args.NodeID)
+	if err != nil {
+		return err
+	}
+	if node == nil {
+		return fmt.Errorf("node not found")
+	}
+
+	now := time.Now().UTC()
+	args.UpdatedAt = now.Unix()
+	args.NodeEvent = structs.NewNodeEvent().SetSubsystem(structs.NodeEventSubsystemCluster)
+
+	if args.Duration == 0 {
+		if node.PlannedDisconnect == nil {
+			return nil // Nothing to do
+		}
+		args.NodeEvent.SetMessage(NodePlannedDisconnectEventCanceled)
+	} else {
+		// Only a connected node can be planned to disconnect, otherwise its
+		// allocations may already be lost
+		if node.Status != structs.NodeStatusReady {
+			return fmt.Errorf("can not plan a disconnect of node with status %q", node.Status)
+		}
+		args.PlannedDisconnect = &structs.PlannedDisconnect{
+			StartedAt: now,
+			Deadline:  now.Add(args.Duration),
+		}
+		args.NodeEvent.SetMessage(NodePlannedDisconnectEventSet).
+			AddDetail("deadline", args.PlannedDisconnect.Deadline.Format(time.RFC3339))
+	}
+
+	// Commit this update via Raft
+	outErr, index, err := n.srv.raftApply(structs.NodeUpdatePlannedDisconnectRequestType, args)
+	if err != nil {
+		n.logger.Error("planned disconnect update failed", "error", err)
+		return err
+	}
+	if outErr != nil {
+		if err, ok := outErr.(error); ok && err != nil {
+			n.logger.Error("planned disconnect update failed", "error", err)
+			return err
+		}
+	}
+
+	// Set the reply index
+	reply.Index = index
+	return nil
+}
+
 // UpdateEligibility is used to update the scheduling eligibility of a node
 func (n *Node) UpdateEligibility(args *structs.NodeUpdateEligibilityRequest,
 	reply *structs.NodeEligibilityUpdateResponse) error {
@@ -733,13 +913,16 @@ func (n *Node) UpdateEligibility(args *structs.NodeUpdateEligibilityRequest,
 
 	// Construct the node event
 	args.NodeEvent = structs.NewNodeEvent().SetSubsystem(structs.NodeEventSubsystemCluster)
-	if node.SchedulingEligibility == args.Eligibility {
+	if node.SchedulingEligibility == args.Eligibility && node.EligibilityReason == args.Reason {
 		return nil // Nothing to do
 	} else if args.Eligibility == structs.NodeSchedulingEligible {
 		args.NodeEvent.SetMessage(NodeEligibilityEventEligible)
 	} else {
 		args.NodeEvent.SetMessage(NodeEligibilityEventIneligible)
 	}
+	if args.Reason != "" {
+		args.NodeEvent.AddDetail("reason", args.Reason)
+	}
 
 	// Commit this update via Raft
 	outErr, index, err := n.srv.raftApply(structs.NodeUpdateEligibilityRequestType, args)
@@ -1430,6 +1613,7 @@ func (n *Node) List(args *structs.NodeListRequest,
 			// Populate the reply.
 			reply.Nodes = nodes
 			reply.NextToken = nextToken
+			reply.ObjectsScanned = paginatorImpl.Scanned()
 
 			// Use the last index that affected the jobs table
 			index, err := state.Index("nodes")
@@ -1475,7 +1659,7 @@ func (n *Node) createNodeEvals(node *structs.Node, nodeIndex uint64) ([]string,
 		// here, but datacenter is a good optimization to start with as
 		// datacenter cardinality tends to be low so the check
 		// shouldn't add much work.
-		for _, dc := range job.Datacenters {
+		for _, dc := range job.DatacenterNames() {
 			if dc == node.Datacenter {
 				sysJobs = append(sysJobs, job)
 				break
@@ -323,6 +323,68 @@ func TestClientEndpoint_Deregister_ACL(t *testing.T) {
 	}
 }
 
+func TestClientEndpoint_PurgeDown(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	now := time.Now()
+	state := s1.fsm.State()
+
+	// A node down for a week, one down for an hour, and one that's ready
+	stale := mock.Node()
+	stale.Status = structs.NodeStatusDown
+	stale.StatusUpdatedAt = now.Add(-7 * 24 * time.Hour).Unix()
+	recent := mock.Node()
+	recent.Status = structs.NodeStatusDown
+	recent.StatusUpdatedAt = now.Add(-time.Hour).Unix()
+	ready := mock.Node()
+	ready.StatusUpdatedAt = stale.StatusUpdatedAt
+	for i, node := range []*structs.Node{stale, recent, ready} {
+		require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, uint64(10+i), node))
+	}
+
+	readToken := mock.CreatePolicyAndToken(t, state, 1001, "node-read", mock.NodePolicy(acl.PolicyRead))
+
+	req := &structs.NodePurgeDownRequest{
+		DownFor: 24 * time.Hour,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var resp structs.NodePurgeDownResponse
+	err := msgpackrpc.CallWithCodec(codec, "Node.PurgeDown", req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	req.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.PurgeDown", req, &resp))
+	require.Equal(t, []string{stale.ID}, resp.NodeIDs)
+	require.NotZero(t, resp.Index)
+
+	out, err := state.NodeByID(nil, stale.ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+	for _, id := range []string{recent.ID, ready.ID} {
+		out, err := state.NodeByID(nil, id)
+		require.NoError(t, err)
+		require.NotNil(t, out)
+	}
+
+	// A zero duration purges every down node
+	req.DownFor = 0
+	resp = structs.NodePurgeDownResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.PurgeDown", req, &resp))
+	require.Equal(t, []string{recent.ID}, resp.NodeIDs)
+
+	out, err = state.NodeByID(nil, ready.ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+}
+
 func TestClientEndpoint_Deregister_Vault(t *testing.T) {
 	ci.Parallel(t)
 
@@ -465,6 +527,58 @@ func TestClientEndpoint_UpdateStatus(t *testing.T) {
 	})
 }
 
+func TestClientEndpoint_UpdateStatus_ReconnectWindow(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.MaxReconnectsPerSecond = 0.001
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create two disconnected nodes
+	state := s1.fsm.State()
+	node1, node2 := mock.Node(), mock.Node()
+	node1.Status = structs.NodeStatusDisconnected
+	node2.Status = structs.NodeStatusDown
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node1))
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1001, node2))
+
+	updateStatus := func(nodeID string) *structs.NodeUpdateResponse {
+		req := &structs.NodeUpdateStatusRequest{
+			NodeID:       nodeID,
+			Status:       structs.NodeStatusReady,
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}
+		var resp structs.NodeUpdateResponse
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateStatus", req, &resp))
+		return &resp
+	}
+
+	// The first node reconnects
+	resp := updateStatus(node1.ID)
+	require.Zero(t, resp.ReconnectWindow)
+	require.NotZero(t, resp.HeartbeatTTL)
+	out, err := state.NodeByID(nil, node1.ID)
+	require.NoError(t, err)
+	require.Equal(t, structs.NodeStatusReady, out.Status)
+
+	// The second node is told to wait, and its status isn't updated
+	resp = updateStatus(node2.ID)
+	require.Greater(t, resp.ReconnectWindow, time.Duration(0))
+	require.Zero(t, resp.HeartbeatTTL)
+	require.Empty(t, resp.EvalIDs)
+	require.NotEmpty(t, resp.Servers)
+	out, err = state.NodeByID(nil, node2.ID)
+	require.NoError(t, err)
+	require.Equal(t, structs.NodeStatusDown, out.Status)
+
+	// Heartbeats of connected nodes aren't limited
+	resp = updateStatus(node1.ID)
+	require.Zero(t, resp.ReconnectWindow)
+}
+
 func TestClientEndpoint_UpdateStatus_Vault(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1423,6 +1537,83 @@ func TestClientEndpoint_Drain_Down(t *testing.T) {
 	})
 }
 
+func TestClientEndpoint_UpdatePlannedDisconnect(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create the register request
+	node := mock.Node()
+	reg := &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+
+	// Fetch the response
+	var resp structs.NodeUpdateResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.Register", reg, &resp))
+
+	// Mark the node as ready and place an allocation on it
+	state := s1.fsm.State()
+	require.NoError(t, state.UpdateNodeStatus(structs.MsgTypeTestSetup, 100, node.ID, structs.NodeStatusReady, 0, nil))
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 101, []*structs.Allocation{alloc}))
+
+	// Plan the disconnect
+	req := &structs.NodeUpdatePlannedDisconnectRequest{
+		NodeID:       node.ID,
+		Duration:     30 * time.Minute,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp2 structs.GenericResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdatePlannedDisconnect", req, &resp2))
+	require.NotZero(t, resp2.Index)
+
+	out, err := state.NodeByID(nil, node.ID)
+	require.NoError(t, err)
+	require.NotNil(t, out.PlannedDisconnect)
+	require.WithinDuration(t, time.Now().Add(30*time.Minute), out.PlannedDisconnect.Deadline, time.Minute)
+	require.Equal(t, NodePlannedDisconnectEventSet, out.Events[len(out.Events)-1].Message)
+
+	outAlloc, err := state.AllocByID(nil, alloc.ID)
+	require.NoError(t, err)
+	require.Equal(t, out.PlannedDisconnect.Deadline, outAlloc.PlannedDisconnectDeadline)
+
+	// The client learns about the planned disconnect from its heartbeats
+	hb := &structs.NodeUpdateStatusRequest{
+		NodeID:       node.ID,
+		Status:       structs.NodeStatusReady,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var hbResp structs.NodeUpdateResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateStatus", hb, &hbResp))
+	require.NotNil(t, hbResp.PlannedDisconnect)
+	require.True(t, out.PlannedDisconnect.Deadline.Equal(hbResp.PlannedDisconnect.Deadline))
+
+	// Cancel the planned disconnect
+	req.Duration = 0
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdatePlannedDisconnect", req, &resp2))
+
+	hbResp = structs.NodeUpdateResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateStatus", hb, &hbResp))
+	require.Nil(t, hbResp.PlannedDisconnect)
+
+	out, err = state.NodeByID(nil, node.ID)
+	require.NoError(t, err)
+	require.Nil(t, out.PlannedDisconnect)
+	require.Equal(t, NodePlannedDisconnectEventCanceled, out.Events[len(out.Events)-1].Message)
+
+	// A node that isn't ready can't be planned to disconnect
+	require.NoError(t, state.UpdateNodeStatus(structs.MsgTypeTestSetup, 200, node.ID, structs.NodeStatusDown, 0, nil))
+	req.Duration = time.Minute
+	err = msgpackrpc.CallWithCodec(codec, "Node.UpdatePlannedDisconnect", req, &resp2)
+	require.ErrorContains(t, err, "can not plan a disconnect")
+}
+
 func TestClientEndpoint_UpdateEligibility(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -1481,6 +1672,58 @@ func TestClientEndpoint_UpdateEligibility(t *testing.T) {
 	require.Equal(NodeEligibilityEventEligible, out.Events[2].Message)
 }
 
+func TestClientEndpoint_UpdateEligibility_Reason(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	node := mock.Node()
+	reg := &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.NodeUpdateResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.Register", reg, &resp))
+
+	// Mark the node ineligible with a reason
+	elig := &structs.NodeUpdateEligibilityRequest{
+		NodeID:       node.ID,
+		Eligibility:  structs.NodeSchedulingIneligible,
+		Reason:       "replacing disk",
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp2 structs.NodeEligibilityUpdateResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateEligibility", elig, &resp2))
+
+	out, err := s1.fsm.State().NodeByID(nil, node.ID)
+	require.NoError(t, err)
+	require.Equal(t, "replacing disk", out.EligibilityReason)
+	require.Len(t, out.Events, 2)
+	require.Equal(t, "replacing disk", out.Events[1].Details["reason"])
+
+	// Changing only the reason updates the node
+	elig.Reason = "replacing disk and fan"
+	var resp3 structs.NodeEligibilityUpdateResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateEligibility", elig, &resp3))
+
+	out, err = s1.fsm.State().NodeByID(nil, node.ID)
+	require.NoError(t, err)
+	require.Equal(t, "replacing disk and fan", out.EligibilityReason)
+
+	// Marking the node eligible without a reason clears it
+	elig.Eligibility = structs.NodeSchedulingEligible
+	elig.Reason = ""
+	var resp4 structs.NodeEligibilityUpdateResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateEligibility", elig, &resp4))
+
+	out, err = s1.fsm.State().NodeByID(nil, node.ID)
+	require.NoError(t, err)
+	require.Empty(t, out.EligibilityReason)
+}
+
 func TestClientEndpoint_UpdateEligibility_ACL(t *testing.T) {
 	ci.Parallel(t)
 
@@ -380,6 +380,26 @@ func (op *Operator) SchedulerGetConfiguration(args *structs.GenericRequest, repl
 	return nil
 }
 
+// PlanQueueStats is used to retrieve the load of the leader's plan applier.
+func (op *Operator) PlanQueueStats(args *structs.GenericRequest, reply *structs.PlanQueueStatsResponse) error {
+	if done, err := op.srv.forward("Operator.PlanQueueStats", args, args, reply); done {
+		return err
+	}
+
+	// This action requires operator read access.
+	rule, err := op.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if rule != nil && !rule.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	reply.Stats = op.srv.planner.backpressure.Stats()
+	op.srv.setQueryMeta(&reply.QueryMeta)
+
+	return nil
+}
+
 func (op *Operator) forwardStreamingRPC(region string, method string, args interface{}, in io.ReadWriteCloser) error {
 	server, err := op.srv.findRegionServer(region)
 	if err != nil {
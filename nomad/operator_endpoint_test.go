@@ -977,3 +977,27 @@ func TestOperator_SnapshotRestore_ACL(t *testing.T) {
 		})
 	}
 }
+
+func TestOperator_PlanQueueStats(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.PlanBackpressureEnabled = true
+		c.PlanBackpressureMaxQueueDepth = 10
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	arg := structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: s1.config.Region,
+		},
+	}
+	var reply structs.PlanQueueStatsResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.PlanQueueStats", &arg, &reply))
+	require.NotNil(t, reply.Stats)
+	require.True(t, reply.Stats.BackpressureEnabled)
+	require.Equal(t, 10, reply.Stats.MaxQueueDepth)
+	require.False(t, reply.Stats.Saturated)
+}
@@ -31,6 +31,10 @@ type planner struct {
 	// scheduling, but repeated rejections for the same node may indicate an
 	// undetected issue, so we need to track rejection history.
 	badNodeTracker BadNodeTracker
+
+	// backpressure tracks the load of the plan applier and rejects plans
+	// while it is saturated.
+	backpressure *planBackpressure
 }
 
 // newPlanner returns a new planner to be used for managing allocation plans.
@@ -59,11 +63,18 @@ func newPlanner(s *Server) (*planner, error) {
 		badNodeTracker = &NoopBadNodeTracker{}
 	}
 
+	backpressure := newPlanBackpressure(&planBackpressureConfig{
+		Enabled:         s.config.PlanBackpressureEnabled,
+		MaxQueueDepth:   s.config.PlanBackpressureMaxQueueDepth,
+		MaxApplyLatency: s.config.PlanBackpressureMaxApplyLatency,
+	}, planQueue)
+
 	return &planner{
 		Server:         s,
 		log:            log,
 		planQueue:      planQueue,
 		badNodeTracker: badNodeTracker,
+		backpressure:   backpressure,
 	}, nil
 }
 
@@ -343,6 +354,27 @@ func (p *planner) applyPlan(plan *structs.Plan, result *structs.PlanResult, snap
 			evals = append(evals, eval)
 		}
 	}
+
+	// Create follow up evals for the jobs whose allocations were preempted by
+	// replacements the plan stops because their original allocations
+	// reconnected, so the capacity freed by the replacements is used again.
+	for jobID := range p.reconnectPreemptedJobIDs(result.NodeUpdate, preemptedJobIDs) {
+		job, _ := p.State().JobByID(nil, jobID.Namespace, jobID.ID)
+		if job != nil && !job.Stopped() {
+			eval := &structs.Evaluation{
+				ID:          uuid.Generate(),
+				Namespace:   job.Namespace,
+				TriggeredBy: structs.EvalTriggerReconnectPreemption,
+				JobID:       job.ID,
+				Type:        job.Type,
+				Priority:    job.Priority,
+				Status:      structs.EvalStatusPending,
+				CreateTime:  now,
+				ModifyTime:  now,
+			}
+			evals = append(evals, eval)
+		}
+	}
 	req.PreemptionEvals = evals
 
 	// Dispatch the Raft transaction
@@ -361,6 +393,39 @@ func (p *planner) applyPlan(plan *structs.Plan, result *structs.PlanResult, snap
 	return future, nil
 }
 
+// reconnectPreemptedJobIDs returns the jobs of the allocations preempted by the
+// replacement allocations stopped in the plan because the allocations they
+// replaced reconnected. Jobs which already get a follow up eval for being
+// preempted by the plan are skipped.
+func (p *planner) reconnectPreemptedJobIDs(nodeUpdate map[string][]*structs.Allocation, skip map[structs.NamespacedID]struct{}) map[structs.NamespacedID]struct{} {
+	jobIDs := make(map[structs.NamespacedID]struct{})
+	for _, updateList := range nodeUpdate {
+		for _, stoppedAlloc := range updateList {
+			if stoppedAlloc.DesiredDescription != structs.AllocDesiredDescriptionReconnected {
+				continue
+			}
+
+			// Stopped allocations may be normalized, so the preempted
+			// allocations are read from the state store.
+			alloc, _ := p.State().AllocByID(nil, stoppedAlloc.ID)
+			if alloc == nil {
+				continue
+			}
+			for _, preemptedID := range alloc.PreemptedAllocations {
+				preemptedAlloc, _ := p.State().AllocByID(nil, preemptedID)
+				if preemptedAlloc == nil {
+					continue
+				}
+				id := structs.NamespacedID{Namespace: preemptedAlloc.Namespace, ID: preemptedAlloc.JobID}
+				if _, ok := skip[id]; !ok {
+					jobIDs[id] = struct{}{}
+				}
+			}
+		}
+	}
+	return jobIDs
+}
+
 // normalizePreemptedAlloc removes redundant fields from a preempted allocation and
 // returns AllocationDiff. Since a preempted allocation is always an existing allocation,
 // the struct returned by this method contains only the differential, which can be
@@ -434,11 +499,13 @@ func (p *planner) asyncPlanWait(indexCh chan<- uint64, future raft.ApplyFuture,
 	defer close(indexCh)
 
 	// Wait for the plan to apply
+	start := time.Now()
 	if err := future.Error(); err != nil {
 		p.logger.Error("failed to apply plan", "error", err)
 		pending.respond(nil, err)
 		return
 	}
+	p.backpressure.observeApply(time.Since(start))
 
 	// Respond to the plan
 	index := future.Index()
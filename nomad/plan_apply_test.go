@@ -390,6 +390,81 @@ func TestPlanApply_applyPlanWithNormalizedAllocs(t *testing.T) {
 	assert.Equal(index, evalOut.ModifyIndex)
 }
 
+func TestPlanApply_applyPlan_ReconnectPreemption(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Register the batch jobs whose allocations were preempted by
+	// replacements of allocations on a disconnected node
+	reconnectedJob := mock.BatchJob()
+	otherJob := mock.BatchJob()
+	store := s1.fsm.State()
+	require.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, reconnectedJob))
+	require.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1001, otherJob))
+
+	preempted := mock.BatchAlloc()
+	preempted.Job = reconnectedJob
+	preempted.JobID = reconnectedJob.ID
+	preempted.DesiredStatus = structs.AllocDesiredStatusEvict
+
+	otherPreempted := mock.BatchAlloc()
+	otherPreempted.Job = otherJob
+	otherPreempted.JobID = otherJob.ID
+	otherPreempted.DesiredStatus = structs.AllocDesiredStatusEvict
+
+	replacement := mock.Alloc()
+	replacement.PreemptedAllocations = []string{preempted.ID}
+	otherReplacement := mock.Alloc()
+	otherReplacement.NodeID = uuid.Generate()
+	otherReplacement.PreemptedAllocations = []string{otherPreempted.ID}
+	require.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1002,
+		[]*structs.Allocation{preempted, otherPreempted, replacement, otherReplacement}))
+
+	eval := mock.Eval()
+	eval.JobID = replacement.JobID
+	require.NoError(t, store.UpsertEvals(structs.MsgTypeTestSetup, 1003, []*structs.Evaluation{eval}))
+
+	// Only the replacement stopped because of the reconnect frees capacity
+	// for the jobs it preempted
+	planRes := &structs.PlanResult{
+		NodeUpdate: map[string][]*structs.Allocation{
+			replacement.NodeID: {{
+				ID:                 replacement.ID,
+				DesiredDescription: structs.AllocDesiredDescriptionReconnected,
+			}},
+			otherReplacement.NodeID: {{
+				ID:                 otherReplacement.ID,
+				DesiredDescription: "alloc not needed due to job update",
+			}},
+		},
+	}
+	plan := &structs.Plan{
+		Job:    replacement.Job,
+		EvalID: eval.ID,
+	}
+
+	snap, err := store.Snapshot()
+	require.NoError(t, err)
+	future, err := s1.applyPlan(plan, planRes, snap)
+	require.NoError(t, err)
+	_, err = planWaitFuture(future)
+	require.NoError(t, err)
+
+	evals, err := store.EvalsByJob(nil, reconnectedJob.Namespace, reconnectedJob.ID)
+	require.NoError(t, err)
+	require.Len(t, evals, 1)
+	require.Equal(t, structs.EvalTriggerReconnectPreemption, evals[0].TriggeredBy)
+	require.Equal(t, structs.JobTypeBatch, evals[0].Type)
+	require.Equal(t, structs.EvalStatusPending, evals[0].Status)
+
+	evals, err = store.EvalsByJob(nil, otherJob.Namespace, otherJob.ID)
+	require.NoError(t, err)
+	require.Empty(t, evals)
+}
+
 func TestPlanApply_EvalPlan_Simple(t *testing.T) {
 	ci.Parallel(t)
 	state := testStateStore(t)
@@ -0,0 +1,179 @@
+package nomad
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// planApplyLatencyWeight is the weight given to each new raft apply
+	// latency sample in the moving average tracked by planBackpressure.
+	planApplyLatencyWeight = 0.2
+
+	// planApplyLatencyHalfLife is how long it takes for the moving average of
+	// the raft apply latency to halve when no plan is applied. Once the
+	// applier is saturated by latency no plan is admitted, so without decay
+	// the average would never be updated and plans would be rejected forever.
+	planApplyLatencyHalfLife = 5 * time.Second
+)
+
+// planBackpressureConfig configures when the leader stops admitting plans
+// because the plan applier can't keep up.
+type planBackpressureConfig struct {
+	// Enabled controls whether plans are rejected when the applier is
+	// saturated. Metrics are tracked either way.
+	Enabled bool
+
+	// MaxQueueDepth is the number of plans waiting in the plan queue above
+	// which new plans are rejected. Zero disables the check.
+	MaxQueueDepth int
+
+	// MaxApplyLatency is the moving average of the raft apply latency of
+	// plans above which new plans are rejected. Zero disables the check.
+	MaxApplyLatency time.Duration
+}
+
+// planBackpressure tracks the load of the plan applier and decides whether
+// new plans should be admitted to the plan queue. Rejected plans fail their
+// submission, so the worker nacks the evaluation and the eval broker
+// redelivers it after the nack delay, slowing the schedulers down instead of
+// letting every plan in the queue time out.
+type planBackpressure struct {
+	config *planBackpressureConfig
+	queue  *PlanQueue
+
+	l            sync.Mutex
+	applyLatency time.Duration
+	lastApply    time.Time
+	rejected     uint64
+}
+
+func newPlanBackpressure(config *planBackpressureConfig, queue *PlanQueue) *planBackpressure {
+	if config == nil {
+		config = &planBackpressureConfig{}
+	}
+	return &planBackpressure{
+		config: config,
+		queue:  queue,
+	}
+}
+
+// observeApply records the time it took raft to apply a plan.
+func (b *planBackpressure) observeApply(d time.Duration) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	now := time.Now()
+	latency := b.latencyLocked(now)
+	b.lastApply = now
+
+	if latency == 0 {
+		b.applyLatency = d
+		return
+	}
+	b.applyLatency = time.Duration(planApplyLatencyWeight*float64(d) +
+		(1-planApplyLatencyWeight)*float64(latency))
+}
+
+// latencyLocked returns the moving average of the raft apply latency at the
+// given time, decayed by the time since the last plan was applied. The lock
+// must be held.
+func (b *planBackpressure) latencyLocked(now time.Time) time.Duration {
+	if b.applyLatency == 0 || b.lastApply.IsZero() {
+		return b.applyLatency
+	}
+	since := now.Sub(b.lastApply)
+	if since <= 0 {
+		return b.applyLatency
+	}
+	decay := math.Exp2(-float64(since) / float64(planApplyLatencyHalfLife))
+	return time.Duration(float64(b.applyLatency) * decay)
+}
+
+// admit returns an error if the plan applier is saturated and new plans
+// should be rejected.
+func (b *planBackpressure) admit() error {
+	if !b.config.Enabled {
+		return nil
+	}
+
+	reason := b.saturated(b.queue.Stats().Depth)
+	if reason == "" {
+		return nil
+	}
+
+	b.l.Lock()
+	b.rejected++
+	b.l.Unlock()
+
+	metrics.IncrCounter([]string{"nomad", "plan", "backpressure_rejected"}, 1)
+	return fmt.Errorf("%w: %s", structs.ErrPlanApplierSaturated, reason)
+}
+
+// saturated returns why the plan applier is saturated given the depth of the
+// plan queue, or an empty string if it isn't.
+func (b *planBackpressure) saturated(depth int) string {
+	if max := b.config.MaxQueueDepth; max > 0 && depth >= max {
+		return fmt.Sprintf("plan queue depth %d reached limit of %d", depth, max)
+	}
+
+	b.l.Lock()
+	latency := b.latencyLocked(time.Now())
+	b.l.Unlock()
+
+	if max := b.config.MaxApplyLatency; max > 0 && latency >= max {
+		return fmt.Sprintf("plan apply latency %s reached limit of %s", latency, max)
+	}
+	return ""
+}
+
+// Stats returns the current load of the plan applier.
+func (b *planBackpressure) Stats() *structs.PlanQueueStats {
+	depth := b.queue.Stats().Depth
+	reason := b.saturated(depth)
+
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	return &structs.PlanQueueStats{
+		Depth:                  depth,
+		ApplyLatency:           b.latencyLocked(time.Now()),
+		Saturated:              reason != "",
+		SaturatedReason:        reason,
+		BackpressureEnabled:    b.config.Enabled,
+		MaxQueueDepth:          b.config.MaxQueueDepth,
+		MaxApplyLatency:        b.config.MaxApplyLatency,
+		BackpressureRejections: b.rejected,
+	}
+}
+
+// EmitStats is used to export metrics about the plan applier's load.
+func (b *planBackpressure) EmitStats(period time.Duration, stopCh <-chan struct{}) {
+	timer, stop := helper.NewSafeTimer(period)
+	defer stop()
+
+	for {
+		timer.Reset(period)
+
+		select {
+		case <-timer.C:
+			stats := b.Stats()
+			metrics.SetGauge([]string{"nomad", "plan", "apply_latency_avg"},
+				float32(stats.ApplyLatency.Milliseconds()))
+
+			var saturated float32
+			if stats.Saturated {
+				saturated = 1
+			}
+			metrics.SetGauge([]string{"nomad", "plan", "saturated"}, saturated)
+		case <-stopCh:
+			return
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package nomad
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanBackpressure_QueueDepth(t *testing.T) {
+	ci.Parallel(t)
+
+	queue, err := NewPlanQueue()
+	require.NoError(t, err)
+	queue.SetEnabled(true)
+
+	b := newPlanBackpressure(&planBackpressureConfig{
+		Enabled:       true,
+		MaxQueueDepth: 2,
+	}, queue)
+	require.NoError(t, b.admit())
+
+	for i := 0; i < 2; i++ {
+		_, err := queue.Enqueue(mock.Plan())
+		require.NoError(t, err)
+	}
+
+	err = b.admit()
+	require.True(t, errors.Is(err, structs.ErrPlanApplierSaturated), err)
+	require.Contains(t, err.Error(), "plan queue depth 2")
+
+	stats := b.Stats()
+	require.Equal(t, 2, stats.Depth)
+	require.True(t, stats.Saturated)
+	require.Equal(t, uint64(1), stats.BackpressureRejections)
+
+	// Draining the queue admits plans again
+	queue.Flush()
+	queue.SetEnabled(true)
+	require.NoError(t, b.admit())
+}
+
+func TestPlanBackpressure_ApplyLatency(t *testing.T) {
+	ci.Parallel(t)
+
+	queue, err := NewPlanQueue()
+	require.NoError(t, err)
+
+	b := newPlanBackpressure(&planBackpressureConfig{
+		Enabled:         true,
+		MaxApplyLatency: time.Second,
+	}, queue)
+
+	// A single slow apply doesn't saturate the applier once the average is
+	// established
+	b.observeApply(100 * time.Millisecond)
+	b.observeApply(2 * time.Second)
+	require.NoError(t, b.admit())
+
+	// Sustained slow applies do
+	for i := 0; i < 10; i++ {
+		b.observeApply(2 * time.Second)
+	}
+	err = b.admit()
+	require.True(t, errors.Is(err, structs.ErrPlanApplierSaturated), err)
+	require.Contains(t, err.Error(), "plan apply latency")
+}
+
+func TestPlanBackpressure_Disabled(t *testing.T) {
+	ci.Parallel(t)
+
+	queue, err := NewPlanQueue()
+	require.NoError(t, err)
+
+	b := newPlanBackpressure(&planBackpressureConfig{
+		MaxApplyLatency: time.Second,
+	}, queue)
+	b.observeApply(time.Minute)
+
+	// Saturation is reported but plans are still admitted
+	require.NoError(t, b.admit())
+	stats := b.Stats()
+	require.True(t, stats.Saturated)
+	require.False(t, stats.BackpressureEnabled)
+	require.Zero(t, stats.BackpressureRejections)
+}
+
+func TestPlanBackpressure_ApplyLatency_Decays(t *testing.T) {
+	ci.Parallel(t)
+
+	queue, err := NewPlanQueue()
+	require.NoError(t, err)
+
+	b := newPlanBackpressure(&planBackpressureConfig{
+		Enabled:         true,
+		MaxApplyLatency: time.Second,
+	}, queue)
+
+	b.observeApply(4 * time.Second)
+	err = b.admit()
+	require.True(t, errors.Is(err, structs.ErrPlanApplierSaturated), err)
+
+	// No plan is applied while the applier is saturated, so the average
+	// decays until plans are admitted again
+	b.l.Lock()
+	b.lastApply = b.lastApply.Add(-3 * planApplyLatencyHalfLife)
+	b.l.Unlock()
+
+	require.NoError(t, b.admit())
+	stats := b.Stats()
+	require.False(t, stats.Saturated)
+	require.Less(t, stats.ApplyLatency, time.Second)
+
+	// The decayed average is the base for new samples
+	b.observeApply(time.Second)
+	require.Less(t, b.Stats().ApplyLatency, time.Second)
+}
@@ -36,6 +36,12 @@ func (p *Plan) Submit(args *structs.PlanRequest, reply *structs.PlanResponse) er
 		return fmt.Errorf("cannot submit nil plan")
 	}
 
+	// Reject the plan while the applier is saturated. The worker nacks the
+	// evaluation, so it is retried once the nack delay has passed.
+	if err := p.srv.planner.backpressure.admit(); err != nil {
+		return err
+	}
+
 	// Pause the Nack timer for the eval as it is making progress as long as it
 	// is in the plan queue. We resume immediately after we get a result to
 	// handle the case that the receiving worker dies.
@@ -545,6 +545,7 @@ func (r *rpcHandler) forward(method string, info structs.RPCInfo, args interface
 		// Mark that we are forwarding the RPC
 		info.SetForwarded()
 		err := r.forwardRegion(region, method, args, reply)
+		setForwardedReply(reply)
 		return true, err
 	}
 
@@ -566,9 +567,18 @@ func (r *rpcHandler) forward(method string, info structs.RPCInfo, args interface
 	// forward to leader
 	info.SetForwarded()
 	err = r.forwardLeader(remoteServer, method, args, reply)
+	setForwardedReply(reply)
 	return true, err
 }
 
+// setForwardedReply marks the reply of a forwarded query so the forwarding is
+// reported back to the caller.
+func setForwardedReply(reply interface{}) {
+	if m, ok := reply.(interface{ SetForwardedReply() }); ok {
+		m.SetForwardedReply()
+	}
+}
+
 // getLeaderForRPC returns the server info of the currently known leader, or
 // nil if this server is the current leader.  If the local server is the leader
 // it blocks until it is ready to handle consistent RPC invocations.  If leader
@@ -809,6 +819,7 @@ func (r *rpcHandler) blockingRPC(opts *blockingOptions) error {
 	ctx := context.Background()
 	var cancel context.CancelFunc
 	var state *state.StateStore
+	var queryTime time.Duration
 
 	// Fast path non-blocking
 	if opts.queryOpts.MinQueryIndex == 0 {
@@ -850,7 +861,10 @@ RUN_QUERY:
 	}
 
 	// Block up to the timeout if we didn't see anything fresh.
+	start := time.Now()
 	err := opts.run(ws, stateSnap)
+	queryTime += time.Since(start)
+	opts.queryMeta.QueryTime = queryTime
 
 	// Check for minimum query time
 	if err == nil && opts.queryOpts.MinQueryIndex > 0 && opts.queryMeta.Index <= opts.queryOpts.MinQueryIndex {
@@ -90,6 +90,46 @@ func TestRPC_forwardLeader(t *testing.T) {
 	}
 }
 
+func TestRPC_forwardLeader_QueryMeta(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.BootstrapExpect = 2
+	})
+	defer cleanupS1()
+	s2, cleanupS2 := TestServer(t, func(c *Config) {
+		c.BootstrapExpect = 2
+	})
+	defer cleanupS2()
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+
+	leader, follower := s1, s2
+	if isLeader, _ := s2.getLeader(); isLeader {
+		leader, follower = s2, s1
+	}
+
+	get := &structs.JobListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+
+	// The leader answers the query itself
+	var resp structs.JobListResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(rpcClient(t, leader), "Job.List", get, &resp))
+	require.False(t, resp.Forwarded)
+	require.NotZero(t, resp.QueryTime)
+
+	// The follower forwards the query to the leader
+	var resp2 structs.JobListResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(rpcClient(t, follower), "Job.List", get, &resp2))
+	require.True(t, resp2.Forwarded)
+	require.NotZero(t, resp2.QueryTime)
+}
+
 func TestRPC_WaitForConsistentReads(t *testing.T) {
 	ci.Parallel(t)
 
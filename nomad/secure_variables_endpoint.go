@@ -2,17 +2,20 @@ package nomad
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
 	metrics "github.com/armon/go-metrics"
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
 
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/mlock"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/state/paginator"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -76,6 +79,7 @@ func (sv *SecureVariables) Apply(args *structs.SecureVariablesApplyRequest, repl
 	sveArgs := structs.SVApplyStateRequest{
 		Op:           args.Op,
 		Var:          ev,
+		Force:        args.Force,
 		WriteRequest: args.WriteRequest,
 	}
 
@@ -84,7 +88,13 @@ func (sv *SecureVariables) Apply(args *structs.SecureVariablesApplyRequest, repl
 	if err != nil {
 		return fmt.Errorf("raft apply failed: %w", err)
 	}
-	r, err := sv.makeSecureVariablesApplyResponse(args, out.(*structs.SVApplyStateResponse), canRead)
+	resp := out.(*structs.SVApplyStateResponse)
+	emitVarWriteMetrics(args.Op, ev, resp.Result)
+	if resp.IsError() && errors.Is(resp.Error, structs.ErrSecureVariableDeleteProtected) {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, resp.Error.Error())
+	}
+
+	r, err := sv.makeSecureVariablesApplyResponse(args, resp, canRead)
 	if err != nil {
 		return err
 	}
@@ -108,6 +118,13 @@ func svePreApply(sv *SecureVariables, args *structs.SecureVariablesApplyRequest,
 		}
 		canRead = hasPerm(acl.SecureVariablesCapabilityRead)
 
+		// Forcing the deletion or unprotection of a protected variable
+		// requires a distinct capability
+		if args.Force && !hasPerm(acl.SecureVariablesCapabilityDeleteProtected) {
+			err = structs.ErrPermissionDenied
+			return
+		}
+
 		switch args.Op {
 		case structs.SVOpSet, structs.SVOpCAS:
 			if !hasPerm(acl.SecureVariablesCapabilityWrite) {
@@ -215,6 +232,8 @@ func (sv *SecureVariables) Read(args *structs.SecureVariablesReadRequest, reply
 	if err != nil {
 		return err
 	}
+	metrics.IncrCounterWithLabels([]string{"vars", "read"}, 1,
+		[]metrics.Label{{Name: "namespace", Value: args.RequestNamespace()}})
 
 	// Setup the blocking query
 	opts := blockingOptions{
@@ -244,6 +263,111 @@ func (sv *SecureVariables) Read(args *structs.SecureVariablesReadRequest, reply
 	return sv.srv.blockingRPC(&opts)
 }
 
+// ReadPrefix is used to get all the secure variables under a path prefix in
+// a single namespace. Secure variables the caller isn't allowed to read are
+// left out, and the results are paginated like List.
+func (sv *SecureVariables) ReadPrefix(
+	args *structs.SecureVariablesReadPrefixRequest,
+	reply *structs.SecureVariablesReadPrefixResponse) error {
+
+	if done, err := sv.srv.forward(structs.SecureVariablesReadPrefixRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "secure_variables", "read_prefix"}, time.Now())
+
+	if args.RequestNamespace() == structs.AllNamespacesSentinel {
+		return structs.NewErrRPCCoded(http.StatusBadRequest,
+			"secure variables can't be read across all namespaces")
+	}
+
+	aclObj, err := sv.handleMixedAuthEndpoint(args.QueryOptions,
+		acl.PolicyRead, args.Prefix)
+	if err != nil {
+		return err
+	}
+	metrics.IncrCounterWithLabels([]string{"vars", "read"}, 1,
+		[]metrics.Label{{Name: "namespace", Value: args.RequestNamespace()}})
+
+	// A workload identity reading its own paths has no ACL object. A short
+	// prefix like "nomad/jobs" is valid for every workload, so each returned
+	// path has to be one the workload could read on its own.
+	var workloadPaths []string
+	if aclObj == nil && sv.srv.config.ACLEnabled {
+		claims, err := sv.srv.VerifyClaim(args.AuthToken)
+		if err != nil {
+			return structs.ErrPermissionDenied
+		}
+		workloadPaths, err = sv.workloadPathParts(claims, args.RequestNamespace())
+		if err != nil {
+			return structs.ErrPermissionDenied
+		}
+	}
+	allowed := func(path string) bool {
+		if aclObj != nil {
+			return aclObj.AllowSecureVariableOperation(args.RequestNamespace(), path, acl.PolicyRead)
+		}
+		if !sv.srv.config.ACLEnabled {
+			return true
+		}
+		return workloadPathMatches(workloadPaths, path)
+	}
+
+	return sv.srv.blockingRPC(&blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+
+			iter, err := stateStore.GetSecureVariablesByNamespaceAndPrefix(ws, args.RequestNamespace(), args.Prefix)
+			if err != nil {
+				return err
+			}
+
+			tokenizer := paginator.NewStructsTokenizer(iter,
+				paginator.StructsTokenizerOptions{
+					WithNamespace: true,
+					WithID:        true,
+				},
+			)
+
+			filters := []paginator.Filter{
+				paginator.GenericFilter{
+					Allow: func(raw interface{}) (bool, error) {
+						v := raw.(*structs.SecureVariableEncrypted)
+						return strings.HasPrefix(v.Path, args.Prefix) && allowed(v.Path), nil
+					},
+				},
+			}
+
+			var svs []*structs.SecureVariableDecrypted
+			paginatorImpl, err := paginator.NewPaginator(iter, tokenizer, filters, args.QueryOptions,
+				func(raw interface{}) error {
+					dv, err := sv.decrypt(raw.(*structs.SecureVariableEncrypted))
+					if err != nil {
+						return err
+					}
+					ov := dv.Copy()
+					svs = append(svs, &ov)
+					return nil
+				})
+			if err != nil {
+				return structs.NewErrRPCCodedf(
+					http.StatusBadRequest, "failed to create result paginator: %v", err)
+			}
+
+			nextToken, err := paginatorImpl.Page()
+			if err != nil {
+				return err
+			}
+
+			reply.Data = svs
+			reply.NextToken = nextToken
+			reply.ObjectsScanned = paginatorImpl.Scanned()
+
+			return sv.srv.setReplyQueryMeta(stateStore, state.TableSecureVariables, &reply.QueryMeta)
+		},
+	})
+}
+
 // List is used to list secure variables held within state. It supports single
 // and wildcard namespace listings.
 func (sv *SecureVariables) List(
@@ -255,10 +379,332 @@ func (sv *SecureVariables) List(
 	}
 	defer metrics.MeasureSince([]string{"nomad", "secure_variables", "list"}, time.Now())
 
+	return sv.list(args, reply, nil)
+}
+
+// Search is used to find secure variables matching a path prefix, a glob
+// pattern, and metadata. It supports single and wildcard namespace searches,
+// and is paginated like List.
+func (sv *SecureVariables) Search(
+	args *structs.SecureVariablesSearchRequest,
+	reply *structs.SecureVariablesSearchResponse) error {
+
+	if done, err := sv.srv.forward(structs.SecureVariablesSearchRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "secure_variables", "search"}, time.Now())
+
+	if err := args.Validate(); err != nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, err.Error())
+	}
+
+	listArgs := &structs.SecureVariablesListRequest{QueryOptions: args.QueryOptions}
+	var listReply structs.SecureVariablesListResponse
+	err := sv.list(listArgs, &listReply, args.Matches)
+	reply.Data = listReply.Data
+	reply.QueryMeta = listReply.QueryMeta
+	return err
+}
+
+// Purge is used to delete all the secure variables under a path prefix. The
+// caller must be allowed to destroy every matching secure variable, and the
+// deletes are applied in batches of SecureVariablesPurgeBatchSize so that
+// large purges don't produce oversized raft log entries.
+func (sv *SecureVariables) Purge(
+	args *structs.SecureVariablesPurgeRequest,
+	reply *structs.SecureVariablesPurgeResponse) error {
+
+	if done, err := sv.srv.forward(structs.SecureVariablesPurgeRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "secure_variables", "purge"}, time.Now())
+
+	if err := args.Validate(); err != nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, err.Error())
+	}
+
+	aclObj, err := sv.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	}
+
+	ns := args.RequestNamespace()
+	snap, err := sv.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	iter, err := snap.GetSecureVariablesByNamespaceAndPrefix(nil, ns, args.Prefix)
+	if err != nil {
+		return err
+	}
+
+	// Check permissions for every secure variable before deleting any, so
+	// that a purge is never left half done because of ACLs.
+	paths := []string{}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		v := raw.(*structs.SecureVariableEncrypted)
+		// Replicas can only be deleted by the replication from their source
+		// region, so they are left alone
+		if !args.Matches(v.Path) || v.SourceRegion != "" {
+			continue
+		}
+		if v.DeleteProtection && !args.Force {
+			continue
+		}
+		if aclObj != nil && !aclObj.AllowSecureVariableOperation(
+			ns, v.Path, acl.SecureVariablesCapabilityDestroy) {
+			return structs.ErrPermissionDenied
+		}
+		if aclObj != nil && v.DeleteProtection && !aclObj.AllowSecureVariableOperation(
+			ns, v.Path, acl.SecureVariablesCapabilityDeleteProtected) {
+			return structs.ErrPermissionDenied
+		}
+		paths = append(paths, v.Path)
+	}
+	reply.Paths = paths
+
+	if args.DryRun || len(paths) == 0 {
+		index, err := snap.Index(state.TableSecureVariables)
+		if err != nil {
+			return err
+		}
+		reply.Index = index
+		return nil
+	}
+
+	for start := 0; start < len(paths); start += structs.SecureVariablesPurgeBatchSize {
+		end := helper.Min(start+structs.SecureVariablesPurgeBatchSize, len(paths))
+		req := structs.SVBatchDeleteStateRequest{
+			Namespace:    ns,
+			Paths:        paths[start:end],
+			Force:        args.Force,
+			WriteRequest: args.WriteRequest,
+		}
+		resp, index, err := sv.srv.raftApply(structs.SVBatchDeleteRequestType, req)
+		if err != nil {
+			return fmt.Errorf("raft apply failed: %w", err)
+		}
+		if respErr, ok := resp.(error); ok {
+			return respErr
+		}
+		reply.Index = index
+	}
+	return nil
+}
+
+// Txn is used to apply many secure variable operations atomically, in a
+// single raft log entry. If the check of any operation conflicts, none of
+// them are applied and the conflicting secure variable is returned.
+func (sv *SecureVariables) Txn(
+	args *structs.SecureVariablesTxnRequest,
+	reply *structs.SecureVariablesTxnResponse) error {
+
+	if done, err := sv.srv.forward(structs.SecureVariablesTxnRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "secure_variables", "txn"}, time.Now())
+
+	if err := args.Validate(); err != nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, err.Error())
+	}
+
+	aclObj, err := sv.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	}
+
+	// Check permissions and validate every operation before applying any.
+	canRead := make([]bool, len(args.Ops))
+	stateArgs := structs.SVTxnStateRequest{
+		Ops:          make([]*structs.SVTxnStateOp, len(args.Ops)),
+		WriteRequest: args.WriteRequest,
+	}
+	now := time.Now().UnixNano()
+	for i, op := range args.Ops {
+		v := op.Var
+		if v.Namespace == "" {
+			v.Namespace = args.RequestNamespace()
+		}
+
+		var perm string
+		switch op.Op {
+		case structs.SVOpSet, structs.SVOpCAS:
+			perm = acl.SecureVariablesCapabilityWrite
+		case structs.SVOpDelete, structs.SVOpDeleteCAS:
+			perm = acl.SecureVariablesCapabilityDestroy
+		case structs.SVOpCheckIndex:
+			perm = acl.SecureVariablesCapabilityRead
+		}
+		canRead[i] = true
+		if aclObj != nil {
+			if !aclObj.AllowSecureVariableOperation(v.Namespace, v.Path, perm) {
+				return structs.ErrPermissionDenied
+			}
+			canRead[i] = aclObj.AllowSecureVariableOperation(
+				v.Namespace, v.Path, acl.SecureVariablesCapabilityRead)
+		}
+
+		var ev *structs.SecureVariableEncrypted
+		switch op.Op {
+		case structs.SVOpSet, structs.SVOpCAS:
+			v.Canonicalize()
+			if err := v.Validate(); err != nil {
+				return structs.NewErrRPCCoded(http.StatusBadRequest,
+					fmt.Sprintf("operation %d: %v", i, err))
+			}
+			ev, err = sv.encrypt(v)
+			if err != nil {
+				return fmt.Errorf("secure variable error: encrypt: %w", err)
+			}
+			ev.CreateTime = now // existing will override if it exists
+			ev.ModifyTime = now
+		default:
+			if v.Path == "" {
+				return structs.NewErrRPCCoded(http.StatusBadRequest,
+					fmt.Sprintf("operation %d: %s requires a Path", i, op.Op))
+			}
+			ev = &structs.SecureVariableEncrypted{
+				SecureVariableMetadata: structs.SecureVariableMetadata{
+					Namespace:   v.Namespace,
+					Path:        v.Path,
+					ModifyIndex: v.ModifyIndex,
+				},
+			}
+		}
+		stateArgs.Ops[i] = &structs.SVTxnStateOp{Op: op.Op, Var: ev}
+	}
+
+	out, index, err := sv.srv.raftApply(structs.SVTxnRequestType, stateArgs)
+	if err != nil {
+		return fmt.Errorf("raft apply failed: %w", err)
+	}
+	resp := out.(*structs.SVTxnStateResponse)
+	if resp.IsError() {
+		if errors.Is(resp.Error, structs.ErrSecureVariableDeleteProtected) {
+			return structs.NewErrRPCCoded(http.StatusBadRequest, resp.Error.Error())
+		}
+		return resp.Error
+	}
+
+	reply.Result = resp.Result
+	reply.Index = index
+
+	for i, op := range stateArgs.Ops {
+		switch {
+		case resp.IsConflict() && i == resp.ConflictIndex:
+			emitVarWriteMetrics(op.Op, op.Var, resp.Result)
+		case resp.IsOk() && op.Op != structs.SVOpCheckIndex:
+			emitVarWriteMetrics(op.Op, op.Var, resp.Result)
+		}
+	}
+
+	if resp.IsConflict() {
+		// Build the conflict like a single operation would, so that it is
+		// redacted the same way.
+		op := args.Ops[resp.ConflictIndex]
+		r, err := sv.makeSecureVariablesApplyResponse(
+			&structs.SecureVariablesApplyRequest{Op: op.Op, Var: op.Var},
+			&structs.SVApplyStateResponse{
+				Op:       op.Op,
+				Result:   resp.Result,
+				Conflict: resp.Conflict,
+			},
+			canRead[resp.ConflictIndex])
+		if err != nil {
+			return err
+		}
+		reply.Result = r.Result
+		reply.ConflictIndex = resp.ConflictIndex
+		reply.Conflict = r.Conflict
+		return nil
+	}
+
+	// The writer is allowed to read their own writes
+	reply.Outputs = make([]*structs.SecureVariableDecrypted, len(args.Ops))
+	for i, meta := range resp.Written {
+		if meta != nil {
+			reply.Outputs[i] = &structs.SecureVariableDecrypted{
+				SecureVariableMetadata: *meta,
+				Items:                  args.Ops[i].Var.Items.Copy(),
+			}
+		}
+	}
+	return nil
+}
+
+// ExchangeIdentity exchanges the workload identity passed as the request's
+// auth token for a token which can read the workload's own secure variables
+// until it expires. Exchanged tokens can be exchanged again to renew them
+// for as long as the allocation is running.
+func (sv *SecureVariables) ExchangeIdentity(
+	args *structs.SecureVariablesExchangeIdentityRequest,
+	reply *structs.SecureVariablesExchangeIdentityResponse) error {
+
+	if done, err := sv.srv.forward(structs.SecureVariablesExchangeIdentityRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "secure_variables", "exchange_identity"}, time.Now())
+
+	claims, err := sv.srv.VerifyClaim(args.AuthToken)
+	if err != nil {
+		metrics.IncrCounter([]string{
+			"nomad", "secure_variables", "invalid_allocation_identity"}, 1)
+		sv.logger.Trace("allocation identity was not valid", "error", err)
+		return structs.ErrPermissionDenied
+	}
+
+	snap, err := sv.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	alloc, err := snap.AllocByID(nil, claims.AllocationID)
+	if err != nil {
+		return err
+	}
+
+	// the alloc may have stopped since the identity was verified, and
+	// terminal allocs must never get a new token
+	if alloc == nil || alloc.TerminalStatus() {
+		return structs.ErrPermissionDenied
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(structs.SecureVariablesIdentityTTL)
+	scoped := &structs.IdentityClaims{
+		Namespace:    claims.Namespace,
+		JobID:        claims.JobID,
+		AllocationID: claims.AllocationID,
+		TaskName:     claims.TaskName,
+		Scope:        structs.IdentityScopeSecureVariablesRead,
+		RegisteredClaims: jwt.RegisteredClaims{
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	token, err := sv.encrypter.SignClaims(scoped)
+	if err != nil {
+		return fmt.Errorf("could not sign token: %w", err)
+	}
+
+	reply.Token = token
+	reply.ExpiresAt = expiresAt
+	reply.Paths = scoped.SecureVariablePaths(alloc.TaskGroup)
+	reply.Index, err = snap.LatestIndex()
+	return err
+}
+
+// list implements List and Search. If match is set, only secure variables
+// it returns true for are included in the reply.
+func (sv *SecureVariables) list(
+	args *structs.SecureVariablesListRequest,
+	reply *structs.SecureVariablesListResponse,
+	match func(*structs.SecureVariableMetadata) bool) error {
+
 	// If the caller has requested to list secure variables across all namespaces, use
 	// the custom function to perform this.
 	if args.RequestNamespace() == structs.AllNamespacesSentinel {
-		return sv.listAllSecureVariables(args, reply)
+		return sv.listAllSecureVariables(args, reply, match)
 	}
 
 	aclObj, err := sv.handleMixedAuthEndpoint(args.QueryOptions,
@@ -296,7 +742,8 @@ func (sv *SecureVariables) List(
 					Allow: func(raw interface{}) (bool, error) {
 						sv := raw.(*structs.SecureVariableEncrypted)
 						return strings.HasPrefix(sv.Path, args.Prefix) &&
-							(aclObj == nil || aclObj.AllowSecureVariableOperation(sv.Namespace, sv.Path, acl.PolicyList)), nil
+							(aclObj == nil || aclObj.AllowSecureVariableOperation(sv.Namespace, sv.Path, acl.PolicyList)) &&
+							(match == nil || match(&sv.SecureVariableMetadata)), nil
 					},
 				},
 			}
@@ -311,6 +758,7 @@ func (sv *SecureVariables) List(
 				func(raw interface{}) error {
 					sv := raw.(*structs.SecureVariableEncrypted)
 					svStub := sv.SecureVariableMetadata
+					svStub.Size = len(sv.Data) // not recorded by older servers
 					svs = append(svs, &svStub)
 					return nil
 				})
@@ -330,6 +778,7 @@ func (sv *SecureVariables) List(
 			// Populate the reply.
 			reply.Data = svs
 			reply.NextToken = nextToken
+			reply.ObjectsScanned = paginatorImpl.Scanned()
 
 			// Use the index table to populate the query meta as we have no way
 			// of tracking the max index on deletes.
@@ -338,11 +787,104 @@ func (sv *SecureVariables) List(
 	})
 }
 
+// Replicate returns the encrypted secure variables written in this region
+// whose namespace replicates them to the target region. It is used by the
+// leader of the target region, which holds the root keys of this region that
+// it needs to decrypt them.
+func (sv *SecureVariables) Replicate(
+	args *structs.SecureVariablesReplicateRequest,
+	reply *structs.SecureVariablesReplicateResponse) error {
+
+	if done, err := sv.srv.forward(structs.SecureVariablesReplicateRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "secure_variables", "replicate"}, time.Now())
+
+	if aclObj, err := sv.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+	if args.TargetRegion == "" {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "target region is required")
+	}
+
+	return sv.srv.blockingRPC(&blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+
+			// Find the namespaces replicated to the target region
+			iter, err := stateStore.Namespaces(ws)
+			if err != nil {
+				return err
+			}
+			replicated := make(map[string]struct{})
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				ns := raw.(*structs.Namespace)
+				if ns.SecureVariables.ReplicatesTo(args.TargetRegion) {
+					replicated[ns.Name] = struct{}{}
+				}
+			}
+
+			// Replicas are only replicated by their own source region,
+			// so that regions replicating to each other don't loop
+			svs := []*structs.SecureVariableEncrypted{}
+			iter, err = stateStore.SecureVariables(ws)
+			if err != nil {
+				return err
+			}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				v := raw.(*structs.SecureVariableEncrypted)
+				if _, ok := replicated[v.Namespace]; ok && v.SourceRegion == "" {
+					svs = append(svs, v)
+				}
+			}
+			reply.Data = svs
+
+			index, err := stateStore.Index(state.TableSecureVariables)
+			if err != nil {
+				return err
+			}
+			nsIndex, err := stateStore.Index(state.TableNamespaces)
+			if err != nil {
+				return err
+			}
+			reply.Index = helper.Max(1, helper.Max(index, nsIndex))
+			sv.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		},
+	})
+}
+
+// ReplicationStatus reports the status of the replication of secure
+// variables from the other regions, as tracked by the leader since it was
+// elected.
+func (sv *SecureVariables) ReplicationStatus(
+	args *structs.SecureVariablesReplicationStatusRequest,
+	reply *structs.SecureVariablesReplicationStatusResponse) error {
+
+	if done, err := sv.srv.forward(structs.SecureVariablesReplicationStatusRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "secure_variables", "replication_status"}, time.Now())
+
+	if aclObj, err := sv.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	reply.Sources = sv.srv.secureVariablesReplication.status()
+	return sv.srv.setReplyQueryMeta(nil, state.TableSecureVariables, &reply.QueryMeta)
+}
+
 // listAllSecureVariables is used to list secure variables held within
 // state where the caller has used the namespace wildcard identifier.
 func (s *SecureVariables) listAllSecureVariables(
 	args *structs.SecureVariablesListRequest,
-	reply *structs.SecureVariablesListResponse) error {
+	reply *structs.SecureVariablesListResponse,
+	match func(*structs.SecureVariableMetadata) bool) error {
 
 	// Perform token resolution. The request already goes through forwarding
 	// and metrics setup before being called.
@@ -399,7 +941,8 @@ func (s *SecureVariables) listAllSecureVariables(
 					Allow: func(raw interface{}) (bool, error) {
 						sv := raw.(*structs.SecureVariableEncrypted)
 						return strings.HasPrefix(sv.Path, args.Prefix) &&
-							(aclObj == nil || aclObj.AllowSecureVariableOperation(sv.Namespace, sv.Path, acl.PolicyList)), nil
+							(aclObj == nil || aclObj.AllowSecureVariableOperation(sv.Namespace, sv.Path, acl.PolicyList)) &&
+							(match == nil || match(&sv.SecureVariableMetadata)), nil
 					},
 				},
 			}
@@ -410,6 +953,7 @@ func (s *SecureVariables) listAllSecureVariables(
 				func(raw interface{}) error {
 					sv := raw.(*structs.SecureVariableEncrypted)
 					svStub := sv.SecureVariableMetadata
+					svStub.Size = len(sv.Data) // not recorded by older servers
 					svs = append(svs, &svStub)
 					return nil
 				})
@@ -429,6 +973,7 @@ func (s *SecureVariables) listAllSecureVariables(
 			// Populate the reply.
 			reply.Data = svs
 			reply.NextToken = nextToken
+			reply.ObjectsScanned = paginatorImpl.Scanned()
 
 			// Use the index table to populate the query meta as we have no way
 			// of tracking the max index on deletes.
@@ -437,6 +982,25 @@ func (s *SecureVariables) listAllSecureVariables(
 	})
 }
 
+// emitVarWriteMetrics emits the result of a write to a secure variable and,
+// for successful sets, the size of its encrypted payload.
+func emitVarWriteMetrics(op structs.SVOp, ev *structs.SecureVariableEncrypted, result structs.SVOpResult) {
+	labels := []metrics.Label{
+		{Name: "namespace", Value: ev.Namespace},
+		{Name: "op", Value: string(op)},
+	}
+	switch result {
+	case structs.SVOpResultOk:
+		metrics.IncrCounterWithLabels([]string{"vars", "write"}, 1, labels)
+		if op == structs.SVOpSet || op == structs.SVOpCAS {
+			metrics.AddSampleWithLabels([]string{"vars", "payload_size"},
+				float32(len(ev.Data)), labels[:1])
+		}
+	case structs.SVOpResultConflict:
+		metrics.IncrCounterWithLabels([]string{"vars", "conflict"}, 1, labels)
+	}
+}
+
 func (sv *SecureVariables) encrypt(v *structs.SecureVariableDecrypted) (*structs.SecureVariableEncrypted, error) {
 	b, err := json.Marshal(v.Items)
 	if err != nil {
@@ -445,10 +1009,15 @@ func (sv *SecureVariables) encrypt(v *structs.SecureVariableDecrypted) (*structs
 	ev := structs.SecureVariableEncrypted{
 		SecureVariableMetadata: v.SecureVariableMetadata,
 	}
+	// secure variables written through the API are never replicas
+	ev.SourceRegion = ""
 	ev.Data, ev.KeyID, err = sv.encrypter.Encrypt(b)
+	mlock.Zero(b)
 	if err != nil {
 		return nil, err
 	}
+	ev.ItemCount = len(v.Items)
+	ev.Size = len(ev.Data)
 	return &ev, nil
 }
 
@@ -462,6 +1031,7 @@ func (sv *SecureVariables) decrypt(v *structs.SecureVariableEncrypted) (*structs
 	}
 	dv.Items = make(map[string]string)
 	err = json.Unmarshal(b, &dv.Items)
+	mlock.Zero(b)
 	if err != nil {
 		return nil, err
 	}
@@ -507,6 +1077,12 @@ func (sv *SecureVariables) handleMixedAuthEndpoint(args structs.QueryOptions, ca
 		return aclObj, nil
 	}
 
+	// Tokens issued by ExchangeIdentity are limited to the workload's own
+	// paths and don't carry the policies attached to the job
+	if claims.Scope == structs.IdentityScopeSecureVariablesRead {
+		return nil, structs.ErrPermissionDenied
+	}
+
 	// If the workload identity doesn't match the implicit permissions
 	// given to paths, check for its attached ACL policies
 	aclObj, err = sv.srv.ResolveClaims(claims)
@@ -523,32 +1099,49 @@ func (sv *SecureVariables) handleMixedAuthEndpoint(args structs.QueryOptions, ca
 // authValidatePrefix asserts that the requested path is valid for
 // this allocation
 func (sv *SecureVariables) authValidatePrefix(claims *structs.IdentityClaims, ns, pathOrPrefix string) error {
+	expect, err := sv.workloadPathParts(claims, ns)
+	if err != nil {
+		return err
+	}
+	if !workloadPathMatches(expect, pathOrPrefix) {
+		return structs.ErrPermissionDenied
+	}
+	return nil
+}
+
+// workloadPathParts returns the segments of the deepest path the allocation
+// has implicit access to, "nomad/jobs/<job>/<group>/<task>"
+func (sv *SecureVariables) workloadPathParts(claims *structs.IdentityClaims, ns string) ([]string, error) {
 
 	store, err := sv.srv.fsm.State().Snapshot()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	alloc, err := store.AllocByID(nil, claims.AllocationID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if alloc == nil || alloc.Job == nil {
-		return fmt.Errorf("allocation does not exist")
+		return nil, fmt.Errorf("allocation does not exist")
 	}
 	if alloc.Job.Namespace != ns {
-		return fmt.Errorf("allocation is in another namespace")
+		return nil, fmt.Errorf("allocation is in another namespace")
 	}
 
-	parts := strings.Split(pathOrPrefix, "/")
-	expect := []string{"nomad", "jobs", claims.JobID, alloc.TaskGroup, claims.TaskName}
+	return []string{"nomad", "jobs", claims.JobID, alloc.TaskGroup, claims.TaskName}, nil
+}
+
+// workloadPathMatches returns true if path is the workload's own path or one
+// of its parents
+func workloadPathMatches(expect []string, path string) bool {
+	parts := strings.Split(path, "/")
 	if len(parts) > len(expect) {
-		return structs.ErrPermissionDenied
+		return false
 	}
-
 	for idx, part := range parts {
 		if part != expect[idx] {
-			return structs.ErrPermissionDenied
+			return false
 		}
 	}
-	return nil
+	return true
 }
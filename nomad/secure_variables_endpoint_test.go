@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v4"
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
 	"github.com/shoenig/test"
 	"github.com/shoenig/test/must"
@@ -574,6 +575,312 @@ namespace "*" {}
 
 }
 
+func TestSecureVariablesEndpoint_List_FilterMeta(t *testing.T) {
+	ci.Parallel(t)
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+	store := srv.fsm.State()
+
+	for i, owner := range []string{"alice", "bob", "alice"} {
+		sv := mock.SecureVariableEncrypted()
+		sv.Namespace = structs.DefaultNamespace
+		sv.Path = fmt.Sprintf("app/%d", i)
+		sv.Meta = map[string]string{"owner": owner}
+		resp := store.SVESet(uint64(1000+i), &structs.SVApplyStateRequest{
+			Op:  structs.SVOpSet,
+			Var: sv,
+		})
+		must.NoError(t, resp.Error)
+	}
+
+	req := &structs.SecureVariablesListRequest{
+		QueryOptions: structs.QueryOptions{
+			Namespace: structs.DefaultNamespace,
+			Region:    "global",
+			Filter:    `SecureVariableMetadata.Meta.owner == "alice"`,
+		},
+	}
+	var resp structs.SecureVariablesListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "SecureVariables.List", req, &resp))
+	must.Len(t, 2, resp.Data)
+	for _, sv := range resp.Data {
+		must.Eq(t, "alice", sv.Meta["owner"])
+	}
+}
+
+func TestSecureVariablesEndpoint_List_ItemCountSize(t *testing.T) {
+	ci.Parallel(t)
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+
+	applyReq := &structs.SecureVariablesApplyRequest{
+		Op: structs.SVOpSet,
+		Var: &structs.SecureVariableDecrypted{
+			SecureVariableMetadata: structs.SecureVariableMetadata{
+				Namespace: structs.DefaultNamespace,
+				Path:      "app/db",
+				ItemCount: 10, // ignored
+			},
+			Items: structs.SecureVariableItems{"user": "admin", "password": "hunter2"},
+		},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var applyResp structs.SecureVariablesApplyResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "SecureVariables.Apply", applyReq, &applyResp))
+
+	// The item count and size are recorded when the variable is written
+	stored, err := srv.fsm.State().GetSecureVariable(nil, structs.DefaultNamespace, "app/db")
+	must.NoError(t, err)
+	must.Eq(t, 2, stored.ItemCount)
+	must.Eq(t, len(stored.Data), stored.Size)
+
+	req := &structs.SecureVariablesListRequest{
+		QueryOptions: structs.QueryOptions{
+			Namespace: structs.DefaultNamespace,
+			Region:    "global",
+		},
+	}
+	var resp structs.SecureVariablesListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "SecureVariables.List", req, &resp))
+	must.Len(t, 1, resp.Data)
+	must.Eq(t, 2, resp.Data[0].ItemCount)
+	must.Eq(t, len(stored.Data), resp.Data[0].Size)
+}
+
+func TestSecureVariablesEndpoint_Search(t *testing.T) {
+	ci.Parallel(t)
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+	store := srv.fsm.State()
+
+	idx := uint64(1000)
+	writeVar := func(path, owner string) {
+		idx++
+		sv := mock.SecureVariableEncrypted()
+		sv.Namespace = structs.DefaultNamespace
+		sv.Path = path
+		sv.Meta = map[string]string{"owner": owner}
+		resp := store.SVESet(idx, &structs.SVApplyStateRequest{
+			Op:  structs.SVOpSet,
+			Var: sv,
+		})
+		must.NoError(t, resp.Error)
+	}
+	writeVar("app/web/db", "alice")
+	writeVar("app/api/db", "bob")
+	writeVar("app/api/cache", "alice")
+	writeVar("other/db", "alice")
+
+	search := func(req *structs.SecureVariablesSearchRequest) *structs.SecureVariablesSearchResponse {
+		req.Region = "global"
+		if req.Namespace == "" {
+			req.Namespace = structs.DefaultNamespace
+		}
+		var resp structs.SecureVariablesSearchResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.SecureVariablesSearchRPCMethod, req, &resp))
+		return &resp
+	}
+	paths := func(resp *structs.SecureVariablesSearchResponse) []string {
+		out := make([]string, 0, len(resp.Data))
+		for _, sv := range resp.Data {
+			out = append(out, sv.Path)
+		}
+		return out
+	}
+
+	resp := search(&structs.SecureVariablesSearchRequest{Glob: "app/*/db"})
+	must.Eq(t, []string{"app/api/db", "app/web/db"}, paths(resp))
+
+	resp = search(&structs.SecureVariablesSearchRequest{
+		Meta: map[string]string{"owner": "alice"},
+		QueryOptions: structs.QueryOptions{
+			Prefix: "app",
+		},
+	})
+	must.Eq(t, []string{"app/api/cache", "app/web/db"}, paths(resp))
+
+	resp = search(&structs.SecureVariablesSearchRequest{
+		Glob: "*/*",
+		QueryOptions: structs.QueryOptions{
+			Namespace: structs.AllNamespacesSentinel,
+		},
+	})
+	must.Eq(t, []string{"other/db"}, paths(resp))
+
+	// Results are paginated
+	resp = search(&structs.SecureVariablesSearchRequest{
+		Meta: map[string]string{"owner": "alice"},
+		QueryOptions: structs.QueryOptions{
+			PerPage: 2,
+		},
+	})
+	must.Eq(t, []string{"app/api/cache", "app/web/db"}, paths(resp))
+	must.NotEq(t, "", resp.NextToken)
+
+	resp = search(&structs.SecureVariablesSearchRequest{
+		Meta: map[string]string{"owner": "alice"},
+		QueryOptions: structs.QueryOptions{
+			PerPage:   2,
+			NextToken: resp.NextToken,
+		},
+	})
+	must.Eq(t, []string{"other/db"}, paths(resp))
+	must.Eq(t, "", resp.NextToken)
+
+	// An invalid pattern is rejected
+	req := &structs.SecureVariablesSearchRequest{
+		Glob: "app/[",
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+	var out structs.SecureVariablesSearchResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.SecureVariablesSearchRPCMethod, req, &out)
+	must.Error(t, err)
+	must.StrContains(t, err.Error(), "invalid glob pattern")
+}
+
+func TestSecureVariablesEndpoint_ReadPrefix(t *testing.T) {
+	ci.Parallel(t)
+	srv, rootToken, shutdown := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+	store := srv.fsm.State()
+
+	alloc := mock.Alloc()
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc}))
+	idToken, err := srv.encrypter.SignClaims(alloc.ToTaskIdentityClaims(nil, "web"))
+	must.NoError(t, err)
+	jobPrefix := "nomad/jobs/" + alloc.JobID
+
+	for _, path := range []string{"app/web/db", "app/api/db", "app/secret/key", "other/db",
+		jobPrefix, jobPrefix + "/web", jobPrefix + "2"} {
+		sv := mock.SecureVariable()
+		sv.Path = path
+		sv.Items = structs.SecureVariableItems{"path": path}
+		applyReq := structs.SecureVariablesApplyRequest{
+			Op:  structs.SVOpSet,
+			Var: sv,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				AuthToken: rootToken.SecretID,
+			},
+		}
+		var applyResp structs.SecureVariablesApplyResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.SecureVariablesApplyRPCMethod, &applyReq, &applyResp))
+	}
+
+	pol := mock.NamespacePolicyWithSecureVariables(
+		structs.DefaultNamespace, "", []string{"list-jobs"},
+		map[string][]string{
+			"app/*":        {"read"},
+			"app/secret/*": {"list"},
+		})
+	limitedToken := mock.CreatePolicyAndToken(t, store, 2000, "read-prefix", pol)
+
+	readPrefix := func(prefix, token string, perPage int32, nextToken string) (*structs.SecureVariablesReadPrefixResponse, error) {
+		req := &structs.SecureVariablesReadPrefixRequest{
+			QueryOptions: structs.QueryOptions{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+				Prefix:    prefix,
+				AuthToken: token,
+				PerPage:   perPage,
+				NextToken: nextToken,
+			},
+		}
+		var resp structs.SecureVariablesReadPrefixResponse
+		err := msgpackrpc.CallWithCodec(codec, structs.SecureVariablesReadPrefixRPCMethod, req, &resp)
+		return &resp, err
+	}
+	paths := func(resp *structs.SecureVariablesReadPrefixResponse) []string {
+		out := make([]string, 0, len(resp.Data))
+		for _, sv := range resp.Data {
+			must.Eq(t, sv.Path, sv.Items["path"])
+			out = append(out, sv.Path)
+		}
+		return out
+	}
+
+	// All the variables under the prefix are returned decrypted
+	resp, err := readPrefix("app/", rootToken.SecretID, 0, "")
+	must.NoError(t, err)
+	must.Eq(t, []string{"app/api/db", "app/secret/key", "app/web/db"}, paths(resp))
+
+	// Variables the token can't read are left out
+	resp, err = readPrefix("app/", limitedToken.SecretID, 0, "")
+	must.NoError(t, err)
+	must.Eq(t, []string{"app/api/db", "app/web/db"}, paths(resp))
+
+	// Reading the prefix itself requires the read capability
+	_, err = readPrefix("other/", limitedToken.SecretID, 0, "")
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Results are paginated
+	resp, err = readPrefix("app/", rootToken.SecretID, 2, "")
+	must.NoError(t, err)
+	must.Eq(t, []string{"app/api/db", "app/secret/key"}, paths(resp))
+	must.NotEq(t, "", resp.NextToken)
+
+	resp, err = readPrefix("app/", rootToken.SecretID, 2, resp.NextToken)
+	must.NoError(t, err)
+	must.Eq(t, []string{"app/web/db"}, paths(resp))
+	must.Eq(t, "", resp.NextToken)
+
+	// A workload identity only reads whole path segments under its prefix
+	resp, err = readPrefix(jobPrefix, idToken, 0, "")
+	must.NoError(t, err)
+	must.Eq(t, []string{jobPrefix, jobPrefix + "/web"}, paths(resp))
+
+	// Another job's workload identity can use a shorter prefix, but doesn't
+	// get to read this job's variables with it
+	otherAlloc := mock.Alloc()
+	otherAlloc.ClientStatus = structs.AllocClientStatusRunning
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 2001, []*structs.Allocation{otherAlloc}))
+	otherIDToken, err := srv.encrypter.SignClaims(otherAlloc.ToTaskIdentityClaims(nil, "web"))
+	must.NoError(t, err)
+
+	for _, prefix := range []string{"nomad/jobs", "nomad"} {
+		resp, err = readPrefix(prefix, otherIDToken, 0, "")
+		must.NoError(t, err)
+		must.Len(t, 0, resp.Data, must.Sprintf("prefix %q", prefix))
+	}
+
+	// Nor can it read prefixes outside its own paths
+	_, err = readPrefix("app/", otherIDToken, 0, "")
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Reading across all namespaces isn't supported
+	req := &structs.SecureVariablesReadPrefixRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.AllNamespacesSentinel,
+			AuthToken: rootToken.SecretID,
+		},
+	}
+	err = msgpackrpc.CallWithCodec(codec, structs.SecureVariablesReadPrefixRPCMethod, req,
+		&structs.SecureVariablesReadPrefixResponse{})
+	must.Error(t, err)
+	must.StrContains(t, err.Error(), "across all namespaces")
+}
+
 func TestSecureVariablesEndpoint_GetSecureVariable_Blocking(t *testing.T) {
 	ci.Parallel(t)
 
@@ -706,3 +1013,455 @@ func writeVar(t *testing.T, s *Server, idx uint64, ns, path string) {
 	})
 	must.NoError(t, resp.Error)
 }
+
+func TestSecureVariablesEndpoint_Purge(t *testing.T) {
+	ci.Parallel(t)
+	srv, rootToken, shutdown := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+	store := srv.fsm.State()
+
+	// Write enough variables that the purge takes several raft applies
+	idx := uint64(1000)
+	writeVar := func(path string) {
+		idx++
+		sv := mock.SecureVariableEncrypted()
+		sv.Namespace = structs.DefaultNamespace
+		sv.Path = path
+		resp := store.SVESet(idx, &structs.SVApplyStateRequest{
+			Op:  structs.SVOpSet,
+			Var: sv,
+		})
+		must.NoError(t, resp.Error)
+	}
+	numVars := 2*structs.SecureVariablesPurgeBatchSize + 5
+	for i := 0; i < numVars; i++ {
+		writeVar(fmt.Sprintf("bulk/%05d", i))
+	}
+	writeVar("bulk/nested/keep")
+	writeVar("other/keep")
+
+	pol := mock.NamespacePolicyWithSecureVariables(
+		structs.DefaultNamespace, "", []string{"list-jobs"},
+		map[string][]string{
+			"bulk/00*": {"destroy"},
+		})
+	limitedToken := mock.CreatePolicyAndToken(t, store, idx+1, "purge-limited", pol)
+
+	purge := func(req *structs.SecureVariablesPurgeRequest, token string) (*structs.SecureVariablesPurgeResponse, error) {
+		req.Region = "global"
+		req.Namespace = structs.DefaultNamespace
+		req.AuthToken = token
+		var resp structs.SecureVariablesPurgeResponse
+		err := msgpackrpc.CallWithCodec(codec, structs.SecureVariablesPurgeRPCMethod, req, &resp)
+		return &resp, err
+	}
+	count := func(prefix string) int {
+		iter, err := store.GetSecureVariablesByNamespaceAndPrefix(nil, structs.DefaultNamespace, prefix)
+		must.NoError(t, err)
+		n := 0
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			n++
+		}
+		return n
+	}
+
+	// A prefix is required
+	_, err := purge(&structs.SecureVariablesPurgeRequest{}, rootToken.SecretID)
+	must.Error(t, err)
+	must.StrContains(t, err.Error(), "purge requires a path prefix")
+
+	// Missing destroy on any variable denies the whole purge
+	_, err = purge(&structs.SecureVariablesPurgeRequest{Prefix: "bulk/"}, limitedToken.SecretID)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+	must.Eq(t, numVars+1, count("bulk/"))
+
+	// A dry run only lists the paths
+	resp, err := purge(&structs.SecureVariablesPurgeRequest{Prefix: "bulk/", DryRun: true}, rootToken.SecretID)
+	must.NoError(t, err)
+	must.Len(t, numVars, resp.Paths)
+	must.Eq(t, numVars+1, count("bulk/"))
+
+	// Nested paths are kept unless recursing
+	resp, err = purge(&structs.SecureVariablesPurgeRequest{Prefix: "bulk/"}, rootToken.SecretID)
+	must.NoError(t, err)
+	must.Len(t, numVars, resp.Paths)
+	must.Positive(t, resp.Index)
+	must.Eq(t, 1, count("bulk/"))
+
+	resp, err = purge(&structs.SecureVariablesPurgeRequest{Prefix: "bulk/", Recurse: true}, rootToken.SecretID)
+	must.NoError(t, err)
+	must.Eq(t, []string{"bulk/nested/keep"}, resp.Paths)
+	must.Eq(t, 0, count("bulk/"))
+	must.Eq(t, 1, count("other/"))
+}
+
+func TestSecureVariablesEndpoint_DeleteProtection(t *testing.T) {
+	ci.Parallel(t)
+	srv, rootToken, shutdown := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+	store := srv.fsm.State()
+
+	destroyToken := mock.CreatePolicyAndToken(t, store, 1001, "destroy",
+		mock.NamespacePolicyWithSecureVariables(
+			structs.DefaultNamespace, "", []string{"list-jobs"},
+			map[string][]string{"protected/*": {"write", "destroy"}}))
+	protectedToken := mock.CreatePolicyAndToken(t, store, 1002, "delete-protected",
+		mock.NamespacePolicyWithSecureVariables(
+			structs.DefaultNamespace, "", []string{"list-jobs"},
+			map[string][]string{"protected/*": {"write", "destroy", "delete-protected"}}))
+
+	apply := func(op structs.SVOp, sv *structs.SecureVariableDecrypted, force bool, token string) error {
+		req := structs.SecureVariablesApplyRequest{
+			Op:    op,
+			Var:   sv,
+			Force: force,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+				AuthToken: token,
+			},
+		}
+		var resp structs.SecureVariablesApplyResponse
+		return msgpackrpc.CallWithCodec(codec, structs.SecureVariablesApplyRPCMethod, &req, &resp)
+	}
+	clone := func(sv *structs.SecureVariableDecrypted) *structs.SecureVariableDecrypted {
+		c := sv.Copy()
+		return &c
+	}
+	exists := func(path string) bool {
+		got, err := store.GetSecureVariable(nil, structs.DefaultNamespace, path)
+		must.NoError(t, err)
+		return got != nil
+	}
+
+	sv := mock.SecureVariable()
+	sv.Namespace = structs.DefaultNamespace
+	sv.Path = "protected/a"
+	sv.DeleteProtection = true
+	must.NoError(t, apply(structs.SVOpSet, sv, false, destroyToken.SecretID))
+
+	// Deleting without force is rejected regardless of the token
+	err := apply(structs.SVOpDelete, clone(sv), false, rootToken.SecretID)
+	must.Error(t, err)
+	must.StrContains(t, err.Error(), structs.ErrSecureVariableDeleteProtected.Error())
+	must.True(t, exists(sv.Path))
+
+	// Removing the protection must be forced too
+	unprotected := sv.Copy()
+	unprotected.DeleteProtection = false
+	err = apply(structs.SVOpSet, &unprotected, false, protectedToken.SecretID)
+	must.Error(t, err)
+	must.StrContains(t, err.Error(), structs.ErrSecureVariableDeleteProtected.Error())
+
+	// Forcing requires the delete-protected capability
+	err = apply(structs.SVOpDelete, clone(sv), true, destroyToken.SecretID)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+	must.True(t, exists(sv.Path))
+
+	must.NoError(t, apply(structs.SVOpDelete, clone(sv), true, protectedToken.SecretID))
+	must.False(t, exists(sv.Path))
+
+	// Purges skip protected variables unless forced
+	sv.Path = "protected/b"
+	must.NoError(t, apply(structs.SVOpSet, clone(sv), false, destroyToken.SecretID))
+	other := mock.SecureVariable()
+	other.Namespace = structs.DefaultNamespace
+	other.Path = "protected/c"
+	must.NoError(t, apply(structs.SVOpSet, other, false, destroyToken.SecretID))
+
+	purge := func(force bool, token string) (*structs.SecureVariablesPurgeResponse, error) {
+		req := &structs.SecureVariablesPurgeRequest{
+			Prefix: "protected/",
+			Force:  force,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+				AuthToken: token,
+			},
+		}
+		var resp structs.SecureVariablesPurgeResponse
+		err := msgpackrpc.CallWithCodec(codec, structs.SecureVariablesPurgeRPCMethod, req, &resp)
+		return &resp, err
+	}
+
+	resp, err := purge(false, destroyToken.SecretID)
+	must.NoError(t, err)
+	must.Eq(t, []string{"protected/c"}, resp.Paths)
+	must.True(t, exists("protected/b"))
+
+	_, err = purge(true, destroyToken.SecretID)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	resp, err = purge(true, protectedToken.SecretID)
+	must.NoError(t, err)
+	must.Eq(t, []string{"protected/b"}, resp.Paths)
+	must.False(t, exists("protected/b"))
+}
+
+func TestSecureVariablesEndpoint_Txn(t *testing.T) {
+	ci.Parallel(t)
+	srv, rootToken, shutdown := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+	store := srv.fsm.State()
+
+	pol := mock.NamespacePolicyWithSecureVariables(
+		structs.DefaultNamespace, "", []string{"list-jobs"},
+		map[string][]string{
+			"creds/*": {"write"},
+		})
+	writeToken := mock.CreatePolicyAndToken(t, store, 1001, "txn-write", pol)
+
+	txn := func(token string, ops ...*structs.SecureVariablesTxnOp) (*structs.SecureVariablesTxnResponse, error) {
+		req := &structs.SecureVariablesTxnRequest{
+			Ops: ops,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+				AuthToken: token,
+			},
+		}
+		var resp structs.SecureVariablesTxnResponse
+		err := msgpackrpc.CallWithCodec(codec, structs.SecureVariablesTxnRPCMethod, req, &resp)
+		return &resp, err
+	}
+	op := func(op structs.SVOp, path string, index uint64, items structs.SecureVariableItems) *structs.SecureVariablesTxnOp {
+		return &structs.SecureVariablesTxnOp{
+			Op: op,
+			Var: &structs.SecureVariableDecrypted{
+				SecureVariableMetadata: structs.SecureVariableMetadata{
+					Path:        path,
+					ModifyIndex: index,
+				},
+				Items: items,
+			},
+		}
+	}
+	read := func(path string) *structs.SecureVariableEncrypted {
+		sv, err := store.GetSecureVariable(nil, structs.DefaultNamespace, path)
+		must.NoError(t, err)
+		return sv
+	}
+
+	// Transactions require operations
+	_, err := txn(rootToken.SecretID)
+	must.Error(t, err)
+	must.StrContains(t, err.Error(), "transaction requires at least one operation")
+
+	// Create a credential pair
+	resp, err := txn(rootToken.SecretID,
+		op(structs.SVOpCAS, "creds/key", 0, structs.SecureVariableItems{"key": "k1"}),
+		op(structs.SVOpCAS, "creds/secret", 0, structs.SecureVariableItems{"secret": "s1"}),
+	)
+	must.NoError(t, err)
+	must.Eq(t, structs.SVOpResultOk, resp.Result)
+	must.Len(t, 2, resp.Outputs)
+	must.Eq(t, resp.Index, resp.Outputs[0].ModifyIndex)
+	must.Eq(t, resp.Index, resp.Outputs[1].ModifyIndex)
+	must.Eq(t, structs.SecureVariableItems{"secret": "s1"}, resp.Outputs[1].Items)
+	createIndex := resp.Index
+
+	// A stale check index fails the whole transaction
+	resp, err = txn(rootToken.SecretID,
+		op(structs.SVOpCAS, "creds/key", createIndex, structs.SecureVariableItems{"key": "k2"}),
+		op(structs.SVOpCAS, "creds/secret", createIndex-1, structs.SecureVariableItems{"secret": "s2"}),
+	)
+	must.NoError(t, err)
+	must.Eq(t, structs.SVOpResultConflict, resp.Result)
+	must.Eq(t, 1, resp.ConflictIndex)
+	must.Eq(t, createIndex, resp.Conflict.ModifyIndex)
+	must.Eq(t, structs.SecureVariableItems{"secret": "s1"}, resp.Conflict.Items)
+	must.Nil(t, resp.Outputs)
+	must.Eq(t, createIndex, read("creds/key").ModifyIndex)
+
+	// Rotate the pair, checking another variable wasn't created meanwhile
+	resp, err = txn(rootToken.SecretID,
+		op(structs.SVOpCAS, "creds/key", createIndex, structs.SecureVariableItems{"key": "k2"}),
+		op(structs.SVOpCAS, "creds/secret", createIndex, structs.SecureVariableItems{"secret": "s2"}),
+		op(structs.SVOpCheckIndex, "creds/lock", 0, nil),
+	)
+	must.NoError(t, err)
+	must.Eq(t, structs.SVOpResultOk, resp.Result)
+	must.Nil(t, resp.Outputs[2])
+	must.Eq(t, resp.Index, read("creds/key").ModifyIndex)
+	must.Eq(t, resp.Index, read("creds/secret").ModifyIndex)
+	must.Nil(t, read("creds/lock"))
+	rotateIndex := resp.Index
+
+	// Tokens must be allowed every operation
+	_, err = txn(writeToken.SecretID,
+		op(structs.SVOpSet, "creds/key", 0, structs.SecureVariableItems{"key": "k3"}),
+		op(structs.SVOpDelete, "creds/secret", 0, nil),
+	)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+	must.Eq(t, rotateIndex, read("creds/key").ModifyIndex)
+
+	// Conflicts are redacted for tokens which can't read the variable
+	resp, err = txn(writeToken.SecretID,
+		op(structs.SVOpCAS, "creds/key", createIndex, structs.SecureVariableItems{"key": "k3"}),
+	)
+	must.NoError(t, err)
+	must.Eq(t, structs.SVOpResultRedacted, resp.Result)
+	must.Eq(t, rotateIndex, resp.Conflict.ModifyIndex)
+	must.Nil(t, resp.Conflict.Items)
+
+	// Delete the pair
+	resp, err = txn(rootToken.SecretID,
+		op(structs.SVOpDeleteCAS, "creds/key", rotateIndex, nil),
+		op(structs.SVOpDelete, "creds/secret", 0, nil),
+	)
+	must.NoError(t, err)
+	must.Eq(t, structs.SVOpResultOk, resp.Result)
+	must.Nil(t, read("creds/key"))
+	must.Nil(t, read("creds/secret"))
+}
+
+func TestSecureVariablesEndpoint_ExchangeIdentity(t *testing.T) {
+	ci.Parallel(t)
+	srv, rootToken, shutdown := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+	store := srv.fsm.State()
+
+	alloc := mock.Alloc()
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	must.NoError(t, store.UpsertAllocs(
+		structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc}))
+
+	// attach a policy to the job so that the workload identity can read
+	// more than its own paths
+	policy := mock.ACLPolicy()
+	policy.Rules = `namespace "default" {
+		secure_variables {
+		    path "other/path" { capabilities = ["read"] }
+		}}`
+	policy.JobACL = &structs.JobACL{
+		Namespace: alloc.Namespace,
+		JobID:     alloc.JobID,
+	}
+	policy.SetHash()
+	must.NoError(t, store.UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 1100, []*structs.ACLPolicy{policy}))
+
+	idToken, err := srv.encrypter.SignClaims(alloc.ToTaskIdentityClaims(nil, "web"))
+	must.NoError(t, err)
+
+	exchange := func(token string) (*structs.SecureVariablesExchangeIdentityResponse, error) {
+		req := &structs.SecureVariablesExchangeIdentityRequest{
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				AuthToken: token,
+			},
+		}
+		var resp structs.SecureVariablesExchangeIdentityResponse
+		err := msgpackrpc.CallWithCodec(codec,
+			structs.SecureVariablesExchangeIdentityRPCMethod, req, &resp)
+		return &resp, err
+	}
+
+	read := func(token, path string) error {
+		_, err := srv.staticEndpoints.SecureVariables.handleMixedAuthEndpoint(
+			structs.QueryOptions{AuthToken: token, Namespace: alloc.Namespace},
+			acl.SecureVariablesCapabilityRead, path)
+		return err
+	}
+
+	ownPath := fmt.Sprintf("nomad/jobs/%s/web/web", alloc.JobID)
+
+	t.Run("ACL tokens cannot be exchanged", func(t *testing.T) {
+		_, err := exchange(rootToken.SecretID)
+		must.EqError(t, err, structs.ErrPermissionDenied.Error())
+	})
+
+	var scopedToken string
+	t.Run("workload identity is exchanged for a scoped token", func(t *testing.T) {
+		resp, err := exchange(idToken)
+		must.NoError(t, err)
+		must.NotEq(t, "", resp.Token)
+		must.NotEq(t, idToken, resp.Token)
+		must.Eq(t, []string{
+			"nomad/jobs/" + alloc.JobID,
+			"nomad/jobs/" + alloc.JobID + "/web",
+			"nomad/jobs/" + alloc.JobID + "/web/web",
+		}, resp.Paths)
+		must.True(t, resp.ExpiresAt.After(time.Now()))
+		must.True(t, resp.ExpiresAt.Before(
+			time.Now().Add(structs.SecureVariablesIdentityTTL+time.Minute)))
+		scopedToken = resp.Token
+
+		claims, err := srv.VerifyClaim(scopedToken)
+		must.NoError(t, err)
+		must.Eq(t, structs.IdentityScopeSecureVariablesRead, claims.Scope)
+		must.Eq(t, alloc.ID, claims.AllocationID)
+	})
+
+	t.Run("scoped token only reads workload paths", func(t *testing.T) {
+		must.NoError(t, read(scopedToken, ownPath))
+		must.EqError(t, read(scopedToken, "other/path"),
+			structs.ErrPermissionDenied.Error())
+
+		// the original identity still gets the job's policies
+		must.NoError(t, read(idToken, "other/path"))
+	})
+
+	t.Run("scoped token can be renewed", func(t *testing.T) {
+		resp, err := exchange(scopedToken)
+		must.NoError(t, err)
+		must.NotEq(t, "", resp.Token)
+	})
+
+	t.Run("expired token is denied", func(t *testing.T) {
+		claims := alloc.ToTaskIdentityClaims(nil, "web")
+		claims.Scope = structs.IdentityScopeSecureVariablesRead
+		claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+		expired, err := srv.encrypter.SignClaims(claims)
+		must.NoError(t, err)
+
+		_, err = exchange(expired)
+		must.EqError(t, err, structs.ErrPermissionDenied.Error())
+		must.EqError(t, read(expired, ownPath), structs.ErrPermissionDenied.Error())
+	})
+
+	t.Run("completed alloc is denied", func(t *testing.T) {
+		completed := alloc.Copy()
+		completed.ClientStatus = structs.AllocClientStatusComplete
+		must.NoError(t, store.UpdateAllocsFromClient(
+			structs.MsgTypeTestSetup, 1150, []*structs.Allocation{completed}))
+
+		_, err := exchange(idToken)
+		must.EqError(t, err, structs.ErrPermissionDenied.Error())
+		_, err = exchange(scopedToken)
+		must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+		// put the alloc back for the following cases
+		must.NoError(t, store.UpdateAllocsFromClient(
+			structs.MsgTypeTestSetup, 1160, []*structs.Allocation{alloc.Copy()}))
+		_, err = exchange(idToken)
+		must.NoError(t, err)
+	})
+
+	t.Run("terminal alloc is denied", func(t *testing.T) {
+		stopped := alloc.Copy()
+		stopped.DesiredStatus = structs.AllocDesiredStatusStop
+		must.NoError(t, store.UpsertAllocs(
+			structs.MsgTypeTestSetup, 1200, []*structs.Allocation{stopped}))
+
+		_, err := exchange(idToken)
+		must.EqError(t, err, structs.ErrPermissionDenied.Error())
+		must.EqError(t, read(scopedToken, ownPath), structs.ErrPermissionDenied.Error())
+	})
+}
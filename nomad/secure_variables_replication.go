@@ -0,0 +1,344 @@
+package nomad
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/mlock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"golang.org/x/crypto/nacl/box"
+	"golang.org/x/time/rate"
+)
+
+// secureVariablesReplicationRegionsInterval is how often the leader checks for
+// new regions to replicate secure variables from.
+const secureVariablesReplicationRegionsInterval = time.Minute
+
+// secureVariablesReplication tracks the status of the replication of secure
+// variables from the other regions. It is reset whenever the server is
+// elected, so the status only covers the current leadership term.
+type secureVariablesReplication struct {
+	sources map[string]*structs.SecureVariablesReplicationStatus
+	lock    sync.Mutex
+}
+
+func newSecureVariablesReplication() *secureVariablesReplication {
+	return &secureVariablesReplication{
+		sources: map[string]*structs.SecureVariablesReplicationStatus{},
+	}
+}
+
+func (r *secureVariablesReplication) reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.sources = map[string]*structs.SecureVariablesReplicationStatus{}
+}
+
+// status returns a copy of the status of each source region, sorted by
+// region.
+func (r *secureVariablesReplication) status() []*structs.SecureVariablesReplicationStatus {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	out := make([]*structs.SecureVariablesReplicationStatus, 0, len(r.sources))
+	for _, source := range r.sources {
+		s := *source
+		out = append(out, &s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Region < out[j].Region })
+	return out
+}
+
+// update applies fn to the status of the source region.
+func (r *secureVariablesReplication) update(region string, fn func(*structs.SecureVariablesReplicationStatus)) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	source, ok := r.sources[region]
+	if !ok {
+		source = &structs.SecureVariablesReplicationStatus{Region: region}
+		r.sources[region] = source
+	}
+	fn(source)
+}
+
+// replicateSecureVariables is a long lived function that starts replicating
+// secure variables from each of the other regions as they join the
+// federation, until the server loses leadership.
+func (s *Server) replicateSecureVariables(stopCh chan struct{}) {
+	s.secureVariablesReplication.reset()
+
+	started := map[string]struct{}{}
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-timer.C:
+			timer.Reset(secureVariablesReplicationRegionsInterval)
+			for _, region := range s.Regions() {
+				if _, ok := started[region]; ok || region == s.config.Region {
+					continue
+				}
+				started[region] = struct{}{}
+				go s.replicateSecureVariablesFrom(region, stopCh)
+			}
+		}
+	}
+}
+
+// replicateSecureVariablesFrom is used to replicate the secure variables of
+// the namespaces that replicate them to this region from the source region,
+// along with the root keys that encrypt them.
+func (s *Server) replicateSecureVariablesFrom(region string, stopCh chan struct{}) {
+	req := structs.SecureVariablesReplicateRequest{
+		TargetRegion: s.config.Region,
+		QueryOptions: structs.QueryOptions{
+			Region:     region,
+			AllowStale: true,
+		},
+	}
+	limiter := rate.NewLimiter(replicationRateLimit, int(replicationRateLimit))
+	s.logger.Debug("starting secure variables replication", "region", region)
+
+START:
+	for {
+		select {
+		case <-stopCh:
+			return
+		default:
+		}
+
+		// Rate limit how often we attempt replication
+		limiter.Wait(context.Background())
+
+		// Fetch the secure variables replicated to this region
+		var resp structs.SecureVariablesReplicateResponse
+		req.AuthToken = s.ReplicationToken()
+		err := s.forwardRegion(region, structs.SecureVariablesReplicateRPCMethod, &req, &resp)
+		if err != nil {
+			s.logger.Error("failed to fetch secure variables from region", "region", region, "error", err)
+			s.secureVariablesReplication.update(region, func(status *structs.SecureVariablesReplicationStatus) {
+				status.Error = err.Error()
+			})
+			goto ERR_WAIT
+		}
+
+		// Update the local replicas
+		newest, err := s.applyReplicatedSecureVariables(region, resp.Data)
+		if err != nil {
+			s.logger.Error("failed to replicate secure variables from region", "region", region, "error", err)
+			s.secureVariablesReplication.update(region, func(status *structs.SecureVariablesReplicationStatus) {
+				status.Error = err.Error()
+			})
+			goto ERR_WAIT
+		}
+
+		now := time.Now()
+		s.secureVariablesReplication.update(region, func(status *structs.SecureVariablesReplicationStatus) {
+			status.Index = resp.Index
+			status.Variables = len(resp.Data)
+			status.LastReplicated = now.UnixNano()
+			status.Error = ""
+			if newest != 0 {
+				status.Lag = helper.Max(0, now.Sub(time.Unix(0, newest)))
+			}
+		})
+
+		// Update the minimum query index, blocks until there is a change.
+		req.MinQueryIndex = resp.Index
+	}
+
+ERR_WAIT:
+	select {
+	case <-time.After(s.config.ReplicationBackoff):
+		goto START
+	case <-stopCh:
+		return
+	}
+}
+
+// applyReplicatedSecureVariables performs a two-way diff between the secure
+// variables replicated from the source region and the remote ones, and
+// applies the difference. The root keys of the remote secure variables are
+// installed first, as inactive keys, so that the replicas can be decrypted.
+// It returns the newest ModifyTime of the secure variables it wrote, or 0 if
+// it didn't write any.
+func (s *Server) applyReplicatedSecureVariables(region string, remote []*structs.SecureVariableEncrypted) (int64, error) {
+	snap, err := s.fsm.State().Snapshot()
+	if err != nil {
+		return 0, err
+	}
+
+	// Install the missing root keys
+	for _, sv := range remote {
+		keyMeta, err := snap.RootKeyMetaByID(nil, sv.KeyID)
+		if err != nil {
+			return 0, err
+		}
+		if keyMeta != nil {
+			continue
+		}
+		if err := s.replicateRootKey(region, sv.KeyID); err != nil {
+			return 0, err
+		}
+		snap, err = s.fsm.State().Snapshot()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// Find the existing replicas from the region
+	local := map[structs.NamespacedID]*structs.SecureVariableEncrypted{}
+	iter, err := snap.SecureVariables(nil)
+	if err != nil {
+		return 0, err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		sv := raw.(*structs.SecureVariableEncrypted)
+		if sv.SourceRegion == region {
+			local[structs.NamespacedID{Namespace: sv.Namespace, ID: sv.Path}] = sv
+		}
+	}
+
+	var ops []*structs.SVTxnStateOp
+	var newest int64
+	for _, sv := range remote {
+		id := structs.NamespacedID{Namespace: sv.Namespace, ID: sv.Path}
+		existing, ok := local[id]
+		delete(local, id)
+		if ok && existing.ModifyTime == sv.ModifyTime && existing.KeyID == sv.KeyID &&
+			bytes.Equal(existing.Data, sv.Data) && helper.CompareMapStringString(existing.Meta, sv.Meta) {
+			continue
+		}
+
+		if !ok {
+			ns, err := snap.NamespaceByName(nil, sv.Namespace)
+			if err != nil {
+				return 0, err
+			}
+			if ns == nil {
+				s.logger.Warn("skipping replicated secure variable in missing namespace",
+					"region", region, "namespace", sv.Namespace, "path", sv.Path)
+				continue
+			}
+
+			// Never overwrite a secure variable written in this region or
+			// replicated from another one, so the first writer wins
+			current, err := snap.GetSecureVariable(nil, sv.Namespace, sv.Path)
+			if err != nil {
+				return 0, err
+			}
+			if current != nil {
+				s.logger.Warn("skipping replicated secure variable which already exists",
+					"region", region, "source_region", current.SourceRegion,
+					"namespace", sv.Namespace, "path", sv.Path)
+				continue
+			}
+		}
+
+		replica := sv.Copy()
+		replica.SourceRegion = region
+		replica.CreateIndex = 0
+		replica.ModifyIndex = 0
+		ops = append(ops, &structs.SVTxnStateOp{Op: structs.SVOpSet, Var: &replica})
+		newest = helper.Max(newest, sv.ModifyTime)
+	}
+
+	// Delete the replicas that are no longer replicated
+	for _, sv := range local {
+		ops = append(ops, &structs.SVTxnStateOp{
+			Op: structs.SVOpDelete,
+			Var: &structs.SecureVariableEncrypted{
+				SecureVariableMetadata: structs.SecureVariableMetadata{
+					Namespace:    sv.Namespace,
+					Path:         sv.Path,
+					SourceRegion: region,
+				},
+			},
+		})
+	}
+
+	// Apply the changes in batches, so that replicating many secure
+	// variables doesn't produce oversized raft log entries
+	for len(ops) > 0 {
+		batch := ops[:helper.Min(len(ops), structs.SecureVariablesPurgeBatchSize)]
+		ops = ops[len(batch):]
+
+		out, _, err := s.raftApply(structs.SVTxnRequestType, &structs.SVTxnStateRequest{Ops: batch})
+		if err != nil {
+			return 0, err
+		}
+		if resp := out.(*structs.SVTxnStateResponse); resp.Result != structs.SVOpResultOk {
+			if resp.Error != nil {
+				return 0, resp.Error
+			}
+			return 0, fmt.Errorf("unexpected result %q", resp.Result)
+		}
+	}
+
+	return newest, nil
+}
+
+// replicateRootKey fetches a root key from the source region and installs it
+// in the keyring as an inactive key, which is only used to decrypt the secure
+// variables replicated from that region. The source region seals the key
+// material to an ephemeral key pair of this server, so it never crosses
+// regions in the clear.
+func (s *Server) replicateRootKey(region, keyID string) error {
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return fmt.Errorf("failed to generate replication key pair: %v", err)
+	}
+	defer mlock.Zero(privateKey[:])
+
+	getReq := &structs.KeyringGetWrappedRootKeyRequest{
+		KeyID:     keyID,
+		PublicKey: publicKey[:],
+		QueryOptions: structs.QueryOptions{
+			Region: region,
+		},
+	}
+	var getResp structs.KeyringGetWrappedRootKeyResponse
+	if err := s.forwardRegion(region, "Keyring.GetWrapped", getReq, &getResp); err != nil {
+		return fmt.Errorf("failed to fetch root key %s: %v", keyID, err)
+	}
+	if getResp.Meta == nil {
+		return fmt.Errorf("root key %s not found", keyID)
+	}
+
+	key, err := unwrapRootKey(getResp.WrappedKey, publicKey, privateKey)
+	if err != nil {
+		return fmt.Errorf("failed to unwrap root key %s: %v", keyID, err)
+	}
+	defer mlock.Zero(key)
+
+	meta := getResp.Meta.Copy()
+	meta.SetInactive()
+	meta.CreateIndex = 0
+	meta.ModifyIndex = 0
+
+	updateReq := &structs.KeyringUpdateRootKeyRequest{
+		RootKey: &structs.RootKey{
+			Meta: meta,
+			Key:  key,
+		},
+		WriteRequest: structs.WriteRequest{
+			Region:    s.config.Region,
+			AuthToken: s.getLeaderAcl(),
+		},
+	}
+	if err := s.RPC("Keyring.Update", updateReq, &structs.KeyringUpdateRootKeyResponse{}); err != nil {
+		return fmt.Errorf("failed to install root key %s: %v", keyID, err)
+	}
+	s.logger.Info("replicated root key", "region", region, "key_id", keyID)
+	return nil
+}
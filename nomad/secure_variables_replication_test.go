@@ -0,0 +1,230 @@
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecureVariablesEndpoint_Replicate(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+	store := srv.fsm.State()
+
+	replicated := mock.Namespace()
+	replicated.SecureVariables = &structs.NamespaceSecureVariablesConfig{
+		ReplicationTargets: []string{"east"},
+	}
+	other := mock.Namespace()
+	require.NoError(t, store.UpsertNamespaces(1000,
+		[]*structs.Namespace{replicated, other}))
+
+	index := uint64(1000)
+	writeVar := func(namespace, path, sourceRegion string) {
+		sv := mock.SecureVariableEncrypted()
+		sv.Namespace = namespace
+		sv.Path = path
+		sv.SourceRegion = sourceRegion
+		index++
+		resp := store.SVESet(index, &structs.SVApplyStateRequest{
+			Op:  structs.SVOpSet,
+			Var: sv,
+		})
+		require.NoError(t, resp.Error)
+	}
+	writeVar(replicated.Name, "a", "")
+	writeVar(replicated.Name, "b", "")
+	writeVar(replicated.Name, "replica", "west")
+	writeVar(other.Name, "c", "")
+
+	replicate := func(target string) (*structs.SecureVariablesReplicateResponse, error) {
+		req := &structs.SecureVariablesReplicateRequest{
+			TargetRegion: target,
+			QueryOptions: structs.QueryOptions{Region: "global"},
+		}
+		var resp structs.SecureVariablesReplicateResponse
+		err := msgpackrpc.CallWithCodec(codec, structs.SecureVariablesReplicateRPCMethod, req, &resp)
+		return &resp, err
+	}
+
+	_, err := replicate("")
+	require.ErrorContains(t, err, "target region is required")
+
+	// Only the local secure variables of the namespaces replicated to the
+	// target region are returned
+	resp, err := replicate("east")
+	require.NoError(t, err)
+	require.Equal(t, index, resp.Index)
+	paths := []string{}
+	for _, sv := range resp.Data {
+		paths = append(paths, sv.Path)
+	}
+	require.ElementsMatch(t, []string{"a", "b"}, paths)
+
+	resp, err = replicate("south")
+	require.NoError(t, err)
+	require.Empty(t, resp.Data)
+}
+
+func TestSecureVariablesReplication_Apply(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+	store := srv.fsm.State()
+
+	ns := mock.Namespace()
+	require.NoError(t, store.UpsertNamespaces(1000, []*structs.Namespace{ns}))
+
+	// Use a root key this region already has, so no key is fetched from the
+	// source region
+	data, keyID, err := srv.encrypter.Encrypt([]byte("data"))
+	require.NoError(t, err)
+	remoteVar := func(namespace, path string, modifyTime int64) *structs.SecureVariableEncrypted {
+		sv := mock.SecureVariableEncrypted()
+		sv.Namespace = namespace
+		sv.Path = path
+		sv.KeyID = keyID
+		sv.Data = data
+		sv.ModifyTime = modifyTime
+		sv.CreateIndex = 50
+		sv.ModifyIndex = 50
+		return sv
+	}
+
+	local := remoteVar(ns.Name, "local", 0)
+	local.Data = []byte("local")
+	resp := store.SVESet(1001, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpSet,
+		Var: local,
+	})
+	require.NoError(t, resp.Error)
+
+	now := time.Now().UnixNano()
+	remote := []*structs.SecureVariableEncrypted{
+		remoteVar(ns.Name, "x", now-int64(time.Hour)),
+		remoteVar(ns.Name, "y", now),
+		remoteVar(ns.Name, "local", now),
+		remoteVar("missing", "z", now),
+	}
+	newest, err := srv.applyReplicatedSecureVariables("east", remote)
+	require.NoError(t, err)
+	require.Equal(t, now, newest)
+
+	get := func(path string) *structs.SecureVariableEncrypted {
+		sv, err := store.GetSecureVariable(nil, ns.Name, path)
+		require.NoError(t, err)
+		return sv
+	}
+
+	// The replicas are written, but never over existing secure variables
+	// nor in missing namespaces
+	x := get("x")
+	require.NotNil(t, x)
+	require.Equal(t, "east", x.SourceRegion)
+	require.NotEqual(t, uint64(50), x.CreateIndex)
+	require.NotNil(t, get("y"))
+	require.Equal(t, "", get("local").SourceRegion)
+	require.Equal(t, []byte("local"), get("local").Data)
+	missing, err := store.GetSecureVariable(nil, "missing", "z")
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	// Unchanged replicas are left alone, changed ones are updated and
+	// removed ones are deleted
+	xIndex := x.ModifyIndex
+	updated := remoteVar(ns.Name, "y", now+1)
+	newest, err = srv.applyReplicatedSecureVariables("east", []*structs.SecureVariableEncrypted{
+		remoteVar(ns.Name, "x", now-int64(time.Hour)),
+		updated,
+	})
+	require.NoError(t, err)
+	require.Equal(t, now+1, newest)
+	require.Equal(t, xIndex, get("x").ModifyIndex)
+	require.Equal(t, now+1, get("y").ModifyTime)
+
+	newest, err = srv.applyReplicatedSecureVariables("east", nil)
+	require.NoError(t, err)
+	require.Zero(t, newest)
+	require.Nil(t, get("x"))
+	require.Nil(t, get("y"))
+	require.NotNil(t, get("local"))
+}
+
+func TestSecureVariablesReplication_Status(t *testing.T) {
+	ci.Parallel(t)
+
+	r := newSecureVariablesReplication()
+	require.Empty(t, r.status())
+
+	r.update("west", func(status *structs.SecureVariablesReplicationStatus) {
+		status.Index = 10
+	})
+	r.update("east", func(status *structs.SecureVariablesReplicationStatus) {
+		status.Error = "failed"
+	})
+
+	status := r.status()
+	require.Len(t, status, 2)
+	require.Equal(t, "east", status[0].Region)
+	require.Equal(t, "failed", status[0].Error)
+	require.Equal(t, "west", status[1].Region)
+	require.Equal(t, uint64(10), status[1].Index)
+
+	// The status is a copy
+	status[1].Index = 20
+	require.Equal(t, uint64(10), r.status()[1].Index)
+
+	r.reset()
+	require.Empty(t, r.status())
+}
+
+func TestSecureVariablesReplication_RootKey(t *testing.T) {
+	ci.Parallel(t)
+
+	west, shutdownWest := TestServer(t, func(c *Config) {
+		c.Region = "west"
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdownWest()
+	east, shutdownEast := TestServer(t, func(c *Config) {
+		c.Region = "east"
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdownEast()
+	TestJoin(t, west, east)
+	testutil.WaitForLeader(t, west.RPC)
+	testutil.WaitForLeader(t, east.RPC)
+
+	data, keyID, err := west.encrypter.Encrypt([]byte("data"))
+	require.NoError(t, err)
+
+	require.NoError(t, east.replicateRootKey("west", keyID))
+
+	// The replicated key is inactive and decrypts what the source encrypted
+	meta, err := east.fsm.State().RootKeyMetaByID(nil, keyID)
+	require.NoError(t, err)
+	require.NotNil(t, meta)
+	require.Equal(t, structs.RootKeyStateInactive, meta.State)
+	plaintext, err := east.encrypter.Decrypt(data, keyID)
+	require.NoError(t, err)
+	require.Equal(t, []byte("data"), plaintext)
+
+	require.ErrorContains(t, east.replicateRootKey("west", uuid.Generate()), "not found")
+}
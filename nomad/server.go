@@ -42,6 +42,8 @@ import (
 	raftboltdb "github.com/hashicorp/raft-boltdb/v2"
 	"github.com/hashicorp/serf/serf"
 	"go.etcd.io/bbolt"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 const (
@@ -222,6 +224,14 @@ type Server struct {
 	// encrypter is the keyring for secure variables
 	encrypter *Encrypter
 
+	// keyringIntegrity tracks the leader's integrity checks of the
+	// encrypted secure variables
+	keyringIntegrity *keyringIntegrity
+
+	// secureVariablesReplication tracks the leader's replication of secure
+	// variables from the other regions
+	secureVariablesReplication *secureVariablesReplication
+
 	// periodicDispatcher is used to track and create evaluations for periodic jobs.
 	periodicDispatcher *PeriodicDispatch
 
@@ -307,6 +317,7 @@ type endpoints struct {
 	Agent             *Agent
 	ClientAllocations *ClientAllocations
 	ClientCSI         *ClientCSI
+	NodeMeta          *NodeMeta
 }
 
 // NewServer is used to construct a new Nomad server from the
@@ -411,6 +422,8 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigEntr
 		return nil, err
 	}
 	s.encrypter = encrypter
+	s.keyringIntegrity = newKeyringIntegrity()
+	s.secureVariablesReplication = newSecureVariablesReplication()
 
 	// Initialize the RPC layer
 	if err := s.setupRPC(tlsWrap); err != nil {
@@ -489,6 +502,9 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigEntr
 	// Emit metrics for the planner's bad node tracker.
 	go s.planner.badNodeTracker.EmitStats(time.Second, s.shutdownCh)
 
+	// Emit metrics for the plan applier's load.
+	go s.planner.backpressure.EmitStats(time.Second, s.shutdownCh)
+
 	// Emit metrics for the blocked eval tracker.
 	go s.blockedEvals.EmitStats(time.Second, s.shutdownCh)
 
@@ -1229,6 +1245,7 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) error {
 		s.staticEndpoints.ClientAllocations = &ClientAllocations{srv: s, logger: s.logger.Named("client_allocs")}
 		s.staticEndpoints.ClientAllocations.register()
 		s.staticEndpoints.ClientCSI = &ClientCSI{srv: s, logger: s.logger.Named("client_csi")}
+		s.staticEndpoints.NodeMeta = &NodeMeta{srv: s, logger: s.logger.Named("node_meta")}
 
 		// Streaming endpoints
 		s.staticEndpoints.FileSystem = &FileSystem{srv: s, logger: s.logger.Named("client_fs")}
@@ -1258,6 +1275,7 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) error {
 	server.Register(s.staticEndpoints.ClientStats)
 	server.Register(s.staticEndpoints.ClientAllocations)
 	server.Register(s.staticEndpoints.ClientCSI)
+	server.Register(s.staticEndpoints.NodeMeta)
 	server.Register(s.staticEndpoints.FileSystem)
 	server.Register(s.staticEndpoints.Agent)
 	server.Register(s.staticEndpoints.Namespace)
@@ -1576,9 +1594,16 @@ func shouldReloadSchedulers(s *Server, newPoolArgs *SchedulerWorkerPoolArgs) (bo
 		return true, newPoolArgs
 	}
 
+	if !maps.Equal(s.config.DedicatedSchedulers, newPoolArgs.DedicatedSchedulers) {
+		return true, newPoolArgs
+	}
+
 	oldSchedulers := make([]string, len(s.config.EnabledSchedulers))
 	copy(oldSchedulers, s.config.EnabledSchedulers)
 	sort.Strings(oldSchedulers)
+	if len(oldSchedulers) != len(newSchedulers) {
+		return true, newPoolArgs
+	}
 
 	for i, v := range newSchedulers {
 		if oldSchedulers[i] != v {
@@ -1589,12 +1614,17 @@ func shouldReloadSchedulers(s *Server, newPoolArgs *SchedulerWorkerPoolArgs) (bo
 	return false, nil
 }
 
-// SchedulerWorkerPoolArgs are the two key configuration options for a Nomad server's
+// SchedulerWorkerPoolArgs are the key configuration options for a Nomad server's
 // scheduler worker pool. Before using, you should always verify that they are rational
 // using IsValid() or IsInvalid()
 type SchedulerWorkerPoolArgs struct {
 	NumSchedulers     int
 	EnabledSchedulers []string
+
+	// DedicatedSchedulers maps a scheduler type to the number of workers,
+	// out of NumSchedulers, that only dequeue evaluations of that type. The
+	// remaining workers dequeue every enabled type.
+	DedicatedSchedulers map[string]int
 }
 
 // IsInvalid returns true when the SchedulerWorkerPoolArgs.IsValid is false
@@ -1623,16 +1653,30 @@ func (swpa SchedulerWorkerPoolArgs) IsValid() bool {
 			return false // found an unknown scheduler in the list; bailing out
 		}
 	}
+	if !foundCore {
+		return false
+	}
 
-	return foundCore
+	// dedicated workers must be for enabled schedulers and leave at least
+	// one shared worker for _core and the other schedulers
+	dedicated := 0
+	for sched, n := range swpa.DedicatedSchedulers {
+		if n < 0 || !slices.Contains(swpa.EnabledSchedulers, sched) {
+			return false
+		}
+		dedicated += n
+	}
+
+	return dedicated == 0 || dedicated < swpa.NumSchedulers
 }
 
 // Copy returns a clone of a SchedulerWorkerPoolArgs struct. Concurrent access
 // concerns should be managed by the caller.
 func (swpa SchedulerWorkerPoolArgs) Copy() SchedulerWorkerPoolArgs {
 	out := SchedulerWorkerPoolArgs{
-		NumSchedulers:     swpa.NumSchedulers,
-		EnabledSchedulers: make([]string, len(swpa.EnabledSchedulers)),
+		NumSchedulers:       swpa.NumSchedulers,
+		EnabledSchedulers:   make([]string, len(swpa.EnabledSchedulers)),
+		DedicatedSchedulers: helper.CopyMap(swpa.DedicatedSchedulers),
 	}
 	copy(out.EnabledSchedulers, swpa.EnabledSchedulers)
 
@@ -1641,8 +1685,9 @@ func (swpa SchedulerWorkerPoolArgs) Copy() SchedulerWorkerPoolArgs {
 
 func getSchedulerWorkerPoolArgsFromConfigLocked(c *Config) *SchedulerWorkerPoolArgs {
 	return &SchedulerWorkerPoolArgs{
-		NumSchedulers:     c.NumSchedulers,
-		EnabledSchedulers: c.EnabledSchedulers,
+		NumSchedulers:       c.NumSchedulers,
+		EnabledSchedulers:   c.EnabledSchedulers,
+		DedicatedSchedulers: c.DedicatedSchedulers,
 	}
 }
 
@@ -1695,6 +1740,7 @@ func reloadSchedulers(s *Server, newArgs *SchedulerWorkerPoolArgs) {
 	// TODO: If EnabledSchedulers didn't change, we can scale rather than drain and rebuild
 	s.config.NumSchedulers = newArgs.NumSchedulers
 	s.config.EnabledSchedulers = newArgs.EnabledSchedulers
+	s.config.DedicatedSchedulers = newArgs.DedicatedSchedulers
 	s.setupNewWorkersLocked()
 }
 
@@ -1736,19 +1782,50 @@ func (s *Server) setupWorkersLocked(ctx context.Context, poolArgs SchedulerWorke
 		return fmt.Errorf("invalid configuration: %q scheduler not enabled", structs.JobTypeCore)
 	}
 
-	s.logger.Info("starting scheduling worker(s)", "num_workers", poolArgs.NumSchedulers, "schedulers", poolArgs.EnabledSchedulers)
-	// Start the workers
+	// Dedicated workers are carved out of the pool so that a flood of one
+	// type of evaluation can't starve the others.
+	dedicated := 0
+	for sched, n := range poolArgs.DedicatedSchedulers {
+		if n < 0 || !slices.Contains(poolArgs.EnabledSchedulers, sched) {
+			return fmt.Errorf("invalid configuration: dedicated scheduler %q is not enabled", sched)
+		}
+		dedicated += n
+	}
+	if dedicated > 0 && dedicated >= poolArgs.NumSchedulers {
+		return fmt.Errorf("invalid configuration: %d dedicated schedulers leave none of the %d available for %q and the other schedulers",
+			dedicated, poolArgs.NumSchedulers, structs.JobTypeCore)
+	}
+
+	s.logger.Info("starting scheduling worker(s)", "num_workers", poolArgs.NumSchedulers,
+		"schedulers", poolArgs.EnabledSchedulers, "dedicated", poolArgs.DedicatedSchedulers)
+
+	// Start the workers, dedicated ones first
+	workerArgs := make([]SchedulerWorkerPoolArgs, 0, poolArgs.NumSchedulers)
+	scheds := make([]string, 0, len(poolArgs.DedicatedSchedulers))
+	for sched := range poolArgs.DedicatedSchedulers {
+		scheds = append(scheds, sched)
+	}
+	sort.Strings(scheds)
+	for _, sched := range scheds {
+		for i := 0; i < poolArgs.DedicatedSchedulers[sched]; i++ {
+			workerArgs = append(workerArgs, SchedulerWorkerPoolArgs{EnabledSchedulers: []string{sched}})
+		}
+	}
+	for len(workerArgs) < poolArgs.NumSchedulers {
+		workerArgs = append(workerArgs, poolArgs)
+	}
 
-	for i := 0; i < s.config.NumSchedulers; i++ {
-		if w, err := NewWorker(ctx, s, poolArgs); err != nil {
+	for i, args := range workerArgs {
+		if w, err := NewWorker(ctx, s, args); err != nil {
 			return err
 		} else {
-			s.logger.Debug("started scheduling worker", "id", w.ID(), "index", i+1, "of", s.config.NumSchedulers)
+			s.logger.Debug("started scheduling worker", "id", w.ID(), "index", i+1, "of", poolArgs.NumSchedulers,
+				"schedulers", args.EnabledSchedulers)
 
 			s.workers = append(s.workers, w)
 		}
 	}
-	s.logger.Info("started scheduling worker(s)", "num_workers", s.config.NumSchedulers, "schedulers", s.config.EnabledSchedulers)
+	s.logger.Info("started scheduling worker(s)", "num_workers", poolArgs.NumSchedulers, "schedulers", poolArgs.EnabledSchedulers)
 	return nil
 }
 
@@ -605,6 +605,87 @@ func TestServer_ReloadSchedulers_EnabledSchedulers(t *testing.T) {
 
 }
 
+func TestServer_ReloadSchedulers_DedicatedSchedulers(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 4
+	})
+	defer cleanupS1()
+
+	config := DefaultConfig()
+	config.NumSchedulers = 4
+	config.DedicatedSchedulers = map[string]int{
+		structs.JobTypeService: 2,
+		structs.JobTypeBatch:   1,
+	}
+	require.NoError(t, s1.Reload(config))
+
+	time.Sleep(1 * time.Second)
+	require.Equal(t, config.DedicatedSchedulers, s1.GetSchedulerWorkerConfig().DedicatedSchedulers)
+
+	byType := map[string]int{}
+	general := 0
+	for _, info := range s1.GetSchedulerWorkersInfo() {
+		if len(info.EnabledSchedulers) == 1 {
+			byType[info.EnabledSchedulers[0]]++
+		} else {
+			require.ElementsMatch(t, config.EnabledSchedulers, info.EnabledSchedulers)
+			general++
+		}
+	}
+	require.Equal(t, config.DedicatedSchedulers, byType)
+	require.Equal(t, 1, general)
+}
+
+func TestSchedulerWorkerPoolArgs_IsValid_Dedicated(t *testing.T) {
+	ci.Parallel(t)
+
+	args := SchedulerWorkerPoolArgs{
+		NumSchedulers:     2,
+		EnabledSchedulers: []string{structs.JobTypeCore, structs.JobTypeService},
+	}
+	require.True(t, args.IsValid())
+
+	args.DedicatedSchedulers = map[string]int{structs.JobTypeService: 1}
+	require.True(t, args.IsValid())
+
+	// no shared worker left for _core
+	args.DedicatedSchedulers = map[string]int{structs.JobTypeService: 2}
+	require.False(t, args.IsValid())
+
+	// more dedicated workers than the pool
+	args.DedicatedSchedulers = map[string]int{structs.JobTypeService: 3}
+	require.False(t, args.IsValid())
+
+	// dedicated to a scheduler that isn't enabled
+	args.DedicatedSchedulers = map[string]int{structs.JobTypeBatch: 1}
+	require.False(t, args.IsValid())
+
+	args.DedicatedSchedulers = map[string]int{structs.JobTypeService: -1}
+	require.False(t, args.IsValid())
+}
+
+func TestServer_SetupWorkers_DedicatedSchedulersLeaveShared(t *testing.T) {
+	ci.Parallel(t)
+
+	config := DefaultConfig()
+	s := &Server{
+		config: config,
+		logger: testlog.HCLogger(t),
+	}
+	s.shutdownCtx, s.shutdownCancel = context.WithCancel(context.Background())
+	defer s.shutdownCancel()
+
+	// Dedicating every worker would leave none to run _core evaluations
+	config.NumSchedulers = 2
+	config.EnabledSchedulers = []string{structs.JobTypeCore, structs.JobTypeService}
+	config.DedicatedSchedulers = map[string]int{structs.JobTypeService: 2}
+	err := s.setupWorkers(s.shutdownCtx)
+	require.ErrorContains(t, err, "dedicated schedulers leave none")
+	require.Empty(t, s.workers)
+}
+
 func TestServer_ReloadSchedulers_InvalidSchedulers(t *testing.T) {
 	ci.Parallel(t)
 
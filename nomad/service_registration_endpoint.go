@@ -410,6 +410,7 @@ func (s *ServiceRegistration) GetService(
 			// Populate the reply.
 			reply.Services = services
 			reply.NextToken = nextToken
+			reply.ObjectsScanned = paginatorImpl.Scanned()
 
 			// Use the index table to populate the query meta as we have no way
 			// of tracking the max index on deletes.
@@ -510,7 +511,7 @@ func (s *ServiceRegistration) handleMixedAuthEndpoint(args structs.QueryOptions,
 			if claims == nil {
 				return structs.ErrPermissionDenied
 			}
-			return nil
+			return s.authorizeClaims(claims, args.RequestNamespace(), cap)
 		}
 
 		// COMPAT(1.4.0): Nomad 1.3.0 shipped with authentication by
@@ -542,3 +543,28 @@ func (s *ServiceRegistration) handleMixedAuthEndpoint(args structs.QueryOptions,
 	}
 	return nil
 }
+
+// authorizeClaims checks whether a workload identity can read the services of
+// the passed namespace. Workloads can discover the services of their own
+// namespace, but services in other namespaces must be explicitly granted to
+// the workload by an ACL policy attached to its job.
+func (s *ServiceRegistration) authorizeClaims(claims *structs.IdentityClaims, ns, cap string) error {
+
+	// Tokens issued by ExchangeIdentity are limited to reading the secure
+	// variables of the workload.
+	if claims.Scope != "" {
+		return structs.ErrPermissionDenied
+	}
+	if claims.Namespace == ns {
+		return nil
+	}
+
+	aclObj, err := s.srv.ResolveClaims(claims)
+	if err != nil {
+		return err
+	}
+	if aclObj == nil || !aclObj.AllowNsOp(ns, cap) {
+		return structs.ErrPermissionDenied
+	}
+	return nil
+}
@@ -893,10 +893,12 @@ func TestServiceRegistration_List(t *testing.T) {
 				require.NoError(t, s.State().UpsertServiceRegistrations(
 					structs.MsgTypeTestSetup, 20, services))
 
-				// Test a request while setting the auth token to the signed token
+				// Test a request while setting the auth token to the signed
+				// token. The services of the allocation's namespace can be
+				// listed.
 				serviceRegReq := &structs.ServiceRegistrationListRequest{
 					QueryOptions: structs.QueryOptions{
-						Namespace: "platform",
+						Namespace: structs.DefaultNamespace,
 						Region:    DefaultRegion,
 						AuthToken: signedToken,
 					},
@@ -906,6 +908,35 @@ func TestServiceRegistration_List(t *testing.T) {
 					codec, structs.ServiceRegistrationListRPCMethod,
 					serviceRegReq, &serviceRegResp)
 				require.NoError(t, err)
+				require.Len(t, serviceRegResp.Services, 1)
+				require.Equal(t, structs.DefaultNamespace, serviceRegResp.Services[0].Namespace)
+
+				// Services in another namespace are denied by default.
+				serviceRegReq.Namespace = "platform"
+				err = msgpackrpc.CallWithCodec(
+					codec, structs.ServiceRegistrationListRPCMethod,
+					serviceRegReq, &serviceRegResp)
+				require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+				// Attach a policy granting access to the other namespace to
+				// the job.
+				policy := mock.NamespacePolicy("platform", "", []string{acl.NamespaceCapabilityReadJob})
+				aclPolicy := &structs.ACLPolicy{
+					Name:  "platform-services",
+					Rules: policy,
+					JobACL: &structs.JobACL{
+						Namespace: job.Namespace,
+						JobID:     job.ID,
+					},
+				}
+				aclPolicy.SetHash()
+				require.NoError(t, s.State().UpsertACLPolicies(
+					structs.MsgTypeTestSetup, 25, []*structs.ACLPolicy{aclPolicy}))
+
+				err = msgpackrpc.CallWithCodec(
+					codec, structs.ServiceRegistrationListRPCMethod,
+					serviceRegReq, &serviceRegResp)
+				require.NoError(t, err)
 				require.ElementsMatch(t, []*structs.ServiceRegistrationListStub{
 					{
 						Namespace: "platform",
@@ -1176,6 +1207,13 @@ func TestServiceRegistration_GetService(t *testing.T) {
 				require.Equal(t, uint64(10), serviceRegResp.Services[0].CreateIndex)
 				require.Equal(t, uint64(20), serviceRegResp.Index)
 				require.Len(t, serviceRegResp.Services, 1)
+
+				// The second registration is in another namespace, which
+				// hasn't been granted to the job.
+				serviceRegReq.ServiceName = services[1].ServiceName
+				serviceRegReq.Namespace = services[1].Namespace
+				err = msgpackrpc.CallWithCodec(codec, structs.ServiceRegistrationGetServiceRPCMethod, serviceRegReq, &serviceRegResp)
+				require.EqualError(t, err, structs.ErrPermissionDenied.Error())
 			},
 			name: "ACLs enabled using valid signed identity",
 		},
@@ -8,6 +8,7 @@ import (
 var MsgTypeEvents = map[structs.MessageType]string{
 	structs.NodeRegisterRequestType:                      structs.TypeNodeRegistration,
 	structs.NodeDeregisterRequestType:                    structs.TypeNodeDeregistration,
+	structs.NodeBatchDeregisterRequestType:               structs.TypeNodeDeregistration,
 	structs.UpsertNodeEventsType:                         structs.TypeNodeEvent,
 	structs.EvalUpdateRequestType:                        structs.TypeEvalUpdated,
 	structs.AllocClientUpdateRequestType:                 structs.TypeAllocationUpdated,
@@ -18,6 +19,7 @@ var MsgTypeEvents = map[structs.MessageType]string{
 	structs.JobBatchDeregisterRequestType:                structs.TypeJobBatchDeregistered,
 	structs.AllocUpdateDesiredTransitionRequestType:      structs.TypeAllocationUpdateDesiredStatus,
 	structs.NodeUpdateEligibilityRequestType:             structs.TypeNodeDrain,
+	structs.NodeUpdatePlannedDisconnectRequestType:       structs.TypeNodeEvent,
 	structs.NodeUpdateDrainRequestType:                   structs.TypeNodeDrain,
 	structs.BatchNodeUpdateDrainRequestType:              structs.TypeNodeDrain,
 	structs.DeploymentStatusUpdateRequestType:            structs.TypeDeploymentUpdate,
@@ -31,6 +33,11 @@ var MsgTypeEvents = map[structs.MessageType]string{
 	structs.ServiceRegistrationUpsertRequestType:         structs.TypeServiceRegistration,
 	structs.ServiceRegistrationDeleteByIDRequestType:     structs.TypeServiceDeregistration,
 	structs.ServiceRegistrationDeleteByNodeIDRequestType: structs.TypeServiceDeregistration,
+	structs.SVApplyStateRequestType:                      structs.TypeSecureVariableUpserted,
+	structs.SVBatchDeleteRequestType:                     structs.TypeSecureVariableDeleted,
+	structs.SVTxnRequestType:                             structs.TypeSecureVariableUpserted,
+	structs.RootKeyMetaUpsertRequestType:                 structs.TypeRootKeyMetaUpserted,
+	structs.RootKeyMetaDeleteRequestType:                 structs.TypeRootKeyMetaDeleted,
 }
 
 func eventsFromChanges(tx ReadTxn, changes Changes) *structs.Events {
@@ -42,7 +49,11 @@ func eventsFromChanges(tx ReadTxn, changes Changes) *structs.Events {
 	var events []structs.Event
 	for _, change := range changes.Changes {
 		if event, ok := eventFromChange(change); ok {
-			event.Type = eventType
+			// Some message types both write and delete objects, in which
+			// case the event type is set by eventFromChange
+			if event.Type == "" {
+				event.Type = eventType
+			}
 			event.Index = changes.Index
 			events = append(events, event)
 		}
@@ -108,6 +119,33 @@ func eventFromChange(change memdb.Change) (structs.Event, bool) {
 					Service: before,
 				},
 			}, true
+		case TableSecureVariables:
+			before, ok := change.Before.(*structs.SecureVariableEncrypted)
+			if !ok {
+				return structs.Event{}, false
+			}
+			meta := before.SecureVariableMetadata
+			return structs.Event{
+				Topic:     structs.TopicSecureVariable,
+				Type:      structs.TypeSecureVariableDeleted,
+				Key:       meta.Path,
+				Namespace: meta.Namespace,
+				Payload: &structs.SecureVariableEvent{
+					SecureVariable: &meta,
+				},
+			}, true
+		case TableRootKeyMeta:
+			before, ok := change.Before.(*structs.RootKeyMeta)
+			if !ok {
+				return structs.Event{}, false
+			}
+			return structs.Event{
+				Topic: structs.TopicKeyring,
+				Key:   before.KeyID,
+				Payload: &structs.KeyringEvent{
+					RootKeyMeta: before,
+				},
+			}, true
 		}
 		return structs.Event{}, false
 	}
@@ -235,6 +273,32 @@ func eventFromChange(change memdb.Change) (structs.Event, bool) {
 				Service: after,
 			},
 		}, true
+	case TableSecureVariables:
+		after, ok := change.After.(*structs.SecureVariableEncrypted)
+		if !ok {
+			return structs.Event{}, false
+		}
+		meta := after.SecureVariableMetadata
+		return structs.Event{
+			Topic:     structs.TopicSecureVariable,
+			Key:       meta.Path,
+			Namespace: meta.Namespace,
+			Payload: &structs.SecureVariableEvent{
+				SecureVariable: &meta,
+			},
+		}, true
+	case TableRootKeyMeta:
+		after, ok := change.After.(*structs.RootKeyMeta)
+		if !ok {
+			return structs.Event{}, false
+		}
+		return structs.Event{
+			Topic: structs.TopicKeyring,
+			Key:   after.KeyID,
+			Payload: &structs.KeyringEvent{
+				RootKeyMeta: after,
+			},
+		}, true
 	}
 
 	return structs.Event{}, false
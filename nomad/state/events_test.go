@@ -561,7 +561,7 @@ func TestEventsFromChanges_NodeUpdateEligibilityRequestType(t *testing.T) {
 		UpdatedAt:   time.Now().UnixNano(),
 	}
 
-	require.NoError(t, s.UpdateNodeEligibility(msgType, 100, req.NodeID, req.Eligibility, req.UpdatedAt, req.NodeEvent))
+	require.NoError(t, s.UpdateNodeEligibility(msgType, 100, req.NodeID, req.Eligibility, req.Reason, req.UpdatedAt, req.NodeEvent))
 
 	events := WaitForEvents(t, s, 100, 1, 1*time.Second)
 	require.Len(t, events, 1)
@@ -778,7 +778,7 @@ func TestNodeEventsFromChanges(t *testing.T) {
 			}},
 		},
 		{
-			MsgType:   structs.NodeDeregisterRequestType,
+			MsgType:   structs.NodeBatchDeregisterRequestType,
 			WantTopic: structs.TopicNode,
 			Name:      "batch node deregistered",
 			Setup: func(s *StateStore, tx *txn) error {
@@ -894,7 +894,7 @@ func TestNodeEventsFromChanges(t *testing.T) {
 				switch tc.MsgType {
 				case structs.NodeRegisterRequestType:
 					requireNodeRegistrationEventEqual(t, tc.WantEvents[idx], g)
-				case structs.NodeDeregisterRequestType:
+				case structs.NodeDeregisterRequestType, structs.NodeBatchDeregisterRequestType:
 					requireNodeDeregistrationEventEqual(t, tc.WantEvents[idx], g)
 				case structs.UpsertNodeEventsType:
 					requireNodeEventEqual(t, tc.WantEvents[idx], g)
@@ -1059,3 +1059,76 @@ func testNodeID() string {
 func testNodeIDTwo() string {
 	return "694ff31d-8c59-4030-ac83-e15692560c8d"
 }
+
+func Test_eventsFromChanges_SecureVariable(t *testing.T) {
+	ci.Parallel(t)
+	testState := TestStateStoreCfg(t, TestStateStorePublisher(t))
+	defer testState.StopEventBroker()
+
+	sv := mock.SecureVariableEncrypted()
+	req := &structs.SVApplyStateRequest{Op: structs.SVOpSet, Var: sv}
+
+	// Write the secure variable.
+	writeTxn := testState.db.WriteTxnMsgT(structs.SVApplyStateRequestType, 10)
+	require.True(t, testState.svSetTxn(writeTxn, 10, req).IsOk())
+	writeTxn.Txn.Commit()
+
+	setChange := Changes{Changes: writeTxn.Changes(), Index: 10, MsgType: structs.SVApplyStateRequestType}
+	received := eventsFromChanges(writeTxn, setChange)
+
+	// Only the metadata of the variable is included in the event.
+	require.Len(t, received.Events, 1)
+	require.Equal(t, structs.TopicSecureVariable, received.Events[0].Topic)
+	require.Equal(t, structs.TypeSecureVariableUpserted, received.Events[0].Type)
+	require.Equal(t, sv.Path, received.Events[0].Key)
+	require.Equal(t, sv.Namespace, received.Events[0].Namespace)
+	payload := received.Events[0].Payload.(*structs.SecureVariableEvent)
+	require.Equal(t, sv.Path, payload.SecureVariable.Path)
+	require.Equal(t, uint64(10), payload.SecureVariable.ModifyIndex)
+
+	// Delete the secure variable.
+	req.Op = structs.SVOpDelete
+	deleteTxn := testState.db.WriteTxnMsgT(structs.SVApplyStateRequestType, 20)
+	require.True(t, testState.svDeleteTxn(deleteTxn, 20, req).IsOk())
+	deleteTxn.Txn.Commit()
+
+	deleteChange := Changes{Changes: deleteTxn.Changes(), Index: 20, MsgType: structs.SVApplyStateRequestType}
+	received = eventsFromChanges(deleteTxn, deleteChange)
+
+	require.Len(t, received.Events, 1)
+	require.Equal(t, structs.TopicSecureVariable, received.Events[0].Topic)
+	require.Equal(t, structs.TypeSecureVariableDeleted, received.Events[0].Type)
+	require.Equal(t, sv.Path, received.Events[0].Key)
+}
+
+func Test_eventsFromChanges_Keyring(t *testing.T) {
+	ci.Parallel(t)
+	testState := TestStateStoreCfg(t, TestStateStorePublisher(t))
+	defer testState.StopEventBroker()
+
+	key := structs.NewRootKeyMeta()
+
+	upsertTxn := testState.db.WriteTxnMsgT(structs.RootKeyMetaUpsertRequestType, 10)
+	require.NoError(t, upsertTxn.Insert(TableRootKeyMeta, key))
+	upsertTxn.Txn.Commit()
+
+	upsertChange := Changes{Changes: upsertTxn.Changes(), Index: 10, MsgType: structs.RootKeyMetaUpsertRequestType}
+	received := eventsFromChanges(upsertTxn, upsertChange)
+
+	require.Len(t, received.Events, 1)
+	require.Equal(t, structs.TopicKeyring, received.Events[0].Topic)
+	require.Equal(t, structs.TypeRootKeyMetaUpserted, received.Events[0].Type)
+	require.Equal(t, key.KeyID, received.Events[0].Key)
+	require.Equal(t, key, received.Events[0].Payload.(*structs.KeyringEvent).RootKeyMeta)
+
+	deleteTxn := testState.db.WriteTxnMsgT(structs.RootKeyMetaDeleteRequestType, 20)
+	require.NoError(t, deleteTxn.Delete(TableRootKeyMeta, key))
+	deleteTxn.Txn.Commit()
+
+	deleteChange := Changes{Changes: deleteTxn.Changes(), Index: 20, MsgType: structs.RootKeyMetaDeleteRequestType}
+	received = eventsFromChanges(deleteTxn, deleteChange)
+
+	require.Len(t, received.Events, 1)
+	require.Equal(t, structs.TopicKeyring, received.Events[0].Topic)
+	require.Equal(t, structs.TypeRootKeyMetaDeleted, received.Events[0].Type)
+}
@@ -28,6 +28,10 @@ type Paginator struct {
 	nextTokenFound bool
 	pageErr        error
 
+	// scanned is the number of objects read from the iterator, including
+	// those skipped by the token or filters.
+	scanned int
+
 	// appendFunc is the function the caller should use to append raw
 	// entries to the results set. The object is guaranteed to be
 	// non-nil.
@@ -85,12 +89,19 @@ DONE:
 	return p.nextToken, p.pageErr
 }
 
+// Scanned returns the number of objects read from the iterator while
+// populating the page. This is reported to callers as the cost of the query.
+func (p *Paginator) Scanned() int {
+	return p.scanned
+}
+
 func (p *Paginator) next() (interface{}, paginatorState) {
 	raw := p.iter.Next()
 	if raw == nil {
 		p.nextToken = ""
 		return nil, paginatorComplete
 	}
+	p.scanned++
 	token := p.tokenizer.GetToken(raw)
 
 	// have we found the token we're seeking (if any)?
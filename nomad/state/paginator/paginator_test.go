@@ -19,6 +19,7 @@ func TestPaginator(t *testing.T) {
 		nextToken         string
 		expected          []string
 		expectedNextToken string
+		expectedScanned   int
 		expectedError     string
 	}{
 		{
@@ -26,6 +27,7 @@ func TestPaginator(t *testing.T) {
 			perPage:           3,
 			expected:          []string{"0", "1", "2"},
 			expectedNextToken: "3",
+			expectedScanned:   4,
 		},
 		{
 			name:              "size-5 page-2 stop before end",
@@ -33,6 +35,7 @@ func TestPaginator(t *testing.T) {
 			nextToken:         "3",
 			expected:          []string{"3", "4", "5", "6", "7"},
 			expectedNextToken: "8",
+			expectedScanned:   9,
 		},
 		{
 			name:              "page-2 reading off the end",
@@ -40,6 +43,7 @@ func TestPaginator(t *testing.T) {
 			nextToken:         "5",
 			expected:          []string{"5", "6", "7", "8", "9"},
 			expectedNextToken: "",
+			expectedScanned:   10,
 		},
 		{
 			name:              "starting off the end",
@@ -47,6 +51,7 @@ func TestPaginator(t *testing.T) {
 			nextToken:         "a",
 			expected:          []string{},
 			expectedNextToken: "",
+			expectedScanned:   10,
 		},
 		{
 			name:          "error during append",
@@ -83,6 +88,7 @@ func TestPaginator(t *testing.T) {
 				require.NoError(t, err)
 				require.Equal(t, tc.expected, results)
 				require.Equal(t, tc.expectedNextToken, nextToken)
+				require.Equal(t, tc.expectedScanned, paginator.Scanned())
 			} else {
 				require.Error(t, err)
 				require.Contains(t, err.Error(), tc.expectedError)
@@ -17,6 +17,8 @@ const (
 	TableSecureVariables       = "secure_variables"
 	TableSecureVariablesQuotas = "secure_variables_quota"
 	TableRootKeyMeta           = "secure_variables_root_key_meta"
+	TableJobSigningKeys        = "job_signing_keys"
+	TableJobRestarts           = "job_restarts"
 )
 
 const (
@@ -78,6 +80,8 @@ func init() {
 		secureVariablesTableSchema,
 		secureVariablesQuotasTableSchema,
 		secureVariablesRootKeyMetaSchema,
+		jobSigningKeysTableSchema,
+		jobRestartsTableSchema,
 	}...)
 }
 
@@ -1408,3 +1412,49 @@ func secureVariablesRootKeyMetaSchema() *memdb.TableSchema {
 		},
 	}
 }
+
+// jobSigningKeysTableSchema returns the MemDB schema for the public keys
+// trusted to sign job submissions
+func jobSigningKeysTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableJobSigningKeys,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "KeyID",
+				},
+			},
+		},
+	}
+}
+
+// jobRestartsTableSchema returns the MemDB schema for the latest rolling
+// restart of each job
+func jobRestartsTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableJobRestarts,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+
+				// Use a compound index so the tuple of (Namespace, JobID) is
+				// uniquely identifying
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+						&memdb.StringFieldIndex{
+							Field: "JobID",
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -380,7 +380,8 @@ func (s *StateStore) UpsertPlanResults(msgType structs.MessageType, index uint64
 			SetMessage(NodeEligibilityEventPlanRejectThreshold)
 
 		err := s.updateNodeEligibilityImpl(index, nodeID,
-			structs.NodeSchedulingIneligible, results.UpdatedAt, nodeEvent, txn)
+			structs.NodeSchedulingIneligible, NodeEligibilityEventPlanRejectThreshold,
+			results.UpdatedAt, nodeEvent, txn)
 		if err != nil {
 			return err
 		}
@@ -923,6 +924,13 @@ func upsertNodeTxn(txn *txn, index uint64, node *structs.Node) error {
 		node.SchedulingEligibility = exist.SchedulingEligibility // Retain the eligibility
 		node.DrainStrategy = exist.DrainStrategy                 // Retain the drain strategy
 		node.LastDrain = exist.LastDrain                         // Retain the drain metadata
+		node.LastReconnect = exist.LastReconnect                 // Retain the reconnect time
+
+		// A node registering again after being disconnected is reconnecting
+		if exist.Status == structs.NodeStatusDisconnected &&
+			node.Status != structs.NodeStatusDisconnected && node.Status != structs.NodeStatusDown {
+			node.LastReconnect = node.StatusUpdatedAt
+		}
 	} else {
 		// Because this is the first time the node is being registered, we should
 		// also create a node registration event
@@ -1025,6 +1033,18 @@ func (s *StateStore) updateNodeStatusTxn(txn *txn, nodeID, status string, update
 		appendNodeEvents(txn.Index, copyNode, []*structs.NodeEvent{event})
 	}
 
+	// A planned disconnect is over once the node is ready again
+	if status == structs.NodeStatusReady && existingNode.Status != structs.NodeStatusReady {
+		copyNode.PlannedDisconnect = nil
+	}
+
+	// Track reconnects so that the scheduler can wait for the node to stay
+	// connected before reinstating its allocations
+	if existingNode.Status == structs.NodeStatusDisconnected &&
+		status != structs.NodeStatusDisconnected && status != structs.NodeStatusDown {
+		copyNode.LastReconnect = updatedAt
+	}
+
 	// Update the status in the copy
 	copyNode.Status = status
 	copyNode.ModifyIndex = txn.Index
@@ -1097,8 +1117,10 @@ func (s *StateStore) updateNodeDrainImpl(txn *txn, index uint64, nodeID string,
 	updatedNode.DrainStrategy = drain
 	if drain != nil {
 		updatedNode.SchedulingEligibility = structs.NodeSchedulingIneligible
+		updatedNode.EligibilityReason = drainMeta["message"]
 	} else if markEligible {
 		updatedNode.SchedulingEligibility = structs.NodeSchedulingEligible
+		updatedNode.EligibilityReason = ""
 	}
 
 	// Update LastDrain
@@ -1162,16 +1184,16 @@ func (s *StateStore) updateNodeDrainImpl(txn *txn, index uint64, nodeID string,
 }
 
 // UpdateNodeEligibility is used to update the scheduling eligibility of a node
-func (s *StateStore) UpdateNodeEligibility(msgType structs.MessageType, index uint64, nodeID string, eligibility string, updatedAt int64, event *structs.NodeEvent) error {
+func (s *StateStore) UpdateNodeEligibility(msgType structs.MessageType, index uint64, nodeID string, eligibility, reason string, updatedAt int64, event *structs.NodeEvent) error {
 	txn := s.db.WriteTxnMsgT(msgType, index)
 	defer txn.Abort()
-	if err := s.updateNodeEligibilityImpl(index, nodeID, eligibility, updatedAt, event, txn); err != nil {
+	if err := s.updateNodeEligibilityImpl(index, nodeID, eligibility, reason, updatedAt, event, txn); err != nil {
 		return err
 	}
 	return txn.Commit()
 }
 
-func (s *StateStore) updateNodeEligibilityImpl(index uint64, nodeID string, eligibility string, updatedAt int64, event *structs.NodeEvent, txn *txn) error {
+func (s *StateStore) updateNodeEligibilityImpl(index uint64, nodeID string, eligibility, reason string, updatedAt int64, event *structs.NodeEvent, txn *txn) error {
 	// Lookup the node
 	existing, err := txn.First("nodes", "id", nodeID)
 	if err != nil {
@@ -1198,6 +1220,7 @@ func (s *StateStore) updateNodeEligibilityImpl(index uint64, nodeID string, elig
 
 	// Update the eligibility in the copy
 	copyNode.SchedulingEligibility = eligibility
+	copyNode.EligibilityReason = reason
 	copyNode.ModifyIndex = index
 
 	// Insert the node
@@ -1211,6 +1234,76 @@ func (s *StateStore) updateNodeEligibilityImpl(index uint64, nodeID string, elig
 	return nil
 }
 
+// UpdateNodePlannedDisconnect is used to plan or cancel a disconnect of a
+// node. The deadline of the planned disconnect is recorded on the allocations
+// running on the node, so that the scheduler can handle them when the node
+// disconnects.
+func (s *StateStore) UpdateNodePlannedDisconnect(msgType structs.MessageType, index uint64, nodeID string,
+	planned *structs.PlannedDisconnect, updatedAt int64, event *structs.NodeEvent) error {
+
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	// Lookup the node
+	existing, err := txn.First("nodes", "id", nodeID)
+	if err != nil {
+		return fmt.Errorf("node lookup failed: %v", err)
+	}
+	if existing == nil {
+		return fmt.Errorf("node not found")
+	}
+
+	// Copy the existing node
+	existingNode := existing.(*structs.Node)
+	copyNode := existingNode.Copy()
+	copyNode.StatusUpdatedAt = updatedAt
+
+	// Add the event if given
+	if event != nil {
+		appendNodeEvents(index, copyNode, []*structs.NodeEvent{event})
+	}
+
+	copyNode.PlannedDisconnect = planned
+	copyNode.ModifyIndex = index
+
+	// Insert the node
+	if err := txn.Insert("nodes", copyNode); err != nil {
+		return fmt.Errorf("node update failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"nodes", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	// Record the deadline on the allocations running on the node
+	var deadline time.Time
+	if planned != nil {
+		deadline = planned.Deadline
+	}
+
+	allocs, err := allocsByNodeTxn(txn, nil, nodeID)
+	if err != nil {
+		return fmt.Errorf("alloc lookup failed: %v", err)
+	}
+	for _, alloc := range allocs {
+		if alloc.TerminalStatus() || alloc.PlannedDisconnectDeadline.Equal(deadline) {
+			continue
+		}
+
+		copyAlloc := alloc.Copy()
+		copyAlloc.PlannedDisconnectDeadline = deadline
+		copyAlloc.ModifyIndex = index
+
+		if err := txn.Insert("allocs", copyAlloc); err != nil {
+			return fmt.Errorf("alloc insert failed: %v", err)
+		}
+	}
+	if err := txn.Insert("index", &IndexEntry{"allocs", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
 // UpsertNodeEvents adds the node events to the nodes, rotating events as
 // necessary.
 func (s *StateStore) UpsertNodeEvents(msgType structs.MessageType, index uint64, nodeEvents map[string][]*structs.NodeEvent) error {
@@ -1826,6 +1919,14 @@ func (s *StateStore) DeleteJobTxn(index uint64, namespace, jobID string, txn Txn
 		return fmt.Errorf("index update failed: %v", err)
 	}
 
+	// Delete the job's restart
+	if _, err = txn.DeleteAll(TableJobRestarts, indexID, namespace, jobID); err != nil {
+		return fmt.Errorf("deleting job restart failed: %v", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableJobRestarts, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
 	return nil
 }
 
@@ -1950,6 +2051,46 @@ func (s *StateStore) upsertJobVersion(index uint64, job *structs.Job, txn *txn)
 	return nil
 }
 
+// DeleteJobVersions is used to delete a set of historic job versions. The
+// current version of a job is never deleted and versions that no longer exist
+// are skipped.
+func (s *StateStore) DeleteJobVersions(index uint64, versions []*structs.JobVersionID) error {
+	txn := s.db.WriteTxn(index)
+	defer txn.Abort()
+
+	if len(versions) == 0 {
+		return nil
+	}
+
+	for _, v := range versions {
+		current, err := txn.First("jobs", "id", v.Namespace, v.ID)
+		if err != nil {
+			return fmt.Errorf("job lookup failed: %v", err)
+		}
+		if current != nil && current.(*structs.Job).Version == v.Version {
+			continue
+		}
+
+		existing, err := txn.First("job_version", "id", v.Namespace, v.ID, v.Version)
+		if err != nil {
+			return fmt.Errorf("job version lookup failed: %v", err)
+		}
+		if existing == nil {
+			continue
+		}
+
+		if err := txn.Delete("job_version", existing); err != nil {
+			return fmt.Errorf("failed to delete job %v (%d) from job_version", v.ID, v.Version)
+		}
+	}
+
+	if err := txn.Insert("index", &IndexEntry{"job_version", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
 // JobByID is used to lookup a job by its ID. JobByID returns the current/latest job
 // version.
 func (s *StateStore) JobByID(ws memdb.WatchSet, namespace, id string) (*structs.Job, error) {
@@ -3766,6 +3907,45 @@ func (s *StateStore) allocByIDImpl(txn Txn, ws memdb.WatchSet, id string) (*stru
 	return alloc, nil
 }
 
+// AllocRescheduleChain returns the IDs of the allocations the allocation
+// replaced and of the allocations that replaced it, following their
+// PreviousAllocation and NextAllocation links.
+func (s *StateStore) AllocRescheduleChain(ws memdb.WatchSet, alloc *structs.Allocation) (*structs.AllocRescheduleChain, error) {
+	txn := s.db.ReadTxn()
+	chain := &structs.AllocRescheduleChain{}
+
+	for id := alloc.PreviousAllocation; id != "" && len(chain.Ancestors) < structs.MaxAllocRescheduleChainLength; {
+		prev, err := s.allocByIDImpl(txn, ws, id)
+		if err != nil {
+			return nil, err
+		}
+		if prev == nil {
+			break
+		}
+		chain.Ancestors = append(chain.Ancestors, prev.ID)
+		id = prev.PreviousAllocation
+	}
+
+	// Ancestors were collected newest first
+	for i, j := 0, len(chain.Ancestors)-1; i < j; i, j = i+1, j-1 {
+		chain.Ancestors[i], chain.Ancestors[j] = chain.Ancestors[j], chain.Ancestors[i]
+	}
+
+	for id := alloc.NextAllocation; id != "" && len(chain.Descendants) < structs.MaxAllocRescheduleChainLength; {
+		next, err := s.allocByIDImpl(txn, ws, id)
+		if err != nil {
+			return nil, err
+		}
+		if next == nil {
+			break
+		}
+		chain.Descendants = append(chain.Descendants, next.ID)
+		id = next.NextAllocation
+	}
+
+	return chain, nil
+}
+
 // AllocsByIDPrefix is used to lookup allocs by prefix
 func (s *StateStore) AllocsByIDPrefix(ws memdb.WatchSet, namespace, id string, sort SortOption) (memdb.ResultIterator, error) {
 	txn := s.db.ReadTxn()
@@ -5230,8 +5410,20 @@ func (s *StateStore) updateDeploymentWithAlloc(index uint64, alloc, existing *st
 		}
 	}
 
+	// Track allocations moving on to or off of disconnected clients
+	unknown := 0
+	if existing != nil && existing.DeploymentID == alloc.DeploymentID {
+		wasUnknown := existing.ClientStatus == structs.AllocClientStatusUnknown
+		isUnknown := alloc.ClientStatus == structs.AllocClientStatusUnknown
+		if !wasUnknown && isUnknown {
+			unknown++
+		} else if wasUnknown && !isUnknown {
+			unknown--
+		}
+	}
+
 	// Nothing to do
-	if placed == 0 && healthy == 0 && unhealthy == 0 {
+	if placed == 0 && healthy == 0 && unhealthy == 0 && unknown == 0 {
 		return nil
 	}
 
@@ -5248,6 +5440,10 @@ func (s *StateStore) updateDeploymentWithAlloc(index uint64, alloc, existing *st
 	dstate.PlacedAllocs += placed
 	dstate.HealthyAllocs += healthy
 	dstate.UnhealthyAllocs += unhealthy
+	dstate.UnknownAllocs += unknown
+	if dstate.UnknownAllocs < 0 {
+		dstate.UnknownAllocs = 0
+	}
 
 	// Ensure PlacedCanaries accurately reflects the alloc canary status
 	if alloc.DeploymentStatus != nil && alloc.DeploymentStatus.Canary {
@@ -5274,6 +5470,12 @@ func (s *StateStore) updateDeploymentWithAlloc(index uint64, alloc, existing *st
 			if d := alloc.DeploymentStatus.Timestamp.Add(pd); d.After(dstate.RequireProgressBy) {
 				dstate.RequireProgressBy = d
 			}
+		} else if unknown < 0 {
+			// The client reconnected, so give the group a full progress
+			// deadline from now on to resume making progress.
+			if d := time.Unix(0, alloc.ModifyTime).Add(pd); d.After(dstate.RequireProgressBy) {
+				dstate.RequireProgressBy = d
+			}
 		}
 	}
 
@@ -6702,7 +6904,7 @@ func getPreemptedAllocDesiredDescription(preemptedByAllocID string) string {
 
 // UpsertRootKeyMeta saves root key meta or updates it in-place.
 func (s *StateStore) UpsertRootKeyMeta(index uint64, rootKeyMeta *structs.RootKeyMeta, rekey bool) error {
-	txn := s.db.WriteTxn(index)
+	txn := s.db.WriteTxnMsgT(structs.RootKeyMetaUpsertRequestType, index)
 	defer txn.Abort()
 
 	// get any existing key for updating
@@ -6756,7 +6958,8 @@ func (s *StateStore) UpsertRootKeyMeta(index uint64, rootKeyMeta *structs.RootKe
 					key.SetInactive()
 				}
 				modified = true
-			case structs.RootKeyStateRekeying, structs.RootKeyStateDeprecated:
+			case structs.RootKeyStateRekeying, structs.RootKeyStateDeprecated,
+				structs.RootKeyStatePrepublished:
 				// nothing to do
 			}
 
@@ -6784,7 +6987,7 @@ func (s *StateStore) UpsertRootKeyMeta(index uint64, rootKeyMeta *structs.RootKe
 // DeleteRootKeyMeta deletes a single root key, or returns an error if
 // it doesn't exist.
 func (s *StateStore) DeleteRootKeyMeta(index uint64, keyID string) error {
-	txn := s.db.WriteTxn(index)
+	txn := s.db.WriteTxnMsgT(structs.RootKeyMetaDeleteRequestType, index)
 	defer txn.Abort()
 
 	// find the old key
@@ -6858,3 +7061,27 @@ func (s *StateStore) GetActiveRootKeyMeta(ws memdb.WatchSet) (*structs.RootKeyMe
 	}
 	return nil, nil
 }
+
+// GetPrepublishedRootKeyMeta returns the metadata for the root key
+// prepublished to become the next active key, if any
+func (s *StateStore) GetPrepublishedRootKeyMeta(ws memdb.WatchSet) (*structs.RootKeyMeta, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableRootKeyMeta, indexID)
+	if err != nil {
+		return nil, err
+	}
+	ws.Add(iter.WatchCh())
+
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+		key := raw.(*structs.RootKeyMeta)
+		if key.Prepublished() {
+			return key, nil
+		}
+	}
+	return nil, nil
+}
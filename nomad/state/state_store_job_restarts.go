@@ -0,0 +1,67 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// UpsertJobRestart stores the restart of a job, replacing the job's previous
+// restart if it is a new one.
+func (s *StateStore) UpsertJobRestart(msgType structs.MessageType, index uint64, restart *structs.JobRestart) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	raw, err := txn.First(TableJobRestarts, indexID, restart.Namespace, restart.JobID)
+	if err != nil {
+		return fmt.Errorf("job restart lookup failed: %v", err)
+	}
+
+	restart = restart.Copy()
+	if existing, ok := raw.(*structs.JobRestart); ok && existing.ID == restart.ID {
+		restart.CreateIndex = existing.CreateIndex
+		restart.CreateTime = existing.CreateTime
+	} else {
+		restart.CreateIndex = index
+	}
+	restart.ModifyIndex = index
+
+	if err := txn.Insert(TableJobRestarts, restart); err != nil {
+		return fmt.Errorf("job restart insert failed: %v", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableJobRestarts, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return txn.Commit()
+}
+
+// JobRestarts returns an iterator over the latest restart of every job.
+func (s *StateStore) JobRestarts(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableJobRestarts, indexID)
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// JobRestartByJob returns the latest restart of the job, or nil if it has
+// never been restarted.
+func (s *StateStore) JobRestartByJob(ws memdb.WatchSet, namespace, jobID string) (*structs.JobRestart, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, raw, err := txn.FirstWatch(TableJobRestarts, indexID, namespace, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("job restart lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if raw != nil {
+		return raw.(*structs.JobRestart), nil
+	}
+	return nil, nil
+}
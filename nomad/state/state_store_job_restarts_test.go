@@ -0,0 +1,84 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateStore_JobRestarts(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+
+	job := mock.Job()
+	require.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 10, job))
+
+	restart := &structs.JobRestart{
+		ID:         uuid.Generate(),
+		Namespace:  job.Namespace,
+		JobID:      job.ID,
+		BatchSize:  1,
+		OnError:    structs.JobRestartOnErrorFail,
+		AllocIDs:   []string{uuid.Generate(), uuid.Generate()},
+		Status:     structs.JobRestartStatusRunning,
+		CreateTime: 100,
+	}
+
+	ws := memdb.NewWatchSet()
+	_, err := store.JobRestarts(ws)
+	require.NoError(t, err)
+
+	require.NoError(t, store.UpsertJobRestart(structs.MsgTypeTestSetup, 11, restart))
+	require.True(t, watchFired(ws))
+
+	out, err := store.JobRestartByJob(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, restart.ID, out.ID)
+	require.Equal(t, uint64(11), out.CreateIndex)
+	require.Equal(t, uint64(11), out.ModifyIndex)
+
+	// Updating the restart keeps its creation
+	update := out.Copy()
+	update.Restarted = 1
+	update.CreateTime = 200
+	require.NoError(t, store.UpsertJobRestart(structs.MsgTypeTestSetup, 12, update))
+
+	out, err = store.JobRestartByJob(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, out.Restarted)
+	require.Equal(t, uint64(11), out.CreateIndex)
+	require.Equal(t, uint64(12), out.ModifyIndex)
+	require.Equal(t, int64(100), out.CreateTime)
+
+	// A new restart replaces the job's previous one
+	next := restart.Copy()
+	next.ID = uuid.Generate()
+	require.NoError(t, store.UpsertJobRestart(structs.MsgTypeTestSetup, 13, next))
+
+	iter, err := store.JobRestarts(nil)
+	require.NoError(t, err)
+	var ids []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		ids = append(ids, raw.(*structs.JobRestart).ID)
+	}
+	require.Equal(t, []string{next.ID}, ids)
+
+	out, err = store.JobRestartByJob(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(13), out.CreateIndex)
+
+	// Deleting the job deletes its restart
+	require.NoError(t, store.DeleteJob(14, job.Namespace, job.ID))
+	out, err = store.JobRestartByJob(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	index, err := store.Index(TableJobRestarts)
+	require.NoError(t, err)
+	require.Equal(t, uint64(14), index)
+}
@@ -0,0 +1,89 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// UpsertJobSigningKey trusts a job signing key, or updates the name of an
+// already trusted key.
+func (s *StateStore) UpsertJobSigningKey(index uint64, key *structs.JobSigningKey) error {
+	txn := s.db.WriteTxnMsgT(structs.JobSigningKeyUpsertRequestType, index)
+	defer txn.Abort()
+
+	raw, err := txn.First(TableJobSigningKeys, indexID, key.KeyID)
+	if err != nil {
+		return fmt.Errorf("job signing key lookup failed: %v", err)
+	}
+
+	key = key.Copy()
+	if raw != nil {
+		existing := raw.(*structs.JobSigningKey)
+		key.CreateIndex = existing.CreateIndex
+		key.CreateTime = existing.CreateTime
+	} else {
+		key.CreateIndex = index
+	}
+	key.ModifyIndex = index
+
+	if err := txn.Insert(TableJobSigningKeys, key); err != nil {
+		return fmt.Errorf("job signing key insert failed: %v", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableJobSigningKeys, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return txn.Commit()
+}
+
+// DeleteJobSigningKey stops trusting a job signing key, or returns an error
+// if it doesn't exist.
+func (s *StateStore) DeleteJobSigningKey(index uint64, keyID string) error {
+	txn := s.db.WriteTxnMsgT(structs.JobSigningKeyDeleteRequestType, index)
+	defer txn.Abort()
+
+	existing, err := txn.First(TableJobSigningKeys, indexID, keyID)
+	if err != nil {
+		return fmt.Errorf("job signing key lookup failed: %v", err)
+	}
+	if existing == nil {
+		return fmt.Errorf("job signing key not found")
+	}
+	if err := txn.Delete(TableJobSigningKeys, existing); err != nil {
+		return fmt.Errorf("job signing key delete failed: %v", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableJobSigningKeys, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return txn.Commit()
+}
+
+// JobSigningKeys returns an iterator over all the trusted job signing keys.
+func (s *StateStore) JobSigningKeys(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableJobSigningKeys, indexID)
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// JobSigningKeyByID returns the trusted job signing key with the given ID.
+func (s *StateStore) JobSigningKeyByID(ws memdb.WatchSet, keyID string) (*structs.JobSigningKey, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, raw, err := txn.FirstWatch(TableJobSigningKeys, indexID, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("job signing key lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if raw != nil {
+		return raw.(*structs.JobSigningKey), nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,75 @@
+package state
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func testJobSigningKey(t *testing.T, name string) *structs.JobSigningKey {
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return &structs.JobSigningKey{
+		KeyID:      structs.JobSigningKeyID(publicKey),
+		Name:       name,
+		PublicKey:  publicKey,
+		CreateTime: 100,
+	}
+}
+
+func TestStateStore_JobSigningKeys(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+
+	key1 := testJobSigningKey(t, "ci")
+	key2 := testJobSigningKey(t, "ops")
+
+	ws := memdb.NewWatchSet()
+	_, err := store.JobSigningKeys(ws)
+	require.NoError(t, err)
+
+	require.NoError(t, store.UpsertJobSigningKey(10, key1))
+	require.NoError(t, store.UpsertJobSigningKey(11, key2))
+	require.True(t, watchFired(ws))
+
+	out, err := store.JobSigningKeyByID(nil, key1.KeyID)
+	require.NoError(t, err)
+	require.Equal(t, "ci", out.Name)
+	require.Equal(t, uint64(10), out.CreateIndex)
+	require.Equal(t, uint64(10), out.ModifyIndex)
+
+	// Updating a key only changes its name
+	update := key1.Copy()
+	update.Name = "deploy"
+	update.CreateTime = 200
+	require.NoError(t, store.UpsertJobSigningKey(12, update))
+
+	out, err = store.JobSigningKeyByID(nil, key1.KeyID)
+	require.NoError(t, err)
+	require.Equal(t, "deploy", out.Name)
+	require.Equal(t, int64(100), out.CreateTime)
+	require.Equal(t, uint64(10), out.CreateIndex)
+	require.Equal(t, uint64(12), out.ModifyIndex)
+
+	index, err := store.Index(TableJobSigningKeys)
+	require.NoError(t, err)
+	require.Equal(t, uint64(12), index)
+
+	// Delete a key
+	require.NoError(t, store.DeleteJobSigningKey(13, key2.KeyID))
+	require.EqualError(t, store.DeleteJobSigningKey(14, key2.KeyID), "job signing key not found")
+
+	iter, err := store.JobSigningKeys(nil)
+	require.NoError(t, err)
+	var keys []*structs.JobSigningKey
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		keys = append(keys, raw.(*structs.JobSigningKey))
+	}
+	require.Len(t, keys, 1)
+	require.Equal(t, key1.KeyID, keys[0].KeyID)
+}
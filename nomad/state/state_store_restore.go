@@ -216,6 +216,24 @@ func (r *StateRestore) SecureVariablesQuotaRestore(quota *structs.SecureVariable
 	return nil
 }
 
+// JobSigningKeyRestore is used to restore a single job signing key into the
+// job_signing_keys table.
+func (r *StateRestore) JobSigningKeyRestore(key *structs.JobSigningKey) error {
+	if err := r.txn.Insert(TableJobSigningKeys, key); err != nil {
+		return fmt.Errorf("job signing key insert failed: %v", err)
+	}
+	return nil
+}
+
+// JobRestartRestore is used to restore a single job restart into the
+// job_restarts table.
+func (r *StateRestore) JobRestartRestore(restart *structs.JobRestart) error {
+	if err := r.txn.Insert(TableJobRestarts, restart); err != nil {
+		return fmt.Errorf("job restart insert failed: %v", err)
+	}
+	return nil
+}
+
 // RootKeyMetaQuotaRestore is used to restore a single root key meta
 // into the secure_variables_root_key_meta table.
 func (r *StateRestore) RootKeyMetaRestore(quota *structs.RootKeyMeta) error {
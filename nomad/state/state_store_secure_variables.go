@@ -114,7 +114,7 @@ func (s *StateStore) GetSecureVariable(
 
 // SVESet is used to store a secure variable object.
 func (s *StateStore) SVESet(idx uint64, sv *structs.SVApplyStateRequest) *structs.SVApplyStateResponse {
-	tx := s.db.WriteTxn(idx)
+	tx := s.db.WriteTxnMsgT(structs.SVApplyStateRequestType, idx)
 	defer tx.Abort()
 
 	// Perform the actual set.
@@ -133,7 +133,7 @@ func (s *StateStore) SVESet(idx uint64, sv *structs.SVApplyStateRequest) *struct
 // variable. The ModifyIndex in the provided entry is used to determine if
 // we should write the entry to the state store or not.
 func (s *StateStore) SVESetCAS(idx uint64, sv *structs.SVApplyStateRequest) *structs.SVApplyStateResponse {
-	tx := s.db.WriteTxn(idx)
+	tx := s.db.WriteTxnMsgT(structs.SVApplyStateRequestType, idx)
 	defer tx.Abort()
 
 	resp := s.svSetCASTxn(tx, idx, sv)
@@ -193,6 +193,16 @@ func (s *StateStore) svSetTxn(tx WriteTxn, idx uint64, req *structs.SVApplyState
 		return req.ErrorResponse(idx, fmt.Errorf("failed sve lookup: %s", err))
 	}
 	existing, _ := existingRaw.(*structs.SecureVariableEncrypted)
+	if existing != nil && existing.SourceRegion != "" && existing.SourceRegion != sv.SourceRegion {
+		return req.ErrorResponse(idx, replicatedSecureVariableError(existing))
+	}
+
+	// Replicas follow the protection of their source, so only local writes
+	// are checked
+	if existing != nil && existing.DeleteProtection && !sv.DeleteProtection &&
+		sv.SourceRegion == "" && !req.Force {
+		return req.ErrorResponse(idx, deleteProtectedSecureVariableError(existing))
+	}
 
 	existingQuota, err := tx.First(TableSecureVariablesQuotas, indexID, sv.Namespace)
 	if err != nil {
@@ -304,7 +314,7 @@ func svGetTxn(tx ReadTxn,
 // SVEDelete is used to delete a single secure variable in the
 // the state store.
 func (s *StateStore) SVEDelete(idx uint64, req *structs.SVApplyStateRequest) *structs.SVApplyStateResponse {
-	tx := s.db.WriteTxn(idx)
+	tx := s.db.WriteTxnMsgT(structs.SVApplyStateRequestType, idx)
 	defer tx.Abort()
 
 	// Perform the actual delete
@@ -321,13 +331,133 @@ func (s *StateStore) SVEDelete(idx uint64, req *structs.SVApplyStateRequest) *st
 	return resp
 }
 
+// SVEDeleteBatch is used to delete many secure variables of a namespace in a
+// single transaction. Paths that do not exist are ignored.
+func (s *StateStore) SVEDeleteBatch(idx uint64, req *structs.SVBatchDeleteStateRequest) error {
+	tx := s.db.WriteTxnMsgT(structs.SVBatchDeleteRequestType, idx)
+	defer tx.Abort()
+
+	for _, path := range req.Paths {
+		delReq := &structs.SVApplyStateRequest{
+			Op:    structs.SVOpDelete,
+			Force: req.Force,
+			Var: &structs.SecureVariableEncrypted{
+				SecureVariableMetadata: structs.SecureVariableMetadata{
+					Namespace: req.Namespace,
+					Path:      path,
+				},
+			},
+		}
+		if resp := s.svDeleteTxn(tx, idx, delReq); resp.IsError() {
+			return resp.Error
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SVETxn is used to apply the operations of a secure variables transaction in
+// a single state store transaction. None of the operations are applied if one
+// of them conflicts or fails.
+func (s *StateStore) SVETxn(idx uint64, req *structs.SVTxnStateRequest) *structs.SVTxnStateResponse {
+	tx := s.db.WriteTxnMsgT(structs.SVTxnRequestType, idx)
+	defer tx.Abort()
+
+	written := make([]*structs.SecureVariableMetadata, len(req.Ops))
+	for i, op := range req.Ops {
+		opReq := &structs.SVApplyStateRequest{Op: op.Op, Var: op.Var}
+
+		var resp *structs.SVApplyStateResponse
+		switch op.Op {
+		case structs.SVOpSet:
+			resp = s.svSetTxn(tx, idx, opReq)
+		case structs.SVOpCAS:
+			resp = s.svSetCASTxn(tx, idx, opReq)
+		case structs.SVOpDelete:
+			resp = s.svDeleteTxn(tx, idx, opReq)
+		case structs.SVOpDeleteCAS:
+			resp = s.svDeleteCASTxn(tx, idx, opReq)
+		case structs.SVOpCheckIndex:
+			resp = svCheckIndexTxn(tx, idx, opReq)
+		default:
+			resp = opReq.ErrorResponse(idx, fmt.Errorf("invalid secure variable operation %q", op.Op))
+		}
+
+		switch {
+		case resp.IsError():
+			return &structs.SVTxnStateResponse{
+				Result:    structs.SVOpResultError,
+				Error:     fmt.Errorf("operation %d: %w", i, resp.Error),
+				WriteMeta: structs.WriteMeta{Index: idx},
+			}
+		case resp.IsConflict():
+			return &structs.SVTxnStateResponse{
+				Result:        structs.SVOpResultConflict,
+				ConflictIndex: i,
+				Conflict:      resp.Conflict,
+				WriteMeta:     structs.WriteMeta{Index: idx},
+			}
+		}
+
+		// Sets of unchanged secure variables don't return their metadata,
+		// but update the request with the stored indexes.
+		if op.Op == structs.SVOpSet || op.Op == structs.SVOpCAS {
+			meta := op.Var.SecureVariableMetadata
+			written[i] = &meta
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &structs.SVTxnStateResponse{
+			Result:    structs.SVOpResultError,
+			Error:     err,
+			WriteMeta: structs.WriteMeta{Index: idx},
+		}
+	}
+	return &structs.SVTxnStateResponse{
+		Result:    structs.SVOpResultOk,
+		Written:   written,
+		WriteMeta: structs.WriteMeta{Index: idx},
+	}
+}
+
+// svCheckIndexTxn checks that the ModifyIndex of a secure variable matches
+// the one of the request within an existing transaction, without modifying
+// it. A ModifyIndex of 0 checks that the secure variable does not exist.
+func svCheckIndexTxn(tx ReadTxn, idx uint64, req *structs.SVApplyStateRequest) *structs.SVApplyStateResponse {
+	sv := req.Var
+	raw, err := tx.First(TableSecureVariables, indexID, sv.Namespace, sv.Path)
+	if err != nil {
+		return req.ErrorResponse(idx, fmt.Errorf("failed secure variable lookup: %s", err))
+	}
+
+	if raw == nil {
+		if sv.ModifyIndex == 0 {
+			return req.SuccessResponse(idx, nil)
+		}
+		zeroVal := &structs.SecureVariableEncrypted{
+			SecureVariableMetadata: structs.SecureVariableMetadata{
+				Namespace: sv.Namespace,
+				Path:      sv.Path,
+			},
+		}
+		return req.ConflictResponse(idx, zeroVal)
+	}
+
+	svEx := raw.(*structs.SecureVariableEncrypted)
+	if sv.ModifyIndex != svEx.ModifyIndex {
+		return req.ConflictResponse(idx, svEx)
+	}
+	return req.SuccessResponse(idx, nil)
+}
+
 // SVEDeleteCAS is used to conditionally delete a secure
 // variable if and only if it has a given modify index. If the CAS
 // index (cidx) specified is not equal to the last observed index for
 // the given variable, then the call is a noop, otherwise a normal
 // delete is invoked.
 func (s *StateStore) SVEDeleteCAS(idx uint64, req *structs.SVApplyStateRequest) *structs.SVApplyStateResponse {
-	tx := s.db.WriteTxn(idx)
+	tx := s.db.WriteTxnMsgT(structs.SVApplyStateRequestType, idx)
 	defer tx.Abort()
 
 	resp := s.svDeleteCASTxn(tx, idx, req)
@@ -401,6 +531,12 @@ func (s *StateStore) svDeleteTxn(tx WriteTxn, idx uint64, req *structs.SVApplySt
 	}
 
 	sv := existingRaw.(*structs.SecureVariableEncrypted)
+	if sv.SourceRegion != "" && sv.SourceRegion != req.Var.SourceRegion {
+		return req.ErrorResponse(idx, replicatedSecureVariableError(sv))
+	}
+	if sv.DeleteProtection && req.Var.SourceRegion == "" && !req.Force {
+		return req.ErrorResponse(idx, deleteProtectedSecureVariableError(sv))
+	}
 
 	// Track quota usage
 	if existingQuota != nil {
@@ -425,6 +561,22 @@ func (s *StateStore) svDeleteTxn(tx WriteTxn, idx uint64, req *structs.SVApplySt
 	return req.SuccessResponse(idx, nil)
 }
 
+// replicatedSecureVariableError is returned when writing or deleting a secure
+// variable replicated from another region, which can only be modified by the
+// replication from its source region.
+func replicatedSecureVariableError(sv *structs.SecureVariableEncrypted) error {
+	return fmt.Errorf("secure variable %q is replicated from region %q and can only be modified there",
+		sv.Path, sv.SourceRegion)
+}
+
+// deleteProtectedSecureVariableError is returned when deleting a secure
+// variable with delete protection, or removing its protection, without
+// forcing it.
+func deleteProtectedSecureVariableError(sv *structs.SecureVariableEncrypted) error {
+	return fmt.Errorf("%w: %q can only be deleted or unprotected with force",
+		structs.ErrSecureVariableDeleteProtected, sv.Path)
+}
+
 // This extra indirection is to facilitate the tombstone case if it matters.
 func svMaxIndex(tx ReadTxn) uint64 {
 	return maxIndexTxn(tx, TableSecureVariables)
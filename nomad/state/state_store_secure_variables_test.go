@@ -333,6 +333,129 @@ func TestStateStore_DeleteSecureVariable(t *testing.T) {
 	})
 }
 
+func TestStateStore_SecureVariables_Replicas(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	replica := mock.SecureVariableEncrypted()
+	replica.Path = "replicated"
+	replica.SourceRegion = "east"
+	resp := testState.SVESet(10, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpSet,
+		Var: replica,
+	})
+	require.NoError(t, resp.Error)
+
+	// Writes that aren't from the source region are rejected
+	local := replica.Copy()
+	local.SourceRegion = ""
+	resp = testState.SVESet(11, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpSet,
+		Var: &local,
+	})
+	require.EqualError(t, resp.Error,
+		`secure variable "replicated" is replicated from region "east" and can only be modified there`)
+
+	other := replica.Copy()
+	other.SourceRegion = "west"
+	resp = testState.SVESet(12, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpSet,
+		Var: &other,
+	})
+	require.Error(t, resp.Error)
+
+	resp = testState.SVEDelete(13, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpDelete,
+		Var: &local,
+	})
+	require.Error(t, resp.Error)
+
+	err := testState.SVEDeleteBatch(14, &structs.SVBatchDeleteStateRequest{
+		Namespace: replica.Namespace,
+		Paths:     []string{replica.Path},
+	})
+	require.Error(t, err)
+
+	// The source region can update and delete it
+	update := replica.Copy()
+	update.Data = []byte("bar")
+	resp = testState.SVESet(15, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpSet,
+		Var: &update,
+	})
+	require.NoError(t, resp.Error)
+
+	got, err := testState.GetSecureVariable(nil, replica.Namespace, replica.Path)
+	require.NoError(t, err)
+	require.Equal(t, "east", got.SourceRegion)
+	require.Equal(t, []byte("bar"), got.Data)
+
+	resp = testState.SVEDelete(16, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpDelete,
+		Var: &update,
+	})
+	require.NoError(t, resp.Error)
+
+	got, err = testState.GetSecureVariable(nil, replica.Namespace, replica.Path)
+	require.NoError(t, err)
+	require.Nil(t, got)
+}
+
+func TestStateStore_SecureVariables_DeleteProtection(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	sv := mock.SecureVariableEncrypted()
+	sv.Path = "protected"
+	sv.DeleteProtection = true
+	resp := testState.SVESet(10, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpSet,
+		Var: sv,
+	})
+	require.NoError(t, resp.Error)
+
+	// Deletes and updates removing the protection must be forced
+	resp = testState.SVEDelete(11, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpDelete,
+		Var: sv,
+	})
+	require.ErrorIs(t, resp.Error, structs.ErrSecureVariableDeleteProtected)
+
+	err := testState.SVEDeleteBatch(12, &structs.SVBatchDeleteStateRequest{
+		Namespace: sv.Namespace,
+		Paths:     []string{sv.Path},
+	})
+	require.ErrorIs(t, err, structs.ErrSecureVariableDeleteProtected)
+
+	unprotected := sv.Copy()
+	unprotected.DeleteProtection = false
+	resp = testState.SVESet(13, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpSet,
+		Var: &unprotected,
+	})
+	require.ErrorIs(t, resp.Error, structs.ErrSecureVariableDeleteProtected)
+
+	// Updates keeping the protection are allowed
+	update := sv.Copy()
+	update.Data = []byte("bar")
+	resp = testState.SVESet(14, &structs.SVApplyStateRequest{
+		Op:  structs.SVOpSet,
+		Var: &update,
+	})
+	require.NoError(t, resp.Error)
+
+	resp = testState.SVEDelete(15, &structs.SVApplyStateRequest{
+		Op:    structs.SVOpDelete,
+		Var:   &update,
+		Force: true,
+	})
+	require.NoError(t, resp.Error)
+
+	got, err := testState.GetSecureVariable(nil, sv.Namespace, sv.Path)
+	require.NoError(t, err)
+	require.Nil(t, got)
+}
+
 func TestStateStore_GetSecureVariables(t *testing.T) {
 	ci.Parallel(t)
 	testState := testStateStore(t)
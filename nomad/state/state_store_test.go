@@ -1354,6 +1354,39 @@ func TestStateStore_UpdateNodeStatus_Node(t *testing.T) {
 	require.False(watchFired(ws))
 }
 
+func TestStateStore_UpdateNodeStatus_LastReconnect(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	node := mock.Node()
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 800, node))
+
+	lastReconnect := func() int64 {
+		out, err := state.NodeByID(nil, node.ID)
+		require.NoError(t, err)
+		return out.LastReconnect
+	}
+
+	require.NoError(t, state.UpdateNodeStatus(structs.MsgTypeTestSetup, 801, node.ID, structs.NodeStatusDisconnected, 70, nil))
+	require.Zero(t, lastReconnect())
+
+	// Leaving the disconnected status records the reconnect
+	require.NoError(t, state.UpdateNodeStatus(structs.MsgTypeTestSetup, 802, node.ID, structs.NodeStatusReady, 80, nil))
+	require.EqualValues(t, 80, lastReconnect())
+
+	// Going down from disconnected isn't a reconnect
+	require.NoError(t, state.UpdateNodeStatus(structs.MsgTypeTestSetup, 803, node.ID, structs.NodeStatusDisconnected, 90, nil))
+	require.NoError(t, state.UpdateNodeStatus(structs.MsgTypeTestSetup, 804, node.ID, structs.NodeStatusDown, 100, nil))
+	require.EqualValues(t, 80, lastReconnect())
+
+	// Registering again retains it
+	node = node.Copy()
+	node.Status = structs.NodeStatusReady
+	node.StatusUpdatedAt = 110
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 805, node))
+	require.EqualValues(t, 80, lastReconnect())
+}
+
 func TestStateStore_BatchUpdateNodeDrain(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -1461,6 +1494,39 @@ func TestStateStore_UpdateNodeDrain_Node(t *testing.T) {
 	require.False(watchFired(ws))
 }
 
+func TestStateStore_UpdateNodeDrain_EligibilityReason(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	node := mock.Node()
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	// Mark the node ineligible with a reason
+	require.NoError(t, state.UpdateNodeEligibility(structs.MsgTypeTestSetup, 1001, node.ID,
+		structs.NodeSchedulingIneligible, "maintenance", 7, nil))
+
+	// A drain without a message clears the previous reason
+	drain := &structs.DrainStrategy{
+		DrainSpec: structs.DrainSpec{
+			Deadline: -1 * time.Second,
+		},
+	}
+	require.NoError(t, state.UpdateNodeDrain(structs.MsgTypeTestSetup, 1002, node.ID, drain, false, 8, nil, nil, ""))
+
+	out, err := state.NodeByID(nil, node.ID)
+	require.NoError(t, err)
+	require.Equal(t, structs.NodeSchedulingIneligible, out.SchedulingEligibility)
+	require.Equal(t, "", out.EligibilityReason)
+
+	// A drain with a message uses it as the reason
+	require.NoError(t, state.UpdateNodeDrain(structs.MsgTypeTestSetup, 1003, node.ID, drain, false, 9, nil,
+		map[string]string{"message": "decommission"}, ""))
+
+	out, err = state.NodeByID(nil, node.ID)
+	require.NoError(t, err)
+	require.Equal(t, "decommission", out.EligibilityReason)
+}
+
 func TestStateStore_AddSingleNodeEvent(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -1633,13 +1699,14 @@ func TestStateStore_UpdateNodeEligibility(t *testing.T) {
 		Subsystem: structs.NodeEventSubsystemCluster,
 		Timestamp: time.Now(),
 	}
-	require.Nil(state.UpdateNodeEligibility(structs.MsgTypeTestSetup, 1001, node.ID, expectedEligibility, 7, event))
+	require.Nil(state.UpdateNodeEligibility(structs.MsgTypeTestSetup, 1001, node.ID, expectedEligibility, "maintenance", 7, event))
 	require.True(watchFired(ws))
 
 	ws = memdb.NewWatchSet()
 	out, err := state.NodeByID(ws, node.ID)
 	require.Nil(err)
 	require.Equal(out.SchedulingEligibility, expectedEligibility)
+	require.Equal("maintenance", out.EligibilityReason)
 	require.Len(out.Events, 2)
 	require.Equal(out.Events[1], event)
 	require.EqualValues(1001, out.ModifyIndex)
@@ -1659,11 +1726,67 @@ func TestStateStore_UpdateNodeEligibility(t *testing.T) {
 	require.Nil(state.UpdateNodeDrain(structs.MsgTypeTestSetup, 1002, node.ID, expectedDrain, false, 7, nil, nil, ""))
 
 	// Try to set the node to eligible
-	err = state.UpdateNodeEligibility(structs.MsgTypeTestSetup, 1003, node.ID, structs.NodeSchedulingEligible, 9, nil)
+	err = state.UpdateNodeEligibility(structs.MsgTypeTestSetup, 1003, node.ID, structs.NodeSchedulingEligible, "", 9, nil)
 	require.NotNil(err)
 	require.Contains(err.Error(), "while it is draining")
 }
 
+func TestStateStore_UpdateNodePlannedDisconnect(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	node := mock.Node()
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	running := mock.Alloc()
+	running.NodeID = node.ID
+	stopped := mock.Alloc()
+	stopped.NodeID = node.ID
+	stopped.DesiredStatus = structs.AllocDesiredStatusStop
+	stopped.ClientStatus = structs.AllocClientStatusComplete
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{running, stopped}))
+
+	now := time.Now().UTC()
+	planned := &structs.PlannedDisconnect{
+		StartedAt: now,
+		Deadline:  now.Add(30 * time.Minute),
+	}
+	require.NoError(t, state.UpdateNodePlannedDisconnect(structs.MsgTypeTestSetup, 1002, node.ID, planned, 7, nil))
+
+	out, err := state.NodeByID(nil, node.ID)
+	require.NoError(t, err)
+	require.Equal(t, planned, out.PlannedDisconnect)
+	require.EqualValues(t, 1002, out.ModifyIndex)
+
+	// Only the running allocation records the deadline
+	alloc, err := state.AllocByID(nil, running.ID)
+	require.NoError(t, err)
+	require.Equal(t, planned.Deadline, alloc.PlannedDisconnectDeadline)
+	require.EqualValues(t, 1002, alloc.ModifyIndex)
+
+	// Clients learn about the planned disconnect from their heartbeats, so
+	// the allocations aren't sent to them again
+	require.EqualValues(t, 1001, alloc.AllocModifyIndex)
+
+	alloc, err = state.AllocByID(nil, stopped.ID)
+	require.NoError(t, err)
+	require.True(t, alloc.PlannedDisconnectDeadline.IsZero())
+
+	// The planned disconnect is cleared when the node reconnects
+	require.NoError(t, state.UpdateNodeStatus(structs.MsgTypeTestSetup, 1003, node.ID, structs.NodeStatusDisconnected, 8, nil))
+	require.NoError(t, state.UpdateNodeStatus(structs.MsgTypeTestSetup, 1004, node.ID, structs.NodeStatusReady, 9, nil))
+
+	out, err = state.NodeByID(nil, node.ID)
+	require.NoError(t, err)
+	require.Nil(t, out.PlannedDisconnect)
+
+	// Canceling clears the deadline on the allocations
+	require.NoError(t, state.UpdateNodePlannedDisconnect(structs.MsgTypeTestSetup, 1005, node.ID, nil, 10, nil))
+	alloc, err = state.AllocByID(nil, running.ID)
+	require.NoError(t, err)
+	require.True(t, alloc.PlannedDisconnectDeadline.IsZero())
+}
+
 func TestStateStore_Nodes(t *testing.T) {
 	ci.Parallel(t)
 
@@ -2192,6 +2315,36 @@ func TestStateStore_UpdateUpsertJob_JobVersion(t *testing.T) {
 	}
 }
 
+func TestStateStore_DeleteJobVersions(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	job := mock.Job()
+	for i := uint64(0); i < 3; i++ {
+		job = job.Copy()
+		job.Meta["version"] = fmt.Sprintf("%d", i)
+		require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000+i, job))
+	}
+
+	// The current version and unknown versions are skipped
+	versions := []*structs.JobVersionID{
+		{Namespace: job.Namespace, ID: job.ID, Version: 0},
+		{Namespace: job.Namespace, ID: job.ID, Version: 2},
+		{Namespace: job.Namespace, ID: job.ID, Version: 10},
+	}
+	require.NoError(t, state.DeleteJobVersions(1010, versions))
+
+	out, err := state.JobVersionsByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Len(t, out, 2)
+	require.Equal(t, uint64(2), out[0].Version)
+	require.Equal(t, uint64(1), out[1].Version)
+
+	index, err := state.Index("job_version")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1010), index)
+}
+
 func TestStateStore_DeleteJob_Job(t *testing.T) {
 	ci.Parallel(t)
 
@@ -6345,6 +6498,36 @@ func TestStateStore_AllocsForRegisteredJob(t *testing.T) {
 	}
 }
 
+func TestStateStore_AllocRescheduleChain(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+
+	// gc'ed <- a1 <- a2 <- a3 <- a4
+	allocs := make([]*structs.Allocation, 4)
+	for i := range allocs {
+		allocs[i] = mock.Alloc()
+	}
+	allocs[0].PreviousAllocation = uuid.Generate()
+	for i := 1; i < len(allocs); i++ {
+		allocs[i].JobID = allocs[0].JobID
+		allocs[i].Job = allocs[0].Job
+		allocs[i].PreviousAllocation = allocs[i-1].ID
+		allocs[i-1].NextAllocation = allocs[i].ID
+	}
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, allocs))
+
+	chain, err := state.AllocRescheduleChain(nil, allocs[2])
+	require.NoError(t, err)
+	require.Equal(t, []string{allocs[0].ID, allocs[1].ID}, chain.Ancestors)
+	require.Equal(t, []string{allocs[3].ID}, chain.Descendants)
+
+	chain, err = state.AllocRescheduleChain(nil, allocs[0])
+	require.NoError(t, err)
+	require.Empty(t, chain.Ancestors)
+	require.Equal(t, []string{allocs[1].ID, allocs[2].ID, allocs[3].ID}, chain.Descendants)
+}
+
 func TestStateStore_AllocsByIDPrefix(t *testing.T) {
 	ci.Parallel(t)
 
@@ -120,7 +120,12 @@ func (e *EventBroker) SubscribeWithACLCheck(req *SubscribeRequest) (*Subscriptio
 		return nil, structs.ErrPermissionDenied
 	}
 
-	return e.Subscribe(req)
+	sub, err := e.Subscribe(req)
+	if err != nil {
+		return nil, err
+	}
+	sub.aclObj.Store(aclObj)
+	return sub, nil
 }
 
 // Subscribe returns a new Subscription for a given request. A Subscription
@@ -209,9 +214,7 @@ func (e *EventBroker) handleACLUpdates(ctx context.Context) {
 					continue
 				}
 
-				e.subscriptions.closeSubscriptionFunc(tokenSecretID, func(sub *Subscription) bool {
-					return !aclAllowsSubscription(aclObj, sub.req)
-				})
+				e.subscriptions.closeSubscriptionFunc(tokenSecretID, updateSubscriptionACL(aclObj))
 
 			case *structs.ACLPolicyEvent:
 				// Re-evaluate each subscriptions permissions since a policy
@@ -251,9 +254,20 @@ func (e *EventBroker) checkSubscriptionsAgainstPolicyChange() {
 			continue
 		}
 
-		e.subscriptions.closeSubscriptionFunc(tokenSecretID, func(sub *Subscription) bool {
-			return !aclAllowsSubscription(aclObj, sub.req)
-		})
+		e.subscriptions.closeSubscriptionFunc(tokenSecretID, updateSubscriptionACL(aclObj))
+	}
+}
+
+// updateSubscriptionACL returns a function for closeSubscriptionFunc that
+// closes the subscriptions the ACL no longer allows, and updates the ACL the
+// events of the others are filtered with.
+func updateSubscriptionACL(aclObj *acl.ACL) func(*Subscription) bool {
+	return func(sub *Subscription) bool {
+		if !aclAllowsSubscription(aclObj, sub.req) {
+			return true
+		}
+		sub.aclObj.Store(aclObj)
+		return false
 	}
 }
 
@@ -308,6 +322,10 @@ func aclAllowsSubscription(aclObj *acl.ACL, subReq *SubscribeRequest) bool {
 			if ok := aclObj.AllowNodeRead(); !ok {
 				return false
 			}
+		case structs.TopicSecureVariable:
+			if ok := aclObj.AllowSecureVariableSearch(subReq.Namespace); !ok {
+				return false
+			}
 		default:
 			if ok := aclObj.IsManagement(); !ok {
 				return false
@@ -630,3 +630,76 @@ func consumeSub(ctx context.Context, sub *Subscription) error {
 		}
 	}
 }
+
+func TestEventBroker_SecureVariableEventsFilteredByACL(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	secretID := "some-secret-id"
+	policy := &structs.ACLPolicy{
+		Name: "some-policy",
+		Rules: mock.NamespacePolicyWithSecureVariables(structs.DefaultNamespace, "", nil,
+			map[string][]string{"foo/*": {acl.PolicyList}}),
+	}
+	policy.SetHash()
+
+	publisher, err := NewEventBroker(ctx, &fakeACLDelegate{
+		tokenProvider: &fakeACLTokenProvider{
+			policy: policy,
+			token: &structs.ACLToken{
+				SecretID: secretID,
+				Policies: []string{policy.Name},
+			},
+		},
+	}, EventBrokerCfg{})
+	require.NoError(t, err)
+
+	sub, err := publisher.SubscribeWithACLCheck(&SubscribeRequest{
+		Topics: map[structs.Topic][]string{
+			structs.TopicSecureVariable: {"*"},
+		},
+		Namespace: structs.DefaultNamespace,
+		Token:     secretID,
+	})
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	varEvent := func(path string) structs.Event {
+		return structs.Event{
+			Topic:     structs.TopicSecureVariable,
+			Type:      structs.TypeSecureVariableUpserted,
+			Key:       path,
+			Namespace: structs.DefaultNamespace,
+			Payload: &structs.SecureVariableEvent{
+				SecureVariable: &structs.SecureVariableMetadata{
+					Namespace: structs.DefaultNamespace,
+					Path:      path,
+				},
+			},
+		}
+	}
+
+	// The events of the secure variables the token can't list are dropped,
+	// even without a path filter
+	publisher.Publish(&structs.Events{Index: 1, Events: []structs.Event{
+		varEvent("bar/secret"), varEvent("foo/app"),
+	}})
+	publisher.Publish(&structs.Events{Index: 2, Events: []structs.Event{
+		varEvent("bar/other"),
+	}})
+	publisher.Publish(&structs.Events{Index: 3, Events: []structs.Event{
+		varEvent("foo/db"),
+	}})
+
+	var paths []string
+	for len(paths) < 2 {
+		events, err := sub.Next(ctx)
+		require.NoError(t, err)
+		for _, e := range events.Events {
+			paths = append(paths, e.Key)
+		}
+	}
+	require.Equal(t, []string{"foo/app", "foo/db"}, paths)
+}
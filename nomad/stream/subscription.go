@@ -3,9 +3,12 @@ package stream
 import (
 	"context"
 	"errors"
+	"strings"
 	"sync/atomic"
 
+	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/ryanuber/go-glob"
 )
 
 const (
@@ -43,6 +46,11 @@ type Subscription struct {
 	// It must be safe to call the function from multiple goroutines and the function
 	// must be idempotent.
 	unsub func()
+
+	// aclObj is the ACL of the subscription's token, set by EventBroker when
+	// ACLs are enabled. It filters out the events of the secure variables
+	// the token isn't allowed to list.
+	aclObj atomic.Pointer[acl.ACL]
 }
 
 type SubscribeRequest struct {
@@ -52,6 +60,10 @@ type SubscribeRequest struct {
 
 	Topics map[structs.Topic][]string
 
+	// Filter holds the filters matching the events' payloads, in addition
+	// to their topic, key and namespace.
+	Filter *structs.EventFilter
+
 	// StartExactlyAtIndex specifies if a subscription needs to
 	// start exactly at the requested Index. If set to false,
 	// the closest index in the buffer will be returned if there is not
@@ -83,7 +95,7 @@ func (s *Subscription) Next(ctx context.Context) (structs.Events, error) {
 		}
 		s.currentItem = next
 
-		events := filter(s.req, next.Events.Events)
+		events := filterByACL(s.aclObj.Load(), filter(s.req, next.Events.Events))
 		if len(events) == 0 {
 			continue
 		}
@@ -103,7 +115,7 @@ func (s *Subscription) NextNoBlock() ([]structs.Event, error) {
 		}
 		s.currentItem = next
 
-		events := filter(s.req, next.Events.Events)
+		events := filterByACL(s.aclObj.Load(), filter(s.req, next.Events.Events))
 		if len(events) == 0 {
 			continue
 		}
@@ -116,6 +128,7 @@ func (s *Subscription) Unsubscribe() {
 }
 
 // filter events to only those that match a subscriptions topic/keys/namespace
+// and payload filters
 func filter(req *SubscribeRequest, events []structs.Event) []structs.Event {
 	if len(events) == 0 {
 		return nil
@@ -124,7 +137,8 @@ func filter(req *SubscribeRequest, events []structs.Event) []structs.Event {
 	allTopicKeys := req.Topics[structs.TopicAll]
 
 	// Return all events if subscribed to all namespaces and all topics
-	if req.Namespace == "*" && len(allTopicKeys) == 1 && allTopicKeys[0] == string(structs.TopicAll) {
+	if req.Namespace == "*" && req.Filter.IsEmpty() &&
+		len(allTopicKeys) == 1 && allTopicKeys[0] == string(structs.TopicAll) {
 		return events
 	}
 
@@ -135,6 +149,10 @@ func filter(req *SubscribeRequest, events []structs.Event) []structs.Event {
 			continue
 		}
 
+		if !eventMatchesFilter(event, req.Filter) {
+			continue
+		}
+
 		// *[*] always matches
 		if len(allTopicKeys) == 1 && allTopicKeys[0] == string(structs.TopicAll) {
 			result = append(result, event)
@@ -163,6 +181,28 @@ func filter(req *SubscribeRequest, events []structs.Event) []structs.Event {
 	return result
 }
 
+// filterByACL filters out the events of the secure variables the ACL doesn't
+// allow listing. Subscribing to the secure variable topic only requires
+// access to some secure variables of the namespace, so each of them has to
+// be checked. A nil ACL allows all events.
+func filterByACL(aclObj *acl.ACL, events []structs.Event) []structs.Event {
+	if aclObj == nil || aclObj.IsManagement() {
+		return events
+	}
+
+	var result []structs.Event
+	for _, event := range events {
+		if payload, ok := event.Payload.(*structs.SecureVariableEvent); ok && payload.SecureVariable != nil {
+			sv := payload.SecureVariable
+			if !aclObj.AllowSecureVariableOperation(sv.Namespace, sv.Path, acl.PolicyList) {
+				continue
+			}
+		}
+		result = append(result, event)
+	}
+	return result
+}
+
 func eventMatchesKey(event structs.Event, key string) bool {
 	if event.Key == key {
 		return true
@@ -176,3 +216,44 @@ func eventMatchesKey(event structs.Event, key string) bool {
 
 	return false
 }
+
+// eventMatchesFilter returns whether the payload of the event matches the
+// filter. Events whose payload doesn't carry the attribute a filter matches
+// on are not filtered out by it.
+func eventMatchesFilter(event structs.Event, f *structs.EventFilter) bool {
+	if f.IsEmpty() {
+		return true
+	}
+
+	switch payload := event.Payload.(type) {
+	case *structs.JobEvent:
+		if payload.Job != nil {
+			return strings.HasPrefix(payload.Job.ID, f.JobPrefix)
+		}
+	case *structs.EvaluationEvent:
+		if payload.Evaluation != nil {
+			return strings.HasPrefix(payload.Evaluation.JobID, f.JobPrefix)
+		}
+	case *structs.AllocationEvent:
+		if payload.Allocation != nil {
+			return strings.HasPrefix(payload.Allocation.JobID, f.JobPrefix)
+		}
+	case *structs.DeploymentEvent:
+		if payload.Deployment != nil {
+			return strings.HasPrefix(payload.Deployment.JobID, f.JobPrefix)
+		}
+	case *structs.ServiceRegistrationStreamEvent:
+		if payload.Service != nil {
+			return strings.HasPrefix(payload.Service.JobID, f.JobPrefix)
+		}
+	case *structs.NodeStreamEvent:
+		if payload.Node != nil && f.NodeClass != "" {
+			return payload.Node.NodeClass == f.NodeClass
+		}
+	case *structs.SecureVariableEvent:
+		if payload.SecureVariable != nil && f.VariablePath != "" {
+			return glob.Glob(f.VariablePath, payload.SecureVariable.Path)
+		}
+	}
+	return true
+}
@@ -208,3 +208,67 @@ func TestFilter_FilterKeys(t *testing.T) {
 
 	require.Equal(t, 1, cap(actual))
 }
+
+func TestFilter_Payload(t *testing.T) {
+	ci.Parallel(t)
+
+	jobEvent := structs.Event{Topic: structs.TopicJob, Key: "web-api",
+		Payload: &structs.JobEvent{Job: &structs.Job{ID: "web-api"}}}
+	otherJobEvent := structs.Event{Topic: structs.TopicJob, Key: "batch",
+		Payload: &structs.JobEvent{Job: &structs.Job{ID: "batch"}}}
+	allocEvent := structs.Event{Topic: structs.TopicAllocation, Key: "a1",
+		Payload: &structs.AllocationEvent{Allocation: &structs.Allocation{ID: "a1", JobID: "web-frontend"}}}
+	nodeEvent := structs.Event{Topic: structs.TopicNode, Key: "n1",
+		Payload: &structs.NodeStreamEvent{Node: &structs.Node{ID: "n1", NodeClass: "gpu"}}}
+	otherNodeEvent := structs.Event{Topic: structs.TopicNode, Key: "n2",
+		Payload: &structs.NodeStreamEvent{Node: &structs.Node{ID: "n2", NodeClass: "cpu"}}}
+	varEvent := structs.Event{Topic: structs.TopicSecureVariable, Key: "nomad/jobs/web/db",
+		Payload: &structs.SecureVariableEvent{SecureVariable: &structs.SecureVariableMetadata{Path: "nomad/jobs/web/db"}}}
+	otherVarEvent := structs.Event{Topic: structs.TopicSecureVariable, Key: "nomad/jobs/batch",
+		Payload: &structs.SecureVariableEvent{SecureVariable: &structs.SecureVariableMetadata{Path: "nomad/jobs/batch"}}}
+	events := []structs.Event{jobEvent, otherJobEvent, allocEvent, nodeEvent, otherNodeEvent, varEvent, otherVarEvent}
+
+	testCases := []struct {
+		name     string
+		filter   *structs.EventFilter
+		expected []structs.Event
+	}{
+		{
+			name:     "empty",
+			filter:   &structs.EventFilter{},
+			expected: events,
+		},
+		{
+			name:     "job prefix",
+			filter:   &structs.EventFilter{JobPrefix: "web"},
+			expected: []structs.Event{jobEvent, allocEvent, nodeEvent, otherNodeEvent, varEvent, otherVarEvent},
+		},
+		{
+			name:     "node class",
+			filter:   &structs.EventFilter{NodeClass: "gpu"},
+			expected: []structs.Event{jobEvent, otherJobEvent, allocEvent, nodeEvent, varEvent, otherVarEvent},
+		},
+		{
+			name:     "variable path",
+			filter:   &structs.EventFilter{VariablePath: "nomad/jobs/web/*"},
+			expected: []structs.Event{jobEvent, otherJobEvent, allocEvent, nodeEvent, otherNodeEvent, varEvent},
+		},
+		{
+			name:     "combined",
+			filter:   &structs.EventFilter{JobPrefix: "web-api", NodeClass: "cpu", VariablePath: "*/batch"},
+			expected: []structs.Event{jobEvent, otherNodeEvent, otherVarEvent},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := &SubscribeRequest{
+				Topics: map[structs.Topic][]string{
+					"*": {"*"},
+				},
+				Namespace: "*",
+				Filter:    tc.filter,
+			}
+			require.Equal(t, tc.expected, filter(req, events))
+		})
+	}
+}
@@ -69,7 +69,7 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 	diff := &JobDiff{Type: DiffTypeNone}
 	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
 	filter := []string{"ID", "Status", "StatusDescription", "Version", "Stable", "CreateIndex",
-		"ModifyIndex", "JobModifyIndex", "Update", "SubmitTime", "NomadTokenID"}
+		"ModifyIndex", "JobModifyIndex", "Update", "SubmitTime", "NomadTokenID", "SigningKeyID"}
 
 	if j == nil && other == nil {
 		return diff, nil
@@ -145,6 +145,29 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 		diff.Objects = append(diff.Objects, mrDiff)
 	}
 
+	// Disconnect diff
+	if dDiff := primitiveObjectDiff(j.Disconnect, other.Disconnect, nil, "Disconnect", contextual); dDiff != nil {
+		diff.Objects = append(diff.Objects, dDiff)
+	}
+
+	// Disconnect hooks diff
+	if hDiff := primitiveObjectSetDiff(
+		interfaceSlice(j.OnDisconnect),
+		interfaceSlice(other.OnDisconnect),
+		nil,
+		"OnDisconnect",
+		contextual); hDiff != nil {
+		diff.Objects = append(diff.Objects, hDiff...)
+	}
+	if hDiff := primitiveObjectSetDiff(
+		interfaceSlice(j.OnReconnect),
+		interfaceSlice(other.OnReconnect),
+		nil,
+		"OnReconnect",
+		contextual); hDiff != nil {
+		diff.Objects = append(diff.Objects, hDiff...)
+	}
+
 	// Check to see if there is a diff. We don't use reflect because we are
 	// filtering quite a few fields that will change on each diff.
 	if diff.Type == DiffTypeNone {
@@ -279,6 +302,20 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 		}
 	}
 
+	// MaxClientDisconnectJitter diff
+	if oldPrimitiveFlat != nil && newPrimitiveFlat != nil {
+		if tg.MaxClientDisconnectJitter == nil {
+			oldPrimitiveFlat["MaxClientDisconnectJitter"] = ""
+		} else {
+			oldPrimitiveFlat["MaxClientDisconnectJitter"] = fmt.Sprintf("%d", *tg.MaxClientDisconnectJitter)
+		}
+		if other.MaxClientDisconnectJitter == nil {
+			newPrimitiveFlat["MaxClientDisconnectJitter"] = ""
+		} else {
+			newPrimitiveFlat["MaxClientDisconnectJitter"] = fmt.Sprintf("%d", *other.MaxClientDisconnectJitter)
+		}
+	}
+
 	// Diff the primitive fields.
 	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, false)
 
@@ -304,12 +341,29 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 		diff.Objects = append(diff.Objects, affinitiesDiff...)
 	}
 
+	// MaxClientDisconnectTiers diff
+	tiersDiff := primitiveObjectSetDiff(
+		interfaceSlice(tg.MaxClientDisconnectTiers),
+		interfaceSlice(other.MaxClientDisconnectTiers),
+		nil,
+		"MaxClientDisconnectTier",
+		contextual)
+	if tiersDiff != nil {
+		diff.Objects = append(diff.Objects, tiersDiff...)
+	}
+
 	// Restart policy diff
 	rDiff := primitiveObjectDiff(tg.RestartPolicy, other.RestartPolicy, nil, "RestartPolicy", contextual)
 	if rDiff != nil {
 		diff.Objects = append(diff.Objects, rDiff)
 	}
 
+	// Restart budget diff
+	rbDiff := primitiveObjectDiff(tg.RestartBudget, other.RestartBudget, nil, "RestartBudget", contextual)
+	if rbDiff != nil {
+		diff.Objects = append(diff.Objects, rbDiff)
+	}
+
 	// Reschedule policy diff
 	reschedDiff := primitiveObjectDiff(tg.ReschedulePolicy, other.ReschedulePolicy, nil, "ReschedulePolicy", contextual)
 	if reschedDiff != nil {
@@ -516,6 +570,18 @@ func (t *Task) Diff(other *Task, contextual bool) (*TaskDiff, error) {
 		diff.Objects = append(diff.Objects, dDiff)
 	}
 
+	// Identity diff
+	idDiff := primitiveObjectDiff(t.Identity, other.Identity, nil, "Identity", contextual)
+	if idDiff != nil {
+		diff.Objects = append(diff.Objects, idDiff)
+	}
+
+	// Checkpoint diff
+	cpDiff := primitiveObjectDiff(t.Checkpoint, other.Checkpoint, nil, "Checkpoint", contextual)
+	if cpDiff != nil {
+		diff.Objects = append(diff.Objects, cpDiff)
+	}
+
 	// Artifacts diff
 	diffs := primitiveObjectSetDiff(
 		interfaceSlice(t.Artifacts),
@@ -855,6 +855,48 @@ func TestJobDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			// Disconnect hooks added
+			Old: &Job{},
+			New: &Job{
+				OnDisconnect: []*DisconnectHook{
+					{
+						Task:    "web",
+						Signal:  "SIGUSR1",
+						Timeout: time.Second,
+					},
+				},
+			},
+			Expected: &JobDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeAdded,
+						Name: "OnDisconnect",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Signal",
+								Old:  "",
+								New:  "SIGUSR1",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Task",
+								Old:  "",
+								New:  "web",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Timeout",
+								Old:  "",
+								New:  "1000000000",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			// Task groups edited
 			Old: &Job{
@@ -2217,6 +2259,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "0",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "CanaryDistinctHosts",
+								Old:  "false",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "HealthyDeadline",
@@ -2279,6 +2327,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "CanaryDistinctHosts",
+								Old:  "",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "HealthyDeadline",
@@ -2446,6 +2500,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "2",
 								New:  "2",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "CanaryDistinctHosts",
+								Old:  "false",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "HealthCheck",
@@ -4429,6 +4489,62 @@ func TestTaskDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "Checkpoint edited",
+			Old: &Task{
+				Checkpoint: &TaskCheckpoint{
+					Timeout: time.Minute,
+				},
+			},
+			New: &Task{
+				Checkpoint: &TaskCheckpoint{
+					Timeout: 2 * time.Minute,
+				},
+			},
+			Expected: &TaskDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Checkpoint",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Timeout",
+								Old:  "60000000000",
+								New:  "120000000000",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "Identity added",
+			Old:  &Task{},
+			New: &Task{
+				Identity: &WorkloadIdentity{
+					Env: true,
+				},
+			},
+			Expected: &TaskDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeAdded,
+						Name: "Identity",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Env",
+								Old:  "",
+								New:  "true",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			Name: "LogConfig deleted",
 			Old: &Task{
@@ -4770,6 +4886,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "100",
 								New:  "200",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "HugePages2MB",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "IOPS",
@@ -4873,6 +4995,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "100",
 								New:  "100",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "HugePages2MB",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "IOPS",
@@ -5238,6 +5366,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "100",
 								New:  "100",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "HugePages2MB",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "IOPS",
@@ -7192,6 +7326,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "",
 								New:  "22",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxStale",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Perms",
@@ -7322,6 +7462,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "20",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "MaxStale",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Perms",
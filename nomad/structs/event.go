@@ -5,9 +5,36 @@ type EventStreamRequest struct {
 	Topics map[Topic][]string
 	Index  int
 
+	// Filter narrows down the events of the subscribed topics on the server,
+	// so that they aren't sent only to be discarded by the client.
+	Filter *EventFilter
+
 	QueryOptions
 }
 
+// EventFilter holds the server-side filters of an event stream subscription.
+// A filter only applies to the events carrying the attribute it matches on,
+// so that it composes with the subscribed topics: filtering on a job prefix
+// doesn't drop the events of the Node topic. Empty filters match all events.
+type EventFilter struct {
+	// JobPrefix matches the job, evaluation, allocation, deployment, and
+	// service events of the jobs whose ID starts with the prefix.
+	JobPrefix string
+
+	// NodeClass matches the node events of the nodes of the class.
+	NodeClass string
+
+	// VariablePath is a glob matching the path of the secure variable
+	// events. The * wildcard matches any sequence of characters, including
+	// path separators.
+	VariablePath string
+}
+
+// IsEmpty returns whether the filter matches all events.
+func (f *EventFilter) IsEmpty() bool {
+	return f == nil || (f.JobPrefix == "" && f.NodeClass == "" && f.VariablePath == "")
+}
+
 type EventStreamWrapper struct {
 	Error *RpcError
 	Event *EventJson
@@ -16,15 +43,17 @@ type EventStreamWrapper struct {
 type Topic string
 
 const (
-	TopicDeployment Topic = "Deployment"
-	TopicEvaluation Topic = "Evaluation"
-	TopicAllocation Topic = "Allocation"
-	TopicJob        Topic = "Job"
-	TopicNode       Topic = "Node"
-	TopicACLPolicy  Topic = "ACLPolicy"
-	TopicACLToken   Topic = "ACLToken"
-	TopicService    Topic = "Service"
-	TopicAll        Topic = "*"
+	TopicDeployment     Topic = "Deployment"
+	TopicEvaluation     Topic = "Evaluation"
+	TopicAllocation     Topic = "Allocation"
+	TopicJob            Topic = "Job"
+	TopicNode           Topic = "Node"
+	TopicACLPolicy      Topic = "ACLPolicy"
+	TopicACLToken       Topic = "ACLToken"
+	TopicService        Topic = "Service"
+	TopicSecureVariable Topic = "SecureVariable"
+	TopicKeyring        Topic = "Keyring"
+	TopicAll            Topic = "*"
 
 	TypeNodeRegistration              = "NodeRegistration"
 	TypeNodeDeregistration            = "NodeDeregistration"
@@ -48,6 +77,10 @@ const (
 	TypeACLPolicyUpserted             = "ACLPolicyUpserted"
 	TypeServiceRegistration           = "ServiceRegistration"
 	TypeServiceDeregistration         = "ServiceDeregistration"
+	TypeSecureVariableUpserted        = "SecureVariableUpserted"
+	TypeSecureVariableDeleted         = "SecureVariableDeleted"
+	TypeRootKeyMetaUpserted           = "RootKeyMetaUpserted"
+	TypeRootKeyMetaDeleted            = "RootKeyMetaDeleted"
 )
 
 // Event represents a change in Nomads state.
@@ -151,3 +184,15 @@ func (a *ACLTokenEvent) SecretID() string {
 type ACLPolicyEvent struct {
 	ACLPolicy *ACLPolicy
 }
+
+// SecureVariableEvent holds the metadata of a newly updated or deleted secure
+// variable. The encrypted contents are never included.
+type SecureVariableEvent struct {
+	SecureVariable *SecureVariableMetadata
+}
+
+// KeyringEvent holds the metadata of a newly updated or deleted root key. The
+// key material is never included.
+type KeyringEvent struct {
+	RootKeyMeta *RootKeyMeta
+}
@@ -162,13 +162,17 @@ func (a TerminalByNodeByName) Get(nodeID, name string) (*Allocation, bool) {
 // The netIdx can optionally be provided if its already been computed.
 // If the netIdx is provided, it is assumed that the client has already
 // ensured there are no collisions. If checkDevices is set to true, we check if
-// there is a device oversubscription.
+// there is a device oversubscription. Allocations marked as
+// MemoryOversubscribed may use memory beyond the node's capacity, up to its
+// memory oversubscription ratio, as long as the other allocations still fit
+// within its capacity.
 func AllocsFit(node *Node, allocs []*Allocation, netIdx *NetworkIndex, checkDevices bool) (bool, string, *ComparableResources, error) {
 	// Compute the allocs' utilization from zero
 	used := new(ComparableResources)
 
 	reservedCores := map[uint16]struct{}{}
 	var coreOverlap bool
+	var oversubscribedMB int64
 
 	// For each alloc, add the resources
 	for _, alloc := range allocs {
@@ -179,6 +183,9 @@ func AllocsFit(node *Node, allocs []*Allocation, netIdx *NetworkIndex, checkDevi
 
 		cr := alloc.ComparableResources()
 		used.Add(cr)
+		if alloc.MemoryOversubscribed {
+			oversubscribedMB += cr.Flattened.Memory.MemoryMB
+		}
 
 		// Adding the comparable resource unions reserved core sets, need to check if reserved cores overlap
 		for _, core := range cr.Flattened.Cpu.ReservedCores {
@@ -198,6 +205,12 @@ func AllocsFit(node *Node, allocs []*Allocation, netIdx *NetworkIndex, checkDevi
 	// super set of those that are being allocated
 	available := node.ComparableResources()
 	available.Subtract(node.ComparableReservedResources())
+	if oversubscribedMB > 0 {
+		if used.Flattened.Memory.MemoryMB-oversubscribedMB > available.Flattened.Memory.MemoryMB {
+			return false, "memory", used, nil
+		}
+		available.Flattened.Memory.MemoryMB = int64(float64(available.Flattened.Memory.MemoryMB) * node.MemoryOversubscriptionRatio())
+	}
 	if superset, dimension := available.Superset(used); !superset {
 		return false, dimension, used, nil
 	}
@@ -626,6 +626,117 @@ func TestAllocsFit_MemoryOversubscription(t *testing.T) {
 	require.EqualValues(t, 12000, used.Flattened.Memory.MemoryMaxMB)
 }
 
+func TestAllocsFit_MemoryOversubscriptionRatio(t *testing.T) {
+	ci.Parallel(t)
+
+	n := &Node{
+		NodeResources: &NodeResources{
+			Cpu: NodeCpuResources{
+				CpuShares: 2000,
+			},
+			Memory: NodeMemoryResources{
+				MemoryMB: 2048,
+			},
+			MemoryOversubscriptionRatio: 1.5,
+		},
+	}
+
+	newAlloc := func(oversubscribed bool) *Allocation {
+		return &Allocation{
+			AllocatedResources: &AllocatedResources{
+				Tasks: map[string]*AllocatedTaskResources{
+					"web": {
+						Cpu: AllocatedCpuResources{
+							CpuShares: 100,
+						},
+						Memory: AllocatedMemoryResources{
+							MemoryMB: 1000,
+						},
+					},
+				},
+			},
+			MemoryOversubscribed: oversubscribed,
+		}
+	}
+
+	// Should not fit a third allocation that isn't oversubscribed
+	fit, dim, _, err := AllocsFit(n, []*Allocation{newAlloc(false), newAlloc(false), newAlloc(false)}, nil, false)
+	require.NoError(t, err)
+	require.False(t, fit)
+	require.Equal(t, "memory", dim)
+
+	// Should fit a third allocation within the oversubscription ratio
+	fit, _, used, err := AllocsFit(n, []*Allocation{newAlloc(false), newAlloc(false), newAlloc(true)}, nil, false)
+	require.NoError(t, err)
+	require.True(t, fit)
+	require.EqualValues(t, 3000, used.Flattened.Memory.MemoryMB)
+
+	// Should not fit a fourth allocation beyond the oversubscription ratio
+	fit, dim, _, err = AllocsFit(n, []*Allocation{newAlloc(false), newAlloc(false), newAlloc(true), newAlloc(true)}, nil, false)
+	require.NoError(t, err)
+	require.False(t, fit)
+	require.Equal(t, "memory", dim)
+
+	// Allocations that aren't oversubscribed must fit within capacity
+	n.NodeResources.MemoryOversubscriptionRatio = 3
+	fit, dim, _, err = AllocsFit(n, []*Allocation{newAlloc(true), newAlloc(false), newAlloc(false), newAlloc(false)}, nil, false)
+	require.NoError(t, err)
+	require.False(t, fit)
+	require.Equal(t, "memory", dim)
+
+	// Should not fit beyond capacity if the node doesn't allow it
+	n.NodeResources.MemoryOversubscriptionRatio = 0
+	fit, dim, _, err = AllocsFit(n, []*Allocation{newAlloc(false), newAlloc(false), newAlloc(true)}, nil, false)
+	require.NoError(t, err)
+	require.False(t, fit)
+	require.Equal(t, "memory", dim)
+}
+
+func TestAllocsFit_HugePages(t *testing.T) {
+	ci.Parallel(t)
+
+	n := &Node{
+		NodeResources: &NodeResources{
+			Cpu: NodeCpuResources{
+				CpuShares: 2000,
+			},
+			Memory: NodeMemoryResources{
+				MemoryMB:     4096,
+				HugePages2MB: 1024,
+			},
+		},
+	}
+
+	a1 := &Allocation{
+		AllocatedResources: &AllocatedResources{
+			Tasks: map[string]*AllocatedTaskResources{
+				"db": {
+					Cpu: AllocatedCpuResources{
+						CpuShares: 100,
+					},
+					Memory: AllocatedMemoryResources{
+						MemoryMB:     100,
+						HugePages2MB: 512,
+					},
+				},
+			},
+		},
+	}
+
+	// Should fit two allocations
+	fit, _, used, err := AllocsFit(n, []*Allocation{a1, a1}, nil, false)
+	require.NoError(t, err)
+	require.True(t, fit)
+	require.EqualValues(t, 1024, used.Flattened.Memory.HugePages2MB)
+
+	// Should not fit a third allocation
+	fit, dim, used, err := AllocsFit(n, []*Allocation{a1, a1, a1}, nil, false)
+	require.NoError(t, err)
+	require.False(t, fit)
+	require.Equal(t, "hugepages", dim)
+	require.EqualValues(t, 1536, used.Flattened.Memory.HugePages2MB)
+}
+
 // COMPAT(0.11): Remove in 0.11
 func TestScoreFitBinPack_Old(t *testing.T) {
 	ci.Parallel(t)
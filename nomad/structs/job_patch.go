@@ -0,0 +1,359 @@
+package structs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	// JobPatchRPCMethod is the RPC method for updating a job by applying a
+	// patch to its current version.
+	//
+	// Args: JobPatchRequest
+	// Reply: JobRegisterResponse
+	JobPatchRPCMethod = "Job.Patch"
+)
+
+const (
+	// JobPatchOpAdd adds a value to an object or inserts it into an array.
+	JobPatchOpAdd = "add"
+
+	// JobPatchOpRemove removes the value at the path.
+	JobPatchOpRemove = "remove"
+
+	// JobPatchOpReplace replaces the existing value at the path.
+	JobPatchOpReplace = "replace"
+
+	// JobPatchOpTest fails the patch if the value at the path is not equal to
+	// the value of the operation.
+	JobPatchOpTest = "test"
+)
+
+// JobPatchOp is a single operation of a job patch. Operations follow the
+// semantics of JSON Patch (RFC 6902) and address fields of the job with a
+// JSON pointer, such as "/TaskGroups/0/Count".
+type JobPatchOp struct {
+	Op   string
+	Path string
+
+	// Value is the JSON encoded value of add, replace and test operations.
+	Value []byte
+}
+
+// JobPatchRequest is used to update a job by applying a JSON merge patch
+// (RFC 7396) and a list of operations to its current version.
+type JobPatchRequest struct {
+	JobID string
+
+	// MergePatch is a JSON merge patch document applied to the job before
+	// the operations.
+	MergePatch []byte
+
+	// Ops are applied in order after the merge patch.
+	Ops []*JobPatchOp
+
+	// EnforcePriorVersion if set will enforce that the job is at the given
+	// version before patching.
+	EnforcePriorVersion *uint64
+
+	// ConsulToken and VaultToken are only used to transfer the tokens used to
+	// register the patched job and are not stored.
+	ConsulToken string
+	VaultToken  string
+
+	WriteRequest
+}
+
+// Validate checks the request is well formed before the job is looked up.
+func (r *JobPatchRequest) Validate() error {
+	if r.JobID == "" {
+		return errors.New("missing job ID for patch")
+	}
+	if len(r.MergePatch) == 0 && len(r.Ops) == 0 {
+		return errors.New("patch must contain a merge patch or operations")
+	}
+	for i, op := range r.Ops {
+		if err := op.Validate(); err != nil {
+			return fmt.Errorf("patch operation %d is invalid: %v", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks the operation is well formed.
+func (o *JobPatchOp) Validate() error {
+	switch o.Op {
+	case JobPatchOpAdd, JobPatchOpReplace, JobPatchOpTest:
+		if len(o.Value) == 0 {
+			return fmt.Errorf("%q operation requires a value", o.Op)
+		}
+	case JobPatchOpRemove:
+	default:
+		return fmt.Errorf("unknown operation %q", o.Op)
+	}
+	if !strings.HasPrefix(o.Path, "/") {
+		return fmt.Errorf("path %q must start with /", o.Path)
+	}
+	return nil
+}
+
+// Patch returns a copy of the job with the merge patch and operations applied.
+// The ID and namespace of the job can not be changed by a patch.
+func (j *Job) Patch(mergePatch []byte, ops []*JobPatchOp) (*Job, error) {
+	raw, err := json.Marshal(j)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode job: %v", err)
+	}
+	doc, err := decodePatchJSON(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode job: %v", err)
+	}
+
+	if len(mergePatch) > 0 {
+		patch, err := decodePatchJSON(mergePatch)
+		if err != nil {
+			return nil, fmt.Errorf("invalid merge patch: %v", err)
+		}
+		if _, ok := patch.(map[string]interface{}); !ok {
+			return nil, errors.New("invalid merge patch: must be a JSON object")
+		}
+		doc = applyMergePatch(doc, patch)
+	}
+
+	for i, op := range ops {
+		if doc, err = op.apply(doc); err != nil {
+			return nil, fmt.Errorf("patch operation %d failed: %v", i+1, err)
+		}
+	}
+
+	raw, err = json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode patched job: %v", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+
+	patched := new(Job)
+	if err := dec.Decode(patched); err != nil {
+		return nil, fmt.Errorf("failed to decode patched job: %v", err)
+	}
+
+	if patched.ID != j.ID {
+		return nil, errors.New("job ID can not be patched")
+	}
+	if patched.Namespace != j.Namespace {
+		return nil, errors.New("job namespace can not be patched")
+	}
+	return patched, nil
+}
+
+// decodePatchJSON decodes a JSON document, keeping numbers exact so that
+// large integers such as indexes survive the round trip.
+func decodePatchJSON(raw []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	var out interface{}
+	if err := dec.Decode(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// applyMergePatch applies a JSON merge patch to the target document.
+func applyMergePatch(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = make(map[string]interface{})
+	}
+	for k, v := range patchObj {
+		if v == nil {
+			delete(targetObj, k)
+			continue
+		}
+		targetObj[k] = applyMergePatch(targetObj[k], v)
+	}
+	return targetObj
+}
+
+// apply applies the operation to the document and returns the result.
+func (o *JobPatchOp) apply(doc interface{}) (interface{}, error) {
+	var value interface{}
+	if len(o.Value) > 0 {
+		v, err := decodePatchJSON(o.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value: %v", err)
+		}
+		value = v
+	}
+
+	tokens, err := parseJSONPointer(o.Path)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, errors.New("path must reference a field of the job")
+	}
+
+	parent, err := resolveJSONPointer(doc, tokens[:len(tokens)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := tokens[len(tokens)-1]
+
+	switch container := parent.(type) {
+	case map[string]interface{}:
+		cur, exists := container[last]
+		switch o.Op {
+		case JobPatchOpAdd:
+			container[last] = value
+		case JobPatchOpReplace:
+			if !exists {
+				return nil, fmt.Errorf("path %q does not exist", o.Path)
+			}
+			container[last] = value
+		case JobPatchOpRemove:
+			if !exists {
+				return nil, fmt.Errorf("path %q does not exist", o.Path)
+			}
+			delete(container, last)
+		case JobPatchOpTest:
+			if !exists || !reflect.DeepEqual(cur, value) {
+				return nil, fmt.Errorf("test of path %q failed", o.Path)
+			}
+		}
+		return doc, nil
+
+	case []interface{}:
+		idx, err := patchArrayIndex(last, len(container), o.Op == JobPatchOpAdd)
+		if err != nil {
+			return nil, fmt.Errorf("path %q: %v", o.Path, err)
+		}
+
+		var updated []interface{}
+		switch o.Op {
+		case JobPatchOpAdd:
+			updated = append(updated, container[:idx]...)
+			updated = append(updated, value)
+			updated = append(updated, container[idx:]...)
+		case JobPatchOpReplace:
+			container[idx] = value
+			return doc, nil
+		case JobPatchOpRemove:
+			updated = append(updated, container[:idx]...)
+			updated = append(updated, container[idx+1:]...)
+		case JobPatchOpTest:
+			if !reflect.DeepEqual(container[idx], value) {
+				return nil, fmt.Errorf("test of path %q failed", o.Path)
+			}
+			return doc, nil
+		}
+
+		// Arrays can't be resized in place, so replace the array in its
+		// own parent.
+		return setJSONPointer(doc, tokens[:len(tokens)-1], updated)
+
+	default:
+		return nil, fmt.Errorf("path %q does not exist", o.Path)
+	}
+}
+
+// parseJSONPointer splits a JSON pointer (RFC 6901) into its unescaped
+// reference tokens.
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("path %q must start with /", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		token = strings.ReplaceAll(token, "~1", "/")
+		tokens[i] = strings.ReplaceAll(token, "~0", "~")
+	}
+	return tokens, nil
+}
+
+// resolveJSONPointer returns the value referenced by the tokens.
+func resolveJSONPointer(doc interface{}, tokens []string) (interface{}, error) {
+	cur := doc
+	for i, token := range tokens {
+		switch v := cur.(type) {
+		case map[string]interface{}:
+			next, ok := v[token]
+			if !ok {
+				return nil, fmt.Errorf("path %q does not exist", "/"+strings.Join(tokens[:i+1], "/"))
+			}
+			cur = next
+		case []interface{}:
+			idx, err := patchArrayIndex(token, len(v), false)
+			if err != nil {
+				return nil, fmt.Errorf("path %q: %v", "/"+strings.Join(tokens[:i+1], "/"), err)
+			}
+			cur = v[idx]
+		default:
+			return nil, fmt.Errorf("path %q does not exist", "/"+strings.Join(tokens[:i+1], "/"))
+		}
+	}
+	return cur, nil
+}
+
+// setJSONPointer replaces the value referenced by the tokens and returns the
+// resulting document.
+func setJSONPointer(doc interface{}, tokens []string, value interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	parent, err := resolveJSONPointer(doc, tokens[:len(tokens)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := tokens[len(tokens)-1]
+
+	switch v := parent.(type) {
+	case map[string]interface{}:
+		v[last] = value
+	case []interface{}:
+		idx, err := patchArrayIndex(last, len(v), false)
+		if err != nil {
+			return nil, err
+		}
+		v[idx] = value
+	}
+	return doc, nil
+}
+
+// patchArrayIndex parses an array index token. The "-" token references the
+// end of the array and is only valid when adding.
+func patchArrayIndex(token string, length int, adding bool) (int, error) {
+	if token == "-" && adding {
+		return length, nil
+	}
+
+	idx, err := strconv.Atoi(token)
+	if err != nil || idx < 0 {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+
+	max := length - 1
+	if adding {
+		max = length
+	}
+	if idx > max {
+		return 0, fmt.Errorf("array index %d out of bounds", idx)
+	}
+	return idx, nil
+}
@@ -0,0 +1,134 @@
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+	"github.com/stretchr/testify/require"
+)
+
+func testPatchJob() *Job {
+	return &Job{
+		ID:          "example",
+		Namespace:   DefaultNamespace,
+		Datacenters: []string{"dc1"},
+		Meta:        map[string]string{"owner": "ops", "team": "web"},
+		TaskGroups: []*TaskGroup{{
+			Name:  "web",
+			Count: 1,
+			Tasks: []*Task{{
+				Name:   "server",
+				Driver: "docker",
+				Config: map[string]interface{}{"image": "nginx:1.21"},
+			}},
+		}},
+		JobModifyIndex: 1 << 60,
+	}
+}
+
+func TestJob_Patch_MergePatch(t *testing.T) {
+	ci.Parallel(t)
+
+	job := testPatchJob()
+	patched, err := job.Patch([]byte(`{"Meta": {"team": null, "tier": "1"}, "Priority": 70}`), nil)
+	must.NoError(t, err)
+
+	must.Eq(t, map[string]string{"owner": "ops", "tier": "1"}, patched.Meta)
+	must.Eq(t, 70, patched.Priority)
+	must.Eq(t, job.JobModifyIndex, patched.JobModifyIndex)
+
+	// The original job is not modified
+	must.Eq(t, map[string]string{"owner": "ops", "team": "web"}, job.Meta)
+}
+
+func TestJob_Patch_Ops(t *testing.T) {
+	ci.Parallel(t)
+
+	job := testPatchJob()
+	patched, err := job.Patch(nil, []*JobPatchOp{
+		{Op: JobPatchOpTest, Path: "/TaskGroups/0/Name", Value: []byte(`"web"`)},
+		{Op: JobPatchOpReplace, Path: "/TaskGroups/0/Count", Value: []byte(`3`)},
+		{Op: JobPatchOpReplace, Path: "/TaskGroups/0/Tasks/0/Config/image", Value: []byte(`"nginx:1.23"`)},
+		{Op: JobPatchOpRemove, Path: "/Meta/team"},
+		{Op: JobPatchOpAdd, Path: "/Datacenters/-", Value: []byte(`"dc2"`)},
+	})
+	must.NoError(t, err)
+
+	must.Eq(t, 3, patched.TaskGroups[0].Count)
+	must.Eq(t, "nginx:1.23", patched.TaskGroups[0].Tasks[0].Config["image"].(string))
+	must.Eq(t, map[string]string{"owner": "ops"}, patched.Meta)
+	must.Eq(t, []string{"dc1", "dc2"}, patched.Datacenters)
+}
+
+func TestJob_Patch_Invalid(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name  string
+		merge string
+		ops   []*JobPatchOp
+		err   string
+	}{
+		{
+			name:  "merge patch not an object",
+			merge: `[1]`,
+			err:   "must be a JSON object",
+		},
+		{
+			name:  "unknown field",
+			merge: `{"Bogus": 1}`,
+			err:   `unknown field "Bogus"`,
+		},
+		{
+			name:  "change id",
+			merge: `{"ID": "other"}`,
+			err:   "job ID can not be patched",
+		},
+		{
+			name: "failed test",
+			ops:  []*JobPatchOp{{Op: JobPatchOpTest, Path: "/TaskGroups/0/Count", Value: []byte(`2`)}},
+			err:  "test of path",
+		},
+		{
+			name: "missing path",
+			ops:  []*JobPatchOp{{Op: JobPatchOpReplace, Path: "/TaskGroups/3/Count", Value: []byte(`2`)}},
+			err:  "out of bounds",
+		},
+		{
+			name: "wrong type",
+			ops:  []*JobPatchOp{{Op: JobPatchOpReplace, Path: "/TaskGroups/0/Count", Value: []byte(`"two"`)}},
+			err:  "failed to decode patched job",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var merge []byte
+			if tc.merge != "" {
+				merge = []byte(tc.merge)
+			}
+			_, err := testPatchJob().Patch(merge, tc.ops)
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestJobPatchRequest_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	req := &JobPatchRequest{JobID: "example"}
+	require.ErrorContains(t, req.Validate(), "merge patch or operations")
+
+	req.Ops = []*JobPatchOp{{Op: "move", Path: "/Priority"}}
+	require.ErrorContains(t, req.Validate(), `unknown operation "move"`)
+
+	req.Ops = []*JobPatchOp{{Op: JobPatchOpReplace, Path: "/Priority"}}
+	require.ErrorContains(t, req.Validate(), "requires a value")
+
+	req.Ops = []*JobPatchOp{{Op: JobPatchOpReplace, Path: "Priority", Value: []byte(`1`)}}
+	require.ErrorContains(t, req.Validate(), "must start with /")
+
+	req.Ops = []*JobPatchOp{{Op: JobPatchOpReplace, Path: "/Priority", Value: []byte(`1`)}}
+	must.NoError(t, req.Validate())
+}
@@ -0,0 +1,211 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	// JobRestartRPCMethod is the RPC method for starting a rolling restart of
+	// the allocations of a job.
+	//
+	// Args: JobRestartRequest
+	// Reply: JobRestartResponse
+	JobRestartRPCMethod = "Job.Restart"
+
+	// JobGetRestartRPCMethod is the RPC method for reading the latest restart
+	// of a job.
+	//
+	// Args: JobSpecificRequest
+	// Reply: SingleJobRestartResponse
+	JobGetRestartRPCMethod = "Job.GetRestart"
+
+	// JobUpdateRestartRPCMethod is the RPC method for pausing, resuming or
+	// cancelling the restart of a job.
+	//
+	// Args: JobRestartUpdateRequest
+	// Reply: JobRestartResponse
+	JobUpdateRestartRPCMethod = "Job.UpdateRestart"
+)
+
+const (
+	// JobRestartStatusRunning is the status of a restart whose batches are
+	// being restarted by the leader.
+	JobRestartStatusRunning = "running"
+
+	// JobRestartStatusPaused is the status of a restart which was paused by
+	// an operator or because a batch failed, until it is resumed.
+	JobRestartStatusPaused = "paused"
+
+	// JobRestartStatusComplete is the status of a restart which restarted
+	// all of its allocations.
+	JobRestartStatusComplete = "complete"
+
+	// JobRestartStatusFailed is the status of a restart which stopped
+	// because a batch failed.
+	JobRestartStatusFailed = "failed"
+
+	// JobRestartStatusCancelled is the status of a restart which was
+	// cancelled by an operator.
+	JobRestartStatusCancelled = "cancelled"
+)
+
+const (
+	// JobRestartOnErrorPause pauses the restart when a batch fails, so that
+	// an operator can resume it.
+	JobRestartOnErrorPause = "pause"
+
+	// JobRestartOnErrorFail fails the restart when a batch fails.
+	JobRestartOnErrorFail = "fail"
+)
+
+// JobRestart is a rolling restart of the running allocations of a job. The
+// leader restarts the allocations in batches, waiting between batches, and
+// records its progress in the state store so that it carries on regardless of
+// the client which started it and across leader elections.
+type JobRestart struct {
+	// ID uniquely identifies the restart
+	ID string
+
+	Namespace string
+	JobID     string
+
+	// BatchSize is the number of allocations restarted at once
+	BatchSize int
+
+	// BatchWait is the time waited after a batch is restarted before the
+	// next one.
+	BatchWait time.Duration
+
+	// OnError is what happens when a batch fails, either pause or fail
+	OnError string
+
+	// AllocIDs are the allocations to restart, in the order they are
+	// restarted. They are the allocations of the job running when the
+	// restart started.
+	AllocIDs []string
+
+	// Restarted is the number of allocations restarted so far, including
+	// those skipped because they were stopped before their batch.
+	Restarted int
+
+	// NextBatch is the earliest time the next batch may be restarted
+	NextBatch time.Time
+
+	Status            string
+	StatusDescription string
+
+	CreateIndex uint64
+	ModifyIndex uint64
+	CreateTime  int64
+	ModifyTime  int64
+}
+
+// Copy returns a deep copy of the restart.
+func (r *JobRestart) Copy() *JobRestart {
+	if r == nil {
+		return nil
+	}
+	nr := new(JobRestart)
+	*nr = *r
+	if r.AllocIDs != nil {
+		nr.AllocIDs = make([]string, len(r.AllocIDs))
+		copy(nr.AllocIDs, r.AllocIDs)
+	}
+	return nr
+}
+
+// Active returns whether the restart is running or paused.
+func (r *JobRestart) Active() bool {
+	return r.Status == JobRestartStatusRunning || r.Status == JobRestartStatusPaused
+}
+
+// NextBatchAllocIDs returns the allocations of the next batch to restart.
+func (r *JobRestart) NextBatchAllocIDs() []string {
+	end := r.Restarted + r.BatchSize
+	if end > len(r.AllocIDs) {
+		end = len(r.AllocIDs)
+	}
+	return r.AllocIDs[r.Restarted:end]
+}
+
+// JobRestartRequest is used to start a rolling restart of the running
+// allocations of a job.
+type JobRestartRequest struct {
+	JobID     string
+	BatchSize int
+	BatchWait time.Duration
+	OnError   string
+
+	WriteRequest
+}
+
+// Validate checks the request is well formed before the job is looked up.
+func (r *JobRestartRequest) Validate() error {
+	var mErr multierror.Error
+	if r.JobID == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing job ID for restart"))
+	}
+	if r.BatchSize < 1 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("batch size must be at least 1, got %d", r.BatchSize))
+	}
+	if r.BatchWait < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("batch wait must not be negative, got %v", r.BatchWait))
+	}
+	switch r.OnError {
+	case JobRestartOnErrorPause, JobRestartOnErrorFail:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("on error must be %q or %q, got %q",
+			JobRestartOnErrorPause, JobRestartOnErrorFail, r.OnError))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// JobRestartUpdateRequest is used to pause, resume or cancel the active
+// restart of a job.
+type JobRestartUpdateRequest struct {
+	JobID string
+
+	// Status is the new status of the restart, either running to resume a
+	// paused restart, paused, or cancelled.
+	Status string
+
+	WriteRequest
+}
+
+// Validate checks the request is well formed before the restart is looked
+// up.
+func (r *JobRestartUpdateRequest) Validate() error {
+	if r.JobID == "" {
+		return errors.New("missing job ID for restart")
+	}
+	switch r.Status {
+	case JobRestartStatusRunning, JobRestartStatusPaused, JobRestartStatusCancelled:
+	default:
+		return fmt.Errorf("restart status must be %q, %q or %q, got %q",
+			JobRestartStatusRunning, JobRestartStatusPaused, JobRestartStatusCancelled, r.Status)
+	}
+	return nil
+}
+
+// JobRestartUpsertRequest is the raft request used to store a restart.
+type JobRestartUpsertRequest struct {
+	Restart *JobRestart
+	WriteRequest
+}
+
+// JobRestartResponse is the response to starting or updating a restart.
+type JobRestartResponse struct {
+	Restart *JobRestart
+	WriteMeta
+}
+
+// SingleJobRestartResponse is the response to reading the latest restart of
+// a job.
+type SingleJobRestartResponse struct {
+	Restart *JobRestart
+	QueryMeta
+}
@@ -0,0 +1,45 @@
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobRestartRequest_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	req := &JobRestartRequest{
+		JobID:     "example",
+		BatchSize: 2,
+		BatchWait: 30 * time.Second,
+		OnError:   JobRestartOnErrorPause,
+	}
+	require.NoError(t, req.Validate())
+
+	req = &JobRestartRequest{BatchSize: 0, BatchWait: -time.Second, OnError: "ignore"}
+	err := req.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "missing job ID")
+	require.Contains(t, err.Error(), "batch size must be at least 1")
+	require.Contains(t, err.Error(), "batch wait must not be negative")
+	require.Contains(t, err.Error(), `on error must be "pause" or "fail"`)
+}
+
+func TestJobRestart_NextBatchAllocIDs(t *testing.T) {
+	ci.Parallel(t)
+
+	restart := &JobRestart{
+		BatchSize: 2,
+		AllocIDs:  []string{"a", "b", "c"},
+	}
+	require.Equal(t, []string{"a", "b"}, restart.NextBatchAllocIDs())
+
+	restart.Restarted = 2
+	require.Equal(t, []string{"c"}, restart.NextBatchAllocIDs())
+
+	restart.Restarted = 3
+	require.Empty(t, restart.NextBatchAllocIDs())
+}
@@ -0,0 +1,196 @@
+package structs
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-msgpack/codec"
+)
+
+const (
+	// JobSigningKeyUpsertRPCMethod is the RPC method for trusting a job
+	// signing key.
+	//
+	// Args: KeyringUpsertJobSigningKeyRequest
+	// Reply: KeyringUpsertJobSigningKeyResponse
+	JobSigningKeyUpsertRPCMethod = "Keyring.UpsertJobSigningKey"
+
+	// JobSigningKeyListRPCMethod is the RPC method for listing the trusted
+	// job signing keys.
+	//
+	// Args: KeyringListJobSigningKeysRequest
+	// Reply: KeyringListJobSigningKeysResponse
+	JobSigningKeyListRPCMethod = "Keyring.ListJobSigningKeys"
+
+	// JobSigningKeyDeleteRPCMethod is the RPC method for no longer trusting a
+	// job signing key.
+	//
+	// Args: KeyringDeleteJobSigningKeyRequest
+	// Reply: KeyringDeleteJobSigningKeyResponse
+	JobSigningKeyDeleteRPCMethod = "Keyring.DeleteJobSigningKey"
+)
+
+var (
+	// ErrJobSignatureRequired is returned when registering a job without a
+	// signature while the servers require signed job submissions.
+	ErrJobSignatureRequired = errors.New("job submissions must be signed")
+
+	// ErrJobSignatureInvalid is returned when the signature of a job
+	// submission doesn't match the job or a trusted key.
+	ErrJobSignatureInvalid = errors.New("invalid job signature")
+)
+
+// JobSigningKey is an ed25519 public key trusted by the servers to sign job
+// submissions. The matching private key is held by the operators or the
+// deployment pipelines submitting jobs, and is never sent to Nomad.
+type JobSigningKey struct {
+	// KeyID is derived from the public key, see JobSigningKeyID.
+	KeyID string
+
+	// Name is an optional operator provided description of the key, such as
+	// the pipeline using it.
+	Name string
+
+	PublicKey []byte
+
+	CreateTime  int64
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// JobSigningKeyID returns the ID of the job signing key with the given public
+// key, which is the hex encoded prefix of the SHA-256 of the key.
+func JobSigningKeyID(publicKey ed25519.PublicKey) string {
+	sum := sha256.Sum256(publicKey)
+	return hex.EncodeToString(sum[:8])
+}
+
+// Validate checks that the key is a valid ed25519 public key with a matching
+// ID.
+func (k *JobSigningKey) Validate() error {
+	if len(k.PublicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("job signing key must be an ed25519 public key of %d bytes", ed25519.PublicKeySize)
+	}
+	if id := JobSigningKeyID(k.PublicKey); k.KeyID != id {
+		return fmt.Errorf("job signing key ID %q does not match public key ID %q", k.KeyID, id)
+	}
+	return nil
+}
+
+func (k *JobSigningKey) Copy() *JobSigningKey {
+	if k == nil {
+		return nil
+	}
+	nk := *k
+	nk.PublicKey = append([]byte(nil), k.PublicKey...)
+	return &nk
+}
+
+// JobSignature is the signature of a job submission.
+type JobSignature struct {
+	// KeyID is the ID of the trusted key whose private key signed the job.
+	KeyID string
+
+	// Signature is the ed25519 signature of the JobSigningPayload of the job.
+	Signature []byte
+}
+
+// JobSigningPayload returns the canonical encoding of the job signed by job
+// signatures. It covers the specification of the job, excluding the fields
+// which are set by the servers, the region and namespace which are set from
+// the request, and the Vault and Consul tokens which are credentials of the
+// submitter. The job goes through a msgpack round trip first, so that the
+// payload is the same before and after the job is sent to the servers.
+func JobSigningPayload(job *Job) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := codec.NewEncoder(&buf, MsgpackHandle).Encode(job); err != nil {
+		return nil, err
+	}
+	var c Job
+	if err := codec.NewDecoder(&buf, MsgpackHandle).Decode(&c); err != nil {
+		return nil, err
+	}
+
+	c.Region = ""
+	c.Namespace = ""
+	c.VaultToken = ""
+	c.ConsulToken = ""
+	c.NomadTokenID = ""
+	c.SigningKeyID = ""
+	c.Status = ""
+	c.StatusDescription = ""
+	c.Stable = false
+	c.Version = 0
+	c.SubmitTime = 0
+	c.CreateIndex = 0
+	c.ModifyIndex = 0
+	c.JobModifyIndex = 0
+
+	return json.Marshal(&c)
+}
+
+// SignJob returns the signature of the job with the given private key.
+func SignJob(job *Job, privateKey ed25519.PrivateKey) (*JobSignature, error) {
+	payload, err := JobSigningPayload(job)
+	if err != nil {
+		return nil, err
+	}
+	return &JobSignature{
+		KeyID:     JobSigningKeyID(privateKey.Public().(ed25519.PublicKey)),
+		Signature: ed25519.Sign(privateKey, payload),
+	}, nil
+}
+
+// VerifyJobSignature checks that the signature of the job was made by the
+// private key of the given public key.
+func VerifyJobSignature(job *Job, sig *JobSignature, key *JobSigningKey) error {
+	if sig == nil || key == nil || sig.KeyID != key.KeyID {
+		return ErrJobSignatureInvalid
+	}
+	payload, err := JobSigningPayload(job)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(key.PublicKey, payload, sig.Signature) {
+		return ErrJobSignatureInvalid
+	}
+	return nil
+}
+
+// KeyringUpsertJobSigningKeyRequest is used to trust a job signing key.
+type KeyringUpsertJobSigningKeyRequest struct {
+	Key *JobSigningKey
+	WriteRequest
+}
+
+type KeyringUpsertJobSigningKeyResponse struct {
+	Key *JobSigningKey
+	WriteMeta
+}
+
+// KeyringListJobSigningKeysRequest is used to list the trusted job signing
+// keys.
+type KeyringListJobSigningKeysRequest struct {
+	QueryOptions
+}
+
+type KeyringListJobSigningKeysResponse struct {
+	Keys []*JobSigningKey
+	QueryMeta
+}
+
+// KeyringDeleteJobSigningKeyRequest is used to stop trusting a job signing
+// key.
+type KeyringDeleteJobSigningKeyRequest struct {
+	KeyID string
+	WriteRequest
+}
+
+type KeyringDeleteJobSigningKeyResponse struct {
+	WriteMeta
+}
@@ -0,0 +1,64 @@
+package structs
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/hashicorp/go-msgpack/codec"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobSigningKey_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	key := &JobSigningKey{KeyID: JobSigningKeyID(publicKey), PublicKey: publicKey}
+	require.NoError(t, key.Validate())
+
+	key.KeyID = "bad"
+	require.ErrorContains(t, key.Validate(), "does not match public key ID")
+
+	key.PublicKey = publicKey[:10]
+	require.ErrorContains(t, key.Validate(), "must be an ed25519 public key")
+}
+
+func TestJobSignature_SignVerify(t *testing.T) {
+	ci.Parallel(t)
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key := &JobSigningKey{KeyID: JobSigningKeyID(publicKey), PublicKey: publicKey}
+
+	job := testJob()
+	sig, err := SignJob(job, privateKey)
+	require.NoError(t, err)
+	require.Equal(t, key.KeyID, sig.KeyID)
+
+	// The signature survives the job being sent to the servers, and covers
+	// neither the request fields nor the fields set by the servers
+	var buf bytes.Buffer
+	require.NoError(t, codec.NewEncoder(&buf, MsgpackHandle).Encode(job))
+	var received Job
+	require.NoError(t, codec.NewDecoder(&buf, MsgpackHandle).Decode(&received))
+	received.Namespace = "other"
+	received.VaultToken = "vault-token"
+	received.Version = 3
+	received.JobModifyIndex = 42
+	require.NoError(t, VerifyJobSignature(&received, sig, key))
+
+	// Changing the specification invalidates the signature
+	received.TaskGroups[0].Count++
+	require.ErrorIs(t, VerifyJobSignature(&received, sig, key), ErrJobSignatureInvalid)
+
+	// Signatures must be made by the given key
+	otherPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	other := &JobSigningKey{KeyID: JobSigningKeyID(otherPublicKey), PublicKey: otherPublicKey}
+	require.ErrorIs(t, VerifyJobSignature(job, sig, other), ErrJobSignatureInvalid)
+	require.ErrorIs(t, VerifyJobSignature(job, nil, key), ErrJobSignatureInvalid)
+}
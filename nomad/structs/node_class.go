@@ -42,7 +42,7 @@ func (n *Node) ComputeClass() error {
 // included in the computed node class.
 func (n Node) HashInclude(field string, v interface{}) (bool, error) {
 	switch field {
-	case "Datacenter", "Attributes", "Meta", "NodeClass", "NodeResources":
+	case "Datacenter", "Attributes", "Meta", "NodeClass", "DisconnectDomain", "NodeResources":
 		return true, nil
 	default:
 		return false, nil
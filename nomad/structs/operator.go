@@ -171,6 +171,12 @@ type SchedulerConfiguration struct {
 	// during leadership transitions.
 	PauseEvalBroker bool `hcl:"pause_eval_broker"`
 
+	// ReconnectStabilization is how long a node must stay connected after
+	// reconnecting before the scheduler reinstates its unknown allocations
+	// and stops their replacements. Zero reinstates them immediately.
+	ReconnectStabilization    time.Duration `hcl:"-"`
+	ReconnectStabilizationHCL string        `hcl:"reconnect_stabilization" json:"-"`
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -210,6 +216,10 @@ func (s *SchedulerConfiguration) Validate() error {
 		return fmt.Errorf("invalid scheduler algorithm: %v", s.SchedulerAlgorithm)
 	}
 
+	if s.ReconnectStabilization < 0 {
+		return fmt.Errorf("reconnect stabilization must not be negative: %v", s.ReconnectStabilization)
+	}
+
 	return nil
 }
 
@@ -221,6 +231,40 @@ type SchedulerConfigurationResponse struct {
 	QueryMeta
 }
 
+// PlanQueueStats describes the load of the leader's plan applier.
+type PlanQueueStats struct {
+	// Depth is the number of plans waiting to be applied.
+	Depth int
+
+	// ApplyLatency is the moving average of the time raft takes to apply
+	// a plan.
+	ApplyLatency time.Duration
+
+	// Saturated is true when new plans are over the backpressure limits,
+	// and SaturatedReason describes which limit was reached.
+	Saturated       bool
+	SaturatedReason string
+
+	// BackpressureEnabled is true when plans are rejected while the applier
+	// is saturated.
+	BackpressureEnabled bool
+
+	// MaxQueueDepth and MaxApplyLatency are the backpressure limits.
+	MaxQueueDepth   int
+	MaxApplyLatency time.Duration
+
+	// BackpressureRejections is the number of plans rejected since the
+	// server started.
+	BackpressureRejections uint64
+}
+
+// PlanQueueStatsResponse is the response object that wraps PlanQueueStats.
+type PlanQueueStatsResponse struct {
+	Stats *PlanQueueStats
+
+	QueryMeta
+}
+
 // SchedulerSetConfigurationResponse is the response object used
 // when updating scheduler configuration
 type SchedulerSetConfigurationResponse struct {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"path"
 	"reflect"
 	"strings"
 	"time"
@@ -39,12 +40,86 @@ const (
 	// Reply: SecureVariablesByNameResponse
 	SecureVariablesReadRPCMethod = "SecureVariables.Read"
 
+	// SecureVariablesReadPrefixRPCMethod is the RPC method for fetching the
+	// decrypted secure variables under a path prefix.
+	//
+	// Args: SecureVariablesReadPrefixRequest
+	// Reply: SecureVariablesReadPrefixResponse
+	SecureVariablesReadPrefixRPCMethod = "SecureVariables.ReadPrefix"
+
+	// SecureVariablesSearchRPCMethod is the RPC method for searching secure
+	// variables by path and metadata.
+	//
+	// Args: SecureVariablesSearchRequest
+	// Reply: SecureVariablesSearchResponse
+	SecureVariablesSearchRPCMethod = "SecureVariables.Search"
+
+	// SecureVariablesPurgeRPCMethod is the RPC method for deleting all the
+	// secure variables under a path prefix.
+	//
+	// Args: SecureVariablesPurgeRequest
+	// Reply: SecureVariablesPurgeResponse
+	SecureVariablesPurgeRPCMethod = "SecureVariables.Purge"
+
+	// SecureVariablesPurgeBatchSize is the maximum number of secure
+	// variables deleted by a single raft log entry during a purge.
+	SecureVariablesPurgeBatchSize = 1000
+
+	// SecureVariablesTxnRPCMethod is the RPC method for applying many
+	// secure variable operations atomically.
+	//
+	// Args: SecureVariablesTxnRequest
+	// Reply: SecureVariablesTxnResponse
+	SecureVariablesTxnRPCMethod = "SecureVariables.Txn"
+
+	// SecureVariablesTxnMaxOps is the maximum number of operations in a
+	// secure variables transaction.
+	SecureVariablesTxnMaxOps = 64
+
+	// SecureVariablesExchangeIdentityRPCMethod is the RPC method for
+	// exchanging a workload identity for a short-lived token that can read
+	// the workload's secure variables.
+	//
+	// Args: SecureVariablesExchangeIdentityRequest
+	// Reply: SecureVariablesExchangeIdentityResponse
+	SecureVariablesExchangeIdentityRPCMethod = "SecureVariables.ExchangeIdentity"
+
+	// SecureVariablesReplicateRPCMethod is the RPC method used by the
+	// leaders of other regions to fetch the encrypted secure variables that
+	// are replicated to them.
+	//
+	// Args: SecureVariablesReplicateRequest
+	// Reply: SecureVariablesReplicateResponse
+	SecureVariablesReplicateRPCMethod = "SecureVariables.Replicate"
+
+	// SecureVariablesReplicationStatusRPCMethod is the RPC method for
+	// reading the status of the replication of secure variables from other
+	// regions to this region.
+	//
+	// Args: SecureVariablesReplicationStatusRequest
+	// Reply: SecureVariablesReplicationStatusResponse
+	SecureVariablesReplicationStatusRPCMethod = "SecureVariables.ReplicationStatus"
+
+	// SecureVariablesIdentityTTL is how long a token issued in exchange for
+	// a workload identity remains valid.
+	SecureVariablesIdentityTTL = time.Hour
+
 	// maxVariableSize is the maximum size of the unencrypted contents of
 	// a variable. This size is deliberately set low and is not
 	// configurable, to discourage DoS'ing the cluster
 	maxVariableSize = 16384
+
+	// maxVariableMetaSize is the maximum size of the metadata of a
+	// variable. Metadata is stored unencrypted and returned in list
+	// results, so it's kept smaller than the variable contents.
+	maxVariableMetaSize = 4096
 )
 
+// ErrSecureVariableDeleteProtected is returned when deleting a secure
+// variable with delete protection, or removing its protection, without
+// forcing it.
+var ErrSecureVariableDeleteProtected = errors.New("secure variable is delete protected")
+
 // SecureVariableMetadata is the metadata envelope for a Secure Variable, it
 // is the list object and is shared data between an SecureVariableEncrypted and
 // a SecureVariableDecrypted object.
@@ -55,6 +130,27 @@ type SecureVariableMetadata struct {
 	CreateTime  int64
 	ModifyIndex uint64
 	ModifyTime  int64
+
+	// ItemCount is the number of items of the secure variable and Size is
+	// the size in bytes of its encrypted data. They are set by the server
+	// when the variable is written, so that they can be listed without
+	// decrypting the variable.
+	ItemCount int
+	Size      int
+
+	// Meta is operator provided metadata, such as an owner or rotation
+	// date. It is stored unencrypted and can be used to filter list results.
+	Meta map[string]string
+
+	// SourceRegion is the region the secure variable was replicated from.
+	// It is empty for secure variables written in this region. Replicated
+	// secure variables can only be modified in their source region.
+	SourceRegion string `json:",omitempty"`
+
+	// DeleteProtection prevents the secure variable from being deleted,
+	// unless the deletion is forced by a token with the delete-protected
+	// capability. Removing the protection requires the same.
+	DeleteProtection bool
 }
 
 // SecureVariableEncrypted structs are returned from the Encrypter's encrypt
@@ -102,7 +198,15 @@ func (v1 SecureVariableDecrypted) Equals(v2 SecureVariableDecrypted) bool {
 // syntax for metadata and the SecureVariablesData or SecureVariableItems
 // struct
 func (sv SecureVariableMetadata) Equals(sv2 SecureVariableMetadata) bool {
-	return sv == sv2
+	return sv.Namespace == sv2.Namespace &&
+		sv.Path == sv2.Path &&
+		sv.CreateIndex == sv2.CreateIndex &&
+		sv.CreateTime == sv2.CreateTime &&
+		sv.ModifyIndex == sv2.ModifyIndex &&
+		sv.ModifyTime == sv2.ModifyTime &&
+		sv.SourceRegion == sv2.SourceRegion &&
+		sv.DeleteProtection == sv2.DeleteProtection &&
+		helper.CompareMapStringString(sv.Meta, sv2.Meta)
 }
 
 // Equals performs deep equality checking on the cleartext items
@@ -127,7 +231,7 @@ func (d1 SecureVariableData) Equals(d2 SecureVariableData) bool {
 
 func (sv SecureVariableDecrypted) Copy() SecureVariableDecrypted {
 	return SecureVariableDecrypted{
-		SecureVariableMetadata: sv.SecureVariableMetadata,
+		SecureVariableMetadata: *sv.SecureVariableMetadata.Copy(),
 		Items:                  sv.Items.Copy(),
 	}
 }
@@ -142,7 +246,7 @@ func (sv SecureVariableItems) Copy() SecureVariableItems {
 
 func (sv SecureVariableEncrypted) Copy() SecureVariableEncrypted {
 	return SecureVariableEncrypted{
-		SecureVariableMetadata: sv.SecureVariableMetadata,
+		SecureVariableMetadata: *sv.SecureVariableMetadata.Copy(),
 		SecureVariableData:     sv.SecureVariableData.Copy(),
 	}
 }
@@ -175,6 +279,16 @@ func (sv SecureVariableDecrypted) Validate() error {
 	if sv.Items.Size() > maxVariableSize {
 		return errors.New("variables are limited to 16KiB in total size")
 	}
+	var metaSize int
+	for k, v := range sv.Meta {
+		if k == "" {
+			return errors.New("variable metadata keys must not be empty")
+		}
+		metaSize += len(k) + len(v)
+	}
+	if metaSize > maxVariableMetaSize {
+		return errors.New("variable metadata is limited to 4KiB in total size")
+	}
 	if sv.Namespace == AllNamespacesSentinel {
 		return errors.New("can not target wildcard (\"*\")namespace")
 	}
@@ -190,6 +304,7 @@ func (sv *SecureVariableDecrypted) Canonicalize() {
 // GetNamespace returns the secure variable's namespace. Used for pagination.
 func (sv *SecureVariableMetadata) Copy() *SecureVariableMetadata {
 	var out SecureVariableMetadata = *sv
+	out.Meta = helper.CopyMapStringString(sv.Meta)
 	return &out
 }
 
@@ -240,6 +355,10 @@ const (
 	SVOpDelete    SVOp = "delete"
 	SVOpDeleteCAS SVOp = "delete-cas"
 	SVOpCAS       SVOp = "cas"
+
+	// SVOpCheckIndex checks the ModifyIndex of a secure variable without
+	// modifying it. It is only supported in transactions.
+	SVOpCheckIndex SVOp = "check-index"
 )
 
 // SVOpResult constants give possible operations results from a transaction.
@@ -254,8 +373,9 @@ const (
 
 // SecureVariablesApplyRequest is used by users to operate on the secure variable store
 type SecureVariablesApplyRequest struct {
-	Op  SVOp                     // Operation to be performed during apply
-	Var *SecureVariableDecrypted // Variable-shaped request data
+	Op    SVOp                     // Operation to be performed during apply
+	Var   *SecureVariableDecrypted // Variable-shaped request data
+	Force bool                     // Delete or unprotect a protected variable
 	WriteRequest
 }
 
@@ -286,10 +406,36 @@ func (r *SecureVariablesApplyResponse) IsRedacted() bool {
 	return r.Result == SVOpResultRedacted
 }
 
+// SecureVariableConflict is the body of the 409 Conflict response to a
+// checked write or delete of a secure variable. It embeds the conflicting
+// secure variable, so clients reading the body as a secure variable keep
+// working.
+type SecureVariableConflict struct {
+	SecureVariableDecrypted
+
+	// CheckIndex is the modify index the write or delete expected
+	CheckIndex uint64
+
+	// Redacted is true if the caller can't read the conflicting secure
+	// variable, in which case only its metadata is set.
+	Redacted bool
+}
+
+// NewSecureVariableConflict returns the conflict body of a response whose
+// check of the given index failed.
+func NewSecureVariableConflict(r *SecureVariablesApplyResponse, checkIndex uint64) *SecureVariableConflict {
+	return &SecureVariableConflict{
+		SecureVariableDecrypted: *r.Conflict,
+		CheckIndex:              checkIndex,
+		Redacted:                r.IsRedacted(),
+	}
+}
+
 // SVApplyStateRequest is used by the FSM to modify the secure variable store
 type SVApplyStateRequest struct {
-	Op  SVOp                     // Which operation are we performing
-	Var *SecureVariableEncrypted // Which directory entry
+	Op    SVOp                     // Which operation are we performing
+	Var   *SecureVariableEncrypted // Which directory entry
+	Force bool                     // Delete or unprotect a protected variable
 	WriteRequest
 }
 
@@ -359,6 +505,210 @@ type SecureVariablesListResponse struct {
 	QueryMeta
 }
 
+// SecureVariablesSearchRequest is used to search secure variables. The
+// QueryOptions Prefix restricts the search to paths under a prefix, which is
+// cheaper than matching with Glob and should be used where possible.
+type SecureVariablesSearchRequest struct {
+	// Glob is a pattern, in the syntax of path.Match, that the path of
+	// matching secure variables must satisfy.
+	Glob string
+
+	// Meta are metadata key/value pairs that matching secure variables
+	// must all have.
+	Meta map[string]string
+
+	QueryOptions
+}
+
+// Validate checks that the search pattern is well formed.
+func (r *SecureVariablesSearchRequest) Validate() error {
+	if r.Glob == "" {
+		return nil
+	}
+	if _, err := path.Match(r.Glob, ""); err != nil {
+		return fmt.Errorf("invalid glob pattern %q: %v", r.Glob, err)
+	}
+	return nil
+}
+
+// Matches returns whether the secure variable satisfies the Glob and Meta of
+// the search. The Prefix is applied separately by the state store query.
+func (r *SecureVariablesSearchRequest) Matches(sv *SecureVariableMetadata) bool {
+	if r.Glob != "" {
+		if ok, _ := path.Match(r.Glob, sv.Path); !ok {
+			return false
+		}
+	}
+	for k, v := range r.Meta {
+		if got, ok := sv.Meta[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
+
+type SecureVariablesSearchResponse struct {
+	Data []*SecureVariableMetadata
+	QueryMeta
+}
+
+// SecureVariablesPurgeRequest is used to delete all the secure variables
+// under a path prefix in the request namespace.
+type SecureVariablesPurgeRequest struct {
+	// Prefix is the path prefix of the secure variables to delete.
+	Prefix string
+
+	// Recurse deletes secure variables at any depth under the Prefix.
+	// Otherwise only those with no further "/" in their path after the
+	// Prefix are deleted.
+	Recurse bool
+
+	// DryRun returns the paths that would be deleted without deleting them.
+	DryRun bool
+
+	// Force also deletes the secure variables with delete protection.
+	// Otherwise they are skipped.
+	Force bool
+
+	WriteRequest
+}
+
+// Validate checks that the purge request is well formed.
+func (r *SecureVariablesPurgeRequest) Validate() error {
+	if r.Prefix == "" {
+		return errors.New("purge requires a path prefix")
+	}
+	if r.RequestNamespace() == AllNamespacesSentinel {
+		return errors.New("purge does not support the wildcard namespace")
+	}
+	return nil
+}
+
+// Matches returns whether the secure variable at path is selected by the
+// purge request.
+func (r *SecureVariablesPurgeRequest) Matches(path string) bool {
+	if !strings.HasPrefix(path, r.Prefix) {
+		return false
+	}
+	return r.Recurse || !strings.Contains(path[len(r.Prefix):], "/")
+}
+
+// SecureVariablesPurgeResponse lists the paths of the secure variables that
+// were deleted, or would be deleted for a dry run.
+type SecureVariablesPurgeResponse struct {
+	Paths []string
+	WriteMeta
+}
+
+// SVBatchDeleteStateRequest is used by the FSM to delete many secure
+// variables of a namespace in a single transaction.
+type SVBatchDeleteStateRequest struct {
+	Namespace string
+	Paths     []string
+	Force     bool
+	WriteRequest
+}
+
+// SecureVariablesTxnOp is a single operation of a secure variables
+// transaction. The ModifyIndex of the Var is the index checked by the cas,
+// delete-cas and check-index operations, where 0 checks that the secure
+// variable does not exist.
+type SecureVariablesTxnOp struct {
+	Op  SVOp
+	Var *SecureVariableDecrypted
+}
+
+// SecureVariablesTxnRequest is used to apply many secure variable operations
+// atomically. Either all the operations are applied or none are.
+type SecureVariablesTxnRequest struct {
+	Ops []*SecureVariablesTxnOp
+	WriteRequest
+}
+
+// Validate checks that the transaction request is well formed.
+func (r *SecureVariablesTxnRequest) Validate() error {
+	if len(r.Ops) == 0 {
+		return errors.New("transaction requires at least one operation")
+	}
+	if len(r.Ops) > SecureVariablesTxnMaxOps {
+		return fmt.Errorf("transaction has %d operations, the maximum is %d",
+			len(r.Ops), SecureVariablesTxnMaxOps)
+	}
+	if r.RequestNamespace() == AllNamespacesSentinel {
+		return errors.New("transaction does not support the wildcard namespace")
+	}
+	for i, op := range r.Ops {
+		if op == nil || op.Var == nil {
+			return fmt.Errorf("operation %d: variable must not be nil", i)
+		}
+		switch op.Op {
+		case SVOpSet, SVOpCAS, SVOpDelete, SVOpDeleteCAS, SVOpCheckIndex:
+		default:
+			return fmt.Errorf("operation %d: unsupported operation %q", i, op.Op)
+		}
+	}
+	return nil
+}
+
+// SecureVariablesTxnResponse is sent back to the user with the result of a
+// secure variables transaction.
+type SecureVariablesTxnResponse struct {
+	// Result is ok if all the operations were applied, or a conflict if the
+	// check of one of them failed.
+	Result SVOpResult
+
+	// Outputs holds the written secure variable of each set and cas
+	// operation of a successful transaction, and nil for other operations.
+	Outputs []*SecureVariableDecrypted
+
+	// ConflictIndex is the position of the operation whose check failed
+	// when the transaction conflicted.
+	ConflictIndex int
+
+	// Conflict is the current value of the secure variable of the
+	// conflicting operation. Its items are redacted if the caller can't read
+	// it.
+	Conflict *SecureVariableDecrypted
+
+	WriteMeta
+}
+
+// SVTxnStateOp is a single operation of a SVTxnStateRequest.
+type SVTxnStateOp struct {
+	Op  SVOp
+	Var *SecureVariableEncrypted
+}
+
+// SVTxnStateRequest is used by the FSM to apply the operations of a secure
+// variables transaction in a single state store transaction.
+type SVTxnStateRequest struct {
+	Ops []*SVTxnStateOp
+	WriteRequest
+}
+
+// SVTxnStateResponse is used by the FSM to inform the RPC layer of the result
+// of a secure variables transaction.
+type SVTxnStateResponse struct {
+	Result        SVOpResult               // What happened (ok, conflict, error)
+	Error         error                    // error if any
+	ConflictIndex int                      // position of the conflicting operation
+	Conflict      *SecureVariableEncrypted // conflicting secure variable if applies
+	Written       []*SecureVariableMetadata
+	WriteMeta
+}
+
+func (r *SVTxnStateResponse) IsOk() bool {
+	return r.Result == SVOpResultOk
+}
+
+func (r *SVTxnStateResponse) IsConflict() bool {
+	return r.Result == SVOpResultConflict
+}
+
+func (r *SVTxnStateResponse) IsError() bool {
+	return r.Result == SVOpResultError
+}
+
 type SecureVariablesReadRequest struct {
 	Path string
 	QueryOptions
@@ -369,6 +719,41 @@ type SecureVariablesReadResponse struct {
 	QueryMeta
 }
 
+// SecureVariablesReadPrefixRequest is used to read all the secure variables
+// under the QueryOptions Prefix in the request namespace. The results are
+// paginated like those of List.
+type SecureVariablesReadPrefixRequest struct {
+	QueryOptions
+}
+
+type SecureVariablesReadPrefixResponse struct {
+	Data []*SecureVariableDecrypted
+	QueryMeta
+}
+
+// SecureVariablesExchangeIdentityRequest is used to exchange the workload
+// identity passed as the request's AuthToken for a token scoped to reading
+// the workload's secure variables.
+type SecureVariablesExchangeIdentityRequest struct {
+	WriteRequest
+}
+
+// SecureVariablesExchangeIdentityResponse holds the token issued in exchange
+// for a workload identity.
+type SecureVariablesExchangeIdentityResponse struct {
+	// Token is the signed token, which can be used as the AuthToken of
+	// secure variable reads and lists until it expires.
+	Token string
+
+	// ExpiresAt is when the token stops being accepted.
+	ExpiresAt time.Time
+
+	// Paths are the secure variable path prefixes the token can read.
+	Paths []string
+
+	WriteMeta
+}
+
 // ---------------------------------------
 // Keyring state and RPC objects
 
@@ -414,16 +799,41 @@ type RootKeyMeta struct {
 	CreateIndex uint64
 	ModifyIndex uint64
 	State       RootKeyState
+
+	// PublishTime is the time a prepublished key becomes the active key,
+	// in UnixNano. It is zero for keys that were never prepublished.
+	PublishTime int64
+
+	// Usage is computed when the keyring is listed and is never written
+	// to raft or the keystore.
+	Usage *RootKeyUsage `json:",omitempty"`
+}
+
+// RootKeyUsage describes how much a root key is still in use, so that
+// operators can tell when an old key can safely be removed.
+type RootKeyUsage struct {
+	// Variables is the number of secure variables encrypted with the key.
+	Variables int
+
+	// SignOperations is the number of workload identities the leader has
+	// signed with the key since it was elected.
+	SignOperations uint64
+
+	// LastUsed is the last time the leader encrypted, decrypted, or signed
+	// with the key since it was elected, in UnixNano. It is zero if the key
+	// hasn't been used in that time.
+	LastUsed int64
 }
 
 // RootKeyState enum describes the lifecycle of a root key.
 type RootKeyState string
 
 const (
-	RootKeyStateInactive   RootKeyState = "inactive"
-	RootKeyStateActive                  = "active"
-	RootKeyStateRekeying                = "rekeying"
-	RootKeyStateDeprecated              = "deprecated"
+	RootKeyStateInactive     RootKeyState = "inactive"
+	RootKeyStateActive                    = "active"
+	RootKeyStateRekeying                  = "rekeying"
+	RootKeyStateDeprecated                = "deprecated"
+	RootKeyStatePrepublished              = "prepublished"
 )
 
 // NewRootKeyMeta returns a new RootKeyMeta with default values
@@ -442,10 +852,11 @@ func NewRootKeyMeta() *RootKeyMeta {
 // fields such as ModifyIndex so we don't have to sync them to the
 // on-disk keystore when the fields are already in raft.
 type RootKeyMetaStub struct {
-	KeyID      string
-	Algorithm  EncryptionAlgorithm
-	CreateTime int64
-	State      RootKeyState
+	KeyID       string
+	Algorithm   EncryptionAlgorithm
+	CreateTime  int64
+	State       RootKeyState
+	PublishTime int64
 }
 
 // Active indicates his key is the one currently being used for
@@ -482,15 +893,27 @@ func (rkm *RootKeyMeta) SetDeprecated() {
 	rkm.State = RootKeyStateDeprecated
 }
 
+// Prepublished indicates that this key has been distributed to the
+// servers ahead of becoming the active key at its PublishTime
+func (rkm *RootKeyMeta) Prepublished() bool {
+	return rkm.State == RootKeyStatePrepublished
+}
+
+func (rkm *RootKeyMeta) SetPrepublished(publishTime int64) {
+	rkm.State = RootKeyStatePrepublished
+	rkm.PublishTime = publishTime
+}
+
 func (rkm *RootKeyMeta) Stub() *RootKeyMetaStub {
 	if rkm == nil {
 		return nil
 	}
 	return &RootKeyMetaStub{
-		KeyID:      rkm.KeyID,
-		Algorithm:  rkm.Algorithm,
-		CreateTime: rkm.CreateTime,
-		State:      rkm.State,
+		KeyID:       rkm.KeyID,
+		Algorithm:   rkm.Algorithm,
+		CreateTime:  rkm.CreateTime,
+		State:       rkm.State,
+		PublishTime: rkm.PublishTime,
 	}
 
 }
@@ -499,6 +922,10 @@ func (rkm *RootKeyMeta) Copy() *RootKeyMeta {
 		return nil
 	}
 	out := *rkm
+	if rkm.Usage != nil {
+		usage := *rkm.Usage
+		out.Usage = &usage
+	}
 	return &out
 }
 
@@ -515,6 +942,10 @@ func (rkm *RootKeyMeta) Validate() error {
 	switch rkm.State {
 	case RootKeyStateInactive, RootKeyStateActive,
 		RootKeyStateRekeying, RootKeyStateDeprecated:
+	case RootKeyStatePrepublished:
+		if rkm.PublishTime == 0 {
+			return fmt.Errorf("prepublished root key requires a publish time")
+		}
 	default:
 		return fmt.Errorf("root key state %q is invalid", rkm.State)
 	}
@@ -532,6 +963,12 @@ const (
 type KeyringRotateRootKeyRequest struct {
 	Algorithm EncryptionAlgorithm
 	Full      bool
+
+	// PublishTime schedules the rotation, in UnixNano. The new key is
+	// prepublished so that all servers have it before it becomes the
+	// active key at this time.
+	PublishTime int64
+
 	WriteRequest
 }
 
@@ -551,6 +988,18 @@ type KeyringListRootKeyMetaResponse struct {
 	QueryMeta
 }
 
+// KeyringGetRootKeyMetaRequest is used to read the metadata of a single key,
+// without its key material.
+type KeyringGetRootKeyMetaRequest struct {
+	KeyID string
+	QueryOptions
+}
+
+type KeyringGetRootKeyMetaResponse struct {
+	Key *RootKeyMeta
+	QueryMeta
+}
+
 // KeyringUpdateRootKeyRequest is used internally for key replication
 // only and for keyring restores. The RootKeyMeta will be extracted
 // for applying to the FSM with the KeyringUpdateRootKeyMetaRequest
@@ -577,6 +1026,22 @@ type KeyringGetRootKeyResponse struct {
 	QueryMeta
 }
 
+// KeyringGetWrappedRootKeyRequest is used internally to replicate root keys
+// across regions. The key material is sealed to PublicKey, an ephemeral
+// X25519 public key of the requesting server, so that it never leaves the
+// region in the clear.
+type KeyringGetWrappedRootKeyRequest struct {
+	KeyID     string
+	PublicKey []byte
+	QueryOptions
+}
+
+type KeyringGetWrappedRootKeyResponse struct {
+	Meta       *RootKeyMeta
+	WrappedKey []byte
+	QueryMeta
+}
+
 // KeyringUpdateRootKeyMetaRequest is used internally for key
 // replication so that we have a request wrapper for writing the
 // metadata to the FSM without including the key material
@@ -598,3 +1063,165 @@ type KeyringDeleteRootKeyRequest struct {
 type KeyringDeleteRootKeyResponse struct {
 	WriteMeta
 }
+
+// RootKeyBundleKDFScrypt is the key derivation function used to turn the
+// passphrase of a RootKeyBundle into a wrapping key
+const RootKeyBundleKDFScrypt = "scrypt"
+
+// RootKeyBundle is a root key sealed with a key derived from an
+// operator-provided passphrase, so that it can be moved between clusters
+// for disaster recovery without ever exposing the key material.
+type RootKeyBundle struct {
+	KeyID      string
+	Algorithm  EncryptionAlgorithm
+	CreateTime int64
+	KDF        string
+	Salt       []byte
+	Ciphertext []byte
+}
+
+// Validate checks that the bundle has everything needed to open it.
+func (b *RootKeyBundle) Validate() error {
+	if b == nil {
+		return fmt.Errorf("root key bundle is required")
+	}
+	if b.KeyID == "" {
+		return fmt.Errorf("root key bundle is missing the key ID")
+	}
+	if b.KDF != RootKeyBundleKDFScrypt {
+		return fmt.Errorf("root key bundle has unsupported KDF %q", b.KDF)
+	}
+	if len(b.Salt) == 0 || len(b.Ciphertext) == 0 {
+		return fmt.Errorf("root key bundle is missing sealed key material")
+	}
+	return nil
+}
+
+// KeyringExportRootKeyRequest is used to export a root key sealed with
+// the passphrase.
+type KeyringExportRootKeyRequest struct {
+	KeyID      string
+	Passphrase string
+	QueryOptions
+}
+
+type KeyringExportRootKeyResponse struct {
+	Bundle *RootKeyBundle
+	QueryMeta
+}
+
+// KeyringImportRootKeyRequest is used to install a root key from a bundle
+// previously exported from this or another cluster.
+type KeyringImportRootKeyRequest struct {
+	Bundle     *RootKeyBundle
+	Passphrase string
+	WriteRequest
+}
+
+type KeyringImportRootKeyResponse struct {
+	Key *RootKeyMeta
+	WriteMeta
+}
+
+// KeyringVerifyRequest is used to check that every secure variable can be
+// decrypted with the keys in the keyring.
+type KeyringVerifyRequest struct {
+	QueryOptions
+}
+
+type KeyringVerifyResponse struct {
+	// Variables is the number of secure variables that were checked.
+	Variables int
+
+	// Orphaned are the secure variables that cannot be decrypted.
+	Orphaned []*KeyringOrphanedVariable
+	QueryMeta
+}
+
+// KeyringOrphanedVariable is a secure variable which cannot be decrypted
+// with the keys in the keyring, typically because the key that encrypted it
+// has been deleted.
+type KeyringOrphanedVariable struct {
+	Namespace string
+	Path      string
+	KeyID     string
+	Error     string
+}
+
+// SecureVariablesReplicateRequest is used by the leader of TargetRegion to
+// fetch the secure variables replicated to it.
+type SecureVariablesReplicateRequest struct {
+	TargetRegion string
+	QueryOptions
+}
+
+// SecureVariablesReplicateResponse holds the encrypted secure variables
+// written in the region whose namespace replicates them to the target region.
+type SecureVariablesReplicateResponse struct {
+	Data []*SecureVariableEncrypted
+	QueryMeta
+}
+
+// SecureVariablesReplicationStatusRequest is used to query the status of the
+// replication of secure variables to this region.
+type SecureVariablesReplicationStatusRequest struct {
+	QueryOptions
+}
+
+type SecureVariablesReplicationStatusResponse struct {
+	// Sources is the replication status from each region, sorted by region.
+	Sources []*SecureVariablesReplicationStatus
+	QueryMeta
+}
+
+// SecureVariablesReplicationStatus is the status of the replication of secure
+// variables from a source region, as tracked by the leader since it was
+// elected.
+type SecureVariablesReplicationStatus struct {
+	Region string
+
+	// Index is the index of the source region's state that was last
+	// replicated.
+	Index uint64
+
+	// Variables is the number of secure variables replicated from the
+	// region.
+	Variables int
+
+	// LastReplicated is the time of the last successful replication in
+	// unix nanoseconds.
+	LastReplicated int64
+
+	// Lag is how long the most recently replicated change took to be
+	// applied, from its write in the source region.
+	Lag time.Duration
+
+	// Error is the error of the last replication attempt, if it failed.
+	Error string
+}
+
+// KeyringHealthRequest is used to query the result of the leader's periodic
+// integrity checks of the encrypted secure variables.
+type KeyringHealthRequest struct {
+	QueryOptions
+}
+
+type KeyringHealthResponse struct {
+	// Healthy is false if any sampled secure variable failed to decrypt and
+	// still does.
+	Healthy bool
+
+	// LastCheck is the time of the leader's last integrity check in unix
+	// nanoseconds, or 0 if it hasn't run one since it was elected.
+	LastCheck int64
+
+	// Checked is the number of secure variables checked by the leader since
+	// it was elected.
+	Checked int
+
+	// Failed are the secure variables that failed to decrypt, either
+	// because their ciphertext doesn't authenticate or because their key is
+	// missing from the keyring.
+	Failed []*KeyringOrphanedVariable
+	QueryMeta
+}
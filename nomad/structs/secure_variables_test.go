@@ -1,6 +1,7 @@
 package structs
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -30,6 +31,13 @@ func TestStructs_SecureVariableDecrypted_Copy(t *testing.T) {
 	require.True(t, sv.Equals(sv2), "sv and sv2 should be equal")
 	sv2.Items["new"] = "new"
 	require.False(t, sv.Equals(sv2), "sv and sv2 should not be equal")
+
+	sv.Meta = map[string]string{"owner": "ops"}
+	sv3 := sv.Copy()
+	require.True(t, sv.Equals(sv3), "sv and sv3 should be equal")
+	sv3.Meta["owner"] = "dev"
+	require.False(t, sv.Equals(sv3), "sv and sv3 should not be equal")
+	require.Equal(t, "ops", sv.Meta["owner"])
 }
 
 func TestStructs_SecureVariableDecrypted_Validate(t *testing.T) {
@@ -63,3 +71,51 @@ func TestStructs_SecureVariableDecrypted_Validate(t *testing.T) {
 		}
 	}
 }
+
+func TestStructs_SecureVariableDecrypted_Validate_Meta(t *testing.T) {
+	ci.Parallel(t)
+
+	sv := SecureVariableDecrypted{
+		SecureVariableMetadata: SecureVariableMetadata{
+			Namespace: "a",
+			Path:      "a/b/c",
+			Meta:      map[string]string{"owner": "ops"},
+		},
+		Items: SecureVariableItems{"foo": "bar"},
+	}
+	require.NoError(t, sv.Validate())
+
+	sv.Meta[""] = "empty"
+	require.EqualError(t, sv.Validate(), "variable metadata keys must not be empty")
+
+	sv.Meta = map[string]string{"big": strings.Repeat("x", maxVariableMetaSize)}
+	require.EqualError(t, sv.Validate(), "variable metadata is limited to 4KiB in total size")
+}
+
+func TestStructs_NewSecureVariableConflict(t *testing.T) {
+	ci.Parallel(t)
+
+	resp := &SecureVariablesApplyResponse{
+		Op:     SVOpCAS,
+		Result: SVOpResultConflict,
+		Conflict: &SecureVariableDecrypted{
+			SecureVariableMetadata: SecureVariableMetadata{
+				Namespace:   "default",
+				Path:        "a/b/c",
+				ModifyIndex: 12,
+			},
+			Items: SecureVariableItems{"key": "value"},
+		},
+	}
+	conflict := NewSecureVariableConflict(resp, 11)
+	require.Equal(t, *resp.Conflict, conflict.SecureVariableDecrypted)
+	require.Equal(t, uint64(11), conflict.CheckIndex)
+	require.False(t, conflict.Redacted)
+
+	// The items of a conflict the caller can't read aren't set
+	resp.Result = SVOpResultRedacted
+	resp.Conflict.Items = nil
+	conflict = NewSecureVariableConflict(resp, 11)
+	require.True(t, conflict.Redacted)
+	require.Nil(t, conflict.Items)
+}
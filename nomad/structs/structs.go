@@ -14,8 +14,10 @@ import (
 	"fmt"
 	"hash"
 	"hash/crc32"
+	"hash/fnv"
 	"math"
 	"net"
+	"net/url"
 	"os"
 	"reflect"
 	"regexp"
@@ -113,6 +115,13 @@ const (
 	SVApplyStateRequestType                      MessageType = 50
 	RootKeyMetaUpsertRequestType                 MessageType = 51
 	RootKeyMetaDeleteRequestType                 MessageType = 52
+	JobVersionsDeleteRequestType                 MessageType = 53
+	SVBatchDeleteRequestType                     MessageType = 54
+	NodeUpdatePlannedDisconnectRequestType       MessageType = 55
+	JobSigningKeyUpsertRequestType               MessageType = 56
+	JobSigningKeyDeleteRequestType               MessageType = 57
+	SVTxnRequestType                             MessageType = 58
+	JobRestartUpsertRequestType                  MessageType = 59
 
 	// Namespace types were moved from enterprise and therefore start at 64
 	NamespaceUpsertRequestType MessageType = 64
@@ -447,6 +456,24 @@ type QueryMeta struct {
 	// paginated lists. To resume paging from this point, pass
 	// this token in the next request's QueryOptions.
 	NextToken string
+
+	// QueryTime is the time the server spent running the query against
+	// its state store, excluding any time spent blocking for changes.
+	QueryTime time.Duration
+
+	// ObjectsScanned is the number of objects read from the state store
+	// to answer a paginated list query, including those dropped by a
+	// filter. It is zero for queries that don't report it.
+	ObjectsScanned int
+
+	// Forwarded is set when the server that received the query forwarded
+	// it to the leader or to another region.
+	Forwarded bool
+}
+
+// SetForwardedReply marks that the query was answered by another server.
+func (q *QueryMeta) SetForwardedReply() {
+	q.Forwarded = true
 }
 
 // WriteMeta allows a write response to include potentially
@@ -478,6 +505,23 @@ type NodeBatchDeregisterRequest struct {
 	WriteRequest
 }
 
+// NodePurgeDownRequest is used for the Node.PurgeDown endpoint to purge
+// every node that has been down for longer than a threshold.
+type NodePurgeDownRequest struct {
+	// DownFor is how long a node must have been down to be purged. All
+	// down nodes are purged if it's zero.
+	DownFor time.Duration
+	WriteRequest
+}
+
+// NodePurgeDownResponse is used to respond to a Node.PurgeDown request
+type NodePurgeDownResponse struct {
+	NodeIDs         []string
+	EvalIDs         []string
+	EvalCreateIndex uint64
+	WriteMeta
+}
+
 // NodeServerInfo is used to in NodeUpdateResponse to return Nomad server
 // information used in RPC server lists.
 type NodeServerInfo struct {
@@ -556,6 +600,31 @@ type NodeUpdateEligibilityRequest struct {
 	NodeID      string
 	Eligibility string
 
+	// Reason is an optional operator supplied reason for the change
+	Reason string
+
+	// NodeEvent is the event added to the node
+	NodeEvent *NodeEvent
+
+	// UpdatedAt represents server time of receiving request
+	UpdatedAt int64
+
+	WriteRequest
+}
+
+// NodeUpdatePlannedDisconnectRequest is used to plan or cancel a disconnect of
+// a node.
+type NodeUpdatePlannedDisconnectRequest struct {
+	NodeID string
+
+	// Duration is how long the node is expected to be disconnected. A zero
+	// duration cancels the planned disconnect.
+	Duration time.Duration
+
+	// PlannedDisconnect is set by the server from Duration when the
+	// request is received.
+	PlannedDisconnect *PlannedDisconnect
+
 	// NodeEvent is the event added to the node
 	NodeEvent *NodeEvent
 
@@ -608,6 +677,10 @@ type JobRegisterRequest struct {
 	// Eval is the evaluation that is associated with the job registration
 	Eval *Evaluation
 
+	// Signature is the optional signature of the job by a trusted job
+	// signing key.
+	Signature *JobSignature
+
 	WriteRequest
 }
 
@@ -706,6 +779,17 @@ type JobScaleRequest struct {
 	Message string
 	Error   bool
 	Meta    map[string]interface{}
+
+	// Groups sets the count of several task groups in a single job update.
+	// It is used instead of the Target group and Count.
+	Groups map[string]int64
+
+	// EnforceIndex is used to scale the job only if its modify index
+	// matches JobModifyIndex, as returned by the scale status, so that counts
+	// computed from a previous read aren't applied to a changed job.
+	EnforceIndex   bool
+	JobModifyIndex uint64
+
 	// PolicyOverride is set when the user is attempting to override any policies
 	PolicyOverride bool
 	WriteRequest
@@ -724,6 +808,26 @@ func (r *JobScaleRequest) Validate() error {
 	}
 
 	groupName := r.Target[ScalingTargetGroup]
+	if len(r.Groups) > 0 {
+		if groupName != "" || r.Count != nil {
+			return NewErrRPCCoded(400, "scaling action can't set both groups and a target group count")
+		}
+		if r.Error {
+			return NewErrRPCCoded(400, "scaling action should not contain groups if error is true")
+		}
+		for name, count := range r.Groups {
+			if count < 0 {
+				return NewErrRPCCoded(400,
+					fmt.Sprintf("scaling action count for group %q can't be negative", name))
+			}
+			if int64(int(count)) != count {
+				return NewErrRPCCoded(400,
+					fmt.Sprintf("new scaling count for group %q is too large for TaskGroup.Count (int): %v", name, count))
+			}
+		}
+		return nil
+	}
+
 	if groupName == "" {
 		return NewErrRPCCoded(400, "missing task group name for scaling action")
 	}
@@ -997,11 +1101,65 @@ type AllocStopResponse struct {
 	WriteMeta
 }
 
+// AllocReconnectRequest is used to accept an unknown allocation back, or to
+// reject it so that it is replaced.
+type AllocReconnectRequest struct {
+	AllocID string
+	Reject  bool
+
+	WriteRequest
+}
+
+// AllocReconnectResponse is the response to an `AllocReconnectRequest`
+type AllocReconnectResponse struct {
+	// EvalID is the id of the evaluation applying the decision.
+	EvalID string
+
+	WriteMeta
+}
+
 // AllocListRequest is used to request a list of allocations
 type AllocListRequest struct {
 	QueryOptions
 
 	Fields *AllocStubFields
+
+	// FilterClientStatus, FilterNodeID and FilterMinTimeInStatus filter the
+	// allocations by client status, by the ID or ID prefix of their node, and
+	// by the minimum time since they entered their client status.
+	FilterClientStatus    string
+	FilterNodeID          string
+	FilterMinTimeInStatus time.Duration
+}
+
+// Validate returns an error if the filters of the request are invalid.
+func (req *AllocListRequest) Validate() error {
+	switch req.FilterClientStatus {
+	case "", AllocClientStatusPending, AllocClientStatusRunning,
+		AllocClientStatusComplete, AllocClientStatusFailed,
+		AllocClientStatusLost, AllocClientStatusUnknown:
+	default:
+		return fmt.Errorf("invalid client status filter %q", req.FilterClientStatus)
+	}
+	if req.FilterMinTimeInStatus < 0 {
+		return fmt.Errorf("minimum time in status filter cannot be negative")
+	}
+	return nil
+}
+
+// ShouldBeFiltered indicates that the allocation should be filtered (that
+// is, removed) from the results
+func (req *AllocListRequest) ShouldBeFiltered(a *Allocation, now time.Time) bool {
+	if req.FilterClientStatus != "" && req.FilterClientStatus != a.ClientStatus {
+		return true
+	}
+	if req.FilterNodeID != "" && !strings.HasPrefix(a.NodeID, req.FilterNodeID) {
+		return true
+	}
+	if req.FilterMinTimeInStatus > 0 && now.Sub(a.ClientStatusTime()) < req.FilterMinTimeInStatus {
+		return true
+	}
+	return false
 }
 
 // AllocSpecificRequest is used to query a specific allocation
@@ -1125,6 +1283,20 @@ type DeploymentDeleteRequest struct {
 	WriteRequest
 }
 
+// JobVersionsDeleteRequest is used for deleting historic job versions that
+// fall outside of their namespace's retention policy.
+type JobVersionsDeleteRequest struct {
+	Versions []*JobVersionID
+	WriteRequest
+}
+
+// JobVersionID identifies a single historic version of a job.
+type JobVersionID struct {
+	Namespace string
+	ID        string
+	Version   uint64
+}
+
 // DeploymentStatusUpdateRequest is used to update the status of a deployment as
 // well as optionally creating an evaluation atomically.
 type DeploymentStatusUpdateRequest struct {
@@ -1358,6 +1530,17 @@ type NodeUpdateResponse struct {
 	// region.
 	Servers []*NodeServerInfo
 
+	// ReconnectWindow is set when the servers deferred the reconnection of
+	// a disconnected or down node because too many nodes are reconnecting.
+	// The node's status was not updated, and the node must retry after
+	// waiting for the window.
+	ReconnectWindow time.Duration
+
+	// PlannedDisconnect is set while a disconnect of the node is planned, so
+	// that the client can checkpoint its tasks if it disconnects before the
+	// deadline.
+	PlannedDisconnect *PlannedDisconnect
+
 	QueryMeta
 }
 
@@ -1942,6 +2125,11 @@ type Node struct {
 	// together for the purpose of determining scheduling pressure.
 	NodeClass string
 
+	// DisconnectDomain is an opaque identifier of the failure domain of the
+	// node's network, such as its rack or switch. Nodes of the same domain
+	// are expected to be disconnected together by a network partition.
+	DisconnectDomain string
+
 	// ComputedClass is a unique id that identifies nodes with a common set of
 	// attributes and capabilities.
 	ComputedClass string
@@ -1954,6 +2142,10 @@ type Node struct {
 	// placements.
 	SchedulingEligibility string
 
+	// EligibilityReason is the operator supplied reason for the most recent
+	// change to the node's scheduling eligibility.
+	EligibilityReason string
+
 	// Status of this node
 	Status string
 
@@ -1964,6 +2156,12 @@ type Node struct {
 	// updated
 	StatusUpdatedAt int64
 
+	// LastReconnect is the time stamp at which the node last left the
+	// disconnected status. The scheduler waits for the node to stay
+	// connected for the scheduler configuration's ReconnectStabilization
+	// before reinstating its reconnected allocations.
+	LastReconnect int64
+
 	// Events is the most recent set of events generated for the node,
 	// retaining only MaxRetainedNodeEvents number at a time
 	Events []*NodeEvent
@@ -1985,11 +2183,37 @@ type Node struct {
 	// LastDrain contains metadata about the most recent drain operation
 	LastDrain *DrainMetadata
 
+	// PlannedDisconnect is set while the node is expected to be
+	// disconnected, for example for maintenance. It is cleared when the node
+	// reconnects.
+	PlannedDisconnect *PlannedDisconnect
+
 	// Raft Indexes
 	CreateIndex uint64
 	ModifyIndex uint64
 }
 
+// PlannedDisconnect describes a window during which a node is expected to be
+// disconnected. Allocations running on the node when the disconnect is
+// planned are marked unknown rather than lost when the node misses its
+// heartbeats, whether or not their task group sets max_client_disconnect.
+type PlannedDisconnect struct {
+	// StartedAt is when the disconnect was planned.
+	StartedAt time.Time
+
+	// Deadline is when the node is expected to have reconnected. After the
+	// deadline, the allocations of a node that hasn't reconnected are lost.
+	Deadline time.Time
+}
+
+func (p *PlannedDisconnect) Copy() *PlannedDisconnect {
+	if p == nil {
+		return nil
+	}
+	np := *p
+	return &np
+}
+
 // GetID is a helper for getting the ID when the object may be nil and is
 // required for pagination.
 func (n *Node) GetID() string {
@@ -2076,6 +2300,7 @@ func (n *Node) Copy() *Node {
 	nn.HostVolumes = helper.DeepCopyMap(n.HostVolumes)
 	nn.HostNetworks = helper.DeepCopyMap(n.HostNetworks)
 	nn.LastDrain = nn.LastDrain.Copy()
+	nn.PlannedDisconnect = nn.PlannedDisconnect.Copy()
 	return &nn
 }
 
@@ -2090,6 +2315,12 @@ func (n *Node) TerminalStatus() bool {
 	}
 }
 
+// DownBefore returns if the node is down and its status was last updated
+// before the cutoff.
+func (n *Node) DownBefore(cutoff time.Time) bool {
+	return n.Status == NodeStatusDown && n.StatusUpdatedAt < cutoff.Unix()
+}
+
 // ComparableReservedResources returns the reserved resouces on the node
 // handling upgrade paths. Reserved networks must be handled separately. After
 // 0.11 calls to this should be replaced with:
@@ -2150,6 +2381,16 @@ func (n *Node) ComparableResources() *ComparableResources {
 	}
 }
 
+// MemoryOversubscriptionRatio returns the factor by which replacements for
+// allocations on disconnected clients may exceed the node's memory capacity.
+// It returns 1 if the node doesn't allow memory oversubscription.
+func (n *Node) MemoryOversubscriptionRatio() float64 {
+	if n.NodeResources == nil || n.NodeResources.MemoryOversubscriptionRatio <= 1 {
+		return 1
+	}
+	return n.NodeResources.MemoryOversubscriptionRatio
+}
+
 // Stub returns a summarized version of the node
 func (n *Node) Stub(fields *NodeStubFields) *NodeListStub {
 
@@ -2230,6 +2471,9 @@ type Resources struct {
 	IOPS        int // COMPAT(0.10): Only being used to issue warnings
 	Networks    Networks
 	Devices     ResourceDevices
+
+	// HugePages2MB is the number of 2MiB huge pages reserved for the task
+	HugePages2MB int
 }
 
 const (
@@ -2292,6 +2536,10 @@ func (r *Resources) Validate() error {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("MemoryMaxMB value (%d) should be larger than MemoryMB value (%d)", r.MemoryMaxMB, r.MemoryMB))
 	}
 
+	if r.HugePages2MB < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("HugePages2MB value (%d) can't be negative", r.HugePages2MB))
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -2310,6 +2558,9 @@ func (r *Resources) Merge(other *Resources) {
 	if other.MemoryMaxMB != 0 {
 		r.MemoryMaxMB = other.MemoryMaxMB
 	}
+	if other.HugePages2MB != 0 {
+		r.HugePages2MB = other.HugePages2MB
+	}
 	if other.DiskMB != 0 {
 		r.DiskMB = other.DiskMB
 	}
@@ -2335,6 +2586,7 @@ func (r *Resources) Equals(o *Resources) bool {
 		r.Cores == o.Cores &&
 		r.MemoryMB == o.MemoryMB &&
 		r.MemoryMaxMB == o.MemoryMaxMB &&
+		r.HugePages2MB == o.HugePages2MB &&
 		r.DiskMB == o.DiskMB &&
 		r.IOPS == o.IOPS &&
 		r.Networks.Equals(&o.Networks) &&
@@ -2450,6 +2702,7 @@ func (r *Resources) Add(delta *Resources) {
 	} else {
 		r.MemoryMaxMB += delta.MemoryMB
 	}
+	r.HugePages2MB += delta.HugePages2MB
 	r.DiskMB += delta.DiskMB
 
 	for _, n := range delta.Networks {
@@ -2865,6 +3118,11 @@ type NodeResources struct {
 	// to select dynamic ports from across all networks.
 	MinDynamicPort int
 	MaxDynamicPort int
+
+	// MemoryOversubscriptionRatio is the factor by which replacements for
+	// allocations on disconnected clients may exceed the node's memory
+	// capacity. A value of 0 or 1 disables oversubscription.
+	MemoryOversubscriptionRatio float64
 }
 
 func (n *NodeResources) Copy() *NodeResources {
@@ -2910,7 +3168,8 @@ func (n *NodeResources) Comparable() *ComparableResources {
 				ReservedCores: n.Cpu.ReservableCpuCores,
 			},
 			Memory: AllocatedMemoryResources{
-				MemoryMB: n.Memory.MemoryMB,
+				MemoryMB:     n.Memory.MemoryMB,
+				HugePages2MB: n.Memory.HugePages2MB,
 			},
 			Networks: n.Networks,
 		},
@@ -3132,6 +3391,9 @@ func (n *NodeCpuResources) SharesPerCore() int64 {
 type NodeMemoryResources struct {
 	// MemoryMB is the total available memory on the node
 	MemoryMB int64
+
+	// HugePages2MB is the number of 2MiB huge pages allocated on the node
+	HugePages2MB int64
 }
 
 func (n *NodeMemoryResources) Merge(o *NodeMemoryResources) {
@@ -3142,6 +3404,10 @@ func (n *NodeMemoryResources) Merge(o *NodeMemoryResources) {
 	if o.MemoryMB != 0 {
 		n.MemoryMB = o.MemoryMB
 	}
+
+	if o.HugePages2MB != 0 {
+		n.HugePages2MB = o.HugePages2MB
+	}
 }
 
 func (n *NodeMemoryResources) Equals(o *NodeMemoryResources) bool {
@@ -3157,6 +3423,10 @@ func (n *NodeMemoryResources) Equals(o *NodeMemoryResources) bool {
 		return false
 	}
 
+	if n.HugePages2MB != o.HugePages2MB {
+		return false
+	}
+
 	return true
 }
 
@@ -3572,10 +3842,11 @@ func (a *AllocatedResources) OldTaskResources() map[string]*Resources {
 	m := make(map[string]*Resources, len(a.Tasks))
 	for name, res := range a.Tasks {
 		m[name] = &Resources{
-			CPU:         int(res.Cpu.CpuShares),
-			MemoryMB:    int(res.Memory.MemoryMB),
-			MemoryMaxMB: int(res.Memory.MemoryMaxMB),
-			Networks:    res.Networks,
+			CPU:          int(res.Cpu.CpuShares),
+			MemoryMB:     int(res.Memory.MemoryMB),
+			MemoryMaxMB:  int(res.Memory.MemoryMaxMB),
+			HugePages2MB: int(res.Memory.HugePages2MB),
+			Networks:     res.Networks,
 		}
 	}
 
@@ -3702,8 +3973,9 @@ func (a *AllocatedTaskResources) Comparable() *ComparableResources {
 				ReservedCores: a.Cpu.ReservedCores,
 			},
 			Memory: AllocatedMemoryResources{
-				MemoryMB:    a.Memory.MemoryMB,
-				MemoryMaxMB: a.Memory.MemoryMaxMB,
+				MemoryMB:     a.Memory.MemoryMB,
+				MemoryMaxMB:  a.Memory.MemoryMaxMB,
+				HugePages2MB: a.Memory.HugePages2MB,
 			},
 		},
 	}
@@ -3825,6 +4097,9 @@ func (a *AllocatedCpuResources) Max(other *AllocatedCpuResources) {
 type AllocatedMemoryResources struct {
 	MemoryMB    int64
 	MemoryMaxMB int64
+
+	// HugePages2MB is the number of 2MiB huge pages reserved for the task
+	HugePages2MB int64
 }
 
 func (a *AllocatedMemoryResources) Add(delta *AllocatedMemoryResources) {
@@ -3838,6 +4113,7 @@ func (a *AllocatedMemoryResources) Add(delta *AllocatedMemoryResources) {
 	} else {
 		a.MemoryMaxMB += delta.MemoryMB
 	}
+	a.HugePages2MB += delta.HugePages2MB
 }
 
 func (a *AllocatedMemoryResources) Subtract(delta *AllocatedMemoryResources) {
@@ -3851,6 +4127,7 @@ func (a *AllocatedMemoryResources) Subtract(delta *AllocatedMemoryResources) {
 	} else {
 		a.MemoryMaxMB -= delta.MemoryMB
 	}
+	a.HugePages2MB -= delta.HugePages2MB
 }
 
 func (a *AllocatedMemoryResources) Max(other *AllocatedMemoryResources) {
@@ -3864,6 +4141,9 @@ func (a *AllocatedMemoryResources) Max(other *AllocatedMemoryResources) {
 	if other.MemoryMaxMB > a.MemoryMaxMB {
 		a.MemoryMaxMB = other.MemoryMaxMB
 	}
+	if other.HugePages2MB > a.HugePages2MB {
+		a.HugePages2MB = other.HugePages2MB
+	}
 }
 
 type AllocatedDevices []*AllocatedDeviceResource
@@ -3976,6 +4256,9 @@ func (c *ComparableResources) Superset(other *ComparableResources) (bool, string
 	if c.Flattened.Memory.MemoryMB < other.Flattened.Memory.MemoryMB {
 		return false, "memory"
 	}
+	if c.Flattened.Memory.HugePages2MB < other.Flattened.Memory.HugePages2MB {
+		return false, "hugepages"
+	}
 	if c.Shared.DiskMB < other.Shared.DiskMB {
 		return false, "disk"
 	}
@@ -4096,6 +4379,16 @@ type Job struct {
 
 	Multiregion *Multiregion
 
+	// Disconnect limits how many allocations of each group may wait in the
+	// unknown state for their disconnected client to come back.
+	Disconnect *DisconnectStrategy
+
+	// OnDisconnect and OnReconnect are the actions the client takes for each
+	// allocation of the job when it loses and regains contact with the
+	// servers.
+	OnDisconnect []*DisconnectHook
+	OnReconnect  []*DisconnectHook
+
 	// Periodic is used to define the interval the job is run at.
 	Periodic *PeriodicConfig
 
@@ -4139,6 +4432,10 @@ type Job struct {
 	// used to register this version of the job. Used by deploymentwatcher.
 	NomadTokenID string
 
+	// SigningKeyID is the ID of the job signing key which signed the
+	// submission of this version of the job, if it was signed.
+	SigningKeyID string
+
 	// Job status
 	Status string
 
@@ -4249,6 +4546,9 @@ func (j *Job) Copy() *Job {
 	nj.Constraints = CopySliceConstraints(nj.Constraints)
 	nj.Affinities = CopySliceAffinities(nj.Affinities)
 	nj.Multiregion = nj.Multiregion.Copy()
+	nj.Disconnect = nj.Disconnect.Copy()
+	nj.OnDisconnect = CopySliceDisconnectHooks(nj.OnDisconnect)
+	nj.OnReconnect = CopySliceDisconnectHooks(nj.OnReconnect)
 
 	if j.TaskGroups != nil {
 		tgs := make([]*TaskGroup, len(nj.TaskGroups))
@@ -4300,7 +4600,10 @@ func (j *Job) Validate() error {
 		mErr.Errors = append(mErr.Errors, errors.New("Missing job datacenters"))
 	} else {
 		for _, v := range j.Datacenters {
-			if v == "" {
+			name, _, err := ParseDatacenter(v)
+			if err != nil {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("Job datacenter %q is invalid: %v", v, err))
+			} else if name == "" {
 				mErr.Errors = append(mErr.Errors, errors.New("Job datacenter must be non-empty string"))
 			}
 		}
@@ -4410,6 +4713,24 @@ func (j *Job) Validate() error {
 		}
 	}
 
+	if j.Disconnect != nil {
+		if err := j.Disconnect.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+		}
+	}
+
+	for name, hooks := range map[string][]*DisconnectHook{
+		"on_disconnect": j.OnDisconnect,
+		"on_reconnect":  j.OnReconnect,
+	} {
+		for idx, hook := range hooks {
+			if err := hook.Validate(j); err != nil {
+				outer := fmt.Errorf("%s %d validation failed: %v", name, idx+1, err)
+				mErr.Errors = append(mErr.Errors, outer)
+			}
+		}
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -4441,6 +4762,22 @@ func (j *Job) Warnings() error {
 		mErr.Errors = append(mErr.Errors, err)
 	}
 
+	// The disconnect budget only applies to groups that keep allocations
+	// on disconnected clients
+	if j.Disconnect != nil {
+		hasMaxClientDisconnect := false
+		for _, tg := range j.TaskGroups {
+			if tg.MaxClientDisconnect != nil || len(tg.MaxClientDisconnectTiers) > 0 {
+				hasMaxClientDisconnect = true
+				break
+			}
+		}
+		if !hasMaxClientDisconnect {
+			err := fmt.Errorf("disconnect has no effect without max_client_disconnect set on a group")
+			mErr.Errors = append(mErr.Errors, err)
+		}
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -4552,6 +4889,57 @@ func (j *Job) IsMultiregion() bool {
 	return j.Multiregion != nil && j.Multiregion.Regions != nil && len(j.Multiregion.Regions) > 0
 }
 
+// ParseDatacenter splits a job datacenter entry of the form "dc1" or
+// "dc1:100" into the datacenter name and its placement weight. Entries
+// without a weight have a weight of 0.
+func ParseDatacenter(dc string) (string, int, error) {
+	idx := strings.LastIndex(dc, ":")
+	if idx == -1 {
+		return dc, 0, nil
+	}
+
+	name, raw := dc[:idx], dc[idx+1:]
+	weight, err := strconv.Atoi(raw)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid weight %q for datacenter %q", raw, name)
+	}
+	if weight < 1 || weight > 100 {
+		return "", 0, fmt.Errorf("weight for datacenter %q must be between 1 and 100", name)
+	}
+	return name, weight, nil
+}
+
+// DatacenterNames returns the datacenters of the job with any placement
+// weights removed, preserving the order in which they were declared.
+func (j *Job) DatacenterNames() []string {
+	names := make([]string, 0, len(j.Datacenters))
+	for _, dc := range j.Datacenters {
+		name, _, err := ParseDatacenter(dc)
+		if err != nil {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// DatacenterWeights returns the placement weight of each weighted datacenter
+// of the job. A nil map is returned if none of the datacenters are weighted.
+func (j *Job) DatacenterWeights() map[string]int {
+	var weights map[string]int
+	for _, dc := range j.Datacenters {
+		name, weight, err := ParseDatacenter(dc)
+		if err != nil || weight == 0 {
+			continue
+		}
+		if weights == nil {
+			weights = make(map[string]int)
+		}
+		weights[name] = weight
+	}
+	return weights
+}
+
 // IsPlugin returns whether a job is implements a plugin (currently just CSI)
 func (j *Job) IsPlugin() bool {
 	for _, tg := range j.TaskGroups {
@@ -4629,11 +5017,21 @@ func (j *Job) RequiredSignals() map[string]map[string][]string {
 
 			// Check if any template change mode uses signals
 			for _, t := range task.Templates {
-				if t.ChangeMode != TemplateChangeModeSignal {
-					continue
+				if t.ChangeMode == TemplateChangeModeSignal {
+					taskSignals[t.ChangeSignal] = struct{}{}
 				}
+				if t.MaxStale > 0 && t.StaleMode == TemplateStaleModeSignal {
+					taskSignals[t.StaleSignal] = struct{}{}
+				}
+			}
 
-				taskSignals[t.ChangeSignal] = struct{}{}
+			// Check if the disconnect hooks signal the task
+			for _, hooks := range [][]*DisconnectHook{j.OnDisconnect, j.OnReconnect} {
+				for _, h := range hooks {
+					if h.Signal != "" && (h.Task == "" || h.Task == task.Name) {
+						taskSignals[h.Signal] = struct{}{}
+					}
+				}
 			}
 
 			// Flatten and sort the signals
@@ -4848,6 +5246,12 @@ type UpdateStrategy struct {
 	// Canary is the number of canaries to deploy when a change to the task
 	// group is detected.
 	Canary int
+
+	// CanaryDistinctHosts requires canaries to be placed on nodes that are
+	// not running allocations of a previous version of the task group, so
+	// that the health of the canaries isn't confounded by the allocations
+	// they may replace.
+	CanaryDistinctHosts bool
 }
 
 func (u *UpdateStrategy) Copy() *UpdateStrategy {
@@ -4963,6 +5367,120 @@ func (m *Multiregion) Copy() *Multiregion {
 	return copy
 }
 
+// DisconnectStrategy limits how allocations of a job behave while their
+// clients are disconnected.
+type DisconnectStrategy struct {
+	// MaxUnknown is the number of allocations of each group that may be in
+	// the unknown state at the same time. The scheduler replaces allocations
+	// beyond it as if they were lost instead of waiting for them to
+	// reconnect.
+	MaxUnknown int
+}
+
+func (d *DisconnectStrategy) Copy() *DisconnectStrategy {
+	if d == nil {
+		return nil
+	}
+	nd := new(DisconnectStrategy)
+	*nd = *d
+	return nd
+}
+
+func (d *DisconnectStrategy) Validate() error {
+	if d.MaxUnknown < 0 {
+		return fmt.Errorf("disconnect max_unknown must be non-negative")
+	}
+	return nil
+}
+
+// DisconnectHook is an action the client takes for each allocation of a job
+// when it loses or regains contact with the servers, so that applications can
+// switch to a degraded mode before the servers consider their allocations
+// lost. Exactly one of Signal, Command, or Webhook is set.
+type DisconnectHook struct {
+	// Task is the task the action applies to. Signals are sent to every task
+	// of the allocation when it is empty. Allocations of groups without the
+	// task are skipped.
+	Task string
+
+	// Signal is sent to the task.
+	Signal string
+
+	// Command is run with Args inside the task.
+	Command string
+	Args    []string
+
+	// Webhook is a URL the client sends a POST request describing the event
+	// to.
+	Webhook string
+
+	// Timeout bounds how long the command or webhook may take.
+	Timeout time.Duration
+}
+
+func (h *DisconnectHook) Copy() *DisconnectHook {
+	if h == nil {
+		return nil
+	}
+	nh := new(DisconnectHook)
+	*nh = *h
+	nh.Args = helper.CopySliceString(h.Args)
+	return nh
+}
+
+func CopySliceDisconnectHooks(s []*DisconnectHook) []*DisconnectHook {
+	if s == nil {
+		return nil
+	}
+	c := make([]*DisconnectHook, len(s))
+	for i, h := range s {
+		c[i] = h.Copy()
+	}
+	return c
+}
+
+func (h *DisconnectHook) Validate(j *Job) error {
+	var mErr multierror.Error
+
+	actions := 0
+	for _, set := range []bool{h.Signal != "", h.Command != "", h.Webhook != ""} {
+		if set {
+			actions++
+		}
+	}
+	if actions != 1 {
+		mErr.Errors = append(mErr.Errors, errors.New("exactly one of signal, command, or webhook must be set"))
+	}
+
+	if h.Command != "" && h.Task == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("command requires a task"))
+	}
+	if h.Task != "" {
+		found := false
+		for _, tg := range j.TaskGroups {
+			if tg.LookupTask(h.Task) != nil {
+				found = true
+				break
+			}
+		}
+		if !found {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("task %q not found in any group", h.Task))
+		}
+	}
+
+	if h.Webhook != "" {
+		u, err := url.Parse(h.Webhook)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("webhook %q must be an http or https URL", h.Webhook))
+		}
+	}
+
+	if h.Timeout < 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("timeout must be non-negative"))
+	}
+	return mErr.ErrorOrNil()
+}
+
 type MultiregionStrategy struct {
 	MaxParallel int
 	OnFailure   string
@@ -4993,6 +5511,16 @@ type Namespace struct {
 	// Meta is the set of metadata key/value pairs that attached to the namespace
 	Meta map[string]string
 
+	// Retention is the retention policy for job versions and deployments in
+	// this namespace. If nil, the server defaults are used.
+	Retention *NamespaceRetention
+
+	// Defaults are merged into the jobs registered in this namespace.
+	Defaults *NamespaceDefaults
+
+	// SecureVariables configures the secure variables of the namespace.
+	SecureVariables *NamespaceSecureVariablesConfig
+
 	// Hash is the hash of the namespace which is used to efficiently replicate
 	// cross-regions.
 	Hash []byte
@@ -5009,6 +5537,186 @@ type NamespaceCapabilities struct {
 	DisabledTaskDrivers []string
 }
 
+// NamespaceRetention is the retention policy for historic objects in a
+// namespace. Zero values leave the corresponding limit unset.
+type NamespaceRetention struct {
+	// JobVersions is the number of historic versions kept per job, in
+	// addition to the current version. It can not exceed JobTrackedVersions.
+	JobVersions int
+
+	// JobVersionMaxAge is how long a historic job version is kept after it
+	// was submitted.
+	JobVersionMaxAge time.Duration
+
+	// Deployments is the number of terminal deployments kept per job.
+	Deployments int
+
+	// DeploymentMaxAge is how long a terminal deployment is kept, and
+	// overrides the server's deployment_gc_threshold for this namespace.
+	DeploymentMaxAge time.Duration
+}
+
+func (r *NamespaceRetention) Copy() *NamespaceRetention {
+	if r == nil {
+		return nil
+	}
+	nr := new(NamespaceRetention)
+	*nr = *r
+	return nr
+}
+
+func (r *NamespaceRetention) Validate() error {
+	var mErr multierror.Error
+	if r.JobVersions < 0 || r.JobVersions > JobTrackedVersions {
+		err := fmt.Errorf("job_versions must be between 0 and %d", JobTrackedVersions)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	if r.JobVersionMaxAge < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("job_version_max_age must be non-negative"))
+	}
+	if r.Deployments < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("deployments must be non-negative"))
+	}
+	if r.DeploymentMaxAge < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("deployment_max_age must be non-negative"))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// NamespaceSecureVariablesConfig configures the secure variables of a
+// namespace.
+type NamespaceSecureVariablesConfig struct {
+	// ReplicationTargets are the regions the secure variables written in
+	// this namespace are replicated to.
+	ReplicationTargets []string
+}
+
+func (c *NamespaceSecureVariablesConfig) Copy() *NamespaceSecureVariablesConfig {
+	if c == nil {
+		return nil
+	}
+	return &NamespaceSecureVariablesConfig{
+		ReplicationTargets: helper.CopySliceString(c.ReplicationTargets),
+	}
+}
+
+func (c *NamespaceSecureVariablesConfig) Validate() error {
+	seen := make(map[string]struct{}, len(c.ReplicationTargets))
+	for _, region := range c.ReplicationTargets {
+		if region == "" {
+			return fmt.Errorf("replication_targets must not contain empty regions")
+		}
+		if _, ok := seen[region]; ok {
+			return fmt.Errorf("replication_targets contains duplicate region %q", region)
+		}
+		seen[region] = struct{}{}
+	}
+	return nil
+}
+
+// ReplicatesTo returns true if the secure variables of the namespace are
+// replicated to the region.
+func (c *NamespaceSecureVariablesConfig) ReplicatesTo(region string) bool {
+	if c == nil {
+		return false
+	}
+	for _, target := range c.ReplicationTargets {
+		if target == region {
+			return true
+		}
+	}
+	return false
+}
+
+// NamespaceDefaults are the defaults of the vault, consul and identity blocks
+// of the jobs in a namespace. They are merged into the jobs when they are
+// registered, and the values set by the jobs take precedence.
+type NamespaceDefaults struct {
+	Vault    *NamespaceVaultDefaults
+	Consul   *NamespaceConsulDefaults
+	Identity *WorkloadIdentity
+}
+
+// NamespaceVaultDefaults are merged into the vault blocks of the tasks. Tasks
+// without a vault block are not given one.
+type NamespaceVaultDefaults struct {
+	// Namespace is the Vault namespace of the tasks which don't set one.
+	Namespace string
+
+	// Policies are the Vault policies of the tasks which don't set any.
+	Policies []string
+}
+
+// NamespaceConsulDefaults are merged into the consul blocks of the groups.
+type NamespaceConsulDefaults struct {
+	// Namespace is the Consul namespace of the groups which don't set one,
+	// either in their consul block or with the job's consul namespace.
+	Namespace string
+}
+
+func (d *NamespaceDefaults) Copy() *NamespaceDefaults {
+	if d == nil {
+		return nil
+	}
+	nd := new(NamespaceDefaults)
+	if d.Vault != nil {
+		nd.Vault = &NamespaceVaultDefaults{
+			Namespace: d.Vault.Namespace,
+			Policies:  helper.CopySliceString(d.Vault.Policies),
+		}
+	}
+	if d.Consul != nil {
+		nd.Consul = &NamespaceConsulDefaults{Namespace: d.Consul.Namespace}
+	}
+	nd.Identity = d.Identity.Copy()
+	return nd
+}
+
+func (d *NamespaceDefaults) Validate() error {
+	if d.Vault != nil {
+		for _, p := range d.Vault.Policies {
+			if p == "root" {
+				return fmt.Errorf("vault: can not specify \"root\" policy")
+			}
+		}
+	}
+	return nil
+}
+
+// MergeInto sets the defaults in the job for the values it doesn't set.
+func (d *NamespaceDefaults) MergeInto(job *Job) {
+	if d == nil {
+		return
+	}
+	for _, tg := range job.TaskGroups {
+		if d.Consul != nil && d.Consul.Namespace != "" {
+			if tg.Consul == nil {
+				tg.Consul = new(Consul)
+			}
+			if tg.Consul.Namespace == "" && job.ConsulNamespace == "" {
+				tg.Consul.Namespace = d.Consul.Namespace
+			}
+		}
+
+		for _, task := range tg.Tasks {
+			// Tasks with an identity block set all of its values
+			if task.Identity == nil {
+				task.Identity = d.Identity.Copy()
+			}
+
+			if d.Vault == nil || task.Vault == nil {
+				continue
+			}
+			if task.Vault.Namespace == "" {
+				task.Vault.Namespace = d.Vault.Namespace
+			}
+			if len(task.Vault.Policies) == 0 {
+				task.Vault.Policies = helper.CopySliceString(d.Vault.Policies)
+			}
+		}
+	}
+}
+
 func (n *Namespace) Validate() error {
 	var mErr multierror.Error
 
@@ -5021,6 +5729,21 @@ func (n *Namespace) Validate() error {
 		err := fmt.Errorf("description longer than %d", maxNamespaceDescriptionLength)
 		mErr.Errors = append(mErr.Errors, err)
 	}
+	if n.Retention != nil {
+		if err := n.Retention.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid retention: %v", err))
+		}
+	}
+	if n.Defaults != nil {
+		if err := n.Defaults.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid defaults: %v", err))
+		}
+	}
+	if n.SecureVariables != nil {
+		if err := n.SecureVariables.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid secure_variables: %v", err))
+		}
+	}
 
 	return mErr.ErrorOrNil()
 }
@@ -5058,6 +5781,34 @@ func (n *Namespace) SetHash() []byte {
 		_, _ = hash.Write([]byte(n.Meta[k]))
 	}
 
+	if r := n.Retention; r != nil {
+		_, _ = hash.Write([]byte(strconv.Itoa(r.JobVersions)))
+		_, _ = hash.Write([]byte(r.JobVersionMaxAge.String()))
+		_, _ = hash.Write([]byte(strconv.Itoa(r.Deployments)))
+		_, _ = hash.Write([]byte(r.DeploymentMaxAge.String()))
+	}
+
+	if d := n.Defaults; d != nil {
+		if d.Vault != nil {
+			_, _ = hash.Write([]byte(d.Vault.Namespace))
+			for _, policy := range d.Vault.Policies {
+				_, _ = hash.Write([]byte(policy))
+			}
+		}
+		if d.Consul != nil {
+			_, _ = hash.Write([]byte(d.Consul.Namespace))
+		}
+		if d.Identity != nil {
+			_, _ = hash.Write([]byte(strconv.FormatBool(d.Identity.Env)))
+		}
+	}
+
+	if sv := n.SecureVariables; sv != nil {
+		for _, region := range sv.ReplicationTargets {
+			_, _ = hash.Write([]byte(region))
+		}
+	}
+
 	// Finalize the hash
 	hashVal := hash.Sum(nil)
 
@@ -5083,6 +5834,9 @@ func (n *Namespace) Copy() *Namespace {
 			nc.Meta[k] = v
 		}
 	}
+	nc.Retention = n.Retention.Copy()
+	nc.Defaults = n.Defaults.Copy()
+	nc.SecureVariables = n.SecureVariables.Copy()
 	copy(nc.Hash, n.Hash)
 	return nc
 }
@@ -5429,6 +6183,59 @@ func (d *TaskLifecycleConfig) Validate() error {
 	return nil
 }
 
+// WorkloadIdentity configures how a task's workload identity is exposed to
+// the task. The identity is always available to the task's templates.
+type WorkloadIdentity struct {
+	// Env injects the workload identity into the task's environment as
+	// NOMAD_TOKEN if set.
+	Env bool
+}
+
+func (wi *WorkloadIdentity) Copy() *WorkloadIdentity {
+	if wi == nil {
+		return nil
+	}
+	nwi := new(WorkloadIdentity)
+	*nwi = *wi
+	return nwi
+}
+
+// DefaultTaskCheckpointTimeout is the default time given to a driver to
+// checkpoint a task. It needs to be in sync with Canonicalize in
+// api/tasks.go.
+const DefaultTaskCheckpointTimeout = 1 * time.Minute
+
+// TaskCheckpoint configures the checkpointing of a task during a planned
+// disconnect of its node, for drivers that support it. The task is restored
+// from its checkpoint if it is no longer running when the client restarts,
+// before the deadline of the planned disconnect.
+type TaskCheckpoint struct {
+	// Timeout is how long the driver is given to checkpoint the task.
+	Timeout time.Duration
+}
+
+func (c *TaskCheckpoint) Copy() *TaskCheckpoint {
+	if c == nil {
+		return nil
+	}
+	nc := new(TaskCheckpoint)
+	*nc = *c
+	return nc
+}
+
+func (c *TaskCheckpoint) Canonicalize() {
+	if c.Timeout == 0 {
+		c.Timeout = DefaultTaskCheckpointTimeout
+	}
+}
+
+func (c *TaskCheckpoint) Validate() error {
+	if c.Timeout < 0 {
+		return fmt.Errorf("timeout must be positive; got %v", c.Timeout)
+	}
+	return nil
+}
+
 var (
 	// These default restart policies needs to be in sync with
 	// Canonicalize in api/tasks.go
@@ -5527,6 +6334,10 @@ type ScalingEvent struct {
 	// EvalID is the ID for an evaluation if one was created as part of a scaling event
 	EvalID *string
 
+	// Initiator is the accessor ID of the ACL token that requested the
+	// scaling action. It is empty when ACLs are disabled.
+	Initiator string
+
 	// Raft index
 	CreateIndex uint64
 }
@@ -5811,6 +6622,37 @@ func NewRestartPolicy(jobType string) *RestartPolicy {
 	return nil
 }
 
+// RestartBudget limits the number of task restarts shared by all the tasks of
+// an allocation within a rolling window.
+type RestartBudget struct {
+	// Attempts is the number of restarts allowed across all tasks in an
+	// interval.
+	Attempts int
+
+	// Interval is the rolling window in which restarts are counted.
+	Interval time.Duration
+}
+
+func (r *RestartBudget) Copy() *RestartBudget {
+	if r == nil {
+		return nil
+	}
+	nrb := new(RestartBudget)
+	*nrb = *r
+	return nrb
+}
+
+func (r *RestartBudget) Validate() error {
+	var mErr multierror.Error
+	if r.Attempts < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Attempts cannot be negative (got %d)", r.Attempts))
+	}
+	if r.Interval < RestartPolicyMinInterval {
+		_ = multierror.Append(&mErr, fmt.Errorf("Interval can not be less than %v (got %v)", RestartPolicyMinInterval, r.Interval))
+	}
+	return mErr.ErrorOrNil()
+}
+
 const ReschedulePolicyMinInterval = 15 * time.Second
 const ReschedulePolicyMinDelay = 5 * time.Second
 
@@ -6107,6 +6949,11 @@ type TaskGroup struct {
 	// RestartPolicy of a TaskGroup
 	RestartPolicy *RestartPolicy
 
+	// RestartBudget, if set, limits the number of restarts of all the tasks
+	// of an allocation of this group in a rolling window. Once it is
+	// exhausted the allocation fails and may be rescheduled.
+	RestartBudget *RestartBudget
+
 	// Tasks are the collection of tasks that this task group needs to run
 	Tasks []*Task
 
@@ -6153,6 +7000,17 @@ type TaskGroup struct {
 	// MaxClientDisconnect, if set, configures the client to allow placed
 	// allocations for tasks in this group to attempt to resume running without a restart.
 	MaxClientDisconnect *time.Duration
+
+	// MaxClientDisconnectJitter, if set, extends the max_client_disconnect of
+	// each allocation by a random amount up to this duration, so that the
+	// replacements for allocations on nodes that disconnect together are not
+	// all placed at once.
+	MaxClientDisconnectJitter *time.Duration
+
+	// MaxClientDisconnectTiers, if set, override MaxClientDisconnect for
+	// allocations placed on nodes matching the tier. The first matching tier
+	// wins and the resolved value is recorded on the allocation.
+	MaxClientDisconnectTiers []*MaxClientDisconnectTier
 }
 
 func (tg *TaskGroup) Copy() *TaskGroup {
@@ -6164,6 +7022,7 @@ func (tg *TaskGroup) Copy() *TaskGroup {
 	ntg.Update = ntg.Update.Copy()
 	ntg.Constraints = CopySliceConstraints(ntg.Constraints)
 	ntg.RestartPolicy = ntg.RestartPolicy.Copy()
+	ntg.RestartBudget = ntg.RestartBudget.Copy()
 	ntg.ReschedulePolicy = ntg.ReschedulePolicy.Copy()
 	ntg.Affinities = CopySliceAffinities(ntg.Affinities)
 	ntg.Spreads = CopySliceSpreads(ntg.Spreads)
@@ -6213,6 +7072,17 @@ func (tg *TaskGroup) Copy() *TaskGroup {
 		ntg.MaxClientDisconnect = tg.MaxClientDisconnect
 	}
 
+	if tg.MaxClientDisconnectJitter != nil {
+		ntg.MaxClientDisconnectJitter = tg.MaxClientDisconnectJitter
+	}
+
+	if tg.MaxClientDisconnectTiers != nil {
+		ntg.MaxClientDisconnectTiers = make([]*MaxClientDisconnectTier, len(tg.MaxClientDisconnectTiers))
+		for i, tier := range tg.MaxClientDisconnectTiers {
+			ntg.MaxClientDisconnectTiers[i] = tier.Copy()
+		}
+	}
+
 	return ntg
 }
 
@@ -6303,6 +7173,26 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		mErr.Errors = append(mErr.Errors, errors.New("max_client_disconnect cannot be negative"))
 	}
 
+	if tg.MaxClientDisconnectJitter != nil {
+		if tg.MaxClientDisconnect == nil {
+			mErr.Errors = append(mErr.Errors, errors.New("max_client_disconnect_jitter requires max_client_disconnect to be set"))
+		}
+		if *tg.MaxClientDisconnectJitter < 0 {
+			mErr.Errors = append(mErr.Errors, errors.New("max_client_disconnect_jitter cannot be negative"))
+		}
+	}
+
+	if len(tg.MaxClientDisconnectTiers) > 0 && tg.MaxClientDisconnect == nil {
+		mErr.Errors = append(mErr.Errors, errors.New("max_client_disconnect_tier requires max_client_disconnect to be set"))
+	}
+
+	for idx, tier := range tg.MaxClientDisconnectTiers {
+		if err := tier.Validate(); err != nil {
+			outer := fmt.Errorf("max_client_disconnect_tier %d validation failed: %s", idx+1, err)
+			mErr.Errors = append(mErr.Errors, outer)
+		}
+	}
+
 	for idx, constr := range tg.Constraints {
 		if err := constr.Validate(); err != nil {
 			outer := fmt.Errorf("Constraint %d validation failed: %s", idx+1, err)
@@ -6330,6 +7220,13 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("Task Group %v should have a restart policy", tg.Name))
 	}
 
+	if tg.RestartBudget != nil {
+		if err := tg.RestartBudget.Validate(); err != nil {
+			outer := fmt.Errorf("Restart budget validation failed: %s", err)
+			mErr.Errors = append(mErr.Errors, outer)
+		}
+	}
+
 	if j.Type == JobTypeSystem {
 		if tg.Spreads != nil {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("System jobs may not have a spread stanza"))
@@ -6826,6 +7723,43 @@ func (tg *TaskGroup) GoString() string {
 	return fmt.Sprintf("*%#v", *tg)
 }
 
+// MaxClientDisconnectTier overrides a task group's MaxClientDisconnect for
+// allocations placed on nodes where Attribute resolves to Value. Attribute
+// supports the same node interpolations as constraints, such as
+// ${node.class} or ${meta.rack}.
+type MaxClientDisconnectTier struct {
+	Attribute string
+	Value     string
+	Duration  time.Duration
+}
+
+func (t *MaxClientDisconnectTier) Copy() *MaxClientDisconnectTier {
+	if t == nil {
+		return nil
+	}
+	nt := new(MaxClientDisconnectTier)
+	*nt = *t
+	return nt
+}
+
+func (t *MaxClientDisconnectTier) Validate() error {
+	var mErr multierror.Error
+	if !strings.HasPrefix(t.Attribute, "${") || !strings.HasSuffix(t.Attribute, "}") {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Attribute %q must be a node interpolation", t.Attribute))
+	}
+	if t.Value == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("Missing value"))
+	}
+	if t.Duration < 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("Duration cannot be negative"))
+	}
+	return mErr.ErrorOrNil()
+}
+
+func (t *MaxClientDisconnectTier) String() string {
+	return fmt.Sprintf("%s = %s => %s", t.Attribute, t.Value, t.Duration)
+}
+
 // CheckRestart describes if and when a task should be restarted based on
 // failing health checks.
 type CheckRestart struct {
@@ -6965,6 +7899,10 @@ type Task struct {
 	// have access to.
 	Vault *Vault
 
+	// Identity configures how the task's workload identity is exposed to
+	// the task.
+	Identity *WorkloadIdentity
+
 	// Templates are the set of templates to be rendered for the task.
 	Templates []*Template
 
@@ -6987,6 +7925,10 @@ type Task struct {
 
 	Lifecycle *TaskLifecycleConfig
 
+	// Checkpoint configures the checkpointing of the task during a planned
+	// disconnect of its node.
+	Checkpoint *TaskCheckpoint
+
 	// Meta is used to associate arbitrary metadata with this
 	// task. This is opaque to Nomad.
 	Meta map[string]string
@@ -7087,6 +8029,8 @@ func (t *Task) Copy() *Task {
 	nt.Meta = helper.CopyMapStringString(nt.Meta)
 	nt.DispatchPayload = nt.DispatchPayload.Copy()
 	nt.Lifecycle = nt.Lifecycle.Copy()
+	nt.Checkpoint = nt.Checkpoint.Copy()
+	nt.Identity = nt.Identity.Copy()
 
 	if t.Artifacts != nil {
 		artifacts := make([]*TaskArtifact, 0, len(t.Artifacts))
@@ -7151,6 +8095,10 @@ func (t *Task) Canonicalize(job *Job, tg *TaskGroup) {
 		t.Vault.Canonicalize()
 	}
 
+	if t.Checkpoint != nil {
+		t.Checkpoint.Canonicalize()
+	}
+
 	for _, template := range t.Templates {
 		template.Canonicalize()
 	}
@@ -7280,6 +8228,13 @@ func (t *Task) Validate(ephemeralDisk *EphemeralDisk, jobType string, tgServices
 
 	}
 
+	// Validate the Checkpoint block if there
+	if t.Checkpoint != nil {
+		if err := t.Checkpoint.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Checkpoint validation failed: %v", err))
+		}
+	}
+
 	// Validation for TaskKind field which is used for Consul Connect integration
 	if t.Kind.IsConnectProxy() {
 		// This task is a Connect proxy so it should not have service stanzas
@@ -7638,10 +8593,24 @@ const (
 	TemplateChangeModeScript = "script"
 )
 
+const (
+	// TemplateStaleModeFail marks that the task should be failed if the data
+	// of the template exceeds its staleness budget
+	TemplateStaleModeFail = "fail"
+
+	// TemplateStaleModeSignal marks that the task should be signaled if the
+	// data of the template exceeds its staleness budget
+	TemplateStaleModeSignal = "signal"
+)
+
 var (
 	// TemplateChangeModeInvalidError is the error for when an invalid change
 	// mode is given
 	TemplateChangeModeInvalidError = errors.New("Invalid change mode. Must be one of the following: noop, signal, script, restart")
+
+	// TemplateStaleModeInvalidError is the error for when an invalid stale
+	// mode is given
+	TemplateStaleModeInvalidError = errors.New("Invalid stale mode. Must be one of the following: fail, signal")
 )
 
 // ChangeScript holds the configuration for the script that is executed if
@@ -7717,6 +8686,20 @@ type Template struct {
 
 	// WaitConfig is used to override the global WaitConfig on a per-template basis
 	Wait *WaitConfig
+
+	// MaxStale is the staleness budget of the data rendered in the template.
+	// If the template hasn't been rendered with fresh data from the servers
+	// for longer than MaxStale, for example because the client is
+	// disconnected, the StaleMode action is taken. Zero disables the budget.
+	MaxStale time.Duration
+
+	// StaleMode indicates what should be done if the rendered data exceeds
+	// the staleness budget.
+	StaleMode string
+
+	// StaleSignal is the signal that should be sent if the stale mode
+	// requires it.
+	StaleSignal string
 }
 
 // DefaultTemplate returns a default template.
@@ -7746,6 +8729,9 @@ func (t *Template) Canonicalize() {
 	if t.ChangeSignal != "" {
 		t.ChangeSignal = strings.ToUpper(t.ChangeSignal)
 	}
+	if t.StaleSignal != "" {
+		t.StaleSignal = strings.ToUpper(t.StaleSignal)
+	}
 }
 
 func (t *Template) Validate() error {
@@ -7792,6 +8778,23 @@ func (t *Template) Validate() error {
 		_ = multierror.Append(&mErr, fmt.Errorf("Must specify positive splay value"))
 	}
 
+	// Verify the staleness budget
+	if t.MaxStale < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Must specify positive max_stale value"))
+	}
+	switch t.StaleMode {
+	case "", TemplateStaleModeFail:
+	case TemplateStaleModeSignal:
+		if t.MaxStale > 0 && t.StaleSignal == "" {
+			_ = multierror.Append(&mErr, fmt.Errorf("Must specify stale signal value when stale mode is signal"))
+		}
+		if t.MaxStale > 0 && t.Envvars {
+			_ = multierror.Append(&mErr, fmt.Errorf("cannot use stale signals with env var templates"))
+		}
+	default:
+		_ = multierror.Append(&mErr, TemplateStaleModeInvalidError)
+	}
+
 	// Verify the permissions
 	if t.Perms != "" {
 		if _, err := strconv.ParseUint(t.Perms, 8, 12); err != nil {
@@ -8104,6 +9107,18 @@ const (
 
 	// TaskClientReconnected indicates that the client running the task disconnected.
 	TaskClientReconnected = "Reconnected"
+
+	// TaskCheckpointed indicates that the task was checkpointed on a
+	// planned disconnect of its node.
+	TaskCheckpointed = "Checkpointed"
+
+	// TaskCheckpointFailed indicates that the task could not be checkpointed
+	// on a planned disconnect of its node.
+	TaskCheckpointFailed = "Checkpoint Failed"
+
+	// TaskRestoredFromCheckpoint indicates that the task was started from its
+	// checkpoint rather than from scratch.
+	TaskRestoredFromCheckpoint = "Restored From Checkpoint"
 )
 
 // TaskEvent is an event that effects the state of a task and contains meta-data
@@ -9148,6 +10163,7 @@ const (
 	DeploymentStatusDescriptionRunning               = "Deployment is running"
 	DeploymentStatusDescriptionRunningNeedsPromotion = "Deployment is running but requires manual promotion"
 	DeploymentStatusDescriptionRunningAutoPromotion  = "Deployment is running pending automatic promotion"
+	DeploymentStatusDescriptionRunningDisconnected   = "Deployment is running but waiting for disconnected clients"
 	DeploymentStatusDescriptionPaused                = "Deployment is paused"
 	DeploymentStatusDescriptionSuccessful            = "Deployment completed successfully"
 	DeploymentStatusDescriptionStoppedJob            = "Cancelled because job is stopped"
@@ -9386,6 +10402,11 @@ type DeploymentState struct {
 
 	// UnhealthyAllocs are allocations that have been marked as unhealthy.
 	UnhealthyAllocs int
+
+	// UnknownAllocs is the number of allocations that are on disconnected
+	// clients. These allocations can't report their health, so progress
+	// accounting for the group is paused until their clients reconnect.
+	UnknownAllocs int
 }
 
 func (d *DeploymentState) GoString() string {
@@ -9396,6 +10417,7 @@ func (d *DeploymentState) GoString() string {
 	base += fmt.Sprintf("\n\tPlaced: %d", d.PlacedAllocs)
 	base += fmt.Sprintf("\n\tHealthy: %d", d.HealthyAllocs)
 	base += fmt.Sprintf("\n\tUnhealthy: %d", d.UnhealthyAllocs)
+	base += fmt.Sprintf("\n\tUnknown: %d", d.UnknownAllocs)
 	base += fmt.Sprintf("\n\tAutoRevert: %v", d.AutoRevert)
 	base += fmt.Sprintf("\n\tAutoPromote: %v", d.AutoPromote)
 	return base
@@ -9494,6 +10516,14 @@ type DesiredTransition struct {
 	// task shutdown_delay configuration and ignore the delay for any
 	// allocations stopped as a result of this Deregister call.
 	NoShutdownDelay *bool
+
+	// Reconnect is an operator's decision on an unknown allocation. If true,
+	// the allocation is reinstated as soon as its node reconnects, without
+	// waiting for the reconnect stabilization period, and is kept over its
+	// replacement. If false, the allocation is marked lost and replaced now,
+	// or stopped if its node already reconnected. It is reset when the
+	// allocation's node disconnects again.
+	Reconnect *bool
 }
 
 // Merge merges the two desired transitions, preferring the values from the
@@ -9514,6 +10544,10 @@ func (d *DesiredTransition) Merge(o *DesiredTransition) {
 	if o.NoShutdownDelay != nil {
 		d.NoShutdownDelay = o.NoShutdownDelay
 	}
+
+	if o.Reconnect != nil {
+		d.Reconnect = o.Reconnect
+	}
 }
 
 // ShouldMigrate returns whether the transition object dictates a migration.
@@ -9545,12 +10579,35 @@ func (d *DesiredTransition) ShouldIgnoreShutdownDelay() bool {
 	return d.NoShutdownDelay != nil && *d.NoShutdownDelay
 }
 
+// ShouldAcceptReconnect returns whether an operator accepted the unknown
+// allocation back.
+func (d *DesiredTransition) ShouldAcceptReconnect() bool {
+	if d == nil {
+		return false
+	}
+	return d.Reconnect != nil && *d.Reconnect
+}
+
+// ShouldRejectReconnect returns whether an operator rejected the unknown
+// allocation.
+func (d *DesiredTransition) ShouldRejectReconnect() bool {
+	if d == nil {
+		return false
+	}
+	return d.Reconnect != nil && !*d.Reconnect
+}
+
 const (
 	AllocDesiredStatusRun   = "run"   // Allocation should run
 	AllocDesiredStatusStop  = "stop"  // Allocation should stop
 	AllocDesiredStatusEvict = "evict" // Allocation should stop, and was evicted
 )
 
+// AllocDesiredDescriptionReconnected is the desired description of the
+// replacement allocations stopped by the scheduler because the client of the
+// allocation they replaced reconnected.
+const AllocDesiredDescriptionReconnected = "alloc not needed due to disconnected client reconnect"
+
 const (
 	AllocClientStatusPending  = "pending"
 	AllocClientStatusRunning  = "running"
@@ -9671,6 +10728,24 @@ type Allocation struct {
 	// to stop running because it got preempted
 	PreemptedByAllocation string
 
+	// MaxClientDisconnect is the task group's max_client_disconnect resolved
+	// against the node this allocation was placed on. If set, it takes
+	// precedence over the task group's value.
+	MaxClientDisconnect *time.Duration
+
+	// PlannedDisconnectDeadline is the deadline of the planned disconnect of
+	// the node this allocation runs on, if one was planned while the
+	// allocation was running. It applies to a disconnect that starts before
+	// the deadline, regardless of the task group's max_client_disconnect.
+	PlannedDisconnectDeadline time.Time
+
+	// MemoryOversubscribed is set when the allocation replaces one on a
+	// disconnected client and was placed beyond its node's memory capacity,
+	// within the node's memory oversubscription ratio. It is stopped to
+	// reclaim that memory once the allocation it replaced reconnects, or
+	// migrated once that allocation is lost.
+	MemoryOversubscribed bool
+
 	// SignedIdentities is a map of task names to signed
 	// identity/capability claim tokens for those tasks. If needed, it
 	// is populated in the plan applier
@@ -10036,9 +11111,11 @@ func (a *Allocation) DisconnectTimeout(now time.Time) time.Time {
 		return now
 	}
 
-	tg := a.Job.LookupTaskGroup(a.TaskGroup)
+	if deadline := a.plannedDisconnectDeadline(now); !deadline.IsZero() {
+		return deadline
+	}
 
-	timeout := tg.MaxClientDisconnect
+	timeout := a.maxClientDisconnect()
 
 	if timeout == nil {
 		return now
@@ -10047,6 +11124,59 @@ func (a *Allocation) DisconnectTimeout(now time.Time) time.Time {
 	return now.Add(*timeout)
 }
 
+// maxClientDisconnect returns the max_client_disconnect value that applies to
+// the allocation, preferring the value resolved for its node at placement
+// time over the task group's default. Any jitter configured on the task group
+// is added to it.
+func (a *Allocation) maxClientDisconnect() *time.Duration {
+	tg := a.Job.LookupTaskGroup(a.TaskGroup)
+	if tg == nil || tg.MaxClientDisconnect == nil {
+		return nil
+	}
+	timeout := *tg.MaxClientDisconnect
+	if a.MaxClientDisconnect != nil {
+		timeout = *a.MaxClientDisconnect
+	}
+	if tg.MaxClientDisconnectJitter != nil {
+		timeout += a.disconnectJitter(*tg.MaxClientDisconnectJitter)
+	}
+	return &timeout
+}
+
+// plannedDisconnectDeadline returns the deadline of the planned disconnect
+// that covers the allocation's current disconnect, or the one that would
+// start now if the allocation isn't disconnected. It returns a zero time if no
+// planned disconnect applies.
+func (a *Allocation) plannedDisconnectDeadline(now time.Time) time.Time {
+	deadline := a.PlannedDisconnectDeadline
+	if deadline.IsZero() {
+		return deadline
+	}
+
+	start := now.UTC()
+	if a.ClientStatus == AllocClientStatusUnknown {
+		if lastUnknown := a.LastUnknown(); !lastUnknown.IsZero() {
+			start = lastUnknown
+		}
+	}
+	if !start.Before(deadline) {
+		return time.Time{}
+	}
+	return deadline
+}
+
+// disconnectJitter returns a duration in [0, max) derived from the
+// allocation ID. The jitter must be stable across evaluations, so that the
+// timeout of an allocation doesn't move each time it is computed.
+func (a *Allocation) disconnectJitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write([]byte(a.ID))
+	return time.Duration(h.Sum64() % uint64(max))
+}
+
 // SupportsDisconnectedClients determines whether both the server and the task group
 // are configured to allow the allocation to reconnect after network connectivity
 // has been lost and then restored.
@@ -10055,6 +11185,12 @@ func (a *Allocation) SupportsDisconnectedClients(serverSupportsDisconnectedClien
 		return false
 	}
 
+	// Allocations on a node with a planned disconnect go through the
+	// reconnect logic even if the task group doesn't allow disconnects.
+	if !a.PlannedDisconnectDeadline.IsZero() {
+		return true
+	}
+
 	if a.Job != nil {
 		tg := a.Job.LookupTaskGroup(a.TaskGroup)
 		if tg != nil {
@@ -10224,8 +11360,9 @@ func (a *Allocation) ComparableResources() *ComparableResources {
 				CpuShares: int64(resources.CPU),
 			},
 			Memory: AllocatedMemoryResources{
-				MemoryMB:    int64(resources.MemoryMB),
-				MemoryMaxMB: int64(resources.MemoryMaxMB),
+				MemoryMB:     int64(resources.MemoryMB),
+				MemoryMaxMB:  int64(resources.MemoryMaxMB),
+				HugePages2MB: int64(resources.HugePages2MB),
 			},
 			Networks: resources.Networks,
 		},
@@ -10317,19 +11454,37 @@ func (a *Allocation) Expired(now time.Time) bool {
 		return false
 	}
 
-	tg := a.Job.LookupTaskGroup(a.TaskGroup)
-	if tg == nil {
-		return false
+	if deadline := a.plannedDisconnectDeadline(now); !deadline.IsZero() {
+		return !now.UTC().Before(deadline)
 	}
 
-	if tg.MaxClientDisconnect == nil {
-		return false
+	timeout := a.maxClientDisconnect()
+	if timeout == nil {
+		// A disconnect outside of the planned window of an allocation which
+		// doesn't otherwise support disconnects expires immediately.
+		return !a.PlannedDisconnectDeadline.IsZero()
 	}
 
-	expiry := lastUnknown.Add(*tg.MaxClientDisconnect)
+	expiry := lastUnknown.Add(*timeout)
 	return now.UTC().After(expiry) || now.UTC().Equal(expiry)
 }
 
+// ClientStatusTime returns the time the allocation entered its current client
+// status. Only some transitions are recorded in AllocStates, such as to lost
+// or unknown, so it falls back to the last time the allocation was modified.
+func (a *Allocation) ClientStatusTime() time.Time {
+	var t time.Time
+	for _, s := range a.AllocStates {
+		if s.Field == AllocStateFieldClientStatus && s.Value == a.ClientStatus && s.Time.After(t) {
+			t = s.Time
+		}
+	}
+	if t.IsZero() {
+		return time.Unix(0, a.ModifyTime).UTC()
+	}
+	return t.UTC()
+}
+
 // LastUnknown returns the timestamp for the last time the allocation
 // transitioned into the unknown client status.
 func (a *Allocation) LastUnknown() time.Time {
@@ -10399,6 +11554,11 @@ func (a *Allocation) ToTaskIdentityClaims(job *Job, taskName string) *IdentityCl
 	return claims
 }
 
+// IdentityScopeSecureVariablesRead is the scope of identity claims issued in
+// exchange for a workload identity. Claims with this scope only grant read
+// access to the workload's own secure variable paths.
+const IdentityScopeSecureVariablesRead = "secure-variables:read"
+
 // IdentityClaims are the input to a JWT identifying a workload. It
 // should never be serialized to msgpack unsigned.
 type IdentityClaims struct {
@@ -10407,9 +11567,25 @@ type IdentityClaims struct {
 	AllocationID string `json:"nomad_allocation_id"`
 	TaskName     string `json:"nomad_task"`
 
+	// Scope restricts what the claims can be used for. It is empty for the
+	// workload identities signed by the plan applier.
+	Scope string `json:"nomad_scope,omitempty"`
+
 	jwt.RegisteredClaims
 }
 
+// SecureVariablePaths returns the secure variable path prefixes that the
+// workload can access without any ACL policy, for an allocation of the
+// given task group.
+func (c *IdentityClaims) SecureVariablePaths(taskGroup string) []string {
+	base := "nomad/jobs/" + c.JobID
+	return []string{
+		base,
+		base + "/" + taskGroup,
+		base + "/" + taskGroup + "/" + c.TaskName,
+	}
+}
+
 // AllocationDiff is another named type for Allocation (to use the same fields),
 // which is used to represent the delta for an Allocation. If you need a method
 // defined on the al
@@ -10439,12 +11615,31 @@ type AllocListStub struct {
 	RescheduleTracker     *RescheduleTracker
 	PreemptedAllocations  []string
 	PreemptedByAllocation string
+	RescheduleChain       *AllocRescheduleChain `json:",omitempty"`
 	CreateIndex           uint64
 	ModifyIndex           uint64
 	CreateTime            int64
 	ModifyTime            int64
 }
 
+// MaxAllocRescheduleChainLength is the maximum number of ancestors and of
+// descendants listed in an AllocRescheduleChain.
+const MaxAllocRescheduleChainLength = 64
+
+// AllocRescheduleChain is the lineage of an allocation across the
+// allocations that replaced each other, whether they were rescheduled,
+// migrated or replaced after their node was lost or disconnected. Allocations
+// that were garbage collected end the chain.
+type AllocRescheduleChain struct {
+	// Ancestors are the IDs of the allocations this allocation replaced,
+	// oldest first.
+	Ancestors []string
+
+	// Descendants are the IDs of the allocations that replaced this
+	// allocation, oldest first.
+	Descendants []string
+}
+
 // SetEventDisplayMessages populates the display message if its not already
 // set, a temporary fix to handle old allocations that don't have it. This
 // method will be removed in a future release.
@@ -10468,6 +11663,9 @@ type AllocStubFields struct {
 	// TaskStates removes the TaskStates field if false (default is to
 	// include TaskStates).
 	TaskStates bool
+
+	// RescheduleChain includes the RescheduleChain field if true.
+	RescheduleChain bool
 }
 
 func NewAllocStubFields() *AllocStubFields {
@@ -10835,6 +12033,7 @@ const (
 	EvalTriggerScaling              = "job-scaling"
 	EvalTriggerMaxDisconnectTimeout = "max-disconnect-timeout"
 	EvalTriggerReconnect            = "reconnect"
+	EvalTriggerReconnectPreemption  = "reconnect-preemption"
 )
 
 const (
@@ -10993,6 +12192,11 @@ type Evaluation struct {
 	// evaluation was processed. The map is keyed by Task Group names.
 	QueuedAllocations map[string]int
 
+	// Explanation explains the decisions the scheduler made about the
+	// allocations of disconnected and reconnected clients while processing
+	// the evaluation. It is nil if there were no such allocations.
+	Explanation *EvalExplanation
+
 	// LeaderACL provides the ACL token to when issuing RPCs back to the
 	// leader. This will be a valid management token as long as the leader is
 	// active. This should not ever be exposed via the API.
@@ -11153,9 +12357,112 @@ func (e *Evaluation) Copy() *Evaluation {
 		ne.QueuedAllocations = queuedAllocations
 	}
 
+	ne.Explanation = e.Explanation.Copy()
+
+	return ne
+}
+
+const (
+	// AllocDecisionMarkUnknown marks the allocation of a client that just
+	// disconnected as unknown until it reconnects or its disconnect timeout
+	// passes.
+	AllocDecisionMarkUnknown = "mark-unknown"
+
+	// AllocDecisionWait leaves the unknown allocation of a client that is
+	// still disconnected alone until its disconnect timeout passes.
+	AllocDecisionWait = "wait"
+
+	// AllocDecisionReplace considers the allocation lost and replaces it.
+	AllocDecisionReplace = "replace"
+
+	// AllocDecisionStabilize leaves the allocation of a client that just
+	// reconnected alone until the client has stayed connected for the
+	// reconnect stabilization period.
+	AllocDecisionStabilize = "stabilize"
+
+	// AllocDecisionReconnect keeps the allocation of a client that
+	// reconnected, stopping its replacement if there is one.
+	AllocDecisionReconnect = "reconnect"
+
+	// AllocDecisionStop stops the allocation of a client that reconnected,
+	// keeping its replacement if there is one.
+	AllocDecisionStop = "stop"
+)
+
+// EvalExplanation explains the decisions the scheduler made about the
+// allocations of disconnected and reconnected clients while processing an
+// evaluation.
+type EvalExplanation struct {
+	// Allocations explains the decision made about each allocation of a
+	// disconnected or reconnected client, ordered by task group and
+	// allocation name.
+	Allocations []*AllocExplanation
+
+	// DisconnectStrategy is the disconnect strategy of the job at the time
+	// of the evaluation, if any.
+	DisconnectStrategy *DisconnectStrategy
+
+	// ReconnectStabilization is the reconnect stabilization period of the
+	// scheduler configuration at the time of the evaluation.
+	ReconnectStabilization time.Duration
+}
+
+func (e *EvalExplanation) Copy() *EvalExplanation {
+	if e == nil {
+		return nil
+	}
+	ne := new(EvalExplanation)
+	*ne = *e
+	if e.Allocations != nil {
+		ne.Allocations = make([]*AllocExplanation, len(e.Allocations))
+		for i, alloc := range e.Allocations {
+			ne.Allocations[i] = alloc.Copy()
+		}
+	}
+	ne.DisconnectStrategy = e.DisconnectStrategy.Copy()
 	return ne
 }
 
+// AllocExplanation explains the decision the scheduler made about an
+// allocation of a disconnected or reconnected client.
+type AllocExplanation struct {
+	AllocID      string
+	AllocName    string
+	TaskGroup    string
+	NodeID       string
+	NodeStatus   string
+	ClientStatus string
+
+	// Decision is one of the AllocDecision constants, and Reason describes
+	// why it was made.
+	Decision string
+	Reason   string
+
+	// DisconnectTimeout is when the allocation is considered lost if its
+	// client doesn't reconnect, if it applies.
+	DisconnectTimeout time.Time
+
+	// StabilizeUntil is when the reconnect stabilization period of the
+	// client ends, if it applies.
+	StabilizeUntil time.Time
+
+	// ReplacementID is the replacement the allocation was compared with when
+	// its client reconnected, and Score and ReplacementScore are their
+	// normalized placement scores.
+	ReplacementID    string
+	Score            float64
+	ReplacementScore float64
+}
+
+func (a *AllocExplanation) Copy() *AllocExplanation {
+	if a == nil {
+		return nil
+	}
+	na := new(AllocExplanation)
+	*na = *a
+	return na
+}
+
 // ShouldEnqueue checks if a given evaluation should be enqueued into the
 // eval_broker
 func (e *Evaluation) ShouldEnqueue() bool {
@@ -11617,6 +12924,14 @@ type DesiredUpdates struct {
 	DestructiveUpdate uint64
 	Canary            uint64
 	Preemptions       uint64
+
+	// Datacenters is the number of placements made in each datacenter.
+	Datacenters map[string]uint64
+
+	// ReplaceUnknown is the number of allocations on disconnected clients
+	// that are replaced instead of waiting for their clients to reconnect,
+	// because they exceed the job's disconnect max_unknown.
+	ReplaceUnknown uint64
 }
 
 func (d *DesiredUpdates) GoString() string {
@@ -12108,6 +13423,54 @@ type ResolveACLTokenResponse struct {
 	QueryMeta
 }
 
+// ACLCheckMaxChecks is the maximum number of checks accepted by a single
+// ACLCheckRequest.
+const ACLCheckMaxChecks = 1000
+
+// ACLCheck is a capability to test against the token of an ACLCheckRequest.
+type ACLCheck struct {
+	// Resource is the name of the ACL policy block to check, such as
+	// "namespace", "secure_variables" or "node".
+	Resource string
+
+	// Namespace is used by namespace and secure variable checks. It
+	// defaults to the namespace of the request.
+	Namespace string `json:",omitempty"`
+
+	// Name is the secure variable path or host volume name to check.
+	Name string `json:",omitempty"`
+
+	// Capability is the capability to check, or the policy disposition
+	// ("read", "write" or "list") for resources without capabilities.
+	Capability string
+}
+
+// ACLCheckResult is the result of a single ACLCheck.
+type ACLCheckResult struct {
+	ACLCheck
+
+	// Allowed is true if the token has the capability.
+	Allowed bool
+
+	// Error is set if the check was invalid, in which case Allowed is
+	// always false.
+	Error string `json:",omitempty"`
+}
+
+// ACLCheckRequest is used to test a batch of capabilities for the token of
+// the request.
+type ACLCheckRequest struct {
+	Checks []*ACLCheck
+	QueryOptions
+}
+
+// ACLCheckResponse holds the results of an ACLCheckRequest, in the same
+// order as the checks.
+type ACLCheckResponse struct {
+	Results []*ACLCheckResult
+	QueryMeta
+}
+
 // ACLTokenDeleteRequest is used to delete a set of tokens
 type ACLTokenDeleteRequest struct {
 	AccessorIDs []string
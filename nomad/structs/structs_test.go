@@ -97,6 +97,28 @@ func TestJob_Validate(t *testing.T) {
 	}
 	err = j.Validate()
 	require.Error(t, err, "datacenter must be non-empty string")
+
+	// test for invalid datacenter weights
+	j = &Job{
+		Datacenters: []string{"dc1:abc", "dc2:200"},
+	}
+	err = j.Validate()
+	requireErrors(t, err,
+		`invalid weight "abc" for datacenter "dc1"`,
+		`weight for datacenter "dc2" must be between 1 and 100`,
+	)
+}
+
+func TestJob_DatacenterWeights(t *testing.T) {
+	ci.Parallel(t)
+
+	j := &Job{Datacenters: []string{"dc1"}}
+	require.Equal(t, []string{"dc1"}, j.DatacenterNames())
+	require.Nil(t, j.DatacenterWeights())
+
+	j = &Job{Datacenters: []string{"dc1:100", "dc2:10", "dc3"}}
+	require.Equal(t, []string{"dc1", "dc2", "dc3"}, j.DatacenterNames())
+	require.Equal(t, map[string]int{"dc1": 100, "dc2": 10}, j.DatacenterWeights())
 }
 
 func TestJob_ValidateScaling(t *testing.T) {
@@ -171,6 +193,71 @@ func TestJob_ValidateNullChar(t *testing.T) {
 	assert.Error(job.Validate(), "null character in task name should not validate")
 }
 
+func TestJob_ValidateDisconnectHooks(t *testing.T) {
+	ci.Parallel(t)
+
+	job := testJob()
+	taskName := job.TaskGroups[0].Tasks[0].Name
+	job.OnDisconnect = []*DisconnectHook{
+		{Signal: "SIGUSR1"},
+		{Webhook: "https://127.0.0.1:8080/degraded", Timeout: time.Second},
+	}
+	job.OnReconnect = []*DisconnectHook{
+		{Task: taskName, Command: "/bin/resume"},
+	}
+	require.NoError(t, job.Validate())
+
+	cases := []struct {
+		name   string
+		hook   *DisconnectHook
+		expErr string
+	}{
+		{
+			name:   "no action",
+			hook:   &DisconnectHook{Task: taskName},
+			expErr: "exactly one of signal, command, or webhook must be set",
+		},
+		{
+			name:   "many actions",
+			hook:   &DisconnectHook{Task: taskName, Signal: "SIGUSR1", Command: "/bin/degrade"},
+			expErr: "exactly one of signal, command, or webhook must be set",
+		},
+		{
+			name:   "command without task",
+			hook:   &DisconnectHook{Command: "/bin/degrade"},
+			expErr: "command requires a task",
+		},
+		{
+			name:   "unknown task",
+			hook:   &DisconnectHook{Task: "nope", Signal: "SIGUSR1"},
+			expErr: `task "nope" not found in any group`,
+		},
+		{
+			name:   "bad webhook",
+			hook:   &DisconnectHook{Webhook: "127.0.0.1:8080/degraded"},
+			expErr: "must be an http or https URL",
+		},
+		{
+			name:   "negative timeout",
+			hook:   &DisconnectHook{Webhook: "http://127.0.0.1", Timeout: -time.Second},
+			expErr: "timeout must be non-negative",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			j := job.Copy()
+			j.OnReconnect = []*DisconnectHook{tc.hook}
+			err := j.Validate()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "on_reconnect 1 validation failed")
+			require.Contains(t, err.Error(), tc.expErr)
+		})
+	}
+
+	// Signals sent by hooks are required by the tasks they are sent to
+	require.Equal(t, []string{"SIGUSR1"}, job.RequiredSignals()[job.TaskGroups[0].Name][taskName])
+}
+
 func TestJob_Warnings(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1604,6 +1691,29 @@ func TestTask_Validate(t *testing.T) {
 	)
 }
 
+func TestTask_Validate_Checkpoint(t *testing.T) {
+	ci.Parallel(t)
+
+	task := &Task{
+		Name:   "web",
+		Driver: "qemu",
+		Resources: &Resources{
+			CPU:      100,
+			MemoryMB: 100,
+		},
+		LogConfig:  DefaultLogConfig(),
+		Checkpoint: &TaskCheckpoint{},
+	}
+	job := MockJob()
+	task.Canonicalize(job, job.TaskGroups[0])
+	require.Equal(t, DefaultTaskCheckpointTimeout, task.Checkpoint.Timeout)
+	require.NoError(t, task.Validate(DefaultEphemeralDisk(), JobTypeService, nil, nil))
+
+	task.Checkpoint.Timeout = -time.Second
+	err := task.Validate(DefaultEphemeralDisk(), JobTypeService, nil, nil)
+	requireErrors(t, err, "Checkpoint validation failed: timeout must be positive")
+}
+
 func TestTask_Validate_Resources(t *testing.T) {
 	ci.Parallel(t)
 
@@ -2692,6 +2802,45 @@ func TestTemplate_Validate(t *testing.T) {
 				"destination escapes",
 			},
 		},
+		{
+			Tmpl: &Template{
+				MaxStale: -1,
+			},
+			Fail: true,
+			ContainsErrs: []string{
+				"positive max_stale",
+			},
+		},
+		{
+			Tmpl: &Template{
+				StaleMode: "foo",
+			},
+			Fail: true,
+			ContainsErrs: []string{
+				TemplateStaleModeInvalidError.Error(),
+			},
+		},
+		{
+			Tmpl: &Template{
+				MaxStale:  time.Hour,
+				StaleMode: TemplateStaleModeSignal,
+			},
+			Fail: true,
+			ContainsErrs: []string{
+				"specify stale signal value",
+			},
+		},
+		{
+			Tmpl: &Template{
+				SourcePath:  "foo",
+				DestPath:    "local/foo",
+				ChangeMode:  "noop",
+				MaxStale:    time.Hour,
+				StaleMode:   TemplateStaleModeSignal,
+				StaleSignal: "SIGUSR1",
+			},
+			Fail: false,
+		},
 		{
 			Tmpl: &Template{
 				SourcePath: "foo",
@@ -5330,6 +5479,8 @@ func TestAllocation_Expired(t *testing.T) {
 		mixedUTC         bool
 		noReconnectEvent bool
 		status           string
+		resolved         string
+		jitter           string
 	}
 
 	testCases := []testCase{
@@ -5399,6 +5550,27 @@ func TestAllocation_Expired(t *testing.T) {
 			expected:         false,
 			noReconnectEvent: true,
 		},
+		{
+			name:          "resolved-has-not-expired",
+			maxDisconnect: "5s",
+			resolved:      "1h",
+			ellapsed:      10,
+			expected:      false,
+		},
+		{
+			name:          "resolved-has-expired",
+			maxDisconnect: "1h",
+			resolved:      "5s",
+			ellapsed:      10,
+			expected:      true,
+		},
+		{
+			name:          "jitter-has-expired",
+			maxDisconnect: "5s",
+			jitter:        "1s",
+			ellapsed:      10,
+			expected:      true,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -5412,6 +5584,18 @@ func TestAllocation_Expired(t *testing.T) {
 				alloc.Job.TaskGroups[0].MaxClientDisconnect = &maxDisconnect
 			}
 
+			if tc.resolved != "" {
+				resolved, err := time.ParseDuration(tc.resolved)
+				require.NoError(t, err)
+				alloc.MaxClientDisconnect = &resolved
+			}
+
+			if tc.jitter != "" {
+				jitter, err := time.ParseDuration(tc.jitter)
+				require.NoError(t, err)
+				alloc.Job.TaskGroups[0].MaxClientDisconnectJitter = &jitter
+			}
+
 			if tc.nilJob {
 				alloc.Job = nil
 			}
@@ -5456,6 +5640,36 @@ func TestAllocation_Expired(t *testing.T) {
 	}
 }
 
+func TestAllocation_ClientStatusTime(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now().UTC()
+	alloc := &Allocation{
+		ClientStatus: AllocClientStatusRunning,
+		ModifyTime:   now.Add(-time.Minute).UnixNano(),
+	}
+
+	// Without a recorded transition, the modify time is used
+	require.Equal(t, now.Add(-time.Minute).UnixNano(), alloc.ClientStatusTime().UnixNano())
+
+	// The last transition to the current status is used
+	alloc.ClientStatus = AllocClientStatusUnknown
+	alloc.AllocStates = []*AllocState{
+		{Field: AllocStateFieldClientStatus, Value: AllocClientStatusUnknown, Time: now.Add(-time.Hour)},
+		{Field: AllocStateFieldClientStatus, Value: AllocClientStatusRunning, Time: now.Add(-50 * time.Minute)},
+		{Field: AllocStateFieldClientStatus, Value: AllocClientStatusUnknown, Time: now.Add(-40 * time.Minute)},
+	}
+	require.Equal(t, now.Add(-40*time.Minute), alloc.ClientStatusTime())
+
+	req := &AllocListRequest{
+		FilterClientStatus:    AllocClientStatusUnknown,
+		FilterMinTimeInStatus: 30 * time.Minute,
+	}
+	require.False(t, req.ShouldBeFiltered(alloc, now))
+	req.FilterMinTimeInStatus = time.Hour
+	require.True(t, req.ShouldBeFiltered(alloc, now))
+}
+
 func TestAllocation_Reconnected(t *testing.T) {
 	type testCase struct {
 		name             string
@@ -7098,7 +7312,7 @@ func TestComparableResources_Superset(t *testing.T) {
 				CpuShares:     4000,
 				ReservedCores: []uint16{0, 1, 2, 3},
 			},
-			Memory: AllocatedMemoryResources{MemoryMB: 4096},
+			Memory: AllocatedMemoryResources{MemoryMB: 4096, HugePages2MB: 512},
 		},
 		Shared: AllocatedSharedResources{DiskMB: 10000},
 	}
@@ -7141,6 +7355,23 @@ func TestComparableResources_Superset(t *testing.T) {
 			},
 			dimension: "cores",
 		},
+		{
+			a: base,
+			b: &ComparableResources{
+				Flattened: AllocatedTaskResources{
+					Memory: AllocatedMemoryResources{MemoryMB: 1024, HugePages2MB: 512},
+				},
+			},
+		},
+		{
+			a: base,
+			b: &ComparableResources{
+				Flattened: AllocatedTaskResources{
+					Memory: AllocatedMemoryResources{MemoryMB: 1024, HugePages2MB: 513},
+				},
+			},
+			dimension: "hugepages",
+		},
 	}
 
 	for _, c := range cases {
@@ -7172,3 +7403,85 @@ func requireErrors(t *testing.T, err error, expected ...string) {
 
 	require.Equal(t, expected, found)
 }
+
+func TestTaskGroup_Validate_RestartBudget(t *testing.T) {
+	ci.Parallel(t)
+
+	j := testJob()
+	tg := j.TaskGroups[0]
+
+	tg.RestartBudget = &RestartBudget{Attempts: 10, Interval: 30 * time.Minute}
+	require.NoError(t, tg.Validate(j))
+
+	tg.RestartBudget = &RestartBudget{Attempts: -1, Interval: time.Second}
+	err := tg.Validate(j)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Attempts cannot be negative")
+	require.Contains(t, err.Error(), "Interval can not be less than")
+}
+
+func TestAllocation_DisconnectTimeout_Jitter(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	maxDisconnect := 10 * time.Minute
+	jitter := time.Minute
+
+	alloc := MockAlloc()
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	tg.MaxClientDisconnect = &maxDisconnect
+	tg.MaxClientDisconnectJitter = &jitter
+
+	// The jitter is stable for the allocation and within the configured range
+	timeout := alloc.DisconnectTimeout(now)
+	require.Equal(t, timeout, alloc.DisconnectTimeout(now))
+	require.False(t, timeout.Before(now.Add(maxDisconnect)))
+	require.True(t, timeout.Before(now.Add(maxDisconnect+jitter)))
+
+	// Allocations disconnected at the same time don't all time out together
+	timeouts := map[time.Time]struct{}{}
+	for i := 0; i < 10; i++ {
+		other := alloc.Copy()
+		other.ID = uuid.Generate()
+		timeouts[other.DisconnectTimeout(now)] = struct{}{}
+	}
+	require.Greater(t, len(timeouts), 1)
+}
+
+func TestAllocation_PlannedDisconnect(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now().UTC()
+	deadline := now.Add(30 * time.Minute)
+
+	// The task group doesn't allow disconnects
+	alloc := MockAlloc()
+	alloc.Job.LookupTaskGroup(alloc.TaskGroup).MaxClientDisconnect = nil
+	require.False(t, alloc.SupportsDisconnectedClients(true))
+	require.Equal(t, now, alloc.DisconnectTimeout(now))
+
+	// A planned disconnect allows them until its deadline
+	alloc.PlannedDisconnectDeadline = deadline
+	require.True(t, alloc.SupportsDisconnectedClients(true))
+	require.False(t, alloc.SupportsDisconnectedClients(false))
+	require.Equal(t, deadline, alloc.DisconnectTimeout(now))
+
+	alloc.ClientStatus = AllocClientStatusUnknown
+	alloc.AllocStates = []*AllocState{{
+		Field: AllocStateFieldClientStatus,
+		Value: AllocClientStatusUnknown,
+		Time:  now,
+	}}
+	require.False(t, alloc.Expired(now.Add(29*time.Minute)))
+	require.True(t, alloc.Expired(deadline))
+
+	// A disconnect that starts after the deadline isn't covered, and expires
+	// immediately since the task group doesn't allow disconnects
+	alloc.AllocStates[0].Time = deadline.Add(time.Minute)
+	require.True(t, alloc.Expired(deadline.Add(time.Minute)))
+
+	// Unless it does, in which case max_client_disconnect applies
+	maxDisconnect := time.Hour
+	alloc.Job.LookupTaskGroup(alloc.TaskGroup).MaxClientDisconnect = &maxDisconnect
+	require.False(t, alloc.Expired(deadline.Add(2*time.Minute)))
+}
@@ -74,6 +74,7 @@ func (d *driverPluginClient) Capabilities() (*Capabilities, error) {
 
 		caps.MountConfigs = MountConfigSupport(resp.Capabilities.MountConfigs)
 		caps.RemoteTasks = resp.Capabilities.RemoteTasks
+		caps.Checkpoint = resp.Capabilities.Checkpoint
 	}
 
 	return caps, nil
@@ -154,19 +155,7 @@ func (d *driverPluginClient) StartTask(c *TaskConfig) (*TaskHandle, *DriverNetwo
 		return nil, nil, grpcutils.HandleGrpcErr(err, d.doneCtx)
 	}
 
-	var net *DriverNetwork
-	if resp.NetworkOverride != nil {
-		net = &DriverNetwork{
-			PortMap:       map[string]int{},
-			IP:            resp.NetworkOverride.Addr,
-			AutoAdvertise: resp.NetworkOverride.AutoAdvertise,
-		}
-		for k, v := range resp.NetworkOverride.PortMap {
-			net.PortMap[k] = int(v)
-		}
-	}
-
-	return taskHandleFromProto(resp.Handle), net, nil
+	return taskHandleFromProto(resp.Handle), networkOverrideFromProto(resp.NetworkOverride), nil
 }
 
 // WaitTask returns a channel that will have an ExitResult pushed to it once when the task
@@ -497,3 +486,37 @@ func (d *driverPluginClient) DestroyNetwork(allocID string, spec *NetworkIsolati
 
 	return nil
 }
+
+var _ CheckpointDriver = (*driverPluginClient)(nil)
+
+// CheckpointTask saves the state of the running task to the directory. It is
+// only supported if the driver sets the Checkpoint capability.
+func (d *driverPluginClient) CheckpointTask(ctx context.Context, taskID string, dir string) error {
+	req := &proto.CheckpointTaskRequest{
+		TaskId: taskID,
+		Dir:    dir,
+	}
+
+	// Join the passed context and the shutdown context
+	joinedCtx, joinedCtxCancel := joincontext.Join(ctx, d.doneCtx)
+	defer joinedCtxCancel()
+
+	_, err := d.client.CheckpointTask(joinedCtx, req)
+	return grpcutils.HandleReqCtxGrpcErr(err, ctx, d.doneCtx)
+}
+
+// RestoreTask starts the task from the state saved to the directory by
+// CheckpointTask.
+func (d *driverPluginClient) RestoreTask(c *TaskConfig, dir string) (*TaskHandle, *DriverNetwork, error) {
+	req := &proto.RestoreTaskRequest{
+		Task: taskConfigToProto(c),
+		Dir:  dir,
+	}
+
+	resp, err := d.client.RestoreTask(d.doneCtx, req)
+	if err != nil {
+		return nil, nil, grpcutils.HandleGrpcErr(err, d.doneCtx)
+	}
+
+	return taskHandleFromProto(resp.Handle), networkOverrideFromProto(resp.NetworkOverride), nil
+}
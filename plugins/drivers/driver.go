@@ -70,6 +70,20 @@ type ExecTaskStreamingDriver interface {
 	ExecTaskStreaming(ctx context.Context, taskID string, execOptions *ExecOptions) (*ExitResult, error)
 }
 
+// CheckpointDriver marks that a driver can checkpoint running tasks before a
+// planned disconnect of the node, and restore them from their checkpoint if
+// they didn't survive it.
+type CheckpointDriver interface {
+	// CheckpointTask saves the state of the running task to the directory.
+	// The saved state must be consistent, including the task's filesystem,
+	// and the task keeps running once it is checkpointed.
+	CheckpointTask(ctx context.Context, taskID string, dir string) error
+
+	// RestoreTask starts the task from the state saved to the directory by
+	// CheckpointTask, rather than from scratch.
+	RestoreTask(cfg *TaskConfig, dir string) (*TaskHandle, *DriverNetwork, error)
+}
+
 type ExecOptions struct {
 	// Command is command to run
 	Command []string
@@ -171,6 +185,9 @@ type Capabilities struct {
 	// adjust behavior such as propogating task handles between allocations
 	// to avoid downtime when a client is lost.
 	RemoteTasks bool
+
+	// Checkpoint indicates this driver implements CheckpointDriver
+	Checkpoint bool
 }
 
 func (c *Capabilities) HasNetIsolationMode(m NetIsolationMode) bool {
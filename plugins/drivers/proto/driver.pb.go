@@ -1848,7 +1848,10 @@ type DriverCapabilities struct {
 	MountConfigs DriverCapabilities_MountConfigs `protobuf:"varint,6,opt,name=mount_configs,json=mountConfigs,proto3,enum=hashicorp.nomad.plugins.drivers.proto.DriverCapabilities_MountConfigs" json:"mount_configs,omitempty"`
 	// remote_tasks indicates whether the driver executes tasks remotely such
 	// on cloud runtimes like AWS ECS.
-	RemoteTasks          bool     `protobuf:"varint,7,opt,name=remote_tasks,json=remoteTasks,proto3" json:"remote_tasks,omitempty"`
+	RemoteTasks bool `protobuf:"varint,7,opt,name=remote_tasks,json=remoteTasks,proto3" json:"remote_tasks,omitempty"`
+	// checkpoint indicates whether the driver implements the CheckpointTask
+	// and RestoreTask RPCs.
+	Checkpoint           bool     `protobuf:"varint,8,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1928,6 +1931,13 @@ func (m *DriverCapabilities) GetRemoteTasks() bool {
 	return false
 }
 
+func (m *DriverCapabilities) GetCheckpoint() bool {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return false
+}
+
 type NetworkIsolationSpec struct {
 	Mode                 NetworkIsolationSpec_NetworkIsolationMode `protobuf:"varint,1,opt,name=mode,proto3,enum=hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec_NetworkIsolationMode" json:"mode,omitempty"`
 	Path                 string                                    `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
@@ -2439,6 +2449,7 @@ func (m *AllocatedCpuResources) GetCpuShares() int64 {
 type AllocatedMemoryResources struct {
 	MemoryMb             int64    `protobuf:"varint,2,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`
 	MemoryMaxMb          int64    `protobuf:"varint,3,opt,name=memory_max_mb,json=memoryMaxMb,proto3" json:"memory_max_mb,omitempty"`
+	Hugepages_2Mb        int64    `protobuf:"varint,4,opt,name=hugepages_2mb,json=hugepages2mb,proto3" json:"hugepages_2mb,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2483,6 +2494,13 @@ func (m *AllocatedMemoryResources) GetMemoryMaxMb() int64 {
 	return 0
 }
 
+func (m *AllocatedMemoryResources) GetHugepages_2Mb() int64 {
+	if m != nil {
+		return m.Hugepages_2Mb
+	}
+	return 0
+}
+
 type NetworkResource struct {
 	Device               string         `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
 	Cidr                 string         `protobuf:"bytes,2,opt,name=cidr,proto3" json:"cidr,omitempty"`
@@ -3599,6 +3617,186 @@ func (m *DriverTaskEvent) GetAnnotations() map[string]string {
 	return nil
 }
 
+type CheckpointTaskRequest struct {
+	// TaskId is the ID of the target task
+	TaskId string `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Dir is the directory the state of the task is saved to
+	Dir                  string   `protobuf:"bytes,2,opt,name=dir,proto3" json:"dir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointTaskRequest) Reset()         { *m = CheckpointTaskRequest{} }
+func (m *CheckpointTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckpointTaskRequest) ProtoMessage()    {}
+func (*CheckpointTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{57}
+}
+
+func (m *CheckpointTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointTaskRequest.Unmarshal(m, b)
+}
+func (m *CheckpointTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckpointTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointTaskRequest.Merge(m, src)
+}
+func (m *CheckpointTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckpointTaskRequest.Size(m)
+}
+func (m *CheckpointTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointTaskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointTaskRequest proto.InternalMessageInfo
+
+func (m *CheckpointTaskRequest) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *CheckpointTaskRequest) GetDir() string {
+	if m != nil {
+		return m.Dir
+	}
+	return ""
+}
+
+type CheckpointTaskResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointTaskResponse) Reset()         { *m = CheckpointTaskResponse{} }
+func (m *CheckpointTaskResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckpointTaskResponse) ProtoMessage()    {}
+func (*CheckpointTaskResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{58}
+}
+
+func (m *CheckpointTaskResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointTaskResponse.Unmarshal(m, b)
+}
+func (m *CheckpointTaskResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointTaskResponse.Marshal(b, m, deterministic)
+}
+func (m *CheckpointTaskResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointTaskResponse.Merge(m, src)
+}
+func (m *CheckpointTaskResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckpointTaskResponse.Size(m)
+}
+func (m *CheckpointTaskResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointTaskResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointTaskResponse proto.InternalMessageInfo
+
+type RestoreTaskRequest struct {
+	// Task configuration to launch
+	Task *TaskConfig `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// Dir is the directory the state of the task was saved to
+	Dir                  string   `protobuf:"bytes,2,opt,name=dir,proto3" json:"dir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreTaskRequest) Reset()         { *m = RestoreTaskRequest{} }
+func (m *RestoreTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreTaskRequest) ProtoMessage()    {}
+func (*RestoreTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{59}
+}
+
+func (m *RestoreTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTaskRequest.Unmarshal(m, b)
+}
+func (m *RestoreTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTaskRequest.Merge(m, src)
+}
+func (m *RestoreTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreTaskRequest.Size(m)
+}
+func (m *RestoreTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTaskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreTaskRequest proto.InternalMessageInfo
+
+func (m *RestoreTaskRequest) GetTask() *TaskConfig {
+	if m != nil {
+		return m.Task
+	}
+	return nil
+}
+
+func (m *RestoreTaskRequest) GetDir() string {
+	if m != nil {
+		return m.Dir
+	}
+	return ""
+}
+
+type RestoreTaskResponse struct {
+	// Handle is opaque to the client, but must be stored in order to recover
+	// the task.
+	Handle *TaskHandle `protobuf:"bytes,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	// NetworkOverride is set if the driver sets network settings and the service ip/port
+	// needs to be set differently.
+	NetworkOverride      *NetworkOverride `protobuf:"bytes,2,opt,name=network_override,json=networkOverride,proto3" json:"network_override,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *RestoreTaskResponse) Reset()         { *m = RestoreTaskResponse{} }
+func (m *RestoreTaskResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreTaskResponse) ProtoMessage()    {}
+func (*RestoreTaskResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{60}
+}
+
+func (m *RestoreTaskResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTaskResponse.Unmarshal(m, b)
+}
+func (m *RestoreTaskResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTaskResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreTaskResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTaskResponse.Merge(m, src)
+}
+func (m *RestoreTaskResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreTaskResponse.Size(m)
+}
+func (m *RestoreTaskResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTaskResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreTaskResponse proto.InternalMessageInfo
+
+func (m *RestoreTaskResponse) GetHandle() *TaskHandle {
+	if m != nil {
+		return m.Handle
+	}
+	return nil
+}
+
+func (m *RestoreTaskResponse) GetNetworkOverride() *NetworkOverride {
+	if m != nil {
+		return m.NetworkOverride
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("hashicorp.nomad.plugins.drivers.proto.TaskState", TaskState_name, TaskState_value)
 	proto.RegisterEnum("hashicorp.nomad.plugins.drivers.proto.FingerprintResponse_HealthState", FingerprintResponse_HealthState_name, FingerprintResponse_HealthState_value)
@@ -3675,6 +3873,10 @@ func init() {
 	proto.RegisterType((*MemoryUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.MemoryUsage")
 	proto.RegisterType((*DriverTaskEvent)(nil), "hashicorp.nomad.plugins.drivers.proto.DriverTaskEvent")
 	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.drivers.proto.DriverTaskEvent.AnnotationsEntry")
+	proto.RegisterType((*CheckpointTaskRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.CheckpointTaskRequest")
+	proto.RegisterType((*CheckpointTaskResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.CheckpointTaskResponse")
+	proto.RegisterType((*RestoreTaskRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.RestoreTaskRequest")
+	proto.RegisterType((*RestoreTaskResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.RestoreTaskResponse")
 }
 
 func init() {
@@ -3682,243 +3884,250 @@ func init() {
 }
 
 var fileDescriptor_4a8f45747846a74d = []byte{
-	// 3776 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0xcd, 0x6f, 0x1b, 0x49,
-	0x76, 0x57, 0xb3, 0xf9, 0xf9, 0x48, 0x51, 0xad, 0xb2, 0x6c, 0xd3, 0x9c, 0x24, 0xe3, 0xed, 0x60,
-	0x02, 0x61, 0x77, 0x86, 0x9e, 0xd5, 0x22, 0xe3, 0xf1, 0xac, 0x67, 0x3d, 0x1c, 0x8a, 0xb6, 0x34,
-	0x96, 0x28, 0xa5, 0x48, 0xc1, 0xeb, 0x38, 0x3b, 0x9d, 0x16, 0xbb, 0x4c, 0xb5, 0xc5, 0xfe, 0x98,
-	0xae, 0xa6, 0x2c, 0x6d, 0x10, 0x24, 0xd8, 0x00, 0xc1, 0x06, 0x48, 0x90, 0x5c, 0x26, 0x7b, 0xc9,
-	0x69, 0x81, 0x9c, 0xf2, 0x0f, 0x04, 0x1b, 0xec, 0x29, 0x87, 0xfc, 0x13, 0xb9, 0xe4, 0x96, 0x63,
-	0x72, 0xca, 0x35, 0xa8, 0x8f, 0x6e, 0x76, 0x93, 0xf4, 0xb8, 0x49, 0xf9, 0xc4, 0x7e, 0xf5, 0xf1,
-	0xab, 0xc7, 0xf7, 0x5e, 0xbd, 0x7a, 0xf5, 0xea, 0x81, 0xee, 0x8f, 0x27, 0x23, 0xdb, 0xa5, 0xf7,
-	0xac, 0xc0, 0xbe, 0x20, 0x01, 0xbd, 0xe7, 0x07, 0x5e, 0xe8, 0x49, 0xaa, 0xc5, 0x09, 0xf4, 0xc1,
-	0x99, 0x49, 0xcf, 0xec, 0xa1, 0x17, 0xf8, 0x2d, 0xd7, 0x73, 0x4c, 0xab, 0x25, 0xe7, 0xb4, 0xe4,
-	0x1c, 0x31, 0xac, 0xf9, 0x7b, 0x23, 0xcf, 0x1b, 0x8d, 0x89, 0x40, 0x38, 0x9d, 0xbc, 0xbc, 0x67,
-	0x4d, 0x02, 0x33, 0xb4, 0x3d, 0x57, 0xf6, 0xbf, 0x3f, 0xdb, 0x1f, 0xda, 0x0e, 0xa1, 0xa1, 0xe9,
-	0xf8, 0x72, 0xc0, 0x07, 0x11, 0x2f, 0xf4, 0xcc, 0x0c, 0x88, 0x75, 0xef, 0x6c, 0x38, 0xa6, 0x3e,
-	0x19, 0xb2, 0x5f, 0x83, 0x7d, 0xc8, 0x61, 0x1f, 0xce, 0x0c, 0xa3, 0x61, 0x30, 0x19, 0x86, 0x11,
-	0xe7, 0x66, 0x18, 0x06, 0xf6, 0xe9, 0x24, 0x24, 0x62, 0xb4, 0x7e, 0x07, 0x6e, 0x0f, 0x4c, 0x7a,
-	0xde, 0xf1, 0xdc, 0x97, 0xf6, 0xa8, 0x3f, 0x3c, 0x23, 0x8e, 0x89, 0xc9, 0x37, 0x13, 0x42, 0x43,
-	0xfd, 0x4f, 0xa0, 0x31, 0xdf, 0x45, 0x7d, 0xcf, 0xa5, 0x04, 0x7d, 0x01, 0x79, 0xb6, 0x64, 0x43,
-	0xb9, 0xab, 0x6c, 0x57, 0x77, 0x3e, 0x6c, 0xbd, 0x49, 0x04, 0x82, 0x87, 0x96, 0x64, 0xb5, 0xd5,
-	0xf7, 0xc9, 0x10, 0xf3, 0x99, 0xfa, 0x4d, 0xb8, 0xd1, 0x31, 0x7d, 0xf3, 0xd4, 0x1e, 0xdb, 0xa1,
-	0x4d, 0x68, 0xb4, 0xe8, 0x04, 0xb6, 0xd2, 0xcd, 0x72, 0xc1, 0x9f, 0x41, 0x6d, 0x98, 0x68, 0x97,
-	0x0b, 0x3f, 0x68, 0x65, 0x92, 0x7d, 0x6b, 0x97, 0x53, 0x29, 0xe0, 0x14, 0x9c, 0xbe, 0x05, 0xe8,
-	0xb1, 0xed, 0x8e, 0x48, 0xe0, 0x07, 0xb6, 0x1b, 0x46, 0xcc, 0xfc, 0x56, 0x85, 0x1b, 0xa9, 0x66,
-	0xc9, 0xcc, 0x2b, 0x80, 0x58, 0x8e, 0x8c, 0x15, 0x75, 0xbb, 0xba, 0xf3, 0x55, 0x46, 0x56, 0x16,
-	0xe0, 0xb5, 0xda, 0x31, 0x58, 0xd7, 0x0d, 0x83, 0x2b, 0x9c, 0x40, 0x47, 0x5f, 0x43, 0xf1, 0x8c,
-	0x98, 0xe3, 0xf0, 0xac, 0x91, 0xbb, 0xab, 0x6c, 0xd7, 0x77, 0x1e, 0x5f, 0x63, 0x9d, 0x3d, 0x0e,
-	0xd4, 0x0f, 0xcd, 0x90, 0x60, 0x89, 0x8a, 0x3e, 0x02, 0x24, 0xbe, 0x0c, 0x8b, 0xd0, 0x61, 0x60,
-	0xfb, 0xcc, 0x24, 0x1b, 0xea, 0x5d, 0x65, 0xbb, 0x82, 0x37, 0x45, 0xcf, 0xee, 0xb4, 0xa3, 0xe9,
-	0xc3, 0xc6, 0x0c, 0xb7, 0x48, 0x03, 0xf5, 0x9c, 0x5c, 0x71, 0x8d, 0x54, 0x30, 0xfb, 0x44, 0x4f,
-	0xa0, 0x70, 0x61, 0x8e, 0x27, 0x84, 0xb3, 0x5c, 0xdd, 0xf9, 0xe1, 0xdb, 0xcc, 0x43, 0x9a, 0xe8,
-	0x54, 0x0e, 0x58, 0xcc, 0xff, 0x2c, 0xf7, 0xa9, 0xa2, 0x3f, 0x80, 0x6a, 0x82, 0x6f, 0x54, 0x07,
-	0x38, 0xe9, 0xed, 0x76, 0x07, 0xdd, 0xce, 0xa0, 0xbb, 0xab, 0xad, 0xa1, 0x75, 0xa8, 0x9c, 0xf4,
-	0xf6, 0xba, 0xed, 0x83, 0xc1, 0xde, 0x73, 0x4d, 0x41, 0x55, 0x28, 0x45, 0x44, 0x4e, 0xbf, 0x04,
-	0x84, 0xc9, 0xd0, 0xbb, 0x20, 0x01, 0x33, 0x64, 0xa9, 0x55, 0x74, 0x1b, 0x4a, 0xa1, 0x49, 0xcf,
-	0x0d, 0xdb, 0x92, 0x3c, 0x17, 0x19, 0xb9, 0x6f, 0xa1, 0x7d, 0x28, 0x9e, 0x99, 0xae, 0x35, 0x7e,
-	0x3b, 0xdf, 0x69, 0x51, 0x33, 0xf0, 0x3d, 0x3e, 0x11, 0x4b, 0x00, 0x66, 0xdd, 0xa9, 0x95, 0x85,
-	0x02, 0xf4, 0xe7, 0xa0, 0xf5, 0x43, 0x33, 0x08, 0x93, 0xec, 0x74, 0x21, 0xcf, 0xd6, 0x97, 0x16,
-	0xbd, 0xcc, 0x9a, 0x62, 0x67, 0x62, 0x3e, 0x5d, 0xff, 0xdf, 0x1c, 0x6c, 0x26, 0xb0, 0xa5, 0xa5,
-	0x3e, 0x83, 0x62, 0x40, 0xe8, 0x64, 0x1c, 0x72, 0xf8, 0xfa, 0xce, 0xa3, 0x8c, 0xf0, 0x73, 0x48,
-	0x2d, 0xcc, 0x61, 0xb0, 0x84, 0x43, 0xdb, 0xa0, 0x89, 0x19, 0x06, 0x09, 0x02, 0x2f, 0x30, 0x1c,
-	0x3a, 0xe2, 0x52, 0xab, 0xe0, 0xba, 0x68, 0xef, 0xb2, 0xe6, 0x43, 0x3a, 0x4a, 0x48, 0x55, 0xbd,
-	0xa6, 0x54, 0x91, 0x09, 0x9a, 0x4b, 0xc2, 0xd7, 0x5e, 0x70, 0x6e, 0x30, 0xd1, 0x06, 0xb6, 0x45,
-	0x1a, 0x79, 0x0e, 0xfa, 0x49, 0x46, 0xd0, 0x9e, 0x98, 0x7e, 0x24, 0x67, 0xe3, 0x0d, 0x37, 0xdd,
-	0xa0, 0xff, 0x00, 0x8a, 0xe2, 0x9f, 0x32, 0x4b, 0xea, 0x9f, 0x74, 0x3a, 0xdd, 0x7e, 0x5f, 0x5b,
-	0x43, 0x15, 0x28, 0xe0, 0xee, 0x00, 0x33, 0x0b, 0xab, 0x40, 0xe1, 0x71, 0x7b, 0xd0, 0x3e, 0xd0,
-	0x72, 0xfa, 0xf7, 0x61, 0xe3, 0x99, 0x69, 0x87, 0x59, 0x8c, 0x4b, 0xf7, 0x40, 0x9b, 0x8e, 0x95,
-	0xda, 0xd9, 0x4f, 0x69, 0x27, 0xbb, 0x68, 0xba, 0x97, 0x76, 0x38, 0xa3, 0x0f, 0x0d, 0x54, 0x12,
-	0x04, 0x52, 0x05, 0xec, 0x53, 0x7f, 0x0d, 0x1b, 0xfd, 0xd0, 0xf3, 0x33, 0x59, 0xfe, 0x8f, 0xa0,
-	0xc4, 0x4e, 0x1b, 0x6f, 0x12, 0x4a, 0xd3, 0xbf, 0xd3, 0x12, 0xa7, 0x51, 0x2b, 0x3a, 0x8d, 0x5a,
-	0xbb, 0xf2, 0xb4, 0xc2, 0xd1, 0x48, 0x74, 0x0b, 0x8a, 0xd4, 0x1e, 0xb9, 0xe6, 0x58, 0x7a, 0x0b,
-	0x49, 0xe9, 0x88, 0x19, 0x79, 0xb4, 0xb0, 0x34, 0xfc, 0x0e, 0xa0, 0x5d, 0x42, 0xc3, 0xc0, 0xbb,
-	0xca, 0xc4, 0xcf, 0x16, 0x14, 0x5e, 0x7a, 0xc1, 0x50, 0x6c, 0xc4, 0x32, 0x16, 0x04, 0xdb, 0x54,
-	0x29, 0x10, 0x89, 0xfd, 0x11, 0xa0, 0x7d, 0x97, 0x9d, 0x29, 0xd9, 0x14, 0xf1, 0x0f, 0x39, 0xb8,
-	0x91, 0x1a, 0x2f, 0x95, 0xb1, 0xfa, 0x3e, 0x64, 0x8e, 0x69, 0x42, 0xc5, 0x3e, 0x44, 0x47, 0x50,
-	0x14, 0x23, 0xa4, 0x24, 0xef, 0x2f, 0x01, 0x24, 0x8e, 0x29, 0x09, 0x27, 0x61, 0x16, 0x1a, 0xbd,
-	0xfa, 0x6e, 0x8d, 0xfe, 0x35, 0x68, 0xd1, 0xff, 0xa0, 0x6f, 0xd5, 0xcd, 0x57, 0x70, 0x63, 0xe8,
-	0x8d, 0xc7, 0x64, 0xc8, 0xac, 0xc1, 0xb0, 0xdd, 0x90, 0x04, 0x17, 0xe6, 0xf8, 0xed, 0x76, 0x83,
-	0xa6, 0xb3, 0xf6, 0xe5, 0x24, 0xfd, 0x05, 0x6c, 0x26, 0x16, 0x96, 0x8a, 0x78, 0x0c, 0x05, 0xca,
-	0x1a, 0xa4, 0x26, 0x3e, 0x5e, 0x52, 0x13, 0x14, 0x8b, 0xe9, 0xfa, 0x0d, 0x01, 0xde, 0xbd, 0x20,
-	0x6e, 0xfc, 0xb7, 0xf4, 0x5d, 0xd8, 0xec, 0x73, 0x33, 0xcd, 0x64, 0x87, 0x53, 0x13, 0xcf, 0xa5,
-	0x4c, 0x7c, 0x0b, 0x50, 0x12, 0x45, 0x1a, 0xe2, 0x15, 0x6c, 0x74, 0x2f, 0xc9, 0x30, 0x13, 0x72,
-	0x03, 0x4a, 0x43, 0xcf, 0x71, 0x4c, 0xd7, 0x6a, 0xe4, 0xee, 0xaa, 0xdb, 0x15, 0x1c, 0x91, 0xc9,
-	0xbd, 0xa8, 0x66, 0xdd, 0x8b, 0xfa, 0xdf, 0x29, 0xa0, 0x4d, 0xd7, 0x96, 0x82, 0x64, 0xdc, 0x87,
-	0x16, 0x03, 0x62, 0x6b, 0xd7, 0xb0, 0xa4, 0x64, 0x7b, 0xe4, 0x2e, 0x44, 0x3b, 0x09, 0x82, 0x84,
-	0x3b, 0x52, 0xaf, 0xe9, 0x8e, 0xf4, 0x3d, 0xf8, 0x9d, 0x88, 0x9d, 0x7e, 0x18, 0x10, 0xd3, 0xb1,
-	0xdd, 0xd1, 0xfe, 0xd1, 0x91, 0x4f, 0x04, 0xe3, 0x08, 0x41, 0xde, 0x32, 0x43, 0x53, 0x32, 0xc6,
-	0xbf, 0xd9, 0xa6, 0x1f, 0x8e, 0x3d, 0x1a, 0x6f, 0x7a, 0x4e, 0xe8, 0xff, 0xa1, 0x42, 0x63, 0x0e,
-	0x2a, 0x12, 0xef, 0x0b, 0x28, 0x50, 0x12, 0x4e, 0x7c, 0x69, 0x2a, 0xdd, 0xcc, 0x0c, 0x2f, 0xc6,
-	0x6b, 0xf5, 0x19, 0x18, 0x16, 0x98, 0x68, 0x04, 0xe5, 0x30, 0xbc, 0x32, 0xa8, 0xfd, 0xf3, 0x28,
-	0x20, 0x38, 0xb8, 0x2e, 0xfe, 0x80, 0x04, 0x8e, 0xed, 0x9a, 0xe3, 0xbe, 0xfd, 0x73, 0x82, 0x4b,
-	0x61, 0x78, 0xc5, 0x3e, 0xd0, 0x73, 0x66, 0xf0, 0x96, 0xed, 0x4a, 0xb1, 0x77, 0x56, 0x5d, 0x25,
-	0x21, 0x60, 0x2c, 0x10, 0x9b, 0x07, 0x50, 0xe0, 0xff, 0x69, 0x15, 0x43, 0xd4, 0x40, 0x0d, 0xc3,
-	0x2b, 0xce, 0x54, 0x19, 0xb3, 0xcf, 0xe6, 0x43, 0xa8, 0x25, 0xff, 0x01, 0x33, 0xa4, 0x33, 0x62,
-	0x8f, 0xce, 0x84, 0x81, 0x15, 0xb0, 0xa4, 0x98, 0x26, 0x5f, 0xdb, 0x96, 0x0c, 0x59, 0x0b, 0x58,
-	0x10, 0xfa, 0xbf, 0xe6, 0xe0, 0xce, 0x02, 0xc9, 0x48, 0x63, 0x7d, 0x91, 0x32, 0xd6, 0x77, 0x24,
-	0x85, 0xc8, 0xe2, 0x5f, 0xa4, 0x2c, 0xfe, 0x1d, 0x82, 0xb3, 0x6d, 0x73, 0x0b, 0x8a, 0xe4, 0xd2,
-	0x0e, 0x89, 0x25, 0x45, 0x25, 0xa9, 0xc4, 0x76, 0xca, 0x5f, 0x77, 0x3b, 0x1d, 0xc2, 0x56, 0x27,
-	0x20, 0x66, 0x48, 0xa4, 0x2b, 0x8f, 0xec, 0xff, 0x0e, 0x94, 0xcd, 0xf1, 0xd8, 0x1b, 0x4e, 0xd5,
-	0x5a, 0xe2, 0xf4, 0xbe, 0x85, 0x9a, 0x50, 0x3e, 0xf3, 0x68, 0xe8, 0x9a, 0x0e, 0x91, 0xce, 0x2b,
-	0xa6, 0xf5, 0x6f, 0x15, 0xb8, 0x39, 0x83, 0x27, 0xb5, 0x70, 0x0a, 0x75, 0x9b, 0x7a, 0x63, 0xfe,
-	0x07, 0x8d, 0xc4, 0x0d, 0xef, 0xc7, 0xcb, 0x1d, 0x35, 0xfb, 0x11, 0x06, 0xbf, 0xf0, 0xad, 0xdb,
-	0x49, 0x92, 0x5b, 0x1c, 0x5f, 0xdc, 0x92, 0x3b, 0x3d, 0x22, 0xf5, 0x7f, 0x54, 0xe0, 0xa6, 0x3c,
-	0xe1, 0xb3, 0xff, 0xd1, 0x79, 0x96, 0x73, 0xef, 0x9a, 0x65, 0xbd, 0x01, 0xb7, 0x66, 0xf9, 0x92,
-	0x3e, 0xff, 0xff, 0xf2, 0x80, 0xe6, 0x6f, 0x97, 0xe8, 0x7b, 0x50, 0xa3, 0xc4, 0xb5, 0x0c, 0x71,
-	0x5e, 0x88, 0xa3, 0xac, 0x8c, 0xab, 0xac, 0x4d, 0x1c, 0x1c, 0x94, 0xb9, 0x40, 0x72, 0x29, 0xb9,
-	0x2d, 0x63, 0xfe, 0x8d, 0xce, 0xa0, 0xf6, 0x92, 0x1a, 0xf1, 0xda, 0xdc, 0xa0, 0xea, 0x99, 0xdd,
-	0xda, 0x3c, 0x1f, 0xad, 0xc7, 0xfd, 0xf8, 0x7f, 0xe1, 0xea, 0x4b, 0x1a, 0x13, 0xe8, 0x97, 0x0a,
-	0xdc, 0x8e, 0xc2, 0x8a, 0xa9, 0xf8, 0x1c, 0xcf, 0x22, 0xb4, 0x91, 0xbf, 0xab, 0x6e, 0xd7, 0x77,
-	0x8e, 0xaf, 0x21, 0xbf, 0xb9, 0xc6, 0x43, 0xcf, 0x22, 0xf8, 0xa6, 0xbb, 0xa0, 0x95, 0xa2, 0x16,
-	0xdc, 0x70, 0x26, 0x34, 0x34, 0x84, 0x15, 0x18, 0x72, 0x50, 0xa3, 0xc0, 0xe5, 0xb2, 0xc9, 0xba,
-	0x52, 0xb6, 0x8a, 0xce, 0x61, 0xdd, 0xf1, 0x26, 0x6e, 0x68, 0x0c, 0xf9, 0xfd, 0x87, 0x36, 0x8a,
-	0x4b, 0x5d, 0x8c, 0x17, 0x48, 0xe9, 0x90, 0xc1, 0x89, 0xdb, 0x14, 0xc5, 0x35, 0x27, 0x41, 0x31,
-	0x45, 0x06, 0xc4, 0xf1, 0x42, 0x62, 0x30, 0x7f, 0x49, 0x1b, 0x25, 0xa1, 0x48, 0xd1, 0xc6, 0x5c,
-	0x03, 0xd5, 0x5b, 0x50, 0x4d, 0x88, 0x19, 0x95, 0x21, 0xdf, 0x3b, 0xea, 0x75, 0xb5, 0x35, 0x04,
-	0x50, 0xec, 0xec, 0xe1, 0xa3, 0xa3, 0x81, 0xb8, 0x35, 0xec, 0x1f, 0xb6, 0x9f, 0x74, 0xb5, 0x9c,
-	0xde, 0x85, 0x5a, 0x72, 0x41, 0x84, 0xa0, 0x7e, 0xd2, 0x7b, 0xda, 0x3b, 0x7a, 0xd6, 0x33, 0x0e,
-	0x8f, 0x4e, 0x7a, 0x03, 0x76, 0xdf, 0xa8, 0x03, 0xb4, 0x7b, 0xcf, 0xa7, 0xf4, 0x3a, 0x54, 0x7a,
-	0x47, 0x11, 0xa9, 0x34, 0x73, 0x9a, 0xa2, 0xff, 0xbb, 0x0a, 0x5b, 0x8b, 0x64, 0x8f, 0x2c, 0xc8,
-	0x33, 0x3d, 0xca, 0x1b, 0xdf, 0xbb, 0x57, 0x23, 0x47, 0x67, 0xe6, 0xeb, 0x9b, 0xd2, 0xc5, 0x57,
-	0x30, 0xff, 0x46, 0x06, 0x14, 0xc7, 0xe6, 0x29, 0x19, 0xd3, 0x86, 0xca, 0x73, 0x22, 0x4f, 0xae,
-	0xb3, 0xf6, 0x01, 0x47, 0x12, 0x09, 0x11, 0x09, 0x8b, 0x06, 0x50, 0x65, 0x4e, 0x8c, 0x0a, 0xd1,
-	0x49, 0xbf, 0xba, 0x93, 0x71, 0x95, 0xbd, 0xe9, 0x4c, 0x9c, 0x84, 0x69, 0x3e, 0x80, 0x6a, 0x62,
-	0xb1, 0x05, 0xf9, 0x8c, 0xad, 0x64, 0x3e, 0xa3, 0x92, 0x4c, 0x4e, 0x3c, 0x9a, 0xd7, 0x01, 0x93,
-	0x11, 0x33, 0x82, 0xbd, 0xa3, 0xfe, 0x40, 0xdc, 0x1c, 0x9f, 0xe0, 0xa3, 0x93, 0x63, 0x4d, 0x61,
-	0x8d, 0x83, 0x76, 0xff, 0xa9, 0x96, 0x8b, 0x6d, 0x44, 0xd5, 0x3b, 0x50, 0x4d, 0xf0, 0x95, 0xf2,
-	0xda, 0x4a, 0xda, 0x6b, 0x33, 0xbf, 0x69, 0x5a, 0x56, 0x40, 0x28, 0x95, 0x7c, 0x44, 0xa4, 0xfe,
-	0x02, 0x2a, 0xbb, 0xbd, 0xbe, 0x84, 0x68, 0x40, 0x89, 0x92, 0x80, 0xfd, 0x6f, 0x9e, 0x99, 0xaa,
-	0xe0, 0x88, 0x64, 0xe0, 0x94, 0x98, 0xc1, 0xf0, 0x8c, 0x50, 0x79, 0xd6, 0xc7, 0x34, 0x9b, 0xe5,
-	0xf1, 0x0c, 0x8f, 0xd0, 0x5d, 0x05, 0x47, 0xa4, 0xfe, 0x3f, 0x25, 0x80, 0x69, 0xb6, 0x01, 0xd5,
-	0x21, 0x17, 0xfb, 0xe0, 0x9c, 0x6d, 0x31, 0x3b, 0x48, 0x9c, 0x31, 0xfc, 0x1b, 0xed, 0xc0, 0x4d,
-	0x87, 0x8e, 0x7c, 0x73, 0x78, 0x6e, 0xc8, 0x24, 0x81, 0xd8, 0xaa, 0xdc, 0x9f, 0xd5, 0xf0, 0x0d,
-	0xd9, 0x29, 0x77, 0xa2, 0xc0, 0x3d, 0x00, 0x95, 0xb8, 0x17, 0xdc, 0xf7, 0x54, 0x77, 0x3e, 0x5b,
-	0x3a, 0x0b, 0xd2, 0xea, 0xba, 0x17, 0xc2, 0x56, 0x18, 0x0c, 0x32, 0x00, 0x2c, 0x72, 0x61, 0x0f,
-	0x89, 0xc1, 0x40, 0x0b, 0x1c, 0xf4, 0x8b, 0xe5, 0x41, 0x77, 0x39, 0x46, 0x0c, 0x5d, 0xb1, 0x22,
-	0x1a, 0xf5, 0xa0, 0x12, 0x10, 0xea, 0x4d, 0x82, 0x21, 0x11, 0x0e, 0x28, 0xfb, 0x45, 0x05, 0x47,
-	0xf3, 0xf0, 0x14, 0x02, 0xed, 0x42, 0x91, 0xfb, 0x1d, 0xe6, 0x61, 0xd4, 0xef, 0x4c, 0xa9, 0xa6,
-	0xc1, 0xb8, 0x27, 0xc1, 0x72, 0x2e, 0x7a, 0x02, 0x25, 0xc1, 0x22, 0x6d, 0x94, 0x39, 0xcc, 0x47,
-	0x59, 0x9d, 0x22, 0x9f, 0x85, 0xa3, 0xd9, 0x4c, 0xab, 0x13, 0x4a, 0x82, 0x46, 0x45, 0x68, 0x95,
-	0x7d, 0xa3, 0xf7, 0xa0, 0x22, 0xce, 0x60, 0xcb, 0x0e, 0x1a, 0x20, 0x8c, 0x93, 0x37, 0xec, 0xda,
-	0x01, 0x7a, 0x1f, 0xaa, 0x22, 0xd6, 0x32, 0xb8, 0x57, 0xa8, 0xf2, 0x6e, 0x10, 0x4d, 0xc7, 0xcc,
-	0x37, 0x88, 0x01, 0x24, 0x08, 0xc4, 0x80, 0x5a, 0x3c, 0x80, 0x04, 0x01, 0x1f, 0xf0, 0x07, 0xb0,
-	0xc1, 0x23, 0xd4, 0x51, 0xe0, 0x4d, 0x7c, 0x83, 0xdb, 0xd4, 0x3a, 0x1f, 0xb4, 0xce, 0x9a, 0x9f,
-	0xb0, 0xd6, 0x1e, 0x33, 0xae, 0x3b, 0x50, 0x7e, 0xe5, 0x9d, 0x8a, 0x01, 0x75, 0xb1, 0x0f, 0x5e,
-	0x79, 0xa7, 0x51, 0x57, 0x1c, 0x25, 0x6c, 0xa4, 0xa3, 0x84, 0x6f, 0xe0, 0xd6, 0xfc, 0x71, 0xc7,
-	0xa3, 0x05, 0xed, 0xfa, 0xd1, 0xc2, 0x96, 0xbb, 0xc8, 0x0f, 0x7f, 0x09, 0xaa, 0xe5, 0xd2, 0xc6,
-	0xe6, 0x52, 0xc6, 0x11, 0xef, 0x63, 0xcc, 0x26, 0x37, 0x3f, 0x81, 0x72, 0x64, 0x7d, 0xcb, 0xf8,
-	0xa5, 0xe6, 0x43, 0xa8, 0xa7, 0x6d, 0x77, 0x29, 0xaf, 0xf6, 0xcf, 0x39, 0xa8, 0xc4, 0x56, 0x8a,
-	0x5c, 0xb8, 0xc1, 0xa5, 0xc8, 0x42, 0x34, 0x63, 0x6a, 0xf4, 0x22, 0x30, 0xfc, 0x3c, 0xe3, 0xff,
-	0x6a, 0x47, 0x08, 0xf2, 0x86, 0x2a, 0x77, 0x00, 0x8a, 0x91, 0xa7, 0xeb, 0x7d, 0x0d, 0x1b, 0x63,
-	0xdb, 0x9d, 0x5c, 0x26, 0xd6, 0x12, 0x11, 0xdd, 0x1f, 0x66, 0x5c, 0xeb, 0x80, 0xcd, 0x9e, 0xae,
-	0x51, 0x1f, 0xa7, 0x68, 0xb4, 0x07, 0x05, 0xdf, 0x0b, 0xc2, 0xe8, 0x90, 0xca, 0x7a, 0x7c, 0x1c,
-	0x7b, 0x41, 0x78, 0x68, 0xfa, 0x3e, 0xbb, 0xb4, 0x08, 0x00, 0xfd, 0xdb, 0x1c, 0xdc, 0x5a, 0xfc,
-	0xc7, 0x50, 0x0f, 0xd4, 0xa1, 0x3f, 0x91, 0x42, 0x7a, 0xb8, 0xac, 0x90, 0x3a, 0xfe, 0x64, 0xca,
-	0x3f, 0x03, 0x42, 0xcf, 0xa0, 0xe8, 0x10, 0xc7, 0x0b, 0xae, 0xa4, 0x2c, 0x1e, 0x2d, 0x0b, 0x79,
-	0xc8, 0x67, 0x4f, 0x51, 0x25, 0x1c, 0xc2, 0x50, 0x96, 0xd6, 0x4b, 0xa5, 0x9f, 0x5c, 0x32, 0xad,
-	0x14, 0x41, 0xe2, 0x18, 0x47, 0xff, 0x04, 0x6e, 0x2e, 0xfc, 0x2b, 0xe8, 0x77, 0x01, 0x86, 0xfe,
-	0xc4, 0xe0, 0x69, 0x7f, 0x61, 0x41, 0x2a, 0xae, 0x0c, 0xfd, 0x49, 0x9f, 0x37, 0xe8, 0x2f, 0xa0,
-	0xf1, 0x26, 0x7e, 0x99, 0xf7, 0x11, 0x1c, 0x1b, 0xce, 0x29, 0x97, 0x81, 0x8a, 0xcb, 0xa2, 0xe1,
-	0xf0, 0x14, 0xe9, 0xb0, 0x1e, 0x75, 0x9a, 0x97, 0x6c, 0x80, 0xca, 0x07, 0x54, 0xe5, 0x00, 0xf3,
-	0xf2, 0xf0, 0x54, 0xff, 0x55, 0x0e, 0x36, 0x66, 0x58, 0x66, 0x57, 0x37, 0xe1, 0xf1, 0xa2, 0x4b,
-	0xb1, 0xa0, 0x98, 0xfb, 0x1b, 0xda, 0x56, 0x94, 0x4e, 0xe5, 0xdf, 0xfc, 0xe0, 0xf3, 0x65, 0xaa,
-	0x33, 0x67, 0xfb, 0x6c, 0xfb, 0x38, 0xa7, 0x76, 0x48, 0x79, 0x14, 0x52, 0xc0, 0x82, 0x40, 0xcf,
-	0xa1, 0x1e, 0x10, 0x7e, 0xe0, 0x5a, 0x86, 0xb0, 0xb2, 0xc2, 0x52, 0x56, 0x26, 0x39, 0x64, 0xc6,
-	0x86, 0xd7, 0x23, 0x24, 0x46, 0x51, 0xf4, 0x0c, 0xd6, 0xad, 0x2b, 0xd7, 0x74, 0xec, 0xa1, 0x44,
-	0x2e, 0xae, 0x8c, 0x5c, 0x93, 0x40, 0x1c, 0x58, 0x7f, 0x00, 0xd5, 0x44, 0x27, 0xfb, 0x63, 0x3c,
-	0xdc, 0x92, 0x32, 0x11, 0x44, 0xda, 0x5b, 0x14, 0xa4, 0xb7, 0xd0, 0x4f, 0xa1, 0x9a, 0xd8, 0x17,
-	0xcb, 0x4c, 0x65, 0xf2, 0x0c, 0x3d, 0x2e, 0xcf, 0x02, 0xce, 0x85, 0x1e, 0xba, 0x0d, 0x25, 0x16,
-	0xea, 0x18, 0xb6, 0xcf, 0x25, 0x5a, 0xc1, 0x45, 0x46, 0xee, 0xfb, 0xfa, 0x6f, 0x72, 0x50, 0x4f,
-	0x6f, 0xe9, 0xc8, 0x8e, 0x7c, 0x12, 0xd8, 0x9e, 0x95, 0xb0, 0xa3, 0x63, 0xde, 0xc0, 0x6c, 0x85,
-	0x75, 0x7f, 0x33, 0xf1, 0x42, 0x33, 0xb2, 0x95, 0xa1, 0x3f, 0xf9, 0x23, 0x46, 0xcf, 0xd8, 0xa0,
-	0x3a, 0x63, 0x83, 0xe8, 0x43, 0x40, 0xd2, 0x94, 0xc6, 0xb6, 0x63, 0x87, 0xc6, 0xe9, 0x55, 0x48,
-	0x84, 0x8e, 0x55, 0xac, 0x89, 0x9e, 0x03, 0xd6, 0xf1, 0x25, 0x6b, 0x67, 0x86, 0xe7, 0x79, 0x8e,
-	0x41, 0x87, 0x5e, 0x40, 0x0c, 0xd3, 0x7a, 0xc5, 0x6f, 0x2d, 0x2a, 0xae, 0x7a, 0x9e, 0xd3, 0x67,
-	0x6d, 0x6d, 0xeb, 0x15, 0x3b, 0xf9, 0x86, 0xfe, 0x84, 0x92, 0xd0, 0x60, 0x3f, 0x3c, 0x58, 0xa8,
-	0x60, 0x10, 0x4d, 0x1d, 0x7f, 0x42, 0xd1, 0xef, 0xc3, 0x7a, 0x34, 0x80, 0x1f, 0x7e, 0xf2, 0xd4,
-	0xad, 0xc9, 0x21, 0xbc, 0x0d, 0xe9, 0x50, 0x3b, 0x26, 0xc1, 0x90, 0xb8, 0xe1, 0xc0, 0x1e, 0x9e,
-	0xb3, 0xf3, 0x5d, 0xd9, 0x56, 0x70, 0xaa, 0xed, 0xab, 0x7c, 0xb9, 0xa4, 0x95, 0x71, 0xb4, 0x9a,
-	0x43, 0x1c, 0xaa, 0xff, 0x0c, 0x0a, 0x3c, 0x44, 0x60, 0x32, 0xe1, 0xc7, 0x2b, 0x3f, 0x7d, 0x65,
-	0x68, 0xc9, 0x1a, 0xf8, 0xd9, 0xfb, 0x1e, 0x54, 0xb8, 0xec, 0x13, 0x11, 0x3d, 0x8f, 0x3b, 0x79,
-	0x67, 0x13, 0xca, 0x01, 0x31, 0x2d, 0xcf, 0x1d, 0x47, 0xc9, 0xa0, 0x98, 0xd6, 0xbf, 0x81, 0xa2,
-	0x38, 0x67, 0xae, 0x81, 0xff, 0x11, 0x20, 0xf1, 0xbf, 0x99, 0x3e, 0x1d, 0x9b, 0x52, 0x19, 0x85,
-	0xf2, 0x17, 0x48, 0xd1, 0x73, 0x3c, 0xed, 0xd0, 0xff, 0x53, 0x11, 0xf1, 0xa8, 0x78, 0x1b, 0x62,
-	0x81, 0x2b, 0x33, 0x72, 0x76, 0x5b, 0x16, 0x49, 0xa8, 0x88, 0x44, 0xfb, 0x50, 0x94, 0x61, 0x67,
-	0x6e, 0xd5, 0xa7, 0x35, 0x09, 0x10, 0xa5, 0xa4, 0x89, 0xbc, 0x90, 0x2f, 0x9b, 0x92, 0x26, 0x22,
-	0x25, 0x4d, 0xd8, 0x6d, 0x52, 0x06, 0xc4, 0x02, 0x2e, 0xcf, 0xe3, 0xe1, 0xaa, 0x15, 0xe7, 0xfd,
-	0x89, 0xfe, 0xdf, 0x4a, 0xec, 0xa6, 0xa2, 0xfc, 0x3c, 0xfa, 0x1a, 0xca, 0x6c, 0xc7, 0x1b, 0x8e,
-	0xe9, 0xcb, 0xd7, 0xe6, 0xce, 0x6a, 0xa9, 0xff, 0xe8, 0x10, 0x13, 0xe1, 0x6c, 0xc9, 0x17, 0x14,
-	0x73, 0x77, 0xec, 0x2a, 0x11, 0xb9, 0x3b, 0xf6, 0x8d, 0x3e, 0x80, 0xba, 0x39, 0x09, 0x3d, 0xc3,
-	0xb4, 0x2e, 0x48, 0x10, 0xda, 0x94, 0x48, 0xdd, 0xaf, 0xb3, 0xd6, 0x76, 0xd4, 0xd8, 0xfc, 0x0c,
-	0x6a, 0x49, 0xcc, 0xb7, 0x85, 0x19, 0x85, 0x64, 0x98, 0xf1, 0xa7, 0x00, 0xd3, 0x5c, 0x17, 0xb3,
-	0x11, 0x72, 0x69, 0xb3, 0x5b, 0xbd, 0xbc, 0xbb, 0x16, 0x70, 0x99, 0x35, 0x74, 0xd8, 0x7d, 0x2a,
-	0x9d, 0x88, 0x2f, 0x44, 0x89, 0x78, 0xb6, 0x99, 0xd9, 0xfe, 0x3b, 0xb7, 0xc7, 0xe3, 0x38, 0xff,
-	0x56, 0xf1, 0x3c, 0xe7, 0x29, 0x6f, 0xd0, 0x7f, 0x9b, 0x13, 0xb6, 0x22, 0x9e, 0x54, 0x32, 0xdd,
-	0x5d, 0xde, 0x95, 0xaa, 0x1f, 0x00, 0xd0, 0xd0, 0x0c, 0x58, 0xcc, 0x64, 0x46, 0x19, 0xc0, 0xe6,
-	0x5c, 0x26, 0x7f, 0x10, 0xd5, 0x78, 0xe0, 0x8a, 0x1c, 0xdd, 0x0e, 0xd1, 0xe7, 0x50, 0x1b, 0x7a,
-	0x8e, 0x3f, 0x26, 0x72, 0x72, 0xe1, 0xad, 0x93, 0xab, 0xf1, 0xf8, 0x76, 0x98, 0xc8, 0x3b, 0x16,
-	0xaf, 0x9b, 0x77, 0xfc, 0x8d, 0x22, 0x5e, 0x86, 0x92, 0x0f, 0x53, 0x68, 0xb4, 0xa0, 0xfa, 0xe1,
-	0xc9, 0x8a, 0xaf, 0x5c, 0xdf, 0x55, 0xfa, 0xd0, 0xfc, 0x3c, 0x4b, 0xad, 0xc1, 0x9b, 0xa3, 0xd8,
-	0x7f, 0x53, 0xa1, 0x12, 0x3f, 0x0a, 0xcd, 0xe9, 0xfe, 0x53, 0xa8, 0xc4, 0x05, 0x36, 0xd2, 0x41,
-	0x7c, 0xa7, 0x7a, 0xe2, 0xc1, 0xe8, 0x25, 0x20, 0x73, 0x34, 0x8a, 0xa3, 0x53, 0x63, 0x42, 0xcd,
-	0x51, 0xf4, 0x24, 0xf7, 0xe9, 0x12, 0x72, 0x88, 0x8e, 0xb3, 0x13, 0x36, 0x1f, 0x6b, 0xe6, 0x68,
-	0x94, 0x6a, 0x41, 0x7f, 0x06, 0x37, 0xd3, 0x6b, 0x18, 0xa7, 0x57, 0x86, 0x6f, 0x5b, 0xf2, 0x8e,
-	0xbc, 0xb7, 0xec, 0xbb, 0x58, 0x2b, 0x05, 0xff, 0xe5, 0xd5, 0xb1, 0x6d, 0x09, 0x99, 0xa3, 0x60,
-	0xae, 0xa3, 0xf9, 0x17, 0x70, 0xfb, 0x0d, 0xc3, 0x17, 0xe8, 0xa0, 0x97, 0xae, 0xf7, 0x58, 0x5d,
-	0x08, 0x09, 0xed, 0xfd, 0x5a, 0x11, 0xcf, 0x77, 0x69, 0x99, 0xb4, 0x93, 0x61, 0xf5, 0xbd, 0x8c,
-	0xeb, 0x74, 0x8e, 0x4f, 0x04, 0x3c, 0x8f, 0xa4, 0xbf, 0x9a, 0x89, 0xa4, 0xb3, 0xc6, 0x4f, 0x22,
-	0x20, 0x15, 0x40, 0x12, 0x41, 0xff, 0x17, 0x15, 0xca, 0x11, 0x3a, 0xbf, 0xe1, 0x5e, 0xd1, 0x90,
-	0x38, 0x46, 0x9c, 0x7e, 0x53, 0x30, 0x88, 0x26, 0x9e, 0x14, 0x7a, 0x0f, 0x2a, 0xec, 0x22, 0x2d,
-	0xba, 0x73, 0xbc, 0xbb, 0xcc, 0x1a, 0x78, 0xe7, 0xfb, 0x50, 0x0d, 0xbd, 0xd0, 0x1c, 0x1b, 0x21,
-	0x3f, 0xde, 0x55, 0x31, 0x9b, 0x37, 0xf1, 0xc3, 0x1d, 0xfd, 0x00, 0x36, 0xc3, 0xb3, 0xc0, 0x0b,
-	0xc3, 0x31, 0x0b, 0x2d, 0x79, 0xa0, 0x23, 0xe2, 0x92, 0x3c, 0xd6, 0xe2, 0x0e, 0x11, 0x00, 0x51,
-	0xe6, 0xbd, 0xa7, 0x83, 0x99, 0xe9, 0x72, 0x27, 0x92, 0xc7, 0xeb, 0x71, 0x2b, 0x33, 0x6d, 0x76,
-	0x78, 0xfa, 0x22, 0x80, 0xe0, 0xbe, 0x42, 0xc1, 0x11, 0x89, 0x0c, 0xd8, 0x70, 0x88, 0x49, 0x27,
-	0x01, 0xb1, 0x8c, 0x97, 0x36, 0x19, 0x5b, 0x22, 0x31, 0x51, 0xcf, 0x7c, 0x3b, 0x88, 0xc4, 0xd2,
-	0x7a, 0xcc, 0x67, 0xe3, 0x7a, 0x04, 0x27, 0x68, 0x16, 0x39, 0x88, 0x2f, 0xb4, 0x01, 0xd5, 0xfe,
-	0xf3, 0xfe, 0xa0, 0x7b, 0x68, 0x1c, 0x1e, 0xed, 0x76, 0x65, 0x49, 0x4f, 0xbf, 0x8b, 0x05, 0xa9,
-	0xb0, 0xfe, 0xc1, 0xd1, 0xa0, 0x7d, 0x60, 0x0c, 0xf6, 0x3b, 0x4f, 0xfb, 0x5a, 0x0e, 0xdd, 0x84,
-	0xcd, 0xc1, 0x1e, 0x3e, 0x1a, 0x0c, 0x0e, 0xba, 0xbb, 0xc6, 0x71, 0x17, 0xef, 0x1f, 0xed, 0xf6,
-	0x35, 0x15, 0x21, 0xa8, 0x4f, 0x9b, 0x07, 0xfb, 0x87, 0x5d, 0x2d, 0x8f, 0xaa, 0x50, 0x3a, 0xee,
-	0xe2, 0x4e, 0xb7, 0x37, 0xd0, 0x0a, 0xfa, 0xaf, 0x54, 0xa8, 0x26, 0xb4, 0xc8, 0x0c, 0x39, 0xa0,
-	0xe2, 0x1a, 0x92, 0xc7, 0xec, 0x93, 0x3f, 0x41, 0x9a, 0xc3, 0x33, 0xa1, 0x9d, 0x3c, 0x16, 0x04,
-	0xbf, 0x7a, 0x98, 0x97, 0x89, 0x7d, 0x9e, 0xc7, 0x65, 0xc7, 0xbc, 0x14, 0x20, 0xdf, 0x83, 0xda,
-	0x39, 0x09, 0x5c, 0x32, 0x96, 0xfd, 0x42, 0x23, 0x55, 0xd1, 0x26, 0x86, 0x6c, 0x83, 0x26, 0x87,
-	0x4c, 0x61, 0x84, 0x3a, 0xea, 0xa2, 0xfd, 0x30, 0x02, 0xdb, 0x82, 0x82, 0xe8, 0x2e, 0x89, 0xf5,
-	0x39, 0xc1, 0x8e, 0x29, 0xfa, 0xda, 0xf4, 0x79, 0xc8, 0x97, 0xc7, 0xfc, 0x1b, 0x9d, 0xce, 0xeb,
-	0xa7, 0xc8, 0xf5, 0xf3, 0x60, 0x79, 0x73, 0x7e, 0x93, 0x8a, 0xce, 0x62, 0x15, 0x95, 0x40, 0xc5,
-	0x51, 0x1d, 0x4c, 0xa7, 0xdd, 0xd9, 0x63, 0x6a, 0x59, 0x87, 0xca, 0x61, 0xfb, 0xa7, 0xc6, 0x49,
-	0x9f, 0x67, 0xb5, 0x91, 0x06, 0xb5, 0xa7, 0x5d, 0xdc, 0xeb, 0x1e, 0xc8, 0x16, 0x15, 0x6d, 0x81,
-	0x26, 0x5b, 0xa6, 0xe3, 0xf2, 0x0c, 0x41, 0x7c, 0x16, 0x50, 0x19, 0xf2, 0xfd, 0x67, 0xed, 0x63,
-	0xad, 0xa8, 0xff, 0x57, 0x0e, 0x36, 0xc4, 0xb1, 0x10, 0xbf, 0xd8, 0xbf, 0xf9, 0xc5, 0x32, 0x99,
-	0xe5, 0xc9, 0xa5, 0xb3, 0x3c, 0x51, 0x10, 0xca, 0x4f, 0x75, 0x75, 0x1a, 0x84, 0xf2, 0xec, 0x50,
-	0xca, 0xe3, 0xe7, 0x97, 0xf1, 0xf8, 0x0d, 0x28, 0x39, 0x84, 0xc6, 0x7a, 0xab, 0xe0, 0x88, 0x44,
-	0x36, 0x54, 0x4d, 0xd7, 0xf5, 0x42, 0x53, 0xa4, 0x4e, 0x8b, 0x4b, 0x1d, 0x86, 0x33, 0xff, 0xb8,
-	0xd5, 0x9e, 0x22, 0x09, 0xc7, 0x9c, 0xc4, 0x6e, 0xfe, 0x04, 0xb4, 0xd9, 0x01, 0xcb, 0x1c, 0x87,
-	0xdf, 0xff, 0xe1, 0xf4, 0x34, 0x24, 0x6c, 0x5f, 0xc8, 0x37, 0x07, 0x6d, 0x8d, 0x11, 0xf8, 0xa4,
-	0xd7, 0xdb, 0xef, 0x3d, 0xd1, 0x14, 0x04, 0x50, 0xec, 0xfe, 0x74, 0x7f, 0xd0, 0xdd, 0xd5, 0x72,
-	0x3b, 0xbf, 0xde, 0x84, 0xa2, 0x60, 0x12, 0x7d, 0x2b, 0x23, 0x81, 0x64, 0x35, 0x28, 0xfa, 0xc9,
-	0xd2, 0x11, 0x75, 0xaa, 0xc2, 0xb4, 0xf9, 0x68, 0xe5, 0xf9, 0xf2, 0xf5, 0x6d, 0x0d, 0xfd, 0x8d,
-	0x02, 0xb5, 0xd4, 0xcb, 0x5b, 0xd6, 0xd4, 0xf1, 0x82, 0xe2, 0xd3, 0xe6, 0x8f, 0x57, 0x9a, 0x1b,
-	0xf3, 0xf2, 0x4b, 0x05, 0xaa, 0x89, 0xb2, 0x4b, 0xf4, 0x60, 0x95, 0x52, 0x4d, 0xc1, 0xc9, 0x67,
-	0xab, 0x57, 0x79, 0xea, 0x6b, 0x1f, 0x2b, 0xe8, 0xaf, 0x15, 0xa8, 0x26, 0x0a, 0x10, 0x33, 0xb3,
-	0x32, 0x5f, 0x2e, 0x99, 0x99, 0x95, 0x45, 0xf5, 0x8e, 0x6b, 0xe8, 0x2f, 0x15, 0xa8, 0xc4, 0xc5,
-	0x84, 0xe8, 0xfe, 0xf2, 0xe5, 0x87, 0x82, 0x89, 0x4f, 0x57, 0xad, 0x5b, 0xd4, 0xd7, 0xd0, 0x9f,
-	0x43, 0x39, 0xaa, 0xbc, 0x43, 0x59, 0x4f, 0xaf, 0x99, 0xb2, 0xbe, 0xe6, 0xfd, 0xa5, 0xe7, 0x25,
-	0x97, 0x8f, 0xca, 0xe1, 0x32, 0x2f, 0x3f, 0x53, 0xb8, 0xd7, 0xbc, 0xbf, 0xf4, 0xbc, 0x78, 0x79,
-	0x66, 0x09, 0x89, 0xaa, 0xb9, 0xcc, 0x96, 0x30, 0x5f, 0xae, 0x97, 0xd9, 0x12, 0x16, 0x15, 0xe9,
-	0x09, 0x46, 0x12, 0x75, 0x77, 0x99, 0x19, 0x99, 0xaf, 0xed, 0xcb, 0xcc, 0xc8, 0x82, 0x32, 0x3f,
-	0x7d, 0x0d, 0xfd, 0x42, 0x49, 0xde, 0x0b, 0xee, 0x2f, 0x5d, 0x5e, 0xb6, 0xa4, 0x49, 0xce, 0x15,
-	0xb8, 0xf1, 0x0d, 0xfa, 0x0b, 0x99, 0xc5, 0x10, 0xd5, 0x69, 0x68, 0x19, 0xb0, 0x54, 0x41, 0x5b,
-	0xf3, 0x93, 0xd5, 0x0e, 0x1b, 0xce, 0xc4, 0x5f, 0x29, 0x00, 0xd3, 0x3a, 0xb6, 0xcc, 0x4c, 0xcc,
-	0x15, 0xd0, 0x35, 0x1f, 0xac, 0x30, 0x33, 0xb9, 0x41, 0xa2, 0x3a, 0x9b, 0xcc, 0x1b, 0x64, 0xa6,
-	0xce, 0x2e, 0xf3, 0x06, 0x99, 0xad, 0x91, 0xd3, 0xd7, 0xd0, 0x3f, 0x29, 0xb0, 0x39, 0x57, 0xe7,
-	0x83, 0x1e, 0x5d, 0xb3, 0xd4, 0xab, 0xf9, 0xc5, 0xea, 0x00, 0x11, 0x6b, 0xdb, 0xca, 0xc7, 0x0a,
-	0xfa, 0x5b, 0x05, 0xd6, 0xd3, 0xf5, 0x0f, 0x99, 0x4f, 0xa9, 0x05, 0x15, 0x43, 0xcd, 0x87, 0xab,
-	0x4d, 0x8e, 0xa5, 0xf5, 0xf7, 0x0a, 0xd4, 0xd3, 0xa5, 0x30, 0xe8, 0xe1, 0x72, 0x6e, 0x61, 0x86,
-	0xa1, 0xcf, 0x57, 0x9c, 0x1d, 0x71, 0xf4, 0x65, 0xe9, 0x8f, 0x0b, 0x22, 0x7a, 0x2b, 0xf2, 0x9f,
-	0x1f, 0xfd, 0x7f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x76, 0x8b, 0xb6, 0x52, 0xb4, 0x33, 0x00, 0x00,
+	// 3885 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x5a, 0x4f, 0x6f, 0x1b, 0x49,
+	0x76, 0x77, 0xf3, 0x9f, 0xc8, 0x47, 0x8a, 0x6a, 0x95, 0xe4, 0x19, 0x9a, 0x93, 0xec, 0x78, 0x3b,
+	0x98, 0xc0, 0xd8, 0x9d, 0xa1, 0x67, 0xb5, 0xc8, 0x78, 0xec, 0xb5, 0xd7, 0x43, 0x53, 0xb4, 0xa5,
+	0xb1, 0x44, 0x29, 0x45, 0x0a, 0x5e, 0xc7, 0xd9, 0xe9, 0xb4, 0xba, 0xcb, 0x64, 0x5b, 0xec, 0x3f,
+	0xd3, 0xd5, 0x94, 0xa5, 0x0d, 0x82, 0x2c, 0x36, 0x40, 0xb0, 0x01, 0x12, 0x24, 0x97, 0xc9, 0x5c,
+	0x72, 0x0a, 0x90, 0x53, 0xbe, 0x40, 0xb0, 0xc1, 0x9c, 0xf6, 0x90, 0x2f, 0x91, 0x4b, 0x6e, 0x39,
+	0x26, 0xdf, 0x20, 0xa8, 0x3f, 0xdd, 0xec, 0x26, 0xe9, 0x75, 0x93, 0x72, 0x4e, 0xec, 0xf7, 0xaa,
+	0xea, 0x57, 0x8f, 0xaf, 0x5e, 0xbd, 0x7a, 0xf5, 0xea, 0x81, 0xe6, 0x8f, 0x27, 0x43, 0xdb, 0xa5,
+	0xb7, 0xad, 0xc0, 0x3e, 0x27, 0x01, 0xbd, 0xed, 0x07, 0x5e, 0xe8, 0x49, 0xaa, 0xc5, 0x09, 0xf4,
+	0xd1, 0xc8, 0xa0, 0x23, 0xdb, 0xf4, 0x02, 0xbf, 0xe5, 0x7a, 0x8e, 0x61, 0xb5, 0xe4, 0x98, 0x96,
+	0x1c, 0x23, 0xba, 0x35, 0xbf, 0x37, 0xf4, 0xbc, 0xe1, 0x98, 0x08, 0x84, 0xd3, 0xc9, 0xcb, 0xdb,
+	0xd6, 0x24, 0x30, 0x42, 0xdb, 0x73, 0x65, 0xfb, 0x87, 0xb3, 0xed, 0xa1, 0xed, 0x10, 0x1a, 0x1a,
+	0x8e, 0x2f, 0x3b, 0x7c, 0x14, 0xc9, 0x42, 0x47, 0x46, 0x40, 0xac, 0xdb, 0x23, 0x73, 0x4c, 0x7d,
+	0x62, 0xb2, 0x5f, 0x9d, 0x7d, 0xc8, 0x6e, 0x1f, 0xcf, 0x74, 0xa3, 0x61, 0x30, 0x31, 0xc3, 0x48,
+	0x72, 0x23, 0x0c, 0x03, 0xfb, 0x74, 0x12, 0x12, 0xd1, 0x5b, 0xbb, 0x01, 0xef, 0x0f, 0x0c, 0x7a,
+	0xd6, 0xf1, 0xdc, 0x97, 0xf6, 0xb0, 0x6f, 0x8e, 0x88, 0x63, 0x60, 0xf2, 0xf5, 0x84, 0xd0, 0x50,
+	0xfb, 0x53, 0x68, 0xcc, 0x37, 0x51, 0xdf, 0x73, 0x29, 0x41, 0x5f, 0x40, 0x81, 0x4d, 0xd9, 0x50,
+	0x6e, 0x2a, 0xb7, 0xaa, 0x3b, 0x1f, 0xb7, 0xde, 0xa4, 0x02, 0x21, 0x43, 0x4b, 0x8a, 0xda, 0xea,
+	0xfb, 0xc4, 0xc4, 0x7c, 0xa4, 0x76, 0x1d, 0xb6, 0x3a, 0x86, 0x6f, 0x9c, 0xda, 0x63, 0x3b, 0xb4,
+	0x09, 0x8d, 0x26, 0x9d, 0xc0, 0x76, 0x9a, 0x2d, 0x27, 0xfc, 0x39, 0xd4, 0xcc, 0x04, 0x5f, 0x4e,
+	0x7c, 0xb7, 0x95, 0x49, 0xf7, 0xad, 0x5d, 0x4e, 0xa5, 0x80, 0x53, 0x70, 0xda, 0x36, 0xa0, 0xc7,
+	0xb6, 0x3b, 0x24, 0x81, 0x1f, 0xd8, 0x6e, 0x18, 0x09, 0xf3, 0x5d, 0x1e, 0xb6, 0x52, 0x6c, 0x29,
+	0xcc, 0x2b, 0x80, 0x58, 0x8f, 0x4c, 0x94, 0xfc, 0xad, 0xea, 0xce, 0x97, 0x19, 0x45, 0x59, 0x80,
+	0xd7, 0x6a, 0xc7, 0x60, 0x5d, 0x37, 0x0c, 0x2e, 0x71, 0x02, 0x1d, 0x7d, 0x05, 0xa5, 0x11, 0x31,
+	0xc6, 0xe1, 0xa8, 0x91, 0xbb, 0xa9, 0xdc, 0xaa, 0xef, 0x3c, 0xbe, 0xc2, 0x3c, 0x7b, 0x1c, 0xa8,
+	0x1f, 0x1a, 0x21, 0xc1, 0x12, 0x15, 0x7d, 0x02, 0x48, 0x7c, 0xe9, 0x16, 0xa1, 0x66, 0x60, 0xfb,
+	0xcc, 0x24, 0x1b, 0xf9, 0x9b, 0xca, 0xad, 0x0a, 0xde, 0x14, 0x2d, 0xbb, 0xd3, 0x86, 0xa6, 0x0f,
+	0x1b, 0x33, 0xd2, 0x22, 0x15, 0xf2, 0x67, 0xe4, 0x92, 0xaf, 0x48, 0x05, 0xb3, 0x4f, 0xf4, 0x04,
+	0x8a, 0xe7, 0xc6, 0x78, 0x42, 0xb8, 0xc8, 0xd5, 0x9d, 0x1f, 0xbd, 0xcd, 0x3c, 0xa4, 0x89, 0x4e,
+	0xf5, 0x80, 0xc5, 0xf8, 0x7b, 0xb9, 0xcf, 0x15, 0xed, 0x2e, 0x54, 0x13, 0x72, 0xa3, 0x3a, 0xc0,
+	0x49, 0x6f, 0xb7, 0x3b, 0xe8, 0x76, 0x06, 0xdd, 0x5d, 0xf5, 0x1a, 0x5a, 0x87, 0xca, 0x49, 0x6f,
+	0xaf, 0xdb, 0x3e, 0x18, 0xec, 0x3d, 0x57, 0x15, 0x54, 0x85, 0xb5, 0x88, 0xc8, 0x69, 0x17, 0x80,
+	0x30, 0x31, 0xbd, 0x73, 0x12, 0x30, 0x43, 0x96, 0xab, 0x8a, 0xde, 0x87, 0xb5, 0xd0, 0xa0, 0x67,
+	0xba, 0x6d, 0x49, 0x99, 0x4b, 0x8c, 0xdc, 0xb7, 0xd0, 0x3e, 0x94, 0x46, 0x86, 0x6b, 0x8d, 0xdf,
+	0x2e, 0x77, 0x5a, 0xd5, 0x0c, 0x7c, 0x8f, 0x0f, 0xc4, 0x12, 0x80, 0x59, 0x77, 0x6a, 0x66, 0xb1,
+	0x00, 0xda, 0x73, 0x50, 0xfb, 0xa1, 0x11, 0x84, 0x49, 0x71, 0xba, 0x50, 0x60, 0xf3, 0x37, 0x94,
+	0xa5, 0xe7, 0x14, 0x3b, 0x13, 0xf3, 0xe1, 0xda, 0xff, 0xe6, 0x60, 0x33, 0x81, 0x2d, 0x2d, 0xf5,
+	0x19, 0x94, 0x02, 0x42, 0x27, 0xe3, 0x90, 0xc3, 0xd7, 0x77, 0x1e, 0x66, 0x84, 0x9f, 0x43, 0x6a,
+	0x61, 0x0e, 0x83, 0x25, 0x1c, 0xba, 0x05, 0xaa, 0x18, 0xa1, 0x93, 0x20, 0xf0, 0x02, 0xdd, 0xa1,
+	0x43, 0xae, 0xb5, 0x0a, 0xae, 0x0b, 0x7e, 0x97, 0xb1, 0x0f, 0xe9, 0x30, 0xa1, 0xd5, 0xfc, 0x15,
+	0xb5, 0x8a, 0x0c, 0x50, 0x5d, 0x12, 0xbe, 0xf6, 0x82, 0x33, 0x9d, 0xa9, 0x36, 0xb0, 0x2d, 0xd2,
+	0x28, 0x70, 0xd0, 0xcf, 0x32, 0x82, 0xf6, 0xc4, 0xf0, 0x23, 0x39, 0x1a, 0x6f, 0xb8, 0x69, 0x86,
+	0xf6, 0x43, 0x28, 0x89, 0x7f, 0xca, 0x2c, 0xa9, 0x7f, 0xd2, 0xe9, 0x74, 0xfb, 0x7d, 0xf5, 0x1a,
+	0xaa, 0x40, 0x11, 0x77, 0x07, 0x98, 0x59, 0x58, 0x05, 0x8a, 0x8f, 0xdb, 0x83, 0xf6, 0x81, 0x9a,
+	0xd3, 0x7e, 0x00, 0x1b, 0xcf, 0x0c, 0x3b, 0xcc, 0x62, 0x5c, 0x9a, 0x07, 0xea, 0xb4, 0xaf, 0x5c,
+	0x9d, 0xfd, 0xd4, 0xea, 0x64, 0x57, 0x4d, 0xf7, 0xc2, 0x0e, 0x67, 0xd6, 0x43, 0x85, 0x3c, 0x09,
+	0x02, 0xb9, 0x04, 0xec, 0x53, 0x7b, 0x0d, 0x1b, 0xfd, 0xd0, 0xf3, 0x33, 0x59, 0xfe, 0x8f, 0x61,
+	0x8d, 0x9d, 0x36, 0xde, 0x24, 0x94, 0xa6, 0x7f, 0xa3, 0x25, 0x4e, 0xa3, 0x56, 0x74, 0x1a, 0xb5,
+	0x76, 0xe5, 0x69, 0x85, 0xa3, 0x9e, 0xe8, 0x3d, 0x28, 0x51, 0x7b, 0xe8, 0x1a, 0x63, 0xe9, 0x2d,
+	0x24, 0xa5, 0x21, 0x50, 0xa7, 0x13, 0x4b, 0xc3, 0xef, 0x00, 0xda, 0x25, 0x34, 0x0c, 0xbc, 0xcb,
+	0x4c, 0xf2, 0x6c, 0x43, 0xf1, 0xa5, 0x17, 0x98, 0x62, 0x23, 0x96, 0xb1, 0x20, 0xd8, 0xa6, 0x4a,
+	0x81, 0x48, 0xec, 0x4f, 0x00, 0xed, 0xbb, 0xec, 0x4c, 0xc9, 0xb6, 0x10, 0xff, 0x90, 0x83, 0xad,
+	0x54, 0x7f, 0xb9, 0x18, 0xab, 0xef, 0x43, 0xe6, 0x98, 0x26, 0x54, 0xec, 0x43, 0x74, 0x04, 0x25,
+	0xd1, 0x43, 0x6a, 0xf2, 0xce, 0x12, 0x40, 0xe2, 0x98, 0x92, 0x70, 0x12, 0x66, 0xa1, 0xd1, 0xe7,
+	0xdf, 0xad, 0xd1, 0xbf, 0x06, 0x35, 0xfa, 0x1f, 0xf4, 0xad, 0x6b, 0xf3, 0x25, 0x6c, 0x99, 0xde,
+	0x78, 0x4c, 0x4c, 0x66, 0x0d, 0xba, 0xed, 0x86, 0x24, 0x38, 0x37, 0xc6, 0x6f, 0xb7, 0x1b, 0x34,
+	0x1d, 0xb5, 0x2f, 0x07, 0x69, 0x2f, 0x60, 0x33, 0x31, 0xb1, 0x5c, 0x88, 0xc7, 0x50, 0xa4, 0x8c,
+	0x21, 0x57, 0xe2, 0xd3, 0x25, 0x57, 0x82, 0x62, 0x31, 0x5c, 0xdb, 0x12, 0xe0, 0xdd, 0x73, 0xe2,
+	0xc6, 0x7f, 0x4b, 0xdb, 0x85, 0xcd, 0x3e, 0x37, 0xd3, 0x4c, 0x76, 0x38, 0x35, 0xf1, 0x5c, 0xca,
+	0xc4, 0xb7, 0x01, 0x25, 0x51, 0xa4, 0x21, 0x5e, 0xc2, 0x46, 0xf7, 0x82, 0x98, 0x99, 0x90, 0x1b,
+	0xb0, 0x66, 0x7a, 0x8e, 0x63, 0xb8, 0x56, 0x23, 0x77, 0x33, 0x7f, 0xab, 0x82, 0x23, 0x32, 0xb9,
+	0x17, 0xf3, 0x59, 0xf7, 0xa2, 0xf6, 0x77, 0x0a, 0xa8, 0xd3, 0xb9, 0xa5, 0x22, 0x99, 0xf4, 0xa1,
+	0xc5, 0x80, 0xd8, 0xdc, 0x35, 0x2c, 0x29, 0xc9, 0x8f, 0xdc, 0x85, 0xe0, 0x93, 0x20, 0x48, 0xb8,
+	0xa3, 0xfc, 0x15, 0xdd, 0x91, 0xb6, 0x07, 0xbf, 0x17, 0x89, 0xd3, 0x0f, 0x03, 0x62, 0x38, 0xb6,
+	0x3b, 0xdc, 0x3f, 0x3a, 0xf2, 0x89, 0x10, 0x1c, 0x21, 0x28, 0x58, 0x46, 0x68, 0x48, 0xc1, 0xf8,
+	0x37, 0xdb, 0xf4, 0xe6, 0xd8, 0xa3, 0xf1, 0xa6, 0xe7, 0x84, 0xf6, 0x1f, 0x79, 0x68, 0xcc, 0x41,
+	0x45, 0xea, 0x7d, 0x01, 0x45, 0x4a, 0xc2, 0x89, 0x2f, 0x4d, 0xa5, 0x9b, 0x59, 0xe0, 0xc5, 0x78,
+	0xad, 0x3e, 0x03, 0xc3, 0x02, 0x13, 0x0d, 0xa1, 0x1c, 0x86, 0x97, 0x3a, 0xb5, 0x7f, 0x11, 0x05,
+	0x04, 0x07, 0x57, 0xc5, 0x1f, 0x90, 0xc0, 0xb1, 0x5d, 0x63, 0xdc, 0xb7, 0x7f, 0x41, 0xf0, 0x5a,
+	0x18, 0x5e, 0xb2, 0x0f, 0xf4, 0x9c, 0x19, 0xbc, 0x65, 0xbb, 0x52, 0xed, 0x9d, 0x55, 0x67, 0x49,
+	0x28, 0x18, 0x0b, 0xc4, 0xe6, 0x01, 0x14, 0xf9, 0x7f, 0x5a, 0xc5, 0x10, 0x55, 0xc8, 0x87, 0xe1,
+	0x25, 0x17, 0xaa, 0x8c, 0xd9, 0x67, 0xf3, 0x3e, 0xd4, 0x92, 0xff, 0x80, 0x19, 0xd2, 0x88, 0xd8,
+	0xc3, 0x91, 0x30, 0xb0, 0x22, 0x96, 0x14, 0x5b, 0xc9, 0xd7, 0xb6, 0x25, 0x43, 0xd6, 0x22, 0x16,
+	0x84, 0xf6, 0x6f, 0x39, 0xb8, 0xb1, 0x40, 0x33, 0xd2, 0x58, 0x5f, 0xa4, 0x8c, 0xf5, 0x1d, 0x69,
+	0x21, 0xb2, 0xf8, 0x17, 0x29, 0x8b, 0x7f, 0x87, 0xe0, 0x6c, 0xdb, 0xbc, 0x07, 0x25, 0x72, 0x61,
+	0x87, 0xc4, 0x92, 0xaa, 0x92, 0x54, 0x62, 0x3b, 0x15, 0xae, 0xba, 0x9d, 0x0e, 0x61, 0xbb, 0x13,
+	0x10, 0x23, 0x24, 0xd2, 0x95, 0x47, 0xf6, 0x7f, 0x03, 0xca, 0xc6, 0x78, 0xec, 0x99, 0xd3, 0x65,
+	0x5d, 0xe3, 0xf4, 0xbe, 0x85, 0x9a, 0x50, 0x1e, 0x79, 0x34, 0x74, 0x0d, 0x87, 0x48, 0xe7, 0x15,
+	0xd3, 0xda, 0x37, 0x0a, 0x5c, 0x9f, 0xc1, 0x93, 0xab, 0x70, 0x0a, 0x75, 0x9b, 0x7a, 0x63, 0xfe,
+	0x07, 0xf5, 0xc4, 0x0d, 0xef, 0x27, 0xcb, 0x1d, 0x35, 0xfb, 0x11, 0x06, 0xbf, 0xf0, 0xad, 0xdb,
+	0x49, 0x92, 0x5b, 0x1c, 0x9f, 0xdc, 0x92, 0x3b, 0x3d, 0x22, 0xb5, 0x7f, 0x54, 0xe0, 0xba, 0x3c,
+	0xe1, 0xb3, 0xff, 0xd1, 0x79, 0x91, 0x73, 0xef, 0x5a, 0x64, 0xad, 0x01, 0xef, 0xcd, 0xca, 0x25,
+	0x7d, 0xfe, 0xb7, 0x45, 0x40, 0xf3, 0xb7, 0x4b, 0xf4, 0x7d, 0xa8, 0x51, 0xe2, 0x5a, 0xba, 0x38,
+	0x2f, 0xc4, 0x51, 0x56, 0xc6, 0x55, 0xc6, 0x13, 0x07, 0x07, 0x65, 0x2e, 0x90, 0x5c, 0x48, 0x69,
+	0xcb, 0x98, 0x7f, 0xa3, 0x11, 0xd4, 0x5e, 0x52, 0x3d, 0x9e, 0x9b, 0x1b, 0x54, 0x3d, 0xb3, 0x5b,
+	0x9b, 0x97, 0xa3, 0xf5, 0xb8, 0x1f, 0xff, 0x2f, 0x5c, 0x7d, 0x49, 0x63, 0x02, 0xfd, 0x5a, 0x81,
+	0xf7, 0xa3, 0xb0, 0x62, 0xaa, 0x3e, 0xc7, 0xb3, 0x08, 0x6d, 0x14, 0x6e, 0xe6, 0x6f, 0xd5, 0x77,
+	0x8e, 0xaf, 0xa0, 0xbf, 0x39, 0xe6, 0xa1, 0x67, 0x11, 0x7c, 0xdd, 0x5d, 0xc0, 0xa5, 0xa8, 0x05,
+	0x5b, 0xce, 0x84, 0x86, 0xba, 0xb0, 0x02, 0x5d, 0x76, 0x6a, 0x14, 0xb9, 0x5e, 0x36, 0x59, 0x53,
+	0xca, 0x56, 0xd1, 0x19, 0xac, 0x3b, 0xde, 0xc4, 0x0d, 0x75, 0x93, 0xdf, 0x7f, 0x68, 0xa3, 0xb4,
+	0xd4, 0xc5, 0x78, 0x81, 0x96, 0x0e, 0x19, 0x9c, 0xb8, 0x4d, 0x51, 0x5c, 0x73, 0x12, 0x14, 0x5b,
+	0xc8, 0x80, 0x38, 0x5e, 0x48, 0x74, 0xe6, 0x2f, 0x69, 0x63, 0x4d, 0x2c, 0xa4, 0xe0, 0x31, 0xd7,
+	0x40, 0xd1, 0xf7, 0x00, 0xcc, 0x11, 0x31, 0xcf, 0x7c, 0xcf, 0x76, 0xc3, 0x46, 0x99, 0x77, 0x48,
+	0x70, 0xb4, 0x16, 0x54, 0x13, 0xcb, 0x80, 0xca, 0x50, 0xe8, 0x1d, 0xf5, 0xba, 0xea, 0x35, 0x04,
+	0x50, 0xea, 0xec, 0xe1, 0xa3, 0xa3, 0x81, 0xb8, 0x55, 0xec, 0x1f, 0xb6, 0x9f, 0x74, 0xd5, 0x9c,
+	0xd6, 0x85, 0x5a, 0x52, 0x20, 0x84, 0xa0, 0x7e, 0xd2, 0x7b, 0xda, 0x3b, 0x7a, 0xd6, 0xd3, 0x0f,
+	0x8f, 0x4e, 0x7a, 0x03, 0x76, 0x1f, 0xa9, 0x03, 0xb4, 0x7b, 0xcf, 0xa7, 0xf4, 0x3a, 0x54, 0x7a,
+	0x47, 0x11, 0xa9, 0x34, 0x73, 0xaa, 0xa2, 0xfd, 0x36, 0x0f, 0xdb, 0x8b, 0xd6, 0x06, 0x59, 0x50,
+	0x60, 0xeb, 0x2c, 0x6f, 0x84, 0xef, 0x7e, 0x99, 0x39, 0x3a, 0x33, 0x6f, 0xdf, 0x90, 0x47, 0x40,
+	0x05, 0xf3, 0x6f, 0xa4, 0x43, 0x69, 0x6c, 0x9c, 0x92, 0x31, 0x6d, 0xe4, 0x79, 0xce, 0xe4, 0xc9,
+	0x55, 0xe6, 0x3e, 0xe0, 0x48, 0x22, 0x61, 0x22, 0x61, 0xd1, 0x00, 0xaa, 0xcc, 0xc9, 0x51, 0xa1,
+	0x3a, 0xe9, 0x77, 0x77, 0x32, 0xce, 0xb2, 0x37, 0x1d, 0x89, 0x93, 0x30, 0xcd, 0xbb, 0x50, 0x4d,
+	0x4c, 0xb6, 0x20, 0xdf, 0xb1, 0x9d, 0xcc, 0x77, 0x54, 0x92, 0xc9, 0x8b, 0x87, 0xb0, 0xbd, 0x48,
+	0x47, 0xcc, 0x08, 0xf6, 0x8e, 0xfa, 0x03, 0x71, 0xb3, 0x7c, 0x82, 0x8f, 0x4e, 0x8e, 0x55, 0x85,
+	0x31, 0x07, 0xed, 0xfe, 0x53, 0x35, 0x17, 0xdb, 0x48, 0x5e, 0xeb, 0x40, 0x35, 0x21, 0x57, 0xca,
+	0xab, 0x2b, 0x69, 0xaf, 0xce, 0xfc, 0xaa, 0x61, 0x59, 0x01, 0xa1, 0x54, 0xca, 0x11, 0x91, 0xda,
+	0x0b, 0xa8, 0xec, 0xf6, 0xfa, 0x12, 0xa2, 0x01, 0x6b, 0x94, 0x04, 0xec, 0x7f, 0xf3, 0xcc, 0x55,
+	0x05, 0x47, 0x24, 0x03, 0xa7, 0xc4, 0x08, 0xcc, 0x11, 0xa1, 0x32, 0x16, 0x88, 0x69, 0x36, 0xca,
+	0xe3, 0x19, 0x20, 0xb1, 0x76, 0x15, 0x1c, 0x91, 0xda, 0xff, 0xac, 0x01, 0x4c, 0xb3, 0x11, 0xa8,
+	0x0e, 0xb9, 0xd8, 0x47, 0xe7, 0x6c, 0x8b, 0xd9, 0x41, 0xe2, 0x0c, 0xe2, 0xdf, 0x68, 0x07, 0xae,
+	0x3b, 0x74, 0xe8, 0x1b, 0xe6, 0x99, 0x2e, 0x93, 0x08, 0x62, 0x2b, 0x73, 0x7f, 0x57, 0xc3, 0x5b,
+	0xb2, 0x51, 0xee, 0x54, 0x81, 0x7b, 0x00, 0x79, 0xe2, 0x9e, 0x73, 0xdf, 0x54, 0xdd, 0xb9, 0xb7,
+	0x74, 0x96, 0xa4, 0xd5, 0x75, 0xcf, 0x85, 0xad, 0x30, 0x18, 0xa4, 0x03, 0x58, 0xe4, 0xdc, 0x36,
+	0x89, 0xce, 0x40, 0x8b, 0x1c, 0xf4, 0x8b, 0xe5, 0x41, 0x77, 0x39, 0x46, 0x0c, 0x5d, 0xb1, 0x22,
+	0x1a, 0xf5, 0xa0, 0x12, 0x10, 0xea, 0x4d, 0x02, 0x93, 0x08, 0x07, 0x95, 0xfd, 0x22, 0x83, 0xa3,
+	0x71, 0x78, 0x0a, 0x81, 0x76, 0xa1, 0xc4, 0xfd, 0x12, 0xf3, 0x40, 0xf9, 0xdf, 0x99, 0x72, 0x4d,
+	0x83, 0x71, 0x4f, 0x82, 0xe5, 0x58, 0xf4, 0x04, 0xd6, 0x84, 0x88, 0xb4, 0x51, 0xe6, 0x30, 0x9f,
+	0x64, 0x75, 0x9a, 0x7c, 0x14, 0x8e, 0x46, 0xb3, 0x55, 0x9d, 0x50, 0x12, 0x34, 0x2a, 0x62, 0x55,
+	0xd9, 0x37, 0xfa, 0x00, 0x2a, 0xe2, 0x8c, 0xb6, 0xec, 0xa0, 0x01, 0xc2, 0x38, 0x39, 0x63, 0xd7,
+	0x0e, 0xd0, 0x87, 0x50, 0x15, 0xb1, 0x98, 0xce, 0xbd, 0x42, 0x95, 0x37, 0x83, 0x60, 0x1d, 0x33,
+	0xdf, 0x20, 0x3a, 0x90, 0x20, 0x10, 0x1d, 0x6a, 0x71, 0x07, 0x12, 0x04, 0xbc, 0xc3, 0x1f, 0xc2,
+	0x06, 0x8f, 0x60, 0x87, 0x81, 0x37, 0xf1, 0x75, 0x6e, 0x53, 0xeb, 0xbc, 0xd3, 0x3a, 0x63, 0x3f,
+	0x61, 0xdc, 0x1e, 0x33, 0xae, 0x1b, 0x50, 0x7e, 0xe5, 0x9d, 0x8a, 0x0e, 0x75, 0xb1, 0x0f, 0x5e,
+	0x79, 0xa7, 0x51, 0x53, 0x1c, 0x45, 0x6c, 0xa4, 0xa3, 0x88, 0xaf, 0xe1, 0xbd, 0xf9, 0xe3, 0x90,
+	0x47, 0x13, 0xea, 0xd5, 0xa3, 0x89, 0x6d, 0x77, 0x01, 0x17, 0x3d, 0x82, 0xbc, 0xe5, 0xd2, 0xc6,
+	0xe6, 0x52, 0xc6, 0x11, 0xef, 0x63, 0xcc, 0x06, 0x37, 0x3f, 0x83, 0x72, 0x64, 0x7d, 0xcb, 0xf8,
+	0xa5, 0xe6, 0x7d, 0xa8, 0xa7, 0x6d, 0x77, 0x29, 0xaf, 0xf6, 0x2f, 0x39, 0xa8, 0xc4, 0x56, 0x8a,
+	0x5c, 0xd8, 0xe2, 0x5a, 0x34, 0x42, 0x62, 0xe9, 0x53, 0xa3, 0x17, 0x81, 0xe3, 0x83, 0x8c, 0xff,
+	0xab, 0x1d, 0x21, 0xc8, 0x1b, 0xac, 0xdc, 0x01, 0x28, 0x46, 0x9e, 0xce, 0xf7, 0x15, 0x6c, 0x8c,
+	0x6d, 0x77, 0x72, 0x91, 0x98, 0x4b, 0x44, 0x7c, 0x7f, 0x94, 0x71, 0xae, 0x03, 0x36, 0x7a, 0x3a,
+	0x47, 0x7d, 0x9c, 0xa2, 0xd1, 0x1e, 0x14, 0x7d, 0x2f, 0x08, 0xa3, 0x43, 0x2a, 0xeb, 0xf1, 0x71,
+	0xec, 0x05, 0xe1, 0xa1, 0xe1, 0xfb, 0xec, 0x52, 0x23, 0x00, 0xb4, 0x6f, 0x72, 0xf0, 0xde, 0xe2,
+	0x3f, 0x86, 0x7a, 0x90, 0x37, 0xfd, 0x89, 0x54, 0xd2, 0xfd, 0x65, 0x95, 0xd4, 0xf1, 0x27, 0x53,
+	0xf9, 0x19, 0x10, 0x4b, 0xf4, 0x3a, 0xc4, 0xf1, 0x82, 0x4b, 0xa9, 0x8b, 0x87, 0xcb, 0x42, 0x1e,
+	0xf2, 0xd1, 0x53, 0x54, 0x09, 0x87, 0x30, 0x94, 0xa5, 0xf5, 0x52, 0xe9, 0x27, 0x97, 0x4c, 0x3b,
+	0x45, 0x90, 0x38, 0xc6, 0xd1, 0x3e, 0x83, 0xeb, 0x0b, 0xff, 0x0a, 0xfa, 0x7d, 0x00, 0xd3, 0x9f,
+	0xe8, 0xfc, 0x59, 0x40, 0x58, 0x50, 0x1e, 0x57, 0x4c, 0x7f, 0xd2, 0xe7, 0x0c, 0xed, 0x97, 0x0a,
+	0x34, 0xde, 0x24, 0x30, 0x73, 0x3f, 0x42, 0x64, 0xdd, 0x39, 0xe5, 0x4a, 0xc8, 0xe3, 0xb2, 0x60,
+	0x1c, 0x9e, 0x22, 0x0d, 0xd6, 0xa3, 0x46, 0xe3, 0x82, 0x75, 0xc8, 0xf3, 0x0e, 0x55, 0xd9, 0xc1,
+	0xb8, 0x38, 0x3c, 0x45, 0x7f, 0x00, 0xeb, 0xa3, 0xc9, 0x90, 0xf8, 0xc6, 0x90, 0x50, 0x7d, 0xc7,
+	0x39, 0xe5, 0xe1, 0x43, 0x1e, 0xd7, 0x62, 0xe6, 0x8e, 0x73, 0xaa, 0x7d, 0x9b, 0x83, 0x8d, 0x99,
+	0x3f, 0xc6, 0x2e, 0x80, 0xc2, 0x2f, 0x46, 0x57, 0x6b, 0x41, 0x31, 0x27, 0x69, 0xda, 0x56, 0x94,
+	0x94, 0xe5, 0xdf, 0xfc, 0x78, 0xf4, 0x65, 0xc2, 0x34, 0x67, 0xfb, 0x6c, 0x93, 0x39, 0xa7, 0x76,
+	0x48, 0xf9, 0x64, 0x45, 0x2c, 0x08, 0xf4, 0x1c, 0xea, 0x01, 0xe1, 0xc7, 0xb2, 0xa5, 0x0b, 0x5b,
+	0x2c, 0x2e, 0x65, 0x8b, 0x52, 0x42, 0x66, 0x92, 0x78, 0x3d, 0x42, 0x62, 0x14, 0x45, 0xcf, 0x60,
+	0xdd, 0xba, 0x74, 0x0d, 0xc7, 0x36, 0x25, 0x72, 0x69, 0x65, 0xe4, 0x9a, 0x04, 0xe2, 0xc0, 0xec,
+	0x9d, 0x26, 0xd1, 0xc8, 0xfe, 0x18, 0x0f, 0xca, 0xa4, 0x4e, 0x04, 0x91, 0xf6, 0x29, 0x45, 0xe9,
+	0x53, 0xb4, 0x53, 0xa8, 0x26, 0x76, 0xcf, 0x32, 0x43, 0x99, 0x3e, 0x43, 0x8f, 0xeb, 0xb3, 0x88,
+	0x73, 0xa1, 0xc7, 0xf2, 0x1c, 0x2c, 0x20, 0xd2, 0x6d, 0x9f, 0x6b, 0xb4, 0x82, 0x4b, 0x8c, 0xdc,
+	0xf7, 0xb5, 0xdf, 0xe4, 0xa0, 0x9e, 0xde, 0xf8, 0x91, 0xb5, 0xf9, 0x24, 0xb0, 0x3d, 0x2b, 0x61,
+	0x6d, 0xc7, 0x9c, 0xc1, 0x0c, 0x8a, 0x35, 0x7f, 0x3d, 0xf1, 0x42, 0x23, 0x32, 0x28, 0xd3, 0x9f,
+	0xfc, 0x31, 0xa3, 0x67, 0x2c, 0x35, 0x3f, 0x63, 0xa9, 0xe8, 0x63, 0x40, 0xd2, 0xde, 0xc6, 0xb6,
+	0x63, 0x87, 0xfa, 0xe9, 0x65, 0x48, 0xa8, 0x34, 0x28, 0x55, 0xb4, 0x1c, 0xb0, 0x86, 0x47, 0x8c,
+	0xcf, 0xac, 0xd3, 0xf3, 0x1c, 0x9d, 0x9a, 0x5e, 0x40, 0x74, 0xc3, 0x7a, 0xc5, 0xef, 0x3e, 0x79,
+	0x5c, 0xf5, 0x3c, 0xa7, 0xcf, 0x78, 0x6d, 0xeb, 0x15, 0x3b, 0x1f, 0x4d, 0x7f, 0x42, 0x49, 0xa8,
+	0xb3, 0x1f, 0x1e, 0x52, 0x54, 0x30, 0x08, 0x56, 0xc7, 0x9f, 0x50, 0x66, 0xbe, 0x51, 0x07, 0x7e,
+	0x44, 0xca, 0xb3, 0xb9, 0x26, 0xbb, 0x70, 0x1e, 0xd2, 0xa0, 0x76, 0x4c, 0x02, 0x93, 0xb8, 0xe1,
+	0xc0, 0x36, 0xcf, 0x28, 0xbf, 0xad, 0x28, 0x38, 0xc5, 0xfb, 0xb2, 0x50, 0x5e, 0x53, 0xcb, 0x38,
+	0x9a, 0xcd, 0x21, 0x0e, 0xd5, 0x7e, 0x0e, 0x45, 0x1e, 0x48, 0x30, 0x9d, 0xf0, 0x43, 0x98, 0x9f,
+	0xd1, 0x32, 0x00, 0x65, 0x0c, 0x7e, 0x42, 0x7f, 0x00, 0x15, 0xae, 0xfb, 0x44, 0xdc, 0xcf, 0xa3,
+	0x53, 0xde, 0xd8, 0x84, 0x72, 0x40, 0x0c, 0xcb, 0x73, 0xc7, 0x51, 0x4a, 0x29, 0xa6, 0xb5, 0xaf,
+	0xa1, 0x24, 0x4e, 0xa3, 0x2b, 0xe0, 0x7f, 0x02, 0x48, 0xfc, 0x6f, 0xb6, 0x9e, 0x8e, 0x4d, 0xa9,
+	0x8c, 0x55, 0xf9, 0x3b, 0xa6, 0x68, 0x39, 0x9e, 0x36, 0x68, 0xff, 0xa9, 0x00, 0x4c, 0x5f, 0x98,
+	0x58, 0x78, 0xcb, 0x8c, 0x9c, 0xdd, 0xb9, 0x45, 0x2a, 0x2b, 0x22, 0x59, 0x16, 0x47, 0x06, 0xa7,
+	0xb9, 0x55, 0x1f, 0xe8, 0x24, 0x40, 0x94, 0xd8, 0x26, 0xf2, 0x5a, 0xbf, 0x6c, 0x62, 0x9b, 0x88,
+	0xc4, 0x36, 0x61, 0x77, 0x52, 0x19, 0x36, 0x0b, 0xb8, 0x02, 0x8f, 0x9a, 0xab, 0x56, 0xfc, 0x7a,
+	0x40, 0xb4, 0xff, 0x56, 0x62, 0x37, 0x15, 0x65, 0xf9, 0xd1, 0x57, 0x50, 0x66, 0x3b, 0x5e, 0x77,
+	0x0c, 0x5f, 0xbe, 0x59, 0x77, 0x56, 0x7b, 0x40, 0x88, 0x8e, 0x3a, 0x11, 0xf4, 0xae, 0xf9, 0x82,
+	0x62, 0xee, 0x8e, 0x5d, 0x38, 0x22, 0x77, 0xc7, 0xbe, 0xd1, 0x47, 0x50, 0x37, 0x26, 0xa1, 0xa7,
+	0x1b, 0xd6, 0x39, 0x09, 0x42, 0x9b, 0x12, 0xb9, 0xf6, 0xeb, 0x8c, 0xdb, 0x8e, 0x98, 0xcd, 0x7b,
+	0x50, 0x4b, 0x62, 0xbe, 0x2d, 0x18, 0x29, 0x26, 0x83, 0x91, 0x3f, 0x03, 0x98, 0x66, 0xcc, 0x98,
+	0x8d, 0xb0, 0xf4, 0x9b, 0x6e, 0x46, 0x37, 0xdc, 0x22, 0x2e, 0x33, 0x46, 0x87, 0xdd, 0xba, 0xd2,
+	0xe9, 0xfc, 0x62, 0x94, 0xce, 0x67, 0x9b, 0x99, 0xed, 0xbf, 0x33, 0x7b, 0x3c, 0x8e, 0xb3, 0x78,
+	0x15, 0xcf, 0x73, 0x9e, 0x72, 0x86, 0xf6, 0x5d, 0x4e, 0xd8, 0x8a, 0x78, 0x98, 0xc9, 0x74, 0xc3,
+	0x79, 0x57, 0x4b, 0x7d, 0x17, 0x80, 0x86, 0x46, 0xc0, 0x22, 0x2b, 0x23, 0xca, 0x23, 0x36, 0xe7,
+	0xde, 0x03, 0x06, 0x51, 0xa5, 0x08, 0xae, 0xc8, 0xde, 0xed, 0x10, 0x3d, 0x80, 0x9a, 0xe9, 0x39,
+	0xfe, 0x98, 0xc8, 0xc1, 0xc5, 0xb7, 0x0e, 0xae, 0xc6, 0xfd, 0xdb, 0x61, 0x22, 0x7b, 0x59, 0xba,
+	0x6a, 0xf6, 0xf2, 0x37, 0x8a, 0x78, 0x5f, 0x4a, 0x3e, 0x6f, 0xa1, 0xe1, 0x82, 0x1a, 0x8a, 0x27,
+	0x2b, 0xbe, 0x95, 0xfd, 0xae, 0x02, 0x8a, 0xe6, 0x83, 0x2c, 0x15, 0x0b, 0x6f, 0x8e, 0x75, 0xff,
+	0x3d, 0x0f, 0x95, 0x68, 0x59, 0xe6, 0xd7, 0xfe, 0x73, 0xa8, 0xc4, 0x65, 0x3a, 0x8d, 0xdc, 0x5b,
+	0x35, 0x3c, 0xed, 0x8c, 0x5e, 0x02, 0x32, 0x86, 0xc3, 0x38, 0x86, 0xd5, 0x27, 0xd4, 0x18, 0x46,
+	0x0f, 0x7b, 0x9f, 0x2f, 0xa1, 0x87, 0xe8, 0x38, 0x3b, 0x61, 0xe3, 0xb1, 0x6a, 0x0c, 0x87, 0x29,
+	0x0e, 0xfa, 0x73, 0xb8, 0x9e, 0x9e, 0x43, 0x3f, 0xbd, 0xd4, 0x7d, 0xdb, 0x92, 0x37, 0xe9, 0xbd,
+	0x65, 0x5f, 0xd7, 0x5a, 0x29, 0xf8, 0x47, 0x97, 0xc7, 0xb6, 0x25, 0x74, 0x8e, 0x82, 0xb9, 0x86,
+	0xe6, 0x5f, 0xc2, 0xfb, 0x6f, 0xe8, 0xbe, 0x60, 0x0d, 0x7a, 0xe9, 0xaa, 0x91, 0xd5, 0x95, 0x90,
+	0x58, 0xbd, 0x7f, 0x56, 0x60, 0x73, 0xae, 0x03, 0x6a, 0x27, 0x83, 0xef, 0xdb, 0x19, 0xe7, 0xe9,
+	0x1c, 0x9f, 0x08, 0x78, 0x36, 0x16, 0x7d, 0x39, 0x13, 0x6f, 0x67, 0x8d, 0x9f, 0x44, 0xd4, 0x2a,
+	0x80, 0x24, 0x82, 0xf6, 0xaf, 0x79, 0x28, 0x47, 0xe8, 0xfc, 0x1e, 0x7c, 0x49, 0x43, 0xe2, 0xe8,
+	0x71, 0x92, 0x4e, 0xc1, 0x20, 0x58, 0x3c, 0x75, 0xf4, 0x01, 0x54, 0xd8, 0x75, 0x5b, 0x34, 0xe7,
+	0x78, 0x73, 0x99, 0x31, 0x78, 0xe3, 0x87, 0x50, 0x0d, 0xbd, 0xd0, 0x18, 0xeb, 0x21, 0x3f, 0xde,
+	0xf3, 0x62, 0x34, 0x67, 0xf1, 0xc3, 0x1d, 0xfd, 0x10, 0x36, 0xc3, 0x51, 0xe0, 0x85, 0xe1, 0x98,
+	0x85, 0x96, 0x3c, 0xd0, 0x11, 0x71, 0x49, 0x01, 0xab, 0x71, 0x83, 0x08, 0x80, 0x28, 0xf3, 0xde,
+	0xd3, 0xce, 0xcc, 0x74, 0xb9, 0x13, 0x29, 0xe0, 0xf5, 0x98, 0xcb, 0x4c, 0x9b, 0x1d, 0x9e, 0xbe,
+	0x08, 0x20, 0xb8, 0xaf, 0x50, 0x70, 0x44, 0x22, 0x1d, 0x36, 0x1c, 0x62, 0xd0, 0x49, 0x40, 0x2c,
+	0xfd, 0xa5, 0x4d, 0xc6, 0x96, 0x48, 0x5f, 0xd4, 0x33, 0xdf, 0x21, 0x22, 0xb5, 0xb4, 0x1e, 0xf3,
+	0xd1, 0xb8, 0x1e, 0xc1, 0x09, 0x9a, 0x45, 0x0e, 0xe2, 0x0b, 0x6d, 0x40, 0xb5, 0xff, 0xbc, 0x3f,
+	0xe8, 0x1e, 0xea, 0x87, 0x47, 0xbb, 0x5d, 0x59, 0x18, 0xd4, 0xef, 0x62, 0x41, 0x2a, 0xac, 0x7d,
+	0x70, 0x34, 0x68, 0x1f, 0xe8, 0x83, 0xfd, 0xce, 0xd3, 0xbe, 0x9a, 0x43, 0xd7, 0x61, 0x73, 0xb0,
+	0x87, 0x8f, 0x06, 0x83, 0x83, 0xee, 0xae, 0x7e, 0xdc, 0xc5, 0xfb, 0x47, 0xbb, 0x7d, 0x35, 0xcf,
+	0xb2, 0xad, 0x53, 0xf6, 0x60, 0xff, 0xb0, 0xab, 0x16, 0x58, 0x29, 0xc8, 0x71, 0x17, 0x77, 0xba,
+	0xbd, 0x81, 0x5a, 0xd4, 0xbe, 0xcd, 0x43, 0x35, 0xb1, 0x8a, 0xcc, 0x90, 0x03, 0x2a, 0x2e, 0x2b,
+	0x05, 0xcc, 0x3e, 0xf9, 0x43, 0xa6, 0x61, 0x8e, 0xc4, 0xea, 0x14, 0xb0, 0x20, 0xf8, 0xfd, 0xc4,
+	0xb8, 0x48, 0xec, 0xf3, 0x02, 0x2e, 0x3b, 0xc6, 0x85, 0x00, 0xf9, 0x3e, 0xd4, 0xce, 0x48, 0xe0,
+	0x92, 0xb1, 0x6c, 0x17, 0x2b, 0x52, 0x15, 0x3c, 0xd1, 0xe5, 0x16, 0xa8, 0xb2, 0xcb, 0x14, 0x46,
+	0x2c, 0x47, 0x5d, 0xf0, 0x0f, 0x23, 0xb0, 0x6d, 0x28, 0x8a, 0xe6, 0x35, 0x31, 0x3f, 0x27, 0xd8,
+	0x31, 0x45, 0x5f, 0x1b, 0x3e, 0x0f, 0xf9, 0x0a, 0x98, 0x7f, 0xa3, 0xd3, 0xf9, 0xf5, 0x29, 0xf1,
+	0xf5, 0xb9, 0xbb, 0xbc, 0x39, 0xbf, 0x69, 0x89, 0x46, 0xf1, 0x12, 0xad, 0x41, 0x1e, 0x47, 0xd5,
+	0x34, 0x9d, 0x76, 0x67, 0x8f, 0x2d, 0xcb, 0x3a, 0x54, 0x0e, 0xdb, 0x3f, 0xd3, 0x4f, 0xfa, 0x3c,
+	0xf7, 0x8d, 0x54, 0xa8, 0x3d, 0xed, 0xe2, 0x5e, 0xf7, 0x40, 0x72, 0xf2, 0x68, 0x1b, 0x54, 0xc9,
+	0x99, 0xf6, 0x2b, 0x30, 0x04, 0xf1, 0x59, 0x64, 0xb9, 0xd2, 0xfe, 0xb3, 0xf6, 0xb1, 0x5a, 0xd2,
+	0xfe, 0x2b, 0x07, 0x1b, 0xe2, 0x58, 0x88, 0xdf, 0xfd, 0xdf, 0xfc, 0xee, 0x99, 0xcc, 0x05, 0xe5,
+	0xd2, 0xb9, 0xa0, 0x28, 0x08, 0xe5, 0xa7, 0x7a, 0x7e, 0x1a, 0x84, 0xf2, 0x1c, 0x52, 0xca, 0xe3,
+	0x17, 0x96, 0xf1, 0xf8, 0x0d, 0x58, 0x73, 0x08, 0x8d, 0xd7, 0xad, 0x82, 0x23, 0x12, 0xd9, 0x50,
+	0x35, 0x5c, 0xd7, 0x0b, 0x0d, 0x91, 0x60, 0x2d, 0x2d, 0x75, 0x18, 0xce, 0xfc, 0xe3, 0x56, 0x7b,
+	0x8a, 0x24, 0x1c, 0x73, 0x12, 0xbb, 0xf9, 0x53, 0x50, 0x67, 0x3b, 0x2c, 0x75, 0x1c, 0x3e, 0x82,
+	0xeb, 0x9d, 0xf8, 0x69, 0x23, 0x53, 0xa5, 0x83, 0x0a, 0x79, 0x96, 0x10, 0x14, 0x48, 0xec, 0x93,
+	0xbd, 0xa6, 0xcd, 0x62, 0xc8, 0xd7, 0x34, 0x87, 0x15, 0xec, 0xd1, 0xd0, 0x0b, 0xc8, 0xbb, 0xaf,
+	0x90, 0x5b, 0x20, 0xc8, 0x6f, 0x15, 0xd8, 0x4a, 0xcd, 0x37, 0xad, 0xcb, 0x92, 0x25, 0x6b, 0xca,
+	0xff, 0x47, 0xc9, 0x5a, 0xee, 0x9d, 0x56, 0xef, 0xfc, 0xe0, 0x47, 0xd3, 0x00, 0x85, 0x30, 0x57,
+	0x25, 0x1f, 0x8b, 0xd4, 0x6b, 0x8c, 0xc0, 0x27, 0xbd, 0xde, 0x7e, 0xef, 0x89, 0xaa, 0xb0, 0xd7,
+	0xa6, 0xee, 0xcf, 0xf6, 0x59, 0xd1, 0x64, 0x6e, 0xe7, 0xbb, 0x2d, 0x28, 0x09, 0xbb, 0x41, 0xdf,
+	0xc8, 0xe0, 0x2c, 0x59, 0xe6, 0x8b, 0x7e, 0xba, 0xb4, 0x8e, 0x53, 0xa5, 0xc3, 0xcd, 0x87, 0x2b,
+	0x8f, 0x97, 0x86, 0x70, 0x0d, 0xfd, 0x8d, 0x02, 0xb5, 0xd4, 0x93, 0x6a, 0xd6, 0x9c, 0xff, 0x82,
+	0xaa, 0xe2, 0xe6, 0x4f, 0x56, 0x1a, 0x1b, 0xcb, 0xf2, 0x6b, 0x05, 0xaa, 0x89, 0x7a, 0x5a, 0x74,
+	0x77, 0x95, 0x1a, 0x5c, 0x21, 0xc9, 0xbd, 0xd5, 0xcb, 0x77, 0xb5, 0x6b, 0x9f, 0x2a, 0xe8, 0xaf,
+	0x15, 0xa8, 0x26, 0x2a, 0x4b, 0x33, 0x8b, 0x32, 0x5f, 0x07, 0xdb, 0xbc, 0xb7, 0xca, 0xd0, 0x58,
+	0x27, 0xbf, 0x54, 0xa0, 0x12, 0x57, 0x89, 0xa2, 0x3b, 0xcb, 0xd7, 0x95, 0x0a, 0x21, 0x3e, 0x5f,
+	0xb5, 0x20, 0x55, 0xbb, 0x86, 0xfe, 0x02, 0xca, 0x51, 0x49, 0x25, 0xca, 0xba, 0x9b, 0x66, 0xea,
+	0x35, 0x9b, 0x77, 0x96, 0x1e, 0x97, 0x9c, 0x3e, 0xaa, 0x73, 0xcc, 0x3c, 0xfd, 0x4c, 0x45, 0x66,
+	0xf3, 0xce, 0xd2, 0xe3, 0xe2, 0xe9, 0x99, 0x25, 0x24, 0xca, 0x21, 0x33, 0x5b, 0xc2, 0x7c, 0x1d,
+	0x66, 0xf3, 0xde, 0x2a, 0x43, 0x53, 0x82, 0x24, 0x0a, 0x2a, 0x33, 0x0b, 0x32, 0x5f, 0xb4, 0xd9,
+	0xbc, 0xb7, 0xca, 0xd0, 0x58, 0x90, 0x5f, 0x29, 0xc9, 0xab, 0xda, 0x9d, 0xa5, 0xeb, 0x06, 0x97,
+	0x34, 0xc9, 0xb9, 0xca, 0x45, 0xbe, 0x41, 0x7f, 0x25, 0x13, 0x4b, 0xa2, 0xec, 0x10, 0x2d, 0x03,
+	0x96, 0xaa, 0x54, 0x6c, 0x7e, 0xb6, 0xda, 0xf9, 0xcf, 0x85, 0xf8, 0x2b, 0x05, 0x60, 0x5a, 0xa0,
+	0x98, 0x59, 0x88, 0xb9, 0xca, 0xc8, 0xe6, 0xdd, 0x15, 0x46, 0x26, 0x37, 0x48, 0x54, 0x40, 0x95,
+	0x79, 0x83, 0xcc, 0x14, 0x50, 0x36, 0xef, 0x2c, 0x3d, 0x2e, 0x9e, 0xfe, 0x9f, 0x14, 0xd8, 0x9c,
+	0x2b, 0xe0, 0x42, 0x0f, 0xaf, 0x58, 0xc3, 0xd7, 0xfc, 0x62, 0x75, 0x80, 0x48, 0xb4, 0x5b, 0xca,
+	0xa7, 0x0a, 0xfa, 0x5b, 0x05, 0xd6, 0xd3, 0x85, 0x2d, 0x99, 0x4f, 0xa9, 0x05, 0xa5, 0x60, 0xcd,
+	0xfb, 0xab, 0x0d, 0x8e, 0xb5, 0xf5, 0xf7, 0x0a, 0xd4, 0xe5, 0xfe, 0x8e, 0xe4, 0xb9, 0xbf, 0x9c,
+	0x5b, 0x98, 0x11, 0xe8, 0xc1, 0x8a, 0xa3, 0x53, 0x12, 0xa5, 0xe3, 0xc4, 0xcc, 0x12, 0x2d, 0x0c,
+	0x51, 0x9b, 0x0f, 0x56, 0x1c, 0x9d, 0xf2, 0x74, 0x89, 0x78, 0x71, 0x89, 0xc3, 0x77, 0x36, 0xa6,
+	0x6d, 0xde, 0x5b, 0x65, 0x68, 0x24, 0xc8, 0xa3, 0xb5, 0x3f, 0x29, 0x8a, 0xbb, 0x46, 0x89, 0xff,
+	0xfc, 0xf8, 0xff, 0x06, 0x00, 0xb3, 0xfb, 0xca, 0x57, 0xa8, 0x36, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3986,6 +4195,12 @@ type DriverClient interface {
 	// DestroyNetwork destroys a previously created network. This rpc is only
 	// implemented if the driver needs to manage network namespace creation.
 	DestroyNetwork(ctx context.Context, in *DestroyNetworkRequest, opts ...grpc.CallOption) (*DestroyNetworkResponse, error)
+	// CheckpointTask saves the state of a running task to a directory, so
+	// that it can be restored if it doesn't survive a planned disconnect of
+	// the node.
+	CheckpointTask(ctx context.Context, in *CheckpointTaskRequest, opts ...grpc.CallOption) (*CheckpointTaskResponse, error)
+	// RestoreTask starts a task from the state saved by CheckpointTask
+	RestoreTask(ctx context.Context, in *RestoreTaskRequest, opts ...grpc.CallOption) (*RestoreTaskResponse, error)
 }
 
 type driverClient struct {
@@ -4231,6 +4446,24 @@ func (c *driverClient) DestroyNetwork(ctx context.Context, in *DestroyNetworkReq
 	return out, nil
 }
 
+func (c *driverClient) CheckpointTask(ctx context.Context, in *CheckpointTaskRequest, opts ...grpc.CallOption) (*CheckpointTaskResponse, error) {
+	out := new(CheckpointTaskResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/CheckpointTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverClient) RestoreTask(ctx context.Context, in *RestoreTaskRequest, opts ...grpc.CallOption) (*RestoreTaskResponse, error) {
+	out := new(RestoreTaskResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/RestoreTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DriverServer is the server API for Driver service.
 type DriverServer interface {
 	// TaskConfigSchema returns the schema for parsing the driver
@@ -4286,6 +4519,12 @@ type DriverServer interface {
 	// DestroyNetwork destroys a previously created network. This rpc is only
 	// implemented if the driver needs to manage network namespace creation.
 	DestroyNetwork(context.Context, *DestroyNetworkRequest) (*DestroyNetworkResponse, error)
+	// CheckpointTask saves the state of a running task to a directory, so
+	// that it can be restored if it doesn't survive a planned disconnect of
+	// the node.
+	CheckpointTask(context.Context, *CheckpointTaskRequest) (*CheckpointTaskResponse, error)
+	// RestoreTask starts a task from the state saved by CheckpointTask
+	RestoreTask(context.Context, *RestoreTaskRequest) (*RestoreTaskResponse, error)
 }
 
 // UnimplementedDriverServer can be embedded to have forward compatible implementations.
@@ -4340,6 +4579,12 @@ func (*UnimplementedDriverServer) CreateNetwork(ctx context.Context, req *Create
 func (*UnimplementedDriverServer) DestroyNetwork(ctx context.Context, req *DestroyNetworkRequest) (*DestroyNetworkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DestroyNetwork not implemented")
 }
+func (*UnimplementedDriverServer) CheckpointTask(ctx context.Context, req *CheckpointTaskRequest) (*CheckpointTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckpointTask not implemented")
+}
+func (*UnimplementedDriverServer) RestoreTask(ctx context.Context, req *RestoreTaskRequest) (*RestoreTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreTask not implemented")
+}
 
 func RegisterDriverServer(s *grpc.Server, srv DriverServer) {
 	s.RegisterService(&_Driver_serviceDesc, srv)
@@ -4650,6 +4895,42 @@ func _Driver_DestroyNetwork_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Driver_CheckpointTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckpointTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).CheckpointTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/CheckpointTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).CheckpointTask(ctx, req.(*CheckpointTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Driver_RestoreTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).RestoreTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/RestoreTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).RestoreTask(ctx, req.(*RestoreTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Driver_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hashicorp.nomad.plugins.drivers.proto.Driver",
 	HandlerType: (*DriverServer)(nil),
@@ -4702,6 +4983,14 @@ var _Driver_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DestroyNetwork",
 			Handler:    _Driver_DestroyNetwork_Handler,
 		},
+		{
+			MethodName: "CheckpointTask",
+			Handler:    _Driver_CheckpointTask_Handler,
+		},
+		{
+			MethodName: "RestoreTask",
+			Handler:    _Driver_RestoreTask_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
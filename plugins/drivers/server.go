@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"math"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/hashicorp/go-plugin"
@@ -46,6 +45,7 @@ func (b *driverPluginServer) Capabilities(ctx context.Context, req *proto.Capabi
 			MustCreateNetwork:     caps.MustInitiateNetwork,
 			NetworkIsolationModes: []proto.NetworkIsolationSpec_NetworkIsolationMode{},
 			RemoteTasks:           caps.RemoteTasks,
+			Checkpoint:            caps.Checkpoint,
 		},
 	}
 
@@ -119,19 +119,9 @@ func (b *driverPluginServer) StartTask(ctx context.Context, req *proto.StartTask
 		return nil, err
 	}
 
-	var pbNet *proto.NetworkOverride
-	if net != nil {
-		pbNet = &proto.NetworkOverride{
-			PortMap:       map[string]int32{},
-			Addr:          net.IP,
-			AutoAdvertise: net.AutoAdvertise,
-		}
-		for k, v := range net.PortMap {
-			if v > math.MaxInt32 {
-				return nil, fmt.Errorf("port map out of bounds")
-			}
-			pbNet.PortMap[k] = int32(v)
-		}
+	pbNet, err := networkOverrideToProto(net)
+	if err != nil {
+		return nil, err
 	}
 
 	resp := &proto.StartTaskResponse{
@@ -417,3 +407,38 @@ func (b *driverPluginServer) DestroyNetwork(ctx context.Context, req *proto.Dest
 
 	return &proto.DestroyNetworkResponse{}, nil
 }
+
+func (b *driverPluginServer) CheckpointTask(ctx context.Context, req *proto.CheckpointTaskRequest) (*proto.CheckpointTaskResponse, error) {
+	cd, ok := b.impl.(CheckpointDriver)
+	if !ok {
+		return nil, fmt.Errorf("CheckpointTask RPC not supported by driver")
+	}
+
+	if err := cd.CheckpointTask(ctx, req.TaskId, req.Dir); err != nil {
+		return nil, err
+	}
+
+	return &proto.CheckpointTaskResponse{}, nil
+}
+
+func (b *driverPluginServer) RestoreTask(ctx context.Context, req *proto.RestoreTaskRequest) (*proto.RestoreTaskResponse, error) {
+	cd, ok := b.impl.(CheckpointDriver)
+	if !ok {
+		return nil, fmt.Errorf("RestoreTask RPC not supported by driver")
+	}
+
+	handle, net, err := cd.RestoreTask(taskConfigFromProto(req.Task), req.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	pbNet, err := networkOverrideToProto(net)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.RestoreTaskResponse{
+		Handle:          taskHandleToProto(handle),
+		NetworkOverride: pbNet,
+	}, nil
+}
@@ -238,6 +238,8 @@ type MockDriver struct {
 	SignalTaskF        func(string, string) error
 	ExecTaskF          func(string, []string, time.Duration) (*drivers.ExecTaskResult, error)
 	ExecTaskStreamingF func(context.Context, string, *drivers.ExecOptions) (*drivers.ExitResult, error)
+	CheckpointTaskF    func(context.Context, string, string) error
+	RestoreTaskF       func(*drivers.TaskConfig, string) (*drivers.TaskHandle, *drivers.DriverNetwork, error)
 	MockNetworkManager
 }
 
@@ -291,6 +293,13 @@ func (d *MockDriver) ExecTaskStreaming(ctx context.Context, taskID string, execO
 	return d.ExecTaskStreamingF(ctx, taskID, execOpts)
 }
 
+func (d *MockDriver) CheckpointTask(ctx context.Context, taskID string, dir string) error {
+	return d.CheckpointTaskF(ctx, taskID, dir)
+}
+func (d *MockDriver) RestoreTask(c *drivers.TaskConfig, dir string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	return d.RestoreTaskF(c, dir)
+}
+
 // SetEnvvars sets path and host env vars depending on the FS isolation used.
 func SetEnvvars(envBuilder *taskenv.Builder, fsi drivers.FSIsolation, taskDir *allocdir.TaskDir, conf *config.Config) {
 
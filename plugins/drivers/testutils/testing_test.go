@@ -35,6 +35,44 @@ func TestDriverHarness(t *testing.T) {
 	require.Equal(t, handle.Config.Name, actual.Config.Name)
 }
 
+func TestDriverHarness_Checkpoint(t *testing.T) {
+	ci.Parallel(t)
+
+	handle := &drivers.TaskHandle{Config: &drivers.TaskConfig{Name: "mock"}}
+	var checkpointed string
+	d := &MockDriver{
+		CapabilitiesF: func() (*drivers.Capabilities, error) {
+			return &drivers.Capabilities{Checkpoint: true}, nil
+		},
+		CheckpointTaskF: func(_ context.Context, taskID string, dir string) error {
+			checkpointed = taskID + ":" + dir
+			return nil
+		},
+		RestoreTaskF: func(task *drivers.TaskConfig, dir string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+			require.Equal(t, "/checkpoint", dir)
+			return handle, &drivers.DriverNetwork{IP: "10.0.0.1", PortMap: map[string]int{"http": 80}}, nil
+		},
+	}
+	harness := NewDriverHarness(t, d)
+	defer harness.Kill()
+
+	// The capability and the RPCs are carried over the plugin protocol
+	caps, err := harness.Capabilities()
+	require.NoError(t, err)
+	require.True(t, caps.Checkpoint)
+
+	cd, ok := harness.DriverPlugin.(drivers.CheckpointDriver)
+	require.True(t, ok)
+	require.NoError(t, cd.CheckpointTask(context.Background(), "task", "/checkpoint"))
+	require.Equal(t, "task:/checkpoint", checkpointed)
+
+	actual, net, err := cd.RestoreTask(&drivers.TaskConfig{}, "/checkpoint")
+	require.NoError(t, err)
+	require.Equal(t, handle.Config.Name, actual.Config.Name)
+	require.Equal(t, "10.0.0.1", net.IP)
+	require.Equal(t, 80, net.PortMap["http"])
+}
+
 type testDriverState struct {
 	Pid int
 	Log string
@@ -1,6 +1,8 @@
 package drivers
 
 import (
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
@@ -111,6 +113,7 @@ func ResourcesFromProto(pb *proto.Resources) *Resources {
 		if pb.AllocatedResources.Memory != nil {
 			r.NomadResources.Memory.MemoryMB = pb.AllocatedResources.Memory.MemoryMb
 			r.NomadResources.Memory.MemoryMaxMB = pb.AllocatedResources.Memory.MemoryMaxMb
+			r.NomadResources.Memory.HugePages2MB = pb.AllocatedResources.Memory.Hugepages_2Mb
 		}
 
 		for _, network := range pb.AllocatedResources.Networks {
@@ -176,8 +179,9 @@ func ResourcesToProto(r *Resources) *proto.Resources {
 				CpuShares: r.NomadResources.Cpu.CpuShares,
 			},
 			Memory: &proto.AllocatedMemoryResources{
-				MemoryMb:    r.NomadResources.Memory.MemoryMB,
-				MemoryMaxMb: r.NomadResources.Memory.MemoryMaxMB,
+				MemoryMb:      r.NomadResources.Memory.MemoryMB,
+				MemoryMaxMb:   r.NomadResources.Memory.MemoryMaxMB,
+				Hugepages_2Mb: r.NomadResources.Memory.HugePages2MB,
 			},
 			Networks: make([]*proto.NetworkResource, len(r.NomadResources.Networks)),
 		}
@@ -356,6 +360,39 @@ func taskHandleToProto(handle *TaskHandle) *proto.TaskHandle {
 	}
 }
 
+func networkOverrideToProto(net *DriverNetwork) (*proto.NetworkOverride, error) {
+	if net == nil {
+		return nil, nil
+	}
+	pb := &proto.NetworkOverride{
+		PortMap:       map[string]int32{},
+		Addr:          net.IP,
+		AutoAdvertise: net.AutoAdvertise,
+	}
+	for k, v := range net.PortMap {
+		if v > math.MaxInt32 {
+			return nil, fmt.Errorf("port map out of bounds")
+		}
+		pb.PortMap[k] = int32(v)
+	}
+	return pb, nil
+}
+
+func networkOverrideFromProto(pb *proto.NetworkOverride) *DriverNetwork {
+	if pb == nil {
+		return nil
+	}
+	net := &DriverNetwork{
+		PortMap:       map[string]int{},
+		IP:            pb.Addr,
+		AutoAdvertise: pb.AutoAdvertise,
+	}
+	for k, v := range pb.PortMap {
+		net.PortMap[k] = int(v)
+	}
+	return net
+}
+
 func exitResultToProto(result *ExitResult) *proto.ExitResult {
 	if result == nil {
 		return &proto.ExitResult{}
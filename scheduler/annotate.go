@@ -11,6 +11,16 @@ const (
 	AnnotationForcesDestroy           = "forces destroy"
 	AnnotationForcesInplaceUpdate     = "forces in-place update"
 	AnnotationForcesDestructiveUpdate = "forces create/destroy update"
+
+	// Annotations for changes to how allocations behave while their client
+	// is disconnected. Changes to max_client_disconnect only apply to
+	// allocations placed or updated with the new version of the job.
+	AnnotationDisconnectWaits        = "new allocations wait for disconnected clients"
+	AnnotationDisconnectReplaced     = "new allocations are replaced when disconnected"
+	AnnotationDisconnectWaitsLonger  = "new allocations wait longer for disconnected clients"
+	AnnotationDisconnectWaitsLess    = "new allocations wait less for disconnected clients"
+	AnnotationDisconnectReplacesNow  = "unknown allocations over the limit are replaced immediately"
+	AnnotationDisconnectWaitsForMore = "more unknown allocations wait for their clients"
 )
 
 // UpdateTypes denote the type of update to occur against the task group.
@@ -22,6 +32,7 @@ const (
 	UpdateTypeCanary            = "canary"
 	UpdateTypeInplaceUpdate     = "in-place update"
 	UpdateTypeDestructiveUpdate = "create/destroy update"
+	UpdateTypeReplaceUnknown    = "replace unknown"
 )
 
 // Annotate takes the diff between the old and new version of a Job, the
@@ -29,14 +40,24 @@ const (
 // human understanding of the plan.
 //
 // Currently the things that are annotated are:
+// * Job changes will be annotated with:
+//   - Disconnect strategy changes
+//
 // * Task group changes will be annotated with:
 //   - Count up and count down changes
 //   - Update counts (creates, destroys, migrates, etc)
+//   - max_client_disconnect changes
 //
 // * Task changes will be annotated with:
 //   - forces create/destroy update
 //   - forces in-place update
 func Annotate(diff *structs.JobDiff, annotations *structs.PlanAnnotations) error {
+	for _, objDiff := range diff.Objects {
+		if objDiff.Name == "Disconnect" {
+			annotateDisconnectStrategy(objDiff)
+		}
+	}
+
 	tgDiffs := diff.TaskGroups
 	if len(tgDiffs) == 0 {
 		return nil
@@ -82,6 +103,9 @@ func annotateTaskGroup(diff *structs.TaskGroupDiff, annotations *structs.PlanAnn
 			if tg.DestructiveUpdate != 0 {
 				diff.Updates[UpdateTypeDestructiveUpdate] = tg.DestructiveUpdate
 			}
+			if tg.ReplaceUnknown != 0 {
+				diff.Updates[UpdateTypeReplaceUnknown] = tg.ReplaceUnknown
+			}
 		}
 	}
 
@@ -90,6 +114,11 @@ func annotateTaskGroup(diff *structs.TaskGroupDiff, annotations *structs.PlanAnn
 		return err
 	}
 
+	// Annotate the disconnect behavior
+	if err := annotateMaxClientDisconnect(diff); err != nil {
+		return err
+	}
+
 	// Annotate the tasks.
 	taskDiffs := diff.Tasks
 	if len(taskDiffs) == 0 {
@@ -147,6 +176,79 @@ func annotateCountChange(diff *structs.TaskGroupDiff) error {
 	return nil
 }
 
+// annotateMaxClientDisconnect takes a task group diff and annotates changes
+// to max_client_disconnect with how allocations will behave when their client
+// is disconnected.
+func annotateMaxClientDisconnect(diff *structs.TaskGroupDiff) error {
+	var mcdDiff *structs.FieldDiff
+	for _, diff := range diff.Fields {
+		if diff.Name == "MaxClientDisconnect" {
+			mcdDiff = diff
+			break
+		}
+	}
+	if mcdDiff == nil {
+		return nil
+	}
+
+	switch {
+	case mcdDiff.Old == "" && mcdDiff.New == "":
+		return nil
+	case mcdDiff.Old == "":
+		mcdDiff.Annotations = append(mcdDiff.Annotations, AnnotationDisconnectWaits)
+		return nil
+	case mcdDiff.New == "":
+		mcdDiff.Annotations = append(mcdDiff.Annotations, AnnotationDisconnectReplaced)
+		return nil
+	}
+
+	oldV, err := strconv.ParseInt(mcdDiff.Old, 10, 64)
+	if err != nil {
+		return err
+	}
+	newV, err := strconv.ParseInt(mcdDiff.New, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	if oldV < newV {
+		mcdDiff.Annotations = append(mcdDiff.Annotations, AnnotationDisconnectWaitsLonger)
+	} else if newV < oldV {
+		mcdDiff.Annotations = append(mcdDiff.Annotations, AnnotationDisconnectWaitsLess)
+	}
+	return nil
+}
+
+// annotateDisconnectStrategy takes the diff of the job's disconnect strategy
+// and annotates changes to the number of allocations that may be unknown.
+func annotateDisconnectStrategy(diff *structs.ObjectDiff) {
+	for _, fDiff := range diff.Fields {
+		if fDiff.Name != "MaxUnknown" || fDiff.Type == structs.DiffTypeNone {
+			continue
+		}
+
+		// Without a disconnect strategy, any number of allocations may be
+		// unknown.
+		switch {
+		case fDiff.Old == "":
+			fDiff.Annotations = append(fDiff.Annotations, AnnotationDisconnectReplacesNow)
+		case fDiff.New == "":
+			fDiff.Annotations = append(fDiff.Annotations, AnnotationDisconnectWaitsForMore)
+		default:
+			oldV, err1 := strconv.Atoi(fDiff.Old)
+			newV, err2 := strconv.Atoi(fDiff.New)
+			if err1 != nil || err2 != nil {
+				continue
+			}
+			if newV < oldV {
+				fDiff.Annotations = append(fDiff.Annotations, AnnotationDisconnectReplacesNow)
+			} else if oldV < newV {
+				fDiff.Annotations = append(fDiff.Annotations, AnnotationDisconnectWaitsForMore)
+			}
+		}
+	}
+}
+
 // annotateCountChange takes a task diff and annotates it.
 func annotateTask(diff *structs.TaskDiff, parent *structs.TaskGroupDiff) {
 	if diff.Type == structs.DiffTypeNone {
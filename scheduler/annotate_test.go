@@ -21,6 +21,7 @@ func TestAnnotateTaskGroup_Updates(t *testing.T) {
 				InPlaceUpdate:     5,
 				DestructiveUpdate: 6,
 				Canary:            7,
+				ReplaceUnknown:    8,
 			},
 		},
 	}
@@ -40,6 +41,7 @@ func TestAnnotateTaskGroup_Updates(t *testing.T) {
 			UpdateTypeInplaceUpdate:     5,
 			UpdateTypeDestructiveUpdate: 6,
 			UpdateTypeCanary:            7,
+			UpdateTypeReplaceUnknown:    8,
 		},
 	}
 
@@ -106,6 +108,80 @@ func TestAnnotateCountChange(t *testing.T) {
 	}
 }
 
+func TestAnnotateMaxClientDisconnect(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name     string
+		old, new string
+		expected []string
+	}{
+		{"added", "", "60000000000", []string{AnnotationDisconnectWaits}},
+		{"removed", "60000000000", "", []string{AnnotationDisconnectReplaced}},
+		{"increased", "60000000000", "120000000000", []string{AnnotationDisconnectWaitsLonger}},
+		{"decreased", "120000000000", "60000000000", []string{AnnotationDisconnectWaitsLess}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tgDiff := &structs.TaskGroupDiff{
+				Type: structs.DiffTypeEdited,
+				Fields: []*structs.FieldDiff{{
+					Type: structs.DiffTypeEdited,
+					Name: "MaxClientDisconnect",
+					Old:  tc.old,
+					New:  tc.new,
+				}},
+			}
+			if err := annotateMaxClientDisconnect(tgDiff); err != nil {
+				t.Fatalf("annotateMaxClientDisconnect(%#v) failed: %v", tgDiff, err)
+			}
+			if got := tgDiff.Fields[0].Annotations; !reflect.DeepEqual(got, tc.expected) {
+				t.Fatalf("got %#v, want %#v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestAnnotate_DisconnectStrategy(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name     string
+		old, new string
+		expected []string
+	}{
+		{"added", "", "1", []string{AnnotationDisconnectReplacesNow}},
+		{"removed", "1", "", []string{AnnotationDisconnectWaitsForMore}},
+		{"increased", "1", "2", []string{AnnotationDisconnectWaitsForMore}},
+		{"decreased", "2", "1", []string{AnnotationDisconnectReplacesNow}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			jobDiff := &structs.JobDiff{
+				Type: structs.DiffTypeEdited,
+				Objects: []*structs.ObjectDiff{{
+					Type: structs.DiffTypeEdited,
+					Name: "Disconnect",
+					Fields: []*structs.FieldDiff{{
+						Type: structs.DiffTypeEdited,
+						Name: "MaxUnknown",
+						Old:  tc.old,
+						New:  tc.new,
+					}},
+				}},
+			}
+			if err := Annotate(jobDiff, nil); err != nil {
+				t.Fatalf("Annotate(%#v) failed: %v", jobDiff, err)
+			}
+			if got := jobDiff.Objects[0].Fields[0].Annotations; !reflect.DeepEqual(got, tc.expected) {
+				t.Fatalf("got %#v, want %#v", got, tc.expected)
+			}
+		})
+	}
+}
+
 func TestAnnotateTask_NonEdited(t *testing.T) {
 	ci.Parallel(t)
 
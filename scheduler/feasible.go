@@ -533,6 +533,11 @@ type DistinctHostsIterator struct {
 	// they don't have to be calculated every time Next() is called.
 	tgDistinctHosts  bool
 	jobDistinctHosts bool
+
+	// canaryDistinctHosts is set when placing a canary for a task group whose
+	// update stanza requires canaries to avoid nodes running previous
+	// versions of the group.
+	canaryDistinctHosts bool
 }
 
 // NewDistinctHostsIterator creates a DistinctHostsIterator from a source.
@@ -546,6 +551,14 @@ func NewDistinctHostsIterator(ctx Context, source FeasibleIterator) *DistinctHos
 func (iter *DistinctHostsIterator) SetTaskGroup(tg *structs.TaskGroup) {
 	iter.tg = tg
 	iter.tgDistinctHosts = iter.hasDistinctHostsConstraint(tg.Constraints)
+	iter.canaryDistinctHosts = false
+}
+
+// SetCanary sets whether the allocation being placed is a canary. It must be
+// called after SetTaskGroup.
+func (iter *DistinctHostsIterator) SetCanary(canary bool) {
+	iter.canaryDistinctHosts = canary && iter.tg != nil &&
+		iter.tg.Update != nil && iter.tg.Update.CanaryDistinctHosts
 }
 
 func (iter *DistinctHostsIterator) SetJob(job *structs.Job) {
@@ -570,7 +583,7 @@ func (iter *DistinctHostsIterator) Next() *structs.Node {
 
 		// Hot-path if the option is nil or there are no distinct_hosts or
 		// distinct_property constraints.
-		hosts := iter.jobDistinctHosts || iter.tgDistinctHosts
+		hosts := iter.jobDistinctHosts || iter.tgDistinctHosts || iter.canaryDistinctHosts
 		if option == nil || !hosts {
 			return option
 		}
@@ -581,6 +594,11 @@ func (iter *DistinctHostsIterator) Next() *structs.Node {
 			continue
 		}
 
+		if !iter.satisfiesCanaryDistinctHosts(option) {
+			iter.ctx.Metrics().FilterNode(option, "canary_distinct_hosts")
+			continue
+		}
+
 		return option
 	}
 }
@@ -615,6 +633,31 @@ func (iter *DistinctHostsIterator) satisfiesDistinctHosts(option *structs.Node)
 	return true
 }
 
+// satisfiesCanaryDistinctHosts checks that a canary isn't placed on a node
+// running an allocation of a previous version of its task group.
+func (iter *DistinctHostsIterator) satisfiesCanaryDistinctHosts(option *structs.Node) bool {
+	if !iter.canaryDistinctHosts {
+		return true
+	}
+
+	proposed, err := iter.ctx.ProposedAllocs(option.ID)
+	if err != nil {
+		iter.ctx.Logger().Named("distinct_hosts").Error("failed to get proposed allocations", "error", err)
+		return false
+	}
+
+	for _, alloc := range proposed {
+		if alloc.JobID != iter.job.ID || alloc.TaskGroup != iter.tg.Name {
+			continue
+		}
+		if alloc.Job != nil && alloc.Job.Version != iter.job.Version {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (iter *DistinctHostsIterator) Reset() {
 	iter.source.Reset()
 }
@@ -786,6 +829,9 @@ func resolveTarget(target string, node *structs.Node) (string, bool) {
 	case "${node.class}" == target:
 		return node.NodeClass, true
 
+	case "${node.disconnect_domain}" == target:
+		return node.DisconnectDomain, node.DisconnectDomain != ""
+
 	case strings.HasPrefix(target, "${attr."):
 		attr := strings.TrimSuffix(strings.TrimPrefix(target, "${attr."), "}")
 		val, ok := node.Attributes[attr]
@@ -947,6 +947,11 @@ func TestResolveConstraintTarget(t *testing.T) {
 			val:    node.NodeClass,
 			result: true,
 		},
+		{
+			target: "${node.disconnect_domain}",
+			node:   node,
+			result: false,
+		},
 		{
 			target: "${node.foo}",
 			node:   node,
@@ -978,6 +983,15 @@ func TestResolveConstraintTarget(t *testing.T) {
 		},
 	}
 
+	domainNode := mock.Node()
+	domainNode.DisconnectDomain = "rack-a"
+	cases = append(cases, tcase{
+		target: "${node.disconnect_domain}",
+		node:   domainNode,
+		val:    "rack-a",
+		result: true,
+	})
+
 	for _, tc := range cases {
 		res, ok := resolveTarget(tc.target, tc.node)
 		if ok != tc.result {
@@ -1520,6 +1534,67 @@ func TestDistinctHostsIterator_TaskGroupDistinctHosts(t *testing.T) {
 	}
 }
 
+func TestDistinctHostsIterator_CanaryDistinctHosts(t *testing.T) {
+	ci.Parallel(t)
+
+	_, ctx := testContext(t)
+	nodes := []*structs.Node{
+		mock.Node(),
+		mock.Node(),
+		mock.Node(),
+	}
+	static := NewStaticIterator(ctx, nodes)
+
+	job := &structs.Job{
+		ID:        "foo",
+		Namespace: structs.DefaultNamespace,
+		Version:   2,
+	}
+	tg := &structs.TaskGroup{
+		Name: "example",
+		Update: &structs.UpdateStrategy{
+			Canary:              1,
+			CanaryDistinctHosts: true,
+		},
+	}
+
+	// Add a planned alloc of the previous version to node1 and one of the
+	// current version to node2.
+	plan := ctx.Plan()
+	plan.NodeAllocation[nodes[0].ID] = []*structs.Allocation{
+		{
+			Namespace: structs.DefaultNamespace,
+			TaskGroup: tg.Name,
+			JobID:     job.ID,
+			Job:       &structs.Job{ID: job.ID, Version: 1},
+		},
+	}
+	plan.NodeAllocation[nodes[1].ID] = []*structs.Allocation{
+		{
+			Namespace: structs.DefaultNamespace,
+			TaskGroup: tg.Name,
+			JobID:     job.ID,
+			Job:       job,
+		},
+	}
+
+	proposed := NewDistinctHostsIterator(ctx, static)
+	proposed.SetJob(job)
+	proposed.SetTaskGroup(tg)
+
+	// Placing a canary skips the node running the previous version
+	proposed.SetCanary(true)
+	out := collectFeasible(proposed)
+	require.Equal(t, []*structs.Node{nodes[1], nodes[2]}, out)
+
+	// Other placements may use any node
+	proposed.Reset()
+	proposed.SetTaskGroup(tg)
+	proposed.SetCanary(false)
+	out = collectFeasible(proposed)
+	require.Len(t, out, 3)
+}
+
 // This test puts creates allocations across task groups that use a property
 // value to detect if the constraint at the job level properly considers all
 // task groups.
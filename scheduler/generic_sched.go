@@ -27,7 +27,7 @@ const (
 
 	// allocReconnected is the status to use when a replacement allocation is stopped
 	// because a disconnected node reconnects.
-	allocReconnected = "alloc not needed due to disconnected client reconnect"
+	allocReconnected = structs.AllocDesiredDescriptionReconnected
 
 	// allocMigrating is the status used when we must migrate an allocation
 	allocMigrating = "alloc is being migrated"
@@ -68,6 +68,11 @@ const (
 	// timeout has passed.
 	disconnectTimeoutFollowupEvalDesc = "created for delayed disconnect timeout"
 
+	// reconnectStabilizationFollowupEvalDesc is the description used when
+	// creating follow up evals for reconnected allocations that are held back
+	// until their node has stayed connected for the reconnect stabilization.
+	reconnectStabilizationFollowupEvalDesc = "created for delayed reconnect stabilization"
+
 	// maxPastRescheduleEvents is the maximum number of past reschedule event
 	// that we track when unlimited rescheduling is enabled
 	maxPastRescheduleEvents = 5
@@ -114,6 +119,10 @@ type GenericScheduler struct {
 	blocked        *structs.Evaluation
 	failedTGAllocs map[string]*structs.AllocMetric
 	queuedAllocs   map[string]int
+
+	// explanation explains the decisions made about the allocations of
+	// disconnected and reconnected clients
+	explanation *structs.EvalExplanation
 }
 
 // NewServiceScheduler is a factory function to instantiate a new service scheduler
@@ -164,13 +173,14 @@ func (s *GenericScheduler) Process(eval *structs.Evaluation) (err error) {
 		structs.EvalTriggerPeriodicJob, structs.EvalTriggerMaxPlans,
 		structs.EvalTriggerDeploymentWatcher, structs.EvalTriggerRetryFailedAlloc,
 		structs.EvalTriggerFailedFollowUp, structs.EvalTriggerPreemption,
-		structs.EvalTriggerScaling, structs.EvalTriggerMaxDisconnectTimeout, structs.EvalTriggerReconnect:
+		structs.EvalTriggerScaling, structs.EvalTriggerMaxDisconnectTimeout, structs.EvalTriggerReconnect,
+		structs.EvalTriggerReconnectPreemption:
 	default:
 		desc := fmt.Sprintf("scheduler cannot handle '%s' evaluation reason",
 			eval.TriggeredBy)
 		return setStatus(s.logger, s.planner, s.eval, nil, s.blocked,
 			s.failedTGAllocs, structs.EvalStatusFailed, desc, s.queuedAllocs,
-			s.deployment.GetID())
+			s.deployment.GetID(), s.explanation)
 	}
 
 	// Retry up to the maxScheduleAttempts and reset if progress is made.
@@ -189,7 +199,7 @@ func (s *GenericScheduler) Process(eval *structs.Evaluation) (err error) {
 			}
 			if err := setStatus(s.logger, s.planner, s.eval, nil, s.blocked,
 				s.failedTGAllocs, statusErr.EvalStatus, err.Error(),
-				s.queuedAllocs, s.deployment.GetID()); err != nil {
+				s.queuedAllocs, s.deployment.GetID(), s.explanation); err != nil {
 				mErr.Errors = append(mErr.Errors, err)
 			}
 			return mErr.ErrorOrNil()
@@ -211,7 +221,7 @@ func (s *GenericScheduler) Process(eval *structs.Evaluation) (err error) {
 	// Update the status to complete
 	return setStatus(s.logger, s.planner, s.eval, nil, s.blocked,
 		s.failedTGAllocs, structs.EvalStatusComplete, "", s.queuedAllocs,
-		s.deployment.GetID())
+		s.deployment.GetID(), s.explanation)
 }
 
 // createBlockedEval creates a blocked eval and submits it to the planner. If
@@ -380,9 +390,29 @@ func (s *GenericScheduler) computeJobAllocs() error {
 		s.batch, s.eval.JobID, s.job, s.deployment, allocs, tainted, s.eval.ID,
 		s.eval.Priority, s.planner.ServersMeetMinimumVersion(minVersionMaxClientDisconnect, true))
 
+	// Hold back reconnected allocations on nodes that haven't stayed
+	// connected for the reconnect stabilization period
+	_, schedConfig, _ := s.ctx.State().SchedulerConfig()
+	if schedConfig != nil && schedConfig.ReconnectStabilization > 0 {
+		stabilizing, err := reconnectStabilizingNodes(s.state, allocs, schedConfig.ReconnectStabilization, reconciler.now)
+		if err != nil {
+			return fmt.Errorf("failed to get reconnecting nodes for job '%s': %v",
+				s.eval.JobID, err)
+		}
+		reconciler.stabilizingNodes = stabilizing
+	}
+
 	results := reconciler.Compute()
 	s.logger.Debug("reconciled current state with desired state", "results", log.Fmt("%#v", results))
 
+	s.explanation = results.explanation()
+	if s.explanation != nil {
+		s.explanation.DisconnectStrategy = s.job.Disconnect.Copy()
+		if schedConfig != nil {
+			s.explanation.ReconnectStabilization = schedConfig.ReconnectStabilization
+		}
+	}
+
 	if s.eval.AnnotatePlan {
 		s.plan.Annotations = &structs.PlanAnnotations{
 			DesiredTGUpdates: results.desiredTGUpdates,
@@ -498,7 +528,7 @@ func (s *GenericScheduler) downgradedJobForPlacement(p placementResult) (string,
 // destructive updates to place and the set of new placements to place.
 func (s *GenericScheduler) computePlacements(destructive, place []placementResult) error {
 	// Get the base nodes
-	nodes, _, byDC, err := readyNodesInDCs(s.state, s.job.Datacenters)
+	nodes, _, byDC, err := readyNodesInDCs(s.state, s.job.DatacenterNames())
 	if err != nil {
 		return err
 	}
@@ -576,6 +606,9 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 			// Compute penalty nodes for rescheduled allocs
 			selectOptions := getSelectOptions(prevAllocation, preferredNode)
 			selectOptions.AllocName = missing.Name()
+			selectOptions.Canary = missing.Canary()
+			selectOptions.PenaltyDisconnectDomains = missing.PenaltyDisconnectDomains()
+			selectOptions.OversubscribeMemory = missing.PreviousDisconnected()
 			option := s.selectNextOption(tg, selectOptions)
 
 			// Store the available nodes by datacenter
@@ -627,6 +660,10 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 					},
 				}
 
+				// Record the disconnect timeout resolved for the chosen node
+				alloc.MaxClientDisconnect = resolveMaxClientDisconnect(tg, option.Node)
+				alloc.MemoryOversubscribed = option.MemoryOversubscribed
+
 				// If the new allocation is replacing an older allocation then we
 				// set the record the older allocation id so that they are chained
 				if prevAllocation != nil {
@@ -649,6 +686,7 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 				}
 
 				s.handlePreemptions(option, alloc, missing)
+				s.annotateDatacenter(option.Node, tg.Name)
 
 				// Track the placement
 				s.plan.AppendAlloc(alloc, downgradedJob)
@@ -818,6 +856,22 @@ func (s *GenericScheduler) selectNextOption(tg *structs.TaskGroup, selectOptions
 	return option
 }
 
+// annotateDatacenter records the datacenter chosen for a placement in the
+// plan annotations.
+func (s *GenericScheduler) annotateDatacenter(node *structs.Node, tgName string) {
+	if !s.eval.AnnotatePlan || s.plan.Annotations == nil || s.plan.Annotations.DesiredTGUpdates == nil {
+		return
+	}
+	desired, ok := s.plan.Annotations.DesiredTGUpdates[tgName]
+	if !ok {
+		return
+	}
+	if desired.Datacenters == nil {
+		desired.Datacenters = make(map[string]uint64)
+	}
+	desired.Datacenters[node.Datacenter]++
+}
+
 // handlePreemptions sets relevant preeemption related fields.
 func (s *GenericScheduler) handlePreemptions(option *RankedNode, alloc *structs.Allocation, missing placementResult) {
 	if option.PreemptedAllocs == nil {
@@ -987,12 +987,67 @@ func TestServiceSched_JobRegister_Annotate(t *testing.T) {
 		t.Fatalf("expected task group web to have desired changes")
 	}
 
-	expected := &structs.DesiredUpdates{Place: 10}
+	expected := &structs.DesiredUpdates{Place: 10, Datacenters: map[string]uint64{"dc1": 10}}
 	if !reflect.DeepEqual(desiredChanges, expected) {
 		t.Fatalf("Unexpected desired updates; got %#v; want %#v", desiredChanges, expected)
 	}
 }
 
+func TestServiceSched_JobRegister_DatacenterWeights(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create nodes in a primary and a secondary datacenter
+	for i := 0; i < 10; i++ {
+		node := mock.Node()
+		if i%2 == 1 {
+			node.Datacenter = "dc2"
+		}
+		require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	// Create a job preferring the primary datacenter
+	job := mock.Job()
+	job.Datacenters = []string{"dc2:100", "dc1:10"}
+	job.TaskGroups[0].Count = 5
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	eval := &structs.Evaluation{
+		Namespace:    structs.DefaultNamespace,
+		ID:           uuid.Generate(),
+		Priority:     job.Priority,
+		TriggeredBy:  structs.EvalTriggerJobRegister,
+		JobID:        job.ID,
+		AnnotatePlan: true,
+		Status:       structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+	require.Len(t, h.Plans, 1)
+	plan := h.Plans[0]
+
+	// Ensure every placement landed in the preferred datacenter
+	var planned []*structs.Allocation
+	for _, allocList := range plan.NodeAllocation {
+		planned = append(planned, allocList...)
+	}
+	require.Len(t, planned, 5)
+	for _, alloc := range planned {
+		node, err := h.State.NodeByID(nil, alloc.NodeID)
+		require.NoError(t, err)
+		require.Equal(t, "dc2", node.Datacenter)
+	}
+
+	// Ensure the chosen datacenter is visible in the annotations
+	require.NotNil(t, plan.Annotations)
+	desired := plan.Annotations.DesiredTGUpdates["web"]
+	require.NotNil(t, desired)
+	require.Equal(t, map[string]uint64{"dc2": 5}, desired.Datacenters)
+}
+
 func TestServiceSched_JobRegister_CountZero(t *testing.T) {
 	ci.Parallel(t)
 
@@ -3034,6 +3089,62 @@ func TestServiceSched_NodeDown(t *testing.T) {
 	}
 }
 
+func TestServiceSched_NodeDown_DisconnectDomain(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Register a down node and a ready node in the same disconnect domain,
+	// and a ready node in another domain
+	down := mock.Node()
+	down.Status = structs.NodeStatusDown
+	down.DisconnectDomain = "rack-a"
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), down))
+
+	sameDomain := mock.Node()
+	sameDomain.DisconnectDomain = "rack-a"
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), sameDomain))
+
+	otherDomain := mock.Node()
+	otherDomain.DisconnectDomain = "rack-b"
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), otherDomain))
+
+	// Generate a fake job with an allocation on the down node
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.NodeID = down.ID
+	alloc.Name = "my-job.web[0]"
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	require.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Allocation{alloc}))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    50,
+		TriggeredBy: structs.EvalTriggerNodeUpdate,
+		JobID:       job.ID,
+		NodeID:      down.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+	require.Len(t, h.Plans, 1)
+
+	// The replacement is placed outside of the domain of the down node
+	plan := h.Plans[0]
+	require.Len(t, plan.NodeAllocation[otherDomain.ID], 1)
+	require.Empty(t, plan.NodeAllocation[sameDomain.ID])
+
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+}
+
 func TestServiceSched_StopAfterClientDisconnect(t *testing.T) {
 	ci.Parallel(t)
 
@@ -6637,6 +6748,13 @@ func TestServiceSched_Client_Disconnect_Creates_Updates_and_Evals(t *testing.T)
 	require.Equal(t, structs.EvalStatusComplete, h.Evals[0].Status)
 	require.Len(t, h.Plans, 1, "plan")
 
+	// The decision about the disconnected alloc is explained
+	explanation := h.Evals[0].Explanation
+	require.NotNil(t, explanation)
+	require.Len(t, explanation.Allocations, count)
+	require.Equal(t, unknownAllocs[0].ID, explanation.Allocations[0].AllocID)
+	require.Equal(t, structs.AllocDecisionMarkUnknown, explanation.Allocations[0].Decision)
+
 	// One followup delayed eval created
 	require.Len(t, h.CreateEvals, 1)
 	followUpEval := h.CreateEvals[0]
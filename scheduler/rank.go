@@ -33,6 +33,10 @@ type RankedNode struct {
 	// PreemptedAllocs is used by the BinpackIterator to identify allocs
 	// that should be preempted in order to make the placement
 	PreemptedAllocs []*structs.Allocation
+
+	// MemoryOversubscribed is set by the BinPackIterator when the placement
+	// only fits by oversubscribing the node's memory
+	MemoryOversubscribed bool
 }
 
 func (r *RankedNode) GoString() string {
@@ -157,6 +161,10 @@ type BinPackIterator struct {
 	taskGroup              *structs.TaskGroup
 	memoryOversubscription bool
 	scoreFit               func(*structs.Node, *structs.ComparableResources) float64
+
+	// oversubscribeMemory allows the placement to exceed the memory capacity
+	// of nodes with a memory oversubscription ratio
+	oversubscribeMemory bool
 }
 
 // NewBinPackIterator returns a BinPackIterator which tries to fit tasks
@@ -190,6 +198,13 @@ func (iter *BinPackIterator) SetTaskGroup(taskGroup *structs.TaskGroup) {
 	iter.taskGroup = taskGroup
 }
 
+// SetOversubscribeMemory sets whether the placement may oversubscribe the
+// memory of nodes that allow it. This only has an effect when memory
+// oversubscription is enabled in the scheduler configuration.
+func (iter *BinPackIterator) SetOversubscribeMemory(oversubscribe bool) {
+	iter.oversubscribeMemory = oversubscribe
+}
+
 func (iter *BinPackIterator) Next() *RankedNode {
 OUTER:
 	for {
@@ -353,7 +368,8 @@ OUTER:
 					CpuShares: int64(task.Resources.CPU),
 				},
 				Memory: structs.AllocatedMemoryResources{
-					MemoryMB: int64(task.Resources.MemoryMB),
+					MemoryMB:     int64(task.Resources.MemoryMB),
+					HugePages2MB: int64(task.Resources.HugePages2MB),
 				},
 			}
 			if iter.memoryOversubscription {
@@ -505,6 +521,14 @@ OUTER:
 
 		// Check if these allocations fit, if they do not, simply skip this node
 		fit, dim, util, _ := structs.AllocsFit(option.Node, proposed, netIdx, false)
+		if !fit && dim == "memory" && iter.oversubscribeMemory && iter.memoryOversubscription &&
+			option.Node.MemoryOversubscriptionRatio() > 1 {
+			// Retry with the placement allowed to use the node's
+			// oversubscribed memory
+			proposed[len(proposed)-1].MemoryOversubscribed = true
+			fit, dim, util, _ = structs.AllocsFit(option.Node, proposed, netIdx, false)
+			option.MemoryOversubscribed = fit
+		}
 		netIdx.Release()
 		if !fit {
 			// Skip the node if evictions are not enabled
@@ -539,6 +563,12 @@ OUTER:
 		option.Scores = append(option.Scores, normalizedFit)
 		iter.ctx.Metrics().ScoreNode(option.Node, "binpack", normalizedFit)
 
+		// Prefer nodes the placement fits on without oversubscribing memory
+		if option.MemoryOversubscribed {
+			option.Scores = append(option.Scores, -1)
+			iter.ctx.Metrics().ScoreNode(option.Node, "memory-oversubscription", -1)
+		}
+
 		// Score the device affinity
 		if totalDeviceAffinityWeight != 0 {
 			sumMatchingAffinities /= totalDeviceAffinityWeight
@@ -625,12 +655,15 @@ func (iter *JobAntiAffinityIterator) Reset() {
 }
 
 // NodeReschedulingPenaltyIterator is used to apply a penalty to
-// a node that had a previous failed allocation for the same job.
-// This is used when attempting to reschedule a failed alloc
+// a node that had a previous failed allocation for the same job, or that
+// belongs to the disconnect domain of a node that is disconnected or down.
+// This is used when attempting to reschedule a failed alloc or to replace
+// an alloc lost to an outage
 type NodeReschedulingPenaltyIterator struct {
-	ctx          Context
-	source       RankIterator
-	penaltyNodes map[string]struct{}
+	ctx            Context
+	source         RankIterator
+	penaltyNodes   map[string]struct{}
+	penaltyDomains map[string]struct{}
 }
 
 // NewNodeReschedulingPenaltyIterator is used to create a NodeReschedulingPenaltyIterator that
@@ -647,6 +680,10 @@ func (iter *NodeReschedulingPenaltyIterator) SetPenaltyNodes(penaltyNodes map[st
 	iter.penaltyNodes = penaltyNodes
 }
 
+func (iter *NodeReschedulingPenaltyIterator) SetPenaltyDisconnectDomains(penaltyDomains map[string]struct{}) {
+	iter.penaltyDomains = penaltyDomains
+}
+
 func (iter *NodeReschedulingPenaltyIterator) Next() *RankedNode {
 	option := iter.source.Next()
 	if option == nil {
@@ -654,6 +691,9 @@ func (iter *NodeReschedulingPenaltyIterator) Next() *RankedNode {
 	}
 
 	_, ok := iter.penaltyNodes[option.Node.ID]
+	if !ok && option.Node.DisconnectDomain != "" {
+		_, ok = iter.penaltyDomains[option.Node.DisconnectDomain]
+	}
 	if ok {
 		option.Scores = append(option.Scores, -1)
 		iter.ctx.Metrics().ScoreNode(option.Node, "node-reschedule-penalty", -1)
@@ -666,6 +706,7 @@ func (iter *NodeReschedulingPenaltyIterator) Next() *RankedNode {
 
 func (iter *NodeReschedulingPenaltyIterator) Reset() {
 	iter.penaltyNodes = make(map[string]struct{})
+	iter.penaltyDomains = nil
 	iter.source.Reset()
 }
 
@@ -758,6 +799,61 @@ func matchesAffinity(ctx Context, affinity *structs.Affinity, option *structs.No
 	return checkAffinity(ctx, affinity.Operand, lVal, rVal, lOk, rOk)
 }
 
+// DatacenterWeightIterator is used to apply a score to nodes based on the
+// placement weight of their datacenter in the job, so that placements prefer
+// the highest weighted datacenters but can spill over to the others.
+type DatacenterWeightIterator struct {
+	ctx       Context
+	source    RankIterator
+	weights   map[string]int
+	maxWeight int
+}
+
+// NewDatacenterWeightIterator is used to create a DatacenterWeightIterator
+// that scores nodes according to the datacenter weights of the job.
+func NewDatacenterWeightIterator(ctx Context, source RankIterator) *DatacenterWeightIterator {
+	return &DatacenterWeightIterator{
+		ctx:    ctx,
+		source: source,
+	}
+}
+
+func (iter *DatacenterWeightIterator) SetJob(job *structs.Job) {
+	iter.weights = job.DatacenterWeights()
+	iter.maxWeight = 0
+	for _, weight := range iter.weights {
+		if weight > iter.maxWeight {
+			iter.maxWeight = weight
+		}
+	}
+}
+
+func (iter *DatacenterWeightIterator) hasWeights() bool {
+	return iter.maxWeight > 0
+}
+
+func (iter *DatacenterWeightIterator) Reset() {
+	iter.source.Reset()
+}
+
+func (iter *DatacenterWeightIterator) Next() *RankedNode {
+	option := iter.source.Next()
+	if option == nil {
+		return nil
+	}
+	if !iter.hasWeights() {
+		iter.ctx.Metrics().ScoreNode(option.Node, "datacenter-weight", 0)
+		return option
+	}
+
+	// Unweighted datacenters receive no score so that they are only used
+	// once the weighted datacenters are exhausted.
+	score := float64(iter.weights[option.Node.Datacenter]) / float64(iter.maxWeight)
+	option.Scores = append(option.Scores, score)
+	iter.ctx.Metrics().ScoreNode(option.Node, "datacenter-weight", score)
+	return option
+}
+
 // ScoreNormalizationIterator is used to combine scores from various prior
 // iterators and combine them into one final score. The current implementation
 // averages the scores together.
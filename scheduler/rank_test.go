@@ -1265,6 +1265,109 @@ func TestBinPackIterator_PlannedAlloc(t *testing.T) {
 	}
 }
 
+func TestBinPackIterator_OversubscribeMemory(t *testing.T) {
+	newNode := func(ratio float64) *RankedNode {
+		return &RankedNode{
+			Node: &structs.Node{
+				ID: uuid.Generate(),
+				NodeResources: &structs.NodeResources{
+					Cpu: structs.NodeCpuResources{
+						CpuShares: 4096,
+					},
+					Memory: structs.NodeMemoryResources{
+						MemoryMB: 2048,
+					},
+					MemoryOversubscriptionRatio: ratio,
+				},
+			},
+		}
+	}
+
+	taskGroup := &structs.TaskGroup{
+		EphemeralDisk: &structs.EphemeralDisk{},
+		Tasks: []*structs.Task{
+			{
+				Name: "web",
+				Resources: &structs.Resources{
+					CPU:      1024,
+					MemoryMB: 1024,
+				},
+			},
+		},
+	}
+
+	cases := []struct {
+		name                string
+		schedConfig         *structs.SchedulerConfiguration
+		oversubscribeMemory bool
+		expectPlaced        bool
+	}{
+		{
+			name:                "placement not oversubscribing memory",
+			schedConfig:         testSchedulerConfig,
+			oversubscribeMemory: false,
+			expectPlaced:        false,
+		},
+		{
+			name:                "memory oversubscription disabled",
+			schedConfig:         &structs.SchedulerConfiguration{},
+			oversubscribeMemory: true,
+			expectPlaced:        false,
+		},
+		{
+			name:                "placement oversubscribing memory",
+			schedConfig:         testSchedulerConfig,
+			oversubscribeMemory: true,
+			expectPlaced:        true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, ctx := testContext(t)
+
+			// Both nodes are full, but only the first one allows memory
+			// oversubscription
+			nodes := []*RankedNode{newNode(1.5), newNode(0)}
+			static := NewStaticRankIterator(ctx, nodes)
+
+			plan := ctx.Plan()
+			for _, node := range nodes {
+				plan.NodeAllocation[node.Node.ID] = []*structs.Allocation{
+					{
+						AllocatedResources: &structs.AllocatedResources{
+							Tasks: map[string]*structs.AllocatedTaskResources{
+								"web": {
+									Cpu: structs.AllocatedCpuResources{
+										CpuShares: 1024,
+									},
+									Memory: structs.AllocatedMemoryResources{
+										MemoryMB: 2048,
+									},
+								},
+							},
+						},
+					},
+				}
+			}
+
+			binp := NewBinPackIterator(ctx, static, false, 0, tc.schedConfig)
+			binp.SetTaskGroup(taskGroup)
+			binp.SetOversubscribeMemory(tc.oversubscribeMemory)
+
+			out := collectRanked(binp)
+			if !tc.expectPlaced {
+				require.Empty(t, out)
+				return
+			}
+			require.Len(t, out, 1)
+			require.Equal(t, nodes[0], out[0])
+			require.True(t, out[0].MemoryOversubscribed)
+			require.Contains(t, out[0].Scores, -1.0)
+		})
+	}
+}
+
 func TestBinPackIterator_ReservedCores(t *testing.T) {
 	state, ctx := testContext(t)
 	nodes := []*RankedNode{
@@ -2058,6 +2161,52 @@ func TestNodeAntiAffinity_PenaltyNodes(t *testing.T) {
 
 }
 
+func TestNodeAntiAffinity_PenaltyDisconnectDomains(t *testing.T) {
+	_, ctx := testContext(t)
+	node1 := &structs.Node{
+		ID:               uuid.Generate(),
+		DisconnectDomain: "rack-a",
+	}
+	node2 := &structs.Node{
+		ID:               uuid.Generate(),
+		DisconnectDomain: "rack-b",
+	}
+	node3 := &structs.Node{
+		ID: uuid.Generate(),
+	}
+
+	nodes := []*RankedNode{
+		{
+			Node: node1,
+		},
+		{
+			Node: node2,
+		},
+		{
+			Node: node3,
+		},
+	}
+	static := NewStaticRankIterator(ctx, nodes)
+
+	nodeAntiAffIter := NewNodeReschedulingPenaltyIterator(ctx, static)
+	nodeAntiAffIter.SetPenaltyDisconnectDomains(map[string]struct{}{"rack-a": {}})
+
+	scoreNorm := NewScoreNormalizationIterator(ctx, nodeAntiAffIter)
+
+	out := collectRanked(scoreNorm)
+
+	require := require.New(t)
+	require.Equal(3, len(out))
+	require.Equal(node1.ID, out[0].Node.ID)
+	require.Equal(-1.0, out[0].FinalScore)
+
+	require.Equal(node2.ID, out[1].Node.ID)
+	require.Equal(0.0, out[1].FinalScore)
+
+	require.Equal(node3.ID, out[2].Node.ID)
+	require.Equal(0.0, out[2].FinalScore)
+}
+
 func TestScoreNormalizationIterator(t *testing.T) {
 	// Test normalized scores when there is more than one scorer
 	_, ctx := testContext(t)
@@ -2123,6 +2272,40 @@ func TestScoreNormalizationIterator(t *testing.T) {
 	require.Equal(out[1].FinalScore, 0.0)
 }
 
+func TestDatacenterWeightIterator(t *testing.T) {
+	_, ctx := testContext(t)
+	nodes := []*RankedNode{
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+	}
+	nodes[1].Node.Datacenter = "dc2"
+	nodes[2].Node.Datacenter = "dc3"
+
+	static := NewStaticRankIterator(ctx, nodes)
+
+	job := mock.Job()
+	job.Datacenters = []string{"dc1:100", "dc2:10", "dc3"}
+
+	dcWeight := NewDatacenterWeightIterator(ctx, static)
+	dcWeight.SetJob(job)
+
+	scoreNorm := NewScoreNormalizationIterator(ctx, dcWeight)
+
+	out := collectRanked(scoreNorm)
+	expectedScores := map[string]float64{
+		nodes[0].Node.ID: 1.0,
+		nodes[1].Node.ID: 0.1,
+		nodes[2].Node.ID: 0.0,
+	}
+
+	require := require.New(t)
+	require.Len(out, 3)
+	for _, n := range out {
+		require.Equal(expectedScores[n.Node.ID], n.FinalScore)
+	}
+}
+
 func TestNodeAffinityIterator(t *testing.T) {
 	_, ctx := testContext(t)
 	nodes := []*RankedNode{
@@ -70,6 +70,11 @@ type allocReconciler struct {
 	// taintedNodes contains a map of nodes that are tainted
 	taintedNodes map[string]*structs.Node
 
+	// stabilizingNodes maps the nodes that reconnected less than the reconnect
+	// stabilization period ago to the time at which the period ends. Their
+	// reconnected allocations are not reinstated until then.
+	stabilizingNodes map[string]time.Time
+
 	// existingAllocs is non-terminal existing allocations
 	existingAllocs []*structs.Allocation
 
@@ -130,6 +135,10 @@ type reconcileResults struct {
 	// task group.
 	desiredTGUpdates map[string]*structs.DesiredUpdates
 
+	// explanations explains the decisions made about the allocations of
+	// disconnected and reconnected clients, keyed by allocation ID.
+	explanations map[string]*structs.AllocExplanation
+
 	// desiredFollowupEvals is the map of follow up evaluations to create per task group
 	// This is used to create a delayed evaluation for rescheduling failed allocations.
 	desiredFollowupEvals map[string][]*structs.Evaluation
@@ -170,6 +179,30 @@ func (r *reconcileResults) Changes() int {
 	return len(r.place) + len(r.inplaceUpdate) + len(r.stop)
 }
 
+// explanation returns the explanations of the decisions made about the
+// allocations of disconnected and reconnected clients, or nil if there were no
+// such allocations.
+func (r *reconcileResults) explanation() *structs.EvalExplanation {
+	if len(r.explanations) == 0 {
+		return nil
+	}
+
+	allocs := make([]*structs.AllocExplanation, 0, len(r.explanations))
+	for _, e := range r.explanations {
+		allocs = append(allocs, e)
+	}
+	sort.Slice(allocs, func(i, j int) bool {
+		if allocs[i].TaskGroup != allocs[j].TaskGroup {
+			return allocs[i].TaskGroup < allocs[j].TaskGroup
+		}
+		if allocs[i].AllocName != allocs[j].AllocName {
+			return allocs[i].AllocName < allocs[j].AllocName
+		}
+		return allocs[i].AllocID < allocs[j].AllocID
+	})
+	return &structs.EvalExplanation{Allocations: allocs}
+}
+
 // NewAllocReconciler creates a new reconciler that should be used to determine
 // the changes required to bring the cluster state inline with the declared jobspec
 func NewAllocReconciler(logger log.Logger, allocUpdateFn allocUpdateType, batch bool,
@@ -195,6 +228,7 @@ func NewAllocReconciler(logger log.Logger, allocUpdateFn allocUpdateType, batch
 			reconnectUpdates:     make(map[string]*structs.Allocation),
 			desiredTGUpdates:     make(map[string]*structs.DesiredUpdates),
 			desiredFollowupEvals: make(map[string][]*structs.Evaluation),
+			explanations:         make(map[string]*structs.AllocExplanation),
 		},
 	}
 }
@@ -408,7 +442,33 @@ func (a *allocReconciler) computeGroup(groupName string, all allocSet) bool {
 
 	// Determine what set of allocations are on tainted nodes
 	untainted, migrate, lost, disconnecting, reconnecting, ignore := all.filterByTainted(a.taintedNodes, a.supportsDisconnectedClients, a.now)
+	a.explainLost(lost)
+	desiredChanges.ReplaceUnknown += uint64(a.applyDisconnectBudget(lost, disconnecting, ignore))
 	desiredChanges.Ignore += uint64(len(ignore))
+	for _, alloc := range ignore.filterByClientStatus(structs.AllocClientStatusUnknown) {
+		e := a.explain(alloc, structs.AllocDecisionWait, "client is still disconnected")
+		e.DisconnectTimeout = unknownTimeout(alloc, a.now)
+	}
+
+	// Replacements that oversubscribe their node's memory only do so until
+	// the allocation they replaced reconnects. Migrate them once it won't, so
+	// that they're placed within the capacity of a node.
+	oversubscribed := untainted.filterByOversubscribedLost(all, lost)
+	untainted = untainted.difference(oversubscribed)
+	migrate = migrate.union(oversubscribed)
+
+	// Leave reconnected allocations and their replacements alone until their
+	// node has stayed connected for the reconnect stabilization period, so a
+	// flapping node doesn't churn them. Failed reconnects are still handled.
+	stabilizing := reconnecting.filterByStabilizing(a.stabilizingNodes, a.now)
+	reconnecting = reconnecting.difference(stabilizing)
+	desiredChanges.Ignore += uint64(len(stabilizing))
+	a.createStabilizeLaterEvals(stabilizing, tg.Name)
+	for _, alloc := range stabilizing {
+		e := a.explain(alloc, structs.AllocDecisionStabilize,
+			"client reconnected less than the reconnect stabilization period ago")
+		e.StabilizeUntil = a.stabilizingNodes[alloc.NodeID]
+	}
 
 	// Determine what set of terminal allocations need to be rescheduled
 	untainted, rescheduleNow, rescheduleLater := untainted.filterByRescheduleable(a.batch, false, a.now, a.evalID, a.deployment)
@@ -416,6 +476,14 @@ func (a *allocReconciler) computeGroup(groupName string, all allocSet) bool {
 	// Determine what set of disconnecting allocations need to be rescheduled
 	_, rescheduleDisconnecting, _ := disconnecting.filterByRescheduleable(a.batch, true, a.now, a.evalID, a.deployment)
 	rescheduleNow = rescheduleNow.union(rescheduleDisconnecting)
+	for id, alloc := range disconnecting {
+		reason := "client disconnected"
+		if _, ok := rescheduleDisconnecting[id]; ok {
+			reason = "client disconnected; placing a replacement until it reconnects"
+		}
+		e := a.explain(alloc, structs.AllocDecisionMarkUnknown, reason)
+		e.DisconnectTimeout = alloc.DisconnectTimeout(a.now)
+	}
 
 	// Find delays for any lost allocs that have stop_after_client_disconnect
 	lostLater := lost.delayByStopAfterClientDisconnect()
@@ -508,6 +576,103 @@ func (a *allocReconciler) computeGroup(groupName string, all allocSet) bool {
 	return deploymentComplete
 }
 
+// applyDisconnectBudget moves allocations beyond the job's disconnect budget
+// out of the unknown and disconnecting sets and into the lost set, so that
+// they're replaced instead of waiting for their client to reconnect.
+// Allocations that are already unknown keep their place in the budget
+// ahead of newly disconnecting ones. Returns the number of allocations
+// moved to the lost set.
+func (a *allocReconciler) applyDisconnectBudget(lost, disconnecting, ignore allocSet) int {
+	if a.job.Disconnect == nil {
+		return 0
+	}
+	budget := a.job.Disconnect.MaxUnknown
+	replaced := 0
+
+	unknown := ignore.filterByClientStatus(structs.AllocClientStatusUnknown)
+	reason := fmt.Sprintf("job disconnect max_unknown of %d reached", a.job.Disconnect.MaxUnknown)
+	for _, alloc := range unknown.nameOrder() {
+		if budget > 0 {
+			budget--
+			continue
+		}
+		delete(ignore, alloc.ID)
+		lost[alloc.ID] = alloc
+		a.explain(alloc, structs.AllocDecisionReplace, reason)
+		replaced++
+	}
+
+	for _, alloc := range disconnecting.nameOrder() {
+		if budget > 0 {
+			budget--
+			continue
+		}
+		delete(disconnecting, alloc.ID)
+		lost[alloc.ID] = alloc
+		a.explain(alloc, structs.AllocDecisionReplace, reason)
+		replaced++
+	}
+	return replaced
+}
+
+// explainLost records why the lost allocations are replaced.
+func (a *allocReconciler) explainLost(lost allocSet) {
+	for _, alloc := range lost {
+		node := a.taintedNodes[alloc.NodeID]
+
+		var reason string
+		timeout := false
+		switch {
+		case alloc.DesiredTransition.ShouldRejectReconnect():
+			reason = "reconnect rejected by an operator"
+		case node != nil && node.Status == structs.NodeStatusDown:
+			reason = "client is down"
+		case alloc.ClientStatus == structs.AllocClientStatusPending:
+			reason = "allocation was pending when its client disconnected"
+		case !alloc.SupportsDisconnectedClients(a.supportsDisconnectedClients):
+			reason = "client disconnected and the group doesn't set max_client_disconnect"
+		case node != nil && node.Status == structs.NodeStatusDisconnected:
+			reason, timeout = "disconnect timeout passed", true
+		default:
+			reason, timeout = "client reconnected after the disconnect timeout passed", true
+		}
+
+		e := a.explain(alloc, structs.AllocDecisionReplace, reason)
+		if timeout {
+			e.DisconnectTimeout = unknownTimeout(alloc, a.now)
+		}
+	}
+}
+
+// explain records the decision made about an allocation of a disconnected or
+// reconnected client, replacing any decision recorded for it earlier.
+func (a *allocReconciler) explain(alloc *structs.Allocation, decision, reason string) *structs.AllocExplanation {
+	e := &structs.AllocExplanation{
+		AllocID:      alloc.ID,
+		AllocName:    alloc.Name,
+		TaskGroup:    alloc.TaskGroup,
+		NodeID:       alloc.NodeID,
+		ClientStatus: alloc.ClientStatus,
+		Decision:     decision,
+		Reason:       reason,
+	}
+	if node := a.taintedNodes[alloc.NodeID]; node != nil {
+		e.NodeStatus = node.Status
+	}
+	a.result.explanations[alloc.ID] = e
+	return e
+}
+
+// unknownTimeout returns when the unknown allocation is considered lost if
+// its client doesn't reconnect.
+func unknownTimeout(alloc *structs.Allocation, now time.Time) time.Time {
+	since := alloc.LastUnknown()
+	if since.IsZero() {
+		return alloc.DisconnectTimeout(now)
+	}
+	return alloc.DisconnectTimeout(since)
+}
+
 func (a *allocReconciler) initializeDeploymentState(group string, tg *structs.TaskGroup) (*structs.DeploymentState, bool) {
 	var dstate *structs.DeploymentState
 	existingDeployment := false
@@ -684,6 +849,11 @@ func (a *allocReconciler) computePlacements(group *structs.TaskGroup,
 	nameIndex *allocNameIndex, untainted, migrate, reschedule, lost, reconnecting allocSet,
 	isCanarying bool) []allocPlaceResult {
 
+	// Steer the replacements away from the disconnect domains of the nodes
+	// that are out, since the rest of these domains is likely to be cut off
+	// by the same outage
+	penaltyDomains := a.outageDisconnectDomains()
+
 	// Add rescheduled placement results
 	var place []allocPlaceResult
 	for _, alloc := range reschedule {
@@ -697,6 +867,8 @@ func (a *allocReconciler) computePlacements(group *structs.TaskGroup,
 			downgradeNonCanary: isCanarying && !alloc.DeploymentStatus.IsCanary(),
 			minJobVersion:      alloc.Job.Version,
 			lost:               false,
+			disconnected:       a.onDisconnectedNode(alloc),
+			penaltyDomains:     penaltyDomains,
 		})
 	}
 
@@ -721,6 +893,7 @@ func (a *allocReconciler) computePlacements(group *structs.TaskGroup,
 			downgradeNonCanary: isCanarying && !alloc.DeploymentStatus.IsCanary(),
 			minJobVersion:      alloc.Job.Version,
 			lost:               true,
+			penaltyDomains:     penaltyDomains,
 		})
 	}
 
@@ -738,6 +911,31 @@ func (a *allocReconciler) computePlacements(group *structs.TaskGroup,
 	return place
 }
 
+// onDisconnectedNode returns true if the allocation's node is disconnected.
+func (a *allocReconciler) onDisconnectedNode(alloc *structs.Allocation) bool {
+	node, ok := a.taintedNodes[alloc.NodeID]
+	return ok && node != nil && node.Status == structs.NodeStatusDisconnected
+}
+
+// outageDisconnectDomains returns the disconnect domains of the tainted nodes
+// that are disconnected or down, or nil if there are none.
+func (a *allocReconciler) outageDisconnectDomains() map[string]struct{} {
+	var domains map[string]struct{}
+	for _, node := range a.taintedNodes {
+		if node == nil || node.DisconnectDomain == "" {
+			continue
+		}
+		if node.Status != structs.NodeStatusDisconnected && node.Status != structs.NodeStatusDown {
+			continue
+		}
+		if domains == nil {
+			domains = make(map[string]struct{})
+		}
+		domains[node.DisconnectDomain] = struct{}{}
+	}
+	return domains
+}
+
 // computeReplacements either applies the placements calculated by computePlacements,
 // or computes more placements based on whether the deployment is ready for placement
 // and if the placement is already rescheduling or part of a failed deployment.
@@ -928,6 +1126,9 @@ func (a *allocReconciler) computeStop(group *structs.TaskGroup, nameIndex *alloc
 	stop = stop.union(failedReconnects)
 	a.markStop(failedReconnects, structs.AllocClientStatusFailed, allocRescheduled)
 	reconnecting = reconnecting.difference(failedReconnects)
+	for _, alloc := range failedReconnects {
+		a.explain(alloc, structs.AllocDecisionStop, "allocation failed while its client was disconnected")
+	}
 
 	// If we are still deploying or creating canaries, don't stop them
 	if isCanarying {
@@ -1056,6 +1257,7 @@ func (a *allocReconciler) computeStopByReconnecting(untainted, reconnecting, sto
 				statusDescription: allocNotNeeded,
 			})
 			delete(reconnecting, reconnectingAlloc.ID)
+			a.explain(reconnectingAlloc, structs.AllocDecisionStop, reconnectStopReason(reconnectingAlloc))
 
 			remove--
 			// if we've removed all we need to, stop iterating and return.
@@ -1089,16 +1291,35 @@ func (a *allocReconciler) computeStopByReconnecting(untainted, reconnecting, sto
 				continue
 			}
 
+			// Allocs accepted back by an operator are kept over their
+			// replacement unless the replacement runs a newer job, and so
+			// are allocs whose replacement oversubscribes its node's memory,
+			// to reclaim that memory.
 			statusDescription := allocNotNeeded
-			if untaintedAlloc.Job.Version > reconnectingAlloc.Job.Version ||
-				untaintedAlloc.Job.CreateIndex > reconnectingAlloc.Job.CreateIndex ||
-				untaintedMaxScoreMeta.NormScore > reconnectingMaxScoreMeta.NormScore {
+			decision, reason := structs.AllocDecisionReconnect, "score is at least as high as the replacement's"
+			switch {
+			case untaintedAlloc.Job.Version > reconnectingAlloc.Job.Version ||
+				untaintedAlloc.Job.CreateIndex > reconnectingAlloc.Job.CreateIndex:
+				decision, reason = structs.AllocDecisionStop, "replacement runs a newer job version"
+			case reconnectingAlloc.DesiredTransition.ShouldAcceptReconnect():
+				reason = "reconnect accepted by an operator"
+			case untaintedAlloc.MemoryOversubscribed:
+				reason = "replacement oversubscribes its node's memory"
+			case untaintedMaxScoreMeta.NormScore > reconnectingMaxScoreMeta.NormScore:
+				decision, reason = structs.AllocDecisionStop, "replacement has a higher score"
+			}
+			if decision == structs.AllocDecisionStop {
 				stopAlloc = reconnectingAlloc
 				deleteSet = reconnecting
 			} else {
 				statusDescription = allocReconnected
 			}
 
+			e := a.explain(reconnectingAlloc, decision, reason)
+			e.ReplacementID = untaintedAlloc.ID
+			e.Score = reconnectingMaxScoreMeta.NormScore
+			e.ReplacementScore = untaintedMaxScoreMeta.NormScore
+
 			stop[stopAlloc.ID] = stopAlloc
 			a.result.stop = append(a.result.stop, allocStopResult{
 				alloc:             stopAlloc,
@@ -1192,6 +1413,25 @@ func (a *allocReconciler) computeReconnecting(reconnecting allocSet) {
 		}
 
 		a.result.reconnectUpdates[alloc.ID] = alloc
+		if _, ok := a.result.explanations[alloc.ID]; !ok {
+			a.explain(alloc, structs.AllocDecisionReconnect, "client reconnected")
+		}
+	}
+}
+
+// reconnectStopReason returns why the allocation of a reconnected client is
+// stopped without being compared to its replacement.
+func reconnectStopReason(alloc *structs.Allocation) string {
+	switch {
+	case alloc.DesiredStatus != structs.AllocDesiredStatusRun:
+		return "allocation is no longer desired"
+	case alloc.DesiredTransition.ShouldMigrate():
+		return "allocation is migrating"
+	case alloc.DesiredTransition.ShouldReschedule() ||
+		alloc.DesiredTransition.ShouldForceReschedule():
+		return "allocation is rescheduling"
+	default:
+		return "allocation runs an older job version"
 	}
 }
 
@@ -1330,6 +1570,8 @@ func (a *allocReconciler) createTimeoutLaterEvals(disconnecting allocSet, tgName
 		updatedAlloc.AppendState(structs.AllocStateFieldClientStatus, structs.AllocClientStatusUnknown)
 		updatedAlloc.ClientDescription = allocUnknown
 		updatedAlloc.FollowupEvalID = eval.ID
+		// A decision made on a previous disconnect doesn't carry over
+		updatedAlloc.DesiredTransition.Reconnect = nil
 		a.result.disconnectUpdates[updatedAlloc.ID] = updatedAlloc
 	}
 
@@ -1338,6 +1580,47 @@ func (a *allocReconciler) createTimeoutLaterEvals(disconnecting allocSet, tgName
 	return allocIDToFollowupEvalID
 }
 
+// createStabilizeLaterEvals creates followup evaluations with the WaitUntil
+// field set to the end of the reconnect stabilization period of the nodes of
+// the stabilizing allocations, so they're reinstated once it's over.
+// Followup Evals are appended to a.result as a side effect.
+func (a *allocReconciler) createStabilizeLaterEvals(stabilizing allocSet, tgName string) {
+	if len(stabilizing) == 0 {
+		return
+	}
+
+	deadlines := make([]time.Time, 0, len(stabilizing))
+	for _, alloc := range stabilizing {
+		deadlines = append(deadlines, a.stabilizingNodes[alloc.NodeID])
+	}
+	sort.Slice(deadlines, func(i, j int) bool {
+		return deadlines[i].Before(deadlines[j])
+	})
+
+	// Batch the evals the same way as for delayed rescheduling, since
+	// nodes that reconnect together will usually stabilize together
+	var evals []*structs.Evaluation
+	for _, deadline := range deadlines {
+		if len(evals) > 0 && deadline.Sub(evals[len(evals)-1].WaitUntil) < batchedFailedAllocWindowSize {
+			continue
+		}
+		evals = append(evals, &structs.Evaluation{
+			ID:                uuid.Generate(),
+			Namespace:         a.job.Namespace,
+			Priority:          a.evalPriority,
+			Type:              a.job.Type,
+			TriggeredBy:       structs.EvalTriggerReconnect,
+			JobID:             a.job.ID,
+			JobModifyIndex:    a.job.ModifyIndex,
+			Status:            structs.EvalStatusPending,
+			StatusDescription: reconnectStabilizationFollowupEvalDesc,
+			WaitUntil:         deadline,
+		})
+	}
+
+	a.appendFollowupEvals(tgName, evals)
+}
+
 // appendFollowupEvals appends a set of followup evals for a task group to the
 // desiredFollowupEvals map which is later added to the scheduler's followUpEvals set.
 func (a *allocReconciler) appendFollowupEvals(tgName string, evals []*structs.Evaluation) {
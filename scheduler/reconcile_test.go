@@ -933,6 +933,56 @@ func TestReconciler_LostNode(t *testing.T) {
 	assertNamesHaveIndexes(t, intRange(0, 1), placeResultsToNames(r.place))
 }
 
+// Tests the reconciler steers the replacements of allocations lost to an
+// outage away from the disconnect domains of the nodes that are down
+func TestReconciler_LostNode_PenaltyDisconnectDomains(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+
+	// Create 10 existing allocations
+	var allocs []*structs.Allocation
+	for i := 0; i < 10; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		allocs = append(allocs, alloc)
+	}
+
+	// Build a map of tainted nodes: one down in a domain, one down without
+	// a domain, and one draining in another domain
+	tainted := make(map[string]*structs.Node, 3)
+	for i, domain := range []string{"rack-a", "", "rack-b"} {
+		n := mock.Node()
+		n.ID = allocs[i].NodeID
+		n.Status = structs.NodeStatusDown
+		n.DisconnectDomain = domain
+		tainted[n.ID] = n
+	}
+	draining := tainted[allocs[2].NodeID]
+	draining.Status = structs.NodeStatusReady
+	draining.DrainStrategy = mock.DrainNode().DrainStrategy
+	allocs[2].DesiredTransition.Migrate = pointer.Of(true)
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job,
+		nil, allocs, tainted, "", 50, true)
+	r := reconciler.Compute()
+
+	var lost, migrating int
+	for _, p := range r.place {
+		if p.PreviousLost() {
+			lost++
+			require.Equal(t, map[string]struct{}{"rack-a": {}}, p.PenaltyDisconnectDomains())
+		} else {
+			migrating++
+		}
+	}
+	require.Equal(t, 2, lost)
+	require.Equal(t, 1, migrating)
+}
+
 // Tests the reconciler properly handles lost nodes with allocations while
 // scaling up
 func TestReconciler_LostNode_ScaleUp(t *testing.T) {
@@ -5272,6 +5322,443 @@ func TestReconciler_Node_Disconnect_Updates_Alloc_To_Unknown(t *testing.T) {
 	})
 }
 
+// Tests that allocations beyond the job's disconnect budget are replaced as
+// lost instead of being marked unknown.
+func TestReconciler_Disconnect_MaxUnknown(t *testing.T) {
+	ci.Parallel(t)
+
+	t.Run("disconnecting", func(t *testing.T) {
+		job, allocs := buildResumableAllocations(4, structs.AllocClientStatusRunning, structs.AllocDesiredStatusRun, 2)
+		job.Disconnect = &structs.DisconnectStrategy{MaxUnknown: 1}
+		nodes := buildDisconnectedNodes(allocs, 3)
+
+		reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job,
+			nil, allocs, nodes, "", 50, true)
+		reconciler.now = time.Now().UTC()
+		results := reconciler.Compute()
+
+		// 1 alloc within the budget is marked unknown and replaced, the
+		// other 2 are stopped as lost and replaced
+		assertResults(t, results, &resultExpectation{
+			place:             3,
+			stop:              2,
+			disconnectUpdates: 1,
+			desiredTGUpdates: map[string]*structs.DesiredUpdates{
+				job.TaskGroups[0].Name: {
+					Place:          3,
+					Stop:           2,
+					Ignore:         1,
+					ReplaceUnknown: 2,
+				},
+			},
+		})
+		for _, stop := range results.stop {
+			require.Equal(t, structs.AllocClientStatusLost, stop.clientStatus)
+		}
+	})
+
+	t.Run("already unknown", func(t *testing.T) {
+		job, allocs := buildResumableAllocations(2, structs.AllocClientStatusUnknown, structs.AllocDesiredStatusRun, 2)
+		job.Disconnect = &structs.DisconnectStrategy{MaxUnknown: 1}
+		nodes := buildDisconnectedNodes(allocs, 2)
+
+		// the unknown allocs have already been replaced
+		replacements := buildAllocations(job, 2, structs.AllocClientStatusRunning, structs.AllocDesiredStatusRun, 2)
+		allocs = append(allocs, replacements...)
+
+		reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job,
+			nil, allocs, nodes, "", 50, true)
+		reconciler.now = time.Now().UTC()
+		results := reconciler.Compute()
+
+		// the unknown alloc beyond the budget is stopped as lost without
+		// another replacement
+		assertResults(t, results, &resultExpectation{
+			place: 0,
+			stop:  1,
+			desiredTGUpdates: map[string]*structs.DesiredUpdates{
+				job.TaskGroups[0].Name: {
+					Stop:           1,
+					Ignore:         3,
+					ReplaceUnknown: 1,
+				},
+			},
+		})
+		require.Equal(t, structs.AllocClientStatusLost, results.stop[0].clientStatus)
+	})
+}
+
+// Tests that reconnected allocations and their replacements are left alone
+// until the node has stayed connected for the reconnect stabilization period.
+// buildReconnectedAllocations returns a job with 4 allocations, 2 of which
+// reconnected on the returned node after being replaced elsewhere.
+func buildReconnectedAllocations() (*structs.Job, []*structs.Allocation, *structs.Node) {
+	job, allocs := buildResumableAllocations(4, structs.AllocClientStatusRunning, structs.AllocDesiredStatusRun, 2)
+	node := mock.Node()
+
+	// 2 allocs reconnected on the node and have been replaced elsewhere
+	replacements := []*structs.Allocation{}
+	for _, alloc := range allocs[:2] {
+		alloc.NodeID = node.ID
+		alloc.AllocStates = []*structs.AllocState{{
+			Field: structs.AllocStateFieldClientStatus,
+			Value: structs.AllocClientStatusUnknown,
+			Time:  time.Now(),
+		}}
+		event := structs.NewTaskEvent(structs.TaskClientReconnected)
+		event.Time = time.Now().UnixNano()
+		alloc.TaskStates = map[string]*structs.TaskState{
+			alloc.Job.TaskGroups[0].Tasks[0].Name: {
+				Events: []*structs.TaskEvent{event},
+			},
+		}
+
+		replacement := alloc.Copy()
+		replacement.ID = uuid.Generate()
+		replacement.NodeID = uuid.Generate()
+		replacement.PreviousAllocation = alloc.ID
+		replacement.AllocStates = nil
+		replacement.TaskStates = nil
+		alloc.NextAllocation = replacement.ID
+		replacements = append(replacements, replacement)
+	}
+	return job, append(allocs, replacements...), node
+}
+
+func TestReconciler_Reconnect_Stabilization(t *testing.T) {
+	ci.Parallel(t)
+
+	t.Run("stabilizing", func(t *testing.T) {
+		job, allocs, node := buildReconnectedAllocations()
+
+		reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job,
+			nil, allocs, nil, "", 50, true)
+		reconciler.now = time.Now().UTC()
+		deadline := reconciler.now.Add(5 * time.Minute)
+		reconciler.stabilizingNodes = map[string]time.Time{node.ID: deadline}
+		results := reconciler.Compute()
+
+		// Nothing is stopped or reinstated
+		assertResults(t, results, &resultExpectation{
+			desiredTGUpdates: map[string]*structs.DesiredUpdates{
+				job.TaskGroups[0].Name: {
+					Ignore: 6,
+				},
+			},
+		})
+
+		// A followup eval reinstates the allocs once the period is over
+		evals := results.desiredFollowupEvals[job.TaskGroups[0].Name]
+		require.Len(t, evals, 1)
+		require.Equal(t, structs.EvalTriggerReconnect, evals[0].TriggeredBy)
+		require.Equal(t, reconnectStabilizationFollowupEvalDesc, evals[0].StatusDescription)
+		require.Equal(t, deadline, evals[0].WaitUntil)
+	})
+
+	t.Run("stabilized", func(t *testing.T) {
+		job, allocs, node := buildReconnectedAllocations()
+
+		reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job,
+			nil, allocs, nil, "", 50, true)
+		reconciler.now = time.Now().UTC()
+		reconciler.stabilizingNodes = map[string]time.Time{node.ID: reconciler.now.Add(-time.Second)}
+		results := reconciler.Compute()
+
+		// The replacements are stopped in favor of the reconnected allocs
+		require.Len(t, results.stop, 2)
+		for _, stop := range results.stop {
+			require.NotEqual(t, node.ID, stop.alloc.NodeID)
+		}
+		require.Empty(t, results.desiredFollowupEvals)
+	})
+}
+
+func TestReconciler_Reconnect_OperatorDecision(t *testing.T) {
+	ci.Parallel(t)
+
+	t.Run("accepted", func(t *testing.T) {
+		job, allocs, node := buildReconnectedAllocations()
+		for _, alloc := range allocs {
+			if alloc.NodeID == node.ID {
+				alloc.DesiredTransition.Reconnect = pointer.Of(true)
+			} else if alloc.PreviousAllocation != "" {
+				// The replacements are placed on better nodes
+				alloc.Metrics.ScoreMetaData[0].NormScore += 1
+			}
+		}
+
+		reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job,
+			nil, allocs, nil, "", 50, true)
+		reconciler.now = time.Now().UTC()
+		reconciler.stabilizingNodes = map[string]time.Time{node.ID: reconciler.now.Add(5 * time.Minute)}
+		results := reconciler.Compute()
+
+		// The accepted allocs don't wait for the node to stabilize and are
+		// kept over their replacements
+		require.Len(t, results.stop, 2)
+		for _, stop := range results.stop {
+			require.NotEqual(t, node.ID, stop.alloc.NodeID)
+		}
+		require.Empty(t, results.desiredFollowupEvals)
+	})
+
+	t.Run("rejected reconnected", func(t *testing.T) {
+		job, allocs, node := buildReconnectedAllocations()
+		for _, alloc := range allocs {
+			if alloc.NodeID == node.ID {
+				alloc.DesiredTransition.Reconnect = pointer.Of(false)
+			}
+		}
+
+		reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job,
+			nil, allocs, nil, "", 50, true)
+		results := reconciler.Compute()
+
+		// The rejected allocs are stopped as lost and the replacements kept
+		require.Len(t, results.stop, 2)
+		for _, stop := range results.stop {
+			require.Equal(t, node.ID, stop.alloc.NodeID)
+			require.Equal(t, structs.AllocClientStatusLost, stop.clientStatus)
+		}
+		require.Empty(t, results.place)
+	})
+
+	t.Run("rejected unknown", func(t *testing.T) {
+		job, allocs := buildResumableAllocations(2, structs.AllocClientStatusRunning, structs.AllocDesiredStatusRun, 2)
+		node := mock.Node()
+		node.Status = structs.NodeStatusDisconnected
+		unknown := allocs[0]
+		unknown.NodeID = node.ID
+		unknown.ClientStatus = structs.AllocClientStatusUnknown
+		unknown.AppendState(structs.AllocStateFieldClientStatus, structs.AllocClientStatusUnknown)
+		unknown.DesiredTransition.Reconnect = pointer.Of(false)
+
+		reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job,
+			nil, allocs, map[string]*structs.Node{node.ID: node}, "", 50, true)
+		results := reconciler.Compute()
+
+		// The rejected alloc is lost and replaced without waiting for the
+		// max_client_disconnect window to expire
+		require.Len(t, results.stop, 1)
+		require.Equal(t, unknown.ID, results.stop[0].alloc.ID)
+		require.Equal(t, structs.AllocClientStatusLost, results.stop[0].clientStatus)
+		require.Len(t, results.place, 1)
+		require.Empty(t, results.disconnectUpdates)
+	})
+}
+
+// Tests that replacements for allocations on disconnected clients may
+// oversubscribe memory, and that they're stopped to reclaim it once the
+// allocations they replaced reconnect.
+func TestReconciler_Reconnect_MemoryOversubscribed(t *testing.T) {
+	ci.Parallel(t)
+
+	t.Run("disconnecting", func(t *testing.T) {
+		job, allocs := buildResumableAllocations(2, structs.AllocClientStatusRunning, structs.AllocDesiredStatusRun, 2)
+		nodes := buildDisconnectedNodes(allocs, 1)
+
+		reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job,
+			nil, allocs, nodes, "", 50, true)
+		reconciler.now = time.Now().UTC()
+		results := reconciler.Compute()
+
+		require.Len(t, results.place, 1)
+		require.True(t, results.place[0].PreviousDisconnected())
+	})
+
+	t.Run("reconnected", func(t *testing.T) {
+		job, allocs, node := buildReconnectedAllocations()
+		for _, alloc := range allocs {
+			if alloc.PreviousAllocation != "" {
+				// The replacements are placed on better nodes, but
+				// oversubscribe their memory
+				alloc.Metrics.ScoreMetaData[0].NormScore += 1
+				alloc.MemoryOversubscribed = true
+			}
+		}
+
+		reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job,
+			nil, allocs, nil, "", 50, true)
+		results := reconciler.Compute()
+
+		// The replacements are stopped to reclaim their memory
+		require.Len(t, results.stop, 2)
+		for _, stop := range results.stop {
+			require.NotEqual(t, node.ID, stop.alloc.NodeID)
+			require.True(t, stop.alloc.MemoryOversubscribed)
+			require.Equal(t, allocReconnected, stop.statusDescription)
+		}
+		for _, e := range results.explanation().Allocations {
+			require.Equal(t, structs.AllocDecisionReconnect, e.Decision)
+			require.Equal(t, "replacement oversubscribes its node's memory", e.Reason)
+		}
+	})
+
+	t.Run("lost", func(t *testing.T) {
+		job, allocs := buildResumableAllocations(2, structs.AllocClientStatusRunning, structs.AllocDesiredStatusRun, 2)
+		job.TaskGroups[0].MaxClientDisconnect = pointer.Of(2 * time.Second)
+		nodes := buildDisconnectedNodes(allocs, 1)
+
+		original := allocs[0]
+		original.ClientStatus = structs.AllocClientStatusUnknown
+		original.AllocStates = []*structs.AllocState{{
+			Field: structs.AllocStateFieldClientStatus,
+			Value: structs.AllocClientStatusUnknown,
+			Time:  time.Now(),
+		}}
+		replacement := original.Copy()
+		replacement.ID = uuid.Generate()
+		replacement.NodeID = uuid.Generate()
+		replacement.ClientStatus = structs.AllocClientStatusRunning
+		replacement.AllocStates = nil
+		replacement.PreviousAllocation = original.ID
+		replacement.MemoryOversubscribed = true
+		original.NextAllocation = replacement.ID
+		allocs = append(allocs, replacement)
+
+		compute := func() *reconcileResults {
+			reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job,
+				nil, allocs, nodes, "", 50, true)
+			reconciler.now = time.Now().Add(time.Minute)
+			return reconciler.Compute()
+		}
+
+		// The original expires to lost, so the replacement is migrated to
+		// reclaim the memory it oversubscribes
+		results := compute()
+		stops := map[string]string{}
+		for _, stop := range results.stop {
+			stops[stop.alloc.ID] = stop.statusDescription
+		}
+		require.Equal(t, map[string]string{
+			original.ID:    allocLost,
+			replacement.ID: allocMigrating,
+		}, stops)
+		require.Len(t, results.place, 1)
+		require.Equal(t, replacement.ID, results.place[0].PreviousAllocation().ID)
+		require.False(t, results.place[0].PreviousDisconnected())
+		require.Equal(t, uint64(1), results.desiredTGUpdates[job.TaskGroups[0].Name].Migrate)
+
+		// The replacement is migrated as well if the original is already
+		// stopped
+		original.ClientStatus = structs.AllocClientStatusLost
+		original.DesiredStatus = structs.AllocDesiredStatusStop
+		results = compute()
+		require.Len(t, results.stop, 1)
+		require.Equal(t, replacement.ID, results.stop[0].alloc.ID)
+		require.Equal(t, allocMigrating, results.stop[0].statusDescription)
+		require.Len(t, results.place, 1)
+
+		// The replacement keeps oversubscribing memory while the original
+		// may still reconnect
+		original.ClientStatus = structs.AllocClientStatusUnknown
+		original.DesiredStatus = structs.AllocDesiredStatusRun
+		job.TaskGroups[0].MaxClientDisconnect = pointer.Of(time.Hour)
+		results = compute()
+		require.Empty(t, results.stop)
+		require.Empty(t, results.place)
+	})
+}
+
+// Tests that the decisions made about the allocations of disconnected and
+// reconnected clients are explained.
+func TestReconciler_Disconnect_Explanation(t *testing.T) {
+	ci.Parallel(t)
+
+	decisions := func(results *reconcileResults) map[string]int {
+		out := map[string]int{}
+		for _, e := range results.explanation().Allocations {
+			out[e.Decision]++
+		}
+		return out
+	}
+
+	t.Run("disconnecting", func(t *testing.T) {
+		job, allocs := buildResumableAllocations(4, structs.AllocClientStatusRunning, structs.AllocDesiredStatusRun, 2)
+		job.Disconnect = &structs.DisconnectStrategy{MaxUnknown: 1}
+		nodes := buildDisconnectedNodes(allocs, 3)
+
+		reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job,
+			nil, allocs, nodes, "", 50, true)
+		reconciler.now = time.Now().UTC()
+		results := reconciler.Compute()
+
+		// Only the allocs of the disconnected clients are explained
+		require.Equal(t, map[string]int{
+			structs.AllocDecisionMarkUnknown: 1,
+			structs.AllocDecisionReplace:     2,
+		}, decisions(results))
+		for _, e := range results.explanation().Allocations {
+			require.Equal(t, structs.NodeStatusDisconnected, e.NodeStatus)
+			switch e.Decision {
+			case structs.AllocDecisionMarkUnknown:
+				require.Equal(t, reconciler.now.Add(5*time.Minute), e.DisconnectTimeout)
+			case structs.AllocDecisionReplace:
+				require.Equal(t, "job disconnect max_unknown of 1 reached", e.Reason)
+			}
+		}
+	})
+
+	t.Run("connected", func(t *testing.T) {
+		job, allocs := buildResumableAllocations(2, structs.AllocClientStatusRunning, structs.AllocDesiredStatusRun, 2)
+
+		reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job,
+			nil, allocs, nil, "", 50, true)
+		results := reconciler.Compute()
+		require.Nil(t, results.explanation())
+	})
+
+	t.Run("stabilizing", func(t *testing.T) {
+		job, allocs, node := buildReconnectedAllocations()
+
+		reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job,
+			nil, allocs, nil, "", 50, true)
+		reconciler.now = time.Now().UTC()
+		deadline := reconciler.now.Add(5 * time.Minute)
+		reconciler.stabilizingNodes = map[string]time.Time{node.ID: deadline}
+		results := reconciler.Compute()
+
+		require.Equal(t, map[string]int{structs.AllocDecisionStabilize: 2}, decisions(results))
+		for _, e := range results.explanation().Allocations {
+			require.Equal(t, deadline, e.StabilizeUntil)
+		}
+	})
+
+	t.Run("reconnected", func(t *testing.T) {
+		job, allocs, node := buildReconnectedAllocations()
+
+		// One of the replacements is placed on a better node
+		var better *structs.Allocation
+		for _, alloc := range allocs {
+			if alloc.PreviousAllocation != "" {
+				better = alloc
+				better.Metrics.ScoreMetaData[0].NormScore += 1
+				break
+			}
+		}
+
+		reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job,
+			nil, allocs, nil, "", 50, true)
+		results := reconciler.Compute()
+
+		require.Equal(t, map[string]int{
+			structs.AllocDecisionReconnect: 1,
+			structs.AllocDecisionStop:      1,
+		}, decisions(results))
+		for _, e := range results.explanation().Allocations {
+			require.NotEmpty(t, e.ReplacementID)
+			if e.Decision == structs.AllocDecisionStop {
+				require.Equal(t, better.PreviousAllocation, e.AllocID)
+				require.Equal(t, better.ID, e.ReplacementID)
+				require.Equal(t, "replacement has a higher score", e.Reason)
+				require.Greater(t, e.ReplacementScore, e.Score)
+			} else {
+				require.Equal(t, node.ID, e.NodeID)
+			}
+		}
+	})
+}
+
 // Tests that when a node disconnects/reconnects allocations for that node are
 // reconciled according to the business rules.
 func TestReconciler_Disconnected_Client(t *testing.T) {
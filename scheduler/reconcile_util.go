@@ -37,11 +37,19 @@ type placementResult interface {
 	// PreviousLost is true if the previous allocation was lost.
 	PreviousLost() bool
 
+	// PreviousDisconnected is true if the previous allocation is on a
+	// disconnected client and may reconnect.
+	PreviousDisconnected() bool
+
 	// DowngradeNonCanary indicates that placement should use the latest stable job
 	// with the MinJobVersion, rather than the current deployment version
 	DowngradeNonCanary() bool
 
 	MinJobVersion() uint64
+
+	// PenaltyDisconnectDomains returns the disconnect domains the placement
+	// should avoid because of an ongoing outage
+	PenaltyDisconnectDomains() map[string]struct{}
 }
 
 // allocStopResult contains the information required to stop a single allocation
@@ -61,9 +69,12 @@ type allocPlaceResult struct {
 	previousAlloc *structs.Allocation
 	reschedule    bool
 	lost          bool
+	disconnected  bool
 
 	downgradeNonCanary bool
 	minJobVersion      uint64
+
+	penaltyDomains map[string]struct{}
 }
 
 func (a allocPlaceResult) TaskGroup() *structs.TaskGroup           { return a.taskGroup }
@@ -75,6 +86,10 @@ func (a allocPlaceResult) StopPreviousAlloc() (bool, string)       { return fals
 func (a allocPlaceResult) DowngradeNonCanary() bool                { return a.downgradeNonCanary }
 func (a allocPlaceResult) MinJobVersion() uint64                   { return a.minJobVersion }
 func (a allocPlaceResult) PreviousLost() bool                      { return a.lost }
+func (a allocPlaceResult) PreviousDisconnected() bool              { return a.disconnected }
+func (a allocPlaceResult) PenaltyDisconnectDomains() map[string]struct{} {
+	return a.penaltyDomains
+}
 
 // allocDestructiveResult contains the information required to do a destructive
 // update. Destructive changes should be applied atomically, as in the old alloc
@@ -94,9 +109,13 @@ func (a allocDestructiveResult) IsRescheduling() bool                    { retur
 func (a allocDestructiveResult) StopPreviousAlloc() (bool, string) {
 	return true, a.stopStatusDescription
 }
-func (a allocDestructiveResult) DowngradeNonCanary() bool { return false }
-func (a allocDestructiveResult) MinJobVersion() uint64    { return 0 }
-func (a allocDestructiveResult) PreviousLost() bool       { return false }
+func (a allocDestructiveResult) DowngradeNonCanary() bool   { return false }
+func (a allocDestructiveResult) MinJobVersion() uint64      { return 0 }
+func (a allocDestructiveResult) PreviousLost() bool         { return false }
+func (a allocDestructiveResult) PreviousDisconnected() bool { return false }
+func (a allocDestructiveResult) PenaltyDisconnectDomains() map[string]struct{} {
+	return nil
+}
 
 // allocMatrix is a mapping of task groups to their allocation set.
 type allocMatrix map[string]allocSet
@@ -250,6 +269,17 @@ func (a allocSet) filterByTainted(taintedNodes map[string]*structs.Node, serverS
 			continue
 		}
 
+		// Allocs whose reconnect was rejected by an operator are lost,
+		// whether their node is still disconnected or has reconnected.
+		if supportsDisconnectedClients &&
+			alloc.DesiredStatus == structs.AllocDesiredStatusRun &&
+			alloc.DesiredTransition.ShouldRejectReconnect() &&
+			(alloc.ClientStatus == structs.AllocClientStatusUnknown ||
+				(reconnected && alloc.ClientStatus == structs.AllocClientStatusRunning)) {
+			lost[alloc.ID] = alloc
+			continue
+		}
+
 		taintedNode, nodeIsTainted := taintedNodes[alloc.NodeID]
 		if taintedNode != nil {
 			// Group disconnecting/reconnecting
@@ -523,6 +553,24 @@ func (a allocSet) filterByFailedReconnect() allocSet {
 	return failed
 }
 
+// filterByStabilizing filters the reconnecting allocations into a set that are
+// running on nodes still within their reconnect stabilization period.
+// stabilizingNodes maps node IDs to the time at which their period ends.
+func (a allocSet) filterByStabilizing(stabilizingNodes map[string]time.Time, now time.Time) allocSet {
+	stabilizing := make(allocSet)
+	for _, alloc := range a {
+		// Allocs accepted back by an operator don't wait
+		if alloc.ClientStatus != structs.AllocClientStatusRunning ||
+			alloc.DesiredTransition.ShouldAcceptReconnect() {
+			continue
+		}
+		if deadline, ok := stabilizingNodes[alloc.NodeID]; ok && now.Before(deadline) {
+			stabilizing[alloc.ID] = alloc
+		}
+	}
+	return stabilizing
+}
+
 // delayByStopAfterClientDisconnect returns a delay for any lost allocation that's got a
 // stop_after_client_disconnect configured
 func (a allocSet) delayByStopAfterClientDisconnect() (later []*delayedRescheduleInfo) {
@@ -565,6 +613,25 @@ func (a allocSet) delayByMaxClientDisconnect(now time.Time) (later []*delayedRes
 	return
 }
 
+// filterByOversubscribedLost returns the allocs from the set that
+// oversubscribe their node's memory to replace an allocation that won't
+// reconnect, because it's in the lost set, terminal or gone from all, so that
+// they can be migrated to reclaim that memory.
+func (a allocSet) filterByOversubscribedLost(all, lost allocSet) allocSet {
+	oversubscribed := make(allocSet)
+	for _, alloc := range a {
+		if !alloc.MemoryOversubscribed || alloc.TerminalStatus() {
+			continue
+		}
+		prev, ok := all[alloc.PreviousAllocation]
+		if _, isLost := lost[alloc.PreviousAllocation]; ok && !isLost && !prev.TerminalStatus() {
+			continue
+		}
+		oversubscribed[alloc.ID] = alloc
+	}
+	return oversubscribed
+}
+
 // filterByClientStatus returns allocs from the set with the specified client status.
 func (a allocSet) filterByClientStatus(clientStatus string) allocSet {
 	allocs := make(allocSet)
@@ -90,7 +90,7 @@ func (s *SystemScheduler) Process(eval *structs.Evaluation) (err error) {
 	if !s.canHandle(eval.TriggeredBy) {
 		desc := fmt.Sprintf("scheduler cannot handle '%s' evaluation reason", eval.TriggeredBy)
 		return setStatus(s.logger, s.planner, s.eval, s.nextEval, nil, s.failedTGAllocs, structs.EvalStatusFailed, desc,
-			s.queuedAllocs, "")
+			s.queuedAllocs, "", nil)
 	}
 
 	limit := maxSystemScheduleAttempts
@@ -103,14 +103,14 @@ func (s *SystemScheduler) Process(eval *structs.Evaluation) (err error) {
 	if err := retryMax(limit, s.process, progress); err != nil {
 		if statusErr, ok := err.(*SetStatusError); ok {
 			return setStatus(s.logger, s.planner, s.eval, s.nextEval, nil, s.failedTGAllocs, statusErr.EvalStatus, err.Error(),
-				s.queuedAllocs, "")
+				s.queuedAllocs, "", nil)
 		}
 		return err
 	}
 
 	// Update the status to complete
 	return setStatus(s.logger, s.planner, s.eval, s.nextEval, nil, s.failedTGAllocs, structs.EvalStatusComplete, "",
-		s.queuedAllocs, "")
+		s.queuedAllocs, "", nil)
 }
 
 // process is wrapped in retryMax to iteratively run the handler until we have no
@@ -132,7 +132,7 @@ func (s *SystemScheduler) process() (bool, error) {
 
 	// Get the ready nodes in the required datacenters
 	if !s.job.Stopped() {
-		s.nodes, s.notReadyNodes, s.nodesByDC, err = readyNodesInDCs(s.state, s.job.Datacenters)
+		s.nodes, s.notReadyNodes, s.nodesByDC, err = readyNodesInDCs(s.state, s.job.DatacenterNames())
 		if err != nil {
 			return false, fmt.Errorf("failed to get ready nodes: %v", err)
 		}
@@ -452,6 +452,9 @@ func (s *SystemScheduler) computePlacements(place []allocTuple) error {
 			},
 		}
 
+		// Record the disconnect timeout resolved for the chosen node
+		alloc.MaxClientDisconnect = resolveMaxClientDisconnect(missing.TaskGroup, option.Node)
+
 		// If the new allocation is replacing an older allocation then we record the
 		// older allocation id so that they are chained
 		if missing.Alloc != nil {
@@ -515,6 +518,7 @@ func (s *SystemScheduler) canHandle(trigger string) bool {
 	case structs.EvalTriggerQueuedAllocs:
 	case structs.EvalTriggerScaling:
 	case structs.EvalTriggerReconnect:
+	case structs.EvalTriggerReconnectPreemption:
 	default:
 		switch s.sysbatch {
 		case true:
@@ -33,9 +33,23 @@ type Stack interface {
 
 type SelectOptions struct {
 	PenaltyNodeIDs map[string]struct{}
+
+	// PenaltyDisconnectDomains are the disconnect domains of the nodes that
+	// are disconnected or down. Replacements are steered away from them, as
+	// their other nodes are likely to be cut off by the same outage.
+	PenaltyDisconnectDomains map[string]struct{}
+
 	PreferredNodes []*structs.Node
 	Preempt        bool
 	AllocName      string
+
+	// Canary is set when the placement is a canary of a deployment
+	Canary bool
+
+	// OversubscribeMemory is set when the placement replaces an allocation
+	// on a disconnected client, and so may oversubscribe the memory of
+	// nodes that allow it until that client reconnects
+	OversubscribeMemory bool
 }
 
 // GenericStack is the Stack used for the Generic scheduler. It is
@@ -64,6 +78,7 @@ type GenericStack struct {
 	limit                      *LimitIterator
 	maxScore                   *MaxScoreIterator
 	nodeAffinity               *NodeAffinityIterator
+	datacenterWeight           *DatacenterWeightIterator
 	spread                     *SpreadIterator
 	scoreNorm                  *ScoreNormalizationIterator
 }
@@ -105,6 +120,7 @@ func (s *GenericStack) SetJob(job *structs.Job) {
 	s.binPack.SetJob(job)
 	s.jobAntiAff.SetJob(job)
 	s.nodeAffinity.SetJob(job)
+	s.datacenterWeight.SetJob(job)
 	s.spread.SetJob(job)
 	s.ctx.Eligibility().SetJob(job)
 	s.taskGroupCSIVolumes.SetNamespace(job.Namespace)
@@ -155,15 +171,18 @@ func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ra
 	s.binPack.SetTaskGroup(tg)
 	if options != nil {
 		s.binPack.evict = options.Preempt
+		s.binPack.SetOversubscribeMemory(options.OversubscribeMemory)
+		s.distinctHostsConstraint.SetCanary(options.Canary)
 	}
 	s.jobAntiAff.SetTaskGroup(tg)
 	if options != nil {
 		s.nodeReschedulingPenalty.SetPenaltyNodes(options.PenaltyNodeIDs)
+		s.nodeReschedulingPenalty.SetPenaltyDisconnectDomains(options.PenaltyDisconnectDomains)
 	}
 	s.nodeAffinity.SetTaskGroup(tg)
 	s.spread.SetTaskGroup(tg)
 
-	if s.nodeAffinity.hasAffinities() || s.spread.hasSpreads() {
+	if s.nodeAffinity.hasAffinities() || s.spread.hasSpreads() || s.datacenterWeight.hasWeights() {
 		// scoring spread across all nodes has quadratic behavior, so
 		// we need to consider a subset of nodes to keep evaluaton times
 		// reasonable but enough to ensure spread is correct. this
@@ -421,8 +440,11 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// Apply scores based on affinity stanza
 	s.nodeAffinity = NewNodeAffinityIterator(ctx, s.nodeReschedulingPenalty)
 
+	// Apply scores based on the weights of the job datacenters
+	s.datacenterWeight = NewDatacenterWeightIterator(ctx, s.nodeAffinity)
+
 	// Apply scores based on spread stanza
-	s.spread = NewSpreadIterator(ctx, s.nodeAffinity)
+	s.spread = NewSpreadIterator(ctx, s.datacenterWeight)
 
 	// Add the preemption options scoring iterator
 	preemptionScorer := NewPreemptionScoringIterator(ctx, s.spread)
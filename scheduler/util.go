@@ -10,6 +10,7 @@ import (
 	log "github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -457,6 +458,63 @@ func taintedNodes(state State, allocs []*structs.Allocation) (map[string]*struct
 	return out, nil
 }
 
+// reconnectStabilizingNodes returns the nodes of reconnected allocations that
+// reconnected less than the stabilization period before now, mapped to the time
+// at which the period ends.
+func reconnectStabilizingNodes(state State, allocs []*structs.Allocation, stabilization time.Duration, now time.Time) (map[string]time.Time, error) {
+	out := make(map[string]time.Time)
+	if stabilization <= 0 {
+		return out, nil
+	}
+
+	seen := make(map[string]struct{})
+	for _, alloc := range allocs {
+		if _, ok := seen[alloc.NodeID]; ok {
+			continue
+		}
+		if alloc.ClientStatus != structs.AllocClientStatusRunning {
+			continue
+		}
+		if reconnected, _ := alloc.Reconnected(); !reconnected {
+			continue
+		}
+		seen[alloc.NodeID] = struct{}{}
+
+		ws := memdb.NewWatchSet()
+		node, err := state.NodeByID(ws, alloc.NodeID)
+		if err != nil {
+			return nil, err
+		}
+		if node == nil || node.LastReconnect == 0 {
+			continue
+		}
+
+		deadline := time.Unix(node.LastReconnect, 0).Add(stabilization)
+		if now.Before(deadline) {
+			out[alloc.NodeID] = deadline
+		}
+	}
+
+	return out, nil
+}
+
+// resolveMaxClientDisconnect returns the max_client_disconnect value for
+// allocations of the task group placed on the given node. The first tier whose
+// attribute resolves to its value on the node wins. It returns nil when no tier
+// matches, in which case the task group's default applies.
+func resolveMaxClientDisconnect(tg *structs.TaskGroup, node *structs.Node) *time.Duration {
+	if tg == nil || node == nil || tg.MaxClientDisconnect == nil {
+		return nil
+	}
+	for _, tier := range tg.MaxClientDisconnectTiers {
+		val, ok := resolveTarget(tier.Attribute, node)
+		if ok && val == tier.Value {
+			return pointer.Of(tier.Duration)
+		}
+	}
+	return nil
+}
+
 // shuffleNodes randomizes the slice order with the Fisher-Yates
 // algorithm. We seed the random source with the eval ID (which is
 // random) to aid in postmortem debugging of specific evaluations and
@@ -585,6 +643,8 @@ func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) bool {
 			return true
 		} else if ar.MemoryMaxMB != br.MemoryMaxMB {
 			return true
+		} else if ar.HugePages2MB != br.HugePages2MB {
+			return true
 		} else if !ar.Devices.Equals(&br.Devices) {
 			return true
 		}
@@ -791,7 +851,8 @@ func spreadsUpdated(jobA, jobB *structs.Job, taskGroup string) bool {
 func setStatus(logger log.Logger, planner Planner,
 	eval, nextEval, spawnedBlocked *structs.Evaluation,
 	tgMetrics map[string]*structs.AllocMetric, status, desc string,
-	queuedAllocs map[string]int, deploymentID string) error {
+	queuedAllocs map[string]int, deploymentID string,
+	explanation *structs.EvalExplanation) error {
 
 	logger.Debug("setting eval status", "status", status)
 	newEval := eval.Copy()
@@ -808,6 +869,7 @@ func setStatus(logger log.Logger, planner Planner,
 	if queuedAllocs != nil {
 		newEval.QueuedAllocations = queuedAllocs
 	}
+	newEval.Explanation = explanation
 
 	return planner.UpdateEval(newEval)
 }
@@ -860,7 +922,7 @@ func inplaceUpdate(ctx Context, eval *structs.Evaluation, job *structs.Job,
 		}
 
 		// The alloc is on a node that's now in an ineligible DC
-		if !helper.SliceStringContains(job.Datacenters, node.Datacenter) {
+		if !helper.SliceStringContains(job.DatacenterNames(), node.Datacenter) {
 			continue
 		}
 
@@ -909,6 +971,7 @@ func inplaceUpdate(ctx Context, eval *structs.Evaluation, job *structs.Job,
 		// Create a shallow copy
 		newAlloc := new(structs.Allocation)
 		*newAlloc = *update.Alloc
+		newAlloc.MaxClientDisconnect = resolveMaxClientDisconnect(update.TaskGroup, node)
 
 		// Update the allocation
 		newAlloc.EvalID = eval.ID
@@ -1148,7 +1211,7 @@ func genericAllocUpdateFn(ctx Context, stack Stack, evalID string) allocUpdateTy
 		}
 
 		// The alloc is on a node that's now in an ineligible DC
-		if !helper.SliceStringContains(newJob.Datacenters, node.Datacenter) {
+		if !helper.SliceStringContains(newJob.DatacenterNames(), node.Datacenter) {
 			return false, true, nil
 		}
 
@@ -1196,6 +1259,7 @@ func genericAllocUpdateFn(ctx Context, stack Stack, evalID string) allocUpdateTy
 		// Create a shallow copy
 		newAlloc := new(structs.Allocation)
 		*newAlloc = *existing
+		newAlloc.MaxClientDisconnect = resolveMaxClientDisconnect(newTG, node)
 
 		// Update the allocation
 		newAlloc.EvalID = evalID
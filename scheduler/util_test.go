@@ -507,6 +507,50 @@ func TestTaintedNodes(t *testing.T) {
 	require.Nil(t, tainted["12345678-abcd-efab-cdef-123456789abc"])
 }
 
+func TestReconnectStabilizingNodes(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	state := state.TestStateStore(t)
+	node1 := mock.Node()
+	node1.LastReconnect = now.Add(-time.Minute).Unix()
+	node2 := mock.Node()
+	node2.LastReconnect = now.Add(-time.Hour).Unix()
+	node3 := mock.Node()
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node1))
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1001, node2))
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1002, node3))
+
+	reconnected := func(nodeID string) *structs.Allocation {
+		event := structs.NewTaskEvent(structs.TaskClientReconnected)
+		event.Time = now.UnixNano()
+		return &structs.Allocation{
+			NodeID:       nodeID,
+			ClientStatus: structs.AllocClientStatusRunning,
+			TaskStates: map[string]*structs.TaskState{
+				"web": {Events: []*structs.TaskEvent{event}},
+			},
+		}
+	}
+	allocs := []*structs.Allocation{
+		reconnected(node1.ID),
+		reconnected(node2.ID),
+		reconnected(node3.ID),
+		reconnected("12345678-abcd-efab-cdef-123456789abc"),
+	}
+
+	stabilizing, err := reconnectStabilizingNodes(state, allocs, 5*time.Minute, now)
+	require.NoError(t, err)
+	require.Len(t, stabilizing, 1)
+	require.Equal(t, time.Unix(node1.LastReconnect, 0).Add(5*time.Minute), stabilizing[node1.ID])
+
+	// Allocs that haven't reconnected don't need their node looked up
+	allocs[0].TaskStates = nil
+	stabilizing, err = reconnectStabilizingNodes(state, allocs, 5*time.Minute, now)
+	require.NoError(t, err)
+	require.Empty(t, stabilizing)
+}
+
 func TestShuffleNodes(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1031,7 +1075,7 @@ func TestSetStatus(t *testing.T) {
 	eval := mock.Eval()
 	status := "a"
 	desc := "b"
-	require.NoError(t, setStatus(logger, h, eval, nil, nil, nil, status, desc, nil, ""))
+	require.NoError(t, setStatus(logger, h, eval, nil, nil, nil, status, desc, nil, "", nil))
 	require.Equal(t, 1, len(h.Evals), "setStatus() didn't update plan: %v", h.Evals)
 
 	newEval := h.Evals[0]
@@ -1041,7 +1085,7 @@ func TestSetStatus(t *testing.T) {
 	// Test next evals
 	h = NewHarness(t)
 	next := mock.Eval()
-	require.NoError(t, setStatus(logger, h, eval, next, nil, nil, status, desc, nil, ""))
+	require.NoError(t, setStatus(logger, h, eval, next, nil, nil, status, desc, nil, "", nil))
 	require.Equal(t, 1, len(h.Evals), "setStatus() didn't update plan: %v", h.Evals)
 
 	newEval = h.Evals[0]
@@ -1050,7 +1094,7 @@ func TestSetStatus(t *testing.T) {
 	// Test blocked evals
 	h = NewHarness(t)
 	blocked := mock.Eval()
-	require.NoError(t, setStatus(logger, h, eval, nil, blocked, nil, status, desc, nil, ""))
+	require.NoError(t, setStatus(logger, h, eval, nil, blocked, nil, status, desc, nil, "", nil))
 	require.Equal(t, 1, len(h.Evals), "setStatus() didn't update plan: %v", h.Evals)
 
 	newEval = h.Evals[0]
@@ -1059,7 +1103,7 @@ func TestSetStatus(t *testing.T) {
 	// Test metrics
 	h = NewHarness(t)
 	metrics := map[string]*structs.AllocMetric{"foo": nil}
-	require.NoError(t, setStatus(logger, h, eval, nil, nil, metrics, status, desc, nil, ""))
+	require.NoError(t, setStatus(logger, h, eval, nil, nil, metrics, status, desc, nil, "", nil))
 	require.Equal(t, 1, len(h.Evals), "setStatus() didn't update plan: %v", h.Evals)
 
 	newEval = h.Evals[0]
@@ -1070,7 +1114,7 @@ func TestSetStatus(t *testing.T) {
 	h = NewHarness(t)
 	queuedAllocs := map[string]int{"web": 1}
 
-	require.NoError(t, setStatus(logger, h, eval, nil, nil, metrics, status, desc, queuedAllocs, ""))
+	require.NoError(t, setStatus(logger, h, eval, nil, nil, metrics, status, desc, queuedAllocs, "", nil))
 	require.Equal(t, 1, len(h.Evals), "setStatus() didn't update plan: %v", h.Evals)
 
 	newEval = h.Evals[0]
@@ -1078,11 +1122,23 @@ func TestSetStatus(t *testing.T) {
 
 	h = NewHarness(t)
 	dID := uuid.Generate()
-	require.NoError(t, setStatus(logger, h, eval, nil, nil, metrics, status, desc, queuedAllocs, dID))
+	require.NoError(t, setStatus(logger, h, eval, nil, nil, metrics, status, desc, queuedAllocs, dID, nil))
 	require.Equal(t, 1, len(h.Evals), "setStatus() didn't update plan: %v", h.Evals)
 
 	newEval = h.Evals[0]
 	require.Equal(t, dID, newEval.DeploymentID, "setStatus() didn't set deployment id correctly: %v", newEval)
+
+	// Test explanation
+	h = NewHarness(t)
+	explanation := &structs.EvalExplanation{
+		Allocations: []*structs.AllocExplanation{{
+			AllocID:  uuid.Generate(),
+			Decision: structs.AllocDecisionWait,
+		}},
+	}
+	require.NoError(t, setStatus(logger, h, eval, nil, nil, metrics, status, desc, queuedAllocs, dID, explanation))
+	require.Equal(t, 1, len(h.Evals), "setStatus() didn't update plan: %v", h.Evals)
+	require.Equal(t, explanation, h.Evals[0].Explanation)
 }
 
 func TestInplaceUpdate_ChangedTaskGroup(t *testing.T) {
@@ -1658,3 +1714,34 @@ func TestUtil_connectSidecarServiceUpdated(t *testing.T) {
 		require.False(t, connectSidecarServiceUpdated(a, b))
 	})
 }
+
+func TestUtil_resolveMaxClientDisconnect(t *testing.T) {
+	ci.Parallel(t)
+
+	tg := mock.Job().TaskGroups[0]
+	tg.MaxClientDisconnect = pointer.Of(10 * time.Minute)
+	tg.MaxClientDisconnectTiers = []*structs.MaxClientDisconnectTier{
+		{Attribute: "${node.class}", Value: "edge", Duration: 24 * time.Hour},
+		{Attribute: "${meta.rack}", Value: "r1", Duration: time.Hour},
+	}
+
+	edge := mock.Node()
+	edge.NodeClass = "edge"
+	edge.Meta["rack"] = "r1"
+
+	rack := mock.Node()
+	rack.Meta["rack"] = "r1"
+
+	other := mock.Node()
+
+	// the first matching tier wins
+	require.Equal(t, pointer.Of(24*time.Hour), resolveMaxClientDisconnect(tg, edge))
+	require.Equal(t, pointer.Of(time.Hour), resolveMaxClientDisconnect(tg, rack))
+
+	// no matching tier falls back to the task group default
+	require.Nil(t, resolveMaxClientDisconnect(tg, other))
+
+	// tiers are ignored without a task group default
+	tg.MaxClientDisconnect = nil
+	require.Nil(t, resolveMaxClientDisconnect(tg, edge))
+}
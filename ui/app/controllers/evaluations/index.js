@@ -127,6 +127,7 @@ export default class EvaluationsController extends Controller {
       { key: 'alloc-failure', label: 'Allocation Failure' },
       { key: 'queued-allocs', label: 'Queued Allocations' },
       { key: 'preemption', label: 'Preemption' },
+      { key: 'reconnect-preemption', label: 'Reconnect Preemption' },
       { key: 'job-scaling', label: 'Job Scalling' },
     ];
   }